@@ -0,0 +1,9 @@
+// Package api はOpenAPI仕様を埋め込んで提供する
+package api
+
+import _ "embed"
+
+// Spec はOpenAPI仕様（YAML）
+//
+//go:embed openapi.yaml
+var Spec []byte
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// JSONPath はOpenAPI仕様（JSON）を配信するパス
+const JSONPath = "/openapi.json"
+
+// DocsPath は Swagger UI を配信するパス
+const DocsPath = "/docs"
+
+//go:embed docs.html
+var docsPage []byte
+
+// DocsHandler はOpenAPI仕様のJSONと、それを表示する Swagger UI を配信する http.Handler
+type DocsHandler struct {
+	spec    []byte
+	modTime time.Time
+}
+
+// NewDocsHandler は埋め込んだOpenAPI仕様をJSONに変換したハンドラーを作成する。
+// serverURL を指定した場合は servers をそのURLに置き換える（空の場合は配信するサーバーと同じオリジン）
+func NewDocsHandler(serverURL string) (*DocsHandler, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+	if serverURL == "" {
+		serverURL = "/"
+	}
+	doc.Servers = openapi3.Servers{{URL: serverURL}}
+
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode openapi spec: %w", err)
+	}
+	return &DocsHandler{spec: spec, modTime: time.Now()}, nil
+}
+
+func (h *DocsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var name string
+	var content []byte
+	switch r.URL.Path {
+	case JSONPath:
+		name, content = "openapi.json", h.spec
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	case DocsPath:
+		name, content = "docs.html", docsPage
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	// 仕様はサーバーの更新で変わるため、常に再検証させる
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeContent(w, r, name, h.modTime, bytes.NewReader(content))
+}
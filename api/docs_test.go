@@ -0,0 +1,65 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocsHandler(t *testing.T) {
+	h, err := NewDocsHandler("")
+	require.NoError(t, err)
+
+	t.Run("正常系: OpenAPI仕様をJSONで返す", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, JSONPath, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+
+		var doc struct {
+			OpenAPI string `json:"openapi"`
+			Servers []struct {
+				URL string `json:"url"`
+			} `json:"servers"`
+			Paths map[string]struct {
+				Get struct {
+					OperationID string `json:"operationId"`
+				} `json:"get"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Equal(t, "3.0.3", doc.OpenAPI)
+		// 配信するサーバーと同じオリジンを指す
+		require.Len(t, doc.Servers, 1)
+		assert.Equal(t, "/", doc.Servers[0].URL)
+		assert.Equal(t, "listItems", doc.Paths["/items"].Get.OperationID)
+	})
+
+	t.Run("正常系: servers を指定したURLに置き換える", func(t *testing.T) {
+		h, err := NewDocsHandler("https://api.example.com")
+		require.NoError(t, err)
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, JSONPath, nil))
+		assert.Contains(t, rec.Body.String(), `"servers":[{"url":"https://api.example.com"}]`)
+	})
+
+	t.Run("正常系: Swagger UI はOpenAPI仕様のJSONを読み込む", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DocsPath, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Body.String(), `url: "`+JSONPath+`"`)
+	})
+
+	t.Run("異常系: その他のパスは404", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.yaml", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+)
+
+// 既定のスキーマ（リポジトリのルートで実行する想定。Docker イメージでは /app/sql/init.sql）
+const defaultSchemaPath = "sql/init.sql"
+
+// runMigrate は init.sql の CREATE TABLE 文を実行し、存在しないテーブルを作成する
+func runMigrate(ctx context.Context, args []string) error {
+	fs := newFlagSet("migrate")
+	schemaPath := fs.String("schema", defaultSchemaPath, "SQL script with the table definitions")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	script, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	dbHandler := databaseInfra.NewSqlHandler()
+	defer dbHandler.Close()
+
+	tables, err := databaseInfra.Migrate(ctx, dbHandler, string(script))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✅ Ensured %d tables: %s\n", len(tables), strings.Join(tables, ", "))
+	fmt.Println("   Columns of existing tables are not changed; apply new columns with ALTER TABLE.")
+	return nil
+}
+
+// runSeed は init.sql の初期データ（カテゴリーとサンプルのアイテム）を、空のテーブルにのみ登録する
+func runSeed(ctx context.Context, args []string) error {
+	fs := newFlagSet("seed")
+	schemaPath := fs.String("schema", defaultSchemaPath, "SQL script with the initial data")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	script, err := os.ReadFile(*schemaPath)
+	if err != nil {
+		return fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	dbHandler := databaseInfra.NewSqlHandler()
+	defer dbHandler.Close()
+
+	tables, err := databaseInfra.Seed(ctx, dbHandler, string(script))
+	if err != nil {
+		return err
+	}
+	if len(tables) == 0 {
+		fmt.Println("✅ Nothing to seed: the tables already have data")
+		return nil
+	}
+	fmt.Printf("✅ Seeded %s\n", strings.Join(tables, ", "))
+	return nil
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"Aicon-assignment/pkg/client"
+)
+
+// 既定の接続先（AICON_API_URL で変更できる）
+const defaultAPIURL = "http://localhost:8080"
+
+// apiFlags はAPIの接続先と認証情報のフラグ
+type apiFlags struct {
+	url    string
+	apiKey string
+	token  string
+}
+
+func (f *apiFlags) register(fs *flag.FlagSet) {
+	url := os.Getenv("AICON_API_URL")
+	if url == "" {
+		url = defaultAPIURL
+	}
+	fs.StringVar(&f.url, "url", url, "API base URL (AICON_API_URL)")
+	fs.StringVar(&f.apiKey, "api-key", os.Getenv("AICON_API_KEY"), "API key sent as X-API-Key (AICON_API_KEY)")
+	fs.StringVar(&f.token, "token", os.Getenv("AICON_TOKEN"), "access token sent as Authorization: Bearer (AICON_TOKEN)")
+}
+
+func (f *apiFlags) client() (*client.Client, error) {
+	if f.apiKey == "" && f.token == "" {
+		return nil, errors.New("an API key (-api-key or AICON_API_KEY) or an access token (-token or AICON_TOKEN) is required")
+	}
+	var opts []client.Option
+	if f.apiKey != "" {
+		opts = append(opts, client.WithAPIKey(f.apiKey))
+	} else {
+		opts = append(opts, client.WithToken(f.token))
+	}
+	return client.New(f.url, opts...)
+}
+
+// filterFlags は items list / export の絞り込み条件のフラグ
+type filterFlags struct {
+	filter client.ListFilter
+}
+
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.filter.Category, "category", "", "category")
+	fs.StringVar(&f.filter.Brand, "brand", "", "brand")
+	fs.StringVar(&f.filter.Condition, "condition", "", "condition")
+	fs.Func("tag", "tag (repeatable, all tags must match)", func(v string) error {
+		f.filter.Tags = append(f.filter.Tags, v)
+		return nil
+	})
+	fs.Func("attr", "attribute filter as name=value (repeatable)", func(v string) error {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("attribute filter must be name=value: %q", v)
+		}
+		if f.filter.Attributes == nil {
+			f.filter.Attributes = map[string]string{}
+		}
+		f.filter.Attributes[name] = value
+		return nil
+	})
+}
+
+func runItems(ctx context.Context, command string, args []string) error {
+	switch command {
+	case "list":
+		return runItemsList(ctx, args)
+	case "create":
+		return runItemsCreate(ctx, args)
+	case "import":
+		return runItemsImport(ctx, args)
+	case "export":
+		return runItemsExport(ctx, args)
+	}
+	return fmt.Errorf("%w: unknown command items %s", errUsage, command)
+}
+
+func runItemsList(ctx context.Context, args []string) error {
+	fs := newFlagSet("items list")
+	var api apiFlags
+	var filter filterFlags
+	api.register(fs)
+	filter.register(fs)
+	asJSON := fs.Bool("json", false, "print items as JSON lines")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c, err := api.client()
+	if err != nil {
+		return err
+	}
+
+	// JSON はアイテムごとに1行（jq などで処理しやすくする）、それ以外は表形式
+	encoder := json.NewEncoder(os.Stdout)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if !*asJSON {
+		fmt.Fprintln(w, "ID\tNAME\tCATEGORY\tBRAND\tPRICE\tCURRENCY\tPURCHASE_DATE")
+	}
+	items, err := c.Items.List(ctx, filter.filter)
+	if err != nil {
+		return fmt.Errorf("failed to list items: %w", describe(err))
+	}
+	for _, item := range items {
+		if *asJSON {
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+			continue
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", item.ID, item.Name, item.Category, item.Brand,
+			client.FormatPrice(item.PurchasePrice, item.PurchaseCurrency), item.PurchaseCurrency, item.PurchaseDate)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if !*asJSON {
+		fmt.Fprintf(os.Stderr, "%d items\n", len(items))
+	}
+	return nil
+}
+
+func runItemsCreate(ctx context.Context, args []string) error {
+	fs := newFlagSet("items create")
+	var api apiFlags
+	api.register(fs)
+	var input client.CreateItemInput
+	var price string
+	fs.StringVar(&input.Name, "name", "", "name (required)")
+	fs.StringVar(&input.Category, "category", "", "category (required)")
+	fs.StringVar(&input.Brand, "brand", "", "brand (required)")
+	fs.StringVar(&price, "price", "", "purchase price as a decimal, e.g. 1500000 or 123.45 (required)")
+	fs.StringVar(&input.PurchaseCurrency, "currency", "", "purchase currency: JPY, USD, EUR (default JPY)")
+	fs.StringVar(&input.PurchaseDate, "date", "", "purchase date YYYY-MM-DD (required)")
+	fs.StringVar(&input.Visibility, "visibility", "", "private, shared or public (default private)")
+	fs.StringVar(&input.Condition, "condition", "", "condition")
+	fs.StringVar(&input.SerialNumber, "serial", "", "serial number")
+	fs.StringVar(&input.Notes, "notes", "", "notes")
+	fs.Int64Var(&input.OrgID, "org", 0, "organization ID (default: personal item)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	input.PurchaseCurrency = strings.ToUpper(input.PurchaseCurrency)
+	amount, err := client.ParsePrice(price, input.PurchaseCurrency)
+	if err != nil {
+		return fmt.Errorf("invalid -price: %w", err)
+	}
+	input.PurchasePrice = amount
+
+	c, err := api.client()
+	if err != nil {
+		return err
+	}
+	item, err := c.Items.Create(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create item: %w", describe(err))
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(item)
+}
+
+func runItemsImport(ctx context.Context, args []string) error {
+	fs := newFlagSet("items import")
+	var api apiFlags
+	api.register(fs)
+	path := fs.String("file", "", "CSV file in the POST /items/import format (- for stdin)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *path == "" {
+		return fmt.Errorf("%w: -file is required", errUsage)
+	}
+
+	c, err := api.client()
+	if err != nil {
+		return err
+	}
+
+	var r io.Reader = os.Stdin
+	if *path != "-" {
+		f, err := os.Open(*path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	result, err := c.Items.Import(ctx, r)
+	if err != nil {
+		return fmt.Errorf("failed to import items: %w", describe(err))
+	}
+
+	fmt.Printf("imported %d, failed %d\n", result.Imported, result.Failed)
+	for _, row := range result.Errors {
+		for _, e := range row.Errors {
+			fmt.Printf("  row %d: %s: %s\n", row.Row, e.Field, e.Message)
+		}
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d rows were not imported", result.Failed)
+	}
+	return nil
+}
+
+func runItemsExport(ctx context.Context, args []string) error {
+	fs := newFlagSet("items export")
+	var api apiFlags
+	var filter filterFlags
+	api.register(fs)
+	filter.register(fs)
+	path := fs.String("out", "items.xlsx", "output Excel file (- for stdout)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	c, err := api.client()
+	if err != nil {
+		return err
+	}
+
+	if *path == "-" {
+		if err := c.Items.Export(ctx, filter.filter, os.Stdout); err != nil {
+			return fmt.Errorf("failed to export items: %w", describe(err))
+		}
+		return nil
+	}
+
+	// 失敗した場合に途中までのファイルを残さないよう、一時ファイルに書き込んでから置き換える
+	tmp, err := os.CreateTemp(filepath.Dir(*path), ".aiconctl-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := c.Items.Export(ctx, filter.filter, tmp); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to export items: %w", describe(err))
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), *path); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "✅ Wrote %s\n", *path)
+	return nil
+}
+
+// describe はAPIのエラーに項目ごとの詳細を加える
+func describe(err error) error {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || len(apiErr.Details) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (%s)", err, strings.Join(apiErr.Details, "; "))
+}
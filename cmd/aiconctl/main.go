@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+const usage = `aiconctl は所持品管理APIの運用のためのコマンド
+
+使い方:
+  aiconctl items list     [-category 時計] [-brand ROLEX] [-tag vintage] [-attr movement=自動巻き] [-json]
+  aiconctl items create   -name デイトナ -category 時計 -brand ROLEX -price 1500000 -date 2023-01-15
+  aiconctl items import   -file items.csv     （- は標準入力）
+  aiconctl items export   -out items.xlsx     [絞り込みは items list と同じ]
+  aiconctl migrate        [-schema sql/init.sql]
+  aiconctl seed           [-schema sql/init.sql]
+
+items はAPIを呼び出します（-url または AICON_API_URL、認証は -api-key / AICON_API_KEY か -token / AICON_TOKEN）。
+migrate と seed はデータベースに直接接続します（接続先はサーバーと同じ DB_HOST などの環境変数）。
+`
+
+// errUsage は引数の誤り（使い方を表示して終了コード 2 で終了する）
+var errUsage = errors.New("invalid arguments")
+
+// 運用作業（アイテムの一覧・登録・取り込み・書き出しと、データベースのテーブル作成・初期データ登録）を行うCLI
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx, os.Args[1:]); err != nil {
+		if errors.Is(err, errUsage) {
+			fmt.Fprint(os.Stderr, usage)
+			os.Exit(2)
+		}
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+
+	switch args[0] {
+	case "items":
+		if len(args) < 2 {
+			return errUsage
+		}
+		return runItems(ctx, args[1], args[2:])
+	case "migrate":
+		return runMigrate(ctx, args[1:])
+	case "seed":
+		return runSeed(ctx, args[1:])
+	case "help", "-h", "-help", "--help":
+		fmt.Print(usage)
+		return nil
+	}
+	return errUsage
+}
+
+// newFlagSet はサブコマンドのフラグを作成する（解釈の誤りは errUsage として扱う）
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.Usage = func() {}
+	return fs
+}
+
+// parseFlags はフラグを解釈する（余分な引数は誤りとする）
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("%w: %v", errUsage, err)
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("%w: unexpected argument %q", errUsage, fs.Arg(0))
+	}
+	return nil
+}
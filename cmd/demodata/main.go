@@ -0,0 +1,131 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 1回の INSERT で書き出す最大の行数
+const insertBatchSize = 500
+
+// 本番のデータベースから件数・分布・長さを保ったまま名前・ブランド・価格などを合成した値に置き換え、
+// デモや本番外での不具合の再現に使えるデータセットを SQL として書き出す
+func main() {
+	outPath := flag.String("out", "", "output SQL path (default: stdout)")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	password := flag.String("password", "demo-password", "password of the generated demo users")
+	flag.Parse()
+
+	dbHandler := databaseInfra.NewSqlHandler()
+	defer dbHandler.Close()
+
+	demoDataset := usecase.NewDemoDatasetUsecase(
+		&itemDatabase.ItemRepository{SqlHandler: dbHandler},
+		&itemDatabase.OrganizationRepository{SqlHandler: dbHandler},
+	)
+	dataset, err := demoDataset.Generate(context.Background(), usecase.GenerateDemoDatasetInput{
+		Seed:     *seed,
+		Password: *password,
+	})
+	if err != nil {
+		log.Fatalf("Failed to generate demo dataset: %v", err)
+	}
+
+	out := os.Stdout
+	if *outPath != "" {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			log.Fatalf("Failed to create output file: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	writeDataset(w, dataset)
+	if err := w.Flush(); err != nil {
+		log.Fatalf("Failed to write demo dataset: %v", err)
+	}
+
+	log.Printf("✅ Wrote %d users, %d organizations and %d items (seed %d)",
+		len(dataset.Users), len(dataset.Organizations), len(dataset.Items), *seed)
+}
+
+// writeDataset はデータセットを空のデータベースに読み込める INSERT 文として書き出す
+func writeDataset(w io.Writer, dataset *usecase.DemoDataset) {
+	fmt.Fprintln(w, "-- 匿名化したデモ用データセット（init.sql を実行した空のデータベースに読み込む）")
+	fmt.Fprintln(w, "SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;")
+
+	users := make([][]string, 0, len(dataset.Users))
+	for _, u := range dataset.Users {
+		users = append(users, []string{fmt.Sprint(u.ID), quote(u.Email), quote(u.PasswordHash), quote(string(u.Role))})
+	}
+	writeInserts(w, "users", []string{"id", "email", "password_hash", "role"}, users)
+
+	orgs := make([][]string, 0, len(dataset.Organizations))
+	for _, o := range dataset.Organizations {
+		orgs = append(orgs, []string{fmt.Sprint(o.ID), quote(o.Name)})
+	}
+	writeInserts(w, "organizations", []string{"id", "name"}, orgs)
+
+	members := make([][]string, 0, len(dataset.Memberships))
+	for _, m := range dataset.Memberships {
+		members = append(members, []string{fmt.Sprint(m.OrganizationID), fmt.Sprint(m.UserID), quote(string(m.Role))})
+	}
+	writeInserts(w, "organization_members", []string{"organization_id", "user_id", "role"}, members)
+
+	items := make([][]string, 0, len(dataset.Items))
+	for _, i := range dataset.Items {
+		items = append(items, []string{
+			nullableID(i.UserID), nullableID(i.OrgID), quote(i.Name), quote(i.Category),
+			quote(i.Brand), fmt.Sprint(i.PurchasePrice.Amount), quote(string(i.PurchasePrice.Currency)), quote(i.PurchaseDate),
+			quote(string(i.Visibility)), quote(string(i.Condition)), quote(string(i.Status)),
+		})
+	}
+	// アイテムは init.sql のサンプルデータと重ならないよう ID を自動採番にする
+	writeInserts(w, "items", []string{
+		"user_id", "org_id", "name", "category", "brand", "purchase_price", "purchase_currency", "purchase_date", "visibility", "item_condition", "status",
+	}, items)
+}
+
+// writeInserts は行を insertBatchSize 行ずつの INSERT 文として書き出す
+func writeInserts(w io.Writer, table string, columns []string, rows [][]string) {
+	for start := 0; start < len(rows); start += insertBatchSize {
+		end := min(start+insertBatchSize, len(rows))
+
+		fmt.Fprintf(w, "INSERT INTO %s (%s) VALUES\n", table, strings.Join(columns, ", "))
+		for i, row := range rows[start:end] {
+			sep := ","
+			if start+i == end-1 {
+				sep = ";"
+			}
+			fmt.Fprintf(w, "    (%s)%s\n", strings.Join(row, ", "), sep)
+		}
+	}
+}
+
+// quote は文字列を SQL の文字列リテラルにする
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `'`, `''`)
+	return "'" + s + "'"
+}
+
+// nullableID は 0 の ID を NULL にする
+func nullableID(id int64) string {
+	if id == 0 {
+		return "NULL"
+	}
+	return fmt.Sprint(id)
+}
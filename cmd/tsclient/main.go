@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+
+	"Aicon-assignment/internal/tsclient"
+)
+
+func main() {
+	specPath := flag.String("spec", "api/openapi.yaml", "OpenAPI spec path")
+	outDir := flag.String("out", "clients/typescript", "output directory")
+	flag.Parse()
+
+	spec, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatalf("Failed to read spec: %v", err)
+	}
+
+	files, err := tsclient.Generate(spec)
+	if err != nil {
+		log.Fatalf("Failed to generate client: %v", err)
+	}
+
+	if err := os.MkdirAll(*outDir, 0o755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*outDir, "index.js"), files.JS, 0o644); err != nil {
+		log.Fatalf("Failed to write index.js: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*outDir, "index.d.ts"), files.DTS, 0o644); err != nil {
+		log.Fatalf("Failed to write index.d.ts: %v", err)
+	}
+}
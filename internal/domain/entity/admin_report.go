@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// BrandRanking はブランドごとの登録数（管理者向けレポート top_brands の行）
+type BrandRanking struct {
+	Brand     string
+	ItemCount int64
+	// OwnerCount はそのブランドのアイテムを登録したユーザーの数
+	OwnerCount int64
+}
+
+// InactiveUser は一定期間操作していないユーザー（管理者向けレポート inactive_users の行）
+type InactiveUser struct {
+	UserID    int64
+	Email     string
+	Role      Role
+	CreatedAt time.Time
+	// LastActivityAt は監査ログに記録された最後の操作（一度も操作していない場合は nil）
+	LastActivityAt *time.Time
+	ItemCount      int64
+}
+
+// LargeAttachment はサイズの大きい添付画像（管理者向けレポート largest_attachments の行）
+type LargeAttachment struct {
+	ImageID     int64
+	ItemID      int64
+	FileName    string
+	ContentType string
+	Size        int64
+	CreatedAt   time.Time
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// APIKey はスクリプトや外部連携から X-API-Key ヘッダーで認証するためのキー。
+// キーそのものは発行時にのみ返し、ハッシュ値だけを保存する
+type APIKey struct {
+	ID         int64      `json:"id"`
+	UserID     int64      `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"` // 識別用のキーの先頭部分
+	KeyHash    string     `json:"-"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewAPIKey(userID int64, name, prefix, keyHash string) (*APIKey, error) {
+	key := &APIKey{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		Prefix:    prefix,
+		KeyHash:   keyHash,
+		CreatedAt: time.Now(),
+	}
+
+	if key.Name == "" {
+		return nil, errors.New("name is required")
+	}
+	if utf8.RuneCountInString(key.Name) > 100 {
+		return nil, errors.New("name must be 100 characters or less")
+	}
+
+	return key, nil
+}
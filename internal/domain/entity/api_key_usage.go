@@ -0,0 +1,29 @@
+package entity
+
+// APIKeyUsage は APIキーごと・日ごとのリクエストの件数
+type APIKeyUsage struct {
+	APIKeyID int64  `json:"-"`
+	Date     string `json:"date"` // YYYY-MM-DD 形式（UTC）
+	Requests int    `json:"requests"`
+	// ClientErrors は 4xx、ServerErrors は 5xx のレスポンスの件数（RateLimited を含む）
+	ClientErrors int `json:"client_errors"`
+	ServerErrors int `json:"server_errors"`
+	// RateLimited は 429 と、混雑のため Retry-After を付けて断った 503 の件数
+	RateLimited int `json:"rate_limited"`
+}
+
+// Add は other の件数を加える
+func (u *APIKeyUsage) Add(other APIKeyUsage) {
+	u.Requests += other.Requests
+	u.ClientErrors += other.ClientErrors
+	u.ServerErrors += other.ServerErrors
+	u.RateLimited += other.RateLimited
+}
+
+// ErrorRate はリクエストのうちエラー（4xx・5xx）の割合を返す（リクエストがない場合は 0）
+func (u APIKeyUsage) ErrorRate() float64 {
+	if u.Requests == 0 {
+		return 0
+	}
+	return float64(u.ClientErrors+u.ServerErrors) / float64(u.Requests)
+}
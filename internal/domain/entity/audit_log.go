@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// AuditAction は監査ログに記録する操作の種類
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+	AuditActionImport AuditAction = "import"
+	AuditActionExport AuditAction = "export"
+)
+
+// IsValid は定義済みの操作かを判定する
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditActionCreate, AuditActionUpdate, AuditActionDelete, AuditActionImport, AuditActionExport:
+		return true
+	}
+	return false
+}
+
+// AuditLog は誰がいつ何を操作したかの記録
+type AuditLog struct {
+	ID int64 `json:"id"`
+	// UserID は操作したユーザー（未認証の操作は0）
+	UserID int64 `json:"user_id"`
+	// UserEmail は表示用の操作したユーザーのメールアドレス（読み込み時に設定する）
+	UserEmail string      `json:"user_email"`
+	Action    AuditAction `json:"action"`
+	// Method と Path はリクエストのメソッドとルート（例: /items/:id）、ResourceID はパスのID
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	ResourceID string    `json:"resource_id"`
+	Status     int       `json:"status"`
+	RequestID  string    `json:"request_id"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// AuditLogFilter は監査ログの絞り込み条件（nil / 空文字 / 0 のフィールドは条件なし）
+type AuditLogFilter struct {
+	From   *time.Time
+	To     *time.Time // To の時刻を含まない
+	UserID int64
+	Action AuditAction
+	Limit  int
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// BackupFormatVersion はバックアップの形式のバージョン（読み込めない形式に変更した場合に上げる）
+const BackupFormatVersion = 1
+
+// BackupTables はバックアップの対象のテーブル（アイテムのデータ）
+var BackupTables = []string{"items"}
+
+// Backup はアイテムのデータの全件のバックアップ。別の環境への移行に使う
+type Backup struct {
+	FormatVersion int       `json:"format_version"`
+	CreatedAt     time.Time `json:"created_at"`
+	// Tables はテーブルごとの全行（ID と作成・更新日時を含む）
+	Tables []BackupTable `json:"tables"`
+	// Checksums は書き出しと同じトランザクションで求めた本番のテーブルの行数とチェックサム（バックアップの検証に使う）
+	Checksums []TableChecksum `json:"checksums,omitempty"`
+}
+
+// BackupTable はテーブルの全行。Rows の各行は Columns と同じ順の値
+// （日時は RFC 3339、日付は YYYY-MM-DD の文字列、NULL は null）
+type BackupTable struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns"`
+	Rows    [][]any  `json:"rows"`
+}
+
+// backupTableFields は JSON の変換で BackupTable のメソッドを引き継がないための型
+type backupTableFields BackupTable
+
+// UnmarshalJSON は数値を json.Number のまま読み込む（float64 では大きな ID の精度が落ちるため）
+func (t *BackupTable) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode((*backupTableFields)(t))
+}
+
+// Validate は読み込めるバックアップかを検証する
+func (b *Backup) Validate() error {
+	if b.FormatVersion != BackupFormatVersion {
+		return fmt.Errorf("unsupported format_version %d (supported: %d)", b.FormatVersion, BackupFormatVersion)
+	}
+
+	seen := map[string]bool{}
+	for _, table := range b.Tables {
+		if !slices.Contains(BackupTables, table.Name) {
+			return fmt.Errorf("unknown table %q", table.Name)
+		}
+		if seen[table.Name] {
+			return fmt.Errorf("duplicate table %q", table.Name)
+		}
+		seen[table.Name] = true
+
+		if !slices.Contains(table.Columns, "id") {
+			return fmt.Errorf("table %q has no id column", table.Name)
+		}
+		for i, column := range table.Columns {
+			if column == "" || slices.Contains(table.Columns[:i], column) {
+				return fmt.Errorf("table %q has an empty or duplicate column %q", table.Name, column)
+			}
+		}
+		for i, row := range table.Rows {
+			if len(row) != len(table.Columns) {
+				return fmt.Errorf("table %q row %d has %d values for %d columns", table.Name, i+1, len(row), len(table.Columns))
+			}
+		}
+	}
+	if len(seen) == 0 {
+		return errors.New("backup has no tables")
+	}
+	return nil
+}
+
+// RestoreResult はバックアップの読み込みの結果
+type RestoreResult struct {
+	FormatVersion int `json:"format_version"`
+	// BackupCreatedAt は読み込んだバックアップの作成日時
+	BackupCreatedAt time.Time       `json:"backup_created_at"`
+	Tables          []RestoredTable `json:"tables"`
+}
+
+// RestoredTable はテーブルごとの読み込んだ行数と、バックアップになかったため削除した行数
+type RestoredTable struct {
+	Name     string `json:"name"`
+	Restored int    `json:"restored"`
+	Deleted  int    `json:"deleted"`
+}
+
+// TableChecksum はテーブルの行数とチェックサム（バックアップの列の値から求めた各行の CRC32 の合計）
+type TableChecksum struct {
+	Name     string `json:"name"`
+	Rows     int64  `json:"rows"`
+	Checksum string `json:"checksum"`
+}
+
+const (
+	BackupVerificationPassed = "passed"
+	BackupVerificationFailed = "failed"
+	BackupVerificationError  = "error"
+)
+
+// BackupVerification はバックアップを検証用のスキーマに読み込み、本番のテーブルと比べた結果
+type BackupVerification struct {
+	// Status は passed（すべてのテーブルが一致）、failed（一致しないテーブルがある）、error（検証を実行できなかった）
+	Status     string    `json:"status"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// BackupCreatedAt は検証したバックアップの作成日時（バックアップを作成できなかった場合は空）
+	BackupCreatedAt *time.Time                `json:"backup_created_at,omitempty"`
+	Tables          []BackupTableVerification `json:"tables"`
+	Error           string                    `json:"error,omitempty"`
+}
+
+// BackupTableVerification はテーブルごとの本番（バックアップの時点）と読み込んだテーブルの行数・チェックサム
+type BackupTableVerification struct {
+	Name               string `json:"name"`
+	ProductionRows     int64  `json:"production_rows"`
+	RestoredRows       int64  `json:"restored_rows"`
+	ProductionChecksum string `json:"production_checksum"`
+	RestoredChecksum   string `json:"restored_checksum"`
+	Match              bool   `json:"match"`
+}
+
+// CompareBackupTables は本番と読み込んだテーブルの行数・チェックサムを比べる（読み込んだ側にないテーブルは不一致）
+func CompareBackupTables(production, restored []TableChecksum) []BackupTableVerification {
+	restoredByName := map[string]TableChecksum{}
+	for _, table := range restored {
+		restoredByName[table.Name] = table
+	}
+	tables := make([]BackupTableVerification, 0, len(production))
+	for _, table := range production {
+		r, ok := restoredByName[table.Name]
+		tables = append(tables, BackupTableVerification{
+			Name:               table.Name,
+			ProductionRows:     table.Rows,
+			RestoredRows:       r.Rows,
+			ProductionChecksum: table.Checksum,
+			RestoredChecksum:   r.Checksum,
+			Match:              ok && r.Rows == table.Rows && r.Checksum == table.Checksum,
+		})
+	}
+	return tables
+}
@@ -0,0 +1,60 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackup_Validate(t *testing.T) {
+	valid := func() *Backup {
+		return &Backup{
+			FormatVersion: BackupFormatVersion,
+			Tables:        []BackupTable{{Name: "items", Columns: []string{"id", "name"}, Rows: [][]any{{int64(1), "a"}}}},
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	tests := []struct {
+		name   string
+		modify func(*Backup)
+		err    string
+	}{
+		{"未対応のバージョン", func(b *Backup) { b.FormatVersion = 0 }, "unsupported format_version 0 (supported: 1)"},
+		{"対象外のテーブル", func(b *Backup) { b.Tables[0].Name = "users" }, `unknown table "users"`},
+		{"テーブルの重複", func(b *Backup) { b.Tables = append(b.Tables, b.Tables[0]) }, `duplicate table "items"`},
+		{"id 列がない", func(b *Backup) { b.Tables[0].Columns = []string{"name", "brand"} }, `table "items" has no id column`},
+		{"列の重複", func(b *Backup) { b.Tables[0].Columns = []string{"id", "id"} }, `table "items" has an empty or duplicate column "id"`},
+		{"値の数が列と異なる", func(b *Backup) { b.Tables[0].Rows = [][]any{{int64(1)}} }, `table "items" row 1 has 1 values for 2 columns`},
+		{"テーブルがない", func(b *Backup) { b.Tables = nil }, "backup has no tables"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := valid()
+			tt.modify(b)
+			assert.EqualError(t, b.Validate(), tt.err)
+		})
+	}
+}
+
+// 大きな ID を float64 にせず読み込むこと
+func TestBackupTable_UnmarshalJSON(t *testing.T) {
+	var table BackupTable
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"items","columns":["id","name"],"rows":[[9007199254740993,null]]}`), &table))
+	assert.Equal(t, json.Number("9007199254740993"), table.Rows[0][0])
+	assert.Nil(t, table.Rows[0][1])
+}
+
+func TestCompareBackupTables(t *testing.T) {
+	production := []TableChecksum{{Name: "items", Rows: 2, Checksum: "10"}, {Name: "tags", Rows: 1, Checksum: "5"}}
+	restored := []TableChecksum{{Name: "items", Rows: 2, Checksum: "10"}}
+
+	tables := CompareBackupTables(production, restored)
+	require.Len(t, tables, 2)
+	assert.True(t, tables[0].Match)
+	// 読み込んだ側にないテーブルは不一致
+	assert.False(t, tables[1].Match)
+	assert.Equal(t, int64(0), tables[1].RestoredRows)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"maps"
+	"strings"
+)
+
+// CatalogEntry は型番（リファレンス番号）で引いたカタログの情報。アイテムの登録内容の補完に使う
+type CatalogEntry struct {
+	ReferenceNumber string `json:"reference_number"`
+	Brand           string `json:"brand"`
+	// Model はモデル名（アイテムの名前の候補）
+	Model string `json:"model"`
+	// Category はカテゴリー（日本語の名前。レスポンスでは表示言語の名前にする）
+	Category string `json:"category"`
+	// Attributes はカテゴリーごとの属性の候補（時計のムーブメントなど）
+	Attributes ItemAttributes `json:"attributes"`
+}
+
+// Clone は属性を含めて複製する（カタログのデータを呼び出し元が書き換えないようにする）
+func (e *CatalogEntry) Clone() *CatalogEntry {
+	clone := *e
+	clone.Attributes = maps.Clone(e.Attributes)
+	if clone.Attributes == nil {
+		clone.Attributes = ItemAttributes{}
+	}
+	return &clone
+}
+
+// NormalizeReferenceNumber は型番を比較用に正規化する（英字を大文字にし、空白とハイフンを除く。
+// "116520"・"126610 LN"・"m-41526" のような表記ゆれを同じ型番として扱う）
+func NormalizeReferenceNumber(ref string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '　', '-', '‐', '－':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(ref)))
+}
@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// カテゴリー名の最大文字数（items.category 列の VARCHAR(50)）
+const MaxCategoryNameLength = 50
+
+// DefaultCategories は初期データのカテゴリー（categories テーブルを読み込むまでの検証にも使う）
+var DefaultCategories = []Category{
+	newDefaultCategory("時計", "Watch"),
+	newDefaultCategory("バッグ", "Bag"),
+	newDefaultCategory("ジュエリー", "Jewelry"),
+	newDefaultCategory("靴", "Shoes"),
+	newDefaultCategory("その他", "Other"),
+}
+
+func newDefaultCategory(name, nameEn string) Category {
+	return Category{Name: name, NameEn: &nameEn}
+}
+
+// Category はアイテムのカテゴリー。アイテムには日本語の名前（Name）で保存する
+type Category struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// NameEn は英語の表示名（未設定は null で、英語でも Name を表示する）
+	NameEn *string `json:"name_en"`
+	// DisplayName はリクエストの表示言語での名前（レスポンスを返すときにユースケースが設定する）
+	DisplayName string `json:"display_name"`
+	// Defaults はこのカテゴリーのアイテムの登録時に使う既定値
+	Defaults CategoryDefaults `json:"defaults"`
+	// RequiredFields はこのカテゴリーのアイテムで追加で必須にする項目（CategoryRequirableFields のいずれか）
+	RequiredFields []string  `json:"required_fields"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func NewCategory(name string, nameEn *string, defaults CategoryDefaults, requiredFields []string, now time.Time) (*Category, error) {
+	category := &Category{
+		Name:           strings.TrimSpace(name),
+		NameEn:         trimmedOrNil(nameEn),
+		RequiredFields: []string{},
+		CreatedAt:      now,
+	}
+	category.SetRules(&defaults, requiredFields)
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (c *Category) Validate() error {
+	var errs domainErrors.ValidationErrors
+	if c.Name == "" {
+		errs.Add("name", domainErrors.CodeRequired, "name is required")
+	} else if utf8.RuneCountInString(c.Name) > MaxCategoryNameLength {
+		errs.Add("name", domainErrors.CodeTooLong, fmt.Sprintf("name must be %d characters or less", MaxCategoryNameLength))
+	}
+	if c.NameEn != nil && utf8.RuneCountInString(*c.NameEn) > MaxCategoryNameLength {
+		errs.Add("name_en", domainErrors.CodeTooLong, fmt.Sprintf("name_en must be %d characters or less", MaxCategoryNameLength))
+	}
+	c.Defaults.validate(&errs)
+	validateRequiredFields(c.RequiredFields, &errs)
+	return errs.Err()
+}
+
+// SetNameEn は英語の表示名を変更する（前後の空白を除く。空文字は未設定に戻す）
+func (c *Category) SetNameEn(nameEn string) {
+	c.NameEn = trimmedOrNil(&nameEn)
+}
+
+// Localized は表示言語での名前（英語の表示名が未設定の場合は日本語の名前）
+func (c *Category) Localized(lang Language) string {
+	if lang == LanguageEnglish && c.NameEn != nil {
+		return *c.NameEn
+	}
+	return c.Name
+}
+
+// matches は日本語の名前か英語の表示名（大文字・小文字を区別しない）と一致するかを判定する
+func (c *Category) matches(name string) bool {
+	return c.Name == name || (c.NameEn != nil && strings.EqualFold(*c.NameEn, name))
+}
+
+// ConflictsWith は名前か英語の表示名が別のカテゴリーと重なるかを判定する
+// （重なると、英語で指定されたカテゴリーがどちらか決まらなくなる）
+func (c *Category) ConflictsWith(other *Category) bool {
+	if other.matches(c.Name) || c.matches(other.Name) {
+		return true
+	}
+	return c.NameEn != nil && other.NameEn != nil && strings.EqualFold(*c.NameEn, *other.NameEn)
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+// アイテムの検証に使うカテゴリー（起動時と変更時にユースケースが categories テーブルから設定する）
+var (
+	categoriesMu sync.RWMutex
+	categories   = slices.Clone(DefaultCategories)
+)
+
+// SetCategories はアイテムの検証と表示に使うカテゴリーを置き換える
+func SetCategories(list []Category) {
+	categoriesMu.Lock()
+	defer categoriesMu.Unlock()
+	categories = slices.Clone(list)
+}
+
+// Categories はアイテムの検証と表示に使うカテゴリーを返す
+func Categories() []Category {
+	categoriesMu.RLock()
+	defer categoriesMu.RUnlock()
+	return slices.Clone(categories)
+}
+
+// GetValidCategories はアイテムに保存できるカテゴリー（日本語の名前）を返す
+func GetValidCategories() []string {
+	list := Categories()
+	names := make([]string, 0, len(list))
+	for _, c := range list {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+// ResolveCategory は日本語の名前か英語の表示名で指定されたカテゴリーを、アイテムに保存する日本語の名前にする
+// （一致するカテゴリーがない場合は前後の空白を除いた入力のまま返し、検証でエラーにする）
+func ResolveCategory(name string) string {
+	name = strings.TrimSpace(name)
+	for _, c := range Categories() {
+		if c.matches(name) {
+			return c.Name
+		}
+	}
+	return name
+}
+
+// LocalizeCategory はアイテムに保存したカテゴリーを表示言語での名前にする
+func LocalizeCategory(name string, lang Language) string {
+	for _, c := range Categories() {
+		if c.Name == name {
+			return c.Localized(lang)
+		}
+	}
+	return name
+}
+
+// カテゴリーのバリデーション
+func isValidCategory(category string) bool {
+	return slices.Contains(GetValidCategories(), category)
+}
+
+// categoryErrorMessage は有効なカテゴリーの一覧を含むエラーメッセージ
+func categoryErrorMessage() string {
+	return "category must be one of: " + strings.Join(GetValidCategories(), ", ")
+}
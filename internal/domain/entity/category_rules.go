@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CategoryRequirableFields はカテゴリーごとに必須にできるアイテムの項目
+// （name や brand などはどのカテゴリーでも必須）
+var CategoryRequirableFields = []string{"condition", ItemFieldSerialNumber, "notes"}
+
+// CategoryDefaults はアイテムの登録時に指定がない項目に使う既定値（空は既定値なし）
+type CategoryDefaults struct {
+	Brand     string    `json:"brand,omitempty"`
+	Condition Condition `json:"condition,omitempty"`
+}
+
+// normalize は既定値の前後の空白を除く
+func (d *CategoryDefaults) normalize() {
+	d.Brand = strings.TrimSpace(d.Brand)
+	d.Condition = Condition(strings.TrimSpace(string(d.Condition)))
+}
+
+func (d *CategoryDefaults) validate(errs *domainErrors.ValidationErrors) {
+	if utf8.RuneCountInString(d.Brand) > ItemBrandMaxLength {
+		errs.Add("defaults.brand", domainErrors.CodeTooLong, fmt.Sprintf("defaults.brand must be %d characters or less", ItemBrandMaxLength))
+	}
+	if d.Condition != ConditionUnknown && !IsValidCondition(d.Condition) {
+		errs.Add("defaults.condition", domainErrors.CodeInvalidChoice, "defaults."+conditionErrorMessage)
+	}
+}
+
+// normalizeRequiredFields は必須項目の前後の空白と重複を除く（未設定は空のスライス）
+func normalizeRequiredFields(fields []string) []string {
+	normalized := []string{}
+	for _, field := range fields {
+		field = strings.TrimSpace(field)
+		if !slices.Contains(normalized, field) {
+			normalized = append(normalized, field)
+		}
+	}
+	return normalized
+}
+
+func validateRequiredFields(fields []string, errs *domainErrors.ValidationErrors) {
+	for _, field := range fields {
+		if !slices.Contains(CategoryRequirableFields, field) {
+			errs.Add("required_fields", domainErrors.CodeInvalidChoice, "required_fields must be a subset of: "+strings.Join(CategoryRequirableFields, ", "))
+			return
+		}
+	}
+}
+
+// SetRules はアイテムの既定値と必須項目を変更する（nil の項目は変更しない）
+func (c *Category) SetRules(defaults *CategoryDefaults, requiredFields []string) {
+	if defaults != nil {
+		c.Defaults = *defaults
+		c.Defaults.normalize()
+	}
+	if requiredFields != nil {
+		c.RequiredFields = normalizeRequiredFields(requiredFields)
+	}
+}
+
+// FindCategory はアイテムに保存したカテゴリー名のカテゴリーを返す
+func FindCategory(name string) (Category, bool) {
+	for _, c := range Categories() {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Category{}, false
+}
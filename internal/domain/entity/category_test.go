@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestNewCategory(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	nameEn := " Camera "
+	category, err := NewCategory(" カメラ ", &nameEn, CategoryDefaults{}, nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, "カメラ", category.Name)
+	assert.Equal(t, "Camera", *category.NameEn)
+	assert.Equal(t, now, category.CreatedAt)
+	assert.Equal(t, []string{}, category.RequiredFields)
+
+	// 空の英語名は未設定
+	blank := " "
+	category, err = NewCategory("カメラ", &blank, CategoryDefaults{}, nil, now)
+	require.NoError(t, err)
+	assert.Nil(t, category.NameEn)
+
+	tests := []struct {
+		name string
+		in   string
+		code string
+	}{
+		{"空の名前", "  ", domainErrors.CodeRequired},
+		{"長すぎる名前", strings.Repeat("あ", MaxCategoryNameLength+1), domainErrors.CodeTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCategory(tt.in, nil, CategoryDefaults{}, nil, now)
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			assert.Equal(t, "name", errs[0].Field)
+			assert.Equal(t, tt.code, errs[0].Code)
+		})
+	}
+}
+
+func TestNewCategory_Rules(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	category, err := NewCategory("時計", nil, CategoryDefaults{Brand: " 不明 ", Condition: ConditionNew}, []string{" serial_number", "serial_number", "notes"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, CategoryDefaults{Brand: "不明", Condition: ConditionNew}, category.Defaults)
+	assert.Equal(t, []string{"serial_number", "notes"}, category.RequiredFields)
+
+	_, err = NewCategory("時計", nil, CategoryDefaults{Brand: strings.Repeat("a", ItemBrandMaxLength+1), Condition: "ぼろぼろ"}, []string{"brand"}, now)
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	require.Len(t, errs, 3)
+	assert.Equal(t, "defaults.brand", errs[0].Field)
+	assert.Equal(t, domainErrors.CodeTooLong, errs[0].Code)
+	assert.Equal(t, "defaults.condition", errs[1].Field)
+	assert.Equal(t, "required_fields", errs[2].Field)
+	assert.Equal(t, domainErrors.CodeInvalidChoice, errs[2].Code)
+}
+
+// setCategories はテストで使うカテゴリーを設定し、終了時に元に戻す
+func setCategories(t *testing.T, list ...Category) {
+	original := Categories()
+	t.Cleanup(func() { SetCategories(original) })
+	SetCategories(list)
+}
+
+func TestSetCategories(t *testing.T) {
+	setCategories(t, newDefaultCategory("時計", "Watch"), Category{Name: "カメラ"})
+
+	item := &Item{Name: "ライカ M6", Category: "カメラ", Brand: "Leica", PurchasePrice: JPY(500000), PurchaseDate: "2023-01-01"}
+	assert.NoError(t, item.Validate())
+
+	item.Category = "バッグ"
+	errs, ok := domainErrors.AsValidationErrors(item.Validate())
+	require.True(t, ok)
+	assert.Equal(t, "category must be one of: 時計, カメラ", errs[0].Message)
+
+	// 返した一覧を書き換えても検証には影響しない
+	categories := Categories()
+	categories[0].Name = "バッグ"
+	assert.Equal(t, []string{"時計", "カメラ"}, GetValidCategories())
+}
+
+func TestResolveCategory(t *testing.T) {
+	setCategories(t, newDefaultCategory("時計", "Watch"), Category{Name: "カメラ"})
+
+	assert.Equal(t, "時計", ResolveCategory("時計"))
+	assert.Equal(t, "時計", ResolveCategory(" watch "))
+	assert.Equal(t, "時計", ResolveCategory("WATCH"))
+	assert.Equal(t, "カメラ", ResolveCategory("カメラ"))
+	// 一致しない場合は入力のまま（検証でエラーにする）
+	assert.Equal(t, "Camera", ResolveCategory("Camera"))
+
+	// アイテムには日本語の名前で保存する
+	item, err := NewItem("GMTマスター", "watch", "ROLEX", JPY(1500000), "2023-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, "時計", item.Category)
+}
+
+func TestLocalizeCategory(t *testing.T) {
+	setCategories(t, newDefaultCategory("時計", "Watch"), Category{Name: "カメラ"})
+
+	assert.Equal(t, "Watch", LocalizeCategory("時計", LanguageEnglish))
+	assert.Equal(t, "時計", LocalizeCategory("時計", LanguageJapanese))
+	// 英語名が未設定のカテゴリーと未知のカテゴリーはそのまま
+	assert.Equal(t, "カメラ", LocalizeCategory("カメラ", LanguageEnglish))
+	assert.Equal(t, "不明", LocalizeCategory("不明", LanguageEnglish))
+}
+
+func TestCategory_ConflictsWith(t *testing.T) {
+	watch := newDefaultCategory("時計", "Watch")
+
+	assert.True(t, (&Category{Name: "時計"}).ConflictsWith(&watch))
+	assert.True(t, (&Category{Name: "watch"}).ConflictsWith(&watch))
+	conflicting := newDefaultCategory("腕時計", "WATCH")
+	assert.True(t, conflicting.ConflictsWith(&watch))
+	other := newDefaultCategory("カメラ", "Camera")
+	assert.False(t, other.ConflictsWith(&watch))
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Language
+	}{
+		{"", LanguageJapanese},
+		{"en", LanguageEnglish},
+		{"en-US,en;q=0.9", LanguageEnglish},
+		{"ja,en;q=0.8", LanguageJapanese},
+		{"fr,en;q=0.5,ja;q=0.7", LanguageJapanese},
+		{"fr,en;q=0.5", LanguageEnglish},
+		{"EN-gb", LanguageEnglish},
+		{"en;q=0", LanguageJapanese},
+		{"en;q=abc", LanguageJapanese},
+		{"de", LanguageJapanese},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseAcceptLanguage(tt.header))
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// ColumnMigrationPhase は列の移行の段階（old → dual_write → dual_read → new の順に進め、戻すときは逆順にする）
+type ColumnMigrationPhase string
+
+const (
+	// ColumnMigrationPhaseOld は旧列だけを読み書きする（既定）
+	ColumnMigrationPhaseOld ColumnMigrationPhase = "old"
+	// ColumnMigrationPhaseDualWrite は両方の列に書き、旧列を読む。既存の行のバックフィルはこの段階以降で行う
+	ColumnMigrationPhaseDualWrite ColumnMigrationPhase = "dual_write"
+	// ColumnMigrationPhaseDualRead は両方の列に書き、旧列を返しつつ新列と比べて不一致を数える
+	ColumnMigrationPhaseDualRead ColumnMigrationPhase = "dual_read"
+	// ColumnMigrationPhaseNew は両方の列に書き、新列を読む（旧列は戻せるように書き続ける）
+	ColumnMigrationPhaseNew ColumnMigrationPhase = "new"
+)
+
+var columnMigrationPhases = []ColumnMigrationPhase{
+	ColumnMigrationPhaseOld, ColumnMigrationPhaseDualWrite, ColumnMigrationPhaseDualRead, ColumnMigrationPhaseNew,
+}
+
+func (p ColumnMigrationPhase) IsValid() bool {
+	for _, phase := range columnMigrationPhases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// WritesNew は新列にも書くかを返す
+func (p ColumnMigrationPhase) WritesNew() bool {
+	return p == ColumnMigrationPhaseDualWrite || p == ColumnMigrationPhaseDualRead || p == ColumnMigrationPhaseNew
+}
+
+// ReadsNew は新列の値を返すかを返す
+func (p ColumnMigrationPhase) ReadsNew() bool {
+	return p == ColumnMigrationPhaseNew
+}
+
+// ComparesReads は読み込んだ行の旧列と新列を比べるかを返す
+func (p ColumnMigrationPhase) ComparesReads() bool {
+	return p == ColumnMigrationPhaseDualRead
+}
+
+// ColumnMigration は利用中の列を停止せずに別の列へ移すための定義。
+// 定義はコードに書き（Table などは SQL にそのまま埋め込むため利用者の入力は使わない）、段階は COLUMN_MIGRATIONS で切り替える
+type ColumnMigration struct {
+	// Name は移行の名前（<テーブル>.<新列> の形式）
+	Name      string
+	Table     string
+	OldColumn string
+	NewColumn string
+	// Convert は旧列の値から新列の値を求める SQL の式（二重書き込み・バックフィル・比較に使う。
+	// 例: 文字列の日付を DATE 型の列に移す場合は STR_TO_DATE(purchase_date_text, '%Y-%m-%d')）
+	Convert string
+
+	phase atomic.Value
+	// 読み込み時の比較の件数
+	readsCompared  atomic.Int64
+	readMismatches atomic.Int64
+	lastMismatchID atomic.Int64
+}
+
+// ColumnMigrations は進行中の列の移行（新列を追加するときに定義し、旧列を削除したら定義も削除する）
+var ColumnMigrations = []*ColumnMigration{}
+
+// FindColumnMigration は名前から列の移行を返す（見つからない場合は nil）
+func FindColumnMigration(name string) *ColumnMigration {
+	for _, m := range ColumnMigrations {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Phase は現在の段階を返す（設定していない場合は old）
+func (m *ColumnMigration) Phase() ColumnMigrationPhase {
+	if phase, ok := m.phase.Load().(ColumnMigrationPhase); ok {
+		return phase
+	}
+	return ColumnMigrationPhaseOld
+}
+
+func (m *ColumnMigration) SetPhase(phase ColumnMigrationPhase) {
+	m.phase.Store(phase)
+}
+
+// ReadColumn は現在の段階で値を読む列を返す
+func (m *ColumnMigration) ReadColumn() string {
+	if m.Phase().ReadsNew() {
+		return m.NewColumn
+	}
+	return m.OldColumn
+}
+
+// RecordReads は読み込み時に比べた行の数と、新列が旧列から求めた値と異なった行を記録する
+func (m *ColumnMigration) RecordReads(compared int, mismatchedIDs []int64) {
+	m.readsCompared.Add(int64(compared))
+	m.readMismatches.Add(int64(len(mismatchedIDs)))
+	if len(mismatchedIDs) > 0 {
+		m.lastMismatchID.Store(mismatchedIDs[len(mismatchedIDs)-1])
+	}
+}
+
+// Status は現在の段階と読み込み時の比較の件数を返す
+func (m *ColumnMigration) Status() *ColumnMigrationStatus {
+	return &ColumnMigrationStatus{
+		Name:               m.Name,
+		Table:              m.Table,
+		OldColumn:          m.OldColumn,
+		NewColumn:          m.NewColumn,
+		Phase:              m.Phase(),
+		ReadsCompared:      m.readsCompared.Load(),
+		ReadMismatches:     m.readMismatches.Load(),
+		LastReadMismatchID: m.lastMismatchID.Load(),
+	}
+}
+
+// ParseColumnMigrationPhases は「items.purchase_date=dual_write」形式の設定を読み込む（定義のない移行はエラー）
+func ParseColumnMigrationPhases(entries []string) (map[string]ColumnMigrationPhase, error) {
+	phases := make(map[string]ColumnMigrationPhase)
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		phase := ColumnMigrationPhase(strings.TrimSpace(value))
+		if !ok || !phase.IsValid() {
+			return nil, fmt.Errorf("invalid column migration %q: must be <name>=<phase> with phase old, dual_write, dual_read or new", entry)
+		}
+		if FindColumnMigration(name) == nil {
+			return nil, fmt.Errorf("invalid column migration %q: unknown migration %s", entry, name)
+		}
+		phases[name] = phase
+	}
+	return phases, nil
+}
+
+// ColumnMigrationStatus は列の移行の状態（管理者向け）
+type ColumnMigrationStatus struct {
+	Name      string               `json:"name"`
+	Table     string               `json:"table"`
+	OldColumn string               `json:"old_column"`
+	NewColumn string               `json:"new_column"`
+	Phase     ColumnMigrationPhase `json:"phase"`
+	// ReadsCompared, ReadMismatches は dual_read の段階で比べた行と不一致だった行の数（起動してから）
+	ReadsCompared      int64 `json:"reads_compared"`
+	ReadMismatches     int64 `json:"read_mismatches"`
+	LastReadMismatchID int64 `json:"last_read_mismatch_id,omitempty"`
+	// LastBackfill は最後に実行したバックフィルの結果（起動してから実行していない場合は null）
+	LastBackfill *ColumnBackfillResult `json:"last_backfill"`
+}
+
+// MaxColumnMismatchIDs はバックフィルの結果に含める不一致の行の ID の最大件数
+const MaxColumnMismatchIDs = 100
+
+// ColumnBackfillResult は既存の行の新列を旧列から埋め、埋めた範囲を比べて検証した結果
+type ColumnBackfillResult struct {
+	// Scanned は対象にした行の数、Updated は新列を書き換えた行の数
+	Scanned int64 `json:"scanned"`
+	Updated int64 `json:"updated"`
+	// Mismatches は書き換えた後の検証で新列が旧列から求めた値と異なった行の数（二重書き込みの漏れなどで発生する）
+	Mismatches    int64      `json:"mismatches"`
+	MismatchIDs   []int64    `json:"mismatch_ids"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at"`
+	LastScannedID int64      `json:"last_scanned_id"`
+	Error         string     `json:"error,omitempty"`
+}
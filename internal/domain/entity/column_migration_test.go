@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withColumnMigrations はテストの間だけ列の移行の定義を差し替える
+func withColumnMigrations(t *testing.T, migrations ...*ColumnMigration) {
+	t.Helper()
+	saved := ColumnMigrations
+	ColumnMigrations = migrations
+	t.Cleanup(func() { ColumnMigrations = saved })
+}
+
+func TestColumnMigrationPhase(t *testing.T) {
+	tests := []struct {
+		phase                             ColumnMigrationPhase
+		writesNew, readsNew, comparesRead bool
+	}{
+		{ColumnMigrationPhaseOld, false, false, false},
+		{ColumnMigrationPhaseDualWrite, true, false, false},
+		{ColumnMigrationPhaseDualRead, true, false, true},
+		{ColumnMigrationPhaseNew, true, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.phase), func(t *testing.T) {
+			assert.True(t, tt.phase.IsValid())
+			assert.Equal(t, tt.writesNew, tt.phase.WritesNew())
+			assert.Equal(t, tt.readsNew, tt.phase.ReadsNew())
+			assert.Equal(t, tt.comparesRead, tt.phase.ComparesReads())
+		})
+	}
+	assert.False(t, ColumnMigrationPhase("backfill").IsValid())
+}
+
+func TestColumnMigration(t *testing.T) {
+	m := &ColumnMigration{Name: "items.purchased_on", Table: "items", OldColumn: "purchase_date_text", NewColumn: "purchased_on"}
+
+	// 段階を設定するまでは旧列を読む
+	assert.Equal(t, ColumnMigrationPhaseOld, m.Phase())
+	assert.Equal(t, "purchase_date_text", m.ReadColumn())
+	m.SetPhase(ColumnMigrationPhaseNew)
+	assert.Equal(t, "purchased_on", m.ReadColumn())
+
+	m.RecordReads(3, nil)
+	m.RecordReads(2, []int64{4, 7})
+	status := m.Status()
+	assert.Equal(t, ColumnMigrationPhaseNew, status.Phase)
+	assert.Equal(t, int64(5), status.ReadsCompared)
+	assert.Equal(t, int64(2), status.ReadMismatches)
+	assert.Equal(t, int64(7), status.LastReadMismatchID)
+}
+
+func TestParseColumnMigrationPhases(t *testing.T) {
+	withColumnMigrations(t,
+		&ColumnMigration{Name: "items.purchased_on"},
+		&ColumnMigration{Name: "item_sales.sold_amount"},
+	)
+
+	t.Run("正常系", func(t *testing.T) {
+		phases, err := ParseColumnMigrationPhases([]string{" items.purchased_on = dual_read ", "", "item_sales.sold_amount=old"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]ColumnMigrationPhase{
+			"items.purchased_on":     ColumnMigrationPhaseDualRead,
+			"item_sales.sold_amount": ColumnMigrationPhaseOld,
+		}, phases)
+	})
+
+	t.Run("正常系: 指定なし", func(t *testing.T) {
+		phases, err := ParseColumnMigrationPhases(nil)
+		require.NoError(t, err)
+		assert.Empty(t, phases)
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		for _, entries := range [][]string{
+			{"items.purchased_on"},
+			{"items.purchased_on=backfill"},
+			{"items.unknown=dual_write"},
+		} {
+			_, err := ParseColumnMigrationPhases(entries)
+			assert.Error(t, err, entries)
+		}
+	})
+}
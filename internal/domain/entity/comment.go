@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// コメント本文の最大文字数
+const MaxCommentLength = 2000
+
+// Comment はアイテムに付けたコメント
+type Comment struct {
+	ID       int64 `json:"id"`
+	ItemID   int64 `json:"item_id"`
+	AuthorID int64 `json:"author_id"`
+	// AuthorEmail は表示用の投稿者のメールアドレス（読み込み時に設定する）
+	AuthorEmail string    `json:"author_email"`
+	Body        string    `json:"body"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// mentionPattern は本文中の "@user@example.com" 形式のメンションに一致する
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w.%+\-@])@([\w.%+\-]+@[\w\-]+(?:\.[\w\-]+)*\.[A-Za-z]{2,})`)
+
+func NewComment(itemID, authorID int64, body string) (*Comment, error) {
+	comment := &Comment{
+		ItemID:    itemID,
+		AuthorID:  authorID,
+		Body:      strings.TrimSpace(body),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (c *Comment) Validate() error {
+	if c.Body == "" {
+		return errors.New("body is required")
+	}
+	if utf8.RuneCountInString(c.Body) > MaxCommentLength {
+		return errors.New("body must be 2000 characters or less")
+	}
+	return nil
+}
+
+// Edit は本文を変更する
+func (c *Comment) Edit(body string) error {
+	c.Body = strings.TrimSpace(body)
+	c.UpdatedAt = time.Now()
+	return c.Validate()
+}
+
+// MentionedEmails は本文でメンションされたメールアドレスを正規化・重複排除して出現順に返す
+func (c *Comment) MentionedEmails() []string {
+	var emails []string
+	seen := make(map[string]bool)
+	for _, m := range mentionPattern.FindAllStringSubmatch(c.Body, -1) {
+		email := NormalizeEmail(m[1])
+		if !seen[email] {
+			seen[email] = true
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComment(t *testing.T) {
+	comment, err := NewComment(1, 2, "  売るか相談したい  ")
+	require.NoError(t, err)
+	assert.Equal(t, "売るか相談したい", comment.Body)
+
+	_, err = NewComment(1, 2, " ")
+	assert.ErrorContains(t, err, "body is required")
+
+	_, err = NewComment(1, 2, strings.Repeat("あ", MaxCommentLength+1))
+	assert.ErrorContains(t, err, "2000 characters or less")
+}
+
+func TestComment_MentionedEmails(t *testing.T) {
+	comment := &Comment{Body: "@Alice@Example.com さんと@bob@example.co.jp、(@alice@example.com) 連絡先は carol@example.com です。"}
+
+	assert.Equal(t, []string{"alice@example.com", "bob@example.co.jp"}, comment.MentionedEmails())
+	assert.Empty(t, (&Comment{Body: "メンションなし"}).MentionedEmails())
+}
@@ -0,0 +1,122 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ConsignmentStatus は委託の状態
+type ConsignmentStatus string
+
+const (
+	// ConsignmentStatusActive は委託品として預かっている
+	ConsignmentStatusActive ConsignmentStatus = "active"
+	// ConsignmentStatusSold は委託品が売れた
+	ConsignmentStatusSold ConsignmentStatus = "sold"
+	// ConsignmentStatusReturned は委託品を委託者に返却した
+	ConsignmentStatusReturned ConsignmentStatus = "returned"
+)
+
+// IsValid は定義済みの状態かを判定する
+func (s ConsignmentStatus) IsValid() bool {
+	switch s {
+	case ConsignmentStatusActive, ConsignmentStatusSold, ConsignmentStatusReturned:
+		return true
+	}
+	return false
+}
+
+// Consignment は販売を委託されて預かっているアイテムの契約内容（アイテムごとに1件）
+type Consignment struct {
+	ID               int64  `json:"id"`
+	ItemID           int64  `json:"item_id"`
+	ConsignorName    string `json:"consignor_name"`
+	ConsignorContact string `json:"consignor_contact"`
+	// AgreedPrice は委託者と合意した販売価格（円）
+	AgreedPrice int `json:"agreed_price"`
+	// CommissionRate は販売価格に対する手数料率（%）
+	CommissionRate float64           `json:"commission_rate"`
+	Deadline       string            `json:"deadline,omitempty"` // YYYY-MM-DD 形式（空は期限なし）
+	Status         ConsignmentStatus `json:"status"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+
+	// Invoice は販売済みにするときに発行した請求書（発行したリクエストのレスポンスのみ）
+	Invoice *Invoice `json:"invoice"`
+}
+
+func NewConsignment(itemID int64, consignorName, consignorContact string, agreedPrice int, commissionRate float64, deadline string, status ConsignmentStatus) (*Consignment, error) {
+	if status == "" {
+		status = ConsignmentStatusActive
+	}
+	consignment := &Consignment{
+		ItemID:           itemID,
+		ConsignorName:    strings.TrimSpace(consignorName),
+		ConsignorContact: strings.TrimSpace(consignorContact),
+		AgreedPrice:      agreedPrice,
+		CommissionRate:   commissionRate,
+		Deadline:         strings.TrimSpace(deadline),
+		Status:           status,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := consignment.Validate(); err != nil {
+		return nil, err
+	}
+
+	return consignment, nil
+}
+
+func (c *Consignment) Validate() error {
+	var errs []string
+
+	if c.ConsignorName == "" {
+		errs = append(errs, "consignor_name is required")
+	} else if utf8.RuneCountInString(c.ConsignorName) > 100 {
+		errs = append(errs, "consignor_name must be 100 characters or less")
+	}
+
+	if utf8.RuneCountInString(c.ConsignorContact) > 255 {
+		errs = append(errs, "consignor_contact must be 255 characters or less")
+	}
+
+	if c.AgreedPrice < 0 {
+		errs = append(errs, "agreed_price must be 0 or greater")
+	} else if c.AgreedPrice > MaxPrice {
+		errs = append(errs, fmt.Sprintf("agreed_price must be %d or less", MaxPrice))
+	}
+
+	if math.IsNaN(c.CommissionRate) || c.CommissionRate < 0 || c.CommissionRate > 100 {
+		errs = append(errs, "commission_rate must be between 0 and 100")
+	}
+
+	if c.Deadline != "" && !isValidDateFormat(c.Deadline) {
+		errs = append(errs, "deadline must be in YYYY-MM-DD format")
+	}
+
+	if !c.Status.IsValid() {
+		errs = append(errs, "status must be one of: active, sold, returned")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// Commission は合意した販売価格で売れた場合の手数料（円未満は四捨五入）
+func (c *Consignment) Commission() int {
+	return int(math.Round(float64(c.AgreedPrice) * c.CommissionRate / 100))
+}
+
+// IsOverdue は預かり中のまま期限を過ぎているかを判定する（期限当日は含まない）
+func (c *Consignment) IsOverdue(now time.Time) bool {
+	// YYYY-MM-DD 形式同士は文字列比較で前後関係を判定できる
+	return c.Status == ConsignmentStatusActive && c.Deadline != "" && c.Deadline < now.Format("2006-01-02")
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConsignment(t *testing.T) {
+	consignment, err := NewConsignment(1, "  佐藤  ", "", 500000, 15, "2024-06-30", "")
+	require.NoError(t, err)
+	assert.Equal(t, "佐藤", consignment.ConsignorName)
+	assert.Equal(t, ConsignmentStatusActive, consignment.Status)
+
+	_, err = NewConsignment(1, "", "", -1, 120, "2024/06/30", "lost")
+	assert.EqualError(t, err, "consignor_name is required, agreed_price must be 0 or greater, "+
+		"commission_rate must be between 0 and 100, deadline must be in YYYY-MM-DD format, status must be one of: active, sold, returned")
+}
+
+func TestConsignment_Commission(t *testing.T) {
+	assert.Equal(t, 75000, (&Consignment{AgreedPrice: 500000, CommissionRate: 15}).Commission())
+	assert.Equal(t, 13, (&Consignment{AgreedPrice: 100, CommissionRate: 12.5}).Commission())
+}
+
+func TestConsignment_IsOverdue(t *testing.T) {
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local)
+
+	assert.True(t, (&Consignment{Status: ConsignmentStatusActive, Deadline: "2024-06-30"}).IsOverdue(now))
+	assert.False(t, (&Consignment{Status: ConsignmentStatusActive, Deadline: "2024-07-01"}).IsOverdue(now))
+	assert.False(t, (&Consignment{Status: ConsignmentStatusActive}).IsOverdue(now))
+	assert.False(t, (&Consignment{Status: ConsignmentStatusSold, Deadline: "2024-06-30"}).IsOverdue(now))
+}
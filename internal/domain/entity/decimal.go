@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal は固定小数点の10進数（Units × 10^-Scale。123.45 は Units 12345・Scale 2）。
+// JSON では数値（123.45）として読み書きし、float64 を経由しないため丸めの誤差がない
+type Decimal struct {
+	Units int64
+	Scale int
+}
+
+// 小数点以下の最大の桁数（int64 の桁数）
+const maxDecimalScale = 18
+
+var decimalPattern = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// ParseDecimal は「123」「-0.5」「123.45」の形式の10進数を読み込む（指数表記は受け付けない）
+func ParseDecimal(s string) (Decimal, error) {
+	if !decimalPattern.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	digits, fraction, _ := strings.Cut(s, ".")
+	if len(fraction) > maxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal %q has too many decimal places", s)
+	}
+	units, err := strconv.ParseInt(digits+fraction, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("decimal %q is out of range", s)
+	}
+	return Decimal{Units: units, Scale: len(fraction)}, nil
+}
+
+// String は「123」「123.45」の形式にする（Scale の桁数を省略しない）
+func (d Decimal) String() string {
+	if d.Scale <= 0 {
+		return strconv.FormatInt(d.Units, 10)
+	}
+	sign := ""
+	units := strconv.FormatUint(uint64(d.Units), 10)
+	if d.Units < 0 {
+		sign = "-"
+		units = strconv.FormatUint(-uint64(d.Units), 10)
+	}
+	if len(units) <= d.Scale {
+		units = strings.Repeat("0", d.Scale-len(units)+1) + units
+	}
+	point := len(units) - d.Scale
+	return sign + units[:point] + "." + units[point:]
+}
+
+// Rescale は小数点以下 scale 桁の単位の整数にする（USD の 123.45 を 2 桁にすると 12345）。
+// 端数が出る場合や int64 の範囲を超える場合は ok が false になる
+func (d Decimal) Rescale(scale int) (units int64, ok bool) {
+	if scale-d.Scale > maxDecimalScale || d.Scale-scale > maxDecimalScale {
+		return 0, false
+	}
+	if scale >= d.Scale {
+		return MulAmount(d.Units, int64(math.Pow10(scale-d.Scale)))
+	}
+	divisor := int64(math.Pow10(d.Scale - scale))
+	if d.Units%divisor != 0 {
+		return 0, false
+	}
+	return d.Units / divisor, true
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON は JSON の数値を読み込む（null は変更しない）
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		return errors.New("decimal must be a JSON number")
+	}
+	v, err := ParseDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
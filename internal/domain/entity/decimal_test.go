@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Decimal
+		wantErr  bool
+	}{
+		{input: "1500000", expected: Decimal{Units: 1500000}},
+		{input: "123.45", expected: Decimal{Units: 12345, Scale: 2}},
+		{input: "-0.5", expected: Decimal{Units: -5, Scale: 1}},
+		{input: "123.450", expected: Decimal{Units: 123450, Scale: 3}},
+		{input: "1e3", wantErr: true},
+		{input: ".5", wantErr: true},
+		{input: "+1", wantErr: true},
+		{input: "99999999999999999999", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			d, err := ParseDecimal(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestDecimal_String(t *testing.T) {
+	assert.Equal(t, "1500000", Decimal{Units: 1500000}.String())
+	assert.Equal(t, "123.45", Decimal{Units: 12345, Scale: 2}.String())
+	assert.Equal(t, "0.05", Decimal{Units: 5, Scale: 2}.String())
+	assert.Equal(t, "-0.05", Decimal{Units: -5, Scale: 2}.String())
+	assert.Equal(t, "21474836.47", Money{Amount: MaxPrice, Currency: CurrencyUSD}.Decimal().String())
+}
+
+func TestDecimal_Rescale(t *testing.T) {
+	units, ok := Decimal{Units: 1234, Scale: 1}.Rescale(2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(12340), units)
+
+	units, ok = Decimal{Units: 123400, Scale: 3}.Rescale(2)
+	assert.True(t, ok)
+	assert.Equal(t, int64(12340), units)
+
+	_, ok = Decimal{Units: 12345, Scale: 3}.Rescale(2)
+	assert.False(t, ok)
+
+	_, ok = Decimal{Units: 1 << 62}.Rescale(2)
+	assert.False(t, ok)
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var v struct {
+		Price Decimal `json:"price"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"price":19.99}`), &v))
+	assert.Equal(t, Decimal{Units: 1999, Scale: 2}, v.Price)
+
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.Equal(t, `{"price":19.99}`, string(data))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"price":"19.99"}`), &v))
+}
+
+func TestMoneyFromDecimal(t *testing.T) {
+	m, err := MoneyFromDecimal(Decimal{Units: 1999, Scale: 2}, "usd")
+	require.NoError(t, err)
+	assert.Equal(t, NewMoney(1999, "USD"), m)
+
+	// 整数は従来の円建ての金額と同じ
+	m, err = MoneyFromDecimal(Decimal{Units: 1500000}, "")
+	require.NoError(t, err)
+	assert.Equal(t, JPY(1500000), m)
+
+	_, err = MoneyFromDecimal(Decimal{Units: 15, Scale: 1}, "JPY")
+	assert.EqualError(t, err, "must have at most 0 decimal places for JPY")
+
+	// int に収まらない金額は範囲の検証で拒否できる値にする
+	m, err = MoneyFromDecimal(Decimal{Units: 1 << 62}, "USD")
+	require.NoError(t, err)
+	assert.Greater(t, m.Amount, MaxPrice)
+}
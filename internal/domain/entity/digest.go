@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// DigestFrequency はダイジェストメールの配信頻度
+type DigestFrequency string
+
+const (
+	// DigestFrequencyOff は配信しない（配信停止を含む）
+	DigestFrequencyOff     DigestFrequency = "off"
+	DigestFrequencyWeekly  DigestFrequency = "weekly"
+	DigestFrequencyMonthly DigestFrequency = "monthly"
+)
+
+// IsValid は定義済みの配信頻度かを判定する
+func (f DigestFrequency) IsValid() bool {
+	switch f {
+	case DigestFrequencyOff, DigestFrequencyWeekly, DigestFrequencyMonthly:
+		return true
+	}
+	return false
+}
+
+// DigestSubscription はユーザーのダイジェストメールの配信設定（ユーザーごとに1件、未設定は配信しない）
+type DigestSubscription struct {
+	UserID    int64           `json:"user_id"`
+	Email     string          `json:"email"`
+	Frequency DigestFrequency `json:"frequency"`
+	// LastSentAt は最後に配信した日時（次の配信の集計期間の開始）
+	LastSentAt *time.Time `json:"last_sent_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func NewDigestSubscription(userID int64, frequency DigestFrequency) (*DigestSubscription, error) {
+	subscription := &DigestSubscription{
+		UserID:    userID,
+		Frequency: frequency,
+		UpdatedAt: time.Now(),
+	}
+
+	if !frequency.IsValid() {
+		return nil, errors.New("frequency must be one of: off, weekly, monthly")
+	}
+
+	return subscription, nil
+}
+
+// PeriodStart は now に配信する場合の集計期間の開始（未配信の場合は1週間または1か月前）
+func (s *DigestSubscription) PeriodStart(now time.Time) time.Time {
+	if s.LastSentAt != nil {
+		return *s.LastSentAt
+	}
+	if s.Frequency == DigestFrequencyMonthly {
+		return now.AddDate(0, -1, 0)
+	}
+	return now.AddDate(0, 0, -7)
+}
+
+// IsDue は now の時点で配信する時期になっているかを判定する
+func (s *DigestSubscription) IsDue(now time.Time) bool {
+	if s.LastSentAt == nil {
+		return s.Frequency != DigestFrequencyOff
+	}
+	switch s.Frequency {
+	case DigestFrequencyWeekly:
+		return !now.Before(s.LastSentAt.AddDate(0, 0, 7))
+	case DigestFrequencyMonthly:
+		return !now.Before(s.LastSentAt.AddDate(0, 1, 0))
+	}
+	return false
+}
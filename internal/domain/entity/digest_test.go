@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDigestSubscription(t *testing.T) {
+	subscription, err := NewDigestSubscription(1, DigestFrequencyWeekly)
+	require.NoError(t, err)
+	assert.Equal(t, DigestFrequencyWeekly, subscription.Frequency)
+
+	_, err = NewDigestSubscription(1, "daily")
+	assert.EqualError(t, err, "frequency must be one of: off, weekly, monthly")
+}
+
+func TestDigestSubscription_IsDue(t *testing.T) {
+	now := time.Date(2024, 7, 8, 9, 0, 0, 0, time.Local)
+	lastWeek := now.AddDate(0, 0, -7)
+	yesterday := now.AddDate(0, 0, -1)
+	lastMonth := now.AddDate(0, -1, 0)
+
+	assert.True(t, (&DigestSubscription{Frequency: DigestFrequencyWeekly}).IsDue(now))
+	assert.True(t, (&DigestSubscription{Frequency: DigestFrequencyWeekly, LastSentAt: &lastWeek}).IsDue(now))
+	assert.False(t, (&DigestSubscription{Frequency: DigestFrequencyWeekly, LastSentAt: &yesterday}).IsDue(now))
+	assert.False(t, (&DigestSubscription{Frequency: DigestFrequencyMonthly, LastSentAt: &lastWeek}).IsDue(now))
+	assert.True(t, (&DigestSubscription{Frequency: DigestFrequencyMonthly, LastSentAt: &lastMonth}).IsDue(now))
+	assert.False(t, (&DigestSubscription{Frequency: DigestFrequencyOff}).IsDue(now))
+	assert.False(t, (&DigestSubscription{Frequency: DigestFrequencyOff, LastSentAt: &lastMonth}).IsDue(now))
+}
+
+func TestDigestSubscription_PeriodStart(t *testing.T) {
+	now := time.Date(2024, 7, 8, 9, 0, 0, 0, time.Local)
+	yesterday := now.AddDate(0, 0, -1)
+
+	assert.Equal(t, now.AddDate(0, 0, -7), (&DigestSubscription{Frequency: DigestFrequencyWeekly}).PeriodStart(now))
+	assert.Equal(t, now.AddDate(0, -1, 0), (&DigestSubscription{Frequency: DigestFrequencyMonthly}).PeriodStart(now))
+	assert.Equal(t, yesterday, (&DigestSubscription{Frequency: DigestFrequencyMonthly, LastSentAt: &yesterday}).PeriodStart(now))
+}
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"strings"
+	"time"
+)
+
+// DocumentTextMaxLength は保存する抽出テキストの最大文字数（超えた分は切り捨てる）
+const DocumentTextMaxLength = 20000
+
+// DocumentText は購入書類（レシート・鑑定書などを撮影した画像）から文字認識で抽出したテキスト
+type DocumentText struct {
+	ItemID    int64
+	ImageID   int64
+	Text      string
+	IndexedAt time.Time
+}
+
+// NewDocumentText は image から抽出したテキストを作成する。前後の空白を除き、DocumentTextMaxLength 文字までにする
+func NewDocumentText(image *ItemImage, text string, now time.Time) *DocumentText {
+	text = strings.TrimSpace(text)
+	if runes := []rune(text); len(runes) > DocumentTextMaxLength {
+		text = string(runes[:DocumentTextMaxLength])
+	}
+	return &DocumentText{ItemID: image.ItemID, ImageID: image.ID, Text: text, IndexedAt: now}
+}
+
+// DocumentMatch は購入書類のテキストが検索キーワードに一致したアイテム
+type DocumentMatch struct {
+	ItemID int64
+	// Score は関連度（同じ検索結果の中での比較用）
+	Score float64
+	// Snippet は一致した箇所の前後のテキスト、Spans は Snippet の中で一致した範囲
+	Snippet string
+	Spans   []TextSpan
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+const (
+	// DraftSessionMaxImages は下書きに追加できる画像の最大数
+	DraftSessionMaxImages = 10
+	// DraftSessionMaxFieldsSize は下書きの項目の JSON の最大バイト数
+	DraftSessionMaxFieldsSize = 64 << 10
+)
+
+// DraftSessionRequiredFields は登録に必要な項目（CreateItemInput の必須項目と、既定値のないカテゴリーの brand）
+var DraftSessionRequiredFields = []string{"name", "category", "brand", "purchase_price", "purchase_date"}
+
+// DraftSession は複数の画面に分けて入力しているアイテムの下書き。
+// 項目は登録時の入力（CreateItemInput）の JSON のまま保持し、登録するときにまとめて検証する
+type DraftSession struct {
+	// ID は推測されにくいランダムな識別子
+	ID     string
+	UserID int64
+	// Fields は入力済みの項目（項目名 → JSON の値）
+	Fields map[string]json.RawMessage
+	Images []DraftImage
+	// Version は更新のたびに増える（同時に届いた更新の検出に使う）
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// ExpiresAt を過ぎた下書きは参照できず、定期的に削除する（更新のたびに延長する）
+	ExpiresAt time.Time
+}
+
+// DraftImage は下書きに追加した画像（登録時にアイテムの画像にする）
+type DraftImage struct {
+	ID          string `json:"id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// StorageKey はストレージ上の保存先（公開しない）
+	StorageKey string    `json:"-"`
+	URL        string    `json:"url"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// IsExpired は有効期限を過ぎたかを判定する
+func (s *DraftSession) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// MissingFields は登録に必要なのに入力されていない項目を返す（空文字も未入力とする）。
+// ブランドの既定値があるカテゴリーでは brand は省略できる
+func (s *DraftSession) MissingFields() []string {
+	missing := []string{}
+	for _, name := range DraftSessionRequiredFields {
+		if name == "brand" && s.hasDefaultBrand() {
+			continue
+		}
+		value := bytes.TrimSpace(s.Fields[name])
+		if len(value) == 0 || string(value) == "null" || string(value) == `""` {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// hasDefaultBrand は入力したカテゴリーにブランドの既定値があるかを返す
+func (s *DraftSession) hasDefaultBrand() bool {
+	var category string
+	if err := json.Unmarshal(s.Fields["category"], &category); err != nil {
+		return false
+	}
+	c, ok := FindCategory(ResolveCategory(category))
+	return ok && c.Defaults.Brand != ""
+}
+
+// FindImage は ID の画像を返す
+func (s *DraftSession) FindImage(id string) (*DraftImage, bool) {
+	for i := range s.Images {
+		if s.Images[i].ID == id {
+			return &s.Images[i], true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON は未入力の必須項目を missing_fields に含める
+func (s DraftSession) MarshalJSON() ([]byte, error) {
+	fields := s.Fields
+	if fields == nil {
+		fields = map[string]json.RawMessage{}
+	}
+	images := s.Images
+	if images == nil {
+		images = []DraftImage{}
+	}
+	return json.Marshal(struct {
+		ID            string                     `json:"id"`
+		Fields        map[string]json.RawMessage `json:"fields"`
+		Images        []DraftImage               `json:"images"`
+		MissingFields []string                   `json:"missing_fields"`
+		CreatedAt     time.Time                  `json:"created_at"`
+		UpdatedAt     time.Time                  `json:"updated_at"`
+		ExpiresAt     time.Time                  `json:"expires_at"`
+	}{s.ID, fields, images, s.MissingFields(), s.CreatedAt, s.UpdatedAt, s.ExpiresAt})
+}
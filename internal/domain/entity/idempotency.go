@@ -0,0 +1,30 @@
+package entity
+
+import "time"
+
+const (
+	// IdempotencyKeyMaxLength は Idempotency-Key ヘッダーの最大の長さ
+	IdempotencyKeyMaxLength = 255
+	// IdempotencyKeyTTL は保存したレスポンスを再送に返す期間（過ぎたキーは新しいリクエストとして扱う）
+	IdempotencyKeyTTL = 24 * time.Hour
+)
+
+// IdempotencyRecord は Idempotency-Key を指定したリクエストとそのレスポンス。
+// 同じキーで再送されたリクエストは処理せず、保存したレスポンスを返す
+type IdempotencyRecord struct {
+	UserID int64
+	Key    string
+	// RequestHash はメソッド・パス・ボディのハッシュ（同じキーで異なるリクエストが送られたことを検出する）
+	RequestHash string
+	// Completed はレスポンスを保存済みか（false の間は最初のリクエストを処理中）
+	Completed   bool
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+}
+
+// IsExpired は保存期間を過ぎたかを判定する
+func (r *IdempotencyRecord) IsExpired(now time.Time) bool {
+	return now.Sub(r.CreatedAt) > IdempotencyKeyTTL
+}
@@ -0,0 +1,134 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultTaxRate は税率の指定がない場合の消費税率（%）
+const DefaultTaxRate = 10.0
+
+// 請求書の明細の最大数
+const maxInvoiceLines = 20
+
+// Invoice は売れたアイテムの請求書。金額は税抜きの明細の合計に消費税を加えたもの
+type Invoice struct {
+	ID int64 `json:"id"`
+	// Number は発行年ごとの連番の請求書番号（例: INV-2024-000001）。保存時に採番する
+	Number string `json:"number"`
+	// ItemID は売れたアイテム（アイテムの削除後は 0）
+	ItemID       int64         `json:"item_id,omitempty"`
+	UserID       int64         `json:"user_id"` // 発行したユーザー
+	BuyerName    string        `json:"buyer_name"`
+	BuyerAddress string        `json:"buyer_address,omitempty"`
+	Lines        []InvoiceLine `json:"lines"`
+	Subtotal     int           `json:"subtotal"`
+	TaxRate      float64       `json:"tax_rate"` // %
+	Tax          int           `json:"tax"`
+	Total        int           `json:"total"`
+	IssuedAt     time.Time     `json:"issued_at"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+// InvoiceLine は請求書の明細（金額は税抜き）
+type InvoiceLine struct {
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int    `json:"unit_price"`
+	Amount      int    `json:"amount"`
+}
+
+// NewInvoice は明細から小計・消費税・合計を計算した請求書を作成する（番号は保存時に採番する）
+func NewInvoice(userID, itemID int64, buyerName, buyerAddress string, lines []InvoiceLine, taxRate float64, issuedAt time.Time) (*Invoice, error) {
+	invoice := &Invoice{
+		ItemID:       itemID,
+		UserID:       userID,
+		BuyerName:    strings.TrimSpace(buyerName),
+		BuyerAddress: strings.TrimSpace(buyerAddress),
+		Lines:        make([]InvoiceLine, len(lines)),
+		TaxRate:      taxRate,
+		IssuedAt:     issuedAt,
+		CreatedAt:    time.Now(),
+	}
+	// 金額は桁あふれしないよう int64 で計算し、上限を超えた場合は Validate でエラーにする
+	var subtotal int64
+	for i, line := range lines {
+		line.Description = strings.TrimSpace(line.Description)
+		amount, ok := MulAmount(int64(line.UnitPrice), int64(line.Quantity))
+		if !ok {
+			amount = math.MaxInt64
+		}
+		line.Amount = int(amount)
+		invoice.Lines[i] = line
+		subtotal = AddAmount(subtotal, amount)
+	}
+	invoice.Subtotal = int(subtotal)
+	// 消費税は請求書ごとに1回計算し、円未満は切り捨てる
+	if subtotal <= MaxPrice {
+		invoice.Tax = int(math.Floor(float64(invoice.Subtotal) * taxRate / 100))
+	}
+	invoice.Total = int(AddAmount(subtotal, int64(invoice.Tax)))
+
+	if err := invoice.Validate(); err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+func (i *Invoice) Validate() error {
+	var errs []string
+
+	if i.BuyerName == "" {
+		errs = append(errs, "buyer_name is required")
+	} else if utf8.RuneCountInString(i.BuyerName) > 100 {
+		errs = append(errs, "buyer_name must be 100 characters or less")
+	}
+
+	if utf8.RuneCountInString(i.BuyerAddress) > 255 {
+		errs = append(errs, "buyer_address must be 255 characters or less")
+	}
+
+	if len(i.Lines) == 0 {
+		errs = append(errs, "at least one line is required")
+	} else if len(i.Lines) > maxInvoiceLines {
+		errs = append(errs, fmt.Sprintf("lines must be %d or fewer", maxInvoiceLines))
+	}
+	for n, line := range i.Lines {
+		if line.Description == "" {
+			errs = append(errs, fmt.Sprintf("lines[%d].description is required", n))
+		} else if utf8.RuneCountInString(line.Description) > 200 {
+			errs = append(errs, fmt.Sprintf("lines[%d].description must be 200 characters or less", n))
+		}
+		if line.Quantity < 1 {
+			errs = append(errs, fmt.Sprintf("lines[%d].quantity must be 1 or greater", n))
+		}
+		if line.UnitPrice < 0 {
+			errs = append(errs, fmt.Sprintf("lines[%d].unit_price must be 0 or greater", n))
+		} else if line.UnitPrice > MaxPrice {
+			errs = append(errs, fmt.Sprintf("lines[%d].unit_price must be %d or less", n, MaxPrice))
+		}
+	}
+	if i.Total > MaxPrice {
+		errs = append(errs, fmt.Sprintf("total must be %d or less", MaxPrice))
+	}
+
+	if math.IsNaN(i.TaxRate) || i.TaxRate < 0 || i.TaxRate > 100 {
+		errs = append(errs, "tax_rate must be between 0 and 100")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// InvoiceNumber は発行年と年ごとの連番から請求書番号を作る
+func InvoiceNumber(year, seq int) string {
+	return fmt.Sprintf("INV-%d-%06d", year, seq)
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInvoice(t *testing.T) {
+	issuedAt := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	invoice, err := NewInvoice(1, 2, "  山田 太郎  ", "", []InvoiceLine{
+		{Description: "ROLEX デイトナ", Quantity: 1, UnitPrice: 1500000},
+		{Description: "送料", Quantity: 1, UnitPrice: 1199},
+	}, DefaultTaxRate, issuedAt)
+	require.NoError(t, err)
+	assert.Equal(t, "山田 太郎", invoice.BuyerName)
+	assert.Equal(t, 1199, invoice.Lines[1].Amount)
+	assert.Equal(t, 1501199, invoice.Subtotal)
+	// 150119.9 円は切り捨てる
+	assert.Equal(t, 150119, invoice.Tax)
+	assert.Equal(t, 1651318, invoice.Total)
+
+	_, err = NewInvoice(1, 2, "", "", []InvoiceLine{{Quantity: 0, UnitPrice: -1}}, 120, issuedAt)
+	assert.EqualError(t, err, "buyer_name is required, lines[0].description is required, lines[0].quantity must be 1 or greater, "+
+		"lines[0].unit_price must be 0 or greater, tax_rate must be between 0 and 100")
+
+	_, err = NewInvoice(1, 2, "山田", "", nil, DefaultTaxRate, issuedAt)
+	assert.EqualError(t, err, "at least one line is required")
+
+	// 数量と単価の積や合計が桁あふれしても負の金額にならずに上限超過のエラーにする
+	_, err = NewInvoice(1, 2, "山田", "", []InvoiceLine{
+		{Description: "ROLEX デイトナ", Quantity: math.MaxInt, UnitPrice: MaxPrice},
+		{Description: "送料", Quantity: 1, UnitPrice: 1000},
+	}, DefaultTaxRate, issuedAt)
+	assert.EqualError(t, err, "total must be 2147483647 or less")
+
+	_, err = NewInvoice(1, 2, "山田", "", []InvoiceLine{{Description: "ROLEX デイトナ", Quantity: 1, UnitPrice: MaxPrice}}, DefaultTaxRate, issuedAt)
+	assert.EqualError(t, err, "total must be 2147483647 or less")
+}
+
+func TestInvoiceNumber(t *testing.T) {
+	assert.Equal(t, "INV-2024-000042", InvoiceNumber(2024, 42))
+}
@@ -1,32 +1,78 @@
 package entity
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            int64      `json:"id"`
+	UserID        int64      `json:"user_id"`
+	OrgID         int64      `json:"org_id,omitempty"` // 所有する組織（0 は UserID の個人のアイテム）
+	Name          string     `json:"name"`
+	Category      string     `json:"category"`
+	Brand         string     `json:"brand"`
+	PurchasePrice Money      `json:"-"`             // JSON では金額を purchase_price、通貨を purchase_currency に分ける
+	PurchaseDate  string     `json:"purchase_date"` // YYYY-MM-DD 形式
+	Visibility    Visibility `json:"visibility"`
+	Condition     Condition  `json:"condition"` // 未設定は null
+	// Status は所有状況（登録時は owned。ChangeStatus で変更する）
+	Status ItemStatus `json:"status"`
+	// CurrentValue は最新の評価日の評価額（評価額を記録したときに更新する。未記録は nil）
+	CurrentValue *Money `json:"-"`
+	// SerialNumber はシリアル番号（英字は大文字。ユーザーごとに一意。未設定は空で、JSON では null）
+	SerialNumber string `json:"serial_number"`
+	// Notes は自由記述のメモ（未設定は空で、JSON では null）
+	Notes string `json:"notes"`
+	// Attributes はカテゴリーごとの属性（未設定は空で、JSON では {}）
+	Attributes ItemAttributes `json:"attributes"`
+	// Version は更新のたびに増える版数（ETag として返し、更新時に If-Match で照合する）
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Thumbnails は先頭の画像のサムネイル（一覧表示用。画像がない場合は空）
+	Thumbnails []ImageThumbnail `json:"thumbnails"`
+	// Tags はアイテムに付けたタグ（名前順。タグがない場合は空）
+	Tags []string `json:"tags"`
+
+	// redacted は操作者の役割によって非表示にした項目（Redact で設定する）
+	redacted []string
 }
 
-// カテゴリー定義
-var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
+// 公開範囲の検証エラーのメッセージ
+const visibilityErrorMessage = "visibility must be one of: private, shared, public"
 
-func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
+// 名前・ブランドの最大文字数（バイト数ではなく文字数で数える。起動時に設定で変更できる）
+var (
+	ItemNameMaxLength  = 100
+	ItemBrandMaxLength = 100
+)
+
+// 購入日の方針（起動時に設定で変更できる）
+var (
+	// PurchaseDateAllowFuture は未来の購入日を許可するか（2203-01-15 のような入力ミスを防ぐため既定では許可しない）
+	PurchaseDateAllowFuture = false
+	// PurchaseDateMaxAgeYears は何年前までの購入日を許可するか（0 は制限しない）
+	PurchaseDateMaxAgeYears = 0
+)
+
+// 未来の日付かの判定は、利用者のタイムゾーンで今日の日付が拒否されないよう最も進んだタイムゾーン（UTC+14）の今日を基準にする
+const latestTimeZoneOffset = 14 * time.Hour
+
+func NewItem(name, category, brand string, purchasePrice Money, purchaseDate string) (*Item, error) {
 	item := &Item{
 		Name:          strings.TrimSpace(name),
-		Category:      strings.TrimSpace(category),
+		Category:      ResolveCategory(category),
 		Brand:         strings.TrimSpace(brand),
 		PurchasePrice: purchasePrice,
 		PurchaseDate:  strings.TrimSpace(purchaseDate),
+		Visibility:    VisibilityPrivate,
+		Status:        ItemStatusOwned,
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
 	}
@@ -38,49 +84,87 @@ func NewItem(name, category, brand string, purchasePrice int, purchaseDate strin
 	return item, nil
 }
 
-// アイテムフィールドのバリデーション
+// アイテムフィールドのバリデーション（エラーは項目ごとの domainErrors.ValidationErrors）
 func (i *Item) Validate() error {
-	var errs []string
+	var errs domainErrors.ValidationErrors
 
-	if i.Name == "" {
-		errs = append(errs, "name is required")
-	} else if len(i.Name) > 100 {
-		errs = append(errs, "name must be 100 characters or less")
+	if fe := validateName(i.Name); fe != nil {
+		errs = append(errs, *fe)
 	}
 
 	if i.Category == "" {
-		errs = append(errs, "category is required")
+		errs.Add("category", domainErrors.CodeRequired, "category is required")
 	} else if !isValidCategory(i.Category) {
-		errs = append(errs, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		errs.Add("category", domainErrors.CodeInvalidChoice, categoryErrorMessage())
 	}
 
-	if i.Brand == "" {
-		errs = append(errs, "brand is required")
-	} else if len(i.Brand) > 100 {
-		errs = append(errs, "brand must be 100 characters or less")
+	if fe := validateBrand(i.Brand); fe != nil {
+		errs = append(errs, *fe)
 	}
 
-	if i.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
-	}
+	errs = append(errs, validatePurchasePrice(i.PurchasePrice)...)
 
 	if i.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+		errs.Add("purchase_date", domainErrors.CodeRequired, "purchase_date is required")
 	} else if !isValidDateFormat(i.PurchaseDate) {
-		errs = append(errs, "purchase_date must be in YYYY-MM-DD format")
+		errs.Add("purchase_date", domainErrors.CodeInvalidFormat, "purchase_date must be in YYYY-MM-DD format")
+	} else if fe := validatePurchaseDatePolicy(i.PurchaseDate, time.Now()); fe != nil {
+		errs = append(errs, *fe)
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
+	if i.Visibility != "" && !IsValidVisibility(i.Visibility) {
+		errs.Add("visibility", domainErrors.CodeInvalidChoice, visibilityErrorMessage)
 	}
 
+	if i.Condition != ConditionUnknown && !IsValidCondition(i.Condition) {
+		errs.Add("condition", domainErrors.CodeInvalidChoice, conditionErrorMessage)
+	}
+
+	if i.Status != "" && !IsValidItemStatus(i.Status) {
+		errs.Add("status", domainErrors.CodeInvalidChoice, statusErrorMessage)
+	}
+
+	if fe := validateSerialNumber(i.SerialNumber); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	if fe := validateNotes(i.Notes); fe != nil {
+		errs = append(errs, *fe)
+	}
+
+	return errs.Err()
+}
+
+// SetVisibility は公開範囲を変更する
+func (i *Item) SetVisibility(visibility string) error {
+	v := Visibility(strings.TrimSpace(visibility))
+	if !IsValidVisibility(v) {
+		return domainErrors.ValidationErrors{{Field: "visibility", Code: domainErrors.CodeInvalidChoice, Message: visibilityErrorMessage}}
+	}
+	if v != i.Visibility {
+		i.Visibility = v
+		i.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// SetCondition は状態を変更する（空文字は未設定に戻す）
+func (i *Item) SetCondition(condition string) error {
+	c := Condition(strings.TrimSpace(condition))
+	if c != ConditionUnknown && !IsValidCondition(c) {
+		return domainErrors.ValidationErrors{{Field: "condition", Code: domainErrors.CodeInvalidChoice, Message: conditionErrorMessage}}
+	}
+	if c != i.Condition {
+		i.Condition = c
+		i.UpdatedAt = time.Now()
+	}
 	return nil
 }
 
 // アイテムフィールドのアップデート
-func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseDate string) error {
+func (i *Item) Update(name, category, brand string, purchasePrice Money, purchaseDate string) error {
 	i.Name = strings.TrimSpace(name)
-	i.Category = strings.TrimSpace(category)
+	i.Category = ResolveCategory(category)
 	i.Brand = strings.TrimSpace(brand)
 	i.PurchasePrice = purchasePrice
 	i.PurchaseDate = strings.TrimSpace(purchaseDate)
@@ -92,14 +176,14 @@ func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseD
 // UpdatePartial performs a partial update on the item, only updating provided fields.
 // Immutable fields (ID, CreatedAt, Category, PurchaseDate) are preserved.
 // Only the provided fields are validated.
-func (i *Item) UpdatePartial(name, brand *string, purchasePrice *int) error {
-	var errs []string
+func (i *Item) UpdatePartial(name, brand *string, purchasePrice *Money) error {
+	var errs domainErrors.ValidationErrors
 
 	// Update name if provided
 	if name != nil {
 		trimmedName := strings.TrimSpace(*name)
-		if err := validateName(trimmedName); err != nil {
-			errs = append(errs, err.Error())
+		if fe := validateName(trimmedName); fe != nil {
+			errs = append(errs, *fe)
 		} else {
 			i.Name = trimmedName
 		}
@@ -108,17 +192,17 @@ func (i *Item) UpdatePartial(name, brand *string, purchasePrice *int) error {
 	// Update brand if provided
 	if brand != nil {
 		trimmedBrand := strings.TrimSpace(*brand)
-		if err := validateBrand(trimmedBrand); err != nil {
-			errs = append(errs, err.Error())
+		if fe := validateBrand(trimmedBrand); fe != nil {
+			errs = append(errs, *fe)
 		} else {
 			i.Brand = trimmedBrand
 		}
 	}
 
-	// Update purchase_price if provided
+	// Update purchase_price and purchase_currency if provided
 	if purchasePrice != nil {
-		if err := validatePurchasePrice(*purchasePrice); err != nil {
-			errs = append(errs, err.Error())
+		if priceErrs := validatePurchasePrice(*purchasePrice); len(priceErrs) > 0 {
+			errs = append(errs, priceErrs...)
 		} else {
 			i.PurchasePrice = *purchasePrice
 		}
@@ -129,51 +213,161 @@ func (i *Item) UpdatePartial(name, brand *string, purchasePrice *int) error {
 		i.UpdatedAt = time.Now()
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
-	}
-
-	return nil
+	return errs.Err()
 }
 
 // validateName validates the name field
-func validateName(name string) error {
+func validateName(name string) *domainErrors.FieldError {
 	if name == "" {
-		return errors.New("name is required")
+		return &domainErrors.FieldError{Field: "name", Code: domainErrors.CodeRequired, Message: "name is required"}
 	}
-	if len(name) > 100 {
-		return errors.New("name must be 100 characters or less")
+	if utf8.RuneCountInString(name) > ItemNameMaxLength {
+		return &domainErrors.FieldError{Field: "name", Code: domainErrors.CodeTooLong, Message: fmt.Sprintf("name must be %d characters or less", ItemNameMaxLength)}
 	}
 	return nil
 }
 
 // validateBrand validates the brand field
-func validateBrand(brand string) error {
+func validateBrand(brand string) *domainErrors.FieldError {
 	if brand == "" {
-		return errors.New("brand is required")
+		return &domainErrors.FieldError{Field: "brand", Code: domainErrors.CodeRequired, Message: "brand is required"}
 	}
-	if len(brand) > 100 {
-		return errors.New("brand must be 100 characters or less")
+	if utf8.RuneCountInString(brand) > ItemBrandMaxLength {
+		return &domainErrors.FieldError{Field: "brand", Code: domainErrors.CodeTooLong, Message: fmt.Sprintf("brand must be %d characters or less", ItemBrandMaxLength)}
 	}
 	return nil
 }
 
-// validatePurchasePrice validates the purchase_price field
-func validatePurchasePrice(price int) error {
-	if price < 0 {
-		return errors.New("purchase_price must be 0 or greater")
+// validatePurchasePrice validates the purchase_price and purchase_currency fields
+func validatePurchasePrice(price Money) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
+	if price.Amount < 0 {
+		errs.Add("purchase_price", domainErrors.CodeOutOfRange, "purchase_price must be 0 or greater")
+	} else if price.Amount > MaxPrice {
+		errs.Add("purchase_price", domainErrors.CodeOutOfRange, fmt.Sprintf("purchase_price must be %s or less", Money{Amount: MaxPrice, Currency: price.Currency}.Decimal()))
+	}
+	if !price.Currency.IsValid() {
+		errs.Add("purchase_currency", domainErrors.CodeInvalidChoice, currencyErrorMessage)
 	}
+	return errs
+}
+
+// itemFields は JSON の変換で Item のメソッドを引き継がないための型
+type itemFields Item
+
+// itemJSON は Item の JSON 表現。円建て以外も扱えるよう purchase_currency を加え、
+// purchase_price は補助単位を小数にした10進数とする（円建ては従来どおり整数）。
+// 非表示にした項目は null にして redacted_fields に含める
+type itemJSON struct {
+	itemFields
+	PurchasePrice    *Decimal `json:"purchase_price"`
+	PurchaseCurrency Currency `json:"purchase_currency"`
+	PurchaseDate     *string  `json:"purchase_date"`
+	// CurrentValue と CurrentValueCurrency は評価額（未記録は null）
+	CurrentValue         *Decimal  `json:"current_value"`
+	CurrentValueCurrency *Currency `json:"current_value_currency"`
+	SerialNumber         *string   `json:"serial_number"`
+	Notes                *string   `json:"notes"`
+	RedactedFields       []string  `json:"redacted_fields"`
+}
+
+func (i Item) toJSON() itemJSON {
+	currency := i.PurchasePrice.Currency
+	if currency == "" {
+		currency = CurrencyJPY
+	}
+	price := Money{Amount: i.PurchasePrice.Amount, Currency: currency}
+	if i.Thumbnails == nil {
+		i.Thumbnails = []ImageThumbnail{}
+	}
+	if i.Tags == nil {
+		i.Tags = []string{}
+	}
+	if i.Attributes == nil {
+		i.Attributes = ItemAttributes{}
+	}
+	v := itemJSON{itemFields: itemFields(i), PurchaseCurrency: currency, RedactedFields: i.RedactedFields()}
+	if v.RedactedFields == nil {
+		v.RedactedFields = []string{}
+	}
+	if !i.IsRedacted(ItemFieldPurchasePrice) {
+		decimal := price.Decimal()
+		v.PurchasePrice = &decimal
+	}
+	if !i.IsRedacted(ItemFieldPurchaseDate) {
+		v.PurchaseDate = &i.PurchaseDate
+	}
+	if i.CurrentValue != nil {
+		decimal := i.CurrentValue.Decimal()
+		v.CurrentValue = &decimal
+		v.CurrentValueCurrency = &i.CurrentValue.Currency
+	}
+	if i.SerialNumber != "" {
+		v.SerialNumber = &i.SerialNumber
+	}
+	if i.Notes != "" {
+		v.Notes = &i.Notes
+	}
+	return v
+}
+
+func (i Item) MarshalJSON() ([]byte, error) {
+	return json.Marshal(i.toJSON())
+}
+
+// UnmarshalJSON は purchase_currency を省略した JSON を円建てとして読み込む
+func (i *Item) UnmarshalJSON(data []byte) error {
+	var v itemJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	var amount Decimal
+	if v.PurchasePrice != nil {
+		amount = *v.PurchasePrice
+	}
+	price, err := MoneyFromDecimal(amount, string(v.PurchaseCurrency))
+	if err != nil {
+		return err
+	}
+	*i = Item(v.itemFields)
+	i.PurchasePrice = price
+	if v.PurchaseDate != nil {
+		i.PurchaseDate = *v.PurchaseDate
+	}
+	if v.CurrentValue != nil && v.CurrentValueCurrency != nil {
+		value, err := MoneyFromDecimal(*v.CurrentValue, string(*v.CurrentValueCurrency))
+		if err != nil {
+			return err
+		}
+		i.CurrentValue = &value
+	}
+	if v.SerialNumber != nil {
+		i.SerialNumber = *v.SerialNumber
+	}
+	if v.Notes != nil {
+		i.Notes = *v.Notes
+	}
+	i.Redact(v.RedactedFields...)
 	return nil
 }
 
-// カテゴリーのバリデーション
-func isValidCategory(category string) bool {
-	for _, valid := range ValidCategories {
-		if category == valid {
-			return true
+// validatePurchaseDatePolicy は YYYY-MM-DD 形式の購入日が未来や古すぎる日付でないかを検証する
+func validatePurchaseDatePolicy(purchaseDate string, now time.Time) *domainErrors.FieldError {
+	// YYYY-MM-DD 形式同士は文字列比較で前後関係を判定できる
+	if !PurchaseDateAllowFuture {
+		latest := now.UTC().Add(latestTimeZoneOffset).Format("2006-01-02")
+		if purchaseDate > latest {
+			return &domainErrors.FieldError{Field: "purchase_date", Code: domainErrors.CodeDateNotAllowed, Message: "purchase_date must not be in the future"}
+		}
+	}
+	if PurchaseDateMaxAgeYears > 0 {
+		// 遅れたタイムゾーンでも境界の日付を拒否しないよう1日の余裕を持たせる
+		earliest := now.UTC().AddDate(-PurchaseDateMaxAgeYears, 0, -1).Format("2006-01-02")
+		if purchaseDate < earliest {
+			return &domainErrors.FieldError{Field: "purchase_date", Code: domainErrors.CodeDateNotAllowed, Message: fmt.Sprintf("purchase_date must be within the last %d years", PurchaseDateMaxAgeYears)}
 		}
 	}
-	return false
+	return nil
 }
 
 // デート形式のバリデーション
@@ -182,7 +376,44 @@ func isValidDateFormat(dateStr string) bool {
 	return err == nil
 }
 
-// カテゴリーの取得
-func GetValidCategories() []string {
-	return ValidCategories
+// CategoryValue はカテゴリー・通貨・組織ごとのアイテムの件数と購入価格の合計（最小単位。OrgID の 0 は個人のアイテム）
+type CategoryValue struct {
+	Category string
+	Currency Currency
+	OrgID    int64
+	Count    int
+	Value    int64
+	// AddedCount と AddedValue はそのうち今月（データベースの時刻の月初以降）に登録したアイテムの件数と購入価格の合計
+	AddedCount int
+	AddedValue int64
+	// MinValue と MaxValue は購入価格の最小と最大、NewestPurchaseDate は最も新しい購入日（YYYY-MM-DD）
+	MinValue           int64
+	MaxValue           int64
+	NewestPurchaseDate string
+}
+
+// BrandValue はブランド・通貨・組織ごとのアイテムの件数と購入価格の合計（最小単位。OrgID の 0 は個人のアイテム）
+type BrandValue struct {
+	Brand    string
+	Currency Currency
+	OrgID    int64
+	Count    int
+	Value    int64
+}
+
+// HoldingValue は手放していないアイテムのカテゴリー・購入価格の通貨・評価額の通貨・組織ごとの件数と金額の合計
+// （最小単位。OrgID の 0 は個人のアイテム）
+type HoldingValue struct {
+	Category string
+	Currency Currency
+	// ValueCurrency は評価額の通貨（評価額を記録していないアイテムのグループでは空）
+	ValueCurrency Currency
+	OrgID         int64
+	Count         int
+	// PurchaseValue はすべてのアイテムの購入価格の合計、UnvaluedPurchaseValue はそのうち評価額を記録していないアイテムの合計
+	PurchaseValue         int64
+	UnvaluedPurchaseValue int64
+	// ValuedCount と CurrentValue は評価額を記録したアイテムの件数と評価額の合計
+	ValuedCount  int
+	CurrentValue int64
 }
@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"encoding/json"
+)
+
+// ItemAttributes はカテゴリーごとの構造化された属性（値は文字列または数値。未設定の属性は含めない）。
+// 設定できる属性と値はユースケースのカテゴリーごとの属性のスキーマで検証する
+type ItemAttributes map[string]any
+
+// 属性の名前
+const (
+	// 時計
+	AttributeReferenceNumber = "reference_number"
+	AttributeMovement        = "movement"
+	AttributeCaseSize        = "case_size_mm"
+	// バッグ
+	AttributeMaterial = "material"
+	AttributeSize     = "size"
+	// ジュエリー
+	AttributeMetal = "metal"
+	AttributeCarat = "carat"
+)
+
+// String は属性を名前順の JSON にする（未設定は空文字。変更履歴で変更前後を比較するため）
+func (a ItemAttributes) String() string {
+	if len(a) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(map[string]any(a))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "encoding/json"
+
+// Condition はアイテムの状態（フリマアプリなどで使われる6段階）。空は未設定
+type Condition string
+
+const (
+	ConditionNew      Condition = "新品"
+	ConditionLikeNew  Condition = "未使用に近い"
+	ConditionGood     Condition = "目立った傷なし"
+	ConditionFair     Condition = "やや傷あり"
+	ConditionPoor     Condition = "傷あり"
+	ConditionForParts Condition = "ジャンク"
+	// ConditionUnknown は状態を登録していないアイテム
+	ConditionUnknown Condition = ""
+)
+
+// 状態の検証エラーのメッセージ
+const conditionErrorMessage = "condition must be one of: 新品, 未使用に近い, 目立った傷なし, やや傷あり, 傷あり, ジャンク"
+
+// ValidConditions は状態の定義（良い順）
+var ValidConditions = []Condition{ConditionNew, ConditionLikeNew, ConditionGood, ConditionFair, ConditionPoor, ConditionForParts}
+
+// IsValidCondition は指定された状態が定義済みかを返す（未設定は含まない）
+func IsValidCondition(c Condition) bool {
+	for _, valid := range ValidConditions {
+		if c == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON は未設定の状態を null にする
+func (c Condition) MarshalJSON() ([]byte, error) {
+	if c == ConditionUnknown {
+		return []byte("null"), nil
+	}
+	return json.Marshal(string(c))
+}
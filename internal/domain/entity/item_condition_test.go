@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItem_SetCondition(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, ConditionUnknown, item.Condition)
+
+	require.NoError(t, item.SetCondition(" 未使用に近い "))
+	assert.Equal(t, ConditionLikeNew, item.Condition)
+
+	err = item.SetCondition("美品")
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, "condition", errs[0].Field)
+	assert.Equal(t, domainErrors.CodeInvalidChoice, errs[0].Code)
+	assert.Equal(t, ConditionLikeNew, item.Condition)
+
+	// 空文字は未設定に戻す
+	require.NoError(t, item.SetCondition(""))
+	assert.Equal(t, ConditionUnknown, item.Condition)
+}
+
+func TestItem_ValidateCondition(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+
+	item.Condition = "美品"
+	assert.ErrorContains(t, item.Validate(), "condition must be one of")
+
+	item.Condition = ConditionForParts
+	assert.NoError(t, item.Validate())
+}
+
+func TestItem_ConditionJSON(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"condition":null`)
+
+	item.Condition = ConditionGood
+	data, err = json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"condition":"目立った傷なし"`)
+
+	var decoded Item
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, ConditionGood, decoded.Condition)
+}
+
+func TestItemFilter_ValidateCondition(t *testing.T) {
+	assert.NoError(t, ItemFilter{Condition: ConditionNew}.Validate())
+	assert.ErrorContains(t, ItemFilter{Condition: "美品"}.Validate(), "condition must be one of")
+}
@@ -0,0 +1,63 @@
+package entity
+
+import "time"
+
+// アイテムのイベントの種類（Webhook のイベント種別と同じ名前）
+const (
+	ItemEventCreated = "item.created"
+	ItemEventUpdated = "item.updated"
+	ItemEventDeleted = "item.deleted"
+)
+
+// ItemEvent は分析用に書き出すアイテムの1回の操作。
+// 同じ操作で記録した項目ごとの変更履歴（ItemHistory）をまとめたもの
+type ItemEvent struct {
+	// ID は操作の最初の変更履歴の ID（書き出しの順序で、重複の除去に使う）
+	ID         int64     `json:"id"`
+	Type       string    `json:"type"`
+	ItemID     int64     `json:"item_id"`
+	ActorID    int64     `json:"actor_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Changes は変更した項目（登録ではすべての項目の登録時の値、削除ではすべての項目の削除前の値）
+	Changes []ItemEventChange `json:"changes"`
+}
+
+// ItemEventChange は1つの項目の変更前後の値（登録では OldValue、削除では NewValue は nil）
+type ItemEventChange struct {
+	Field    string  `json:"field"`
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+}
+
+// SameOperation は変更履歴がこのイベントと同じ操作で記録されたものかを返す
+func (e *ItemEvent) SameOperation(h *ItemHistory) bool {
+	return e.ItemID == h.ItemID && e.ActorID == h.ActorID && e.Type == itemEventType(h.Action) && e.OccurredAt.Equal(h.CreatedAt)
+}
+
+// Add は変更履歴をイベントの変更に加える
+func (e *ItemEvent) Add(h *ItemHistory) {
+	e.Changes = append(e.Changes, ItemEventChange{Field: h.Field, OldValue: h.OldValue, NewValue: h.NewValue})
+}
+
+// NewItemEvent は変更履歴から始まるイベントを作成する
+func NewItemEvent(h *ItemHistory) *ItemEvent {
+	e := &ItemEvent{
+		ID:         h.ID,
+		Type:       itemEventType(h.Action),
+		ItemID:     h.ItemID,
+		ActorID:    h.ActorID,
+		OccurredAt: h.CreatedAt,
+	}
+	e.Add(h)
+	return e
+}
+
+func itemEventType(action ItemHistoryAction) string {
+	switch action {
+	case ItemHistoryActionCreate:
+		return ItemEventCreated
+	case ItemHistoryActionDelete:
+		return ItemEventDeleted
+	}
+	return ItemEventUpdated
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+)
+
+// ItemFilter はアイテム一覧の絞り込み条件（nil / 空文字 / 0 のフィールドは条件なし）
+type ItemFilter struct {
+	// UserID は参照するユーザーでの絞り込み（リクエストからではなくユースケースが設定する）。
+	// 個人のアイテムと所属する組織のアイテムに絞り込む
+	UserID           int64
+	OrgID            int64 // 組織での絞り込み
+	Category         string
+	Brand            string
+	Condition        Condition
+	Status           ItemStatus
+	PurchaseCurrency Currency // 購入価格の通貨での絞り込み（価格の範囲はこの通貨の最小単位の整数）
+	MinPurchasePrice *int
+	MaxPurchasePrice *int
+	PurchaseDateFrom string // YYYY-MM-DD 形式
+	PurchaseDateTo   string // YYYY-MM-DD 形式
+	// Tags はすべてのタグが付いたアイテムに絞り込む（NormalizeTag で正規化した名前）
+	Tags []string
+	// Attributes は属性の値での絞り込み（属性名 → 値。索引のある属性のみ）
+	Attributes map[string]string
+	Sort       ItemSort
+}
+
+// ソート順
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// ソートキー
+const (
+	SortKeyName          = "name"
+	SortKeyPurchasePrice = "purchase_price"
+	SortKeyPurchaseDate  = "purchase_date"
+	SortKeyCreatedAt     = "created_at"
+)
+
+// 名前の照合順序（sort=name の場合のみ。空の場合はバイト順）
+const (
+	// CollationJa は日本語の照合順序（ひらがな・カタカナは五十音順、英字はアルファベット順）
+	CollationJa = "ja"
+	// CollationNatural は日本語の照合順序で、名前の中の数字を数値として比較する（例: 「No.2」は「No.10」より前）
+	CollationNatural = "natural"
+)
+
+// ItemSort はアイテム一覧の並び順（空の場合は作成日時の降順）
+type ItemSort struct {
+	Key       string
+	Order     SortOrder
+	Collation string
+}
+
+// 絞り込み条件のバリデーション
+func (f ItemFilter) Validate() error {
+	var errs []string
+
+	if f.Category != "" && !isValidCategory(f.Category) {
+		errs = append(errs, categoryErrorMessage())
+	}
+
+	if f.Condition != ConditionUnknown && !IsValidCondition(f.Condition) {
+		errs = append(errs, conditionErrorMessage)
+	}
+
+	if f.Status != "" && !IsValidItemStatus(f.Status) {
+		errs = append(errs, statusErrorMessage)
+	}
+
+	if f.OrgID < 0 {
+		errs = append(errs, "org_id must be a positive integer")
+	}
+
+	if f.PurchaseCurrency != "" && !f.PurchaseCurrency.IsValid() {
+		errs = append(errs, currencyErrorMessage)
+	}
+	if f.MinPurchasePrice != nil && *f.MinPurchasePrice < 0 {
+		errs = append(errs, "min_price must be 0 or greater")
+	}
+	if f.MaxPurchasePrice != nil && *f.MaxPurchasePrice < 0 {
+		errs = append(errs, "max_price must be 0 or greater")
+	}
+	if f.MinPurchasePrice != nil && f.MaxPurchasePrice != nil && *f.MinPurchasePrice > *f.MaxPurchasePrice {
+		errs = append(errs, "min_price must be less than or equal to max_price")
+	}
+
+	validFrom := f.PurchaseDateFrom == "" || isValidDateFormat(f.PurchaseDateFrom)
+	validTo := f.PurchaseDateTo == "" || isValidDateFormat(f.PurchaseDateTo)
+	if !validFrom {
+		errs = append(errs, "purchase_date_from must be in YYYY-MM-DD format")
+	}
+	if !validTo {
+		errs = append(errs, "purchase_date_to must be in YYYY-MM-DD format")
+	}
+	// YYYY-MM-DD 形式同士は文字列比較で前後関係を判定できる
+	if validFrom && validTo && f.PurchaseDateFrom != "" && f.PurchaseDateTo != "" && f.PurchaseDateFrom > f.PurchaseDateTo {
+		errs = append(errs, "purchase_date_from must be on or before purchase_date_to")
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
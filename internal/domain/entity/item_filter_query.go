@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ItemFilterAttributePrefix は属性での絞り込みのクエリパラメータの接頭辞（attr.<属性名>=値）
+const ItemFilterAttributePrefix = "attr."
+
+// ParseItemFilterQuery はクエリパラメータ（GET /items と同じ名前）から絞り込み条件を組み立てる。
+// 一覧・エクスポート・一括変更はすべてこの関数で絞り込み条件を解釈するため、同じ条件は同じアイテムに一致する。
+// 値の形式の誤りのみ返し、カテゴリーなどの値の検証は ItemFilter.Validate で行う
+func ParseItemFilterQuery(query url.Values) (ItemFilter, domainErrors.ValidationErrors) {
+	var errs domainErrors.ValidationErrors
+	filter := ItemFilter{
+		Category:         query.Get("category"),
+		Brand:            query.Get("brand"),
+		Condition:        Condition(query.Get("condition")),
+		Status:           ItemStatus(query.Get("status")),
+		PurchaseDateFrom: query.Get("purchase_date_from"),
+		PurchaseDateTo:   query.Get("purchase_date_to"),
+		Sort: ItemSort{
+			Key:       query.Get("sort"),
+			Order:     SortOrder(query.Get("order")),
+			Collation: query.Get("collation"),
+		},
+	}
+
+	if v := query.Get("org_id"); v != "" {
+		orgID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || orgID <= 0 {
+			errs.Add("org_id", domainErrors.CodeOutOfRange, "org_id must be a positive integer")
+		} else {
+			filter.OrgID = orgID
+		}
+	}
+	if v := query.Get("purchase_currency"); v != "" {
+		filter.PurchaseCurrency = NewMoney(0, v).Currency
+		if !filter.PurchaseCurrency.IsValid() {
+			errs.Add("purchase_currency", domainErrors.CodeInvalidChoice, currencyErrorMessage)
+		}
+	}
+	// 価格の範囲は purchase_currency の補助単位を小数にした10進数（USD の 123.45）。
+	// 通貨の異なる金額は比べられないため、purchase_currency を省略した場合は円建てのアイテムに絞り込む
+	minPrice, maxPrice := query.Get("min_price"), query.Get("max_price")
+	if (minPrice != "" || maxPrice != "") && filter.PurchaseCurrency == "" {
+		filter.PurchaseCurrency = CurrencyJPY
+	}
+	if minPrice != "" {
+		filter.MinPurchasePrice = parsePriceBound(&errs, "min_price", minPrice, filter.PurchaseCurrency)
+	}
+	if maxPrice != "" {
+		filter.MaxPurchasePrice = parsePriceBound(&errs, "max_price", maxPrice, filter.PurchaseCurrency)
+	}
+	for _, v := range query["tag"] {
+		tag, err := NormalizeTag(v)
+		if err != nil {
+			errs.Add("tag", domainErrors.CodeInvalidFormat, err.Error())
+			continue
+		}
+		filter.Tags = append(filter.Tags, tag)
+	}
+	// attr.<属性名>=値 は属性での絞り込み（絞り込みに使える属性かはユースケースで検証する）
+	for key, values := range query {
+		name, ok := strings.CutPrefix(key, ItemFilterAttributePrefix)
+		if !ok || len(values) == 0 || strings.TrimSpace(values[0]) == "" {
+			continue
+		}
+		if filter.Attributes == nil {
+			filter.Attributes = map[string]string{}
+		}
+		filter.Attributes[name] = strings.TrimSpace(values[0])
+	}
+
+	return filter, errs
+}
+
+// parsePriceBound は価格の範囲の10進数を currency の最小単位の整数にする（形式の誤りは errs に加えて nil を返す）
+func parsePriceBound(errs *domainErrors.ValidationErrors, field, value string, currency Currency) *int {
+	amount, err := ParseDecimal(value)
+	if err != nil {
+		errs.Add(field, domainErrors.CodeInvalidType, field+" must be a number")
+		return nil
+	}
+	if !currency.IsValid() {
+		// 通貨の誤りは purchase_currency の検証エラーで返す
+		return nil
+	}
+	price, err := MoneyFromDecimal(amount, string(currency))
+	if err != nil {
+		errs.Add(field, domainErrors.CodeInvalidFormat, field+" "+err.Error())
+		return nil
+	}
+	return &price.Amount
+}
+
+// Query は絞り込み条件を ParseItemFilterQuery で解釈できるクエリパラメータにする（UserID は含めない）
+func (f ItemFilter) Query() url.Values {
+	query := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+
+	set("category", f.Category)
+	set("brand", f.Brand)
+	set("condition", string(f.Condition))
+	set("status", string(f.Status))
+	set("purchase_date_from", f.PurchaseDateFrom)
+	set("purchase_date_to", f.PurchaseDateTo)
+	set("sort", f.Sort.Key)
+	set("order", string(f.Sort.Order))
+	set("collation", f.Sort.Collation)
+	if f.OrgID != 0 {
+		query.Set("org_id", strconv.FormatInt(f.OrgID, 10))
+	}
+	set("purchase_currency", string(f.PurchaseCurrency))
+	if f.MinPurchasePrice != nil {
+		query.Set("min_price", NewMoney(*f.MinPurchasePrice, string(f.PurchaseCurrency)).Decimal().String())
+	}
+	if f.MaxPurchasePrice != nil {
+		query.Set("max_price", NewMoney(*f.MaxPurchasePrice, string(f.PurchaseCurrency)).Decimal().String())
+	}
+	for _, tag := range f.Tags {
+		query.Add("tag", tag)
+	}
+	for name, value := range f.Attributes {
+		set(ItemFilterAttributePrefix+name, value)
+	}
+	return query
+}
@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestParseItemFilterQuery(t *testing.T) {
+	minPrice, maxPrice := 1000, 50000
+
+	t.Run("正常系: すべての条件を解釈する", func(t *testing.T) {
+		query, err := url.ParseQuery("category=時計&brand=ROLEX&condition=" + url.QueryEscape(string(ConditionGood)) + "&status=owned&org_id=3&min_price=1000&max_price=50000" +
+			"&purchase_date_from=2023-01-01&purchase_date_to=2023-12-31&tag=Vintage&tag=gift&attr.movement=+自動巻き+&attr.metal=" +
+			"&sort=name&order=asc&collation=ja")
+		require.NoError(t, err)
+
+		filter, errs := ParseItemFilterQuery(query)
+		assert.Empty(t, errs)
+		// purchase_currency を省略した価格の範囲は円建て
+		assert.Equal(t, ItemFilter{
+			OrgID:            3,
+			Category:         "時計",
+			Brand:            "ROLEX",
+			Condition:        ConditionGood,
+			Status:           ItemStatusOwned,
+			PurchaseCurrency: CurrencyJPY,
+			MinPurchasePrice: &minPrice,
+			MaxPurchasePrice: &maxPrice,
+			PurchaseDateFrom: "2023-01-01",
+			PurchaseDateTo:   "2023-12-31",
+			Tags:             []string{"vintage", "gift"},
+			// 値が空の属性は条件なし
+			Attributes: map[string]string{AttributeMovement: "自動巻き"},
+			Sort:       ItemSort{Key: SortKeyName, Order: SortOrderAsc, Collation: CollationJa},
+		}, filter)
+	})
+
+	t.Run("正常系: 条件がない場合は空の絞り込み条件", func(t *testing.T) {
+		filter, errs := ParseItemFilterQuery(url.Values{})
+		assert.Empty(t, errs)
+		assert.Equal(t, ItemFilter{}, filter)
+	})
+
+	t.Run("正常系: 価格の範囲は purchase_currency の最小単位の整数にする", func(t *testing.T) {
+		query, err := url.ParseQuery("purchase_currency=usd&min_price=12.5&max_price=100")
+		require.NoError(t, err)
+
+		filter, errs := ParseItemFilterQuery(query)
+		assert.Empty(t, errs)
+		assert.Equal(t, CurrencyUSD, filter.PurchaseCurrency)
+		assert.Equal(t, 1250, *filter.MinPurchasePrice)
+		assert.Equal(t, 10000, *filter.MaxPurchasePrice)
+	})
+
+	t.Run("正常系: 価格の範囲がなくても通貨で絞り込める", func(t *testing.T) {
+		filter, errs := ParseItemFilterQuery(url.Values{"purchase_currency": {"EUR"}})
+		assert.Empty(t, errs)
+		assert.Equal(t, ItemFilter{PurchaseCurrency: CurrencyEUR}, filter)
+	})
+
+	t.Run("異常系: 通貨の補助単位より細かい端数と対応していない通貨", func(t *testing.T) {
+		_, errs := ParseItemFilterQuery(url.Values{"min_price": {"1000.5"}})
+		assert.Equal(t, []string{"min_price"}, errorFields(errs))
+		assert.Equal(t, domainErrors.CodeInvalidFormat, errs[0].Code)
+
+		_, errs = ParseItemFilterQuery(url.Values{"purchase_currency": {"GBP"}, "min_price": {"10"}})
+		assert.Equal(t, []string{"purchase_currency"}, errorFields(errs))
+		assert.Equal(t, domainErrors.CodeInvalidChoice, errs[0].Code)
+	})
+
+	t.Run("異常系: 形式の誤りを項目ごとに返す", func(t *testing.T) {
+		query, err := url.ParseQuery("org_id=0&min_price=abc&max_price=1e3&tag=+")
+		require.NoError(t, err)
+
+		_, errs := ParseItemFilterQuery(query)
+		assert.Equal(t, []string{"org_id", "min_price", "max_price", "tag"}, errorFields(errs))
+		assert.Equal(t, domainErrors.CodeOutOfRange, errs[0].Code)
+		assert.Equal(t, domainErrors.CodeInvalidType, errs[1].Code)
+	})
+}
+
+func TestItemFilter_Query(t *testing.T) {
+	minPrice := 1250
+	filters := map[string]ItemFilter{
+		"空の条件": {},
+		"すべての条件": {
+			OrgID:            3,
+			Category:         "時計",
+			Brand:            "ROLEX",
+			Condition:        ConditionGood,
+			Status:           ItemStatusSold,
+			PurchaseCurrency: CurrencyUSD,
+			MinPurchasePrice: &minPrice,
+			PurchaseDateFrom: "2023-01-01",
+			PurchaseDateTo:   "2023-12-31",
+			Tags:             []string{"vintage", "gift"},
+			Attributes:       map[string]string{AttributeMovement: "自動巻き", AttributeReferenceNumber: "126610LN"},
+			Sort:             ItemSort{Key: SortKeyPurchasePrice, Order: SortOrderDesc},
+		},
+	}
+
+	for name, filter := range filters {
+		t.Run("正常系: ParseItemFilterQuery で元の条件に戻る: "+name, func(t *testing.T) {
+			parsed, errs := ParseItemFilterQuery(filter.Query())
+			assert.Empty(t, errs)
+			assert.Equal(t, filter, parsed)
+		})
+	}
+
+	t.Run("正常系: UserID はクエリに含めない", func(t *testing.T) {
+		assert.Empty(t, ItemFilter{UserID: 1}.Query())
+	})
+}
+
+func errorFields(errs domainErrors.ValidationErrors) []string {
+	names := make([]string, len(errs))
+	for i, err := range errs {
+		names[i] = err.Field
+	}
+	return names
+}
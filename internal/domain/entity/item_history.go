@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// ItemHistoryAction はアイテムに対して行われた操作
+type ItemHistoryAction string
+
+const (
+	ItemHistoryActionCreate ItemHistoryAction = "create"
+	ItemHistoryActionUpdate ItemHistoryAction = "update"
+	ItemHistoryActionDelete ItemHistoryAction = "delete"
+)
+
+// ItemHistory はアイテムの1つの項目の変更の記録
+type ItemHistory struct {
+	ID      int64 `json:"id"`
+	ItemID  int64 `json:"item_id"`
+	ActorID int64 `json:"actor_id"`
+	// ActorEmail は表示用の操作したユーザーのメールアドレス（読み込み時に設定する）
+	ActorEmail string            `json:"actor_email"`
+	Action     ItemHistoryAction `json:"action"`
+	Field      string            `json:"field"`
+	// OldValue / NewValue は変更前後の値（登録では OldValue、削除では NewValue は nil）
+	OldValue *string `json:"old_value"`
+	NewValue *string `json:"new_value"`
+	// RawValue は名前・ブランドの登録・変更で、送信された正規化（前後の空白の除去など）する前の値。
+	// 変更履歴でのみ返し、NewValue と同じ場合は記録しない
+	RawValue  *string   `json:"raw_value,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewItemHistories は変更前後のアイテムを比較し、変わった項目ごとの履歴を返す。
+// before が nil の場合は登録として、すべての項目の登録時の値を記録する（ItemID は登録後に設定する）。
+// after が nil の場合は削除として、すべての項目の削除前の値を記録する
+func NewItemHistories(actorID int64, before, after *Item, at time.Time) []*ItemHistory {
+	action, item := ItemHistoryActionUpdate, before
+	switch {
+	case before == nil:
+		action, item = ItemHistoryActionCreate, after
+	case after == nil:
+		action = ItemHistoryActionDelete
+	}
+
+	var oldValues, newValues []itemHistoryValue
+	if before != nil {
+		oldValues = itemHistoryValues(before)
+	}
+	if after != nil {
+		newValues = itemHistoryValues(after)
+	}
+
+	var histories []*ItemHistory
+	for i, field := range itemHistoryValues(item) {
+		history := &ItemHistory{
+			ItemID:    item.ID,
+			ActorID:   actorID,
+			Action:    action,
+			Field:     field.field,
+			CreatedAt: at,
+		}
+		if before != nil {
+			history.OldValue = &oldValues[i].value
+		}
+		if after != nil {
+			if before != nil && newValues[i].value == oldValues[i].value {
+				continue
+			}
+			history.NewValue = &newValues[i].value
+		}
+		histories = append(histories, history)
+	}
+	return histories
+}
+
+// SetRawInputs は名前・ブランドの登録・変更の履歴に、送信された正規化する前の値を記録する
+// （送信されなかった項目は nil。正規化した値と同じ場合は記録しない）
+func SetRawInputs(histories []*ItemHistory, name, brand *string) {
+	raw := map[string]*string{"name": name, "brand": brand}
+	for _, history := range histories {
+		value := raw[history.Field]
+		if value == nil || history.NewValue == nil || *value == *history.NewValue {
+			continue
+		}
+		v := *value
+		history.RawValue = &v
+	}
+}
+
+type itemHistoryValue struct {
+	field string
+	value string
+}
+
+// itemHistoryValues は履歴に記録するアイテムの項目と値を返す
+func itemHistoryValues(item *Item) []itemHistoryValue {
+	return []itemHistoryValue{
+		{"name", item.Name},
+		{"category", item.Category},
+		{"brand", item.Brand},
+		{"purchase_price", strconv.Itoa(item.PurchasePrice.Amount)},
+		{"purchase_currency", string(item.PurchasePrice.Currency)},
+		{"purchase_date", item.PurchaseDate},
+		{"visibility", string(item.Visibility)},
+		{"condition", string(item.Condition)},
+		{"status", string(item.Status)},
+		{"serial_number", item.SerialNumber},
+		{"notes", item.Notes},
+		{"attributes", item.Attributes.String()},
+		{"org_id", strconv.FormatInt(item.OrgID, 10)},
+	}
+}
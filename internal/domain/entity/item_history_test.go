@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewItemHistories(t *testing.T) {
+	at := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	before := &Item{ID: 1, Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: JPY(1500000), PurchaseDate: "2023-01-15", Visibility: VisibilityPrivate}
+
+	t.Run("更新: 変わった項目のみ記録する", func(t *testing.T) {
+		after := *before
+		after.Name = "コスモグラフ デイトナ"
+		after.PurchasePrice = JPY(1600000)
+
+		histories := NewItemHistories(2, before, &after, at)
+
+		require.Len(t, histories, 2)
+		assert.Equal(t, "name", histories[0].Field)
+		assert.Equal(t, "デイトナ", *histories[0].OldValue)
+		assert.Equal(t, "コスモグラフ デイトナ", *histories[0].NewValue)
+		assert.Equal(t, "purchase_price", histories[1].Field)
+		assert.Equal(t, "1500000", *histories[1].OldValue)
+		assert.Equal(t, "1600000", *histories[1].NewValue)
+		for _, history := range histories {
+			assert.Equal(t, ItemHistoryActionUpdate, history.Action)
+			assert.Equal(t, int64(1), history.ItemID)
+			assert.Equal(t, int64(2), history.ActorID)
+			assert.Equal(t, at, history.CreatedAt)
+		}
+	})
+
+	t.Run("更新: 通貨の変更を記録する", func(t *testing.T) {
+		after := *before
+		after.PurchasePrice = NewMoney(1500000, "USD")
+
+		histories := NewItemHistories(2, before, &after, at)
+
+		require.Len(t, histories, 1)
+		assert.Equal(t, "purchase_currency", histories[0].Field)
+		assert.Equal(t, "JPY", *histories[0].OldValue)
+		assert.Equal(t, "USD", *histories[0].NewValue)
+	})
+
+	t.Run("更新: 属性は名前順の JSON で記録する", func(t *testing.T) {
+		after := *before
+		after.Attributes = ItemAttributes{AttributeMovement: "自動巻き", AttributeCaseSize: 40.0}
+
+		histories := NewItemHistories(2, before, &after, at)
+		require.Len(t, histories, 1)
+		assert.Equal(t, "attributes", histories[0].Field)
+		assert.Equal(t, "", *histories[0].OldValue)
+		assert.Equal(t, `{"case_size_mm":40,"movement":"自動巻き"}`, *histories[0].NewValue)
+	})
+
+	t.Run("更新: 変更がない場合は記録しない", func(t *testing.T) {
+		after := *before
+
+		assert.Empty(t, NewItemHistories(2, before, &after, at))
+	})
+
+	t.Run("登録: すべての項目の登録時の値を記録する", func(t *testing.T) {
+		histories := NewItemHistories(2, nil, before, at)
+
+		require.Len(t, histories, 13)
+		for _, history := range histories {
+			assert.Equal(t, ItemHistoryActionCreate, history.Action)
+			assert.Nil(t, history.OldValue)
+			assert.NotNil(t, history.NewValue)
+		}
+		assert.Equal(t, "name", histories[0].Field)
+		assert.Equal(t, before.Name, *histories[0].NewValue)
+	})
+
+	t.Run("削除: すべての項目の削除前の値を記録する", func(t *testing.T) {
+		histories := NewItemHistories(2, before, nil, at)
+
+		require.Len(t, histories, 13)
+		for _, history := range histories {
+			assert.Equal(t, ItemHistoryActionDelete, history.Action)
+			assert.NotNil(t, history.OldValue)
+			assert.Nil(t, history.NewValue)
+		}
+	})
+}
+
+func TestSetRawInputs(t *testing.T) {
+	before, err := NewItem("デイトナ", "時計", "ROLEX", JPY(1500000), "2023-01-15")
+	require.NoError(t, err)
+	after := *before
+	after.Name = "デイトナ 116500LN"
+	after.Brand = "Rolex"
+	after.Notes = "箱あり"
+	histories := NewItemHistories(2, before, &after, time.Now())
+	require.Len(t, histories, 3)
+
+	name, brand := " デイトナ 116500LN ", "Rolex"
+	SetRawInputs(histories, &name, &brand)
+
+	// 正規化で変わった名前のみ記録し、入力のまま記録する
+	assert.Equal(t, "name", histories[0].Field)
+	require.NotNil(t, histories[0].RawValue)
+	assert.Equal(t, " デイトナ 116500LN ", *histories[0].RawValue)
+	assert.Equal(t, "brand", histories[1].Field)
+	assert.Nil(t, histories[1].RawValue)
+	assert.Equal(t, "notes", histories[2].Field)
+	assert.Nil(t, histories[2].RawValue)
+
+	t.Run("送信されなかった項目と削除は記録しない", func(t *testing.T) {
+		deleted := NewItemHistories(2, before, nil, time.Now())
+		SetRawInputs(deleted, &name, nil)
+		for _, history := range deleted {
+			assert.Nil(t, history.RawValue)
+		}
+	})
+}
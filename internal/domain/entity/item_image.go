@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ItemImage はアイテムに添付した画像。Position の昇順で表示する
+type ItemImage struct {
+	ID          int64  `json:"id"`
+	ItemID      int64  `json:"item_id"`
+	Position    int    `json:"position"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	// StorageKey はストレージ上の保存先（公開しない）
+	StorageKey string `json:"-"`
+	URL        string `json:"url"`
+	// ThumbnailsReady はサムネイルの生成が完了しているか
+	ThumbnailsReady bool `json:"-"`
+	// Thumbnails は生成済みのサムネイル（生成前や生成できない形式の場合は空）
+	Thumbnails []ImageThumbnail `json:"thumbnails"`
+	CreatedAt  time.Time        `json:"created_at"`
+}
+
+// ImageThumbnail は縮小した画像の取得先
+type ImageThumbnail struct {
+	Width int    `json:"width"`
+	URL   string `json:"url"`
+}
+
+// アップロード後に生成するサムネイルの幅（px）
+var ThumbnailWidths = []int{200, 800}
+
+// アップロードできる画像の形式
+var ImageContentTypes = []string{"image/jpeg", "image/png", "image/gif", "image/webp"}
+
+// アップロードできる画像の最大サイズ（バイト）
+const MaxImageSize = 10 << 20
+
+func NewItemImage(itemID int64, fileName, contentType string, size int64) (*ItemImage, error) {
+	image := &ItemImage{
+		ItemID:      itemID,
+		FileName:    path.Base(strings.ReplaceAll(strings.TrimSpace(fileName), "\\", "/")),
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := image.Validate(); err != nil {
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (i *ItemImage) Validate() error {
+	var errs []string
+
+	if i.FileName == "" || i.FileName == "." || i.FileName == "/" {
+		errs = append(errs, "file name is required")
+	} else if utf8.RuneCountInString(i.FileName) > 255 {
+		errs = append(errs, "file name must be 255 characters or less")
+	}
+
+	if !isValidImageContentType(i.ContentType) {
+		errs = append(errs, fmt.Sprintf("content type must be one of: %s", strings.Join(ImageContentTypes, ", ")))
+	}
+
+	if i.Size <= 0 {
+		errs = append(errs, "file is empty")
+	} else if i.Size > MaxImageSize {
+		errs = append(errs, fmt.Sprintf("file must be %d MB or less", MaxImageSize>>20))
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// Extension はストレージのキーに付ける拡張子を返す
+func (i *ItemImage) Extension() string {
+	switch i.ContentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/gif":
+		return ".gif"
+	case "image/webp":
+		return ".webp"
+	}
+	return ""
+}
+
+// ThumbnailKey は指定した幅のサムネイルのストレージ上のキーを返す（サムネイルは常にJPEG）
+func (i *ItemImage) ThumbnailKey(width int) string {
+	return fmt.Sprintf("%s_%d.jpg", strings.TrimSuffix(i.StorageKey, path.Ext(i.StorageKey)), width)
+}
+
+// StorageKeys は元の画像とサムネイルのキーをすべて返す
+func (i *ItemImage) StorageKeys() []string {
+	keys := []string{i.StorageKey}
+	for _, width := range ThumbnailWidths {
+		keys = append(keys, i.ThumbnailKey(width))
+	}
+	return keys
+}
+
+// IsValidThumbnailWidth は生成対象の幅かを返す
+func IsValidThumbnailWidth(width int) bool {
+	for _, w := range ThumbnailWidths {
+		if w == width {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidImageContentType(contentType string) bool {
+	for _, t := range ImageContentTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewItemImage(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileName     string
+		contentType  string
+		size         int64
+		wantFileName string
+		expectedErr  string
+	}{
+		{name: "正常系", fileName: "front.jpg", contentType: "image/jpeg", size: 100, wantFileName: "front.jpg"},
+		{name: "正常系: パスはファイル名だけにする", fileName: `C:\photos\..\front.png`, contentType: "image/png", size: 100, wantFileName: "front.png"},
+		{name: "異常系: ファイル名が空", fileName: " ", contentType: "image/png", size: 100, expectedErr: "file name is required"},
+		{name: "異常系: 画像以外の形式", fileName: "a.pdf", contentType: "application/pdf", size: 100, expectedErr: "content type must be one of"},
+		{name: "異常系: 空のファイル", fileName: "a.png", contentType: "image/png", size: 0, expectedErr: "file is empty"},
+		{name: "異常系: サイズ超過", fileName: "a.png", contentType: "image/png", size: MaxImageSize + 1, expectedErr: "file must be 10 MB or less"},
+		{name: "異常系: ファイル名が長すぎる", fileName: strings.Repeat("あ", 256), contentType: "image/png", size: 1, expectedErr: "file name must be 255 characters or less"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			image, err := NewItemImage(1, tt.fileName, tt.contentType, tt.size)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantFileName, image.FileName)
+			assert.Equal(t, int64(1), image.ItemID)
+		})
+	}
+}
+
+func TestItemImage_StorageKeys(t *testing.T) {
+	image := &ItemImage{StorageKey: "items/1/abc.png"}
+
+	assert.Equal(t, "items/1/abc_200.jpg", image.ThumbnailKey(200))
+	assert.Equal(t, []string{"items/1/abc.png", "items/1/abc_200.jpg", "items/1/abc_800.jpg"}, image.StorageKeys())
+	assert.True(t, IsValidThumbnailWidth(800))
+	assert.False(t, IsValidThumbnailWidth(300))
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// メモ本文の最大文字数
+const MaxItemMemoLength = 500
+
+// ItemMemo はアイテムに残す自分用の短いメモ（アイテムを編集せずに気づいたことを書き足す。書いたユーザーだけが参照できる）
+type ItemMemo struct {
+	ID        int64     `json:"id"`
+	ItemID    int64     `json:"item_id"`
+	UserID    int64     `json:"user_id"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewItemMemo(itemID, userID int64, body string, now time.Time) (*ItemMemo, error) {
+	memo := &ItemMemo{
+		ItemID:    itemID,
+		UserID:    userID,
+		Body:      strings.TrimSpace(body),
+		CreatedAt: now,
+	}
+
+	if err := memo.Validate(); err != nil {
+		return nil, err
+	}
+
+	return memo, nil
+}
+
+func (m *ItemMemo) Validate() error {
+	if m.Body == "" {
+		return domainErrors.ValidationErrors{{Field: "body", Code: domainErrors.CodeRequired, Message: "body is required"}}
+	}
+	if utf8.RuneCountInString(m.Body) > MaxItemMemoLength {
+		return domainErrors.ValidationErrors{{Field: "body", Code: domainErrors.CodeTooLong, Message: fmt.Sprintf("body must be %d characters or less", MaxItemMemoLength)}}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestNewItemMemo(t *testing.T) {
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	memo, err := NewItemMemo(1, 2, "  ベルトにひびが入り始めた\n", now)
+	require.NoError(t, err)
+	assert.Equal(t, "ベルトにひびが入り始めた", memo.Body)
+	assert.Equal(t, now, memo.CreatedAt)
+
+	_, err = NewItemMemo(1, 2, " ", now)
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.CodeRequired, errs[0].Code)
+
+	// 文字数で数える
+	_, err = NewItemMemo(1, 2, strings.Repeat("あ", MaxItemMemoLength), now)
+	require.NoError(t, err)
+	_, err = NewItemMemo(1, 2, strings.Repeat("あ", MaxItemMemoLength+1), now)
+	errs, ok = domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.CodeTooLong, errs[0].Code)
+	assert.Equal(t, "body must be 500 characters or less", errs[0].Message)
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// NotesMaxLength はメモの最大文字数（バイト数ではなく文字数で数える）
+const NotesMaxLength = 2000
+
+// validateNotes はメモの長さを検証する（空は未設定）
+func validateNotes(notes string) *domainErrors.FieldError {
+	if utf8.RuneCountInString(notes) > NotesMaxLength {
+		return &domainErrors.FieldError{Field: "notes", Code: domainErrors.CodeTooLong, Message: fmt.Sprintf("notes must be %d characters or less", NotesMaxLength)}
+	}
+	return nil
+}
+
+// SetNotes はメモを変更する（前後の空白を除く。空文字は未設定に戻す）
+func (i *Item) SetNotes(notes string) error {
+	n := strings.TrimSpace(notes)
+	if fe := validateNotes(n); fe != nil {
+		return domainErrors.ValidationErrors{*fe}
+	}
+	if n != i.Notes {
+		i.Notes = n
+		i.UpdatedAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItem_SetNotes(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+	assert.Empty(t, item.Notes)
+
+	require.NoError(t, item.SetNotes("  2023年にオーバーホール済み\n"))
+	assert.Equal(t, "2023年にオーバーホール済み", item.Notes)
+
+	// 文字数で数える（日本語の2000文字は上限内）
+	require.NoError(t, item.SetNotes(strings.Repeat("あ", NotesMaxLength)))
+
+	err = item.SetNotes(strings.Repeat("あ", NotesMaxLength+1))
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, "notes", errs[0].Field)
+	assert.Equal(t, domainErrors.CodeTooLong, errs[0].Code)
+	assert.Equal(t, strings.Repeat("あ", NotesMaxLength), item.Notes)
+
+	// 空白だけのメモは未設定に戻す
+	require.NoError(t, item.SetNotes("  "))
+	assert.Empty(t, item.Notes)
+}
+
+func TestItem_NotesJSON(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"notes":null`)
+
+	item.Notes = "箱・保証書あり"
+	data, err = json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"notes":"箱・保証書あり"`)
+
+	var decoded Item
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "箱・保証書あり", decoded.Notes)
+
+	item.Notes = strings.Repeat("a", NotesMaxLength+1)
+	assert.ErrorContains(t, item.Validate(), "notes must be 2000 characters or less")
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// 役割によって非表示にできるアイテムの項目（JSON の項目名）
+const (
+	ItemFieldPurchasePrice = "purchase_price"
+	ItemFieldPurchaseDate  = "purchase_date"
+	ItemFieldSerialNumber  = "serial_number"
+)
+
+// RedactableItemFields は非表示にできるアイテムの項目
+var RedactableItemFields = []string{ItemFieldPurchasePrice, ItemFieldPurchaseDate, ItemFieldSerialNumber}
+
+// RedactedItemFields は組織での役割ごとに、その役割のメンバーに返さない組織のアイテムの項目（起動時に設定で変更できる）。
+// 個人のアイテムと管理者には適用しない
+var RedactedItemFields = map[OrgRole][]string{
+	OrgRoleViewer: {ItemFieldPurchasePrice},
+}
+
+// ParseItemRedaction は「viewer:purchase_price|serial_number」形式の役割ごとの設定を読み込む。
+// 指定しない役割と「none」は何も非表示にしない
+func ParseItemRedaction(entries []string) (map[OrgRole][]string, error) {
+	redaction := make(map[OrgRole][]string)
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" || entry == "none" {
+			continue
+		}
+		name, list, ok := strings.Cut(entry, ":")
+		role := OrgRole(strings.TrimSpace(name))
+		if !ok || !role.IsValid() {
+			return nil, fmt.Errorf("invalid item redaction %q: must be <role>:<field>|<field> with role owner, editor or viewer", entry)
+		}
+		for _, field := range strings.Split(list, "|") {
+			field = strings.TrimSpace(field)
+			if !slices.Contains(RedactableItemFields, field) {
+				return nil, fmt.Errorf("invalid item redaction %q: field must be one of: %s", entry, strings.Join(RedactableItemFields, ", "))
+			}
+			if !slices.Contains(redaction[role], field) {
+				redaction[role] = append(redaction[role], field)
+			}
+		}
+	}
+	return redaction, nil
+}
+
+// ItemRedactionFor は user に返す組織 orgID のアイテムで非表示にする項目を返す（0 は個人のアイテム）
+func ItemRedactionFor(user *User, orgID int64) []string {
+	if orgID == 0 || user == nil || user.IsAdmin() {
+		return nil
+	}
+	role, ok := user.OrgRole(orgID)
+	if !ok {
+		return nil
+	}
+	return RedactedItemFields[role]
+}
+
+// RedactFor は user の組織での役割に応じてアイテムの項目を非表示にする
+func (i *Item) RedactFor(user *User) {
+	i.Redact(ItemRedactionFor(user, i.OrgID)...)
+}
+
+// Redact は項目の値を消去し、非表示にした項目として記録する（JSON では null になり、redacted_fields に含める）。
+// 値そのものを消去するため、エクスポートなど JSON 以外の経路にも漏れない。保存するアイテムには使わない
+func (i *Item) Redact(fields ...string) {
+	for _, field := range fields {
+		switch field {
+		case ItemFieldPurchasePrice:
+			i.PurchasePrice.Amount = 0
+		case ItemFieldPurchaseDate:
+			i.PurchaseDate = ""
+		case ItemFieldSerialNumber:
+			i.SerialNumber = ""
+		default:
+			continue
+		}
+		if !slices.Contains(i.redacted, field) {
+			i.redacted = append(i.redacted, field)
+		}
+	}
+}
+
+// IsRedacted は項目が非表示にされているかを返す
+func (i *Item) IsRedacted(field string) bool {
+	return slices.Contains(i.redacted, field)
+}
+
+// RedactedFields は非表示にされている項目を返す
+func (i *Item) RedactedFields() []string {
+	return slices.Clone(i.redacted)
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseItemRedaction(t *testing.T) {
+	redaction, err := ParseItemRedaction([]string{"viewer:purchase_price|serial_number", " editor : purchase_price ", ""})
+	require.NoError(t, err)
+	assert.Equal(t, map[OrgRole][]string{
+		OrgRoleViewer: {ItemFieldPurchasePrice, ItemFieldSerialNumber},
+		OrgRoleEditor: {ItemFieldPurchasePrice},
+	}, redaction)
+
+	redaction, err = ParseItemRedaction([]string{"none"})
+	require.NoError(t, err)
+	assert.Empty(t, redaction)
+
+	_, err = ParseItemRedaction([]string{"guest:purchase_price"})
+	assert.ErrorContains(t, err, "role owner, editor or viewer")
+	_, err = ParseItemRedaction([]string{"viewer:name"})
+	assert.ErrorContains(t, err, "field must be one of")
+	_, err = ParseItemRedaction([]string{"viewer"})
+	assert.Error(t, err)
+}
+
+func TestItemRedactionFor(t *testing.T) {
+	viewer := &User{ID: 1, Role: RoleEditor, Memberships: []Membership{{OrganizationID: 10, UserID: 1, Role: OrgRoleViewer}}}
+	editor := &User{ID: 2, Role: RoleEditor, Memberships: []Membership{{OrganizationID: 10, UserID: 2, Role: OrgRoleEditor}}}
+
+	assert.Equal(t, []string{ItemFieldPurchasePrice}, ItemRedactionFor(viewer, 10))
+	assert.Empty(t, ItemRedactionFor(editor, 10))
+	// 個人のアイテム、所属しない組織、管理者には適用しない
+	assert.Empty(t, ItemRedactionFor(viewer, 0))
+	assert.Empty(t, ItemRedactionFor(viewer, 20))
+	assert.Empty(t, ItemRedactionFor(&User{ID: 3, Role: RoleAdmin}, 10))
+}
+
+func TestItem_RedactJSON(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1234567), "2023-01-01")
+	require.NoError(t, err)
+	item.SerialNumber = "Z123456"
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"purchase_price":1234567`)
+	assert.Contains(t, string(data), `"redacted_fields":[]`)
+
+	item.Redact(ItemFieldPurchasePrice, ItemFieldPurchaseDate, ItemFieldSerialNumber, "name")
+	assert.Equal(t, 0, item.PurchasePrice.Amount)
+	assert.Equal(t, "時計1", item.Name)
+	data, err = json.Marshal(item)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "1234567")
+	assert.NotContains(t, string(data), "Z123456")
+	assert.Contains(t, string(data), `"purchase_price":null`)
+	assert.Contains(t, string(data), `"purchase_date":null`)
+	assert.Contains(t, string(data), `"redacted_fields":["purchase_price","purchase_date","serial_number"]`)
+
+	var decoded Item
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, decoded.IsRedacted(ItemFieldPurchasePrice))
+	assert.Equal(t, CurrencyJPY, decoded.PurchasePrice.Currency)
+
+	// 検索結果と最近表示したアイテムも同じ表現を使う
+	for _, v := range []any{SearchResult{Item: item}, RecentlyViewedItem{Item: item}} {
+		data, err := json.Marshal(v)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"purchase_price":null`)
+	}
+}
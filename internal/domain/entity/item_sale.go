@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// BuyerNotesMaxLength は購入者のメモの最大文字数
+const BuyerNotesMaxLength = 1000
+
+// ItemSale はアイテムを売却した記録（アイテムごとに1件）。金額は売却時のアイテムの購入価格と同じ通貨
+type ItemSale struct {
+	ID        int64
+	ItemID    int64
+	SoldPrice Money
+	// Fees は販売手数料・送料など売却にかかった費用
+	Fees     Money
+	SoldDate string // YYYY-MM-DD 形式
+	// BuyerNotes は購入者についてのメモ（未設定は空）
+	BuyerNotes string
+	CreatedAt  time.Time
+
+	// Profit は売却価格から購入価格と費用を引いた損益（SetProfit で設定する。計算できない場合は nil）
+	Profit *Money
+}
+
+// NewItemSale は item を売却した記録を作成する（売却価格と費用は item の購入価格の通貨で、補助単位を小数にした金額）
+func NewItemSale(item *Item, soldPrice, fees Decimal, soldDate, buyerNotes string, now time.Time) (*ItemSale, error) {
+	var errs domainErrors.ValidationErrors
+	currency := string(item.PurchasePrice.Currency)
+
+	sold, err := MoneyFromDecimal(soldPrice, currency)
+	if err != nil {
+		errs.Add("sold_price", domainErrors.CodeInvalidFormat, "sold_price "+err.Error())
+	}
+	fee, err := MoneyFromDecimal(fees, currency)
+	if err != nil {
+		errs.Add("fees", domainErrors.CodeInvalidFormat, "fees "+err.Error())
+	}
+
+	sale := &ItemSale{
+		ItemID:     item.ID,
+		SoldPrice:  sold,
+		Fees:       fee,
+		SoldDate:   strings.TrimSpace(soldDate),
+		BuyerNotes: strings.TrimSpace(buyerNotes),
+		CreatedAt:  now,
+	}
+	errs = append(errs, sale.validate(item, now)...)
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return sale, nil
+}
+
+func (s *ItemSale) validate(item *Item, now time.Time) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
+
+	for _, amount := range []struct {
+		field string
+		money Money
+	}{{"sold_price", s.SoldPrice}, {"fees", s.Fees}} {
+		if amount.money.Amount < 0 {
+			errs.Add(amount.field, domainErrors.CodeOutOfRange, amount.field+" must be 0 or greater")
+		} else if amount.money.Amount > MaxPrice {
+			errs.Add(amount.field, domainErrors.CodeOutOfRange, fmt.Sprintf("%s must be %s or less", amount.field, Money{Amount: MaxPrice, Currency: amount.money.Currency}.Decimal()))
+		}
+	}
+
+	// YYYY-MM-DD 形式同士は文字列比較で前後関係を判定できる
+	switch {
+	case s.SoldDate == "":
+		errs.Add("sold_date", domainErrors.CodeRequired, "sold_date is required")
+	case !isValidDateFormat(s.SoldDate):
+		errs.Add("sold_date", domainErrors.CodeInvalidFormat, "sold_date must be in YYYY-MM-DD format")
+	case s.SoldDate > now.UTC().Add(latestTimeZoneOffset).Format("2006-01-02"):
+		errs.Add("sold_date", domainErrors.CodeDateNotAllowed, "sold_date must not be in the future")
+	case s.SoldDate < item.PurchaseDate:
+		errs.Add("sold_date", domainErrors.CodeDateNotAllowed, "sold_date must be on or after purchase_date")
+	}
+
+	if utf8.RuneCountInString(s.BuyerNotes) > BuyerNotesMaxLength {
+		errs.Add("buyer_notes", domainErrors.CodeTooLong, fmt.Sprintf("buyer_notes must be %d characters or less", BuyerNotesMaxLength))
+	}
+
+	return errs
+}
+
+// SetProfit は購入価格から損益を計算する。購入価格を非表示にしたアイテムと、
+// 売却後に購入価格の通貨を変更したアイテムは損益を計算しない
+func (s *ItemSale) SetProfit(item *Item) {
+	s.Profit = nil
+	if item.IsRedacted(ItemFieldPurchasePrice) || item.PurchasePrice.Currency != s.SoldPrice.Currency {
+		return
+	}
+	s.Profit = &Money{
+		Amount:   s.SoldPrice.Amount - item.PurchasePrice.Amount - s.Fees.Amount,
+		Currency: s.SoldPrice.Currency,
+	}
+}
+
+// MarshalJSON は金額を補助単位を小数にした10進数とし、通貨を currency に分ける
+func (s ItemSale) MarshalJSON() ([]byte, error) {
+	var profit *Decimal
+	if s.Profit != nil {
+		d := s.Profit.Decimal()
+		profit = &d
+	}
+	var buyerNotes *string
+	if s.BuyerNotes != "" {
+		buyerNotes = &s.BuyerNotes
+	}
+	return json.Marshal(struct {
+		ID         int64     `json:"id"`
+		ItemID     int64     `json:"item_id"`
+		SoldPrice  Decimal   `json:"sold_price"`
+		Fees       Decimal   `json:"fees"`
+		Currency   Currency  `json:"currency"`
+		SoldDate   string    `json:"sold_date"`
+		BuyerNotes *string   `json:"buyer_notes"`
+		Profit     *Decimal  `json:"profit"`
+		CreatedAt  time.Time `json:"created_at"`
+	}{s.ID, s.ItemID, s.SoldPrice.Decimal(), s.Fees.Decimal(), s.SoldPrice.Currency, s.SoldDate, buyerNotes, profit, s.CreatedAt})
+}
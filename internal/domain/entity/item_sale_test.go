@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestNewItemSale(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	item, err := NewItem("時計1", "時計", "ROLEX", Money{Amount: 150000, Currency: CurrencyUSD}, "2023-01-15")
+	require.NoError(t, err)
+	item.ID = 1
+
+	t.Run("正常系: 金額はアイテムの購入価格の通貨で記録する", func(t *testing.T) {
+		sale, err := NewItemSale(item, Decimal{Units: 180050, Scale: 2}, Decimal{Units: 90, Scale: 0}, " 2024-05-31 ", " 店頭で販売 ", now)
+		require.NoError(t, err)
+		assert.Equal(t, Money{Amount: 180050, Currency: CurrencyUSD}, sale.SoldPrice)
+		assert.Equal(t, Money{Amount: 9000, Currency: CurrencyUSD}, sale.Fees)
+		assert.Equal(t, "2024-05-31", sale.SoldDate)
+		assert.Equal(t, "店頭で販売", sale.BuyerNotes)
+	})
+
+	tests := []struct {
+		name      string
+		soldPrice Decimal
+		fees      Decimal
+		soldDate  string
+		field     string
+		code      string
+	}{
+		{"売却価格が負", Decimal{Units: -1}, Decimal{}, "2024-05-31", "sold_price", domainErrors.CodeOutOfRange},
+		{"補助単位より細かい端数", Decimal{Units: 1001, Scale: 3}, Decimal{}, "2024-05-31", "sold_price", domainErrors.CodeInvalidFormat},
+		{"費用が負", Decimal{Units: 1000}, Decimal{Units: -1}, "2024-05-31", "fees", domainErrors.CodeOutOfRange},
+		{"売却日がない", Decimal{Units: 1000}, Decimal{}, "", "sold_date", domainErrors.CodeRequired},
+		{"売却日の形式", Decimal{Units: 1000}, Decimal{}, "2024/05/31", "sold_date", domainErrors.CodeInvalidFormat},
+		{"未来の売却日", Decimal{Units: 1000}, Decimal{}, "2024-06-03", "sold_date", domainErrors.CodeDateNotAllowed},
+		{"購入日より前の売却日", Decimal{Units: 1000}, Decimal{}, "2023-01-14", "sold_date", domainErrors.CodeDateNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run("異常系: "+tt.name, func(t *testing.T) {
+			_, err := NewItemSale(item, tt.soldPrice, tt.fees, tt.soldDate, "", now)
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.field, errs[0].Field)
+			assert.Equal(t, tt.code, errs[0].Code)
+		})
+	}
+}
+
+func TestItemSale_SetProfit(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1500000), "2023-01-15")
+	require.NoError(t, err)
+	sale := &ItemSale{SoldPrice: JPY(1800000), Fees: JPY(90000), SoldDate: "2024-05-31"}
+
+	sale.SetProfit(item)
+	require.NotNil(t, sale.Profit)
+	assert.Equal(t, JPY(210000), *sale.Profit)
+
+	// 売却価格が購入価格と費用を下回る場合は損失
+	sale.SoldPrice = JPY(1400000)
+	sale.SetProfit(item)
+	assert.Equal(t, JPY(-190000), *sale.Profit)
+
+	// 売却後に購入価格の通貨を変更したアイテムは計算しない
+	item.PurchasePrice = Money{Amount: 1000000, Currency: CurrencyUSD}
+	sale.SetProfit(item)
+	assert.Nil(t, sale.Profit)
+
+	// 購入価格が非表示のアイテムは計算しない
+	item.PurchasePrice = JPY(1500000)
+	item.Redact(ItemFieldPurchasePrice)
+	sale.SetProfit(item)
+	assert.Nil(t, sale.Profit)
+}
+
+func TestItemSale_JSON(t *testing.T) {
+	profit := Money{Amount: 2105, Currency: CurrencyUSD}
+	sale := ItemSale{
+		ID: 1, ItemID: 2,
+		SoldPrice: Money{Amount: 180050, Currency: CurrencyUSD}, Fees: Money{Amount: 9000, Currency: CurrencyUSD},
+		SoldDate: "2024-05-31", Profit: &profit,
+		CreatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(sale)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 1, "item_id": 2, "sold_price": 1800.50, "fees": 90.00, "currency": "USD",
+		"sold_date": "2024-05-31", "buyer_notes": null, "profit": 21.05, "created_at": "2024-06-01T00:00:00Z"
+	}`, string(data))
+}
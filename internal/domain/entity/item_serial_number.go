@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// SerialNumberMaxLength はシリアル番号の最大文字数（items.serial_number 列の大きさ）
+const SerialNumberMaxLength = 64
+
+// シリアル番号に使える文字（英数字で始まり、英数字・ハイフン・ピリオド・スラッシュ・空白が続く）
+var serialNumberPattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9./ -]*$`)
+
+// NormalizeSerialNumber は前後の空白を除き、英字を大文字にする
+// （刻印の読み取りで大文字・小文字が揺れても同じシリアル番号として扱うため）
+func NormalizeSerialNumber(serialNumber string) string {
+	return strings.ToUpper(strings.TrimSpace(serialNumber))
+}
+
+// validateSerialNumber は正規化したシリアル番号を検証する（空は未設定）
+func validateSerialNumber(serialNumber string) *domainErrors.FieldError {
+	if serialNumber == "" {
+		return nil
+	}
+	if utf8.RuneCountInString(serialNumber) > SerialNumberMaxLength {
+		return &domainErrors.FieldError{Field: "serial_number", Code: domainErrors.CodeTooLong, Message: fmt.Sprintf("serial_number must be %d characters or less", SerialNumberMaxLength)}
+	}
+	if !serialNumberPattern.MatchString(serialNumber) {
+		return &domainErrors.FieldError{Field: "serial_number", Code: domainErrors.CodeInvalidFormat, Message: "serial_number must start with a letter or digit and contain only letters, digits, spaces, '-', '.' and '/'"}
+	}
+	return nil
+}
+
+// SetSerialNumber はシリアル番号を変更する（空文字は未設定に戻す）
+func (i *Item) SetSerialNumber(serialNumber string) error {
+	s := NormalizeSerialNumber(serialNumber)
+	if fe := validateSerialNumber(s); fe != nil {
+		return domainErrors.ValidationErrors{*fe}
+	}
+	if s != i.SerialNumber {
+		i.SerialNumber = s
+		i.UpdatedAt = time.Now()
+	}
+	return nil
+}
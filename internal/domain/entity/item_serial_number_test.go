@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItem_SetSerialNumber(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+	assert.Empty(t, item.SerialNumber)
+
+	// 前後の空白を除き、英字を大文字にする
+	require.NoError(t, item.SetSerialNumber(" v8-12345/a "))
+	assert.Equal(t, "V8-12345/A", item.SerialNumber)
+
+	tests := []struct {
+		name  string
+		value string
+		code  string
+	}{
+		{name: "記号で始まる", value: "-123", code: domainErrors.CodeInvalidFormat},
+		{name: "使えない文字", value: "AB#123", code: domainErrors.CodeInvalidFormat},
+		{name: "長すぎる", value: strings.Repeat("A", SerialNumberMaxLength+1), code: domainErrors.CodeTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := item.SetSerialNumber(tt.value)
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			assert.Equal(t, "serial_number", errs[0].Field)
+			assert.Equal(t, tt.code, errs[0].Code)
+			assert.Equal(t, "V8-12345/A", item.SerialNumber)
+		})
+	}
+
+	// 空文字は未設定に戻す
+	require.NoError(t, item.SetSerialNumber(""))
+	assert.Empty(t, item.SerialNumber)
+}
+
+func TestItem_ValidateSerialNumber(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+
+	item.SerialNumber = "A 123"
+	assert.NoError(t, item.Validate())
+
+	item.SerialNumber = "シリアル"
+	assert.ErrorContains(t, item.Validate(), "serial_number must start with a letter or digit")
+}
+
+func TestItem_SerialNumberJSON(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"serial_number":null`)
+
+	item.SerialNumber = "Z123456"
+	data, err = json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"serial_number":"Z123456"`)
+
+	var decoded Item
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "Z123456", decoded.SerialNumber)
+}
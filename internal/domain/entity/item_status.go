@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ItemStatus はアイテムの所有状況
+type ItemStatus string
+
+const (
+	// ItemStatusOwned は手元に所有している（登録時の状況）
+	ItemStatusOwned ItemStatus = "owned"
+	// ItemStatusListedForSale は販売に出品している
+	ItemStatusListedForSale ItemStatus = "listed_for_sale"
+	// ItemStatusSold は売却した
+	ItemStatusSold ItemStatus = "sold"
+	// ItemStatusConsigned は販売を委託して預けている
+	ItemStatusConsigned ItemStatus = "consigned"
+	// ItemStatusLost は紛失した
+	ItemStatusLost ItemStatus = "lost"
+	// ItemStatusGifted は譲渡した
+	ItemStatusGifted ItemStatus = "gifted"
+)
+
+// 所有状況の検証エラーのメッセージ
+const statusErrorMessage = "status must be one of: owned, listed_for_sale, sold, consigned, lost, gifted"
+
+var ValidItemStatuses = []ItemStatus{ItemStatusOwned, ItemStatusListedForSale, ItemStatusSold, ItemStatusConsigned, ItemStatusLost, ItemStatusGifted}
+
+// itemStatusTransitions は所有状況ごとの変更できる状況。
+// 売却・譲渡は手放した後の状況のため変更できず、紛失は見つかった場合に所有に戻せる
+var itemStatusTransitions = map[ItemStatus][]ItemStatus{
+	ItemStatusOwned:         {ItemStatusListedForSale, ItemStatusConsigned, ItemStatusSold, ItemStatusLost, ItemStatusGifted},
+	ItemStatusListedForSale: {ItemStatusOwned, ItemStatusSold, ItemStatusLost},
+	ItemStatusConsigned:     {ItemStatusOwned, ItemStatusSold, ItemStatusLost},
+	ItemStatusLost:          {ItemStatusOwned},
+}
+
+// IsValidItemStatus は指定された所有状況が定義済みかを返す
+func IsValidItemStatus(s ItemStatus) bool {
+	for _, valid := range ValidItemStatuses {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransitionTo は所有状況を to に変更できるかを返す（同じ状況への変更は含まない）
+func (s ItemStatus) CanTransitionTo(to ItemStatus) bool {
+	for _, next := range itemStatusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsHeld は手放していない（所有・出品中・委託中の）状況かを返す
+func (s ItemStatus) IsHeld() bool {
+	return s == ItemStatusOwned || s == ItemStatusListedForSale || s == ItemStatusConsigned
+}
+
+// ChangeStatus は所有状況を変更する（同じ状況の指定は何もしない）
+func (i *Item) ChangeStatus(status string) error {
+	s := ItemStatus(strings.TrimSpace(status))
+	if !IsValidItemStatus(s) {
+		return domainErrors.ValidationErrors{{Field: "status", Code: domainErrors.CodeInvalidChoice, Message: statusErrorMessage}}
+	}
+	if s == i.Status {
+		return nil
+	}
+	if !i.Status.CanTransitionTo(s) {
+		return domainErrors.ValidationErrors{{Field: "status", Code: domainErrors.CodeNotAllowed, Message: fmt.Sprintf("status cannot be changed from %s to %s", i.Status, s)}}
+	}
+	i.Status = s
+	i.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItem_ChangeStatus(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, ItemStatusOwned, item.Status)
+
+	require.NoError(t, item.ChangeStatus(" listed_for_sale "))
+	assert.Equal(t, ItemStatusListedForSale, item.Status)
+
+	// 同じ状況の指定は何もしない
+	require.NoError(t, item.ChangeStatus("listed_for_sale"))
+
+	err = item.ChangeStatus("reserved")
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, "status", errs[0].Field)
+	assert.Equal(t, domainErrors.CodeInvalidChoice, errs[0].Code)
+
+	// 出品中のアイテムは委託に出せない
+	err = item.ChangeStatus("consigned")
+	errs, ok = domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.CodeNotAllowed, errs[0].Code)
+	assert.Equal(t, ItemStatusListedForSale, item.Status)
+
+	require.NoError(t, item.ChangeStatus("sold"))
+	assert.Equal(t, ItemStatusSold, item.Status)
+}
+
+func TestItemStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to ItemStatus
+		want     bool
+	}{
+		{ItemStatusOwned, ItemStatusListedForSale, true},
+		{ItemStatusOwned, ItemStatusGifted, true},
+		{ItemStatusListedForSale, ItemStatusOwned, true},
+		{ItemStatusConsigned, ItemStatusSold, true},
+		{ItemStatusLost, ItemStatusOwned, true},
+		{ItemStatusLost, ItemStatusSold, false},
+		{ItemStatusSold, ItemStatusOwned, false},
+		{ItemStatusGifted, ItemStatusOwned, false},
+		{ItemStatusOwned, ItemStatusOwned, false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.from.CanTransitionTo(tt.to), "%s -> %s", tt.from, tt.to)
+	}
+}
+
+func TestItemFilter_ValidateStatus(t *testing.T) {
+	assert.NoError(t, ItemFilter{Status: ItemStatusSold}.Validate())
+	assert.ErrorContains(t, ItemFilter{Status: "reserved"}.Validate(), "status must be one of")
+}
@@ -1,11 +1,15 @@
 package entity
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 func TestNewItem(t *testing.T) {
@@ -40,7 +44,7 @@ func TestNewItem(t *testing.T) {
 		},
 		{
 			name:          "異常系: 名前が100文字超過",
-			itemName:      "ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム",
+			itemName:      strings.Repeat("あ", 101),
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
@@ -48,6 +52,15 @@ func TestNewItem(t *testing.T) {
 			wantErr:       true,
 			expectedErr:   "name must be 100 characters or less",
 		},
+		{
+			name:          "正常系: 日本語の名前は100文字まで",
+			itemName:      strings.Repeat("あ", 100),
+			category:      "時計",
+			brand:         strings.Repeat("ロ", 100),
+			purchasePrice: 1500000,
+			purchaseDate:  "2023-01-15",
+			wantErr:       false,
+		},
 		{
 			name:          "異常系: カテゴリーが空",
 			itemName:      "ロレックス デイトナ",
@@ -131,7 +144,7 @@ func TestNewItem(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			item, err := NewItem(tt.itemName, tt.category, tt.brand, tt.purchasePrice, tt.purchaseDate)
+			item, err := NewItem(tt.itemName, tt.category, tt.brand, JPY(tt.purchasePrice), tt.purchaseDate)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -147,7 +160,7 @@ func TestNewItem(t *testing.T) {
 			assert.Equal(t, tt.itemName, item.Name)
 			assert.Equal(t, tt.category, item.Category)
 			assert.Equal(t, tt.brand, item.Brand)
-			assert.Equal(t, tt.purchasePrice, item.PurchasePrice)
+			assert.Equal(t, JPY(tt.purchasePrice), item.PurchasePrice)
 			assert.Equal(t, tt.purchaseDate, item.PurchaseDate)
 
 			// CreatedAt と UpdatedAt がセットされているかチェック
@@ -159,7 +172,7 @@ func TestNewItem(t *testing.T) {
 
 func TestItem_Update(t *testing.T) {
 	// 初期アイテムを作成
-	item, err := NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+	item, err := NewItem("初期アイテム", "時計", "初期ブランド", JPY(100000), "2023-01-01")
 	require.NoError(t, err)
 
 	originalUpdatedAt := item.UpdatedAt
@@ -208,7 +221,7 @@ func TestItem_Update(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := item.Update(tt.newName, tt.newCategory, tt.newBrand, tt.newPrice, tt.newDate)
+			err := item.Update(tt.newName, tt.newCategory, tt.newBrand, JPY(tt.newPrice), tt.newDate)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -222,7 +235,7 @@ func TestItem_Update(t *testing.T) {
 			assert.Equal(t, tt.newName, item.Name)
 			assert.Equal(t, tt.newCategory, item.Category)
 			assert.Equal(t, tt.newBrand, item.Brand)
-			assert.Equal(t, tt.newPrice, item.PurchasePrice)
+			assert.Equal(t, JPY(tt.newPrice), item.PurchasePrice)
 			assert.Equal(t, tt.newDate, item.PurchaseDate)
 
 			// UpdatedAt が更新されているかチェック
@@ -244,7 +257,7 @@ func TestItem_Validate(t *testing.T) {
 				Name:          "ロレックス デイトナ",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: JPY(1500000),
 				PurchaseDate:  "2023-01-15",
 			},
 			wantErr: false,
@@ -255,7 +268,7 @@ func TestItem_Validate(t *testing.T) {
 				Name:          "",
 				Category:      "",
 				Brand:         "",
-				PurchasePrice: -1,
+				PurchasePrice: JPY(-1),
 				PurchaseDate:  "",
 			},
 			wantErr:     true,
@@ -278,6 +291,28 @@ func TestItem_Validate(t *testing.T) {
 	}
 }
 
+func TestItem_Validate_FieldErrors(t *testing.T) {
+	item := &Item{
+		Name:          strings.Repeat("a", 101),
+		Category:      "家具",
+		Brand:         "ROLEX",
+		PurchasePrice: JPY(-1),
+		PurchaseDate:  "2023/01/15",
+		Visibility:    "everyone",
+	}
+
+	fieldErrs, ok := domainErrors.AsValidationErrors(item.Validate())
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.ValidationErrors{
+		{Field: "name", Code: domainErrors.CodeTooLong, Message: "name must be 100 characters or less"},
+		{Field: "category", Code: domainErrors.CodeInvalidChoice, Message: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他"},
+		{Field: "purchase_price", Code: domainErrors.CodeOutOfRange, Message: "purchase_price must be 0 or greater"},
+		{Field: "purchase_date", Code: domainErrors.CodeInvalidFormat, Message: "purchase_date must be in YYYY-MM-DD format"},
+		{Field: "visibility", Code: domainErrors.CodeInvalidChoice, Message: "visibility must be one of: private, shared, public"},
+	}, fieldErrs)
+	assert.True(t, domainErrors.IsValidationError(item.Validate()))
+}
+
 func TestIsValidCategory(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -338,19 +373,19 @@ func TestGetValidCategories(t *testing.T) {
 
 func TestItem_UpdatePartial(t *testing.T) {
 	tests := []struct {
-		name          string
-		initialName   string
-		initialBrand  string
-		initialPrice  int
-		newName       *string
-		newBrand      *string
-		newPrice      *int
-		wantErr       bool
-		expectedErr   string
-		checkName     string
-		checkBrand    string
-		checkPrice    int
-		checkUpdated  bool
+		name         string
+		initialName  string
+		initialBrand string
+		initialPrice int
+		newName      *string
+		newBrand     *string
+		newPrice     *Money
+		wantErr      bool
+		expectedErr  string
+		checkName    string
+		checkBrand   string
+		checkPrice   int
+		checkUpdated bool
 	}{
 		{
 			name:         "正常系: nameのみ更新",
@@ -387,7 +422,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 			initialPrice: 100000,
 			newName:      nil,
 			newBrand:     nil,
-			newPrice:     intPtr(200000),
+			newPrice:     jpyPtr(200000),
 			wantErr:      false,
 			checkName:    "初期アイテム",
 			checkBrand:   "初期ブランド",
@@ -415,7 +450,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 			initialPrice: 100000,
 			newName:      stringPtr("最終的な名前"),
 			newBrand:     stringPtr("最終的なブランド"),
-			newPrice:     intPtr(300000),
+			newPrice:     jpyPtr(300000),
 			wantErr:      false,
 			checkName:    "最終的な名前",
 			checkBrand:   "最終的なブランド",
@@ -429,7 +464,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 			initialPrice: 100000,
 			newName:      nil,
 			newBrand:     nil,
-			newPrice:     intPtr(0),
+			newPrice:     jpyPtr(0),
 			wantErr:      false,
 			checkName:    "初期アイテム",
 			checkBrand:   "初期ブランド",
@@ -452,7 +487,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 			initialName:  "初期アイテム",
 			initialBrand: "初期ブランド",
 			initialPrice: 100000,
-			newName:      stringPtr("ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム"),
+			newName:      stringPtr(strings.Repeat("あ", 101)),
 			newBrand:     nil,
 			newPrice:     nil,
 			wantErr:      true,
@@ -487,7 +522,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 			initialPrice: 100000,
 			newName:      nil,
 			newBrand:     nil,
-			newPrice:     intPtr(-1),
+			newPrice:     jpyPtr(-1),
 			wantErr:      true,
 			expectedErr:  "purchase_price must be 0 or greater",
 		},
@@ -498,7 +533,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 			initialPrice: 100000,
 			newName:      stringPtr(""),
 			newBrand:     stringPtr(""),
-			newPrice:     intPtr(-1),
+			newPrice:     jpyPtr(-1),
 			wantErr:      true,
 			expectedErr:  "name is required",
 		},
@@ -507,7 +542,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// 各テストで新しいアイテムを作成
-			item, err := NewItem(tt.initialName, "時計", tt.initialBrand, tt.initialPrice, "2023-01-01")
+			item, err := NewItem(tt.initialName, "時計", tt.initialBrand, JPY(tt.initialPrice), "2023-01-01")
 			require.NoError(t, err)
 
 			originalID := item.ID
@@ -536,7 +571,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 				assert.Equal(t, tt.checkBrand, item.Brand)
 			}
 			if tt.checkPrice != 0 || tt.newPrice != nil {
-				assert.Equal(t, tt.checkPrice, item.PurchasePrice)
+				assert.Equal(t, tt.checkPrice, item.PurchasePrice.Amount)
 			}
 
 			// 不変フィールドが保持されているかチェック
@@ -555,7 +590,7 @@ func TestItem_UpdatePartial(t *testing.T) {
 
 func TestItem_UpdatePartial_ImmutableFields(t *testing.T) {
 	// 不変フィールドが保持されることを確認する専用テスト
-	item, err := NewItem("テストアイテム", "時計", "テストブランド", 100000, "2023-01-01")
+	item, err := NewItem("テストアイテム", "時計", "テストブランド", JPY(100000), "2023-01-01")
 	require.NoError(t, err)
 
 	originalID := item.ID
@@ -580,7 +615,7 @@ func TestItem_UpdatePartial_ImmutableFields(t *testing.T) {
 
 func TestItem_UpdatePartial_WhitespaceHandling(t *testing.T) {
 	// 空白文字の処理を確認するテスト
-	item, err := NewItem("テストアイテム", "時計", "テストブランド", 100000, "2023-01-01")
+	item, err := NewItem("テストアイテム", "時計", "テストブランド", JPY(100000), "2023-01-01")
 	require.NoError(t, err)
 
 	// 前後に空白がある名前で更新
@@ -601,6 +636,141 @@ func stringPtr(s string) *string {
 	return &s
 }
 
-func intPtr(i int) *int {
-	return &i
+func jpyPtr(amount int) *Money {
+	price := JPY(amount)
+	return &price
+}
+
+func TestNewItem_ConfiguredMaxLength(t *testing.T) {
+	defer func(name, brand int) {
+		ItemNameMaxLength, ItemBrandMaxLength = name, brand
+	}(ItemNameMaxLength, ItemBrandMaxLength)
+	ItemNameMaxLength, ItemBrandMaxLength = 10, 5
+
+	_, err := NewItem(strings.Repeat("時", 10), "時計", "ロレックス", JPY(1000), "2023-01-15")
+	require.NoError(t, err)
+
+	_, err = NewItem(strings.Repeat("時", 11), "時計", "ROLEX!", JPY(1000), "2023-01-15")
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.ValidationErrors{
+		{Field: "name", Code: domainErrors.CodeTooLong, Message: "name must be 10 characters or less"},
+		{Field: "brand", Code: domainErrors.CodeTooLong, Message: "brand must be 5 characters or less"},
+	}, errs)
+}
+
+func TestNewItem_MaxPurchasePrice(t *testing.T) {
+	_, err := NewItem("ロレックス デイトナ", "時計", "ROLEX", JPY(MaxPrice), "2023-01-15")
+	require.NoError(t, err)
+
+	_, err = NewItem("ロレックス デイトナ", "時計", "ROLEX", JPY(MaxPrice+1), "2023-01-15")
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.ValidationErrors{
+		{Field: "purchase_price", Code: domainErrors.CodeOutOfRange, Message: "purchase_price must be 2147483647 or less"},
+	}, errs)
+}
+
+func TestValidatePurchaseDatePolicy(t *testing.T) {
+	defer func(allowFuture bool, maxAge int) {
+		PurchaseDateAllowFuture, PurchaseDateMaxAgeYears = allowFuture, maxAge
+	}(PurchaseDateAllowFuture, PurchaseDateMaxAgeYears)
+
+	// 日本時間では 2024-06-11 の朝
+	now := time.Date(2024, 6, 10, 23, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		allowFuture  bool
+		maxAgeYears  int
+		purchaseDate string
+		expectedErr  string
+	}{
+		{name: "正常系: 今日", purchaseDate: "2024-06-10"},
+		{name: "正常系: 進んだタイムゾーンの今日", purchaseDate: "2024-06-11"},
+		{name: "異常系: 未来の日付", purchaseDate: "2024-06-12", expectedErr: "purchase_date must not be in the future"},
+		{name: "異常系: 入力ミスの未来の年", purchaseDate: "2203-01-15", expectedErr: "purchase_date must not be in the future"},
+		{name: "正常系: 未来の日付を許可", allowFuture: true, purchaseDate: "2203-01-15"},
+		{name: "正常系: 古さを制限しない", purchaseDate: "1900-01-01"},
+		{name: "正常系: 制限した年数ちょうど", maxAgeYears: 50, purchaseDate: "1974-06-10"},
+		{name: "異常系: 制限した年数より古い", maxAgeYears: 50, purchaseDate: "1974-06-08", expectedErr: "purchase_date must be within the last 50 years"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			PurchaseDateAllowFuture, PurchaseDateMaxAgeYears = tt.allowFuture, tt.maxAgeYears
+
+			fe := validatePurchaseDatePolicy(tt.purchaseDate, now)
+			if tt.expectedErr == "" {
+				assert.Nil(t, fe)
+				return
+			}
+			require.NotNil(t, fe)
+			assert.Equal(t, "purchase_date", fe.Field)
+			assert.Equal(t, domainErrors.CodeDateNotAllowed, fe.Code)
+			assert.Equal(t, tt.expectedErr, fe.Message)
+		})
+	}
+}
+
+func TestItem_PurchaseCurrency(t *testing.T) {
+	t.Run("正常系: 外貨建ての購入価格", func(t *testing.T) {
+		item, err := NewItem("サブマリーナ", "時計", "ROLEX", NewMoney(1234500, "USD"), "2023-01-15")
+		require.NoError(t, err)
+
+		data, err := json.Marshal(item)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"purchase_price":12345.00,"purchase_currency":"USD"`)
+
+		var decoded Item
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		assert.Equal(t, item.PurchasePrice, decoded.PurchasePrice)
+		assert.Equal(t, item.Name, decoded.Name)
+	})
+
+	t.Run("正常系: purchase_currency のない JSON は円建て", func(t *testing.T) {
+		var item Item
+		require.NoError(t, json.Unmarshal([]byte(`{"name":"デイトナ","purchase_price":1500000}`), &item))
+		assert.Equal(t, JPY(1500000), item.PurchasePrice)
+	})
+
+	t.Run("正常系: 小数の購入価格は通貨の最小単位で保持する", func(t *testing.T) {
+		var item Item
+		require.NoError(t, json.Unmarshal([]byte(`{"purchase_price":123.4,"purchase_currency":"USD"}`), &item))
+		assert.Equal(t, NewMoney(12340, "USD"), item.PurchasePrice)
+
+		data, err := json.Marshal(item)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"purchase_price":123.40,"purchase_currency":"USD"`)
+	})
+
+	t.Run("異常系: 通貨の補助単位より細かい端数", func(t *testing.T) {
+		var item Item
+		assert.Error(t, json.Unmarshal([]byte(`{"purchase_price":1500.5}`), &item))
+		assert.Error(t, json.Unmarshal([]byte(`{"purchase_price":1.005,"purchase_currency":"USD"}`), &item))
+	})
+
+	t.Run("正常系: 検索結果と最近見たアイテムも通貨を含む", func(t *testing.T) {
+		item := &Item{ID: 1, PurchasePrice: NewMoney(500, "EUR")}
+
+		data, err := json.Marshal(&SearchResult{Item: item, Score: 1.5})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"purchase_currency":"EUR"`)
+		assert.Contains(t, string(data), `"score":1.5`)
+
+		data, err = json.Marshal(&RecentlyViewedItem{Item: item})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), `"purchase_currency":"EUR"`)
+		assert.Contains(t, string(data), `"viewed_at"`)
+	})
+
+	t.Run("異常系: 対応していない通貨", func(t *testing.T) {
+		_, err := NewItem("サブマリーナ", "時計", "ROLEX", NewMoney(1000, "GBP"), "2023-01-15")
+		require.Error(t, err)
+
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, "purchase_currency", errs[0].Field)
+		assert.Equal(t, domainErrors.CodeInvalidChoice, errs[0].Code)
+	})
 }
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ValuationNotesMaxLength は評価額のメモの最大文字数
+const ValuationNotesMaxLength = 1000
+
+// ItemValuation はある日付時点のアイテムの評価額（相場や査定額の見積もり）
+type ItemValuation struct {
+	ID       int64
+	ItemID   int64
+	Value    Money
+	ValuedOn string // YYYY-MM-DD 形式
+	// Notes は評価の根拠などのメモ（未設定は空）
+	Notes     string
+	CreatedAt time.Time
+}
+
+// NewItemValuation は item の評価額を作成する（金額は補助単位を小数にした金額。通貨を省略した場合は item の購入価格の通貨）
+func NewItemValuation(item *Item, value Decimal, currency, valuedOn, notes string, now time.Time) (*ItemValuation, error) {
+	var errs domainErrors.ValidationErrors
+
+	valuation := &ItemValuation{
+		ItemID:    item.ID,
+		ValuedOn:  strings.TrimSpace(valuedOn),
+		Notes:     strings.TrimSpace(notes),
+		CreatedAt: now,
+	}
+
+	if strings.TrimSpace(currency) == "" {
+		currency = string(item.PurchasePrice.Currency)
+	}
+	if c := NewMoney(0, currency).Currency; !c.IsValid() {
+		errs.Add("currency", domainErrors.CodeInvalidChoice, "currency must be one of: JPY, USD, EUR")
+	} else if money, err := MoneyFromDecimal(value, string(c)); err != nil {
+		errs.Add("value", domainErrors.CodeInvalidFormat, "value "+err.Error())
+	} else {
+		valuation.Value = money
+		if money.Amount < 0 {
+			errs.Add("value", domainErrors.CodeOutOfRange, "value must be 0 or greater")
+		} else if money.Amount > MaxPrice {
+			errs.Add("value", domainErrors.CodeOutOfRange, fmt.Sprintf("value must be %s or less", Money{Amount: MaxPrice, Currency: c}.Decimal()))
+		}
+	}
+
+	// YYYY-MM-DD 形式同士は文字列比較で前後関係を判定できる
+	switch {
+	case valuation.ValuedOn == "":
+		errs.Add("valued_on", domainErrors.CodeRequired, "valued_on is required")
+	case !isValidDateFormat(valuation.ValuedOn):
+		errs.Add("valued_on", domainErrors.CodeInvalidFormat, "valued_on must be in YYYY-MM-DD format")
+	case valuation.ValuedOn > now.UTC().Add(latestTimeZoneOffset).Format("2006-01-02"):
+		errs.Add("valued_on", domainErrors.CodeDateNotAllowed, "valued_on must not be in the future")
+	case valuation.ValuedOn < item.PurchaseDate:
+		errs.Add("valued_on", domainErrors.CodeDateNotAllowed, "valued_on must be on or after purchase_date")
+	}
+
+	if utf8.RuneCountInString(valuation.Notes) > ValuationNotesMaxLength {
+		errs.Add("notes", domainErrors.CodeTooLong, fmt.Sprintf("notes must be %d characters or less", ValuationNotesMaxLength))
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return valuation, nil
+}
+
+// MarshalJSON は金額を補助単位を小数にした10進数とし、通貨を currency に分ける
+func (v ItemValuation) MarshalJSON() ([]byte, error) {
+	var notes *string
+	if v.Notes != "" {
+		notes = &v.Notes
+	}
+	return json.Marshal(struct {
+		ID        int64     `json:"id"`
+		ItemID    int64     `json:"item_id"`
+		Value     Decimal   `json:"value"`
+		Currency  Currency  `json:"currency"`
+		ValuedOn  string    `json:"valued_on"`
+		Notes     *string   `json:"notes"`
+		CreatedAt time.Time `json:"created_at"`
+	}{v.ID, v.ItemID, v.Value.Decimal(), v.Value.Currency, v.ValuedOn, notes, v.CreatedAt})
+}
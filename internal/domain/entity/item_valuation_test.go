@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestNewItemValuation(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	item, err := NewItem("時計1", "時計", "ROLEX", Money{Amount: 150000, Currency: CurrencyUSD}, "2023-01-15")
+	require.NoError(t, err)
+	item.ID = 1
+
+	t.Run("正常系: 通貨を省略した場合はアイテムの購入価格の通貨で記録する", func(t *testing.T) {
+		valuation, err := NewItemValuation(item, Decimal{Units: 210050, Scale: 2}, "", " 2024-05-31 ", " 買取店の査定 ", now)
+		require.NoError(t, err)
+		assert.Equal(t, Money{Amount: 210050, Currency: CurrencyUSD}, valuation.Value)
+		assert.Equal(t, "2024-05-31", valuation.ValuedOn)
+		assert.Equal(t, "買取店の査定", valuation.Notes)
+	})
+
+	t.Run("正常系: 通貨を指定して記録する", func(t *testing.T) {
+		valuation, err := NewItemValuation(item, Decimal{Units: 3200000}, "jpy", "2024-05-31", "", now)
+		require.NoError(t, err)
+		assert.Equal(t, JPY(3200000), valuation.Value)
+	})
+
+	tests := []struct {
+		name     string
+		value    Decimal
+		currency string
+		valuedOn string
+		field    string
+		code     string
+	}{
+		{"評価額が負", Decimal{Units: -1}, "", "2024-05-31", "value", domainErrors.CodeOutOfRange},
+		{"補助単位より細かい端数", Decimal{Units: 1001, Scale: 3}, "", "2024-05-31", "value", domainErrors.CodeInvalidFormat},
+		{"対応していない通貨", Decimal{Units: 1000}, "GBP", "2024-05-31", "currency", domainErrors.CodeInvalidChoice},
+		{"評価日がない", Decimal{Units: 1000}, "", "", "valued_on", domainErrors.CodeRequired},
+		{"評価日の形式", Decimal{Units: 1000}, "", "2024/05/31", "valued_on", domainErrors.CodeInvalidFormat},
+		{"未来の評価日", Decimal{Units: 1000}, "", "2024-06-03", "valued_on", domainErrors.CodeDateNotAllowed},
+		{"購入日より前の評価日", Decimal{Units: 1000}, "", "2023-01-14", "valued_on", domainErrors.CodeDateNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run("異常系: "+tt.name, func(t *testing.T) {
+			_, err := NewItemValuation(item, tt.value, tt.currency, tt.valuedOn, "", now)
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			assert.Equal(t, tt.field, errs[0].Field)
+			assert.Equal(t, tt.code, errs[0].Code)
+		})
+	}
+}
+
+func TestItemValuation_JSON(t *testing.T) {
+	valuation := ItemValuation{
+		ID: 1, ItemID: 2, Value: Money{Amount: 210050, Currency: CurrencyUSD}, ValuedOn: "2024-05-31",
+		CreatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(valuation)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": 1, "item_id": 2, "value": 2100.50, "currency": "USD",
+		"valued_on": "2024-05-31", "notes": null, "created_at": "2024-06-01T00:00:00Z"
+	}`, string(data))
+}
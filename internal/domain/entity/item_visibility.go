@@ -0,0 +1,48 @@
+package entity
+
+// Visibility はアイテムを所有者以外に見せる範囲
+type Visibility string
+
+const (
+	// VisibilityPrivate は所有者だけが見られる（デフォルト）
+	VisibilityPrivate Visibility = "private"
+	// VisibilityShared は共有リンク・Webhook・エクスポートなど、所有者が渡した相手にだけ見せる
+	VisibilityShared Visibility = "shared"
+	// VisibilityPublic は公開ポートフォリオなど、誰でも見られる場所にも載せる
+	VisibilityPublic Visibility = "public"
+)
+
+var ValidVisibilities = []Visibility{VisibilityPrivate, VisibilityShared, VisibilityPublic}
+
+// Audience はアイテムを所有者以外に渡す際の受け手
+type Audience string
+
+const (
+	// AudienceShared は共有リンク・Webhook・エクスポートの受け手
+	AudienceShared Audience = "shared"
+	// AudiencePublic は公開ポートフォリオの閲覧者
+	AudiencePublic Audience = "public"
+)
+
+// IsValidVisibility は指定された公開範囲が定義済みかを返す
+func IsValidVisibility(v Visibility) bool {
+	for _, valid := range ValidVisibilities {
+		if v == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// VisibleTo は受け手にアイテムを見せてよいかを返す。
+// 未設定や不明な値は private として扱う。
+func (v Visibility) VisibleTo(audience Audience) bool {
+	switch audience {
+	case AudienceShared:
+		return v == VisibilityShared || v == VisibilityPublic
+	case AudiencePublic:
+		return v == VisibilityPublic
+	default:
+		return false
+	}
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItem_SetVisibility(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+	assert.Equal(t, VisibilityPrivate, item.Visibility)
+
+	require.NoError(t, item.SetVisibility(" public "))
+	assert.Equal(t, VisibilityPublic, item.Visibility)
+
+	assert.Error(t, item.SetVisibility("friends"))
+	assert.Equal(t, VisibilityPublic, item.Visibility)
+}
+
+func TestItem_ValidateVisibility(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+
+	item.Visibility = "friends"
+	assert.ErrorContains(t, item.Validate(), "visibility must be one of")
+
+	// 未設定は private として扱うため許容する
+	item.Visibility = ""
+	assert.NoError(t, item.Validate())
+}
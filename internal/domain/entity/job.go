@@ -0,0 +1,91 @@
+package entity
+
+import "time"
+
+// JobKind は非同期ジョブの種類
+type JobKind string
+
+const (
+	JobKindExport JobKind = "export"
+	JobKindImport JobKind = "import"
+	JobKindReport JobKind = "report"
+	// JobKindDigest はダイジェストメールの定期配信
+	JobKindDigest JobKind = "digest"
+	// JobKindMarketPrice は中古相場からの評価額の更新
+	JobKindMarketPrice JobKind = "market_price"
+	// JobKindColumnBackfill は列の移行のバックフィル
+	JobKindColumnBackfill JobKind = "column_backfill"
+	// JobKindBackupVerification はバックアップの検証用のスキーマへの読み込みと検証
+	JobKindBackupVerification JobKind = "backup_verification"
+)
+
+// JobStatus は非同期ジョブの状態
+type JobStatus string
+
+const (
+	JobStatusRunning   JobStatus = "running"
+	JobStatusSucceeded JobStatus = "succeeded"
+	JobStatusFailed    JobStatus = "failed"
+)
+
+type Job struct {
+	ID     int64     `json:"id"`
+	UserID string    `json:"user_id"`
+	Kind   JobKind   `json:"kind"`
+	Status JobStatus `json:"status"`
+	// Progress は進捗率（0〜100。成功すると100になる。進捗を報告しない処理は終了まで0）
+	Progress int    `json:"progress"`
+	Error    string `json:"error,omitempty"`
+	// Errors は処理を続けた一部の失敗（最大 MaxJobChunkErrors 件）
+	Errors     []JobChunkError `json:"errors,omitempty"`
+	CreatedAt  time.Time       `json:"created_at"`
+	FinishedAt *time.Time      `json:"finished_at"`
+	// ResultFile はジョブが出力したファイルの名前（GET /jobs/{id}/result でダウンロードする）
+	ResultFile string `json:"result_file,omitempty"`
+
+	// Result はジョブが出力したファイル（ジョブの処理で設定する）
+	Result *JobResult `json:"-"`
+}
+
+// MaxJobChunkErrors はジョブに記録する一部の失敗の最大件数
+const MaxJobChunkErrors = 100
+
+// JobChunkError はジョブの処理の一部（1ユーザーへの送信など）の失敗
+type JobChunkError struct {
+	// Chunk は失敗した部分の説明
+	Chunk   string `json:"chunk"`
+	Message string `json:"message"`
+}
+
+// JobEventType はジョブの進捗の通知の種類
+type JobEventType string
+
+const (
+	JobEventProgress   JobEventType = "progress"
+	JobEventChunkError JobEventType = "chunk_error"
+	// JobEventFinished はジョブの終了（最後の通知）
+	JobEventFinished JobEventType = "finished"
+)
+
+// JobEvent はジョブの進捗の通知
+type JobEvent struct {
+	Type JobEventType
+	// Progress は通知した時点の進捗率
+	Progress int
+	// ChunkError は chunk_error の場合の失敗
+	ChunkError *JobChunkError
+	// Job は finished の場合の終了したジョブ
+	Job *Job
+}
+
+// JobResult はジョブが出力したファイル
+type JobResult struct {
+	FileName    string
+	ContentType string
+	Body        []byte
+}
+
+// IsFinished はジョブが終了しているかを返す
+func (j *Job) IsFinished() bool {
+	return j.Status == JobStatusSucceeded || j.Status == JobStatusFailed
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Language はレスポンスの表示言語
+type Language string
+
+const (
+	LanguageJapanese Language = "ja"
+	LanguageEnglish  Language = "en"
+)
+
+// ParseAcceptLanguage は Accept-Language から対応している表示言語を選ぶ（q 値の大きい順。対応していない場合は日本語）
+func ParseAcceptLanguage(header string) Language {
+	best, bestQ := LanguageJapanese, 0.0
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		// en-US などの地域は区別しない
+		primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		var lang Language
+		switch Language(primary) {
+		case LanguageJapanese, LanguageEnglish:
+			lang = Language(primary)
+		default:
+			continue
+		}
+		// 同じ q 値の場合は先に書かれた言語を優先する
+		if q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
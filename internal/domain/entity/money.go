@@ -0,0 +1,161 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxPrice は1件の金額の上限。金額は通貨の最小単位の整数で扱い、DB の INT 列に収まる範囲とする
+const MaxPrice = math.MaxInt32
+
+// AddAmount は合計に金額を加える。int64 の範囲を超える場合は上限（下限）で止め、符号が反転しないようにする
+func AddAmount(total, amount int64) int64 {
+	if amount > 0 && total > math.MaxInt64-amount {
+		return math.MaxInt64
+	}
+	if amount < 0 && total < math.MinInt64-amount {
+		return math.MinInt64
+	}
+	return total + amount
+}
+
+// MulAmount は単価と数量の積を返す。int64 の範囲を超える場合は ok が false になる
+func MulAmount(price, quantity int64) (amount int64, ok bool) {
+	if price == 0 || quantity == 0 {
+		return 0, true
+	}
+	amount = price * quantity
+	if amount/quantity != price || (price == -1 && quantity == math.MinInt64) || (quantity == -1 && price == math.MinInt64) {
+		return 0, false
+	}
+	return amount, true
+}
+
+// Currency は ISO 4217 の通貨コード
+type Currency string
+
+const (
+	CurrencyJPY Currency = "JPY"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+)
+
+// SupportedCurrencies は購入価格に使える通貨
+var SupportedCurrencies = []Currency{CurrencyJPY, CurrencyUSD, CurrencyEUR}
+
+const currencyErrorMessage = "purchase_currency must be one of: JPY, USD, EUR"
+
+// IsValid は対応している通貨かを判定する
+func (c Currency) IsValid() bool {
+	for _, supported := range SupportedCurrencies {
+		if c == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// MinorUnits は補助単位の桁数（JPY は 0、USD・EUR はセントの 2）
+func (c Currency) MinorUnits() int {
+	if c == CurrencyJPY {
+		return 0
+	}
+	return 2
+}
+
+func (c Currency) symbol() string {
+	switch c {
+	case CurrencyJPY:
+		return "¥"
+	case CurrencyUSD:
+		return "$"
+	case CurrencyEUR:
+		return "€"
+	}
+	return string(c) + " "
+}
+
+// Money は金額と通貨の値オブジェクト。Amount は通貨の最小単位の整数（USD の 12345 は $123.45）
+type Money struct {
+	Amount   int
+	Currency Currency
+}
+
+// JPY は円建ての金額を返す
+func JPY(amount int) Money {
+	return Money{Amount: amount, Currency: CurrencyJPY}
+}
+
+// NewMoney は通貨コードを大文字にそろえた金額を返す（空の場合は JPY）
+func NewMoney(amount int, currency string) Money {
+	c := Currency(strings.ToUpper(strings.TrimSpace(currency)))
+	if c == "" {
+		c = CurrencyJPY
+	}
+	return Money{Amount: amount, Currency: c}
+}
+
+// MoneyFromDecimal は補助単位を小数にした金額（USD の 123.45）を通貨の最小単位の Money にする（通貨が空の場合は JPY）。
+// 補助単位より細かい端数はエラーにする。int の範囲を超える金額は MaxPrice を超える値にして、範囲の検証で拒否できるようにする
+func MoneyFromDecimal(amount Decimal, currency string) (Money, error) {
+	m := NewMoney(0, currency)
+	minor := m.Currency.MinorUnits()
+	if amount.Scale > minor && amount.Units%int64(math.Pow10(amount.Scale-minor)) != 0 {
+		return m, fmt.Errorf("must have at most %d decimal places for %s", minor, m.Currency)
+	}
+	units, ok := amount.Rescale(minor)
+	switch {
+	case !ok && amount.Units < 0:
+		m.Amount = math.MinInt
+	case !ok || units > math.MaxInt:
+		m.Amount = math.MaxInt
+	default:
+		m.Amount = int(units)
+	}
+	return m, nil
+}
+
+// Decimal は補助単位を小数にした金額（USD の 12345 は 123.45）
+func (m Money) Decimal() Decimal {
+	return Decimal{Units: int64(m.Amount), Scale: m.Currency.MinorUnits()}
+}
+
+// IsJPY は円建てかを判定する（円の合計や会計ソフトの仕訳には円建ての金額のみ含める）
+func (m Money) IsJPY() bool {
+	return m.Currency == CurrencyJPY
+}
+
+// Major は補助単位を小数にした金額（USD の 12345 は 123.45）
+func (m Money) Major() float64 {
+	return float64(m.Amount) / math.Pow10(m.Currency.MinorUnits())
+}
+
+// String は金額を「¥1,500,000」「$123.45」の形式にする
+func (m Money) String() string {
+	amount := int64(m.Amount)
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	minor := m.Currency.MinorUnits()
+	unit := int64(math.Pow10(minor))
+	s := sign + m.Currency.symbol() + groupDigits(strconv.FormatInt(amount/unit, 10))
+	if minor > 0 {
+		s += fmt.Sprintf(".%0*d", minor, amount%unit)
+	}
+	return s
+}
+
+// groupDigits は整数の文字列を3桁ごとにカンマで区切る
+func groupDigits(digits string) string {
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAmount(t *testing.T) {
+	assert.Equal(t, int64(3000), AddAmount(1000, 2000))
+	assert.Equal(t, int64(math.MaxInt64), AddAmount(math.MaxInt64-1, MaxPrice))
+	assert.Equal(t, int64(math.MinInt64), AddAmount(math.MinInt64+1, -MaxPrice))
+
+	// INT の上限の価格を多数合計しても int64 で正しく数えられる
+	var total int64
+	for i := 0; i < 1000; i++ {
+		total = AddAmount(total, MaxPrice)
+	}
+	assert.Equal(t, int64(MaxPrice)*1000, total)
+}
+
+func TestMulAmount(t *testing.T) {
+	amount, ok := MulAmount(MaxPrice, 3)
+	assert.True(t, ok)
+	assert.Equal(t, int64(MaxPrice)*3, amount)
+
+	_, ok = MulAmount(MaxPrice, math.MaxInt64/2)
+	assert.False(t, ok)
+
+	_, ok = MulAmount(-1, math.MinInt64)
+	assert.False(t, ok)
+}
+
+func TestNewMoney(t *testing.T) {
+	assert.Equal(t, Money{Amount: 1500000, Currency: CurrencyJPY}, NewMoney(1500000, ""))
+	assert.Equal(t, Money{Amount: 12345, Currency: CurrencyUSD}, NewMoney(12345, " usd "))
+	assert.False(t, NewMoney(100, "GBP").Currency.IsValid())
+}
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "¥1,500,000", JPY(1500000).String())
+	assert.Equal(t, "¥0", JPY(0).String())
+	assert.Equal(t, "$123.45", NewMoney(12345, "USD").String())
+	assert.Equal(t, "€1,234.05", NewMoney(123405, "EUR").String())
+	assert.Equal(t, 123.45, NewMoney(12345, "USD").Major())
+}
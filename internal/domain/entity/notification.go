@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// NotificationKind は通知の種類
+type NotificationKind string
+
+const (
+	// NotificationKindMention はコメントでメンションされたことを表す
+	NotificationKindMention NotificationKind = "comment.mention"
+)
+
+// Notification はユーザーへの通知
+type Notification struct {
+	ID     int64            `json:"id"`
+	UserID int64            `json:"user_id"`
+	Kind   NotificationKind `json:"kind"`
+	ItemID int64            `json:"item_id"`
+	// CommentID は通知のきっかけになったコメント
+	CommentID int64 `json:"comment_id"`
+	// ActorID は通知のきっかけになった操作をしたユーザー
+	ActorID   int64      `json:"actor_id"`
+	ReadAt    *time.Time `json:"read_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// NewMentionNotification はコメントでメンションされたユーザーへの通知を作成する
+func NewMentionNotification(userID int64, comment *Comment) *Notification {
+	return &Notification{
+		UserID:    userID,
+		Kind:      NotificationKindMention,
+		ItemID:    comment.ItemID,
+		CommentID: comment.ID,
+		ActorID:   comment.AuthorID,
+		CreatedAt: time.Now(),
+	}
+}
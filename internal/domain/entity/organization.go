@@ -0,0 +1,90 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Organization は家族や小規模な販売店など、アイテムを共同で所有するユーザーのグループ
+type Organization struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// Role は操作を行うユーザーの組織での役割（読み込み時に設定する）
+	Role      OrgRole   `json:"role,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// OrgRole は組織内での役割
+type OrgRole string
+
+const (
+	// OrgRoleOwner は組織のアイテムを管理でき、メンバーの追加・削除もできる
+	OrgRoleOwner OrgRole = "owner"
+	// OrgRoleEditor は組織のアイテムを参照・登録・更新・削除できる
+	OrgRoleEditor OrgRole = "editor"
+	// OrgRoleViewer は組織のアイテムの参照のみできる
+	OrgRoleViewer OrgRole = "viewer"
+)
+
+// IsValid は定義済みの役割かを判定する
+func (r OrgRole) IsValid() bool {
+	switch r {
+	case OrgRoleOwner, OrgRoleEditor, OrgRoleViewer:
+		return true
+	}
+	return false
+}
+
+// CanWrite は組織のアイテムの登録・更新・削除ができるかを判定する
+func (r OrgRole) CanWrite() bool {
+	return r == OrgRoleOwner || r == OrgRoleEditor
+}
+
+// Membership はユーザーの組織への所属
+type Membership struct {
+	OrganizationID int64 `json:"organization_id"`
+	UserID         int64 `json:"user_id"`
+	// Email は表示用のメンバーのメールアドレス（読み込み時に設定する）
+	Email     string    `json:"email"`
+	Role      OrgRole   `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewOrganization(name string) (*Organization, error) {
+	org := &Organization{
+		Name:      strings.TrimSpace(name),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := org.Validate(); err != nil {
+		return nil, err
+	}
+
+	return org, nil
+}
+
+func (o *Organization) Validate() error {
+	if o.Name == "" {
+		return errors.New("name is required")
+	}
+	if utf8.RuneCountInString(o.Name) > 100 {
+		return errors.New("name must be 100 characters or less")
+	}
+	return nil
+}
+
+func NewMembership(organizationID, userID int64, role OrgRole) (*Membership, error) {
+	if !role.IsValid() {
+		return nil, errors.New("role must be one of: owner, editor, viewer")
+	}
+	return &Membership{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      time.Now(),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrganization(t *testing.T) {
+	org, err := NewOrganization("  山田家  ")
+	require.NoError(t, err)
+	assert.Equal(t, "山田家", org.Name)
+
+	_, err = NewOrganization(" ")
+	assert.ErrorContains(t, err, "name is required")
+
+	_, err = NewOrganization(strings.Repeat("あ", 101))
+	assert.ErrorContains(t, err, "100 characters or less")
+}
+
+func TestNewMembership(t *testing.T) {
+	_, err := NewMembership(1, 2, OrgRoleEditor)
+	assert.NoError(t, err)
+
+	_, err = NewMembership(1, 2, "admin")
+	assert.ErrorContains(t, err, "role must be one of")
+}
+
+func TestOrgRole_CanWrite(t *testing.T) {
+	assert.True(t, OrgRoleOwner.CanWrite())
+	assert.True(t, OrgRoleEditor.CanWrite())
+	assert.False(t, OrgRoleViewer.CanWrite())
+}
+
+func TestUser_OrgRole(t *testing.T) {
+	user := &User{ID: 1, Memberships: []Membership{{OrganizationID: 10, UserID: 1, Role: OrgRoleViewer}}}
+
+	role, ok := user.OrgRole(10)
+	assert.True(t, ok)
+	assert.Equal(t, OrgRoleViewer, role)
+
+	_, ok = user.OrgRole(20)
+	assert.False(t, ok)
+}
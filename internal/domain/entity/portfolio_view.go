@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// PortfolioView は公開ポートフォリオページの設定。
+// Token を知っている人だけが、選択したアイテムを価格を除いて閲覧できる
+type PortfolioView struct {
+	ID          int64   `json:"id"`
+	UserID      int64   `json:"user_id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	ItemIDs     []int64 `json:"item_ids"` // 表示順
+	// ShowPurchaseDate は購入日を公開するか
+	ShowPurchaseDate bool `json:"show_purchase_date"`
+	// HTMLEnabled はサーバー側で描画したHTMLページを公開するか（JSONは常に公開）
+	HTMLEnabled bool      `json:"html_enabled"`
+	Token       string    `json:"token"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ポートフォリオに掲載できるアイテムの最大数
+const MaxPortfolioItems = 100
+
+func NewPortfolioView(userID int64, title, description string, itemIDs []int64, showPurchaseDate, htmlEnabled bool) (*PortfolioView, error) {
+	view := &PortfolioView{
+		UserID:           userID,
+		Title:            strings.TrimSpace(title),
+		Description:      strings.TrimSpace(description),
+		ItemIDs:          uniqueIDs(itemIDs),
+		ShowPurchaseDate: showPurchaseDate,
+		HTMLEnabled:      htmlEnabled,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := view.Validate(); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (v *PortfolioView) Validate() error {
+	var errs []string
+
+	if v.Title == "" {
+		errs = append(errs, "title is required")
+	} else if utf8.RuneCountInString(v.Title) > 100 {
+		errs = append(errs, "title must be 100 characters or less")
+	}
+
+	if utf8.RuneCountInString(v.Description) > 1000 {
+		errs = append(errs, "description must be 1000 characters or less")
+	}
+
+	if len(v.ItemIDs) > MaxPortfolioItems {
+		errs = append(errs, fmt.Sprintf("item_ids must contain %d items or less", MaxPortfolioItems))
+	}
+	for _, id := range v.ItemIDs {
+		if id <= 0 {
+			errs = append(errs, "item_ids must be positive integers")
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// SetItemIDs は重複を除いて掲載するアイテムを設定する
+func (v *PortfolioView) SetItemIDs(ids []int64) {
+	v.ItemIDs = uniqueIDs(ids)
+}
+
+// uniqueIDs は順序を保ったまま重複を除く
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
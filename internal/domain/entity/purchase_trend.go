@@ -0,0 +1,25 @@
+package entity
+
+// PurchasePeriodUnit は購入の推移を集計する期間の単位
+type PurchasePeriodUnit string
+
+const (
+	// PurchasePeriodMonth は月ごと（期間は YYYY-MM）
+	PurchasePeriodMonth PurchasePeriodUnit = "month"
+	// PurchasePeriodYear は年ごと（期間は YYYY）
+	PurchasePeriodYear PurchasePeriodUnit = "year"
+)
+
+// IsValid は定義済みの期間の単位かを返す
+func (u PurchasePeriodUnit) IsValid() bool {
+	return u == PurchasePeriodMonth || u == PurchasePeriodYear
+}
+
+// PurchasePeriodValue は購入日の期間・通貨・組織ごとのアイテムの件数と購入価格の合計（最小単位。OrgID の 0 は個人のアイテム）
+type PurchasePeriodValue struct {
+	Period   string
+	Currency Currency
+	OrgID    int64
+	Count    int
+	Value    int64
+}
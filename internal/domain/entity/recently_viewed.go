@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// RecentlyViewedItem は最近詳細を表示したアイテムと、最後に表示した日時
+type RecentlyViewedItem struct {
+	*Item
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+// MarshalJSON はアイテムの項目に表示日時を加える（*Item の MarshalJSON が引き継がれないようにする）
+func (v RecentlyViewedItem) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		itemJSON
+		ViewedAt time.Time `json:"viewed_at"`
+	}{v.Item.toJSON(), v.ViewedAt})
+}
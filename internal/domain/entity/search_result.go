@@ -0,0 +1,43 @@
+package entity
+
+import "encoding/json"
+
+// SearchResult は検索に一致したアイテムと、一致した理由
+type SearchResult struct {
+	*Item
+	// Score は関連度（同じ検索結果の中での比較用で、値の大きさに意味はない）
+	Score float64 `json:"score"`
+	// Highlights はキーワードに一致した項目と位置（一致した項目のみ）
+	Highlights []SearchHighlight `json:"highlights"`
+}
+
+// MarshalJSON はアイテムの項目に関連度と一致した範囲を加える（*Item の MarshalJSON が引き継がれないようにする）
+func (r SearchResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		itemJSON
+		Score      float64           `json:"score"`
+		Highlights []SearchHighlight `json:"highlights"`
+	}{r.Item.toJSON(), r.Score, r.Highlights})
+}
+
+// 検索対象の項目
+const (
+	SearchFieldName  = "name"
+	SearchFieldBrand = "brand"
+	// SearchFieldDocuments は購入書類の画像から抽出したテキスト
+	SearchFieldDocuments = "documents"
+)
+
+// SearchHighlight は1つの項目の中でキーワードに一致した範囲
+type SearchHighlight struct {
+	Field string     `json:"field"`
+	Spans []TextSpan `json:"spans"`
+	// Snippet は documents の一致した箇所の前後のテキスト（Spans は Snippet の中の位置）
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// TextSpan は文字列の範囲（文字単位の位置で、Start を含み End を含まない）
+type TextSpan struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// タグ名の最大文字数
+const MaxTagLength = 30
+
+// 1つのアイテムに付けられるタグの最大数
+const MaxTagsPerItem = 20
+
+// TagCount はタグと、そのタグが付いたアイテムの件数（GET /tags）
+type TagCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// NormalizeTag はタグ名を正規化して検証する（前後の空白を除き、連続する空白を1つにまとめ、英字を小文字にする）
+func NormalizeTag(name string) (string, error) {
+	tag := strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if tag == "" {
+		return "", domainErrors.ValidationErrors{{Field: "name", Code: domainErrors.CodeRequired, Message: "tag name is required"}}
+	}
+	if utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", domainErrors.ValidationErrors{{Field: "name", Code: domainErrors.CodeTooLong, Message: fmt.Sprintf("tag name must be %d characters or less", MaxTagLength)}}
+	}
+	return tag, nil
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tag, err := NormalizeTag("  Vintage   Watch ")
+	require.NoError(t, err)
+	assert.Equal(t, "vintage watch", tag)
+
+	// 日本語はそのまま（ひらがなとカタカナは別のタグ）
+	tag, err = NormalizeTag("ヴィンテージ")
+	require.NoError(t, err)
+	assert.Equal(t, "ヴィンテージ", tag)
+
+	_, err = NormalizeTag(" \t")
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.CodeRequired, errs[0].Code)
+
+	_, err = NormalizeTag(strings.Repeat("あ", MaxTagLength))
+	require.NoError(t, err)
+	_, err = NormalizeTag(strings.Repeat("あ", MaxTagLength+1))
+	errs, ok = domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	assert.Equal(t, domainErrors.CodeTooLong, errs[0].Code)
+}
+
+func TestItem_TagsJSON(t *testing.T) {
+	item, err := NewItem("時計1", "時計", "ROLEX", JPY(1000000), "2023-01-01")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"tags":[]`)
+
+	item.Tags = []string{"vintage", "箱あり"}
+	data, err = json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"tags":["vintage","箱あり"]`)
+}
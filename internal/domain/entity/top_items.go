@@ -0,0 +1,16 @@
+package entity
+
+// ItemValueKey は高額なアイテムを選ぶ金額の種類
+type ItemValueKey string
+
+const (
+	// ItemValuePurchasePrice は購入価格
+	ItemValuePurchasePrice ItemValueKey = "purchase_price"
+	// ItemValueCurrent は最新の評価額（評価額を記録したアイテムのみ）
+	ItemValueCurrent ItemValueKey = "current_value"
+)
+
+// IsValid は定義済みの金額の種類かを返す
+func (k ItemValueKey) IsValid() bool {
+	return k == ItemValuePurchasePrice || k == ItemValueCurrent
+}
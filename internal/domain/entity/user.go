@@ -0,0 +1,126 @@
+package entity
+
+import (
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type User struct {
+	ID           int64  `json:"id"`
+	Email        string `json:"email"`
+	PasswordHash string `json:"-"`
+	Role         Role   `json:"role"`
+	// PreferredCurrency は集計やエクスポートの金額を表示する通貨
+	PreferredCurrency Currency  `json:"preferred_currency"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	// Memberships は所属する組織（読み込み時に設定する）
+	Memberships []Membership `json:"-"`
+}
+
+// Role はユーザーの権限
+type Role string
+
+const (
+	// RoleAdmin はすべてのユーザーのアイテムとカテゴリーを参照・管理できる
+	RoleAdmin Role = "admin"
+	// RoleEditor は自分のアイテムを参照・登録・更新・削除できる
+	RoleEditor Role = "editor"
+	// RoleViewer は自分のアイテムの参照のみできる
+	RoleViewer Role = "viewer"
+)
+
+// DefaultRole は新規登録ユーザーの権限
+const DefaultRole = RoleEditor
+
+// IsValid は定義済みの権限かを判定する
+func (r Role) IsValid() bool {
+	switch r {
+	case RoleAdmin, RoleEditor, RoleViewer:
+		return true
+	}
+	return false
+}
+
+const (
+	minPasswordLength = 8
+	maxPasswordLength = 72 // bcrypt の上限
+)
+
+func NewUser(email, passwordHash string) (*User, error) {
+	user := &User{
+		Email:             NormalizeEmail(email),
+		PasswordHash:      passwordHash,
+		Role:              DefaultRole,
+		PreferredCurrency: CurrencyJPY,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+
+	if err := validateEmail(user.Email); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// IsAdmin は管理者かを判定する
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+// CanWrite はアイテムの登録・更新・削除ができるかを判定する
+func (u *User) CanWrite() bool {
+	return u.Role == RoleAdmin || u.Role == RoleEditor
+}
+
+// Currency は集計やエクスポートの金額を表示する通貨（未設定の場合は JPY）
+func (u *User) Currency() Currency {
+	if u.PreferredCurrency == "" {
+		return CurrencyJPY
+	}
+	return u.PreferredCurrency
+}
+
+// OrgRole は組織での役割を返す（所属していない場合は false）
+func (u *User) OrgRole(organizationID int64) (OrgRole, bool) {
+	for _, m := range u.Memberships {
+		if m.OrganizationID == organizationID {
+			return m.Role, true
+		}
+	}
+	return "", false
+}
+
+// NormalizeEmail はメールアドレスを比較用に正規化する
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// ValidatePassword はハッシュ化前のパスワードを検証する
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < minPasswordLength {
+		return errors.New("password must be at least 8 characters")
+	}
+	if len(password) > maxPasswordLength {
+		return errors.New("password must be 72 bytes or less")
+	}
+	return nil
+}
+
+func validateEmail(email string) error {
+	if email == "" {
+		return errors.New("email is required")
+	}
+	if len(email) > 255 {
+		return errors.New("email must be 255 characters or less")
+	}
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return errors.New("email must be a valid email address")
+	}
+	return nil
+}
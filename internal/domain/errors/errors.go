@@ -1,16 +1,72 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
-	ErrItemNotFound   = errors.New("item not found")
-	ErrInvalidInput   = errors.New("invalid input")
-	ErrDatabaseError  = errors.New("database error")
-	ErrDuplicateEntry = errors.New("duplicate entry")
+	ErrItemNotFound            = errors.New("item not found")
+	ErrInvalidInput            = errors.New("invalid input")
+	ErrDatabaseError           = errors.New("database error")
+	ErrDuplicateEntry          = errors.New("duplicate entry")
+	ErrDuplicateSerialNumber   = errors.New("an item with the same serial number is already registered")
+	ErrJobNotFound             = errors.New("job not found")
+	ErrJobResultNotFound       = errors.New("job result not found")
+	ErrJobAlreadyRunning       = errors.New("job already running")
+	ErrTooManyJobSubscribers   = errors.New("too many progress streams for the job")
+	ErrUserNotFound            = errors.New("user not found")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrItemImageNotFound       = errors.New("item image not found")
+	ErrPortfolioNotFound       = errors.New("portfolio not found")
+	ErrCommentNotFound         = errors.New("comment not found")
+	ErrTagNotFound             = errors.New("tag not found")
+	ErrCategoryNotFound        = errors.New("category not found")
+	ErrCategoryInUse           = errors.New("category is used by items")
+	ErrCatalogEntryNotFound    = errors.New("catalog entry not found")
+	ErrNotificationNotFound    = errors.New("notification not found")
+	ErrOrganizationNotFound    = errors.New("organization not found")
+	ErrMemberNotFound          = errors.New("organization member not found")
+	ErrConsignmentNotFound     = errors.New("consignment not found")
+	ErrItemSaleNotFound        = errors.New("item sale not found")
+	ErrInvoiceNotFound         = errors.New("invoice not found")
+	ErrReportNotFound          = errors.New("report not found")
+	ErrInvalidCredentials      = errors.New("invalid credentials")
+	ErrUnauthorized            = errors.New("unauthorized")
+	ErrForbidden               = errors.New("forbidden")
+	ErrItemVersionMismatch     = errors.New("item version mismatch")
+	ErrItemVersionConflict     = errors.New("item was modified by another request")
+	ErrIdempotencyKeyNotFound  = errors.New("idempotency key not found")
+	ErrIdempotencyKeyInUse     = errors.New("a request with the same idempotency key is in progress")
+	ErrIdempotencyKeyReused    = errors.New("idempotency key was already used for a different request")
+	ErrExchangeRateUnavailable = errors.New("exchange rate unavailable")
+	ErrMarketPriceNotFound     = errors.New("market price not found")
+	ErrDraftSessionNotFound    = errors.New("draft not found")
+	ErrDraftImageNotFound      = errors.New("draft image not found")
+	ErrColumnMigrationNotFound = errors.New("column migration not found")
 )
 
+// JobConflictError は同じユーザーのジョブが既に実行中の場合のエラー
+type JobConflictError struct {
+	JobID int64
+}
+
+func (e *JobConflictError) Error() string {
+	return fmt.Sprintf("%s: job %d", ErrJobAlreadyRunning.Error(), e.JobID)
+}
+
+func (e *JobConflictError) Is(target error) bool {
+	return target == ErrJobAlreadyRunning
+}
+
 func IsNotFoundError(err error) bool {
-	return errors.Is(err, ErrItemNotFound)
+	return errors.Is(err, ErrItemNotFound) || errors.Is(err, ErrJobNotFound) || errors.Is(err, ErrJobResultNotFound) ||
+		errors.Is(err, ErrAPIKeyNotFound) || errors.Is(err, ErrItemImageNotFound) || errors.Is(err, ErrPortfolioNotFound) ||
+		errors.Is(err, ErrCommentNotFound) || errors.Is(err, ErrNotificationNotFound) || errors.Is(err, ErrOrganizationNotFound) ||
+		errors.Is(err, ErrMemberNotFound) || errors.Is(err, ErrConsignmentNotFound) || errors.Is(err, ErrInvoiceNotFound) ||
+		errors.Is(err, ErrIdempotencyKeyNotFound) || errors.Is(err, ErrTagNotFound) ||
+		errors.Is(err, ErrCategoryNotFound) || errors.Is(err, ErrCatalogEntryNotFound) || errors.Is(err, ErrItemSaleNotFound) ||
+		errors.Is(err, ErrDraftSessionNotFound) || errors.Is(err, ErrDraftImageNotFound) || errors.Is(err, ErrColumnMigrationNotFound)
 }
 
 func IsDatabaseError(err error) bool {
@@ -20,3 +76,54 @@ func IsDatabaseError(err error) bool {
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsDuplicateError(err error) bool {
+	return errors.Is(err, ErrDuplicateEntry)
+}
+
+// IsDuplicateSerialNumberError は同じ所有者のアイテムに同じシリアル番号が登録済みかを判定する
+func IsDuplicateSerialNumberError(err error) bool {
+	return errors.Is(err, ErrDuplicateSerialNumber)
+}
+
+func IsUnauthorizedError(err error) bool {
+	return errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrInvalidCredentials)
+}
+
+func IsForbiddenError(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
+
+// IsVersionMismatchError は If-Match で指定されたバージョンが現在のアイテムと異なるかを判定する
+func IsVersionMismatchError(err error) bool {
+	return errors.Is(err, ErrItemVersionMismatch)
+}
+
+// IsVersionConflictError は読み込んでから更新するまでに他のリクエストがアイテムを更新したかを判定する
+func IsVersionConflictError(err error) bool {
+	return errors.Is(err, ErrItemVersionConflict)
+}
+
+// IsIdempotencyKeyInUseError は同じ Idempotency-Key のリクエストを処理中かを判定する
+func IsIdempotencyKeyInUseError(err error) bool {
+	return errors.Is(err, ErrIdempotencyKeyInUse)
+}
+
+// IsIdempotencyKeyReusedError は Idempotency-Key が異なるリクエストに使われたかを判定する
+func IsIdempotencyKeyReusedError(err error) bool {
+	return errors.Is(err, ErrIdempotencyKeyReused)
+}
+
+func IsJobConflictError(err error) bool {
+	return errors.Is(err, ErrJobAlreadyRunning)
+}
+
+// IsCategoryInUseError は削除しようとしたカテゴリーのアイテムがあるかを判定する
+func IsCategoryInUseError(err error) bool {
+	return errors.Is(err, ErrCategoryInUse)
+}
+
+// IsExchangeRateUnavailableError は為替レートを取得できず金額を換算できなかったかを判定する
+func IsExchangeRateUnavailableError(err error) bool {
+	return errors.Is(err, ErrExchangeRateUnavailable)
+}
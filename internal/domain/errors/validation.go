@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// 検証エラーの種類を表す機械可読なコード
+const (
+	CodeRequired       = "required"
+	CodeTooLong        = "too_long"
+	CodeInvalidChoice  = "invalid_choice"
+	CodeInvalidFormat  = "invalid_format"
+	CodeInvalidType    = "invalid_type"
+	CodeOutOfRange     = "out_of_range"
+	CodeNotNull        = "not_null"
+	CodeNotAllowed     = "not_allowed"
+	CodeDateNotAllowed = "date_not_allowed"
+	CodeInvalid        = "invalid"
+)
+
+// FieldError は入力の項目ごとの検証エラー（Field は JSON の項目名。項目を特定できない場合は空）
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationErrors は入力の検証エラーの一覧（ErrInvalidInput として扱う）
+type ValidationErrors []FieldError
+
+// Add は項目の検証エラーを追加する
+func (e *ValidationErrors) Add(field, code, message string) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: message})
+}
+
+// Err はエラーがない場合に nil を返す（nil の ValidationErrors を error として返さないため）
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fe := range e {
+		messages = append(messages, fe.Message)
+	}
+	return strings.Join(messages, ", ")
+}
+
+func (e ValidationErrors) Is(target error) bool {
+	return target == ErrInvalidInput
+}
+
+// AsValidationErrors はエラーに含まれる項目ごとの検証エラーを返す
+func AsValidationErrors(err error) (ValidationErrors, bool) {
+	var errs ValidationErrors
+	if errors.As(err, &errs) {
+		return errs, true
+	}
+	return nil, false
+}
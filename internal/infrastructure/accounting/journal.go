@@ -0,0 +1,170 @@
+// Package accounting は仕訳を会計ソフトの取り込み形式のCSVに変換する
+package accounting
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/japanese"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// FreeeRenderer は freee の仕訳帳インポート向けのCSV（UTF-8、見出し行あり）を出力する
+type FreeeRenderer struct{}
+
+func NewFreeeRenderer() *FreeeRenderer {
+	return &FreeeRenderer{}
+}
+
+func (r *FreeeRenderer) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (r *FreeeRenderer) Render(entries []usecase.JournalEntry) ([]byte, error) {
+	rows := [][]string{{"日付", "伝票番号", "借方勘定科目", "借方税区分", "借方金額", "借方税額", "貸方勘定科目", "貸方税区分", "貸方金額", "貸方税額", "摘要"}}
+	for _, e := range entries {
+		debitTax, creditTax := taxAmounts(e)
+		rows = append(rows, []string{
+			e.Date.Format("2006/01/02"),
+			e.Number,
+			e.DebitAccount,
+			e.DebitTaxClass,
+			strconv.Itoa(e.Amount),
+			debitTax,
+			e.CreditAccount,
+			e.CreditTaxClass,
+			strconv.Itoa(e.Amount),
+			creditTax,
+			e.Description,
+		})
+	}
+	return writeCSV(rows, false)
+}
+
+// YayoiRenderer は弥生会計の仕訳日記帳インポート形式（Shift_JIS、CRLF、見出し行なしの25列）を出力する
+type YayoiRenderer struct{}
+
+func NewYayoiRenderer() *YayoiRenderer {
+	return &YayoiRenderer{}
+}
+
+func (r *YayoiRenderer) ContentType() string {
+	return "text/csv; charset=shift_jis"
+}
+
+// 弥生会計の摘要の最大文字数（全角）
+const yayoiDescriptionLength = 32
+
+func (r *YayoiRenderer) Render(entries []usecase.JournalEntry) ([]byte, error) {
+	rows := [][]string{}
+	for _, e := range entries {
+		debitTax, creditTax := taxAmounts(e)
+		rows = append(rows, []string{
+			"2000", // 識別フラグ（1行の仕訳）
+			"",     // 伝票No（取り込み時に採番）
+			"",     // 決算
+			e.Date.Format("2006/01/02"),
+			e.DebitAccount,
+			"", // 借方補助科目
+			"", // 借方部門
+			e.DebitTaxClass,
+			strconv.Itoa(e.Amount),
+			debitTax,
+			e.CreditAccount,
+			"", // 貸方補助科目
+			"", // 貸方部門
+			e.CreditTaxClass,
+			strconv.Itoa(e.Amount),
+			creditTax,
+			truncate(e.Description, yayoiDescriptionLength),
+			e.Number,
+			"",  // 期日
+			"0", // タイプ（仕訳データ）
+			"",  // 生成元
+			"",  // 仕訳メモ
+			"0", // 付箋1
+			"0", // 付箋2
+			"no",
+		})
+	}
+
+	out, err := writeCSV(rows, true)
+	if err != nil {
+		return nil, err
+	}
+	return toShiftJIS(out), nil
+}
+
+// QuickBooksRenderer は QuickBooks の仕訳（Journal Entry）インポート向けのCSVを出力する。
+// 1件の仕訳を借方と貸方の2行にする
+type QuickBooksRenderer struct{}
+
+func NewQuickBooksRenderer() *QuickBooksRenderer {
+	return &QuickBooksRenderer{}
+}
+
+func (r *QuickBooksRenderer) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (r *QuickBooksRenderer) Render(entries []usecase.JournalEntry) ([]byte, error) {
+	rows := [][]string{{"Journal No", "Journal Date", "Account", "Debits", "Credits", "Description"}}
+	for _, e := range entries {
+		date := e.Date.Format("01/02/2006")
+		amount := strconv.Itoa(e.Amount)
+		rows = append(rows,
+			[]string{e.Number, date, e.DebitAccount, amount, "", e.Description},
+			[]string{e.Number, date, e.CreditAccount, "", amount, e.Description},
+		)
+	}
+	return writeCSV(rows, false)
+}
+
+// taxAmounts は消費税額を税区分が対象外でない側に記載する（借方・貸方の順）
+func taxAmounts(e usecase.JournalEntry) (string, string) {
+	if e.Tax == 0 {
+		return "", ""
+	}
+	if e.CreditTaxClass != "" && e.CreditTaxClass != "対象外" {
+		return "", strconv.Itoa(e.Tax)
+	}
+	return strconv.Itoa(e.Tax), ""
+}
+
+func writeCSV(rows [][]string, crlf bool) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = crlf
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// toShiftJIS は UTF-8 を Shift_JIS に変換する（Shift_JIS にない文字は "?" にする）
+func toShiftJIS(s []byte) []byte {
+	encoder := japanese.ShiftJIS.NewEncoder()
+	var out bytes.Buffer
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		encoded, err := encoder.Bytes(s[:size])
+		if err != nil || r == utf8.RuneError {
+			out.WriteByte('?')
+		} else {
+			out.Write(encoded)
+		}
+		s = s[size:]
+	}
+	return out.Bytes()
+}
+
+// truncate は n 文字を超える文字列を切り詰める
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
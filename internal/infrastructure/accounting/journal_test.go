@@ -0,0 +1,59 @@
+package accounting
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/japanese"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+var testEntries = []usecase.JournalEntry{
+	{
+		Date: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC), Number: "ITEM-1",
+		DebitAccount: "仕入高", DebitTaxClass: "対象外", CreditAccount: "現金", CreditTaxClass: "対象外",
+		Amount: 2000000, Description: "HERMÈS バーキン",
+	},
+	{
+		Date: time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC), Number: "INV-2024-000001",
+		DebitAccount: "売掛金", DebitTaxClass: "対象外", CreditAccount: "売上高", CreditTaxClass: "課税売上10%",
+		Amount: 550000, Tax: 50000, Description: "山田 太郎",
+	},
+}
+
+func TestFreeeRenderer_Render(t *testing.T) {
+	out, err := NewFreeeRenderer().Render(testEntries)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "日付,伝票番号,借方勘定科目,借方税区分,借方金額,借方税額,貸方勘定科目,貸方税区分,貸方金額,貸方税額,摘要", lines[0])
+	assert.Equal(t, "2024/01/15,ITEM-1,仕入高,対象外,2000000,,現金,対象外,2000000,,HERMÈS バーキン", lines[1])
+	assert.Equal(t, "2024/07/01,INV-2024-000001,売掛金,対象外,550000,,売上高,課税売上10%,550000,50000,山田 太郎", lines[2])
+}
+
+func TestYayoiRenderer_Render(t *testing.T) {
+	out, err := NewYayoiRenderer().Render(testEntries)
+	require.NoError(t, err)
+
+	decoded, err := japanese.ShiftJIS.NewDecoder().Bytes(out)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSuffix(string(decoded), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "2000,,,2024/01/15,仕入高,,,対象外,2000000,,現金,,,対象外,2000000,,HERM?S バーキン,ITEM-1,,0,,,0,0,no", lines[0])
+	assert.Len(t, strings.Split(lines[1], ","), 25)
+	assert.Contains(t, lines[1], ",売上高,,,課税売上10%,550000,50000,山田 太郎,")
+}
+
+func TestQuickBooksRenderer_Render(t *testing.T) {
+	out, err := NewQuickBooksRenderer().Render(testEntries[1:])
+	require.NoError(t, err)
+
+	assert.Equal(t, "Journal No,Journal Date,Account,Debits,Credits,Description\n"+
+		"INV-2024-000001,07/01/2024,売掛金,550000,,山田 太郎\n"+
+		"INV-2024-000001,07/01/2024,売上高,,550000,山田 太郎\n", string(out))
+}
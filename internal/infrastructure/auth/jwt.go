@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// JWTIssuer は HS256 で署名したJWTを発行・検証する
+type JWTIssuer struct {
+	secret []byte
+	ttl    time.Duration
+	issuer string
+	now    func() time.Time
+}
+
+func NewJWTIssuer(secret string, ttl time.Duration) *JWTIssuer {
+	return &JWTIssuer{
+		secret: []byte(secret),
+		ttl:    ttl,
+		issuer: "aicon-items-api",
+		now:    time.Now,
+	}
+}
+
+func (i *JWTIssuer) Issue(user *entity.User) (string, time.Time, error) {
+	now := i.now()
+	expiresAt := now.Add(i.ttl)
+
+	claims := jwt.RegisteredClaims{
+		Subject:   strconv.FormatInt(user.ID, 10),
+		Issuer:    i.issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token, expiresAt, nil
+}
+
+func (i *JWTIssuer) Parse(token string) (int64, error) {
+	var claims jwt.RegisteredClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		return i.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	userID, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, errors.New("invalid subject")
+	}
+	return userID, nil
+}
+
+// 設定値の検証（起動時に呼び出す）
+func (i *JWTIssuer) Validate() error {
+	if len(i.secret) < 32 {
+		return fmt.Errorf("jwt secret must be at least 32 bytes")
+	}
+	if i.ttl <= 0 {
+		return fmt.Errorf("jwt ttl must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestJWTIssuer(t *testing.T) {
+	issuer := NewJWTIssuer(testSecret, time.Hour)
+	require.NoError(t, issuer.Validate())
+
+	token, expiresAt, err := issuer.Issue(&entity.User{ID: 42})
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, time.Second)
+
+	userID, err := issuer.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), userID)
+
+	t.Run("異常系: 別の秘密鍵で署名されたトークン", func(t *testing.T) {
+		other := NewJWTIssuer("ffffffffffffffffffffffffffffffff", time.Hour)
+		_, err := other.Parse(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: 期限切れのトークン", func(t *testing.T) {
+		expired := NewJWTIssuer(testSecret, time.Hour)
+		expired.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		_, err := expired.Parse(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: 改ざんされたトークン", func(t *testing.T) {
+		_, err := issuer.Parse(token + "x")
+		assert.Error(t, err)
+	})
+
+	t.Run("異常系: 短すぎる秘密鍵", func(t *testing.T) {
+		assert.Error(t, NewJWTIssuer("short", time.Hour).Validate())
+	})
+}
@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 同梱のカタログ（主要な時計・バッグ・ジュエリーの型番）
+//
+//go:embed catalog.json
+var embedded []byte
+
+// Catalog は型番ごとのカタログの情報をメモリに持ち、型番から引く
+type Catalog struct {
+	entries map[string]*entity.CatalogEntry
+}
+
+// NewEmbedded は同梱のカタログを返す
+func NewEmbedded() (*Catalog, error) {
+	return Parse(embedded)
+}
+
+// Open は JSON ファイル（同梱の catalog.json と同じ形式）のカタログを返す
+func Open(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse はカタログの情報の JSON 配列を読み込む（正規化して同じ型番になる情報が複数ある場合はエラー）
+func Parse(data []byte) (*Catalog, error) {
+	var list []*entity.CatalogEntry
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("catalog: invalid json: %w", err)
+	}
+
+	entries := make(map[string]*entity.CatalogEntry, len(list))
+	for i, entry := range list {
+		key := entity.NormalizeReferenceNumber(entry.ReferenceNumber)
+		if key == "" {
+			return nil, fmt.Errorf("catalog: entry %d: reference_number is required", i)
+		}
+		if _, ok := entries[key]; ok {
+			return nil, fmt.Errorf("catalog: entry %d: duplicate reference_number %q", i, entry.ReferenceNumber)
+		}
+		entries[key] = entry
+	}
+
+	return &Catalog{entries: entries}, nil
+}
+
+// Lookup は正規化した型番のカタログの情報の複製を返す
+func (c *Catalog) Lookup(ctx context.Context, ref string) (*entity.CatalogEntry, error) {
+	entry, ok := c.entries[ref]
+	if !ok {
+		return nil, domainErrors.ErrCatalogEntryNotFound
+	}
+	return entry.Clone(), nil
+}
+
+// Entries はすべてのカタログの情報の複製を返す（順序は不定）
+func (c *Catalog) Entries() []*entity.CatalogEntry {
+	entries := make([]*entity.CatalogEntry, 0, len(c.entries))
+	for _, entry := range c.entries {
+		entries = append(entries, entry.Clone())
+	}
+	return entries
+}
@@ -0,0 +1,104 @@
+package catalog
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestNewEmbedded(t *testing.T) {
+	c, err := NewEmbedded()
+	require.NoError(t, err)
+
+	entry, err := c.Lookup(context.Background(), "116520")
+	require.NoError(t, err)
+	assert.Equal(t, "ROLEX", entry.Brand)
+	assert.Equal(t, "コスモグラフ デイトナ", entry.Model)
+	assert.Equal(t, "時計", entry.Category)
+
+	// 型番は正規化した値で引く
+	entry, err = c.Lookup(context.Background(), entity.NormalizeReferenceNumber("126610 ln"))
+	require.NoError(t, err)
+	assert.Equal(t, "サブマリーナー デイト", entry.Model)
+
+	_, err = c.Lookup(context.Background(), "000000")
+	assert.ErrorIs(t, err, domainErrors.ErrCatalogEntryNotFound)
+}
+
+func TestCatalog_LookupReturnsCopy(t *testing.T) {
+	c, err := NewEmbedded()
+	require.NoError(t, err)
+
+	entry, err := c.Lookup(context.Background(), "116520")
+	require.NoError(t, err)
+	entry.Brand = "変更"
+	entry.Attributes[entity.AttributeMovement] = "クオーツ"
+
+	entry, err = c.Lookup(context.Background(), "116520")
+	require.NoError(t, err)
+	assert.Equal(t, "ROLEX", entry.Brand)
+	assert.Equal(t, "自動巻き", entry.Attributes[entity.AttributeMovement])
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"JSON ではない", `{`},
+		{"型番がない", `[{"brand":"ROLEX"}]`},
+		{"正規化すると同じ型番", `[{"reference_number":"M41526"},{"reference_number":"m-41526"}]`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "catalog.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"reference_number":"RM011","brand":"RICHARD MILLE","model":"RM 011","category":"時計"}]`), 0o600))
+
+	c, err := Open(path)
+	require.NoError(t, err)
+	entry, err := c.Lookup(context.Background(), "RM011")
+	require.NoError(t, err)
+	assert.Equal(t, "RICHARD MILLE", entry.Brand)
+	assert.Equal(t, entity.ItemAttributes{}, entry.Attributes)
+
+	_, err = Open(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+// 同梱のカタログはそのまま登録できる内容にする
+func TestEmbedded_Valid(t *testing.T) {
+	c, err := NewEmbedded()
+	require.NoError(t, err)
+
+	for _, entry := range c.Entries() {
+		t.Run(entry.ReferenceNumber, func(t *testing.T) {
+			_, err := entity.NewItem(entry.Model, entry.Category, entry.Brand, entity.JPY(1), "2023-01-01")
+			assert.NoError(t, err)
+
+			schemas := usecase.CategoryAttributes[entry.Category]
+			for name, value := range entry.Attributes {
+				i := slices.IndexFunc(schemas, func(s usecase.AttributeSchema) bool { return s.Name == name })
+				require.GreaterOrEqual(t, i, 0, name)
+				if choices := schemas[i].Choices; len(choices) > 0 {
+					assert.Contains(t, choices, value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+// Package challenge は公開エンドポイントの不正利用対策として、不審なクライアントに求める
+// CAPTCHA などのチャレンジの検証と、チャレンジを求めるかの判定を提供する
+package challenge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// チャレンジの提供元の種類
+const (
+	ProviderTurnstile = "turnstile"
+	ProviderHCaptcha  = "hcaptcha"
+)
+
+// HeaderToken はクライアントが解いたチャレンジのトークンを送るヘッダー
+const HeaderToken = "X-Challenge-Token"
+
+// ErrFailed はトークンが不正・期限切れ・使用済みなど、チャレンジの検証に失敗したことを表す
+var ErrFailed = errors.New("challenge verification failed")
+
+// Challenge はクライアントが解いたチャレンジのトークンを提供元で検証する。
+// Cloudflare Turnstile と hCaptcha の実装がある
+type Challenge interface {
+	// Provider は提供元の種類（クライアントが表示するウィジェットの選択に使う）
+	Provider() string
+	// SiteKey はクライアントがウィジェットの表示に使う公開のキー
+	SiteKey() string
+	// Verify は token を検証する。検証に失敗した場合は ErrFailed を返す
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+var (
+	_ Challenge = (*SiteVerifier)(nil)
+)
+
+// Config はチャレンジの設定。Provider が空の場合はチャレンジを使わない
+type Config struct {
+	Provider string
+	SiteKey  string
+	Secret   string
+	// VerifyURL は検証 API の URL（空の場合は提供元の既定の URL）
+	VerifyURL string
+}
+
+// New は設定に応じたチャレンジを作成する（Provider が空の場合は nil）
+func New(cfg Config) (Challenge, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case ProviderTurnstile:
+		return NewTurnstile(cfg.SiteKey, cfg.Secret, cfg.VerifyURL)
+	case ProviderHCaptcha:
+		return NewHCaptcha(cfg.SiteKey, cfg.Secret, cfg.VerifyURL)
+	default:
+		return nil, fmt.Errorf("unknown challenge provider: %q", cfg.Provider)
+	}
+}
@@ -0,0 +1,190 @@
+package challenge
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rule はルートごとのチャレンジを求める条件。
+// 同じクライアントからの Window の間のリクエストが Requests を超えるか、
+// 404 の応答が NotFound を超えると、以降のリクエストにチャレンジを求める
+type Rule struct {
+	Requests int
+	Window   time.Duration
+	// NotFound は許容する 404 の数（0 は数えない）。共有リンクのトークンの総当たりを検知する
+	NotFound int
+}
+
+// 期限切れのカウンターを取り除く間隔
+const pruneInterval = time.Minute
+
+var pathParamPattern = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// ParseRules は「GET /public/portfolios/{token}=60/1m/5」（リクエスト数/期間/404の数）の形式の設定を読み込む。
+// パスは OpenAPI の {name} と echo の :name のどちらでも指定できる
+func ParseRules(specs []string) (map[string]Rule, error) {
+	rules := make(map[string]Rule, len(specs))
+	for _, spec := range specs {
+		route, limits, ok := strings.Cut(spec, "=")
+		fields := strings.Fields(route)
+		if !ok || len(fields) != 2 || !strings.HasPrefix(fields[1], "/") {
+			return nil, fmt.Errorf("invalid challenge rule %q: want \"METHOD /path=requests/window[/not_found]\"", spec)
+		}
+
+		parts := strings.Split(strings.TrimSpace(limits), "/")
+		if len(parts) != 2 && len(parts) != 3 {
+			return nil, fmt.Errorf("invalid challenge rule %q: want requests/window[/not_found]", spec)
+		}
+		var rule Rule
+		var err error
+		if rule.Requests, err = strconv.Atoi(parts[0]); err != nil || rule.Requests <= 0 {
+			return nil, fmt.Errorf("invalid challenge rule %q: requests must be a positive integer", spec)
+		}
+		if rule.Window, err = time.ParseDuration(parts[1]); err != nil || rule.Window <= 0 {
+			return nil, fmt.Errorf("invalid challenge rule %q: window must be a positive duration", spec)
+		}
+		if len(parts) == 3 {
+			if rule.NotFound, err = strconv.Atoi(parts[2]); err != nil || rule.NotFound < 0 {
+				return nil, fmt.Errorf("invalid challenge rule %q: not_found must be 0 or greater", spec)
+			}
+		}
+		rules[RouteKey(fields[0], pathParamPattern.ReplaceAllString(fields[1], ":$1"))] = rule
+	}
+	return rules, nil
+}
+
+// RouteKey はルールを引くためのキー（メソッドと echo のルートのパス）
+func RouteKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+type counterKey struct {
+	route  string
+	client string
+}
+
+// counter は期間ごとのリクエストと 404 の数
+type counter struct {
+	start     time.Time
+	requests  int
+	notFounds int
+}
+
+// Guard はクライアントごとの公開エンドポイントの利用を数え、ルールの条件を超えたクライアントにチャレンジを求める。
+// チャレンジを解いたクライアントは passTTL の間チャレンジを求めない（クライアントは IP アドレスで識別する）
+type Guard struct {
+	rules   map[string]Rule
+	passTTL time.Duration
+	now     func() time.Time
+
+	mu       sync.Mutex
+	counters map[counterKey]*counter
+	passes   map[string]time.Time
+	prunedAt time.Time
+}
+
+// NewGuard はルート（RouteKey）ごとのルールで判定する Guard を作成する
+func NewGuard(rules map[string]Rule, passTTL time.Duration) *Guard {
+	return &Guard{
+		rules:    rules,
+		passTTL:  passTTL,
+		now:      time.Now,
+		counters: make(map[counterKey]*counter),
+		passes:   make(map[string]time.Time),
+	}
+}
+
+// Rule はルートのルールを返す（ルールのないルートはチャレンジを求めない）
+func (g *Guard) Rule(route string) (Rule, bool) {
+	rule, ok := g.rules[route]
+	return rule, ok
+}
+
+// Passed はクライアントが有効期間内にチャレンジを解いているかを返す
+func (g *Guard) Passed(client string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	until, ok := g.passes[client]
+	return ok && g.now().Before(until)
+}
+
+// Pass はクライアントがチャレンジを解いたことを記録し、ルートの数をリセットする
+func (g *Guard) Pass(client string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.passes[client] = g.now().Add(g.passTTL)
+	for key := range g.counters {
+		if key.client == client {
+			delete(g.counters, key)
+		}
+	}
+}
+
+// Required はクライアントのルートの利用がルールの条件を超えていて、チャレンジを求めるかを返す
+func (g *Guard) Required(route, client string) bool {
+	rule, ok := g.rules[route]
+	if !ok {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	c := g.counter(route, client, rule, g.now())
+	return c.requests >= rule.Requests || (rule.NotFound > 0 && c.notFounds >= rule.NotFound)
+}
+
+// Record はクライアントのルートへのリクエストと応答のステータスを数える
+func (g *Guard) Record(route, client string, status int) {
+	rule, ok := g.rules[route]
+	if !ok {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	c := g.counter(route, client, rule, now)
+	c.requests++
+	if status == http.StatusNotFound {
+		c.notFounds++
+	}
+	g.prune(now)
+}
+
+// counter は現在の期間のカウンターを返す（期間が終わっている場合は新しい期間を始める）
+func (g *Guard) counter(route, client string, rule Rule, now time.Time) *counter {
+	key := counterKey{route: route, client: client}
+	c, ok := g.counters[key]
+	if !ok || now.Sub(c.start) >= rule.Window {
+		c = &counter{start: now}
+		g.counters[key] = c
+	}
+	return c
+}
+
+// prune は pruneInterval ごとに期間の終わったカウンターと期限切れの通過を取り除く
+func (g *Guard) prune(now time.Time) {
+	if now.Sub(g.prunedAt) < pruneInterval {
+		return
+	}
+	g.prunedAt = now
+	for key, c := range g.counters {
+		if now.Sub(c.start) >= g.rules[key.route].Window {
+			delete(g.counters, key)
+		}
+	}
+	for client, until := range g.passes {
+		if !now.Before(until) {
+			delete(g.passes, client)
+		}
+	}
+}
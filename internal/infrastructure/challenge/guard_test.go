@@ -0,0 +1,81 @@
+package challenge
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRules(t *testing.T) {
+	t.Run("正常系: OpenAPI と echo の形式のパスを読み込む", func(t *testing.T) {
+		rules, err := ParseRules([]string{"POST /auth/register=5/1h", " GET /public/portfolios/{token}=60/1m/5"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]Rule{
+			"POST /auth/register":           {Requests: 5, Window: time.Hour},
+			"GET /public/portfolios/:token": {Requests: 60, Window: time.Minute, NotFound: 5},
+		}, rules)
+	})
+
+	for _, spec := range []string{
+		"/auth/register=5/1h",
+		"POST /auth/register",
+		"POST /auth/register=5",
+		"POST /auth/register=0/1h",
+		"POST /auth/register=5/hour",
+		"POST /auth/register=5/1h/-1",
+	} {
+		t.Run("異常系: "+spec, func(t *testing.T) {
+			_, err := ParseRules([]string{spec})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGuard(t *testing.T) {
+	const route = "GET /public/portfolios/:token"
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	newGuard := func() *Guard {
+		g := NewGuard(map[string]Rule{route: {Requests: 3, Window: time.Minute, NotFound: 2}}, 30*time.Minute)
+		g.now = func() time.Time { return now }
+		return g
+	}
+
+	t.Run("リクエスト数が条件を超えるとチャレンジを求め、期間が終わるとリセットする", func(t *testing.T) {
+		g := newGuard()
+		for range 3 {
+			assert.False(t, g.Required(route, "203.0.113.5"))
+			g.Record(route, "203.0.113.5", http.StatusOK)
+		}
+		assert.True(t, g.Required(route, "203.0.113.5"))
+		// 他のクライアントとルールのないルートは影響を受けない
+		assert.False(t, g.Required(route, "198.51.100.7"))
+		assert.False(t, g.Required("POST /auth/login", "203.0.113.5"))
+
+		now = now.Add(time.Minute)
+		assert.False(t, g.Required(route, "203.0.113.5"))
+	})
+
+	t.Run("404 が続くとリクエスト数より先にチャレンジを求める", func(t *testing.T) {
+		g := newGuard()
+		g.Record(route, "203.0.113.5", http.StatusNotFound)
+		assert.False(t, g.Required(route, "203.0.113.5"))
+		g.Record(route, "203.0.113.5", http.StatusNotFound)
+		assert.True(t, g.Required(route, "203.0.113.5"))
+	})
+
+	t.Run("チャレンジを解いたクライアントは有効期間の間チャレンジを求めない", func(t *testing.T) {
+		g := newGuard()
+		for range 3 {
+			g.Record(route, "203.0.113.5", http.StatusOK)
+		}
+		g.Pass("203.0.113.5")
+		assert.True(t, g.Passed("203.0.113.5"))
+		assert.False(t, g.Required(route, "203.0.113.5"))
+
+		now = now.Add(30 * time.Minute)
+		assert.False(t, g.Passed("203.0.113.5"))
+	})
+}
@@ -0,0 +1,110 @@
+package challenge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// 提供元ごとの検証 API の既定の URL
+const (
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	hcaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+)
+
+// SiteVerifier は siteverify 形式の検証 API でトークンを検証する。
+// API は secret・response・remoteip をフォームで受け取り、{"success":true,"error-codes":[]} を返す形式
+// （Cloudflare Turnstile と hCaptcha で共通）
+type SiteVerifier struct {
+	provider  string
+	siteKey   string
+	secret    string
+	verifyURL string
+	client    *http.Client
+}
+
+// NewTurnstile は Cloudflare Turnstile のトークンを検証する（verifyURL が空の場合は既定の URL）
+func NewTurnstile(siteKey, secret, verifyURL string) (*SiteVerifier, error) {
+	return newSiteVerifier(ProviderTurnstile, siteKey, secret, verifyURL, turnstileVerifyURL)
+}
+
+// NewHCaptcha は hCaptcha のトークンを検証する（verifyURL が空の場合は既定の URL）
+func NewHCaptcha(siteKey, secret, verifyURL string) (*SiteVerifier, error) {
+	return newSiteVerifier(ProviderHCaptcha, siteKey, secret, verifyURL, hcaptchaVerifyURL)
+}
+
+func newSiteVerifier(provider, siteKey, secret, verifyURL, defaultURL string) (*SiteVerifier, error) {
+	if siteKey == "" || secret == "" {
+		return nil, fmt.Errorf("%s: site key and secret are required", provider)
+	}
+	if verifyURL == "" {
+		verifyURL = defaultURL
+	}
+	u, err := url.Parse(verifyURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("%s: invalid verify url: %q", provider, verifyURL)
+	}
+
+	return &SiteVerifier{
+		provider:  provider,
+		siteKey:   siteKey,
+		secret:    secret,
+		verifyURL: u.String(),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (v *SiteVerifier) Provider() string {
+	return v.provider
+}
+
+func (v *SiteVerifier) SiteKey() string {
+	return v.siteKey
+}
+
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes"`
+}
+
+func (v *SiteVerifier) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return ErrFailed
+	}
+
+	form := url.Values{"secret": {v.secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("%s siteverify returned %d: %s", v.provider, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var body siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("invalid %s siteverify response: %w", v.provider, err)
+	}
+	if !body.Success {
+		return fmt.Errorf("%w: %s", ErrFailed, strings.Join(body.ErrorCodes, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package challenge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSiteVerify はトークンごとの検証結果を返す siteverify API
+func fakeSiteVerify(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "secret", r.PostForm.Get("secret"))
+		switch r.PostForm.Get("response") {
+		case "valid":
+			assert.Equal(t, "203.0.113.5", r.PostForm.Get("remoteip"))
+			fmt.Fprint(w, `{"success":true,"error-codes":[]}`)
+		case "error":
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"success":false,"error-codes":["invalid-input-response"]}`)
+		}
+	}
+}
+
+func TestSiteVerifier_Verify(t *testing.T) {
+	server := httptest.NewServer(fakeSiteVerify(t))
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+
+	for _, newVerifier := range []func(siteKey, secret, verifyURL string) (*SiteVerifier, error){NewTurnstile, NewHCaptcha} {
+		verifier, err := newVerifier("site-key", "secret", server.URL)
+		require.NoError(t, err)
+
+		t.Run(verifier.Provider()+"/正常系: 有効なトークン", func(t *testing.T) {
+			assert.NoError(t, verifier.Verify(ctx, "valid", "203.0.113.5"))
+		})
+
+		t.Run(verifier.Provider()+"/異常系: 不正なトークン", func(t *testing.T) {
+			err := verifier.Verify(ctx, "used", "203.0.113.5")
+			assert.ErrorIs(t, err, ErrFailed)
+			assert.ErrorContains(t, err, "invalid-input-response")
+			assert.ErrorIs(t, verifier.Verify(ctx, "", "203.0.113.5"), ErrFailed)
+		})
+
+		t.Run(verifier.Provider()+"/異常系: 検証 API のエラーは検証の失敗と区別する", func(t *testing.T) {
+			err := verifier.Verify(ctx, "error", "203.0.113.5")
+			require.Error(t, err)
+			assert.NotErrorIs(t, err, ErrFailed)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	verifier, err := New(Config{})
+	require.NoError(t, err)
+	assert.Nil(t, verifier)
+
+	verifier, err = New(Config{Provider: ProviderTurnstile, SiteKey: "site-key", Secret: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, ProviderTurnstile, verifier.Provider())
+	assert.Equal(t, "site-key", verifier.SiteKey())
+
+	_, err = New(Config{Provider: ProviderHCaptcha, SiteKey: "site-key"})
+	assert.Error(t, err)
+	_, err = New(Config{Provider: "recaptcha", SiteKey: "site-key", Secret: "secret"})
+	assert.Error(t, err)
+}
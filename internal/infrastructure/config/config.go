@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -14,6 +17,134 @@ var (
 	DBHost     string
 	DBName     string
 	DBPort     string
+	// 画面操作の読み込みに使うレプリカ（ホストが空の場合はプライマリのみ）と、レプリカの反映の遅れの上限
+	DBReplicaHost   string
+	DBReplicaPort   string
+	DBReplicaMaxLag time.Duration
+	// sort=name&collation=ja で使う日本語のコレーション（空の場合はアプリケーションで並べ替える）
+	DBJapaneseCollation string
+	// アイテムの ID の採番方法（auto または snowflake）
+	IDGenerator string
+	// ID_GENERATOR=snowflake のノード番号（サーバーやシャードごとに 0〜1023 で重複しない値）
+	IDNodeID int
+	// アイテムの名前・ブランドの最大文字数（100 を超える場合は items の列を広げる）
+	ItemNameMaxLength  int
+	ItemBrandMaxLength int
+	// 未来の購入日を許可するか、何年前までの購入日を許可するか（0 は制限しない）
+	PurchaseDateAllowFuture bool
+	PurchaseDateMaxAgeYears int
+	// 組織での役割ごとに非表示にするアイテムの項目（「viewer:purchase_price|serial_number」をカンマ区切り。none は何も非表示にしない）
+	ItemRedactedFields []string
+	// 列の移行の段階（「items.purchase_date=dual_write」をカンマ区切り。指定しない移行は old）
+	ColumnMigrations []string
+	// 列の移行のバックフィルでバッチの間に待つ時間（本番の負荷を抑えるため）
+	ColumnBackfillPause time.Duration
+	// 新しい実装に振り分けるリクエストの割合（「items.value_change=10」をカンマ区切り。指定しないメソッドは従来の実装のみ）
+	Canaries []string
+	// CSVの取り込みで読み込む最大バイト数（gzip の場合は展開後。0以下は制限しない）
+	ImportMaxBytes int
+
+	// 終了したジョブ（結果のファイルを含む）をメモリに保持する期間と、ユーザーごとに保持する数
+	JobRetention          time.Duration
+	JobMaxFinishedPerUser int
+
+	// レーンごとの同時実行数・DB接続数の上限（0以下は無制限）
+	InteractiveMaxConcurrency int
+	BatchMaxConcurrency       int
+	InteractiveDBMaxConns     int
+	BatchDBMaxConns           int
+	LaneWaitTimeout           time.Duration
+	BatchPathPrefixes         []string
+
+	// UIから呼び出すAPIのベースURL（空の場合は同一オリジン）
+	APIBaseURL string
+
+	// gRPC サーバーのアドレス（例: :9090。空の場合は gRPC サーバーを起動しない）
+	GRPCAddr string
+
+	// JWT認証の設定
+	JWTSecret string
+	JWTTTL    time.Duration
+
+	// 評価証明書の検証コードの署名鍵（空の場合は JWTSecret を使用）
+	CertificateSecret string
+	// 証明書のQRコードなど、外部に公開するURLの起点
+	PublicBaseURL string
+
+	// アップロードされた画像の保存先（local または s3）
+	StorageDriver string
+	// STORAGE_DRIVER=local の保存先ディレクトリ
+	StorageDir string
+	// STORAGE_DRIVER=s3 の接続設定（S3互換であれば MinIO や GCS も可）
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3Prefix          string
+	S3PathStyle       bool
+
+	// 公開ポートフォリオページを有効にするか（オプトイン）
+	PortfolioEnabled bool
+
+	// メールの送信方法（log または smtp。log は送信せずログに出力する）
+	MailDriver string
+	MailFrom   string
+	// MAIL_DRIVER=smtp の接続設定
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+
+	// ダイジェストメールの配信停止リンクの署名鍵（空の場合は JWTSecret を使用）
+	DigestSecret string
+	// 配信する時期になったダイジェストメールを確認する間隔（0以下は定期配信しない）
+	DigestInterval time.Duration
+
+	// 集計やエクスポートの金額の換算に使う為替レート API（空の場合は換算せず円建てのみ合計する）
+	ExchangeRateAPIURL string
+	// 取得した為替レートをキャッシュする期間
+	ExchangeRateCacheTTL time.Duration
+
+	// アイテムの評価額の更新に使う相場 API（空の場合は相場での更新を受け付けない）
+	MarketPriceAPIURL string
+	// 相場 API に Bearer トークンとして送る API キー（空の場合は送らない）
+	MarketPriceAPIKey string
+	// すべてのアイテムの評価額を相場で更新する間隔（0以下は定期実行しない）
+	MarketPriceRefreshInterval time.Duration
+
+	// バックアップを読み込んで検証する検証用のスキーマ（空の場合は検証しない。本番のデータベースとは別のスキーマ）
+	BackupVerifySchema string
+	// バックアップを検証する間隔（0以下は定期実行しない）
+	BackupVerifyInterval time.Duration
+
+	// 購入書類（レシートや鑑定書）の画像の文字認識に使う OCR API（空の場合は書類のテキストを検索しない）
+	OCRAPIURL string
+	// OCR API に Bearer トークンとして送る API キー（空の場合は送らない）
+	OCRAPIKey string
+
+	// アイテムの登録の下書きを最後の更新から保持する期間
+	ItemDraftTTL time.Duration
+
+	// 同じクライアントによる同じ非推奨の API の利用をログに出力する間隔
+	DeprecationLogInterval time.Duration
+
+	// APIキーごとのリクエストの件数をデータベースに保存する間隔
+	APIUsageFlushInterval time.Duration
+
+	// 型番から登録内容を補完するカタログの JSON ファイル（空の場合は同梱のカタログを使用）
+	CatalogPath string
+
+	// 公開エンドポイントの不正利用対策のチャレンジ（turnstile または hcaptcha。空の場合はチャレンジを求めない）
+	ChallengeProvider string
+	ChallengeSiteKey  string
+	ChallengeSecret   string
+	// 検証 API の URL（空の場合は提供元の既定の URL）
+	ChallengeVerifyURL string
+	// チャレンジを求めるルートと条件（「GET /public/portfolios/{token}=60/1m/5」をカンマ区切り。リクエスト数/期間/404の数）
+	ChallengeRoutes []string
+	// チャレンジを解いたクライアントに再びチャレンジを求めない期間
+	ChallengePassTTL time.Duration
 )
 
 func init() {
@@ -27,12 +158,152 @@ func init() {
 	DBHost = os.Getenv("DB_HOST")
 	DBPort = os.Getenv("DB_PORT")
 	DBName = os.Getenv("DB_NAME")
+	DBReplicaHost = os.Getenv("DB_REPLICA_HOST")
+	DBReplicaPort = getEnv("DB_REPLICA_PORT", DBPort)
+	DBReplicaMaxLag = getEnvDuration("DB_REPLICA_MAX_LAG", 5*time.Second)
+	DBJapaneseCollation = os.Getenv("DB_JA_COLLATION")
+	IDGenerator = getEnv("ID_GENERATOR", "auto")
+	IDNodeID = getEnvInt("ID_NODE_ID", 0)
+	ItemNameMaxLength = getEnvInt("ITEM_NAME_MAX_LENGTH", 100)
+	ItemBrandMaxLength = getEnvInt("ITEM_BRAND_MAX_LENGTH", 100)
+	PurchaseDateAllowFuture = getEnvBool("PURCHASE_DATE_ALLOW_FUTURE", false)
+	PurchaseDateMaxAgeYears = getEnvInt("PURCHASE_DATE_MAX_AGE_YEARS", 0)
+	ItemRedactedFields = getEnvList("ITEM_REDACTED_FIELDS", []string{"viewer:purchase_price"})
+	ColumnMigrations = getEnvList("COLUMN_MIGRATIONS", nil)
+	ColumnBackfillPause = getEnvDuration("COLUMN_BACKFILL_PAUSE", 100*time.Millisecond)
+	Canaries = getEnvList("CANARIES", nil)
+	ImportMaxBytes = getEnvInt("IMPORT_MAX_BYTES", 1<<30)
+	JobRetention = getEnvDuration("JOB_RETENTION", 24*time.Hour)
+	JobMaxFinishedPerUser = getEnvInt("JOB_MAX_FINISHED_PER_USER", 50)
+
+	InteractiveMaxConcurrency = getEnvInt("INTERACTIVE_MAX_CONCURRENCY", 64)
+	BatchMaxConcurrency = getEnvInt("BATCH_MAX_CONCURRENCY", 2)
+	InteractiveDBMaxConns = getEnvInt("INTERACTIVE_DB_MAX_CONNS", 20)
+	BatchDBMaxConns = getEnvInt("BATCH_DB_MAX_CONNS", 4)
+	LaneWaitTimeout = getEnvDuration("LANE_WAIT_TIMEOUT", 5*time.Second)
+	APIBaseURL = os.Getenv("API_BASE_URL")
+	GRPCAddr = os.Getenv("GRPC_ADDR")
+	JWTSecret = os.Getenv("JWT_SECRET")
+	JWTTTL = getEnvDuration("JWT_TTL", 24*time.Hour)
+	CertificateSecret = os.Getenv("CERTIFICATE_SECRET")
+	if CertificateSecret == "" {
+		CertificateSecret = JWTSecret
+	}
+	PublicBaseURL = strings.TrimRight(getEnv("PUBLIC_BASE_URL", "http://localhost:8080"), "/")
+	StorageDriver = getEnv("STORAGE_DRIVER", "local")
+	StorageDir = getEnv("STORAGE_DIR", "./data/uploads")
+	S3Endpoint = os.Getenv("S3_ENDPOINT")
+	S3Region = getEnv("S3_REGION", "us-east-1")
+	S3Bucket = os.Getenv("S3_BUCKET")
+	S3AccessKeyID = os.Getenv("S3_ACCESS_KEY_ID")
+	S3SecretAccessKey = os.Getenv("S3_SECRET_ACCESS_KEY")
+	S3Prefix = os.Getenv("S3_PREFIX")
+	S3PathStyle = getEnvBool("S3_PATH_STYLE", false)
+	PortfolioEnabled = getEnvBool("PORTFOLIO_ENABLED", false)
+	MailDriver = getEnv("MAIL_DRIVER", "log")
+	MailFrom = getEnv("MAIL_FROM", "noreply@example.com")
+	SMTPHost = os.Getenv("SMTP_HOST")
+	SMTPPort = getEnv("SMTP_PORT", "587")
+	SMTPUsername = os.Getenv("SMTP_USERNAME")
+	SMTPPassword = os.Getenv("SMTP_PASSWORD")
+	DigestSecret = os.Getenv("DIGEST_SECRET")
+	if DigestSecret == "" {
+		DigestSecret = JWTSecret
+	}
+	DigestInterval = getEnvDuration("DIGEST_INTERVAL", time.Hour)
+	BatchPathPrefixes = getEnvList("BATCH_PATH_PREFIXES", []string{"/items/import", "/items/export", "/reports", "/admin/reports", "/admin/events", "/admin/backup", "/admin/restore"})
+	ExchangeRateAPIURL = os.Getenv("EXCHANGE_RATE_API_URL")
+	ExchangeRateCacheTTL = getEnvDuration("EXCHANGE_RATE_CACHE_TTL", time.Hour)
+	MarketPriceAPIURL = os.Getenv("MARKET_PRICE_API_URL")
+	MarketPriceAPIKey = os.Getenv("MARKET_PRICE_API_KEY")
+	MarketPriceRefreshInterval = getEnvDuration("MARKET_PRICE_REFRESH_INTERVAL", 24*time.Hour)
+	BackupVerifySchema = os.Getenv("BACKUP_VERIFY_SCHEMA")
+	BackupVerifyInterval = getEnvDuration("BACKUP_VERIFY_INTERVAL", 24*time.Hour)
+	OCRAPIURL = os.Getenv("OCR_API_URL")
+	OCRAPIKey = os.Getenv("OCR_API_KEY")
+	ItemDraftTTL = getEnvDuration("ITEM_DRAFT_TTL", 24*time.Hour)
+	DeprecationLogInterval = getEnvDuration("DEPRECATION_LOG_INTERVAL", 24*time.Hour)
+	APIUsageFlushInterval = getEnvDuration("API_USAGE_FLUSH_INTERVAL", time.Minute)
+	CatalogPath = os.Getenv("CATALOG_PATH")
+	ChallengeProvider = os.Getenv("CHALLENGE_PROVIDER")
+	ChallengeSiteKey = os.Getenv("CHALLENGE_SITE_KEY")
+	ChallengeSecret = os.Getenv("CHALLENGE_SECRET")
+	ChallengeVerifyURL = os.Getenv("CHALLENGE_VERIFY_URL")
+	ChallengeRoutes = getEnvList("CHALLENGE_ROUTES", []string{
+		"POST /auth/register=5/1h",
+		"GET /public/portfolios/{token}=60/1m/5",
+		"GET /public/portfolios/{token}/page=60/1m/5",
+	})
+	ChallengePassTTL = getEnvDuration("CHALLENGE_PASS_TTL", 30*time.Minute)
 }
 
 // DB接続文字列を返す
 func GetDSN() string {
+	return dsn(DBHost, DBPort)
+}
+
+// GetReplicaDSN はレプリカの接続文字列（ユーザー・パスワード・データベース名はプライマリと同じ）
+func GetReplicaDSN() string {
+	return dsn(DBReplicaHost, DBReplicaPort)
+}
+
+func dsn(host, port string) string {
 	return fmt.Sprintf(
 		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL",
-		DBUser, DBPassword, DBHost, DBPort, DBName,
+		DBUser, DBPassword, host, port, DBName,
 	)
 }
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("⚠️  %s の値が不正です (%q)。デフォルト値 %d を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("⚠️  %s の値が不正です (%q)。デフォルト値 %t を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("⚠️  %s の値が不正です (%q)。デフォルト値 %s を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return strings.Split(value, ",")
+}
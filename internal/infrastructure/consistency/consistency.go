@@ -0,0 +1,73 @@
+package consistency
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// HeaderToken は書き込みの時刻をクライアントに返し、以降のリクエストで送り返してもらうヘッダー
+const HeaderToken = "X-Consistency-Token"
+
+// Token はリクエストで最後に書き込んだ時刻。書き込みの後の読み込みを、レプリカに反映されるまでプライマリに送るために使う
+type Token struct {
+	mu        sync.Mutex
+	writtenAt time.Time
+}
+
+// Parse はクライアントが送り返したトークン（書き込み時刻の UNIX ミリ秒）を読み込む。空や不正な値は書き込みのないトークンになる
+func Parse(value string) *Token {
+	t := &Token{}
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil && ms > 0 {
+		t.writtenAt = time.UnixMilli(ms)
+	}
+	return t
+}
+
+// MarkWrite は書き込みを記録する。
+// 書き込みが失敗した場合も DB に反映されている可能性があるため、実行する前に記録して再送や続く読み込みをプライマリに送る
+func (t *Token) MarkWrite(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.After(t.writtenAt) {
+		t.writtenAt = now
+	}
+}
+
+// RequiresPrimary は最後の書き込みから maxLag が経つまでは true を返す
+func (t *Token) RequiresPrimary(now time.Time, maxLag time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.writtenAt.IsZero() && now.Before(t.writtenAt.Add(maxLag))
+}
+
+// WrittenAt は最後に書き込んだ時刻を返す（書き込みがない場合はゼロ値）
+func (t *Token) WrittenAt() time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.writtenAt
+}
+
+// String はレスポンスのヘッダーに返す値（書き込みがない場合は空）
+func (t *Token) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.writtenAt.IsZero() {
+		return ""
+	}
+	return strconv.FormatInt(t.writtenAt.UnixMilli(), 10)
+}
+
+type contextKey struct{}
+
+// WithToken はコンテキストにトークンを設定する
+func WithToken(ctx context.Context, t *Token) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext はコンテキストからトークンを取得する（未設定の場合は nil）
+func FromContext(ctx context.Context) *Token {
+	t, _ := ctx.Value(contextKey{}).(*Token)
+	return t
+}
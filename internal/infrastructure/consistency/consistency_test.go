@@ -0,0 +1,53 @@
+package consistency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken(t *testing.T) {
+	now := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("書き込みから maxLag の間はプライマリに送る", func(t *testing.T) {
+		token := Parse("")
+		assert.False(t, token.RequiresPrimary(now, 5*time.Second))
+		assert.Empty(t, token.String())
+
+		token.MarkWrite(now)
+		assert.True(t, token.RequiresPrimary(now.Add(4*time.Second), 5*time.Second))
+		assert.False(t, token.RequiresPrimary(now.Add(5*time.Second), 5*time.Second))
+	})
+
+	t.Run("送り返されたトークンで次のリクエストもプライマリに送る", func(t *testing.T) {
+		written := Parse("")
+		written.MarkWrite(now)
+
+		token := Parse(written.String())
+		assert.True(t, token.RequiresPrimary(now.Add(time.Second), 5*time.Second))
+		assert.Equal(t, written.String(), token.String())
+		assert.True(t, now.Equal(token.WrittenAt()))
+	})
+
+	t.Run("古い時刻では記録を戻さない", func(t *testing.T) {
+		token := Parse("")
+		token.MarkWrite(now)
+		token.MarkWrite(now.Add(-time.Minute))
+
+		assert.True(t, token.RequiresPrimary(now.Add(time.Second), 5*time.Second))
+	})
+
+	t.Run("不正なトークンは書き込みのないトークンになる", func(t *testing.T) {
+		assert.Empty(t, Parse("abc").String())
+		assert.Empty(t, Parse("-1").String())
+	})
+
+	t.Run("コンテキスト", func(t *testing.T) {
+		assert.Nil(t, FromContext(context.Background()))
+
+		token := Parse("")
+		assert.Same(t, token, FromContext(WithToken(context.Background(), token)))
+	})
+}
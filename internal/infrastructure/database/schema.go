@@ -0,0 +1,100 @@
+package databaseInfra
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// init.sql の文から対象のテーブル名を取り出す（CREATE TABLE IF NOT EXISTS t / INSERT INTO t）
+var (
+	createTablePattern = regexp.MustCompile(`(?i)^CREATE\s+TABLE\s+(?:IF\s+NOT\s+EXISTS\s+)?` + "`?" + `(\w+)`)
+	insertPattern      = regexp.MustCompile(`(?i)^INSERT\s+INTO\s+` + "`?" + `(\w+)`)
+)
+
+// SplitStatements は SQL スクリプトを文ごとに分ける（-- のコメントを除き、文字列中の ; では分けない）
+func SplitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	var quote byte
+	for i := 0; i < len(script); i++ {
+		ch := script[i]
+		switch {
+		case quote != 0:
+			current.WriteByte(ch)
+			if ch == '\\' && i+1 < len(script) {
+				i++
+				current.WriteByte(script[i])
+			} else if ch == quote {
+				quote = 0
+			}
+		case ch == '\'' || ch == '"' || ch == '`':
+			quote = ch
+			current.WriteByte(ch)
+		case ch == '-' && strings.HasPrefix(script[i:], "--"):
+			// 行末までのコメントを読み飛ばす
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end - 1
+			} else {
+				i = len(script)
+			}
+		case ch == ';':
+			if statement := strings.TrimSpace(current.String()); statement != "" {
+				statements = append(statements, statement)
+			}
+			current.Reset()
+		default:
+			current.WriteByte(ch)
+		}
+	}
+	if statement := strings.TrimSpace(current.String()); statement != "" {
+		statements = append(statements, statement)
+	}
+	return statements
+}
+
+// Migrate は init.sql の CREATE TABLE 文を順に実行し、存在しないテーブルを作成して作成を試みたテーブルを返す。
+// 既存のテーブルの列は変更しないため、列の追加は ALTER TABLE で行う
+func Migrate(ctx context.Context, db database.SqlHandler, script string) ([]string, error) {
+	var tables []string
+	for _, statement := range SplitStatements(script) {
+		match := createTablePattern.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+		if _, err := db.Execute(ctx, statement); err != nil {
+			return tables, fmt.Errorf("failed to create table %s: %w", match[1], err)
+		}
+		tables = append(tables, match[1])
+	}
+	return tables, nil
+}
+
+// Seed は init.sql の INSERT 文（初期のカテゴリーとサンプルデータ）のうち、対象のテーブルが空のものを実行し、
+// データを登録したテーブルを返す（繰り返し実行しても重複して登録しない）
+func Seed(ctx context.Context, db database.SqlHandler, script string) ([]string, error) {
+	var tables []string
+	for _, statement := range SplitStatements(script) {
+		match := insertPattern.FindStringSubmatch(statement)
+		if match == nil {
+			continue
+		}
+		table := match[1]
+
+		var exists bool
+		if err := db.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM `"+table+"`)").Scan(&exists); err != nil {
+			return tables, fmt.Errorf("failed to check table %s: %w", table, err)
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Execute(ctx, statement); err != nil {
+			return tables, fmt.Errorf("failed to seed table %s: %w", table, err)
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
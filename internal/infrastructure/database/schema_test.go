@@ -0,0 +1,102 @@
+package databaseInfra
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+func TestSplitStatements(t *testing.T) {
+	t.Run("コメントを除き、文字列中の ; では分けない", func(t *testing.T) {
+		statements := SplitStatements(`
+-- 先頭のコメント
+CREATE TABLE a (
+    id BIGINT, -- 列のコメント; 区切りではない
+    name VARCHAR(10) COMMENT 'a; b -- c'
+);
+INSERT INTO a (name) VALUES ('it''s; fine'), ('back\'slash;');
+SELECT 1`)
+
+		require.Len(t, statements, 3)
+		assert.Equal(t, "CREATE TABLE a (\n    id BIGINT, \n    name VARCHAR(10) COMMENT 'a; b -- c'\n)", statements[0])
+		assert.Equal(t, `INSERT INTO a (name) VALUES ('it''s; fine'), ('back\'slash;')`, statements[1])
+		assert.Equal(t, "SELECT 1", statements[2])
+	})
+
+	t.Run("init.sql のすべてのテーブルと初期データを分ける", func(t *testing.T) {
+		script, err := os.ReadFile("../../../sql/init.sql")
+		require.NoError(t, err)
+
+		var creates, inserts int
+		for _, statement := range SplitStatements(string(script)) {
+			switch {
+			case createTablePattern.MatchString(statement):
+				creates++
+			case insertPattern.MatchString(statement):
+				inserts++
+			}
+		}
+		assert.Equal(t, strings.Count(string(script), "\nCREATE TABLE IF NOT EXISTS "), creates)
+		assert.Equal(t, 2, inserts)
+	})
+}
+
+// 実行した文を記録し、テーブルが空かの問い合わせには nonEmpty で答える SqlHandler
+type recordingSqlHandler struct {
+	database.SqlHandler
+	nonEmpty map[string]bool
+	executed []string
+}
+
+func (h *recordingSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	h.executed = append(h.executed, statement)
+	return nil, nil
+}
+
+func (h *recordingSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	table := strings.TrimSuffix(strings.TrimPrefix(statement, "SELECT EXISTS (SELECT 1 FROM `"), "`)")
+	return existsRow(h.nonEmpty[table])
+}
+
+type existsRow bool
+
+func (r existsRow) Scan(dest ...interface{}) error {
+	*dest[0].(*bool) = bool(r)
+	return nil
+}
+
+const testScript = `
+SET NAMES utf8mb4;
+CREATE TABLE IF NOT EXISTS categories (id BIGINT);
+CREATE TABLE IF NOT EXISTS items (id BIGINT);
+INSERT INTO categories (name) VALUES ('時計');
+INSERT INTO items (name) VALUES ('ロレックス');
+`
+
+func TestMigrate(t *testing.T) {
+	db := &recordingSqlHandler{}
+
+	tables, err := Migrate(context.Background(), db, testScript)
+	require.NoError(t, err)
+
+	// CREATE TABLE 文のみ実行する
+	assert.Equal(t, []string{"categories", "items"}, tables)
+	assert.Equal(t, []string{"CREATE TABLE IF NOT EXISTS categories (id BIGINT)", "CREATE TABLE IF NOT EXISTS items (id BIGINT)"}, db.executed)
+}
+
+func TestSeed(t *testing.T) {
+	db := &recordingSqlHandler{nonEmpty: map[string]bool{"categories": true}}
+
+	tables, err := Seed(context.Background(), db, testScript)
+	require.NoError(t, err)
+
+	// データがあるテーブルには登録しない
+	assert.Equal(t, []string{"items"}, tables)
+	assert.Equal(t, []string{"INSERT INTO items (name) VALUES ('ロレックス')"}, db.executed)
+}
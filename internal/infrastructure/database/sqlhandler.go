@@ -3,17 +3,27 @@ package databaseInfra
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 
 	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/consistency"
+	"Aicon-assignment/internal/infrastructure/lane"
 	"Aicon-assignment/internal/interfaces/database"
 )
 
 type MySqlHandler struct {
 	Conn *sql.DB
+	// BatchConn はバッチレーン専用の接続プール（nilの場合は Conn を使用）
+	BatchConn *sql.DB
+	// ReplicaConn は画面操作の読み込みに使うレプリカの接続プール（nilの場合は Conn を使用）
+	ReplicaConn *sql.DB
+	// ReplicaMaxLag はレプリカの反映の遅れの上限。書き込みからこの時間が経つまでは、同じトークンの読み込みをプライマリに送る
+	ReplicaMaxLag time.Duration
 }
 
 func NewSqlHandler() database.SqlHandler {
@@ -22,6 +32,9 @@ func NewSqlHandler() database.SqlHandler {
 	if err != nil {
 		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
 	}
+	if config.InteractiveDBMaxConns > 0 {
+		conn.SetMaxOpenConns(config.InteractiveDBMaxConns)
+	}
 
 	// DB接続が確立できているかを確認
 	if err := conn.Ping(); err != nil {
@@ -41,19 +54,77 @@ func NewSqlHandler() database.SqlHandler {
 		}
 	}
 
-	return &MySqlHandler{Conn: conn}
+	// バッチ処理が画面操作用の接続を使い切らないよう、別のプールを用意する
+	batchConn, err := sql.Open("mysql", dsn)
+	if err != nil {
+		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
+	}
+	if config.BatchDBMaxConns > 0 {
+		batchConn.SetMaxOpenConns(config.BatchDBMaxConns)
+	}
+	if err := batchConn.Ping(); err != nil {
+		panic(fmt.Sprintf("❌ Failed to ping batch database: %v", err))
+	}
+
+	handler := &MySqlHandler{Conn: conn, BatchConn: batchConn, ReplicaMaxLag: config.DBReplicaMaxLag}
+
+	// レプリカを指定した場合は、画面操作の読み込みをレプリカに送る
+	if config.DBReplicaHost != "" {
+		replicaConn, err := sql.Open("mysql", config.GetReplicaDSN())
+		if err != nil {
+			panic(fmt.Sprintf("❌ Failed to connect to replica database: %v", err))
+		}
+		if config.InteractiveDBMaxConns > 0 {
+			replicaConn.SetMaxOpenConns(config.InteractiveDBMaxConns)
+		}
+		if err := replicaConn.Ping(); err != nil {
+			panic(fmt.Sprintf("❌ Failed to ping replica database: %v", err))
+		}
+		fmt.Println("✅ Successfully connected to the replica database!")
+		handler.ReplicaConn = replicaConn
+	}
+
+	return handler
+}
+
+// コンテキストのレーンに応じた接続プールを返す
+func (h *MySqlHandler) db(ctx context.Context) *sql.DB {
+	if lane.FromContext(ctx) == lane.Batch && h.BatchConn != nil {
+		return h.BatchConn
+	}
+	return h.Conn
+}
+
+// readDB は読み込みに使う接続プールを返す。
+// 画面操作の読み込みはレプリカに送り、同じトークンで書き込んでからレプリカに反映されるまではプライマリに送る（read-your-writes）
+func (h *MySqlHandler) readDB(ctx context.Context) *sql.DB {
+	if h.ReplicaConn == nil || lane.FromContext(ctx) == lane.Batch {
+		return h.db(ctx)
+	}
+	if token := consistency.FromContext(ctx); token != nil && token.RequiresPrimary(time.Now(), h.ReplicaMaxLag) {
+		return h.db(ctx)
+	}
+	return h.ReplicaConn
+}
+
+// markWrite はコンテキストのトークンに書き込みを記録する
+func markWrite(ctx context.Context) {
+	if token := consistency.FromContext(ctx); token != nil {
+		token.MarkWrite(time.Now())
+	}
 }
 
 func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
-	result, err := h.Conn.ExecContext(ctx, statement, args...)
+	markWrite(ctx)
+	result, err := h.db(ctx).ExecContext(ctx, statement, args...)
 	if err != nil {
-		return nil, err
+		return nil, translateError(err)
 	}
 	return &mysqlResult{result: result}, nil
 }
 
 func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
-	rows, err := h.Conn.QueryContext(ctx, statement, args...)
+	rows, err := h.readDB(ctx).QueryContext(ctx, statement, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -61,17 +132,91 @@ func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...inte
 }
 
 func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
-	row := h.Conn.QueryRowContext(ctx, statement, args...)
+	row := h.readDB(ctx).QueryRowContext(ctx, statement, args...)
 	return &mysqlRow{row: row}
 }
 
+// Transaction はプライマリで実行する（トランザクション内の読み込みも含む）
+func (h *MySqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context, tx database.SqlHandler) error) error {
+	markWrite(ctx)
+	tx, err := h.db(ctx).BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(ctx, &txHandler{tx: tx}); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %s)", err, rollbackErr.Error())
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
 func (h *MySqlHandler) Close() error {
+	if h.ReplicaConn != nil {
+		if err := h.ReplicaConn.Close(); err != nil {
+			return err
+		}
+	}
+	if h.BatchConn != nil {
+		if err := h.BatchConn.Close(); err != nil {
+			return err
+		}
+	}
 	if h.Conn != nil {
 		return h.Conn.Close()
 	}
 	return nil
 }
 
+// txHandler はトランザクション内で SQL を実行する
+type txHandler struct {
+	tx *sql.Tx
+}
+
+func (h *txHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := h.tx.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return nil, translateError(err)
+	}
+	return &mysqlResult{result: result}, nil
+}
+
+func (h *txHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := h.tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &mysqlRows{rows: rows}, nil
+}
+
+func (h *txHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	return &mysqlRow{row: h.tx.QueryRowContext(ctx, statement, args...)}
+}
+
+// Transaction は外側のトランザクションに参加する（コミット・ロールバックは外側で行う）
+func (h *txHandler) Transaction(ctx context.Context, fn func(ctx context.Context, tx database.SqlHandler) error) error {
+	return fn(ctx, h)
+}
+
+// Close はトランザクションを終了しない（終了は MySqlHandler.Transaction が行う）
+func (h *txHandler) Close() error {
+	return nil
+}
+
+// MySQLの重複キーエラー番号
+const mysqlErrDuplicateEntry = 1062
+
+// ドライバー固有のエラーを database パッケージのエラーに変換する
+func translateError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return fmt.Errorf("%w: %s", database.ErrDuplicateKey, err.Error())
+	}
+	return err
+}
+
 type mysqlResult struct {
 	result sql.Result
 }
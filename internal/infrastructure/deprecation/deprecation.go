@@ -0,0 +1,125 @@
+// Package deprecation は OpenAPI 仕様で非推奨にしたエンドポイント・パラメーター・項目の
+// Deprecation / Sunset ヘッダーと、クライアントごとの利用状況の記録を提供する
+package deprecation
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Extension は非推奨の日付と代替を記述する OpenAPI の拡張（deprecated: true と合わせて指定する）
+//
+//	deprecated: true
+//	x-deprecation:
+//	  since: 2024-10-01       # 非推奨にした日（Deprecation ヘッダー）
+//	  sunset: 2025-03-31      # 削除する予定日（Sunset ヘッダー。/v2 で削除するまで未定なら省略）
+//	  replacement: GET /me/preferences
+//	  link: https://example.com/docs/migration
+const Extension = "x-deprecation"
+
+// Notice は非推奨の API の告知内容
+type Notice struct {
+	// Since は非推奨にした日（ゼロ値は日付を告知しない）
+	Since time.Time
+	// Sunset は削除する予定日（ゼロ値は未定）
+	Sunset time.Time
+	// Replacement は代わりに使うエンドポイントや項目
+	Replacement string
+	// Link は移行方法の説明の URL
+	Link string
+}
+
+// FromExtensions は deprecated: true の要素の拡張から告知内容を読み込む（拡張がない場合は日付のない告知）
+func FromExtensions(extensions map[string]any) (Notice, error) {
+	var notice Notice
+	raw, ok := extensions[Extension]
+	if !ok {
+		return notice, nil
+	}
+	fields, ok := raw.(map[string]any)
+	if !ok {
+		return notice, fmt.Errorf("%s must be an object", Extension)
+	}
+
+	for key, value := range fields {
+		s, ok := value.(string)
+		if !ok {
+			return notice, fmt.Errorf("%s.%s must be a string", Extension, key)
+		}
+		var err error
+		switch key {
+		case "since":
+			notice.Since, err = parseDate(s)
+		case "sunset":
+			notice.Sunset, err = parseDate(s)
+		case "replacement":
+			notice.Replacement = s
+		case "link":
+			notice.Link = s
+		default:
+			err = fmt.Errorf("unknown key")
+		}
+		if err != nil {
+			return notice, fmt.Errorf("invalid %s.%s: %w", Extension, key, err)
+		}
+	}
+	if !notice.Since.IsZero() && !notice.Sunset.IsZero() && notice.Sunset.Before(notice.Since) {
+		return notice, fmt.Errorf("%s.sunset must not be before since", Extension)
+	}
+	return notice, nil
+}
+
+// parseDate は日付を読み込む。YAML の引用符のない日付はタイムスタンプとして読み込まれるため、RFC 3339 の形式も受け付ける
+func parseDate(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", s)
+}
+
+// Text は TypeScript の @deprecated などに書く説明（代替と削除予定日）
+func (n Notice) Text() string {
+	var parts []string
+	if n.Replacement != "" {
+		parts = append(parts, "use "+n.Replacement+" instead")
+	}
+	if !n.Sunset.IsZero() {
+		parts = append(parts, "removed after "+n.Sunset.Format("2006-01-02"))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Merge は複数の告知を1つのレスポンスのヘッダーにまとめる（最も早い非推奨日と削除予定日を使う）
+func Merge(notices []Notice) Notice {
+	var merged Notice
+	for _, n := range notices {
+		if merged.Since.IsZero() || (!n.Since.IsZero() && n.Since.Before(merged.Since)) {
+			merged.Since = n.Since
+		}
+		if merged.Sunset.IsZero() || (!n.Sunset.IsZero() && n.Sunset.Before(merged.Sunset)) {
+			merged.Sunset = n.Sunset
+		}
+		if merged.Link == "" {
+			merged.Link = n.Link
+		}
+	}
+	return merged
+}
+
+// SetHeaders はレスポンスに Deprecation（RFC 9745）・Sunset（RFC 8594）・Link ヘッダーを設定する
+func SetHeaders(h http.Header, n Notice) {
+	if n.Since.IsZero() {
+		// 日付を告知しない場合も非推奨であることは示す（RFC 9745 以前の草案の形式）
+		h.Set("Deprecation", "true")
+	} else {
+		h.Set("Deprecation", fmt.Sprintf("@%d", n.Since.Unix()))
+	}
+	if !n.Sunset.IsZero() {
+		h.Set("Sunset", n.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if n.Link != "" {
+		h.Add("Link", fmt.Sprintf(`<%s>; rel="deprecation"; type="text/html"`, n.Link))
+	}
+}
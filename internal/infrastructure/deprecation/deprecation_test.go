@@ -0,0 +1,145 @@
+package deprecation
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromExtensions(t *testing.T) {
+	tests := []struct {
+		name        string
+		extensions  map[string]any
+		expected    Notice
+		expectedErr string
+	}{
+		{
+			name:       "拡張がない",
+			extensions: map[string]any{},
+		},
+		{
+			name: "すべての項目",
+			extensions: map[string]any{Extension: map[string]any{
+				"since":       "2024-10-01",
+				"sunset":      "2025-03-31",
+				"replacement": "GET /me/preferences",
+				"link":        "https://example.com/migration",
+			}},
+			expected: Notice{
+				Since:       time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+				Sunset:      time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+				Replacement: "GET /me/preferences",
+				Link:        "https://example.com/migration",
+			},
+		},
+		{
+			name:       "YAML のタイムスタンプとして読み込まれた日付",
+			extensions: map[string]any{Extension: map[string]any{"since": "2024-10-01T00:00:00Z"}},
+			expected:   Notice{Since: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:        "オブジェクトでない",
+			extensions:  map[string]any{Extension: "2024-10-01"},
+			expectedErr: "x-deprecation must be an object",
+		},
+		{
+			name:        "日付の形式の誤り",
+			extensions:  map[string]any{Extension: map[string]any{"since": "2024/10/01"}},
+			expectedErr: "invalid x-deprecation.since",
+		},
+		{
+			name:        "未知の項目",
+			extensions:  map[string]any{Extension: map[string]any{"removed": "2025-03-31"}},
+			expectedErr: "invalid x-deprecation.removed",
+		},
+		{
+			name:        "削除予定日が非推奨にした日より前",
+			extensions:  map[string]any{Extension: map[string]any{"since": "2024-10-01", "sunset": "2024-09-30"}},
+			expectedErr: "x-deprecation.sunset must not be before since",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notice, err := FromExtensions(tt.extensions)
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, notice)
+		})
+	}
+}
+
+func TestNotice_Text(t *testing.T) {
+	assert.Equal(t, "", Notice{}.Text())
+	assert.Equal(t, "use GET /new instead", Notice{Replacement: "GET /new"}.Text())
+	assert.Equal(t, "removed after 2025-03-31", Notice{Sunset: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC)}.Text())
+}
+
+func TestMerge(t *testing.T) {
+	merged := Merge([]Notice{
+		{},
+		{Since: time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC), Sunset: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), Link: "https://example.com/a"},
+		{Since: time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), Link: "https://example.com/b"},
+	})
+	assert.Equal(t, Notice{
+		Since:  time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		Sunset: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+		Link:   "https://example.com/a",
+	}, merged)
+}
+
+func TestSetHeaders(t *testing.T) {
+	t.Run("日付と移行方法の告知", func(t *testing.T) {
+		h := http.Header{}
+		SetHeaders(h, Notice{
+			Since:  time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			Sunset: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+			Link:   "https://example.com/migration",
+		})
+		assert.Equal(t, "@1727740800", h.Get("Deprecation"))
+		assert.Equal(t, "Mon, 31 Mar 2025 00:00:00 GMT", h.Get("Sunset"))
+		assert.Equal(t, `<https://example.com/migration>; rel="deprecation"; type="text/html"`, h.Get("Link"))
+	})
+
+	t.Run("日付のない告知", func(t *testing.T) {
+		h := http.Header{}
+		SetHeaders(h, Notice{})
+		assert.Equal(t, "true", h.Get("Deprecation"))
+		assert.Empty(t, h.Get("Sunset"))
+		assert.Empty(t, h.Values("Link"))
+	})
+}
+
+func TestTracker_Record(t *testing.T) {
+	var logs []string
+	tracker := NewTracker(time.Hour, func(format string, args ...any) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	})
+	now := time.Date(2024, 10, 1, 10, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+
+	// 最初の利用はすぐに出力する
+	tracker.Record("GET /old", "user 1 (a)")
+	require.Len(t, logs, 1)
+	assert.Contains(t, logs[0], "GET /old used by user 1 (a) (1 times since last report, 1 in total)")
+
+	// 間隔内の利用はまとめる（クライアントが違えば別に出力する）
+	now = now.Add(10 * time.Minute)
+	tracker.Record("GET /old", "user 1 (a)")
+	tracker.Record("GET /old", "user 1 (a)")
+	tracker.Record("GET /old", "user 2 (b)")
+	require.Len(t, logs, 2)
+	assert.Contains(t, logs[1], "used by user 2 (b)")
+
+	now = now.Add(time.Hour)
+	tracker.Record("GET /old", "user 1 (a)")
+	require.Len(t, logs, 3)
+	assert.Contains(t, logs[2], "GET /old used by user 1 (a) (3 times since last report, 4 in total)")
+}
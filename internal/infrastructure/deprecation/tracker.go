@@ -0,0 +1,63 @@
+package deprecation
+
+import (
+	"sync"
+	"time"
+)
+
+type usageKey struct {
+	surface string
+	client  string
+}
+
+// usage は非推奨の API のクライアントごとの利用回数
+type usage struct {
+	count    int64
+	unlogged int64
+	loggedAt time.Time
+}
+
+// Tracker は非推奨の API の利用をクライアントごとに数え、ログに出力する。
+// 同じクライアントの同じ API の利用は、最初の1回と、その後は interval ごとに回数をまとめて出力する
+type Tracker struct {
+	interval time.Duration
+	now      func() time.Time
+	logf     func(format string, args ...any)
+
+	mu     sync.Mutex
+	usages map[usageKey]*usage
+}
+
+// NewTracker は logf（通常は log.Printf）に利用状況を出力する Tracker を作成する
+func NewTracker(interval time.Duration, logf func(format string, args ...any)) *Tracker {
+	return &Tracker{
+		interval: interval,
+		now:      time.Now,
+		logf:     logf,
+		usages:   make(map[usageKey]*usage),
+	}
+}
+
+// Record は非推奨の API（"GET /items/search" や "PATCH /items/{id} body.brand"）の利用を記録する
+func (t *Tracker) Record(surface, client string) {
+	now := t.now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := usageKey{surface: surface, client: client}
+	u, ok := t.usages[key]
+	if !ok {
+		u = &usage{}
+		t.usages[key] = u
+	}
+	u.count++
+	u.unlogged++
+
+	if !u.loggedAt.IsZero() && now.Sub(u.loggedAt) < t.interval {
+		return
+	}
+	t.logf("⚠️  deprecated %s used by %s (%d times since last report, %d in total)", surface, client, u.unlogged, u.count)
+	u.loggedAt = now
+	u.unlogged = 0
+}
@@ -0,0 +1,136 @@
+package exchangerate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Client は為替レート API から基準通貨ごとのレートを取得して金額を換算する。
+// API は GET {BaseURL}/{基準通貨} で {"result":"success","rates":{"USD":0.0067,...}} を返す形式（open.er-api.com など）
+type Client struct {
+	baseURL *url.URL
+	ttl     time.Duration
+	client  *http.Client
+	now     func() time.Time
+
+	mu    sync.Mutex
+	cache map[entity.Currency]*rates
+}
+
+// rates は基準通貨の1単位あたりの各通貨の金額
+type rates struct {
+	values    map[entity.Currency]float64
+	fetchedAt time.Time
+}
+
+// NewClient は取得したレートを ttl の間キャッシュするクライアントを返す
+func NewClient(baseURL string, ttl time.Duration) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("exchange rate: invalid base url: %q", baseURL)
+	}
+
+	return &Client{
+		baseURL: u,
+		ttl:     ttl,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		now:     time.Now,
+		cache:   make(map[entity.Currency]*rates),
+	}, nil
+}
+
+// Convert は from の最小単位の金額を to の最小単位の金額に換算する（最小単位未満は四捨五入）
+func (c *Client) Convert(ctx context.Context, amount int64, from, to entity.Currency) (int64, error) {
+	if from == to {
+		return amount, nil
+	}
+
+	rate, err := c.rate(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	value := math.Round(float64(amount) * rate * math.Pow10(to.MinorUnits()-from.MinorUnits()))
+	switch {
+	case value >= math.MaxInt64:
+		return math.MaxInt64, nil
+	case value <= math.MinInt64:
+		return math.MinInt64, nil
+	}
+	return int64(value), nil
+}
+
+// rate は from の1単位あたりの to の金額を返す。
+// キャッシュが古い場合は取得し直し、取得に失敗した場合は古いレートで換算を続ける
+func (c *Client) rate(ctx context.Context, from, to entity.Currency) (float64, error) {
+	// 同じ基準通貨のレートを同時に何度も取得しないよう、取得中もロックを保持する
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached := c.cache[from]
+	if cached == nil || c.now().Sub(cached.fetchedAt) >= c.ttl {
+		fetched, err := c.fetch(ctx, from)
+		switch {
+		case err == nil:
+			c.cache[from] = fetched
+			cached = fetched
+		case cached != nil:
+			log.Printf("⚠️  failed to refresh exchange rates for %s, using rates fetched at %s: %v", from, cached.fetchedAt.Format(time.RFC3339), err)
+		default:
+			return 0, fmt.Errorf("%w: %s", domainErrors.ErrExchangeRateUnavailable, err.Error())
+		}
+	}
+
+	rate, ok := cached.values[to]
+	if !ok || rate <= 0 {
+		return 0, fmt.Errorf("%w: no rate from %s to %s", domainErrors.ErrExchangeRateUnavailable, from, to)
+	}
+	return rate, nil
+}
+
+type ratesResponse struct {
+	Result string                      `json:"result"`
+	Rates  map[entity.Currency]float64 `json:"rates"`
+}
+
+func (c *Client) fetch(ctx context.Context, base entity.Currency) (*rates, error) {
+	endpoint := c.baseURL.JoinPath(string(base))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("exchange rate api returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var body ratesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid exchange rate response: %w", err)
+	}
+	if body.Result != "success" || len(body.Rates) == 0 {
+		return nil, fmt.Errorf("exchange rate api returned result %q", body.Result)
+	}
+
+	return &rates{values: body.Rates, fetchedAt: c.now()}, nil
+}
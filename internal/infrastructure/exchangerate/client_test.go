@@ -0,0 +1,130 @@
+package exchangerate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeAPI は基準通貨ごとのレートを返す為替レート API
+type fakeAPI struct {
+	requests atomic.Int32
+	failing  atomic.Bool
+}
+
+func (f *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests.Add(1)
+	if f.failing.Load() {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	switch r.URL.Path {
+	case "/v6/latest/USD":
+		fmt.Fprint(w, `{"result":"success","base_code":"USD","rates":{"USD":1,"JPY":150.5,"EUR":0.92}}`)
+	case "/v6/latest/JPY":
+		fmt.Fprint(w, `{"result":"success","base_code":"JPY","rates":{"JPY":1,"USD":0.0066445}}`)
+	default:
+		fmt.Fprint(w, `{"result":"error","error-type":"unsupported-code"}`)
+	}
+}
+
+func newTestClient(t *testing.T, api *fakeAPI) (*Client, *time.Time) {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL+"/v6/latest/", time.Hour)
+	require.NoError(t, err)
+	now := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	client.now = func() time.Time { return now }
+	return client, &now
+}
+
+func TestClient_Convert(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("最小単位の桁数の違いを考慮して換算する", func(t *testing.T) {
+		client, _ := newTestClient(t, &fakeAPI{})
+
+		// $123.45 → ¥18,579.225 → ¥18,579
+		value, err := client.Convert(ctx, 12345, entity.CurrencyUSD, entity.CurrencyJPY)
+		require.NoError(t, err)
+		assert.Equal(t, int64(18579), value)
+
+		// ¥1,500,000 → $9,966.75
+		value, err = client.Convert(ctx, 1500000, entity.CurrencyJPY, entity.CurrencyUSD)
+		require.NoError(t, err)
+		assert.Equal(t, int64(996675), value)
+	})
+
+	t.Run("同じ通貨は API を呼ばない", func(t *testing.T) {
+		api := &fakeAPI{}
+		client, _ := newTestClient(t, api)
+
+		value, err := client.Convert(ctx, 1500000, entity.CurrencyJPY, entity.CurrencyJPY)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1500000), value)
+		assert.Zero(t, api.requests.Load())
+	})
+
+	t.Run("TTL の間はキャッシュしたレートを使う", func(t *testing.T) {
+		api := &fakeAPI{}
+		client, now := newTestClient(t, api)
+
+		for i := 0; i < 3; i++ {
+			_, err := client.Convert(ctx, 100, entity.CurrencyUSD, entity.CurrencyJPY)
+			require.NoError(t, err)
+		}
+		_, err := client.Convert(ctx, 100, entity.CurrencyUSD, entity.CurrencyEUR)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), api.requests.Load())
+
+		*now = now.Add(time.Hour)
+		_, err = client.Convert(ctx, 100, entity.CurrencyUSD, entity.CurrencyJPY)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), api.requests.Load())
+	})
+
+	t.Run("取得に失敗した場合は古いレートを使う", func(t *testing.T) {
+		api := &fakeAPI{}
+		client, now := newTestClient(t, api)
+
+		_, err := client.Convert(ctx, 100, entity.CurrencyUSD, entity.CurrencyJPY)
+		require.NoError(t, err)
+
+		api.failing.Store(true)
+		*now = now.Add(2 * time.Hour)
+		value, err := client.Convert(ctx, 100, entity.CurrencyUSD, entity.CurrencyJPY)
+		require.NoError(t, err)
+		assert.Equal(t, int64(151), value)
+	})
+
+	t.Run("レートを取得できない場合", func(t *testing.T) {
+		api := &fakeAPI{}
+		api.failing.Store(true)
+		client, _ := newTestClient(t, api)
+
+		_, err := client.Convert(ctx, 100, entity.CurrencyUSD, entity.CurrencyJPY)
+		assert.True(t, domainErrors.IsExchangeRateUnavailableError(err))
+
+		api.failing.Store(false)
+		_, err = client.Convert(ctx, 100, entity.CurrencyEUR, entity.CurrencyJPY)
+		assert.True(t, domainErrors.IsExchangeRateUnavailableError(err))
+		_, err = client.Convert(ctx, 100, entity.CurrencyJPY, entity.CurrencyEUR)
+		assert.True(t, domainErrors.IsExchangeRateUnavailableError(err))
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient("open.er-api.com/v6/latest", time.Hour)
+	assert.Error(t, err)
+}
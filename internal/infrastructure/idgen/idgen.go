@@ -0,0 +1,42 @@
+// Package idgen はアイテムの ID の採番方法を提供する
+package idgen
+
+import (
+	"fmt"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 採番方法の種類
+const (
+	StrategyAutoIncrement = "auto"
+	StrategySnowflake     = "snowflake"
+	StrategyULID          = "ulid"
+)
+
+var (
+	_ database.IDGenerator = database.AutoIncrement{}
+	_ database.IDGenerator = (*Snowflake)(nil)
+)
+
+// Config は採番の設定。Strategy に応じて使われる項目が異なる
+type Config struct {
+	Strategy string
+	// snowflake
+	NodeID int64
+}
+
+// New は設定に応じた採番方法を作成する（未設定の場合はデータベースの AUTO_INCREMENT）
+func New(cfg Config) (database.IDGenerator, error) {
+	switch cfg.Strategy {
+	case "", StrategyAutoIncrement:
+		return database.AutoIncrement{}, nil
+	case StrategySnowflake:
+		return NewSnowflake(cfg.NodeID)
+	case StrategyULID:
+		// ULID は128ビットのため、64ビット整数の ID（API とテーブル）に収まらない
+		return nil, fmt.Errorf("id generator %q is not supported: ids are 64-bit integers", cfg.Strategy)
+	default:
+		return nil, fmt.Errorf("unknown id generator: %q", cfg.Strategy)
+	}
+}
@@ -0,0 +1,77 @@
+package idgen
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Snowflake の ID のビット構成。ブラウザ（JavaScript の number）で精度を失わないよう、
+// 合計を 53 ビット（Number.MAX_SAFE_INTEGER）に収める
+const (
+	snowflakeTimeBits     = 31 // エポックからの秒数（約68年）
+	snowflakeNodeBits     = 10 // ノード（0〜1023）
+	snowflakeSequenceBits = 12 // 同じ秒のうちの連番（1ノードあたり毎秒4096件）
+
+	// MaxNodeID は指定できるノードの最大値
+	MaxNodeID = 1<<snowflakeNodeBits - 1
+
+	maxSnowflakeSequence = 1<<snowflakeSequenceBits - 1
+	maxSnowflakeSeconds  = 1<<snowflakeTimeBits - 1
+)
+
+// snowflakeEpoch は ID の時刻部分の起点
+var snowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Snowflake は時刻・ノード・連番から ID を生成する IDGenerator。
+// ノードごとに異なる NodeID を設定すれば、複数のサーバーやシャードで重複しない ID を採番できる
+type Snowflake struct {
+	nodeID int64
+	now    func() time.Time
+
+	mu       sync.Mutex
+	seconds  int64
+	sequence int64
+}
+
+func NewSnowflake(nodeID int64) (*Snowflake, error) {
+	if nodeID < 0 || nodeID > MaxNodeID {
+		return nil, fmt.Errorf("snowflake node id must be between 0 and %d: %d", MaxNodeID, nodeID)
+	}
+	return &Snowflake{nodeID: nodeID, now: time.Now}, nil
+}
+
+// NextID は新しい ID を返す。同じ秒の連番を使い切った場合は次の秒まで待つ。
+// 時計が戻った場合は最後に採番した秒のまま連番を進め、ID が減らないようにする
+func (s *Snowflake) NextID() (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seconds := s.elapsedSeconds()
+	if seconds < s.seconds {
+		seconds = s.seconds
+	}
+	if seconds == s.seconds {
+		if s.sequence == maxSnowflakeSequence {
+			for seconds <= s.seconds {
+				time.Sleep(time.Until(snowflakeEpoch.Add(time.Duration(s.seconds+1) * time.Second)))
+				seconds = s.elapsedSeconds()
+			}
+			s.sequence = 0
+		} else {
+			s.sequence++
+		}
+	} else {
+		s.sequence = 0
+	}
+	if seconds > maxSnowflakeSeconds {
+		return 0, fmt.Errorf("snowflake time overflow")
+	}
+	s.seconds = seconds
+
+	return seconds<<(snowflakeNodeBits+snowflakeSequenceBits) | s.nodeID<<snowflakeSequenceBits | s.sequence, nil
+}
+
+func (s *Snowflake) elapsedSeconds() int64 {
+	return int64(s.now().Sub(snowflakeEpoch) / time.Second)
+}
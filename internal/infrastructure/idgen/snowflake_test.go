@@ -0,0 +1,107 @@
+package idgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// JavaScript の Number.MAX_SAFE_INTEGER
+const maxSafeInteger = 1<<53 - 1
+
+func TestSnowflake_NextID(t *testing.T) {
+	t.Run("同じ秒では連番を進め、ノードごとに重複しない", func(t *testing.T) {
+		now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		seen := map[int64]bool{}
+		for _, nodeID := range []int64{0, 1, MaxNodeID} {
+			s, err := NewSnowflake(nodeID)
+			require.NoError(t, err)
+			s.now = func() time.Time { return now }
+
+			var prev int64
+			for i := 0; i < 100; i++ {
+				id, err := s.NextID()
+				require.NoError(t, err)
+				assert.Greater(t, id, prev)
+				assert.False(t, seen[id], "duplicate id %d", id)
+				seen[id] = true
+				prev = id
+			}
+		}
+	})
+
+	t.Run("時計が戻っても ID は減らない", func(t *testing.T) {
+		now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		s, err := NewSnowflake(3)
+		require.NoError(t, err)
+		s.now = func() time.Time { return now }
+
+		first, err := s.NextID()
+		require.NoError(t, err)
+		now = now.Add(-time.Minute)
+		second, err := s.NextID()
+		require.NoError(t, err)
+
+		assert.Greater(t, second, first)
+	})
+
+	t.Run("連番を使い切った場合は次の秒の ID を返す", func(t *testing.T) {
+		now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+		s, err := NewSnowflake(3)
+		require.NoError(t, err)
+		calls := 0
+		s.now = func() time.Time {
+			calls++
+			if calls > maxSnowflakeSequence+1 {
+				return now.Add(time.Second)
+			}
+			return now
+		}
+
+		var last int64
+		for i := 0; i <= maxSnowflakeSequence+1; i++ {
+			last, err = s.NextID()
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, int64(0), last&maxSnowflakeSequence)
+		assert.Equal(t, now.Add(time.Second).Sub(snowflakeEpoch)/time.Second, time.Duration(last>>(snowflakeNodeBits+snowflakeSequenceBits)))
+	})
+
+	t.Run("JavaScript で精度を失わない範囲に収まる", func(t *testing.T) {
+		s, err := NewSnowflake(MaxNodeID)
+		require.NoError(t, err)
+		s.now = func() time.Time { return snowflakeEpoch.Add(maxSnowflakeSeconds * time.Second) }
+
+		id, err := s.NextID()
+		require.NoError(t, err)
+		assert.LessOrEqual(t, id, int64(maxSafeInteger))
+	})
+
+	t.Run("範囲外のノードはエラー", func(t *testing.T) {
+		_, err := NewSnowflake(MaxNodeID + 1)
+		assert.Error(t, err)
+		_, err = NewSnowflake(-1)
+		assert.Error(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	gen, err := New(Config{})
+	require.NoError(t, err)
+	assert.Equal(t, database.AutoIncrement{}, gen)
+
+	gen, err = New(Config{Strategy: StrategySnowflake, NodeID: 5})
+	require.NoError(t, err)
+	assert.IsType(t, &Snowflake{}, gen)
+
+	_, err = New(Config{Strategy: StrategyULID})
+	assert.ErrorContains(t, err, "not supported")
+
+	_, err = New(Config{Strategy: "uuid"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package lane
+
+import (
+	"context"
+	"strings"
+)
+
+// Lane はリクエストの優先度区分
+type Lane int
+
+const (
+	// Interactive は画面操作などの通常のCRUDリクエスト
+	Interactive Lane = iota
+	// Batch はインポート・エクスポート・レポート生成などの重い処理
+	Batch
+	// Stream はジョブの進捗の配信など、接続を長く保つリクエスト（同時実行数を制限しない）
+	Stream
+)
+
+// streamSuffix は Stream として扱うパスの末尾
+const streamSuffix = "/events"
+
+func (l Lane) String() string {
+	switch l {
+	case Batch:
+		return "batch"
+	case Stream:
+		return "stream"
+	default:
+		return "interactive"
+	}
+}
+
+type contextKey struct{}
+
+// WithLane はコンテキストにレーンを設定する
+func WithLane(ctx context.Context, l Lane) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext はコンテキストからレーンを取得する（未設定の場合は Interactive）
+func FromContext(ctx context.Context) Lane {
+	if l, ok := ctx.Value(contextKey{}).(Lane); ok {
+		return l
+	}
+	return Interactive
+}
+
+// Classifier はリクエストパスからレーンを判定する
+type Classifier struct {
+	batchPrefixes []string
+}
+
+func NewClassifier(batchPrefixes []string) *Classifier {
+	prefixes := make([]string, 0, len(batchPrefixes))
+	for _, p := range batchPrefixes {
+		if p = strings.TrimSpace(p); p != "" {
+			prefixes = append(prefixes, p)
+		}
+	}
+	return &Classifier{batchPrefixes: prefixes}
+}
+
+// Classify はパスが /events で終われば Stream、バッチ用のプレフィックスに一致すれば Batch を返す。
+// 進捗の配信がインタラクティブの枠を占有し続けないよう、Stream の判定を優先する
+func (c *Classifier) Classify(path string) Lane {
+	if strings.HasSuffix(path, streamSuffix) {
+		return Stream
+	}
+	for _, prefix := range c.batchPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return Batch
+		}
+	}
+	return Interactive
+}
@@ -0,0 +1,56 @@
+package lane
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrLaneBusy はレーンの空きを待っている間にタイムアウトした場合のエラー
+var ErrLaneBusy = errors.New("lane is busy")
+
+// Limiter はレーンごとの同時実行数を制限する
+type Limiter struct {
+	slots       map[Lane]chan struct{}
+	waitTimeout time.Duration
+}
+
+// NewLimiter はレーンごとの上限を指定して Limiter を作成する。
+// 上限が0以下のレーンは制限しない。
+func NewLimiter(interactive, batch int, waitTimeout time.Duration) *Limiter {
+	l := &Limiter{
+		slots:       make(map[Lane]chan struct{}),
+		waitTimeout: waitTimeout,
+	}
+	if interactive > 0 {
+		l.slots[Interactive] = make(chan struct{}, interactive)
+	}
+	if batch > 0 {
+		l.slots[Batch] = make(chan struct{}, batch)
+	}
+	return l
+}
+
+// Acquire はレーンの枠を確保し、解放用の関数を返す
+func (l *Limiter) Acquire(ctx context.Context, lane Lane) (func(), error) {
+	slots, ok := l.slots[lane]
+	if !ok {
+		return func() {}, nil
+	}
+
+	var timeout <-chan time.Time
+	if l.waitTimeout > 0 {
+		timer := time.NewTimer(l.waitTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-timeout:
+		return nil, ErrLaneBusy
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,71 @@
+package lane
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifier_Classify(t *testing.T) {
+	c := NewClassifier([]string{"/items/import", " /items/export ", ""})
+
+	assert.Equal(t, Batch, c.Classify("/items/import"))
+	assert.Equal(t, Batch, c.Classify("/items/export/123"))
+	assert.Equal(t, Interactive, c.Classify("/items"))
+	assert.Equal(t, Interactive, c.Classify("/items/1"))
+	assert.Equal(t, Stream, c.Classify("/jobs/1/events"))
+	assert.Equal(t, Stream, c.Classify("/items/export/events"))
+}
+
+func TestLimiter_Acquire(t *testing.T) {
+	t.Run("正常系: バッチが埋まってもインタラクティブは実行できる", func(t *testing.T) {
+		l := NewLimiter(1, 1, 10*time.Millisecond)
+		ctx := context.Background()
+
+		releaseBatch, err := l.Acquire(ctx, Batch)
+		require.NoError(t, err)
+		defer releaseBatch()
+
+		_, err = l.Acquire(ctx, Batch)
+		assert.ErrorIs(t, err, ErrLaneBusy)
+
+		releaseInteractive, err := l.Acquire(ctx, Interactive)
+		require.NoError(t, err)
+		releaseInteractive()
+	})
+
+	t.Run("正常系: 解放後は再取得できる", func(t *testing.T) {
+		l := NewLimiter(1, 1, 10*time.Millisecond)
+		ctx := context.Background()
+
+		release, err := l.Acquire(ctx, Batch)
+		require.NoError(t, err)
+		release()
+
+		release, err = l.Acquire(ctx, Batch)
+		require.NoError(t, err)
+		release()
+	})
+
+	t.Run("正常系: 上限0のレーンは制限しない", func(t *testing.T) {
+		l := NewLimiter(0, 0, 0)
+		for i := 0; i < 3; i++ {
+			_, err := l.Acquire(context.Background(), Interactive)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("異常系: コンテキストのキャンセル", func(t *testing.T) {
+		l := NewLimiter(1, 1, 0)
+		_, err := l.Acquire(context.Background(), Interactive)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = l.Acquire(ctx, Interactive)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
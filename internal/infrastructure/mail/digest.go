@@ -0,0 +1,93 @@
+package mail
+
+import (
+	"bytes"
+	_ "embed"
+	htmlTemplate "html/template"
+	"strconv"
+	textTemplate "text/template"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+//go:embed templates/digest.txt.tmpl
+var digestText string
+
+//go:embed templates/digest.html.tmpl
+var digestHTML string
+
+var digestFuncs = map[string]any{
+	"total":       total,
+	"price":       price,
+	"date":        func(t time.Time) string { return t.Format("2006-01-02") },
+	"periodLabel": periodLabel,
+	"subject":     digestSubject,
+	"daysLeft":    daysLeft,
+}
+
+var (
+	digestTextTemplate = textTemplate.Must(textTemplate.New("digest.txt").Funcs(digestFuncs).Parse(digestText))
+	digestHTMLTemplate = htmlTemplate.Must(htmlTemplate.New("digest.html").Funcs(digestFuncs).Parse(digestHTML))
+)
+
+// DigestRenderer はダイジェストメールをテンプレートから描画する
+type DigestRenderer struct{}
+
+func NewDigestRenderer() *DigestRenderer {
+	return &DigestRenderer{}
+}
+
+func (r *DigestRenderer) Render(digest *usecase.Digest) (*usecase.MailMessage, error) {
+	var text, html bytes.Buffer
+	if err := digestTextTemplate.Execute(&text, digest); err != nil {
+		return nil, err
+	}
+	if err := digestHTMLTemplate.Execute(&html, digest); err != nil {
+		return nil, err
+	}
+
+	return &usecase.MailMessage{
+		Subject: digestSubject(digest),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+func digestSubject(digest *usecase.Digest) string {
+	return "【" + periodLabel(digest.Frequency) + "ダイジェスト】アイテム追加 " + strconv.Itoa(len(digest.AddedItems)) + " 件"
+}
+
+func periodLabel(frequency entity.DigestFrequency) string {
+	if frequency == entity.DigestFrequencyMonthly {
+		return "今月"
+	}
+	return "今週"
+}
+
+func daysLeft(days int) string {
+	switch {
+	case days < 0:
+		return strconv.Itoa(-days) + " 日超過"
+	case days == 0:
+		return "本日"
+	}
+	return "あと " + strconv.Itoa(days) + " 日"
+}
+
+// total は合計の金額を表示通貨で「¥1,234,567」「$1,234.56」の形式にする（通貨が未設定の場合は円）
+func total(amount int64, currency entity.Currency) string {
+	if currency == "" {
+		currency = entity.CurrencyJPY
+	}
+	return entity.Money{Amount: int(amount), Currency: currency}.String()
+}
+
+// price はアイテムの購入価格を表示する（受け手に非表示の場合は「非表示」）
+func price(item *entity.Item) string {
+	if item.IsRedacted(entity.ItemFieldPurchasePrice) {
+		return "非表示"
+	}
+	return item.PurchasePrice.String()
+}
@@ -0,0 +1,22 @@
+package mail
+
+import (
+	"context"
+	"log"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// LogMailer はメールを送信せず、宛先と件名をログに出力する（開発環境用）
+type LogMailer struct {
+	from string
+}
+
+func NewLogMailer(from string) *LogMailer {
+	return &LogMailer{from: from}
+}
+
+func (m *LogMailer) Send(ctx context.Context, message *usecase.MailMessage) error {
+	log.Printf("📧 mail (not sent): from=%s to=%s subject=%q", m.from, message.To, message.Subject)
+	return nil
+}
@@ -0,0 +1,39 @@
+// Package mail はメールの送信とダイジェストメールのテンプレートを提供する
+package mail
+
+import (
+	"fmt"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 送信方法の種類
+const (
+	DriverLog  = "log"
+	DriverSMTP = "smtp"
+)
+
+var (
+	_ usecase.Mailer = (*LogMailer)(nil)
+	_ usecase.Mailer = (*SMTPMailer)(nil)
+)
+
+// Config はメールの送信設定。Driver に応じて使われる項目が異なる
+type Config struct {
+	Driver string
+	From   string
+	// smtp
+	SMTP SMTPConfig
+}
+
+// New は設定に応じたメールの送信方法を作成する（未設定の場合は送信せずログに出力する）
+func New(cfg Config) (usecase.Mailer, error) {
+	switch cfg.Driver {
+	case "", DriverLog:
+		return NewLogMailer(cfg.From), nil
+	case DriverSMTP:
+		return NewSMTPMailer(cfg.From, cfg.SMTP)
+	default:
+		return nil, fmt.Errorf("unknown mail driver: %q", cfg.Driver)
+	}
+}
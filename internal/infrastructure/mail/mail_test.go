@@ -0,0 +1,120 @@
+package mail
+
+import (
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestDigestRenderer_Render(t *testing.T) {
+	digest := &usecase.Digest{
+		Email:     "user@example.com",
+		Frequency: entity.DigestFrequencyWeekly,
+		From:      time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local),
+		To:        time.Date(2024, 7, 8, 9, 0, 0, 0, time.Local),
+		AddedItems: []*entity.Item{
+			{Name: "デイトナ <限定>", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000)},
+		},
+		UpdatedItems: []*entity.Item{},
+		AddedValue:   1500000,
+		TotalCount:   3,
+		TotalValue:   3800000,
+		ExpiringConsignments: []usecase.DigestConsignment{
+			{Item: &entity.Item{Name: "バーキン", Brand: "HERMÈS"}, Deadline: "2024-07-01", DaysLeft: -7},
+		},
+		UnsubscribeURL: "https://example.com/digest/unsubscribe?token=1-abc",
+	}
+
+	message, err := NewDigestRenderer().Render(digest)
+	require.NoError(t, err)
+
+	assert.Equal(t, "【今週ダイジェスト】アイテム追加 1 件", message.Subject)
+	assert.Contains(t, message.Text, "今週のアイテムの動き（2024-07-01 〜 2024-07-08）")
+	assert.Contains(t, message.Text, "登録アイテム: 3 件 / 購入価格の合計: ¥3,800,000")
+	assert.Contains(t, message.Text, "  - ROLEX デイトナ <限定>（時計） ¥1,500,000")
+	assert.Contains(t, message.Text, "■ 更新されたアイテム（0 件）\n  なし")
+	assert.Contains(t, message.Text, "  - HERMÈS バーキン 期限: 2024-07-01（7 日超過）")
+	assert.Contains(t, message.Text, "配信を停止する: https://example.com/digest/unsubscribe?token=1-abc")
+
+	assert.Contains(t, message.HTML, "デイトナ &lt;限定&gt;")
+	assert.Contains(t, message.HTML, `href="https://example.com/digest/unsubscribe?token=1-abc"`)
+
+	// 受け手に非表示の購入価格は表示しない
+	digest.AddedItems[0].Redact(entity.ItemFieldPurchasePrice)
+	message, err = NewDigestRenderer().Render(digest)
+	require.NoError(t, err)
+	assert.Contains(t, message.Text, "  - ROLEX デイトナ <限定>（時計） 非表示")
+	assert.Contains(t, message.HTML, "（時計） 非表示")
+
+	// 合計は表示通貨で表示する
+	digest.Currency = entity.CurrencyUSD
+	digest.TotalValue = 2546675
+	message, err = NewDigestRenderer().Render(digest)
+	require.NoError(t, err)
+	assert.Contains(t, message.Text, "登録アイテム: 3 件 / 購入価格の合計: $25,466.75")
+	assert.Contains(t, message.HTML, "$25,466.75")
+}
+
+func TestBuildMessage(t *testing.T) {
+	message := &usecase.MailMessage{
+		To:      "user@example.com",
+		Subject: "【今週ダイジェスト】",
+		Text:    "テキスト",
+		HTML:    "<p>HTML</p>",
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.com/digest/unsubscribe?token=1-abc>"},
+	}
+
+	raw, err := buildMessage("noreply@example.com", message, time.Date(2024, 7, 8, 9, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "【今週ダイジェスト】", subject)
+	assert.Equal(t, "user@example.com", parsed.Header.Get("To"))
+	assert.Equal(t, "<https://example.com/digest/unsubscribe?token=1-abc>", parsed.Header.Get("List-Unsubscribe"))
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		bodies = append(bodies, part.Header.Get("Content-Type")+": "+string(body))
+	}
+	assert.Equal(t, []string{
+		"text/plain; charset=UTF-8: テキスト",
+		"text/html; charset=UTF-8: <p>HTML</p>",
+	}, bodies)
+}
+
+func TestNew(t *testing.T) {
+	mailer, err := New(Config{From: "noreply@example.com"})
+	require.NoError(t, err)
+	assert.IsType(t, &LogMailer{}, mailer)
+
+	_, err = New(Config{Driver: DriverSMTP, From: "noreply@example.com"})
+	assert.EqualError(t, err, "smtp host is required")
+
+	_, err = New(Config{Driver: "sendgrid"})
+	assert.EqualError(t, err, `unknown mail driver: "sendgrid"`)
+}
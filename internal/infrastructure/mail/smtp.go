@@ -0,0 +1,137 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"time"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// SMTPConfig は SMTP サーバーへの接続設定（Username が空の場合は認証しない）
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+}
+
+// SMTPMailer は SMTP サーバーを経由してメールを送信する
+type SMTPMailer struct {
+	from string
+	cfg  SMTPConfig
+	now  func() time.Time
+}
+
+func NewSMTPMailer(from string, cfg SMTPConfig) (*SMTPMailer, error) {
+	if cfg.Host == "" {
+		return nil, errors.New("smtp host is required")
+	}
+	if _, err := mail.ParseAddress(from); err != nil {
+		return nil, fmt.Errorf("invalid mail from address: %w", err)
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	return &SMTPMailer{from: from, cfg: cfg, now: time.Now}, nil
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message *usecase.MailMessage) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	body, err := buildMessage(m.from, message, m.now())
+	if err != nil {
+		return err
+	}
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		return fmt.Errorf("invalid mail from address: %w", err)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, m.cfg.Port)
+	if err := smtp.SendMail(addr, auth, sender.Address, []string{message.To}, body); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// buildMessage はテキストと HTML の本文を multipart/alternative にしたメールを組み立てる
+func buildMessage(from string, message *usecase.MailMessage, now time.Time) ([]byte, error) {
+	var content bytes.Buffer
+	parts := multipart.NewWriter(&content)
+
+	headers := map[string]string{
+		"From":         from,
+		"To":           message.To,
+		"Subject":      mime.BEncoding.Encode("UTF-8", message.Subject),
+		"Date":         now.Format(time.RFC1123Z),
+		"MIME-Version": "1.0",
+	}
+	for k, v := range message.Headers {
+		headers[k] = v
+	}
+
+	headers["Content-Type"] = "multipart/alternative; boundary=" + parts.Boundary()
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", message.Text},
+		{"text/html; charset=UTF-8", message.HTML},
+	} {
+		if part.content == "" {
+			continue
+		}
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	// ヘッダーの順序を固定する
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		fmt.Fprintf(&buf, "%s: %s\r\n", k, headers[k])
+	}
+	buf.WriteString("\r\n")
+	buf.Write(content.Bytes())
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,88 @@
+package marketprice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// Client は相場 API からブランドとモデルの中古相場を取得する。
+// API は GET {BaseURL}?brand=...&model=... で {"price":1250000,"currency":"JPY","source":"..."} を返し、
+// 相場がない場合は404を返す形式（price は補助単位を小数にした金額の数値）
+type Client struct {
+	baseURL *url.URL
+	apiKey  string
+	client  *http.Client
+}
+
+// NewClient は apiKey（空の場合は送らない）を Bearer トークンとして送るクライアントを返す
+func NewClient(baseURL, apiKey string) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("market price: invalid base url: %q", baseURL)
+	}
+
+	return &Client{
+		baseURL: u,
+		apiKey:  apiKey,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+type quoteResponse struct {
+	Price    *entity.Decimal `json:"price"`
+	Currency entity.Currency `json:"currency"`
+	Source   string          `json:"source"`
+}
+
+// Quote は brand の model の相場を取得する。相場がない場合は ErrMarketPriceNotFound を返す
+func (c *Client) Quote(ctx context.Context, brand, model string) (*usecase.MarketQuote, error) {
+	endpoint := *c.baseURL
+	query := endpoint.Query()
+	query.Set("brand", brand)
+	query.Set("model", model)
+	endpoint.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, domainErrors.ErrMarketPriceNotFound
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("market price api returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var body quoteResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("invalid market price response: %w", err)
+	}
+	if body.Price == nil || body.Currency == "" {
+		return nil, fmt.Errorf("invalid market price response: price and currency are required")
+	}
+
+	return &usecase.MarketQuote{Value: *body.Price, Currency: body.Currency, Source: body.Source}, nil
+}
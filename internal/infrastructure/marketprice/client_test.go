@@ -0,0 +1,77 @@
+package marketprice
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeAPI はブランドとモデルごとの相場を返す相場 API
+func fakeAPI(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "v1", r.URL.Query().Get("api"))
+		switch q := r.URL.Query(); q.Get("brand") + "/" + q.Get("model") {
+		case "ROLEX/デイトナ 116500LN":
+			fmt.Fprint(w, `{"price":3450000,"currency":"JPY","source":"example market"}`)
+		case "Hermès/Birkin 30":
+			fmt.Fprint(w, `{"price":21500.50,"currency":"USD"}`)
+		case "ROLEX/broken":
+			fmt.Fprint(w, `{"currency":"JPY"}`)
+		case "ROLEX/error":
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestClient_Quote(t *testing.T) {
+	server := httptest.NewServer(fakeAPI(t))
+	t.Cleanup(server.Close)
+	client, err := NewClient(server.URL+"/v1/prices?api=v1", "secret")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("正常系: ブランドとモデルの相場を返す", func(t *testing.T) {
+		quote, err := client.Quote(ctx, "ROLEX", "デイトナ 116500LN")
+		require.NoError(t, err)
+		assert.Equal(t, "3450000", quote.Value.String())
+		assert.Equal(t, entity.CurrencyJPY, quote.Currency)
+		assert.Equal(t, "example market", quote.Source)
+
+		quote, err = client.Quote(ctx, "Hermès", "Birkin 30")
+		require.NoError(t, err)
+		assert.Equal(t, "21500.50", quote.Value.String())
+		assert.Equal(t, entity.CurrencyUSD, quote.Currency)
+	})
+
+	t.Run("異常系: 相場がない", func(t *testing.T) {
+		_, err := client.Quote(ctx, "ROLEX", "unknown")
+		assert.ErrorIs(t, err, domainErrors.ErrMarketPriceNotFound)
+	})
+
+	t.Run("異常系: API のエラーや不正なレスポンス", func(t *testing.T) {
+		_, err := client.Quote(ctx, "ROLEX", "error")
+		assert.ErrorContains(t, err, "429")
+		assert.NotErrorIs(t, err, domainErrors.ErrMarketPriceNotFound)
+
+		_, err = client.Quote(ctx, "ROLEX", "broken")
+		assert.ErrorContains(t, err, "price and currency are required")
+	})
+}
+
+func TestNewClient_InvalidURL(t *testing.T) {
+	for _, baseURL := range []string{"", "ftp://example.com", "example.com/prices"} {
+		_, err := NewClient(baseURL, "")
+		assert.Error(t, err, baseURL)
+	}
+}
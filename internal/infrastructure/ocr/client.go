@@ -0,0 +1,71 @@
+package ocr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client は文字認識 API で画像の文字を認識する。
+// API は画像をそのままリクエストボディにした POST {URL}（Content-Type は画像の形式）に {"text":"..."} を返す形式
+type Client struct {
+	endpoint *url.URL
+	apiKey   string
+	client   *http.Client
+}
+
+// NewClient は apiKey（空の場合は送らない）を Bearer トークンとして送るクライアントを返す
+func NewClient(endpoint, apiKey string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return nil, fmt.Errorf("ocr: invalid url: %q", endpoint)
+	}
+
+	return &Client{
+		endpoint: u,
+		apiKey:   apiKey,
+		client:   &http.Client{Timeout: 60 * time.Second},
+	}, nil
+}
+
+type recognizeResponse struct {
+	Text *string `json:"text"`
+}
+
+// Recognize は image の文字を認識したテキストを返す（文字がない場合は空文字）
+func (c *Client) Recognize(ctx context.Context, contentType string, image io.Reader) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint.String(), image)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return "", fmt.Errorf("ocr api returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var body recognizeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid ocr response: %w", err)
+	}
+	if body.Text == nil {
+		return "", fmt.Errorf("invalid ocr response: text is required")
+	}
+	return *body.Text, nil
+}
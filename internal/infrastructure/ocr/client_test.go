@@ -0,0 +1,62 @@
+package ocr
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Recognize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		body, _ := io.ReadAll(r.Body)
+		switch string(body) {
+		case "receipt":
+			assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+			fmt.Fprint(w, `{"text":"お買上げ店舗: 銀座本店"}`)
+		case "blank":
+			fmt.Fprint(w, `{"text":""}`)
+		case "broken":
+			fmt.Fprint(w, `{}`)
+		default:
+			http.Error(w, "unsupported image", http.StatusUnprocessableEntity)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, err := NewClient(server.URL+"/v1/recognize", "secret")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	t.Run("正常系: 認識したテキストを返す", func(t *testing.T) {
+		text, err := client.Recognize(ctx, "image/jpeg", strings.NewReader("receipt"))
+		require.NoError(t, err)
+		assert.Equal(t, "お買上げ店舗: 銀座本店", text)
+
+		text, err = client.Recognize(ctx, "image/png", strings.NewReader("blank"))
+		require.NoError(t, err)
+		assert.Empty(t, text)
+	})
+
+	t.Run("異常系: API のエラーや不正なレスポンス", func(t *testing.T) {
+		_, err := client.Recognize(ctx, "image/gif", strings.NewReader("animated"))
+		assert.ErrorContains(t, err, "422")
+
+		_, err = client.Recognize(ctx, "image/png", strings.NewReader("broken"))
+		assert.ErrorContains(t, err, "text is required")
+	})
+}
+
+func TestNewClient_InvalidURL(t *testing.T) {
+	for _, endpoint := range []string{"", "ftp://example.com", "example.com/recognize"} {
+		_, err := NewClient(endpoint, "")
+		assert.Error(t, err, endpoint)
+	}
+}
@@ -0,0 +1,113 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// CertificateRenderer はアイテムの評価証明書をA4縦1ページのPDFに描画する
+type CertificateRenderer struct{}
+
+func NewCertificateRenderer() *CertificateRenderer {
+	return &CertificateRenderer{}
+}
+
+const (
+	marginX    = 60.0
+	labelWidth = 110.0
+	rowHeight  = 26.0
+	qrSize     = 120.0
+)
+
+func (r *CertificateRenderer) Render(cert *usecase.Certificate) ([]byte, error) {
+	item := cert.Item
+	doc := New(fmt.Sprintf("評価証明書 %s", item.Name))
+	page := doc.AddPage()
+
+	// 外枠とタイトル
+	page.Rect(30, 30, A4Width-60, A4Height-60, 2)
+	page.Rect(36, 36, A4Width-72, A4Height-72, 0.5)
+	page.TextCenter(A4Width/2, 110, 28, "評価証明書")
+	page.TextCenter(A4Width/2, 135, 10, "CERTIFICATE OF VALUATION")
+
+	y := 190.0
+	y = section(page, y, "品目", [][2]string{
+		{"品名", item.Name},
+		{"ブランド", item.Brand},
+		{"カテゴリー", item.Category},
+	})
+	y = section(page, y, "来歴", [][2]string{
+		{"購入日", item.PurchaseDate},
+		{"登録日", item.CreatedAt.Format("2006-01-02")},
+		{"最終更新日", item.UpdatedAt.Format("2006-01-02")},
+	})
+	y = section(page, y, "評価", [][2]string{
+		{"評価額", item.PurchasePrice.String()},
+		{"評価方法", "取得価額による"},
+	})
+
+	// 検証用QRコード
+	qrY := y + 10
+	if err := page.QRCode(marginX, qrY, qrSize, cert.VerificationURL); err != nil {
+		return nil, err
+	}
+	textX := marginX + qrSize + 20
+	page.Text(textX, qrY+12, 10, "本証明書の真正性は、左のQRコードまたは")
+	page.Text(textX, qrY+26, 10, "下記URLから確認できます。")
+	page.Text(textX, qrY+45, 9, "検証コード")
+	page.Text(textX, qrY+60, 9, cert.Code)
+	for i, line := range wrap(cert.VerificationURL, 60) {
+		page.Text(textX, qrY+85+float64(i)*13, 8, line)
+	}
+
+	page.Line(marginX, A4Height-110, A4Width-marginX, A4Height-110, 0.5)
+	page.Text(marginX, A4Height-88, 10, "発行日時: "+cert.IssuedAt.Format("2006-01-02 15:04:05 MST"))
+	page.Text(marginX, A4Height-72, 10, fmt.Sprintf("アイテムID: %d", item.ID))
+
+	return doc.Bytes()
+}
+
+// section は見出しとラベル・値の行を描画し、次に描画する y 座標を返す
+func section(page *Page, y float64, title string, rows [][2]string) float64 {
+	page.Text(marginX, y, 14, title)
+	page.Line(marginX, y+6, A4Width-marginX, y+6, 1)
+	y += 6 + rowHeight
+	for _, row := range rows {
+		page.SetGray(0.4)
+		page.Text(marginX+10, y, 11, row[0])
+		page.SetGray(0)
+		page.Text(marginX+10+labelWidth, y, 12, row[1])
+		y += rowHeight
+	}
+	return y + 16
+}
+
+// yen は金額を「¥1,234,567」の形式にする
+func yen(amount int) string {
+	digits := strconv.Itoa(amount)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return sign + "¥" + b.String()
+}
+
+// wrap は半角の長い文字列を n 文字ごとに折り返す
+func wrap(s string, n int) []string {
+	var lines []string
+	for len(s) > n {
+		lines = append(lines, s[:n])
+		s = s[n:]
+	}
+	return append(lines, s)
+}
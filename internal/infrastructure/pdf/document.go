@@ -0,0 +1,196 @@
+// Package pdf は証明書や請求書などの帳票を出力するための最小限のPDFレンダラー。
+// 日本語は埋め込みなしの CID フォント（HeiseiKakuGo-W5）で描画するため、フォントファイルを同梱しない。
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// A4 のページサイズ（ポイント）
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document はページの集まり
+type Document struct {
+	title string
+	pages []*Page
+}
+
+// Page は1ページ分の描画内容。座標は左上を原点とし、y は下向きに増える
+type Page struct {
+	width, height float64
+	content       bytes.Buffer
+}
+
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage は A4 縦のページを追加する
+func (d *Document) AddPage() *Page {
+	p := &Page{width: A4Width, height: A4Height}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Text は (x, y) をベースラインの左端として文字列を描画する
+func (p *Page) Text(x, y, size float64, s string) {
+	fmt.Fprintf(&p.content, "BT /F1 %s Tf %s %s Td <%s> Tj ET\n", num(size), num(x), num(p.height-y), encodeText(s))
+}
+
+// TextCenter は x を中心として文字列を描画する
+func (p *Page) TextCenter(x, y, size float64, s string) {
+	p.Text(x-TextWidth(s, size)/2, y, size, s)
+}
+
+// SetGray は以降の塗りと線の色をグレースケール（0: 黒, 1: 白）で設定する
+func (p *Page) SetGray(gray float64) {
+	fmt.Fprintf(&p.content, "%s g %s G\n", num(gray), num(gray))
+}
+
+// Line は線分を描画する
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n", num(width), num(x1), num(p.height-y1), num(x2), num(p.height-y2))
+}
+
+// Rect は (x, y) を左上とする矩形を描画する
+func (p *Page) Rect(x, y, w, h, lineWidth float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n", num(lineWidth), num(x), num(p.height-y-h), num(w), num(h))
+}
+
+// QRCode は (x, y) を左上とする size 四方のQRコードを描画する
+func (p *Page) QRCode(x, y, size float64, content string) error {
+	qr, err := qrcode.New(content, qrcode.Medium)
+	if err != nil {
+		return fmt.Errorf("failed to encode qr code: %w", err)
+	}
+
+	bitmap := qr.Bitmap()
+	module := size / float64(len(bitmap))
+	for row, cells := range bitmap {
+		for col, dark := range cells {
+			if dark {
+				fmt.Fprintf(&p.content, "%s %s %s %s re\n",
+					num(x+float64(col)*module), num(p.height-y-float64(row+1)*module), num(module), num(module))
+			}
+		}
+	}
+	p.content.WriteString("f\n")
+	return nil
+}
+
+// TextWidth は文字列の描画幅の目安を返す（半角は 0.5em、それ以外は 1em）
+func TextWidth(s string, size float64) float64 {
+	var em float64
+	for _, r := range s {
+		if r < 0x80 || (r >= 0xFF61 && r <= 0xFF9F) {
+			em += 0.5
+		} else {
+			em++
+		}
+	}
+	return em * size
+}
+
+// Bytes はPDFファイルの内容を返す
+func (d *Document) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		return nil, fmt.Errorf("document has no pages")
+	}
+
+	w := &writer{}
+	w.buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: Catalog, 2: Pages, 3-5: フォント, 6: Info, 7以降: ページとコンテンツ
+	const firstPageObj = 7
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+i*2)
+	}
+
+	w.object("<< /Type /Catalog /Pages 2 0 R >>")
+	w.object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	w.object("<< /Type /Font /Subtype /Type0 /BaseFont /HeiseiKakuGo-W5-UniJIS-UCS2-H /Encoding /UniJIS-UCS2-H /DescendantFonts [4 0 R] >>")
+	w.object("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /HeiseiKakuGo-W5 " +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> " +
+		"/FontDescriptor 5 0 R /DW 1000 /W [1 95 500 231 632 500] >>")
+	w.object("<< /Type /FontDescriptor /FontName /HeiseiKakuGo-W5 /Flags 4 /FontBBox [-92 -250 1010 922] " +
+		"/ItalicAngle 0 /Ascent 752 /Descent -221 /CapHeight 737 /StemV 114 >>")
+	w.object(fmt.Sprintf("<< /Title <%s> /Producer (Aicon items API) >>", encodeInfoText(d.title)))
+
+	for i, page := range d.pages {
+		w.object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R >> >> /Contents %d 0 R >>",
+			num(page.width), num(page.height), firstPageObj+i*2+1))
+		w.stream(page.content.Bytes())
+	}
+
+	w.trailer()
+	return w.buf.Bytes(), nil
+}
+
+// writer はオブジェクトのオフセットを記録しながらPDFを書き出す
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+func (w *writer) object(body string) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", len(w.offsets), body)
+}
+
+func (w *writer) stream(data []byte) {
+	w.offsets = append(w.offsets, w.buf.Len())
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< /Length %d >>\nstream\n", len(w.offsets), len(data))
+	w.buf.Write(data)
+	w.buf.WriteString("\nendstream\nendobj\n")
+}
+
+func (w *writer) trailer() {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root 1 0 R /Info 6 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, xref)
+}
+
+// encodeText は UniJIS-UCS2-H で描画する文字列を16進表記の UTF-16BE に変換する
+func encodeText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r > 0xFFFF {
+			// UCS-2 の範囲外の文字は描画できないため〓に置き換える
+			r = '〓'
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// encodeInfoText は文書情報の文字列を BOM 付き UTF-16BE の16進表記に変換する
+func encodeInfoText(s string) string {
+	var b strings.Builder
+	b.WriteString("FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	return b.String()
+}
+
+// num は座標などの数値を小数点以下2桁までで出力する
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-" || s == "-0" {
+		return "0"
+	}
+	return s
+}
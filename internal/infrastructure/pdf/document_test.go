@@ -0,0 +1,106 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := New("テスト")
+	page := doc.AddPage()
+	page.Text(10, 20, 12, "時計 ROLEX")
+	require.NoError(t, page.QRCode(10, 40, 100, "https://example.com"))
+
+	out, err := doc.Bytes()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "<66428A0800200052004F004C00450058> Tj")
+
+	// startxref が xref テーブルの位置を指し、各オブジェクトのオフセットが正しいこと
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n0 9\n")))
+	for i, offset := range regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1) {
+		n, _ := strconv.Atoi(string(offset[1]))
+		assert.True(t, bytes.HasPrefix(out[n:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "object %d", i+1)
+	}
+}
+
+func TestDocument_BytesWithoutPages(t *testing.T) {
+	_, err := New("empty").Bytes()
+	assert.Error(t, err)
+}
+
+func TestCertificateRenderer_Render(t *testing.T) {
+	cert := &usecase.Certificate{
+		Item: &entity.Item{
+			ID:            1,
+			Name:          "デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: entity.JPY(1500000),
+			PurchaseDate:  "2023-01-15",
+		},
+		IssuedAt:        time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC),
+		Code:            "1-1714559400-00112233445566778899aabbccddeeff",
+		VerificationURL: "https://example.com/certificates/verify?code=1-1714559400-00112233445566778899aabbccddeeff",
+	}
+
+	out, err := NewCertificateRenderer().Render(cert)
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.Contains(t, string(out), encodeText("¥1,500,000"))
+	assert.Contains(t, string(out), encodeText(cert.Code))
+}
+
+func TestYen(t *testing.T) {
+	assert.Equal(t, "¥0", yen(0))
+	assert.Equal(t, "¥999", yen(999))
+	assert.Equal(t, "¥1,000", yen(1000))
+	assert.Equal(t, "¥12,345,678", yen(12345678))
+}
+
+func TestInvoiceRenderer_Render(t *testing.T) {
+	invoice := &entity.Invoice{
+		ID:        1,
+		Number:    "INV-2024-000001",
+		BuyerName: "山田 太郎",
+		Lines: []entity.InvoiceLine{
+			{Description: "ROLEX デイトナ", Quantity: 1, UnitPrice: 1500000, Amount: 1500000},
+			{Description: "送料", Quantity: 1, UnitPrice: 1200, Amount: 1200},
+		},
+		Subtotal: 1501200,
+		TaxRate:  10,
+		Tax:      150120,
+		Total:    1651320,
+		IssuedAt: time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	out, err := NewInvoiceRenderer().Render(invoice)
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.Contains(t, string(out), encodeText("請求書番号: INV-2024-000001"))
+	assert.Contains(t, string(out), encodeText("山田 太郎 様"))
+	assert.Contains(t, string(out), encodeText("消費税（10%）"))
+	assert.Contains(t, string(out), encodeText("¥1,651,320"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "ROLEX", truncate("ROLEX", 100, 10))
+	assert.Equal(t, "あいう…", truncate("あいうえおかきくけこ", 40, 10))
+}
@@ -0,0 +1,110 @@
+package pdf
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// InvoiceRenderer は請求書をA4縦1ページのPDFに描画する（明細は最大20行）
+type InvoiceRenderer struct{}
+
+func NewInvoiceRenderer() *InvoiceRenderer {
+	return &InvoiceRenderer{}
+}
+
+// 明細の列（数量・単価・金額は右端の x 座標で右揃えにする）
+const (
+	descriptionWidth = 260.0
+	quantityRight    = 380.0
+	unitPriceRight   = 455.0
+	amountRight      = A4Width - marginX
+	lineHeight       = 22.0
+)
+
+func (r *InvoiceRenderer) Render(invoice *entity.Invoice) ([]byte, error) {
+	doc := New(fmt.Sprintf("請求書 %s", invoice.Number))
+	page := doc.AddPage()
+
+	page.TextCenter(A4Width/2, 90, 26, "請求書")
+	textRight(page, amountRight, 130, 10, "請求書番号: "+invoice.Number)
+	textRight(page, amountRight, 146, 10, "発行日: "+invoice.IssuedAt.Format("2006-01-02"))
+
+	// 宛名
+	page.Text(marginX, 140, 16, invoice.BuyerName+" 様")
+	page.Line(marginX, 148, marginX+260, 148, 1)
+	if invoice.BuyerAddress != "" {
+		page.Text(marginX, 166, 10, truncate(invoice.BuyerAddress, 260, 10))
+	}
+
+	// ご請求金額
+	page.Text(marginX, 215, 12, "下記のとおりご請求申し上げます。")
+	page.Rect(marginX, 230, 260, 36, 1)
+	page.Text(marginX+10, 254, 12, "ご請求金額（税込）")
+	textRight(page, marginX+250, 254, 16, yen(invoice.Total))
+
+	// 明細
+	y := 300.0
+	page.Line(marginX, y, amountRight, y, 1)
+	y += 16
+	page.SetGray(0.4)
+	page.Text(marginX+4, y, 10, "品名")
+	textRight(page, quantityRight, y, 10, "数量")
+	textRight(page, unitPriceRight, y, 10, "単価")
+	textRight(page, amountRight-4, y, 10, "金額")
+	page.SetGray(0)
+	y += 8
+	page.Line(marginX, y, amountRight, y, 0.5)
+	for _, line := range invoice.Lines {
+		y += lineHeight
+		page.Text(marginX+4, y-6, 10, truncate(line.Description, descriptionWidth, 10))
+		textRight(page, quantityRight, y-6, 10, strconv.Itoa(line.Quantity))
+		textRight(page, unitPriceRight, y-6, 10, yen(line.UnitPrice))
+		textRight(page, amountRight-4, y-6, 10, yen(line.Amount))
+		page.SetGray(0.7)
+		page.Line(marginX, y, amountRight, y, 0.5)
+		page.SetGray(0)
+	}
+
+	// 合計
+	y += 24
+	labelX := unitPriceRight - 60
+	for _, row := range [][2]string{
+		{"小計（税抜）", yen(invoice.Subtotal)},
+		{fmt.Sprintf("消費税（%s%%）", strconv.FormatFloat(invoice.TaxRate, 'f', -1, 64)), yen(invoice.Tax)},
+	} {
+		page.Text(labelX, y, 10, row[0])
+		textRight(page, amountRight-4, y, 10, row[1])
+		y += 18
+	}
+	page.Line(labelX, y-10, amountRight, y-10, 1)
+	page.Text(labelX, y+6, 12, "合計")
+	textRight(page, amountRight-4, y+6, 12, yen(invoice.Total))
+
+	page.Line(marginX, A4Height-90, A4Width-marginX, A4Height-90, 0.5)
+	page.Text(marginX, A4Height-70, 9, "消費税は請求書ごとに計算し、1円未満を切り捨てています。")
+
+	return doc.Bytes()
+}
+
+// textRight は right を右端として文字列を描画する
+func textRight(page *Page, right, y, size float64, s string) {
+	page.Text(right-TextWidth(s, size), y, size, s)
+}
+
+// truncate は描画幅が width を超える文字列を末尾を省略して切り詰める
+func truncate(s string, width, size float64) string {
+	if TextWidth(s, size) <= width {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		if TextWidth(b.String()+string(r)+"…", size) > width {
+			break
+		}
+		b.WriteRune(r)
+	}
+	return b.String() + "…"
+}
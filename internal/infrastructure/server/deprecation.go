@@ -0,0 +1,168 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/deprecation"
+	"Aicon-assignment/internal/interfaces/controller/identity"
+)
+
+// operationDeprecations は1つの操作で非推奨にしたもの（操作自体・クエリパラメーター・JSON のリクエストボディの項目）
+type operationDeprecations struct {
+	surface   string
+	operation *deprecation.Notice
+	query     map[string]deprecation.Notice
+	body      map[string]deprecation.Notice
+}
+
+// deprecatedUse はリクエストが使った非推奨の API
+type deprecatedUse struct {
+	surface string
+	notice  deprecation.Notice
+}
+
+// newDeprecations は OpenAPI 仕様の deprecated: true の要素を操作（"メソッド パス"）ごとに集める。
+// x-deprecation の記述の誤りは起動時にエラーにする
+func newDeprecations(spec []byte) (map[string]*operationDeprecations, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+
+	deprecations := make(map[string]*operationDeprecations)
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			surface := method + " " + path
+			d := &operationDeprecations{surface: surface}
+			found := false
+
+			if op.Deprecated {
+				notice, err := deprecation.FromExtensions(op.Extensions)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", surface, err)
+				}
+				d.operation = &notice
+				found = true
+			}
+
+			params := append(openapi3.Parameters{}, item.Parameters...)
+			for _, p := range append(params, op.Parameters...) {
+				if p.Value == nil || !p.Value.Deprecated || p.Value.In != openapi3.ParameterInQuery {
+					continue
+				}
+				notice, err := deprecation.FromExtensions(p.Value.Extensions)
+				if err != nil {
+					return nil, fmt.Errorf("%s parameter %s: %w", surface, p.Value.Name, err)
+				}
+				if d.query == nil {
+					d.query = make(map[string]deprecation.Notice)
+				}
+				d.query[p.Value.Name] = notice
+				found = true
+			}
+
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				if mt := op.RequestBody.Value.Content.Get(echo.MIMEApplicationJSON); mt != nil && mt.Schema != nil && mt.Schema.Value != nil {
+					for name, prop := range mt.Schema.Value.Properties {
+						if prop.Value == nil || !prop.Value.Deprecated {
+							continue
+						}
+						notice, err := deprecation.FromExtensions(prop.Value.Extensions)
+						if err != nil {
+							return nil, fmt.Errorf("%s body %s: %w", surface, name, err)
+						}
+						if d.body == nil {
+							d.body = make(map[string]deprecation.Notice)
+						}
+						d.body[name] = notice
+						found = true
+					}
+				}
+			}
+
+			if found {
+				deprecations[surface] = d
+			}
+		}
+	}
+	return deprecations, nil
+}
+
+// uses はリクエストが使った非推奨の API を返す（リクエストボディは読み込んだ後に元に戻す）
+func (d *operationDeprecations) uses(req *http.Request) ([]deprecatedUse, error) {
+	var uses []deprecatedUse
+	if d.operation != nil {
+		uses = append(uses, deprecatedUse{surface: d.surface, notice: *d.operation})
+	}
+
+	query := req.URL.Query()
+	for name, notice := range d.query {
+		if query.Has(name) {
+			uses = append(uses, deprecatedUse{surface: d.surface + " query." + name, notice: notice})
+		}
+	}
+
+	if len(d.body) > 0 && req.Body != nil && strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		// 形式の誤りは後続の検証で 400 にする
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(body, &fields) == nil {
+			for name, notice := range d.body {
+				if _, ok := fields[name]; ok {
+					uses = append(uses, deprecatedUse{surface: d.surface + " body." + name, notice: notice})
+				}
+			}
+		}
+	}
+	return uses, nil
+}
+
+// 非推奨の API を使ったリクエストのレスポンスに Deprecation / Sunset ヘッダーを付け、
+// 利用したクライアント（ユーザーと User-Agent）を記録するミドルウェア
+func deprecationMiddleware(router routers.Router, deprecations map[string]*operationDeprecations, tracker *deprecation.Tracker) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route, _, err := router.FindRoute(c.Request())
+			if err != nil {
+				return next(c)
+			}
+			d, ok := deprecations[route.Method+" "+route.Path]
+			if !ok {
+				return next(c)
+			}
+
+			uses, err := d.uses(c.Request())
+			if err != nil || len(uses) == 0 {
+				return next(c)
+			}
+			notices := make([]deprecation.Notice, len(uses))
+			for i, use := range uses {
+				notices[i] = use.notice
+			}
+			deprecation.SetHeaders(c.Response().Header(), deprecation.Merge(notices))
+
+			err = next(c)
+
+			// 認証はルートのミドルウェアで行うため、ユーザーはハンドラーの実行後に参照する
+			client := fmt.Sprintf("user %s (%s)", identity.UserID(c), c.Request().UserAgent())
+			for _, use := range uses {
+				tracker.Record(use.surface, client)
+			}
+			return err
+		}
+	}
+}
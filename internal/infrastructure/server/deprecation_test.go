@@ -0,0 +1,187 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/api"
+	"Aicon-assignment/internal/infrastructure/deprecation"
+)
+
+// deprecationSpec は非推奨の操作・クエリパラメーター・リクエストボディの項目を含む仕様
+const deprecationSpec = `
+openapi: 3.0.3
+info:
+  title: test
+  version: "1"
+paths:
+  /old:
+    get:
+      deprecated: true
+      x-deprecation:
+        since: 2024-10-01
+        sunset: 2025-03-31
+        replacement: GET /new
+        link: https://example.com/migration
+      responses:
+        "200":
+          description: ok
+  /new:
+    get:
+      parameters:
+        - name: sort
+          in: query
+          deprecated: true
+          x-deprecation:
+            since: "2024-11-01"
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+                brand:
+                  type: string
+                  deprecated: true
+      responses:
+        "200":
+          description: ok
+`
+
+func TestNewDeprecations(t *testing.T) {
+	deprecations, err := newDeprecations([]byte(deprecationSpec))
+	require.NoError(t, err)
+	require.Len(t, deprecations, 3)
+
+	old := deprecations["GET /old"]
+	require.NotNil(t, old.operation)
+	assert.Equal(t, time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC), old.operation.Since)
+	assert.Equal(t, "use GET /new instead; removed after 2025-03-31", old.operation.Text())
+
+	assert.Contains(t, deprecations["GET /new"].query, "sort")
+	assert.Contains(t, deprecations["POST /new"].body, "brand")
+
+	t.Run("x-deprecation の誤りは起動時にエラーにする", func(t *testing.T) {
+		spec := strings.Replace(deprecationSpec, "sunset: 2025-03-31", "sunset: 2024-01-01", 1)
+		_, err := newDeprecations([]byte(spec))
+		assert.ErrorContains(t, err, "GET /old")
+	})
+
+	t.Run("API の仕様", func(t *testing.T) {
+		_, err := newDeprecations(api.Spec)
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeprecationMiddleware(t *testing.T) {
+	router, err := newOpenAPIRouter([]byte(deprecationSpec))
+	require.NoError(t, err)
+	deprecations, err := newDeprecations([]byte(deprecationSpec))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		deprecation string
+		sunset      string
+		link        string
+		logged      string
+	}{
+		{
+			name:        "非推奨の操作",
+			method:      http.MethodGet,
+			target:      "/old",
+			deprecation: fmt.Sprintf("@%d", time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC).Unix()),
+			sunset:      "Mon, 31 Mar 2025 00:00:00 GMT",
+			link:        `<https://example.com/migration>; rel="deprecation"; type="text/html"`,
+			logged:      "GET /old",
+		},
+		{
+			name:        "非推奨のクエリパラメーター",
+			method:      http.MethodGet,
+			target:      "/new?sort=name",
+			deprecation: fmt.Sprintf("@%d", time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC).Unix()),
+			logged:      "GET /new query.sort",
+		},
+		{
+			name:        "非推奨のリクエストボディの項目（日付の告知なし）",
+			method:      http.MethodPost,
+			target:      "/new",
+			body:        `{"name":"a","brand":"b"}`,
+			deprecation: "true",
+			logged:      "POST /new body.brand",
+		},
+		{
+			name:   "非推奨の要素を使わないリクエスト",
+			method: http.MethodPost,
+			target: "/new",
+			body:   `{"name":"a"}`,
+		},
+		{
+			name:   "仕様にないパス",
+			method: http.MethodGet,
+			target: "/unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs []string
+			tracker := deprecation.NewTracker(time.Hour, func(format string, args ...any) {
+				logs = append(logs, fmt.Sprintf(format, args...))
+			})
+
+			e := echo.New()
+			e.Use(deprecationMiddleware(router, deprecations, tracker))
+			var received string
+			e.Any("/*", func(c echo.Context) error {
+				// 後続のハンドラーはリクエストボディをそのまま読める
+				var body map[string]any
+				if err := c.Bind(&body); err != nil {
+					return err
+				}
+				received = fmt.Sprint(body["name"])
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+				defer func() { assert.Equal(t, "a", received) }()
+			}
+			req.Header.Set("User-Agent", "test-client/1.0")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.deprecation, rec.Header().Get("Deprecation"))
+			assert.Equal(t, tt.sunset, rec.Header().Get("Sunset"))
+			assert.Equal(t, tt.link, rec.Header().Get("Link"))
+			if tt.logged == "" {
+				assert.Empty(t, logs)
+			} else {
+				require.Len(t, logs, 1)
+				assert.Contains(t, logs[0], tt.logged)
+				assert.Contains(t, logs[0], "test-client/1.0")
+			}
+		})
+	}
+}
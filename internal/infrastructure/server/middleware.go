@@ -0,0 +1,346 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/challenge"
+	"Aicon-assignment/internal/infrastructure/consistency"
+	"Aicon-assignment/internal/infrastructure/lane"
+	authController "Aicon-assignment/internal/interfaces/controller/auth"
+	"Aicon-assignment/internal/interfaces/controller/identity"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// クライアントが指定したリクエストIDとして受け付ける最大の長さ
+const maxRequestIDLength = 64
+
+const (
+	// headerIdempotencyKey は再送されたリクエストを識別するためにクライアントが指定するキー
+	headerIdempotencyKey = "Idempotency-Key"
+	// headerIdempotentReplayed は保存したレスポンスを返した場合に付けるヘッダー
+	headerIdempotentReplayed = "Idempotent-Replayed"
+)
+
+// リクエストをレーンに振り分け、レーンごとの同時実行数を制限するミドルウェア
+func laneMiddleware(classifier *lane.Classifier, limiter *lane.Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			l := classifier.Classify(req.URL.Path)
+
+			release, err := limiter.Acquire(req.Context(), l)
+			if err != nil {
+				if errors.Is(err, lane.ErrLaneBusy) {
+					c.Response().Header().Set("Retry-After", "1")
+					return problem.Respond(c, http.StatusServiceUnavailable, "server is busy, please retry later")
+				}
+				return err
+			}
+			defer release()
+
+			c.SetRequest(req.WithContext(lane.WithLane(req.Context(), l)))
+			return next(c)
+		}
+	}
+}
+
+// リクエストに書き込みの時刻を記録するトークンを設定し、書き込んだ場合は X-Consistency-Token ヘッダーで返すミドルウェア。
+// クライアントがトークンを送り返すと、次のリクエストや再送でもレプリカに反映されるまではプライマリから読み込む
+func consistencyMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			token := consistency.Parse(req.Header.Get(consistency.HeaderToken))
+			c.Response().Before(func() {
+				if value := token.String(); value != "" {
+					c.Response().Header().Set(consistency.HeaderToken, value)
+				}
+			})
+
+			c.SetRequest(req.WithContext(consistency.WithToken(req.Context(), token)))
+			return next(c)
+		}
+	}
+}
+
+// Accept-Language からレスポンスの表示言語（カテゴリー名）を選び、リクエストのコンテキストに設定するミドルウェア。
+// 言語によってレスポンスが変わるため、キャッシュ向けに Vary と Content-Language を返す
+func languageMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			lang := entity.ParseAcceptLanguage(req.Header.Get("Accept-Language"))
+			c.Response().Header().Add(echo.HeaderVary, "Accept-Language")
+			c.Response().Header().Set("Content-Language", string(lang))
+
+			c.SetRequest(req.WithContext(usecase.WithLanguage(req.Context(), lang)))
+			return next(c)
+		}
+	}
+}
+
+// 管理者以外のリクエストを 403 にするミドルウェア（RequireAuth の後に使う）
+func requireAdminMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			actor, ok := usecase.ActorFromContext(c.Request().Context())
+			if !ok || !actor.IsAdmin() {
+				return problem.Respond(c, http.StatusForbidden, "insufficient permissions")
+			}
+			return next(c)
+		}
+	}
+}
+
+// リクエストIDを X-Request-ID ヘッダーで返すミドルウェア（クライアントが指定した場合はそれを使う）
+func requestIDMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Request().Header.Get(echo.HeaderXRequestID)
+			if id == "" || len(id) > maxRequestIDLength {
+				id = newRequestID()
+			}
+			c.Response().Header().Set(echo.HeaderXRequestID, id)
+			return next(c)
+		}
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// 公開エンドポイントの利用がルールの条件を超えたクライアントにチャレンジ（CAPTCHA）を求めるミドルウェア。
+// チャレンジを求められたクライアントは、解いたトークンを X-Challenge-Token ヘッダーで送ると
+// 一定期間チャレンジを求められなくなる。ルールのないルートは何もしない
+func challengeMiddleware(verifier challenge.Challenge, guard *challenge.Guard) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route := challenge.RouteKey(c.Request().Method, c.Path())
+			if _, ok := guard.Rule(route); !ok {
+				return next(c)
+			}
+
+			client := c.RealIP()
+			if !guard.Passed(client) && guard.Required(route, client) {
+				token := c.Request().Header.Get(challenge.HeaderToken)
+				if token == "" {
+					return writeChallenge(c, verifier, http.StatusForbidden, problem.CodeChallengeRequired, "solve the challenge and retry with the "+challenge.HeaderToken+" header")
+				}
+				if err := verifier.Verify(c.Request().Context(), token, client); err != nil {
+					if errors.Is(err, challenge.ErrFailed) {
+						return writeChallenge(c, verifier, http.StatusForbidden, problem.CodeChallengeFailed, "challenge verification failed, solve a new challenge and retry")
+					}
+					log.Printf("⚠️  challenge verification error: %v", err)
+					return problem.Respond(c, http.StatusServiceUnavailable, "challenge verification is temporarily unavailable, retry later")
+				}
+				guard.Pass(client)
+			}
+
+			err := next(c)
+			status := c.Response().Status
+			var httpErr *echo.HTTPError
+			if errors.As(err, &httpErr) {
+				status = httpErr.Code
+			}
+			guard.Record(route, client, status)
+			return err
+		}
+	}
+}
+
+func writeChallenge(c echo.Context, verifier challenge.Challenge, status int, code, detail string) error {
+	p := problem.New(status, code, detail)
+	p.Challenge = &problem.Challenge{Provider: verifier.Provider(), SiteKey: verifier.SiteKey()}
+	return problem.Write(c, p)
+}
+
+// 成功した変更系の操作（作成・更新・削除・取り込み・エクスポート）を監査ログに記録するミドルウェア
+func auditMiddleware(audit usecase.AuditUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil || status >= http.StatusBadRequest {
+				return err
+			}
+			action, ok := auditAction(c.Request().Method, c.Path())
+			if !ok {
+				return err
+			}
+
+			entry := &entity.AuditLog{
+				Action:     action,
+				Method:     c.Request().Method,
+				Path:       c.Path(),
+				ResourceID: c.Param("id"),
+				Status:     status,
+				RequestID:  c.Response().Header().Get(echo.HeaderXRequestID),
+				IP:         c.RealIP(),
+				CreatedAt:  time.Now(),
+			}
+			if user := identity.User(c); user != nil {
+				entry.UserID = user.ID
+			}
+			audit.Record(entry)
+			return err
+		}
+	}
+}
+
+// X-API-Key を送ったリクエストのレスポンスをキーごとの利用状況に記録するミドルウェア（GET /me/api-usage）。
+// 混雑で断ったリクエストも数えるため、レーン制御より前の e.Pre で使う
+func apiUsageMiddleware(usage usecase.APIUsageUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(authController.HeaderAPIKey)
+			if key == "" {
+				return next(c)
+			}
+
+			err := next(c)
+			status := c.Response().Status
+			if err != nil && !c.Response().Committed {
+				// エラーのレスポンスはこの後 HTTPErrorHandler が書き込む
+				status = http.StatusInternalServerError
+				var httpErr *echo.HTTPError
+				if errors.As(err, &httpErr) {
+					status = httpErr.Code
+				}
+			}
+			// 429 と、レーンが混雑して Retry-After を付けて断った 503 をレート制限として数える
+			rateLimited := status == http.StatusTooManyRequests ||
+				(status == http.StatusServiceUnavailable && c.Response().Header().Get("Retry-After") != "")
+			usage.Record(key, status, rateLimited)
+			return err
+		}
+	}
+}
+
+// 操作の種類がメソッドから決まらないルート（"メソッド ルート"）
+var auditRoutes = map[string]entity.AuditAction{
+	"GET /items/export":                      entity.AuditActionExport,
+	"POST /items/import":                     entity.AuditActionImport,
+	"POST /items/export/accounting":          entity.AuditActionExport,
+	"GET /admin/reports/:name":               entity.AuditActionExport,
+	"GET /admin/events/export":               entity.AuditActionExport,
+	"POST /notifications/:id/read":           entity.AuditActionUpdate,
+	"POST /reports/naming-suggestions/apply": entity.AuditActionUpdate,
+	"POST /items/bulk-recategorize":          entity.AuditActionUpdate,
+	"POST /items/market-prices/refresh":      entity.AuditActionUpdate,
+	"GET /digest/unsubscribe":                entity.AuditActionUpdate,
+	"POST /digest/unsubscribe":               entity.AuditActionUpdate,
+}
+
+// データを変更しないため記録しない POST のルート
+var auditIgnoredRoutes = map[string]bool{
+	"POST /auth/login":  true,
+	"POST /items/quick": true,
+	"POST /items/parse": true,
+}
+
+// auditAction はリクエストを監査ログに記録する操作の種類を返す（記録しない場合は false）
+func auditAction(method, path string) (entity.AuditAction, bool) {
+	route := method + " " + path
+	if action, ok := auditRoutes[route]; ok {
+		return action, true
+	}
+	if auditIgnoredRoutes[route] {
+		return "", false
+	}
+
+	switch method {
+	case http.MethodPost:
+		return entity.AuditActionCreate, true
+	case http.MethodPut, http.MethodPatch:
+		return entity.AuditActionUpdate, true
+	case http.MethodDelete:
+		return entity.AuditActionDelete, true
+	}
+	return "", false
+}
+
+// Idempotency-Key を指定したリクエストのレスポンスを保存し、同じキーで再送されたリクエストには
+// 処理せずに保存したレスポンスを返すミドルウェア（認証後のルートに指定する）。
+// 処理に失敗した（5xx の）リクエストは保存せず、再送で処理し直す
+func idempotencyMiddleware(idempotency usecase.IdempotencyUsecase) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(headerIdempotencyKey)
+			if key == "" {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return problem.Respond(c, http.StatusBadRequest, "failed to read request body")
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := c.Request().Context()
+			record, err := idempotency.Begin(ctx, key, idempotencyRequestHash(c.Request(), body))
+			if err != nil {
+				return problem.Error(c, err, "internal server error")
+			}
+
+			if record != nil {
+				c.Response().Header().Set(headerIdempotentReplayed, "true")
+				return c.Blob(record.StatusCode, record.ContentType, record.Body)
+			}
+
+			writer := c.Response().Writer
+			recorder := &bodyRecorder{ResponseWriter: writer}
+			c.Response().Writer = recorder
+			err = next(c)
+			c.Response().Writer = writer
+
+			// クライアントが切断してもキーの状態を更新できるよう、キャンセルを引き継がない
+			saveCtx := context.WithoutCancel(ctx)
+			if err != nil || c.Response().Status >= http.StatusInternalServerError {
+				if abortErr := idempotency.Abort(saveCtx, key); abortErr != nil {
+					log.Printf("⚠️  failed to release idempotency key: %v", abortErr)
+				}
+				return err
+			}
+			if saveErr := idempotency.Complete(saveCtx, key, c.Response().Status, c.Response().Header().Get(echo.HeaderContentType), recorder.body.Bytes()); saveErr != nil {
+				log.Printf("⚠️  failed to save idempotent response: %v", saveErr)
+			}
+			return nil
+		}
+	}
+}
+
+// idempotencyRequestHash は同じキーで異なるリクエストが送られたことを検出するためのハッシュを返す
+func idempotencyRequestHash(req *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyRecorder はレスポンスのボディを書き込みながら記録する
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,442 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/challenge"
+	"Aicon-assignment/internal/infrastructure/consistency"
+	"Aicon-assignment/internal/interfaces/controller/identity"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 記録された監査ログを保持する AuditUsecase
+type recordingAuditUsecase struct {
+	logs []*entity.AuditLog
+}
+
+func (u *recordingAuditUsecase) Record(log *entity.AuditLog) {
+	u.logs = append(u.logs, log)
+}
+
+func (u *recordingAuditUsecase) Run(ctx context.Context) {}
+
+func (u *recordingAuditUsecase) List(ctx context.Context, input usecase.ListAuditLogsInput) ([]*entity.AuditLog, error) {
+	return u.logs, nil
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	audit := &recordingAuditUsecase{}
+	e := echo.New()
+	e.Use(requestIDMiddleware())
+	e.Use(auditMiddleware(audit))
+
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			identity.SetUser(c, &entity.User{ID: 7})
+			return next(c)
+		}
+	}
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.PATCH("/items/:id", ok, setUser)
+	e.GET("/items/:id", ok, setUser)
+	e.GET("/items/export", ok, setUser)
+	e.DELETE("/items/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusNotFound)
+	}, setUser)
+
+	serve := func(method, target string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		for k, v := range header {
+			req.Header.Set(k, v[0])
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(http.MethodPatch, "/items/12", http.Header{
+		echo.HeaderXRequestID:    {"req-1"},
+		echo.HeaderXForwardedFor: {"203.0.113.5"},
+	})
+	assert.Equal(t, "req-1", rec.Header().Get(echo.HeaderXRequestID))
+
+	serve(http.MethodGet, "/items/12", nil)
+	serve(http.MethodGet, "/items/export", nil)
+	serve(http.MethodDelete, "/items/12", nil)
+
+	// 参照と失敗した操作は記録しない
+	require.Len(t, audit.logs, 2)
+	update := audit.logs[0]
+	assert.Equal(t, int64(7), update.UserID)
+	assert.Equal(t, entity.AuditActionUpdate, update.Action)
+	assert.Equal(t, "/items/:id", update.Path)
+	assert.Equal(t, "12", update.ResourceID)
+	assert.Equal(t, "req-1", update.RequestID)
+	assert.Equal(t, "203.0.113.5", update.IP)
+
+	export := audit.logs[1]
+	assert.Equal(t, entity.AuditActionExport, export.Action)
+	// リクエストIDを指定しない場合は生成する
+	assert.Len(t, export.RequestID, 32)
+}
+
+func TestAuditAction(t *testing.T) {
+	tests := []struct {
+		method   string
+		path     string
+		expected entity.AuditAction
+		recorded bool
+	}{
+		{http.MethodPost, "/items", entity.AuditActionCreate, true},
+		{http.MethodPut, "/items/:id/consignment", entity.AuditActionUpdate, true},
+		{http.MethodDelete, "/items/:id", entity.AuditActionDelete, true},
+		{http.MethodPost, "/items/export/accounting", entity.AuditActionExport, true},
+		{http.MethodPost, "/items/import", entity.AuditActionImport, true},
+		{http.MethodPost, "/notifications/:id/read", entity.AuditActionUpdate, true},
+		{http.MethodPost, "/reports/naming-suggestions/apply", entity.AuditActionUpdate, true},
+		{http.MethodPost, "/auth/login", "", false},
+		{http.MethodPost, "/items/parse", "", false},
+		{http.MethodGet, "/items", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			action, recorded := auditAction(tt.method, tt.path)
+
+			assert.Equal(t, tt.expected, action)
+			assert.Equal(t, tt.recorded, recorded)
+		})
+	}
+}
+
+// 記録されたリクエストの結果を保持する APIUsageUsecase
+type recordingAPIUsageUsecase struct {
+	usecase.APIUsageUsecase
+	records []apiUsageRecord
+}
+
+type apiUsageRecord struct {
+	key         string
+	status      int
+	rateLimited bool
+}
+
+func (u *recordingAPIUsageUsecase) Record(key string, status int, rateLimited bool) {
+	u.records = append(u.records, apiUsageRecord{key, status, rateLimited})
+}
+
+func TestAPIUsageMiddleware(t *testing.T) {
+	usage := &recordingAPIUsageUsecase{}
+	e := echo.New()
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+	e.Pre(apiUsageMiddleware(usage))
+
+	e.GET("/ok", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+	e.GET("/busy", func(c echo.Context) error {
+		c.Response().Header().Set("Retry-After", "1")
+		return problem.Respond(c, http.StatusServiceUnavailable, "server is busy, please retry later")
+	})
+	e.GET("/unavailable", func(c echo.Context) error {
+		return problem.Respond(c, http.StatusServiceUnavailable, "exchange rates are temporarily unavailable")
+	})
+	e.GET("/limited", func(c echo.Context) error { return echo.NewHTTPError(http.StatusTooManyRequests) })
+
+	serve := func(target, key string) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve("/ok", "aic_key")
+	serve("/ok", "")
+	serve("/busy", "aic_key")
+	serve("/unavailable", "aic_key")
+	serve("/limited", "aic_key")
+	serve("/missing", "aic_key")
+
+	// キーを送らないリクエストは記録しない。エラーを返したハンドラーとルートがない場合も最終的なステータスで記録する
+	assert.Equal(t, []apiUsageRecord{
+		{"aic_key", http.StatusOK, false},
+		{"aic_key", http.StatusServiceUnavailable, true},
+		{"aic_key", http.StatusServiceUnavailable, false},
+		{"aic_key", http.StatusTooManyRequests, true},
+		{"aic_key", http.StatusNotFound, false},
+	}, usage.records)
+}
+
+// メモリ上に Idempotency-Key を保存する IdempotencyRepository
+type memoryIdempotencyRepository struct {
+	records map[string]*entity.IdempotencyRecord
+}
+
+func (r *memoryIdempotencyRepository) Create(ctx context.Context, record *entity.IdempotencyRecord) error {
+	if _, ok := r.records[record.Key]; ok {
+		return domainErrors.ErrDuplicateEntry
+	}
+	r.records[record.Key] = record
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Find(ctx context.Context, userID int64, key string) (*entity.IdempotencyRecord, error) {
+	record, ok := r.records[key]
+	if !ok {
+		return nil, domainErrors.ErrIdempotencyKeyNotFound
+	}
+	return record, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	stored := r.records[record.Key]
+	stored.Completed = true
+	stored.StatusCode = record.StatusCode
+	stored.ContentType = record.ContentType
+	stored.Body = record.Body
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Delete(ctx context.Context, userID int64, key string) error {
+	delete(r.records, key)
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[string]*entity.IdempotencyRecord{}}
+	e := echo.New()
+
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.SetRequest(c.Request().WithContext(usecase.WithActor(c.Request().Context(), &entity.User{ID: 7})))
+			return next(c)
+		}
+	}
+	created := 0
+	e.POST("/items", func(c echo.Context) error {
+		body, _ := io.ReadAll(c.Request().Body)
+		if string(body) == "fail" {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		created++
+		return c.JSON(http.StatusCreated, map[string]int{"id": created})
+	}, setUser, idempotencyMiddleware(usecase.NewIdempotencyUsecase(repo)))
+
+	serve := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+		if key != "" {
+			req.Header.Set(headerIdempotencyKey, key)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := serve("key-1", `{"name":"a"}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(headerIdempotentReplayed))
+
+	// 同じキーの再送は処理せず、保存したレスポンスを返す
+	retried := serve("key-1", `{"name":"a"}`)
+	assert.Equal(t, http.StatusCreated, retried.Code)
+	assert.Equal(t, "true", retried.Header().Get(headerIdempotentReplayed))
+	assert.Equal(t, first.Body.String(), retried.Body.String())
+	assert.Equal(t, echo.MIMEApplicationJSON, retried.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, 1, created)
+
+	// 同じキーで異なるリクエストは 422
+	assert.Equal(t, http.StatusUnprocessableEntity, serve("key-1", `{"name":"b"}`).Code)
+
+	// 処理中のキーは 409
+	repo.records["key-2"] = &entity.IdempotencyRecord{UserID: 7, Key: "key-2", RequestHash: idempotencyRequestHash(httptest.NewRequest(http.MethodPost, "/items", nil), []byte("x")), CreatedAt: time.Now()}
+	assert.Equal(t, http.StatusConflict, serve("key-2", "x").Code)
+
+	// 失敗したリクエストは保存せず、再送で処理し直す
+	assert.Equal(t, http.StatusInternalServerError, serve("key-3", "fail").Code)
+	assert.NotContains(t, repo.records, "key-3")
+
+	// キーを指定しない場合は毎回処理する
+	serve("", `{"name":"a"}`)
+	serve("", `{"name":"a"}`)
+	assert.Equal(t, 3, created)
+}
+
+func TestConsistencyMiddleware(t *testing.T) {
+	e := echo.New()
+	e.Use(consistencyMiddleware())
+
+	var requiresPrimary bool
+	e.GET("/items", func(c echo.Context) error {
+		token := consistency.FromContext(c.Request().Context())
+		requiresPrimary = token.RequiresPrimary(time.Now(), time.Minute)
+		return c.NoContent(http.StatusOK)
+	})
+	e.PATCH("/items/:id", func(c echo.Context) error {
+		consistency.FromContext(c.Request().Context()).MarkWrite(time.Now())
+		return c.NoContent(http.StatusOK)
+	})
+
+	serve := func(method, target, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		if token != "" {
+			req.Header.Set(consistency.HeaderToken, token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// 書き込みのないリクエストはトークンを返さない
+	rec := serve(http.MethodGet, "/items", "")
+	assert.Empty(t, rec.Header().Get(consistency.HeaderToken))
+	assert.False(t, requiresPrimary)
+
+	rec = serve(http.MethodPatch, "/items/1", "")
+	token := rec.Header().Get(consistency.HeaderToken)
+	require.NotEmpty(t, token)
+
+	// 送り返されたトークンの読み込みはプライマリに送る
+	serve(http.MethodGet, "/items", token)
+	assert.True(t, requiresPrimary)
+}
+
+func TestRequireAdminMiddleware(t *testing.T) {
+	e := echo.New()
+	e.GET("/debug/vars", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, requireAdminMiddleware())
+
+	serve := func(actor *entity.User) int {
+		req := httptest.NewRequest(http.MethodGet, "/debug/vars", nil)
+		if actor != nil {
+			req = req.WithContext(usecase.WithActor(req.Context(), actor))
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, serve(&entity.User{ID: 1, Role: entity.RoleAdmin}))
+	assert.Equal(t, http.StatusForbidden, serve(&entity.User{ID: 2, Role: entity.RoleEditor}))
+	assert.Equal(t, http.StatusForbidden, serve(nil))
+}
+
+func TestLanguageMiddleware(t *testing.T) {
+	e := echo.New()
+	e.GET("/categories", func(c echo.Context) error {
+		return c.String(http.StatusOK, string(usecase.LanguageFromContext(c.Request().Context())))
+	}, languageMiddleware())
+
+	serve := func(acceptLanguage string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve("en-US,en;q=0.9,ja;q=0.8")
+	assert.Equal(t, "en", rec.Body.String())
+	assert.Equal(t, "en", rec.Header().Get("Content-Language"))
+	assert.Equal(t, "Accept-Language", rec.Header().Get("Vary"))
+
+	// 指定がない場合と対応していない言語は日本語
+	assert.Equal(t, "ja", serve("").Body.String())
+	assert.Equal(t, "ja", serve("fr").Body.String())
+}
+
+// トークンごとの検証結果を返す Challenge
+type fakeChallenge struct{}
+
+func (fakeChallenge) Provider() string { return challenge.ProviderTurnstile }
+func (fakeChallenge) SiteKey() string  { return "site-key" }
+func (fakeChallenge) Verify(ctx context.Context, token, remoteIP string) error {
+	switch token {
+	case "valid":
+		return nil
+	case "error":
+		return errors.New("siteverify unavailable")
+	}
+	return challenge.ErrFailed
+}
+
+func TestChallengeMiddleware(t *testing.T) {
+	newServer := func() *echo.Echo {
+		e := echo.New()
+		guard := challenge.NewGuard(map[string]challenge.Rule{
+			"GET /public/portfolios/:token": {Requests: 2, Window: time.Minute, NotFound: 1},
+		}, time.Hour)
+		e.Use(challengeMiddleware(fakeChallenge{}, guard))
+		e.GET("/public/portfolios/:token", func(c echo.Context) error {
+			if c.Param("token") == "unknown" {
+				return c.NoContent(http.StatusNotFound)
+			}
+			return c.NoContent(http.StatusOK)
+		})
+		e.GET("/items", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+		return e
+	}
+	serve := func(e *echo.Echo, target, ip, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req.Header.Set(echo.HeaderXRealIP, ip)
+		if token != "" {
+			req.Header.Set(challenge.HeaderToken, token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("条件を超えたクライアントにチャレンジを求め、解いたトークンで通す", func(t *testing.T) {
+		e := newServer()
+		assert.Equal(t, http.StatusOK, serve(e, "/public/portfolios/abc", "203.0.113.5", "").Code)
+		assert.Equal(t, http.StatusOK, serve(e, "/public/portfolios/abc", "203.0.113.5", "").Code)
+
+		rec := serve(e, "/public/portfolios/abc", "203.0.113.5", "")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.JSONEq(t, `{
+			"type": "urn:aicon-assignment:problem:challenge_required", "title": "Forbidden", "status": 403,
+			"detail": "solve the challenge and retry with the X-Challenge-Token header", "code": "challenge_required",
+			"challenge": {"provider": "turnstile", "site_key": "site-key"}
+		}`, rec.Body.String())
+		// ルールのないルートと他のクライアントは影響を受けない
+		assert.Equal(t, http.StatusOK, serve(e, "/items", "203.0.113.5", "").Code)
+		assert.Equal(t, http.StatusOK, serve(e, "/public/portfolios/abc", "198.51.100.7", "").Code)
+
+		rec = serve(e, "/public/portfolios/abc", "203.0.113.5", "used")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"code":"challenge_failed"`)
+		assert.Equal(t, http.StatusServiceUnavailable, serve(e, "/public/portfolios/abc", "203.0.113.5", "error").Code)
+
+		assert.Equal(t, http.StatusOK, serve(e, "/public/portfolios/abc", "203.0.113.5", "valid").Code)
+		// 解いた後は有効期間の間トークンなしで通す
+		for range 3 {
+			assert.Equal(t, http.StatusOK, serve(e, "/public/portfolios/abc", "203.0.113.5", "").Code)
+		}
+	})
+
+	t.Run("存在しないトークンの共有リンクが続くとチャレンジを求める", func(t *testing.T) {
+		e := newServer()
+		assert.Equal(t, http.StatusNotFound, serve(e, "/public/portfolios/unknown", "203.0.113.5", "").Code)
+		assert.Equal(t, http.StatusForbidden, serve(e, "/public/portfolios/abc", "203.0.113.5", "").Code)
+	})
+}
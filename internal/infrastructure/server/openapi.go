@@ -0,0 +1,179 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+)
+
+func init() {
+	// multipart で送られる画像のパートを検証できるようにする（形式の判定はユースケースで内容から行う）
+	for _, contentType := range entity.ImageContentTypes {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+}
+
+// OpenAPI仕様を読み込み、リクエストのルーティング用ルーターを作成する
+func newOpenAPIRouter(spec []byte) (routers.Router, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+	// servers のホストに依存せずパスだけでマッチさせる
+	doc.Servers = nil
+
+	return gorillamux.NewRouter(doc)
+}
+
+// リクエストをOpenAPI仕様に照らして検証し、構造的な違反は400で返すミドルウェア。
+// 仕様に定義されていないパスはそのまま後続に渡す。
+func openAPIValidationMiddleware(router routers.Router) echo.MiddlewareFunc {
+	options := &openapi3filter.Options{
+		MultiError:         true,
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				return next(c)
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				if missingIfMatch(err) {
+					return problem.Respond(c, http.StatusPreconditionRequired, "If-Match header is required")
+				}
+				return problem.ValidationFailed(c, openAPIErrorDetails(err))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// missingIfMatch は必須の If-Match ヘッダーがない場合に true を返す（400 ではなく 428 で返す）
+func missingIfMatch(err error) bool {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		for _, e := range multi {
+			if missingIfMatch(e) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var reqErr *openapi3filter.RequestError
+	return errors.As(err, &reqErr) && reqErr.Parameter != nil &&
+		reqErr.Parameter.In == openapi3.ParameterInHeader && reqErr.Parameter.Name == itemController.HeaderIfMatch &&
+		errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired)
+}
+
+// 検証エラーを項目ごとの検証エラーに変換する
+func openAPIErrorDetails(err error) domainErrors.ValidationErrors {
+	var multi openapi3.MultiError
+	if errors.As(err, &multi) {
+		details := make(domainErrors.ValidationErrors, 0, len(multi))
+		for _, e := range multi {
+			details = append(details, openAPIErrorDetails(e)...)
+		}
+		return details
+	}
+
+	var reqErr *openapi3filter.RequestError
+	if errors.As(err, &reqErr) {
+		if reqErr.Parameter != nil {
+			reason := reqErr.Reason
+			if reason == "" && reqErr.Err != nil {
+				reason = reqErr.Err.Error()
+			}
+			return domainErrors.ValidationErrors{{
+				Field:   reqErr.Parameter.Name,
+				Code:    openAPIErrorCode(reqErr.Err),
+				Message: fmt.Sprintf("%s: %s", reqErr.Parameter.Name, reason),
+			}}
+		}
+		// リクエストボディの違反は項目ごとのスキーマの違反に展開する
+		if reqErr.Err != nil {
+			var multi openapi3.MultiError
+			var schemaErr *openapi3.SchemaError
+			if errors.As(reqErr.Err, &multi) || errors.As(reqErr.Err, &schemaErr) {
+				return openAPIErrorDetails(reqErr.Err)
+			}
+		}
+		if reqErr.Reason != "" {
+			return domainErrors.ValidationErrors{{Code: domainErrors.CodeInvalid, Message: reqErr.Reason}}
+		}
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		field := strings.Join(schemaErr.JSONPointer(), ".")
+		message := schemaErr.Reason
+		if field != "" {
+			message = field + ": " + message
+		}
+		return domainErrors.ValidationErrors{{Field: field, Code: openAPIErrorCode(schemaErr), Message: message}}
+	}
+
+	return domainErrors.ValidationErrors{{Code: domainErrors.CodeInvalid, Message: err.Error()}}
+}
+
+// スキーマのキーワードに対応する検証エラーのコード
+var openAPISchemaFieldCodes = map[string]string{
+	"required":  domainErrors.CodeRequired,
+	"type":      domainErrors.CodeInvalidType,
+	"nullable":  domainErrors.CodeNotNull,
+	"enum":      domainErrors.CodeInvalidChoice,
+	"format":    domainErrors.CodeInvalidFormat,
+	"pattern":   domainErrors.CodeInvalidFormat,
+	"maxLength": domainErrors.CodeTooLong,
+	"maxItems":  domainErrors.CodeTooLong,
+	"minLength": domainErrors.CodeRequired,
+	"minItems":  domainErrors.CodeRequired,
+	"minimum":   domainErrors.CodeOutOfRange,
+	"maximum":   domainErrors.CodeOutOfRange,
+}
+
+func openAPIErrorCode(err error) string {
+	if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+		return domainErrors.CodeRequired
+	}
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		if code, ok := openAPISchemaFieldCodes[schemaErr.SchemaField]; ok {
+			return code
+		}
+	}
+	var parseErr *openapi3filter.ParseError
+	if errors.As(err, &parseErr) {
+		return domainErrors.CodeInvalidType
+	}
+	return domainErrors.CodeInvalid
+}
@@ -0,0 +1,334 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/api"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+)
+
+func TestOpenAPIValidationMiddleware(t *testing.T) {
+	router, err := newOpenAPIRouter(api.Spec)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		body           string
+		ifMatch        string
+		expectedStatus int
+	}{
+		{
+			name:           "正常系: 正しいリクエスト",
+			method:         http.MethodPost,
+			target:         "/items",
+			body:           `{"name":"a","category":"時計","brand":"b","purchase_price":1,"purchase_date":"2023-01-01"}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 仕様にないパスは素通し",
+			method:         http.MethodGet,
+			target:         "/unknown",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 必須フィールドの欠落",
+			method:         http.MethodPost,
+			target:         "/items",
+			body:           `{"name":"a"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 型の不一致",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"purchase_price":"高い"}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: If-Match の指定がない更新は428",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"name":"a"}`,
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name:           "正常系: 置き換えの visibility と org_id は null を指定できる",
+			method:         http.MethodPut,
+			target:         "/items/1",
+			body:           `{"name":"a","category":"時計","brand":"b","purchase_price":1,"purchase_date":"2023-01-01","visibility":null,"org_id":null}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 部分更新の condition は null を指定できる",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"condition":null}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 部分更新の serial_number は null を指定できる",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"serial_number":null}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 部分更新の notes は null を指定できる",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"notes":null}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 部分更新の attributes は null を指定できる",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"attributes":null}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 選択肢にない movement",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"attributes":{"movement":"ゼンマイ"}}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "正常系: 属性で絞り込める",
+			method:         http.MethodGet,
+			target:         "/items?attr.movement=%E8%87%AA%E5%8B%95%E5%B7%BB%E3%81%8D",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "正常系: 追加したカテゴリーで絞り込める",
+			method:         http.MethodGet,
+			target:         "/items?category=%E3%82%AB%E3%83%A1%E3%83%A9",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 空のカテゴリー名",
+			method:         http.MethodPost,
+			target:         "/categories",
+			body:           `{"name":""}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "正常系: 英語のカテゴリー名で絞り込める",
+			method:         http.MethodGet,
+			target:         "/items?category=watch",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 変更内容のない名前の一括変更",
+			method:         http.MethodPost,
+			target:         "/reports/naming-suggestions/apply",
+			body:           `{"changes":[]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 定義されていない所有状況への変更",
+			method:         http.MethodPatch,
+			target:         "/items/1/status",
+			body:           `{"status":"reserved"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 定義されていない所有状況での絞り込み",
+			method:         http.MethodGet,
+			target:         "/items?status=reserved",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 売却価格のない売却の記録",
+			method:         http.MethodPost,
+			target:         "/items/1/sale",
+			body:           `{"sold_date":"2024-05-31"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 対応していない通貨の評価額の記録",
+			method:         http.MethodPost,
+			target:         "/items/1/valuations",
+			body:           `{"value":2100000,"currency":"GBP","valued_on":"2024-05-31"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 型番のないカタログの検索",
+			method:         http.MethodGet,
+			target:         "/catalog/lookup",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 変更内容のないカテゴリーの変更",
+			method:         http.MethodPatch,
+			target:         "/categories/1",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "正常系: タグは複数指定できる",
+			method:         http.MethodGet,
+			target:         "/items?tag=vintage&tag=%E7%AE%B1%E3%81%82%E3%82%8A",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 30文字を超えるタグ",
+			method:         http.MethodPost,
+			target:         "/items/1/tags",
+			body:           `{"name":"` + strings.Repeat("あ", 31) + `"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 500文字を超えるメモ",
+			method:         http.MethodPost,
+			target:         "/items/1/memos",
+			body:           `{"body":"` + strings.Repeat("あ", 501) + `"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 未定義の condition",
+			method:         http.MethodPatch,
+			target:         "/items/1",
+			body:           `{"condition":"美品"}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 置き換えで必須フィールドを省略",
+			method:         http.MethodPut,
+			target:         "/items/1",
+			body:           `{"name":"a"}`,
+			ifMatch:        `"1"`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: パスパラメータが数値でない",
+			method:         http.MethodGet,
+			target:         "/items/abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: クエリパラメータが範囲外",
+			method:         http.MethodGet,
+			target:         "/items?min_price=-1",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, tt.target, body)
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			}
+			if tt.ifMatch != "" {
+				req.Header.Set(itemController.HeaderIfMatch, tt.ifMatch)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			next := func(c echo.Context) error {
+				// 後続のハンドラーでもボディを読めること
+				got, err := io.ReadAll(c.Request().Body)
+				require.NoError(t, err)
+				assert.Equal(t, tt.body, string(got))
+				return c.NoContent(http.StatusOK)
+			}
+
+			require.NoError(t, openAPIValidationMiddleware(router)(next)(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus == http.StatusBadRequest {
+				assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+				var resp problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+				assert.Equal(t, problem.CodeValidationFailed, resp.Code)
+				require.NotEmpty(t, resp.Errors)
+				assert.NotEmpty(t, resp.Errors[0].Code)
+			}
+		})
+	}
+}
+
+func TestOpenAPIValidationMiddleware_Multipart(t *testing.T) {
+	router, err := newOpenAPIRouter(api.Spec)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	part, err := w.CreatePart(textproto.MIMEHeader{
+		"Content-Disposition": {`form-data; name="file"; filename="photo.png"`},
+		"Content-Type":        {"image/png"},
+	})
+	require.NoError(t, err)
+	part.Write([]byte("\x89PNG\r\n\x1a\n"))
+	require.NoError(t, w.Close())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/items/1/images", bytes.NewReader(buf.Bytes()))
+	req.Header.Set(echo.HeaderContentType, w.FormDataContentType())
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	next := func(c echo.Context) error {
+		// 後続のハンドラーでファイルを読めること
+		header, err := c.FormFile("file")
+		require.NoError(t, err)
+		assert.Equal(t, "photo.png", header.Filename)
+		return c.NoContent(http.StatusOK)
+	}
+
+	require.NoError(t, openAPIValidationMiddleware(router)(next)(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestOpenAPIValidationMiddleware_FieldErrors(t *testing.T) {
+	router, err := newOpenAPIRouter(api.Spec)
+	require.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"a","category":"時計","brand":"b","purchase_price":"高い","purchase_date":"2023-01-01","visibility":"everyone"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	next := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	require.NoError(t, openAPIValidationMiddleware(router)(next)(c))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var resp problem.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Errors, 2)
+	assert.Equal(t, "purchase_price", resp.Errors[0].Field)
+	assert.Equal(t, domainErrors.CodeInvalidType, resp.Errors[0].Code)
+	assert.Equal(t, "visibility", resp.Errors[1].Field)
+	assert.Equal(t, domainErrors.CodeInvalidChoice, resp.Errors[1].Code)
+}
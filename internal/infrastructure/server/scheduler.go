@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ダイジェストメールの配信ジョブを実行するユーザー（利用者のジョブとは別に1件ずつ実行する）
+const digestJobOwner = "system:digest"
+
+// 相場での評価額の更新ジョブを実行するユーザー
+const marketPriceJobOwner = "system:market-prices"
+
+// バックアップの検証ジョブを実行するユーザー
+const backupVerificationJobOwner = "system:backup-verification"
+
+// 保存期間を過ぎた Idempotency-Key を削除する間隔
+const idempotencyKeyCleanupInterval = time.Hour
+
+// 期限切れのアイテムの下書きを削除する間隔
+const draftSessionCleanupInterval = time.Hour
+
+// runDigestScheduler は interval ごとに配信する時期になったダイジェストメールを送信するジョブを開始する。
+// 前回のジョブが実行中の場合はその回を見送る
+func runDigestScheduler(ctx context.Context, interval time.Duration, jobs usecase.JobUsecase, digests usecase.DigestUsecase) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, err := jobs.Submit(ctx, digestJobOwner, entity.JobKindDigest, func(ctx context.Context, job *entity.Job) error {
+			sent, err := digests.SendDue(ctx)
+			if sent > 0 {
+				log.Printf("📧 digest sent to %d users", sent)
+			}
+			if err != nil {
+				log.Printf("⚠️  digest delivery failed: %v", err)
+			}
+			return err
+		})
+		if err != nil && !domainErrors.IsJobConflictError(err) {
+			log.Printf("⚠️  failed to start digest job: %v", err)
+		}
+	}
+}
+
+// runMarketPriceScheduler は interval ごとにすべてのアイテムの評価額を相場で更新するジョブを開始する。
+// 前回のジョブが実行中の場合はその回を見送る
+func runMarketPriceScheduler(ctx context.Context, interval time.Duration, jobs usecase.JobUsecase, marketPrices usecase.MarketPriceUsecase) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, err := jobs.Submit(ctx, marketPriceJobOwner, entity.JobKindMarketPrice, func(ctx context.Context, job *entity.Job) error {
+			result, err := marketPrices.RefreshAll(ctx)
+			if result != nil && result.Updated > 0 {
+				log.Printf("💹 market prices updated for %d items", result.Updated)
+			}
+			if err != nil {
+				log.Printf("⚠️  market price refresh failed: %v", err)
+			}
+			return err
+		})
+		if err != nil && !domainErrors.IsJobConflictError(err) {
+			log.Printf("⚠️  failed to start market price job: %v", err)
+		}
+	}
+}
+
+// runBackupVerificationScheduler は interval ごとにバックアップを検証用のスキーマに読み込んで検証するジョブを開始する。
+// 前回のジョブが実行中の場合はその回を見送る
+func runBackupVerificationScheduler(ctx context.Context, interval time.Duration, jobs usecase.JobUsecase, verifications usecase.BackupVerificationUsecase) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		_, err := jobs.Submit(ctx, backupVerificationJobOwner, entity.JobKindBackupVerification, func(ctx context.Context, job *entity.Job) error {
+			result, err := verifications.Verify(ctx)
+			if err != nil {
+				log.Printf("⚠️  backup verification failed: %v", err)
+				return err
+			}
+			if result.Status != entity.BackupVerificationPassed {
+				log.Printf("⚠️  backup verification found mismatched tables: %+v", result.Tables)
+				return fmt.Errorf("backup verification %s", result.Status)
+			}
+			log.Printf("🗄️  backup verified (%d tables)", len(result.Tables))
+			return nil
+		})
+		if err != nil && !domainErrors.IsJobConflictError(err) {
+			log.Printf("⚠️  failed to start backup verification job: %v", err)
+		}
+	}
+}
+
+// runIdempotencyKeyCleanup は interval ごとに保存期間を過ぎた Idempotency-Key を削除する
+func runIdempotencyKeyCleanup(ctx context.Context, interval time.Duration, idempotency usecase.IdempotencyUsecase) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := idempotency.DeleteExpired(ctx); err != nil {
+			log.Printf("⚠️  failed to delete expired idempotency keys: %v", err)
+		}
+	}
+}
+
+// runDraftSessionCleanup は interval ごとに期限切れのアイテムの下書きと画像を削除する
+func runDraftSessionCleanup(ctx context.Context, interval time.Duration, drafts usecase.DraftSessionUsecase) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := drafts.DeleteExpired(ctx)
+		if deleted > 0 {
+			log.Printf("🗑️  deleted %d expired item drafts", deleted)
+		}
+		if err != nil {
+			log.Printf("⚠️  failed to delete expired item drafts: %v", err)
+		}
+	}
+}
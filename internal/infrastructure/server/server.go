@@ -2,21 +2,98 @@ package server
 
 import (
 	"context"
+	"expvar"
 	"fmt"
+	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
 
+	"Aicon-assignment/api"
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/infrastructure/accounting"
+	authInfra "Aicon-assignment/internal/infrastructure/auth"
+	"Aicon-assignment/internal/infrastructure/catalog"
+	"Aicon-assignment/internal/infrastructure/challenge"
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/infrastructure/consistency"
 	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/deprecation"
+	"Aicon-assignment/internal/infrastructure/exchangerate"
+	"Aicon-assignment/internal/infrastructure/idgen"
+	"Aicon-assignment/internal/infrastructure/lane"
+	"Aicon-assignment/internal/infrastructure/mail"
+	"Aicon-assignment/internal/infrastructure/marketprice"
+	"Aicon-assignment/internal/infrastructure/ocr"
+	"Aicon-assignment/internal/infrastructure/pdf"
+	"Aicon-assignment/internal/infrastructure/storage"
+	"Aicon-assignment/internal/infrastructure/thumbnail"
+	"Aicon-assignment/internal/infrastructure/xlsx"
+	auditController "Aicon-assignment/internal/interfaces/controller/audit"
+	authController "Aicon-assignment/internal/interfaces/controller/auth"
+	backupController "Aicon-assignment/internal/interfaces/controller/backups"
+	catalogController "Aicon-assignment/internal/interfaces/controller/catalog"
+	categoryController "Aicon-assignment/internal/interfaces/controller/categories"
+	certificateController "Aicon-assignment/internal/interfaces/controller/certificates"
+	commentController "Aicon-assignment/internal/interfaces/controller/comments"
+	consignmentController "Aicon-assignment/internal/interfaces/controller/consignments"
+	digestController "Aicon-assignment/internal/interfaces/controller/digests"
+	eventController "Aicon-assignment/internal/interfaces/controller/events"
+	imageController "Aicon-assignment/internal/interfaces/controller/images"
+	invoiceController "Aicon-assignment/internal/interfaces/controller/invoices"
 	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	jobController "Aicon-assignment/internal/interfaces/controller/jobs"
+	memoController "Aicon-assignment/internal/interfaces/controller/memos"
+	migrationController "Aicon-assignment/internal/interfaces/controller/migrations"
+	notificationController "Aicon-assignment/internal/interfaces/controller/notifications"
+	organizationController "Aicon-assignment/internal/interfaces/controller/organizations"
+	portfolioController "Aicon-assignment/internal/interfaces/controller/portfolios"
+	preferenceController "Aicon-assignment/internal/interfaces/controller/preferences"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	reportController "Aicon-assignment/internal/interfaces/controller/reports"
 	"Aicon-assignment/internal/interfaces/controller/system"
+	tagController "Aicon-assignment/internal/interfaces/controller/tags"
 	itemDatabase "Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/interfaces/rpc"
+	"Aicon-assignment/internal/interfaces/rpc/itemv1"
 	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/web"
 )
 
+// APIのバージョン（api/openapi.yaml の info.version と合わせる）
+const apiVersion = "1.0.0"
+
+// 有効な機能の一覧（/capabilities と UI に公開する）
+func enabledFeatures() []string {
+	features := []string{"audit", "auth", "auth.api_keys", "auth.api_usage", "consignments", "digest", "docs", "idempotency", "invoices", "items.bulk_recategorize", "items.brand_summary", "items.certificate", "items.comments", "items.drafts", "items.export", "items.export.accounting", "items.import", "items.filter", "items.images", "items.memos", "items.price_history", "items.thumbnails", "items.sort", "items.search", "items.top", "items.status", "items.sales", "items.valuations", "items.tags", "items.naming", "items.quick_stats", "catalog", "categories", "jobs", "jobs.events", "notifications", "organizations", "reports", "reports.events", "reports.purchases", "reports.value_change", "stats", "summary.portfolio"}
+	if config.PortfolioEnabled {
+		features = append(features, "portfolio")
+	}
+	if config.ChallengeProvider != "" {
+		features = append(features, "challenge")
+	}
+	if config.ExchangeRateAPIURL != "" {
+		features = append(features, "currency_conversion")
+	}
+	if config.MarketPriceAPIURL != "" {
+		features = append(features, "items.market_prices")
+	}
+	if config.OCRAPIURL != "" {
+		features = append(features, "items.search.documents")
+	}
+	if config.GRPCAddr != "" {
+		features = append(features, "grpc")
+	}
+	return features
+}
+
 // サーバー用の構造体
 type Server struct{}
 
@@ -27,41 +104,791 @@ func NewServer() *Server {
 // サーバー起動
 func (s *Server) Run(ctx context.Context) error {
 	e := echo.New()
+	// 存在しないルートなど echo が返すエラーも problem+json で返す
+	e.HTTPErrorHandler = problem.HTTPErrorHandler
+
+	// リクエストID（監査ログと問い合わせの照合に使う）
+	e.Use(requestIDMiddleware())
+
+	// Accept-Language による表示言語（カテゴリー名を英語で返す）
+	e.Use(languageMiddleware())
+
+	// リードレプリカを使う場合は、書き込んだクライアントの読み込みをプライマリに送る
+	if config.DBReplicaHost != "" {
+		e.Use(consistencyMiddleware())
+	}
+
+	// インタラクティブ/バッチのレーン制御
+	e.Use(laneMiddleware(
+		lane.NewClassifier(config.BatchPathPrefixes),
+		lane.NewLimiter(config.InteractiveMaxConcurrency, config.BatchMaxConcurrency, config.LaneWaitTimeout),
+	))
+
+	// OpenAPI仕様によるリクエスト検証
+	openAPIRouter, err := newOpenAPIRouter(api.Spec)
+	if err != nil {
+		return err
+	}
+
+	// 非推奨にした API の利用を告知・記録する（検証で 400 になるリクエストにもヘッダーを付ける）
+	deprecations, err := newDeprecations(api.Spec)
+	if err != nil {
+		return err
+	}
+	e.Use(deprecationMiddleware(openAPIRouter, deprecations, deprecation.NewTracker(config.DeprecationLogInterval, log.Printf)))
+	e.Use(openAPIValidationMiddleware(openAPIRouter))
+
+	// 公開エンドポイントの不正利用対策（提供元を設定した場合のみ、条件を超えたクライアントにチャレンジを求める）
+	verifier, err := challenge.New(challenge.Config{
+		Provider:  config.ChallengeProvider,
+		SiteKey:   config.ChallengeSiteKey,
+		Secret:    config.ChallengeSecret,
+		VerifyURL: config.ChallengeVerifyURL,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid challenge configuration: %w", err)
+	}
+	if verifier != nil {
+		rules, err := challenge.ParseRules(config.ChallengeRoutes)
+		if err != nil {
+			return fmt.Errorf("invalid challenge configuration: CHALLENGE_ROUTES: %w", err)
+		}
+		e.Use(challengeMiddleware(verifier, challenge.NewGuard(rules, config.ChallengePassTTL)))
+	}
+
+	// JWTの設定値を検証
+	tokenIssuer := authInfra.NewJWTIssuer(config.JWTSecret, config.JWTTTL)
+	if err := tokenIssuer.Validate(); err != nil {
+		return fmt.Errorf("invalid auth configuration: %w", err)
+	}
+
+	// アイテムの ID の採番方法を検証
+	idGenerator, err := idgen.New(idgen.Config{
+		Strategy: config.IDGenerator,
+		NodeID:   int64(config.IDNodeID),
+	})
+	if err != nil {
+		return fmt.Errorf("invalid id generator configuration: %w", err)
+	}
+
+	// 集計やエクスポートの金額を表示通貨に換算する為替レート API（未設定の場合は円建てのみ合計する）
+	var currencyConverter usecase.CurrencyConverter
+	if config.ExchangeRateAPIURL != "" {
+		client, err := exchangerate.NewClient(config.ExchangeRateAPIURL, config.ExchangeRateCacheTTL)
+		if err != nil {
+			return fmt.Errorf("invalid exchange rate configuration: %w", err)
+		}
+		currencyConverter = client
+	}
+
+	// アイテムの評価額を更新する相場 API（未設定の場合は相場での更新を受け付けない）
+	var marketPriceProvider usecase.MarketPriceProvider
+	if config.MarketPriceAPIURL != "" {
+		client, err := marketprice.NewClient(config.MarketPriceAPIURL, config.MarketPriceAPIKey)
+		if err != nil {
+			return fmt.Errorf("invalid market price configuration: %w", err)
+		}
+		marketPriceProvider = client
+	}
+
+	// 購入書類の画像の文字を認識する OCR API（未設定の場合は書類のテキストを検索しない）
+	var textRecognizer usecase.TextRecognizer
+	if config.OCRAPIURL != "" {
+		client, err := ocr.NewClient(config.OCRAPIURL, config.OCRAPIKey)
+		if err != nil {
+			return fmt.Errorf("invalid ocr configuration: %w", err)
+		}
+		textRecognizer = client
+	}
+
+	// 型番から登録内容を補完するカタログ（CATALOG_PATH の指定がない場合は同梱のカタログ）
+	itemCatalog, err := catalog.NewEmbedded()
+	if config.CatalogPath != "" {
+		itemCatalog, err = catalog.Open(config.CatalogPath)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid catalog: %w", err)
+	}
+
+	// アイテムの名前・ブランドの最大文字数を設定
+	if config.ItemNameMaxLength <= 0 || config.ItemBrandMaxLength <= 0 {
+		return fmt.Errorf("invalid item length configuration: ITEM_NAME_MAX_LENGTH and ITEM_BRAND_MAX_LENGTH must be positive")
+	}
+	entity.ItemNameMaxLength = config.ItemNameMaxLength
+	entity.ItemBrandMaxLength = config.ItemBrandMaxLength
+
+	// 購入日の方針を設定
+	if config.PurchaseDateMaxAgeYears < 0 {
+		return fmt.Errorf("invalid purchase date configuration: PURCHASE_DATE_MAX_AGE_YEARS must be 0 or greater")
+	}
+	entity.PurchaseDateAllowFuture = config.PurchaseDateAllowFuture
+	entity.PurchaseDateMaxAgeYears = config.PurchaseDateMaxAgeYears
+
+	// 組織での役割ごとに非表示にするアイテムの項目を設定
+	redaction, err := entity.ParseItemRedaction(config.ItemRedactedFields)
+	if err != nil {
+		return fmt.Errorf("invalid item redaction configuration: ITEM_REDACTED_FIELDS: %w", err)
+	}
+	entity.RedactedItemFields = redaction
+
+	// 列の移行の段階を設定
+	migrationPhases, err := entity.ParseColumnMigrationPhases(config.ColumnMigrations)
+	if err != nil {
+		return fmt.Errorf("invalid column migration configuration: COLUMN_MIGRATIONS: %w", err)
+	}
+	for _, m := range entity.ColumnMigrations {
+		if phase, ok := migrationPhases[m.Name]; ok {
+			m.SetPhase(phase)
+		}
+	}
+	publishColumnMigrationStats()
 
 	// 依存性注入
 	dbHandler := databaseInfra.NewSqlHandler()
 	defer dbHandler.Close()
 
 	itemRepo := &itemDatabase.ItemRepository{
+		SqlHandler:        dbHandler,
+		JapaneseCollation: config.DBJapaneseCollation,
+		IDs:               idGenerator,
+	}
+
+	userRepo := &itemDatabase.UserRepository{
+		SqlHandler: dbHandler,
+	}
+
+	apiKeyRepo := &itemDatabase.APIKeyRepository{
+		SqlHandler: dbHandler,
+	}
+
+	itemImageRepo := &itemDatabase.ItemImageRepository{
+		SqlHandler: dbHandler,
+	}
+
+	fileStorage, err := storage.New(storage.Config{
+		Driver: config.StorageDriver,
+		Dir:    config.StorageDir,
+		S3: storage.S3Config{
+			Endpoint:        config.S3Endpoint,
+			Region:          config.S3Region,
+			Bucket:          config.S3Bucket,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			Prefix:          config.S3Prefix,
+			PathStyle:       config.S3PathStyle,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	portfolioRepo := &itemDatabase.PortfolioViewRepository{
 		SqlHandler: dbHandler,
 	}
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	commentRepo := &itemDatabase.CommentRepository{
+		SqlHandler: dbHandler,
+	}
+
+	itemMemoRepo := &itemDatabase.ItemMemoRepository{
+		SqlHandler: dbHandler,
+	}
+
+	tagRepo := &itemDatabase.TagRepository{
+		SqlHandler: dbHandler,
+	}
+
+	categoryRepo := &itemDatabase.CategoryRepository{
+		SqlHandler: dbHandler,
+	}
+
+	itemHistoryRepo := &itemDatabase.ItemHistoryRepository{
+		SqlHandler: dbHandler,
+		IDs:        idGenerator,
+	}
+
+	itemViewRepo := &itemDatabase.ItemViewRepository{
+		SqlHandler: dbHandler,
+	}
+
+	notificationRepo := &itemDatabase.NotificationRepository{
+		SqlHandler: dbHandler,
+	}
+
+	orgRepo := &itemDatabase.OrganizationRepository{
+		SqlHandler: dbHandler,
+	}
+
+	consignmentRepo := &itemDatabase.ConsignmentRepository{
+		SqlHandler: dbHandler,
+	}
+
+	itemSaleRepo := &itemDatabase.ItemSaleRepository{
+		SqlHandler: dbHandler,
+	}
+
+	itemValuationRepo := &itemDatabase.ItemValuationRepository{
+		SqlHandler: dbHandler,
+	}
 
-	systemHandler := system.NewSystemHandler()
+	documentTextRepo := &itemDatabase.DocumentTextRepository{
+		SqlHandler: dbHandler,
+	}
+
+	invoiceRepo := &itemDatabase.InvoiceRepository{
+		SqlHandler: dbHandler,
+	}
+
+	backupRepo := &itemDatabase.BackupRepository{
+		SqlHandler: dbHandler,
+	}
+
+	digestSubscriptionRepo := &itemDatabase.DigestSubscriptionRepository{
+		SqlHandler: dbHandler,
+	}
+
+	auditLogRepo := &itemDatabase.AuditLogRepository{
+		SqlHandler: dbHandler,
+	}
+
+	apiKeyUsageRepo := &itemDatabase.APIKeyUsageRepository{
+		SqlHandler: dbHandler,
+	}
+
+	adminReportRepo := &itemDatabase.AdminReportRepository{
+		SqlHandler: dbHandler,
+	}
+
+	columnMigrationRepo := &itemDatabase.ColumnMigrationRepository{
+		SqlHandler: dbHandler,
+	}
+
+	idempotencyRepo := &itemDatabase.IdempotencyRepository{
+		SqlHandler: dbHandler,
+	}
+
+	draftSessionRepo := &itemDatabase.DraftSessionRepository{
+		SqlHandler: dbHandler,
+	}
+
+	mailer, err := mail.New(mail.Config{
+		Driver: config.MailDriver,
+		From:   config.MailFrom,
+		SMTP: mail.SMTPConfig{
+			Host:     config.SMTPHost,
+			Port:     config.SMTPPort,
+			Username: config.SMTPUsername,
+			Password: config.SMTPPassword,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	summaryStats := usecase.NewCoalescingStats()
+	publishCoalescingStats(summaryStats)
+	canaryPercents, err := usecase.ParseCanaries(config.Canaries)
+	if err != nil {
+		return fmt.Errorf("invalid canary configuration: CANARIES: %w", err)
+	}
+	canaries := usecase.NewCanaries(canaryPercents)
+	publishCanaryStats(canaries)
+	itemOptions := []usecase.ItemUsecaseOption{usecase.WithItemImages(itemImageRepo, fileStorage), usecase.WithItemHistory(itemHistoryRepo), usecase.WithItemSales(itemSaleRepo), usecase.WithItemValuations(itemValuationRepo), usecase.WithRecentlyViewed(itemViewRepo), usecase.WithTags(tagRepo), usecase.WithCurrencyConverter(currencyConverter), usecase.WithCoalescingStats(summaryStats), usecase.WithCanaries(canaries), usecase.WithLastWrite(lastWrite)}
+	itemImageOptions := []usecase.ItemImageUsecaseOption{usecase.WithThumbnails(thumbnail.NewGenerator())}
+	if textRecognizer != nil {
+		itemOptions = append(itemOptions, usecase.WithDocumentSearch(documentTextRepo))
+		itemImageOptions = append(itemImageOptions, usecase.WithDocumentText(textRecognizer, documentTextRepo))
+	}
+	itemUsecase := usecase.NewItemUsecase(itemRepo, itemOptions...)
+	priceHistoryUsecase := usecase.NewPriceHistoryUsecase(itemRepo, itemHistoryRepo, consignmentRepo)
+	itemImageUsecase := usecase.NewItemImageUsecase(itemRepo, itemImageRepo, fileStorage, itemImageOptions...)
+	portfolioUsecase := usecase.NewPortfolioUsecase(portfolioRepo, itemRepo)
+	commentUsecase := usecase.NewCommentUsecase(commentRepo, notificationRepo, itemRepo, userRepo)
+	itemMemoUsecase := usecase.NewItemMemoUsecase(itemMemoRepo, itemRepo)
+	tagUsecase := usecase.NewTagUsecase(tagRepo, itemRepo)
+	categoryUsecase := usecase.NewCategoryUsecase(categoryRepo)
+	// アイテムの検証に categories テーブルのカテゴリーを使う
+	if err := categoryUsecase.Load(ctx); err != nil {
+		return err
+	}
+	catalogUsecase := usecase.NewCatalogUsecase(itemCatalog)
+	notificationUsecase := usecase.NewNotificationUsecase(notificationRepo)
+	orgUsecase := usecase.NewOrganizationUsecase(orgRepo, userRepo)
+	jobUsecase := usecase.NewJobUsecase(usecase.WithJobRetention(config.JobRetention, config.JobMaxFinishedPerUser))
+	consignmentUsecase := usecase.NewConsignmentUsecase(consignmentRepo, itemRepo, usecase.WithInvoices(invoiceRepo), usecase.WithReportJobs(jobUsecase))
+	invoiceUsecase := usecase.NewInvoiceUsecase(invoiceRepo, pdf.NewInvoiceRenderer())
+	backupUsecase := usecase.NewBackupUsecase(backupRepo, fileStorage)
+	backupVerificationUsecase := usecase.NewBackupVerificationUsecase(backupRepo, fileStorage, config.BackupVerifySchema)
+	publishBackupVerificationStats(backupVerificationUsecase)
+	exportUsecase := usecase.NewExportUsecase(itemRepo, map[usecase.ExportFormat]usecase.ExportRenderer{
+		usecase.ExportFormatXLSX: xlsx.NewItemExportRenderer(),
+	}, usecase.WithExportJobs(jobUsecase), usecase.WithExportCurrencyConverter(currencyConverter), usecase.WithAccountingExport(jobUsecase, invoiceRepo, map[usecase.AccountingFormat]usecase.JournalRenderer{
+		usecase.AccountingFormatFreee:      accounting.NewFreeeRenderer(),
+		usecase.AccountingFormatYayoi:      accounting.NewYayoiRenderer(),
+		usecase.AccountingFormatQuickBooks: accounting.NewQuickBooksRenderer(),
+	}))
+	importUsecase := usecase.NewItemImportUsecase(itemRepo, usecase.WithImportHistory(itemHistoryRepo), usecase.WithImportJobs(jobUsecase))
+	authUsecase := usecase.NewAuthUsecase(userRepo, tokenIssuer)
+	preferenceUsecase := usecase.NewUserPreferenceUsecase(userRepo)
+	apiKeyUsecase := usecase.NewAPIKeyUsecase(apiKeyRepo, userRepo)
+	certificateUsecase := usecase.NewCertificateUsecase(
+		itemRepo,
+		pdf.NewCertificateRenderer(),
+		config.CertificateSecret,
+		config.PublicBaseURL+"/certificates/verify",
+	)
+	digestUsecase := usecase.NewDigestUsecase(
+		digestSubscriptionRepo,
+		userRepo,
+		itemRepo,
+		consignmentRepo,
+		mailer,
+		mail.NewDigestRenderer(),
+		config.DigestSecret,
+		config.PublicBaseURL+"/digest/unsubscribe",
+		usecase.WithDigestCurrencyConverter(currencyConverter),
+	)
+
+	draftSessionUsecase := usecase.NewDraftSessionUsecase(draftSessionRepo, fileStorage, itemUsecase, itemImageUsecase, config.ItemDraftTTL)
+
+	marketPriceUsecase := usecase.NewMarketPriceUsecase(itemRepo, itemValuationRepo, marketPriceProvider, jobUsecase)
+
+	idempotencyUsecase := usecase.NewIdempotencyUsecase(idempotencyRepo)
+	auditUsecase := usecase.NewAuditUsecase(auditLogRepo)
+	adminReportUsecase := usecase.NewAdminReportUsecase(adminReportRepo)
+	columnMigrationUsecase := usecase.NewColumnMigrationUsecase(columnMigrationRepo, jobUsecase, config.ColumnBackfillPause)
+	eventExportUsecase := usecase.NewEventExportUsecase(itemHistoryRepo, itemRepo)
+
+	// 監査ログの非同期保存（DB接続を閉じる前に残りを保存する）
+	auditCtx, stopAudit := context.WithCancel(ctx)
+	auditDone := make(chan struct{})
+	go func() {
+		defer close(auditDone)
+		auditUsecase.Run(auditCtx)
+	}()
+	defer func() {
+		stopAudit()
+		<-auditDone
+	}()
+	e.Use(auditMiddleware(auditUsecase))
+
+	// APIキーごとの利用状況の非同期保存（DB接続を閉じる前に残りを保存する）
+	apiUsageUsecase := usecase.NewAPIUsageUsecase(apiKeyUsageRepo, apiKeyRepo, config.APIUsageFlushInterval)
+	apiUsageCtx, stopAPIUsage := context.WithCancel(ctx)
+	apiUsageDone := make(chan struct{})
+	go func() {
+		defer close(apiUsageDone)
+		apiUsageUsecase.Run(apiUsageCtx)
+	}()
+	defer func() {
+		stopAPIUsage()
+		<-apiUsageDone
+	}()
+	e.Pre(apiUsageMiddleware(apiUsageUsecase))
+
+	capabilities := system.Capabilities{
+		Version:  apiVersion,
+		Features: enabledFeatures(),
+	}
+	systemHandler := system.NewSystemHandler(capabilities)
 	itemHandler := itemController.NewItemHandler(itemUsecase)
+	exportHandler := itemController.NewExportHandler(exportUsecase, jobUsecase)
+	importHandler := itemController.NewImportHandler(importUsecase, int64(config.ImportMaxBytes))
+	marketPriceHandler := itemController.NewMarketPriceHandler(marketPriceUsecase)
+	draftSessionHandler := itemController.NewDraftSessionHandler(draftSessionUsecase)
+	priceHistoryHandler := itemController.NewPriceHistoryHandler(priceHistoryUsecase)
+	jobHandler := jobController.NewJobHandler(jobUsecase)
+	authHandler := authController.NewAuthHandler(authUsecase, apiKeyUsecase)
+	apiUsageHandler := authController.NewAPIUsageHandler(apiUsageUsecase)
+	certificateHandler := certificateController.NewCertificateHandler(certificateUsecase)
+	imageHandler := imageController.NewImageHandler(itemImageUsecase)
+	portfolioHandler := portfolioController.NewPortfolioHandler(portfolioUsecase)
+	commentHandler := commentController.NewCommentHandler(commentUsecase)
+	itemMemoHandler := memoController.NewItemMemoHandler(itemMemoUsecase)
+	tagHandler := tagController.NewTagHandler(tagUsecase)
+	categoryHandler := categoryController.NewCategoryHandler(categoryUsecase)
+	catalogHandler := catalogController.NewCatalogHandler(catalogUsecase)
+	notificationHandler := notificationController.NewNotificationHandler(notificationUsecase)
+	orgHandler := organizationController.NewOrganizationHandler(orgUsecase)
+	consignmentHandler := consignmentController.NewConsignmentHandler(consignmentUsecase, jobUsecase)
+	invoiceHandler := invoiceController.NewInvoiceHandler(invoiceUsecase)
+	backupHandler := backupController.NewBackupHandler(backupUsecase, backupVerificationUsecase)
+	digestHandler := digestController.NewDigestHandler(digestUsecase)
+	preferenceHandler := preferenceController.NewPreferenceHandler(preferenceUsecase)
+	auditHandler := auditController.NewAuditHandler(auditUsecase)
+	adminReportHandler := reportController.NewAdminReportHandler(adminReportUsecase)
+	columnMigrationHandler := migrationController.NewColumnMigrationHandler(columnMigrationUsecase)
+	eventHandler := eventController.NewEventHandler(eventExportUsecase)
 
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
 		systemHandler.Health(c)
 		return nil
 	})
+	e.GET("/capabilities", systemHandler.Capabilities)
+
+	// 認証に関するエンドポイント
+	authGroup := e.Group("/auth")
+	{
+		authGroup.POST("/register", authHandler.Register) // POST /auth/register
+		authGroup.POST("/login", authHandler.Login)       // POST /auth/login
+	}
+
+	// APIキーに関するエンドポイント（要認証）
+	apiKeysGroup := e.Group("/auth/api-keys", authHandler.RequireAuth)
+	{
+		apiKeysGroup.GET("", authHandler.ListAPIKeys)         // GET /auth/api-keys
+		apiKeysGroup.POST("", authHandler.CreateAPIKey)       // POST /auth/api-keys
+		apiKeysGroup.DELETE("/:id", authHandler.DeleteAPIKey) // DELETE /auth/api-keys/{id}
+	}
+
+	// 再送されたリクエストで重複して作成しないよう、Idempotency-Key を受け付ける
+	idempotent := idempotencyMiddleware(idempotencyUsecase)
+
+	// アイテムに関するエンドポイント（要認証）
+	itemsGroup := e.Group("/items", authHandler.RequireAuth)
+	{
+		itemsGroup.GET("", itemHandler.GetItems)                                        // GET /items
+		itemsGroup.POST("", itemHandler.CreateItem, idempotent)                         // POST /items
+		itemsGroup.GET("/:id", itemHandler.GetItem)                                     // GET /items/{id}
+		itemsGroup.PUT("/:id", itemHandler.ReplaceItem)                                 // PUT /items/{id}
+		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)                                // PATCH /items/{id}
+		itemsGroup.PATCH("/bulk", itemHandler.BulkUpdateItems, idempotent)              // PATCH /items/bulk
+		itemsGroup.POST("/bulk-recategorize", itemHandler.BulkRecategorize, idempotent) // POST /items/bulk-recategorize
+		itemsGroup.PATCH("/:id/status", itemHandler.ChangeItemStatus)                   // PATCH /items/{id}/status
+		itemsGroup.GET("/:id/sale", itemHandler.GetSale)                                // GET /items/{id}/sale
+		itemsGroup.POST("/:id/sale", itemHandler.RecordSale, idempotent)                // POST /items/{id}/sale
+		itemsGroup.GET("/:id/valuations", itemHandler.GetValuations)                    // GET /items/{id}/valuations
+		itemsGroup.POST("/:id/valuations", itemHandler.RecordValuation, idempotent)     // POST /items/{id}/valuations
+		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)                               // DELETE /items/{id}
+		itemsGroup.GET("/:id/history", itemHandler.GetItemHistory)                      // GET /items/{id}/history
+		itemsGroup.GET("/:id/price-history", priceHistoryHandler.GetPriceHistory)       // GET /items/{id}/price-history
+		itemsGroup.GET("/summary", itemHandler.GetSummary)                              // GET /items/summary (bonus)
+		itemsGroup.GET("/summary/brands", itemHandler.GetBrandSummary)                  // GET /items/summary/brands?limit=
+		itemsGroup.GET("/top", itemHandler.GetTopItems)                                 // GET /items/top?n=10&by=purchase_price
+		itemsGroup.GET("/search", itemHandler.SearchItems)                              // GET /items/search?q=...
+		itemsGroup.GET("/export", exportHandler.ExportItems)                            // GET /items/export?format=xlsx（バッチ処理のレーン）
+		itemsGroup.POST("/import", importHandler.ImportItems)                           // POST /items/import（CSV。バッチ処理のレーン）
+		itemsGroup.POST("/quick", itemHandler.PreviewQuickAdd)                          // POST /items/quick
+		itemsGroup.POST("/parse", itemHandler.ParseItem)                                // POST /items/parse
+	}
+
+	// 会計ソフト向けの仕訳（要認証。ジョブで作成し GET /jobs/{id}/result でダウンロードする）
+	e.POST("/items/export/accounting", exportHandler.StartAccountingExport, authHandler.RequireAuth) // POST /items/export/accounting
+
+	// 相場での評価額の更新（要認証。ジョブで更新し GET /jobs/{id}/result で件数を確認する）
+	e.POST("/items/market-prices/refresh", marketPriceHandler.RefreshMarketPrices, authHandler.RequireAuth) // POST /items/market-prices/refresh
+
+	// アイテムの登録の下書き（要認証。複数の画面に分けて入力し、最後に登録する）
+	draftsGroup := e.Group("/items/drafts", authHandler.RequireAuth)
+	{
+		draftsGroup.POST("", draftSessionHandler.CreateDraft)                                 // POST /items/drafts
+		draftsGroup.GET("/:draftId", draftSessionHandler.GetDraft)                            // GET /items/drafts/{draftId}
+		draftsGroup.PATCH("/:draftId", draftSessionHandler.UpdateDraft)                       // PATCH /items/drafts/{draftId}
+		draftsGroup.DELETE("/:draftId", draftSessionHandler.DeleteDraft)                      // DELETE /items/drafts/{draftId}
+		draftsGroup.POST("/:draftId/images", draftSessionHandler.UploadDraftImage)            // POST /items/drafts/{draftId}/images
+		draftsGroup.GET("/:draftId/images/:imageId", draftSessionHandler.GetDraftImage)       // GET /items/drafts/{draftId}/images/{imageId}
+		draftsGroup.DELETE("/:draftId/images/:imageId", draftSessionHandler.DeleteDraftImage) // DELETE /items/drafts/{draftId}/images/{imageId}
+		draftsGroup.POST("/:draftId/commit", draftSessionHandler.CommitDraft, idempotent)     // POST /items/drafts/{draftId}/commit
+	}
+
+	// アイテムの画像（要認証）
+	imagesGroup := e.Group("/items/:id/images", authHandler.RequireAuth)
+	{
+		imagesGroup.GET("", imageHandler.ListImages)                              // GET /items/{id}/images
+		imagesGroup.POST("", imageHandler.UploadImage)                            // POST /items/{id}/images
+		imagesGroup.GET("/:imageId", imageHandler.GetImage)                       // GET /items/{id}/images/{imageId}
+		imagesGroup.DELETE("/:imageId", imageHandler.DeleteImage)                 // DELETE /items/{id}/images/{imageId}
+		imagesGroup.GET("/:imageId/thumbnails/:width", imageHandler.GetThumbnail) // GET /items/{id}/images/{imageId}/thumbnails/{width}
+	}
+
+	// アイテムのコメント（要認証）
+	commentsGroup := e.Group("/items/:id/comments", authHandler.RequireAuth)
+	{
+		commentsGroup.GET("", commentHandler.ListComments)                // GET /items/{id}/comments
+		commentsGroup.POST("", commentHandler.CreateComment)              // POST /items/{id}/comments
+		commentsGroup.PATCH("/:commentId", commentHandler.UpdateComment)  // PATCH /items/{id}/comments/{commentId}
+		commentsGroup.DELETE("/:commentId", commentHandler.DeleteComment) // DELETE /items/{id}/comments/{commentId}
+	}
+
+	// アイテムの自分用のメモ（要認証。書いたユーザーにだけ新しい順に返す）
+	memosGroup := e.Group("/items/:id/memos", authHandler.RequireAuth)
+	{
+		memosGroup.GET("", itemMemoHandler.ListMemos)   // GET /items/{id}/memos
+		memosGroup.POST("", itemMemoHandler.CreateMemo) // POST /items/{id}/memos
+	}
+
+	// アイテムのタグ（要認証。付け外しはアイテムを変更できるユーザーのみ）
+	e.GET("/tags", tagHandler.ListTags, authHandler.RequireAuth) // GET /tags
+	tagsGroup := e.Group("/items/:id/tags", authHandler.RequireAuth)
+	{
+		tagsGroup.POST("", tagHandler.AddItemTag)            // POST /items/{id}/tags
+		tagsGroup.DELETE("/:name", tagHandler.RemoveItemTag) // DELETE /items/{id}/tags/{name}
+	}
+
+	// アイテムのカテゴリー（要認証。変更は管理者のみ）
+	categoriesGroup := e.Group("/categories", authHandler.RequireAuth)
+	{
+		categoriesGroup.GET("", categoryHandler.ListCategories)        // GET /categories
+		categoriesGroup.POST("", categoryHandler.CreateCategory)       // POST /categories
+		categoriesGroup.PATCH("/:id", categoryHandler.UpdateCategory)  // PATCH /categories/{id}
+		categoriesGroup.DELETE("/:id", categoryHandler.DeleteCategory) // DELETE /categories/{id}
+	}
+
+	// 型番から登録内容を補完するカタログ（要認証）
+	e.GET("/catalog/lookup", catalogHandler.LookupCatalog, authHandler.RequireAuth) // GET /catalog/lookup
+
+	// 最近表示したアイテム（要認証。アイテムの詳細を表示すると記録される）
+	e.GET("/me/recently-viewed", itemHandler.GetRecentlyViewedItems, authHandler.RequireAuth) // GET /me/recently-viewed
+	e.GET("/me/preferences", preferenceHandler.GetPreferences, authHandler.RequireAuth)       // GET /me/preferences
+	e.PUT("/me/preferences", preferenceHandler.UpdatePreferences, authHandler.RequireAuth)    // PUT /me/preferences
+
+	// 自分のAPIキーごとの直近30日の利用状況（要認証。X-API-Key を送ったリクエストを数える）
+	e.GET("/me/api-usage", apiUsageHandler.GetAPIUsage, authHandler.RequireAuth) // GET /me/api-usage
+
+	// ヘッダー表示用の件数と合計（要認証。最大 30 秒前のカテゴリー集計を返す）
+	e.GET("/me/quickstats", itemHandler.GetQuickStats, authHandler.RequireAuth) // GET /me/quickstats
+
+	// ホーム画面用の保有アイテムの合計（要認証）
+	e.GET("/summary/portfolio", itemHandler.GetPortfolioSummary, authHandler.RequireAuth) // GET /summary/portfolio
+
+	// モバイルのダッシュボード用の集計（要認証。カテゴリー・ブランド・月ごとの購入・高額なアイテムを1回で返す）
+	e.GET("/stats", itemHandler.GetDashboardStats, authHandler.RequireAuth) // GET /stats
+
+	// 通知（要認証）
+	notificationsGroup := e.Group("/notifications", authHandler.RequireAuth)
+	{
+		notificationsGroup.GET("", notificationHandler.ListNotifications)              // GET /notifications
+		notificationsGroup.POST("/:id/read", notificationHandler.MarkNotificationRead) // POST /notifications/{id}/read
+	}
+
+	// 委託品（要認証。集計は /reports 以下のためバッチ処理のレーンで実行する）
+	consignmentGroup := e.Group("/items/:id/consignment", authHandler.RequireAuth)
+	{
+		consignmentGroup.GET("", consignmentHandler.GetConsignment)       // GET /items/{id}/consignment
+		consignmentGroup.PUT("", consignmentHandler.PutConsignment)       // PUT /items/{id}/consignment
+		consignmentGroup.DELETE("", consignmentHandler.DeleteConsignment) // DELETE /items/{id}/consignment
+	}
+	e.GET("/consignments", consignmentHandler.ListConsignments, authHandler.RequireAuth)  // GET /consignments
+	e.GET("/reports/consignments", consignmentHandler.GetReport, authHandler.RequireAuth) // GET /reports/consignments
+
+	// 名前の表記ゆれの候補と一括適用（要認証。/reports 以下のためバッチ処理のレーンで実行する）
+	namingGroup := e.Group("/reports/naming-suggestions", authHandler.RequireAuth)
+	{
+		namingGroup.GET("", itemHandler.GetNamingSuggestions)           // GET /reports/naming-suggestions
+		namingGroup.POST("/apply", itemHandler.ApplyNaming, idempotent) // POST /reports/naming-suggestions/apply
+	}
+
+	// 購入価格と評価額の比較（要認証。/reports 以下のためバッチ処理のレーンで実行する）
+	e.GET("/reports/value-change", itemHandler.GetValueChangeReport, authHandler.RequireAuth) // GET /reports/value-change
+
+	// 購入の推移（要認証。/reports 以下のためバッチ処理のレーンで実行する）
+	e.GET("/reports/purchases", itemHandler.GetPurchaseTrend, authHandler.RequireAuth) // GET /reports/purchases?group_by=month|year
 
-	// アイテムに関するエンドポイント
-	itemsGroup := e.Group("/items")
+	// 請求書（要認証。発行は委託品を販売済みにするときに行う）
+	invoicesGroup := e.Group("/invoices", authHandler.RequireAuth)
 	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)   // PATCH /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
+		invoicesGroup.GET("", invoiceHandler.ListInvoices)                  // GET /invoices
+		invoicesGroup.GET("/:id", invoiceHandler.GetInvoice)                // GET /invoices/{id}
+		invoicesGroup.GET("/:id/invoice.pdf", invoiceHandler.GetInvoicePDF) // GET /invoices/{id}/invoice.pdf
 	}
 
-	return s.startWithGracefulShutdown(ctx, e)
+	// バックアップと読み込み（要認証。管理者のみ。/admin/backup と /admin/restore は BATCH_PATH_PREFIXES の既定値に含まれる）
+	e.GET("/admin/backup", backupHandler.GetBackup, authHandler.RequireAuth) // GET /admin/backup
+	e.POST("/admin/restore", backupHandler.Restore, authHandler.RequireAuth) // POST /admin/restore
+	// バックアップの検証の回数と最後の結果（要認証。管理者のみ）
+	e.GET("/admin/backup/verification", backupHandler.GetVerification, authHandler.RequireAuth) // GET /admin/backup/verification
+
+	// ダイジェストメール（設定は要認証、配信停止はメールのリンクから開かれるため認証不要）
+	digestGroup := e.Group("/digest", authHandler.RequireAuth)
+	{
+		digestGroup.GET("/preferences", digestHandler.GetPreference)    // GET /digest/preferences
+		digestGroup.PUT("/preferences", digestHandler.UpdatePreference) // PUT /digest/preferences
+		digestGroup.GET("/preview", digestHandler.PreviewDigest)        // GET /digest/preview
+	}
+	e.GET("/digest/unsubscribe", digestHandler.Unsubscribe)  // GET /digest/unsubscribe?token=...
+	e.POST("/digest/unsubscribe", digestHandler.Unsubscribe) // POST /digest/unsubscribe?token=...（ワンクリックの配信停止）
+
+	// 組織（要認証）
+	orgsGroup := e.Group("/organizations", authHandler.RequireAuth)
+	{
+		orgsGroup.GET("", orgHandler.ListOrganizations)                   // GET /organizations
+		orgsGroup.POST("", orgHandler.CreateOrganization)                 // POST /organizations
+		orgsGroup.GET("/:id/members", orgHandler.ListMembers)             // GET /organizations/{id}/members
+		orgsGroup.POST("/:id/members", orgHandler.AddMember)              // POST /organizations/{id}/members
+		orgsGroup.DELETE("/:id/members/:userId", orgHandler.RemoveMember) // DELETE /organizations/{id}/members/{userId}
+	}
+
+	// 評価証明書（発行は要認証、検証はQRコードから開かれるため認証不要）
+	e.GET("/items/:id/certificate.pdf", certificateHandler.GetCertificate, authHandler.RequireAuth) // GET /items/{id}/certificate.pdf
+	e.GET("/certificates/verify", certificateHandler.Verify)                                        // GET /certificates/verify?code=...
+
+	// 公開ポートフォリオ（設定の管理は要認証、公開ページはトークンで保護）
+	if config.PortfolioEnabled {
+		portfoliosGroup := e.Group("/portfolios", authHandler.RequireAuth)
+		{
+			portfoliosGroup.GET("", portfolioHandler.ListPortfolios)         // GET /portfolios
+			portfoliosGroup.POST("", portfolioHandler.CreatePortfolio)       // POST /portfolios
+			portfoliosGroup.GET("/:id", portfolioHandler.GetPortfolio)       // GET /portfolios/{id}
+			portfoliosGroup.PATCH("/:id", portfolioHandler.UpdatePortfolio)  // PATCH /portfolios/{id}
+			portfoliosGroup.DELETE("/:id", portfolioHandler.DeletePortfolio) // DELETE /portfolios/{id}
+		}
+		e.GET("/public/portfolios/:token", portfolioHandler.GetPublicPortfolio)          // GET /public/portfolios/{token}
+		e.GET("/public/portfolios/:token/page", portfolioHandler.GetPublicPortfolioPage) // GET /public/portfolios/{token}/page
+	}
+
+	// 監査ログ（要認証。管理者のみ）
+	e.GET("/admin/audit-logs", auditHandler.ListAuditLogs, authHandler.RequireAuth) // GET /admin/audit-logs
+
+	// 定型レポート（要認証。管理者のみ。/admin/reports は BATCH_PATH_PREFIXES の既定値に含まれる）
+	e.GET("/admin/reports/:name", adminReportHandler.RunReport, authHandler.RequireAuth) // GET /admin/reports/{name}
+
+	// 分析用のイベントの書き出し（要認証。管理者のみ。NDJSON で配信する。/admin/events は BATCH_PATH_PREFIXES の既定値に含まれる）
+	e.GET("/admin/events/export", eventHandler.ExportEvents, authHandler.RequireAuth) // GET /admin/events/export?since=
+
+	// 列の移行の状態とバックフィル（要認証。管理者のみ）
+	e.GET("/admin/column-migrations", columnMigrationHandler.ListColumnMigrations, authHandler.RequireAuth)          // GET /admin/column-migrations
+	e.POST("/admin/column-migrations/:name/backfill", columnMigrationHandler.StartBackfill, authHandler.RequireAuth) // POST /admin/column-migrations/{name}/backfill
+
+	// 実行中のメトリクス（要認証。管理者のみ。expvar の JSON。OpenAPI には含めない）
+	e.GET("/debug/vars", echo.WrapHandler(expvar.Handler()), authHandler.RequireAuth, requireAdminMiddleware()) // GET /debug/vars
+
+	// ジョブに関するエンドポイント（要認証）
+	jobsGroup := e.Group("/jobs", authHandler.RequireAuth)
+	{
+		jobsGroup.GET("/:id", jobHandler.GetJob)              // GET /jobs/{id}
+		jobsGroup.GET("/:id/result", jobHandler.GetJobResult) // GET /jobs/{id}/result
+		// 進捗の配信（Server-Sent Events。パスが /events で終わるためレーンの同時実行数の制限を受けない）
+		jobsGroup.GET("/:id/events", jobHandler.StreamJobEvents) // GET /jobs/{id}/events
+	}
+
+	// 簡易UI（index.html と フィンガープリント付きアセット）
+	uiHandler, err := web.NewHandler(web.Config{
+		APIBaseURL: config.APIBaseURL,
+		Features:   capabilities.Features,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build ui: %w", err)
+	}
+	e.GET("/", echo.WrapHandler(uiHandler))
+	e.GET("/index.html", echo.WrapHandler(uiHandler))
+	e.GET(web.AssetPrefix+"*", echo.WrapHandler(uiHandler))
+
+	// APIドキュメント（OpenAPI仕様のJSON と Swagger UI。認証不要。OpenAPI には含めない）
+	docsHandler, err := api.NewDocsHandler(config.APIBaseURL)
+	if err != nil {
+		return fmt.Errorf("failed to build api docs: %w", err)
+	}
+	e.GET(api.JSONPath, echo.WrapHandler(docsHandler)) // GET /openapi.json
+	e.GET(api.DocsPath, echo.WrapHandler(docsHandler)) // GET /docs
+
+	// ダイジェストメールの定期配信
+	if config.DigestInterval > 0 {
+		go runDigestScheduler(ctx, config.DigestInterval, jobUsecase, digestUsecase)
+	}
+
+	// 相場での評価額の定期更新
+	if marketPriceProvider != nil && config.MarketPriceRefreshInterval > 0 {
+		go runMarketPriceScheduler(ctx, config.MarketPriceRefreshInterval, jobUsecase, marketPriceUsecase)
+	}
+
+	// バックアップの検証用のスキーマへの定期的な読み込みと検証
+	if config.BackupVerifySchema != "" && config.BackupVerifyInterval > 0 {
+		go runBackupVerificationScheduler(ctx, config.BackupVerifyInterval, jobUsecase, backupVerificationUsecase)
+	}
+
+	// 保存期間を過ぎた Idempotency-Key の削除
+	go runIdempotencyKeyCleanup(ctx, idempotencyKeyCleanupInterval, idempotencyUsecase)
+
+	// 期限切れのアイテムの下書きの削除
+	go runDraftSessionCleanup(ctx, draftSessionCleanupInterval, draftSessionUsecase)
+
+	// 内部サービス向けの gRPC（REST と同じユースケース。GRPC_ADDR を設定した場合のみ）
+	var grpcServer *grpc.Server
+	if config.GRPCAddr != "" {
+		grpcServer = grpc.NewServer(grpc.ChainUnaryInterceptor(
+			rpc.AuthInterceptor(authUsecase, apiKeyUsecase),
+			rpc.AuditInterceptor(auditUsecase),
+		))
+		itemv1.RegisterItemServiceServer(grpcServer, rpc.NewItemService(itemUsecase))
+	}
+
+	return s.startWithGracefulShutdown(ctx, e, grpcServer)
+}
+
+// lastWrite はリクエストのトークンに記録した最後の書き込みの時刻を返す（トークンがない場合はゼロ値）
+func lastWrite(ctx context.Context) time.Time {
+	if token := consistency.FromContext(ctx); token != nil {
+		return token.WrittenAt()
+	}
+	return time.Time{}
+}
+
+// publishCoalescingStats はカテゴリー集計をまとめた回数を expvar の item_summary_coalescing として公開する
+// （expvar.Publish は同じ名前で2回呼ぶと panic するため、2回目以降は公開する値を差し替える）
+func publishCoalescingStats(stats *usecase.CoalescingStats) {
+	summaryCoalescingStats.Store(stats)
+	publishCoalescingOnce.Do(func() {
+		expvar.Publish("item_summary_coalescing", expvar.Func(func() any {
+			return summaryCoalescingStats.Load().Snapshot()
+		}))
+	})
 }
 
-func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
+var (
+	summaryCoalescingStats atomic.Pointer[usecase.CoalescingStats]
+	publishCoalescingOnce  sync.Once
+)
+
+// publishColumnMigrationStats は列の移行の段階と読み込み時の比較の件数を expvar の column_migrations として公開する
+func publishColumnMigrationStats() {
+	publishColumnMigrationsOnce.Do(func() {
+		expvar.Publish("column_migrations", expvar.Func(func() any {
+			statuses := make([]*entity.ColumnMigrationStatus, 0, len(entity.ColumnMigrations))
+			for _, m := range entity.ColumnMigrations {
+				statuses = append(statuses, m.Status())
+			}
+			return statuses
+		}))
+	})
+}
+
+var publishColumnMigrationsOnce sync.Once
+
+// publishCanaryStats は新しい実装への振り分けと比較の件数を expvar の canaries として公開する
+// （publishCoalescingStats と同じく、2回目以降は公開する値を差し替える）
+func publishCanaryStats(canaries *usecase.Canaries) {
+	canaryStats.Store(canaries)
+	publishCanariesOnce.Do(func() {
+		expvar.Publish("canaries", expvar.Func(func() any {
+			return canaryStats.Load().Snapshot()
+		}))
+	})
+}
+
+var (
+	canaryStats         atomic.Pointer[usecase.Canaries]
+	publishCanariesOnce sync.Once
+)
+
+// publishBackupVerificationStats はバックアップの検証の回数と最後の結果を expvar の backup_verification として公開する
+// （publishCoalescingStats と同じく、2回目以降は公開する値を差し替える）
+func publishBackupVerificationStats(verifications usecase.BackupVerificationUsecase) {
+	backupVerifications.Store(verifications)
+	publishBackupVerificationOnce.Do(func() {
+		expvar.Publish("backup_verification", expvar.Func(func() any {
+			return backupVerifications.Load().(usecase.BackupVerificationUsecase).Snapshot()
+		}))
+	})
+}
+
+var (
+	backupVerifications           atomic.Value
+	publishBackupVerificationOnce sync.Once
+)
+
+// startWithGracefulShutdown は HTTP サーバーと（nil でなければ）gRPC サーバーを起動し、終了時に両方を停止する
+func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo, grpcServer *grpc.Server) error {
 	go func() {
 		port := ":8080"
 		fmt.Printf("🚀 Server starting on port %s\n", port)
@@ -71,6 +898,20 @@ func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) er
 		}
 	}()
 
+	if grpcServer != nil {
+		listener, err := net.Listen("tcp", config.GRPCAddr)
+		if err != nil {
+			return fmt.Errorf("failed to listen for grpc: %w", err)
+		}
+		go func() {
+			fmt.Printf("🚀 gRPC server starting on %s\n", config.GRPCAddr)
+
+			if err := grpcServer.Serve(listener); err != nil {
+				e.Logger.Fatal("gRPC server startup failed:", err)
+			}
+		}()
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt)
 
@@ -84,6 +925,20 @@ func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) er
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	if grpcServer != nil {
+		// 処理中の呼び出しの完了を待つ（HTTP と同じ猶予を過ぎたら打ち切る）
+		stopped := make(chan struct{})
+		go func() {
+			grpcServer.GracefulStop()
+			close(stopped)
+		}()
+		select {
+		case <-stopped:
+		case <-shutdownCtx.Done():
+			grpcServer.Stop()
+		}
+	}
+
 	if err := e.Shutdown(shutdownCtx); err != nil {
 		return fmt.Errorf("server forced to shutdown: %w", err)
 	}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+
+	t.Run("正常系: 保存・取得・削除", func(t *testing.T) {
+		require.NoError(t, s.Put(ctx, "items/1/a.png", strings.NewReader("png")))
+
+		r, err := s.Get(ctx, "items/1/a.png")
+		require.NoError(t, err)
+		body, err := io.ReadAll(r)
+		r.Close()
+		require.NoError(t, err)
+		assert.Equal(t, "png", string(body))
+
+		require.NoError(t, s.Delete(ctx, "items/1/a.png"))
+		_, err = s.Get(ctx, "items/1/a.png")
+		assert.Error(t, err)
+	})
+
+	t.Run("正常系: 存在しないキーの削除はエラーにしない", func(t *testing.T) {
+		assert.NoError(t, s.Delete(ctx, "items/1/missing.png"))
+	})
+
+	t.Run("異常系: ルートの外を指すキー", func(t *testing.T) {
+		for _, key := range []string{"../a.png", "/etc/passwd", "items/../../a.png", `items\..\a.png`, ""} {
+			assert.Error(t, s.Put(ctx, key, strings.NewReader("x")), key)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+// Package storage はアップロードされたファイルの保存先を提供する
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+// 保存先の種類
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// Storage はファイルの保存先。ローカルディスクとS3互換のオブジェクトストレージの実装がある
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+var (
+	_ usecase.FileStorage = Storage(nil)
+	_ Storage             = (*LocalStorage)(nil)
+	_ Storage             = (*S3Storage)(nil)
+)
+
+// Config は保存先の設定。Driver に応じて使われる項目が異なる
+type Config struct {
+	Driver string
+	// local
+	Dir string
+	// s3
+	S3 S3Config
+}
+
+// New は設定に応じた保存先を作成する
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", DriverLocal:
+		return NewLocalStorage(cfg.Dir)
+	case DriverS3:
+		return NewS3Storage(cfg.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %q", cfg.Driver)
+	}
+}
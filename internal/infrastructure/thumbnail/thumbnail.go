@@ -0,0 +1,104 @@
+// Package thumbnail はアップロードされた画像からサムネイルを作成する
+package thumbnail
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // GIF のデコードを登録する（先頭のフレームを使う）
+	"image/jpeg"
+	_ "image/png" // PNG のデコードを登録する
+	"io"
+)
+
+// DefaultQuality はサムネイルのJPEG品質
+const DefaultQuality = 82
+
+// Generator は画像を縮小してJPEGに変換する。
+// 標準ライブラリでデコードできない形式（WebP）は未対応としてエラーを返す
+type Generator struct {
+	Quality int
+}
+
+func NewGenerator() *Generator {
+	return &Generator{Quality: DefaultQuality}
+}
+
+// Generate は src を幅 width に縮小したJPEGを返す（元の画像より大きくはしない）
+func (g *Generator) Generate(src io.Reader, width int) ([]byte, error) {
+	if width <= 0 {
+		return nil, fmt.Errorf("invalid thumbnail width: %d", width)
+	}
+
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return nil, fmt.Errorf("image is empty")
+	}
+	if width > bounds.Dx() {
+		width = bounds.Dx()
+	}
+	height := max(1, bounds.Dy()*width/bounds.Dx())
+
+	// 透過部分は白にする（JPEG は透過を扱えないため）
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resize(flat, width, height), &jpeg.Options{Quality: g.Quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// resize は面積平均法で縮小する。縮小後の各ピクセルに重なる元のピクセルを、重なった面積で重み付けして平均する
+func resize(src *image.RGBA, width, height int) *image.RGBA {
+	srcW, srcH := src.Bounds().Dx(), src.Bounds().Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	if srcW == width && srcH == height {
+		copy(dst.Pix, src.Pix)
+		return dst
+	}
+
+	scaleX := float64(srcW) / float64(width)
+	scaleY := float64(srcH) / float64(height)
+
+	for y := 0; y < height; y++ {
+		y0, y1 := float64(y)*scaleY, float64(y+1)*scaleY
+		for x := 0; x < width; x++ {
+			x0, x1 := float64(x)*scaleX, float64(x+1)*scaleX
+
+			var r, g, b, total float64
+			for sy := int(y0); sy < srcH && float64(sy) < y1; sy++ {
+				wy := overlap(float64(sy), y0, y1)
+				for sx := int(x0); sx < srcW && float64(sx) < x1; sx++ {
+					w := wy * overlap(float64(sx), x0, x1)
+					i := sy*src.Stride + sx*4
+					r += float64(src.Pix[i]) * w
+					g += float64(src.Pix[i+1]) * w
+					b += float64(src.Pix[i+2]) * w
+					total += w
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r/total + 0.5)
+			dst.Pix[i+1] = uint8(g/total + 0.5)
+			dst.Pix[i+2] = uint8(b/total + 0.5)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+	return dst
+}
+
+// overlap はピクセル [p, p+1) と区間 [lo, hi) が重なる長さを返す
+func overlap(p, lo, hi float64) float64 {
+	return min(p+1, hi) - max(p, lo)
+}
@@ -0,0 +1,84 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, img image.Image) *bytes.Reader {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return bytes.NewReader(buf.Bytes())
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	t.Run("正常系: 縦横比を保って縮小する", func(t *testing.T) {
+		src := image.NewRGBA(image.Rect(0, 0, 1000, 500))
+		for y := 0; y < 500; y++ {
+			for x := 0; x < 1000; x++ {
+				src.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+			}
+		}
+
+		out, err := NewGenerator().Generate(encodePNG(t, src), 200)
+		require.NoError(t, err)
+
+		thumb, err := jpeg.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 200, 100), thumb.Bounds())
+		r, g, b, _ := thumb.At(100, 50).RGBA()
+		assert.InDelta(t, 200, r>>8, 4)
+		assert.InDelta(t, 100, g>>8, 4)
+		assert.InDelta(t, 50, b>>8, 4)
+	})
+
+	t.Run("正常系: 元の画像より大きくはしない", func(t *testing.T) {
+		out, err := NewGenerator().Generate(encodePNG(t, image.NewRGBA(image.Rect(0, 0, 120, 80))), 800)
+		require.NoError(t, err)
+
+		thumb, err := jpeg.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 120, 80), thumb.Bounds())
+	})
+
+	t.Run("正常系: 透過部分は白になる", func(t *testing.T) {
+		out, err := NewGenerator().Generate(encodePNG(t, image.NewNRGBA(image.Rect(0, 0, 10, 10))), 10)
+		require.NoError(t, err)
+
+		thumb, err := jpeg.Decode(bytes.NewReader(out))
+		require.NoError(t, err)
+		r, g, b, _ := thumb.At(5, 5).RGBA()
+		assert.Greater(t, r>>8, uint32(250))
+		assert.Greater(t, g>>8, uint32(250))
+		assert.Greater(t, b>>8, uint32(250))
+	})
+
+	t.Run("異常系: デコードできない形式", func(t *testing.T) {
+		_, err := NewGenerator().Generate(strings.NewReader("RIFF....WEBPVP8 "), 200)
+		assert.ErrorContains(t, err, "failed to decode image")
+	})
+}
+
+func TestResize_AveragesPixels(t *testing.T) {
+	// 白黒の縦縞を半分の幅にすると灰色になる
+	src := image.NewRGBA(image.Rect(0, 0, 4, 1))
+	for x := 0; x < 4; x++ {
+		v := uint8(0)
+		if x%2 == 0 {
+			v = 255
+		}
+		src.Set(x, 0, color.RGBA{R: v, G: v, B: v, A: 255})
+	}
+
+	dst := resize(src, 2, 1)
+	assert.Equal(t, color.RGBA{R: 128, G: 128, B: 128, A: 255}, dst.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{R: 128, G: 128, B: 128, A: 255}, dst.RGBAAt(1, 0))
+}
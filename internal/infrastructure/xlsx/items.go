@@ -0,0 +1,153 @@
+package xlsx
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemExportRenderer はアイテムの一覧とカテゴリー別の集計を2シートのブックに出力する
+type ItemExportRenderer struct{}
+
+func NewItemExportRenderer() *ItemExportRenderer {
+	return &ItemExportRenderer{}
+}
+
+const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// 一覧シートの列（換算額の列の見出しには表示通貨を付ける）
+var itemColumns = []string{"ID", "品名", "カテゴリー", "ブランド", "購入価格", "通貨", "換算額", "購入日", "公開範囲", "状態", "シリアル番号", "メモ", "登録日時"}
+
+// 換算額の列（合計行の数式で参照する）
+const valueColumn = 6
+
+func (r *ItemExportRenderer) ContentType() string {
+	return ContentType
+}
+
+func (r *ItemExportRenderer) Render(export *usecase.ItemExport) ([]byte, error) {
+	book := New()
+	renderItems(book.AddSheet("アイテム"), export)
+	renderCategories(book.AddSheet("カテゴリー別"), export)
+	return book.Bytes()
+}
+
+func renderItems(sheet *Sheet, export *usecase.ItemExport) {
+	sheet.SetColumnWidths(8, 36, 12, 20, 14, 8, 16, 12, 10, 14, 18, 40, 18)
+	sheet.FreezeHeader()
+	titles := append([]string{}, itemColumns...)
+	titles[valueColumn] = fmt.Sprintf("換算額（%s）", export.Currency)
+	sheet.AddRow(header(titles)...)
+
+	for _, item := range export.Items {
+		purchaseDate := String(item.PurchaseDate, StyleDefault)
+		if t, err := time.Parse("2006-01-02", item.PurchaseDate); err == nil {
+			purchaseDate = Date(t)
+		}
+		sheet.AddRow(
+			Number(float64(item.ID), StyleDefault),
+			String(item.Name, StyleDefault),
+			String(item.Category, StyleDefault),
+			String(item.Brand, StyleDefault),
+			priceCell(item),
+			String(string(item.PurchasePrice.Currency), StyleDefault),
+			valueCell(export, item.ID),
+			purchaseDate,
+			String(string(item.Visibility), StyleDefault),
+			String(string(item.Condition), StyleDefault),
+			String(item.SerialNumber, StyleDefault),
+			String(item.Notes, StyleDefault),
+			DateTime(item.CreatedAt),
+		)
+	}
+
+	// 合計行（アイテムがない場合も見出しの直後に出力する）
+	total := make([]Cell, len(itemColumns))
+	for i := range total {
+		total[i] = Empty()
+	}
+	total[1] = String(fmt.Sprintf("合計（%d件）", export.Total.Count), StyleBold)
+	total[valueColumn] = sumCell(valueColumn, 2, len(export.Items)+1, major(export.Total.PurchasePrice, export.Currency), amountStyle(export.Currency, true))
+	sheet.AddRow(total...)
+}
+
+func renderCategories(sheet *Sheet, export *usecase.ItemExport) {
+	sheet.SetColumnWidths(16, 10, 16)
+	sheet.FreezeHeader()
+	sheet.AddRow(header([]string{"カテゴリー", "件数", fmt.Sprintf("購入価格の合計（%s）", export.Currency)})...)
+
+	for _, category := range export.Categories {
+		sheet.AddRow(
+			String(category.Category, StyleDefault),
+			Number(float64(category.Count), StyleDefault),
+			amountCell(category.PurchasePrice, export.Currency),
+		)
+	}
+
+	last := len(export.Categories) + 1
+	sheet.AddRow(
+		String("合計", StyleBold),
+		sumCell(1, 2, last, float64(export.Total.Count), StyleBold),
+		sumCell(2, 2, last, major(export.Total.PurchasePrice, export.Currency), amountStyle(export.Currency, true)),
+	)
+	sheet.AddRow()
+	sheet.AddRow(String("出力日時", StyleDefault), DateTime(export.GeneratedAt))
+}
+
+func header(titles []string) []Cell {
+	cells := make([]Cell, len(titles))
+	for i, title := range titles {
+		cells[i] = String(title, StyleHeader)
+	}
+	return cells
+}
+
+// amountCell は通貨の最小単位の金額のセル（外貨は補助単位を小数にする）
+func amountCell(amount int64, currency entity.Currency) Cell {
+	return Number(major(amount, currency), amountStyle(currency, false))
+}
+
+// priceCell はアイテムの購入価格のセル（操作者に非表示の場合は空）
+func priceCell(item *entity.Item) Cell {
+	if item.IsRedacted(entity.ItemFieldPurchasePrice) {
+		return Empty()
+	}
+	return amountCell(int64(item.PurchasePrice.Amount), item.PurchasePrice.Currency)
+}
+
+// valueCell はアイテムの購入価格を表示通貨に換算した金額のセル（換算できない場合は空）
+func valueCell(export *usecase.ItemExport, id int64) Cell {
+	value, ok := export.Values[id]
+	if !ok {
+		return Empty()
+	}
+	return amountCell(value, export.Currency)
+}
+
+// major は最小単位の金額を補助単位を小数にした金額にする（USD の 12345 は 123.45）
+func major(amount int64, currency entity.Currency) float64 {
+	return float64(amount) / math.Pow10(currency.MinorUnits())
+}
+
+func amountStyle(currency entity.Currency, bold bool) Style {
+	switch {
+	case currency.MinorUnits() == 0 && bold:
+		return StyleBoldYen
+	case currency.MinorUnits() == 0:
+		return StyleYen
+	case bold:
+		return StyleBoldDecimal
+	}
+	return StyleDecimal
+}
+
+// sumCell は列 col の first 行目から last 行目までの合計の数式（データ行がない場合は 0）
+func sumCell(col, first, last int, cached float64, style Style) Cell {
+	if last < first {
+		return Number(0, style)
+	}
+	return Formula(fmt.Sprintf("SUM(%s:%s)", CellRef(col, first), CellRef(col, last)), cached, style)
+}
@@ -0,0 +1,309 @@
+// Package xlsx は表計算ソフトで開ける帳票を出力するための最小限の Excel (Office Open XML) ライター。
+// 文字列はインライン文字列として書き込むため、共有文字列テーブルは作成しない
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Style はセルの書式（styles.xml の cellXfs の順序と一致させる）
+type Style int
+
+const (
+	StyleDefault Style = iota
+	// StyleHeader は見出し行（太字・背景色付き）
+	StyleHeader
+	// StyleYen は桁区切りの整数（金額）
+	StyleYen
+	// StyleDate は YYYY-MM-DD 形式の日付
+	StyleDate
+	// StyleDateTime は YYYY-MM-DD hh:mm 形式の日時
+	StyleDateTime
+	// StyleBold は合計行の見出しや件数
+	StyleBold
+	// StyleBoldYen は合計行の金額
+	StyleBoldYen
+	// StyleDecimal は桁区切りの小数点以下2桁（外貨の金額）
+	StyleDecimal
+	// StyleBoldDecimal は合計行の外貨の金額
+	StyleBoldDecimal
+)
+
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellString
+	cellNumber
+	cellFormula
+)
+
+// Cell は1つのセルの値と書式
+type Cell struct {
+	kind    cellKind
+	str     string
+	num     float64
+	formula string
+	style   Style
+}
+
+// Empty は空のセル
+func Empty() Cell {
+	return Cell{kind: cellEmpty}
+}
+
+// String は文字列のセル
+func String(s string, style Style) Cell {
+	return Cell{kind: cellString, str: s, style: style}
+}
+
+// Number は数値のセル
+func Number(n float64, style Style) Cell {
+	return Cell{kind: cellNumber, num: n, style: style}
+}
+
+// Date は日付のセル（Excel のシリアル値として書き込む）
+func Date(t time.Time) Cell {
+	return Cell{kind: cellNumber, num: serial(t), style: StyleDate}
+}
+
+// DateTime は日時のセル（Excel のシリアル値として書き込む）
+func DateTime(t time.Time) Cell {
+	return Cell{kind: cellNumber, num: serial(t), style: StyleDateTime}
+}
+
+// Formula は数式のセル。cached は開いた直後やプレビューで表示される計算結果
+func Formula(formula string, cached float64, style Style) Cell {
+	return Cell{kind: cellFormula, formula: formula, num: cached, style: style}
+}
+
+// Workbook はシートの集まり
+type Workbook struct {
+	sheets []*Sheet
+}
+
+// Sheet は1シート分の内容
+type Sheet struct {
+	name   string
+	widths []float64
+	rows   [][]Cell
+	// freezeHeader は先頭行を固定するか
+	freezeHeader bool
+}
+
+func New() *Workbook {
+	return &Workbook{}
+}
+
+// AddSheet はシートを追加する（シート名は31文字まで）
+func (w *Workbook) AddSheet(name string) *Sheet {
+	sheet := &Sheet{name: name}
+	w.sheets = append(w.sheets, sheet)
+	return sheet
+}
+
+// SetColumnWidths は先頭の列から順に列幅（文字数）を設定する
+func (s *Sheet) SetColumnWidths(widths ...float64) {
+	s.widths = widths
+}
+
+// FreezeHeader はスクロールしても先頭行が表示されたままにする
+func (s *Sheet) FreezeHeader() {
+	s.freezeHeader = true
+}
+
+// AddRow は行を追加し、その行番号（1始まり）を返す
+func (s *Sheet) AddRow(cells ...Cell) int {
+	s.rows = append(s.rows, cells)
+	return len(s.rows)
+}
+
+// CellRef は列番号（0始まり）と行番号（1始まり）から "A1" 形式の参照を返す
+func CellRef(col, row int) string {
+	return ColumnName(col) + strconv.Itoa(row)
+}
+
+// ColumnName は列番号（0始まり）から "A", "B", ..., "AA" 形式の列名を返す
+func ColumnName(col int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+// Bytes は .xlsx ファイルの内容を返す
+func (w *Workbook) Bytes() ([]byte, error) {
+	if len(w.sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no sheets")
+	}
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", w.contentTypes()},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", w.workbook()},
+		{"xl/_rels/workbook.xml.rels", w.workbookRels()},
+		{"xl/styles.xml", styles},
+	}
+	for i, sheet := range w.sheets {
+		parts = append(parts, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	for _, part := range parts {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+const xmlHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+
+const rootRels = xmlHeader + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+	`</Relationships>`
+
+// styles は Style の定義。numFmtId 3 は組み込みの "#,##0"、4 は "#,##0.00"
+const styles = xmlHeader + `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+	`<numFmts count="2"><numFmt numFmtId="164" formatCode="yyyy\-mm\-dd"/><numFmt numFmtId="165" formatCode="yyyy\-mm\-dd\ hh:mm"/></numFmts>` +
+	`<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill>` +
+	`<fill><patternFill patternType="solid"><fgColor rgb="FFDDEBF7"/><bgColor indexed="64"/></patternFill></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="9">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>` +
+	`<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>` +
+	`<xf numFmtId="3" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>` +
+	`<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="4" fontId="1" fillId="0" borderId="0" xfId="0" applyNumberFormat="1" applyFont="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
+
+func (w *Workbook) contentTypes() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	b.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+	b.WriteString(`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	b.WriteString(`</Types>`)
+	return b.String()
+}
+
+func (w *Workbook) workbook() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	for i, sheet := range w.sheets {
+		fmt.Fprintf(&b, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.name), i+1, i+1)
+	}
+	b.WriteString(`</sheets></workbook>`)
+	return b.String()
+}
+
+func (w *Workbook) workbookRels() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i := range w.sheets {
+		fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	fmt.Fprintf(&b, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(w.sheets)+1)
+	b.WriteString(`</Relationships>`)
+	return b.String()
+}
+
+func (s *Sheet) xml() string {
+	var b strings.Builder
+	b.WriteString(xmlHeader)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if s.freezeHeader {
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+	if len(s.widths) > 0 {
+		b.WriteString(`<cols>`)
+		for i, width := range s.widths {
+			fmt.Fprintf(&b, `<col min="%d" max="%d" width="%s" customWidth="1"/>`, i+1, i+1, formatNumber(width))
+		}
+		b.WriteString(`</cols>`)
+	}
+	b.WriteString(`<sheetData>`)
+	for i, cells := range s.rows {
+		row := i + 1
+		fmt.Fprintf(&b, `<row r="%d">`, row)
+		for col, cell := range cells {
+			writeCell(&b, CellRef(col, row), cell)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.String()
+}
+
+func writeCell(b *strings.Builder, ref string, cell Cell) {
+	switch cell.kind {
+	case cellString:
+		fmt.Fprintf(b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.style, escape(cell.str))
+	case cellNumber:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, formatNumber(cell.num))
+	case cellFormula:
+		fmt.Fprintf(b, `<c r="%s" s="%d"><f>%s</f><v>%s</v></c>`, ref, cell.style, escape(cell.formula), formatNumber(cell.num))
+	default:
+		if cell.style != StyleDefault {
+			fmt.Fprintf(b, `<c r="%s" s="%d"/>`, ref, cell.style)
+		}
+	}
+}
+
+// excelEpoch は Excel のシリアル値の起点（1900年のうるう年の扱いを含めた 1899-12-30）
+var excelEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+
+// serial は日時を Excel のシリアル値（日数）に変換する。タイムゾーンは t の表示上の日時をそのまま使う
+func serial(t time.Time) float64 {
+	wall := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return wall.Sub(excelEpoch).Hours() / 24
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
+
+// escape はXMLのテキストとして書き込めるようエスケープする（XMLで使えない制御文字は置き換える）
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,171 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// readParts は .xlsx を展開してパスごとの内容を返す（各パートが正しいXMLであることも確認する）
+func readParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := decoder.Token(); err != nil {
+				require.ErrorIs(t, err, io.EOF, f.Name)
+				break
+			}
+		}
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", ColumnName(0))
+	assert.Equal(t, "Z", ColumnName(25))
+	assert.Equal(t, "AA", ColumnName(26))
+	assert.Equal(t, "AZ", ColumnName(51))
+	assert.Equal(t, "E12", CellRef(4, 12))
+}
+
+func TestSerial(t *testing.T) {
+	assert.Equal(t, 45306.0, serial(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, 45306.5, serial(time.Date(2024, 1, 15, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))))
+}
+
+func TestWorkbook_Bytes(t *testing.T) {
+	t.Run("異常系: シートがない", func(t *testing.T) {
+		_, err := New().Bytes()
+		assert.Error(t, err)
+	})
+
+	t.Run("正常系: 型ごとのセルを書き込む", func(t *testing.T) {
+		book := New()
+		sheet := book.AddSheet("A&B")
+		sheet.AddRow(String("<名前>", StyleHeader), Number(1500000, StyleYen), Date(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)))
+		sheet.AddRow(Empty(), Formula("SUM(B1:B1)", 1500000, StyleBoldYen))
+
+		out, err := book.Bytes()
+		require.NoError(t, err)
+		parts := readParts(t, out)
+
+		for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+			assert.Contains(t, parts, name)
+		}
+		assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="A&amp;B" sheetId="1" r:id="rId1"/>`)
+
+		sheetXML := parts["xl/worksheets/sheet1.xml"]
+		assert.Contains(t, sheetXML, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">&lt;名前&gt;</t></is></c>`)
+		assert.Contains(t, sheetXML, `<c r="B1" s="2"><v>1500000</v></c>`)
+		assert.Contains(t, sheetXML, `<c r="C1" s="3"><v>45306</v></c>`)
+		assert.Contains(t, sheetXML, `<c r="B2" s="6"><f>SUM(B1:B1)</f><v>1500000</v></c>`)
+		assert.NotContains(t, sheetXML, `r="A2"`)
+	})
+}
+
+func TestItemExportRenderer_Render(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	export := &usecase.ItemExport{
+		GeneratedAt: time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC),
+		Items: []*entity.Item{
+			{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2024-01-15", Visibility: entity.VisibilityPrivate, CreatedAt: createdAt},
+			{ID: 2, Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: entity.JPY(2000000), PurchaseDate: "2024-02-01", Visibility: entity.VisibilityPrivate, CreatedAt: createdAt},
+			{ID: 3, Name: "サブマリーナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.NewMoney(1234550, "USD"), PurchaseDate: "2024-03-01", Visibility: entity.VisibilityPrivate, CreatedAt: createdAt},
+		},
+		Currency: entity.CurrencyJPY,
+		Values:   map[int64]int64{1: 1500000, 2: 2000000},
+		Categories: []usecase.CategoryTotal{
+			{Category: "バッグ", Count: 1, PurchasePrice: 2000000},
+			{Category: "時計", Count: 2, PurchasePrice: 1500000},
+		},
+		Total: usecase.CategoryTotal{Count: 3, PurchasePrice: 3500000},
+	}
+
+	out, err := NewItemExportRenderer().Render(export)
+	require.NoError(t, err)
+	parts := readParts(t, out)
+
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="アイテム" sheetId="1" r:id="rId1"/>`)
+	assert.Contains(t, parts["xl/workbook.xml"], `<sheet name="カテゴリー別" sheetId="2" r:id="rId2"/>`)
+
+	items := parts["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, items, `state="frozen"`)
+	assert.Contains(t, items, `<c r="E2" s="2"><v>1500000</v></c>`)
+	assert.Contains(t, items, `<c r="F2" s="0" t="inlineStr"><is><t xml:space="preserve">JPY</t></is></c>`)
+	assert.Contains(t, items, `<c r="G1" s="1" t="inlineStr"><is><t xml:space="preserve">換算額（JPY）</t></is></c>`)
+	assert.Contains(t, items, `<c r="G2" s="2"><v>1500000</v></c>`)
+	assert.Contains(t, items, `<c r="H2" s="3"><v>45306</v></c>`)
+	// 外貨は補助単位を小数にし、換算できない場合は換算額を空にする
+	assert.Contains(t, items, `<c r="E4" s="7"><v>12345.5</v></c>`)
+	assert.NotContains(t, items, `r="G4"`)
+	assert.Contains(t, items, `合計（3件）`)
+	assert.Contains(t, items, `<c r="G5" s="6"><f>SUM(G2:G4)</f><v>3500000</v></c>`)
+
+	categories := parts["xl/worksheets/sheet2.xml"]
+	assert.Contains(t, categories, `購入価格の合計（JPY）`)
+	assert.Contains(t, categories, `<c r="B4" s="5"><f>SUM(B2:B3)</f><v>3</v></c>`)
+	assert.Contains(t, categories, `<c r="C4" s="6"><f>SUM(C2:C3)</f><v>3500000</v></c>`)
+
+	t.Run("表示通貨が外貨の場合は補助単位を小数にする", func(t *testing.T) {
+		usd := *export
+		usd.Currency = entity.CurrencyUSD
+		usd.Values = map[int64]int64{1: 996675, 2: 1328900, 3: 1234550}
+		usd.Categories = []usecase.CategoryTotal{
+			{Category: "バッグ", Count: 1, PurchasePrice: 1328900},
+			{Category: "時計", Count: 2, PurchasePrice: 2231225},
+		}
+		usd.Total = usecase.CategoryTotal{Count: 3, PurchasePrice: 3560125}
+
+		out, err := NewItemExportRenderer().Render(&usd)
+		require.NoError(t, err)
+		parts := readParts(t, out)
+
+		items := parts["xl/worksheets/sheet1.xml"]
+		assert.Contains(t, items, `換算額（USD）`)
+		assert.Contains(t, items, `<c r="G2" s="7"><v>9966.75</v></c>`)
+		assert.Contains(t, items, `<c r="G5" s="8"><f>SUM(G2:G4)</f><v>35601.25</v></c>`)
+
+		categories := parts["xl/worksheets/sheet2.xml"]
+		assert.Contains(t, categories, `<c r="C3" s="7"><v>22312.25</v></c>`)
+	})
+
+	t.Run("非表示にした購入価格は空にする", func(t *testing.T) {
+		item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2024-01-15", CreatedAt: createdAt}
+		item.Redact(entity.ItemFieldPurchasePrice)
+		redacted := &usecase.ItemExport{
+			GeneratedAt: export.GeneratedAt,
+			Items:       []*entity.Item{item},
+			Currency:    entity.CurrencyJPY,
+			Values:      map[int64]int64{},
+			Categories:  []usecase.CategoryTotal{{Category: "時計", Count: 1}},
+			Total:       usecase.CategoryTotal{Count: 1},
+		}
+
+		out, err := NewItemExportRenderer().Render(redacted)
+		require.NoError(t, err)
+		items := readParts(t, out)["xl/worksheets/sheet1.xml"]
+		assert.NotContains(t, items, `r="E2"`)
+		assert.NotContains(t, items, `r="G2"`)
+	})
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type AuditHandler struct {
+	auditUsecase usecase.AuditUsecase
+}
+
+func NewAuditHandler(auditUsecase usecase.AuditUsecase) *AuditHandler {
+	return &AuditHandler{
+		auditUsecase: auditUsecase,
+	}
+}
+
+// ListAuditLogs は監査ログを新しい順に返す（管理者のみ。?from=&to=&user_id=&action=&limit= で絞り込む）
+func (h *AuditHandler) ListAuditLogs(c echo.Context) error {
+	input := usecase.ListAuditLogsInput{
+		From:   c.QueryParam("from"),
+		To:     c.QueryParam("to"),
+		Action: c.QueryParam("action"),
+	}
+	if v := c.QueryParam("user_id"); v != "" {
+		userID, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || userID <= 0 {
+			return problem.Respond(c, http.StatusBadRequest, "invalid user_id parameter")
+		}
+		input.UserID = userID
+	}
+	if v := c.QueryParam("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return problem.Respond(c, http.StatusBadRequest, "invalid limit parameter")
+		}
+		input.Limit = limit
+	}
+
+	logs, err := h.auditUsecase.List(c.Request().Context(), input)
+	if err != nil {
+		switch {
+		case domainErrors.IsForbiddenError(err):
+			return problem.Respond(c, http.StatusForbidden, "insufficient permissions")
+		case domainErrors.IsValidationError(err):
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve audit logs")
+	}
+
+	return response.List(c, http.StatusOK, logs)
+}
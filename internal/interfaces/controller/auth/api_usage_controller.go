@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type APIUsageHandler struct {
+	apiUsageUsecase usecase.APIUsageUsecase
+}
+
+func NewAPIUsageHandler(apiUsageUsecase usecase.APIUsageUsecase) *APIUsageHandler {
+	return &APIUsageHandler{
+		apiUsageUsecase: apiUsageUsecase,
+	}
+}
+
+// GetAPIUsage は自分のAPIキーごとの直近30日のリクエスト数・エラー率・レート制限の件数を返す
+func (h *APIUsageHandler) GetAPIUsage(c echo.Context) error {
+	summary, err := h.apiUsageUsecase.Summary(c.Request().Context())
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve api usage")
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
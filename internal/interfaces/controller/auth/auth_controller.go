@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/identity"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type AuthHandler struct {
+	authUsecase   usecase.AuthUsecase
+	apiKeyUsecase usecase.APIKeyUsecase
+}
+
+func NewAuthHandler(authUsecase usecase.AuthUsecase, apiKeyUsecase usecase.APIKeyUsecase) *AuthHandler {
+	return &AuthHandler{
+		authUsecase:   authUsecase,
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+// APIキーを受け付けるヘッダー
+const HeaderAPIKey = "X-API-Key"
+
+func (h *AuthHandler) Register(c echo.Context) error {
+	var input usecase.RegisterInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	user, err := h.authUsecase.Register(c.Request().Context(), input)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		if domainErrors.IsDuplicateError(err) {
+			return problem.Respond(c, http.StatusConflict, "email is already registered")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to register user")
+	}
+
+	return c.JSON(http.StatusCreated, user)
+}
+
+func (h *AuthHandler) Login(c echo.Context) error {
+	var input usecase.LoginInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	token, err := h.authUsecase.Login(c.Request().Context(), input)
+	if err != nil {
+		if domainErrors.IsUnauthorizedError(err) {
+			return problem.Respond(c, http.StatusUnauthorized, "invalid email or password")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to login")
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+// RequireAuth は X-API-Key または Authorization: Bearer <token> を検証し、ユーザーを echo.Context に格納するミドルウェア
+func (h *AuthHandler) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		var user *entity.User
+		var err error
+		if key := c.Request().Header.Get(HeaderAPIKey); key != "" {
+			user, err = h.apiKeyUsecase.Authenticate(c.Request().Context(), key)
+		} else {
+			token, ok := bearerToken(c.Request().Header.Get(echo.HeaderAuthorization))
+			if !ok {
+				return unauthorized(c)
+			}
+			user, err = h.authUsecase.Authenticate(c.Request().Context(), token)
+		}
+		if err != nil {
+			if domainErrors.IsUnauthorizedError(err) {
+				return unauthorized(c)
+			}
+			return problem.Respond(c, http.StatusInternalServerError, "failed to authenticate")
+		}
+
+		identity.SetUser(c, user)
+		// ユースケースが操作者を参照できるようリクエストのコンテキストにも設定する
+		c.SetRequest(c.Request().WithContext(usecase.WithActor(c.Request().Context(), user)))
+		return next(c)
+	}
+}
+
+func (h *AuthHandler) CreateAPIKey(c echo.Context) error {
+	var input usecase.IssueAPIKeyInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	issued, err := h.apiKeyUsecase.Issue(c.Request().Context(), input)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to create api key")
+	}
+
+	return c.JSON(http.StatusCreated, issued)
+}
+
+func (h *AuthHandler) ListAPIKeys(c echo.Context) error {
+	keys, err := h.apiKeyUsecase.List(c.Request().Context())
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve api keys")
+	}
+
+	return response.List(c, http.StatusOK, keys)
+}
+
+func (h *AuthHandler) DeleteAPIKey(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid api key ID")
+	}
+
+	if err := h.apiKeyUsecase.Revoke(c.Request().Context(), id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "api key not found")
+		}
+		if domainErrors.IsValidationError(err) {
+			return problem.Respond(c, http.StatusBadRequest, "invalid api key ID")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to delete api key")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func unauthorized(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="items"`)
+	return problem.Respond(c, http.StatusUnauthorized, "authentication required")
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
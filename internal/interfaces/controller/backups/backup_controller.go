@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type BackupHandler struct {
+	backupUsecase             usecase.BackupUsecase
+	backupVerificationUsecase usecase.BackupVerificationUsecase
+}
+
+func NewBackupHandler(backupUsecase usecase.BackupUsecase, backupVerificationUsecase usecase.BackupVerificationUsecase) *BackupHandler {
+	return &BackupHandler{
+		backupUsecase:             backupUsecase,
+		backupVerificationUsecase: backupVerificationUsecase,
+	}
+}
+
+// GetBackup は全アイテムのバックアップを JSON ファイルで返す（管理者のみ）
+func (h *BackupHandler) GetBackup(c echo.Context) error {
+	backup, err := h.backupUsecase.Backup(c.Request().Context())
+	if err != nil {
+		return respondError(c, err, "failed to create backup")
+	}
+
+	filename := fmt.Sprintf("aicon-backup-%s.json", backup.CreatedAt.Format("20060102-150405"))
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	return c.JSON(http.StatusOK, backup)
+}
+
+// Restore は GET /admin/backup のバックアップを読み込み、アイテムをバックアップの時点の内容に置き換える（管理者のみ）
+func (h *BackupHandler) Restore(c echo.Context) error {
+	var backup entity.Backup
+	if err := c.Bind(&backup); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	result, err := h.backupUsecase.Restore(c.Request().Context(), &backup)
+	if err != nil {
+		return respondError(c, err, "failed to restore backup")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetVerification はバックアップの検証の回数と最後の結果を返す（管理者のみ）
+func (h *BackupHandler) GetVerification(c echo.Context) error {
+	stats, err := h.backupVerificationUsecase.Status(c.Request().Context())
+	if err != nil {
+		return respondError(c, err, "failed to get backup verification status")
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// respondError はユースケースのエラーをエラーレスポンスに変換する
+func respondError(c echo.Context, err error, message string) error {
+	return problem.Error(c, err, message)
+}
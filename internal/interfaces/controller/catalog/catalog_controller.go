@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type CatalogHandler struct {
+	catalogUsecase usecase.CatalogUsecase
+}
+
+func NewCatalogHandler(catalogUsecase usecase.CatalogUsecase) *CatalogHandler {
+	return &CatalogHandler{
+		catalogUsecase: catalogUsecase,
+	}
+}
+
+func (h *CatalogHandler) LookupCatalog(c echo.Context) error {
+	entry, err := h.catalogUsecase.Lookup(c.Request().Context(), c.QueryParam("ref"))
+	if err != nil {
+		return problem.Error(c, err, "failed to look up catalog")
+	}
+
+	return c.JSON(http.StatusOK, entry)
+}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type CategoryHandler struct {
+	categoryUsecase usecase.CategoryUsecase
+}
+
+func NewCategoryHandler(categoryUsecase usecase.CategoryUsecase) *CategoryHandler {
+	return &CategoryHandler{
+		categoryUsecase: categoryUsecase,
+	}
+}
+
+func (h *CategoryHandler) ListCategories(c echo.Context) error {
+	categories, err := h.categoryUsecase.List(c.Request().Context())
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve categories")
+	}
+
+	return response.List(c, http.StatusOK, categories)
+}
+
+func (h *CategoryHandler) CreateCategory(c echo.Context) error {
+	var input usecase.CategoryInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	category, err := h.categoryUsecase.Create(c.Request().Context(), input)
+	if err != nil {
+		return problem.Error(c, err, "failed to create category")
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+func (h *CategoryHandler) UpdateCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid category ID")
+	}
+
+	var input usecase.UpdateCategoryInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	category, err := h.categoryUsecase.Update(c.Request().Context(), id, input)
+	if err != nil {
+		return problem.Error(c, err, "failed to update category")
+	}
+
+	return c.JSON(http.StatusOK, category)
+}
+
+func (h *CategoryHandler) DeleteCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid category ID")
+	}
+
+	if err := h.categoryUsecase.Delete(c.Request().Context(), id); err != nil {
+		return problem.Error(c, err, "failed to delete category")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
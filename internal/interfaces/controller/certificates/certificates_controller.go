@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type CertificateHandler struct {
+	certificateUsecase usecase.CertificateUsecase
+}
+
+func NewCertificateHandler(certificateUsecase usecase.CertificateUsecase) *CertificateHandler {
+	return &CertificateHandler{
+		certificateUsecase: certificateUsecase,
+	}
+}
+
+// GetCertificate はアイテムの評価証明書をPDFで返す
+func (h *CertificateHandler) GetCertificate(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	pdf, err := h.certificateUsecase.Generate(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "item not found")
+		}
+		if domainErrors.IsValidationError(err) {
+			return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+		}
+		if domainErrors.IsForbiddenError(err) {
+			return problem.Respond(c, http.StatusForbidden, "insufficient permissions")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to generate certificate")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="certificate-%d.pdf"`, id))
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// Verify は証明書の検証コードを照合する（認証不要）
+func (h *CertificateHandler) Verify(c echo.Context) error {
+	verification, err := h.certificateUsecase.Verify(c.Request().Context(), c.QueryParam("code"))
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Respond(c, http.StatusBadRequest, "invalid certificate code")
+		}
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "certificate not found")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to verify certificate")
+	}
+
+	return c.JSON(http.StatusOK, verification)
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type CommentHandler struct {
+	commentUsecase usecase.CommentUsecase
+}
+
+func NewCommentHandler(commentUsecase usecase.CommentUsecase) *CommentHandler {
+	return &CommentHandler{
+		commentUsecase: commentUsecase,
+	}
+}
+
+func (h *CommentHandler) ListComments(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	comments, err := h.commentUsecase.List(c.Request().Context(), itemID)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve comments")
+	}
+
+	return response.List(c, http.StatusOK, comments)
+}
+
+func (h *CommentHandler) CreateComment(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.CommentInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	comment, err := h.commentUsecase.Create(c.Request().Context(), itemID, input)
+	if err != nil {
+		return respondError(c, err, "failed to create comment")
+	}
+
+	return c.JSON(http.StatusCreated, comment)
+}
+
+func (h *CommentHandler) UpdateComment(c echo.Context) error {
+	itemID, commentID, ok := parseIDs(c)
+	if !ok {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item or comment ID")
+	}
+
+	var input usecase.CommentInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	comment, err := h.commentUsecase.Update(c.Request().Context(), itemID, commentID, input)
+	if err != nil {
+		return respondError(c, err, "failed to update comment")
+	}
+
+	return c.JSON(http.StatusOK, comment)
+}
+
+func (h *CommentHandler) DeleteComment(c echo.Context) error {
+	itemID, commentID, ok := parseIDs(c)
+	if !ok {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item or comment ID")
+	}
+
+	if err := h.commentUsecase.Delete(c.Request().Context(), itemID, commentID); err != nil {
+		return respondError(c, err, "failed to delete comment")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func parseIDs(c echo.Context) (int64, int64, bool) {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	commentID, err := strconv.ParseInt(c.Param("commentId"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return itemID, commentID, true
+}
+
+// respondError はユースケースのエラーをエラーレスポンスに変換する
+func respondError(c echo.Context, err error, message string) error {
+	return problem.Error(c, err, message)
+}
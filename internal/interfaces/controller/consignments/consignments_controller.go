@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	jobController "Aicon-assignment/internal/interfaces/controller/jobs"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ConsignmentHandler struct {
+	consignmentUsecase usecase.ConsignmentUsecase
+	jobUsecase         usecase.JobUsecase
+}
+
+func NewConsignmentHandler(consignmentUsecase usecase.ConsignmentUsecase, jobUsecase usecase.JobUsecase) *ConsignmentHandler {
+	return &ConsignmentHandler{
+		consignmentUsecase: consignmentUsecase,
+		jobUsecase:         jobUsecase,
+	}
+}
+
+func (h *ConsignmentHandler) GetConsignment(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	consignment, err := h.consignmentUsecase.Get(c.Request().Context(), itemID)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve consignment")
+	}
+
+	return c.JSON(http.StatusOK, consignment)
+}
+
+func (h *ConsignmentHandler) PutConsignment(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.ConsignmentInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	consignment, err := h.consignmentUsecase.Put(c.Request().Context(), itemID, input)
+	if err != nil {
+		return respondError(c, err, "failed to save consignment")
+	}
+
+	return c.JSON(http.StatusOK, consignment)
+}
+
+func (h *ConsignmentHandler) DeleteConsignment(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	if err := h.consignmentUsecase.Delete(c.Request().Context(), itemID); err != nil {
+		return respondError(c, err, "failed to delete consignment")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListConsignments は委託品を期限の早い順に返す（?status=...、?overdue=true で絞り込み）
+func (h *ConsignmentHandler) ListConsignments(c echo.Context) error {
+	filter := usecase.ConsignmentFilter{
+		Status: entity.ConsignmentStatus(c.QueryParam("status")),
+	}
+	if v := c.QueryParam("overdue"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return problem.Respond(c, http.StatusBadRequest, "invalid overdue parameter")
+		}
+		filter.Overdue = b
+	}
+
+	consignments, err := h.consignmentUsecase.List(c.Request().Context(), filter)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve consignments")
+	}
+
+	return response.List(c, http.StatusOK, consignments)
+}
+
+// GetReport は在庫の集計を返す。Prefer: respond-async が指定された場合はジョブを開始して202を返す
+func (h *ConsignmentHandler) GetReport(c echo.Context) error {
+	jobController.SetLoadHeaders(c, h.jobUsecase.Load(entity.JobKindReport))
+
+	if jobController.PrefersAsync(c) {
+		job, err := h.consignmentUsecase.StartReport(c.Request().Context())
+		if err != nil {
+			if domainErrors.IsJobConflictError(err) {
+				return jobController.RespondConflict(c, err)
+			}
+			return respondError(c, err, "failed to start consignment report")
+		}
+		return jobController.RespondAccepted(c, job)
+	}
+
+	report, err := h.consignmentUsecase.Report(c.Request().Context())
+	if err != nil {
+		return respondError(c, err, "failed to create consignment report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// respondError はユースケースのエラーをエラーレスポンスに変換する
+func respondError(c echo.Context, err error, message string) error {
+	if domainErrors.IsDuplicateError(err) {
+		return problem.Respond(c, http.StatusConflict, "invoice has already been issued for this item")
+	}
+	return problem.Error(c, err, message)
+}
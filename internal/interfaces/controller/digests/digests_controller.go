@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type DigestHandler struct {
+	digestUsecase usecase.DigestUsecase
+}
+
+func NewDigestHandler(digestUsecase usecase.DigestUsecase) *DigestHandler {
+	return &DigestHandler{
+		digestUsecase: digestUsecase,
+	}
+}
+
+type UpdatePreferenceRequest struct {
+	Frequency entity.DigestFrequency `json:"frequency"`
+}
+
+// 配信停止リンクを開いたときに表示するページ
+const unsubscribedPage = `<!DOCTYPE html>
+<html lang="ja"><head><meta charset="utf-8"><meta name="robots" content="noindex, nofollow"><title>配信停止</title></head>
+<body><p>ダイジェストメールの配信を停止しました。</p></body></html>`
+
+func (h *DigestHandler) GetPreference(c echo.Context) error {
+	subscription, err := h.digestUsecase.GetPreference(c.Request().Context())
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve digest preference")
+	}
+
+	return c.JSON(http.StatusOK, subscription)
+}
+
+// UpdatePreference はダイジェストメールの配信頻度（off, weekly, monthly）を変更する
+func (h *DigestHandler) UpdatePreference(c echo.Context) error {
+	var req UpdatePreferenceRequest
+	if err := c.Bind(&req); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	subscription, err := h.digestUsecase.UpdatePreference(c.Request().Context(), req.Frequency)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to update digest preference")
+	}
+
+	return c.JSON(http.StatusOK, subscription)
+}
+
+// PreviewDigest は次に配信するダイジェストメールの HTML を返す（送信はしない）
+func (h *DigestHandler) PreviewDigest(c echo.Context) error {
+	message, err := h.digestUsecase.Preview(c.Request().Context())
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to render digest")
+	}
+
+	return c.HTML(http.StatusOK, message.HTML)
+}
+
+// Unsubscribe はメールの配信停止リンク（GET）とワンクリックの配信停止（POST）を処理する（認証不要）
+func (h *DigestHandler) Unsubscribe(c echo.Context) error {
+	if err := h.digestUsecase.Unsubscribe(c.Request().Context(), c.QueryParam("token")); err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Respond(c, http.StatusBadRequest, "invalid unsubscribe token")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to unsubscribe")
+	}
+
+	return c.HTML(http.StatusOK, unsubscribedPage)
+}
@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// NDJSON（1行に1つの JSON）の Content-Type
+const mimeNDJSON = "application/x-ndjson"
+
+type EventHandler struct {
+	eventUsecase usecase.EventExportUsecase
+}
+
+func NewEventHandler(eventUsecase usecase.EventExportUsecase) *EventHandler {
+	return &EventHandler{
+		eventUsecase: eventUsecase,
+	}
+}
+
+// ExportEvents はアイテムのイベントを古い順に NDJSON で配信する（管理者のみ。?since= で開始日時を指定する）。
+// 最初のイベントを書き込むまでのエラーは problem+json で返し、書き込み始めた後のエラーはログに出力して打ち切る
+func (h *EventHandler) ExportEvents(c echo.Context) error {
+	res := c.Response()
+	enc := json.NewEncoder(res)
+	started := false
+	write := func(event *entity.ItemEvent) error {
+		if !started {
+			res.Header().Set(echo.HeaderContentType, mimeNDJSON)
+			res.WriteHeader(http.StatusOK)
+			started = true
+		}
+		if err := enc.Encode(event); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+
+	err := h.eventUsecase.Export(c.Request().Context(), c.QueryParam("since"), write)
+	switch {
+	case err == nil && !started:
+		// イベントがない場合は空の本文を返す
+		return c.Blob(http.StatusOK, mimeNDJSON, nil)
+	case err == nil:
+		return nil
+	case !started:
+		return problem.Error(c, err, "failed to export events")
+	default:
+		log.Printf("⚠️  event export aborted: %v", err)
+		return nil
+	}
+}
@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// fakeEventExportUsecase は events を書き込んでから err を返す
+type fakeEventExportUsecase struct {
+	since  string
+	events []*entity.ItemEvent
+	err    error
+}
+
+func (f *fakeEventExportUsecase) Export(ctx context.Context, since string, write func(*entity.ItemEvent) error) error {
+	f.since = since
+	for _, e := range f.events {
+		if err := write(e); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+func TestEventHandler_ExportEvents(t *testing.T) {
+	event := &entity.ItemEvent{ID: 1, Type: entity.ItemEventDeleted, ItemID: 10, ActorID: 2, OccurredAt: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)}
+
+	run := func(usecase *fakeEventExportUsecase, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		require.NoError(t, NewEventHandler(usecase).ExportEvents(echo.New().NewContext(req, rec)))
+		return rec
+	}
+
+	t.Run("正常系: イベントを1行ずつ NDJSON で返す", func(t *testing.T) {
+		usecase := &fakeEventExportUsecase{events: []*entity.ItemEvent{event, event}}
+
+		rec := run(usecase, "/admin/events/export?since=2024-06-01")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, mimeNDJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, "2024-06-01", usecase.since)
+		line := `{"id":1,"type":"item.deleted","item_id":10,"actor_id":2,"occurred_at":"2024-06-01T09:00:00Z","changes":null}` + "\n"
+		assert.Equal(t, line+line, rec.Body.String())
+	})
+
+	t.Run("正常系: イベントがない場合は空の本文", func(t *testing.T) {
+		rec := run(&fakeEventExportUsecase{}, "/admin/events/export")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("異常系: 書き込む前のエラーは problem+json で返す", func(t *testing.T) {
+		rec := run(&fakeEventExportUsecase{err: domainErrors.ErrForbidden}, "/admin/events/export")
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("異常系: 書き込み始めた後のエラーは打ち切る", func(t *testing.T) {
+		rec := run(&fakeEventExportUsecase{events: []*entity.ItemEvent{event}, err: errors.New("db error")}, "/admin/events/export")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":1`)
+	})
+}
@@ -0,0 +1,34 @@
+package identity
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ContextKeyUser は認証済みユーザーを echo.Context に格納するキー
+const ContextKeyUser = "user"
+
+// AnonymousUserID はユーザーを特定できない場合のID
+const AnonymousUserID = "anonymous"
+
+// SetUser は認証済みユーザーを echo.Context に格納する
+func SetUser(c echo.Context, user *entity.User) {
+	c.Set(ContextKeyUser, user)
+}
+
+// User は認証済みユーザーを返す（未認証の場合は nil）
+func User(c echo.Context) *entity.User {
+	user, _ := c.Get(ContextKeyUser).(*entity.User)
+	return user
+}
+
+// UserID はリクエストのユーザーIDを返す
+func UserID(c echo.Context) string {
+	if user := User(c); user != nil {
+		return strconv.FormatInt(user.ID, 10)
+	}
+	return AnonymousUserID
+}
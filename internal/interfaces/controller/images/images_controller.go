@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ImageHandler struct {
+	imageUsecase usecase.ItemImageUsecase
+}
+
+func NewImageHandler(imageUsecase usecase.ItemImageUsecase) *ImageHandler {
+	return &ImageHandler{
+		imageUsecase: imageUsecase,
+	}
+}
+
+// アップロードするファイルのフォームフィールド名
+const formFieldFile = "file"
+
+func (h *ImageHandler) UploadImage(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	header, err := c.FormFile(formFieldFile)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "file is required")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+	defer file.Close()
+
+	image, err := h.imageUsecase.Upload(c.Request().Context(), itemID, usecase.UploadImageInput{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Body:     file,
+	})
+	if err != nil {
+		return respondError(c, err, "failed to upload image")
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
+func (h *ImageHandler) ListImages(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	images, err := h.imageUsecase.List(c.Request().Context(), itemID)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve images")
+	}
+
+	return response.List(c, http.StatusOK, images)
+}
+
+// GetImage は画像のファイルそのものを返す
+func (h *ImageHandler) GetImage(c echo.Context) error {
+	itemID, imageID, ok := parseIDs(c)
+	if !ok {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item or image ID")
+	}
+
+	image, body, err := h.imageUsecase.Open(c.Request().Context(), itemID, imageID)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve image")
+	}
+	defer body.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentLength, strconv.FormatInt(image.Size, 10))
+	res.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("inline", map[string]string{"filename": image.FileName}))
+	res.Header().Set(echo.HeaderCacheControl, "private, max-age=86400")
+	return c.Stream(http.StatusOK, image.ContentType, io.LimitReader(body, image.Size))
+}
+
+// GetThumbnail は縮小したJPEGを返す（生成前は404）
+func (h *ImageHandler) GetThumbnail(c echo.Context) error {
+	itemID, imageID, ok := parseIDs(c)
+	if !ok {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item or image ID")
+	}
+	width, err := strconv.Atoi(c.Param("width"))
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid thumbnail width")
+	}
+
+	body, err := h.imageUsecase.OpenThumbnail(c.Request().Context(), itemID, imageID, width)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve thumbnail")
+	}
+	defer body.Close()
+
+	c.Response().Header().Set(echo.HeaderCacheControl, "private, max-age=86400")
+	return c.Stream(http.StatusOK, "image/jpeg", body)
+}
+
+func (h *ImageHandler) DeleteImage(c echo.Context) error {
+	itemID, imageID, ok := parseIDs(c)
+	if !ok {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item or image ID")
+	}
+
+	if err := h.imageUsecase.Delete(c.Request().Context(), itemID, imageID); err != nil {
+		return respondError(c, err, "failed to delete image")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func parseIDs(c echo.Context) (int64, int64, bool) {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	imageID, err := strconv.ParseInt(c.Param("imageId"), 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return itemID, imageID, true
+}
+
+// respondError はユースケースのエラーをエラーレスポンスに変換する
+func respondError(c echo.Context, err error, message string) error {
+	return problem.Error(c, err, message)
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type InvoiceHandler struct {
+	invoiceUsecase usecase.InvoiceUsecase
+}
+
+func NewInvoiceHandler(invoiceUsecase usecase.InvoiceUsecase) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceUsecase: invoiceUsecase,
+	}
+}
+
+func (h *InvoiceHandler) ListInvoices(c echo.Context) error {
+	invoices, err := h.invoiceUsecase.List(c.Request().Context())
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve invoices")
+	}
+
+	return response.List(c, http.StatusOK, invoices)
+}
+
+func (h *InvoiceHandler) GetInvoice(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid invoice ID")
+	}
+
+	invoice, err := h.invoiceUsecase.Get(c.Request().Context(), id)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve invoice")
+	}
+
+	return c.JSON(http.StatusOK, invoice)
+}
+
+// GetInvoicePDF は請求書をPDFで返す
+func (h *InvoiceHandler) GetInvoicePDF(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid invoice ID")
+	}
+
+	pdf, err := h.invoiceUsecase.PDF(c.Request().Context(), id)
+	if err != nil {
+		return respondError(c, err, "failed to generate invoice")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`inline; filename="invoice-%d.pdf"`, id))
+	return c.Blob(http.StatusOK, "application/pdf", pdf)
+}
+
+// respondError はユースケースのエラーをエラーレスポンスに変換する
+func respondError(c echo.Context, err error, message string) error {
+	return problem.Error(c, err, message)
+}
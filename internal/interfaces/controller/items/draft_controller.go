@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type DraftSessionHandler struct {
+	draftUsecase usecase.DraftSessionUsecase
+}
+
+func NewDraftSessionHandler(draftUsecase usecase.DraftSessionUsecase) *DraftSessionHandler {
+	return &DraftSessionHandler{
+		draftUsecase: draftUsecase,
+	}
+}
+
+// 下書きにアップロードするファイルのフォームフィールド名
+const draftFormFieldFile = "file"
+
+// CreateDraft は下書きを作成する（ボディは最初の画面で入力した項目。省略できる）
+func (h *DraftSessionHandler) CreateDraft(c echo.Context) error {
+	var fields map[string]json.RawMessage
+	if err := c.Bind(&fields); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	session, err := h.draftUsecase.Create(c.Request().Context(), fields)
+	if err != nil {
+		return problem.Error(c, err, "failed to create draft")
+	}
+
+	c.Response().Header().Set(echo.HeaderLocation, "/items/drafts/"+session.ID)
+	return c.JSON(http.StatusCreated, session)
+}
+
+func (h *DraftSessionHandler) GetDraft(c echo.Context) error {
+	session, err := h.draftUsecase.Get(c.Request().Context(), c.Param("draftId"))
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve draft")
+	}
+
+	return c.JSON(http.StatusOK, session)
+}
+
+// UpdateDraft は画面で入力した項目を下書きに反映する（null の項目は入力を取り消す）
+func (h *DraftSessionHandler) UpdateDraft(c echo.Context) error {
+	var fields map[string]json.RawMessage
+	if err := c.Bind(&fields); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	session, err := h.draftUsecase.UpdateFields(c.Request().Context(), c.Param("draftId"), fields)
+	if err != nil {
+		return problem.Error(c, err, "failed to update draft")
+	}
+
+	return c.JSON(http.StatusOK, session)
+}
+
+func (h *DraftSessionHandler) DeleteDraft(c echo.Context) error {
+	if err := h.draftUsecase.Delete(c.Request().Context(), c.Param("draftId")); err != nil {
+		return problem.Error(c, err, "failed to delete draft")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (h *DraftSessionHandler) UploadDraftImage(c echo.Context) error {
+	header, err := c.FormFile(draftFormFieldFile)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "file is required")
+	}
+	file, err := header.Open()
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+	defer file.Close()
+
+	image, err := h.draftUsecase.AddImage(c.Request().Context(), c.Param("draftId"), usecase.UploadImageInput{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Body:     file,
+	})
+	if err != nil {
+		return problem.Error(c, err, "failed to upload image")
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
+// GetDraftImage は下書きの画像のファイルそのものを返す
+func (h *DraftSessionHandler) GetDraftImage(c echo.Context) error {
+	image, body, err := h.draftUsecase.OpenImage(c.Request().Context(), c.Param("draftId"), c.Param("imageId"))
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve image")
+	}
+	defer body.Close()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentLength, strconv.FormatInt(image.Size, 10))
+	res.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("inline", map[string]string{"filename": image.FileName}))
+	res.Header().Set(echo.HeaderCacheControl, "private, max-age=3600")
+	return c.Stream(http.StatusOK, image.ContentType, io.LimitReader(body, image.Size))
+}
+
+func (h *DraftSessionHandler) DeleteDraftImage(c echo.Context) error {
+	if err := h.draftUsecase.RemoveImage(c.Request().Context(), c.Param("draftId"), c.Param("imageId")); err != nil {
+		return problem.Error(c, err, "failed to delete image")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// CommitDraft は下書きの項目でアイテムを登録する。項目が不正な場合は下書きを残して422を返す
+func (h *DraftSessionHandler) CommitDraft(c echo.Context) error {
+	item, err := h.draftUsecase.Commit(c.Request().Context(), c.Param("draftId"))
+	if err != nil {
+		return problem.Error(c, err, "failed to create item")
+	}
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusCreated, item)
+}
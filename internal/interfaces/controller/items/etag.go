@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// setItemETag はアイテムのバージョンを強いエンティティタグ（例: "3"）として ETag ヘッダーに設定する
+func setItemETag(c echo.Context, item *entity.Item) {
+	c.Response().Header().Set(HeaderETag, `"`+strconv.FormatInt(item.Version, 10)+`"`)
+}
+
+// parseIfMatch は If-Match ヘッダーから更新前のバージョンを取り出す。
+// "*" は照合しない（0 を返す）。ヘッダーがない場合と解釈できない場合は false を返す
+func parseIfMatch(header string) (version int64, present, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false, false
+	}
+	if header == "*" {
+		return 0, true, true
+	}
+
+	// 弱いエンティティタグ（W/"3"）は更新の照合に使えない
+	if len(header) < 3 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, true, false
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, true, false
+	}
+	return version, true, true
+}
+
+func preconditionRequired(c echo.Context) error {
+	return problem.Respond(c, http.StatusPreconditionRequired, "If-Match header is required")
+}
+
+func preconditionFailed(c echo.Context) error {
+	return problem.Error(c, domainErrors.ErrItemVersionMismatch, "")
+}
+
+func versionConflict(c echo.Context) error {
+	return problem.Error(c, domainErrors.ErrItemVersionConflict, "")
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	jobController "Aicon-assignment/internal/interfaces/controller/jobs"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ExportHandler struct {
+	exportUsecase usecase.ExportUsecase
+	jobUsecase    usecase.JobUsecase
+}
+
+func NewExportHandler(exportUsecase usecase.ExportUsecase, jobUsecase usecase.JobUsecase) *ExportHandler {
+	return &ExportHandler{
+		exportUsecase: exportUsecase,
+		jobUsecase:    jobUsecase,
+	}
+}
+
+// ExportItems は一覧と同じ絞り込み条件のアイテムをファイルで返す（?format=xlsx、省略時 xlsx）。
+// Prefer: respond-async が指定された場合はジョブを開始して202を返す
+func (h *ExportHandler) ExportItems(c echo.Context) error {
+	jobController.SetLoadHeaders(c, h.jobUsecase.Load(entity.JobKindExport))
+
+	filter, validationErrors := parseItemFilter(c)
+	if len(validationErrors) > 0 {
+		return problem.ValidationFailed(c, validationErrors)
+	}
+
+	format := usecase.ExportFormat(c.QueryParam("format"))
+	if format == "" {
+		format = usecase.ExportFormatXLSX
+	}
+
+	if jobController.PrefersAsync(c) {
+		job, err := h.exportUsecase.StartExport(c.Request().Context(), format, filter)
+		if err != nil {
+			return h.respondExportError(c, err, "failed to start export")
+		}
+		return jobController.RespondAccepted(c, job)
+	}
+
+	file, err := h.exportUsecase.Export(c.Request().Context(), format, filter)
+	if err != nil {
+		if domainErrors.IsValidationError(err) || domainErrors.IsExchangeRateUnavailableError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to export items")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	return c.Blob(http.StatusOK, file.ContentType, file.Body)
+}
+
+// StartAccountingExport は会計ソフト向けの仕訳のCSVを作成するジョブを開始する（結果は GET /jobs/{id}/result）
+func (h *ExportHandler) StartAccountingExport(c echo.Context) error {
+	jobController.SetLoadHeaders(c, h.jobUsecase.Load(entity.JobKindExport))
+
+	var input usecase.AccountingExportInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	job, err := h.exportUsecase.StartAccountingExport(c.Request().Context(), input)
+	if err != nil {
+		return h.respondExportError(c, err, "failed to start accounting export")
+	}
+
+	return jobController.RespondAccepted(c, job)
+}
+
+// respondExportError はジョブを開始できなかった場合のエラーをステータスコードに変換する
+func (h *ExportHandler) respondExportError(c echo.Context, err error, message string) error {
+	if domainErrors.IsValidationError(err) {
+		return problem.Error(c, err, "")
+	}
+	if domainErrors.IsJobConflictError(err) {
+		return jobController.RespondConflict(c, err)
+	}
+	return problem.Respond(c, http.StatusInternalServerError, message)
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// PUT で省略できないアイテムの項目
+var replaceRequiredFields = []string{"name", "category", "brand", "purchase_price", "purchase_date"}
+
+// PATCH では変更できない（PUT で置き換える）アイテムの項目
+var replaceOnlyFields = []string{"category", "purchase_date"}
+
+// bodyFields はリクエストボディの JSON に含まれていた項目（値が null かどうか）
+type bodyFields map[string]bool
+
+// bindJSONFields はリクエストボディの JSON を dst に読み込み、省略された項目と null の項目を区別できるよう
+// ボディに含まれていた項目を返す。ボディが空の場合は項目のない JSON として扱う
+func bindJSONFields(c echo.Context, dst interface{}) (bodyFields, error) {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return bodyFields{}, nil
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, dst); err != nil {
+		return nil, err
+	}
+
+	fields := make(bodyFields, len(raw))
+	for name, value := range raw {
+		fields[name] = string(bytes.TrimSpace(value)) == "null"
+	}
+	return fields, nil
+}
+
+// validateReplaceFields は PUT のボディで省略された項目と null の項目をエラーにする
+func validateReplaceFields(fields bodyFields) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
+	for _, name := range replaceRequiredFields {
+		isNull, present := fields[name]
+		switch {
+		case !present:
+			errs.Add(name, domainErrors.CodeRequired, name+" is required: PUT replaces the whole item, use PATCH to update only some fields")
+		case isNull:
+			errs.Add(name, domainErrors.CodeNotNull, name+" must not be null")
+		}
+	}
+	return errs
+}
+
+// validatePatchFields は PATCH のボディで PUT でのみ変更できる項目と null の項目をエラーにする
+func validatePatchFields(fields bodyFields) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
+	for _, name := range replaceOnlyFields {
+		if _, present := fields[name]; present {
+			errs.Add(name, domainErrors.CodeNotAllowed, name+" cannot be changed with PATCH, use PUT to replace the whole item")
+		}
+	}
+	for _, name := range []string{"name", "brand", "purchase_price", "purchase_currency", "visibility", "org_id"} {
+		if fields[name] {
+			errs.Add(name, domainErrors.CodeNotNull, name+" must not be null: omit the field to keep the current value")
+		}
+	}
+	return errs
+}
+
+// clearNullFields は PATCH のボディで null を指定した condition・serial_number・notes・attributes を未設定への変更にする
+// （null のレスポンスをそのまま送り返せるようにするため、他の項目と異なり null を許可する）
+func clearNullFields(fields bodyFields, input *usecase.UpdateItemInput) {
+	if fields["condition"] {
+		unknown := string(entity.ConditionUnknown)
+		input.Condition = &unknown
+	}
+	if fields["serial_number"] {
+		none := ""
+		input.SerialNumber = &none
+	}
+	if fields["notes"] {
+		none := ""
+		input.Notes = &none
+	}
+	if fields["attributes"] {
+		none := entity.ItemAttributes{}
+		input.Attributes = &none
+	}
+}
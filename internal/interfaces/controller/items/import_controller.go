@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ImportHandler struct {
+	importUsecase usecase.ItemImportUsecase
+	// maxBytes は読み込むCSVの最大バイト数（gzip の場合は展開後。0以下は制限しない）
+	maxBytes int64
+}
+
+func NewImportHandler(importUsecase usecase.ItemImportUsecase, maxBytes int64) *ImportHandler {
+	return &ImportHandler{
+		importUsecase: importUsecase,
+		maxBytes:      maxBytes,
+	}
+}
+
+// ImportItems はリクエストボディのCSV（text/csv）のアイテムを取り込む。
+// Content-Encoding: gzip の場合は展開しながら読み込み、展開後のサイズが上限を超えた時点で413を返す
+func (h *ImportHandler) ImportItems(c echo.Context) error {
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || mediaType != "text/csv" {
+		return problem.Respond(c, http.StatusUnsupportedMediaType, "Content-Type must be text/csv")
+	}
+
+	var body io.ReadCloser = c.Request().Body
+	switch encoding := strings.ToLower(strings.TrimSpace(c.Request().Header.Get(echo.HeaderContentEncoding))); encoding {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return problem.Respond(c, http.StatusBadRequest, "request body is not valid gzip")
+		}
+		defer gz.Close()
+		body = gz
+	default:
+		return problem.Respond(c, http.StatusUnsupportedMediaType, "Content-Encoding must be gzip or identity")
+	}
+	if h.maxBytes > 0 {
+		body = http.MaxBytesReader(c.Response(), body, h.maxBytes)
+	}
+
+	result, err := h.importUsecase.Import(c.Request().Context(), body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return problem.Respond(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("CSV exceeds %d bytes (after decompression)", tooLarge.Limit))
+		}
+		return problem.Error(c, err, "failed to import items")
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MockItemImportUsecase struct {
+	mock.Mock
+}
+
+func (m *MockItemImportUsecase) Import(ctx context.Context, r io.Reader) (*usecase.ItemImportResult, error) {
+	// 取り込むCSVの内容で呼び出しを照合する
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx, string(body))
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ItemImportResult), args.Error(1)
+}
+
+const importCSV = "name,category,brand,purchase_price,purchase_date\nデイトナ,時計,ROLEX,1500000,2023-01-15\n"
+
+// テストの取り込みの上限（importCSV より大きく、繰り返した行より小さい）
+const importMaxBytes = 1024
+
+func TestImportHandler_ImportItems(t *testing.T) {
+	gzipped := func(s string) []byte {
+		var b bytes.Buffer
+		w := gzip.NewWriter(&b)
+		_, _ = w.Write([]byte(s))
+		_ = w.Close()
+		return b.Bytes()
+	}
+
+	tests := []struct {
+		name           string
+		contentType    string
+		encoding       string
+		body           []byte
+		setupMock      func(*MockItemImportUsecase)
+		expectedStatus int
+	}{
+		{
+			name:        "正常系: CSVを取り込む",
+			contentType: "text/csv; charset=utf-8",
+			body:        []byte(importCSV),
+			setupMock: func(m *MockItemImportUsecase) {
+				m.On("Import", mock.Anything, importCSV).Return(&usecase.ItemImportResult{Imported: 1, Errors: []usecase.ItemImportRowError{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "正常系: gzip で圧縮したCSVを展開して取り込む",
+			contentType: "text/csv",
+			encoding:    "gzip",
+			body:        gzipped(importCSV),
+			setupMock: func(m *MockItemImportUsecase) {
+				m.On("Import", mock.Anything, importCSV).Return(&usecase.ItemImportResult{Imported: 1, Errors: []usecase.ItemImportRowError{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: gzip ではないボディ",
+			contentType:    "text/csv",
+			encoding:       "gzip",
+			body:           []byte(importCSV),
+			setupMock:      func(m *MockItemImportUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 展開後のサイズが上限を超える",
+			contentType:    "text/csv",
+			encoding:       "gzip",
+			body:           gzipped(importCSV + strings.Repeat("デイトナ,時計,ROLEX,1500000,2023-01-15\n", 100)),
+			setupMock:      func(m *MockItemImportUsecase) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:        "異常系: 同じユーザーのジョブが実行中",
+			contentType: "text/csv",
+			body:        []byte(importCSV),
+			setupMock: func(m *MockItemImportUsecase) {
+				m.On("Import", mock.Anything, importCSV).Return(nil, &domainErrors.JobConflictError{JobID: 7})
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "異常系: 対応していない圧縮形式",
+			contentType:    "text/csv",
+			encoding:       "br",
+			body:           []byte(importCSV),
+			setupMock:      func(m *MockItemImportUsecase) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:           "異常系: CSV 以外のボディ",
+			contentType:    "application/json",
+			body:           []byte(`{}`),
+			setupMock:      func(m *MockItemImportUsecase) {},
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUsecase := new(MockItemImportUsecase)
+			tt.setupMock(mockUsecase)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/items/import", bytes.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			if tt.encoding != "" {
+				req.Header.Set(echo.HeaderContentEncoding, tt.encoding)
+			}
+			rec := httptest.NewRecorder()
+
+			err := NewImportHandler(mockUsecase, importMaxBytes).ImportItems(e.NewContext(req, rec))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), `"imported":1`)
+			}
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
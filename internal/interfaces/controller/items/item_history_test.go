@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 登録したアイテムをメモリに保持する ItemRepository（使うメソッドのみ実装する）
+type memoryItemRepository struct {
+	usecase.ItemRepository
+	items map[int64]*entity.Item
+}
+
+func (r *memoryItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	created := *item
+	created.ID = int64(len(r.items) + 1)
+	r.items[created.ID] = &created
+	return r.FindByID(ctx, created.ID)
+}
+
+func (r *memoryItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	item, ok := r.items[id]
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	found := *item
+	return &found, nil
+}
+
+// 登録と変更履歴をメモリに保持する ItemHistoryRepository
+type memoryItemHistoryRepository struct {
+	usecase.ItemHistoryRepository
+	items     *memoryItemRepository
+	histories []*entity.ItemHistory
+}
+
+func (r *memoryItemHistoryRepository) RecordCreate(ctx context.Context, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error) {
+	created := *item
+	created.ID = int64(len(r.items.items) + 1)
+	r.items.items[created.ID] = &created
+	for _, history := range histories {
+		history.ItemID = created.ID
+	}
+	r.histories = append(r.histories, histories...)
+	return r.items.FindByID(ctx, created.ID)
+}
+
+func (r *memoryItemHistoryRepository) RecordCreateMany(ctx context.Context, items []*entity.Item, histories [][]*entity.ItemHistory) error {
+	for i, item := range items {
+		if _, err := r.RecordCreate(ctx, item, histories[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *memoryItemHistoryRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemHistory, error) {
+	var histories []*entity.ItemHistory
+	for _, history := range r.histories {
+		if history.ItemID == itemID {
+			histories = append(histories, history)
+		}
+	}
+	return histories, nil
+}
+
+// 登録で送信された正規化する前の名前・ブランドは GET /items/{id}/history でのみ返し、アイテムの取得では返さないこと
+func TestItemHandler_CreateItem_RecordsRawInputsInHistory(t *testing.T) {
+	items := &memoryItemRepository{items: map[int64]*entity.Item{}}
+	historyRepo := &memoryItemHistoryRepository{items: items}
+	handler := NewItemHandler(usecase.NewItemUsecase(items, usecase.WithItemHistory(historyRepo)))
+	ctx := usecase.WithActor(context.Background(), &entity.User{ID: 1, Role: entity.RoleEditor})
+	e := echo.New()
+
+	body := `{"name": "  デイトナ 116500LN\t", "category": "時計", "brand": " ROLEX ", "purchase_price": 1500000, "purchase_date": "2023-01-15"}`
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body)).WithContext(ctx)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	require.NoError(t, handler.CreateItem(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var created map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "デイトナ 116500LN", created["name"])
+	assert.NotContains(t, rec.Body.String(), "raw_value")
+	id := strconv.FormatInt(int64(created["id"].(float64)), 10)
+
+	req = httptest.NewRequest(http.MethodGet, "/items/"+id+"/history", nil).WithContext(ctx)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/items/:id/history")
+	c.SetParamNames("id")
+	c.SetParamValues(id)
+	require.NoError(t, handler.GetItemHistory(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var histories []entity.ItemHistory
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &histories))
+	raw := map[string]*string{}
+	for _, history := range histories {
+		assert.Equal(t, entity.ItemHistoryActionCreate, history.Action)
+		assert.Nil(t, history.OldValue)
+		raw[history.Field] = history.RawValue
+	}
+	require.NotNil(t, raw["name"])
+	assert.Equal(t, "  デイトナ 116500LN\t", *raw["name"])
+	require.NotNil(t, raw["brand"])
+	assert.Equal(t, " ROLEX ", *raw["brand"])
+	assert.Nil(t, raw["category"])
+}
+
+// CSVで取り込んだアイテムも登録が変更履歴に残り、GET /items/{id}/history で空白を除く前の名前を確認できること
+func TestImportHandler_ImportItems_RecordsCreationInHistory(t *testing.T) {
+	items := &memoryItemRepository{items: map[int64]*entity.Item{}}
+	historyRepo := &memoryItemHistoryRepository{items: items}
+	importHandler := NewImportHandler(usecase.NewItemImportUsecase(items, usecase.WithImportHistory(historyRepo), usecase.WithImportJobs(usecase.NewJobUsecase())), 0)
+	itemHandler := NewItemHandler(usecase.NewItemUsecase(items, usecase.WithItemHistory(historyRepo)))
+	ctx := usecase.WithActor(context.Background(), &entity.User{ID: 1, Role: entity.RoleEditor})
+	e := echo.New()
+
+	body := "name,category,brand,purchase_price,purchase_date\n\" デイトナ 116500LN\",時計,ROLEX,1500000,2023-01-15\n"
+	req := httptest.NewRequest(http.MethodPost, "/items/import", strings.NewReader(body)).WithContext(ctx)
+	req.Header.Set(echo.HeaderContentType, "text/csv")
+	rec := httptest.NewRecorder()
+	require.NoError(t, importHandler.ImportItems(e.NewContext(req, rec)))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Len(t, items.items, 1)
+
+	req = httptest.NewRequest(http.MethodGet, "/items/1/history", nil).WithContext(ctx)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/items/:id/history")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	require.NoError(t, itemHandler.GetItemHistory(c))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var histories []entity.ItemHistory
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &histories))
+	require.NotEmpty(t, histories)
+	raw := map[string]*string{}
+	for _, history := range histories {
+		assert.Equal(t, entity.ItemHistoryActionCreate, history.Action)
+		assert.Equal(t, int64(1), history.ItemID)
+		raw[history.Field] = history.RawValue
+	}
+	require.NotNil(t, raw["name"])
+	assert.Equal(t, " デイトナ 116500LN", *raw["name"])
+}
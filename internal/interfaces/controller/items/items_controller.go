@@ -1,10 +1,15 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"unicode/utf8"
 
+	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
 	"Aicon-assignment/internal/usecase"
 
 	"github.com/labstack/echo/v4"
@@ -20,76 +25,68 @@ func NewItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
 	}
 }
 
-// エラーレスポンスの形式
-type ErrorResponse struct {
-	Error   string   `json:"error"`
-	Details []string `json:"details,omitempty"`
-}
-
 func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+	filter, validationErrors := parseItemFilter(c)
+	if len(validationErrors) > 0 {
+		return problem.ValidationFailed(c, validationErrors)
+	}
+
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), filter)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve items",
-		})
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve items")
 	}
 
-	return c.JSON(http.StatusOK, items)
+	return response.List(c, http.StatusOK, items)
 }
 
 func (h *ItemHandler) GetItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
 	}
 
 	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+			return problem.Respond(c, http.StatusNotFound, "item not found")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve item",
-		})
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve item")
 	}
 
+	setItemETag(c, item)
 	return c.JSON(http.StatusOK, item)
 }
 
 func (h *ItemHandler) CreateItem(c echo.Context) error {
 	var input usecase.CreateItemInput
 	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
 	}
 
 	// バリデーション
 	if validationErrors := validateCreateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+		return problem.ValidationFailed(c, validationErrors)
 	}
 
 	item, err := h.itemUsecase.CreateItem(c.Request().Context(), input)
 	if err != nil {
+		if domainErrors.IsForbiddenError(err) {
+			return forbidden(c)
+		}
 		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
+			return problem.Error(c, err, "")
+		}
+		if domainErrors.IsDuplicateSerialNumberError(err) {
+			return problem.Error(c, err, "")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to create item",
-		})
+		return problem.Respond(c, http.StatusInternalServerError, "failed to create item")
 	}
 
+	setItemETag(c, item)
 	return c.JSON(http.StatusCreated, item)
 }
 
@@ -98,113 +95,407 @@ func (h *ItemHandler) UpdateItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	// 他のクライアントの更新を上書きしないよう If-Match でバージョンの指定を必須にする
+	version, present, ok := parseIfMatch(c.Request().Header.Get(HeaderIfMatch))
+	if !present {
+		return preconditionRequired(c)
+	}
+	if !ok {
+		return preconditionFailed(c)
 	}
 
 	// Bind JSON request body
 	var input usecase.UpdateItemInput
-	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+	fields, err := bindJSONFields(c, &input)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
 	}
+	input.Version = version
 
 	// Validate input (at least one field must be provided)
+	if validationErrors := validatePatchFields(fields); len(validationErrors) > 0 {
+		return problem.ValidationFailed(c, validationErrors)
+	}
+	clearNullFields(fields, &input)
 	if validationErrors := validateUpdateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+		return problem.ValidationFailed(c, validationErrors)
 	}
 
 	// Call use case
 	item, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
 	if err != nil {
+		if domainErrors.IsForbiddenError(err) {
+			return forbidden(c)
+		}
 		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+			return problem.Respond(c, http.StatusNotFound, "item not found")
 		}
 		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
+			return problem.Error(c, err, "")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to update item",
-		})
+		if domainErrors.IsVersionMismatchError(err) {
+			return preconditionFailed(c)
+		}
+		if domainErrors.IsVersionConflictError(err) {
+			return versionConflict(c)
+		}
+		if domainErrors.IsDuplicateSerialNumberError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to update item")
 	}
 
+	setItemETag(c, item)
 	return c.JSON(http.StatusOK, item)
 }
 
+// ReplaceItem はアイテムの内容をリクエストボディで置き換える（PUT）。
+// 必須の項目は省略も null もできない。visibility の省略と null は private に、condition・serial_number・notes の省略と null は未設定に戻し、org_id の省略と null は所有者を変更しない
+func (h *ItemHandler) ReplaceItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	// 他のクライアントの更新を上書きしないよう If-Match でバージョンの指定を必須にする
+	version, present, ok := parseIfMatch(c.Request().Header.Get(HeaderIfMatch))
+	if !present {
+		return preconditionRequired(c)
+	}
+	if !ok {
+		return preconditionFailed(c)
+	}
+
+	var input usecase.ReplaceItemInput
+	fields, err := bindJSONFields(c, &input)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+	input.Version = version
+
+	if validationErrors := validateReplaceFields(fields); len(validationErrors) > 0 {
+		return problem.ValidationFailed(c, validationErrors)
+	}
+
+	item, err := h.itemUsecase.ReplaceItem(c.Request().Context(), id, input)
+	if err != nil {
+		if domainErrors.IsForbiddenError(err) {
+			return forbidden(c)
+		}
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "item not found")
+		}
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		if domainErrors.IsVersionMismatchError(err) {
+			return preconditionFailed(c)
+		}
+		if domainErrors.IsVersionConflictError(err) {
+			return versionConflict(c)
+		}
+		if domainErrors.IsDuplicateSerialNumberError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to replace item")
+	}
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
+
+// BulkUpdateItems は ids のアイテムに同じ部分更新を適用する（1件でも更新できない場合は何も更新しない）
+func (h *ItemHandler) BulkUpdateItems(c echo.Context) error {
+	var input usecase.BulkUpdateItemsInput
+	fields, err := bindJSONFields(c, &input)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	if validationErrors := validatePatchFields(fields); len(validationErrors) > 0 {
+		return problem.ValidationFailed(c, validationErrors)
+	}
+	clearNullFields(fields, &input.UpdateItemInput)
+	if validationErrors := validateUpdateItemInput(input.UpdateItemInput); len(validationErrors) > 0 {
+		return problem.ValidationFailed(c, validationErrors)
+	}
+
+	items, err := h.itemUsecase.BulkUpdateItems(c.Request().Context(), input)
+	if err != nil {
+		return bulkUpdateError(c, err)
+	}
+
+	return response.List(c, http.StatusOK, items)
+}
+
+// 複数のアイテムの更新に失敗した場合のレスポンス（見つからないアイテムの ID はそのまま返す）
+func bulkUpdateError(c echo.Context, err error) error {
+	if domainErrors.IsForbiddenError(err) {
+		return forbidden(c)
+	}
+	if domainErrors.IsNotFoundError(err) {
+		return problem.Respond(c, http.StatusNotFound, err.Error())
+	}
+	if domainErrors.IsValidationError(err) {
+		return problem.Error(c, err, "")
+	}
+	if domainErrors.IsVersionConflictError(err) {
+		return versionConflict(c)
+	}
+	if domainErrors.IsDuplicateSerialNumberError(err) {
+		return problem.Error(c, err, "")
+	}
+	return problem.Respond(c, http.StatusInternalServerError, "failed to update items")
+}
+
 func (h *ItemHandler) DeleteItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
 	}
 
 	err = h.itemUsecase.DeleteItem(c.Request().Context(), id)
 	if err != nil {
+		if domainErrors.IsForbiddenError(err) {
+			return forbidden(c)
+		}
 		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
+			return problem.Respond(c, http.StatusNotFound, "item not found")
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to delete item",
-		})
+		return problem.Respond(c, http.StatusInternalServerError, "failed to delete item")
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+// GetRecentlyViewedItems は操作者が最近詳細を表示したアイテムを新しい順に返す
+func (h *ItemHandler) GetRecentlyViewedItems(c echo.Context) error {
+	items, err := h.itemUsecase.GetRecentlyViewedItems(c.Request().Context())
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve recently viewed items")
+	}
+
+	return response.List(c, http.StatusOK, items)
+}
+
+// GetItemHistory はアイテムの変更履歴を古い順に返す
+func (h *ItemHandler) GetItemHistory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	histories, err := h.itemUsecase.GetItemHistory(c.Request().Context(), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "item not found")
+		}
+		if domainErrors.IsValidationError(err) {
+			return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve item history")
+	}
+
+	return response.List(c, http.StatusOK, histories)
+}
+
+func (h *ItemHandler) SearchItems(c echo.Context) error {
+	items, err := h.itemUsecase.SearchItems(c.Request().Context(), c.QueryParam("q"))
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to search items")
+	}
+
+	return response.List(c, http.StatusOK, items)
+}
+
+// クイック登録のプレビューのレスポンス
+type QuickAddResponse struct {
+	Items []usecase.QuickAddPreview `json:"items"`
+}
+
+func (h *ItemHandler) PreviewQuickAdd(c echo.Context) error {
+	var input usecase.QuickAddInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	previews, err := h.itemUsecase.PreviewQuickAdd(c.Request().Context(), input)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to parse items")
+	}
+
+	return c.JSON(http.StatusOK, QuickAddResponse{Items: response.Slice(previews)})
+}
+
+func (h *ItemHandler) ParseItem(c echo.Context) error {
+	var input usecase.ParseItemInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	draft, err := h.itemUsecase.ParseItemText(c.Request().Context(), input)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to parse text")
+	}
+
+	return c.JSON(http.StatusOK, draft)
+}
+
+// GetSummary はカテゴリー別集計を返す（?as_of=YYYY-MM-DD でその日に所有していたアイテムのみ集計する）
 func (h *ItemHandler) GetSummary(c echo.Context) error {
-	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
+	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context(), c.QueryParam("as_of"))
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve summary")
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GetBrandSummary はブランドごとの件数と購入価格の合計・平均を返す（?limit= で件数の多いブランドに絞る）
+func (h *ItemHandler) GetBrandSummary(c echo.Context) error {
+	limit := 0
+	if v := c.QueryParam("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return problem.Respond(c, http.StatusBadRequest, "invalid limit parameter")
+		}
+		limit = n
+	}
+
+	summary, err := h.itemUsecase.GetBrandSummary(c.Request().Context(), limit)
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve brand summary")
+	}
+
+	return c.JSON(http.StatusOK, summary)
+}
+
+// GetTopItems は購入価格または評価額の高いアイテムを返す（?n=10&by=purchase_price|current_value）
+func (h *ItemHandler) GetTopItems(c echo.Context) error {
+	n := 0
+	if v := c.QueryParam("n"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			return problem.Respond(c, http.StatusBadRequest, "invalid n parameter")
+		}
+		n = parsed
+	}
+
+	top, err := h.itemUsecase.GetTopItems(c.Request().Context(), n, c.QueryParam("by"))
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve top items")
+	}
+
+	return c.JSON(http.StatusOK, top)
+}
+
+// GetDashboardStats はモバイルのダッシュボード用の集計をまとめて返す
+func (h *ItemHandler) GetDashboardStats(c echo.Context) error {
+	stats, err := h.itemUsecase.GetDashboardStats(c.Request().Context())
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve stats")
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// GetPortfolioSummary はホーム画面用の手放していないアイテムの件数と購入価格・評価額の合計、カテゴリーごとの内訳を返す
+func (h *ItemHandler) GetPortfolioSummary(c echo.Context) error {
+	summary, err := h.itemUsecase.GetPortfolioSummary(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve summary",
-		})
+		return problem.Error(c, err, "failed to retrieve portfolio summary")
 	}
 
 	return c.JSON(http.StatusOK, summary)
 }
 
-func validateCreateItemInput(input usecase.CreateItemInput) []string {
-	var errs []string
+// GetQuickStats はヘッダー表示用のアイテムの件数と購入価格の合計を返す
+func (h *ItemHandler) GetQuickStats(c echo.Context) error {
+	stats, err := h.itemUsecase.GetQuickStats(c.Request().Context())
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve quick stats")
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
+
+// 権限が不足している場合のレスポンス
+func forbidden(c echo.Context) error {
+	return problem.Respond(c, http.StatusForbidden, "insufficient permissions")
+}
+
+// クエリパラメータから絞り込み条件を組み立てる
+func parseItemFilter(c echo.Context) (entity.ItemFilter, domainErrors.ValidationErrors) {
+	return entity.ParseItemFilterQuery(c.QueryParams())
+}
+
+func validateCreateItemInput(input usecase.CreateItemInput) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
 
 	// Basic required field validation
 	if input.Name == "" {
-		errs = append(errs, "name is required")
+		errs.Add("name", domainErrors.CodeRequired, "name is required")
 	}
 	if input.Category == "" {
-		errs = append(errs, "category is required")
-	}
-	if input.Brand == "" {
-		errs = append(errs, "brand is required")
+		errs.Add("category", domainErrors.CodeRequired, "category is required")
 	}
+	// brand は省略するとカテゴリーの既定値を使うため、ユースケースで検証する
 	if input.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+		errs.Add("purchase_date", domainErrors.CodeRequired, "purchase_date is required")
 	}
-	if input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+	validatePurchasePrice(&errs, input.PurchasePrice, &input.PurchaseCurrency)
+	if input.PurchaseCurrency != "" {
+		validatePurchaseCurrency(&errs, input.PurchaseCurrency)
 	}
 
 	return errs
 }
 
-func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
-	var errs []string
+// validatePurchasePrice は購入価格の金額（補助単位を小数にした10進数）を検証する。
+// 通貨が分からない場合（部分更新で通貨を省略した場合）は符号のみ検証し、端数と上限はユースケースで検証する
+func validatePurchasePrice(errs *domainErrors.ValidationErrors, amount entity.Decimal, currency *string) {
+	if amount.Units < 0 {
+		errs.Add("purchase_price", domainErrors.CodeOutOfRange, "purchase_price must be 0 or greater")
+		return
+	}
+	if currency == nil {
+		return
+	}
+	price, err := entity.MoneyFromDecimal(amount, *currency)
+	switch {
+	case err != nil:
+		errs.Add("purchase_price", domainErrors.CodeInvalidFormat, "purchase_price "+err.Error())
+	case price.Amount > entity.MaxPrice:
+		errs.Add("purchase_price", domainErrors.CodeOutOfRange, fmt.Sprintf("purchase_price must be %s or less", entity.Money{Amount: entity.MaxPrice, Currency: price.Currency}.Decimal()))
+	}
+}
+
+// validatePurchaseCurrency は購入価格の通貨が対応している通貨かを検証する（大文字・小文字は区別しない）
+func validatePurchaseCurrency(errs *domainErrors.ValidationErrors, currency string) {
+	if !entity.NewMoney(0, currency).Currency.IsValid() {
+		errs.Add("purchase_currency", domainErrors.CodeInvalidChoice, "purchase_currency must be one of: JPY, USD, EUR")
+	}
+}
+
+func validateUpdateItemInput(input usecase.UpdateItemInput) domainErrors.ValidationErrors {
+	var errs domainErrors.ValidationErrors
 
 	// Check if at least one field is provided
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
-		errs = append(errs, "at least one field (name, brand, purchase_price) must be provided")
+	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil && input.PurchaseCurrency == nil && input.Visibility == nil && input.Condition == nil && input.SerialNumber == nil && input.Notes == nil && input.Attributes == nil && input.OrgID == nil {
+		errs.Add("", domainErrors.CodeRequired, "at least one field (name, brand, purchase_price, purchase_currency, visibility, condition, serial_number, notes, attributes, org_id) must be provided")
 		return errs
 	}
 
@@ -212,25 +503,27 @@ func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
 	if input.Name != nil {
 		name := *input.Name
 		if name == "" {
-			errs = append(errs, "name cannot be empty")
-		} else if len(name) > 100 {
-			errs = append(errs, "name must be 100 characters or less")
+			errs.Add("name", domainErrors.CodeRequired, "name cannot be empty")
+		} else if utf8.RuneCountInString(name) > entity.ItemNameMaxLength {
+			errs.Add("name", domainErrors.CodeTooLong, fmt.Sprintf("name must be %d characters or less", entity.ItemNameMaxLength))
 		}
 	}
 
 	if input.Brand != nil {
 		brand := *input.Brand
 		if brand == "" {
-			errs = append(errs, "brand cannot be empty")
-		} else if len(brand) > 100 {
-			errs = append(errs, "brand must be 100 characters or less")
+			errs.Add("brand", domainErrors.CodeRequired, "brand cannot be empty")
+		} else if utf8.RuneCountInString(brand) > entity.ItemBrandMaxLength {
+			errs.Add("brand", domainErrors.CodeTooLong, fmt.Sprintf("brand must be %d characters or less", entity.ItemBrandMaxLength))
 		}
 	}
 
 	if input.PurchasePrice != nil {
-		if *input.PurchasePrice < 0 {
-			errs = append(errs, "purchase_price must be 0 or greater")
-		}
+		validatePurchasePrice(&errs, *input.PurchasePrice, input.PurchaseCurrency)
+	}
+
+	if input.PurchaseCurrency != nil {
+		validatePurchaseCurrency(&errs, *input.PurchaseCurrency)
 	}
 
 	return errs
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
 	"Aicon-assignment/internal/usecase"
 )
 
@@ -24,8 +27,8 @@ type MockItemUsecase struct {
 	mock.Mock
 }
 
-func (m *MockItemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
+func (m *MockItemUsecase) GetAllItems(ctx context.Context, filter entity.ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -56,24 +59,203 @@ func (m *MockItemUsecase) UpdateItem(ctx context.Context, id int64, input usecas
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemUsecase) ReplaceItem(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) BulkUpdateItems(ctx context.Context, input usecase.BulkUpdateItemsInput) ([]*entity.Item, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetRecentlyViewedItems(ctx context.Context) ([]*entity.RecentlyViewedItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.RecentlyViewedItem), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetItemHistory(ctx context.Context, id int64) ([]*entity.ItemHistory, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemHistory), args.Error(1)
+}
+
 func (m *MockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.CategorySummary, error) {
+func (m *MockItemUsecase) SearchItems(ctx context.Context, query string) ([]*entity.SearchResult, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SearchResult), args.Error(1)
+}
+
+func (m *MockItemUsecase) PreviewQuickAdd(ctx context.Context, input usecase.QuickAddInput) ([]usecase.QuickAddPreview, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.QuickAddPreview), args.Error(1)
+}
+
+func (m *MockItemUsecase) ParseItemText(ctx context.Context, input usecase.ParseItemInput) (*usecase.ItemDraft, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.ItemDraft), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetNamingSuggestions(ctx context.Context) ([]usecase.NamingSuggestion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]usecase.NamingSuggestion), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetPurchaseTrend(ctx context.Context, groupBy string) (*usecase.PurchaseTrendReport, error) {
+	args := m.Called(ctx, groupBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PurchaseTrendReport), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetValueChangeReport(ctx context.Context) (*usecase.ValueChangeReport, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
+	return args.Get(0).(*usecase.ValueChangeReport), args.Error(1)
+}
+
+func (m *MockItemUsecase) ApplyNaming(ctx context.Context, input usecase.ApplyNamingInput) ([]*entity.Item, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) BulkRecategorize(ctx context.Context, input usecase.BulkRecategorizeInput) (*usecase.BulkRecategorizeResult, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BulkRecategorizeResult), args.Error(1)
+}
+
+func (m *MockItemUsecase) ChangeItemStatus(ctx context.Context, id int64, input usecase.ChangeItemStatusInput) (*entity.Item, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemUsecase) RecordSale(ctx context.Context, id int64, input usecase.RecordSaleInput) (*entity.ItemSale, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemSale), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetSale(ctx context.Context, id int64) (*entity.ItemSale, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemSale), args.Error(1)
+}
+
+func (m *MockItemUsecase) RecordValuation(ctx context.Context, id int64, input usecase.RecordValuationInput) (*entity.ItemValuation, error) {
+	args := m.Called(ctx, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemValuation), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetValuations(ctx context.Context, id int64) ([]*entity.ItemValuation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemValuation), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetCategorySummary(ctx context.Context, asOf string) (*usecase.CategorySummary, error) {
+	args := m.Called(ctx, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).(*usecase.CategorySummary), args.Error(1)
 }
 
+func (m *MockItemUsecase) GetBrandSummary(ctx context.Context, limit int) (*usecase.BrandSummary, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.BrandSummary), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetTopItems(ctx context.Context, n int, by string) (*usecase.TopItems, error) {
+	args := m.Called(ctx, n, by)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.TopItems), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetDashboardStats(ctx context.Context) (*usecase.DashboardStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.DashboardStats), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetPortfolioSummary(ctx context.Context) (*usecase.PortfolioSummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.PortfolioSummary), args.Error(1)
+}
+
+func (m *MockItemUsecase) GetQuickStats(ctx context.Context) (*usecase.QuickStats, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*usecase.QuickStats), args.Error(1)
+}
+
 func TestItemHandler_UpdateItem(t *testing.T) {
 	tests := []struct {
-		name           string
-		id             string
-		requestBody    interface{}
+		name        string
+		id          string
+		requestBody interface{}
+		// ifMatch は If-Match ヘッダー（空の場合は "1"、omitIfMatch の場合は送らない）
+		ifMatch        string
+		omitIfMatch    bool
 		setupMock      func(*MockItemUsecase)
 		expectedStatus int
 		expectedError  string
@@ -86,13 +268,14 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				"name": "更新された名前",
 			},
 			setupMock: func(mockUsecase *MockItemUsecase) {
-				updatedItem, _ := entity.NewItem("更新された名前", "時計", "初期ブランド", 100000, "2023-01-01")
+				updatedItem, _ := entity.NewItem("更新された名前", "時計", "初期ブランド", entity.JPY(100000), "2023-01-01")
 				updatedItem.ID = 1
+				updatedItem.Version = 2
 				updatedItem.CreatedAt = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 				updatedItem.UpdatedAt = time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
 				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
 					return input.Name != nil && *input.Name == "更新された名前" &&
-						input.Brand == nil && input.PurchasePrice == nil
+						input.Brand == nil && input.PurchasePrice == nil && input.Version == 1
 				})).Return(updatedItem, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -102,7 +285,109 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				require.NoError(t, err)
 				assert.Equal(t, "更新された名前", item.Name)
 				assert.Equal(t, int64(1), item.ID)
+				assert.Equal(t, `"2"`, rec.Header().Get(HeaderETag))
+			},
+		},
+		{
+			name:        "正常系: condition の null は未設定に戻す",
+			id:          "1",
+			requestBody: `{"condition": null}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
+					return input.Condition != nil && *input.Condition == ""
+				})).Return(&entity.Item{ID: 1, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Contains(t, rec.Body.String(), `"condition":null`)
+			},
+		},
+		{
+			name:        "正常系: serial_number の null は未設定に戻す",
+			id:          "1",
+			requestBody: `{"serial_number": null}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
+					return input.SerialNumber != nil && *input.SerialNumber == ""
+				})).Return(&entity.Item{ID: 1, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Contains(t, rec.Body.String(), `"serial_number":null`)
+			},
+		},
+		{
+			name:        "正常系: notes の null は未設定に戻す",
+			id:          "1",
+			requestBody: `{"notes": null}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
+					return input.Notes != nil && *input.Notes == ""
+				})).Return(&entity.Item{ID: 1, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, rec *httptest.ResponseRecorder) {
+				assert.Contains(t, rec.Body.String(), `"notes":null`)
+			},
+		},
+		{
+			name:        "正常系: If-Match * はバージョンを照合しない",
+			id:          "1",
+			requestBody: map[string]interface{}{"name": "上書き"},
+			ifMatch:     "*",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
+					return input.Version == 0
+				})).Return(&entity.Item{ID: 1, Version: 5}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: If-Match がない",
+			id:             "1",
+			requestBody:    map[string]interface{}{"name": "更新された名前"},
+			omitIfMatch:    true,
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusPreconditionRequired,
+			expectedError:  "If-Match header is required",
+		},
+		{
+			name:           "異常系: 弱いエンティティタグ",
+			id:             "1",
+			requestBody:    map[string]interface{}{"name": "更新された名前"},
+			ifMatch:        `W/"1"`,
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:        "異常系: バージョンが異なる",
+			id:          "1",
+			requestBody: map[string]interface{}{"name": "更新された名前"},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.Anything).Return((*entity.Item)(nil), domainErrors.ErrItemVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedError:  "item has been modified",
+		},
+		{
+			name:        "異常系: 更新中に他のリクエストが更新した",
+			id:          "1",
+			requestBody: map[string]interface{}{"name": "更新された名前"},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.Anything).Return((*entity.Item)(nil), fmt.Errorf("failed to update item: %w", domainErrors.ErrItemVersionConflict))
 			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "item was modified by another request",
+		},
+		{
+			name:        "異常系: 同じシリアル番号のアイテムが登録済み",
+			id:          "1",
+			requestBody: map[string]interface{}{"serial_number": "A1234567"},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.Anything).Return((*entity.Item)(nil), fmt.Errorf("failed to update item: %w", domainErrors.ErrDuplicateSerialNumber))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  "same serial number",
 		},
 		{
 			name: "正常系: brandのみ更新",
@@ -111,7 +396,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				"brand": "更新されたブランド",
 			},
 			setupMock: func(mockUsecase *MockItemUsecase) {
-				updatedItem, _ := entity.NewItem("初期アイテム", "時計", "更新されたブランド", 100000, "2023-01-01")
+				updatedItem, _ := entity.NewItem("初期アイテム", "時計", "更新されたブランド", entity.JPY(100000), "2023-01-01")
 				updatedItem.ID = 1
 				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
 					return input.Brand != nil && *input.Brand == "更新されたブランド" &&
@@ -133,10 +418,10 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				"purchase_price": 200000,
 			},
 			setupMock: func(mockUsecase *MockItemUsecase) {
-				updatedItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 200000, "2023-01-01")
+				updatedItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", entity.JPY(200000), "2023-01-01")
 				updatedItem.ID = 1
 				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
-					return input.PurchasePrice != nil && *input.PurchasePrice == 200000 &&
+					return input.PurchasePrice != nil && *input.PurchasePrice == entity.Decimal{Units: 200000} &&
 						input.Name == nil && input.Brand == nil
 				})).Return(updatedItem, nil)
 			},
@@ -145,7 +430,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				var item entity.Item
 				err := json.Unmarshal(rec.Body.Bytes(), &item)
 				require.NoError(t, err)
-				assert.Equal(t, 200000, item.PurchasePrice)
+				assert.Equal(t, 200000, item.PurchasePrice.Amount)
 			},
 		},
 		{
@@ -157,12 +442,12 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				"purchase_price": 300000,
 			},
 			setupMock: func(mockUsecase *MockItemUsecase) {
-				updatedItem, _ := entity.NewItem("新しい名前", "時計", "新しいブランド", 300000, "2023-01-01")
+				updatedItem, _ := entity.NewItem("新しい名前", "時計", "新しいブランド", entity.JPY(300000), "2023-01-01")
 				updatedItem.ID = 1
 				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.UpdateItemInput) bool {
 					return input.Name != nil && *input.Name == "新しい名前" &&
 						input.Brand != nil && *input.Brand == "新しいブランド" &&
-						input.PurchasePrice != nil && *input.PurchasePrice == 300000
+						input.PurchasePrice != nil && *input.PurchasePrice == entity.Decimal{Units: 300000}
 				})).Return(updatedItem, nil)
 			},
 			expectedStatus: http.StatusOK,
@@ -172,7 +457,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 				require.NoError(t, err)
 				assert.Equal(t, "新しい名前", item.Name)
 				assert.Equal(t, "新しいブランド", item.Brand)
-				assert.Equal(t, 300000, item.PurchasePrice)
+				assert.Equal(t, 300000, item.PurchasePrice.Amount)
 			},
 		},
 		{
@@ -207,6 +492,29 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "validation failed",
 		},
+		{
+			name: "異常系: PATCHではcategoryを変更できない",
+			id:   "1",
+			requestBody: map[string]interface{}{
+				"name":     "更新された名前",
+				"category": "バッグ",
+			},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				// UpdateItemは呼ばれない
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
+		{
+			name:        "異常系: nullは指定できない",
+			id:          "1",
+			requestBody: `{"name": null}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				// UpdateItemは呼ばれない
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
 		{
 			name: "異常系: nameが空文字",
 			id:   "1",
@@ -223,7 +531,7 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 			name: "異常系: nameが100文字超過",
 			id:   "1",
 			requestBody: map[string]interface{}{
-				"name": "ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム",
+				"name": strings.Repeat("あ", 101),
 			},
 			setupMock: func(mockUsecase *MockItemUsecase) {
 				// UpdateItemは呼ばれない
@@ -243,6 +551,31 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedError:  "validation failed",
 		},
+		{
+			name: "異常系: 通貨の補助単位より細かい端数",
+			id:   "1",
+			requestBody: map[string]interface{}{
+				"purchase_price":    19.999,
+				"purchase_currency": "USD",
+			},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				// UpdateItemは呼ばれない
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
+		{
+			name: "異常系: 対応していない通貨",
+			id:   "1",
+			requestBody: map[string]interface{}{
+				"purchase_currency": "GBP",
+			},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				// UpdateItemは呼ばれない
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
 		{
 			name: "異常系: アイテムが見つからない",
 			id:   "999",
@@ -255,6 +588,18 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			expectedError:  "item not found",
 		},
+		{
+			name: "異常系: 権限不足（閲覧者）",
+			id:   "1",
+			requestBody: map[string]interface{}{
+				"name": "更新された名前",
+			},
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("UpdateItem", mock.Anything, int64(1), mock.Anything).Return((*entity.Item)(nil), domainErrors.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+			expectedError:  "insufficient permissions",
+		},
 		{
 			name: "異常系: バリデーションエラー（use case層）",
 			id:   "1",
@@ -302,6 +647,13 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 			// Create HTTP request
 			req := httptest.NewRequest(http.MethodPatch, "/items/"+tt.id, bytes.NewBuffer(reqBody))
 			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if !tt.omitIfMatch {
+				ifMatch := tt.ifMatch
+				if ifMatch == "" {
+					ifMatch = `"1"`
+				}
+				req.Header.Set(HeaderIfMatch, ifMatch)
+			}
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetPath("/items/:id")
@@ -319,10 +671,10 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 			if tt.expectedStatus >= 400 {
 				// Check error response body
 				if rec.Body.Len() > 0 {
-					var errorResp ErrorResponse
+					var errorResp problem.Problem
 					err := json.Unmarshal(rec.Body.Bytes(), &errorResp)
 					if err == nil {
-						assert.Contains(t, errorResp.Error, tt.expectedError)
+						assert.Contains(t, errorResp.Detail, tt.expectedError)
 					}
 				}
 			} else {
@@ -337,3 +689,725 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 	}
 }
 
+func TestItemHandler_ReplaceItem(t *testing.T) {
+	fullBody := `{"name": "ロレックス サブマリーナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1200000, "purchase_date": "2023-06-01"}`
+
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		omitIfMatch    bool
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedErrors domainErrors.ValidationErrors
+	}{
+		{
+			name: "正常系: すべての項目を置き換える",
+			id:   "1",
+			body: fullBody,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				replaced, _ := entity.NewItem("ロレックス サブマリーナ", "時計", "ROLEX", entity.JPY(1200000), "2023-06-01")
+				replaced.ID = 1
+				replaced.Version = 2
+				mockUsecase.On("ReplaceItem", mock.Anything, int64(1), usecase.ReplaceItemInput{
+					Name:          "ロレックス サブマリーナ",
+					Category:      "時計",
+					Brand:         "ROLEX",
+					PurchasePrice: entity.Decimal{Units: 1200000},
+					PurchaseDate:  "2023-06-01",
+					Version:       1,
+				}).Return(replaced, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "正常系: nullのvisibilityとorg_idは省略と同じ",
+			id:   "1",
+			body: `{"name": "ロレックス サブマリーナ", "category": "時計", "brand": "ROLEX", "purchase_price": 1200000, "purchase_date": "2023-06-01", "visibility": null, "org_id": null}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ReplaceItem", mock.Anything, int64(1), mock.MatchedBy(func(input usecase.ReplaceItemInput) bool {
+					return input.Visibility == "" && input.OrgID == nil
+				})).Return(&entity.Item{ID: 1, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "異常系: 省略した項目とnullの項目",
+			id:   "1",
+			body: `{"name": "ロレックス サブマリーナ", "brand": null, "purchase_price": 1200000}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				// ReplaceItemは呼ばれない
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: domainErrors.ValidationErrors{
+				{Field: "category", Code: domainErrors.CodeRequired, Message: "category is required: PUT replaces the whole item, use PATCH to update only some fields"},
+				{Field: "brand", Code: domainErrors.CodeNotNull, Message: "brand must not be null"},
+				{Field: "purchase_date", Code: domainErrors.CodeRequired, Message: "purchase_date is required: PUT replaces the whole item, use PATCH to update only some fields"},
+			},
+		},
+		{
+			name: "異常系: 置き換える内容の検証エラーは項目ごとに返す",
+			id:   "1",
+			body: fullBody,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ReplaceItem", mock.Anything, int64(1), mock.Anything).Return(nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, domainErrors.ValidationErrors{
+					{Field: "purchase_date", Code: domainErrors.CodeInvalidFormat, Message: "purchase_date must be in YYYY-MM-DD format"},
+				}))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: domainErrors.ValidationErrors{
+				{Field: "purchase_date", Code: domainErrors.CodeInvalidFormat, Message: "purchase_date must be in YYYY-MM-DD format"},
+			},
+		},
+		{
+			name:           "異常系: If-Matchなし",
+			id:             "1",
+			body:           fullBody,
+			omitIfMatch:    true,
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   "999",
+			body: fullBody,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ReplaceItem", mock.Anything, int64(999), mock.Anything).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "異常系: バージョンの不一致",
+			id:   "1",
+			body: fullBody,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ReplaceItem", mock.Anything, int64(1), mock.Anything).Return(nil, domainErrors.ErrItemVersionMismatch)
+			},
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodPut, "/items/"+tt.id, strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			if !tt.omitIfMatch {
+				req.Header.Set(HeaderIfMatch, `"1"`)
+			}
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			require.NoError(t, handler.ReplaceItem(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, `"2"`, rec.Header().Get(HeaderETag))
+			}
+			if tt.expectedErrors != nil {
+				var errorResp problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedErrors, errorResp.Errors)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetItems(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:  "正常系: 絞り込み条件をユースケースに渡す",
+			query: "?category=%E6%99%82%E8%A8%88&brand=ROLEX&min_price=100&max_price=200&purchase_date_from=2023-01-01&purchase_date_to=2023-12-31",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, mock.MatchedBy(func(f entity.ItemFilter) bool {
+					return f.Category == "時計" && f.Brand == "ROLEX" &&
+						f.MinPurchasePrice != nil && *f.MinPurchasePrice == 100 &&
+						f.MaxPurchasePrice != nil && *f.MaxPurchasePrice == 200 &&
+						f.PurchaseDateFrom == "2023-01-01" && f.PurchaseDateTo == "2023-12-31"
+				})).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "正常系: 価格の範囲は purchase_currency の最小単位にする",
+			query: "?purchase_currency=USD&min_price=99.5&max_price=200",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, mock.MatchedBy(func(f entity.ItemFilter) bool {
+					return f.PurchaseCurrency == entity.CurrencyUSD &&
+						f.MinPurchasePrice != nil && *f.MinPurchasePrice == 9950 &&
+						f.MaxPurchasePrice != nil && *f.MaxPurchasePrice == 20000
+				})).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "正常系: 状態で絞り込む",
+			query: "?condition=%E6%96%B0%E5%93%81",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, entity.ItemFilter{Condition: entity.ConditionNew}).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "正常系: 所有状況で絞り込む",
+			query: "?status=listed_for_sale",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, entity.ItemFilter{Status: entity.ItemStatusListedForSale}).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "正常系: タグは正規化して複数指定できる",
+			query: "?tag=+Vintage+&tag=%E7%AE%B1%E3%81%82%E3%82%8A",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, entity.ItemFilter{Tags: []string{"vintage", "箱あり"}}).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "正常系: 属性で絞り込める（空の値は条件なし）",
+			query: "?attr.movement=%E8%87%AA%E5%8B%95%E5%B7%BB%E3%81%8D&attr.metal=",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, entity.ItemFilter{Attributes: map[string]string{"movement": "自動巻き"}}).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 空のタグ",
+			query:          "?tag=+",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
+		{
+			name:  "正常系: 条件なし",
+			query: "",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, entity.ItemFilter{}).Return([]*entity.Item{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 価格が数値でない",
+			query:          "?min_price=abc",
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
+		{
+			name:  "異常系: ユースケースのバリデーションエラー",
+			query: "?purchase_date_from=2023/01/01",
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("GetAllItems", mock.Anything, mock.Anything).
+					Return(nil, domainErrors.ErrInvalidInput)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "validation failed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := handler.GetItems(c)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedError != "" {
+				assert.Equal(t, problem.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+				var errorResp problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedError, errorResp.Detail)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_ChangeItemStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+		expectedErrors domainErrors.ValidationErrors
+	}{
+		{
+			name: "正常系: 所有状況を変更する",
+			id:   "1",
+			body: `{"status": "sold"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ChangeItemStatus", mock.Anything, int64(1), usecase.ChangeItemStatusInput{Status: "sold"}).
+					Return(&entity.Item{ID: 1, Status: entity.ItemStatusSold, Version: 2}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "異常系: 所有状況の指定がない",
+			id:             "1",
+			body:           `{}`,
+			setupMock:      func(mockUsecase *MockItemUsecase) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: domainErrors.ValidationErrors{
+				{Field: "status", Code: domainErrors.CodeRequired, Message: "status is required"},
+			},
+		},
+		{
+			name: "異常系: 変更できない所有状況",
+			id:   "1",
+			body: `{"status": "owned"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ChangeItemStatus", mock.Anything, int64(1), mock.Anything).Return(nil, domainErrors.ValidationErrors{
+					{Field: "status", Code: domainErrors.CodeNotAllowed, Message: "status cannot be changed from sold to owned"},
+				})
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: domainErrors.ValidationErrors{
+				{Field: "status", Code: domainErrors.CodeNotAllowed, Message: "status cannot be changed from sold to owned"},
+			},
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   "999",
+			body: `{"status": "sold"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ChangeItemStatus", mock.Anything, int64(999), mock.Anything).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "異常系: 変更できないアイテム",
+			id:   "1",
+			body: `{"status": "sold"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("ChangeItemStatus", mock.Anything, int64(1), mock.Anything).Return(nil, domainErrors.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodPatch, "/items/"+tt.id+"/status", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id/status")
+			c.SetParamNames("id")
+			c.SetParamValues(tt.id)
+
+			require.NoError(t, handler.ChangeItemStatus(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, `"2"`, rec.Header().Get(HeaderETag))
+			}
+			if tt.expectedErrors != nil {
+				var errorResp problem.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &errorResp))
+				assert.Equal(t, tt.expectedErrors, errorResp.Errors)
+			}
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_RecordSale(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 売却を記録する",
+			body: `{"sold_price": 1800000, "sold_date": "2024-05-31", "fees": 90000}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("RecordSale", mock.Anything, int64(1), usecase.RecordSaleInput{
+					SoldPrice: &entity.Decimal{Units: 1800000}, SoldDate: "2024-05-31", Fees: entity.Decimal{Units: 90000},
+				}).Return(&entity.ItemSale{ID: 5, ItemID: 1}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "異常系: 売却を記録済み",
+			body: `{"sold_price": 1800000, "sold_date": "2024-05-31"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("RecordSale", mock.Anything, int64(1), mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "異常系: 変更できないアイテム",
+			body: `{"sold_price": 1800000, "sold_date": "2024-05-31"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("RecordSale", mock.Anything, int64(1), mock.Anything).Return(nil, domainErrors.ErrForbidden)
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodPost, "/items/1/sale", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id/sale")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			require.NoError(t, handler.RecordSale(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_RecordValuation(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		setupMock      func(*MockItemUsecase)
+		expectedStatus int
+	}{
+		{
+			name: "正常系: 評価額を記録する",
+			body: `{"value": 2100000, "valued_on": "2024-06-01", "notes": "買取店の査定"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("RecordValuation", mock.Anything, int64(1), usecase.RecordValuationInput{
+					Value: &entity.Decimal{Units: 2100000}, ValuedOn: "2024-06-01", Notes: "買取店の査定",
+				}).Return(&entity.ItemValuation{ID: 3, ItemID: 1, Value: entity.JPY(2100000), ValuedOn: "2024-06-01"}, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "異常系: 未来の評価日",
+			body: `{"value": 2100000, "valued_on": "2999-01-01"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("RecordValuation", mock.Anything, int64(1), mock.Anything).Return(nil, domainErrors.ValidationErrors{
+					{Field: "valued_on", Code: domainErrors.CodeDateNotAllowed, Message: "valued_on must not be in the future"},
+				})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			body: `{"value": 2100000, "valued_on": "2024-06-01"}`,
+			setupMock: func(mockUsecase *MockItemUsecase) {
+				mockUsecase.On("RecordValuation", mock.Anything, int64(1), mock.Anything).Return(nil, domainErrors.ErrItemNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			mockUsecase := new(MockItemUsecase)
+			tt.setupMock(mockUsecase)
+			handler := NewItemHandler(mockUsecase)
+
+			req := httptest.NewRequest(http.MethodPost, "/items/1/valuations", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetPath("/items/:id/valuations")
+			c.SetParamNames("id")
+			c.SetParamValues("1")
+
+			require.NoError(t, handler.RecordValuation(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+
+			mockUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemHandler_GetValuations(t *testing.T) {
+	e := echo.New()
+	mockUsecase := new(MockItemUsecase)
+	mockUsecase.On("GetValuations", mock.Anything, int64(1)).Return([]*entity.ItemValuation(nil), nil)
+	handler := NewItemHandler(mockUsecase)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1/valuations", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/items/:id/valuations")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	require.NoError(t, handler.GetValuations(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	// 評価額を記録していないアイテムは空の配列を返す
+	assert.JSONEq(t, `[]`, rec.Body.String())
+}
+
+func TestItemHandler_GetBrandSummary(t *testing.T) {
+	t.Run("正常系: limit を渡してブランド別集計を返す", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		average := int64(1500000)
+		mockUsecase.On("GetBrandSummary", mock.Anything, 5).Return(&usecase.BrandSummary{
+			Currency: entity.CurrencyJPY, TotalBrands: 8,
+			Brands: []usecase.BrandStats{{Brand: "ROLEX", Count: 2, ValuedCount: 2, TotalValue: 3000000, AverageValue: &average}},
+		}, nil)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/items/summary/brands?limit=5", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetBrandSummary(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"currency": "JPY", "total_brands": 8,
+			"brands": [{"brand": "ROLEX", "count": 2, "valued_count": 2, "total_value": 3000000, "average_value": 1500000}]
+		}`, rec.Body.String())
+	})
+
+	t.Run("異常系: 不正な limit", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/items/summary/brands?limit=0", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetBrandSummary(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUsecase.AssertNotCalled(t, "GetBrandSummary", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemHandler_GetTopItems(t *testing.T) {
+	t.Run("正常系: n と by を渡して高額なアイテムを返す", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("GetTopItems", mock.Anything, 3, "current_value").Return(&usecase.TopItems{
+			By: entity.ItemValueCurrent, Currency: entity.CurrencyJPY,
+			Items: []usecase.TopItem{{Value: 4000000, Item: &entity.Item{ID: 1, Name: "デイトナ"}}},
+		}, nil)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/items/top?n=3&by=current_value", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetTopItems(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"by":"current_value"`)
+		assert.Contains(t, rec.Body.String(), `"value":4000000`)
+	})
+
+	t.Run("異常系: 不正な n", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/items/top?n=abc", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetTopItems(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUsecase.AssertNotCalled(t, "GetTopItems", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 不正な by", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("GetTopItems", mock.Anything, 0, "name").Return(nil, domainErrors.ErrInvalidInput)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/items/top?by=name", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetTopItems(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_GetDashboardStats(t *testing.T) {
+	t.Run("正常系: 集計をまとめて返す", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("GetDashboardStats", mock.Anything).Return(&usecase.DashboardStats{
+			Categories:   &usecase.CategorySummary{Currency: entity.CurrencyJPY},
+			Brands:       &usecase.BrandSummary{Currency: entity.CurrencyJPY, Brands: []usecase.BrandStats{}},
+			MonthlySpend: &usecase.PurchaseTrendReport{GroupBy: entity.PurchasePeriodMonth, Currency: entity.CurrencyJPY, Periods: []usecase.PurchasePeriod{}},
+			TopItems:     &usecase.TopItems{By: entity.ItemValuePurchasePrice, Currency: entity.CurrencyJPY, Items: []usecase.TopItem{}},
+		}, nil)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetDashboardStats(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Contains(t, body, "categories")
+		assert.Contains(t, body, "brands")
+		assert.Contains(t, body, "monthly_spend")
+		assert.Contains(t, body, "top_items")
+	})
+
+	t.Run("異常系: 集計の失敗", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("GetDashboardStats", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/stats", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetDashboardStats(c))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestItemHandler_GetPortfolioSummary(t *testing.T) {
+	t.Run("正常系: 合計とカテゴリーごとの内訳を返す", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		totals := usecase.PortfolioTotals{ItemCount: 3, PurchaseValue: 4000000, EstimatedValue: 5000000, ValuedCount: 2}
+		mockUsecase.On("GetPortfolioSummary", mock.Anything).Return(&usecase.PortfolioSummary{
+			Currency: entity.CurrencyJPY, PortfolioTotals: totals,
+			Categories: []usecase.PortfolioCategory{{Category: "時計", PortfolioTotals: totals}},
+		}, nil)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/summary/portfolio", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetPortfolioSummary(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"currency": "JPY", "item_count": 3, "purchase_value": 4000000, "estimated_value": 5000000, "valued_count": 2, "excluded_count": 0,
+			"categories": [{"category": "時計", "item_count": 3, "purchase_value": 4000000, "estimated_value": 5000000, "valued_count": 2, "excluded_count": 0}]
+		}`, rec.Body.String())
+	})
+}
+
+func TestItemHandler_GetQuickStats(t *testing.T) {
+	t.Run("正常系: 件数と合計を返す", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("GetQuickStats", mock.Anything).Return(&usecase.QuickStats{
+			ItemCount: 4, Currency: entity.CurrencyJPY, TotalValue: 5000000, ItemCountChange: 2, TotalValueChange: 1000000,
+			AsOf: time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC),
+		}, nil)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/quickstats", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetQuickStats(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"item_count": 4, "currency": "JPY", "total_value": 5000000,
+			"item_count_change": 2, "total_value_change": 1000000, "as_of": "2024-06-01T09:00:00Z"
+		}`, rec.Body.String())
+	})
+
+	t.Run("異常系: 集計に失敗した", func(t *testing.T) {
+		e := echo.New()
+		mockUsecase := new(MockItemUsecase)
+		mockUsecase.On("GetQuickStats", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+		handler := NewItemHandler(mockUsecase)
+
+		req := httptest.NewRequest(http.MethodGet, "/me/quickstats", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, handler.GetQuickStats(c))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+// ブランドを省略した登録では、カテゴリーの既定値を使うか、既定値のないカテゴリーでは brand の項目エラーを返すこと
+func TestItemHandler_CreateItem_WithoutBrand(t *testing.T) {
+	categories := entity.Categories()
+	t.Cleanup(func() { entity.SetCategories(categories) })
+	entity.SetCategories([]entity.Category{
+		{ID: 1, Name: "時計"},
+		{ID: 5, Name: "その他", Defaults: entity.CategoryDefaults{Brand: "不明"}},
+	})
+
+	create := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		items := &memoryItemRepository{items: map[int64]*entity.Item{}}
+		handler := NewItemHandler(usecase.NewItemUsecase(items))
+		ctx := usecase.WithActor(context.Background(), &entity.User{ID: 1, Role: entity.RoleEditor})
+
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body)).WithContext(ctx)
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		require.NoError(t, handler.CreateItem(echo.New().NewContext(req, rec)))
+		return rec
+	}
+
+	t.Run("正常系: カテゴリーの既定値のブランドで登録する", func(t *testing.T) {
+		rec := create(t, `{"name": "置き時計", "category": "その他", "purchase_price": 5000, "purchase_date": "2023-01-15"}`)
+		require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+		var created map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+		assert.Equal(t, "不明", created["brand"])
+	})
+
+	t.Run("異常系: 既定値のないカテゴリーでは brand が必須", func(t *testing.T) {
+		rec := create(t, `{"name": "デイトナ", "category": "時計", "purchase_price": 1500000, "purchase_date": "2023-01-15"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var p problem.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+		require.Len(t, p.Errors, 1)
+		assert.Equal(t, "brand", p.Errors[0].Field)
+		assert.Equal(t, domainErrors.CodeRequired, p.Errors[0].Code)
+	})
+}
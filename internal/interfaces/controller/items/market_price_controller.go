@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	jobController "Aicon-assignment/internal/interfaces/controller/jobs"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type MarketPriceHandler struct {
+	marketPriceUsecase usecase.MarketPriceUsecase
+}
+
+func NewMarketPriceHandler(marketPriceUsecase usecase.MarketPriceUsecase) *MarketPriceHandler {
+	return &MarketPriceHandler{
+		marketPriceUsecase: marketPriceUsecase,
+	}
+}
+
+// RefreshMarketPrices はアイテムの評価額を相場で更新するジョブを開始する（結果は GET /jobs/{id}/result）
+func (h *MarketPriceHandler) RefreshMarketPrices(c echo.Context) error {
+	var input usecase.MarketPriceRefreshInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	job, err := h.marketPriceUsecase.StartRefresh(c.Request().Context(), input)
+	if err != nil {
+		return problem.Error(c, err, "failed to start market price refresh")
+	}
+
+	return jobController.RespondAccepted(c, job)
+}
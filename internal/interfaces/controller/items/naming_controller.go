@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+// GetNamingSuggestions は名前の表記が揃っていないアイテムと、揃える名前の候補を返す
+func (h *ItemHandler) GetNamingSuggestions(c echo.Context) error {
+	suggestions, err := h.itemUsecase.GetNamingSuggestions(c.Request().Context())
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve naming suggestions")
+	}
+
+	return response.List(c, http.StatusOK, suggestions)
+}
+
+// ApplyNaming は候補の名前（またはクライアントが修正した名前）を複数のアイテムにまとめて適用する
+func (h *ItemHandler) ApplyNaming(c echo.Context) error {
+	var input usecase.ApplyNamingInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	items, err := h.itemUsecase.ApplyNaming(c.Request().Context(), input)
+	if err != nil {
+		return bulkUpdateError(c, err)
+	}
+
+	return response.List(c, http.StatusOK, items)
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type PriceHistoryHandler struct {
+	priceHistoryUsecase usecase.PriceHistoryUsecase
+}
+
+func NewPriceHistoryHandler(priceHistoryUsecase usecase.PriceHistoryUsecase) *PriceHistoryHandler {
+	return &PriceHistoryHandler{
+		priceHistoryUsecase: priceHistoryUsecase,
+	}
+}
+
+// GetPriceHistory はアイテムの価格の推移をグラフ用に月ごとに返す（?interpolation=previous|linear|none）
+func (h *PriceHistoryHandler) GetPriceHistory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	interpolation := usecase.PriceHistoryInterpolation(c.QueryParam("interpolation"))
+	history, err := h.priceHistoryUsecase.Get(c.Request().Context(), id, interpolation)
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve price history")
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+)
+
+// GetPurchaseTrend は購入日の月・年ごとのアイテムの件数と購入価格の合計を返す（?group_by=month|year）
+func (h *ItemHandler) GetPurchaseTrend(c echo.Context) error {
+	report, err := h.itemUsecase.GetPurchaseTrend(c.Request().Context(), c.QueryParam("group_by"))
+	if err != nil {
+		return problem.Error(c, err, "failed to create purchase report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// BulkRecategorize は ID か絞り込み条件で指定したアイテムのカテゴリーをまとめて変更する（dry_run は件数のみ返す）
+func (h *ItemHandler) BulkRecategorize(c echo.Context) error {
+	var input usecase.BulkRecategorizeInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	result, err := h.itemUsecase.BulkRecategorize(c.Request().Context(), input)
+	if err != nil {
+		return bulkUpdateError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,46 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// RecordSale はアイテムの売却を記録し、アイテムを売却済みにする
+func (h *ItemHandler) RecordSale(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.RecordSaleInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	sale, err := h.itemUsecase.RecordSale(c.Request().Context(), id, input)
+	if err != nil {
+		return problem.Error(c, err, "failed to record sale")
+	}
+
+	return c.JSON(http.StatusCreated, sale)
+}
+
+// GetSale はアイテムの売却の記録を損益とともに返す
+func (h *ItemHandler) GetSale(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	sale, err := h.itemUsecase.GetSale(c.Request().Context(), id)
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve sale")
+	}
+
+	return c.JSON(http.StatusOK, sale)
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ChangeItemStatus はアイテムの所有状況を変更する（変更できない状況への変更は 400）
+func (h *ItemHandler) ChangeItemStatus(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.ChangeItemStatusInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+	if strings.TrimSpace(input.Status) == "" {
+		var errs domainErrors.ValidationErrors
+		errs.Add("status", domainErrors.CodeRequired, "status is required")
+		return problem.ValidationFailed(c, errs)
+	}
+
+	item, err := h.itemUsecase.ChangeItemStatus(c.Request().Context(), id, input)
+	if err != nil {
+		if domainErrors.IsForbiddenError(err) {
+			return forbidden(c)
+		}
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "item not found")
+		}
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		if domainErrors.IsVersionConflictError(err) {
+			return versionConflict(c)
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to change item status")
+	}
+
+	setItemETag(c, item)
+	return c.JSON(http.StatusOK, item)
+}
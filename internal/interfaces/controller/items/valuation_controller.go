@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+// RecordValuation はアイテムのある日付時点の評価額を記録する
+func (h *ItemHandler) RecordValuation(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.RecordValuationInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	valuation, err := h.itemUsecase.RecordValuation(c.Request().Context(), id, input)
+	if err != nil {
+		return problem.Error(c, err, "failed to record valuation")
+	}
+
+	return c.JSON(http.StatusCreated, valuation)
+}
+
+// GetValuations はアイテムの評価額の推移を評価日の古い順に返す
+func (h *ItemHandler) GetValuations(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	valuations, err := h.itemUsecase.GetValuations(c.Request().Context(), id)
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve valuations")
+	}
+
+	return response.List(c, http.StatusOK, valuations)
+}
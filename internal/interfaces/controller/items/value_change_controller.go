@@ -0,0 +1,19 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+)
+
+// GetValueChangeReport は購入価格と最新の評価額の比較（アイテムごととカテゴリー・ブランドごと）を返す
+func (h *ItemHandler) GetValueChangeReport(c echo.Context) error {
+	report, err := h.itemUsecase.GetValueChangeReport(c.Request().Context())
+	if err != nil {
+		return problem.Error(c, err, "failed to create value change report")
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
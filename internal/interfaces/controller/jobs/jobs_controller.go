@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/identity"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+// プロキシに接続を切られないよう、進捗がない間も送るコメントの間隔
+const streamHeartbeatInterval = 15 * time.Second
+
+type JobHandler struct {
+	jobUsecase usecase.JobUsecase
+}
+
+func NewJobHandler(jobUsecase usecase.JobUsecase) *JobHandler {
+	return &JobHandler{
+		jobUsecase: jobUsecase,
+	}
+}
+
+// RespondConflict は実行中ジョブのIDを含む409レスポンスを返す
+func RespondConflict(c echo.Context, err error) error {
+	return problem.Error(c, err, "another job is already running")
+}
+
+func (h *JobHandler) GetJob(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid job ID")
+	}
+
+	job, err := h.jobUsecase.GetJob(c.Request().Context(), identity.UserID(c), id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "job not found")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve job")
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+// GetJobResult はジョブが出力したファイルを返す
+func (h *JobHandler) GetJobResult(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid job ID")
+	}
+
+	result, err := h.jobUsecase.GetResult(c.Request().Context(), identity.UserID(c), id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrJobResultNotFound) {
+			return problem.Respond(c, http.StatusNotFound, "job result not found")
+		}
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "job not found")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve job result")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, result.FileName))
+	return c.Blob(http.StatusOK, result.ContentType, result.Body)
+}
+
+// StreamJobEvents はジョブの進捗を Server-Sent Events で配信する。
+// progress（進捗率）、chunk_error（処理を続けた一部の失敗）を送り、finished（終了したジョブ）を送った後に接続を閉じる
+func (h *JobHandler) StreamJobEvents(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid job ID")
+	}
+
+	ctx := c.Request().Context()
+	events, unsubscribe, err := h.jobUsecase.Subscribe(ctx, identity.UserID(c), id)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrTooManyJobSubscribers) {
+			return problem.Respond(c, http.StatusTooManyRequests, "too many progress streams for the job")
+		}
+		if domainErrors.IsNotFoundError(err) {
+			return problem.Respond(c, http.StatusNotFound, "job not found")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to stream job events")
+	}
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	// リバースプロキシにバッファリングさせない
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeJobEvent(res, event); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// writeJobEvent はイベントを SSE の形式で書き込む
+func writeJobEvent(res *echo.Response, event entity.JobEvent) error {
+	var data any
+	switch event.Type {
+	case entity.JobEventChunkError:
+		data = event.ChunkError
+	case entity.JobEventFinished:
+		data = event.Job
+	default:
+		data = map[string]int{"progress": event.Progress}
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, body)
+	return err
+}
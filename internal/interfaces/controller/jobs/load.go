@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+// 重いエンドポイントの負荷の目安を伝えるヘッダー
+const (
+	// HeaderEstimatedDuration は処理にかかる時間の見積もり（秒、切り上げ。実績がない場合は0）
+	HeaderEstimatedDuration = "X-Estimated-Duration"
+	// HeaderJobQueueLength は実行中のジョブの数
+	HeaderJobQueueLength = "X-Job-Queue-Length"
+)
+
+// SetLoadHeaders は重いエンドポイントのレスポンスに負荷の目安を設定する。
+// クライアントはこれを見て、同期で待つか Prefer: respond-async でジョブにするかを選べる
+func SetLoadHeaders(c echo.Context, load usecase.JobLoad) {
+	header := c.Response().Header()
+	header.Set(HeaderEstimatedDuration, strconv.Itoa(int(math.Ceil(load.EstimatedDuration.Seconds()))))
+	header.Set(HeaderJobQueueLength, strconv.Itoa(load.QueueLength))
+	header.Add(echo.HeaderAccessControlExposeHeaders, HeaderEstimatedDuration+", "+HeaderJobQueueLength)
+}
+
+// PrefersAsync は Prefer ヘッダーで respond-async が指定されているかを返す（RFC 7240）
+func PrefersAsync(c echo.Context) bool {
+	for _, value := range c.Request().Header.Values("Prefer") {
+		for _, preference := range strings.Split(value, ",") {
+			token, _, _ := strings.Cut(preference, ";")
+			if strings.EqualFold(strings.TrimSpace(token), "respond-async") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RespondAccepted は開始したジョブを、状態を確認するURLの Location ヘッダー付きの202で返す
+func RespondAccepted(c echo.Context, job *entity.Job) error {
+	header := c.Response().Header()
+	header.Set(echo.HeaderLocation, fmt.Sprintf("/jobs/%d", job.ID))
+	if PrefersAsync(c) {
+		header.Set("Preference-Applied", "respond-async")
+	}
+	return c.JSON(http.StatusAccepted, job)
+}
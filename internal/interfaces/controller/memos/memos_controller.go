@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ItemMemoHandler struct {
+	memoUsecase usecase.ItemMemoUsecase
+}
+
+func NewItemMemoHandler(memoUsecase usecase.ItemMemoUsecase) *ItemMemoHandler {
+	return &ItemMemoHandler{
+		memoUsecase: memoUsecase,
+	}
+}
+
+func (h *ItemMemoHandler) ListMemos(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	memos, err := h.memoUsecase.List(c.Request().Context(), itemID)
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve memos")
+	}
+
+	return response.List(c, http.StatusOK, memos)
+}
+
+func (h *ItemMemoHandler) CreateMemo(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.ItemMemoInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	memo, err := h.memoUsecase.Create(c.Request().Context(), itemID, input)
+	if err != nil {
+		return problem.Error(c, err, "failed to create memo")
+	}
+
+	return c.JSON(http.StatusCreated, memo)
+}
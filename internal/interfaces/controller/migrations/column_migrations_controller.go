@@ -0,0 +1,41 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	jobController "Aicon-assignment/internal/interfaces/controller/jobs"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type ColumnMigrationHandler struct {
+	migrationUsecase usecase.ColumnMigrationUsecase
+}
+
+func NewColumnMigrationHandler(migrationUsecase usecase.ColumnMigrationUsecase) *ColumnMigrationHandler {
+	return &ColumnMigrationHandler{
+		migrationUsecase: migrationUsecase,
+	}
+}
+
+// ListColumnMigrations は列の移行の段階と比較の件数を返す（管理者のみ）
+func (h *ColumnMigrationHandler) ListColumnMigrations(c echo.Context) error {
+	migrations, err := h.migrationUsecase.List(c.Request().Context())
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve column migrations")
+	}
+
+	return c.JSON(http.StatusOK, migrations)
+}
+
+// StartBackfill は列の移行のバックフィルのジョブを開始する（管理者のみ。結果は GET /admin/column-migrations の last_backfill）
+func (h *ColumnMigrationHandler) StartBackfill(c echo.Context) error {
+	job, err := h.migrationUsecase.StartBackfill(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return problem.Error(c, err, "failed to start column backfill")
+	}
+
+	return jobController.RespondAccepted(c, job)
+}
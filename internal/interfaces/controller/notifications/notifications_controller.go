@@ -0,0 +1,61 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type NotificationHandler struct {
+	notificationUsecase usecase.NotificationUsecase
+}
+
+func NewNotificationHandler(notificationUsecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{
+		notificationUsecase: notificationUsecase,
+	}
+}
+
+// ListNotifications は通知を新しい順に返す（?unread=true で未読のみ）
+func (h *NotificationHandler) ListNotifications(c echo.Context) error {
+	unreadOnly := false
+	if v := c.QueryParam("unread"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return problem.Respond(c, http.StatusBadRequest, "invalid unread parameter")
+		}
+		unreadOnly = b
+	}
+
+	notifications, err := h.notificationUsecase.List(c.Request().Context(), unreadOnly)
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve notifications")
+	}
+
+	return response.List(c, http.StatusOK, notifications)
+}
+
+func (h *NotificationHandler) MarkNotificationRead(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid notification ID")
+	}
+
+	if err := h.notificationUsecase.MarkRead(c.Request().Context(), id); err != nil {
+		switch {
+		case domainErrors.IsNotFoundError(err):
+			return problem.Respond(c, http.StatusNotFound, "notification not found")
+		case domainErrors.IsValidationError(err):
+			return problem.Respond(c, http.StatusBadRequest, "invalid notification ID")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to mark notification as read")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type OrganizationHandler struct {
+	orgUsecase usecase.OrganizationUsecase
+}
+
+func NewOrganizationHandler(orgUsecase usecase.OrganizationUsecase) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgUsecase: orgUsecase,
+	}
+}
+
+func (h *OrganizationHandler) ListOrganizations(c echo.Context) error {
+	orgs, err := h.orgUsecase.List(c.Request().Context())
+	if err != nil {
+		return respondError(c, err, "failed to retrieve organizations")
+	}
+
+	return response.List(c, http.StatusOK, orgs)
+}
+
+func (h *OrganizationHandler) CreateOrganization(c echo.Context) error {
+	var input usecase.OrganizationInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	org, err := h.orgUsecase.Create(c.Request().Context(), input)
+	if err != nil {
+		return respondError(c, err, "failed to create organization")
+	}
+
+	return c.JSON(http.StatusCreated, org)
+}
+
+func (h *OrganizationHandler) ListMembers(c echo.Context) error {
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid organization ID")
+	}
+
+	members, err := h.orgUsecase.ListMembers(c.Request().Context(), orgID)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve members")
+	}
+
+	return response.List(c, http.StatusOK, members)
+}
+
+func (h *OrganizationHandler) AddMember(c echo.Context) error {
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid organization ID")
+	}
+
+	var input usecase.AddMemberInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	member, err := h.orgUsecase.AddMember(c.Request().Context(), orgID, input)
+	if err != nil {
+		return respondError(c, err, "failed to add member")
+	}
+
+	return c.JSON(http.StatusCreated, member)
+}
+
+func (h *OrganizationHandler) RemoveMember(c echo.Context) error {
+	orgID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid organization ID")
+	}
+	userID, err := strconv.ParseInt(c.Param("userId"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid user ID")
+	}
+
+	if err := h.orgUsecase.RemoveMember(c.Request().Context(), orgID, userID); err != nil {
+		return respondError(c, err, "failed to remove member")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// respondError はユースケースのエラーをエラーレスポンスに変換する
+func respondError(c echo.Context, err error, message string) error {
+	if domainErrors.IsDuplicateError(err) {
+		return problem.Respond(c, http.StatusConflict, "user is already a member")
+	}
+	return problem.Error(c, err, message)
+}
@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+	"Aicon-assignment/web"
+)
+
+type PortfolioHandler struct {
+	portfolioUsecase usecase.PortfolioUsecase
+}
+
+func NewPortfolioHandler(portfolioUsecase usecase.PortfolioUsecase) *PortfolioHandler {
+	return &PortfolioHandler{
+		portfolioUsecase: portfolioUsecase,
+	}
+}
+
+func (h *PortfolioHandler) CreatePortfolio(c echo.Context) error {
+	var input usecase.PortfolioViewInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	view, err := h.portfolioUsecase.Create(c.Request().Context(), input)
+	if err != nil {
+		return respondError(c, err, "failed to create portfolio")
+	}
+
+	return c.JSON(http.StatusCreated, view)
+}
+
+func (h *PortfolioHandler) ListPortfolios(c echo.Context) error {
+	views, err := h.portfolioUsecase.List(c.Request().Context())
+	if err != nil {
+		return respondError(c, err, "failed to retrieve portfolios")
+	}
+
+	return response.List(c, http.StatusOK, views)
+}
+
+func (h *PortfolioHandler) GetPortfolio(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid portfolio ID")
+	}
+
+	view, err := h.portfolioUsecase.Get(c.Request().Context(), id)
+	if err != nil {
+		return respondError(c, err, "failed to retrieve portfolio")
+	}
+
+	return c.JSON(http.StatusOK, view)
+}
+
+func (h *PortfolioHandler) UpdatePortfolio(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid portfolio ID")
+	}
+
+	var input usecase.UpdatePortfolioViewInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	view, err := h.portfolioUsecase.Update(c.Request().Context(), id, input)
+	if err != nil {
+		return respondError(c, err, "failed to update portfolio")
+	}
+
+	return c.JSON(http.StatusOK, view)
+}
+
+func (h *PortfolioHandler) DeletePortfolio(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid portfolio ID")
+	}
+
+	if err := h.portfolioUsecase.Delete(c.Request().Context(), id); err != nil {
+		return respondError(c, err, "failed to delete portfolio")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// GetPublicPortfolio は公開ポートフォリオをJSONで返す（認証不要）
+func (h *PortfolioHandler) GetPublicPortfolio(c echo.Context) error {
+	portfolio, err := h.portfolioUsecase.GetPublic(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return respondError(c, err, "failed to retrieve portfolio")
+	}
+
+	setPublicHeaders(c)
+	return c.JSON(http.StatusOK, portfolio)
+}
+
+// GetPublicPortfolioPage は公開ポートフォリオをHTMLで返す（設定で許可されている場合のみ）
+func (h *PortfolioHandler) GetPublicPortfolioPage(c echo.Context) error {
+	portfolio, err := h.portfolioUsecase.GetPublic(c.Request().Context(), c.Param("token"))
+	if err == nil && !portfolio.HTMLEnabled {
+		err = domainErrors.ErrPortfolioNotFound
+	}
+	if err != nil {
+		return respondError(c, err, "failed to retrieve portfolio")
+	}
+
+	var buf bytes.Buffer
+	if err := web.RenderPortfolio(&buf, portfolio); err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to render portfolio")
+	}
+
+	setPublicHeaders(c)
+	return c.HTMLBlob(http.StatusOK, buf.Bytes())
+}
+
+// setPublicHeaders はURLに含まれるトークンが検索エンジンや遷移先に漏れないようにする
+func setPublicHeaders(c echo.Context) {
+	header := c.Response().Header()
+	header.Set("X-Robots-Tag", "noindex, nofollow")
+	header.Set("Referrer-Policy", "no-referrer")
+	header.Set(echo.HeaderCacheControl, "private, no-cache")
+}
+
+// respondError はユースケースのエラーをエラーレスポンスに変換する
+func respondError(c echo.Context, err error, message string) error {
+	return problem.Error(c, err, message)
+}
@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type PreferenceHandler struct {
+	preferenceUsecase usecase.UserPreferenceUsecase
+}
+
+func NewPreferenceHandler(preferenceUsecase usecase.UserPreferenceUsecase) *PreferenceHandler {
+	return &PreferenceHandler{
+		preferenceUsecase: preferenceUsecase,
+	}
+}
+
+func (h *PreferenceHandler) GetPreferences(c echo.Context) error {
+	preferences, err := h.preferenceUsecase.GetPreferences(c.Request().Context())
+	if err != nil {
+		return problem.Respond(c, http.StatusInternalServerError, "failed to retrieve preferences")
+	}
+
+	return c.JSON(http.StatusOK, preferences)
+}
+
+// UpdatePreferences は集計やエクスポートの金額を表示する通貨（JPY, USD, EUR）を変更する
+func (h *PreferenceHandler) UpdatePreferences(c echo.Context) error {
+	var req usecase.UserPreferences
+	if err := c.Bind(&req); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	preferences, err := h.preferenceUsecase.UpdatePreferences(c.Request().Context(), req)
+	if err != nil {
+		if domainErrors.IsValidationError(err) {
+			return problem.Error(c, err, "")
+		}
+		return problem.Respond(c, http.StatusInternalServerError, "failed to update preferences")
+	}
+
+	return c.JSON(http.StatusOK, preferences)
+}
@@ -0,0 +1,242 @@
+// Package problem は RFC 7807 形式（application/problem+json）のエラーレスポンスと、
+// ユースケースのエラーからレスポンスへの変換を提供する
+package problem
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MIMEApplicationProblemJSON はエラーレスポンスの Content-Type
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// TypePrefix はエラーの種類を表す URI の接頭辞（後ろに Code を付ける）
+const TypePrefix = "urn:aicon-assignment:problem:"
+
+// エラーの種類を表す機械可読なコード
+const (
+	CodeInvalidRequest          = "invalid_request"
+	CodeValidationFailed        = "validation_failed"
+	CodeUnauthorized            = "unauthorized"
+	CodeForbidden               = "forbidden"
+	CodeNotFound                = "not_found"
+	CodeMethodNotAllowed        = "method_not_allowed"
+	CodeConflict                = "conflict"
+	CodeDuplicate               = "duplicate"
+	CodeDuplicateSerialNumber   = "duplicate_serial_number"
+	CodeCategoryInUse           = "category_in_use"
+	CodeVersionConflict         = "version_conflict"
+	CodeVersionMismatch         = "version_mismatch"
+	CodePreconditionRequired    = "precondition_required"
+	CodeJobConflict             = "job_conflict"
+	CodeIdempotencyKeyInUse     = "idempotency_key_in_use"
+	CodeIdempotencyKeyReused    = "idempotency_key_reused"
+	CodePayloadTooLarge         = "payload_too_large"
+	CodeUnsupportedMediaType    = "unsupported_media_type"
+	CodeTooManyRequests         = "too_many_requests"
+	CodeInternal                = "internal_error"
+	CodeUnavailable             = "service_unavailable"
+	CodeExchangeRateUnavailable = "exchange_rate_unavailable"
+	CodeChallengeRequired       = "challenge_required"
+	CodeChallengeFailed         = "challenge_failed"
+)
+
+// Problem は RFC 7807 のエラーレスポンス
+type Problem struct {
+	// Type はエラーの種類を表す URI（TypePrefix + Code）
+	Type string `json:"type"`
+	// Title は HTTP ステータスの説明（同じ Type では常に同じ）
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	// Detail はこのリクエストに固有の説明
+	Detail string `json:"detail,omitempty"`
+	// Code はクライアントが分岐に使う機械可読なコード（拡張メンバー）
+	Code string `json:"code"`
+	// Errors は入力の項目ごとの検証エラーの一覧（拡張メンバー）
+	Errors domainErrors.ValidationErrors `json:"errors,omitempty"`
+	// JobID は実行中のジョブ（Code が job_conflict の場合の拡張メンバー）
+	JobID int64 `json:"job_id,omitempty"`
+	// Challenge はクライアントが表示するチャレンジ（Code が challenge_required・challenge_failed の場合の拡張メンバー）
+	Challenge *Challenge `json:"challenge,omitempty"`
+}
+
+// Challenge はクライアントが解くチャレンジの提供元とウィジェットの公開のキー
+type Challenge struct {
+	Provider string `json:"provider"`
+	SiteKey  string `json:"site_key"`
+}
+
+// New はステータスとコードからエラーレスポンスを作成する
+func New(status int, code, detail string) *Problem {
+	return &Problem{
+		Type:   TypePrefix + code,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// Write はエラーレスポンスを application/problem+json で返す
+func Write(c echo.Context, p *Problem) error {
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	return c.JSON(p.Status, p)
+}
+
+// Respond はステータスに応じたコードのエラーレスポンスを返す
+func Respond(c echo.Context, status int, detail string) error {
+	return Write(c, New(status, codeForStatus(status), detail))
+}
+
+// ValidationFailed は入力の項目ごとの検証エラーの一覧を400で返す
+func ValidationFailed(c echo.Context, errs domainErrors.ValidationErrors) error {
+	return Write(c, validationFailed(errs))
+}
+
+func validationFailed(errs domainErrors.ValidationErrors) *Problem {
+	p := New(http.StatusBadRequest, CodeValidationFailed, "validation failed")
+	p.Errors = errs
+	return p
+}
+
+// Error はユースケースのエラーを対応するエラーレスポンスで返す。
+// 対応するものがない場合は message を説明とした500を返す
+func Error(c echo.Context, err error, message string) error {
+	return Write(c, FromError(err, message))
+}
+
+// FromError はユースケースのエラーをエラーレスポンスに変換する
+func FromError(err error, message string) *Problem {
+	switch {
+	case domainErrors.IsValidationError(err):
+		// 項目を特定できないエラーは field のない1件の検証エラーとして返す
+		errs, ok := domainErrors.AsValidationErrors(err)
+		if !ok {
+			errs = domainErrors.ValidationErrors{{Code: domainErrors.CodeInvalid, Message: err.Error()}}
+		}
+		return validationFailed(errs)
+	case domainErrors.IsUnauthorizedError(err):
+		return New(http.StatusUnauthorized, CodeUnauthorized, "authentication required")
+	case domainErrors.IsForbiddenError(err):
+		return New(http.StatusForbidden, CodeForbidden, "insufficient permissions")
+	case domainErrors.IsNotFoundError(err):
+		return New(http.StatusNotFound, CodeNotFound, notFoundDetail(err))
+	case domainErrors.IsVersionMismatchError(err):
+		return New(http.StatusPreconditionFailed, CodeVersionMismatch, "item has been modified, fetch it again and retry")
+	case domainErrors.IsVersionConflictError(err):
+		return New(http.StatusConflict, CodeVersionConflict, "item was modified by another request, fetch it again and retry")
+	case domainErrors.IsJobConflictError(err):
+		p := New(http.StatusConflict, CodeJobConflict, "another job is already running")
+		var conflict *domainErrors.JobConflictError
+		if errors.As(err, &conflict) {
+			p.JobID = conflict.JobID
+		}
+		return p
+	case domainErrors.IsIdempotencyKeyInUseError(err):
+		return New(http.StatusConflict, CodeIdempotencyKeyInUse, err.Error())
+	case domainErrors.IsIdempotencyKeyReusedError(err):
+		return New(http.StatusUnprocessableEntity, CodeIdempotencyKeyReused, err.Error())
+	case domainErrors.IsDuplicateSerialNumberError(err):
+		return New(http.StatusConflict, CodeDuplicateSerialNumber, domainErrors.ErrDuplicateSerialNumber.Error())
+	case domainErrors.IsCategoryInUseError(err):
+		return New(http.StatusConflict, CodeCategoryInUse, domainErrors.ErrCategoryInUse.Error()+", move them to another category first")
+	case domainErrors.IsDuplicateError(err):
+		return New(http.StatusConflict, CodeDuplicate, "already exists")
+	case domainErrors.IsExchangeRateUnavailableError(err):
+		return New(http.StatusServiceUnavailable, CodeExchangeRateUnavailable, "exchange rates are temporarily unavailable, retry later")
+	}
+	return New(http.StatusInternalServerError, CodeInternal, message)
+}
+
+// 見つからなかったものの説明（ラップされたエラーの詳細は返さない）
+var notFoundErrors = []error{
+	domainErrors.ErrItemNotFound,
+	domainErrors.ErrJobNotFound,
+	domainErrors.ErrJobResultNotFound,
+	domainErrors.ErrUserNotFound,
+	domainErrors.ErrAPIKeyNotFound,
+	domainErrors.ErrItemImageNotFound,
+	domainErrors.ErrPortfolioNotFound,
+	domainErrors.ErrCommentNotFound,
+	domainErrors.ErrTagNotFound,
+	domainErrors.ErrCategoryNotFound,
+	domainErrors.ErrCatalogEntryNotFound,
+	domainErrors.ErrNotificationNotFound,
+	domainErrors.ErrOrganizationNotFound,
+	domainErrors.ErrMemberNotFound,
+	domainErrors.ErrConsignmentNotFound,
+	domainErrors.ErrItemSaleNotFound,
+	domainErrors.ErrInvoiceNotFound,
+	domainErrors.ErrReportNotFound,
+	domainErrors.ErrDraftSessionNotFound,
+	domainErrors.ErrDraftImageNotFound,
+	domainErrors.ErrColumnMigrationNotFound,
+}
+
+func notFoundDetail(err error) string {
+	for _, target := range notFoundErrors {
+		if errors.Is(err, target) {
+			return target.Error()
+		}
+	}
+	return "not found"
+}
+
+// ステータスに対応する既定のコード
+var statusCodes = map[int]string{
+	http.StatusBadRequest:            CodeInvalidRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusConflict:              CodeConflict,
+	http.StatusPreconditionFailed:    CodeVersionMismatch,
+	http.StatusRequestEntityTooLarge: CodePayloadTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMediaType,
+	http.StatusPreconditionRequired:  CodePreconditionRequired,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+func codeForStatus(status int) string {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeInvalidRequest
+}
+
+// HTTPErrorHandler は echo が返すエラー（存在しないルートなど）をエラーレスポンスで返す
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	status := http.StatusInternalServerError
+	detail := "internal server error"
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		status = httpErr.Code
+		if message, ok := httpErr.Message.(string); ok {
+			detail = message
+		} else {
+			detail = http.StatusText(status)
+		}
+	} else {
+		c.Logger().Error(err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		_ = c.NoContent(status)
+		return
+	}
+	if writeErr := Respond(c, status, detail); writeErr != nil {
+		c.Logger().Error(writeErr)
+	}
+}
@@ -0,0 +1,176 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedCode   string
+		expectedDetail string
+		expectedErrors domainErrors.ValidationErrors
+		expectedJobID  int64
+	}{
+		{
+			name:           "項目を特定できない検証エラーは field なしの1件",
+			err:            fmt.Errorf("%w: ids must contain at most 100 items", domainErrors.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+			expectedDetail: "validation failed",
+			expectedErrors: domainErrors.ValidationErrors{
+				{Code: domainErrors.CodeInvalid, Message: "invalid input: ids must contain at most 100 items"},
+			},
+		},
+		{
+			name: "項目ごとの検証エラーはそのまま返す",
+			err: fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, domainErrors.ValidationErrors{
+				{Field: "name", Code: domainErrors.CodeTooLong, Message: "name must be 100 characters or less"},
+				{Field: "category", Code: domainErrors.CodeInvalidChoice, Message: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他"},
+			}),
+			expectedStatus: http.StatusBadRequest,
+			expectedCode:   CodeValidationFailed,
+			expectedDetail: "validation failed",
+			expectedErrors: domainErrors.ValidationErrors{
+				{Field: "name", Code: domainErrors.CodeTooLong, Message: "name must be 100 characters or less"},
+				{Field: "category", Code: domainErrors.CodeInvalidChoice, Message: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他"},
+			},
+		},
+		{
+			name:           "見つからない場合はラップされた詳細を返さない",
+			err:            fmt.Errorf("%w: id 3", domainErrors.ErrCommentNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedCode:   CodeNotFound,
+			expectedDetail: "comment not found",
+		},
+		{
+			name:           "権限が不足している場合は403",
+			err:            domainErrors.ErrForbidden,
+			expectedStatus: http.StatusForbidden,
+			expectedCode:   CodeForbidden,
+			expectedDetail: "insufficient permissions",
+		},
+		{
+			name:           "バージョンの不一致は412",
+			err:            domainErrors.ErrItemVersionMismatch,
+			expectedStatus: http.StatusPreconditionFailed,
+			expectedCode:   CodeVersionMismatch,
+			expectedDetail: "item has been modified, fetch it again and retry",
+		},
+		{
+			name:           "ジョブの競合は実行中のジョブの ID を含める",
+			err:            &domainErrors.JobConflictError{JobID: 42},
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeJobConflict,
+			expectedDetail: "another job is already running",
+			expectedJobID:  42,
+		},
+		{
+			name:           "シリアル番号の重複は409",
+			err:            domainErrors.ErrDuplicateSerialNumber,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeDuplicateSerialNumber,
+			expectedDetail: "an item with the same serial number is already registered",
+		},
+		{
+			name:           "アイテムのあるカテゴリーの削除は409",
+			err:            domainErrors.ErrCategoryInUse,
+			expectedStatus: http.StatusConflict,
+			expectedCode:   CodeCategoryInUse,
+			expectedDetail: "category is used by items, move them to another category first",
+		},
+		{
+			name:           "為替レートを取得できない場合は503",
+			err:            fmt.Errorf("failed to convert USD to JPY: %w", domainErrors.ErrExchangeRateUnavailable),
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedCode:   CodeExchangeRateUnavailable,
+			expectedDetail: "exchange rates are temporarily unavailable, retry later",
+		},
+		{
+			name:           "対応するものがない場合は500",
+			err:            errors.New("connection refused"),
+			expectedStatus: http.StatusInternalServerError,
+			expectedCode:   CodeInternal,
+			expectedDetail: "failed to create item",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := FromError(tt.err, "failed to create item")
+
+			assert.Equal(t, tt.expectedStatus, p.Status)
+			assert.Equal(t, tt.expectedCode, p.Code)
+			assert.Equal(t, TypePrefix+tt.expectedCode, p.Type)
+			assert.Equal(t, http.StatusText(tt.expectedStatus), p.Title)
+			assert.Equal(t, tt.expectedDetail, p.Detail)
+			assert.Equal(t, tt.expectedErrors, p.Errors)
+			assert.Equal(t, tt.expectedJobID, p.JobID)
+		})
+	}
+}
+
+func TestRespond(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodPost, "/items", nil), rec)
+
+	require.NoError(t, ValidationFailed(c, domainErrors.ValidationErrors{
+		{Field: "name", Code: domainErrors.CodeRequired, Message: "name is required"},
+	}))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	assert.JSONEq(t, `{
+		"type": "urn:aicon-assignment:problem:validation_failed",
+		"title": "Bad Request",
+		"status": 400,
+		"detail": "validation failed",
+		"code": "validation_failed",
+		"errors": [{"field": "name", "code": "required", "message": "name is required"}]
+	}`, rec.Body.String())
+}
+
+func TestHTTPErrorHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.GET("/items", func(c echo.Context) error { return nil })
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		expectedStatus int
+		expectedCode   string
+	}{
+		{name: "存在しないルートは404", method: http.MethodGet, path: "/unknown", expectedStatus: http.StatusNotFound, expectedCode: CodeNotFound},
+		{name: "許可されないメソッドは405", method: http.MethodDelete, path: "/items", expectedStatus: http.StatusMethodNotAllowed, expectedCode: CodeMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			var p Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &p))
+			assert.Equal(t, tt.expectedCode, p.Code)
+			assert.Equal(t, tt.expectedStatus, p.Status)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/usecase"
+)
+
+type AdminReportHandler struct {
+	reportUsecase usecase.AdminReportUsecase
+}
+
+func NewAdminReportHandler(reportUsecase usecase.AdminReportUsecase) *AdminReportHandler {
+	return &AdminReportHandler{
+		reportUsecase: reportUsecase,
+	}
+}
+
+// RunReport は定型レポートを実行して CSV で返す（管理者のみ。パラメーターはクエリパラメーターで指定する）
+func (h *AdminReportHandler) RunReport(c echo.Context) error {
+	params := make(map[string]string)
+	for name, values := range c.QueryParams() {
+		params[name] = values[0]
+	}
+
+	file, err := h.reportUsecase.Run(c.Request().Context(), c.Param("name"), params)
+	if err != nil {
+		return problem.Error(c, err, "failed to run report")
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, file.FileName))
+	return c.Blob(http.StatusOK, file.ContentType, file.Body)
+}
@@ -0,0 +1,21 @@
+// Package response は成功したレスポンスの JSON の形を揃える。
+// 一覧は要素がない場合も null ではなく [] を返し、省略できる入れ子のオブジェクトは項目を省略せず null を返す
+// （入れ子のオブジェクトはエンティティの json タグに omitempty を付けないことで揃える）
+package response
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+// List は一覧を JSON で返す（nil のスライスも [] として返す）
+func List[T any](c echo.Context, status int, items []T) error {
+	return c.JSON(status, Slice(items))
+}
+
+// Slice は nil のスライスを空のスライスにする（レスポンスに含める一覧を null にしないため）
+func Slice[T any](items []T) []T {
+	if items == nil {
+		return []T{}
+	}
+	return items
+}
@@ -0,0 +1,125 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+var update = flag.Bool("update", false, "testdata のゴールデンファイルを更新する")
+
+// respond はハンドラーのレスポンスのボディを返す
+func respond(t *testing.T, handler func(c echo.Context) error) []byte {
+	t.Helper()
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+	require.NoError(t, handler(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.Bytes()
+}
+
+// assertGolden は JSON を整形して testdata/<name>.json と比較する（-update で書き換える）
+func assertGolden(t *testing.T, name string, body []byte) {
+	t.Helper()
+	var got bytes.Buffer
+	require.NoError(t, json.Indent(&got, body, "", "  "))
+	got.WriteByte('\n')
+
+	path := filepath.Join("testdata", name+".json")
+	if *update {
+		require.NoError(t, os.WriteFile(path, got.Bytes(), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got.String())
+}
+
+func TestList(t *testing.T) {
+	t.Run("nil の一覧は [] を返す", func(t *testing.T) {
+		body := respond(t, func(c echo.Context) error {
+			return List[*entity.Item](c, http.StatusOK, nil)
+		})
+		assert.JSONEq(t, `[]`, string(body))
+	})
+
+	t.Run("要素がある一覧はそのまま返す", func(t *testing.T) {
+		body := respond(t, func(c echo.Context) error {
+			return List(c, http.StatusOK, []int64{1, 2})
+		})
+		assert.JSONEq(t, `[1, 2]`, string(body))
+	})
+}
+
+func TestSlice(t *testing.T) {
+	assert.Equal(t, []string{}, Slice[string](nil))
+	assert.Equal(t, []string{"a"}, Slice([]string{"a"}))
+}
+
+// TestGolden は省略できる項目が空の場合のレスポンスの形（一覧は []、入れ子のオブジェクトは null）を固定する
+func TestGolden(t *testing.T) {
+	createdAt := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{
+			name: "item",
+			value: []*entity.Item{{
+				ID: 1, UserID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX",
+				PurchasePrice: entity.NewMoney(1500000, "JPY"), PurchaseDate: "2023-01-15",
+				Visibility: entity.VisibilityPrivate, Status: entity.ItemStatusOwned, CurrentValue: &entity.Money{Amount: 2100000, Currency: entity.CurrencyJPY},
+				Version: 1, CreatedAt: createdAt, UpdatedAt: createdAt,
+			}},
+		},
+		{
+			name:  "notification",
+			value: []*entity.Notification{{ID: 1, UserID: 1, Kind: entity.NotificationKindMention, ItemID: 1, CommentID: 1, ActorID: 2, CreatedAt: createdAt}},
+		},
+		{
+			name:  "job",
+			value: &entity.Job{ID: 1, UserID: "1", Kind: entity.JobKindExport, Status: entity.JobStatusRunning, CreatedAt: createdAt},
+		},
+		{
+			name:  "api_key",
+			value: []*entity.APIKey{{ID: 1, UserID: 1, Name: "ci", Prefix: "ak_1234", CreatedAt: createdAt}},
+		},
+		{
+			name:  "digest_subscription",
+			value: &entity.DigestSubscription{UserID: 1, Email: "user@example.com", Frequency: entity.DigestFrequencyWeekly, UpdatedAt: createdAt},
+		},
+		{
+			name: "consignment",
+			value: &entity.Consignment{
+				ID: 1, ItemID: 1, ConsignorName: "山田", AgreedPrice: 100000, CommissionRate: 10,
+				Status: entity.ConsignmentStatusActive, CreatedAt: createdAt, UpdatedAt: createdAt,
+			},
+		},
+		{
+			name:  "certificate_verification",
+			value: &usecase.CertificateVerification{Reason: "certificate does not match the current item", IssuedAt: createdAt},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			assertGolden(t, tt.name, body)
+		})
+	}
+}
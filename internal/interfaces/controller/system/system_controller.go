@@ -6,12 +6,27 @@ import (
 	"github.com/labstack/echo/v4"
 )
 
-type SystemHandler struct{}
+// Capabilities はクライアントに公開するサーバーの機能情報
+type Capabilities struct {
+	Version  string   `json:"version"`
+	Features []string `json:"features"`
+}
+
+type SystemHandler struct {
+	capabilities Capabilities
+}
 
 func (handler *SystemHandler) Health(ctx echo.Context) {
 	ctx.NoContent(http.StatusOK)
 }
 
-func NewSystemHandler() *SystemHandler {
-	return &SystemHandler{}
+func (handler *SystemHandler) Capabilities(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, handler.capabilities)
+}
+
+func NewSystemHandler(capabilities Capabilities) *SystemHandler {
+	if capabilities.Features == nil {
+		capabilities.Features = []string{}
+	}
+	return &SystemHandler{capabilities: capabilities}
 }
@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/interfaces/controller/problem"
+	"Aicon-assignment/internal/interfaces/controller/response"
+	"Aicon-assignment/internal/usecase"
+)
+
+type TagHandler struct {
+	tagUsecase usecase.TagUsecase
+}
+
+func NewTagHandler(tagUsecase usecase.TagUsecase) *TagHandler {
+	return &TagHandler{
+		tagUsecase: tagUsecase,
+	}
+}
+
+func (h *TagHandler) ListTags(c echo.Context) error {
+	tags, err := h.tagUsecase.List(c.Request().Context())
+	if err != nil {
+		return problem.Error(c, err, "failed to retrieve tags")
+	}
+
+	return response.List(c, http.StatusOK, tags)
+}
+
+func (h *TagHandler) AddItemTag(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	var input usecase.TagInput
+	if err := c.Bind(&input); err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid request format")
+	}
+
+	tags, err := h.tagUsecase.AddToItem(c.Request().Context(), itemID, input)
+	if err != nil {
+		return problem.Error(c, err, "failed to add tag")
+	}
+
+	return response.List(c, http.StatusOK, tags)
+}
+
+func (h *TagHandler) RemoveItemTag(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return problem.Respond(c, http.StatusBadRequest, "invalid item ID")
+	}
+
+	// エンコードした "/" などを含むパスでは、echo はパラメータをデコードしない
+	name := c.Param("name")
+	if c.Request().URL.RawPath != "" {
+		if name, err = url.PathUnescape(name); err != nil {
+			return problem.Respond(c, http.StatusBadRequest, "invalid tag name")
+		}
+	}
+
+	if err := h.tagUsecase.RemoveFromItem(c.Request().Context(), itemID, name); err != nil {
+		return problem.Error(c, err, "failed to remove tag")
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
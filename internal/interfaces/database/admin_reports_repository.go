@@ -0,0 +1,96 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// AdminReportRepository は管理者向けの定型レポートの読み込み専用のクエリ。
+// SQL は固定で、利用者が指定できるのはプレースホルダーに渡す値のみ
+type AdminReportRepository struct {
+	SqlHandler
+}
+
+func (r *AdminReportRepository) TopBrands(ctx context.Context, limit int) ([]*entity.BrandRanking, error) {
+	query := `
+        SELECT brand, COUNT(*), COUNT(DISTINCT user_id)
+        FROM items
+        GROUP BY brand
+        ORDER BY COUNT(*) DESC, brand
+        LIMIT ?`
+
+	return queryReport(ctx, r.SqlHandler, query, []interface{}{limit}, func(rows Rows) (*entity.BrandRanking, error) {
+		var b entity.BrandRanking
+		err := rows.Scan(&b.Brand, &b.ItemCount, &b.OwnerCount)
+		return &b, err
+	})
+}
+
+func (r *AdminReportRepository) InactiveUsers(ctx context.Context, since time.Time, limit int) ([]*entity.InactiveUser, error) {
+	// 最後の操作は監査ログから、アイテム数は登録したアイテム（組織のアイテムを含む）から数える。
+	// since より後に登録したユーザーは対象にしない
+	query := `
+        SELECT u.id, u.email, u.role, u.created_at,
+            (SELECT MAX(a.created_at) FROM audit_logs a WHERE a.user_id = u.id) AS last_activity_at,
+            (SELECT COUNT(*) FROM items i WHERE i.user_id = u.id)
+        FROM users u
+        WHERE u.created_at < ?
+        HAVING last_activity_at IS NULL OR last_activity_at < ?
+        ORDER BY last_activity_at IS NOT NULL, last_activity_at, u.id
+        LIMIT ?`
+
+	return queryReport(ctx, r.SqlHandler, query, []interface{}{since, since, limit}, func(rows Rows) (*entity.InactiveUser, error) {
+		var u entity.InactiveUser
+		var lastActivityAt sql.NullTime
+		if err := rows.Scan(&u.UserID, &u.Email, &u.Role, &u.CreatedAt, &lastActivityAt, &u.ItemCount); err != nil {
+			return nil, err
+		}
+		if lastActivityAt.Valid {
+			u.LastActivityAt = &lastActivityAt.Time
+		}
+		return &u, nil
+	})
+}
+
+func (r *AdminReportRepository) LargestAttachments(ctx context.Context, limit int) ([]*entity.LargeAttachment, error) {
+	query := `
+        SELECT id, item_id, file_name, content_type, size, created_at
+        FROM item_images
+        ORDER BY size DESC, id
+        LIMIT ?`
+
+	return queryReport(ctx, r.SqlHandler, query, []interface{}{limit}, func(rows Rows) (*entity.LargeAttachment, error) {
+		var a entity.LargeAttachment
+		err := rows.Scan(&a.ImageID, &a.ItemID, &a.FileName, &a.ContentType, &a.Size, &a.CreatedAt)
+		return &a, err
+	})
+}
+
+// queryReport はレポートのクエリを実行し、行を scan で変換する
+func queryReport[T any](ctx context.Context, handler SqlHandler, query string, args []interface{}, scan func(rows Rows) (*T, error)) ([]*T, error) {
+	rows, err := handler.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	results := []*T{}
+	for rows.Next() {
+		result, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return results, nil
+}
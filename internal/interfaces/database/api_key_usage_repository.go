@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type APIKeyUsageRepository struct {
+	SqlHandler
+}
+
+func (r *APIKeyUsageRepository) Add(ctx context.Context, usage []*entity.APIKeyUsage) error {
+	if len(usage) == 0 {
+		return nil
+	}
+
+	// 同じキー・同じ日の件数は既存の件数に加える
+	placeholders := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?), ", len(usage)), ", ")
+	query := `
+        INSERT INTO api_key_usage (api_key_id, date, requests, client_errors, server_errors, rate_limited)
+        VALUES ` + placeholders + `
+        ON DUPLICATE KEY UPDATE
+            requests = requests + VALUES(requests),
+            client_errors = client_errors + VALUES(client_errors),
+            server_errors = server_errors + VALUES(server_errors),
+            rate_limited = rate_limited + VALUES(rate_limited)
+    `
+
+	args := make([]interface{}, 0, len(usage)*6)
+	for _, u := range usage {
+		args = append(args, u.APIKeyID, u.Date, u.Requests, u.ClientErrors, u.ServerErrors, u.RateLimited)
+	}
+
+	if _, err := r.Execute(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *APIKeyUsageRepository) FindByUserID(ctx context.Context, userID int64, from string) ([]*entity.APIKeyUsage, error) {
+	query := `
+        SELECT u.api_key_id, u.date, u.requests, u.client_errors, u.server_errors, u.rate_limited
+        FROM api_key_usage u
+        JOIN api_keys k ON k.id = u.api_key_id
+        WHERE k.user_id = ? AND u.date >= ?
+        ORDER BY u.date ASC, u.api_key_id ASC
+    `
+
+	rows, err := r.Query(ctx, query, userID, from)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	usage := []*entity.APIKeyUsage{}
+	for rows.Next() {
+		var u entity.APIKeyUsage
+		var date time.Time
+		if err := rows.Scan(&u.APIKeyID, &date, &u.Requests, &u.ClientErrors, &u.ServerErrors, &u.RateLimited); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		u.Date = date.Format("2006-01-02")
+		usage = append(usage, &u)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return usage, nil
+}
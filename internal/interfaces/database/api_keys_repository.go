@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type APIKeyRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanAPIKey の順序と一致させる）
+const apiKeyColumns = "id, user_id, name, prefix, key_hash, last_used_at, created_at"
+
+func (r *APIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	query := `
+        INSERT INTO api_keys (user_id, name, prefix, key_hash)
+        VALUES (?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, key.UserID, key.Name, key.Prefix, key.KeyHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.findOne(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE id = ?`, id)
+}
+
+func (r *APIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	return r.findOne(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE key_hash = ?`, keyHash)
+}
+
+func (r *APIKeyRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.APIKey, error) {
+	query := `
+        SELECT ` + apiKeyColumns + `
+        FROM api_keys
+        WHERE user_id = ?
+        ORDER BY created_at DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	keys := []*entity.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM api_keys WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	if _, err := r.Execute(ctx, `UPDATE api_keys SET last_used_at = ? WHERE id = ?`, usedAt, id); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *APIKeyRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.APIKey, error) {
+	key, err := scanAPIKey(r.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return key, nil
+}
+
+// APIキーの行をエンティティに変換する
+func scanAPIKey(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.APIKey, error) {
+	var key entity.APIKey
+	var lastUsedAt sql.NullTime
+
+	err := scanner.Scan(
+		&key.ID,
+		&key.UserID,
+		&key.Name,
+		&key.Prefix,
+		&key.KeyHash,
+		&lastUsedAt,
+		&key.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+
+	return &key, nil
+}
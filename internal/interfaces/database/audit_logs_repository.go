@@ -0,0 +1,121 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type AuditLogRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanAuditLog の順序と一致させる）。操作したユーザーのメールアドレスは users から取得する
+const auditLogColumns = "a.id, a.user_id, COALESCE(u.email, ''), a.action, a.method, a.path, a.resource_id, a.status, a.request_id, a.ip, a.created_at"
+
+func (r *AuditLogRepository) Create(ctx context.Context, logs []*entity.AuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?, ?), ", len(logs)), ", ")
+	query := `
+        INSERT INTO audit_logs (user_id, action, method, path, resource_id, status, request_id, ip, created_at)
+        VALUES ` + placeholders
+
+	args := make([]interface{}, 0, len(logs)*9)
+	for _, l := range logs {
+		args = append(args, l.UserID, l.Action, l.Method, l.Path, l.ResourceID, l.Status, l.RequestID, l.IP, l.CreatedAt)
+	}
+
+	if _, err := r.Execute(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *AuditLogRepository) Find(ctx context.Context, filter entity.AuditLogFilter) ([]*entity.AuditLog, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.From != nil {
+		conditions = append(conditions, "a.created_at >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "a.created_at < ?")
+		args = append(args, *filter.To)
+	}
+	if filter.UserID != 0 {
+		conditions = append(conditions, "a.user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Action != "" {
+		conditions = append(conditions, "a.action = ?")
+		args = append(args, filter.Action)
+	}
+
+	query := `
+        SELECT ` + auditLogColumns + `
+        FROM audit_logs a LEFT JOIN users u ON u.id = a.user_id`
+	if len(conditions) > 0 {
+		query += `
+        WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += `
+        ORDER BY a.created_at DESC, a.id DESC`
+	if filter.Limit > 0 {
+		query += `
+        LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	logs := []*entity.AuditLog{}
+	for rows.Next() {
+		log, err := scanAuditLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		logs = append(logs, log)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return logs, nil
+}
+
+// 監査ログの行をエンティティに変換する
+func scanAuditLog(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.AuditLog, error) {
+	var log entity.AuditLog
+
+	err := scanner.Scan(
+		&log.ID,
+		&log.UserID,
+		&log.UserEmail,
+		&log.Action,
+		&log.Method,
+		&log.Path,
+		&log.ResourceID,
+		&log.Status,
+		&log.RequestID,
+		&log.IP,
+		&log.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
@@ -0,0 +1,365 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 1回の INSERT・DELETE で扱う行数
+const backupBatchSize = 500
+
+type BackupRepository struct {
+	SqlHandler
+}
+
+// backupColumn はテーブルの列（生成列は読み込めないため含めない）
+type backupColumn struct {
+	name     string
+	dataType string
+}
+
+// tableRef は schema のテーブルを表す（schema が空の場合は接続先のデータベースのテーブル）
+func tableRef(schema, table string) string {
+	if schema == "" {
+		return "`" + table + "`"
+	}
+	return "`" + schema + "`.`" + table + "`"
+}
+
+// tableColumns は schema（空の場合は接続先のデータベース）のテーブルの列を定義順に返す
+// （列の追加に合わせてバックアップの内容も増える）
+func tableColumns(ctx context.Context, h SqlHandler, schema, table string) ([]backupColumn, error) {
+	rows, err := h.Query(ctx, `
+        SELECT COLUMN_NAME, DATA_TYPE FROM information_schema.COLUMNS
+        WHERE TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE()) AND TABLE_NAME = ?
+          AND EXTRA NOT LIKE '%VIRTUAL GENERATED%' AND EXTRA NOT LIKE '%STORED GENERATED%'
+        ORDER BY ORDINAL_POSITION
+    `, schema, table)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var columns []backupColumn
+	for rows.Next() {
+		var column backupColumn
+		if err := rows.Scan(&column.name, &column.dataType); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("%w: table %s not found", domainErrors.ErrDatabaseError, table)
+	}
+	return columns, nil
+}
+
+func quoteColumns(columns []string) string {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = "`" + column + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (r *BackupRepository) Dump(ctx context.Context) (*entity.Backup, error) {
+	backup := &entity.Backup{
+		FormatVersion: entity.BackupFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+	}
+	for _, table := range entity.BackupTables {
+		dumped, err := dumpTable(ctx, r.SqlHandler, table)
+		if err != nil {
+			return nil, err
+		}
+		backup.Tables = append(backup.Tables, *dumped)
+	}
+	return backup, nil
+}
+
+func (r *BackupRepository) Snapshot(ctx context.Context) (*entity.Backup, []entity.TableChecksum, error) {
+	backup := &entity.Backup{
+		FormatVersion: entity.BackupFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+	}
+	var checksums []entity.TableChecksum
+	// 書き出しと集計が同じ時点のデータを読むよう、1つのトランザクション（REPEATABLE READ のスナップショット）で行う
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		for _, table := range entity.BackupTables {
+			dumped, err := dumpTable(ctx, tx, table)
+			if err != nil {
+				return err
+			}
+			checksum, err := tableChecksum(ctx, tx, "", table, dumped.Columns)
+			if err != nil {
+				return err
+			}
+			backup.Tables = append(backup.Tables, *dumped)
+			checksums = append(checksums, *checksum)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return backup, checksums, nil
+}
+
+func dumpTable(ctx context.Context, h SqlHandler, table string) (*entity.BackupTable, error) {
+	columns, err := tableColumns(ctx, h, "", table)
+	if err != nil {
+		return nil, err
+	}
+	dumped := &entity.BackupTable{Name: table, Rows: [][]any{}}
+	for _, column := range columns {
+		dumped.Columns = append(dumped.Columns, column.name)
+	}
+
+	rows, err := h.Query(ctx, fmt.Sprintf("SELECT %s FROM %s ORDER BY id", quoteColumns(dumped.Columns), tableRef("", table)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		for i, column := range columns {
+			values[i] = dumpValue(values[i], column.dataType)
+		}
+		dumped.Rows = append(dumped.Rows, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return dumped, nil
+}
+
+// dumpValue はドライバーの値を JSON で表せる値にする
+func dumpValue(value any, dataType string) any {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case time.Time:
+		if dataType == "date" {
+			return v.Format("2006-01-02")
+		}
+		return v.Format(time.RFC3339Nano)
+	}
+	return value
+}
+
+// restoreValue はバックアップの値を INSERT の引数にする
+func restoreValue(value any, dataType string) (any, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String(), nil
+	case string:
+		if dataType == "datetime" || dataType == "timestamp" {
+			t, err := time.Parse(time.RFC3339Nano, v)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid %s value %q", domainErrors.ErrInvalidInput, dataType, v)
+			}
+			return t, nil
+		}
+		return v, nil
+	case nil, bool, int, int64, float64:
+		return v, nil
+	}
+	return nil, fmt.Errorf("%w: unsupported value %v", domainErrors.ErrInvalidInput, value)
+}
+
+func (r *BackupRepository) Restore(ctx context.Context, backup *entity.Backup) (*entity.RestoreResult, error) {
+	result := &entity.RestoreResult{
+		FormatVersion:   backup.FormatVersion,
+		BackupCreatedAt: backup.CreatedAt,
+	}
+	// 途中で失敗した場合に一部のテーブルだけが置き換わらないよう、すべてを1つのトランザクションで読み込む
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		for _, table := range backup.Tables {
+			restored, err := restoreTable(ctx, tx, "", table)
+			if err != nil {
+				return err
+			}
+			result.Tables = append(result.Tables, *restored)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// restoreTable はバックアップの行を schema のテーブルに ID ごとに書き込み（既存の行は置き換え）、バックアップにない行を削除する。
+// バックアップにない列（バックアップ後に追加した列）は既定値になる
+func restoreTable(ctx context.Context, tx SqlHandler, schema string, table entity.BackupTable) (*entity.RestoredTable, error) {
+	columns, err := tableColumns(ctx, tx, schema, table.Name)
+	if err != nil {
+		return nil, err
+	}
+	dataTypes := map[string]string{}
+	for _, column := range columns {
+		dataTypes[column.name] = column.dataType
+	}
+	idIndex := -1
+	var updates []string
+	for i, name := range table.Columns {
+		if _, ok := dataTypes[name]; !ok {
+			return nil, fmt.Errorf("%w: table %s has no column %s", domainErrors.ErrInvalidInput, table.Name, name)
+		}
+		if name == "id" {
+			idIndex = i
+			continue
+		}
+		updates = append(updates, fmt.Sprintf("`%s` = VALUES(`%s`)", name, name))
+	}
+
+	keep := map[string]bool{}
+	placeholder := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(table.Columns)), ", ") + ")"
+	for start := 0; start < len(table.Rows); start += backupBatchSize {
+		batch := table.Rows[start:min(start+backupBatchSize, len(table.Rows))]
+		values := make([]string, 0, len(batch))
+		args := make([]any, 0, len(batch)*len(table.Columns))
+		for _, row := range batch {
+			for i, value := range row {
+				arg, err := restoreValue(value, dataTypes[table.Columns[i]])
+				if err != nil {
+					return nil, err
+				}
+				args = append(args, arg)
+			}
+			keep[idKey(args[len(args)-len(row)+idIndex])] = true
+			values = append(values, placeholder)
+		}
+
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", tableRef(schema, table.Name), quoteColumns(table.Columns), strings.Join(values, ", "))
+		if len(updates) > 0 {
+			query += " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", ")
+		}
+		if _, err := tx.Execute(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+
+	deleted, err := deleteRowsNotIn(ctx, tx, tableRef(schema, table.Name), keep)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.RestoredTable{Name: table.Name, Restored: len(table.Rows), Deleted: deleted}, nil
+}
+
+// idKey は ID を比較用の文字列にする（大きな ID が指数表記にならないようにする）
+func idKey(id any) string {
+	if f, ok := id.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprint(id)
+}
+
+// deleteRowsNotIn は keep にない ID の行を削除し、削除した行数を返す（table は tableRef で表したテーブル）
+func deleteRowsNotIn(ctx context.Context, tx SqlHandler, table string, keep map[string]bool) (int, error) {
+	rows, err := tx.Query(ctx, fmt.Sprintf("SELECT id FROM %s", table))
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	var ids []any
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if !keep[idKey(id)] {
+			ids = append(ids, id)
+		}
+	}
+	err = rows.Err()
+	rows.Close()
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	for start := 0; start < len(ids); start += backupBatchSize {
+		batch := ids[start:min(start+backupBatchSize, len(ids))]
+		query := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s)", table, strings.TrimSuffix(strings.Repeat("?, ", len(batch)), ", "))
+		if _, err := tx.Execute(ctx, query, batch...); err != nil {
+			return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+	return len(ids), nil
+}
+
+// RestoreInto は検証用のスキーマにテーブルを作り直してバックアップの行を書き込み、書き込んだテーブルの行数とチェックサムを返す
+func (r *BackupRepository) RestoreInto(ctx context.Context, schema string, backup *entity.Backup) ([]entity.TableChecksum, error) {
+	if schema == "" || strings.ContainsAny(schema, "`.") {
+		return nil, fmt.Errorf("%w: invalid schema name %q", domainErrors.ErrInvalidInput, schema)
+	}
+	// 本番のテーブルを作り直さないよう、接続先のデータベースへの読み込みは受け付けない
+	var current string
+	if err := r.QueryRow(ctx, "SELECT DATABASE()").Scan(&current); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if strings.EqualFold(current, schema) {
+		return nil, fmt.Errorf("%w: schema %s is the production database", domainErrors.ErrInvalidInput, schema)
+	}
+
+	var checksums []entity.TableChecksum
+	for _, table := range backup.Tables {
+		// CREATE TABLE ... LIKE は外部キーを引き継がないため、検証用のスキーマに users などのテーブルは要らない
+		// （DDL は暗黙にコミットするため、トランザクションの外で実行する）
+		if _, err := r.Execute(ctx, "DROP TABLE IF EXISTS "+tableRef(schema, table.Name)); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if _, err := r.Execute(ctx, fmt.Sprintf("CREATE TABLE %s LIKE %s", tableRef(schema, table.Name), tableRef("", table.Name))); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+			_, err := restoreTable(ctx, tx, schema, table)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		checksum, err := tableChecksum(ctx, r.SqlHandler, schema, table.Name, table.Columns)
+		if err != nil {
+			return nil, err
+		}
+		checksums = append(checksums, *checksum)
+	}
+	return checksums, nil
+}
+
+// tableChecksum は columns の値から求めた各行の CRC32 の合計と行数を返す
+// （行の順序によらず、NULL と空文字列も区別する）
+func tableChecksum(ctx context.Context, h SqlHandler, schema, table string, columns []string) (*entity.TableChecksum, error) {
+	values := make([]string, 0, len(columns)*2)
+	for _, column := range columns {
+		values = append(values, fmt.Sprintf("ISNULL(`%s`), COALESCE(CAST(`%s` AS CHAR), '')", column, column))
+	}
+	query := fmt.Sprintf("SELECT COUNT(*), CAST(COALESCE(SUM(CRC32(CONCAT_WS('|', %s))), 0) AS CHAR) FROM %s",
+		strings.Join(values, ", "), tableRef(schema, table))
+
+	checksum := &entity.TableChecksum{Name: table}
+	if err := h.QueryRow(ctx, query).Scan(&checksum.Rows, &checksum.Checksum); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return checksum, nil
+}
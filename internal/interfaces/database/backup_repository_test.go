@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// items テーブルの列と行を返し、実行した文と引数を記録する SqlHandler
+type backupSqlHandler struct {
+	SqlHandler
+	columns    [][]any
+	rows       [][]any
+	ids        []int64
+	statements []string
+	args       [][]interface{}
+	// database は接続先のデータベース、queries は QueryRow で実行した文（行数 2、チェックサム "123" を返す）
+	database string
+	queries  []string
+}
+
+func (h *backupSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	h.queries = append(h.queries, statement)
+	if statement == "SELECT DATABASE()" {
+		return &valueRows{values: [][]any{{h.database}}, pos: 1}
+	}
+	return &valueRows{values: [][]any{{int64(2), "123"}}, pos: 1}
+}
+
+func (h *backupSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	switch {
+	case strings.Contains(statement, "information_schema.COLUMNS"):
+		return &valueRows{values: h.columns}, nil
+	case strings.HasPrefix(statement, "SELECT id FROM"):
+		values := make([][]any, len(h.ids))
+		for i, id := range h.ids {
+			values[i] = []any{id}
+		}
+		return &valueRows{values: values}, nil
+	}
+	return &valueRows{values: h.rows}, nil
+}
+
+func (h *backupSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	return nil, nil
+}
+
+func (h *backupSqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context, tx SqlHandler) error) error {
+	return fn(ctx, h)
+}
+
+type valueRows struct {
+	values [][]any
+	pos    int
+}
+
+func (r *valueRows) Next() bool {
+	r.pos++
+	return r.pos <= len(r.values)
+}
+
+func (r *valueRows) Scan(dest ...interface{}) error {
+	for i, d := range dest {
+		switch d := d.(type) {
+		case *string:
+			*d = r.values[r.pos-1][i].(string)
+		case *int64:
+			*d = r.values[r.pos-1][i].(int64)
+		case *any:
+			*d = r.values[r.pos-1][i]
+		}
+	}
+	return nil
+}
+
+func (r *valueRows) Close() error { return nil }
+func (r *valueRows) Err() error   { return nil }
+
+var backupColumns = [][]any{
+	{"id", "bigint"}, {"org_id", "bigint"}, {"name", "varchar"}, {"purchase_date", "date"},
+	{"created_at", "timestamp"}, {"updated_at", "timestamp"},
+}
+
+// 書き出したバックアップを JSON を経由して読み込むと、ID・作成日時・更新日時・形式のバージョンが変わらないこと
+func TestBackupRepository_RoundTrip(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	createdAt := time.Date(2024, 1, 15, 10, 30, 0, 123456000, jst)
+	updatedAt := time.Date(2024, 6, 1, 8, 0, 5, 0, jst)
+	// float64 では表せない大きな ID
+	const largeID int64 = 9007199254740993
+
+	source := &BackupRepository{SqlHandler: &backupSqlHandler{
+		columns: backupColumns,
+		rows: [][]any{
+			{int64(1), nil, []byte("ロレックス デイトナ"), time.Date(2023, 1, 15, 0, 0, 0, 0, time.Local), createdAt, updatedAt},
+			{largeID, int64(10), []byte("エルメス バーキン"), time.Date(2023, 2, 1, 0, 0, 0, 0, time.Local), createdAt, createdAt},
+		},
+	}}
+	backup, err := source.Dump(context.Background())
+	require.NoError(t, err)
+
+	data, err := json.Marshal(backup)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"format_version":1`)
+	assert.Contains(t, string(data), `"2023-01-15"`)
+
+	var decoded entity.Backup
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.NoError(t, decoded.Validate())
+
+	// 読み込み先には ID 1 と、バックアップにない ID 99 がある
+	target := &backupSqlHandler{columns: backupColumns, ids: []int64{1, 99}}
+	result, err := (&BackupRepository{SqlHandler: target}).Restore(context.Background(), &decoded)
+	require.NoError(t, err)
+
+	assert.Equal(t, entity.BackupFormatVersion, result.FormatVersion)
+	assert.True(t, backup.CreatedAt.Equal(result.BackupCreatedAt))
+	assert.Equal(t, []entity.RestoredTable{{Name: "items", Restored: 2, Deleted: 1}}, result.Tables)
+
+	require.Len(t, target.statements, 2)
+	assert.Contains(t, target.statements[0], "INSERT INTO `items` (`id`, `org_id`, `name`, `purchase_date`, `created_at`, `updated_at`) VALUES (?, ?, ?, ?, ?, ?), (?, ?, ?, ?, ?, ?)")
+	assert.Contains(t, target.statements[0], "ON DUPLICATE KEY UPDATE `org_id` = VALUES(`org_id`)")
+	assert.NotContains(t, target.statements[0], "`id` = VALUES")
+
+	args := target.args[0]
+	assert.Equal(t, "1", args[0])
+	assert.Nil(t, args[1])
+	assert.Equal(t, "ロレックス デイトナ", args[2])
+	assert.Equal(t, "2023-01-15", args[3])
+	assert.True(t, createdAt.Equal(args[4].(time.Time)), "created_at: %v", args[4])
+	assert.True(t, updatedAt.Equal(args[5].(time.Time)), "updated_at: %v", args[5])
+	assert.Equal(t, "9007199254740993", args[6])
+	assert.Equal(t, "10", args[7])
+	assert.True(t, createdAt.Equal(args[11].(time.Time)))
+
+	assert.Equal(t, "DELETE FROM `items` WHERE id IN (?)", target.statements[1])
+	assert.Equal(t, []interface{}{int64(99)}, target.args[1])
+}
+
+func TestBackupRepository_Restore_UnknownColumn(t *testing.T) {
+	target := &backupSqlHandler{columns: backupColumns}
+	backup := &entity.Backup{
+		FormatVersion: entity.BackupFormatVersion,
+		Tables:        []entity.BackupTable{{Name: "items", Columns: []string{"id", "removed_column"}, Rows: [][]any{{int64(1), "x"}}}},
+	}
+
+	_, err := (&BackupRepository{SqlHandler: target}).Restore(context.Background(), backup)
+	assert.ErrorContains(t, err, "table items has no column removed_column")
+	assert.Empty(t, target.statements)
+}
+
+// 検証用のスキーマにテーブルを作り直してバックアップの行を書き込み、書き込んだテーブルの行数とチェックサムを返すこと
+func TestBackupRepository_RestoreInto(t *testing.T) {
+	backup := &entity.Backup{
+		FormatVersion: entity.BackupFormatVersion,
+		Tables:        []entity.BackupTable{{Name: "items", Columns: []string{"id", "name"}, Rows: [][]any{{json.Number("1"), "a"}, {json.Number("2"), nil}}}},
+	}
+
+	t.Run("正常系", func(t *testing.T) {
+		h := &backupSqlHandler{columns: backupColumns, database: "items_db"}
+
+		checksums, err := (&BackupRepository{SqlHandler: h}).RestoreInto(context.Background(), "items_verify", backup)
+		require.NoError(t, err)
+		assert.Equal(t, []entity.TableChecksum{{Name: "items", Rows: 2, Checksum: "123"}}, checksums)
+
+		require.Len(t, h.statements, 3)
+		assert.Equal(t, "DROP TABLE IF EXISTS `items_verify`.`items`", h.statements[0])
+		assert.Equal(t, "CREATE TABLE `items_verify`.`items` LIKE `items`", h.statements[1])
+		assert.Contains(t, h.statements[2], "INSERT INTO `items_verify`.`items` (`id`, `name`)")
+		require.Len(t, h.queries, 2)
+		assert.Contains(t, h.queries[1], "ISNULL(`name`), COALESCE(CAST(`name` AS CHAR), '')")
+		assert.Contains(t, h.queries[1], "FROM `items_verify`.`items`")
+	})
+
+	t.Run("異常系: 本番のデータベースには読み込まない", func(t *testing.T) {
+		h := &backupSqlHandler{columns: backupColumns, database: "items_db"}
+
+		_, err := (&BackupRepository{SqlHandler: h}).RestoreInto(context.Background(), "items_db", backup)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Empty(t, h.statements)
+	})
+}
+
+// 書き出しと本番のテーブルの集計を同じトランザクションで行い、バックアップの列でチェックサムを求めること
+func TestBackupRepository_Snapshot(t *testing.T) {
+	h := &backupSqlHandler{columns: backupColumns}
+
+	backup, checksums, err := (&BackupRepository{SqlHandler: h}).Snapshot(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, entity.BackupFormatVersion, backup.FormatVersion)
+	assert.Equal(t, []entity.TableChecksum{{Name: "items", Rows: 2, Checksum: "123"}}, checksums)
+	require.Len(t, h.queries, 1)
+	assert.Contains(t, h.queries[0], "ISNULL(`purchase_date`)")
+	assert.Contains(t, h.queries[0], "FROM `items`")
+}
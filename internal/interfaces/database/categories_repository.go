@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CategoryRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanCategory の順序と一致させる）
+const categoryColumns = "id, name, name_en, defaults, required_fields, created_at"
+
+func (r *CategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY id ASC`
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	categories := []*entity.Category{}
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		categories = append(categories, category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = ?`
+
+	category, err := scanCategory(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return category, nil
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	defaults, requiredFields, err := marshalCategoryRules(category)
+	if err != nil {
+		return nil, err
+	}
+	query := `INSERT INTO categories (name, name_en, defaults, required_fields, created_at) VALUES (?, ?, ?, ?, ?)`
+
+	result, err := r.Execute(ctx, query, category.Name, category.NameEn, defaults, requiredFields, category.CreatedAt)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return nil, domainErrors.ErrDuplicateEntry
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, id int64, category *entity.Category, actorID int64, at time.Time) (*entity.Category, error) {
+	defaults, requiredFields, err := marshalCategoryRules(category)
+	if err != nil {
+		return nil, err
+	}
+	name := category.Name
+
+	var renamed *entity.Category
+	err = r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		txRepo := &CategoryRepository{SqlHandler: tx}
+		current, err := txRepo.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		query := `UPDATE categories SET name = ?, name_en = ?, defaults = ?, required_fields = ? WHERE id = ?`
+		if _, err := tx.Execute(ctx, query, name, category.NameEn, defaults, requiredFields, id); err != nil {
+			if errors.Is(err, ErrDuplicateKey) {
+				return domainErrors.ErrDuplicateEntry
+			}
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// アイテムはカテゴリー名で保存しているため、同じトランザクションで付け替える。
+		// 内容が変わるため、取得済みの ETag で上書きされないようバージョンも進める
+		// 名前を変えていない場合はアイテムを変更しない
+		if name == current.Name {
+			renamed, err = txRepo.FindByID(ctx, id)
+			return err
+		}
+		// 付け替えるアイテムごとにカテゴリーの変更を変更履歴に記録する（アイテムの更新と同じく変更前後の値を残す）
+		query = `
+            INSERT INTO item_histories (item_id, actor_id, action, field, old_value, new_value, created_at)
+            SELECT id, ?, ?, 'category', category, ?, ? FROM items WHERE category = ?
+        `
+		if _, err := tx.Execute(ctx, query, actorID, entity.ItemHistoryActionUpdate, name, at, current.Name); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		query = `UPDATE items SET category = ?, version = version + 1 WHERE category = ?`
+		if _, err := tx.Execute(ctx, query, name, current.Name); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		renamed, err = txRepo.FindByID(ctx, id)
+		return err
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsDatabaseError(err) || domainErrors.IsDuplicateError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return renamed, nil
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id int64) error {
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		current, err := (&CategoryRepository{SqlHandler: tx}).FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		// アイテムのあるカテゴリーは削除しない（アイテムが無効なカテゴリーのまま残らないようにする）。
+		// 索引の範囲をロックし、確認してから削除するまでにアイテムが登録されないようにする
+		var itemID int64
+		query := `SELECT id FROM items WHERE category = ? LIMIT 1 FOR UPDATE`
+		err = tx.QueryRow(ctx, query, current.Name).Scan(&itemID)
+		switch {
+		case err == nil:
+			return domainErrors.ErrCategoryInUse
+		case err != sql.ErrNoRows:
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		if _, err := tx.Execute(ctx, `DELETE FROM categories WHERE id = ?`, id); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsDatabaseError(err) || domainErrors.IsCategoryInUseError(err) {
+			return err
+		}
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+// カテゴリーの行をエンティティに変換する
+func scanCategory(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Category, error) {
+	var category entity.Category
+	var nameEn, defaults, requiredFields sql.NullString
+
+	err := scanner.Scan(
+		&category.ID,
+		&category.Name,
+		&nameEn,
+		&defaults,
+		&requiredFields,
+		&category.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if nameEn.Valid {
+		category.NameEn = &nameEn.String
+	}
+	if defaults.Valid {
+		if err := json.Unmarshal([]byte(defaults.String), &category.Defaults); err != nil {
+			return nil, fmt.Errorf("failed to decode defaults: %w", err)
+		}
+	}
+	category.RequiredFields = []string{}
+	if requiredFields.Valid {
+		if err := json.Unmarshal([]byte(requiredFields.String), &category.RequiredFields); err != nil {
+			return nil, fmt.Errorf("failed to decode required_fields: %w", err)
+		}
+	}
+
+	return &category, nil
+}
+
+// marshalCategoryRules は既定値と必須項目を defaults 列と required_fields 列の JSON にする（未設定は NULL）
+func marshalCategoryRules(category *entity.Category) (defaults, requiredFields interface{}, err error) {
+	if category.Defaults != (entity.CategoryDefaults{}) {
+		b, err := json.Marshal(category.Defaults)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: failed to encode defaults: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		defaults = string(b)
+	}
+	if len(category.RequiredFields) > 0 {
+		b, err := json.Marshal(category.RequiredFields)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: failed to encode required_fields: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		requiredFields = string(b)
+	}
+	return defaults, requiredFields, nil
+}
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// カテゴリーの取得には names の順に名前を返し、実行した文をトランザクションの内外で分けて記録する SqlHandler
+type categorySqlHandler struct {
+	historySqlHandler
+	names []string
+	args  [][]interface{}
+}
+
+func (h *categorySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.args = append(h.args, args)
+	return h.historySqlHandler.Execute(ctx, statement, args...)
+}
+
+func (h *categorySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	name := h.names[0]
+	h.names = h.names[1:]
+	return categoryRow{name: name}
+}
+
+func (h *categorySqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context, tx SqlHandler) error) error {
+	return h.historySqlHandler.Transaction(ctx, func(ctx context.Context, _ SqlHandler) error {
+		return fn(ctx, h)
+	})
+}
+
+type categoryRow struct {
+	name string
+}
+
+func (r categoryRow) Scan(dest ...interface{}) error {
+	*dest[0].(*int64) = 5
+	*dest[1].(*string) = r.name
+	*dest[5].(*time.Time) = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	return nil
+}
+
+// 名前の変更でカテゴリーを付け替えたアイテムごとに、同じトランザクションで変更履歴を記録すること
+func TestCategoryRepository_Update_RecordsItemHistory(t *testing.T) {
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	h := &categorySqlHandler{names: []string{"その他", "雑貨"}}
+
+	renamed, err := (&CategoryRepository{SqlHandler: h}).Update(context.Background(), 5, &entity.Category{Name: "雑貨"}, 9, at)
+	require.NoError(t, err)
+	assert.Equal(t, "雑貨", renamed.Name)
+
+	require.Len(t, h.statements, 3)
+	assert.Contains(t, h.statements[1], "INSERT INTO item_histories")
+	assert.Contains(t, h.statements[1], "FROM items WHERE category = ?")
+	assert.Equal(t, []interface{}{int64(9), entity.ItemHistoryActionUpdate, "雑貨", at, "その他"}, h.args[1])
+	assert.Contains(t, h.statements[2], "UPDATE items SET category = ?")
+	assert.Empty(t, h.outside)
+}
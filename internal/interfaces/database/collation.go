@@ -0,0 +1,60 @@
+package database
+
+import (
+	"bytes"
+	"regexp"
+	"sort"
+
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// collationNamePattern は SQL に埋め込むコレーション名の形式（設定値を検証する）
+var collationNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// nameCollationClause は名前の照合順序を DB で適用する COLLATE 句を返す。
+// DB で適用できない場合（コレーション未設定や数字を数値として比較する場合）は空を返す
+func nameCollationClause(sort entity.ItemSort, collation string) string {
+	if sort.Key != entity.SortKeyName || sort.Collation != entity.CollationJa {
+		return ""
+	}
+	if collation == "" || !collationNamePattern.MatchString(collation) {
+		return ""
+	}
+	return " COLLATE " + collation
+}
+
+// needsCollationFallback は DB で照合順序を適用できず、取得後に並べ替える必要があるかを判定する
+func needsCollationFallback(sort entity.ItemSort, collation string) bool {
+	return sort.Key == entity.SortKeyName && sort.Collation != "" && nameCollationClause(sort, collation) == ""
+}
+
+// sortItemsByCollation は名前を日本語の照合順序で並べ替える（同じ名前はIDの順）
+func sortItemsByCollation(items []*entity.Item, s entity.ItemSort) {
+	options := []collate.Option{}
+	if s.Collation == entity.CollationNatural {
+		options = append(options, collate.Numeric)
+	}
+	collator := collate.New(language.Japanese, options...)
+
+	// 比較のたびに照合キーを作らないよう、先にまとめて作る
+	var buf collate.Buffer
+	keys := make(map[int64][]byte, len(items))
+	for _, item := range items {
+		keys[item.ID] = collator.KeyFromString(&buf, item.Name)
+	}
+
+	desc := s.Order == entity.SortOrderDesc
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if desc {
+			a, b = b, a
+		}
+		if c := bytes.Compare(keys[a.ID], keys[b.ID]); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestBuildItemOrderBy_Collation(t *testing.T) {
+	ja := entity.ItemSort{Key: entity.SortKeyName, Collation: entity.CollationJa}
+	assert.Equal(t, "name COLLATE utf8mb4_ja_0900_as_cs ASC, id ASC", buildItemOrderBy(ja, "utf8mb4_ja_0900_as_cs"))
+	assert.Equal(t, "name ASC, id ASC", buildItemOrderBy(ja, ""))
+	// 設定値であっても識別子以外は埋め込まない
+	assert.Equal(t, "name ASC, id ASC", buildItemOrderBy(ja, "x; DROP TABLE items"))
+
+	natural := entity.ItemSort{Key: entity.SortKeyName, Order: entity.SortOrderDesc, Collation: entity.CollationNatural}
+	assert.Equal(t, "name DESC, id DESC", buildItemOrderBy(natural, "utf8mb4_ja_0900_as_cs"))
+
+	assert.False(t, needsCollationFallback(ja, "utf8mb4_ja_0900_as_cs"))
+	assert.True(t, needsCollationFallback(ja, ""))
+	assert.True(t, needsCollationFallback(natural, "utf8mb4_ja_0900_as_cs"))
+	assert.False(t, needsCollationFallback(entity.ItemSort{Key: entity.SortKeyName}, ""))
+}
+
+func TestSortItemsByCollation(t *testing.T) {
+	names := func(items []*entity.Item) []string {
+		var out []string
+		for _, item := range items {
+			out = append(out, item.Name)
+		}
+		return out
+	}
+	newItems := func() []*entity.Item {
+		return []*entity.Item{
+			{ID: 1, Name: "時計 No.10"},
+			{ID: 2, Name: "エルメス"},
+			{ID: 3, Name: "時計 No.2"},
+			{ID: 4, Name: "あいう"},
+			{ID: 5, Name: "Apple"},
+			{ID: 6, Name: "かばん"},
+		}
+	}
+
+	items := newItems()
+	sortItemsByCollation(items, entity.ItemSort{Key: entity.SortKeyName, Collation: entity.CollationJa})
+	assert.Equal(t, []string{"Apple", "あいう", "エルメス", "かばん", "時計 No.10", "時計 No.2"}, names(items))
+
+	items = newItems()
+	sortItemsByCollation(items, entity.ItemSort{Key: entity.SortKeyName, Collation: entity.CollationNatural})
+	assert.Equal(t, []string{"Apple", "あいう", "エルメス", "かばん", "時計 No.2", "時計 No.10"}, names(items))
+
+	items = newItems()
+	sortItemsByCollation(items, entity.ItemSort{Key: entity.SortKeyName, Order: entity.SortOrderDesc, Collation: entity.CollationNatural})
+	assert.Equal(t, []string{"時計 No.10", "時計 No.2", "かばん", "エルメス", "あいう", "Apple"}, names(items))
+}
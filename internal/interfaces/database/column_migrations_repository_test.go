@@ -0,0 +1,108 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 実行した文と引数を記録し、問い合わせには ids を1列の行として返す SqlHandler
+type columnMigrationSqlHandler struct {
+	SqlHandler
+	ids        []int64
+	statements []string
+	args       [][]interface{}
+}
+
+func (h *columnMigrationSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	return nil, nil
+}
+
+func (h *columnMigrationSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.statements = append(h.statements, statement)
+	h.args = append(h.args, args)
+	return &idRows{ids: h.ids}, nil
+}
+
+type idRows struct {
+	ids []int64
+	pos int
+}
+
+func (r *idRows) Next() bool {
+	r.pos++
+	return r.pos <= len(r.ids)
+}
+
+func (r *idRows) Scan(dest ...interface{}) error {
+	*dest[0].(*int64) = r.ids[r.pos-1]
+	return nil
+}
+
+func (r *idRows) Close() error { return nil }
+func (r *idRows) Err() error   { return nil }
+
+func testColumnMigration(phase entity.ColumnMigrationPhase) *entity.ColumnMigration {
+	m := &entity.ColumnMigration{
+		Name:      "items.purchased_on",
+		Table:     "items",
+		OldColumn: "purchase_date_text",
+		NewColumn: "purchased_on",
+		Convert:   "STR_TO_DATE(purchase_date_text, '%Y-%m-%d')",
+	}
+	m.SetPhase(phase)
+	return m
+}
+
+func TestDualWriteColumn(t *testing.T) {
+	t.Run("二重書き込みの段階では書き込んだ行の新列を更新する", func(t *testing.T) {
+		db := &columnMigrationSqlHandler{}
+
+		require.NoError(t, dualWriteColumn(context.Background(), db, testColumnMigration(entity.ColumnMigrationPhaseDualWrite), 3, 5))
+
+		assert.Equal(t, []string{"UPDATE items SET purchased_on = STR_TO_DATE(purchase_date_text, '%Y-%m-%d') WHERE id IN (?, ?)"}, db.statements)
+		assert.Equal(t, [][]interface{}{{int64(3), int64(5)}}, db.args)
+	})
+
+	t.Run("旧列のみの段階では何もしない", func(t *testing.T) {
+		db := &columnMigrationSqlHandler{}
+
+		require.NoError(t, dualWriteColumn(context.Background(), db, testColumnMigration(entity.ColumnMigrationPhaseOld), 3))
+
+		assert.Empty(t, db.statements)
+	})
+}
+
+func TestCompareColumnReads(t *testing.T) {
+	t.Run("dual_read の段階では不一致の行を記録する", func(t *testing.T) {
+		m := testColumnMigration(entity.ColumnMigrationPhaseDualRead)
+		db := &columnMigrationSqlHandler{ids: []int64{5}}
+
+		compareColumnReads(context.Background(), db, m, []int64{3, 5, 8})
+
+		require.Len(t, db.statements, 1)
+		assert.Equal(t, "SELECT id FROM items WHERE id IN (?, ?, ?) AND NOT (purchased_on <=> STR_TO_DATE(purchase_date_text, '%Y-%m-%d')) ORDER BY id", db.statements[0])
+		status := m.Status()
+		assert.Equal(t, int64(3), status.ReadsCompared)
+		assert.Equal(t, int64(1), status.ReadMismatches)
+		assert.Equal(t, int64(5), status.LastReadMismatchID)
+	})
+
+	t.Run("ほかの段階では比べない", func(t *testing.T) {
+		for _, phase := range []entity.ColumnMigrationPhase{entity.ColumnMigrationPhaseDualWrite, entity.ColumnMigrationPhaseNew} {
+			m := testColumnMigration(phase)
+			db := &columnMigrationSqlHandler{}
+
+			compareColumnReads(context.Background(), db, m, []int64{3})
+
+			assert.Empty(t, db.statements)
+			assert.Zero(t, m.Status().ReadsCompared)
+		}
+	})
+}
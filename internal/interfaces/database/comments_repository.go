@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type CommentRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanComment の順序と一致させる）。投稿者のメールアドレスは users から取得する
+const commentColumns = "c.id, c.item_id, c.author_id, COALESCE(u.email, ''), c.body, c.created_at, c.updated_at"
+
+const commentFrom = "FROM item_comments c LEFT JOIN users u ON u.id = c.author_id"
+
+func (r *CommentRepository) Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	query := `
+        INSERT INTO item_comments (item_id, author_id, body)
+        VALUES (?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, comment.ItemID, comment.AuthorID, comment.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, comment.ItemID, id)
+}
+
+func (r *CommentRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Comment, error) {
+	query := `
+        SELECT ` + commentColumns + `
+        ` + commentFrom + `
+        WHERE c.item_id = ?
+        ORDER BY c.created_at ASC, c.id ASC
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	comments := []*entity.Comment{}
+	for rows.Next() {
+		comment, err := scanComment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		comments = append(comments, comment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return comments, nil
+}
+
+func (r *CommentRepository) FindByID(ctx context.Context, itemID, id int64) (*entity.Comment, error) {
+	query := `SELECT ` + commentColumns + ` ` + commentFrom + ` WHERE c.id = ? AND c.item_id = ?`
+
+	comment, err := scanComment(r.QueryRow(ctx, query, id, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return comment, nil
+}
+
+func (r *CommentRepository) Update(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	query := `
+        UPDATE item_comments
+        SET body = ?
+        WHERE id = ? AND item_id = ?
+    `
+
+	if _, err := r.Execute(ctx, query, comment.Body, comment.ID, comment.ItemID); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, comment.ItemID, comment.ID)
+}
+
+func (r *CommentRepository) Delete(ctx context.Context, itemID, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM item_comments WHERE id = ? AND item_id = ?`, id, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrCommentNotFound
+	}
+
+	return nil
+}
+
+// コメントの行をエンティティに変換する
+func scanComment(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Comment, error) {
+	var comment entity.Comment
+
+	err := scanner.Scan(
+		&comment.ID,
+		&comment.ItemID,
+		&comment.AuthorID,
+		&comment.AuthorEmail,
+		&comment.Body,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ConsignmentRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanConsignment の順序と一致させる）
+const consignmentColumns = "id, item_id, consignor_name, consignor_contact, agreed_price, commission_rate, deadline, status, created_at, updated_at"
+
+func (r *ConsignmentRepository) FindByItemID(ctx context.Context, itemID int64) (*entity.Consignment, error) {
+	query := `SELECT ` + consignmentColumns + ` FROM consignments WHERE item_id = ?`
+
+	consignment, err := scanConsignment(r.QueryRow(ctx, query, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrConsignmentNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return consignment, nil
+}
+
+func (r *ConsignmentRepository) Save(ctx context.Context, consignment *entity.Consignment) (*entity.Consignment, error) {
+	// アイテムごとに1件のため、既存の契約は内容を置き換える
+	query := `
+        INSERT INTO consignments (item_id, consignor_name, consignor_contact, agreed_price, commission_rate, deadline, status)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            consignor_name = VALUES(consignor_name),
+            consignor_contact = VALUES(consignor_contact),
+            agreed_price = VALUES(agreed_price),
+            commission_rate = VALUES(commission_rate),
+            deadline = VALUES(deadline),
+            status = VALUES(status)
+    `
+
+	var deadline interface{}
+	if consignment.Deadline != "" {
+		deadline = consignment.Deadline
+	}
+
+	_, err := r.Execute(ctx, query,
+		consignment.ItemID,
+		consignment.ConsignorName,
+		consignment.ConsignorContact,
+		consignment.AgreedPrice,
+		consignment.CommissionRate,
+		deadline,
+		consignment.Status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByItemID(ctx, consignment.ItemID)
+}
+
+func (r *ConsignmentRepository) Delete(ctx context.Context, itemID int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM consignments WHERE item_id = ?`, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrConsignmentNotFound
+	}
+
+	return nil
+}
+
+func (r *ConsignmentRepository) FindAll(ctx context.Context, userID int64, status entity.ConsignmentStatus) ([]*entity.Consignment, error) {
+	scope, args := accessCondition(userID)
+	query := `
+        SELECT ` + consignmentColumns + `
+        FROM consignments
+        WHERE item_id IN (SELECT id FROM items WHERE ` + scope + `)`
+	if status != "" {
+		query += ` AND status = ?`
+		args = append(args, status)
+	}
+	// 期限のない契約は最後にする
+	query += `
+        ORDER BY deadline IS NULL, deadline ASC, id ASC
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	consignments := []*entity.Consignment{}
+	for rows.Next() {
+		consignment, err := scanConsignment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		consignments = append(consignments, consignment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return consignments, nil
+}
+
+func (r *ConsignmentRepository) SummarizeOwned(ctx context.Context, userID int64) (int, int64, error) {
+	scope, args := accessCondition(userID)
+	query := `
+        SELECT COUNT(*), COALESCE(SUM(CASE WHEN purchase_currency = 'JPY' THEN purchase_price ELSE 0 END), 0)
+        FROM items
+        WHERE ` + scope + ` AND NOT EXISTS (SELECT 1 FROM consignments c WHERE c.item_id = items.id)
+    `
+
+	// 合計は円建ての購入価格のみ（外貨建ては件数にのみ含める）。
+	// 合計は DECIMAL で返るため、INT の上限を超えても int64 で受け取る
+	var count int
+	var purchaseValue int64
+	if err := r.QueryRow(ctx, query, args...).Scan(&count, &purchaseValue); err != nil {
+		return 0, 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return count, purchaseValue, nil
+}
+
+func scanConsignment(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Consignment, error) {
+	var consignment entity.Consignment
+	var deadline sql.NullTime
+	var createdAt, updatedAt time.Time
+
+	err := scanner.Scan(
+		&consignment.ID,
+		&consignment.ItemID,
+		&consignment.ConsignorName,
+		&consignment.ConsignorContact,
+		&consignment.AgreedPrice,
+		&consignment.CommissionRate,
+		&deadline,
+		&consignment.Status,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline.Valid {
+		consignment.Deadline = deadline.Time.Format("2006-01-02")
+	}
+	consignment.CreatedAt = createdAt
+	consignment.UpdatedAt = updatedAt
+
+	return &consignment, nil
+}
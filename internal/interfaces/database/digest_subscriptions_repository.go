@@ -0,0 +1,116 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type DigestSubscriptionRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanDigestSubscription の順序と一致させる）。
+// 配信設定のないユーザーは配信しない設定として扱う
+const digestSubscriptionColumns = "u.id, u.email, COALESCE(d.frequency, 'off'), d.last_sent_at, COALESCE(d.updated_at, u.created_at)"
+
+func (r *DigestSubscriptionRepository) FindByUserID(ctx context.Context, userID int64) (*entity.DigestSubscription, error) {
+	query := `
+        SELECT ` + digestSubscriptionColumns + `
+        FROM users u LEFT JOIN digest_subscriptions d ON d.user_id = u.id
+        WHERE u.id = ?
+    `
+
+	subscription, err := scanDigestSubscription(r.QueryRow(ctx, query, userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return subscription, nil
+}
+
+func (r *DigestSubscriptionRepository) Save(ctx context.Context, subscription *entity.DigestSubscription) (*entity.DigestSubscription, error) {
+	// ユーザーごとに1件のため、既存の設定は配信頻度を置き換える（最後の配信日時は残す）
+	query := `
+        INSERT INTO digest_subscriptions (user_id, frequency)
+        VALUES (?, ?)
+        ON DUPLICATE KEY UPDATE
+            frequency = VALUES(frequency)
+    `
+
+	_, err := r.Execute(ctx, query, subscription.UserID, subscription.Frequency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByUserID(ctx, subscription.UserID)
+}
+
+func (r *DigestSubscriptionRepository) FindSubscribed(ctx context.Context) ([]*entity.DigestSubscription, error) {
+	query := `
+        SELECT ` + digestSubscriptionColumns + `
+        FROM digest_subscriptions d JOIN users u ON u.id = d.user_id
+        WHERE d.frequency <> 'off'
+        ORDER BY u.id ASC
+    `
+
+	rows, err := r.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	subscriptions := []*entity.DigestSubscription{}
+	for rows.Next() {
+		subscription, err := scanDigestSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return subscriptions, nil
+}
+
+func (r *DigestSubscriptionRepository) MarkSent(ctx context.Context, userID int64, sentAt time.Time) error {
+	query := `UPDATE digest_subscriptions SET last_sent_at = ? WHERE user_id = ?`
+
+	if _, err := r.Execute(ctx, query, sentAt, userID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func scanDigestSubscription(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.DigestSubscription, error) {
+	var subscription entity.DigestSubscription
+	var lastSentAt sql.NullTime
+
+	err := scanner.Scan(
+		&subscription.UserID,
+		&subscription.Email,
+		&subscription.Frequency,
+		&lastSentAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastSentAt.Valid {
+		subscription.LastSentAt = &lastSentAt.Time
+	}
+
+	return &subscription, nil
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// DocumentTextRepository は購入書類の画像から抽出したテキストを ngram の全文インデックスで検索する
+type DocumentTextRepository struct {
+	SqlHandler
+}
+
+// スニペットに含める一致した箇所の前後の文字数
+const snippetContext = 30
+
+func (r *DocumentTextRepository) Put(ctx context.Context, doc *entity.DocumentText) error {
+	// 認識中に削除された画像のテキストは保存しない
+	query := `
+        INSERT INTO item_document_texts (image_id, item_id, text, indexed_at)
+        SELECT id, item_id, ?, ? FROM item_images WHERE id = ? AND item_id = ?
+        ON DUPLICATE KEY UPDATE text = VALUES(text), indexed_at = VALUES(indexed_at)
+    `
+
+	result, err := r.Execute(ctx, query, doc.Text, doc.IndexedAt, doc.ImageID, doc.ItemID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if rowsAffected == 0 {
+		// 同じテキストで上書きした場合も0件になるため、画像が残っているかを確認する
+		var exists bool
+		if err := r.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM item_images WHERE id = ? AND item_id = ?)", doc.ImageID, doc.ItemID).Scan(&exists); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if !exists {
+			return domainErrors.ErrItemImageNotFound
+		}
+	}
+
+	return nil
+}
+
+func (r *DocumentTextRepository) Delete(ctx context.Context, itemID, imageID int64) error {
+	query := `DELETE FROM item_document_texts WHERE item_id = ? AND image_id = ?`
+
+	if _, err := r.Execute(ctx, query, itemID, imageID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *DocumentTextRepository) Search(ctx context.Context, keyword string, scope int64, limit int) ([]entity.DocumentMatch, error) {
+	condition, args := accessCondition(scope)
+
+	// ngram の最小トークン長（2文字）未満のキーワードは LIKE で検索する（関連度は一致した箇所の数）
+	var query string
+	fullText := utf8.RuneCountInString(keyword) >= 2
+	if fullText {
+		query = `
+        SELECT d.item_id, d.text, MATCH(d.text) AGAINST (? IN BOOLEAN MODE) AS score
+        FROM item_document_texts d
+        JOIN items ON items.id = d.item_id
+        WHERE ` + condition + ` AND MATCH(d.text) AGAINST (? IN BOOLEAN MODE)
+        ORDER BY score DESC, d.item_id DESC
+    `
+		phrase := toBooleanPhrase(keyword)
+		args = append([]interface{}{phrase}, append(args, phrase)...)
+	} else {
+		query = `
+        SELECT d.item_id, d.text, 0
+        FROM item_document_texts d
+        JOIN items ON items.id = d.item_id
+        WHERE ` + condition + ` AND d.text LIKE ?
+        ORDER BY d.item_id DESC
+    `
+		args = append(args, "%"+escapeLike(keyword)+"%")
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	highlighter := newSearchHighlighter(keyword)
+	matches := []entity.DocumentMatch{}
+	seen := make(map[int64]bool)
+	for rows.Next() {
+		var match entity.DocumentMatch
+		var text string
+		if err := rows.Scan(&match.ItemID, &text, &match.Score); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		// 1つのアイテムに複数の書類がある場合は関連度の最も高い書類のみ返す
+		if seen[match.ItemID] || len(matches) >= limit {
+			continue
+		}
+		seen[match.ItemID] = true
+
+		match.Snippet, match.Spans = highlighter.snippet(text, snippetContext)
+		if !fullText {
+			match.Score = float64(len(highlighter.spans(text)))
+		}
+		matches = append(matches, match)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return matches, nil
+}
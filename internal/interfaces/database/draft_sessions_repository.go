@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type DraftSessionRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanDraftSession の順序と一致させる）
+const draftSessionColumns = "id, user_id, fields, images, version, created_at, updated_at, expires_at"
+
+// draftImageRecord は images 列に保存する下書きの画像（entity.DraftImage の JSON には保存先を含めないため別に定義する）
+type draftImageRecord struct {
+	ID          string    `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	StorageKey  string    `json:"storage_key"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func (r *DraftSessionRepository) Create(ctx context.Context, session *entity.DraftSession) error {
+	fields, images, err := encodeDraftSession(session)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        INSERT INTO item_draft_sessions (id, user_id, fields, images, version, created_at, updated_at, expires_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+    `
+	if _, err := r.Execute(ctx, query, session.ID, session.UserID, fields, images, session.Version, session.CreatedAt, session.UpdatedAt, session.ExpiresAt); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *DraftSessionRepository) FindByID(ctx context.Context, id string) (*entity.DraftSession, error) {
+	query := `SELECT ` + draftSessionColumns + ` FROM item_draft_sessions WHERE id = ?`
+
+	session, err := scanDraftSession(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrDraftSessionNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return session, nil
+}
+
+func (r *DraftSessionRepository) Update(ctx context.Context, session *entity.DraftSession, version int64) error {
+	fields, images, err := encodeDraftSession(session)
+	if err != nil {
+		return err
+	}
+
+	query := `
+        UPDATE item_draft_sessions
+        SET fields = ?, images = ?, version = ?, updated_at = ?, expires_at = ?
+        WHERE id = ? AND version = ?
+    `
+	result, err := r.Execute(ctx, query, fields, images, session.Version, session.UpdatedAt, session.ExpiresAt, session.ID, version)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if affected == 0 {
+		return domainErrors.ErrDraftSessionNotFound
+	}
+	return nil
+}
+
+func (r *DraftSessionRepository) CountActive(ctx context.Context, userID int64, now time.Time) (int, error) {
+	var count int
+	if err := r.QueryRow(ctx, `SELECT COUNT(*) FROM item_draft_sessions WHERE user_id = ? AND expires_at > ?`, userID, now).Scan(&count); err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return count, nil
+}
+
+func (r *DraftSessionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.Execute(ctx, `DELETE FROM item_draft_sessions WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if affected == 0 {
+		return domainErrors.ErrDraftSessionNotFound
+	}
+	return nil
+}
+
+func (r *DraftSessionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.DraftSession, error) {
+	query := `SELECT ` + draftSessionColumns + ` FROM item_draft_sessions WHERE expires_at <= ? ORDER BY expires_at ASC LIMIT ?`
+
+	rows, err := r.Query(ctx, query, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	sessions := []*entity.DraftSession{}
+	for rows.Next() {
+		session, err := scanDraftSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		sessions = append(sessions, session)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return sessions, nil
+}
+
+func encodeDraftSession(session *entity.DraftSession) ([]byte, []byte, error) {
+	fields, err := json.Marshal(session.Fields)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode draft fields: %w", err)
+	}
+	records := make([]draftImageRecord, len(session.Images))
+	for i, image := range session.Images {
+		records[i] = draftImageRecord{image.ID, image.FileName, image.ContentType, image.Size, image.StorageKey, image.CreatedAt}
+	}
+	images, err := json.Marshal(records)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode draft images: %w", err)
+	}
+	return fields, images, nil
+}
+
+// 下書きの行をエンティティに変換する
+func scanDraftSession(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.DraftSession, error) {
+	var session entity.DraftSession
+	var fields, images []byte
+	if err := scanner.Scan(&session.ID, &session.UserID, &fields, &images, &session.Version, &session.CreatedAt, &session.UpdatedAt, &session.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(fields, &session.Fields); err != nil {
+		return nil, fmt.Errorf("invalid draft fields: %w", err)
+	}
+	var records []draftImageRecord
+	if err := json.Unmarshal(images, &records); err != nil {
+		return nil, fmt.Errorf("invalid draft images: %w", err)
+	}
+	session.Images = make([]entity.DraftImage, len(records))
+	for i, r := range records {
+		session.Images[i] = entity.DraftImage{ID: r.ID, FileName: r.FileName, ContentType: r.ContentType, Size: r.Size, StorageKey: r.StorageKey, CreatedAt: r.CreatedAt}
+	}
+	return &session, nil
+}
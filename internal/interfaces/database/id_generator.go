@@ -0,0 +1,15 @@
+package database
+
+// IDGenerator は INSERT する行の ID を生成する。
+// シャーディングなどで ID をデータベースの外で採番する構成でも、リポジトリを変更せずに差し替えられる
+type IDGenerator interface {
+	// NextID は新しい ID を返す。0 の場合はデータベースの AUTO_INCREMENT で採番する
+	NextID() (int64, error)
+}
+
+// AutoIncrement はデータベースの AUTO_INCREMENT で採番する IDGenerator
+type AutoIncrement struct{}
+
+func (AutoIncrement) NextID() (int64, error) {
+	return 0, nil
+}
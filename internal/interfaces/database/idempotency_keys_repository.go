@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type IdempotencyRepository struct {
+	SqlHandler
+}
+
+func (r *IdempotencyRepository) Create(ctx context.Context, record *entity.IdempotencyRecord) error {
+	query := `
+        INSERT INTO idempotency_keys (user_id, idempotency_key, request_hash, created_at)
+        VALUES (?, ?, ?, ?)
+    `
+
+	if _, err := r.Execute(ctx, query, record.UserID, record.Key, record.RequestHash, record.CreatedAt); err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return domainErrors.ErrDuplicateEntry
+		}
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Find(ctx context.Context, userID int64, key string) (*entity.IdempotencyRecord, error) {
+	query := `
+        SELECT user_id, idempotency_key, request_hash, completed, status_code, content_type, body, created_at
+        FROM idempotency_keys
+        WHERE user_id = ? AND idempotency_key = ?
+    `
+
+	var record entity.IdempotencyRecord
+	err := r.QueryRow(ctx, query, userID, key).Scan(
+		&record.UserID,
+		&record.Key,
+		&record.RequestHash,
+		&record.Completed,
+		&record.StatusCode,
+		&record.ContentType,
+		&record.Body,
+		&record.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return &record, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	query := `
+        UPDATE idempotency_keys
+        SET completed = TRUE, status_code = ?, content_type = ?, body = ?
+        WHERE user_id = ? AND idempotency_key = ?
+    `
+
+	if _, err := r.Execute(ctx, query, record.StatusCode, record.ContentType, record.Body, record.UserID, record.Key); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, userID int64, key string) error {
+	if _, err := r.Execute(ctx, `DELETE FROM idempotency_keys WHERE user_id = ? AND idempotency_key = ?`, userID, key); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	if _, err := r.Execute(ctx, `DELETE FROM idempotency_keys WHERE created_at < ?`, before); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type InvoiceRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanInvoice の順序と一致させる）
+const invoiceColumns = "id, number, item_id, user_id, buyer_name, buyer_address, line_items, subtotal, tax_rate, tax, total, issued_at, created_at"
+
+func (r *InvoiceRepository) Create(ctx context.Context, invoice *entity.Invoice) (*entity.Invoice, error) {
+	// 採番の前に確認し、発行済みのアイテムで番号が欠けないようにする（一意制約は同時に発行された場合のため）
+	var exists int
+	err := r.QueryRow(ctx, `SELECT COUNT(*) FROM invoices WHERE item_id = ?`, invoice.ItemID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	if exists > 0 {
+		return nil, domainErrors.ErrDuplicateEntry
+	}
+
+	lines, err := json.Marshal(invoice.Lines)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	year := invoice.IssuedAt.Year()
+	seq, err := r.nextNumber(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+        INSERT INTO invoices (number, item_id, user_id, buyer_name, buyer_address, line_items, subtotal, tax_rate, tax, total, issued_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		entity.InvoiceNumber(year, seq),
+		invoice.ItemID,
+		invoice.UserID,
+		invoice.BuyerName,
+		invoice.BuyerAddress,
+		string(lines),
+		invoice.Subtotal,
+		invoice.TaxRate,
+		invoice.Tax,
+		invoice.Total,
+		invoice.IssuedAt,
+	)
+	if err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return nil, domainErrors.ErrDuplicateEntry
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// nextNumber は発行年の次の連番を返す。LAST_INSERT_ID(expr) は接続ごとの値のため、同時に発行しても重複しない
+func (r *InvoiceRepository) nextNumber(ctx context.Context, year int) (int, error) {
+	query := `
+        INSERT INTO invoice_sequences (year, last_number) VALUES (?, LAST_INSERT_ID(1))
+        ON DUPLICATE KEY UPDATE last_number = LAST_INSERT_ID(last_number + 1)
+    `
+
+	result, err := r.Execute(ctx, query, year)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	seq, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("%w: failed to get invoice number: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return int(seq), nil
+}
+
+func (r *InvoiceRepository) FindByID(ctx context.Context, id int64) (*entity.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices WHERE id = ?`
+
+	invoice, err := scanInvoice(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return invoice, nil
+}
+
+func (r *InvoiceRepository) FindAll(ctx context.Context, userID int64) ([]*entity.Invoice, error) {
+	query := `SELECT ` + invoiceColumns + ` FROM invoices`
+	var args []interface{}
+	if userID != 0 {
+		query += ` WHERE user_id = ?`
+		args = append(args, userID)
+	}
+	query += ` ORDER BY issued_at DESC, id DESC`
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	invoices := []*entity.Invoice{}
+	for rows.Next() {
+		invoice, err := scanInvoice(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		invoices = append(invoices, invoice)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return invoices, nil
+}
+
+// 請求書の行をエンティティに変換する（明細はJSON配列で保存する）
+func scanInvoice(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	var itemID sql.NullInt64
+	var lines []byte
+
+	err := scanner.Scan(
+		&invoice.ID,
+		&invoice.Number,
+		&itemID,
+		&invoice.UserID,
+		&invoice.BuyerName,
+		&invoice.BuyerAddress,
+		&lines,
+		&invoice.Subtotal,
+		&invoice.TaxRate,
+		&invoice.Tax,
+		&invoice.Total,
+		&invoice.IssuedAt,
+		&invoice.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	invoice.ItemID = itemID.Int64
+	invoice.Lines = []entity.InvoiceLine{}
+	if len(lines) > 0 {
+		if err := json.Unmarshal(lines, &invoice.Lines); err != nil {
+			return nil, err
+		}
+	}
+
+	return &invoice, nil
+}
@@ -0,0 +1,237 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemHistoryRepository struct {
+	SqlHandler
+	// IDs は RecordCreate で登録するアイテムの ID の採番方法（ItemRepository.IDs と同じもの。nil の場合は AUTO_INCREMENT）
+	IDs IDGenerator
+}
+
+// SELECT 対象の列（scanItemHistory の順序と一致させる）。操作したユーザーのメールアドレスは users から取得する
+const itemHistoryColumns = "h.id, h.item_id, h.actor_id, COALESCE(u.email, ''), h.action, h.field, h.old_value, h.new_value, h.raw_value, h.created_at"
+
+func (r *ItemHistoryRepository) Create(ctx context.Context, histories []*entity.ItemHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("(?, ?, ?, ?, ?, ?, ?, ?), ", len(histories)), ", ")
+	query := `
+        INSERT INTO item_histories (item_id, actor_id, action, field, old_value, new_value, raw_value, created_at)
+        VALUES ` + placeholders
+
+	args := make([]interface{}, 0, len(histories)*8)
+	for _, h := range histories {
+		args = append(args, h.ItemID, h.ActorID, h.Action, h.Field, h.OldValue, h.NewValue, h.RawValue, h.CreatedAt)
+	}
+
+	if _, err := r.Execute(ctx, query, args...); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+func (r *ItemHistoryRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemHistory, error) {
+	query := `
+        SELECT ` + itemHistoryColumns + `
+        FROM item_histories h LEFT JOIN users u ON u.id = h.actor_id
+        WHERE h.item_id = ?
+        ORDER BY h.created_at ASC, h.id ASC
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	histories := []*entity.ItemHistory{}
+	for rows.Next() {
+		history, err := scanItemHistory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		histories = append(histories, history)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return histories, nil
+}
+
+func (r *ItemHistoryRepository) FindSince(ctx context.Context, since time.Time, afterID int64, limit int) ([]*entity.ItemHistory, error) {
+	condition, args := "h.id > ?", []interface{}{afterID}
+	if !since.IsZero() {
+		condition += " AND h.created_at >= ?"
+		args = append(args, since)
+	}
+	query := `
+        SELECT ` + itemHistoryColumns + `
+        FROM item_histories h LEFT JOIN users u ON u.id = h.actor_id
+        WHERE ` + condition + `
+        ORDER BY h.id ASC
+        LIMIT ?
+    `
+
+	rows, err := r.Query(ctx, query, append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	histories := []*entity.ItemHistory{}
+	for rows.Next() {
+		history, err := scanItemHistory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		histories = append(histories, history)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return histories, nil
+}
+
+func (r *ItemHistoryRepository) RecordCreate(ctx context.Context, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error) {
+	var created *entity.Item
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		var err error
+		if created, err = (&ItemRepository{SqlHandler: tx, IDs: r.IDs}).Create(ctx, item); err != nil {
+			return err
+		}
+		// ID は登録時に採番するため、履歴には登録の後に設定する
+		for _, history := range histories {
+			history.ItemID = created.ID
+		}
+		return (&ItemHistoryRepository{SqlHandler: tx}).Create(ctx, histories)
+	})
+	if err != nil {
+		return nil, itemWriteError(err)
+	}
+	return created, nil
+}
+
+func (r *ItemHistoryRepository) RecordCreateMany(ctx context.Context, items []*entity.Item, histories [][]*entity.ItemHistory) error {
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		txRepo := &ItemRepository{SqlHandler: tx, IDs: r.IDs}
+		var all []*entity.ItemHistory
+		for i, item := range items {
+			id, err := txRepo.insert(ctx, item)
+			if err != nil {
+				return err
+			}
+			for _, history := range histories[i] {
+				history.ItemID = id
+			}
+			all = append(all, histories[i]...)
+		}
+		return (&ItemHistoryRepository{SqlHandler: tx}).Create(ctx, all)
+	})
+	if err != nil {
+		return itemWriteError(err)
+	}
+	return nil
+}
+
+func (r *ItemHistoryRepository) RecordUpdate(ctx context.Context, id int64, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error) {
+	var updated *entity.Item
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		var err error
+		if updated, err = (&ItemRepository{SqlHandler: tx}).Update(ctx, id, item); err != nil {
+			return err
+		}
+		return (&ItemHistoryRepository{SqlHandler: tx}).Create(ctx, histories)
+	})
+	if err != nil {
+		return nil, itemWriteError(err)
+	}
+	return updated, nil
+}
+
+func (r *ItemHistoryRepository) RecordUpdates(ctx context.Context, items []*entity.Item, histories []*entity.ItemHistory) ([]*entity.Item, error) {
+	var updated []*entity.Item
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		var err error
+		if updated, err = (&ItemRepository{SqlHandler: tx}).UpdateMany(ctx, items); err != nil {
+			return err
+		}
+		return (&ItemHistoryRepository{SqlHandler: tx}).Create(ctx, histories)
+	})
+	if err != nil {
+		return nil, itemWriteError(err)
+	}
+	return updated, nil
+}
+
+func (r *ItemHistoryRepository) RecordDelete(ctx context.Context, id int64, histories []*entity.ItemHistory) error {
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		if err := (&ItemRepository{SqlHandler: tx}).Delete(ctx, id); err != nil {
+			return err
+		}
+		return (&ItemHistoryRepository{SqlHandler: tx}).Create(ctx, histories)
+	})
+	if err != nil {
+		return itemWriteError(err)
+	}
+	return nil
+}
+
+// itemWriteError はトランザクションのエラーを返す（コミットの失敗などドメインのエラーでないものは DB エラーにする）
+func itemWriteError(err error) error {
+	if domainErrors.IsNotFoundError(err) || domainErrors.IsDatabaseError(err) || domainErrors.IsVersionConflictError(err) || domainErrors.IsValidationError(err) ||
+		domainErrors.IsDuplicateSerialNumberError(err) {
+		return err
+	}
+	return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+}
+
+// 変更履歴の行をエンティティに変換する
+func scanItemHistory(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemHistory, error) {
+	var history entity.ItemHistory
+	var oldValue, newValue, rawValue sql.NullString
+
+	err := scanner.Scan(
+		&history.ID,
+		&history.ItemID,
+		&history.ActorID,
+		&history.ActorEmail,
+		&history.Action,
+		&history.Field,
+		&oldValue,
+		&newValue,
+		&rawValue,
+		&history.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldValue.Valid {
+		history.OldValue = &oldValue.String
+	}
+	if newValue.Valid {
+		history.NewValue = &newValue.String
+	}
+	if rawValue.Valid {
+		history.RawValue = &rawValue.String
+	}
+
+	return &history, nil
+}
@@ -0,0 +1,182 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// トランザクションの内外で実行した文を記録し、failOn を含む文をエラーにする SqlHandler
+type historySqlHandler struct {
+	SqlHandler
+	inTx       bool
+	statements []string
+	outside    []string
+	failOn     string
+	rolledBack bool
+}
+
+func (h *historySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	if !h.inTx {
+		h.outside = append(h.outside, statement)
+	}
+	h.statements = append(h.statements, statement)
+	if h.failOn != "" && strings.Contains(statement, h.failOn) {
+		return nil, errors.New("connection reset")
+	}
+	return affectedResult(1), nil
+}
+
+func (h *historySqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context, tx SqlHandler) error) error {
+	h.inTx = true
+	defer func() { h.inTx = false }()
+	if err := fn(ctx, h); err != nil {
+		h.rolledBack = true
+		return err
+	}
+	return nil
+}
+
+type affectedResult int64
+
+func (r affectedResult) LastInsertId() (int64, error) { return 0, nil }
+func (r affectedResult) RowsAffected() (int64, error) { return int64(r), nil }
+
+func TestItemHistoryRepository_RecordDelete(t *testing.T) {
+	old := "デイトナ"
+	histories := []*entity.ItemHistory{{ItemID: 1, ActorID: 2, Action: entity.ItemHistoryActionDelete, Field: "name", OldValue: &old}}
+
+	t.Run("正常系: 削除と変更履歴の記録を同じトランザクションで行う", func(t *testing.T) {
+		h := &historySqlHandler{}
+
+		require.NoError(t, (&ItemHistoryRepository{SqlHandler: h}).RecordDelete(context.Background(), 1, histories))
+		require.Len(t, h.statements, 2)
+		assert.Contains(t, h.statements[0], "DELETE FROM items")
+		assert.Contains(t, h.statements[1], "INSERT INTO item_histories")
+		assert.Empty(t, h.outside)
+	})
+
+	t.Run("異常系: 変更履歴を記録できなければ削除も取り消す", func(t *testing.T) {
+		h := &historySqlHandler{failOn: "INSERT INTO item_histories"}
+
+		err := (&ItemHistoryRepository{SqlHandler: h}).RecordDelete(context.Background(), 1, histories)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.True(t, h.rolledBack)
+	})
+}
+
+// 登録したアイテムの ID 42 を返し、実行した文の引数を記録する SqlHandler
+type createSqlHandler struct {
+	historySqlHandler
+	args [][]interface{}
+}
+
+func (h *createSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.args = append(h.args, args)
+	return h.historySqlHandler.Execute(ctx, statement, args...)
+}
+
+func (h *createSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return createdItemRow{}
+}
+
+func (h *createSqlHandler) Transaction(ctx context.Context, fn func(ctx context.Context, tx SqlHandler) error) error {
+	return h.historySqlHandler.Transaction(ctx, func(ctx context.Context, _ SqlHandler) error {
+		return fn(ctx, h)
+	})
+}
+
+type createdItemRow struct{}
+
+func (createdItemRow) Scan(dest ...interface{}) error {
+	*dest[0].(*int64) = 42
+	return nil
+}
+
+type fixedID int64
+
+func (id fixedID) NextID() (int64, error) { return int64(id), nil }
+
+// 登録と登録の変更履歴を同じトランザクションで記録し、履歴には採番した ID を設定すること
+func TestItemHistoryRepository_RecordCreate(t *testing.T) {
+	item, err := entity.NewItem("デイトナ", "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+	require.NoError(t, err)
+	histories := entity.NewItemHistories(2, nil, item, item.CreatedAt)
+
+	t.Run("正常系", func(t *testing.T) {
+		h := &createSqlHandler{}
+
+		created, err := (&ItemHistoryRepository{SqlHandler: h, IDs: fixedID(42)}).RecordCreate(context.Background(), item, histories)
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), created.ID)
+
+		require.Len(t, h.statements, 2)
+		assert.Contains(t, h.statements[0], "INSERT INTO items")
+		assert.Contains(t, h.statements[1], "INSERT INTO item_histories")
+		// 履歴の最初の列は item_id
+		assert.Equal(t, int64(42), h.args[1][0])
+		assert.Equal(t, entity.ItemHistoryActionCreate, h.args[1][2])
+		assert.Empty(t, h.outside)
+	})
+
+	t.Run("異常系: 変更履歴を記録できなければ登録も取り消す", func(t *testing.T) {
+		h := &createSqlHandler{historySqlHandler: historySqlHandler{failOn: "INSERT INTO item_histories"}}
+
+		_, err := (&ItemHistoryRepository{SqlHandler: h, IDs: fixedID(42)}).RecordCreate(context.Background(), item, histories)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.True(t, h.rolledBack)
+	})
+}
+
+// 1, 2, ... と順に採番する IDGenerator
+type sequentialIDs struct{ last int64 }
+
+func (g *sequentialIDs) NextID() (int64, error) {
+	g.last++
+	return g.last, nil
+}
+
+// 取り込みの複数のアイテムと登録の変更履歴を1つのトランザクションで記録し、各履歴にはそのアイテムの ID を設定すること
+func TestItemHistoryRepository_RecordCreateMany(t *testing.T) {
+	newItems := func(t *testing.T) ([]*entity.Item, [][]*entity.ItemHistory) {
+		var items []*entity.Item
+		var histories [][]*entity.ItemHistory
+		for _, name := range []string{"デイトナ", "サブマリーナー"} {
+			item, err := entity.NewItem(name, "時計", "ROLEX", entity.JPY(1500000), "2023-01-15")
+			require.NoError(t, err)
+			items = append(items, item)
+			histories = append(histories, entity.NewItemHistories(2, nil, item, item.CreatedAt))
+		}
+		return items, histories
+	}
+
+	t.Run("正常系", func(t *testing.T) {
+		items, histories := newItems(t)
+		h := &createSqlHandler{}
+
+		require.NoError(t, (&ItemHistoryRepository{SqlHandler: h, IDs: &sequentialIDs{}}).RecordCreateMany(context.Background(), items, histories))
+		require.Len(t, h.statements, 3)
+		assert.Contains(t, h.statements[0], "INSERT INTO items")
+		assert.Contains(t, h.statements[1], "INSERT INTO items")
+		assert.Contains(t, h.statements[2], "INSERT INTO item_histories")
+		assert.Equal(t, int64(1), histories[0][0].ItemID)
+		assert.Equal(t, int64(2), histories[1][0].ItemID)
+		assert.Empty(t, h.outside)
+	})
+
+	t.Run("異常系: 変更履歴を記録できなければ登録も取り消す", func(t *testing.T) {
+		items, histories := newItems(t)
+		h := &createSqlHandler{historySqlHandler: historySqlHandler{failOn: "INSERT INTO item_histories"}}
+
+		err := (&ItemHistoryRepository{SqlHandler: h, IDs: &sequentialIDs{}}).RecordCreateMany(context.Background(), items, histories)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.True(t, h.rolledBack)
+	})
+}
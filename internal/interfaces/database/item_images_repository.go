@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemImageRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanItemImage の順序と一致させる）
+const itemImageColumns = "id, item_id, position, file_name, content_type, size, storage_key, thumbnails_ready, created_at"
+
+func (r *ItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	query := `
+        INSERT INTO item_images (item_id, position, file_name, content_type, size, storage_key)
+        VALUES (?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, image.ItemID, image.Position, image.FileName, image.ContentType, image.Size, image.StorageKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, image.ItemID, id)
+}
+
+func (r *ItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	query := `
+        SELECT ` + itemImageColumns + `
+        FROM item_images
+        WHERE item_id = ?
+        ORDER BY position ASC, id ASC
+    `
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	images := []*entity.ItemImage{}
+	for rows.Next() {
+		image, err := scanItemImage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		images = append(images, image)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return images, nil
+}
+
+func (r *ItemImageRepository) FindByID(ctx context.Context, itemID, id int64) (*entity.ItemImage, error) {
+	query := `SELECT ` + itemImageColumns + ` FROM item_images WHERE id = ? AND item_id = ?`
+
+	image, err := scanItemImage(r.QueryRow(ctx, query, id, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemImageNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return image, nil
+}
+
+func (r *ItemImageRepository) FindCoversByItemIDs(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemImage, error) {
+	covers := make(map[int64]*entity.ItemImage, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return covers, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(itemIDs)), ", ")
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	query := `
+        SELECT ` + itemImageColumns + `
+        FROM item_images
+        WHERE item_id IN (` + placeholders + `)
+        ORDER BY item_id ASC, position ASC, id ASC
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		image, err := scanItemImage(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		// 表示順で最初の画像だけを使う
+		if _, ok := covers[image.ItemID]; !ok {
+			covers[image.ItemID] = image
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return covers, nil
+}
+
+func (r *ItemImageRepository) MarkThumbnailsReady(ctx context.Context, itemID, id int64) error {
+	result, err := r.Execute(ctx, `UPDATE item_images SET thumbnails_ready = TRUE WHERE id = ? AND item_id = ?`, id, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// 既に TRUE の場合も0件になるため、存在するかを確認する
+	if rowsAffected == 0 {
+		if _, err := r.FindByID(ctx, itemID, id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ItemImageRepository) Delete(ctx context.Context, itemID, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM item_images WHERE id = ? AND item_id = ?`, id, itemID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemImageNotFound
+	}
+
+	return nil
+}
+
+// 画像の行をエンティティに変換する
+func scanItemImage(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemImage, error) {
+	var image entity.ItemImage
+
+	err := scanner.Scan(
+		&image.ID,
+		&image.ItemID,
+		&image.Position,
+		&image.FileName,
+		&image.ContentType,
+		&image.Size,
+		&image.StorageKey,
+		&image.ThumbnailsReady,
+		&image.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &image, nil
+}
@@ -0,0 +1,94 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemMemoRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanItemMemo の順序と一致させる）
+const itemMemoColumns = "id, item_id, user_id, body, created_at"
+
+func (r *ItemMemoRepository) Create(ctx context.Context, memo *entity.ItemMemo) (*entity.ItemMemo, error) {
+	query := `
+        INSERT INTO item_memos (item_id, user_id, body, created_at)
+        VALUES (?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, memo.ItemID, memo.UserID, memo.Body, memo.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query = `SELECT ` + itemMemoColumns + ` FROM item_memos WHERE id = ?`
+	created, err := scanItemMemo(r.QueryRow(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("%w: created memo not found", domainErrors.ErrDatabaseError)
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return created, nil
+}
+
+func (r *ItemMemoRepository) FindByItemID(ctx context.Context, itemID, userID int64) ([]*entity.ItemMemo, error) {
+	query := `
+        SELECT ` + itemMemoColumns + `
+        FROM item_memos
+        WHERE item_id = ? AND user_id = ?
+        ORDER BY created_at DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, itemID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	memos := []*entity.ItemMemo{}
+	for rows.Next() {
+		memo, err := scanItemMemo(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		memos = append(memos, memo)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return memos, nil
+}
+
+// メモの行をエンティティに変換する
+func scanItemMemo(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemMemo, error) {
+	var memo entity.ItemMemo
+
+	err := scanner.Scan(
+		&memo.ID,
+		&memo.ItemID,
+		&memo.UserID,
+		&memo.Body,
+		&memo.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &memo, nil
+}
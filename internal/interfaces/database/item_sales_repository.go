@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemSaleRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanItemSale の順序と一致させる）
+const itemSaleColumns = "id, item_id, sold_price, fees, currency, sold_date, buyer_notes, created_at"
+
+func (r *ItemSaleRepository) FindByItemID(ctx context.Context, itemID int64) (*entity.ItemSale, error) {
+	query := `SELECT ` + itemSaleColumns + ` FROM item_sales WHERE item_id = ?`
+
+	sale, err := scanItemSale(r.QueryRow(ctx, query, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemSaleNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return sale, nil
+}
+
+func (r *ItemSaleRepository) Record(ctx context.Context, sale *entity.ItemSale, item *entity.Item, histories []*entity.ItemHistory) (*entity.ItemSale, *entity.Item, error) {
+	var saved *entity.ItemSale
+	var updated *entity.Item
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		query := `
+            INSERT INTO item_sales (item_id, sold_price, fees, currency, sold_date, buyer_notes)
+            VALUES (?, ?, ?, ?, ?, ?)
+        `
+		if _, err := tx.Execute(ctx, query,
+			sale.ItemID,
+			sale.SoldPrice.Amount,
+			sale.Fees.Amount,
+			string(sale.SoldPrice.Currency),
+			sale.SoldDate,
+			nullableString(sale.BuyerNotes),
+		); err != nil {
+			if errors.Is(err, ErrDuplicateKey) {
+				return domainErrors.ErrDuplicateEntry
+			}
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// 売却と所有状況の変更（とその変更履歴）は一部だけが保存されないよう同じトランザクションで行う
+		itemRepo := &ItemRepository{SqlHandler: tx}
+		if err := itemRepo.updateVersioned(ctx, item.ID, item); err != nil {
+			return err
+		}
+		if err := (&ItemHistoryRepository{SqlHandler: tx}).Create(ctx, histories); err != nil {
+			return err
+		}
+
+		var err error
+		if updated, err = itemRepo.FindByID(ctx, item.ID); err != nil {
+			return err
+		}
+		saved, err = (&ItemSaleRepository{SqlHandler: tx}).FindByItemID(ctx, sale.ItemID)
+		return err
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsDatabaseError(err) || domainErrors.IsDuplicateError(err) ||
+			domainErrors.IsVersionConflictError(err) || domainErrors.IsValidationError(err) {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return saved, updated, nil
+}
+
+func scanItemSale(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemSale, error) {
+	var sale entity.ItemSale
+	var currency string
+	var buyerNotes sql.NullString
+	var soldDate, createdAt time.Time
+
+	err := scanner.Scan(
+		&sale.ID,
+		&sale.ItemID,
+		&sale.SoldPrice.Amount,
+		&sale.Fees.Amount,
+		&currency,
+		&soldDate,
+		&buyerNotes,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	sale.SoldPrice.Currency = entity.Currency(currency)
+	sale.Fees.Currency = entity.Currency(currency)
+	sale.SoldDate = soldDate.Format("2006-01-02")
+	sale.BuyerNotes = buyerNotes.String
+	sale.CreatedAt = createdAt
+
+	return &sale, nil
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemValuationRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanItemValuation の順序と一致させる）
+const itemValuationColumns = "id, item_id, value, currency, valued_on, notes, created_at"
+
+func (r *ItemValuationRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error) {
+	query := `SELECT ` + itemValuationColumns + ` FROM item_valuations WHERE item_id = ? ORDER BY valued_on ASC, id ASC`
+
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	valuations := []*entity.ItemValuation{}
+	for rows.Next() {
+		valuation, err := scanItemValuation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		valuations = append(valuations, valuation)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return valuations, nil
+}
+
+func (r *ItemValuationRepository) Record(ctx context.Context, valuation *entity.ItemValuation) (*entity.ItemValuation, error) {
+	var saved *entity.ItemValuation
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		query := `
+            INSERT INTO item_valuations (item_id, value, currency, valued_on, notes)
+            VALUES (?, ?, ?, ?, ?)
+        `
+		result, err := tx.Execute(ctx, query,
+			valuation.ItemID,
+			valuation.Value.Amount,
+			string(valuation.Value.Currency),
+			valuation.ValuedOn,
+			nullableString(valuation.Notes),
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// 過去の日付の評価額を後から記録した場合も、アイテムの評価額は最新の評価日のものにする。
+		// 評価額が変わったことをクライアントが検知できるようバージョンを進める
+		latest, err := scanItemValuation(tx.QueryRow(ctx,
+			`SELECT `+itemValuationColumns+` FROM item_valuations WHERE item_id = ? ORDER BY valued_on DESC, id DESC LIMIT 1`,
+			valuation.ItemID))
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		result, err = tx.Execute(ctx,
+			`UPDATE items SET current_value = ?, current_value_currency = ?, version = version + 1 WHERE id = ?`,
+			latest.Value.Amount, string(latest.Value.Currency), valuation.ItemID,
+		)
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		if rowsAffected, err := result.RowsAffected(); err != nil {
+			return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		} else if rowsAffected == 0 {
+			return domainErrors.ErrItemNotFound
+		}
+
+		saved, err = scanItemValuation(tx.QueryRow(ctx, `SELECT `+itemValuationColumns+` FROM item_valuations WHERE id = ?`, id))
+		if err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsDatabaseError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return saved, nil
+}
+
+func scanItemValuation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.ItemValuation, error) {
+	var valuation entity.ItemValuation
+	var currency string
+	var notes sql.NullString
+	var valuedOn, createdAt time.Time
+
+	err := scanner.Scan(
+		&valuation.ID,
+		&valuation.ItemID,
+		&valuation.Value.Amount,
+		&currency,
+		&valuedOn,
+		&notes,
+		&createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	valuation.Value.Currency = entity.Currency(currency)
+	valuation.ValuedOn = valuedOn.Format("2006-01-02")
+	valuation.Notes = notes.String
+	valuation.CreatedAt = createdAt
+
+	return &valuation, nil
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemViewRepository struct {
+	SqlHandler
+}
+
+func (r *ItemViewRepository) Record(ctx context.Context, userID, itemID int64, viewedAt time.Time, limit int) error {
+	return r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		// アイテムごとに1件のため、表示済みのアイテムは表示日時を更新する
+		query := `
+        INSERT INTO item_views (user_id, item_id, viewed_at)
+        VALUES (?, ?, ?)
+        ON DUPLICATE KEY UPDATE
+            viewed_at = VALUES(viewed_at)
+    `
+		if _, err := tx.Execute(ctx, query, userID, itemID, viewedAt); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+
+		// 新しい順に limit 件を超えた古い表示を削除する（リングバッファ）
+		trim := `
+        DELETE FROM item_views
+        WHERE user_id = ? AND item_id NOT IN (
+            SELECT item_id FROM (
+                SELECT item_id FROM item_views
+                WHERE user_id = ?
+                ORDER BY viewed_at DESC, item_id DESC
+                LIMIT ?
+            ) latest
+        )
+    `
+		if _, err := tx.Execute(ctx, trim, userID, userID, limit); err != nil {
+			return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		return nil
+	})
+}
+
+func (r *ItemViewRepository) FindRecentItems(ctx context.Context, userID, scope int64, limit int) ([]*entity.RecentlyViewedItem, error) {
+	where, args := accessCondition(scope)
+	query := `
+        SELECT ` + itemColumns + `, v.viewed_at
+        FROM items
+        JOIN (SELECT item_id, viewed_at FROM item_views WHERE user_id = ?) v ON v.item_id = items.id
+        WHERE ` + where + `
+        ORDER BY v.viewed_at DESC, items.id DESC
+        LIMIT ?
+    `
+	args = append([]interface{}{userID}, append(args, limit)...)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	views := []*entity.RecentlyViewedItem{}
+	for rows.Next() {
+		var viewedAt time.Time
+		item, err := scanItem(withExtraColumns(rows, &viewedAt))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		views = append(views, &entity.RecentlyViewedItem{Item: item, ViewedAt: viewedAt})
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return views, nil
+}
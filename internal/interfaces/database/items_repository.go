@@ -3,8 +3,13 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
@@ -12,16 +17,59 @@ import (
 
 type ItemRepository struct {
 	SqlHandler
+	// JapaneseCollation は sort=name&collation=ja で使う MySQL のコレーション（例: utf8mb4_ja_0900_as_cs）。
+	// 空の場合は取得後に Go で並べ替える
+	JapaneseCollation string
+	// IDs は登録するアイテムの ID の採番方法（nil の場合は AUTO_INCREMENT）
+	IDs IDGenerator
 }
 
-func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
+// SELECT 対象の列（scanItem の順序と一致させる）
+const itemColumns = "id, user_id, org_id, name, category, brand, purchase_price, purchase_currency, purchase_date, visibility, item_condition, status, current_value, current_value_currency, serial_number, notes, attributes, version, created_at, updated_at"
+
+// items の name・brand 列の大きさ（VARCHAR(100)。utf8mb4 は1文字あたり最大4バイト）
+const (
+	itemTextColumnLength = 100
+	itemTextColumnBytes  = itemTextColumnLength * 4
+)
+
+// checkItemColumns は列に収まらない name・brand を保存前に検証エラーにする。
+// 設定で最大文字数を列より大きくした場合に、切り詰められたりDBエラーになったりしないようにする
+func checkItemColumns(item *entity.Item) error {
+	var errs domainErrors.ValidationErrors
+	for _, column := range []struct{ field, value string }{{"name", item.Name}, {"brand", item.Brand}} {
+		if utf8.RuneCountInString(column.value) > itemTextColumnLength || len(column.value) > itemTextColumnBytes {
+			errs.Add(column.field, domainErrors.CodeTooLong, fmt.Sprintf("%s must fit in the database column (%d characters, %d bytes)", column.field, itemTextColumnLength, itemTextColumnBytes))
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// isSerialNumberConflict はシリアル番号の一意制約（uq_items_user_serial_number）の違反かを判定する
+// （採番した ID の重複など他の一意制約の違反と区別するため、エラーに含まれる制約の名前で判定する）
+func isSerialNumberConflict(err error) bool {
+	return errors.Is(err, ErrDuplicateKey) && strings.Contains(err.Error(), "serial_number")
+}
+
+// 更新は読み込んだ時点のバージョンの場合のみ行い、バージョンを1つ進める（楽観的ロック）
+const updateItemQuery = `
+        UPDATE items
+        SET name = ?, category = ?, brand = ?, purchase_price = ?, purchase_currency = ?, purchase_date = ?, visibility = ?, item_condition = ?, status = ?, serial_number = ?, notes = ?, attributes = ?, user_id = ?, org_id = ?, version = version + 1
+        WHERE id = ? AND version = ?
+    `
+
+func (r *ItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter) ([]*entity.Item, error) {
+	where, args := buildItemFilter(filter)
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemColumns + `
         FROM items
-        ORDER BY created_at DESC
-    `
+    ` + where + `
+        ORDER BY ` + buildItemOrderBy(filter.Sort, r.JapaneseCollation)
 
-	rows, err := r.Query(ctx, query)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
@@ -40,12 +88,16 @@ func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
+	if needsCollationFallback(filter.Sort, r.JapaneseCollation) {
+		sortItemsByCollation(items, filter.Sort)
+	}
+
 	return items, nil
 }
 
 func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + itemColumns + `
         FROM items
         WHERE id = ?
     `
@@ -64,59 +116,179 @@ func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item,
 }
 
 func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	id, err := r.insert(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *ItemRepository) CreateMany(ctx context.Context, items []*entity.Item) error {
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		txRepo := &ItemRepository{SqlHandler: tx, IDs: r.IDs}
+		for _, item := range items {
+			if _, err := txRepo.insert(ctx, item); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsDatabaseError(err) || domainErrors.IsValidationError(err) || domainErrors.IsDuplicateSerialNumberError(err) {
+			return err
+		}
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return nil
+}
+
+// insert はアイテムを登録し、採番した ID を返す
+func (r *ItemRepository) insert(ctx context.Context, item *entity.Item) (int64, error) {
+	if err := checkItemColumns(item); err != nil {
+		return 0, err
+	}
+
+	var id int64
+	if r.IDs != nil {
+		var err error
+		if id, err = r.IDs.NextID(); err != nil {
+			return 0, fmt.Errorf("%w: failed to generate id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+	}
+
+	attributes, err := marshalItemAttributes(item.Attributes)
+	if err != nil {
+		return 0, err
+	}
+
+	// id が NULL の場合は AUTO_INCREMENT で採番される
 	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
+        INSERT INTO items (id, user_id, org_id, name, category, brand, purchase_price, purchase_currency, purchase_date, visibility, item_condition, status, serial_number, notes, attributes)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
 	result, err := r.Execute(ctx, query,
+		nullableID(id),
+		nullableID(item.UserID),
+		nullableID(item.OrgID),
 		item.Name,
 		item.Category,
 		item.Brand,
-		item.PurchasePrice,
+		item.PurchasePrice.Amount,
+		string(item.PurchasePrice.Currency),
 		item.PurchaseDate,
+		visibilityOrDefault(item.Visibility),
+		string(item.Condition),
+		statusOrDefault(item.Status),
+		nullableString(item.SerialNumber),
+		nullableString(item.Notes),
+		attributes,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		if isSerialNumberConflict(err) {
+			return 0, domainErrors.ErrDuplicateSerialNumber
+		}
+		return 0, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	if id == 0 {
+		if id, err = result.LastInsertId(); err != nil {
+			return 0, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
 	}
 
-	return r.FindByID(ctx, id)
+	return id, nil
 }
 
 func (r *ItemRepository) Update(ctx context.Context, id int64, item *entity.Item) (*entity.Item, error) {
-	query := `
-        UPDATE items
-        SET name = ?, brand = ?, purchase_price = ?
-        WHERE id = ?
-    `
+	if err := r.updateVersioned(ctx, id, item); err != nil {
+		return nil, err
+	}
 
-	result, err := r.Execute(ctx, query,
+	// Return the updated item by fetching it from the database
+	// This ensures we get the actual database state including auto-updated timestamps
+	return r.FindByID(ctx, id)
+}
+
+// updateVersioned は item.Version のアイテムを更新する。
+// 更新されなかった場合は、アイテムがなければ ErrItemNotFound、他のリクエストが先に更新していれば ErrItemVersionConflict を返す
+func (r *ItemRepository) updateVersioned(ctx context.Context, id int64, item *entity.Item) error {
+	if err := checkItemColumns(item); err != nil {
+		return err
+	}
+	attributes, err := marshalItemAttributes(item.Attributes)
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Execute(ctx, updateItemQuery,
 		item.Name,
+		item.Category,
 		item.Brand,
-		item.PurchasePrice,
+		item.PurchasePrice.Amount,
+		string(item.PurchasePrice.Currency),
+		item.PurchaseDate,
+		visibilityOrDefault(item.Visibility),
+		string(item.Condition),
+		statusOrDefault(item.Status),
+		nullableString(item.SerialNumber),
+		nullableString(item.Notes),
+		attributes,
+		nullableID(item.UserID),
+		nullableID(item.OrgID),
 		id,
+		item.Version,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		if isSerialNumberConflict(err) {
+			return domainErrors.ErrDuplicateSerialNumber
+		}
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 
+	// バージョンが必ず変わるため、値が同じでも対象の行があれば RowsAffected は 1 になる
 	if rowsAffected == 0 {
-		return nil, domainErrors.ErrItemNotFound
+		if _, err := r.FindByID(ctx, id); err != nil {
+			return err
+		}
+		return domainErrors.ErrItemVersionConflict
 	}
+	return nil
+}
 
-	// Return the updated item by fetching it from the database
-	// This ensures we get the actual database state including auto-updated timestamps
-	return r.FindByID(ctx, id)
+func (r *ItemRepository) UpdateMany(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	var updated []*entity.Item
+	err := r.Transaction(ctx, func(ctx context.Context, tx SqlHandler) error {
+		txRepo := &ItemRepository{SqlHandler: tx}
+		updated = make([]*entity.Item, 0, len(items))
+		for _, item := range items {
+			if err := txRepo.updateVersioned(ctx, item.ID, item); err != nil {
+				return err
+			}
+
+			found, err := txRepo.FindByID(ctx, item.ID)
+			if err != nil {
+				return err
+			}
+			updated = append(updated, found)
+		}
+		return nil
+	})
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsDatabaseError(err) || domainErrors.IsVersionConflictError(err) || domainErrors.IsValidationError(err) ||
+			domainErrors.IsDuplicateSerialNumberError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return updated, nil
 }
 
 func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
@@ -139,27 +311,117 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+func (r *ItemRepository) Search(ctx context.Context, keyword string, userID int64) ([]*entity.SearchResult, error) {
+	var query string
+	scope, args := accessCondition(userID)
+
+	// ngram の最小トークン長（2文字）未満のキーワードは全文インデックスで検索できないため LIKE で検索する
+	// （関連度は取得後に一致した箇所の数から計算する）
+	fullText := utf8.RuneCountInString(keyword) >= 2
+	if !fullText {
+		query = `
+        SELECT ` + itemColumns + `, 0
+        FROM items
+        WHERE ` + scope + ` AND (name LIKE ? OR brand LIKE ?)
+        ORDER BY created_at DESC, id DESC
+    `
+		pattern := "%" + escapeLike(keyword) + "%"
+		args = append(args, pattern, pattern)
+	} else {
+		query = `
+        SELECT ` + itemColumns + `, MATCH(name, brand) AGAINST (? IN BOOLEAN MODE) AS score
+        FROM items
+        WHERE ` + scope + ` AND MATCH(name, brand) AGAINST (? IN BOOLEAN MODE)
+        ORDER BY score DESC, id DESC
+    `
+		phrase := toBooleanPhrase(keyword)
+		args = append([]interface{}{phrase}, append(args, phrase)...)
+	}
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	highlighter := newSearchHighlighter(keyword)
+	var results []*entity.SearchResult
+	for rows.Next() {
+		var score float64
+		item, err := scanItem(withExtraColumns(rows, &score))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		result := &entity.SearchResult{Item: item, Score: score, Highlights: highlighter.highlight(item)}
+		if !fullText {
+			result.Score = countSpans(result.Highlights)
+		}
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if !fullText {
+		// 一致した箇所の多い順（同じ場合は登録の新しい順のまま）
+		sort.SliceStable(results, func(i, j int) bool {
+			return results[i].Score > results[j].Score
+		})
+	}
+
+	return results, nil
+}
+
+// キーワードを BOOLEAN MODE のフレーズ検索に変換する（演算子として解釈されないよう引用符を除去）
+func toBooleanPhrase(keyword string) string {
+	return `"` + strings.ReplaceAll(keyword, `"`, " ") + `"`
+}
+
+// LIKE のワイルドカード文字をエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, userID int64) ([]entity.CategoryValue, error) {
+	scope, args := accessCondition(userID)
+	return r.summaryByCategory(ctx, scope, args)
+}
+
+func (r *ItemRepository) GetSummaryByCategoryAsOf(ctx context.Context, userID int64, asOf string) ([]entity.CategoryValue, error) {
+	scope, args := accessCondition(userID)
+	// asOf までに購入し、asOf より前に売却していないアイテム
+	scope += ` AND purchase_date <= ? AND NOT EXISTS (SELECT 1 FROM item_sales s WHERE s.item_id = items.id AND s.sold_date < ?)`
+	return r.summaryByCategory(ctx, scope, append(args, asOf, asOf))
+}
+
+// summaryByCategory は condition に一致するアイテムをカテゴリー・通貨・組織ごとに集計する
+func (r *ItemRepository) summaryByCategory(ctx context.Context, condition string, args []interface{}) ([]entity.CategoryValue, error) {
 	query := `
-        SELECT category, COUNT(*) as count
+        SELECT category, purchase_currency, COALESCE(org_id, 0) as org_id, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as value,
+               COUNT(CASE WHEN created_at >= DATE_FORMAT(CURRENT_DATE, '%Y-%m-01') THEN 1 END) as added_count,
+               COALESCE(SUM(CASE WHEN created_at >= DATE_FORMAT(CURRENT_DATE, '%Y-%m-01') THEN purchase_price END), 0) as added_value,
+               MIN(purchase_price) as min_value, MAX(purchase_price) as max_value,
+               DATE_FORMAT(MAX(purchase_date), '%Y-%m-%d') as newest_purchase_date
         FROM items
-        GROUP BY category
+        WHERE ` + condition + `
+        GROUP BY category, purchase_currency, COALESCE(org_id, 0)
     `
 
-	rows, err := r.Query(ctx, query)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 	}
 	defer rows.Close()
 
-	summary := make(map[string]int)
+	summary := []entity.CategoryValue{}
 	for rows.Next() {
-		var category string
-		var count int
-		if err := rows.Scan(&category, &count); err != nil {
+		var value entity.CategoryValue
+		if err := rows.Scan(&value.Category, &value.Currency, &value.OrgID, &value.Count, &value.Value, &value.AddedCount, &value.AddedValue,
+			&value.MinValue, &value.MaxValue, &value.NewestPurchaseDate); err != nil {
 			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 		}
-		summary[category] = count
+		summary = append(summary, value)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -169,20 +431,383 @@ func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]i
 	return summary, nil
 }
 
+func (r *ItemRepository) GetSummaryByBrand(ctx context.Context, userID int64) ([]entity.BrandValue, error) {
+	scope, args := accessCondition(userID)
+	query := `
+        SELECT brand, purchase_currency, COALESCE(org_id, 0) as org_id, COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as value
+        FROM items
+        WHERE ` + scope + `
+        GROUP BY brand, purchase_currency, COALESCE(org_id, 0)
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	summary := []entity.BrandValue{}
+	for rows.Next() {
+		var value entity.BrandValue
+		if err := rows.Scan(&value.Brand, &value.Currency, &value.OrgID, &value.Count, &value.Value); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		summary = append(summary, value)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return summary, nil
+}
+
+func (r *ItemRepository) GetHoldingValues(ctx context.Context, userID int64) ([]entity.HoldingValue, error) {
+	scope, args := accessCondition(userID)
+	query := `
+        SELECT category, purchase_currency, COALESCE(current_value_currency, '') as value_currency, COALESCE(org_id, 0) as org_id,
+               COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as purchase_value,
+               COALESCE(SUM(CASE WHEN current_value IS NULL THEN purchase_price END), 0) as unvalued_purchase_value,
+               COUNT(current_value) as valued_count, COALESCE(SUM(current_value), 0) as current_value
+        FROM items
+        WHERE ` + scope + ` AND status IN (?, ?, ?)
+        GROUP BY category, purchase_currency, COALESCE(current_value_currency, ''), COALESCE(org_id, 0)
+    `
+	args = append(args, entity.ItemStatusOwned, entity.ItemStatusListedForSale, entity.ItemStatusConsigned)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	values := []entity.HoldingValue{}
+	for rows.Next() {
+		var value entity.HoldingValue
+		if err := rows.Scan(&value.Category, &value.Currency, &value.ValueCurrency, &value.OrgID, &value.Count,
+			&value.PurchaseValue, &value.UnvaluedPurchaseValue, &value.ValuedCount, &value.CurrentValue); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		values = append(values, value)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return values, nil
+}
+
+// 購入の推移を集計する期間の単位ごとの購入日の書式（DATE_FORMAT の書式）
+var purchasePeriodFormats = map[entity.PurchasePeriodUnit]string{
+	entity.PurchasePeriodMonth: "%Y-%m",
+	entity.PurchasePeriodYear:  "%Y",
+}
+
+func (r *ItemRepository) GetPurchasesByPeriod(ctx context.Context, userID int64, unit entity.PurchasePeriodUnit) ([]entity.PurchasePeriodValue, error) {
+	format, ok := purchasePeriodFormats[unit]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown period unit %q", domainErrors.ErrInvalidInput, unit)
+	}
+	scope, args := accessCondition(userID)
+	// 書式は固定の値のみのため埋め込む（プレースホルダーにすると GROUP BY の式と一致しない）
+	query := `
+        SELECT DATE_FORMAT(purchase_date, '` + format + `') as period, purchase_currency, COALESCE(org_id, 0) as org_id,
+               COUNT(*) as count, COALESCE(SUM(purchase_price), 0) as value
+        FROM items
+        WHERE ` + scope + `
+        GROUP BY period, purchase_currency, COALESCE(org_id, 0)
+        ORDER BY period
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	values := []entity.PurchasePeriodValue{}
+	for rows.Next() {
+		var value entity.PurchasePeriodValue
+		if err := rows.Scan(&value.Period, &value.Currency, &value.OrgID, &value.Count, &value.Value); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		values = append(values, value)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return values, nil
+}
+
+// 高額なアイテムを選ぶ金額の種類ごとの金額と通貨の列
+var itemValueColumns = map[entity.ItemValueKey]struct{ amount, currency string }{
+	entity.ItemValuePurchasePrice: {"purchase_price", "purchase_currency"},
+	entity.ItemValueCurrent:       {"current_value", "current_value_currency"},
+}
+
+func (r *ItemRepository) FindHeld(ctx context.Context, userID int64) ([]*entity.Item, error) {
+	scope, args := accessCondition(userID)
+	query := `
+        SELECT id, user_id, org_id, name, category, brand, purchase_price, purchase_currency, purchase_date, status, current_value, current_value_currency
+        FROM items
+        WHERE ` + scope + ` AND status IN (?, ?, ?)
+        ORDER BY created_at DESC, id DESC
+    `
+	args = append(args, entity.ItemStatusOwned, entity.ItemStatusListedForSale, entity.ItemStatusConsigned)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var items []*entity.Item
+	for rows.Next() {
+		var item entity.Item
+		var userID, orgID, currentValue sql.NullInt64
+		var currentValueCurrency sql.NullString
+		var purchaseDate string
+		err := rows.Scan(&item.ID, &userID, &orgID, &item.Name, &item.Category, &item.Brand,
+			&item.PurchasePrice.Amount, &item.PurchasePrice.Currency, &purchaseDate, &item.Status, &currentValue, &currentValueCurrency)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		item.UserID = userID.Int64
+		item.OrgID = orgID.Int64
+		item.PurchaseDate = formatPurchaseDate(purchaseDate)
+		if currentValue.Valid {
+			item.CurrentValue = &entity.Money{Amount: int(currentValue.Int64), Currency: entity.Currency(currentValueCurrency.String)}
+		}
+		items = append(items, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return items, nil
+}
+
+func (r *ItemRepository) FindTopByValue(ctx context.Context, userID int64, by entity.ItemValueKey, n int) ([]*entity.Item, error) {
+	columns, ok := itemValueColumns[by]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown value key %q", domainErrors.ErrInvalidInput, by)
+	}
+	scope, args := accessCondition(userID)
+	// 通貨ごとに金額の高い順の n 件を選ぶ（列名は固定の値のみのため埋め込む）
+	query := `
+        SELECT ` + itemColumns + `
+        FROM (
+            SELECT ` + itemColumns + `,
+                   ROW_NUMBER() OVER (PARTITION BY ` + columns.currency + ` ORDER BY ` + columns.amount + ` DESC, id DESC) as currency_rank
+            FROM items
+            WHERE ` + scope + ` AND ` + columns.amount + ` IS NOT NULL
+        ) ranked
+        WHERE currency_rank <= ?
+        ORDER BY ` + columns.amount + ` DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, append(args, n)...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	var items []*entity.Item
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return items, nil
+}
+
+// ユーザーが参照できるアイテム（個人のアイテムと所属する組織のアイテム）の絞り込み条件を組み立てる（0 の場合は全ユーザー）
+func accessCondition(userID int64) (string, []interface{}) {
+	if userID == 0 {
+		return "TRUE", nil
+	}
+	return "((org_id IS NULL AND user_id = ?) OR org_id IN (SELECT organization_id FROM organization_members WHERE user_id = ?))",
+		[]interface{}{userID, userID}
+}
+
+// 未設定（0）のIDを NULL として保存する
+func nullableID(id int64) interface{} {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// 未設定（空文字）の値を NULL として保存する（NULL は一意制約の対象にならない）
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// 絞り込み条件から WHERE 句とプレースホルダーの値を組み立てる
+func buildItemFilter(filter entity.ItemFilter) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+
+	if filter.UserID != 0 {
+		condition, conditionArgs := accessCondition(filter.UserID)
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
+	}
+	if filter.OrgID != 0 {
+		conditions = append(conditions, "org_id = ?")
+		args = append(args, filter.OrgID)
+	}
+	if filter.Category != "" {
+		conditions = append(conditions, "category = ?")
+		args = append(args, filter.Category)
+	}
+	if filter.Brand != "" {
+		conditions = append(conditions, "brand = ?")
+		args = append(args, filter.Brand)
+	}
+	if filter.Condition != entity.ConditionUnknown {
+		conditions = append(conditions, "item_condition = ?")
+		args = append(args, filter.Condition)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.PurchaseCurrency != "" {
+		conditions = append(conditions, "purchase_currency = ?")
+		args = append(args, filter.PurchaseCurrency)
+	}
+	// 金額は PurchaseCurrency の最小単位の整数（範囲を指定した場合は常に通貨でも絞り込む）
+	if filter.MinPurchasePrice != nil {
+		conditions = append(conditions, "purchase_price >= ?")
+		args = append(args, *filter.MinPurchasePrice)
+	}
+	if filter.MaxPurchasePrice != nil {
+		conditions = append(conditions, "purchase_price <= ?")
+		args = append(args, *filter.MaxPurchasePrice)
+	}
+	if filter.PurchaseDateFrom != "" {
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, filter.PurchaseDateFrom)
+	}
+	if filter.PurchaseDateTo != "" {
+		conditions = append(conditions, "purchase_date <= ?")
+		args = append(args, filter.PurchaseDateTo)
+	}
+	for _, tag := range filter.Tags {
+		conditions = append(conditions, "id IN (SELECT it.item_id FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name = ?)")
+		args = append(args, tag)
+	}
+	// 条件の順序が実行ごとに変わらないよう属性名の順に組み立てる
+	names := make([]string, 0, len(filter.Attributes))
+	for name := range filter.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		column, ok := itemAttributeColumns[name]
+		if !ok {
+			// 索引のない属性はユースケースで検証エラーにするため、ここでは条件に含めない
+			continue
+		}
+		conditions = append(conditions, column+" = ?")
+		args = append(args, filter.Attributes[name])
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// 絞り込みに使える属性と生成列の対応（ユーザー入力を直接SQLに埋め込まないため）
+var itemAttributeColumns = map[string]string{
+	entity.AttributeReferenceNumber: "attr_reference_number",
+	entity.AttributeMovement:        "attr_movement",
+	entity.AttributeMaterial:        "attr_material",
+	entity.AttributeMetal:           "attr_metal",
+}
+
+// marshalItemAttributes は属性を attributes 列の JSON にする（属性がない場合は NULL）
+func marshalItemAttributes(attrs entity.ItemAttributes) (interface{}, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+	b, err := json.Marshal(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to encode attributes: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return string(b), nil
+}
+
+// ソートキーと列名の対応（ユーザー入力を直接SQLに埋め込まないため）
+var itemSortColumns = map[string]string{
+	entity.SortKeyName:          "name",
+	entity.SortKeyPurchasePrice: "purchase_price",
+	entity.SortKeyPurchaseDate:  "purchase_date",
+	entity.SortKeyCreatedAt:     "created_at",
+}
+
+// ソート条件から ORDER BY 句の内容を組み立てる。同値の場合はIDで順序を固定する
+func buildItemOrderBy(sort entity.ItemSort, japaneseCollation string) string {
+	column, ok := itemSortColumns[sort.Key]
+	if !ok {
+		return "created_at DESC, id DESC"
+	}
+
+	direction := "ASC"
+	if sort.Order == entity.SortOrderDesc {
+		direction = "DESC"
+	}
+	// 通貨の異なる金額は比べられないため、購入価格は通貨ごとにまとめてから金額で並べる
+	if sort.Key == entity.SortKeyPurchasePrice {
+		column = "purchase_currency ASC, " + column
+	}
+	return column + nameCollationClause(sort, japaneseCollation) + " " + direction + ", id " + direction
+}
+
 func scanItem(scanner interface {
 	Scan(dest ...interface{}) error
 }) (*entity.Item, error) {
 	var item entity.Item
+	var userID, orgID, currentValue sql.NullInt64
+	var serialNumber, notes, attributes, currentValueCurrency sql.NullString
 	var purchaseDate string
 	var createdAt, updatedAt time.Time
 
 	err := scanner.Scan(
 		&item.ID,
+		&userID,
+		&orgID,
 		&item.Name,
 		&item.Category,
 		&item.Brand,
-		&item.PurchasePrice,
+		&item.PurchasePrice.Amount,
+		&item.PurchasePrice.Currency,
 		&purchaseDate,
+		&item.Visibility,
+		&item.Condition,
+		&item.Status,
+		&currentValue,
+		&currentValueCurrency,
+		&serialNumber,
+		&notes,
+		&attributes,
+		&item.Version,
 		&createdAt,
 		&updatedAt,
 	)
@@ -190,16 +815,47 @@ func scanItem(scanner interface {
 		return nil, err
 	}
 
-	if purchaseDate != "" {
-		if parsedDate, err := time.Parse("2006-01-02", purchaseDate); err == nil {
-			item.PurchaseDate = parsedDate.Format("2006-01-02")
-		} else {
-			item.PurchaseDate = purchaseDate
+	item.UserID = userID.Int64
+	item.OrgID = orgID.Int64
+	item.SerialNumber = serialNumber.String
+	item.Notes = notes.String
+	if currentValue.Valid {
+		item.CurrentValue = &entity.Money{Amount: int(currentValue.Int64), Currency: entity.Currency(currentValueCurrency.String)}
+	}
+	if attributes.Valid {
+		if err := json.Unmarshal([]byte(attributes.String), &item.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to decode attributes: %w", err)
 		}
 	}
 
+	item.PurchaseDate = formatPurchaseDate(purchaseDate)
+
 	item.CreatedAt = createdAt
 	item.UpdatedAt = updatedAt
 
 	return &item, nil
 }
+
+// formatPurchaseDate は読み込んだ購入日を YYYY-MM-DD 形式にする（解釈できない値はそのまま返す）
+func formatPurchaseDate(purchaseDate string) string {
+	if parsedDate, err := time.Parse("2006-01-02", purchaseDate); err == nil {
+		return parsedDate.Format("2006-01-02")
+	}
+	return purchaseDate
+}
+
+// 未設定の公開範囲は private として保存する
+func visibilityOrDefault(v entity.Visibility) entity.Visibility {
+	if v == "" {
+		return entity.VisibilityPrivate
+	}
+	return v
+}
+
+// 未設定の所有状況は owned として保存する
+func statusOrDefault(s entity.ItemStatus) entity.ItemStatus {
+	if s == "" {
+		return entity.ItemStatusOwned
+	}
+	return s
+}
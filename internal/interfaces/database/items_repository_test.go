@@ -0,0 +1,87 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestCheckItemColumns(t *testing.T) {
+	// 日本語は1文字3バイトでも100文字まで保存できる
+	assert.NoError(t, checkItemColumns(&entity.Item{Name: strings.Repeat("あ", 100), Brand: strings.Repeat("🎁", 100)}))
+
+	err := checkItemColumns(&entity.Item{Name: strings.Repeat("あ", 101), Brand: "ROLEX"})
+	require.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	errs, ok := domainErrors.AsValidationErrors(err)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "name", errs[0].Field)
+	assert.Equal(t, domainErrors.CodeTooLong, errs[0].Code)
+
+}
+
+// failingSqlHandler は Execute で err を返す SqlHandler
+type failingSqlHandler struct {
+	SqlHandler
+	err error
+}
+
+func (h failingSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	return nil, h.err
+}
+
+func TestItemRepository_SerialNumberConflict(t *testing.T) {
+	item := &entity.Item{UserID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1000), PurchaseDate: "2023-01-01", SerialNumber: "A123", Version: 1}
+	duplicate := func(key string) error {
+		return fmt.Errorf("%w: Error 1062 (23000): Duplicate entry '1-A123' for key '%s'", ErrDuplicateKey, key)
+	}
+
+	t.Run("シリアル番号の重複は専用のエラーにする", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: failingSqlHandler{err: duplicate("items.uq_items_user_serial_number")}}
+
+		_, err := repo.Create(context.Background(), item)
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateSerialNumber)
+
+		_, err = repo.Update(context.Background(), 1, item)
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateSerialNumber)
+	})
+
+	t.Run("他の一意制約の違反はデータベースのエラーのまま", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: failingSqlHandler{err: duplicate("items.PRIMARY")}}
+
+		_, err := repo.Create(context.Background(), item)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.False(t, errors.Is(err, domainErrors.ErrDuplicateSerialNumber))
+	})
+}
+
+func TestBuildItemFilter_Attributes(t *testing.T) {
+	where, args := buildItemFilter(entity.ItemFilter{Attributes: map[string]string{"movement": "自動巻き", "metal": "K18", "case_size_mm": "40"}})
+
+	// 索引のない属性は条件に含めず、属性名の順に生成列で絞り込む
+	assert.Equal(t, "WHERE attr_metal = ? AND attr_movement = ?", where)
+	assert.Equal(t, []interface{}{"K18", "自動巻き"}, args)
+}
+
+func TestBuildItemFilter_PurchasePrice(t *testing.T) {
+	minPrice, maxPrice := 1250, 10000
+	where, args := buildItemFilter(entity.ItemFilter{PurchaseCurrency: entity.CurrencyUSD, MinPurchasePrice: &minPrice, MaxPurchasePrice: &maxPrice})
+
+	// 金額の範囲は通貨の最小単位の整数で、同じ通貨のアイテムのみと比べる
+	assert.Equal(t, "WHERE purchase_currency = ? AND purchase_price >= ? AND purchase_price <= ?", where)
+	assert.Equal(t, []interface{}{entity.CurrencyUSD, 1250, 10000}, args)
+}
+
+func TestBuildItemOrderBy_PurchasePrice(t *testing.T) {
+	// 通貨の異なる金額は比べられないため、通貨ごとにまとめてから金額で並べる
+	assert.Equal(t, "purchase_currency ASC, purchase_price DESC, id DESC",
+		buildItemOrderBy(entity.ItemSort{Key: entity.SortKeyPurchasePrice, Order: entity.SortOrderDesc}, ""))
+}
@@ -0,0 +1,134 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type NotificationRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanNotification の順序と一致させる）
+const notificationColumns = "id, user_id, kind, item_id, comment_id, actor_id, read_at, created_at"
+
+func (r *NotificationRepository) Create(ctx context.Context, notification *entity.Notification) (*entity.Notification, error) {
+	query := `
+        INSERT INTO notifications (user_id, kind, item_id, comment_id, actor_id)
+        VALUES (?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query,
+		notification.UserID,
+		notification.Kind,
+		notification.ItemID,
+		notification.CommentID,
+		notification.ActorID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query = `SELECT ` + notificationColumns + ` FROM notifications WHERE id = ?`
+	created, err := scanNotification(r.QueryRow(ctx, query, id))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return created, nil
+}
+
+func (r *NotificationRepository) FindByUserID(ctx context.Context, userID int64, unreadOnly bool) ([]*entity.Notification, error) {
+	where := "WHERE user_id = ?"
+	if unreadOnly {
+		where += " AND read_at IS NULL"
+	}
+	query := `
+        SELECT ` + notificationColumns + `
+        FROM notifications
+        ` + where + `
+        ORDER BY created_at DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	notifications := []*entity.Notification{}
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return notifications, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userID, id int64) error {
+	// 既読の通知は既読日時を変えない
+	query := `
+        UPDATE notifications
+        SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+        WHERE id = ? AND user_id = ?
+    `
+
+	if _, err := r.Execute(ctx, query, id, userID); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// 既読の通知は更新件数が0件になるため、存在するかを確認する
+	var exists int
+	err := r.QueryRow(ctx, `SELECT 1 FROM notifications WHERE id = ? AND user_id = ?`, id, userID).Scan(&exists)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domainErrors.ErrNotificationNotFound
+		}
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+// 通知の行をエンティティに変換する
+func scanNotification(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.Notification, error) {
+	var notification entity.Notification
+	var readAt sql.NullTime
+
+	err := scanner.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Kind,
+		&notification.ItemID,
+		&notification.CommentID,
+		&notification.ActorID,
+		&readAt,
+		&notification.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if readAt.Valid {
+		notification.ReadAt = &readAt.Time
+	}
+
+	return &notification, nil
+}
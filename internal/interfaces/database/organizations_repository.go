@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type OrganizationRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanMembership の順序と一致させる）。メンバーのメールアドレスは users から取得する
+const membershipColumns = "m.organization_id, m.user_id, COALESCE(u.email, ''), m.role, m.created_at"
+
+func (r *OrganizationRepository) Create(ctx context.Context, org *entity.Organization, ownerID int64) (*entity.Organization, error) {
+	result, err := r.Execute(ctx, `INSERT INTO organizations (name) VALUES (?)`, org.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query := `
+        INSERT INTO organization_members (organization_id, user_id, role)
+        VALUES (?, ?, ?)
+    `
+	if _, err := r.Execute(ctx, query, id, ownerID, entity.OrgRoleOwner); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	created, err := r.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	created.Role = entity.OrgRoleOwner
+	return created, nil
+}
+
+func (r *OrganizationRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.Organization, error) {
+	query := `
+        SELECT o.id, o.name, m.role, o.created_at, o.updated_at
+        FROM organizations o
+        JOIN organization_members m ON m.organization_id = o.id
+        WHERE m.user_id = ?
+        ORDER BY o.created_at ASC, o.id ASC
+    `
+
+	rows, err := r.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	orgs := []*entity.Organization{}
+	for rows.Next() {
+		var org entity.Organization
+		if err := rows.Scan(&org.ID, &org.Name, &org.Role, &org.CreatedAt, &org.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		orgs = append(orgs, &org)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return orgs, nil
+}
+
+func (r *OrganizationRepository) FindByID(ctx context.Context, id int64) (*entity.Organization, error) {
+	var org entity.Organization
+	err := r.QueryRow(ctx, `SELECT id, name, created_at, updated_at FROM organizations WHERE id = ?`, id).Scan(
+		&org.ID,
+		&org.Name,
+		&org.CreatedAt,
+		&org.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return &org, nil
+}
+
+func (r *OrganizationRepository) FindMembers(ctx context.Context, organizationID int64) ([]*entity.Membership, error) {
+	query := `
+        SELECT ` + membershipColumns + `
+        FROM organization_members m LEFT JOIN users u ON u.id = m.user_id
+        WHERE m.organization_id = ?
+        ORDER BY m.created_at ASC, m.user_id ASC
+    `
+
+	return queryMemberships(ctx, r.SqlHandler, query, organizationID)
+}
+
+func (r *OrganizationRepository) AddMember(ctx context.Context, membership *entity.Membership) (*entity.Membership, error) {
+	query := `
+        INSERT INTO organization_members (organization_id, user_id, role)
+        VALUES (?, ?, ?)
+    `
+
+	if _, err := r.Execute(ctx, query, membership.OrganizationID, membership.UserID, membership.Role); err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return nil, domainErrors.ErrDuplicateEntry
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query = `
+        SELECT ` + membershipColumns + `
+        FROM organization_members m LEFT JOIN users u ON u.id = m.user_id
+        WHERE m.organization_id = ? AND m.user_id = ?
+    `
+	memberships, err := queryMemberships(ctx, r.SqlHandler, query, membership.OrganizationID, membership.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return nil, domainErrors.ErrMemberNotFound
+	}
+	return memberships[0], nil
+}
+
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM organization_members WHERE organization_id = ? AND user_id = ?`, organizationID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrMemberNotFound
+	}
+
+	return nil
+}
+
+// queryMemberships は membershipColumns を SELECT するクエリを実行する（ユーザーの読み込みからも利用する）
+func queryMemberships(ctx context.Context, handler SqlHandler, query string, args ...interface{}) ([]*entity.Membership, error) {
+	rows, err := handler.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	memberships := []*entity.Membership{}
+	for rows.Next() {
+		var m entity.Membership
+		if err := rows.Scan(&m.OrganizationID, &m.UserID, &m.Email, &m.Role, &m.CreatedAt); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		memberships = append(memberships, &m)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return memberships, nil
+}
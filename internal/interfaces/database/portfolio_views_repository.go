@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type PortfolioViewRepository struct {
+	SqlHandler
+}
+
+// SELECT 対象の列（scanPortfolioView の順序と一致させる）
+const portfolioViewColumns = "id, user_id, title, description, item_ids, show_purchase_date, html_enabled, token, created_at, updated_at"
+
+func (r *PortfolioViewRepository) Create(ctx context.Context, view *entity.PortfolioView) (*entity.PortfolioView, error) {
+	itemIDs, err := json.Marshal(view.ItemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query := `
+        INSERT INTO portfolio_views (user_id, title, description, item_ids, show_purchase_date, html_enabled, token)
+        VALUES (?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, view.UserID, view.Title, view.Description, string(itemIDs), view.ShowPurchaseDate, view.HTMLEnabled, view.Token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, view.UserID, id)
+}
+
+func (r *PortfolioViewRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.PortfolioView, error) {
+	query := `
+        SELECT ` + portfolioViewColumns + `
+        FROM portfolio_views
+        WHERE user_id = ?
+        ORDER BY created_at DESC, id DESC
+    `
+
+	rows, err := r.Query(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	views := []*entity.PortfolioView{}
+	for rows.Next() {
+		view, err := scanPortfolioView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		views = append(views, view)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return views, nil
+}
+
+func (r *PortfolioViewRepository) FindByID(ctx context.Context, userID, id int64) (*entity.PortfolioView, error) {
+	return r.findOne(ctx, `SELECT `+portfolioViewColumns+` FROM portfolio_views WHERE id = ? AND user_id = ?`, id, userID)
+}
+
+func (r *PortfolioViewRepository) FindByToken(ctx context.Context, token string) (*entity.PortfolioView, error) {
+	return r.findOne(ctx, `SELECT `+portfolioViewColumns+` FROM portfolio_views WHERE token = ?`, token)
+}
+
+func (r *PortfolioViewRepository) Update(ctx context.Context, view *entity.PortfolioView) (*entity.PortfolioView, error) {
+	itemIDs, err := json.Marshal(view.ItemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query := `
+        UPDATE portfolio_views
+        SET title = ?, description = ?, item_ids = ?, show_purchase_date = ?, html_enabled = ?
+        WHERE id = ? AND user_id = ?
+    `
+
+	_, err = r.Execute(ctx, query, view.Title, view.Description, string(itemIDs), view.ShowPurchaseDate, view.HTMLEnabled, view.ID, view.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, view.UserID, view.ID)
+}
+
+func (r *PortfolioViewRepository) Delete(ctx context.Context, userID, id int64) error {
+	result, err := r.Execute(ctx, `DELETE FROM portfolio_views WHERE id = ? AND user_id = ?`, id, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrPortfolioNotFound
+	}
+
+	return nil
+}
+
+func (r *PortfolioViewRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.PortfolioView, error) {
+	view, err := scanPortfolioView(r.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrPortfolioNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	return view, nil
+}
+
+// ポートフォリオの行をエンティティに変換する（item_ids はJSON配列で保存する）
+func scanPortfolioView(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*entity.PortfolioView, error) {
+	var view entity.PortfolioView
+	var itemIDs []byte
+
+	err := scanner.Scan(
+		&view.ID,
+		&view.UserID,
+		&view.Title,
+		&view.Description,
+		&itemIDs,
+		&view.ShowPurchaseDate,
+		&view.HTMLEnabled,
+		&view.Token,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	view.ItemIDs = []int64{}
+	if len(itemIDs) > 0 {
+		if err := json.Unmarshal(itemIDs, &view.ItemIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	return &view, nil
+}
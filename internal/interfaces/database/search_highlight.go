@@ -0,0 +1,94 @@
+package database
+
+import (
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/search"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// searchHighlighter は検索キーワードに一致した範囲を求める。
+// DB の照合順序（utf8mb4_unicode_ci）と同様に、大文字小文字・アクセント記号・全角半角を区別しない
+type searchHighlighter struct {
+	pattern *search.Pattern
+}
+
+func newSearchHighlighter(keyword string) *searchHighlighter {
+	matcher := search.New(language.Und, search.Loose)
+	return &searchHighlighter{pattern: matcher.CompileString(keyword)}
+}
+
+// highlight は名前とブランドのうちキーワードに一致した項目と範囲を返す
+func (h *searchHighlighter) highlight(item *entity.Item) []entity.SearchHighlight {
+	highlights := []entity.SearchHighlight{}
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{entity.SearchFieldName, item.Name},
+		{entity.SearchFieldBrand, item.Brand},
+	} {
+		if spans := h.spans(field.value); len(spans) > 0 {
+			highlights = append(highlights, entity.SearchHighlight{Field: field.name, Spans: spans})
+		}
+	}
+	return highlights
+}
+
+// spans は s の中でキーワードに一致した重ならない範囲を文字単位の位置で返す
+func (h *searchHighlighter) spans(s string) []entity.TextSpan {
+	var spans []entity.TextSpan
+	offset, runeOffset := 0, 0
+	for offset < len(s) {
+		start, end := h.pattern.IndexString(s[offset:])
+		if start < 0 || end <= start {
+			break
+		}
+		startRunes := runeOffset + utf8.RuneCountInString(s[offset:offset+start])
+		endRunes := startRunes + utf8.RuneCountInString(s[offset+start:offset+end])
+		spans = append(spans, entity.TextSpan{Start: startRunes, End: endRunes})
+		offset += end
+		runeOffset = endRunes
+	}
+	return spans
+}
+
+// snippet は s の最初に一致した箇所の前後 context 文字までを切り出し、切り出した中で一致した範囲とともに返す。
+// 一致した箇所がない場合は先頭から切り出す
+func (h *searchHighlighter) snippet(s string, context int) (string, []entity.TextSpan) {
+	runes := []rune(s)
+	start, end := 0, min(len(runes), 2*context)
+	if spans := h.spans(s); len(spans) > 0 {
+		start = max(spans[0].Start-context, 0)
+		end = min(spans[0].End+context, len(runes))
+	}
+	snippet := string(runes[start:end])
+	return snippet, h.spans(snippet)
+}
+
+// countSpans は一致した範囲の数を返す（全文検索の関連度がない場合の関連度）
+func countSpans(highlights []entity.SearchHighlight) float64 {
+	count := 0
+	for _, h := range highlights {
+		count += len(h.Spans)
+	}
+	return float64(count)
+}
+
+// withExtraColumns は scanItem で読み取る列の後ろに続く列を dest に読み取る Scan を返す
+func withExtraColumns(rows Rows, dest ...interface{}) interface {
+	Scan(dest ...interface{}) error
+} {
+	return extraColumnsScanner{rows: rows, extra: dest}
+}
+
+type extraColumnsScanner struct {
+	rows  Rows
+	extra []interface{}
+}
+
+func (s extraColumnsScanner) Scan(dest ...interface{}) error {
+	return s.rows.Scan(append(dest, s.extra...)...)
+}
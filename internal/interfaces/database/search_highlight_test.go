@@ -0,0 +1,81 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestSearchHighlighter(t *testing.T) {
+	tests := []struct {
+		name     string
+		keyword  string
+		item     *entity.Item
+		expected []entity.SearchHighlight
+	}{
+		{
+			name:    "名前とブランドの両方に一致",
+			keyword: "rolex",
+			item:    &entity.Item{Name: "ROLEX デイトナ", Brand: "Rolex"},
+			expected: []entity.SearchHighlight{
+				{Field: entity.SearchFieldName, Spans: []entity.TextSpan{{Start: 0, End: 5}}},
+				{Field: entity.SearchFieldBrand, Spans: []entity.TextSpan{{Start: 0, End: 5}}},
+			},
+		},
+		{
+			name:    "日本語の位置は文字単位",
+			keyword: "デイトナ",
+			item:    &entity.Item{Name: "ロレックス デイトナ デイトナ", Brand: "ROLEX"},
+			expected: []entity.SearchHighlight{
+				{Field: entity.SearchFieldName, Spans: []entity.TextSpan{{Start: 6, End: 10}, {Start: 11, End: 15}}},
+			},
+		},
+		{
+			name:    "アクセント記号を区別しない",
+			keyword: "hermes",
+			item:    &entity.Item{Name: "バーキン", Brand: "HERMÈS"},
+			expected: []entity.SearchHighlight{
+				{Field: entity.SearchFieldBrand, Spans: []entity.TextSpan{{Start: 0, End: 6}}},
+			},
+		},
+		{
+			name:     "一致しない",
+			keyword:  "シャネル",
+			item:     &entity.Item{Name: "バーキン", Brand: "HERMÈS"},
+			expected: []entity.SearchHighlight{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			highlights := newSearchHighlighter(tt.keyword).highlight(tt.item)
+
+			assert.Equal(t, tt.expected, highlights)
+		})
+	}
+}
+
+func TestCountSpans(t *testing.T) {
+	highlights := []entity.SearchHighlight{
+		{Field: entity.SearchFieldName, Spans: []entity.TextSpan{{Start: 0, End: 1}, {Start: 3, End: 4}}},
+		{Field: entity.SearchFieldBrand, Spans: []entity.TextSpan{{Start: 0, End: 1}}},
+	}
+
+	assert.Equal(t, 3.0, countSpans(highlights))
+}
+
+func TestSearchHighlighter_Snippet(t *testing.T) {
+	h := newSearchHighlighter("銀座本店")
+	text := "領収書\nお買上げ店舗: 銀座本店\n品名: デイトナ 116500LN\n合計 ¥3,450,000"
+
+	snippet, spans := h.snippet(text, 5)
+	assert.Equal(t, "げ店舗: 銀座本店\n品名: ", snippet)
+	assert.Equal(t, []entity.TextSpan{{Start: 5, End: 9}}, spans)
+
+	// 一致した箇所がない場合は先頭から切り出す
+	snippet, spans = newSearchHighlighter("新宿").snippet(text, 3)
+	assert.Equal(t, "領収書\nお買", snippet)
+	assert.Empty(t, spans)
+}
@@ -1,11 +1,20 @@
 package database
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrDuplicateKey は一意制約違反の場合に SqlHandler が返すエラー
+var ErrDuplicateKey = errors.New("duplicate key")
 
 type SqlHandler interface {
 	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
 	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
 	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+	// Transaction は fn をトランザクション内で実行し、fn がエラーを返した場合はロールバックする。
+	// fn には同じトランザクションで実行する SqlHandler が渡される（トランザクション内で呼ぶと外側のトランザクションに参加する）
+	Transaction(ctx context.Context, fn func(ctx context.Context, tx SqlHandler) error) error
 	Close() error
 }
 
@@ -0,0 +1,128 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type TagRepository struct {
+	SqlHandler
+}
+
+func (r *TagRepository) AddToItem(ctx context.Context, itemID int64, name string) error {
+	// タグがなければ作成し、付いていなければアイテムに付ける（同時に付けても重複しない）
+	if _, err := r.Execute(ctx, `INSERT IGNORE INTO tags (name) VALUES (?)`, name); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	query := `
+        INSERT IGNORE INTO item_tags (item_id, tag_id)
+        SELECT ?, id FROM tags WHERE name = ?
+    `
+	if _, err := r.Execute(ctx, query, itemID, name); err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return nil
+}
+
+func (r *TagRepository) RemoveFromItem(ctx context.Context, itemID int64, name string) error {
+	query := `
+        DELETE FROM item_tags
+        WHERE item_id = ? AND tag_id = (SELECT id FROM tags WHERE name = ?)
+    `
+
+	result, err := r.Execute(ctx, query, itemID, name)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrTagNotFound
+	}
+
+	return nil
+}
+
+func (r *TagRepository) FindByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]string, error) {
+	tags := make(map[int64][]string, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return tags, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(itemIDs)), ", ")
+	args := make([]interface{}, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
+	query := `
+        SELECT it.item_id, t.name
+        FROM item_tags it JOIN tags t ON t.id = it.tag_id
+        WHERE it.item_id IN (` + placeholders + `)
+        ORDER BY it.item_id ASC, t.name ASC
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var itemID int64
+		var name string
+		if err := rows.Scan(&itemID, &name); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		tags[itemID] = append(tags[itemID], name)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return tags, nil
+}
+
+func (r *TagRepository) CountByUser(ctx context.Context, userID int64) ([]entity.TagCount, error) {
+	scope, args := accessCondition(userID)
+	query := `
+        SELECT t.name, COUNT(*)
+        FROM item_tags it
+        JOIN tags t ON t.id = it.tag_id
+        JOIN items ON items.id = it.item_id
+        WHERE ` + scope + `
+        GROUP BY t.name
+        ORDER BY COUNT(*) DESC, t.name ASC
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+	defer rows.Close()
+
+	counts := []entity.TagCount{}
+	for rows.Next() {
+		var count entity.TagCount
+		if err := rows.Scan(&count.Name, &count.Count); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		}
+		counts = append(counts, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return counts, nil
+}
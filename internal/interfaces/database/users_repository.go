@@ -0,0 +1,107 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type UserRepository struct {
+	SqlHandler
+}
+
+func (r *UserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	query := `
+        SELECT id, email, password_hash, role, preferred_currency, created_at, updated_at
+        FROM users
+        WHERE id = ?
+    `
+
+	return r.findOne(ctx, query, id)
+}
+
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	query := `
+        SELECT id, email, password_hash, role, preferred_currency, created_at, updated_at
+        FROM users
+        WHERE email = ?
+    `
+
+	return r.findOne(ctx, query, email)
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	query := `
+        INSERT INTO users (email, password_hash, role, preferred_currency)
+        VALUES (?, ?, ?, ?)
+    `
+
+	result, err := r.Execute(ctx, query, user.Email, user.PasswordHash, user.Role, user.Currency())
+	if err != nil {
+		if errors.Is(err, ErrDuplicateKey) {
+			return nil, domainErrors.ErrDuplicateEntry
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *UserRepository) UpdatePreferredCurrency(ctx context.Context, id int64, currency entity.Currency) (*entity.User, error) {
+	query := `
+        UPDATE users
+        SET preferred_currency = ?
+        WHERE id = ?
+    `
+
+	if _, err := r.Execute(ctx, query, currency, id); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+func (r *UserRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.User, error) {
+	var user entity.User
+	err := r.QueryRow(ctx, query, args...).Scan(
+		&user.ID,
+		&user.Email,
+		&user.PasswordHash,
+		&user.Role,
+		&user.PreferredCurrency,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	}
+
+	// アイテムの参照・変更の権限を判定できるよう、所属する組織も読み込む
+	query = `
+        SELECT ` + membershipColumns + `
+        FROM organization_members m LEFT JOIN users u ON u.id = m.user_id
+        WHERE m.user_id = ?
+        ORDER BY m.created_at ASC, m.organization_id ASC
+    `
+	memberships, err := queryMemberships(ctx, r.SqlHandler, query, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		user.Memberships = append(user.Memberships, *m)
+	}
+
+	return &user, nil
+}
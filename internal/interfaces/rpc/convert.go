@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/rpc/itemv1"
+)
+
+// toItem はアイテムを gRPC のメッセージにする（非表示の項目は REST の null と同じく設定しない）
+func toItem(item *entity.Item) (*itemv1.Item, error) {
+	attributes, err := structpb.NewStruct(item.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	currency := item.PurchasePrice.Currency
+	if currency == "" {
+		currency = entity.CurrencyJPY
+	}
+
+	v := &itemv1.Item{
+		Id:               item.ID,
+		UserId:           item.UserID,
+		OrgId:            item.OrgID,
+		Name:             item.Name,
+		Category:         item.Category,
+		Brand:            item.Brand,
+		PurchaseCurrency: string(currency),
+		Visibility:       string(item.Visibility),
+		Condition:        string(item.Condition),
+		Status:           string(item.Status),
+		SerialNumber:     item.SerialNumber,
+		Notes:            item.Notes,
+		Attributes:       attributes,
+		Tags:             item.Tags,
+		RedactedFields:   item.RedactedFields(),
+		Version:          item.Version,
+		CreatedAt:        timestamppb.New(item.CreatedAt),
+		UpdatedAt:        timestamppb.New(item.UpdatedAt),
+	}
+	if !item.IsRedacted(entity.ItemFieldPurchasePrice) {
+		price := entity.Money{Amount: item.PurchasePrice.Amount, Currency: currency}.Decimal().String()
+		v.PurchasePrice = &price
+	}
+	if !item.IsRedacted(entity.ItemFieldPurchaseDate) {
+		v.PurchaseDate = &item.PurchaseDate
+	}
+	if item.CurrentValue != nil {
+		value := item.CurrentValue.Decimal().String()
+		valueCurrency := string(item.CurrentValue.Currency)
+		v.CurrentValue = &value
+		v.CurrentValueCurrency = &valueCurrency
+	}
+	return v, nil
+}
+
+// fromAttributes はリクエストの属性をアイテムの属性にする（検証はユースケースで行う）
+func fromAttributes(attributes *structpb.Struct) entity.ItemAttributes {
+	if attributes == nil {
+		return nil
+	}
+	return attributes.AsMap()
+}
@@ -0,0 +1,66 @@
+package rpc
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// statusError はユースケースのエラーを gRPC のステータスに変換する（REST の problem.FromError と同じ分類）。
+// 検証エラーは項目ごとの違反を BadRequest の詳細に含める
+func statusError(err error, message string) error {
+	switch {
+	case domainErrors.IsValidationError(err):
+		errs, ok := domainErrors.AsValidationErrors(err)
+		if !ok {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return validationStatus(errs)
+	case domainErrors.IsUnauthorizedError(err):
+		return status.Error(codes.Unauthenticated, "authentication required")
+	case domainErrors.IsForbiddenError(err):
+		return status.Error(codes.PermissionDenied, "insufficient permissions")
+	case domainErrors.IsNotFoundError(err):
+		return status.Error(codes.NotFound, notFoundMessage(err))
+	case domainErrors.IsVersionMismatchError(err):
+		return status.Error(codes.FailedPrecondition, "item has been modified, fetch it again and retry")
+	case domainErrors.IsVersionConflictError(err):
+		return status.Error(codes.Aborted, "item was modified by another request, fetch it again and retry")
+	case domainErrors.IsDuplicateSerialNumberError(err):
+		return status.Error(codes.AlreadyExists, domainErrors.ErrDuplicateSerialNumber.Error())
+	case domainErrors.IsDuplicateError(err):
+		return status.Error(codes.AlreadyExists, "already exists")
+	case domainErrors.IsExchangeRateUnavailableError(err):
+		return status.Error(codes.Unavailable, "exchange rates are temporarily unavailable, retry later")
+	}
+	return status.Error(codes.Internal, message)
+}
+
+// invalidArgument は1つの項目の形式の誤りのステータスを返す
+func invalidArgument(field, message string) error {
+	return validationStatus(domainErrors.ValidationErrors{{Field: field, Code: domainErrors.CodeInvalidFormat, Message: message}})
+}
+
+func validationStatus(errs domainErrors.ValidationErrors) error {
+	st := status.New(codes.InvalidArgument, errs.Error())
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(errs))
+	for _, e := range errs {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{Field: e.Field, Description: e.Message})
+	}
+	if detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// 見つからなかったものの説明（ラップされたエラーの詳細は返さない）
+func notFoundMessage(err error) string {
+	if errors.Is(err, domainErrors.ErrItemNotFound) {
+		return domainErrors.ErrItemNotFound.Error()
+	}
+	return "not found"
+}
@@ -0,0 +1,125 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// メタデータのキー（REST のヘッダーと同じ。gRPC のメタデータのキーは小文字）
+const (
+	metadataAuthorization  = "authorization"
+	metadataAPIKey         = "x-api-key"
+	metadataAcceptLanguage = "accept-language"
+)
+
+// AuthInterceptor は REST の RequireAuth と同じく、x-api-key か authorization（Bearer）で認証し、
+// 操作者と Accept-Language の表示言語をコンテキストに設定するインターセプター
+func AuthInterceptor(authUsecase usecase.AuthUsecase, apiKeyUsecase usecase.APIKeyUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		var user *entity.User
+		var err error
+		if key := firstValue(md, metadataAPIKey); key != "" {
+			user, err = apiKeyUsecase.Authenticate(ctx, key)
+		} else {
+			token, ok := bearerToken(firstValue(md, metadataAuthorization))
+			if !ok {
+				return nil, status.Error(codes.Unauthenticated, "authentication required")
+			}
+			user, err = authUsecase.Authenticate(ctx, token)
+		}
+		if err != nil {
+			if domainErrors.IsUnauthorizedError(err) {
+				return nil, status.Error(codes.Unauthenticated, "authentication required")
+			}
+			return nil, status.Error(codes.Internal, "failed to authenticate")
+		}
+
+		ctx = usecase.WithActor(ctx, user)
+		ctx = usecase.WithLanguage(ctx, entity.ParseAcceptLanguage(firstValue(md, metadataAcceptLanguage)))
+		return handler(ctx, req)
+	}
+}
+
+// auditMethods は監査ログに記録するメソッドと操作の種類（参照は記録しない）
+var auditMethods = map[string]entity.AuditAction{
+	"CreateItem": entity.AuditActionCreate,
+	"UpdateItem": entity.AuditActionUpdate,
+	"DeleteItem": entity.AuditActionDelete,
+}
+
+// AuditInterceptor は成功した変更を REST と同じ監査ログに記録するインターセプター（AuthInterceptor の後に使う）。
+// Method は "GRPC"、Path はフルメソッド名、ResourceID はアイテムの ID、Status は REST に揃えて 200 にする
+func AuditInterceptor(audit usecase.AuditUsecase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, err
+		}
+		action, ok := auditMethods[info.FullMethod[strings.LastIndex(info.FullMethod, "/")+1:]]
+		if !ok {
+			return res, err
+		}
+
+		entry := &entity.AuditLog{
+			Action:     action,
+			Method:     "GRPC",
+			Path:       info.FullMethod,
+			ResourceID: resourceID(req, res),
+			Status:     http.StatusOK,
+			CreatedAt:  time.Now(),
+		}
+		if actor, ok := usecase.ActorFromContext(ctx); ok {
+			entry.UserID = actor.ID
+		}
+		if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+			entry.IP = p.Addr.String()
+			if host, _, err := net.SplitHostPort(entry.IP); err == nil {
+				entry.IP = host
+			}
+		}
+		audit.Record(entry)
+		return res, err
+	}
+}
+
+// resourceID は操作したアイテムの ID を返す
+func resourceID(req, res any) string {
+	for _, v := range []any{req, res} {
+		if withID, ok := v.(interface{ GetId() int64 }); ok && withID.GetId() != 0 {
+			return strconv.FormatInt(withID.GetId(), 10)
+		}
+	}
+	return ""
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func bearerToken(value string) (string, bool) {
+	scheme, token, ok := strings.Cut(value, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
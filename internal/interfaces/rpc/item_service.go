@@ -0,0 +1,161 @@
+// Package rpc は REST API と同じユースケースを gRPC で提供する
+package rpc
+
+import (
+	"context"
+	"net/url"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/interfaces/rpc/itemv1"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemService は itemv1.ItemService の実装（REST の ItemHandler と同じユースケースを呼び出す）
+type ItemService struct {
+	itemv1.UnimplementedItemServiceServer
+	itemUsecase usecase.ItemUsecase
+}
+
+func NewItemService(itemUsecase usecase.ItemUsecase) *ItemService {
+	return &ItemService{
+		itemUsecase: itemUsecase,
+	}
+}
+
+func (s *ItemService) ListItems(ctx context.Context, req *itemv1.ListItemsRequest) (*itemv1.ListItemsResponse, error) {
+	query, err := url.ParseQuery(req.GetQuery())
+	if err != nil {
+		return nil, invalidArgument("query", "query must be URL-encoded query parameters")
+	}
+	// GET /items と同じ関数で解釈するため、同じ条件は同じアイテムに一致する
+	filter, validationErrors := entity.ParseItemFilterQuery(query)
+	if len(validationErrors) > 0 {
+		return nil, statusError(validationErrors, "")
+	}
+
+	items, err := s.itemUsecase.GetAllItems(ctx, filter)
+	if err != nil {
+		return nil, statusError(err, "failed to retrieve items")
+	}
+
+	res := &itemv1.ListItemsResponse{Items: make([]*itemv1.Item, 0, len(items))}
+	for _, item := range items {
+		converted, err := toItem(item)
+		if err != nil {
+			return nil, statusError(err, "failed to encode item")
+		}
+		res.Items = append(res.Items, converted)
+	}
+	return res, nil
+}
+
+func (s *ItemService) GetItem(ctx context.Context, req *itemv1.GetItemRequest) (*itemv1.Item, error) {
+	item, err := s.itemUsecase.GetItemByID(ctx, req.GetId())
+	if err != nil {
+		return nil, statusError(err, "failed to retrieve item")
+	}
+	return encodeItem(item)
+}
+
+func (s *ItemService) CreateItem(ctx context.Context, req *itemv1.CreateItemRequest) (*itemv1.Item, error) {
+	price, err := parsePrice("purchase_price", req.GetPurchasePrice())
+	if err != nil {
+		return nil, err
+	}
+	item, err := s.itemUsecase.CreateItem(ctx, usecase.CreateItemInput{
+		Name:             req.GetName(),
+		Category:         req.GetCategory(),
+		Brand:            req.GetBrand(),
+		PurchasePrice:    price,
+		PurchaseCurrency: req.GetPurchaseCurrency(),
+		PurchaseDate:     req.GetPurchaseDate(),
+		Visibility:       req.GetVisibility(),
+		Condition:        req.GetCondition(),
+		SerialNumber:     req.GetSerialNumber(),
+		Notes:            req.GetNotes(),
+		Attributes:       fromAttributes(req.GetAttributes()),
+		OrgID:            req.GetOrgId(),
+	})
+	if err != nil {
+		return nil, statusError(err, "failed to create item")
+	}
+	return encodeItem(item)
+}
+
+func (s *ItemService) UpdateItem(ctx context.Context, req *itemv1.UpdateItemRequest) (*itemv1.Item, error) {
+	input := usecase.UpdateItemInput{
+		Name:             req.Name,
+		Brand:            req.Brand,
+		PurchaseCurrency: req.PurchaseCurrency,
+		Visibility:       req.Visibility,
+		Condition:        req.Condition,
+		SerialNumber:     req.SerialNumber,
+		Notes:            req.Notes,
+		OrgID:            req.OrgId,
+		Version:          req.GetVersion(),
+	}
+	if req.PurchasePrice != nil {
+		price, err := parsePrice("purchase_price", req.GetPurchasePrice())
+		if err != nil {
+			return nil, err
+		}
+		input.PurchasePrice = &price
+	}
+	if req.Attributes != nil {
+		attributes := fromAttributes(req.GetAttributes())
+		input.Attributes = &attributes
+	}
+
+	item, err := s.itemUsecase.UpdateItem(ctx, req.GetId(), input)
+	if err != nil {
+		return nil, statusError(err, "failed to update item")
+	}
+	return encodeItem(item)
+}
+
+func (s *ItemService) DeleteItem(ctx context.Context, req *itemv1.DeleteItemRequest) (*emptypb.Empty, error) {
+	if err := s.itemUsecase.DeleteItem(ctx, req.GetId()); err != nil {
+		return nil, statusError(err, "failed to delete item")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ItemService) GetCategorySummary(ctx context.Context, req *itemv1.GetCategorySummaryRequest) (*itemv1.CategorySummary, error) {
+	summary, err := s.itemUsecase.GetCategorySummary(ctx, req.GetAsOf())
+	if err != nil {
+		return nil, statusError(err, "failed to retrieve summary")
+	}
+
+	res := &itemv1.CategorySummary{
+		AsOf:       summary.AsOf,
+		Categories: make(map[string]int64, len(summary.Categories)),
+		Total:      int64(summary.Total),
+		Currency:   string(summary.Currency),
+		Values:     summary.Values,
+		TotalValue: summary.TotalValue,
+	}
+	for category, count := range summary.Categories {
+		res.Categories[category] = int64(count)
+	}
+	return res, nil
+}
+
+// encodeItem はユースケースが返したアイテムをレスポンスにする
+func encodeItem(item *entity.Item) (*itemv1.Item, error) {
+	converted, err := toItem(item)
+	if err != nil {
+		return nil, statusError(err, "failed to encode item")
+	}
+	return converted, nil
+}
+
+// parsePrice は10進数の文字列の金額を読み込む
+func parsePrice(field, value string) (entity.Decimal, error) {
+	price, err := entity.ParseDecimal(value)
+	if err != nil {
+		return entity.Decimal{}, invalidArgument(field, field+" must be a decimal number")
+	}
+	return price, nil
+}
@@ -0,0 +1,229 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/interfaces/rpc/itemv1"
+	"Aicon-assignment/internal/usecase"
+)
+
+// mockItemUsecase は ItemService が呼び出すメソッドのみ実装する（それ以外を呼ぶと panic する）
+type mockItemUsecase struct {
+	usecase.ItemUsecase
+	mock.Mock
+}
+
+func (m *mockItemUsecase) GetAllItems(ctx context.Context, filter entity.ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *mockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *mockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *mockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type mockAuthUsecase struct {
+	usecase.AuthUsecase
+	mock.Mock
+}
+
+func (m *mockAuthUsecase) Authenticate(ctx context.Context, token string) (*entity.User, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+type recordingAuditUsecase struct {
+	usecase.AuditUsecase
+	entries []*entity.AuditLog
+}
+
+func (r *recordingAuditUsecase) Record(entry *entity.AuditLog) {
+	r.entries = append(r.entries, entry)
+}
+
+var testUser = &entity.User{ID: 7, Role: entity.RoleEditor}
+
+// newTestClient はインターセプターを含めた gRPC サーバーをメモリ上で起動し、クライアントを返す
+func newTestClient(t *testing.T, itemUsecase usecase.ItemUsecase, authUsecase usecase.AuthUsecase, audit usecase.AuditUsecase) itemv1.ItemServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(AuthInterceptor(authUsecase, nil), AuditInterceptor(audit)))
+	itemv1.RegisterItemServiceServer(server, NewItemService(itemUsecase))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return itemv1.NewItemServiceClient(conn)
+}
+
+func authorized() context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer valid-token")
+}
+
+func TestItemService(t *testing.T) {
+	setup := func(t *testing.T) (*mockItemUsecase, *recordingAuditUsecase, itemv1.ItemServiceClient) {
+		itemUsecase := &mockItemUsecase{}
+		authUsecase := &mockAuthUsecase{}
+		authUsecase.On("Authenticate", mock.Anything, "valid-token").Return(testUser, nil).Maybe()
+		audit := &recordingAuditUsecase{}
+		return itemUsecase, audit, newTestClient(t, itemUsecase, authUsecase, audit)
+	}
+
+	t.Run("正常系: 一覧は GET /items と同じクエリで絞り込む", func(t *testing.T) {
+		itemUsecase, audit, client := setup(t)
+		minPrice := 1000
+		itemUsecase.On("GetAllItems", mock.Anything, entity.ItemFilter{Category: "時計", PurchaseCurrency: entity.CurrencyJPY, MinPurchasePrice: &minPrice, Tags: []string{"vintage"}}).
+			Return([]*entity.Item{{ID: 1, Name: "ロレックス", Category: "時計", PurchasePrice: entity.NewMoney(150000, "JPY")}}, nil)
+
+		res, err := client.ListItems(authorized(), &itemv1.ListItemsRequest{Query: "category=時計&min_price=1000&tag=Vintage"})
+		require.NoError(t, err)
+		require.Len(t, res.GetItems(), 1)
+		assert.Equal(t, "ロレックス", res.GetItems()[0].GetName())
+		assert.Equal(t, "150000", res.GetItems()[0].GetPurchasePrice())
+		assert.Equal(t, "JPY", res.GetItems()[0].GetPurchaseCurrency())
+		// 参照は監査ログに記録しない
+		assert.Empty(t, audit.entries)
+		itemUsecase.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 操作者はユースケースのコンテキストに設定される", func(t *testing.T) {
+		itemUsecase, _, client := setup(t)
+		itemUsecase.On("GetItemByID", mock.MatchedBy(func(ctx context.Context) bool {
+			actor, ok := usecase.ActorFromContext(ctx)
+			return ok && actor.ID == testUser.ID
+		}), int64(1)).Return(&entity.Item{ID: 1, Name: "ロレックス"}, nil)
+
+		res, err := client.GetItem(authorized(), &itemv1.GetItemRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), res.GetId())
+		itemUsecase.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 非表示の項目は設定しない", func(t *testing.T) {
+		itemUsecase, _, client := setup(t)
+		item := &entity.Item{ID: 1, Name: "ロレックス", PurchasePrice: entity.NewMoney(150000, "JPY"), PurchaseDate: "2023-01-15"}
+		item.Redact(entity.ItemFieldPurchasePrice)
+		itemUsecase.On("GetItemByID", mock.Anything, int64(1)).Return(item, nil)
+
+		res, err := client.GetItem(authorized(), &itemv1.GetItemRequest{Id: 1})
+		require.NoError(t, err)
+		assert.Nil(t, res.PurchasePrice)
+		assert.Equal(t, "2023-01-15", res.GetPurchaseDate())
+		assert.Equal(t, []string{entity.ItemFieldPurchasePrice}, res.GetRedactedFields())
+	})
+
+	t.Run("正常系: 登録と削除は監査ログに記録する", func(t *testing.T) {
+		itemUsecase, audit, client := setup(t)
+		itemUsecase.On("CreateItem", mock.Anything, mock.MatchedBy(func(input usecase.CreateItemInput) bool {
+			return input.Name == "ロレックス" && input.PurchasePrice.String() == "150000.50"
+		})).Return(&entity.Item{ID: 5, Name: "ロレックス", CreatedAt: time.Now()}, nil)
+		itemUsecase.On("DeleteItem", mock.Anything, int64(5)).Return(nil)
+
+		_, err := client.CreateItem(authorized(), &itemv1.CreateItemRequest{Name: "ロレックス", PurchasePrice: "150000.50"})
+		require.NoError(t, err)
+		_, err = client.DeleteItem(authorized(), &itemv1.DeleteItemRequest{Id: 5})
+		require.NoError(t, err)
+
+		require.Len(t, audit.entries, 2)
+		assert.Equal(t, entity.AuditActionCreate, audit.entries[0].Action)
+		assert.Equal(t, "GRPC", audit.entries[0].Method)
+		assert.Equal(t, itemv1.ItemService_CreateItem_FullMethodName, audit.entries[0].Path)
+		assert.Equal(t, "5", audit.entries[0].ResourceID)
+		assert.Equal(t, testUser.ID, audit.entries[0].UserID)
+		assert.Equal(t, entity.AuditActionDelete, audit.entries[1].Action)
+		assert.Equal(t, "5", audit.entries[1].ResourceID)
+	})
+
+	t.Run("異常系: 認証情報がない場合は Unauthenticated", func(t *testing.T) {
+		_, _, client := setup(t)
+
+		_, err := client.GetItem(context.Background(), &itemv1.GetItemRequest{Id: 1})
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("異常系: 検証エラーは項目ごとの違反を含む InvalidArgument", func(t *testing.T) {
+		itemUsecase, audit, client := setup(t)
+		var errs domainErrors.ValidationErrors
+		errs.Add("name", domainErrors.CodeRequired, "name is required")
+		itemUsecase.On("CreateItem", mock.Anything, mock.Anything).Return(nil, errs.Err())
+
+		_, err := client.CreateItem(authorized(), &itemv1.CreateItemRequest{PurchasePrice: "1000"})
+		st := status.Convert(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+		require.Len(t, st.Details(), 1)
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		require.True(t, ok)
+		require.Len(t, badRequest.GetFieldViolations(), 1)
+		assert.Equal(t, "name", badRequest.GetFieldViolations()[0].GetField())
+		// 失敗した変更は記録しない
+		assert.Empty(t, audit.entries)
+	})
+
+	t.Run("異常系: 金額の形式の誤りはユースケースを呼ばずに InvalidArgument", func(t *testing.T) {
+		itemUsecase, _, client := setup(t)
+
+		_, err := client.CreateItem(authorized(), &itemv1.CreateItemRequest{Name: "ロレックス", PurchasePrice: "abc"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		itemUsecase.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: クエリの形式の誤りは InvalidArgument", func(t *testing.T) {
+		_, _, client := setup(t)
+
+		_, err := client.ListItems(authorized(), &itemv1.ListItemsRequest{Query: "min_price=abc"})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("異常系: 見つからない場合は NotFound", func(t *testing.T) {
+		itemUsecase, _, client := setup(t)
+		itemUsecase.On("GetItemByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := client.GetItem(authorized(), &itemv1.GetItemRequest{Id: 999})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
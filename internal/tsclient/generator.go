@@ -0,0 +1,498 @@
+// Package tsclient はOpenAPI仕様からTypeScriptクライアント（ESM + 型定義）を生成する
+package tsclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"Aicon-assignment/internal/infrastructure/deprecation"
+)
+
+// Files は生成されるファイル
+type Files struct {
+	JS  []byte // index.js
+	DTS []byte // index.d.ts
+}
+
+// 生成順を固定するためのHTTPメソッドの並び
+// identifierPattern は TypeScript のプロパティ名にそのまま書ける名前
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+var methodOrder = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete}
+
+type operation struct {
+	name        string
+	summary     string
+	deprecated  *deprecation.Notice
+	method      string
+	path        string
+	pathParams  []string
+	queryParams []*openapi3.Parameter
+	// リクエストごとに指定するヘッダー（If-Match や Idempotency-Key など）。共通のヘッダーは ClientOptions.headers で指定する
+	headerParams []*openapi3.Parameter
+	requestBody  *openapi3.SchemaRef
+	multipart    bool   // リクエストボディを FormData で送る
+	rawBodyType  string // リクエストボディをそのまま送る場合（CSV など）のメディアタイプ
+	responseType string
+	binaryType   string // JSON以外（PDFなど）を返す場合のメディアタイプ
+}
+
+// Generate はOpenAPI仕様（YAML/JSON）からクライアントを生成する
+func Generate(spec []byte) (*Files, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load openapi spec: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+
+	ops, err := collectOperations(doc)
+	if err != nil {
+		return nil, err
+	}
+	dts, err := renderDTS(doc, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Files{
+		JS:  renderJS(ops),
+		DTS: dts,
+	}, nil
+}
+
+func collectOperations(doc *openapi3.T) ([]operation, error) {
+	paths := doc.Paths.InMatchingOrder()
+	sort.Strings(paths)
+
+	var ops []operation
+	for _, path := range paths {
+		item := doc.Paths.Value(path)
+		for _, method := range methodOrder {
+			op := item.GetOperation(method)
+			if op == nil {
+				continue
+			}
+			if op.OperationID == "" {
+				return nil, fmt.Errorf("%s %s: operationId is required", method, path)
+			}
+
+			o := operation{
+				name:         op.OperationID,
+				summary:      op.Summary,
+				method:       method,
+				path:         path,
+				responseType: responseType(op),
+				binaryType:   binaryType(op),
+			}
+			if op.Deprecated {
+				notice, err := deprecation.FromExtensions(op.Extensions)
+				if err != nil {
+					return nil, fmt.Errorf("%s %s: %w", method, path, err)
+				}
+				o.deprecated = &notice
+			}
+
+			params := append(openapi3.Parameters{}, item.Parameters...)
+			params = append(params, op.Parameters...)
+			for _, p := range params {
+				switch p.Value.In {
+				case openapi3.ParameterInPath:
+					o.pathParams = append(o.pathParams, p.Value.Name)
+				case openapi3.ParameterInQuery:
+					o.queryParams = append(o.queryParams, p.Value)
+				case openapi3.ParameterInHeader:
+					// Prefer: respond-async はレスポンスの型が変わるため、クライアントでは同期で呼び出す
+					if p.Value.Name != "Prefer" {
+						o.headerParams = append(o.headerParams, p.Value)
+					}
+				}
+			}
+
+			if op.RequestBody != nil && op.RequestBody.Value != nil {
+				if mt := op.RequestBody.Value.Content.Get("application/json"); mt != nil {
+					o.requestBody = mt.Schema
+				} else if mt := op.RequestBody.Value.Content.Get("multipart/form-data"); mt != nil {
+					o.requestBody = mt.Schema
+					o.multipart = true
+				} else if mt := op.RequestBody.Value.Content.Get("text/csv"); mt != nil {
+					o.requestBody = mt.Schema
+					o.rawBodyType = "text/csv"
+				}
+			}
+
+			ops = append(ops, o)
+		}
+	}
+	return ops, nil
+}
+
+// 成功レスポンス（2xx）のJSONスキーマからTypeScriptの型を決める
+func responseType(op *openapi3.Operation) string {
+	codes := make([]string, 0, op.Responses.Len())
+	for code := range op.Responses.Map() {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	for _, code := range codes {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		resp := op.Responses.Value(code)
+		if resp == nil || resp.Value == nil {
+			continue
+		}
+		if mt := resp.Value.Content.Get("application/json"); mt != nil && mt.Schema != nil {
+			return tsType(mt.Schema)
+		}
+		if len(resp.Value.Content) > 0 {
+			return "Blob"
+		}
+		return "void"
+	}
+	return "void"
+}
+
+// 成功レスポンスがJSON以外の場合、そのメディアタイプを返す
+func binaryType(op *openapi3.Operation) string {
+	for code, resp := range op.Responses.Map() {
+		if !strings.HasPrefix(code, "2") || resp.Value == nil || resp.Value.Content.Get("application/json") != nil {
+			continue
+		}
+		for _, mediaType := range sortedKeys(resp.Value.Content) {
+			return mediaType
+		}
+	}
+	return ""
+}
+
+// スキーマをTypeScriptの型表現に変換する
+func tsType(ref *openapi3.SchemaRef) string {
+	if ref == nil || ref.Value == nil {
+		return "unknown"
+	}
+	if ref.Ref != "" {
+		return ref.Ref[strings.LastIndex(ref.Ref, "/")+1:]
+	}
+
+	s := ref.Value
+	if s.Nullable {
+		nonNull := *s
+		nonNull.Nullable = false
+		return tsType(&openapi3.SchemaRef{Value: &nonNull}) + " | null"
+	}
+	// nullable を付けるために1つだけ allOf で参照したスキーマは、参照先の型とする
+	if len(s.AllOf) == 1 {
+		return tsType(s.AllOf[0])
+	}
+	if len(s.Enum) > 0 {
+		values := make([]string, 0, len(s.Enum))
+		for _, v := range s.Enum {
+			values = append(values, fmt.Sprintf("%q", fmt.Sprint(v)))
+		}
+		return strings.Join(values, " | ")
+	}
+
+	switch {
+	case s.Type.Is(openapi3.TypeInteger), s.Type.Is(openapi3.TypeNumber):
+		return "number"
+	case s.Type.Is(openapi3.TypeString):
+		return "string"
+	case s.Type.Is(openapi3.TypeBoolean):
+		return "boolean"
+	case s.Type.Is(openapi3.TypeArray):
+		return "Array<" + tsType(s.Items) + ">"
+	case s.Type.Is(openapi3.TypeObject):
+		if len(s.Properties) == 0 && s.AdditionalProperties.Schema != nil {
+			return "Record<string, " + tsType(s.AdditionalProperties.Schema) + ">"
+		}
+		if len(s.Properties) == 0 {
+			return "Record<string, unknown>"
+		}
+		var b strings.Builder
+		b.WriteString("{ ")
+		for _, name := range sortedKeys(s.Properties) {
+			fmt.Fprintf(&b, "%s%s: %s; ", name, optionalMark(s, name), tsType(s.Properties[name]))
+		}
+		b.WriteString("}")
+		return b.String()
+	}
+	return "unknown"
+}
+
+// propertyName は識別子として書けない名前（attr.movement など）を引用符で囲む
+func propertyName(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return fmt.Sprintf("%q", name)
+}
+
+func optionalMark(s *openapi3.Schema, name string) string {
+	for _, r := range s.Required {
+		if r == name {
+			return ""
+		}
+	}
+	return "?"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// listItems -> ListItemsQuery
+func queryTypeName(op operation) string {
+	return strings.ToUpper(op.name[:1]) + op.name[1:] + "Query"
+}
+
+// updateItem -> UpdateItemHeaders
+func headersTypeName(op operation) string {
+	return strings.ToUpper(op.name[:1]) + op.name[1:] + "Headers"
+}
+
+func renderDTS(doc *openapi3.T, ops []operation) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by cmd/tsclient from api/openapi.yaml. DO NOT EDIT.\n\n")
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		schema := doc.Components.Schemas[name]
+		if schema.Value.Type.Is(openapi3.TypeObject) && len(schema.Value.Properties) > 0 {
+			fmt.Fprintf(&b, "export interface %s {\n", name)
+			for _, prop := range sortedKeys(schema.Value.Properties) {
+				if p := schema.Value.Properties[prop].Value; p != nil && p.Deprecated {
+					notice, err := deprecation.FromExtensions(p.Extensions)
+					if err != nil {
+						return nil, fmt.Errorf("%s.%s: %w", name, prop, err)
+					}
+					writeDoc(&b, "", &notice)
+				}
+				fmt.Fprintf(&b, "  %s%s: %s;\n", prop, optionalMark(schema.Value, prop), tsType(schema.Value.Properties[prop]))
+			}
+			b.WriteString("}\n\n")
+			continue
+		}
+		fmt.Fprintf(&b, "export type %s = %s;\n\n", name, tsType(&openapi3.SchemaRef{Value: schema.Value}))
+	}
+
+	for _, op := range ops {
+		if len(op.queryParams) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", queryTypeName(op))
+		for _, p := range op.queryParams {
+			if p.Deprecated {
+				notice, err := deprecation.FromExtensions(p.Extensions)
+				if err != nil {
+					return nil, fmt.Errorf("%s parameter %s: %w", op.name, p.Name, err)
+				}
+				writeDoc(&b, "", &notice)
+			}
+			mark := "?"
+			if p.Required {
+				mark = ""
+			}
+			fmt.Fprintf(&b, "  %s%s: %s;\n", propertyName(p.Name), mark, tsType(p.Schema))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	for _, op := range ops {
+		if len(op.headerParams) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "export interface %s {\n", headersTypeName(op))
+		for _, p := range op.headerParams {
+			mark := "?"
+			if p.Required {
+				mark = ""
+			}
+			fmt.Fprintf(&b, "  %q%s: %s;\n", p.Name, mark, tsType(p.Schema))
+		}
+		b.WriteString("}\n\n")
+	}
+
+	b.WriteString(`export declare class ApiError extends Error {
+  readonly status: number;
+  readonly body: Problem | undefined;
+}
+
+export interface ClientOptions {
+  baseUrl: string;
+  headers?: Record<string, string>;
+  fetch?: typeof fetch;
+}
+
+export interface Client {
+`)
+	for _, op := range ops {
+		writeDoc(&b, op.summary, op.deprecated)
+		fmt.Fprintf(&b, "  %s(%s): Promise<%s>;\n", op.name, strings.Join(dtsArgs(op), ", "), op.responseType)
+	}
+	b.WriteString("}\n\nexport declare function createClient(options: ClientOptions): Client;\n")
+
+	return b.Bytes(), nil
+}
+
+// writeDoc はメンバーの JSDoc を書き出す（非推奨の場合は @deprecated で代替と削除予定日を示す）
+func writeDoc(b *bytes.Buffer, summary string, deprecated *deprecation.Notice) {
+	var tag string
+	if deprecated != nil {
+		tag = strings.TrimSpace("@deprecated " + deprecated.Text())
+	}
+	switch {
+	case tag != "" && summary != "":
+		fmt.Fprintf(b, "  /**\n   * %s\n   * %s\n   */\n", summary, tag)
+	case tag != "":
+		fmt.Fprintf(b, "  /** %s */\n", tag)
+	case summary != "":
+		fmt.Fprintf(b, "  /** %s */\n", summary)
+	}
+}
+
+func dtsArgs(op operation) []string {
+	var args []string
+	for _, p := range op.pathParams {
+		args = append(args, p+": number | string")
+	}
+	if op.multipart {
+		args = append(args, "body: FormData")
+	} else if op.rawBodyType != "" {
+		args = append(args, "body: Blob | string")
+	} else if op.requestBody != nil {
+		args = append(args, "body: "+tsType(op.requestBody))
+	}
+	if len(op.queryParams) > 0 {
+		mark := "?"
+		for _, p := range op.queryParams {
+			if p.Required {
+				mark = ""
+			}
+		}
+		args = append(args, "query"+mark+": "+queryTypeName(op))
+	}
+	if len(op.headerParams) > 0 {
+		mark := "?"
+		for _, p := range op.headerParams {
+			if p.Required {
+				mark = ""
+			}
+		}
+		args = append(args, "headers"+mark+": "+headersTypeName(op))
+	}
+	return args
+}
+
+func renderJS(ops []operation) []byte {
+	var b bytes.Buffer
+	b.WriteString(`// Code generated by cmd/tsclient from api/openapi.yaml. DO NOT EDIT.
+
+export class ApiError extends Error {
+  constructor(status, body) {
+    super((body && (body.detail || body.title)) || "request failed with status " + status);
+    this.name = "ApiError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+export function createClient(options) {
+  const baseUrl = options.baseUrl.replace(/\/+$/, "");
+  const fetchImpl = options.fetch || globalThis.fetch;
+  const defaultHeaders = options.headers || {};
+
+  async function request(method, path, query, body, accept, extraHeaders) {
+    let url = baseUrl + path;
+    if (query) {
+      const params = new URLSearchParams();
+      for (const [key, value] of Object.entries(query)) {
+        if (value === undefined || value === null) continue;
+        // 配列は同じ名前のパラメータを繰り返す（tag=a&tag=b）
+        for (const v of Array.isArray(value) ? value : [value]) params.append(key, String(v));
+      }
+      const qs = params.toString();
+      if (qs) url += "?" + qs;
+    }
+
+    const headers = { Accept: accept || "application/json, application/problem+json", ...defaultHeaders, ...extraHeaders };
+    const init = { method, headers };
+    if (body instanceof FormData) {
+      // Content-Type（boundary 付き）は fetch に設定させる
+      init.body = body;
+    } else if (body instanceof Blob) {
+      // CSV などのそのまま送るボディは Blob の type を Content-Type にする
+      headers["Content-Type"] = body.type;
+      init.body = body;
+    } else if (body !== undefined) {
+      headers["Content-Type"] = "application/json";
+      init.body = JSON.stringify(body);
+    }
+
+    const res = await fetchImpl(url, init);
+    if (accept && res.ok) return res.blob();
+    const text = await res.text();
+    const data = text ? JSON.parse(text) : undefined;
+    if (!res.ok) throw new ApiError(res.status, data);
+    return data;
+  }
+
+  return {
+`)
+	for _, op := range ops {
+		var params []string
+		params = append(params, op.pathParams...)
+		body := "undefined"
+		if op.requestBody != nil {
+			params = append(params, "body")
+			body = "body"
+			if op.rawBodyType != "" {
+				body = fmt.Sprintf("new Blob([body], { type: %q })", op.rawBodyType)
+			}
+		}
+		query := "undefined"
+		if len(op.queryParams) > 0 {
+			params = append(params, "query")
+			query = "query"
+		}
+
+		path := "\"" + op.path + "\""
+		if len(op.pathParams) > 0 {
+			path = "`" + op.path + "`"
+			for _, p := range op.pathParams {
+				path = strings.ReplaceAll(path, "{"+p+"}", "${encodeURIComponent("+p+")}")
+			}
+		}
+
+		args := []string{fmt.Sprintf("%q", op.method), path, query, body}
+		if op.binaryType != "" {
+			args = append(args, fmt.Sprintf("%q", op.binaryType))
+		}
+		if len(op.headerParams) > 0 {
+			if op.binaryType == "" {
+				args = append(args, "undefined")
+			}
+			params = append(params, "headers")
+			args = append(args, "headers")
+		}
+
+		fmt.Fprintf(&b, "    %s(%s) {\n      return request(%s);\n    },\n",
+			op.name, strings.Join(params, ", "), strings.Join(args, ", "))
+	}
+	b.WriteString("  };\n}\n")
+
+	return b.Bytes()
+}
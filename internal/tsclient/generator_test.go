@@ -0,0 +1,367 @@
+package tsclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/api"
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func init() {
+	// サーバーと同様に multipart の画像パートを検証できるようにする
+	for _, contentType := range entity.ImageContentTypes {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.FileBodyDecoder)
+	}
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.FileBodyDecoder)
+}
+
+// 生成済みのクライアントが仕様と一致していること（make ts-client の実行漏れを検出する）
+func TestGenerate_UpToDate(t *testing.T) {
+	files, err := Generate(api.Spec)
+	require.NoError(t, err)
+
+	js, err := os.ReadFile("../../clients/typescript/index.js")
+	require.NoError(t, err)
+	dts, err := os.ReadFile("../../clients/typescript/index.d.ts")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(files.JS), string(js), "run `make ts-client` to regenerate")
+	assert.Equal(t, string(files.DTS), string(dts), "run `make ts-client` to regenerate")
+}
+
+// 生成したクライアントで全オペレーションを呼び出し、送信されたリクエストが仕様に適合することを検証する
+const harness = `
+import { createClient, ApiError } from "./index.js";
+
+const anonymous = createClient({ baseUrl: process.env.BASE_URL });
+await anonymous.register({ email: "user@example.com", password: "password123" });
+await anonymous.login({ email: "user@example.com", password: "password123" });
+await anonymous.getPublicPortfolio("token");
+const page = await anonymous.getPublicPortfolioPage("token");
+if (!(page instanceof Blob)) throw new Error("expected html blob");
+await anonymous.verifyCertificate({ code: "1-1700000000-00112233445566778899aabbccddeeff" });
+const unsubscribed = await anonymous.unsubscribeDigest({ token: "1-00112233445566778899aabbccddeeff" });
+if (!(unsubscribed instanceof Blob)) throw new Error("expected html blob");
+await anonymous.unsubscribeDigestOneClick({ token: "1-00112233445566778899aabbccddeeff" });
+
+const client = createClient({ baseUrl: process.env.BASE_URL, headers: { Authorization: "Bearer token" } });
+
+await client.health();
+await client.getCapabilities();
+await client.listItems({ category: "時計", purchase_currency: "USD", min_price: 99.5, sort: "purchase_price", order: "desc" });
+await client.listItems();
+await client.listItems({ org_id: 10 });
+await client.listItems({ tag: ["ヴィンテージ", "箱あり"] });
+await client.createItem({ name: "a", category: "時計", brand: "b", purchase_price: 1, purchase_date: "2023-01-01" });
+await client.createItem({ name: "a", category: "時計", brand: "b", purchase_price: 1, purchase_date: "2023-01-01" }, { "Idempotency-Key": "retry-1" });
+await client.searchItems({ q: "ロレックス" });
+const exported = await client.exportItems({ format: "xlsx", category: "時計" });
+if (!(exported instanceof Blob)) throw new Error("expected xlsx blob");
+await client.previewQuickAdd({ text: "ROLEX デイトナ 時計 1500000 2023-01-15" });
+await client.parseItem({ text: "去年の3月に80万円で買ったエルメスのバーキン" });
+await client.getCategorySummary({ as_of: "2023-12-31" });
+await client.getBrandSummary({ limit: 5 });
+await client.getTopItems({ n: 5, by: "current_value" });
+await client.getItem(1);
+await client.replaceItem(1, { name: "b", category: "時計", brand: "c", purchase_price: 1, purchase_date: "2024-01-01" }, { "If-Match": '"1"' });
+await client.updateItem(1, { name: "b" }, { "If-Match": '"1"' });
+await client.changeItemStatus(1, { status: "listed_for_sale" });
+await client.recordItemSale(1, { sold_price: 1800000, sold_date: "2024-06-01", fees: 90000 }, { "Idempotency-Key": "retry-4" });
+await client.getItemSale(1);
+await client.recordItemValuation(1, { value: 2100000, valued_on: "2024-06-01", notes: "買取店の査定" }, { "Idempotency-Key": "retry-5" });
+await client.listItemValuations(1);
+await client.bulkUpdateItems({ ids: [1, 2], brand: "HERMÈS" }, { "Idempotency-Key": "retry-2" });
+await client.bulkRecategorizeItems({ filter: { category: "その他", brand: "HERMÈS" }, category: "バッグ", dry_run: true });
+await client.getNamingSuggestions();
+await client.getValueChangeReport();
+await client.getPurchaseTrend({ group_by: "year" });
+await client.applyNaming({ changes: [{ name: "デイトナ", item_ids: [1, 2] }] }, { "Idempotency-Key": "retry-3" });
+await client.getItemHistory(1);
+await client.getItemPriceHistory(1, { interpolation: "linear" });
+await client.getRecentlyViewedItems();
+await client.getQuickStats();
+await client.getPortfolioSummary();
+await client.getDashboardStats();
+await client.updatePreferences({ preferred_currency: "USD" });
+await client.getPreferences();
+await client.getAPIUsage();
+await client.deleteItem(1);
+await client.refreshMarketPrices({ brand: "ROLEX" });
+await client.getJob(1);
+const events = await client.streamJobEvents(1);
+if (!(events instanceof Blob) || !(await events.text()).startsWith("event: finished")) throw new Error("expected event stream");
+await client.listAuditLogs({ from: "2024-01-01", to: "2024-01-31", action: "export" });
+const report = await client.runAdminReport("inactive_users", { days: 30, limit: 10 });
+if (!(report instanceof Blob)) throw new Error("expected csv blob");
+const eventLog = await client.exportEvents({ since: "2024-06-01" });
+await client.listColumnMigrations();
+await client.startColumnBackfill("items.purchase_date");
+if (!(eventLog instanceof Blob) || !(await eventLog.text()).startsWith("{")) throw new Error("expected ndjson blob");
+await client.startAccountingExport({ format: "yayoi", from: "2024-01-01", to: "2024-12-31", accounts: { purchase_payment: "普通預金" } });
+await client.importItems("name,category,brand,purchase_price,purchase_date\nデイトナ,時計,ROLEX,1500000,2023-01-15\n");
+const journal = await client.getJobResult(1);
+if (!(journal instanceof Blob)) throw new Error("expected csv blob");
+const form = new FormData();
+form.append("file", new Blob(["\x89PNG"], { type: "image/png" }), "photo.png");
+await client.uploadItemImage(1, form);
+await client.listItemImages(1);
+const image = await client.getItemImage(1, 2);
+if (!(image instanceof Blob)) throw new Error("expected image blob");
+const thumbnail = await client.getItemImageThumbnail(1, 2, 200);
+if (!(thumbnail instanceof Blob)) throw new Error("expected thumbnail blob");
+await client.deleteItemImage(1, 2);
+await client.createDraftSession({ name: "サブマリーナー", category: "時計" });
+await client.getDraftSession("0123456789abcdef0123456789abcdef");
+await client.updateDraftSession("0123456789abcdef0123456789abcdef", { brand: "ROLEX", notes: null });
+await client.uploadDraftImage("0123456789abcdef0123456789abcdef", form);
+const draftImage = await client.getDraftImage("0123456789abcdef0123456789abcdef", "0123456789abcdef");
+if (!(draftImage instanceof Blob)) throw new Error("expected draft image blob");
+await client.deleteDraftImage("0123456789abcdef0123456789abcdef", "0123456789abcdef");
+await client.commitDraftSession("0123456789abcdef0123456789abcdef");
+await client.deleteDraftSession("0123456789abcdef0123456789abcdef");
+await client.createItemComment(1, { body: "売るか相談したい @partner@example.com" });
+await client.listItemComments(1);
+await client.updateItemComment(1, 2, { body: "edited" });
+await client.deleteItemComment(1, 2);
+await client.createItemMemo(1, { body: "ベルトにひびが入り始めた" });
+await client.listItemMemos(1);
+await client.addItemTag(1, { name: "ヴィンテージ" });
+await client.removeItemTag(1, "ヴィンテージ");
+await client.listTags();
+await client.listCategories();
+await client.createCategory({ name: "時計ケース", name_en: "Watch Case" });
+await client.renameCategory(1, { name: "ウォッチケース", defaults: { brand: "不明" }, required_fields: ["serial_number"] });
+await client.deleteCategory(1);
+await client.lookupCatalog({ ref: "116520" });
+await client.listNotifications({ unread: true });
+await client.markNotificationRead(1);
+await client.updateDigestPreference({ frequency: "weekly" });
+await client.getDigestPreference();
+const digest = await client.previewDigest();
+if (!(digest instanceof Blob)) throw new Error("expected html blob");
+await client.putItemConsignment(1, { consignor_name: "佐藤", agreed_price: 500000, commission_rate: 15, deadline: "2024-06-30" });
+await client.getItemConsignment(1);
+await client.listConsignments({ status: "active", overdue: true });
+await client.getConsignmentReport();
+await client.putItemConsignment(1, { consignor_name: "佐藤", agreed_price: 500000, status: "sold", invoice: { buyer_name: "山田", lines: [{ description: "送料", quantity: 1, unit_price: 1000 }] } });
+await client.getBackup();
+await client.restoreBackup({ format_version: 1, created_at: "2024-06-01T00:00:00Z", tables: [{ name: "items", columns: ["id", "name", "created_at"], rows: [[1, "a", "2024-01-01T00:00:00Z"], [2, null, "2024-01-02T00:00:00Z"]] }] });
+await client.getBackupVerification();
+await client.listInvoices();
+await client.getInvoice(1);
+const invoice = await client.getInvoicePdf(1);
+if (!(invoice instanceof Blob)) throw new Error("expected pdf blob");
+await client.deleteItemConsignment(1);
+await client.createOrganization({ name: "山田家" });
+await client.listOrganizations();
+await client.addOrganizationMember(10, { email: "partner@example.com", role: "editor" });
+await client.listOrganizationMembers(10);
+await client.removeOrganizationMember(10, 3);
+await client.createPortfolio({ title: "コレクション", item_ids: [1, 2], html_enabled: true });
+await client.listPortfolios();
+await client.getPortfolio(1);
+await client.updatePortfolio(1, { show_purchase_date: true });
+await client.deletePortfolio(1);
+const certificate = await client.getItemCertificate(1);
+if (!(certificate instanceof Blob) || (await certificate.text()) !== "%PDF-1.4") throw new Error("expected pdf blob");
+await client.createAPIKey({ name: "ci" });
+await client.listAPIKeys();
+await client.deleteAPIKey(1);
+
+try {
+  await client.getItem(404);
+  throw new Error("expected ApiError");
+} catch (e) {
+  if (!(e instanceof ApiError) || e.status !== 404) throw e;
+  if (e.body.code !== "not_found" || e.message !== "item not found") throw new Error("unexpected problem: " + e.message);
+}
+`
+
+func TestGeneratedClient_Contract(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not installed")
+	}
+
+	files, err := Generate(api.Spec)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "index.js"), files.JS, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"type":"module"}`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "harness.js"), []byte(harness), 0o644))
+
+	doc, err := openapi3.NewLoader().LoadFromData(api.Spec)
+	require.NoError(t, err)
+	doc.Servers = nil
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	called := map[string]bool{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := router.FindRoute(r)
+		if !assert.NoError(t, err, "%s %s", r.Method, r.URL) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				// Bearer トークンが送信されていることだけを確認する
+				AuthenticationFunc: func(ctx context.Context, in *openapi3filter.AuthenticationInput) error {
+					if in.RequestValidationInput.Request.Header.Get("Authorization") == "" {
+						return errors.New("missing token")
+					}
+					return nil
+				},
+			},
+		}
+		if !assert.NoError(t, openapi3filter.ValidateRequest(context.Background(), input)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		called[route.Operation.OperationID] = true
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case pathParams["id"] == "404":
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"type":"urn:aicon-assignment:problem:not_found","title":"Not Found","status":404,"detail":"item not found","code":"not_found"}`))
+		case route.Operation.OperationID == "listItems" && r.URL.Query().Has("tag"):
+			assert.Equal(t, []string{"ヴィンテージ", "箱あり"}, r.URL.Query()["tag"])
+			w.Write([]byte(`[]`))
+		case route.Operation.OperationID == "health":
+			w.WriteHeader(http.StatusOK)
+		case route.Operation.OperationID == "getItemCertificate", route.Operation.OperationID == "getInvoicePdf":
+			assert.Equal(t, "application/pdf", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write([]byte("%PDF-1.4"))
+		case route.Operation.OperationID == "streamJobEvents":
+			assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Write([]byte("event: finished\ndata: {}\n\n"))
+		case route.Operation.OperationID == "exportEvents":
+			assert.Equal(t, "application/x-ndjson", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "application/x-ndjson")
+			w.Write([]byte(`{"id":1,"type":"item.deleted","item_id":1,"actor_id":1,"occurred_at":"2024-06-01T00:00:00Z","changes":[]}` + "\n"))
+		case route.Operation.OperationID == "importItems":
+			assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+			w.Write([]byte(`{"imported":1,"failed":0,"errors":[]}`))
+		case route.Operation.OperationID == "getJobResult", route.Operation.OperationID == "runAdminReport":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Write([]byte("a,b\n"))
+		case route.Operation.OperationID == "exportItems":
+			assert.Equal(t, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+			w.Write([]byte("PK\x03\x04"))
+		case route.Operation.OperationID == "getItemImage", route.Operation.OperationID == "getDraftImage":
+			assert.Equal(t, "image/*", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("\x89PNG"))
+		case route.Operation.OperationID == "getItemImageThumbnail":
+			assert.Equal(t, "image/jpeg", r.Header.Get("Accept"))
+			w.Header().Set("Content-Type", "image/jpeg")
+			w.Write([]byte("\xff\xd8\xff"))
+		case route.Operation.OperationID == "getPublicPortfolioPage", route.Operation.OperationID == "previewDigest",
+			route.Operation.OperationID == "unsubscribeDigest", route.Operation.OperationID == "unsubscribeDigestOneClick":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<!DOCTYPE html>"))
+		case route.Operation.OperationID == "deleteItem", route.Operation.OperationID == "deleteItemImage",
+			route.Operation.OperationID == "deletePortfolio", route.Operation.OperationID == "deleteItemComment",
+			route.Operation.OperationID == "removeItemTag", route.Operation.OperationID == "deleteCategory",
+			route.Operation.OperationID == "markNotificationRead", route.Operation.OperationID == "removeOrganizationMember",
+			route.Operation.OperationID == "deleteItemConsignment", route.Operation.OperationID == "deleteDraftSession",
+			route.Operation.OperationID == "deleteDraftImage":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	cmd := exec.Command(node, "harness.js")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "BASE_URL="+srv.URL)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		for _, op := range doc.Paths.Value(path).Operations() {
+			assert.True(t, called[op.OperationID], "operation %s was not exercised", op.OperationID)
+		}
+	}
+}
+
+// 非推奨にした操作・項目・クエリパラメーターに @deprecated を付ける
+func TestGenerate_Deprecated(t *testing.T) {
+	spec := `
+openapi: 3.0.3
+info:
+  title: test
+  version: "1"
+paths:
+  /old:
+    get:
+      operationId: getOld
+      summary: 古い一覧
+      deprecated: true
+      x-deprecation:
+        since: 2024-10-01
+        sunset: 2025-03-31
+        replacement: GET /new
+      parameters:
+        - name: sort
+          in: query
+          deprecated: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Old"
+components:
+  schemas:
+    Old:
+      type: object
+      properties:
+        brand:
+          type: string
+          deprecated: true
+          x-deprecation:
+            replacement: maker
+        maker:
+          type: string
+`
+	files, err := Generate([]byte(spec))
+	require.NoError(t, err)
+
+	dts := string(files.DTS)
+	assert.Contains(t, dts, "  /** @deprecated use maker instead */\n  brand?: string;\n  maker?: string;\n")
+	assert.Contains(t, dts, "  /** @deprecated */\n  sort?: string;\n")
+	assert.Contains(t, dts, "  /**\n   * 古い一覧\n   * @deprecated use GET /new instead; removed after 2025-03-31\n   */\n  getOld(")
+}
@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// AccountingFormat は仕訳を取り込む会計ソフトの形式
+type AccountingFormat string
+
+const (
+	AccountingFormatFreee      AccountingFormat = "freee"
+	AccountingFormatYayoi      AccountingFormat = "yayoi"
+	AccountingFormatQuickBooks AccountingFormat = "quickbooks"
+)
+
+// JournalRenderer は仕訳を会計ソフトが取り込めるファイルに変換する（形式ごとに実装する）
+type JournalRenderer interface {
+	Render(entries []JournalEntry) ([]byte, error)
+	ContentType() string
+}
+
+// JournalEntry は1件の仕訳（借方と貸方の金額は同じ）
+type JournalEntry struct {
+	Date time.Time
+	// Number は仕訳の元になった取引の番号（アイテムIDや請求書番号）
+	Number         string
+	DebitAccount   string
+	DebitTaxClass  string
+	CreditAccount  string
+	CreditTaxClass string
+	// Amount は税込みの金額、Tax はそのうちの消費税
+	Amount      int
+	Tax         int
+	Description string
+}
+
+// AccountMapping は仕訳に使う勘定科目（会計ソフトに登録済みの名前またはコード）と税区分
+type AccountMapping struct {
+	// Purchase はアイテムの購入の借方（例: 仕入高）
+	Purchase string `json:"purchase"`
+	// PurchasePayment はアイテムの購入の貸方（例: 現金）
+	PurchasePayment string `json:"purchase_payment"`
+	// PurchaseTaxClass はアイテムの購入の税区分（個人からの購入が多いため既定は対象外）
+	PurchaseTaxClass string `json:"purchase_tax_class"`
+	// Receivable は請求書を発行した販売の借方（例: 売掛金）
+	Receivable string `json:"receivable"`
+	// Sales は請求書を発行した販売の貸方（例: 売上高）
+	Sales string `json:"sales"`
+}
+
+// DefaultAccountMapping は指定がない項目に使う勘定科目と税区分
+var DefaultAccountMapping = AccountMapping{
+	Purchase:         "仕入高",
+	PurchasePayment:  "現金",
+	PurchaseTaxClass: "対象外",
+	Receivable:       "売掛金",
+	Sales:            "売上高",
+}
+
+// AccountingExportInput は仕訳のエクスポートの条件
+type AccountingExportInput struct {
+	Format AccountingFormat `json:"format"`
+	// From, To は取引日（購入日・請求書の発行日）の範囲（YYYY-MM-DD 形式、省略時は制限なし）
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Accounts は勘定科目の指定（省略した項目は DefaultAccountMapping）
+	Accounts *AccountMapping `json:"accounts,omitempty"`
+}
+
+// ExportUsecaseOption は ExportUsecase の設定を変更する
+type ExportUsecaseOption func(*exportUsecase)
+
+// WithAccountingExport は会計ソフト向けの仕訳のエクスポートを有効にする
+func WithAccountingExport(jobs JobUsecase, invoiceRepo InvoiceRepository, renderers map[AccountingFormat]JournalRenderer) ExportUsecaseOption {
+	return func(u *exportUsecase) {
+		u.jobs = jobs
+		u.invoiceRepo = invoiceRepo
+		u.journalRenderers = renderers
+	}
+}
+
+func (u *exportUsecase) StartAccountingExport(ctx context.Context, input AccountingExportInput) (*entity.Job, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.jobs == nil {
+		return nil, fmt.Errorf("%w: accounting export is not enabled", domainErrors.ErrInvalidInput)
+	}
+	renderer, ok := u.journalRenderers[input.Format]
+	if !ok {
+		return nil, fmt.Errorf("%w: format must be one of: freee, yayoi, quickbooks", domainErrors.ErrInvalidInput)
+	}
+	if err := validateJournalPeriod(input.From, input.To); err != nil {
+		return nil, err
+	}
+	accounts := mergeAccountMapping(input.Accounts)
+
+	// 集計はジョブで行い、結果のファイルは GET /jobs/{id}/result でダウンロードする
+	fileName := fmt.Sprintf("journal-%s-%s.csv", input.Format, u.now().Format("20060102-150405"))
+	return u.jobs.Submit(ctx, strconv.FormatInt(actor.ID, 10), entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+		entries, err := u.journalEntries(ctx, actor, input.From, input.To, accounts)
+		if err != nil {
+			return err
+		}
+		body, err := renderer.Render(entries)
+		if err != nil {
+			return fmt.Errorf("failed to render journal: %w", err)
+		}
+		job.Result = &entity.JobResult{
+			FileName:    fileName,
+			ContentType: renderer.ContentType(),
+			Body:        body,
+		}
+		return nil
+	})
+}
+
+// journalEntries は期間内のアイテムの購入と請求書を発行した販売を取引日の順の仕訳にする
+func (u *exportUsecase) journalEntries(ctx context.Context, actor *entity.User, from, to string, accounts AccountMapping) ([]JournalEntry, error) {
+	userID := itemScope(actor)
+	items, err := u.itemRepo.FindAll(ctx, entity.ItemFilter{
+		UserID:           userID,
+		PurchaseDateFrom: from,
+		PurchaseDateTo:   to,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	redactItems(actor, items...)
+
+	entries := []JournalEntry{}
+	for _, item := range items {
+		// 会計ソフトの仕訳は円建てのため、外貨建てのアイテムは含めない。
+		// 購入価格・購入日を非表示にしたアイテムも含めない
+		if !item.PurchasePrice.IsJPY() || item.IsRedacted(entity.ItemFieldPurchasePrice) {
+			continue
+		}
+		date, err := time.Parse("2006-01-02", item.PurchaseDate)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, JournalEntry{
+			Date:           date,
+			Number:         fmt.Sprintf("ITEM-%d", item.ID),
+			DebitAccount:   accounts.Purchase,
+			DebitTaxClass:  accounts.PurchaseTaxClass,
+			CreditAccount:  accounts.PurchasePayment,
+			CreditTaxClass: "対象外",
+			Amount:         item.PurchasePrice.Amount,
+			Description:    strings.TrimSpace(item.Brand + " " + item.Name),
+		})
+	}
+
+	// 請求書は発行したユーザーのもの（管理者はすべて）
+	if u.invoiceRepo != nil {
+		invoices, err := u.invoiceRepo.FindAll(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve invoices: %w", err)
+		}
+		for _, invoice := range invoices {
+			date := invoice.IssuedAt.Format("2006-01-02")
+			if (from != "" && date < from) || (to != "" && date > to) {
+				continue
+			}
+			entries = append(entries, JournalEntry{
+				Date:           invoice.IssuedAt,
+				Number:         invoice.Number,
+				DebitAccount:   accounts.Receivable,
+				DebitTaxClass:  "対象外",
+				CreditAccount:  accounts.Sales,
+				CreditTaxClass: salesTaxClass(invoice),
+				Amount:         invoice.Total,
+				Tax:            invoice.Tax,
+				Description:    invoice.BuyerName,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.Format("2006-01-02") < entries[j].Date.Format("2006-01-02")
+	})
+	return entries, nil
+}
+
+// salesTaxClass は請求書の消費税率から売上の税区分を決める
+func salesTaxClass(invoice *entity.Invoice) string {
+	if invoice.TaxRate == 0 {
+		return "対象外"
+	}
+	return "課税売上" + strconv.FormatFloat(invoice.TaxRate, 'f', -1, 64) + "%"
+}
+
+// validateJournalPeriod は取引日の範囲を検証する
+func validateJournalPeriod(from, to string) error {
+	for _, date := range [][2]string{{"from", from}, {"to", to}} {
+		if date[1] == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date[1]); err != nil {
+			return fmt.Errorf("%w: %s must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput, date[0])
+		}
+	}
+	// YYYY-MM-DD 形式同士は文字列比較で前後関係を判定できる
+	if from != "" && to != "" && from > to {
+		return fmt.Errorf("%w: from must be on or before to", domainErrors.ErrInvalidInput)
+	}
+	return nil
+}
+
+func mergeAccountMapping(accounts *AccountMapping) AccountMapping {
+	merged := DefaultAccountMapping
+	if accounts == nil {
+		return merged
+	}
+	for _, field := range []struct {
+		value  string
+		target *string
+	}{
+		{accounts.Purchase, &merged.Purchase},
+		{accounts.PurchasePayment, &merged.PurchasePayment},
+		{accounts.PurchaseTaxClass, &merged.PurchaseTaxClass},
+		{accounts.Receivable, &merged.Receivable},
+		{accounts.Sales, &merged.Sales},
+	} {
+		if v := strings.TrimSpace(field.value); v != "" {
+			*field.target = v
+		}
+	}
+	return merged
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockJournalRenderer struct {
+	mock.Mock
+}
+
+func (m *MockJournalRenderer) Render(entries []JournalEntry) ([]byte, error) {
+	args := m.Called(entries)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockJournalRenderer) ContentType() string {
+	return "text/csv"
+}
+
+func newAccountingExportTestUsecase() (*exportUsecase, *MockItemRepository, *MockInvoiceRepository, *MockJournalRenderer, JobUsecase) {
+	itemRepo := new(MockItemRepository)
+	invoiceRepo := new(MockInvoiceRepository)
+	renderer := new(MockJournalRenderer)
+	jobs := NewJobUsecase()
+	u := NewExportUsecase(itemRepo, nil, WithAccountingExport(jobs, invoiceRepo, map[AccountingFormat]JournalRenderer{
+		AccountingFormatFreee: renderer,
+	})).(*exportUsecase)
+	u.now = func() time.Time { return time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC) }
+	return u, itemRepo, invoiceRepo, renderer, jobs
+}
+
+func TestExportUsecase_StartAccountingExport(t *testing.T) {
+	t.Run("正常系: 購入と販売を取引日の順の仕訳にしてジョブの結果にする", func(t *testing.T) {
+		usecase, itemRepo, invoiceRepo, renderer, jobs := newAccountingExportTestUsecase()
+		itemRepo.On("FindAll", mock.Anything, entity.ItemFilter{
+			UserID: testActor.ID, PurchaseDateFrom: "2024-01-01", PurchaseDateTo: "2024-12-31",
+		}).Return([]*entity.Item{
+			{ID: 1, Name: "デイトナ", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2024-03-01"},
+		}, nil)
+		invoiceRepo.On("FindAll", mock.Anything, testActor.ID).Return([]*entity.Invoice{
+			{Number: "INV-2024-000001", BuyerName: "山田", TaxRate: 10, Tax: 50000, Total: 550000, IssuedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			{Number: "INV-2023-000009", BuyerName: "佐藤", TaxRate: 10, Total: 110000, IssuedAt: time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)},
+		}, nil)
+		renderer.On("Render", []JournalEntry{
+			{
+				Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Number: "INV-2024-000001",
+				DebitAccount: "売掛金", DebitTaxClass: "対象外", CreditAccount: "Sales", CreditTaxClass: "課税売上10%",
+				Amount: 550000, Tax: 50000, Description: "山田",
+			},
+			{
+				Date: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), Number: "ITEM-1",
+				DebitAccount: "仕入高", DebitTaxClass: "対象外", CreditAccount: "普通預金", CreditTaxClass: "対象外",
+				Amount: 1500000, Description: "ROLEX デイトナ",
+			},
+		}).Return([]byte("csv"), nil)
+
+		job, err := usecase.StartAccountingExport(actorContext(), AccountingExportInput{
+			Format: AccountingFormatFreee, From: "2024-01-01", To: "2024-12-31",
+			Accounts: &AccountMapping{PurchasePayment: "普通預金", Sales: "Sales"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobKindExport, job.Kind)
+
+		userID := strconv.FormatInt(testActor.ID, 10)
+		finished := waitForJob(t, jobs, userID, job.ID)
+		require.Equal(t, entity.JobStatusSucceeded, finished.Status, finished.Error)
+		result, err := jobs.GetResult(context.Background(), userID, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "journal-freee-20240801-090000.csv", result.FileName)
+		assert.Equal(t, []byte("csv"), result.Body)
+	})
+
+	t.Run("異常系: 対応していない形式", func(t *testing.T) {
+		usecase, _, _, _, _ := newAccountingExportTestUsecase()
+
+		_, err := usecase.StartAccountingExport(actorContext(), AccountingExportInput{Format: "mf"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 期間の前後が逆", func(t *testing.T) {
+		usecase, _, _, _, _ := newAccountingExportTestUsecase()
+
+		_, err := usecase.StartAccountingExport(actorContext(), AccountingExportInput{
+			Format: AccountingFormatFreee, From: "2024-12-31", To: "2024-01-01",
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 仕訳のエクスポートが無効", func(t *testing.T) {
+		usecase, _, _ := newExportTestUsecase()
+
+		_, err := usecase.StartAccountingExport(actorContext(), AccountingExportInput{Format: AccountingFormatFreee})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type actorKey struct{}
+
+// WithActor は操作を行うユーザー（認証済みユーザー）をコンテキストに設定する
+func WithActor(ctx context.Context, user *entity.User) context.Context {
+	return context.WithValue(ctx, actorKey{}, user)
+}
+
+// ActorFromContext はコンテキストから操作を行うユーザーを取得する
+func ActorFromContext(ctx context.Context) (*entity.User, bool) {
+	user, ok := ctx.Value(actorKey{}).(*entity.User)
+	return user, ok && user != nil
+}
+
+// requireActor は操作を行うユーザーを取得し、未設定の場合は ErrUnauthorized を返す
+func requireActor(ctx context.Context) (*entity.User, error) {
+	user, ok := ActorFromContext(ctx)
+	if !ok {
+		return nil, domainErrors.ErrUnauthorized
+	}
+	return user, nil
+}
+
+// requireWriter は操作を行うユーザーを取得し、アイテムを変更できない場合は ErrForbidden を返す
+func requireWriter(ctx context.Context) (*entity.User, error) {
+	user, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !user.CanWrite() {
+		return nil, domainErrors.ErrForbidden
+	}
+	return user, nil
+}
+
+// requireAdmin は操作を行うユーザーが管理者でない場合に ErrForbidden を返す
+func requireAdmin(ctx context.Context) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+	if !actor.IsAdmin() {
+		return domainErrors.ErrForbidden
+	}
+	return nil
+}
+
+// itemScope はアイテムの一覧・検索・集計を絞り込むユーザーIDを返す（管理者は 0 で全ユーザー）。
+// 個人のアイテムと所属する組織のアイテムが対象になる
+func itemScope(user *entity.User) int64 {
+	if user.IsAdmin() {
+		return 0
+	}
+	return user.ID
+}
+
+// canReadItem はユーザーがアイテムを参照できるかを判定する。
+// 個人のアイテムは所有者、組織のアイテムはその組織のメンバーが参照できる（管理者はすべて参照できる）
+func canReadItem(user *entity.User, item *entity.Item) bool {
+	if user.IsAdmin() {
+		return true
+	}
+	if item.OrgID == 0 {
+		return item.UserID == user.ID
+	}
+	_, ok := user.OrgRole(item.OrgID)
+	return ok
+}
+
+// canWriteItem はユーザーがアイテムを変更できるかを判定する（組織のアイテムは owner と editor のみ）
+func canWriteItem(user *entity.User, item *entity.Item) bool {
+	if !user.CanWrite() {
+		return false
+	}
+	if user.IsAdmin() || item.OrgID == 0 {
+		return canReadItem(user, item)
+	}
+	role, ok := user.OrgRole(item.OrgID)
+	return ok && role.CanWrite()
+}
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 管理者向けの定型レポートの名前
+const (
+	// AdminReportTopBrands は登録数の多いブランド
+	AdminReportTopBrands = "top_brands"
+	// AdminReportInactiveUsers は一定期間操作していないユーザー
+	AdminReportInactiveUsers = "inactive_users"
+	// AdminReportLargestAttachments はサイズの大きい添付画像
+	AdminReportLargestAttachments = "largest_attachments"
+)
+
+type AdminReportUsecase interface {
+	// Run は定型レポートを実行して CSV で返す（管理者のみ）。
+	// params はレポートのパラメーター（クエリパラメーター）で、定義されていないものは検証エラーにする
+	Run(ctx context.Context, name string, params map[string]string) (*ExportFile, error)
+}
+
+// adminReportParam はレポートの整数のパラメーター
+type adminReportParam struct {
+	name         string
+	defaultValue int
+	min, max     int
+}
+
+// adminReport はコードで定義した定型レポート（任意の SQL は実行しない）
+type adminReport struct {
+	params  []adminReportParam
+	columns []string
+	rows    func(ctx context.Context, repo AdminReportRepository, args map[string]int, now time.Time) ([][]string, error)
+}
+
+var limitParam = adminReportParam{name: "limit", defaultValue: 50, min: 1, max: 1000}
+
+var adminReports = map[string]adminReport{
+	AdminReportTopBrands: {
+		params:  []adminReportParam{limitParam},
+		columns: []string{"brand", "item_count", "owner_count"},
+		rows: func(ctx context.Context, repo AdminReportRepository, args map[string]int, _ time.Time) ([][]string, error) {
+			brands, err := repo.TopBrands(ctx, args["limit"])
+			if err != nil {
+				return nil, err
+			}
+			rows := make([][]string, 0, len(brands))
+			for _, b := range brands {
+				rows = append(rows, []string{b.Brand, strconv.FormatInt(b.ItemCount, 10), strconv.FormatInt(b.OwnerCount, 10)})
+			}
+			return rows, nil
+		},
+	},
+	AdminReportInactiveUsers: {
+		params:  []adminReportParam{{name: "days", defaultValue: 90, min: 1, max: 3650}, limitParam},
+		columns: []string{"user_id", "email", "role", "created_at", "last_activity_at", "item_count"},
+		rows: func(ctx context.Context, repo AdminReportRepository, args map[string]int, now time.Time) ([][]string, error) {
+			users, err := repo.InactiveUsers(ctx, now.AddDate(0, 0, -args["days"]), args["limit"])
+			if err != nil {
+				return nil, err
+			}
+			rows := make([][]string, 0, len(users))
+			for _, u := range users {
+				lastActivityAt := ""
+				if u.LastActivityAt != nil {
+					lastActivityAt = u.LastActivityAt.Format(time.RFC3339)
+				}
+				rows = append(rows, []string{
+					strconv.FormatInt(u.UserID, 10), u.Email, string(u.Role), u.CreatedAt.Format(time.RFC3339), lastActivityAt, strconv.FormatInt(u.ItemCount, 10),
+				})
+			}
+			return rows, nil
+		},
+	},
+	AdminReportLargestAttachments: {
+		params:  []adminReportParam{limitParam},
+		columns: []string{"image_id", "item_id", "file_name", "content_type", "size", "created_at"},
+		rows: func(ctx context.Context, repo AdminReportRepository, args map[string]int, _ time.Time) ([][]string, error) {
+			attachments, err := repo.LargestAttachments(ctx, args["limit"])
+			if err != nil {
+				return nil, err
+			}
+			rows := make([][]string, 0, len(attachments))
+			for _, a := range attachments {
+				rows = append(rows, []string{
+					strconv.FormatInt(a.ImageID, 10), strconv.FormatInt(a.ItemID, 10), a.FileName, a.ContentType, strconv.FormatInt(a.Size, 10), a.CreatedAt.Format(time.RFC3339),
+				})
+			}
+			return rows, nil
+		},
+	},
+}
+
+type adminReportUsecase struct {
+	reportRepo AdminReportRepository
+	now        func() time.Time
+}
+
+func NewAdminReportUsecase(reportRepo AdminReportRepository) AdminReportUsecase {
+	return &adminReportUsecase{
+		reportRepo: reportRepo,
+		now:        time.Now,
+	}
+}
+
+func (u *adminReportUsecase) Run(ctx context.Context, name string, params map[string]string) (*ExportFile, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.IsAdmin() {
+		return nil, domainErrors.ErrForbidden
+	}
+
+	report, ok := adminReports[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrReportNotFound, name)
+	}
+	args, err := report.parseParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now()
+	rows, err := report.rows(ctx, u.reportRepo, args, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run report %s: %w", name, err)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(report.columns); err != nil {
+		return nil, err
+	}
+	if err := w.WriteAll(rows); err != nil {
+		return nil, err
+	}
+
+	return &ExportFile{
+		FileName:    fmt.Sprintf("%s-%s.csv", name, now.Format("20060102-150405")),
+		ContentType: "text/csv; charset=utf-8",
+		Body:        buf.Bytes(),
+	}, nil
+}
+
+// parseParams はパラメーターを検証し、省略されたものには既定値を設定する
+func (r adminReport) parseParams(params map[string]string) (map[string]int, error) {
+	var errs domainErrors.ValidationErrors
+	args := make(map[string]int, len(r.params))
+	defined := make(map[string]bool, len(r.params))
+	for _, p := range r.params {
+		defined[p.name] = true
+		value, ok := params[p.name]
+		if !ok || value == "" {
+			args[p.name] = p.defaultValue
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		switch {
+		case err != nil:
+			errs.Add(p.name, domainErrors.CodeInvalidType, p.name+" must be an integer")
+		case n < p.min || n > p.max:
+			errs.Add(p.name, domainErrors.CodeOutOfRange, fmt.Sprintf("%s must be between %d and %d", p.name, p.min, p.max))
+		default:
+			args[p.name] = n
+		}
+	}
+
+	// 定義されていないパラメーターは無視せずに誤りとして知らせる
+	names := make([]string, 0, len(params))
+	for name := range params {
+		if !defined[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errs.Add(name, domainErrors.CodeNotAllowed, name+" is not a parameter of this report")
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	return args, nil
+}
@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockAdminReportRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminReportRepository) TopBrands(ctx context.Context, limit int) ([]*entity.BrandRanking, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.BrandRanking), args.Error(1)
+}
+
+func (m *MockAdminReportRepository) InactiveUsers(ctx context.Context, since time.Time, limit int) ([]*entity.InactiveUser, error) {
+	args := m.Called(ctx, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.InactiveUser), args.Error(1)
+}
+
+func (m *MockAdminReportRepository) LargestAttachments(ctx context.Context, limit int) ([]*entity.LargeAttachment, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.LargeAttachment), args.Error(1)
+}
+
+func TestAdminReportUsecase_Run(t *testing.T) {
+	admin := &entity.User{ID: 1, Email: "admin@example.com", Role: entity.RoleAdmin}
+	editor := &entity.User{ID: 2, Email: "editor@example.com", Role: entity.RoleEditor}
+	now := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	newUsecase := func(repo AdminReportRepository) AdminReportUsecase {
+		u := NewAdminReportUsecase(repo)
+		u.(*adminReportUsecase).now = func() time.Time { return now }
+		return u
+	}
+
+	t.Run("top_brands を既定の件数で CSV にする", func(t *testing.T) {
+		repo := new(MockAdminReportRepository)
+		repo.On("TopBrands", mock.Anything, 50).Return([]*entity.BrandRanking{
+			{Brand: "ROLEX", ItemCount: 12, OwnerCount: 3},
+			{Brand: "Louis Vuitton, Paris", ItemCount: 5, OwnerCount: 2},
+		}, nil)
+
+		file, err := newUsecase(repo).Run(WithActor(context.Background(), admin), AdminReportTopBrands, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "top_brands-20240701-100000.csv", file.FileName)
+		assert.Equal(t, "text/csv; charset=utf-8", file.ContentType)
+		assert.Equal(t, "brand,item_count,owner_count\nROLEX,12,3\n\"Louis Vuitton, Paris\",5,2\n", string(file.Body))
+	})
+
+	t.Run("inactive_users は days 日前より後に操作していないユーザー", func(t *testing.T) {
+		repo := new(MockAdminReportRepository)
+		lastActivityAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+		createdAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		repo.On("InactiveUsers", mock.Anything, now.AddDate(0, 0, -30), 10).Return([]*entity.InactiveUser{
+			{UserID: 3, Email: "a@example.com", Role: entity.RoleViewer, CreatedAt: createdAt, ItemCount: 0},
+			{UserID: 4, Email: "b@example.com", Role: entity.RoleEditor, CreatedAt: createdAt, LastActivityAt: &lastActivityAt, ItemCount: 7},
+		}, nil)
+
+		file, err := newUsecase(repo).Run(WithActor(context.Background(), admin), AdminReportInactiveUsers, map[string]string{"days": "30", "limit": "10"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "user_id,email,role,created_at,last_activity_at,item_count\n"+
+			"3,a@example.com,viewer,2023-01-01T00:00:00Z,,0\n"+
+			"4,b@example.com,editor,2023-01-01T00:00:00Z,2024-03-01T09:00:00Z,7\n", string(file.Body))
+	})
+
+	t.Run("largest_attachments", func(t *testing.T) {
+		repo := new(MockAdminReportRepository)
+		repo.On("LargestAttachments", mock.Anything, 1).Return([]*entity.LargeAttachment{
+			{ImageID: 9, ItemID: 1, FileName: "photo.png", ContentType: "image/png", Size: 5242880, CreatedAt: now},
+		}, nil)
+
+		file, err := newUsecase(repo).Run(WithActor(context.Background(), admin), AdminReportLargestAttachments, map[string]string{"limit": "1"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "image_id,item_id,file_name,content_type,size,created_at\n9,1,photo.png,image/png,5242880,2024-07-01T10:00:00Z\n", string(file.Body))
+	})
+
+	t.Run("パラメーターの誤り", func(t *testing.T) {
+		repo := new(MockAdminReportRepository)
+
+		_, err := newUsecase(repo).Run(WithActor(context.Background(), admin), AdminReportTopBrands, map[string]string{
+			"limit": "1001",
+			"days":  "30",
+			"sql":   "SELECT * FROM users",
+		})
+
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.ValidationErrors{
+			{Field: "limit", Code: domainErrors.CodeOutOfRange, Message: "limit must be between 1 and 1000"},
+			{Field: "days", Code: domainErrors.CodeNotAllowed, Message: "days is not a parameter of this report"},
+			{Field: "sql", Code: domainErrors.CodeNotAllowed, Message: "sql is not a parameter of this report"},
+		}, errs)
+
+		_, err = newUsecase(repo).Run(WithActor(context.Background(), admin), AdminReportInactiveUsers, map[string]string{"days": "ninety"})
+		errs, ok = domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.CodeInvalidType, errs[0].Code)
+		repo.AssertNotCalled(t, "TopBrands", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "InactiveUsers", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("定義されていないレポート", func(t *testing.T) {
+		_, err := newUsecase(new(MockAdminReportRepository)).Run(WithActor(context.Background(), admin), "all_passwords", nil)
+		assert.ErrorIs(t, err, domainErrors.ErrReportNotFound)
+	})
+
+	t.Run("管理者以外は実行できない", func(t *testing.T) {
+		_, err := newUsecase(new(MockAdminReportRepository)).Run(WithActor(context.Background(), editor), AdminReportTopBrands, nil)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+
+		_, err = newUsecase(new(MockAdminReportRepository)).Run(context.Background(), AdminReportTopBrands, nil)
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+
+	t.Run("データベースエラー", func(t *testing.T) {
+		repo := new(MockAdminReportRepository)
+		repo.On("TopBrands", mock.Anything, 50).Return(nil, errors.New("connection refused"))
+
+		_, err := newUsecase(repo).Run(WithActor(context.Background(), admin), AdminReportTopBrands, nil)
+		assert.ErrorContains(t, err, "failed to run report top_brands")
+	})
+}
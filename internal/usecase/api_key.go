@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// APIキーの接頭辞（キーの種類を見分けやすくする）
+	apiKeyPrefix = "aic_"
+	// APIキーのランダム部分のバイト数
+	apiKeyRandomBytes = 32
+	// 識別用に保存するキーの先頭の文字数（接頭辞を含む）
+	apiKeyDisplayLength = 12
+)
+
+type APIKeyUsecase interface {
+	// Issue は操作を行うユーザーのAPIキーを発行する。キーそのものはこのときだけ返す
+	Issue(ctx context.Context, input IssueAPIKeyInput) (*IssuedAPIKey, error)
+	List(ctx context.Context) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, id int64) error
+	// Authenticate はAPIキーを検証し、発行したユーザーを返す
+	Authenticate(ctx context.Context, key string) (*entity.User, error)
+}
+
+type IssueAPIKeyInput struct {
+	Name string `json:"name"`
+}
+
+// IssuedAPIKey は発行したAPIキー（Key は再表示できない）
+type IssuedAPIKey struct {
+	Key    string         `json:"key"`
+	APIKey *entity.APIKey `json:"api_key"`
+}
+
+type apiKeyUsecase struct {
+	apiKeyRepo APIKeyRepository
+	userRepo   UserRepository
+	now        func() time.Time
+}
+
+func NewAPIKeyUsecase(apiKeyRepo APIKeyRepository, userRepo UserRepository) APIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		now:        time.Now,
+	}
+}
+
+func (u *apiKeyUsecase) Issue(ctx context.Context, input IssueAPIKeyInput) (*IssuedAPIKey, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	apiKey, err := entity.NewAPIKey(actor.ID, input.Name, key[:apiKeyDisplayLength], hashAPIKey(key))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.apiKeyRepo.Create(ctx, apiKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return &IssuedAPIKey{Key: key, APIKey: created}, nil
+}
+
+func (u *apiKeyUsecase) List(ctx context.Context) ([]*entity.APIKey, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := u.apiKeyRepo.FindByUserID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (u *apiKeyUsecase) Revoke(ctx context.Context, id int64) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.apiKeyRepo.Delete(ctx, actor.ID, id); err != nil {
+		if errors.Is(err, domainErrors.ErrAPIKeyNotFound) {
+			return domainErrors.ErrAPIKeyNotFound
+		}
+		return fmt.Errorf("failed to delete api key: %w", err)
+	}
+
+	return nil
+}
+
+func (u *apiKeyUsecase) Authenticate(ctx context.Context, key string) (*entity.User, error) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return nil, domainErrors.ErrUnauthorized
+	}
+
+	apiKey, err := u.apiKeyRepo.FindByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrAPIKeyNotFound) {
+			return nil, domainErrors.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve api key: %w", err)
+	}
+
+	user, err := u.userRepo.FindByID(ctx, apiKey.UserID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			return nil, domainErrors.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	// 最終利用日時の記録は認証の成否に影響させない
+	_ = u.apiKeyRepo.TouchLastUsed(ctx, apiKey.ID, u.now())
+
+	return user, nil
+}
+
+// generateAPIKey は接頭辞付きのランダムなAPIキーを生成する
+func generateAPIKey() (string, error) {
+	b := make([]byte, apiKeyRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return apiKeyPrefix + hex.EncodeToString(b), nil
+}
+
+// hashAPIKey は保存・照合用のハッシュ値を返す（キーは十分な長さの乱数のため bcrypt は使わない）
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
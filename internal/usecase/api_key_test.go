@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.APIKey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) Delete(ctx context.Context, userID, id int64) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error {
+	args := m.Called(ctx, id, usedAt)
+	return args.Error(0)
+}
+
+func TestAPIKeyUsecase_Issue(t *testing.T) {
+	t.Run("正常系: ハッシュ値のみを保存し、キーを一度だけ返す", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		var saved *entity.APIKey
+		keyRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.APIKey")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*entity.APIKey) }).
+			Return(&entity.APIKey{ID: 1, UserID: testActor.ID, Name: "ci"}, nil)
+		usecase := NewAPIKeyUsecase(keyRepo, new(MockUserRepository))
+
+		issued, err := usecase.Issue(actorContext(), IssueAPIKeyInput{Name: " ci "})
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(issued.Key, "aic_"))
+		assert.Equal(t, int64(1), issued.APIKey.ID)
+		require.NotNil(t, saved)
+		assert.Equal(t, testActor.ID, saved.UserID)
+		assert.Equal(t, "ci", saved.Name)
+		assert.Equal(t, hashAPIKey(issued.Key), saved.KeyHash)
+		assert.NotContains(t, saved.KeyHash, issued.Key)
+		assert.True(t, strings.HasPrefix(issued.Key, saved.Prefix))
+	})
+
+	t.Run("異常系: 名前が空", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		usecase := NewAPIKeyUsecase(keyRepo, new(MockUserRepository))
+
+		_, err := usecase.Issue(actorContext(), IssueAPIKeyInput{Name: " "})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		keyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 操作者が未設定", func(t *testing.T) {
+		usecase := NewAPIKeyUsecase(new(MockAPIKeyRepository), new(MockUserRepository))
+
+		_, err := usecase.Issue(context.Background(), IssueAPIKeyInput{Name: "ci"})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
+
+func TestAPIKeyUsecase_Authenticate(t *testing.T) {
+	const key = "aic_0123456789abcdef"
+
+	t.Run("正常系: キーを発行したユーザーを返し、最終利用日時を記録する", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("FindByHash", mock.Anything, hashAPIKey(key)).Return(&entity.APIKey{ID: 5, UserID: 1}, nil)
+		keyRepo.On("TouchLastUsed", mock.Anything, int64(5), mock.AnythingOfType("time.Time")).Return(nil)
+		userRepo := new(MockUserRepository)
+		userRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.User{ID: 1}, nil)
+		usecase := NewAPIKeyUsecase(keyRepo, userRepo)
+
+		user, err := usecase.Authenticate(context.Background(), key)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), user.ID)
+		keyRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 存在しないキー", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("FindByHash", mock.Anything, hashAPIKey(key)).Return(nil, domainErrors.ErrAPIKeyNotFound)
+		usecase := NewAPIKeyUsecase(keyRepo, new(MockUserRepository))
+
+		_, err := usecase.Authenticate(context.Background(), key)
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+
+	t.Run("異常系: 接頭辞のないキーは照合しない", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		usecase := NewAPIKeyUsecase(keyRepo, new(MockUserRepository))
+
+		_, err := usecase.Authenticate(context.Background(), "not-a-key")
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+		keyRepo.AssertNotCalled(t, "FindByHash", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAPIKeyUsecase_Revoke(t *testing.T) {
+	t.Run("正常系: 自分のキーを削除", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("Delete", mock.Anything, testActor.ID, int64(3)).Return(nil)
+		usecase := NewAPIKeyUsecase(keyRepo, new(MockUserRepository))
+
+		assert.NoError(t, usecase.Revoke(actorContext(), 3))
+		keyRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 存在しないキー", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("Delete", mock.Anything, testActor.ID, int64(3)).Return(domainErrors.ErrAPIKeyNotFound)
+		usecase := NewAPIKeyUsecase(keyRepo, new(MockUserRepository))
+
+		assert.ErrorIs(t, usecase.Revoke(actorContext(), 3), domainErrors.ErrAPIKeyNotFound)
+	})
+}
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// 利用状況を返す期間の日数（今日を含む）
+	apiUsageDays = 30
+	// 保存待ちの件数の上限（キーと日の組の数。存在しないキーを大量に送られてもメモリを使い切らないようにする）
+	apiUsagePendingLimit = 10000
+	// 1回の INSERT で保存する件数の最大数
+	apiUsageBatchSize = 500
+)
+
+type APIUsageUsecase interface {
+	// Record は X-API-Key を送ったリクエストのレスポンスを件数に加える（保存は Run が非同期で行うため、リクエストを待たせない）
+	Record(key string, status int, rateLimited bool)
+	// Run は一定間隔で件数を保存する。ctx が終了すると残りを保存してから戻る
+	Run(ctx context.Context)
+	// Summary は操作者のAPIキーごとの直近30日の利用状況を返す（保存前の件数を含む）
+	Summary(ctx context.Context) (*APIUsageSummary, error)
+}
+
+// APIUsageSummary は操作者のAPIキーの利用状況（From, To は YYYY-MM-DD 形式の UTC の日付で、To を含む）
+type APIUsageSummary struct {
+	From string                `json:"from"`
+	To   string                `json:"to"`
+	Keys []*APIKeyUsageSummary `json:"keys"`
+}
+
+// APIKeyUsageSummary は1つのAPIキーの期間の合計と、リクエストがあった日ごとの件数（古い順）
+type APIKeyUsageSummary struct {
+	APIKey       *entity.APIKey       `json:"api_key"`
+	Requests     int                  `json:"requests"`
+	ClientErrors int                  `json:"client_errors"`
+	ServerErrors int                  `json:"server_errors"`
+	RateLimited  int                  `json:"rate_limited"`
+	ErrorRate    float64              `json:"error_rate"`
+	Days         []entity.APIKeyUsage `json:"days"`
+}
+
+// apiUsageKey は保存待ちの件数のキー（キーそのものは保持せずハッシュ値で集計する）
+type apiUsageKey struct {
+	keyHash string
+	date    string
+}
+
+type apiUsageUsecase struct {
+	usageRepo     APIKeyUsageRepository
+	apiKeyRepo    APIKeyRepository
+	flushInterval time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	pending map[apiUsageKey]*entity.APIKeyUsage
+	dropped int
+}
+
+func NewAPIUsageUsecase(usageRepo APIKeyUsageRepository, apiKeyRepo APIKeyRepository, flushInterval time.Duration) APIUsageUsecase {
+	return &apiUsageUsecase{
+		usageRepo:     usageRepo,
+		apiKeyRepo:    apiKeyRepo,
+		flushInterval: flushInterval,
+		now:           time.Now,
+		pending:       map[apiUsageKey]*entity.APIKeyUsage{},
+	}
+}
+
+func (u *apiUsageUsecase) Record(key string, status int, rateLimited bool) {
+	if !strings.HasPrefix(key, apiKeyPrefix) {
+		return
+	}
+	k := apiUsageKey{keyHash: hashAPIKey(key), date: u.now().UTC().Format("2006-01-02")}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	usage, ok := u.pending[k]
+	if !ok {
+		if len(u.pending) >= apiUsagePendingLimit {
+			u.dropped++
+			return
+		}
+		usage = &entity.APIKeyUsage{Date: k.date}
+		u.pending[k] = usage
+	}
+	usage.Requests++
+	switch {
+	case status >= http.StatusInternalServerError:
+		usage.ServerErrors++
+	case status >= http.StatusBadRequest:
+		usage.ClientErrors++
+	}
+	if rateLimited {
+		usage.RateLimited++
+	}
+}
+
+func (u *apiUsageUsecase) Run(ctx context.Context) {
+	// 終了時に残りを保存できるよう、保存はキャンセルを引き継がないコンテキストで行う
+	saveCtx := context.WithoutCancel(ctx)
+	ticker := time.NewTicker(u.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			u.flush(saveCtx)
+			return
+		case <-ticker.C:
+			u.flush(saveCtx)
+		}
+	}
+}
+
+// flush は保存待ちの件数をAPIキーの ID に対応付けて保存する（存在しないキーの件数は破棄する）
+func (u *apiUsageUsecase) flush(ctx context.Context) {
+	u.mu.Lock()
+	pending, dropped := u.pending, u.dropped
+	u.pending, u.dropped = map[apiUsageKey]*entity.APIKeyUsage{}, 0
+	u.mu.Unlock()
+
+	if dropped > 0 {
+		log.Printf("⚠️  api usage buffer is full, dropped %d requests", dropped)
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	ids := map[string]int64{}
+	batch := make([]*entity.APIKeyUsage, 0, len(pending))
+	for k, usage := range pending {
+		id, ok := ids[k.keyHash]
+		if !ok {
+			apiKey, err := u.apiKeyRepo.FindByHash(ctx, k.keyHash)
+			switch {
+			case err == nil:
+				id = apiKey.ID
+			case !errors.Is(err, domainErrors.ErrAPIKeyNotFound):
+				log.Printf("⚠️  failed to resolve api key for usage: %v", err)
+			}
+			ids[k.keyHash] = id
+		}
+		if id == 0 {
+			continue
+		}
+		usage.APIKeyID = id
+		batch = append(batch, usage)
+	}
+
+	for start := 0; start < len(batch); start += apiUsageBatchSize {
+		end := min(start+apiUsageBatchSize, len(batch))
+		if err := u.usageRepo.Add(ctx, batch[start:end]); err != nil {
+			log.Printf("⚠️  failed to save usage of %d api keys: %v", end-start, err)
+		}
+	}
+}
+
+func (u *apiUsageUsecase) Summary(ctx context.Context) (*APIUsageSummary, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	today := u.now().UTC()
+	from := today.AddDate(0, 0, -(apiUsageDays - 1)).Format("2006-01-02")
+	summary := &APIUsageSummary{From: from, To: today.Format("2006-01-02"), Keys: []*APIKeyUsageSummary{}}
+
+	keys, err := u.apiKeyRepo.FindByUserID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve api keys: %w", err)
+	}
+	if len(keys) == 0 {
+		return summary, nil
+	}
+	stored, err := u.usageRepo.FindByUserID(ctx, actor.ID, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve api usage: %w", err)
+	}
+
+	// キーごと・日ごとに保存済みの件数と保存前の件数を合わせる
+	days := map[int64]map[string]*entity.APIKeyUsage{}
+	add := func(id int64, usage entity.APIKeyUsage) {
+		if days[id] == nil {
+			days[id] = map[string]*entity.APIKeyUsage{}
+		}
+		day, ok := days[id][usage.Date]
+		if !ok {
+			day = &entity.APIKeyUsage{APIKeyID: id, Date: usage.Date}
+			days[id][usage.Date] = day
+		}
+		day.Add(usage)
+	}
+	for _, usage := range stored {
+		add(usage.APIKeyID, *usage)
+	}
+	hashes := make(map[string]int64, len(keys))
+	for _, key := range keys {
+		hashes[key.KeyHash] = key.ID
+	}
+	u.mu.Lock()
+	for k, usage := range u.pending {
+		if id, ok := hashes[k.keyHash]; ok && k.date >= from {
+			add(id, *usage)
+		}
+	}
+	u.mu.Unlock()
+
+	for _, key := range keys {
+		s := &APIKeyUsageSummary{APIKey: key, Days: []entity.APIKeyUsage{}}
+		var total entity.APIKeyUsage
+		for _, day := range days[key.ID] {
+			total.Add(*day)
+			s.Days = append(s.Days, *day)
+		}
+		sort.Slice(s.Days, func(i, j int) bool { return s.Days[i].Date < s.Days[j].Date })
+		s.Requests, s.ClientErrors, s.ServerErrors, s.RateLimited = total.Requests, total.ClientErrors, total.ServerErrors, total.RateLimited
+		s.ErrorRate = total.ErrorRate()
+		summary.Keys = append(summary.Keys, s)
+	}
+
+	return summary, nil
+}
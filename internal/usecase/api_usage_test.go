@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockAPIKeyUsageRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyUsageRepository) Add(ctx context.Context, usage []*entity.APIKeyUsage) error {
+	args := m.Called(ctx, usage)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyUsageRepository) FindByUserID(ctx context.Context, userID int64, from string) ([]*entity.APIKeyUsage, error) {
+	args := m.Called(ctx, userID, from)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.APIKeyUsage), args.Error(1)
+}
+
+func newTestAPIUsageUsecase(usageRepo APIKeyUsageRepository, keyRepo APIKeyRepository, now time.Time) *apiUsageUsecase {
+	u := NewAPIUsageUsecase(usageRepo, keyRepo, time.Minute).(*apiUsageUsecase)
+	u.now = func() time.Time { return now }
+	return u
+}
+
+func TestAPIUsageUsecase_Run(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	t.Run("キーごと・日ごとに数え、終了時にキーの ID に対応付けて保存する", func(t *testing.T) {
+		usageRepo := new(MockAPIKeyUsageRepository)
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("FindByHash", mock.Anything, hashAPIKey("aic_valid")).Return(&entity.APIKey{ID: 3}, nil).Once()
+		keyRepo.On("FindByHash", mock.Anything, hashAPIKey("aic_unknown")).Return(nil, domainErrors.ErrAPIKeyNotFound).Once()
+		var saved []*entity.APIKeyUsage
+		usageRepo.On("Add", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).([]*entity.APIKeyUsage)...)
+		}).Return(nil)
+
+		u := newTestAPIUsageUsecase(usageRepo, keyRepo, now)
+		u.Record("aic_valid", http.StatusOK, false)
+		u.Record("aic_valid", http.StatusNotFound, false)
+		u.Record("aic_valid", http.StatusTooManyRequests, true)
+		u.Record("aic_valid", http.StatusInternalServerError, false)
+		u.Record("aic_unknown", http.StatusUnauthorized, false)
+		// 形式の異なるキーは数えない
+		u.Record("not-a-key", http.StatusUnauthorized, false)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		u.Run(ctx)
+
+		// 存在しないキーの件数は破棄する
+		require.Len(t, saved, 1)
+		assert.Equal(t, entity.APIKeyUsage{APIKeyID: 3, Date: "2026-10-18", Requests: 4, ClientErrors: 2, ServerErrors: 1, RateLimited: 1}, *saved[0])
+		assert.Empty(t, u.pending)
+		keyRepo.AssertExpectations(t)
+	})
+
+	t.Run("保存待ちがあふれた件数は破棄する", func(t *testing.T) {
+		u := newTestAPIUsageUsecase(new(MockAPIKeyUsageRepository), new(MockAPIKeyRepository), now)
+		for i := 0; i < apiUsagePendingLimit; i++ {
+			u.pending[apiUsageKey{keyHash: strconv.Itoa(i), date: "2026-10-18"}] = &entity.APIKeyUsage{}
+		}
+
+		u.Record("aic_valid", http.StatusOK, false)
+
+		assert.Len(t, u.pending, apiUsagePendingLimit)
+		assert.Equal(t, 1, u.dropped)
+	})
+}
+
+func TestAPIUsageUsecase_Summary(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 保存済みと保存前の件数をキーごとに合わせる", func(t *testing.T) {
+		usageRepo := new(MockAPIKeyUsageRepository)
+		keyRepo := new(MockAPIKeyRepository)
+		keys := []*entity.APIKey{
+			{ID: 2, UserID: testActor.ID, Name: "ci", KeyHash: hashAPIKey("aic_ci")},
+			{ID: 1, UserID: testActor.ID, Name: "unused", KeyHash: hashAPIKey("aic_unused")},
+		}
+		keyRepo.On("FindByUserID", mock.Anything, testActor.ID).Return(keys, nil)
+		usageRepo.On("FindByUserID", mock.Anything, testActor.ID, "2026-09-19").Return([]*entity.APIKeyUsage{
+			{APIKeyID: 2, Date: "2026-10-01", Requests: 6, ClientErrors: 1, RateLimited: 1},
+			{APIKeyID: 2, Date: "2026-10-18", Requests: 2},
+		}, nil)
+
+		u := newTestAPIUsageUsecase(usageRepo, keyRepo, now)
+		u.Record("aic_ci", http.StatusInternalServerError, false)
+		u.Record("aic_ci", http.StatusOK, false)
+		// ほかのユーザーのキーの保存前の件数は含めない
+		u.Record("aic_other", http.StatusOK, false)
+
+		summary, err := u.Summary(actorContext())
+		require.NoError(t, err)
+
+		assert.Equal(t, "2026-09-19", summary.From)
+		assert.Equal(t, "2026-10-18", summary.To)
+		require.Len(t, summary.Keys, 2)
+		ci := summary.Keys[0]
+		assert.Equal(t, int64(2), ci.APIKey.ID)
+		assert.Equal(t, 10, ci.Requests)
+		assert.Equal(t, 1, ci.ClientErrors)
+		assert.Equal(t, 1, ci.ServerErrors)
+		assert.Equal(t, 1, ci.RateLimited)
+		assert.InDelta(t, 0.2, ci.ErrorRate, 1e-9)
+		assert.Equal(t, []entity.APIKeyUsage{
+			{APIKeyID: 2, Date: "2026-10-01", Requests: 6, ClientErrors: 1, RateLimited: 1},
+			{APIKeyID: 2, Date: "2026-10-18", Requests: 4, ServerErrors: 1},
+		}, ci.Days)
+
+		// リクエストがないキーも返す
+		assert.Equal(t, int64(1), summary.Keys[1].APIKey.ID)
+		assert.Zero(t, summary.Keys[1].Requests)
+		assert.Empty(t, summary.Keys[1].Days)
+	})
+
+	t.Run("正常系: APIキーがない場合は空", func(t *testing.T) {
+		keyRepo := new(MockAPIKeyRepository)
+		keyRepo.On("FindByUserID", mock.Anything, testActor.ID).Return([]*entity.APIKey{}, nil)
+		usageRepo := new(MockAPIKeyUsageRepository)
+
+		summary, err := newTestAPIUsageUsecase(usageRepo, keyRepo, now).Summary(actorContext())
+		require.NoError(t, err)
+
+		assert.Empty(t, summary.Keys)
+		usageRepo.AssertNotCalled(t, "FindByUserID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 未認証", func(t *testing.T) {
+		_, err := newTestAPIUsageUsecase(new(MockAPIKeyUsageRepository), new(MockAPIKeyRepository), now).Summary(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// 保存待ちの監査ログの上限（あふれた記録は破棄してログに出力する）
+	auditQueueSize = 1000
+	// 1回の INSERT で保存する監査ログの最大数
+	auditBatchSize = 100
+	// 監査ログの一覧の既定の件数と最大の件数
+	defaultAuditLogLimit = 100
+	maxAuditLogLimit     = 1000
+)
+
+type AuditUsecase interface {
+	// Record は監査ログを保存待ちに追加する（保存は Run が非同期で行うため、リクエストを待たせない）
+	Record(log *entity.AuditLog)
+	// Run は保存待ちの監査ログをまとめて保存する。ctx が終了すると残りを保存してから戻る
+	Run(ctx context.Context)
+	// List は条件に一致する監査ログを新しい順に返す（管理者のみ）
+	List(ctx context.Context, input ListAuditLogsInput) ([]*entity.AuditLog, error)
+}
+
+// ListAuditLogsInput は監査ログの絞り込み条件（空文字 / 0 のフィールドは条件なし）
+type ListAuditLogsInput struct {
+	// From, To は操作日の範囲（YYYY-MM-DD 形式、To の日を含む）
+	From   string
+	To     string
+	UserID int64
+	Action string
+	Limit  int
+}
+
+type auditUsecase struct {
+	auditLogRepo AuditLogRepository
+	queue        chan *entity.AuditLog
+}
+
+func NewAuditUsecase(auditLogRepo AuditLogRepository) AuditUsecase {
+	return &auditUsecase{
+		auditLogRepo: auditLogRepo,
+		queue:        make(chan *entity.AuditLog, auditQueueSize),
+	}
+}
+
+func (u *auditUsecase) Record(entry *entity.AuditLog) {
+	select {
+	case u.queue <- entry:
+	default:
+		log.Printf("⚠️  audit log queue is full, dropped: %s %s by user %d (request %s)", entry.Method, entry.Path, entry.UserID, entry.RequestID)
+	}
+}
+
+func (u *auditUsecase) Run(ctx context.Context) {
+	// 終了時に残りを保存できるよう、保存はキャンセルを引き継がないコンテキストで行う
+	saveCtx := context.WithoutCancel(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				batch := u.drain(nil)
+				if len(batch) == 0 {
+					return
+				}
+				u.save(saveCtx, batch)
+			}
+		case entry := <-u.queue:
+			u.save(saveCtx, u.drain([]*entity.AuditLog{entry}))
+		}
+	}
+}
+
+// drain は保存待ちの監査ログを待たずに取り出し、batch に最大 auditBatchSize 件まで追加する
+func (u *auditUsecase) drain(batch []*entity.AuditLog) []*entity.AuditLog {
+	for len(batch) < auditBatchSize {
+		select {
+		case entry := <-u.queue:
+			batch = append(batch, entry)
+		default:
+			return batch
+		}
+	}
+	return batch
+}
+
+func (u *auditUsecase) save(ctx context.Context, batch []*entity.AuditLog) {
+	if err := u.auditLogRepo.Create(ctx, batch); err != nil {
+		log.Printf("⚠️  failed to save %d audit logs: %v", len(batch), err)
+	}
+}
+
+func (u *auditUsecase) List(ctx context.Context, input ListAuditLogsInput) ([]*entity.AuditLog, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.IsAdmin() {
+		return nil, domainErrors.ErrForbidden
+	}
+
+	filter, err := auditLogFilter(input)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := u.auditLogRepo.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve audit logs: %w", err)
+	}
+
+	return logs, nil
+}
+
+// auditLogFilter は絞り込み条件を検証して AuditLogFilter に変換する（日付はサーバーのタイムゾーン）
+func auditLogFilter(input ListAuditLogsInput) (entity.AuditLogFilter, error) {
+	filter := entity.AuditLogFilter{
+		UserID: input.UserID,
+		Action: entity.AuditAction(input.Action),
+		Limit:  input.Limit,
+	}
+
+	if filter.Action != "" && !filter.Action.IsValid() {
+		return filter, fmt.Errorf("%w: action must be one of: create, update, delete, import, export", domainErrors.ErrInvalidInput)
+	}
+
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultAuditLogLimit
+	case filter.Limit < 0 || filter.Limit > maxAuditLogLimit:
+		return filter, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, maxAuditLogLimit)
+	}
+
+	if input.From != "" {
+		from, err := time.ParseInLocation("2006-01-02", input.From, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("%w: from must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+		}
+		filter.From = &from
+	}
+	if input.To != "" {
+		to, err := time.ParseInLocation("2006-01-02", input.To, time.Local)
+		if err != nil {
+			return filter, fmt.Errorf("%w: to must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+		}
+		// To の日を含めるため翌日の0時より前を対象にする
+		to = to.AddDate(0, 0, 1)
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return filter, fmt.Errorf("%w: from must be on or before to", domainErrors.ErrInvalidInput)
+	}
+
+	return filter, nil
+}
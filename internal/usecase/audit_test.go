@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockAuditLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditLogRepository) Create(ctx context.Context, logs []*entity.AuditLog) error {
+	args := m.Called(ctx, logs)
+	return args.Error(0)
+}
+
+func (m *MockAuditLogRepository) Find(ctx context.Context, filter entity.AuditLogFilter) ([]*entity.AuditLog, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.AuditLog), args.Error(1)
+}
+
+func TestAuditUsecase_Run(t *testing.T) {
+	t.Run("記録をまとめて保存し、終了時に残りを保存する", func(t *testing.T) {
+		repo := new(MockAuditLogRepository)
+		var saved []*entity.AuditLog
+		repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(1).([]*entity.AuditLog)...)
+		}).Return(nil)
+
+		u := NewAuditUsecase(repo)
+		for i := 0; i < auditBatchSize+5; i++ {
+			u.Record(&entity.AuditLog{UserID: 1, Action: entity.AuditActionCreate, RequestID: "req"})
+		}
+
+		// 停止済みのコンテキストでは保存待ちをすべて保存してから戻る
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		u.Run(ctx)
+
+		assert.Len(t, saved, auditBatchSize+5)
+		repo.AssertNumberOfCalls(t, "Create", 2)
+	})
+
+	t.Run("保存待ちがあふれた記録は破棄する", func(t *testing.T) {
+		repo := new(MockAuditLogRepository)
+		u := NewAuditUsecase(repo)
+		for i := 0; i < auditQueueSize+1; i++ {
+			u.Record(&entity.AuditLog{Action: entity.AuditActionDelete})
+		}
+
+		assert.Len(t, u.(*auditUsecase).queue, auditQueueSize)
+	})
+}
+
+func TestAuditUsecase_List(t *testing.T) {
+	admin := &entity.User{ID: 1, Email: "admin@example.com", Role: entity.RoleAdmin}
+	editor := &entity.User{ID: 2, Email: "editor@example.com", Role: entity.RoleEditor}
+
+	t.Run("日付・ユーザー・操作で絞り込む", func(t *testing.T) {
+		repo := new(MockAuditLogRepository)
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+		to := time.Date(2024, 2, 1, 0, 0, 0, 0, time.Local)
+		expected := []*entity.AuditLog{{ID: 1, UserID: 2, Action: entity.AuditActionExport}}
+		repo.On("Find", mock.Anything, entity.AuditLogFilter{
+			From:   &from,
+			To:     &to,
+			UserID: 2,
+			Action: entity.AuditActionExport,
+			Limit:  defaultAuditLogLimit,
+		}).Return(expected, nil)
+
+		u := NewAuditUsecase(repo)
+		logs, err := u.List(WithActor(context.Background(), admin), ListAuditLogsInput{
+			From:   "2024-01-01",
+			To:     "2024-01-31",
+			UserID: 2,
+			Action: "export",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, expected, logs)
+	})
+
+	t.Run("管理者以外は参照できない", func(t *testing.T) {
+		u := NewAuditUsecase(new(MockAuditLogRepository))
+
+		_, err := u.List(WithActor(context.Background(), editor), ListAuditLogsInput{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+
+	t.Run("不正な条件", func(t *testing.T) {
+		u := NewAuditUsecase(new(MockAuditLogRepository))
+		ctx := WithActor(context.Background(), admin)
+
+		for _, input := range []ListAuditLogsInput{
+			{Action: "read"},
+			{From: "2024/01/01"},
+			{From: "2024-02-01", To: "2024-01-31"},
+			{Limit: maxAuditLogLimit + 1},
+		} {
+			_, err := u.List(ctx, input)
+			assert.True(t, domainErrors.IsValidationError(err), "%+v", input)
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// TokenIssuer はアクセストークンの発行と検証を行う
+type TokenIssuer interface {
+	// Issue はユーザーのトークンと有効期限を返す
+	Issue(user *entity.User) (string, time.Time, error)
+	// Parse はトークンを検証し、ユーザーIDを返す
+	Parse(token string) (int64, error)
+}
+
+type AuthUsecase interface {
+	Register(ctx context.Context, input RegisterInput) (*entity.User, error)
+	Login(ctx context.Context, input LoginInput) (*AuthToken, error)
+	// Authenticate はトークンを検証し、対応するユーザーを返す
+	Authenticate(ctx context.Context, token string) (*entity.User, error)
+}
+
+type RegisterInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LoginInput struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type AuthToken struct {
+	AccessToken string       `json:"access_token"`
+	TokenType   string       `json:"token_type"`
+	ExpiresAt   time.Time    `json:"expires_at"`
+	User        *entity.User `json:"user"`
+}
+
+type authUsecase struct {
+	userRepo UserRepository
+	tokens   TokenIssuer
+}
+
+func NewAuthUsecase(userRepo UserRepository, tokens TokenIssuer) AuthUsecase {
+	return &authUsecase{
+		userRepo: userRepo,
+		tokens:   tokens,
+	}
+}
+
+func (u *authUsecase) Register(ctx context.Context, input RegisterInput) (*entity.User, error) {
+	if err := entity.ValidatePassword(input.Password); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user, err := entity.NewUser(input.Email, string(hash))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	createdUser, err := u.userRepo.Create(ctx, user)
+	if err != nil {
+		if domainErrors.IsDuplicateError(err) {
+			return nil, fmt.Errorf("%w: email is already registered", domainErrors.ErrDuplicateEntry)
+		}
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	return createdUser, nil
+}
+
+func (u *authUsecase) Login(ctx context.Context, input LoginInput) (*AuthToken, error) {
+	user, err := u.userRepo.FindByEmail(ctx, entity.NormalizeEmail(input.Email))
+	if err != nil {
+		// ユーザーの存在有無を区別できないよう同じエラーを返す
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			return nil, domainErrors.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(input.Password)); err != nil {
+		return nil, domainErrors.ErrInvalidCredentials
+	}
+
+	token, expiresAt, err := u.tokens.Issue(user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue token: %w", err)
+	}
+
+	return &AuthToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   expiresAt,
+		User:        user,
+	}, nil
+}
+
+func (u *authUsecase) Authenticate(ctx context.Context, token string) (*entity.User, error) {
+	userID, err := u.tokens.Parse(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrUnauthorized, err.Error())
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			return nil, domainErrors.ErrUnauthorized
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	return user, nil
+}
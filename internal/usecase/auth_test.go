@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockUserRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserRepository) FindByID(ctx context.Context, id int64) (*entity.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *entity.User) (*entity.User, error) {
+	args := m.Called(ctx, user)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+func (m *MockUserRepository) UpdatePreferredCurrency(ctx context.Context, id int64, currency entity.Currency) (*entity.User, error) {
+	args := m.Called(ctx, id, currency)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.User), args.Error(1)
+}
+
+type MockTokenIssuer struct {
+	mock.Mock
+}
+
+func (m *MockTokenIssuer) Issue(user *entity.User) (string, time.Time, error) {
+	args := m.Called(user)
+	return args.String(0), args.Get(1).(time.Time), args.Error(2)
+}
+
+func (m *MockTokenIssuer) Parse(token string) (int64, error) {
+	args := m.Called(token)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestAuthUsecase_Register(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       RegisterInput
+		setupMock   func(*MockUserRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: メールアドレスを正規化してパスワードをハッシュ化（権限は編集者）",
+			input: RegisterInput{Email: " User@Example.com ", Password: "password123"},
+			setupMock: func(mockRepo *MockUserRepository) {
+				mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(u *entity.User) bool {
+					return u.Email == "user@example.com" &&
+						u.Role == entity.RoleEditor &&
+						bcrypt.CompareHashAndPassword([]byte(u.PasswordHash), []byte("password123")) == nil
+				})).Return(&entity.User{ID: 1, Email: "user@example.com"}, nil)
+			},
+		},
+		{
+			name:        "異常系: パスワードが短い",
+			input:       RegisterInput{Email: "user@example.com", Password: "short"},
+			setupMock:   func(mockRepo *MockUserRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: メールアドレスが不正",
+			input:       RegisterInput{Email: "not-an-email", Password: "password123"},
+			setupMock:   func(mockRepo *MockUserRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: 登録済みのメールアドレス",
+			input: RegisterInput{Email: "user@example.com", Password: "password123"},
+			setupMock: func(mockRepo *MockUserRepository) {
+				mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+			},
+			expectedErr: domainErrors.ErrDuplicateEntry,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			tt.setupMock(mockRepo)
+			u := NewAuthUsecase(mockRepo, new(MockTokenIssuer))
+
+			user, err := u.Register(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, int64(1), user.ID)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAuthUsecase_Login(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := &entity.User{ID: 1, Email: "user@example.com", PasswordHash: string(hash)}
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: トークンを発行", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil)
+		mockTokens := new(MockTokenIssuer)
+		mockTokens.On("Issue", user).Return("token", expiresAt, nil)
+
+		token, err := NewAuthUsecase(mockRepo, mockTokens).Login(context.Background(), LoginInput{
+			Email:    "USER@example.com",
+			Password: "password123",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "token", token.AccessToken)
+		assert.Equal(t, "Bearer", token.TokenType)
+		assert.Equal(t, expiresAt, token.ExpiresAt)
+	})
+
+	t.Run("異常系: パスワードが違う", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("FindByEmail", mock.Anything, "user@example.com").Return(user, nil)
+
+		_, err := NewAuthUsecase(mockRepo, new(MockTokenIssuer)).Login(context.Background(), LoginInput{
+			Email:    "user@example.com",
+			Password: "wrong-password",
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidCredentials)
+	})
+
+	t.Run("異常系: ユーザーが存在しない", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, domainErrors.ErrUserNotFound)
+
+		_, err := NewAuthUsecase(mockRepo, new(MockTokenIssuer)).Login(context.Background(), LoginInput{
+			Email:    "nobody@example.com",
+			Password: "password123",
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidCredentials)
+	})
+}
+
+func TestAuthUsecase_Authenticate(t *testing.T) {
+	t.Run("正常系: トークンのユーザーを返す", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.User{ID: 1}, nil)
+		mockTokens := new(MockTokenIssuer)
+		mockTokens.On("Parse", "token").Return(int64(1), nil)
+
+		user, err := NewAuthUsecase(mockRepo, mockTokens).Authenticate(context.Background(), "token")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), user.ID)
+	})
+
+	t.Run("異常系: 不正なトークン", func(t *testing.T) {
+		mockTokens := new(MockTokenIssuer)
+		mockTokens.On("Parse", "bad").Return(int64(0), errors.New("signature is invalid"))
+
+		_, err := NewAuthUsecase(new(MockUserRepository), mockTokens).Authenticate(context.Background(), "bad")
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+
+	t.Run("異常系: 削除済みのユーザー", func(t *testing.T) {
+		mockRepo := new(MockUserRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrUserNotFound)
+		mockTokens := new(MockTokenIssuer)
+		mockTokens.On("Parse", "token").Return(int64(9), nil)
+
+		_, err := NewAuthUsecase(mockRepo, mockTokens).Authenticate(context.Background(), "token")
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
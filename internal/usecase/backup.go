@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// LatestBackupKey は最後に書き出したバックアップを保存するストレージのキー（バックアップの検証で読み込む）
+const LatestBackupKey = "backups/latest.json"
+
+// BackupUsecase はアイテムのデータの全件のバックアップと読み込みを行う（管理者のみ）
+type BackupUsecase interface {
+	// Backup は全アイテムを ID と作成・更新日時を含めて書き出し、最後のバックアップとしてストレージに保存する
+	Backup(ctx context.Context) (*entity.Backup, error)
+	// Restore はバックアップを読み込み、アイテムをバックアップの時点の内容に置き換える
+	Restore(ctx context.Context, backup *entity.Backup) (*entity.RestoreResult, error)
+}
+
+type backupUsecase struct {
+	backupRepo BackupRepository
+	storage    FileStorage
+}
+
+func NewBackupUsecase(backupRepo BackupRepository, storage FileStorage) BackupUsecase {
+	return &backupUsecase{
+		backupRepo: backupRepo,
+		storage:    storage,
+	}
+}
+
+func (u *backupUsecase) Backup(ctx context.Context) (*entity.Backup, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	backup, checksums, err := u.backupRepo.Snapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dump items: %w", err)
+	}
+	backup.Checksums = checksums
+
+	data, err := json.Marshal(backup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode backup: %w", err)
+	}
+	if err := u.storage.Put(ctx, LatestBackupKey, bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to store backup: %w", err)
+	}
+	return backup, nil
+}
+
+func (u *backupUsecase) Restore(ctx context.Context, backup *entity.Backup) (*entity.RestoreResult, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := backup.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	result, err := u.backupRepo.Restore(ctx, backup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore items: %w", err)
+	}
+	return result, nil
+}
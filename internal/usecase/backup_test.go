@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockBackupRepository struct {
+	mock.Mock
+}
+
+func (m *MockBackupRepository) Restore(ctx context.Context, backup *entity.Backup) (*entity.RestoreResult, error) {
+	args := m.Called(ctx, backup)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.RestoreResult), args.Error(1)
+}
+
+func (m *MockBackupRepository) Snapshot(ctx context.Context) (*entity.Backup, []entity.TableChecksum, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entity.Backup), args.Get(1).([]entity.TableChecksum), args.Error(2)
+}
+
+func (m *MockBackupRepository) RestoreInto(ctx context.Context, schema string, backup *entity.Backup) ([]entity.TableChecksum, error) {
+	args := m.Called(ctx, schema, backup)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.TableChecksum), args.Error(1)
+}
+
+func TestBackupUsecase(t *testing.T) {
+	admin := WithActor(context.Background(), &entity.User{ID: 2, Role: entity.RoleAdmin})
+	backup := &entity.Backup{
+		FormatVersion: entity.BackupFormatVersion,
+		CreatedAt:     time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Tables:        []entity.BackupTable{{Name: "items", Columns: []string{"id", "name"}, Rows: [][]any{{int64(1), "a"}}}},
+	}
+
+	t.Run("正常系: 管理者はバックアップを書き出して読み込める", func(t *testing.T) {
+		checksums := []entity.TableChecksum{{Name: "items", Rows: 1, Checksum: "123"}}
+		repo := new(MockBackupRepository)
+		repo.On("Snapshot", admin).Return(backup, checksums, nil)
+		repo.On("Restore", admin, backup).Return(&entity.RestoreResult{FormatVersion: 1, Tables: []entity.RestoredTable{{Name: "items", Restored: 1}}}, nil)
+		storage := new(MockFileStorage)
+		// 書き出したバックアップは本番のチェックサムとともに最後のバックアップとして保存する
+		storage.On("Put", admin, LatestBackupKey, mock.MatchedBy(func(body []byte) bool {
+			var stored entity.Backup
+			return json.Unmarshal(body, &stored) == nil && assert.ObjectsAreEqual(checksums, stored.Checksums)
+		})).Return(nil)
+		u := NewBackupUsecase(repo, storage)
+
+		dumped, err := u.Backup(admin)
+		require.NoError(t, err)
+		assert.Equal(t, backup, dumped)
+		assert.Equal(t, checksums, dumped.Checksums)
+		storage.AssertExpectations(t)
+
+		result, err := u.Restore(admin, dumped)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Tables[0].Restored)
+	})
+
+	t.Run("異常系: 保存に失敗したバックアップは返さない", func(t *testing.T) {
+		repo := new(MockBackupRepository)
+		repo.On("Snapshot", admin).Return(&entity.Backup{FormatVersion: entity.BackupFormatVersion, Tables: backup.Tables}, []entity.TableChecksum{}, nil)
+		storage := new(MockFileStorage)
+		storage.On("Put", admin, LatestBackupKey, mock.Anything).Return(errors.New("disk full"))
+		u := NewBackupUsecase(repo, storage)
+
+		_, err := u.Backup(admin)
+		assert.ErrorContains(t, err, "disk full")
+	})
+
+	t.Run("異常系: 管理者以外は403", func(t *testing.T) {
+		u := NewBackupUsecase(new(MockBackupRepository), new(MockFileStorage))
+
+		_, err := u.Backup(actorContext())
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		_, err = u.Restore(actorContext(), backup)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+
+	t.Run("異常系: 形式のバージョンが異なるバックアップは読み込まない", func(t *testing.T) {
+		repo := new(MockBackupRepository)
+		u := NewBackupUsecase(repo, new(MockFileStorage))
+
+		_, err := u.Restore(admin, &entity.Backup{FormatVersion: 2, Tables: backup.Tables})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.ErrorContains(t, err, "unsupported format_version 2")
+		repo.AssertNotCalled(t, "Restore", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// BackupVerificationUsecase は保存したバックアップを検証用のスキーマに読み込み、本番のテーブルと行数・チェックサムを比べる
+type BackupVerificationUsecase interface {
+	// Verify は最後に保存したバックアップ（LatestBackupKey）を検証用のスキーマに読み込み、
+	// バックアップに記録した書き出しの時点の本番のテーブルと比べた結果を記録する（定期ジョブから実行する）
+	Verify(ctx context.Context) (*entity.BackupVerification, error)
+	// Status は検証の回数と最後の結果を返す（管理者のみ）
+	Status(ctx context.Context) (*BackupVerificationStats, error)
+	// Snapshot は検証の回数と最後の結果を返す（expvar での公開用）
+	Snapshot() *BackupVerificationStats
+}
+
+// BackupVerificationStats はバックアップの検証の回数と最後の結果（起動してから）
+type BackupVerificationStats struct {
+	// Schema はバックアップを読み込む検証用のスキーマ
+	Schema string `json:"schema"`
+	Runs   int64  `json:"runs"`
+	// Failures は一致しないテーブルがあったか、検証を実行できなかった回数
+	Failures int64                      `json:"failures"`
+	Last     *entity.BackupVerification `json:"last"`
+}
+
+type backupVerificationUsecase struct {
+	backupRepo BackupRepository
+	storage    FileStorage
+	schema     string
+	now        func() time.Time
+
+	mu    sync.Mutex
+	stats BackupVerificationStats
+}
+
+func NewBackupVerificationUsecase(backupRepo BackupRepository, storage FileStorage, schema string) BackupVerificationUsecase {
+	return &backupVerificationUsecase{
+		backupRepo: backupRepo,
+		storage:    storage,
+		schema:     schema,
+		now:        time.Now,
+		stats:      BackupVerificationStats{Schema: schema},
+	}
+}
+
+func (u *backupVerificationUsecase) Verify(ctx context.Context) (*entity.BackupVerification, error) {
+	result := &entity.BackupVerification{StartedAt: u.now().UTC(), Tables: []entity.BackupTableVerification{}}
+	err := u.verify(ctx, result)
+	result.FinishedAt = u.now().UTC()
+	if err != nil {
+		result.Status = entity.BackupVerificationError
+		result.Error = err.Error()
+	}
+	u.record(result)
+	return result, err
+}
+
+func (u *backupVerificationUsecase) verify(ctx context.Context, result *entity.BackupVerification) error {
+	body, err := u.storage.Get(ctx, LatestBackupKey)
+	if errors.Is(err, fs.ErrNotExist) {
+		return errors.New("no stored backup to verify (GET /admin/backup stores one)")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read stored backup: %w", err)
+	}
+	defer body.Close()
+
+	var backup entity.Backup
+	if err := json.NewDecoder(body).Decode(&backup); err != nil {
+		return fmt.Errorf("failed to decode stored backup: %w", err)
+	}
+	result.BackupCreatedAt = &backup.CreatedAt
+	if err := backup.Validate(); err != nil {
+		return fmt.Errorf("invalid backup: %w", err)
+	}
+	if len(backup.Checksums) == 0 {
+		return errors.New("stored backup has no checksums")
+	}
+
+	restored, err := u.backupRepo.RestoreInto(ctx, u.schema, &backup)
+	if err != nil {
+		return fmt.Errorf("failed to restore backup into %s: %w", u.schema, err)
+	}
+
+	result.Tables = entity.CompareBackupTables(backup.Checksums, restored)
+	result.Status = entity.BackupVerificationPassed
+	for _, table := range result.Tables {
+		if !table.Match {
+			result.Status = entity.BackupVerificationFailed
+		}
+	}
+	return nil
+}
+
+func (u *backupVerificationUsecase) record(result *entity.BackupVerification) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.stats.Runs++
+	if result.Status != entity.BackupVerificationPassed {
+		u.stats.Failures++
+	}
+	u.stats.Last = result
+}
+
+func (u *backupVerificationUsecase) Status(ctx context.Context) (*BackupVerificationStats, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	return u.Snapshot(), nil
+}
+
+func (u *backupVerificationUsecase) Snapshot() *BackupVerificationStats {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	stats := u.stats
+	return &stats
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestBackupVerificationUsecase_Verify(t *testing.T) {
+	ctx := context.Background()
+	backup := &entity.Backup{
+		FormatVersion: entity.BackupFormatVersion,
+		CreatedAt:     time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Tables:        []entity.BackupTable{{Name: "items", Columns: []string{"id", "name"}, Rows: [][]any{{int64(1), "a"}}}},
+		Checksums:     []entity.TableChecksum{{Name: "items", Rows: 1, Checksum: "123"}},
+	}
+	// stored は最後に保存したバックアップを返すストレージ
+	stored := func(backup *entity.Backup) *MockFileStorage {
+		data, _ := json.Marshal(backup)
+		storage := new(MockFileStorage)
+		storage.On("Get", ctx, LatestBackupKey).Return(io.NopCloser(bytes.NewReader(data)), nil)
+		return storage
+	}
+	// 保存した JSON の内容（ID は json.Number）を読み込む
+	decoded := mock.MatchedBy(func(b *entity.Backup) bool {
+		return b.Tables[0].Rows[0][0] == json.Number("1")
+	})
+
+	t.Run("正常系: 行数とチェックサムが一致すれば passed", func(t *testing.T) {
+		repo := new(MockBackupRepository)
+		repo.On("RestoreInto", ctx, "items_verify", decoded).Return([]entity.TableChecksum{{Name: "items", Rows: 1, Checksum: "123"}}, nil)
+		u := NewBackupVerificationUsecase(repo, stored(backup), "items_verify")
+
+		result, err := u.Verify(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entity.BackupVerificationPassed, result.Status)
+		assert.True(t, backup.CreatedAt.Equal(*result.BackupCreatedAt))
+		assert.Equal(t, []entity.BackupTableVerification{{Name: "items", ProductionRows: 1, RestoredRows: 1, ProductionChecksum: "123", RestoredChecksum: "123", Match: true}}, result.Tables)
+
+		stats, err := u.Status(adminContext())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), stats.Runs)
+		assert.Equal(t, int64(0), stats.Failures)
+		assert.Equal(t, result, stats.Last)
+	})
+
+	t.Run("正常系: チェックサムが異なれば failed", func(t *testing.T) {
+		repo := new(MockBackupRepository)
+		repo.On("RestoreInto", ctx, "items_verify", decoded).Return([]entity.TableChecksum{{Name: "items", Rows: 1, Checksum: "456"}}, nil)
+		u := NewBackupVerificationUsecase(repo, stored(backup), "items_verify")
+
+		result, err := u.Verify(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, entity.BackupVerificationFailed, result.Status)
+		assert.False(t, result.Tables[0].Match)
+		assert.Equal(t, int64(1), u.Snapshot().Failures)
+	})
+
+	t.Run("異常系: 読み込めなければ error を記録する", func(t *testing.T) {
+		repo := new(MockBackupRepository)
+		repo.On("RestoreInto", ctx, "items_verify", decoded).Return(nil, errors.New("access denied"))
+		u := NewBackupVerificationUsecase(repo, stored(backup), "items_verify")
+
+		_, err := u.Verify(ctx)
+		assert.ErrorContains(t, err, "access denied")
+		stats := u.Snapshot()
+		assert.Equal(t, int64(1), stats.Failures)
+		assert.Equal(t, entity.BackupVerificationError, stats.Last.Status)
+		assert.Contains(t, stats.Last.Error, "access denied")
+	})
+
+	t.Run("異常系: 保存したバックアップがなければ error を記録する", func(t *testing.T) {
+		repo := new(MockBackupRepository)
+		storage := new(MockFileStorage)
+		storage.On("Get", ctx, LatestBackupKey).Return(nil, fmt.Errorf("failed to open file: %w", fs.ErrNotExist))
+		u := NewBackupVerificationUsecase(repo, storage, "items_verify")
+
+		_, err := u.Verify(ctx)
+		assert.ErrorContains(t, err, "no stored backup")
+		assert.Equal(t, entity.BackupVerificationError, u.Snapshot().Last.Status)
+		repo.AssertNotCalled(t, "RestoreInto", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: チェックサムのないバックアップは読み込まない", func(t *testing.T) {
+		repo := new(MockBackupRepository)
+		u := NewBackupVerificationUsecase(repo, stored(&entity.Backup{FormatVersion: entity.BackupFormatVersion, CreatedAt: backup.CreatedAt, Tables: backup.Tables}), "items_verify")
+
+		_, err := u.Verify(ctx)
+		assert.ErrorContains(t, err, "no checksums")
+		repo.AssertNotCalled(t, "RestoreInto", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 検証の結果は管理者以外には返さない", func(t *testing.T) {
+		u := NewBackupVerificationUsecase(new(MockBackupRepository), new(MockFileStorage), "items_verify")
+
+		_, err := u.Status(actorContext())
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ブランド別集計で件数を指定する場合の最大のブランド数
+const maxBrandSummaryLimit = 100
+
+// BrandSummary はブランドごとのアイテムの件数と購入価格の集計
+type BrandSummary struct {
+	// Currency は金額の通貨（操作者の表示通貨）
+	Currency entity.Currency `json:"currency"`
+	// TotalBrands は集計したブランドの数（limit で省略したブランドを含む）
+	TotalBrands int `json:"total_brands"`
+	// Brands はブランドごとの集計（件数の多い順、同じ場合は合計の多い順）
+	Brands []BrandStats `json:"brands"`
+}
+
+// BrandStats はブランドの件数と購入価格の統計（Currency の最小単位）。
+// 金額は換算できない外貨建てのアイテムと購入価格が非表示のアイテムを除いて集計し、対象がない場合は null
+type BrandStats struct {
+	Brand string `json:"brand"`
+	Count int    `json:"count"`
+	// ValuedCount は金額の集計に含めたアイテムの数
+	ValuedCount  int    `json:"valued_count"`
+	TotalValue   int64  `json:"total_value"`
+	AverageValue *int64 `json:"average_value"`
+}
+
+// GetBrandSummary はブランドごとのアイテムの件数と購入価格の合計・平均を件数の多い順に返す。
+// 表記の異なる同じブランドはまとめ、limit が0の場合はすべてのブランドを返す
+func (u *itemUsecase) GetBrandSummary(ctx context.Context, limit int) (*BrandSummary, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if limit < 0 || limit > maxBrandSummaryLimit {
+		return nil, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, maxBrandSummaryLimit)
+	}
+
+	values, err := u.itemRepo.GetSummaryByBrand(ctx, itemScope(actor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get brand summary: %w", err)
+	}
+
+	valuation := newValuation(actor, u.converter)
+	brands := make(map[string]*BrandStats)
+	for _, v := range values {
+		brand := canonicalBrand(v.Brand)
+		stats, ok := brands[strings.ToLower(brand)]
+		if !ok {
+			stats = &BrandStats{Brand: brand}
+			brands[strings.ToLower(brand)] = stats
+		}
+		stats.Count += v.Count
+
+		// アイテムごとではなくブランドと通貨ごとの合計を換算する。
+		// 購入価格を非表示にする組織のアイテムは件数のみ数える
+		if slices.Contains(entity.ItemRedactionFor(actor, v.OrgID), entity.ItemFieldPurchasePrice) {
+			continue
+		}
+		value, ok, err := valuation.convert(ctx, v.Value, v.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		stats.ValuedCount += v.Count
+		stats.TotalValue = entity.AddAmount(stats.TotalValue, value)
+	}
+
+	summary := &BrandSummary{Currency: valuation.currency, TotalBrands: len(brands), Brands: make([]BrandStats, 0, len(brands))}
+	for _, stats := range brands {
+		if stats.ValuedCount > 0 {
+			average := stats.TotalValue / int64(stats.ValuedCount)
+			stats.AverageValue = &average
+		}
+		summary.Brands = append(summary.Brands, *stats)
+	}
+	slices.SortFunc(summary.Brands, func(a, b BrandStats) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(b.TotalValue, a.TotalValue), cmp.Compare(a.Brand, b.Brand))
+	})
+	if limit > 0 && len(summary.Brands) > limit {
+		summary.Brands = summary.Brands[:limit]
+	}
+	return summary, nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetBrandSummary(t *testing.T) {
+	values := []entity.BrandValue{
+		{Brand: "ROLEX", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000},
+		// 表記の異なる同じブランドはまとめる
+		{Brand: "rolex", Currency: entity.CurrencyJPY, OrgID: 10, Count: 1, Value: 1500000},
+		{Brand: "HERMÈS", Currency: entity.CurrencyJPY, Count: 3, Value: 2400000},
+		// 換算できない外貨建てのアイテムは件数のみ数える
+		{Brand: "CHANEL", Currency: entity.CurrencyUSD, Count: 1, Value: 500000},
+	}
+
+	t.Run("正常系: ブランドごとの件数と合計・平均を件数の多い順に返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByBrand", mock.Anything, testActor.ID).Return(values, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetBrandSummary(actorContext(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyJPY, summary.Currency)
+		assert.Equal(t, 3, summary.TotalBrands)
+		require.Len(t, summary.Brands, 3)
+
+		// 件数が同じ場合は合計の多い順
+		assert.Equal(t, "ROLEX", summary.Brands[0].Brand)
+		assert.Equal(t, 3, summary.Brands[0].Count)
+		assert.Equal(t, int64(4500000), summary.Brands[0].TotalValue)
+		assert.Equal(t, int64(1500000), *summary.Brands[0].AverageValue)
+		assert.Equal(t, "HERMÈS", summary.Brands[1].Brand)
+		assert.Equal(t, int64(800000), *summary.Brands[1].AverageValue)
+		assert.Equal(t, BrandStats{Brand: "CHANEL", Count: 1}, summary.Brands[2])
+	})
+
+	t.Run("正常系: limit を指定すると件数の多いブランドのみ返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByBrand", mock.Anything, testActor.ID).Return(values, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetBrandSummary(actorContext(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, summary.TotalBrands)
+		require.Len(t, summary.Brands, 1)
+		assert.Equal(t, "ROLEX", summary.Brands[0].Brand)
+	})
+
+	t.Run("正常系: 表示通貨に換算して合計する", func(t *testing.T) {
+		usdActor := &entity.User{ID: testActor.ID, Email: testActor.Email, Role: entity.RoleEditor, PreferredCurrency: entity.CurrencyUSD}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByBrand", mock.Anything, testActor.ID).Return([]entity.BrandValue{
+			{Brand: "ROLEX", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000},
+			{Brand: "ROLEX", Currency: entity.CurrencyUSD, Count: 1, Value: 1000000},
+		}, nil)
+		converter := new(MockCurrencyConverter)
+		converter.On("Convert", mock.Anything, int64(3000000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(2000000), nil)
+
+		summary, err := NewItemUsecase(mockRepo, WithCurrencyConverter(converter)).GetBrandSummary(WithActor(context.Background(), usdActor), 0)
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, summary.Currency)
+		assert.Equal(t, int64(3000000), summary.Brands[0].TotalValue)
+		assert.Equal(t, int64(1000000), *summary.Brands[0].AverageValue)
+	})
+
+	t.Run("正常系: 購入価格が非表示の組織のアイテムは件数のみ数える", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleViewer: {entity.ItemFieldPurchasePrice}})
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByBrand", mock.Anything, orgViewer.ID).Return([]entity.BrandValue{
+			{Brand: "ROLEX", Currency: entity.CurrencyJPY, OrgID: redactionOrgID, Count: 2, Value: 3000000},
+		}, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetBrandSummary(WithActor(context.Background(), orgViewer), 0)
+		require.NoError(t, err)
+		assert.Equal(t, []BrandStats{{Brand: "ROLEX", Count: 2}}, summary.Brands)
+	})
+
+	t.Run("異常系: limit が上限を超える", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).GetBrandSummary(actorContext(), maxBrandSummaryLimit+1)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "GetSummaryByBrand", mock.Anything, mock.Anything)
+	})
+}
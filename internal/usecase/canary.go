@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// 新しい実装を試しているユースケースのメソッド（CANARIES で振り分ける割合を指定する）
+const (
+	// CanaryValueChangeReport は値上がり・値下がりのレポートを、手放していないアイテムだけを SQL で絞り込んで読み込む実装
+	CanaryValueChangeReport = "items.value_change"
+)
+
+var canaryNames = []string{CanaryValueChangeReport}
+
+// 従来の実装との比較を同時に実行する数の上限（超えた分は比較しない）
+const maxCanaryComparisons = 4
+
+// ParseCanaries は「items.value_change=10」形式の設定（新しい実装に振り分けるリクエストの割合 %）を読み込む
+func ParseCanaries(entries []string) (map[string]float64, error) {
+	percents := make(map[string]float64)
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		percent, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if !ok || err != nil || percent < 0 || percent > 100 {
+			return nil, fmt.Errorf("invalid canary %q: must be <name>=<percent> with percent between 0 and 100", entry)
+		}
+		if !slices.Contains(canaryNames, name) {
+			return nil, fmt.Errorf("invalid canary %q: name must be one of: %s", entry, strings.Join(canaryNames, ", "))
+		}
+		percents[name] = percent
+	}
+	return percents, nil
+}
+
+// Canaries はメソッドごとの新しい実装への振り分けの設定と結果の比較の件数
+type Canaries struct {
+	canaries map[string]*canary
+}
+
+// NewCanaries は名前ごとの振り分ける割合（%）から Canaries を作成する（指定のないメソッドは従来の実装のみ使う）
+func NewCanaries(percents map[string]float64) *Canaries {
+	c := &Canaries{canaries: make(map[string]*canary)}
+	for _, name := range canaryNames {
+		c.canaries[name] = &canary{
+			name:      name,
+			percent:   percents[name],
+			sample:    rand.Float64,
+			comparing: make(chan struct{}, maxCanaryComparisons),
+		}
+	}
+	return c
+}
+
+// get は名前の振り分けの設定を返す（Canaries が nil の場合は nil で、従来の実装のみ使う）
+func (c *Canaries) get(name string) *canary {
+	if c == nil {
+		return nil
+	}
+	return c.canaries[name]
+}
+
+// Snapshot はメソッドごとの現在の件数を名前の順に返す
+func (c *Canaries) Snapshot() []CanarySnapshot {
+	snapshots := make([]CanarySnapshot, 0, len(canaryNames))
+	for _, name := range canaryNames {
+		snapshots = append(snapshots, c.canaries[name].snapshot())
+	}
+	return snapshots
+}
+
+// CanarySnapshot は1つのメソッドの振り分けと比較の件数（起動してから）
+type CanarySnapshot struct {
+	Name    string  `json:"name"`
+	Percent float64 `json:"percent"`
+	// Requests はメソッドの呼び出し回数、Routed は新しい実装に振り分けた回数
+	Requests int64 `json:"requests"`
+	Routed   int64 `json:"routed"`
+	// CandidateErrors は新しい実装が失敗して従来の実装で返した回数
+	CandidateErrors int64 `json:"candidate_errors"`
+	// Compared は従来の実装の結果と比べた回数、Mismatches は結果が異なった回数
+	Compared   int64 `json:"compared"`
+	Mismatches int64 `json:"mismatches"`
+	// Skipped は比較の同時実行数の上限を超えたか従来の実装が失敗したため比べなかった回数
+	Skipped int64 `json:"skipped"`
+	// LegacyAverageMs と CandidateAverageMs は比べたリクエストでのそれぞれの実装の平均の所要時間（ミリ秒）
+	LegacyAverageMs    float64 `json:"legacy_average_ms"`
+	CandidateAverageMs float64 `json:"candidate_average_ms"`
+}
+
+type canary struct {
+	name      string
+	percent   float64
+	sample    func() float64
+	comparing chan struct{}
+
+	requests        atomic.Int64
+	routed          atomic.Int64
+	candidateErrors atomic.Int64
+	compared        atomic.Int64
+	mismatches      atomic.Int64
+	skipped         atomic.Int64
+	legacyNanos     atomic.Int64
+	candidateNanos  atomic.Int64
+}
+
+func (c *canary) snapshot() CanarySnapshot {
+	s := CanarySnapshot{
+		Name:            c.name,
+		Percent:         c.percent,
+		Requests:        c.requests.Load(),
+		Routed:          c.routed.Load(),
+		CandidateErrors: c.candidateErrors.Load(),
+		Compared:        c.compared.Load(),
+		Mismatches:      c.mismatches.Load(),
+		Skipped:         c.skipped.Load(),
+	}
+	if s.Compared > 0 {
+		s.LegacyAverageMs = float64(c.legacyNanos.Load()) / float64(s.Compared) / float64(time.Millisecond)
+		s.CandidateAverageMs = float64(c.candidateNanos.Load()) / float64(s.Compared) / float64(time.Millisecond)
+	}
+	return s
+}
+
+// runCanary は設定した割合のリクエストを新しい実装（candidate）で返し、残りは従来の実装（legacy）で返す。
+// 新しい実装で返したリクエストは、応答を待たせないよう従来の実装をバックグラウンドで実行して結果（reflect.DeepEqual）と
+// 所要時間を比べ、異なる場合はログに出力する。新しい実装が失敗した場合は従来の実装の結果を返す。
+// 比較は返した後に行うため、呼び出し元は結果を変更しないこと
+func runCanary[T any](ctx context.Context, c *canary, legacy, candidate func(ctx context.Context) (T, error)) (T, error) {
+	if c == nil {
+		return legacy(ctx)
+	}
+	c.requests.Add(1)
+	if c.percent <= 0 || c.sample()*100 >= c.percent {
+		return legacy(ctx)
+	}
+	c.routed.Add(1)
+
+	start := time.Now()
+	result, err := candidate(ctx)
+	candidateTime := time.Since(start)
+	if err != nil {
+		c.candidateErrors.Add(1)
+		log.Printf("⚠️  canary %s: candidate failed, using legacy: %v", c.name, err)
+		return legacy(ctx)
+	}
+
+	select {
+	case c.comparing <- struct{}{}:
+	default:
+		c.skipped.Add(1)
+		return result, nil
+	}
+	// 比較はリクエストの終了後も続けるため、キャンセルを引き継がないコンテキストで行う
+	compareCtx := context.WithoutCancel(ctx)
+	go func() {
+		defer func() { <-c.comparing }()
+		start := time.Now()
+		expected, err := legacy(compareCtx)
+		legacyTime := time.Since(start)
+		if err != nil {
+			c.skipped.Add(1)
+			return
+		}
+		c.compared.Add(1)
+		c.legacyNanos.Add(int64(legacyTime))
+		c.candidateNanos.Add(int64(candidateTime))
+		if !reflect.DeepEqual(expected, result) {
+			c.mismatches.Add(1)
+			log.Printf("⚠️  canary %s: candidate result differs from legacy (legacy %s, candidate %s)", c.name, legacyTime, candidateTime)
+		}
+	}()
+	return result, nil
+}
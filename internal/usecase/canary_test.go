@@ -0,0 +1,118 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCanaries(t *testing.T) {
+	t.Run("正常系", func(t *testing.T) {
+		percents, err := ParseCanaries([]string{" items.value_change = 12.5 ", ""})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]float64{CanaryValueChangeReport: 12.5}, percents)
+	})
+
+	t.Run("異常系", func(t *testing.T) {
+		for _, entries := range [][]string{
+			{"items.value_change"},
+			{"items.value_change=abc"},
+			{"items.value_change=101"},
+			{"items.value_change=-1"},
+			{"items.unknown=10"},
+		} {
+			_, err := ParseCanaries(entries)
+			assert.Error(t, err, entries)
+		}
+	})
+}
+
+// testCanary は sample が常に value を返す振り分けの設定を返す
+func testCanary(percent, value float64) *canary {
+	c := NewCanaries(map[string]float64{CanaryValueChangeReport: percent}).get(CanaryValueChangeReport)
+	c.sample = func() float64 { return value }
+	return c
+}
+
+func TestRunCanary(t *testing.T) {
+	impl := func(result string, err error) (*int, func(ctx context.Context) (string, error)) {
+		calls := new(int)
+		return calls, func(ctx context.Context) (string, error) {
+			*calls++
+			return result, err
+		}
+	}
+
+	t.Run("割合に含まれないリクエストは従来の実装で返す", func(t *testing.T) {
+		c := testCanary(10, 0.1)
+		_, candidate := impl("new", nil)
+		calls, old := impl("old", nil)
+
+		result, err := runCanary(context.Background(), c, old, candidate)
+		require.NoError(t, err)
+
+		assert.Equal(t, "old", result)
+		assert.Equal(t, 1, *calls)
+		s := c.snapshot()
+		assert.Equal(t, int64(1), s.Requests)
+		assert.Zero(t, s.Routed)
+	})
+
+	t.Run("振り分けたリクエストは新しい実装で返し、従来の実装と比べる", func(t *testing.T) {
+		c := testCanary(10, 0.05)
+		_, candidate := impl("new", nil)
+		_, old := impl("old", nil)
+
+		result, err := runCanary(context.Background(), c, old, candidate)
+		require.NoError(t, err)
+
+		assert.Equal(t, "new", result)
+		require.Eventually(t, func() bool { return c.snapshot().Compared == 1 }, time.Second, 5*time.Millisecond)
+		s := c.snapshot()
+		assert.Equal(t, int64(1), s.Routed)
+		assert.Equal(t, int64(1), s.Mismatches)
+	})
+
+	t.Run("新しい実装が失敗した場合は従来の実装で返す", func(t *testing.T) {
+		c := testCanary(100, 0.5)
+		_, candidate := impl("", errors.New("query failed"))
+		_, old := impl("old", nil)
+
+		result, err := runCanary(context.Background(), c, old, candidate)
+		require.NoError(t, err)
+
+		assert.Equal(t, "old", result)
+		assert.Equal(t, int64(1), c.snapshot().CandidateErrors)
+	})
+
+	t.Run("比較の同時実行数を超えた分は比べない", func(t *testing.T) {
+		c := testCanary(100, 0.5)
+		for i := 0; i < maxCanaryComparisons; i++ {
+			c.comparing <- struct{}{}
+		}
+		_, candidate := impl("new", nil)
+		calls, old := impl("old", nil)
+
+		result, err := runCanary(context.Background(), c, old, candidate)
+		require.NoError(t, err)
+
+		assert.Equal(t, "new", result)
+		assert.Zero(t, *calls)
+		assert.Equal(t, int64(1), c.snapshot().Skipped)
+	})
+
+	t.Run("設定がない場合は従来の実装のみ使う", func(t *testing.T) {
+		var canaries *Canaries
+		calls, old := impl("old", nil)
+
+		result, err := runCanary(context.Background(), canaries.get(CanaryValueChangeReport), old, old)
+		require.NoError(t, err)
+
+		assert.Equal(t, "old", result)
+		assert.Equal(t, 1, *calls)
+	})
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 型番の最大文字数（時計の reference_number 属性と同じ）
+const maxReferenceNumberLength = 32
+
+// CatalogProvider は型番からカタログの情報を引く（同梱のデータセットや外部のカタログ API で実装する）
+type CatalogProvider interface {
+	// Lookup は正規化した型番（entity.NormalizeReferenceNumber）のカタログの情報を返す。
+	// 見つからない場合は ErrCatalogEntryNotFound を返す
+	Lookup(ctx context.Context, ref string) (*entity.CatalogEntry, error)
+}
+
+// CatalogUsecase は型番からアイテムの登録内容（ブランド・モデル名・カテゴリー）の候補を返す
+type CatalogUsecase interface {
+	Lookup(ctx context.Context, ref string) (*entity.CatalogEntry, error)
+}
+
+type catalogUsecase struct {
+	provider CatalogProvider
+}
+
+func NewCatalogUsecase(provider CatalogProvider) CatalogUsecase {
+	return &catalogUsecase{
+		provider: provider,
+	}
+}
+
+func (u *catalogUsecase) Lookup(ctx context.Context, ref string) (*entity.CatalogEntry, error) {
+	if _, err := requireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	normalized := entity.NormalizeReferenceNumber(ref)
+	if normalized == "" {
+		return nil, fmt.Errorf("%w: ref is required", domainErrors.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(normalized) > maxReferenceNumberLength {
+		return nil, fmt.Errorf("%w: ref must be %d characters or less", domainErrors.ErrInvalidInput, maxReferenceNumberLength)
+	}
+
+	entry, err := u.provider.Lookup(ctx, normalized)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to look up catalog: %w", err)
+	}
+
+	// 型番の属性があるカテゴリー（時計）では、カタログの型番も属性の候補にする
+	schemas := CategoryAttributes[entry.Category]
+	if slices.ContainsFunc(schemas, func(s AttributeSchema) bool { return s.Name == entity.AttributeReferenceNumber }) {
+		if entry.Attributes == nil {
+			entry.Attributes = entity.ItemAttributes{}
+		}
+		if _, ok := entry.Attributes[entity.AttributeReferenceNumber]; !ok {
+			entry.Attributes[entity.AttributeReferenceNumber] = entry.ReferenceNumber
+		}
+	}
+
+	// カタログの属性はカテゴリーのスキーマに合うものだけを候補にする（カテゴリー名を変更した場合など）
+	if attrs, err := validateItemAttributes(entry.Category, entry.Attributes); err == nil {
+		entry.Attributes = attrs
+	} else {
+		entry.Attributes = entity.ItemAttributes{}
+	}
+	entry.Category = entity.LocalizeCategory(entry.Category, LanguageFromContext(ctx))
+
+	return entry, nil
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockCatalogProvider struct {
+	mock.Mock
+}
+
+func (m *MockCatalogProvider) Lookup(ctx context.Context, ref string) (*entity.CatalogEntry, error) {
+	args := m.Called(ctx, ref)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.CatalogEntry), args.Error(1)
+}
+
+func daytonaEntry() *entity.CatalogEntry {
+	return &entity.CatalogEntry{
+		ReferenceNumber: "116520",
+		Brand:           "ROLEX",
+		Model:           "コスモグラフ デイトナ",
+		Category:        "時計",
+		Attributes:      entity.ItemAttributes{entity.AttributeMovement: "自動巻き"},
+	}
+}
+
+func TestCatalogUsecase_Lookup(t *testing.T) {
+	t.Run("正常系: 正規化した型番で引く", func(t *testing.T) {
+		provider := new(MockCatalogProvider)
+		provider.On("Lookup", mock.Anything, "126610LN").Return(daytonaEntry(), nil)
+
+		_, err := NewCatalogUsecase(provider).Lookup(actorContext(), " 126610-ln ")
+		require.NoError(t, err)
+		provider.AssertExpectations(t)
+	})
+
+	t.Run("正常系: カテゴリーを表示言語の名前で返す", func(t *testing.T) {
+		loadDefaultCategories(t)
+		provider := new(MockCatalogProvider)
+		provider.On("Lookup", mock.Anything, "116520").Return(daytonaEntry(), nil)
+
+		entry, err := NewCatalogUsecase(provider).Lookup(WithLanguage(actorContext(), entity.LanguageEnglish), "116520")
+		require.NoError(t, err)
+		assert.Equal(t, "Watch", entry.Category)
+		assert.Equal(t, "ROLEX", entry.Brand)
+		// 時計の型番は属性の候補にもする
+		assert.Equal(t, entity.ItemAttributes{
+			entity.AttributeReferenceNumber: "116520",
+			entity.AttributeMovement:        "自動巻き",
+		}, entry.Attributes)
+	})
+
+	t.Run("正常系: カテゴリーのスキーマに合わない属性は返さない", func(t *testing.T) {
+		entry := daytonaEntry()
+		entry.Attributes[entity.AttributeMetal] = "K18"
+		provider := new(MockCatalogProvider)
+		provider.On("Lookup", mock.Anything, "116520").Return(entry, nil)
+
+		entry, err := NewCatalogUsecase(provider).Lookup(actorContext(), "116520")
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemAttributes{}, entry.Attributes)
+	})
+
+	t.Run("異常系: 型番の指定がない", func(t *testing.T) {
+		provider := new(MockCatalogProvider)
+
+		_, err := NewCatalogUsecase(provider).Lookup(actorContext(), " - ")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		provider.AssertNotCalled(t, "Lookup", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 長すぎる型番", func(t *testing.T) {
+		provider := new(MockCatalogProvider)
+
+		_, err := NewCatalogUsecase(provider).Lookup(actorContext(), "123456789012345678901234567890123")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: カタログにない型番", func(t *testing.T) {
+		provider := new(MockCatalogProvider)
+		provider.On("Lookup", mock.Anything, "000000").Return(nil, domainErrors.ErrCatalogEntryNotFound)
+
+		_, err := NewCatalogUsecase(provider).Lookup(actorContext(), "000000")
+		assert.ErrorIs(t, err, domainErrors.ErrCatalogEntryNotFound)
+	})
+
+	t.Run("異常系: 未認証", func(t *testing.T) {
+		provider := new(MockCatalogProvider)
+
+		_, err := NewCatalogUsecase(provider).Lookup(context.Background(), "116520")
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
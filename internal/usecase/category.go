@@ -0,0 +1,199 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CategoryUsecase はアイテムのカテゴリーを扱う。
+// カテゴリーは全ユーザーで共通のため、一覧は誰でも参照でき、変更は管理者のみが行える。
+// 変更するたびにアイテムの検証と表示に使うカテゴリー（entity.SetCategories）を読み込み直す
+type CategoryUsecase interface {
+	// Load は categories テーブルのカテゴリーをアイテムの検証に使うよう設定する（起動時に呼ぶ）
+	Load(ctx context.Context) error
+	List(ctx context.Context) ([]*entity.Category, error)
+	Create(ctx context.Context, input CategoryInput) (*entity.Category, error)
+	// Update はカテゴリー名（英語の表示名）とアイテムの既定値・必須項目を変更する。
+	// 名前を変えた場合は、そのカテゴリーのアイテムを新しい名前に付け替える
+	Update(ctx context.Context, id int64, input UpdateCategoryInput) (*entity.Category, error)
+	// Delete はアイテムのないカテゴリーを削除する（アイテムがある場合は ErrCategoryInUse）
+	Delete(ctx context.Context, id int64) error
+}
+
+type CategoryInput struct {
+	Name   string  `json:"name"`
+	NameEn *string `json:"name_en"`
+	// Defaults はアイテムの登録時に指定がない項目に使う既定値（省略時は既定値なし）
+	Defaults entity.CategoryDefaults `json:"defaults"`
+	// RequiredFields はこのカテゴリーのアイテムで追加で必須にする項目（省略時はなし）
+	RequiredFields []string `json:"required_fields"`
+}
+
+// UpdateCategoryInput はカテゴリーの変更内容（指定しない項目は変更しない。name_en は空文字で未設定に戻す）
+type UpdateCategoryInput struct {
+	Name   *string `json:"name"`
+	NameEn *string `json:"name_en"`
+	// Defaults は既定値を置き換える（{} で既定値をなくす）
+	Defaults *entity.CategoryDefaults `json:"defaults"`
+	// RequiredFields は必須項目を置き換える（[] で必須項目をなくす）
+	RequiredFields []string `json:"required_fields"`
+}
+
+type categoryUsecase struct {
+	categoryRepo CategoryRepository
+	now          func() time.Time
+}
+
+func NewCategoryUsecase(categoryRepo CategoryRepository) CategoryUsecase {
+	return &categoryUsecase{
+		categoryRepo: categoryRepo,
+		now:          time.Now,
+	}
+}
+
+func (u *categoryUsecase) Load(ctx context.Context) error {
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load categories: %w", err)
+	}
+
+	list := make([]entity.Category, 0, len(categories))
+	for _, category := range categories {
+		list = append(list, *category)
+	}
+	entity.SetCategories(list)
+
+	return nil
+}
+
+func (u *categoryUsecase) List(ctx context.Context) ([]*entity.Category, error) {
+	if _, err := requireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	return localizeCategories(ctx, categories...), nil
+}
+
+func (u *categoryUsecase) Create(ctx context.Context, input CategoryInput) (*entity.Category, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	category, err := entity.NewCategory(input.Name, input.NameEn, input.Defaults, input.RequiredFields, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if conflictsWithOthers(category) {
+		return nil, domainErrors.ErrDuplicateEntry
+	}
+
+	created, err := u.categoryRepo.Create(ctx, category)
+	if err != nil {
+		if domainErrors.IsDuplicateError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	if err := u.Load(ctx); err != nil {
+		return nil, err
+	}
+	return localizeCategories(ctx, created)[0], nil
+}
+
+func (u *categoryUsecase) Update(ctx context.Context, id int64, input UpdateCategoryInput) (*entity.Category, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	if input.Name == nil && input.NameEn == nil && input.Defaults == nil && input.RequiredFields == nil {
+		return nil, fmt.Errorf("%w: at least one field (name, name_en, defaults, required_fields) must be provided", domainErrors.ErrInvalidInput)
+	}
+
+	category, err := u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve category: %w", err)
+	}
+	if input.Name != nil {
+		category.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.NameEn != nil {
+		category.SetNameEn(*input.NameEn)
+	}
+	category.SetRules(input.Defaults, input.RequiredFields)
+	if err := category.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if conflictsWithOthers(category) {
+		return nil, domainErrors.ErrDuplicateEntry
+	}
+
+	// 名前の変更でアイテムのカテゴリーが変わるため、変更履歴に操作した管理者を記録する
+	actor, _ := ActorFromContext(ctx)
+	updated, err := u.categoryRepo.Update(ctx, id, category, actor.ID, u.now())
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsDuplicateError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	if err := u.Load(ctx); err != nil {
+		return nil, err
+	}
+	return localizeCategories(ctx, updated)[0], nil
+}
+
+func (u *categoryUsecase) Delete(ctx context.Context, id int64) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.categoryRepo.Delete(ctx, id); err != nil {
+		if domainErrors.IsNotFoundError(err) || domainErrors.IsCategoryInUseError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return u.Load(ctx)
+}
+
+// conflictsWithOthers は名前か英語の表示名がほかのカテゴリーと重なるかを判定する
+// （大文字・小文字だけが違う英語名は DB の一意制約では防げないため、ここで確認する）
+func conflictsWithOthers(category *entity.Category) bool {
+	for _, other := range entity.Categories() {
+		if other.ID != category.ID && category.ConflictsWith(&other) {
+			return true
+		}
+	}
+	return false
+}
+
+// localizeCategories はカテゴリーの表示名をリクエストの表示言語にする
+func localizeCategories(ctx context.Context, categories ...*entity.Category) []*entity.Category {
+	lang := LanguageFromContext(ctx)
+	for _, category := range categories {
+		category.DisplayName = category.Localized(lang)
+	}
+	return categories
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// applyCategoryDefaults は指定のないブランドと状態にカテゴリーの既定値を使う（管理者がカテゴリーに設定する）
+func applyCategoryDefaults(category string, brand, condition *string) {
+	c, ok := entity.FindCategory(entity.ResolveCategory(category))
+	if !ok {
+		return
+	}
+	if strings.TrimSpace(*brand) == "" {
+		*brand = c.Defaults.Brand
+	}
+	if strings.TrimSpace(*condition) == "" {
+		*condition = string(c.Defaults.Condition)
+	}
+}
+
+// requireCategoryFields はアイテムのカテゴリーで必須の項目が設定されているかを検証する。
+// fields を指定した場合はそのうちの必須の項目だけを検証する
+func requireCategoryFields(item *entity.Item, fields ...string) error {
+	c, ok := entity.FindCategory(item.Category)
+	if !ok {
+		return nil
+	}
+
+	var errs domainErrors.ValidationErrors
+	for _, field := range c.RequiredFields {
+		if len(fields) > 0 && !slices.Contains(fields, field) {
+			continue
+		}
+		if itemFieldValue(item, field) == "" {
+			errs.Add(field, domainErrors.CodeRequired, fmt.Sprintf("%s is required for category %s", field, item.Category))
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// itemFieldValue はカテゴリーで必須にできる項目の値（未設定は空文字）
+func itemFieldValue(item *entity.Item, field string) string {
+	switch field {
+	case "condition":
+		return string(item.Condition)
+	case entity.ItemFieldSerialNumber:
+		return item.SerialNumber
+	case "notes":
+		return item.Notes
+	}
+	return ""
+}
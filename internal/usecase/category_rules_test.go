@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// withCategoryRules は時計にシリアル番号を必須、その他にブランドの既定値を設定したカテゴリーを読み込んだ状態にする
+func withCategoryRules(t *testing.T) {
+	restoreValidCategories(t)
+	entity.SetCategories([]entity.Category{
+		{ID: 1, Name: "時計", RequiredFields: []string{entity.ItemFieldSerialNumber}},
+		{ID: 2, Name: "バッグ"},
+		{ID: 5, Name: "その他", Defaults: entity.CategoryDefaults{Brand: "不明", Condition: entity.ConditionGood}},
+	})
+}
+
+func TestItemUsecase_CreateItem_CategoryRules(t *testing.T) {
+	t.Run("正常系: 指定のないブランドと状態にカテゴリーの既定値を使う", func(t *testing.T) {
+		withCategoryRules(t)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Brand == "不明" && item.Condition == entity.ConditionGood
+		})).Return(&entity.Item{ID: 1, Category: "その他", Brand: "不明"}, nil)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(actorContext(), CreateItemInput{
+			Name:          "置き時計",
+			Category:      "その他",
+			PurchasePrice: entity.Decimal{Units: 5000},
+			PurchaseDate:  "2023-01-15",
+		})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 指定したブランドは既定値で上書きしない", func(t *testing.T) {
+		withCategoryRules(t)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Brand == "SEIKO" && item.Condition == entity.ConditionNew
+		})).Return(&entity.Item{ID: 1}, nil)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(actorContext(), CreateItemInput{
+			Name:          "置き時計",
+			Category:      "その他",
+			Brand:         "SEIKO",
+			Condition:     string(entity.ConditionNew),
+			PurchasePrice: entity.Decimal{Units: 5000},
+			PurchaseDate:  "2023-01-15",
+		})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: カテゴリーで必須の項目がない", func(t *testing.T) {
+		withCategoryRules(t)
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(actorContext(), CreateItemInput{
+			Name:          "ロレックス デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: entity.Decimal{Units: 1500000},
+			PurchaseDate:  "2023-01-15",
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, "serial_number", errs[0].Field)
+		assert.Equal(t, domainErrors.CodeRequired, errs[0].Code)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_UpdateItem_CategoryRules(t *testing.T) {
+	newWatch := func() *entity.Item {
+		item, _ := newOwnedItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		return item
+	}
+
+	t.Run("正常系: 必須項目のないアイテムでも他の項目は変更できる", func(t *testing.T) {
+		withCategoryRules(t)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newWatch(), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return(newWatch(), nil)
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(actorContext(), 1, UpdateItemInput{Name: stringPtr("デイトナ")})
+		require.NoError(t, err)
+	})
+
+	t.Run("異常系: 必須項目を未設定に戻す", func(t *testing.T) {
+		withCategoryRules(t)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newWatch(), nil)
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(actorContext(), 1, UpdateItemInput{SerialNumber: stringPtr("")})
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, "serial_number", errs[0].Field)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 置き換えでは必須項目をすべて検証する", func(t *testing.T) {
+		withCategoryRules(t)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newWatch(), nil)
+
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(actorContext(), 1, ReplaceItemInput{
+			Name:          "デイトナ",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: entity.Decimal{Units: 1500000},
+			PurchaseDate:  "2023-01-15",
+		})
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, "serial_number", errs[0].Field)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
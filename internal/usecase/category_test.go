@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) FindAll(ctx context.Context) ([]*entity.Category, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) FindByID(ctx context.Context, id int64) (*entity.Category, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Create(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Update(ctx context.Context, id int64, category *entity.Category, actorID int64, at time.Time) (*entity.Category, error) {
+	args := m.Called(ctx, id, category, actorID, at)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) Delete(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func adminContext() context.Context {
+	return WithActor(context.Background(), &entity.User{ID: 9, Email: "admin@example.com", Role: entity.RoleAdmin})
+}
+
+// categoriesOf は名前のカテゴリーを作成順に返す
+func categoriesOf(names ...string) []*entity.Category {
+	categories := make([]*entity.Category, 0, len(names))
+	for i, name := range names {
+		categories = append(categories, &entity.Category{ID: int64(i + 1), Name: name})
+	}
+	return categories
+}
+
+// restoreValidCategories はテストで変更したアイテムの検証に使うカテゴリーを元に戻す
+func restoreValidCategories(t *testing.T) {
+	original := entity.Categories()
+	t.Cleanup(func() { entity.SetCategories(original) })
+}
+
+// loadDefaultCategories は初期データのカテゴリーを ID 付きで読み込んだ状態にする
+func loadDefaultCategories(t *testing.T) []*entity.Category {
+	restoreValidCategories(t)
+	categories := make([]entity.Category, len(entity.DefaultCategories))
+	loaded := make([]*entity.Category, len(entity.DefaultCategories))
+	for i, c := range entity.DefaultCategories {
+		c.ID = int64(i + 1)
+		categories[i] = c
+		loaded[i] = &categories[i]
+	}
+	entity.SetCategories(categories)
+	return loaded
+}
+
+func stringPtrOf(s string) *string {
+	return &s
+}
+
+func TestCategoryUsecase_Load(t *testing.T) {
+	restoreValidCategories(t)
+	repo := new(MockCategoryRepository)
+	repo.On("FindAll", mock.Anything).Return(categoriesOf("時計", "カメラ"), nil)
+
+	require.NoError(t, NewCategoryUsecase(repo).Load(context.Background()))
+	assert.Equal(t, []string{"時計", "カメラ"}, entity.GetValidCategories())
+
+	// 読み込んだカテゴリーでアイテムを検証する
+	_, err := entity.NewItem("ライカ M6", "カメラ", "Leica", entity.JPY(500000), "2023-01-01")
+	assert.NoError(t, err)
+	_, err = entity.NewItem("エルメス バーキン", "バッグ", "HERMES", entity.JPY(500000), "2023-01-01")
+	assert.Error(t, err)
+}
+
+func TestCategoryUsecase_Create(t *testing.T) {
+	t.Run("正常系: 管理者は前後の空白を除いた名前で作成でき、アイテムの検証に反映される", func(t *testing.T) {
+		restoreValidCategories(t)
+		repo := new(MockCategoryRepository)
+		repo.On("Create", mock.Anything, mock.MatchedBy(func(c *entity.Category) bool { return c.Name == "カメラ" })).
+			Return(&entity.Category{ID: 6, Name: "カメラ"}, nil)
+		repo.On("FindAll", mock.Anything).Return(categoriesOf("時計", "バッグ", "ジュエリー", "靴", "その他", "カメラ"), nil)
+
+		created, err := NewCategoryUsecase(repo).Create(adminContext(), CategoryInput{Name: " カメラ "})
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), created.ID)
+		assert.Contains(t, entity.GetValidCategories(), "カメラ")
+	})
+
+	t.Run("異常系: 管理者以外は作成できない", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+
+		_, err := NewCategoryUsecase(repo).Create(actorContext(), CategoryInput{Name: "カメラ"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 空の名前", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+
+		_, err := NewCategoryUsecase(repo).Create(adminContext(), CategoryInput{Name: "  "})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 同じ名前のカテゴリー", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+
+		_, err := NewCategoryUsecase(repo).Create(adminContext(), CategoryInput{Name: "腕時計"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	})
+
+	t.Run("異常系: 英語名がほかのカテゴリーと大文字・小文字だけ違う", func(t *testing.T) {
+		loadDefaultCategories(t)
+		repo := new(MockCategoryRepository)
+
+		_, err := NewCategoryUsecase(repo).Create(adminContext(), CategoryInput{Name: "腕時計", NameEn: stringPtrOf("WATCH")})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCategoryUsecase_Update(t *testing.T) {
+	t.Run("正常系: 名前を変更すると古い名前ではアイテムを登録できない", func(t *testing.T) {
+		restoreValidCategories(t)
+		repo := new(MockCategoryRepository)
+		repo.On("FindByID", mock.Anything, int64(5)).Return(&entity.Category{ID: 5, Name: "その他"}, nil)
+		repo.On("Update", mock.Anything, int64(5), mock.MatchedBy(func(c *entity.Category) bool {
+			return c.Name == "雑貨" && c.NameEn == nil
+		}), int64(9), mock.Anything).Return(&entity.Category{ID: 5, Name: "雑貨"}, nil)
+		repo.On("FindAll", mock.Anything).Return(categoriesOf("時計", "バッグ", "ジュエリー", "靴", "雑貨"), nil)
+
+		renamed, err := NewCategoryUsecase(repo).Update(adminContext(), 5, UpdateCategoryInput{Name: stringPtrOf("雑貨")})
+		require.NoError(t, err)
+		assert.Equal(t, "雑貨", renamed.Name)
+		assert.NotContains(t, entity.GetValidCategories(), "その他")
+	})
+
+	t.Run("正常系: 英語名だけを変更する", func(t *testing.T) {
+		defaults := loadDefaultCategories(t)
+		repo := new(MockCategoryRepository)
+		repo.On("FindByID", mock.Anything, int64(1)).Return(defaults[0], nil)
+		repo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(c *entity.Category) bool {
+			return c.Name == "時計" && *c.NameEn == "Timepiece"
+		}), mock.Anything, mock.Anything).Return(&entity.Category{ID: 1, Name: "時計", NameEn: stringPtrOf("Timepiece")}, nil)
+		repo.On("FindAll", mock.Anything).Return(defaults, nil)
+
+		ctx := WithLanguage(adminContext(), entity.LanguageEnglish)
+		renamed, err := NewCategoryUsecase(repo).Update(ctx, 1, UpdateCategoryInput{NameEn: stringPtrOf(" Timepiece ")})
+		require.NoError(t, err)
+		assert.Equal(t, "Timepiece", renamed.DisplayName)
+	})
+
+	t.Run("正常系: 既定値と必須項目を設定するとアイテムの登録に使われる", func(t *testing.T) {
+		defaults := loadDefaultCategories(t)
+		updated := &entity.Category{ID: 1, Name: "時計", Defaults: entity.CategoryDefaults{Brand: "不明"}, RequiredFields: []string{"serial_number"}}
+		repo := new(MockCategoryRepository)
+		repo.On("FindByID", mock.Anything, int64(1)).Return(defaults[0], nil)
+		repo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(c *entity.Category) bool {
+			return c.Name == "時計" && c.Defaults.Brand == "不明" && assert.ObjectsAreEqual([]string{"serial_number"}, c.RequiredFields)
+		}), mock.Anything, mock.Anything).Return(updated, nil)
+		repo.On("FindAll", mock.Anything).Return([]*entity.Category{updated}, nil)
+
+		category, err := NewCategoryUsecase(repo).Update(adminContext(), 1, UpdateCategoryInput{
+			Defaults:       &entity.CategoryDefaults{Brand: " 不明 "},
+			RequiredFields: []string{"serial_number"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"serial_number"}, category.RequiredFields)
+		loaded, ok := entity.FindCategory("時計")
+		require.True(t, ok)
+		assert.Equal(t, "不明", loaded.Defaults.Brand)
+	})
+
+	t.Run("異常系: 必須にできない項目", func(t *testing.T) {
+		defaults := loadDefaultCategories(t)
+		repo := new(MockCategoryRepository)
+		repo.On("FindByID", mock.Anything, int64(1)).Return(defaults[0], nil)
+
+		_, err := NewCategoryUsecase(repo).Update(adminContext(), 1, UpdateCategoryInput{RequiredFields: []string{"purchase_price"}})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, "required_fields", errs[0].Field)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 変更内容がない", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+
+		_, err := NewCategoryUsecase(repo).Update(adminContext(), 5, UpdateCategoryInput{})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 存在しないカテゴリー", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrCategoryNotFound)
+
+		_, err := NewCategoryUsecase(repo).Update(adminContext(), 99, UpdateCategoryInput{Name: stringPtrOf("雑貨")})
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+	})
+}
+
+func TestCategoryUsecase_List(t *testing.T) {
+	loadDefaultCategories(t)
+	repo := new(MockCategoryRepository)
+	repo.On("FindAll", mock.Anything).Return([]*entity.Category{
+		{ID: 1, Name: "時計", NameEn: stringPtrOf("Watch")},
+		{ID: 2, Name: "カメラ"},
+	}, nil)
+
+	// 日本語（Accept-Language の指定なし）
+	categories, err := NewCategoryUsecase(repo).List(actorContext())
+	require.NoError(t, err)
+	assert.Equal(t, "時計", categories[0].DisplayName)
+
+	// 英語名が未設定のカテゴリーは日本語の名前
+	categories, err = NewCategoryUsecase(repo).List(WithLanguage(actorContext(), entity.LanguageEnglish))
+	require.NoError(t, err)
+	assert.Equal(t, "Watch", categories[0].DisplayName)
+	assert.Equal(t, "カメラ", categories[1].DisplayName)
+}
+
+func TestCategoryUsecase_Delete(t *testing.T) {
+	t.Run("異常系: アイテムのあるカテゴリーは削除できない", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+		repo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrCategoryInUse)
+
+		err := NewCategoryUsecase(repo).Delete(adminContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryInUse)
+		repo.AssertNotCalled(t, "FindAll", mock.Anything)
+	})
+
+	t.Run("異常系: 管理者以外は削除できない", func(t *testing.T) {
+		repo := new(MockCategoryRepository)
+
+		err := NewCategoryUsecase(repo).Delete(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CertificateRenderer は証明書を帳票（PDF）に変換する
+type CertificateRenderer interface {
+	Render(cert *Certificate) ([]byte, error)
+}
+
+// Certificate はアイテムの評価証明書の内容
+type Certificate struct {
+	Item     *entity.Item
+	IssuedAt time.Time
+	// Code は証明書の検証コード（アイテムの内容と発行日時の署名を含む）
+	Code            string
+	VerificationURL string
+}
+
+// CertificateVerification は検証コードの照合結果（価格は公開しない）
+type CertificateVerification struct {
+	Valid    bool             `json:"valid"`
+	Reason   string           `json:"reason,omitempty"`
+	IssuedAt time.Time        `json:"issued_at"`
+	Item     *CertificateItem `json:"item"`
+}
+
+type CertificateItem struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Category     string `json:"category"`
+	Brand        string `json:"brand"`
+	PurchaseDate string `json:"purchase_date"`
+}
+
+type CertificateUsecase interface {
+	// Generate は操作を行うユーザーのアイテムの証明書PDFを生成する
+	Generate(ctx context.Context, itemID int64) ([]byte, error)
+	// Verify は検証コードを照合する（認証不要）
+	Verify(ctx context.Context, code string) (*CertificateVerification, error)
+}
+
+type certificateUsecase struct {
+	itemRepo  ItemRepository
+	renderer  CertificateRenderer
+	secret    []byte
+	verifyURL string
+	now       func() time.Time
+}
+
+// NewCertificateUsecase は secret で検証コードに署名し、verifyURL（例: https://example.com/certificates/verify）を
+// QRコードのリンク先とする CertificateUsecase を返す
+func NewCertificateUsecase(itemRepo ItemRepository, renderer CertificateRenderer, secret, verifyURL string) CertificateUsecase {
+	return &certificateUsecase{
+		itemRepo:  itemRepo,
+		renderer:  renderer,
+		secret:    []byte(secret),
+		verifyURL: verifyURL,
+		now:       time.Now,
+	}
+}
+
+func (u *certificateUsecase) Generate(ctx context.Context, itemID int64) ([]byte, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := findItemForActor(ctx, u.itemRepo, actor, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	// 証明書には評価額と購入日を記載し署名するため、どちらかが非表示の場合は発行しない
+	if err := requireUnredacted(actor, item, entity.ItemFieldPurchasePrice, entity.ItemFieldPurchaseDate); err != nil {
+		return nil, err
+	}
+
+	// 署名は秒単位の発行日時に対して行う
+	issuedAt := u.now().Truncate(time.Second)
+	code := u.sign(item, issuedAt)
+	cert := &Certificate{
+		Item:            item,
+		IssuedAt:        issuedAt,
+		Code:            code,
+		VerificationURL: u.verifyURL + "?code=" + url.QueryEscape(code),
+	}
+
+	pdf, err := u.renderer.Render(cert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render certificate: %w", err)
+	}
+
+	return pdf, nil
+}
+
+func (u *certificateUsecase) Verify(ctx context.Context, code string) (*CertificateVerification, error) {
+	itemID, issuedAt, ok := parseCertificateCode(code)
+	if !ok {
+		return nil, fmt.Errorf("%w: code is malformed", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	verification := &CertificateVerification{IssuedAt: issuedAt}
+	// アイテムが発行後に変更された場合も署名が一致しなくなる
+	if !hmac.Equal([]byte(u.sign(item, issuedAt)), []byte(code)) {
+		verification.Reason = "certificate does not match the current item"
+		return verification, nil
+	}
+
+	verification.Valid = true
+	verification.Item = &CertificateItem{
+		ID:           item.ID,
+		Name:         item.Name,
+		Category:     item.Category,
+		Brand:        item.Brand,
+		PurchaseDate: item.PurchaseDate,
+	}
+	return verification, nil
+}
+
+// sign は「アイテムID-発行日時(UNIX秒)-署名」形式の検証コードを返す
+func (u *certificateUsecase) sign(item *entity.Item, issuedAt time.Time) string {
+	mac := hmac.New(sha256.New, u.secret)
+	fmt.Fprintf(mac, "%d\n%d\n%s\n%s\n%s\n%s\n%s",
+		item.ID, issuedAt.Unix(), item.Name, item.Category, item.Brand, signedPrice(item.PurchasePrice), item.PurchaseDate)
+	return fmt.Sprintf("%d-%d-%s", item.ID, issuedAt.Unix(), hex.EncodeToString(mac.Sum(nil)[:16]))
+}
+
+// signedPrice は署名する購入価格。円建ては通貨を含めず、通貨の導入前に発行した検証コードを有効なままにする
+func signedPrice(price entity.Money) string {
+	if price.IsJPY() {
+		return strconv.Itoa(price.Amount)
+	}
+	return fmt.Sprintf("%d %s", price.Amount, price.Currency)
+}
+
+func parseCertificateCode(code string) (int64, time.Time, bool) {
+	parts := strings.Split(code, "-")
+	if len(parts) != 3 || len(parts[2]) != 32 {
+		return 0, time.Time{}, false
+	}
+	itemID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || itemID <= 0 {
+		return 0, time.Time{}, false
+	}
+	issued, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+	return itemID, time.Unix(issued, 0), true
+}
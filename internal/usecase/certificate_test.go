@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockCertificateRenderer struct {
+	mock.Mock
+}
+
+func (m *MockCertificateRenderer) Render(cert *Certificate) ([]byte, error) {
+	args := m.Called(cert)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+var certificateIssuedAt = time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+
+func newTestCertificateUsecase(itemRepo ItemRepository, renderer CertificateRenderer) *certificateUsecase {
+	u := NewCertificateUsecase(itemRepo, renderer, "certificate-secret", "https://example.com/certificates/verify").(*certificateUsecase)
+	u.now = func() time.Time { return certificateIssuedAt }
+	return u
+}
+
+func TestCertificateUsecase_Generate(t *testing.T) {
+	item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	item.ID = 1
+
+	t.Run("正常系: 検証コードとQRコードのURLを含む証明書を描画する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		renderer := new(MockCertificateRenderer)
+		var rendered *Certificate
+		renderer.On("Render", mock.AnythingOfType("*usecase.Certificate")).
+			Run(func(args mock.Arguments) { rendered = args.Get(0).(*Certificate) }).
+			Return([]byte("%PDF"), nil)
+		usecase := newTestCertificateUsecase(itemRepo, renderer)
+
+		pdf, err := usecase.Generate(actorContext(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, []byte("%PDF"), pdf)
+		require.NotNil(t, rendered)
+		assert.Equal(t, item, rendered.Item)
+		assert.Equal(t, certificateIssuedAt, rendered.IssuedAt)
+		assert.True(t, strings.HasPrefix(rendered.Code, "1-1714559400-"))
+		assert.Equal(t, "https://example.com/certificates/verify?code="+rendered.Code, rendered.VerificationURL)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテム", func(t *testing.T) {
+		other := *item
+		other.UserID = 99
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&other, nil)
+		renderer := new(MockCertificateRenderer)
+		usecase := newTestCertificateUsecase(itemRepo, renderer)
+
+		_, err := usecase.Generate(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		renderer.AssertNotCalled(t, "Render", mock.Anything)
+	})
+
+	t.Run("異常系: 描画に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		renderer := new(MockCertificateRenderer)
+		renderer.On("Render", mock.Anything).Return(nil, errors.New("render failed"))
+		usecase := newTestCertificateUsecase(itemRepo, renderer)
+
+		_, err := usecase.Generate(actorContext(), 1)
+		assert.ErrorContains(t, err, "failed to render certificate")
+	})
+
+	t.Run("異常系: 操作者が未設定", func(t *testing.T) {
+		usecase := newTestCertificateUsecase(new(MockItemRepository), new(MockCertificateRenderer))
+
+		_, err := usecase.Generate(context.Background(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
+
+func TestCertificateUsecase_Verify(t *testing.T) {
+	item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	item.ID = 1
+	usecase := newTestCertificateUsecase(nil, nil)
+	code := usecase.sign(item, certificateIssuedAt)
+
+	t.Run("正常系: 発行時と同じアイテムなら有効（価格は含めない）", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		usecase := newTestCertificateUsecase(itemRepo, nil)
+
+		result, err := usecase.Verify(context.Background(), code)
+		require.NoError(t, err)
+
+		assert.True(t, result.Valid)
+		assert.True(t, certificateIssuedAt.Equal(result.IssuedAt))
+		assert.Equal(t, &CertificateItem{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01"}, result.Item)
+	})
+
+	t.Run("正常系: 発行後に価格が変更されたアイテムは無効", func(t *testing.T) {
+		changed := *item
+		changed.PurchasePrice = entity.JPY(1)
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&changed, nil)
+		usecase := newTestCertificateUsecase(itemRepo, nil)
+
+		result, err := usecase.Verify(context.Background(), code)
+		require.NoError(t, err)
+
+		assert.False(t, result.Valid)
+		assert.Nil(t, result.Item)
+	})
+
+	t.Run("異常系: 形式が不正なコード", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		usecase := newTestCertificateUsecase(itemRepo, nil)
+
+		for _, code := range []string{"", "abc", "1-2", "x-1714559400-00112233445566778899aabbccddeeff"} {
+			_, err := usecase.Verify(context.Background(), code)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput, code)
+		}
+		itemRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 削除されたアイテム", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+		usecase := newTestCertificateUsecase(itemRepo, nil)
+
+		_, err := usecase.Verify(context.Background(), code)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
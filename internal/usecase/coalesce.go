@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"sync/atomic"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CoalescingStats は同時に実行された同じ読み取りをまとめた回数を数える
+type CoalescingStats struct {
+	requests  atomic.Int64
+	coalesced atomic.Int64
+}
+
+// CoalescingSnapshot は CoalescingStats のある時点の値
+type CoalescingSnapshot struct {
+	// Requests は読み取りの呼び出し回数
+	Requests int64 `json:"requests"`
+	// Coalesced は実行中の読み取りの結果を共有した（リポジトリを呼ばなかった）回数
+	Coalesced int64 `json:"coalesced"`
+}
+
+// NewCoalescingStats は CoalescingStats を作成する
+func NewCoalescingStats() *CoalescingStats {
+	return &CoalescingStats{}
+}
+
+// Snapshot は現在の回数を返す
+func (s *CoalescingStats) Snapshot() CoalescingSnapshot {
+	return CoalescingSnapshot{Requests: s.requests.Load(), Coalesced: s.coalesced.Load()}
+}
+
+// coalesce は同じ key の fn が実行中であればその結果を待って共有する。
+// fn は最初の呼び出し元のキャンセルの影響を受けないよう context.WithoutCancel の ctx で実行し、
+// 各呼び出し元は自分の ctx がキャンセルされた時点で待つのをやめる
+func coalesce[T any](ctx context.Context, group *singleflight.Group, stats *CoalescingStats, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	stats.requests.Add(1)
+	executed := false
+	ch := group.DoChan(key, func() (any, error) {
+		executed = true
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	var zero T
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case res := <-ch:
+		if !executed {
+			stats.coalesced.Add(1)
+		}
+		if res.Err != nil {
+			return zero, res.Err
+		}
+		return res.Val.(T), nil
+	}
+}
@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// waitForRequests は n 件の呼び出しが集計を待ち始めるまで待つ
+func waitForRequests(t *testing.T, stats *CoalescingStats, n int64) {
+	t.Helper()
+	require.Eventually(t, func() bool { return stats.Snapshot().Requests == n }, time.Second, time.Millisecond)
+	// 回数を数えてから実行中の集計に合流するまでのわずかな間を待つ
+	time.Sleep(20 * time.Millisecond)
+}
+
+func TestItemUsecase_GetCategorySummary_Coalescing(t *testing.T) {
+	values := []entity.CategoryValue{{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000}}
+
+	t.Run("正常系: 同時に実行された同じ集計はリポジトリの呼び出しを共有する", func(t *testing.T) {
+		release := make(chan struct{})
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).
+			Run(func(mock.Arguments) { <-release }).Return(values, nil).Once()
+		stats := NewCoalescingStats()
+		u := NewItemUsecase(mockRepo, WithCoalescingStats(stats))
+
+		const callers = 5
+		var wg sync.WaitGroup
+		results := make([]*CategorySummary, callers)
+		errs := make([]error, callers)
+		for i := 0; i < callers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], errs[i] = u.GetCategorySummary(actorContext(), "")
+			}(i)
+		}
+		waitForRequests(t, stats, callers)
+		close(release)
+		wg.Wait()
+
+		for i := 0; i < callers; i++ {
+			require.NoError(t, errs[i])
+			assert.Equal(t, 2, results[i].Categories["時計"])
+			assert.Equal(t, int64(3000000), results[i].TotalValue)
+		}
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+		assert.Equal(t, CoalescingSnapshot{Requests: callers, Coalesced: callers - 1}, stats.Snapshot())
+	})
+
+	t.Run("正常系: 集計が終わった後の呼び出しはリポジトリを呼び直す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		stats := NewCoalescingStats()
+		u := NewItemUsecase(mockRepo, WithCoalescingStats(stats))
+
+		for i := 0; i < 2; i++ {
+			_, err := u.GetCategorySummary(actorContext(), "")
+			require.NoError(t, err)
+		}
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+		assert.Equal(t, CoalescingSnapshot{Requests: 2, Coalesced: 0}, stats.Snapshot())
+	})
+
+	t.Run("正常系: 範囲の異なる集計はまとめない", func(t *testing.T) {
+		release := make(chan struct{})
+		admin := &entity.User{ID: 2, Email: "admin@example.com", Role: entity.RoleAdmin}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, mock.Anything).
+			Run(func(mock.Arguments) { <-release }).Return(values, nil)
+		stats := NewCoalescingStats()
+		u := NewItemUsecase(mockRepo, WithCoalescingStats(stats))
+
+		var wg sync.WaitGroup
+		for _, actor := range []*entity.User{testActor, admin} {
+			wg.Add(1)
+			go func(actor *entity.User) {
+				defer wg.Done()
+				_, err := u.GetCategorySummary(WithActor(context.Background(), actor), "")
+				assert.NoError(t, err)
+			}(actor)
+		}
+		waitForRequests(t, stats, 2)
+		close(release)
+		wg.Wait()
+
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+		assert.Equal(t, int64(0), stats.Snapshot().Coalesced)
+	})
+
+	t.Run("正常系: 最初の呼び出し元がキャンセルしても合流した呼び出しは結果を受け取る", func(t *testing.T) {
+		started := make(chan struct{})
+		release := make(chan struct{})
+		var repoCtxErr error
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).
+			Run(func(args mock.Arguments) {
+				close(started)
+				<-release
+				repoCtxErr = args.Get(0).(context.Context).Err()
+			}).Return(values, nil).Once()
+		stats := NewCoalescingStats()
+		u := NewItemUsecase(mockRepo, WithCoalescingStats(stats))
+
+		leaderCtx, cancel := context.WithCancel(actorContext())
+		leaderErr := make(chan error, 1)
+		go func() {
+			_, err := u.GetCategorySummary(leaderCtx, "")
+			leaderErr <- err
+		}()
+		<-started
+
+		followerDone := make(chan *CategorySummary, 1)
+		go func() {
+			summary, err := u.GetCategorySummary(actorContext(), "")
+			assert.NoError(t, err)
+			followerDone <- summary
+		}()
+		waitForRequests(t, stats, 2)
+
+		cancel()
+		assert.ErrorIs(t, <-leaderErr, context.Canceled)
+		close(release)
+
+		summary := <-followerDone
+		require.NotNil(t, summary)
+		assert.Equal(t, 2, summary.Categories["時計"])
+		assert.NoError(t, repoCtxErr)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+	})
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// バックフィルで1回に更新する行の数（ロックを短くするため小さめにする）
+const columnBackfillBatchSize = 1000
+
+type ColumnMigrationUsecase interface {
+	// List は定義されている列の移行の段階・比較の件数・最後のバックフィルの結果を返す（管理者のみ）
+	List(ctx context.Context) ([]*entity.ColumnMigrationStatus, error)
+	// StartBackfill は既存の行の新列を旧列から埋めて検証するジョブを開始する（管理者のみ）。
+	// バックフィル中に書き込まれた行も新列に反映されるよう、段階が dual_write 以降の場合のみ実行できる
+	StartBackfill(ctx context.Context, name string) (*entity.Job, error)
+}
+
+type columnMigrationUsecase struct {
+	migrationRepo ColumnMigrationRepository
+	jobs          JobUsecase
+	now           func() time.Time
+	// バッチの間隔（負荷を抑えるため。0 は待たない）
+	pause time.Duration
+
+	mu           sync.Mutex
+	lastBackfill map[string]*entity.ColumnBackfillResult
+}
+
+func NewColumnMigrationUsecase(migrationRepo ColumnMigrationRepository, jobs JobUsecase, pause time.Duration) ColumnMigrationUsecase {
+	return &columnMigrationUsecase{
+		migrationRepo: migrationRepo,
+		jobs:          jobs,
+		now:           time.Now,
+		pause:         pause,
+		lastBackfill:  make(map[string]*entity.ColumnBackfillResult),
+	}
+}
+
+func (u *columnMigrationUsecase) List(ctx context.Context) ([]*entity.ColumnMigrationStatus, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	statuses := make([]*entity.ColumnMigrationStatus, 0, len(entity.ColumnMigrations))
+	for _, m := range entity.ColumnMigrations {
+		status := m.Status()
+		if result, ok := u.lastBackfill[m.Name]; ok {
+			copied := *result
+			copied.MismatchIDs = append([]int64{}, result.MismatchIDs...)
+			status.LastBackfill = &copied
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+func (u *columnMigrationUsecase) StartBackfill(ctx context.Context, name string) (*entity.Job, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.IsAdmin() {
+		return nil, domainErrors.ErrForbidden
+	}
+
+	m := entity.FindColumnMigration(name)
+	if m == nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrColumnMigrationNotFound, name)
+	}
+	if !m.Phase().WritesNew() {
+		return nil, fmt.Errorf("%w: column migration %s is in phase %s, switch it to dual_write before backfilling", domainErrors.ErrInvalidInput, name, m.Phase())
+	}
+
+	return u.jobs.Submit(ctx, strconv.FormatInt(actor.ID, 10), entity.JobKindColumnBackfill, func(ctx context.Context, job *entity.Job) error {
+		return u.backfill(ctx, m)
+	})
+}
+
+// backfill は ID の順にバッチごとに新列を埋め、同じ範囲を比べて検証する。
+// 不一致の行はジョブを失敗させず結果に記録する（段階を進める前に原因を調べる）
+func (u *columnMigrationUsecase) backfill(ctx context.Context, m *entity.ColumnMigration) error {
+	result := &entity.ColumnBackfillResult{StartedAt: u.now(), MismatchIDs: []int64{}}
+	u.saveBackfill(m.Name, result)
+
+	err := u.backfillBatches(ctx, m, result)
+	finishedAt := u.now()
+	u.mu.Lock()
+	result.FinishedAt = &finishedAt
+	if err != nil {
+		result.Error = err.Error()
+	}
+	u.mu.Unlock()
+	return err
+}
+
+func (u *columnMigrationUsecase) backfillBatches(ctx context.Context, m *entity.ColumnMigration, result *entity.ColumnBackfillResult) error {
+	// 開始時点の行の数を進捗の目安にする（開始後に追加された行は二重書き込みで埋まっている）
+	total, _, err := u.migrationRepo.NextBatch(ctx, m, 0, math.MaxInt32)
+	if err != nil {
+		return fmt.Errorf("failed to count rows: %w", err)
+	}
+
+	var afterID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		count, lastID, err := u.migrationRepo.NextBatch(ctx, m, afterID, columnBackfillBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to find rows after id %d: %w", afterID, err)
+		}
+		if count == 0 {
+			return nil
+		}
+
+		updated, err := u.migrationRepo.Backfill(ctx, m, afterID, lastID)
+		if err != nil {
+			return fmt.Errorf("failed to backfill ids %d-%d: %w", afterID+1, lastID, err)
+		}
+		ids, mismatches, err := u.migrationRepo.Mismatches(ctx, m, afterID, lastID, entity.MaxColumnMismatchIDs)
+		if err != nil {
+			return fmt.Errorf("failed to verify ids %d-%d: %w", afterID+1, lastID, err)
+		}
+
+		u.mu.Lock()
+		result.Scanned += count
+		result.Updated += updated
+		result.Mismatches += mismatches
+		for _, id := range ids {
+			if len(result.MismatchIDs) < entity.MaxColumnMismatchIDs {
+				result.MismatchIDs = append(result.MismatchIDs, id)
+			}
+		}
+		result.LastScannedID = lastID
+		u.mu.Unlock()
+		if mismatches > 0 {
+			ReportJobChunkError(ctx, fmt.Sprintf("ids %d-%d", afterID+1, lastID), fmt.Errorf("%d rows differ after backfill", mismatches))
+		}
+		ReportJobProgress(ctx, int(min(result.Scanned, total)), int(max(total, 1)))
+
+		afterID = lastID
+		if u.pause > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(u.pause):
+			}
+		}
+	}
+}
+
+func (u *columnMigrationUsecase) saveBackfill(name string, result *entity.ColumnBackfillResult) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.lastBackfill[name] = result
+}
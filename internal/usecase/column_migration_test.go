@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockColumnMigrationRepository struct {
+	mock.Mock
+}
+
+func (m *MockColumnMigrationRepository) NextBatch(ctx context.Context, migration *entity.ColumnMigration, afterID int64, limit int) (int64, int64, error) {
+	args := m.Called(ctx, migration, afterID, limit)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockColumnMigrationRepository) Backfill(ctx context.Context, migration *entity.ColumnMigration, afterID, throughID int64) (int64, error) {
+	args := m.Called(ctx, migration, afterID, throughID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockColumnMigrationRepository) Mismatches(ctx context.Context, migration *entity.ColumnMigration, afterID, throughID int64, limit int) ([]int64, int64, error) {
+	args := m.Called(ctx, migration, afterID, throughID, limit)
+	return args.Get(0).([]int64), args.Get(1).(int64), args.Error(2)
+}
+
+// testColumnMigration はテストの間だけ定義する列の移行を返す
+func testColumnMigration(t *testing.T, phase entity.ColumnMigrationPhase) *entity.ColumnMigration {
+	t.Helper()
+	m := &entity.ColumnMigration{
+		Name:      "items.purchased_on",
+		Table:     "items",
+		OldColumn: "purchase_date_text",
+		NewColumn: "purchased_on",
+		Convert:   "STR_TO_DATE(purchase_date_text, '%Y-%m-%d')",
+	}
+	m.SetPhase(phase)
+	saved := entity.ColumnMigrations
+	entity.ColumnMigrations = []*entity.ColumnMigration{m}
+	t.Cleanup(func() { entity.ColumnMigrations = saved })
+	return m
+}
+
+func TestColumnMigrationUsecase_StartBackfill(t *testing.T) {
+	t.Run("正常系: バッチごとに埋めて検証し、不一致を結果に記録する", func(t *testing.T) {
+		m := testColumnMigration(t, entity.ColumnMigrationPhaseDualWrite)
+		repo := new(MockColumnMigrationRepository)
+		repo.On("NextBatch", mock.Anything, m, int64(0), math.MaxInt32).Return(int64(1500), int64(1800), nil).Once()
+		repo.On("NextBatch", mock.Anything, m, int64(0), columnBackfillBatchSize).Return(int64(1000), int64(1200), nil).Once()
+		repo.On("Backfill", mock.Anything, m, int64(0), int64(1200)).Return(int64(990), nil)
+		repo.On("Mismatches", mock.Anything, m, int64(0), int64(1200), entity.MaxColumnMismatchIDs).Return([]int64{}, int64(0), nil)
+		repo.On("NextBatch", mock.Anything, m, int64(1200), columnBackfillBatchSize).Return(int64(500), int64(1800), nil).Once()
+		repo.On("Backfill", mock.Anything, m, int64(1200), int64(1800)).Return(int64(500), nil)
+		repo.On("Mismatches", mock.Anything, m, int64(1200), int64(1800), entity.MaxColumnMismatchIDs).Return([]int64{1301}, int64(1), nil)
+		repo.On("NextBatch", mock.Anything, m, int64(1800), columnBackfillBatchSize).Return(int64(0), int64(0), nil).Once()
+
+		jobs := NewJobUsecase()
+		u := NewColumnMigrationUsecase(repo, jobs, 0)
+		job, err := u.StartBackfill(adminContext(), "items.purchased_on")
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobKindColumnBackfill, job.Kind)
+
+		finished := waitForJob(t, jobs, "9", job.ID)
+		assert.Equal(t, entity.JobStatusSucceeded, finished.Status)
+		// 不一致はジョブを失敗させず errors に記録する
+		require.Len(t, finished.Errors, 1)
+		assert.Equal(t, "ids 1201-1800", finished.Errors[0].Chunk)
+
+		statuses, err := u.List(adminContext())
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		result := statuses[0].LastBackfill
+		require.NotNil(t, result)
+		assert.Equal(t, int64(1500), result.Scanned)
+		assert.Equal(t, int64(1490), result.Updated)
+		assert.Equal(t, int64(1), result.Mismatches)
+		assert.Equal(t, []int64{1301}, result.MismatchIDs)
+		assert.Equal(t, int64(1800), result.LastScannedID)
+		assert.NotNil(t, result.FinishedAt)
+		assert.Empty(t, result.Error)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 途中の失敗は結果に記録してジョブを失敗にする", func(t *testing.T) {
+		m := testColumnMigration(t, entity.ColumnMigrationPhaseDualRead)
+		repo := new(MockColumnMigrationRepository)
+		repo.On("NextBatch", mock.Anything, m, int64(0), mock.Anything).Return(int64(10), int64(10), nil)
+		repo.On("Backfill", mock.Anything, m, int64(0), int64(10)).Return(int64(0), errors.New("lock wait timeout"))
+
+		jobs := NewJobUsecase()
+		u := NewColumnMigrationUsecase(repo, jobs, 0)
+		job, err := u.StartBackfill(adminContext(), "items.purchased_on")
+		require.NoError(t, err)
+
+		finished := waitForJob(t, jobs, "9", job.ID)
+		assert.Equal(t, entity.JobStatusFailed, finished.Status)
+		statuses, err := u.List(adminContext())
+		require.NoError(t, err)
+		assert.Contains(t, statuses[0].LastBackfill.Error, "lock wait timeout")
+	})
+
+	t.Run("異常系: 二重書き込みの前はバックフィルできない", func(t *testing.T) {
+		testColumnMigration(t, entity.ColumnMigrationPhaseOld)
+		repo := new(MockColumnMigrationRepository)
+
+		_, err := NewColumnMigrationUsecase(repo, NewJobUsecase(), 0).StartBackfill(adminContext(), "items.purchased_on")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		repo.AssertNotCalled(t, "NextBatch", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 定義のない移行", func(t *testing.T) {
+		testColumnMigration(t, entity.ColumnMigrationPhaseDualWrite)
+
+		_, err := NewColumnMigrationUsecase(new(MockColumnMigrationRepository), NewJobUsecase(), 0).StartBackfill(adminContext(), "items.unknown")
+		assert.ErrorIs(t, err, domainErrors.ErrColumnMigrationNotFound)
+	})
+
+	t.Run("異常系: 管理者以外", func(t *testing.T) {
+		testColumnMigration(t, entity.ColumnMigrationPhaseDualWrite)
+		u := NewColumnMigrationUsecase(new(MockColumnMigrationRepository), NewJobUsecase(), 0)
+
+		_, err := u.StartBackfill(actorContext(), "items.purchased_on")
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		_, err = u.List(actorContext())
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestColumnMigrationUsecase_List(t *testing.T) {
+	m := testColumnMigration(t, entity.ColumnMigrationPhaseDualRead)
+	m.RecordReads(4, []int64{3})
+
+	statuses, err := NewColumnMigrationUsecase(new(MockColumnMigrationRepository), NewJobUsecase(), 0).List(adminContext())
+	require.NoError(t, err)
+
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "items.purchased_on", statuses[0].Name)
+	assert.Equal(t, entity.ColumnMigrationPhaseDualRead, statuses[0].Phase)
+	assert.Equal(t, int64(4), statuses[0].ReadsCompared)
+	assert.Equal(t, int64(1), statuses[0].ReadMismatches)
+	assert.Nil(t, statuses[0].LastBackfill)
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CommentUsecase はアイテムへのコメントを扱う。
+// アイテムを参照できるユーザー（所有者・組織のメンバーと管理者）はコメントを読み書きでき、閲覧者もコメントできる
+type CommentUsecase interface {
+	List(ctx context.Context, itemID int64) ([]*entity.Comment, error)
+	// Create はコメントを投稿し、メンションされたユーザーに通知する
+	Create(ctx context.Context, itemID int64, input CommentInput) (*entity.Comment, error)
+	// Update は本文を変更する（投稿者のみ）。新たにメンションされたユーザーに通知する
+	Update(ctx context.Context, itemID, id int64, input CommentInput) (*entity.Comment, error)
+	// Delete はコメントを削除する（投稿者と管理者のみ）
+	Delete(ctx context.Context, itemID, id int64) error
+}
+
+type CommentInput struct {
+	Body string `json:"body"`
+}
+
+type commentUsecase struct {
+	commentRepo      CommentRepository
+	notificationRepo NotificationRepository
+	itemRepo         ItemRepository
+	userRepo         UserRepository
+}
+
+func NewCommentUsecase(commentRepo CommentRepository, notificationRepo NotificationRepository, itemRepo ItemRepository, userRepo UserRepository) CommentUsecase {
+	return &commentUsecase{
+		commentRepo:      commentRepo,
+		notificationRepo: notificationRepo,
+		itemRepo:         itemRepo,
+		userRepo:         userRepo,
+	}
+}
+
+func (u *commentUsecase) List(ctx context.Context, itemID int64) ([]*entity.Comment, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findItem(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	comments, err := u.commentRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+func (u *commentUsecase) Create(ctx context.Context, itemID int64, input CommentInput) (*entity.Comment, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.findItem(ctx, actor, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := entity.NewComment(itemID, actor.ID, input.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.commentRepo.Create(ctx, comment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	u.notifyMentions(ctx, item, created, created.MentionedEmails())
+
+	return created, nil
+}
+
+func (u *commentUsecase) Update(ctx context.Context, itemID, id int64, input CommentInput) (*entity.Comment, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.findItem(ctx, actor, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment, err := u.find(ctx, itemID, id)
+	if err != nil {
+		return nil, err
+	}
+	if comment.AuthorID != actor.ID {
+		return nil, domainErrors.ErrForbidden
+	}
+
+	before := comment.MentionedEmails()
+	if err := comment.Edit(input.Body); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	updated, err := u.commentRepo.Update(ctx, comment)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	// 編集前からメンションされていたユーザーには再度通知しない
+	var added []string
+	for _, email := range updated.MentionedEmails() {
+		if !slices.Contains(before, email) {
+			added = append(added, email)
+		}
+	}
+	u.notifyMentions(ctx, item, updated, added)
+
+	return updated, nil
+}
+
+func (u *commentUsecase) Delete(ctx context.Context, itemID, id int64) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.findItem(ctx, actor, itemID); err != nil {
+		return err
+	}
+
+	comment, err := u.find(ctx, itemID, id)
+	if err != nil {
+		return err
+	}
+	if comment.AuthorID != actor.ID && !actor.IsAdmin() {
+		return domainErrors.ErrForbidden
+	}
+
+	if err := u.commentRepo.Delete(ctx, itemID, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	return nil
+}
+
+// notifyMentions はメンションされたユーザーのうち、アイテムを参照できるユーザーに通知する。
+// コメントは保存済みのため、通知に失敗してもエラーにはしない
+func (u *commentUsecase) notifyMentions(ctx context.Context, item *entity.Item, comment *entity.Comment, emails []string) {
+	for _, email := range emails {
+		user, err := u.userRepo.FindByEmail(ctx, email)
+		if err != nil {
+			// 登録されていないアドレスは無視する
+			if !errors.Is(err, domainErrors.ErrUserNotFound) {
+				log.Printf("⚠️  メンションされたユーザーを取得できませんでした (comment %d): %v", comment.ID, err)
+			}
+			continue
+		}
+		// 自分自身と、アイテムを参照できないユーザー（アイテムの存在を知られないようにする）には通知しない
+		if user.ID == comment.AuthorID || !canReadItem(user, item) {
+			continue
+		}
+
+		if _, err := u.notificationRepo.Create(ctx, entity.NewMentionNotification(user.ID, comment)); err != nil {
+			log.Printf("⚠️  メンションの通知を作成できませんでした (comment %d, user %d): %v", comment.ID, user.ID, err)
+		}
+	}
+}
+
+func (u *commentUsecase) find(ctx context.Context, itemID, id int64) (*entity.Comment, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	comment, err := u.commentRepo.FindByID(ctx, itemID, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve comment: %w", err)
+	}
+
+	return comment, nil
+}
+
+func (u *commentUsecase) findItem(ctx context.Context, actor *entity.User, itemID int64) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := findItemForActor(ctx, u.itemRepo, actor, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, nil
+}
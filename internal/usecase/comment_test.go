@@ -0,0 +1,209 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockCommentRepository struct {
+	mock.Mock
+}
+
+func (m *MockCommentRepository) Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	args := m.Called(ctx, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.Comment, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByID(ctx context.Context, itemID, id int64) (*entity.Comment, error) {
+	args := m.Called(ctx, itemID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Update(ctx context.Context, comment *entity.Comment) (*entity.Comment, error) {
+	args := m.Called(ctx, comment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) Delete(ctx context.Context, itemID, id int64) error {
+	args := m.Called(ctx, itemID, id)
+	return args.Error(0)
+}
+
+type MockNotificationRepository struct {
+	mock.Mock
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *entity.Notification) (*entity.Notification, error) {
+	args := m.Called(ctx, notification)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) FindByUserID(ctx context.Context, userID int64, unreadOnly bool) ([]*entity.Notification, error) {
+	args := m.Called(ctx, userID, unreadOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Notification), args.Error(1)
+}
+
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, userID, id int64) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+type commentTestDeps struct {
+	comments      *MockCommentRepository
+	notifications *MockNotificationRepository
+	items         *MockItemRepository
+	users         *MockUserRepository
+}
+
+func newCommentTestUsecase() (CommentUsecase, commentTestDeps) {
+	deps := commentTestDeps{
+		comments:      new(MockCommentRepository),
+		notifications: new(MockNotificationRepository),
+		items:         new(MockItemRepository),
+		users:         new(MockUserRepository),
+	}
+	deps.items.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+	return NewCommentUsecase(deps.comments, deps.notifications, deps.items, deps.users), deps
+}
+
+func TestCommentUsecase_Create(t *testing.T) {
+	admin := &entity.User{ID: 2, Email: "admin@example.com", Role: entity.RoleAdmin}
+	stranger := &entity.User{ID: 3, Email: "other@example.com", Role: entity.RoleEditor}
+
+	t.Run("正常系: アイテムを参照できるユーザーだけにメンションを通知する", func(t *testing.T) {
+		usecase, deps := newCommentTestUsecase()
+		body := "売るか相談したい @admin@example.com @other@example.com @user@example.com @nobody@example.com"
+		deps.comments.On("Create", mock.Anything, mock.AnythingOfType("*entity.Comment")).
+			Return(&entity.Comment{ID: 10, ItemID: 1, AuthorID: testActor.ID, Body: body}, nil)
+		deps.users.On("FindByEmail", mock.Anything, "admin@example.com").Return(admin, nil)
+		deps.users.On("FindByEmail", mock.Anything, "other@example.com").Return(stranger, nil)
+		deps.users.On("FindByEmail", mock.Anything, "user@example.com").Return(testActor, nil)
+		deps.users.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, domainErrors.ErrUserNotFound)
+		var notified *entity.Notification
+		deps.notifications.On("Create", mock.Anything, mock.AnythingOfType("*entity.Notification")).
+			Run(func(args mock.Arguments) { notified = args.Get(1).(*entity.Notification) }).
+			Return(&entity.Notification{ID: 1}, nil)
+
+		comment, err := usecase.Create(actorContext(), 1, CommentInput{Body: body})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(10), comment.ID)
+		deps.notifications.AssertNumberOfCalls(t, "Create", 1)
+		assert.Equal(t, &entity.Notification{
+			UserID: admin.ID, Kind: entity.NotificationKindMention, ItemID: 1, CommentID: 10, ActorID: testActor.ID,
+			CreatedAt: notified.CreatedAt,
+		}, notified)
+	})
+
+	t.Run("異常系: 本文が空", func(t *testing.T) {
+		usecase, deps := newCommentTestUsecase()
+
+		_, err := usecase.Create(actorContext(), 1, CommentInput{Body: "  "})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		deps.comments.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテム", func(t *testing.T) {
+		usecase, _ := newCommentTestUsecase()
+
+		_, err := usecase.Create(WithActor(context.Background(), stranger), 1, CommentInput{Body: "hello"})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestCommentUsecase_Update(t *testing.T) {
+	admin := &entity.User{ID: 2, Email: "admin@example.com", Role: entity.RoleAdmin}
+
+	t.Run("正常系: 新たにメンションされたユーザーにだけ通知する", func(t *testing.T) {
+		usecase, deps := newCommentTestUsecase()
+		existing := &entity.Comment{ID: 10, ItemID: 1, AuthorID: testActor.ID, Body: "@admin@example.com 確認して"}
+		deps.comments.On("FindByID", mock.Anything, int64(1), int64(10)).Return(existing, nil)
+		deps.comments.On("Update", mock.Anything, existing).Return(existing, nil)
+
+		_, err := usecase.Update(actorContext(), 1, 10, CommentInput{Body: "@admin@example.com 再確認して"})
+		require.NoError(t, err)
+
+		deps.users.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+		deps.notifications.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 投稿者以外は編集できない", func(t *testing.T) {
+		usecase, deps := newCommentTestUsecase()
+		deps.comments.On("FindByID", mock.Anything, int64(1), int64(10)).
+			Return(&entity.Comment{ID: 10, ItemID: 1, AuthorID: admin.ID, Body: "hello"}, nil)
+
+		_, err := usecase.Update(actorContext(), 1, 10, CommentInput{Body: "edited"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestCommentUsecase_Delete(t *testing.T) {
+	admin := &entity.User{ID: 2, Email: "admin@example.com", Role: entity.RoleAdmin}
+
+	t.Run("正常系: 管理者は他のユーザーのコメントも削除できる", func(t *testing.T) {
+		usecase, deps := newCommentTestUsecase()
+		deps.comments.On("FindByID", mock.Anything, int64(1), int64(10)).
+			Return(&entity.Comment{ID: 10, ItemID: 1, AuthorID: testActor.ID}, nil)
+		deps.comments.On("Delete", mock.Anything, int64(1), int64(10)).Return(nil)
+
+		require.NoError(t, usecase.Delete(WithActor(context.Background(), admin), 1, 10))
+		deps.comments.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 存在しないコメント", func(t *testing.T) {
+		usecase, deps := newCommentTestUsecase()
+		deps.comments.On("FindByID", mock.Anything, int64(1), int64(10)).Return(nil, domainErrors.ErrCommentNotFound)
+
+		assert.ErrorIs(t, usecase.Delete(actorContext(), 1, 10), domainErrors.ErrCommentNotFound)
+	})
+}
+
+func TestNotificationUsecase(t *testing.T) {
+	t.Run("正常系: 未読の通知を返す", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		repo.On("FindByUserID", mock.Anything, testActor.ID, true).Return([]*entity.Notification{{ID: 1}}, nil)
+		usecase := NewNotificationUsecase(repo)
+
+		notifications, err := usecase.List(actorContext(), true)
+		require.NoError(t, err)
+		assert.Len(t, notifications, 1)
+	})
+
+	t.Run("異常系: 他のユーザーの通知は既読にできない", func(t *testing.T) {
+		repo := new(MockNotificationRepository)
+		repo.On("MarkRead", mock.Anything, testActor.ID, int64(5)).Return(domainErrors.ErrNotificationNotFound)
+		usecase := NewNotificationUsecase(repo)
+
+		assert.ErrorIs(t, usecase.MarkRead(actorContext(), 5), domainErrors.ErrNotificationNotFound)
+	})
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ConsignmentUsecase は販売を委託されて預かっているアイテム（委託品）を扱う。
+// 委託の契約はアイテムに紐づき、アイテムを参照・変更できるユーザーが参照・変更できる
+type ConsignmentUsecase interface {
+	Get(ctx context.Context, itemID int64) (*entity.Consignment, error)
+	// Put はアイテムの委託の契約を登録する（登録済みの場合は置き換える）。
+	// 販売済みにするときに input.Invoice を指定すると請求書も発行する
+	Put(ctx context.Context, itemID int64, input ConsignmentInput) (*entity.Consignment, error)
+	// Delete はアイテムの委託の契約を削除する（誤って登録した場合など）
+	Delete(ctx context.Context, itemID int64) error
+	List(ctx context.Context, filter ConsignmentFilter) ([]*entity.Consignment, error)
+	// Report は自己所有のアイテムと委託品の在庫を集計する
+	Report(ctx context.Context) (*ConsignmentReport, error)
+	// StartReport は Report の集計をJSONファイルとして出力するジョブを開始する（結果は GET /jobs/{id}/result）
+	StartReport(ctx context.Context) (*entity.Job, error)
+}
+
+type ConsignmentInput struct {
+	ConsignorName    string  `json:"consignor_name"`
+	ConsignorContact string  `json:"consignor_contact"`
+	AgreedPrice      int     `json:"agreed_price"`
+	CommissionRate   float64 `json:"commission_rate"`
+	Deadline         string  `json:"deadline"`
+	// Status は省略時 active
+	Status entity.ConsignmentStatus `json:"status"`
+	// Invoice は販売済み（sold）にする場合のみ指定できる
+	Invoice *InvoiceInput `json:"invoice,omitempty"`
+}
+
+// ConsignmentFilter は委託品一覧の絞り込み条件
+type ConsignmentFilter struct {
+	Status entity.ConsignmentStatus
+	// Overdue は預かり中のまま期限を過ぎたものに絞り込む
+	Overdue bool
+}
+
+// ConsignmentReport は自己所有のアイテムと委託品の在庫の集計
+type ConsignmentReport struct {
+	// Owned は委託されたことのない自己所有のアイテム（Value は購入価格の合計）
+	Owned InventoryTotals `json:"owned"`
+	// Consigned は預かり中の委託品（Value は合意した販売価格の合計）
+	Consigned InventoryTotals `json:"consigned"`
+	// ExpectedCommission は預かり中の委託品がすべて合意した価格で売れた場合の手数料の合計
+	ExpectedCommission int64 `json:"expected_commission"`
+	// EarnedCommission は売れた委託品の手数料の合計
+	EarnedCommission int64 `json:"earned_commission"`
+	// Overdue は預かり中のまま期限を過ぎた委託品の数
+	Overdue int `json:"overdue"`
+	// ByStatus は状態ごとの委託の件数
+	ByStatus map[entity.ConsignmentStatus]int `json:"by_status"`
+}
+
+type InventoryTotals struct {
+	Count int   `json:"count"`
+	Value int64 `json:"value"`
+}
+
+type consignmentUsecase struct {
+	consignmentRepo ConsignmentRepository
+	itemRepo        ItemRepository
+	invoiceRepo     InvoiceRepository
+	jobs            JobUsecase
+	now             func() time.Time
+}
+
+// ConsignmentUsecaseOption は ConsignmentUsecase の設定を変更する
+type ConsignmentUsecaseOption func(*consignmentUsecase)
+
+// WithInvoices は販売済みにするときの請求書の発行を有効にする
+func WithInvoices(invoiceRepo InvoiceRepository) ConsignmentUsecaseOption {
+	return func(u *consignmentUsecase) {
+		u.invoiceRepo = invoiceRepo
+	}
+}
+
+// WithReportJobs は集計をジョブとして実行できるようにし、実行時間を負荷の見積もりに反映する
+func WithReportJobs(jobs JobUsecase) ConsignmentUsecaseOption {
+	return func(u *consignmentUsecase) {
+		u.jobs = jobs
+	}
+}
+
+func NewConsignmentUsecase(consignmentRepo ConsignmentRepository, itemRepo ItemRepository, opts ...ConsignmentUsecaseOption) ConsignmentUsecase {
+	u := &consignmentUsecase{
+		consignmentRepo: consignmentRepo,
+		itemRepo:        itemRepo,
+		now:             time.Now,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *consignmentUsecase) Get(ctx context.Context, itemID int64) (*entity.Consignment, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findItem(ctx, actor, itemID, false); err != nil {
+		return nil, err
+	}
+
+	consignment, err := u.consignmentRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrConsignmentNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve consignment: %w", err)
+	}
+
+	return consignment, nil
+}
+
+func (u *consignmentUsecase) Put(ctx context.Context, itemID int64, input ConsignmentInput) (*entity.Consignment, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.findItem(ctx, actor, itemID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	consignment, err := entity.NewConsignment(
+		itemID,
+		input.ConsignorName,
+		input.ConsignorContact,
+		input.AgreedPrice,
+		input.CommissionRate,
+		input.Deadline,
+		input.Status,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	var invoice *entity.Invoice
+	if input.Invoice != nil {
+		if u.invoiceRepo == nil {
+			return nil, fmt.Errorf("%w: invoices are not enabled", domainErrors.ErrInvalidInput)
+		}
+		if consignment.Status != entity.ConsignmentStatusSold {
+			return nil, fmt.Errorf("%w: invoice can only be issued when status is sold", domainErrors.ErrInvalidInput)
+		}
+		invoice, err = newSaleInvoice(actor, item, consignment, *input.Invoice, u.now())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	saved, err := u.consignmentRepo.Save(ctx, consignment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save consignment: %w", err)
+	}
+
+	// 請求書の発行に失敗しても販売済みの状態は保存されるため、同じリクエストで再発行できる
+	if invoice != nil {
+		saved.Invoice, err = u.invoiceRepo.Create(ctx, invoice)
+		if err != nil {
+			if domainErrors.IsDuplicateError(err) {
+				return nil, fmt.Errorf("%w: invoice has already been issued for this item", domainErrors.ErrDuplicateEntry)
+			}
+			return nil, fmt.Errorf("failed to issue invoice: %w", err)
+		}
+	}
+
+	return saved, nil
+}
+
+func (u *consignmentUsecase) Delete(ctx context.Context, itemID int64) error {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.findItem(ctx, actor, itemID, true); err != nil {
+		return err
+	}
+
+	if err := u.consignmentRepo.Delete(ctx, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrConsignmentNotFound
+		}
+		return fmt.Errorf("failed to delete consignment: %w", err)
+	}
+
+	return nil
+}
+
+func (u *consignmentUsecase) List(ctx context.Context, filter ConsignmentFilter) ([]*entity.Consignment, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, fmt.Errorf("%w: status must be one of: active, sold, returned", domainErrors.ErrInvalidInput)
+	}
+	if filter.Overdue {
+		if filter.Status != "" && filter.Status != entity.ConsignmentStatusActive {
+			return []*entity.Consignment{}, nil
+		}
+		filter.Status = entity.ConsignmentStatusActive
+	}
+
+	consignments, err := u.consignmentRepo.FindAll(ctx, itemScope(actor), filter.Status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve consignments: %w", err)
+	}
+
+	if !filter.Overdue {
+		return consignments, nil
+	}
+	now := u.now()
+	overdue := []*entity.Consignment{}
+	for _, c := range consignments {
+		if c.IsOverdue(now) {
+			overdue = append(overdue, c)
+		}
+	}
+	return overdue, nil
+}
+
+func (u *consignmentUsecase) Report(ctx context.Context) (*ConsignmentReport, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	report, err := u.report(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+	if u.jobs != nil {
+		u.jobs.Observe(entity.JobKindReport, time.Since(start))
+	}
+
+	return report, nil
+}
+
+func (u *consignmentUsecase) StartReport(ctx context.Context) (*entity.Job, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.jobs == nil {
+		return nil, fmt.Errorf("%w: asynchronous report is not enabled", domainErrors.ErrInvalidInput)
+	}
+
+	fileName := fmt.Sprintf("consignment-report-%s.json", u.now().Format("20060102-150405"))
+	return u.jobs.Submit(ctx, strconv.FormatInt(actor.ID, 10), entity.JobKindReport, func(ctx context.Context, job *entity.Job) error {
+		report, err := u.report(ctx, actor)
+		if err != nil {
+			return err
+		}
+		body, err := json.Marshal(report)
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		job.Result = &entity.JobResult{
+			FileName:    fileName,
+			ContentType: "application/json",
+			Body:        body,
+		}
+		return nil
+	})
+}
+
+func (u *consignmentUsecase) report(ctx context.Context, actor *entity.User) (*ConsignmentReport, error) {
+	ownedCount, ownedValue, err := u.consignmentRepo.SummarizeOwned(ctx, itemScope(actor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to summarize owned items: %w", err)
+	}
+
+	consignments, err := u.consignmentRepo.FindAll(ctx, itemScope(actor), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve consignments: %w", err)
+	}
+
+	report := &ConsignmentReport{
+		Owned: InventoryTotals{Count: ownedCount, Value: ownedValue},
+		ByStatus: map[entity.ConsignmentStatus]int{
+			entity.ConsignmentStatusActive:   0,
+			entity.ConsignmentStatusSold:     0,
+			entity.ConsignmentStatusReturned: 0,
+		},
+	}
+	now := u.now()
+	for _, c := range consignments {
+		report.ByStatus[c.Status]++
+		switch c.Status {
+		case entity.ConsignmentStatusActive:
+			report.Consigned.Count++
+			report.Consigned.Value = entity.AddAmount(report.Consigned.Value, int64(c.AgreedPrice))
+			report.ExpectedCommission = entity.AddAmount(report.ExpectedCommission, int64(c.Commission()))
+			if c.IsOverdue(now) {
+				report.Overdue++
+			}
+		case entity.ConsignmentStatusSold:
+			report.EarnedCommission = entity.AddAmount(report.EarnedCommission, int64(c.Commission()))
+		}
+	}
+
+	return report, nil
+}
+
+// findItem は委託の契約を扱うアイテムを取得する（write が true の場合は変更できることも確認する）
+func (u *consignmentUsecase) findItem(ctx context.Context, actor *entity.User, itemID int64, write bool) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	find := findItemForActor
+	if write {
+		find = findWritableItem
+	}
+	item, err := find(ctx, u.itemRepo, actor, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsForbiddenError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, nil
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockConsignmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockConsignmentRepository) FindByItemID(ctx context.Context, itemID int64) (*entity.Consignment, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Consignment), args.Error(1)
+}
+
+func (m *MockConsignmentRepository) Save(ctx context.Context, consignment *entity.Consignment) (*entity.Consignment, error) {
+	args := m.Called(ctx, consignment)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Consignment), args.Error(1)
+}
+
+func (m *MockConsignmentRepository) Delete(ctx context.Context, itemID int64) error {
+	args := m.Called(ctx, itemID)
+	return args.Error(0)
+}
+
+func (m *MockConsignmentRepository) FindAll(ctx context.Context, userID int64, status entity.ConsignmentStatus) ([]*entity.Consignment, error) {
+	args := m.Called(ctx, userID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Consignment), args.Error(1)
+}
+
+func (m *MockConsignmentRepository) SummarizeOwned(ctx context.Context, userID int64) (int, int64, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Get(1).(int64), args.Error(2)
+}
+
+func newConsignmentTestUsecase() (*consignmentUsecase, *MockConsignmentRepository, *MockItemRepository) {
+	consignmentRepo := new(MockConsignmentRepository)
+	itemRepo := new(MockItemRepository)
+	item, _ := newOwnedItem("ロレックス デイトナ", "時計", "ROLEX", 0, "2024-01-15")
+	item.ID = 1
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil).Maybe()
+	u := NewConsignmentUsecase(consignmentRepo, itemRepo).(*consignmentUsecase)
+	u.now = func() time.Time { return time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local) }
+	return u, consignmentRepo, itemRepo
+}
+
+func TestConsignmentUsecase_Put(t *testing.T) {
+	t.Run("正常系: 状態の省略時は預かり中として登録する", func(t *testing.T) {
+		usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+		consignmentRepo.On("Save", mock.Anything, mock.MatchedBy(func(c *entity.Consignment) bool {
+			return c.ItemID == 1 && c.ConsignorName == "佐藤" && c.Status == entity.ConsignmentStatusActive
+		})).Return(&entity.Consignment{ID: 1, ItemID: 1}, nil)
+
+		_, err := usecase.Put(actorContext(), 1, ConsignmentInput{
+			ConsignorName: "佐藤", AgreedPrice: 500000, CommissionRate: 15, Deadline: "2024-06-30",
+		})
+		require.NoError(t, err)
+		consignmentRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 手数料率が範囲外", func(t *testing.T) {
+		usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+
+		_, err := usecase.Put(actorContext(), 1, ConsignmentInput{ConsignorName: "佐藤", CommissionRate: 150})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		consignmentRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 閲覧者は登録できない", func(t *testing.T) {
+		usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+		viewer := WithActor(context.Background(), &entity.User{ID: testActor.ID, Role: entity.RoleViewer})
+
+		_, err := usecase.Put(viewer, 1, ConsignmentInput{ConsignorName: "佐藤"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		consignmentRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテム", func(t *testing.T) {
+		usecase, _, _ := newConsignmentTestUsecase()
+		other := WithActor(context.Background(), &entity.User{ID: 2, Role: entity.RoleEditor})
+
+		_, err := usecase.Put(other, 1, ConsignmentInput{ConsignorName: "佐藤"})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestConsignmentUsecase_Get(t *testing.T) {
+	t.Run("異常系: 委託品ではないアイテム", func(t *testing.T) {
+		usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+		consignmentRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrConsignmentNotFound)
+
+		_, err := usecase.Get(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrConsignmentNotFound)
+	})
+}
+
+func TestConsignmentUsecase_List(t *testing.T) {
+	t.Run("正常系: 期限切れのみに絞り込む", func(t *testing.T) {
+		usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+		consignmentRepo.On("FindAll", mock.Anything, testActor.ID, entity.ConsignmentStatusActive).Return([]*entity.Consignment{
+			{ID: 1, Status: entity.ConsignmentStatusActive, Deadline: "2024-06-30"},
+			{ID: 2, Status: entity.ConsignmentStatusActive, Deadline: "2024-07-01"},
+			{ID: 3, Status: entity.ConsignmentStatusActive},
+		}, nil)
+
+		consignments, err := usecase.List(actorContext(), ConsignmentFilter{Overdue: true})
+		require.NoError(t, err)
+		require.Len(t, consignments, 1)
+		assert.Equal(t, int64(1), consignments[0].ID)
+	})
+
+	t.Run("異常系: 定義されていない状態", func(t *testing.T) {
+		usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+
+		_, err := usecase.List(actorContext(), ConsignmentFilter{Status: "lost"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		consignmentRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestConsignmentUsecase_StartReport(t *testing.T) {
+	usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+	jobs := NewJobUsecase()
+	WithReportJobs(jobs)(usecase)
+	consignmentRepo.On("SummarizeOwned", mock.Anything, testActor.ID).Return(1, int64(100000), nil)
+	consignmentRepo.On("FindAll", mock.Anything, testActor.ID, entity.ConsignmentStatus("")).Return([]*entity.Consignment{}, nil)
+
+	job, err := usecase.StartReport(actorContext())
+	require.NoError(t, err)
+	assert.Equal(t, entity.JobKindReport, job.Kind)
+
+	userID := strconv.FormatInt(testActor.ID, 10)
+	finished := waitForJob(t, jobs, userID, job.ID)
+	require.Equal(t, entity.JobStatusSucceeded, finished.Status, finished.Error)
+	result, err := jobs.GetResult(context.Background(), userID, job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "consignment-report-20240701-090000.json", result.FileName)
+	assert.Equal(t, "application/json", result.ContentType)
+	assert.Contains(t, string(result.Body), `"owned":{"count":1,"value":100000}`)
+}
+
+func TestConsignmentUsecase_Report(t *testing.T) {
+	usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+	consignmentRepo.On("SummarizeOwned", mock.Anything, testActor.ID).Return(3, int64(2400000), nil)
+	consignmentRepo.On("FindAll", mock.Anything, testActor.ID, entity.ConsignmentStatus("")).Return([]*entity.Consignment{
+		{ID: 1, Status: entity.ConsignmentStatusActive, AgreedPrice: 500000, CommissionRate: 15, Deadline: "2024-06-30"},
+		{ID: 2, Status: entity.ConsignmentStatusActive, AgreedPrice: 300000, CommissionRate: 10},
+		{ID: 3, Status: entity.ConsignmentStatusSold, AgreedPrice: 200000, CommissionRate: 20},
+		{ID: 4, Status: entity.ConsignmentStatusReturned, AgreedPrice: 100000, CommissionRate: 20},
+	}, nil)
+
+	report, err := usecase.Report(actorContext())
+	require.NoError(t, err)
+
+	assert.Equal(t, &ConsignmentReport{
+		Owned:              InventoryTotals{Count: 3, Value: 2400000},
+		Consigned:          InventoryTotals{Count: 2, Value: 800000},
+		ExpectedCommission: 105000,
+		EarnedCommission:   40000,
+		Overdue:            1,
+		ByStatus: map[entity.ConsignmentStatus]int{
+			entity.ConsignmentStatusActive:   2,
+			entity.ConsignmentStatusSold:     1,
+			entity.ConsignmentStatusReturned: 1,
+		},
+	}, report)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// CurrencyConverter は金額を別の通貨に換算する（為替レート API などで実装する）
+type CurrencyConverter interface {
+	// Convert は from の最小単位の金額を to の最小単位の金額に換算する。
+	// 為替レートを取得できない場合は ErrExchangeRateUnavailable を返す
+	Convert(ctx context.Context, amount int64, from, to entity.Currency) (int64, error)
+}
+
+// valuation は集計やエクスポートの金額を操作者の表示通貨に換算する
+type valuation struct {
+	currency  entity.Currency
+	converter CurrencyConverter
+}
+
+// newValuation は操作者の表示通貨への換算を返す。
+// converter がない場合は為替レートを持たないため円で表示し、外貨建ての金額は合計に含めない
+func newValuation(actor *entity.User, converter CurrencyConverter) valuation {
+	if converter == nil {
+		return valuation{currency: entity.CurrencyJPY}
+	}
+	return valuation{currency: actor.Currency(), converter: converter}
+}
+
+// convert は金額を表示通貨に換算する。換算できない外貨建ての金額の場合は ok が false になる
+func (v valuation) convert(ctx context.Context, amount int64, from entity.Currency) (value int64, ok bool, err error) {
+	if from == v.currency {
+		return amount, true, nil
+	}
+	if v.converter == nil {
+		return 0, false, nil
+	}
+	value, err = v.converter.Convert(ctx, amount, from, v.currency)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to convert %s to %s: %w", from, v.currency, err)
+	}
+	return value, true, nil
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+type MockCurrencyConverter struct {
+	mock.Mock
+}
+
+func (m *MockCurrencyConverter) Convert(ctx context.Context, amount int64, from, to entity.Currency) (int64, error) {
+	args := m.Called(ctx, amount, from, to)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestValuation(t *testing.T) {
+	ctx := context.Background()
+	usdActor := &entity.User{ID: 1, PreferredCurrency: entity.CurrencyUSD}
+
+	t.Run("為替レートを設定していない場合は円で表示し、外貨は換算しない", func(t *testing.T) {
+		v := newValuation(usdActor, nil)
+		assert.Equal(t, entity.CurrencyJPY, v.currency)
+
+		value, ok, err := v.convert(ctx, 1500000, entity.CurrencyJPY)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, int64(1500000), value)
+
+		_, ok, err = v.convert(ctx, 12345, entity.CurrencyUSD)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("表示通貨と同じ通貨は換算しない", func(t *testing.T) {
+		converter := new(MockCurrencyConverter)
+		v := newValuation(usdActor, converter)
+
+		value, ok, err := v.convert(ctx, 12345, entity.CurrencyUSD)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, int64(12345), value)
+		converter.AssertNotCalled(t, "Convert")
+	})
+
+	t.Run("表示通貨が未設定のユーザーは円で表示する", func(t *testing.T) {
+		v := newValuation(&entity.User{ID: 2}, new(MockCurrencyConverter))
+		assert.Equal(t, entity.CurrencyJPY, v.currency)
+	})
+}
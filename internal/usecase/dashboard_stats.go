@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ダッシュボードに含めるブランド・月・高額なアイテムの数
+const (
+	dashboardBrands   = 10
+	dashboardMonths   = 12
+	dashboardTopItems = 5
+)
+
+// DashboardStats はモバイルのダッシュボード用に1回で返す集計
+type DashboardStats struct {
+	Categories *CategorySummary `json:"categories"`
+	// Brands は件数の多いブランド（最大10）
+	Brands *BrandSummary `json:"brands"`
+	// MonthlySpend は月ごとの購入（直近の最大12か月。total と undated はすべての期間）
+	MonthlySpend *PurchaseTrendReport `json:"monthly_spend"`
+	// TopItems は購入価格の高いアイテム（最大5件）
+	TopItems *TopItems `json:"top_items"`
+}
+
+// GetDashboardStats はカテゴリー別集計・ブランド別集計・月ごとの購入・高額なアイテムをまとめて返す。
+// それぞれの集計は並行して実行し、どれかが失敗した場合は残りを中断してエラーを返す
+func (u *itemUsecase) GetDashboardStats(ctx context.Context) (*DashboardStats, error) {
+	if _, err := requireActor(ctx); err != nil {
+		return nil, err
+	}
+
+	stats := &DashboardStats{}
+	g, gctx := errgroup.WithContext(ctx)
+	g.Go(func() (err error) {
+		stats.Categories, err = u.GetCategorySummary(gctx, "")
+		return err
+	})
+	g.Go(func() (err error) {
+		stats.Brands, err = u.GetBrandSummary(gctx, dashboardBrands)
+		return err
+	})
+	g.Go(func() (err error) {
+		stats.MonthlySpend, err = u.GetPurchaseTrend(gctx, string(entity.PurchasePeriodMonth))
+		return err
+	})
+	g.Go(func() (err error) {
+		stats.TopItems, err = u.GetTopItems(gctx, dashboardTopItems, string(entity.ItemValuePurchasePrice))
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	if periods := stats.MonthlySpend.Periods; len(periods) > dashboardMonths {
+		stats.MonthlySpend.Periods = periods[len(periods)-dashboardMonths:]
+	}
+	return stats, nil
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestItemUsecase_GetDashboardStats(t *testing.T) {
+	newRepo := func() *MockItemRepository {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return([]entity.CategoryValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000, MinValue: 1000000, MaxValue: 2000000},
+		}, nil)
+		mockRepo.On("GetSummaryByBrand", mock.Anything, testActor.ID).Return([]entity.BrandValue{
+			{Brand: "ROLEX", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000},
+		}, nil)
+		mockRepo.On("FindTopByValue", mock.Anything, testActor.ID, entity.ItemValuePurchasePrice, dashboardTopItems).Return([]*entity.Item{
+			{ID: 1, UserID: testActor.ID, PurchasePrice: entity.JPY(2000000)},
+		}, nil)
+		return mockRepo
+	}
+
+	t.Run("正常系: 4つの集計をまとめて返し、月ごとの購入は直近12か月に絞る", func(t *testing.T) {
+		mockRepo := newRepo()
+		mockRepo.On("GetPurchasesByPeriod", mock.Anything, testActor.ID, entity.PurchasePeriodMonth).Return([]entity.PurchasePeriodValue{
+			{Period: "2023-01", Currency: entity.CurrencyJPY, Count: 1, Value: 1000000},
+			{Period: "2024-06", Currency: entity.CurrencyJPY, Count: 1, Value: 2000000},
+		}, nil)
+
+		stats, err := NewItemUsecase(mockRepo).GetDashboardStats(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, 2, stats.Categories.Categories["時計"])
+		require.Len(t, stats.Brands.Brands, 1)
+		assert.Equal(t, "ROLEX", stats.Brands.Brands[0].Brand)
+		require.Len(t, stats.TopItems.Items, 1)
+		require.Len(t, stats.MonthlySpend.Periods, dashboardMonths)
+		assert.Equal(t, "2023-07", stats.MonthlySpend.Periods[0].Period)
+		assert.Equal(t, "2024-06", stats.MonthlySpend.Periods[dashboardMonths-1].Period)
+		// 合計はすべての期間
+		assert.Equal(t, 2, stats.MonthlySpend.Total.Count)
+	})
+
+	t.Run("異常系: いずれかの集計が失敗するとエラーを返す", func(t *testing.T) {
+		mockRepo := newRepo()
+		mockRepo.On("GetPurchasesByPeriod", mock.Anything, testActor.ID, entity.PurchasePeriodMonth).Return(nil, errors.New("db error"))
+
+		_, err := NewItemUsecase(mockRepo).GetDashboardStats(actorContext())
+		assert.Error(t, err)
+	})
+}
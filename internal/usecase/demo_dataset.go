@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// 購入価格をずらす倍率の範囲
+	demoPriceMinFactor = 0.8
+	demoPriceMaxFactor = 1.25
+	// 購入日をずらす最大の日数（前後）
+	demoDateJitterDays = 15
+)
+
+// 置き換えに使う文字（元の文字と同じ種類の文字に置き換え、文字数とバイト数の傾向を保つ）
+var (
+	demoHiragana = []rune("あいうえおかきくけこさしすせそたちつてとなにぬねのはひふへほまみむめもやゆよらりるれろわ")
+	demoKatakana = []rune("アイウエオカキクケコサシスセソタチツテトナニヌネノハヒフヘホマミムメモヤユヨラリルレロワ")
+	demoKanji    = []rune("山川田中本木花月日金石水森林村松竹梅空海星雲風光")
+	demoUpper    = []rune("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	demoLower    = []rune("abcdefghijklmnopqrstuvwxyz")
+	demoDigits   = []rune("0123456789")
+)
+
+// DemoDatasetUsecase は本番のデータの形（件数・分布・長さ）を保ったまま、
+// 個人や取引を特定できる値を合成した値に置き換えたデモ用のデータセットを作る
+type DemoDatasetUsecase interface {
+	Generate(ctx context.Context, input GenerateDemoDatasetInput) (*DemoDataset, error)
+}
+
+// GenerateDemoDatasetInput はデモ用データセットの生成条件
+type GenerateDemoDatasetInput struct {
+	// Seed は乱数の種（同じデータと種からは同じデータセットを作る）
+	Seed int64
+	// Password はデモ用のユーザー全員に設定するパスワード
+	Password string
+}
+
+// DemoDataset は匿名化したデータセット。ID は 1 からの連番に振り直す
+type DemoDataset struct {
+	Users         []*entity.User
+	Organizations []*entity.Organization
+	Memberships   []*entity.Membership
+	Items         []*entity.Item
+}
+
+type demoDatasetUsecase struct {
+	itemRepo ItemRepository
+	orgRepo  OrganizationRepository
+}
+
+func NewDemoDatasetUsecase(itemRepo ItemRepository, orgRepo OrganizationRepository) DemoDatasetUsecase {
+	return &demoDatasetUsecase{
+		itemRepo: itemRepo,
+		orgRepo:  orgRepo,
+	}
+}
+
+func (u *demoDatasetUsecase) Generate(ctx context.Context, input GenerateDemoDatasetInput) (*DemoDataset, error) {
+	if err := entity.ValidatePassword(input.Password); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(input.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	items, err := u.itemRepo.FindAll(ctx, entity.ItemFilter{
+		Sort: entity.ItemSort{Key: entity.SortKeyCreatedAt, Order: entity.SortOrderAsc},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g := &demoGenerator{
+		rng:          rand.New(rand.NewSource(input.Seed)),
+		passwordHash: string(hash),
+		dataset:      &DemoDataset{},
+		userIDs:      map[int64]int64{},
+		orgIDs:       map[int64]int64{},
+		names:        map[string]string{},
+		brands:       map[string]string{},
+	}
+
+	for _, item := range items {
+		demoItem := &entity.Item{
+			ID:            int64(len(g.dataset.Items) + 1),
+			Name:          g.replace(g.names, item.Name),
+			Category:      item.Category,
+			Brand:         g.replace(g.brands, item.Brand),
+			PurchasePrice: entity.Money{Amount: g.price(item.PurchasePrice.Amount), Currency: item.PurchasePrice.Currency},
+			PurchaseDate:  g.date(item.PurchaseDate),
+			Visibility:    item.Visibility,
+			Condition:     item.Condition,
+			Status:        item.Status,
+			Version:       1,
+		}
+		if item.UserID != 0 {
+			demoItem.UserID = g.user(item.UserID)
+		}
+		if item.OrgID != 0 {
+			if demoItem.OrgID, err = u.org(ctx, g, item.OrgID); err != nil {
+				return nil, err
+			}
+		}
+		g.dataset.Items = append(g.dataset.Items, demoItem)
+	}
+
+	return g.dataset, nil
+}
+
+// org は組織とそのメンバーをデータセットに加え、振り直した組織のIDを返す
+func (u *demoDatasetUsecase) org(ctx context.Context, g *demoGenerator, orgID int64) (int64, error) {
+	if id, ok := g.orgIDs[orgID]; ok {
+		return id, nil
+	}
+
+	members, err := u.orgRepo.FindMembers(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+
+	id := int64(len(g.dataset.Organizations) + 1)
+	g.orgIDs[orgID] = id
+	g.dataset.Organizations = append(g.dataset.Organizations, &entity.Organization{
+		ID:   id,
+		Name: fmt.Sprintf("デモ組織%d", id),
+	})
+	for _, member := range members {
+		g.dataset.Memberships = append(g.dataset.Memberships, &entity.Membership{
+			OrganizationID: id,
+			UserID:         g.user(member.UserID),
+			Role:           member.Role,
+		})
+	}
+
+	return id, nil
+}
+
+// demoGenerator は元の値から合成した値への対応を保持する（同じ値は同じ値に置き換え、分布を保つ）
+type demoGenerator struct {
+	rng          *rand.Rand
+	passwordHash string
+	dataset      *DemoDataset
+	userIDs      map[int64]int64
+	orgIDs       map[int64]int64
+	names        map[string]string
+	brands       map[string]string
+}
+
+// user はユーザーをデータセットに加え、振り直したユーザーのIDを返す
+func (g *demoGenerator) user(userID int64) int64 {
+	if id, ok := g.userIDs[userID]; ok {
+		return id
+	}
+
+	id := int64(len(g.dataset.Users) + 1)
+	g.userIDs[userID] = id
+	g.dataset.Users = append(g.dataset.Users, &entity.User{
+		ID:           id,
+		Email:        fmt.Sprintf("demo%d@example.com", id),
+		PasswordHash: g.passwordHash,
+		Role:         entity.DefaultRole,
+	})
+	return id
+}
+
+// replace は文字列を同じ長さ・同じ文字種の合成した文字列に置き換える
+func (g *demoGenerator) replace(seen map[string]string, s string) string {
+	if replaced, ok := seen[s]; ok {
+		return replaced
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = g.replaceRune(r)
+	}
+	replaced := string(runes)
+	seen[s] = replaced
+	return replaced
+}
+
+// replaceRune は文字を同じ種類の文字に置き換える（空白や記号はそのまま残す）
+func (g *demoGenerator) replaceRune(r rune) rune {
+	var pool []rune
+	switch {
+	case unicode.In(r, unicode.Hiragana):
+		pool = demoHiragana
+	case unicode.In(r, unicode.Katakana):
+		pool = demoKatakana
+	case unicode.In(r, unicode.Han):
+		pool = demoKanji
+	case unicode.IsDigit(r):
+		pool = demoDigits
+	case unicode.IsUpper(r):
+		pool = demoUpper
+	case unicode.IsLower(r):
+		pool = demoLower
+	default:
+		return r
+	}
+	return pool[g.rng.Intn(len(pool))]
+}
+
+// price は購入価格をずらし、元の値の末尾の 0 の数（千円単位など）に丸める
+func (g *demoGenerator) price(price int) int {
+	if price <= 0 {
+		return price
+	}
+
+	unit := 1
+	for price%(unit*10) == 0 {
+		unit *= 10
+	}
+	factor := demoPriceMinFactor + g.rng.Float64()*(demoPriceMaxFactor-demoPriceMinFactor)
+	scaled := int(math.Round(float64(price)*factor/float64(unit))) * unit
+	if scaled <= 0 {
+		return unit
+	}
+	if scaled > math.MaxInt32 {
+		return math.MaxInt32 / unit * unit
+	}
+	return scaled
+}
+
+// date は購入日を前後にずらす（未来の日付にはしない）
+func (g *demoGenerator) date(date string) string {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+
+	shifted := t.AddDate(0, 0, g.rng.Intn(2*demoDateJitterDays+1)-demoDateJitterDays)
+	if today := time.Now().UTC().Truncate(24 * time.Hour); shifted.After(today) {
+		shifted = today
+	}
+	return shifted.Format("2006-01-02")
+}
@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestDemoDatasetUsecase_Generate(t *testing.T) {
+	items := []*entity.Item{
+		{ID: 11, UserID: 5, Name: "ロレックス デイトナ 116500LN", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15", Visibility: entity.VisibilityPublic},
+		{ID: 12, UserID: 9, OrgID: 3, Name: "バーキン30", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: entity.JPY(2500000), PurchaseDate: "2022-06-01", Visibility: entity.VisibilityPrivate},
+		{ID: 13, UserID: 5, Name: "ロレックス デイトナ 116500LN", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.JPY(0), PurchaseDate: "2024-03-10", Visibility: entity.VisibilityPrivate},
+	}
+
+	t.Run("名前・ブランド・価格を置き換え、件数と長さと分布を保つ", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		orgRepo := new(MockOrganizationRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return(items, nil)
+		orgRepo.On("FindMembers", mock.Anything, int64(3)).Return([]*entity.Membership{
+			{OrganizationID: 3, UserID: 7, Role: entity.OrgRoleOwner},
+			{OrganizationID: 3, UserID: 9, Role: entity.OrgRoleEditor},
+		}, nil)
+
+		dataset, err := NewDemoDatasetUsecase(itemRepo, orgRepo).Generate(context.Background(), GenerateDemoDatasetInput{
+			Seed:     1,
+			Password: "demo-password",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, dataset.Items, 3)
+		for i, item := range dataset.Items {
+			original := items[i]
+			assert.Equal(t, int64(i+1), item.ID)
+			assert.NotEqual(t, original.Name, item.Name)
+			assert.Equal(t, utf8.RuneCountInString(original.Name), utf8.RuneCountInString(item.Name))
+			assert.NotEqual(t, original.Brand, item.Brand)
+			assert.Equal(t, utf8.RuneCountInString(original.Brand), utf8.RuneCountInString(item.Brand))
+			assert.Equal(t, original.Category, item.Category)
+			assert.Equal(t, original.Visibility, item.Visibility)
+			assert.NoError(t, item.Validate())
+		}
+		// 同じ値は同じ値に置き換える
+		assert.Equal(t, dataset.Items[0].Name, dataset.Items[2].Name)
+		assert.Equal(t, dataset.Items[0].Brand, dataset.Items[2].Brand)
+		// 空白は残す
+		assert.Contains(t, dataset.Items[0].Name, " ")
+
+		// 価格は元の値の近くに丸める
+		assert.InDelta(t, 1500000, dataset.Items[0].PurchasePrice.Amount, 400000)
+		assert.Zero(t, dataset.Items[0].PurchasePrice.Amount%100000)
+		assert.Zero(t, dataset.Items[2].PurchasePrice.Amount)
+		assert.Equal(t, entity.CurrencyJPY, dataset.Items[0].PurchasePrice.Currency)
+
+		// ユーザーと組織は連番に振り直し、組織の役割を保つ
+		require.Len(t, dataset.Users, 3)
+		assert.Equal(t, int64(1), dataset.Items[0].UserID)
+		assert.Equal(t, int64(2), dataset.Items[1].UserID)
+		assert.Equal(t, int64(1), dataset.Items[1].OrgID)
+		assert.Equal(t, "demo1@example.com", dataset.Users[0].Email)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(dataset.Users[0].PasswordHash), []byte("demo-password")))
+		require.Len(t, dataset.Organizations, 1)
+		assert.Equal(t, []*entity.Membership{
+			{OrganizationID: 1, UserID: 3, Role: entity.OrgRoleOwner},
+			{OrganizationID: 1, UserID: 2, Role: entity.OrgRoleEditor},
+		}, dataset.Memberships)
+		orgRepo.AssertNumberOfCalls(t, "FindMembers", 1)
+	})
+
+	t.Run("同じ種からは同じデータセットを作る", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return(items[:1], nil)
+		u := NewDemoDatasetUsecase(itemRepo, new(MockOrganizationRepository))
+
+		first, err := u.Generate(context.Background(), GenerateDemoDatasetInput{Seed: 42, Password: "demo-password"})
+		require.NoError(t, err)
+		second, err := u.Generate(context.Background(), GenerateDemoDatasetInput{Seed: 42, Password: "demo-password"})
+		require.NoError(t, err)
+
+		assert.Equal(t, first.Items, second.Items)
+	})
+
+	t.Run("パスワードが短い場合はエラー", func(t *testing.T) {
+		_, err := NewDemoDatasetUsecase(new(MockItemRepository), new(MockOrganizationRepository)).
+			Generate(context.Background(), GenerateDemoDatasetInput{Password: "short"})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+}
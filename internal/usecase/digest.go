@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// Mailer はメールを送信する（SMTP サーバーへの送信やログへの出力で実装する）
+type Mailer interface {
+	Send(ctx context.Context, message *MailMessage) error
+}
+
+// MailMessage は送信するメール（本文はテキストと HTML の両方）
+type MailMessage struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+	// Headers は追加のヘッダー（List-Unsubscribe など）
+	Headers map[string]string
+}
+
+// DigestRenderer はダイジェストをメールの件名と本文に変換する（テンプレートで実装する）
+type DigestRenderer interface {
+	Render(digest *Digest) (*MailMessage, error)
+}
+
+// Digest はダイジェストメールの内容
+type Digest struct {
+	Email     string
+	Frequency entity.DigestFrequency
+	// From, To は集計期間
+	From time.Time
+	To   time.Time
+	// AddedItems は期間内に登録されたアイテム、UpdatedItems は期間より前に登録され期間内に更新されたアイテム
+	AddedItems   []*entity.Item
+	UpdatedItems []*entity.Item
+	// Currency は AddedValue と TotalValue の通貨（ユーザーの表示通貨）
+	Currency entity.Currency
+	// AddedValue は期間内に登録されたアイテムの購入価格の合計（ポートフォリオの増加分）
+	AddedValue int64
+	TotalCount int
+	TotalValue int64
+	// ExpiringConsignments は期限が近い委託中のアイテム（期限の近い順）
+	ExpiringConsignments []DigestConsignment
+	UnsubscribeURL       string
+}
+
+// DigestConsignment は期限が近い委託中のアイテム
+type DigestConsignment struct {
+	Item     *entity.Item
+	Deadline string
+	DaysLeft int
+}
+
+// 期限が近いとみなす委託品の期限までの日数
+const digestExpiryDays = 30
+
+type DigestUsecase interface {
+	// GetPreference は操作者のダイジェストメールの配信設定を返す
+	GetPreference(ctx context.Context) (*entity.DigestSubscription, error)
+	// UpdatePreference は操作者のダイジェストメールの配信頻度を変更する
+	UpdatePreference(ctx context.Context, frequency entity.DigestFrequency) (*entity.DigestSubscription, error)
+	// Unsubscribe はメールの配信停止リンクのトークンのユーザーの配信を停止する（認証不要）
+	Unsubscribe(ctx context.Context, token string) error
+	// Preview は操作者に次に配信するダイジェストメールを返す（送信はしない）
+	Preview(ctx context.Context) (*MailMessage, error)
+	// SendDue は配信する時期になったユーザーにダイジェストメールを送信し、送信した件数を返す
+	SendDue(ctx context.Context) (int, error)
+}
+
+type digestUsecase struct {
+	subscriptionRepo DigestSubscriptionRepository
+	userRepo         UserRepository
+	itemRepo         ItemRepository
+	consignmentRepo  ConsignmentRepository
+	mailer           Mailer
+	renderer         DigestRenderer
+	secret           []byte
+	unsubscribeURL   string
+	// converter は合計の金額を表示通貨に換算する（nil の場合は円建てのアイテムのみ合計する）
+	converter CurrencyConverter
+	now       func() time.Time
+}
+
+// DigestUsecaseOption は DigestUsecase の任意の設定
+type DigestUsecaseOption func(*digestUsecase)
+
+// WithDigestCurrencyConverter は合計の金額をユーザーの表示通貨に換算するよう設定する（未設定の場合は円建てのみ合計する）
+func WithDigestCurrencyConverter(converter CurrencyConverter) DigestUsecaseOption {
+	return func(u *digestUsecase) {
+		u.converter = converter
+	}
+}
+
+// NewDigestUsecase は secret で配信停止リンクのトークンに署名し、unsubscribeURL（例: https://example.com/digest/unsubscribe）を
+// 配信停止リンクのリンク先とする DigestUsecase を返す
+func NewDigestUsecase(
+	subscriptionRepo DigestSubscriptionRepository,
+	userRepo UserRepository,
+	itemRepo ItemRepository,
+	consignmentRepo ConsignmentRepository,
+	mailer Mailer,
+	renderer DigestRenderer,
+	secret, unsubscribeURL string,
+	opts ...DigestUsecaseOption,
+) DigestUsecase {
+	u := &digestUsecase{
+		subscriptionRepo: subscriptionRepo,
+		userRepo:         userRepo,
+		itemRepo:         itemRepo,
+		consignmentRepo:  consignmentRepo,
+		mailer:           mailer,
+		renderer:         renderer,
+		secret:           []byte(secret),
+		unsubscribeURL:   unsubscribeURL,
+		now:              time.Now,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *digestUsecase) GetPreference(ctx context.Context) (*entity.DigestSubscription, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := u.subscriptionRepo.FindByUserID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve digest subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (u *digestUsecase) UpdatePreference(ctx context.Context, frequency entity.DigestFrequency) (*entity.DigestSubscription, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := entity.NewDigestSubscription(actor.ID, frequency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	saved, err := u.subscriptionRepo.Save(ctx, subscription)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save digest subscription: %w", err)
+	}
+
+	return saved, nil
+}
+
+func (u *digestUsecase) Unsubscribe(ctx context.Context, token string) error {
+	userID, ok := u.verifyUnsubscribeToken(token)
+	if !ok {
+		return fmt.Errorf("%w: invalid unsubscribe token", domainErrors.ErrInvalidInput)
+	}
+
+	subscription, err := entity.NewDigestSubscription(userID, entity.DigestFrequencyOff)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	if _, err := u.subscriptionRepo.Save(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to save digest subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (u *digestUsecase) Preview(ctx context.Context) (*MailMessage, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := u.subscriptionRepo.FindByUserID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve digest subscription: %w", err)
+	}
+	// 配信しない設定でも内容を確認できるよう、週次として集計する
+	if subscription.Frequency == entity.DigestFrequencyOff {
+		subscription.Frequency = entity.DigestFrequencyWeekly
+	}
+
+	return u.compose(ctx, actor, subscription, u.now())
+}
+
+func (u *digestUsecase) SendDue(ctx context.Context) (int, error) {
+	subscriptions, err := u.subscriptionRepo.FindSubscribed(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve digest subscriptions: %w", err)
+	}
+
+	// 1人への送信に失敗しても、他のユーザーへの送信は続ける
+	sent := 0
+	var errs []error
+	for i, subscription := range subscriptions {
+		ReportJobProgress(ctx, i, len(subscriptions))
+		now := u.now()
+		if !subscription.IsDue(now) {
+			continue
+		}
+		if err := u.send(ctx, subscription, now); err != nil {
+			errs = append(errs, fmt.Errorf("user %d: %w", subscription.UserID, err))
+			ReportJobChunkError(ctx, "user "+strconv.FormatInt(subscription.UserID, 10), err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+func (u *digestUsecase) send(ctx context.Context, subscription *entity.DigestSubscription, now time.Time) error {
+	// 管理者や組織のメンバーは一覧と同じ範囲のアイテムを集計する
+	user, err := u.userRepo.FindByID(ctx, subscription.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	message, err := u.compose(ctx, user, subscription, now)
+	if err != nil {
+		return err
+	}
+	if err := u.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send digest: %w", err)
+	}
+
+	if err := u.subscriptionRepo.MarkSent(ctx, subscription.UserID, now); err != nil {
+		return fmt.Errorf("failed to record digest delivery: %w", err)
+	}
+	return nil
+}
+
+// compose はユーザーのダイジェストを集計してメールにする
+func (u *digestUsecase) compose(ctx context.Context, user *entity.User, subscription *entity.DigestSubscription, now time.Time) (*MailMessage, error) {
+	digest, err := u.collect(ctx, user, subscription, now)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := u.renderer.Render(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render digest: %w", err)
+	}
+	message.To = subscription.Email
+	if message.Headers == nil {
+		message.Headers = map[string]string{}
+	}
+	message.Headers["List-Unsubscribe"] = "<" + digest.UnsubscribeURL + ">"
+	message.Headers["List-Unsubscribe-Post"] = "List-Unsubscribe=One-Click"
+
+	return message, nil
+}
+
+func (u *digestUsecase) collect(ctx context.Context, user *entity.User, subscription *entity.DigestSubscription, now time.Time) (*Digest, error) {
+	from := subscription.PeriodStart(now)
+	valuation := newValuation(user, u.converter)
+	digest := &Digest{
+		Email:                subscription.Email,
+		Frequency:            subscription.Frequency,
+		From:                 from,
+		To:                   now,
+		Currency:             valuation.currency,
+		AddedItems:           []*entity.Item{},
+		UpdatedItems:         []*entity.Item{},
+		ExpiringConsignments: []DigestConsignment{},
+		UnsubscribeURL:       u.unsubscribeLink(subscription.UserID),
+	}
+
+	scope := itemScope(user)
+	items, err := u.itemRepo.FindAll(ctx, entity.ItemFilter{UserID: scope})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	redactItems(user, items...)
+
+	itemsByID := make(map[int64]*entity.Item, len(items))
+	for _, item := range items {
+		itemsByID[item.ID] = item
+		digest.TotalCount++
+
+		// 非表示にした購入価格と換算できない外貨建ての金額は合計に含めない
+		var value int64
+		if !item.IsRedacted(entity.ItemFieldPurchasePrice) {
+			converted, ok, err := valuation.convert(ctx, int64(item.PurchasePrice.Amount), item.PurchasePrice.Currency)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				value = converted
+			}
+		}
+		digest.TotalValue = entity.AddAmount(digest.TotalValue, value)
+
+		switch {
+		case inPeriod(item.CreatedAt, from, now):
+			digest.AddedItems = append(digest.AddedItems, item)
+			digest.AddedValue = entity.AddAmount(digest.AddedValue, value)
+		case inPeriod(item.UpdatedAt, from, now):
+			digest.UpdatedItems = append(digest.UpdatedItems, item)
+		}
+	}
+
+	consignments, err := u.consignmentRepo.FindAll(ctx, scope, entity.ConsignmentStatusActive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve consignments: %w", err)
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	for _, consignment := range consignments {
+		item, ok := itemsByID[consignment.ItemID]
+		if !ok || consignment.Deadline == "" {
+			continue
+		}
+		deadline, err := time.ParseInLocation("2006-01-02", consignment.Deadline, now.Location())
+		if err != nil {
+			continue
+		}
+		daysLeft := int(deadline.Sub(today).Hours() / 24)
+		if daysLeft > digestExpiryDays {
+			continue
+		}
+		digest.ExpiringConsignments = append(digest.ExpiringConsignments, DigestConsignment{
+			Item:     item,
+			Deadline: consignment.Deadline,
+			DaysLeft: daysLeft,
+		})
+	}
+	sort.SliceStable(digest.ExpiringConsignments, func(i, j int) bool {
+		return digest.ExpiringConsignments[i].Deadline < digest.ExpiringConsignments[j].Deadline
+	})
+
+	return digest, nil
+}
+
+// inPeriod は t が from より後かつ to 以前かを判定する
+func inPeriod(t, from, to time.Time) bool {
+	return t.After(from) && !t.After(to)
+}
+
+// unsubscribeLink はユーザーの配信停止リンクを返す
+func (u *digestUsecase) unsubscribeLink(userID int64) string {
+	return u.unsubscribeURL + "?token=" + url.QueryEscape(u.signUnsubscribe(userID))
+}
+
+func (u *digestUsecase) signUnsubscribe(userID int64) string {
+	mac := hmac.New(sha256.New, u.secret)
+	fmt.Fprintf(mac, "digest-unsubscribe\n%d", userID)
+	return fmt.Sprintf("%d-%s", userID, hex.EncodeToString(mac.Sum(nil)[:16]))
+}
+
+func (u *digestUsecase) verifyUnsubscribeToken(token string) (int64, bool) {
+	idPart, _, ok := strings.Cut(token, "-")
+	if !ok {
+		return 0, false
+	}
+	userID, err := strconv.ParseInt(idPart, 10, 64)
+	if err != nil || userID <= 0 {
+		return 0, false
+	}
+	return userID, hmac.Equal([]byte(u.signUnsubscribe(userID)), []byte(token))
+}
@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockDigestSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockDigestSubscriptionRepository) FindByUserID(ctx context.Context, userID int64) (*entity.DigestSubscription, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DigestSubscription), args.Error(1)
+}
+
+func (m *MockDigestSubscriptionRepository) Save(ctx context.Context, subscription *entity.DigestSubscription) (*entity.DigestSubscription, error) {
+	args := m.Called(ctx, subscription)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DigestSubscription), args.Error(1)
+}
+
+func (m *MockDigestSubscriptionRepository) FindSubscribed(ctx context.Context) ([]*entity.DigestSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.DigestSubscription), args.Error(1)
+}
+
+func (m *MockDigestSubscriptionRepository) MarkSent(ctx context.Context, userID int64, sentAt time.Time) error {
+	args := m.Called(ctx, userID, sentAt)
+	return args.Error(0)
+}
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, message *MailMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
+
+type MockDigestRenderer struct {
+	mock.Mock
+}
+
+func (m *MockDigestRenderer) Render(digest *Digest) (*MailMessage, error) {
+	args := m.Called(digest)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MailMessage), args.Error(1)
+}
+
+type digestTestDeps struct {
+	subscriptions *MockDigestSubscriptionRepository
+	users         *MockUserRepository
+	items         *MockItemRepository
+	consignments  *MockConsignmentRepository
+	mailer        *MockMailer
+	renderer      *MockDigestRenderer
+}
+
+var digestTestNow = time.Date(2024, 7, 8, 9, 0, 0, 0, time.Local)
+
+func newDigestTestUsecase() (*digestUsecase, digestTestDeps) {
+	deps := digestTestDeps{
+		subscriptions: new(MockDigestSubscriptionRepository),
+		users:         new(MockUserRepository),
+		items:         new(MockItemRepository),
+		consignments:  new(MockConsignmentRepository),
+		mailer:        new(MockMailer),
+		renderer:      new(MockDigestRenderer),
+	}
+	u := NewDigestUsecase(deps.subscriptions, deps.users, deps.items, deps.consignments, deps.mailer, deps.renderer,
+		"secret", "https://example.com/digest/unsubscribe").(*digestUsecase)
+	u.now = func() time.Time { return digestTestNow }
+	return u, deps
+}
+
+func TestDigestUsecase_UpdatePreference(t *testing.T) {
+	t.Run("正常系: 配信頻度を変更する", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+		saved := &entity.DigestSubscription{UserID: testActor.ID, Email: testActor.Email, Frequency: entity.DigestFrequencyMonthly}
+		deps.subscriptions.On("Save", mock.Anything, mock.MatchedBy(func(s *entity.DigestSubscription) bool {
+			return s.UserID == testActor.ID && s.Frequency == entity.DigestFrequencyMonthly
+		})).Return(saved, nil)
+
+		subscription, err := usecase.UpdatePreference(actorContext(), entity.DigestFrequencyMonthly)
+
+		require.NoError(t, err)
+		assert.Equal(t, saved, subscription)
+	})
+
+	t.Run("異常系: 未定義の配信頻度", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+
+		_, err := usecase.UpdatePreference(actorContext(), "daily")
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		deps.subscriptions.AssertNotCalled(t, "Save")
+	})
+}
+
+func TestDigestUsecase_Unsubscribe(t *testing.T) {
+	t.Run("正常系: 配信停止リンクのトークンで配信を停止する", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+		deps.subscriptions.On("Save", mock.Anything, mock.MatchedBy(func(s *entity.DigestSubscription) bool {
+			return s.UserID == testActor.ID && s.Frequency == entity.DigestFrequencyOff
+		})).Return(&entity.DigestSubscription{UserID: testActor.ID, Frequency: entity.DigestFrequencyOff}, nil)
+
+		link, err := url.Parse(usecase.unsubscribeLink(testActor.ID))
+		require.NoError(t, err)
+
+		require.NoError(t, usecase.Unsubscribe(context.Background(), link.Query().Get("token")))
+		deps.subscriptions.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 他のユーザーのIDに書き換えたトークン", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+		token := usecase.signUnsubscribe(testActor.ID)
+		_, signature, _ := strings.Cut(token, "-")
+
+		for _, token := range []string{"2-" + signature, "", "abc", "1-"} {
+			err := usecase.Unsubscribe(context.Background(), token)
+			assert.True(t, domainErrors.IsValidationError(err), token)
+		}
+		deps.subscriptions.AssertNotCalled(t, "Save")
+	})
+}
+
+func TestDigestUsecase_SendDue(t *testing.T) {
+	lastWeek := digestTestNow.AddDate(0, 0, -7)
+	yesterday := digestTestNow.AddDate(0, 0, -1)
+	longAgo := digestTestNow.AddDate(-1, 0, 0)
+
+	added := &entity.Item{ID: 1, Name: "デイトナ", PurchasePrice: entity.JPY(1500000), CreatedAt: yesterday, UpdatedAt: yesterday}
+	updated := &entity.Item{ID: 2, Name: "バーキン", PurchasePrice: entity.JPY(2000000), CreatedAt: longAgo, UpdatedAt: yesterday}
+	unchanged := &entity.Item{ID: 3, Name: "ネックレス", PurchasePrice: entity.JPY(300000), CreatedAt: longAgo, UpdatedAt: longAgo}
+
+	t.Run("正常系: 配信する時期になったユーザーに集計を送信する", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+		deps.subscriptions.On("FindSubscribed", mock.Anything).Return([]*entity.DigestSubscription{
+			{UserID: testActor.ID, Email: testActor.Email, Frequency: entity.DigestFrequencyWeekly, LastSentAt: &lastWeek},
+			{UserID: 2, Email: "monthly@example.com", Frequency: entity.DigestFrequencyMonthly, LastSentAt: &lastWeek},
+		}, nil)
+		deps.users.On("FindByID", mock.Anything, testActor.ID).Return(testActor, nil)
+		deps.items.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID}).
+			Return([]*entity.Item{added, updated, unchanged}, nil)
+		deps.consignments.On("FindAll", mock.Anything, testActor.ID, entity.ConsignmentStatusActive).Return([]*entity.Consignment{
+			{ItemID: 3, Deadline: "2024-08-31"},
+			{ItemID: 2, Deadline: "2024-07-10"},
+			{ItemID: 1, Deadline: "2024-07-01"},
+			{ItemID: 1},
+		}, nil)
+
+		var rendered *Digest
+		deps.renderer.On("Render", mock.Anything).Run(func(args mock.Arguments) {
+			rendered = args.Get(0).(*Digest)
+		}).Return(&MailMessage{Subject: "digest"}, nil)
+		deps.mailer.On("Send", mock.Anything, mock.MatchedBy(func(m *MailMessage) bool {
+			return m.To == testActor.Email && strings.HasPrefix(m.Headers["List-Unsubscribe"], "<https://example.com/digest/unsubscribe?token=1-")
+		})).Return(nil)
+		deps.subscriptions.On("MarkSent", mock.Anything, testActor.ID, digestTestNow).Return(nil)
+
+		sent, err := usecase.SendDue(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		require.NotNil(t, rendered)
+		assert.Equal(t, lastWeek, rendered.From)
+		assert.Equal(t, []*entity.Item{added}, rendered.AddedItems)
+		assert.Equal(t, []*entity.Item{updated}, rendered.UpdatedItems)
+		assert.Equal(t, int64(1500000), rendered.AddedValue)
+		assert.Equal(t, 3, rendered.TotalCount)
+		assert.Equal(t, int64(3800000), rendered.TotalValue)
+		require.Len(t, rendered.ExpiringConsignments, 2)
+		assert.Equal(t, "2024-07-01", rendered.ExpiringConsignments[0].Deadline)
+		assert.Equal(t, -7, rendered.ExpiringConsignments[0].DaysLeft)
+		assert.Equal(t, 2, rendered.ExpiringConsignments[1].DaysLeft)
+		deps.mailer.AssertExpectations(t)
+		deps.subscriptions.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 外貨建てのアイテムも表示通貨に換算して合計する", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+		converter := new(MockCurrencyConverter)
+		WithDigestCurrencyConverter(converter)(usecase)
+		usdUser := &entity.User{ID: testActor.ID, Email: testActor.Email, Role: entity.RoleEditor, PreferredCurrency: entity.CurrencyUSD}
+		addedUSD := &entity.Item{ID: 4, Name: "スピードマスター", PurchasePrice: entity.NewMoney(650000, "USD"), CreatedAt: yesterday, UpdatedAt: yesterday}
+		addedEUR := &entity.Item{ID: 5, Name: "ケリー", PurchasePrice: entity.NewMoney(900000, "EUR"), CreatedAt: yesterday, UpdatedAt: yesterday}
+		deps.subscriptions.On("FindSubscribed", mock.Anything).Return([]*entity.DigestSubscription{
+			{UserID: testActor.ID, Email: testActor.Email, Frequency: entity.DigestFrequencyWeekly, LastSentAt: &lastWeek},
+		}, nil)
+		deps.users.On("FindByID", mock.Anything, testActor.ID).Return(usdUser, nil)
+		deps.items.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID}).
+			Return([]*entity.Item{added, addedUSD, addedEUR, unchanged}, nil)
+		deps.consignments.On("FindAll", mock.Anything, testActor.ID, entity.ConsignmentStatusActive).Return([]*entity.Consignment{}, nil)
+		converter.On("Convert", mock.Anything, int64(1500000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(996675), nil)
+		converter.On("Convert", mock.Anything, int64(900000), entity.CurrencyEUR, entity.CurrencyUSD).Return(int64(972000), nil)
+		converter.On("Convert", mock.Anything, int64(300000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(199335), nil)
+		var rendered *Digest
+		deps.renderer.On("Render", mock.Anything).Run(func(args mock.Arguments) {
+			rendered = args.Get(0).(*Digest)
+		}).Return(&MailMessage{Subject: "digest"}, nil)
+		deps.mailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+		deps.subscriptions.On("MarkSent", mock.Anything, testActor.ID, digestTestNow).Return(nil)
+
+		_, err := usecase.SendDue(context.Background())
+
+		require.NoError(t, err)
+		require.NotNil(t, rendered)
+		assert.Equal(t, entity.CurrencyUSD, rendered.Currency)
+		assert.Equal(t, int64(996675+650000+972000), rendered.AddedValue)
+		assert.Equal(t, 4, rendered.TotalCount)
+		assert.Equal(t, int64(996675+650000+972000+199335), rendered.TotalValue)
+		converter.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 換算できない場合は円建てのアイテムのみ合計する", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+		addedUSD := &entity.Item{ID: 4, Name: "スピードマスター", PurchasePrice: entity.NewMoney(650000, "USD"), CreatedAt: yesterday, UpdatedAt: yesterday}
+		deps.subscriptions.On("FindSubscribed", mock.Anything).Return([]*entity.DigestSubscription{
+			{UserID: testActor.ID, Email: testActor.Email, Frequency: entity.DigestFrequencyWeekly, LastSentAt: &lastWeek},
+		}, nil)
+		deps.users.On("FindByID", mock.Anything, testActor.ID).Return(testActor, nil)
+		deps.items.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{added, addedUSD}, nil)
+		deps.consignments.On("FindAll", mock.Anything, testActor.ID, entity.ConsignmentStatusActive).Return([]*entity.Consignment{}, nil)
+		var rendered *Digest
+		deps.renderer.On("Render", mock.Anything).Run(func(args mock.Arguments) {
+			rendered = args.Get(0).(*Digest)
+		}).Return(&MailMessage{Subject: "digest"}, nil)
+		deps.mailer.On("Send", mock.Anything, mock.Anything).Return(nil)
+		deps.subscriptions.On("MarkSent", mock.Anything, testActor.ID, digestTestNow).Return(nil)
+
+		_, err := usecase.SendDue(context.Background())
+
+		require.NoError(t, err)
+		require.NotNil(t, rendered)
+		assert.Equal(t, entity.CurrencyJPY, rendered.Currency)
+		assert.Equal(t, 2, rendered.TotalCount)
+		assert.Equal(t, int64(1500000), rendered.TotalValue)
+	})
+
+	t.Run("異常系: 送信に失敗したユーザーは配信済みにしない", func(t *testing.T) {
+		usecase, deps := newDigestTestUsecase()
+		deps.subscriptions.On("FindSubscribed", mock.Anything).Return([]*entity.DigestSubscription{
+			{UserID: testActor.ID, Email: testActor.Email, Frequency: entity.DigestFrequencyWeekly},
+		}, nil)
+		deps.users.On("FindByID", mock.Anything, testActor.ID).Return(testActor, nil)
+		deps.items.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{}, nil)
+		deps.consignments.On("FindAll", mock.Anything, testActor.ID, entity.ConsignmentStatusActive).Return([]*entity.Consignment{}, nil)
+		deps.renderer.On("Render", mock.Anything).Return(&MailMessage{Subject: "digest"}, nil)
+		deps.mailer.On("Send", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+		sent, err := usecase.SendDue(context.Background())
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, sent)
+		deps.subscriptions.AssertNotCalled(t, "MarkSent")
+	})
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 同時に文字認識する画像数
+const maxConcurrentRecognitions = 2
+
+// 検索結果に加える購入書類のテキストに一致したアイテムの最大件数
+const maxDocumentMatches = 50
+
+// TextRecognizer は画像の文字を認識してテキストにする（OCR の API などで実装する）
+type TextRecognizer interface {
+	Recognize(ctx context.Context, contentType string, image io.Reader) (string, error)
+}
+
+// DocumentTextIndex は購入書類の画像から抽出したテキストを保存し、全文検索する（DB の全文インデックスや検索エンジンで実装する）
+type DocumentTextIndex interface {
+	// Put は画像のテキストを保存する（同じ画像のテキストは置き換える）。画像が削除済みの場合は ErrItemImageNotFound を返す
+	Put(ctx context.Context, doc *entity.DocumentText) error
+	// Delete は画像のテキストを削除する（保存していない場合は何もしない）
+	Delete(ctx context.Context, itemID, imageID int64) error
+	// Search は keyword を含むテキストのアイテムを関連度の高い順に最大 limit 件返す（1アイテム1件）。
+	// scope は itemScope と同じ絞り込みで、実装が絞り込めない場合も呼び出し側で閲覧できるアイテムに限る
+	Search(ctx context.Context, keyword string, scope int64, limit int) ([]entity.DocumentMatch, error)
+}
+
+// WithDocumentText はアップロード後に画像の文字を非同期で認識し、テキストを検索用に保存する
+func WithDocumentText(recognizer TextRecognizer, index DocumentTextIndex) ItemImageUsecaseOption {
+	return func(u *itemImageUsecase) {
+		u.recognizer = recognizer
+		u.documentIndex = index
+	}
+}
+
+// indexDocumentText は画像の文字を認識してテキストを保存する（文字がない画像は保存しない）
+func (u *itemImageUsecase) indexDocumentText(ctx context.Context, image *entity.ItemImage) error {
+	src, err := u.storage.Get(ctx, image.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+	text, err := u.recognizer.Recognize(ctx, image.ContentType, src)
+	src.Close()
+	if err != nil {
+		return fmt.Errorf("failed to recognize text: %w", err)
+	}
+
+	doc := entity.NewDocumentText(image, text, time.Now())
+	if doc.Text == "" {
+		return nil
+	}
+	if err := u.documentIndex.Put(ctx, doc); err != nil {
+		// 認識中に画像が削除された場合は保存しない
+		if domainErrors.IsNotFoundError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to index text: %w", err)
+	}
+	return nil
+}
+
+// WithDocumentSearch はアイテム検索で購入書類の画像から抽出したテキストも検索する
+func WithDocumentSearch(index DocumentTextIndex) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.documentIndex = index
+	}
+}
+
+// searchDocuments は購入書類のテキストに一致したアイテムを results に加える。
+// 名前・ブランドに一致したアイテムには一致した箇所を加え、テキストのみに一致したアイテムは関連度の高い順に後ろに加える。
+// 購入価格を非表示にするアイテムは、書類の金額から購入価格を推測できないよう書類のテキストでは検索しない
+func (u *itemUsecase) searchDocuments(ctx context.Context, actor *entity.User, query string, results []*entity.SearchResult) ([]*entity.SearchResult, error) {
+	matches, err := u.documentIndex.Search(ctx, query, itemScope(actor), maxDocumentMatches)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+
+	found := make(map[int64]*entity.SearchResult, len(results))
+	for _, result := range results {
+		found[result.ID] = result
+	}
+	for _, match := range matches {
+		result, ok := found[match.ItemID]
+		if !ok {
+			item, err := u.itemRepo.FindByID(ctx, match.ItemID)
+			if err != nil {
+				// 索引に残った削除済みのアイテムは除く
+				if domainErrors.IsNotFoundError(err) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to retrieve item: %w", err)
+			}
+			if !canReadItem(actor, item) {
+				continue
+			}
+			result = &entity.SearchResult{Item: item, Score: match.Score, Highlights: []entity.SearchHighlight{}}
+		}
+		if slices.Contains(entity.ItemRedactionFor(actor, result.OrgID), entity.ItemFieldPurchasePrice) {
+			continue
+		}
+
+		result.Highlights = append(result.Highlights, entity.SearchHighlight{Field: entity.SearchFieldDocuments, Spans: match.Spans, Snippet: match.Snippet})
+		if !ok {
+			found[match.ItemID] = result
+			results = append(results, result)
+		}
+	}
+	return results, nil
+}
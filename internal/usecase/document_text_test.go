@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockTextRecognizer struct {
+	mock.Mock
+}
+
+func (m *MockTextRecognizer) Recognize(ctx context.Context, contentType string, image io.Reader) (string, error) {
+	body, _ := io.ReadAll(image)
+	args := m.Called(contentType, body)
+	return args.String(0), args.Error(1)
+}
+
+type MockDocumentTextIndex struct {
+	mock.Mock
+}
+
+func (m *MockDocumentTextIndex) Put(ctx context.Context, doc *entity.DocumentText) error {
+	args := m.Called(ctx, doc)
+	return args.Error(0)
+}
+
+func (m *MockDocumentTextIndex) Delete(ctx context.Context, itemID, imageID int64) error {
+	args := m.Called(ctx, itemID, imageID)
+	return args.Error(0)
+}
+
+func (m *MockDocumentTextIndex) Search(ctx context.Context, keyword string, scope int64, limit int) ([]entity.DocumentMatch, error) {
+	args := m.Called(ctx, keyword, scope, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.DocumentMatch), args.Error(1)
+}
+
+// newDocumentTextTestUsecase は文字認識を同期的に実行する ItemImageUsecase を作成する
+func newDocumentTextTestUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, storage FileStorage, recognizer TextRecognizer, index DocumentTextIndex) *itemImageUsecase {
+	u := NewItemImageUsecase(itemRepo, imageRepo, storage, WithDocumentText(recognizer, index)).(*itemImageUsecase)
+	u.async = func(fn func()) { fn() }
+	return u
+}
+
+func TestItemImageUsecase_DocumentText(t *testing.T) {
+	image := &entity.ItemImage{ID: 5, ItemID: 1, ContentType: "image/png", StorageKey: "items/1/a.png"}
+
+	t.Run("正常系: アップロード後に認識したテキストを保存する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{}, nil)
+		imageRepo.On("Create", mock.Anything, mock.Anything).Return(image, nil)
+		storage := new(MockFileStorage)
+		storage.On("Put", mock.Anything, mock.AnythingOfType("string"), testPNG).Return(nil)
+		storage.On("Get", mock.Anything, "items/1/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+		recognizer := new(MockTextRecognizer)
+		recognizer.On("Recognize", "image/png", testPNG).Return("  お買上げ店舗: 銀座本店\n", nil)
+		index := new(MockDocumentTextIndex)
+		index.On("Put", mock.Anything, mock.MatchedBy(func(doc *entity.DocumentText) bool {
+			return doc.ItemID == 1 && doc.ImageID == 5 && doc.Text == "お買上げ店舗: 銀座本店"
+		})).Return(nil)
+		usecase := newDocumentTextTestUsecase(itemRepo, imageRepo, storage, recognizer, index)
+
+		_, err := usecase.Upload(actorContext(), 1, UploadImageInput{FileName: "a.png", Size: int64(len(testPNG)), Body: bytes.NewReader(testPNG)})
+		require.NoError(t, err)
+		index.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 文字がない画像や認識中に削除された画像は保存しない", func(t *testing.T) {
+		storage := new(MockFileStorage)
+		storage.On("Get", mock.Anything, "items/1/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil).Once()
+		storage.On("Get", mock.Anything, "items/1/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil).Once()
+		recognizer := new(MockTextRecognizer)
+		recognizer.On("Recognize", "image/png", testPNG).Return(" \n", nil).Once()
+		recognizer.On("Recognize", "image/png", testPNG).Return("鑑定書", nil).Once()
+		index := new(MockDocumentTextIndex)
+		index.On("Put", mock.Anything, mock.Anything).Return(domainErrors.ErrItemImageNotFound)
+		usecase := newDocumentTextTestUsecase(nil, nil, storage, recognizer, index)
+
+		require.NoError(t, usecase.indexDocumentText(context.Background(), image))
+		index.AssertNotCalled(t, "Put", mock.Anything, mock.Anything)
+		require.NoError(t, usecase.indexDocumentText(context.Background(), image))
+		index.AssertNumberOfCalls(t, "Put", 1)
+	})
+
+	t.Run("異常系: 文字認識の失敗", func(t *testing.T) {
+		storage := new(MockFileStorage)
+		storage.On("Get", mock.Anything, "items/1/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+		recognizer := new(MockTextRecognizer)
+		recognizer.On("Recognize", "image/png", testPNG).Return("", errors.New("ocr api returned 500"))
+		index := new(MockDocumentTextIndex)
+		usecase := newDocumentTextTestUsecase(nil, nil, storage, recognizer, index)
+
+		err := usecase.indexDocumentText(context.Background(), image)
+		assert.ErrorContains(t, err, "failed to recognize text")
+		index.AssertNotCalled(t, "Put", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 画像の削除でテキストも削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(1), int64(5)).Return(image, nil)
+		imageRepo.On("Delete", mock.Anything, int64(1), int64(5)).Return(nil)
+		storage := new(MockFileStorage)
+		storage.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		index := new(MockDocumentTextIndex)
+		index.On("Delete", mock.Anything, int64(1), int64(5)).Return(nil)
+		usecase := newDocumentTextTestUsecase(itemRepo, imageRepo, storage, new(MockTextRecognizer), index)
+
+		require.NoError(t, usecase.Delete(actorContext(), 1, 5))
+		index.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_SearchItemsWithDocuments(t *testing.T) {
+	ginzaSpans := []entity.TextSpan{{Start: 5, End: 9}}
+	newItem := func(id int64, name string) *entity.Item {
+		item, _ := newOwnedItem(name, "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = id
+		return item
+	}
+	newUsecase := func(itemRepo *MockItemRepository, index *MockDocumentTextIndex) ItemUsecase {
+		return NewItemUsecase(itemRepo, WithDocumentSearch(index))
+	}
+
+	t.Run("正常系: 名前に一致したアイテムに書類の一致箇所を加え、書類のみに一致したアイテムを後ろに加える", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Search", mock.Anything, "銀座本店", testActor.ID).Return([]*entity.SearchResult{{
+			Item: newItem(1, "銀座本店限定 デイトナ"), Score: 2,
+			Highlights: []entity.SearchHighlight{{Field: entity.SearchFieldName, Spans: []entity.TextSpan{{Start: 0, End: 4}}}},
+		}}, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(newItem(2, "サブマリーナ"), nil)
+		itemRepo.On("FindByID", mock.Anything, int64(3)).Return(nil, domainErrors.ErrItemNotFound)
+		index := new(MockDocumentTextIndex)
+		index.On("Search", mock.Anything, "銀座本店", testActor.ID, maxDocumentMatches).Return([]entity.DocumentMatch{
+			{ItemID: 2, Score: 1.5, Snippet: "お買上げ店舗: 銀座本店", Spans: ginzaSpans},
+			{ItemID: 1, Score: 0.5, Snippet: "店舗: 銀座本店", Spans: []entity.TextSpan{{Start: 4, End: 8}}},
+			{ItemID: 3, Score: 0.2, Snippet: "銀座本店", Spans: []entity.TextSpan{{Start: 0, End: 4}}},
+		}, nil)
+
+		results, err := newUsecase(itemRepo, index).SearchItems(actorContext(), "銀座本店")
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.Equal(t, int64(1), results[0].ID)
+		assert.Equal(t, []entity.SearchHighlight{
+			{Field: entity.SearchFieldName, Spans: []entity.TextSpan{{Start: 0, End: 4}}},
+			{Field: entity.SearchFieldDocuments, Spans: []entity.TextSpan{{Start: 4, End: 8}}, Snippet: "店舗: 銀座本店"},
+		}, results[0].Highlights)
+		assert.Equal(t, int64(2), results[1].ID)
+		assert.Equal(t, 1.5, results[1].Score)
+		assert.Equal(t, []entity.SearchHighlight{
+			{Field: entity.SearchFieldDocuments, Spans: ginzaSpans, Snippet: "お買上げ店舗: 銀座本店"},
+		}, results[1].Highlights)
+	})
+
+	t.Run("正常系: 閲覧できないアイテムや購入価格が非表示のアイテムは書類のテキストで検索しない", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleViewer: {entity.ItemFieldPurchasePrice}})
+		other := newItem(2, "サブマリーナ")
+		other.UserID = 99
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Search", mock.Anything, "銀座本店", orgViewer.ID).Return([]*entity.SearchResult{}, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(other, nil)
+		index := new(MockDocumentTextIndex)
+		index.On("Search", mock.Anything, "銀座本店", orgViewer.ID, maxDocumentMatches).Return([]entity.DocumentMatch{
+			{ItemID: 1, Score: 1, Snippet: "銀座本店 ¥1,234,567", Spans: []entity.TextSpan{{Start: 0, End: 4}}},
+			{ItemID: 2, Score: 1, Snippet: "銀座本店", Spans: []entity.TextSpan{{Start: 0, End: 4}}},
+		}, nil)
+
+		results, err := newUsecase(itemRepo, index).SearchItems(WithActor(context.Background(), orgViewer), "銀座本店")
+		require.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("異常系: 書類のテキストの検索に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("Search", mock.Anything, "銀座本店", testActor.ID).Return([]*entity.SearchResult{}, nil)
+		index := new(MockDocumentTextIndex)
+		index.On("Search", mock.Anything, "銀座本店", testActor.ID, maxDocumentMatches).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := newUsecase(itemRepo, index).SearchItems(actorContext(), "銀座本店")
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
@@ -0,0 +1,463 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// DefaultDraftSessionTTL は最後の更新から下書きを保持する既定の期間
+const DefaultDraftSessionTTL = 24 * time.Hour
+
+// 1ユーザーが同時に持てる期限内の下書きの数
+const maxDraftSessionsPerUser = 20
+
+// 同時に届いた更新と競合した場合に下書きを読み直して更新し直す回数
+const maxDraftUpdateAttempts = 3
+
+// 1回の定期削除で削除する期限切れの下書きの数
+const draftCleanupBatchSize = 100
+
+// DraftSessionUsecase はアイテムを複数の画面に分けて入力するための下書きを扱う。
+// 下書きは作成したユーザーのみが参照・変更でき、登録するまでアイテムは作成しない
+type DraftSessionUsecase interface {
+	// Create は下書きを作成する（fields は最初の画面で入力した項目。空でもよい）
+	Create(ctx context.Context, fields map[string]json.RawMessage) (*entity.DraftSession, error)
+	Get(ctx context.Context, id string) (*entity.DraftSession, error)
+	// UpdateFields は fields の項目を下書きに反映する（null の項目は入力を取り消す。含まない項目は変更しない）
+	UpdateFields(ctx context.Context, id string, fields map[string]json.RawMessage) (*entity.DraftSession, error)
+	Delete(ctx context.Context, id string) error
+	// AddImage は下書きに画像を追加する（形式は内容から判定する）
+	AddImage(ctx context.Context, id string, input UploadImageInput) (*entity.DraftImage, error)
+	// OpenImage は下書きの画像の内容を返す。呼び出し側で Close する
+	OpenImage(ctx context.Context, id, imageID string) (*entity.DraftImage, io.ReadCloser, error)
+	RemoveImage(ctx context.Context, id, imageID string) error
+	// Commit は下書きの項目でアイテムを登録し、画像をアイテムに追加して下書きを削除する。
+	// 項目が不正な場合は下書きを残して検証エラーを返す
+	Commit(ctx context.Context, id string) (*entity.Item, error)
+	// DeleteExpired は期限切れの下書きと画像を削除し、削除した件数を返す
+	DeleteExpired(ctx context.Context) (int, error)
+}
+
+type draftSessionUsecase struct {
+	draftRepo DraftSessionRepository
+	storage   FileStorage
+	items     ItemUsecase
+	images    ItemImageUsecase
+	ttl       time.Duration
+	now       func() time.Time
+}
+
+// NewDraftSessionUsecase は最後の更新から ttl の間保持する下書きを扱う（0以下は DefaultDraftSessionTTL）
+func NewDraftSessionUsecase(draftRepo DraftSessionRepository, storage FileStorage, items ItemUsecase, images ItemImageUsecase, ttl time.Duration) DraftSessionUsecase {
+	if ttl <= 0 {
+		ttl = DefaultDraftSessionTTL
+	}
+	return &draftSessionUsecase{
+		draftRepo: draftRepo,
+		storage:   storage,
+		items:     items,
+		images:    images,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+func (u *draftSessionUsecase) Create(ctx context.Context, fields map[string]json.RawMessage) (*entity.DraftSession, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now()
+	count, err := u.draftRepo.CountActive(ctx, actor.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count drafts: %w", err)
+	}
+	if count >= maxDraftSessionsPerUser {
+		return nil, fmt.Errorf("%w: too many drafts, commit or delete one first (up to %d)", domainErrors.ErrInvalidInput, maxDraftSessionsPerUser)
+	}
+
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate draft id: %w", err)
+	}
+	session := &entity.DraftSession{
+		ID:        id,
+		UserID:    actor.ID,
+		Fields:    map[string]json.RawMessage{},
+		Images:    []entity.DraftImage{},
+		Version:   1,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(u.ttl),
+	}
+	if err := mergeDraftFields(session, fields); err != nil {
+		return nil, err
+	}
+
+	if err := u.draftRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to create draft: %w", err)
+	}
+	return withDraftImageURLs(session), nil
+}
+
+func (u *draftSessionUsecase) Get(ctx context.Context, id string) (*entity.DraftSession, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.find(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	return withDraftImageURLs(session), nil
+}
+
+func (u *draftSessionUsecase) UpdateFields(ctx context.Context, id string, fields map[string]json.RawMessage) (*entity.DraftSession, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.modify(ctx, actor, id, func(session *entity.DraftSession) error {
+		return mergeDraftFields(session, fields)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return withDraftImageURLs(session), nil
+}
+
+func (u *draftSessionUsecase) Delete(ctx context.Context, id string) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	session, err := u.find(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	if err := u.draftRepo.Delete(ctx, session.ID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrDraftSessionNotFound
+		}
+		return fmt.Errorf("failed to delete draft: %w", err)
+	}
+	u.deleteImages(ctx, session.Images)
+	return nil
+}
+
+func (u *draftSessionUsecase) AddImage(ctx context.Context, id string, input UploadImageInput) (*entity.DraftImage, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.find(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(session.Images) >= entity.DraftSessionMaxImages {
+		return nil, fmt.Errorf("%w: a draft can have up to %d images", domainErrors.ErrInvalidInput, entity.DraftSessionMaxImages)
+	}
+
+	// アイテムの画像と同じく、Content-Type ヘッダーは信用せず先頭のバイト列から形式を判定する
+	body := bufio.NewReaderSize(io.LimitReader(input.Body, input.Size), 512)
+	head, _ := body.Peek(512)
+	validated, err := entity.NewItemImage(0, input.FileName, http.DetectContentType(head), input.Size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	imageID, err := randomHex(8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate image id: %w", err)
+	}
+	image := entity.DraftImage{
+		ID:          imageID,
+		FileName:    validated.FileName,
+		ContentType: validated.ContentType,
+		Size:        validated.Size,
+		StorageKey:  fmt.Sprintf("drafts/%s/%s%s", session.ID, imageID, validated.Extension()),
+		CreatedAt:   u.now(),
+	}
+	if err := u.storage.Put(ctx, image.StorageKey, body); err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+
+	_, err = u.modify(ctx, actor, id, func(session *entity.DraftSession) error {
+		if len(session.Images) >= entity.DraftSessionMaxImages {
+			return fmt.Errorf("%w: a draft can have up to %d images", domainErrors.ErrInvalidInput, entity.DraftSessionMaxImages)
+		}
+		session.Images = append(session.Images, image)
+		return nil
+	})
+	if err != nil {
+		// 下書きに追加できなかった画像はストレージに残さない
+		_ = u.storage.Delete(ctx, image.StorageKey)
+		return nil, err
+	}
+	return withDraftImageURL(session.ID, &image), nil
+}
+
+func (u *draftSessionUsecase) OpenImage(ctx context.Context, id, imageID string) (*entity.DraftImage, io.ReadCloser, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session, err := u.find(ctx, actor, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	image, ok := session.FindImage(imageID)
+	if !ok {
+		return nil, nil, domainErrors.ErrDraftImageNotFound
+	}
+
+	body, err := u.storage.Get(ctx, image.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	return withDraftImageURL(session.ID, image), body, nil
+}
+
+func (u *draftSessionUsecase) RemoveImage(ctx context.Context, id, imageID string) error {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return err
+	}
+
+	var removed entity.DraftImage
+	_, err = u.modify(ctx, actor, id, func(session *entity.DraftSession) error {
+		image, ok := session.FindImage(imageID)
+		if !ok {
+			return domainErrors.ErrDraftImageNotFound
+		}
+		removed = *image
+		session.Images = slices.DeleteFunc(session.Images, func(i entity.DraftImage) bool { return i.ID == imageID })
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	u.deleteImages(ctx, []entity.DraftImage{removed})
+	return nil
+}
+
+func (u *draftSessionUsecase) Commit(ctx context.Context, id string) (*entity.Item, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := u.find(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	input, err := decodeDraftFields(session.Fields)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.items.CreateItem(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.draftRepo.Delete(ctx, session.ID); err != nil && !domainErrors.IsNotFoundError(err) {
+		log.Printf("⚠️  登録した下書きを削除できませんでした (draft %s): %v", session.ID, err)
+	}
+
+	// アイテムは登録済みのため、画像を追加できなかった場合もアイテムを返す
+	for _, image := range session.Images {
+		if err := u.attachImage(ctx, item.ID, image); err != nil {
+			log.Printf("⚠️  下書きの画像をアイテムに追加できませんでした (draft %s, item %d): %v", session.ID, item.ID, err)
+		}
+	}
+	u.deleteImages(ctx, session.Images)
+	return item, nil
+}
+
+func (u *draftSessionUsecase) DeleteExpired(ctx context.Context) (int, error) {
+	sessions, err := u.draftRepo.FindExpired(ctx, u.now(), draftCleanupBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to retrieve expired drafts: %w", err)
+	}
+
+	deleted := 0
+	for _, session := range sessions {
+		if err := u.draftRepo.Delete(ctx, session.ID); err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				continue
+			}
+			return deleted, fmt.Errorf("failed to delete draft: %w", err)
+		}
+		u.deleteImages(ctx, session.Images)
+		deleted++
+	}
+	return deleted, nil
+}
+
+// find は操作者の期限内の下書きを返す（他のユーザーの下書きは存在しないものとして扱う）
+func (u *draftSessionUsecase) find(ctx context.Context, actor *entity.User, id string) (*entity.DraftSession, error) {
+	session, err := u.draftRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrDraftSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve draft: %w", err)
+	}
+	if session.UserID != actor.ID || session.IsExpired(u.now()) {
+		return nil, domainErrors.ErrDraftSessionNotFound
+	}
+	return session, nil
+}
+
+// modify は下書きに change を適用して保存し、有効期限を延長する。
+// 別の画面からの更新（画像のアップロード中の項目の入力など）と競合した場合は読み直して適用し直す
+func (u *draftSessionUsecase) modify(ctx context.Context, actor *entity.User, id string, change func(*entity.DraftSession) error) (*entity.DraftSession, error) {
+	for range maxDraftUpdateAttempts {
+		session, err := u.find(ctx, actor, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := change(session); err != nil {
+			return nil, err
+		}
+
+		version := session.Version
+		session.Version++
+		session.UpdatedAt = u.now()
+		session.ExpiresAt = session.UpdatedAt.Add(u.ttl)
+		err = u.draftRepo.Update(ctx, session, version)
+		if err == nil {
+			return session, nil
+		}
+		if !domainErrors.IsNotFoundError(err) {
+			return nil, fmt.Errorf("failed to update draft: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("%w: draft was modified by another request, retry", domainErrors.ErrItemVersionConflict)
+}
+
+// attachImage は下書きの画像をアイテムの画像として追加する
+func (u *draftSessionUsecase) attachImage(ctx context.Context, itemID int64, image entity.DraftImage) error {
+	body, err := u.storage.Get(ctx, image.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+	defer body.Close()
+
+	_, err = u.images.Upload(ctx, itemID, UploadImageInput{FileName: image.FileName, Size: image.Size, Body: body})
+	return err
+}
+
+// deleteImages は下書きの画像をストレージから削除する（失敗しても下書きの削除は取り消さない）
+func (u *draftSessionUsecase) deleteImages(ctx context.Context, images []entity.DraftImage) {
+	for _, image := range images {
+		if err := u.storage.Delete(ctx, image.StorageKey); err != nil {
+			log.Printf("⚠️  下書きの画像を削除できませんでした (%s): %v", image.StorageKey, err)
+		}
+	}
+}
+
+// draftFieldNames は下書きに入力できる項目（CreateItemInput の JSON の項目名）
+var draftFieldNames = []string{
+	"name", "category", "brand", "purchase_price", "purchase_currency", "purchase_date",
+	"visibility", "condition", "serial_number", "notes", "attributes", "org_id",
+}
+
+// mergeDraftFields は fields を下書きの項目に反映する。
+// 項目名と値の型のみ検証し、値の内容は登録するときに検証する
+func mergeDraftFields(session *entity.DraftSession, fields map[string]json.RawMessage) error {
+	var errs domainErrors.ValidationErrors
+	merged := maps.Clone(session.Fields)
+	if merged == nil {
+		merged = map[string]json.RawMessage{}
+	}
+	for _, name := range slices.Sorted(maps.Keys(fields)) {
+		value := bytes.TrimSpace(fields[name])
+		if !slices.Contains(draftFieldNames, name) {
+			errs.Add(name, domainErrors.CodeNotAllowed, name+" is not a field of an item")
+			continue
+		}
+		if len(value) == 0 || string(value) == "null" {
+			delete(merged, name)
+			continue
+		}
+		var input CreateItemInput
+		if err := json.Unmarshal(fmt.Appendf(nil, `{%q:%s}`, name, value), &input); err != nil {
+			errs.Add(name, domainErrors.CodeInvalidType, name+" has an invalid type")
+			continue
+		}
+		merged[name] = json.RawMessage(value)
+	}
+	if err := errs.Err(); err != nil {
+		return err
+	}
+
+	encoded, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if len(encoded) > entity.DraftSessionMaxFieldsSize {
+		return domainErrors.ValidationErrors{{Field: "fields", Code: domainErrors.CodeTooLong, Message: fmt.Sprintf("fields must be %d KB or less", entity.DraftSessionMaxFieldsSize>>10)}}
+	}
+	session.Fields = merged
+	return nil
+}
+
+// decodeDraftFields は下書きの項目を登録時の入力にする
+func decodeDraftFields(fields map[string]json.RawMessage) (CreateItemInput, error) {
+	var input CreateItemInput
+	encoded, err := json.Marshal(fields)
+	if err == nil {
+		err = json.Unmarshal(encoded, &input)
+	}
+	if err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return input, domainErrors.ValidationErrors{{Field: typeErr.Field, Code: domainErrors.CodeInvalidType, Message: typeErr.Field + " has an invalid type"}}
+		}
+		return input, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	return input, nil
+}
+
+func withDraftImageURLs(session *entity.DraftSession) *entity.DraftSession {
+	for i := range session.Images {
+		withDraftImageURL(session.ID, &session.Images[i])
+	}
+	return session
+}
+
+func withDraftImageURL(sessionID string, image *entity.DraftImage) *entity.DraftImage {
+	image.URL = fmt.Sprintf("/items/drafts/%s/images/%s", sessionID, image.ID)
+	return image
+}
+
+// randomHex は n バイトの推測されにくい値を16進数の文字列で返す
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockDraftSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockDraftSessionRepository) Create(ctx context.Context, session *entity.DraftSession) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockDraftSessionRepository) FindByID(ctx context.Context, id string) (*entity.DraftSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.DraftSession), args.Error(1)
+}
+
+func (m *MockDraftSessionRepository) Update(ctx context.Context, session *entity.DraftSession, version int64) error {
+	args := m.Called(ctx, session, version)
+	return args.Error(0)
+}
+
+func (m *MockDraftSessionRepository) CountActive(ctx context.Context, userID int64, now time.Time) (int, error) {
+	args := m.Called(ctx, userID, now)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockDraftSessionRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDraftSessionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.DraftSession, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.DraftSession), args.Error(1)
+}
+
+type MockItemImageUsecase struct {
+	mock.Mock
+}
+
+func (m *MockItemImageUsecase) Upload(ctx context.Context, itemID int64, input UploadImageInput) (*entity.ItemImage, error) {
+	body, _ := io.ReadAll(input.Body)
+	args := m.Called(itemID, input.FileName, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageUsecase) List(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	args := m.Called(itemID)
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageUsecase) Open(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, io.ReadCloser, error) {
+	args := m.Called(itemID, imageID)
+	return args.Get(0).(*entity.ItemImage), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockItemImageUsecase) OpenThumbnail(ctx context.Context, itemID, imageID int64, width int) (io.ReadCloser, error) {
+	args := m.Called(itemID, imageID, width)
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockItemImageUsecase) Delete(ctx context.Context, itemID, imageID int64) error {
+	args := m.Called(itemID, imageID)
+	return args.Error(0)
+}
+
+const testDraftID = "0123456789abcdef0123456789abcdef"
+
+var draftTestNow = time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+
+func newTestDraft(fields map[string]string) *entity.DraftSession {
+	raw := map[string]json.RawMessage{}
+	for name, value := range fields {
+		raw[name] = json.RawMessage(value)
+	}
+	return &entity.DraftSession{
+		ID:        testDraftID,
+		UserID:    testActor.ID,
+		Fields:    raw,
+		Images:    []entity.DraftImage{},
+		Version:   1,
+		CreatedAt: draftTestNow.Add(-time.Hour),
+		UpdatedAt: draftTestNow.Add(-time.Hour),
+		ExpiresAt: draftTestNow.Add(time.Hour),
+	}
+}
+
+func newDraftTestUsecase(repo DraftSessionRepository, storage FileStorage, items ItemUsecase, images ItemImageUsecase) *draftSessionUsecase {
+	u := NewDraftSessionUsecase(repo, storage, items, images, 0).(*draftSessionUsecase)
+	u.now = func() time.Time { return draftTestNow }
+	return u
+}
+
+func TestDraftSessionUsecase_Create(t *testing.T) {
+	t.Run("正常系: 入力した項目で下書きを作成する", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("CountActive", mock.Anything, testActor.ID, draftTestNow).Return(2, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.DraftSession")).Return(nil)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		session, err := u.Create(actorContext(), map[string]json.RawMessage{"name": json.RawMessage(`"サブマリーナー"`), "brand": json.RawMessage(`null`)})
+		require.NoError(t, err)
+		assert.Len(t, session.ID, 32)
+		assert.Equal(t, testActor.ID, session.UserID)
+		assert.Equal(t, map[string]json.RawMessage{"name": json.RawMessage(`"サブマリーナー"`)}, session.Fields)
+		assert.Equal(t, draftTestNow.Add(DefaultDraftSessionTTL), session.ExpiresAt)
+		assert.Equal(t, []string{"category", "brand", "purchase_price", "purchase_date"}, session.MissingFields())
+	})
+
+	t.Run("正常系: ブランドの既定値があるカテゴリーではブランドを未入力にしない", func(t *testing.T) {
+		withCategoryRules(t)
+		repo := new(MockDraftSessionRepository)
+		repo.On("CountActive", mock.Anything, testActor.ID, draftTestNow).Return(0, nil)
+		repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.DraftSession")).Return(nil)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		session, err := u.Create(actorContext(), map[string]json.RawMessage{"name": json.RawMessage(`"置き時計"`), "category": json.RawMessage(`"その他"`)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"purchase_price", "purchase_date"}, session.MissingFields())
+	})
+
+	t.Run("異常系: 項目ではない名前や型の異なる値", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("CountActive", mock.Anything, testActor.ID, draftTestNow).Return(0, nil)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		_, err := u.Create(actorContext(), map[string]json.RawMessage{"user_id": json.RawMessage(`2`), "purchase_price": json.RawMessage(`"高い"`)})
+		var errs domainErrors.ValidationErrors
+		require.ErrorAs(t, err, &errs)
+		assert.Equal(t, domainErrors.ValidationErrors{
+			{Field: "purchase_price", Code: domainErrors.CodeInvalidType, Message: "purchase_price has an invalid type"},
+			{Field: "user_id", Code: domainErrors.CodeNotAllowed, Message: "user_id is not a field of an item"},
+		}, errs)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 期限内の下書きが上限に達している", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("CountActive", mock.Anything, testActor.ID, draftTestNow).Return(maxDraftSessionsPerUser, nil)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		_, err := u.Create(actorContext(), nil)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 閲覧者は作成できない", func(t *testing.T) {
+		u := newDraftTestUsecase(new(MockDraftSessionRepository), nil, nil, nil)
+
+		_, err := u.Create(WithActor(context.Background(), &entity.User{ID: 2, Role: entity.RoleViewer}), nil)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestDraftSessionUsecase_Get(t *testing.T) {
+	t.Run("正常系: 画像の URL を含めて返す", func(t *testing.T) {
+		draft := newTestDraft(nil)
+		draft.Images = []entity.DraftImage{{ID: "00112233aabbccdd", StorageKey: "drafts/x/00112233aabbccdd.png"}}
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(draft, nil)
+
+		session, err := newDraftTestUsecase(repo, nil, nil, nil).Get(actorContext(), testDraftID)
+		require.NoError(t, err)
+		assert.Equal(t, "/items/drafts/"+testDraftID+"/images/00112233aabbccdd", session.Images[0].URL)
+	})
+
+	t.Run("異常系: 他のユーザーの下書きと期限切れの下書きは存在しないものとして扱う", func(t *testing.T) {
+		other := newTestDraft(nil)
+		other.UserID = 2
+		expired := newTestDraft(nil)
+		expired.ExpiresAt = draftTestNow
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, "other").Return(other, nil)
+		repo.On("FindByID", mock.Anything, "expired").Return(expired, nil)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		_, err := u.Get(actorContext(), "other")
+		assert.ErrorIs(t, err, domainErrors.ErrDraftSessionNotFound)
+		_, err = u.Get(actorContext(), "expired")
+		assert.ErrorIs(t, err, domainErrors.ErrDraftSessionNotFound)
+	})
+}
+
+func TestDraftSessionUsecase_UpdateFields(t *testing.T) {
+	t.Run("正常系: 項目を反映・取り消して有効期限を延長する", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(map[string]string{"name": `"サブマリーナー"`, "notes": `"箱あり"`}), nil)
+		repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.DraftSession"), int64(1)).Return(nil)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		session, err := u.UpdateFields(actorContext(), testDraftID, map[string]json.RawMessage{
+			"brand": json.RawMessage(`"ROLEX"`),
+			"notes": json.RawMessage(`null`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]json.RawMessage{"name": json.RawMessage(`"サブマリーナー"`), "brand": json.RawMessage(`"ROLEX"`)}, session.Fields)
+		assert.Equal(t, int64(2), session.Version)
+		assert.Equal(t, draftTestNow.Add(DefaultDraftSessionTTL), session.ExpiresAt)
+	})
+
+	t.Run("正常系: 同時に届いた更新と競合した場合は読み直して反映する", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(nil), nil).Once()
+		withImage := newTestDraft(nil)
+		withImage.Version = 2
+		withImage.Images = []entity.DraftImage{{ID: "00112233aabbccdd"}}
+		repo.On("FindByID", mock.Anything, testDraftID).Return(withImage, nil).Once()
+		repo.On("Update", mock.Anything, mock.Anything, int64(1)).Return(domainErrors.ErrDraftSessionNotFound)
+		repo.On("Update", mock.Anything, mock.Anything, int64(2)).Return(nil)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		session, err := u.UpdateFields(actorContext(), testDraftID, map[string]json.RawMessage{"brand": json.RawMessage(`"ROLEX"`)})
+		require.NoError(t, err)
+		assert.Len(t, session.Images, 1)
+		assert.Equal(t, int64(3), session.Version)
+		repo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("異常系: 競合が続く", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		for range maxDraftUpdateAttempts {
+			repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(nil), nil).Once()
+		}
+		repo.On("Update", mock.Anything, mock.Anything, int64(1)).Return(domainErrors.ErrDraftSessionNotFound)
+		u := newDraftTestUsecase(repo, nil, nil, nil)
+
+		_, err := u.UpdateFields(actorContext(), testDraftID, map[string]json.RawMessage{"brand": json.RawMessage(`"ROLEX"`)})
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionConflict)
+		repo.AssertNumberOfCalls(t, "Update", maxDraftUpdateAttempts)
+	})
+}
+
+func TestDraftSessionUsecase_AddImage(t *testing.T) {
+	t.Run("正常系: 内容から形式を判定してストレージに保存する", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(nil), nil).Once()
+		repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(nil), nil).Once()
+		repo.On("Update", mock.Anything, mock.MatchedBy(func(s *entity.DraftSession) bool { return len(s.Images) == 1 }), int64(1)).Return(nil)
+		storage := new(MockFileStorage)
+		storage.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+			return len(key) == len("drafts/"+testDraftID+"/0011223344556677.png")
+		}), testPNG).Return(nil)
+		u := newDraftTestUsecase(repo, storage, nil, nil)
+
+		image, err := u.AddImage(actorContext(), testDraftID, UploadImageInput{FileName: "front.png", Size: int64(len(testPNG)), Body: bytes.NewReader(testPNG)})
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", image.ContentType)
+		assert.Equal(t, "/items/drafts/"+testDraftID+"/images/"+image.ID, image.URL)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 画像ではないファイル", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(nil), nil)
+		storage := new(MockFileStorage)
+		u := newDraftTestUsecase(repo, storage, nil, nil)
+
+		_, err := u.AddImage(actorContext(), testDraftID, UploadImageInput{FileName: "a.txt", Size: 5, Body: bytes.NewReader([]byte("hello"))})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		storage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 下書きが削除された場合は保存した画像を削除する", func(t *testing.T) {
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(nil), nil).Once()
+		repo.On("FindByID", mock.Anything, testDraftID).Return(nil, domainErrors.ErrDraftSessionNotFound).Once()
+		storage := new(MockFileStorage)
+		storage.On("Put", mock.Anything, mock.Anything, testPNG).Return(nil)
+		storage.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		u := newDraftTestUsecase(repo, storage, nil, nil)
+
+		_, err := u.AddImage(actorContext(), testDraftID, UploadImageInput{FileName: "front.png", Size: int64(len(testPNG)), Body: bytes.NewReader(testPNG)})
+		assert.ErrorIs(t, err, domainErrors.ErrDraftSessionNotFound)
+		storage.AssertNumberOfCalls(t, "Delete", 1)
+	})
+}
+
+func TestDraftSessionUsecase_Commit(t *testing.T) {
+	completeFields := map[string]string{
+		"name":           `"サブマリーナー"`,
+		"category":       `"時計"`,
+		"brand":          `"ROLEX"`,
+		"purchase_price": `1500000`,
+		"purchase_date":  `"2023-01-15"`,
+	}
+
+	t.Run("正常系: アイテムを登録して画像を追加し、下書きを削除する", func(t *testing.T) {
+		draft := newTestDraft(completeFields)
+		draft.Images = []entity.DraftImage{
+			{ID: "00112233aabbccdd", FileName: "front.png", Size: int64(len(testPNG)), StorageKey: "drafts/a.png"},
+			{ID: "8899aabbccddeeff", FileName: "back.png", Size: int64(len(testPNG)), StorageKey: "drafts/b.png"},
+		}
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(draft, nil)
+		repo.On("Delete", mock.Anything, testDraftID).Return(nil)
+		itemRepo := new(MockItemRepository)
+		created := newImageTestItem()
+		itemRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "サブマリーナー" && item.PurchasePrice.Amount == 1500000 && item.UserID == testActor.ID
+		})).Return(created, nil)
+		storage := new(MockFileStorage)
+		storage.On("Get", mock.Anything, "drafts/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+		storage.On("Get", mock.Anything, "drafts/b.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+		storage.On("Delete", mock.Anything, mock.Anything).Return(nil)
+		images := new(MockItemImageUsecase)
+		images.On("Upload", created.ID, "front.png", testPNG).Return(&entity.ItemImage{ID: 1}, nil)
+		images.On("Upload", created.ID, "back.png", testPNG).Return(nil, domainErrors.ErrDatabaseError)
+		u := newDraftTestUsecase(repo, storage, NewItemUsecase(itemRepo), images)
+
+		item, err := u.Commit(actorContext(), testDraftID)
+		require.NoError(t, err)
+		assert.Equal(t, created, item)
+		// 追加できなかった画像があってもアイテムは登録済みのため成功とし、下書きの画像はすべて削除する
+		images.AssertNumberOfCalls(t, "Upload", 2)
+		storage.AssertCalled(t, "Delete", mock.Anything, "drafts/a.png")
+		storage.AssertCalled(t, "Delete", mock.Anything, "drafts/b.png")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 項目が不正な場合は下書きを残す", func(t *testing.T) {
+		fields := map[string]string{"name": `"サブマリーナー"`, "purchase_price": `1500000`}
+		repo := new(MockDraftSessionRepository)
+		repo.On("FindByID", mock.Anything, testDraftID).Return(newTestDraft(fields), nil)
+		itemRepo := new(MockItemRepository)
+		u := newDraftTestUsecase(repo, nil, NewItemUsecase(itemRepo), nil)
+
+		_, err := u.Commit(actorContext(), testDraftID)
+		var errs domainErrors.ValidationErrors
+		assert.ErrorAs(t, err, &errs)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		itemRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDraftSessionUsecase_DeleteExpired(t *testing.T) {
+	expired := newTestDraft(nil)
+	expired.Images = []entity.DraftImage{{ID: "00112233aabbccdd", StorageKey: "drafts/a.png"}}
+	gone := newTestDraft(nil)
+	gone.ID = "gone"
+	repo := new(MockDraftSessionRepository)
+	repo.On("FindExpired", mock.Anything, draftTestNow, draftCleanupBatchSize).Return([]*entity.DraftSession{expired, gone}, nil)
+	repo.On("Delete", mock.Anything, testDraftID).Return(nil)
+	repo.On("Delete", mock.Anything, "gone").Return(domainErrors.ErrDraftSessionNotFound)
+	storage := new(MockFileStorage)
+	storage.On("Delete", mock.Anything, "drafts/a.png").Return(nil)
+	u := newDraftTestUsecase(repo, storage, nil, nil)
+
+	deleted, err := u.DeleteExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+	storage.AssertExpectations(t)
+}
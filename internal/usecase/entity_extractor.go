@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// テキスト解析で受け付ける最大文字数
+const maxParseTextLength = 500
+
+type ParseItemInput struct {
+	Text string `json:"text"`
+}
+
+// ItemDraft はテキストから推定した登録内容の下書き（登録はせず、確認用に返す）
+type ItemDraft struct {
+	Text   string          `json:"text"`
+	Input  CreateItemInput `json:"input"`
+	Valid  bool            `json:"valid"`
+	Errors []string        `json:"errors"`
+}
+
+// EntityExtractor は自由入力のテキスト（音声認識の結果など）からアイテムの項目を推定する。
+// 推定できなかった項目はゼロ値のまま返す
+type EntityExtractor interface {
+	Extract(ctx context.Context, text string) (CreateItemInput, error)
+}
+
+// ブランドの表記ゆれ（小文字で比較する）と正式な表記
+var brandAliases = map[string]string{
+	"rolex":               "ROLEX",
+	"ロレックス":               "ROLEX",
+	"omega":               "OMEGA",
+	"オメガ":                 "OMEGA",
+	"patek philippe":      "PATEK PHILIPPE",
+	"パテックフィリップ":           "PATEK PHILIPPE",
+	"パテック・フィリップ":          "PATEK PHILIPPE",
+	"hermès":              "HERMÈS",
+	"hermes":              "HERMÈS",
+	"エルメス":                "HERMÈS",
+	"louis vuitton":       "LOUIS VUITTON",
+	"ルイヴィトン":              "LOUIS VUITTON",
+	"ルイ・ヴィトン":             "LOUIS VUITTON",
+	"ヴィトン":                "LOUIS VUITTON",
+	"chanel":              "CHANEL",
+	"シャネル":                "CHANEL",
+	"gucci":               "GUCCI",
+	"グッチ":                 "GUCCI",
+	"prada":               "PRADA",
+	"プラダ":                 "PRADA",
+	"cartier":             "Cartier",
+	"カルティエ":               "Cartier",
+	"tiffany":             "Tiffany & Co.",
+	"ティファニー":              "Tiffany & Co.",
+	"bvlgari":             "BVLGARI",
+	"ブルガリ":                "BVLGARI",
+	"christian louboutin": "Christian Louboutin",
+	"louboutin":           "Christian Louboutin",
+	"ルブタン":                "Christian Louboutin",
+	"apple":               "Apple",
+	"アップル":                "Apple",
+}
+
+// カテゴリーを示す語（モデル名を含む）
+var categoryKeywords = map[string]string{
+	"時計":       "時計",
+	"腕時計":      "時計",
+	"ウォッチ":     "時計",
+	"デイトナ":     "時計",
+	"サブマリーナ":   "時計",
+	"スピードマスター": "時計",
+	"バッグ":      "バッグ",
+	"かばん":      "バッグ",
+	"鞄":        "バッグ",
+	"バーキン":     "バッグ",
+	"ケリー":      "バッグ",
+	"財布":       "バッグ",
+	"ジュエリー":    "ジュエリー",
+	"ネックレス":    "ジュエリー",
+	"指輪":       "ジュエリー",
+	"リング":      "ジュエリー",
+	"ブレスレット":   "ジュエリー",
+	"ピアス":      "ジュエリー",
+	"靴":        "靴",
+	"パンプス":     "靴",
+	"スニーカー":    "靴",
+	"ブーツ":      "靴",
+}
+
+var (
+	// 80万円 / 1,500,000円 / ¥2,000,000 / 150万
+	pricePattern = regexp.MustCompile(`[¥￥][0-9][0-9,]*(?:万円?|円)?|[0-9][0-9,]*(?:万円?|円)`)
+	// 2023年3月15日 / 2023年3月 / 2023/3/15 / 2023-03-15
+	absoluteDatePattern = regexp.MustCompile(`([0-9]{4})(?:年|/|-|\.)([0-9]{1,2})(?:月|/|-|\.)?(?:([0-9]{1,2})日?)?`)
+	// 去年の3月 / 今年3月10日 / おととしの12月
+	relativeYearPattern = regexp.MustCompile(`(今年|去年|昨年|一昨年|おととし)の?(?:([0-9]{1,2})月(?:([0-9]{1,2})日)?)?`)
+	// 先月 / 今月 / 昨日 / 今日 / 一昨日
+	relativeDayPattern = regexp.MustCompile(`一昨日|おととい|昨日|今日|先月|今月`)
+	// 名前の区切りとして扱う助詞・動詞・記号
+	phraseSeparatorPattern = regexp.MustCompile(`を?購入した|を?購入|を?買った|を?買いました|で|に|の|を|は|、|。|,|\s`)
+)
+
+// 表記ゆれの長い順に照合するため、キーを長さの降順で並べる
+var (
+	brandAliasKeys      = sortedByLength(brandAliases)
+	categoryKeywordKeys = sortedByLength(categoryKeywords)
+)
+
+func sortedByLength(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, func(a, b string) int {
+		if len(a) != len(b) {
+			return len(b) - len(a)
+		}
+		return strings.Compare(a, b)
+	})
+	return keys
+}
+
+type ruleBasedExtractor struct {
+	now func() time.Time
+}
+
+// NewRuleBasedExtractor は辞書と正規表現による EntityExtractor を返す
+func NewRuleBasedExtractor() EntityExtractor {
+	return &ruleBasedExtractor{now: time.Now}
+}
+
+// Extract は「去年の3月に80万円で買ったエルメスのバーキン」のような文から
+// 購入日・価格・ブランド・カテゴリー・名前を推定する
+func (e *ruleBasedExtractor) Extract(ctx context.Context, text string) (CreateItemInput, error) {
+	var input CreateItemInput
+	rest := toHalfWidthDigits(strings.TrimSpace(text))
+
+	if loc := pricePattern.FindStringIndex(rest); loc != nil {
+		if price, ok := parsePrice(strings.TrimSpace(rest[loc[0]:loc[1]])); ok {
+			input.PurchasePrice = entity.Decimal{Units: int64(price)}
+		}
+		rest = cut(rest, loc)
+	}
+
+	input.PurchaseDate, rest = e.extractDate(rest)
+
+	// ブランドの後ろに続く語を優先して名前とし、なければ前の語を名前とする
+	before, after := rest, ""
+	if brand, loc, ok := findAlias(rest, brandAliases, brandAliasKeys); ok {
+		input.Brand = brand
+		before, after = rest[:loc[0]], rest[loc[1]:]
+	}
+	if category, _, ok := findAlias(rest, categoryKeywords, categoryKeywordKeys); ok {
+		input.Category = category
+	}
+	if segments := splitPhrases(after + " " + before); len(segments) > 0 {
+		input.Name = segments[0]
+	}
+
+	return input, nil
+}
+
+// extractDate は購入日を YYYY-MM-DD で推定し、該当部分を除いたテキストを返す。
+// 日が不明な場合は1日、月も不明な場合は1月1日とする
+func (e *ruleBasedExtractor) extractDate(text string) (string, string) {
+	now := e.now()
+
+	if m := absoluteDatePattern.FindStringSubmatchIndex(text); m != nil {
+		if date, ok := buildDate(atoi(text, m[2], m[3]), atoi(text, m[4], m[5]), atoi(text, m[6], m[7])); ok {
+			return date, cut(text, m[:2])
+		}
+	}
+
+	if m := relativeYearPattern.FindStringSubmatchIndex(text); m != nil {
+		year := now.Year()
+		switch text[m[2]:m[3]] {
+		case "去年", "昨年":
+			year--
+		case "一昨年", "おととし":
+			year -= 2
+		}
+		if date, ok := buildDate(year, atoi(text, m[4], m[5]), atoi(text, m[6], m[7])); ok {
+			return date, cut(text, m[:2])
+		}
+	}
+
+	if loc := relativeDayPattern.FindStringIndex(text); loc != nil {
+		var date time.Time
+		switch text[loc[0]:loc[1]] {
+		case "今日":
+			date = now
+		case "昨日":
+			date = now.AddDate(0, 0, -1)
+		case "一昨日", "おととい":
+			date = now.AddDate(0, 0, -2)
+		case "今月":
+			date = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+		case "先月":
+			date = time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, now.Location())
+		}
+		return date.Format("2006-01-02"), cut(text, loc)
+	}
+
+	return "", text
+}
+
+func buildDate(year, month, day int) (string, bool) {
+	if month == 0 {
+		month = 1
+	}
+	if day == 0 {
+		day = 1
+	}
+	date := fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return "", false
+	}
+	return date, true
+}
+
+// 部分一致のインデックスが未一致（-1）の場合は 0 を返す
+func atoi(text string, start, end int) int {
+	if start < 0 {
+		return 0
+	}
+	n, _ := strconv.Atoi(text[start:end])
+	return n
+}
+
+// loc の範囲を区切り文字に置き換える
+func cut(text string, loc []int) string {
+	return text[:loc[0]] + " " + text[loc[1]:]
+}
+
+// findAlias はテキストに含まれる表記ゆれを探し、正式な表記と一致した範囲を返す
+func findAlias(text string, aliases map[string]string, keys []string) (string, []int, bool) {
+	lower := strings.ToLower(text)
+	if len(lower) != len(text) {
+		// 小文字化でバイト長が変わる文字を含む場合は範囲がずれるため大文字小文字を区別する
+		lower = text
+	}
+	for _, key := range keys {
+		if i := strings.Index(lower, key); i >= 0 {
+			return aliases[key], []int{i, i + len(key)}, true
+		}
+	}
+	return "", nil, false
+}
+
+// splitPhrases は助詞・動詞・記号で区切った空でない語を返す
+func splitPhrases(text string) []string {
+	var phrases []string
+	for _, phrase := range phraseSeparatorPattern.Split(text, -1) {
+		if phrase = strings.TrimSpace(phrase); phrase != "" {
+			phrases = append(phrases, phrase)
+		}
+	}
+	return phrases
+}
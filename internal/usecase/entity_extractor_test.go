@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestRuleBasedExtractor_Extract(t *testing.T) {
+	extractor := &ruleBasedExtractor{
+		now: func() time.Time { return time.Date(2024, 6, 15, 12, 0, 0, 0, time.Local) },
+	}
+
+	tests := []struct {
+		name     string
+		text     string
+		expected CreateItemInput
+	}{
+		{
+			name: "正常系: 相対的な購入日と万円表記",
+			text: "去年の3月に80万円で買ったエルメスのバーキン",
+			expected: CreateItemInput{
+				Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: entity.Decimal{Units: 800000}, PurchaseDate: "2023-03-01",
+			},
+		},
+		{
+			name: "正常系: 日付と価格の表記ゆれ・英字ブランド",
+			text: "２０２２年１２月２４日に rolex デイトナを ¥1,500,000 で購入",
+			expected: CreateItemInput{
+				Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1500000}, PurchaseDate: "2022-12-24",
+			},
+		},
+		{
+			name: "正常系: ブランドと名前が続けて書かれている",
+			text: "昨日ティファニーネックレスを30万円で買った",
+			expected: CreateItemInput{
+				Name: "ネックレス", Category: "ジュエリー", Brand: "Tiffany & Co.", PurchasePrice: entity.Decimal{Units: 300000}, PurchaseDate: "2024-06-14",
+			},
+		},
+		{
+			name: "正常系: 複数語のブランド",
+			text: "先月 Christian Louboutin のパンプス 15万円",
+			expected: CreateItemInput{
+				Name: "パンプス", Category: "靴", Brand: "Christian Louboutin", PurchasePrice: entity.Decimal{Units: 150000}, PurchaseDate: "2024-05-01",
+			},
+		},
+		{
+			name: "正常系: 推定できない項目はゼロ値",
+			text: "骨董品",
+			expected: CreateItemInput{
+				Name: "骨董品",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := extractor.Extract(context.Background(), tt.text)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, input)
+		})
+	}
+}
+
+type stubExtractor struct {
+	mock.Mock
+}
+
+func (s *stubExtractor) Extract(ctx context.Context, text string) (CreateItemInput, error) {
+	args := s.Called(ctx, text)
+	return args.Get(0).(CreateItemInput), args.Error(1)
+}
+
+func TestItemUsecase_ParseItemText(t *testing.T) {
+	t.Run("正常系: 設定した EntityExtractor の結果を検証して返す", func(t *testing.T) {
+		extractor := new(stubExtractor)
+		extractor.On("Extract", mock.Anything, "エルメスのバーキン").Return(CreateItemInput{
+			Name: "バーキン", Category: "バッグ", Brand: "HERMÈS",
+		}, nil)
+		usecase := NewItemUsecase(new(MockItemRepository), WithEntityExtractor(extractor))
+
+		draft, err := usecase.ParseItemText(context.Background(), ParseItemInput{Text: "  エルメスのバーキン "})
+		require.NoError(t, err)
+		assert.Equal(t, "エルメスのバーキン", draft.Text)
+		assert.Equal(t, "バーキン", draft.Input.Name)
+		assert.False(t, draft.Valid)
+		assert.Equal(t, []string{"purchase_date is required"}, draft.Errors)
+		extractor.AssertExpectations(t)
+	})
+
+	t.Run("異常系: テキストが空", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		_, err := usecase.ParseItemText(context.Background(), ParseItemInput{Text: " "})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: テキストが長すぎる", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		_, err := usecase.ParseItemText(context.Background(), ParseItemInput{Text: strings.Repeat("あ", 501)})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: EntityExtractor のエラー", func(t *testing.T) {
+		extractor := new(stubExtractor)
+		extractor.On("Extract", mock.Anything, "テキスト").Return(CreateItemInput{}, errors.New("provider unavailable"))
+		usecase := NewItemUsecase(new(MockItemRepository), WithEntityExtractor(extractor))
+
+		_, err := usecase.ParseItemText(context.Background(), ParseItemInput{Text: "テキスト"})
+		assert.Error(t, err)
+		assert.False(t, domainErrors.IsValidationError(err))
+	})
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 変更履歴を読み込む1回あたりの件数
+const eventExportPageSize = 1000
+
+type EventExportUsecase interface {
+	// Export は since（RFC 3339 か YYYY-MM-DD。空の場合は最初から）以降のアイテムのイベントを古い順に write に渡す（管理者のみ）。
+	// 変更履歴を少しずつ読み込むため、全件をメモリに載せない。write がエラーを返すと中断する。
+	// イベントは ExposeItemEvent で共有リンクなどと同じ受け手向けにし、公開範囲の外のアイテムのイベントは渡さない
+	Export(ctx context.Context, since string, write func(*entity.ItemEvent) error) error
+}
+
+type eventExportUsecase struct {
+	historyRepo ItemHistoryRepository
+	itemRepo    ItemRepository
+	pageSize    int
+}
+
+func NewEventExportUsecase(historyRepo ItemHistoryRepository, itemRepo ItemRepository) EventExportUsecase {
+	return &eventExportUsecase{
+		historyRepo: historyRepo,
+		itemRepo:    itemRepo,
+		pageSize:    eventExportPageSize,
+	}
+}
+
+func (u *eventExportUsecase) Export(ctx context.Context, since string, write func(*entity.ItemEvent) error) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+	if !actor.IsAdmin() {
+		return domainErrors.ErrForbidden
+	}
+	from, err := parseEventSince(since)
+	if err != nil {
+		return err
+	}
+
+	// アイテムごとの公開範囲（イベントの順に追う）
+	visibilities := make(map[int64]entity.Visibility)
+	expose := func(event *entity.ItemEvent) error {
+		visibility, err := u.visibility(ctx, visibilities, event)
+		if err != nil {
+			return err
+		}
+		exposed, ok := ExposeItemEvent(event, visibility, entity.AudienceShared, ExposeOptions{})
+		if !ok {
+			return nil
+		}
+		return write(exposed)
+	}
+
+	// 同じ操作の変更履歴は続けて記録されるため、続く行をまとめて1つのイベントにする
+	var pending *entity.ItemEvent
+	var afterID int64
+	for {
+		histories, err := u.historyRepo.FindSince(ctx, from, afterID, u.pageSize)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve item histories: %w", err)
+		}
+		for _, h := range histories {
+			if pending != nil && pending.SameOperation(h) {
+				pending.Add(h)
+				continue
+			}
+			if pending != nil {
+				if err := expose(pending); err != nil {
+					return err
+				}
+			}
+			pending = entity.NewItemEvent(h)
+		}
+		if len(histories) < u.pageSize {
+			break
+		}
+		afterID = histories[len(histories)-1].ID
+	}
+	if pending != nil {
+		return expose(pending)
+	}
+	return nil
+}
+
+// visibility はイベントの操作後（削除では削除前）のアイテムの公開範囲を返す。
+// 公開範囲の変更を含まないイベントはそれまでのイベントの公開範囲を使い、初めてのアイテムは現在の公開範囲を読み込む
+// （削除済みで分からないアイテムは private として扱う）
+func (u *eventExportUsecase) visibility(ctx context.Context, visibilities map[int64]entity.Visibility, event *entity.ItemEvent) (entity.Visibility, error) {
+	for _, change := range event.Changes {
+		if change.Field != "visibility" {
+			continue
+		}
+		value := change.NewValue
+		if event.Type == entity.ItemEventDeleted {
+			value = change.OldValue
+		}
+		if value != nil {
+			visibilities[event.ItemID] = entity.Visibility(*value)
+		}
+	}
+	if visibility, ok := visibilities[event.ItemID]; ok {
+		return visibility, nil
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, event.ItemID)
+	if err != nil {
+		if !domainErrors.IsNotFoundError(err) {
+			return "", fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		item = &entity.Item{Visibility: entity.VisibilityPrivate}
+	}
+	visibilities[event.ItemID] = item.Visibility
+	return item.Visibility, nil
+}
+
+// parseEventSince は書き出しの開始日時を読み込む（YYYY-MM-DD は UTC のその日の始まり）
+func parseEventSince(since string) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, since); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("%w: since must be an RFC 3339 timestamp or a YYYY-MM-DD date", domainErrors.ErrInvalidInput)
+}
@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestEventExportUsecase_Export(t *testing.T) {
+	admin := &entity.User{ID: 1, Email: "admin@example.com", Role: entity.RoleAdmin}
+	adminCtx := WithActor(context.Background(), admin)
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	value := func(s string) *string { return &s }
+	history := func(id, itemID int64, action entity.ItemHistoryAction, field string, createdAt time.Time) *entity.ItemHistory {
+		h := &entity.ItemHistory{ID: id, ItemID: itemID, ActorID: 2, Action: action, Field: field, OldValue: value("old"), CreatedAt: createdAt}
+		if action == entity.ItemHistoryActionUpdate {
+			h.NewValue = value("new")
+		}
+		return h
+	}
+
+	// 現在のアイテムの公開範囲（ItemRepository.FindByID で読み込む）
+	sharedItems := func(ids ...int64) *MockItemRepository {
+		itemRepo := new(MockItemRepository)
+		for _, id := range ids {
+			itemRepo.On("FindByID", mock.Anything, id).Return(&entity.Item{ID: id, Visibility: entity.VisibilityShared}, nil)
+		}
+		return itemRepo
+	}
+
+	collect := func(t *testing.T, u EventExportUsecase, since string) ([]*entity.ItemEvent, error) {
+		t.Helper()
+		var events []*entity.ItemEvent
+		err := u.Export(adminCtx, since, func(e *entity.ItemEvent) error {
+			events = append(events, e)
+			return nil
+		})
+		return events, err
+	}
+
+	t.Run("正常系: 同じ操作の変更履歴を1つのイベントにまとめ、ページをまたいで読み込む", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(0), 2).Return([]*entity.ItemHistory{
+			history(1, 10, entity.ItemHistoryActionUpdate, "name", at),
+			history(2, 10, entity.ItemHistoryActionUpdate, "brand", at),
+		}, nil)
+		// 前のページの最後の操作の続きは同じイベントにまとめる
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(2), 2).Return([]*entity.ItemHistory{
+			history(3, 10, entity.ItemHistoryActionUpdate, "category", at),
+			history(4, 11, entity.ItemHistoryActionDelete, "name", at.Add(time.Minute)),
+		}, nil)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(4), 2).Return([]*entity.ItemHistory{}, nil)
+		u := &eventExportUsecase{historyRepo: historyRepo, itemRepo: sharedItems(10, 11), pageSize: 2}
+
+		events, err := collect(t, u, "")
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(1), events[0].ID)
+		assert.Equal(t, entity.ItemEventUpdated, events[0].Type)
+		assert.Equal(t, at, events[0].OccurredAt)
+		require.Len(t, events[0].Changes, 3)
+		assert.Equal(t, "category", events[0].Changes[2].Field)
+		assert.Equal(t, "new", *events[0].Changes[2].NewValue)
+		assert.Equal(t, entity.ItemEventDeleted, events[1].Type)
+		assert.Equal(t, int64(11), events[1].ItemID)
+		assert.Nil(t, events[1].Changes[0].NewValue)
+	})
+
+	t.Run("正常系: 登録の変更履歴は item.created のイベントにする", func(t *testing.T) {
+		created := history(1, 10, entity.ItemHistoryActionCreate, "name", at)
+		created.OldValue, created.NewValue = nil, value("new")
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(0), eventExportPageSize).Return([]*entity.ItemHistory{
+			created,
+			history(2, 10, entity.ItemHistoryActionUpdate, "name", at),
+		}, nil)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(2), eventExportPageSize).Return([]*entity.ItemHistory{}, nil)
+
+		events, err := collect(t, NewEventExportUsecase(historyRepo, sharedItems(10)), "")
+		require.NoError(t, err)
+		// 同じ日時でも登録と更新は別の操作
+		require.Len(t, events, 2)
+		assert.Equal(t, entity.ItemEventCreated, events[0].Type)
+		assert.Nil(t, events[0].Changes[0].OldValue)
+		assert.Equal(t, entity.ItemEventUpdated, events[1].Type)
+	})
+
+	t.Run("正常系: since の日付以降の変更履歴を読み込む", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindSince", mock.Anything, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), int64(0), eventExportPageSize).Return([]*entity.ItemHistory{}, nil)
+
+		events, err := collect(t, NewEventExportUsecase(historyRepo, sharedItems()), "2024-06-01")
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		historyRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 価格・シリアル番号・メモなど公開しない項目の変更は書き出さない", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(0), eventExportPageSize).Return([]*entity.ItemHistory{
+			history(1, 10, entity.ItemHistoryActionUpdate, "name", at),
+			history(2, 10, entity.ItemHistoryActionUpdate, "purchase_price", at),
+			history(3, 10, entity.ItemHistoryActionUpdate, "serial_number", at),
+			history(4, 10, entity.ItemHistoryActionUpdate, "notes", at),
+			// 公開しない項目だけの操作はイベントごと書き出さない
+			history(5, 10, entity.ItemHistoryActionUpdate, "purchase_price", at.Add(time.Minute)),
+		}, nil)
+
+		events, err := collect(t, NewEventExportUsecase(historyRepo, sharedItems(10)), "")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		require.Len(t, events[0].Changes, 1)
+		assert.Equal(t, "name", events[0].Changes[0].Field)
+	})
+
+	t.Run("正常系: 非公開のアイテムのイベントは書き出さない", func(t *testing.T) {
+		visibility := func(id, itemID int64, action entity.ItemHistoryAction, oldValue, newValue entity.Visibility, createdAt time.Time) *entity.ItemHistory {
+			h := history(id, itemID, action, "visibility", createdAt)
+			h.OldValue, h.NewValue = value(string(oldValue)), value(string(newValue))
+			if action == entity.ItemHistoryActionCreate {
+				h.OldValue = nil
+			}
+			if action == entity.ItemHistoryActionDelete {
+				h.NewValue = nil
+			}
+			return h
+		}
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(0), eventExportPageSize).Return([]*entity.ItemHistory{
+			// 現在は非公開のアイテム
+			history(1, 10, entity.ItemHistoryActionUpdate, "name", at),
+			// 非公開で登録し、後から共有にしたアイテム
+			history(2, 11, entity.ItemHistoryActionCreate, "name", at),
+			visibility(3, 11, entity.ItemHistoryActionCreate, "", entity.VisibilityPrivate, at),
+			history(4, 11, entity.ItemHistoryActionUpdate, "name", at.Add(time.Minute)),
+			visibility(5, 11, entity.ItemHistoryActionUpdate, entity.VisibilityPrivate, entity.VisibilityShared, at.Add(time.Minute)),
+			// 削除済みで現在の公開範囲が分からないアイテム
+			history(6, 12, entity.ItemHistoryActionUpdate, "name", at),
+		}, nil)
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(10)).Return(&entity.Item{ID: 10, Visibility: entity.VisibilityPrivate}, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(12)).Return(nil, domainErrors.ErrItemNotFound)
+
+		events, err := collect(t, NewEventExportUsecase(historyRepo, itemRepo), "")
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, int64(11), events[0].ItemID)
+		assert.Equal(t, entity.ItemEventUpdated, events[0].Type)
+		// 公開範囲そのものは ExposedItem に含めないため書き出さない
+		require.Len(t, events[0].Changes, 1)
+		assert.Equal(t, "name", events[0].Changes[0].Field)
+		itemRepo.AssertNotCalled(t, "FindByID", mock.Anything, int64(11))
+	})
+
+	t.Run("異常系: アイテムの読み込みのエラー", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(0), eventExportPageSize).Return([]*entity.ItemHistory{
+			history(1, 10, entity.ItemHistoryActionUpdate, "name", at),
+		}, nil)
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(10)).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := collect(t, NewEventExportUsecase(historyRepo, itemRepo), "")
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+
+	t.Run("異常系: 書き込みのエラーで中断する", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindSince", mock.Anything, time.Time{}, int64(0), eventExportPageSize).Return([]*entity.ItemHistory{
+			history(1, 10, entity.ItemHistoryActionUpdate, "name", at),
+		}, nil)
+		writeErr := errors.New("connection closed")
+
+		err := NewEventExportUsecase(historyRepo, sharedItems(10)).Export(adminCtx, "", func(*entity.ItemEvent) error { return writeErr })
+		assert.ErrorIs(t, err, writeErr)
+	})
+
+	t.Run("異常系: 管理者以外", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+
+		err := NewEventExportUsecase(historyRepo, sharedItems()).Export(actorContext(), "", func(*entity.ItemEvent) error { return nil })
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		historyRepo.AssertNotCalled(t, "FindSince", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 不正な since", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+
+		err := NewEventExportUsecase(historyRepo, sharedItems()).Export(adminCtx, "yesterday", func(*entity.ItemEvent) error { return nil })
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ExportFormat はエクスポートのファイル形式
+type ExportFormat string
+
+const (
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// ExportRenderer はエクスポートの内容をファイルに変換する（形式ごとに実装する）
+type ExportRenderer interface {
+	Render(export *ItemExport) ([]byte, error)
+	ContentType() string
+}
+
+// ItemExport はエクスポートするアイテムと集計
+type ItemExport struct {
+	GeneratedAt time.Time
+	Items       []*entity.Item
+	// Currency は Values と集計の金額の通貨（操作者の表示通貨）
+	Currency entity.Currency
+	// Values はアイテムの ID ごとの購入価格を Currency に換算した金額（換算できない外貨建てのアイテムは含まない）
+	Values map[int64]int64
+	// Categories はカテゴリー別の集計（カテゴリー名の順）
+	Categories []CategoryTotal
+	Total      CategoryTotal
+}
+
+// CategoryTotal はカテゴリーごとの件数と購入価格を表示通貨に換算した合計（全体の合計では Category は空）
+type CategoryTotal struct {
+	Category      string
+	Count         int
+	PurchasePrice int64
+}
+
+// ExportFile はダウンロードさせるファイル
+type ExportFile struct {
+	FileName    string
+	ContentType string
+	Body        []byte
+}
+
+type ExportUsecase interface {
+	// Export は一覧と同じ条件で絞り込んだアイテムを指定の形式で出力する
+	Export(ctx context.Context, format ExportFormat, filter entity.ItemFilter) (*ExportFile, error)
+	// StartExport は Export と同じファイルを作成するジョブを開始する（結果は GET /jobs/{id}/result）
+	StartExport(ctx context.Context, format ExportFormat, filter entity.ItemFilter) (*entity.Job, error)
+	// StartAccountingExport は会計ソフト向けの仕訳のCSVを作成するジョブを開始する
+	StartAccountingExport(ctx context.Context, input AccountingExportInput) (*entity.Job, error)
+}
+
+type exportUsecase struct {
+	itemRepo  ItemRepository
+	renderers map[ExportFormat]ExportRenderer
+	converter CurrencyConverter
+	now       func() time.Time
+
+	jobs             JobUsecase
+	invoiceRepo      InvoiceRepository
+	journalRenderers map[AccountingFormat]JournalRenderer
+}
+
+func NewExportUsecase(itemRepo ItemRepository, renderers map[ExportFormat]ExportRenderer, opts ...ExportUsecaseOption) ExportUsecase {
+	u := &exportUsecase{
+		itemRepo:  itemRepo,
+		renderers: renderers,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// WithExportJobs はエクスポートをジョブとして実行できるようにし、実行時間を負荷の見積もりに反映する
+func WithExportJobs(jobs JobUsecase) ExportUsecaseOption {
+	return func(u *exportUsecase) {
+		u.jobs = jobs
+	}
+}
+
+// WithExportCurrencyConverter は集計の金額を操作者の表示通貨に換算するよう設定する（未設定の場合は円建てのみ合計する）
+func WithExportCurrencyConverter(converter CurrencyConverter) ExportUsecaseOption {
+	return func(u *exportUsecase) {
+		u.converter = converter
+	}
+}
+
+func (u *exportUsecase) Export(ctx context.Context, format ExportFormat, filter entity.ItemFilter) (*ExportFile, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer, filter, err := u.prepareExport(actor, format, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	file, err := u.export(ctx, actor, renderer, format, filter, newValuation(actor, u.converter))
+	if err != nil {
+		return nil, err
+	}
+	if u.jobs != nil {
+		u.jobs.Observe(entity.JobKindExport, time.Since(start))
+	}
+
+	return file, nil
+}
+
+func (u *exportUsecase) StartExport(ctx context.Context, format ExportFormat, filter entity.ItemFilter) (*entity.Job, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.jobs == nil {
+		return nil, fmt.Errorf("%w: asynchronous export is not enabled", domainErrors.ErrInvalidInput)
+	}
+	// 条件の誤りはジョブの開始前に返す
+	renderer, filter, err := u.prepareExport(actor, format, filter)
+	if err != nil {
+		return nil, err
+	}
+	valuation := newValuation(actor, u.converter)
+
+	return u.jobs.Submit(ctx, strconv.FormatInt(actor.ID, 10), entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+		file, err := u.export(ctx, actor, renderer, format, filter, valuation)
+		if err != nil {
+			return err
+		}
+		job.Result = &entity.JobResult{
+			FileName:    file.FileName,
+			ContentType: file.ContentType,
+			Body:        file.Body,
+		}
+		return nil
+	})
+}
+
+// prepareExport は形式と絞り込み条件を検証し、操作者が参照できるアイテムに絞り込む条件を返す
+func (u *exportUsecase) prepareExport(actor *entity.User, format ExportFormat, filter entity.ItemFilter) (ExportRenderer, entity.ItemFilter, error) {
+	renderer, ok := u.renderers[format]
+	if !ok {
+		return nil, filter, fmt.Errorf("%w: unsupported export format: %s", domainErrors.ErrInvalidInput, format)
+	}
+	filter, err := scopeItemFilter(actor, filter)
+	if err != nil {
+		return nil, filter, err
+	}
+
+	return renderer, filter, nil
+}
+
+func (u *exportUsecase) export(ctx context.Context, actor *entity.User, renderer ExportRenderer, format ExportFormat, filter entity.ItemFilter, valuation valuation) (*ExportFile, error) {
+	items, err := u.itemRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	redactItems(actor, items...)
+	items = hideRedactedMatches(filter, items)
+	// エクスポートしたファイルは所有者以外に渡すため、共有リンクと同じ公開範囲のアイテムのみ含める
+	items = ExposableItems(items, entity.AudienceShared)
+
+	export, err := newItemExport(ctx, items, valuation, u.now())
+	if err != nil {
+		return nil, err
+	}
+	body, err := renderer.Render(export)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render export: %w", err)
+	}
+
+	return &ExportFile{
+		FileName:    fmt.Sprintf("items-%s.%s", export.GeneratedAt.Format("20060102-150405"), format),
+		ContentType: renderer.ContentType(),
+		Body:        body,
+	}, nil
+}
+
+func newItemExport(ctx context.Context, items []*entity.Item, valuation valuation, now time.Time) (*ItemExport, error) {
+	export := &ItemExport{
+		GeneratedAt: now,
+		Items:       items,
+		Currency:    valuation.currency,
+		Values:      make(map[int64]int64),
+		Categories:  []CategoryTotal{},
+	}
+
+	totals := make(map[string]*CategoryTotal)
+	for i, item := range items {
+		// ジョブとして実行する場合の進捗は、取得と出力をそれぞれ1件分として数える
+		ReportJobProgress(ctx, i+1, len(items)+2)
+		total, ok := totals[item.Category]
+		if !ok {
+			total = &CategoryTotal{Category: item.Category}
+			totals[item.Category] = total
+		}
+		total.Count++
+		export.Total.Count++
+
+		// 購入価格を非表示にしたアイテムは換算額と合計に含めない
+		if item.IsRedacted(entity.ItemFieldPurchasePrice) {
+			continue
+		}
+		value, ok, err := valuation.convert(ctx, int64(item.PurchasePrice.Amount), item.PurchasePrice.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		export.Values[item.ID] = value
+		total.PurchasePrice = entity.AddAmount(total.PurchasePrice, value)
+		export.Total.PurchasePrice = entity.AddAmount(export.Total.PurchasePrice, value)
+	}
+
+	for _, total := range totals {
+		export.Categories = append(export.Categories, *total)
+	}
+	sort.Slice(export.Categories, func(i, j int) bool {
+		return export.Categories[i].Category < export.Categories[j].Category
+	})
+
+	return export, nil
+}
@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockExportRenderer struct {
+	mock.Mock
+}
+
+func (m *MockExportRenderer) Render(export *ItemExport) ([]byte, error) {
+	args := m.Called(export)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockExportRenderer) ContentType() string {
+	return "application/test"
+}
+
+func newExportTestUsecase() (*exportUsecase, *MockItemRepository, *MockExportRenderer) {
+	itemRepo := new(MockItemRepository)
+	renderer := new(MockExportRenderer)
+	u := NewExportUsecase(itemRepo, map[ExportFormat]ExportRenderer{ExportFormatXLSX: renderer}).(*exportUsecase)
+	u.now = func() time.Time { return time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC) }
+	return u, itemRepo, renderer
+}
+
+func TestExportUsecase_Export(t *testing.T) {
+	t.Run("正常系: カテゴリー別と全体の合計を集計して出力する", func(t *testing.T) {
+		usecase, itemRepo, renderer := newExportTestUsecase()
+		items := []*entity.Item{
+			{ID: 1, Name: "ロレックス デイトナ", Category: "時計", PurchasePrice: entity.JPY(1500000), Visibility: entity.VisibilityShared},
+			{ID: 2, Name: "エルメス バーキン", Category: "バッグ", PurchasePrice: entity.JPY(2000000), Visibility: entity.VisibilityShared},
+			{ID: 3, Name: "オメガ スピードマスター", Category: "時計", PurchasePrice: entity.JPY(500000), Visibility: entity.VisibilityShared},
+		}
+		itemRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(f entity.ItemFilter) bool {
+			return f.UserID == testActor.ID && f.Category == ""
+		})).Return(items, nil)
+		renderer.On("Render", mock.MatchedBy(func(e *ItemExport) bool {
+			return assert.ObjectsAreEqual([]CategoryTotal{
+				{Category: "バッグ", Count: 1, PurchasePrice: 2000000},
+				{Category: "時計", Count: 2, PurchasePrice: 2000000},
+			}, e.Categories) && e.Total == CategoryTotal{Count: 3, PurchasePrice: 4000000} && len(e.Items) == 3
+		})).Return([]byte("file"), nil)
+
+		file, err := usecase.Export(actorContext(), ExportFormatXLSX, entity.ItemFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, "items-20240701-093000.xlsx", file.FileName)
+		assert.Equal(t, "application/test", file.ContentType)
+		assert.Equal(t, []byte("file"), file.Body)
+		renderer.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 非公開のアイテムは含めない", func(t *testing.T) {
+		usecase, itemRepo, renderer := newExportTestUsecase()
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{
+			{ID: 1, Name: "ロレックス デイトナ", Category: "時計", PurchasePrice: entity.JPY(1500000), Visibility: entity.VisibilityShared},
+			{ID: 2, Name: "カルティエ タンク", Category: "時計", PurchasePrice: entity.JPY(800000), Visibility: entity.VisibilityPrivate, SerialNumber: "SN-0002"},
+			{ID: 3, Name: "オメガ スピードマスター", Category: "時計", PurchasePrice: entity.JPY(500000)},
+		}, nil)
+		renderer.On("Render", mock.MatchedBy(func(e *ItemExport) bool {
+			return len(e.Items) == 1 && e.Items[0].ID == 1 &&
+				e.Total == CategoryTotal{Count: 1, PurchasePrice: 1500000}
+		})).Return([]byte("file"), nil)
+
+		_, err := usecase.Export(actorContext(), ExportFormatXLSX, entity.ItemFilter{})
+		require.NoError(t, err)
+		renderer.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 表示通貨に換算して集計する", func(t *testing.T) {
+		usecase, itemRepo, renderer := newExportTestUsecase()
+		converter := new(MockCurrencyConverter)
+		WithExportCurrencyConverter(converter)(usecase)
+		items := []*entity.Item{
+			{ID: 1, Name: "ロレックス デイトナ", Category: "時計", PurchasePrice: entity.JPY(1500000), Visibility: entity.VisibilityShared},
+			{ID: 2, Name: "オメガ スピードマスター", Category: "時計", PurchasePrice: entity.NewMoney(650000, "USD"), Visibility: entity.VisibilityShared},
+		}
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return(items, nil)
+		converter.On("Convert", mock.Anything, int64(1500000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(996675), nil)
+		renderer.On("Render", mock.MatchedBy(func(e *ItemExport) bool {
+			return e.Currency == entity.CurrencyUSD &&
+				assert.ObjectsAreEqual(map[int64]int64{1: 996675, 2: 650000}, e.Values) &&
+				e.Total == CategoryTotal{Count: 2, PurchasePrice: 1646675}
+		})).Return([]byte("file"), nil)
+
+		usdActor := &entity.User{ID: testActor.ID, Role: entity.RoleEditor, PreferredCurrency: entity.CurrencyUSD}
+		_, err := usecase.Export(WithActor(context.Background(), usdActor), ExportFormatXLSX, entity.ItemFilter{})
+		require.NoError(t, err)
+		renderer.AssertExpectations(t)
+		converter.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 為替レートを取得できない場合", func(t *testing.T) {
+		usecase, itemRepo, renderer := newExportTestUsecase()
+		converter := new(MockCurrencyConverter)
+		WithExportCurrencyConverter(converter)(usecase)
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{
+			{ID: 1, Category: "時計", PurchasePrice: entity.NewMoney(650000, "USD"), Visibility: entity.VisibilityShared},
+		}, nil)
+		converter.On("Convert", mock.Anything, int64(650000), entity.CurrencyUSD, entity.CurrencyJPY).Return(int64(0), domainErrors.ErrExchangeRateUnavailable)
+
+		_, err := usecase.Export(actorContext(), ExportFormatXLSX, entity.ItemFilter{})
+		assert.True(t, domainErrors.IsExchangeRateUnavailableError(err))
+		renderer.AssertNotCalled(t, "Render", mock.Anything)
+	})
+
+	t.Run("異常系: 対応していない形式", func(t *testing.T) {
+		usecase, itemRepo, _ := newExportTestUsecase()
+
+		_, err := usecase.Export(actorContext(), "ods", entity.ItemFilter{})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 不正な絞り込み条件", func(t *testing.T) {
+		usecase, itemRepo, _ := newExportTestUsecase()
+
+		_, err := usecase.Export(actorContext(), ExportFormatXLSX, entity.ItemFilter{PurchaseDateFrom: "2024/01/01"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 未認証", func(t *testing.T) {
+		usecase, _, _ := newExportTestUsecase()
+
+		_, err := usecase.Export(context.Background(), ExportFormatXLSX, entity.ItemFilter{})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
+
+func TestExportUsecase_StartExport(t *testing.T) {
+	t.Run("正常系: エクスポートと同じファイルをジョブの結果にする", func(t *testing.T) {
+		usecase, itemRepo, renderer := newExportTestUsecase()
+		jobs := NewJobUsecase()
+		WithExportJobs(jobs)(usecase)
+		itemRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(f entity.ItemFilter) bool {
+			return f.UserID == testActor.ID && f.Category == "時計"
+		})).Return([]*entity.Item{{ID: 1, Category: "時計", PurchasePrice: entity.JPY(1500000), Visibility: entity.VisibilityShared}}, nil)
+		renderer.On("Render", mock.Anything).Return([]byte("file"), nil)
+
+		job, err := usecase.StartExport(actorContext(), ExportFormatXLSX, entity.ItemFilter{Category: "時計"})
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobKindExport, job.Kind)
+
+		userID := strconv.FormatInt(testActor.ID, 10)
+		finished := waitForJob(t, jobs, userID, job.ID)
+		require.Equal(t, entity.JobStatusSucceeded, finished.Status, finished.Error)
+		result, err := jobs.GetResult(context.Background(), userID, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "items-20240701-093000.xlsx", result.FileName)
+		assert.Equal(t, []byte("file"), result.Body)
+	})
+
+	t.Run("異常系: 条件の誤りはジョブを開始せずに返す", func(t *testing.T) {
+		usecase, itemRepo, _ := newExportTestUsecase()
+		WithExportJobs(NewJobUsecase())(usecase)
+
+		_, err := usecase.StartExport(actorContext(), "pdf", entity.ItemFilter{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		itemRepo.AssertNotCalled(t, "FindAll")
+	})
+
+	t.Run("異常系: ジョブが有効でない", func(t *testing.T) {
+		usecase, _, _ := newExportTestUsecase()
+
+		_, err := usecase.StartExport(actorContext(), ExportFormatXLSX, entity.ItemFilter{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// IdempotencyUsecase は Idempotency-Key を指定したリクエストのレスポンスを保存し、
+// 通信の失敗などで再送された同じリクエストを処理せずに保存したレスポンスを返せるようにする
+type IdempotencyUsecase interface {
+	// Begin はキーでリクエストの処理を開始する。同じキーのリクエストを処理済みの場合は保存したレスポンスを返す。
+	// 処理中の場合は ErrIdempotencyKeyInUse、異なるリクエストに使われたキーの場合は ErrIdempotencyKeyReused を返す
+	Begin(ctx context.Context, key, requestHash string) (*entity.IdempotencyRecord, error)
+	// Complete は処理したリクエストのレスポンスを保存する
+	Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error
+	// Abort は処理に失敗したリクエストのキーを削除し、再送で処理し直せるようにする
+	Abort(ctx context.Context, key string) error
+	// DeleteExpired は保存期間を過ぎたキーを削除する
+	DeleteExpired(ctx context.Context) error
+}
+
+type idempotencyUsecase struct {
+	idempotencyRepo IdempotencyRepository
+	now             func() time.Time
+}
+
+func NewIdempotencyUsecase(idempotencyRepo IdempotencyRepository) IdempotencyUsecase {
+	return &idempotencyUsecase{
+		idempotencyRepo: idempotencyRepo,
+		now:             time.Now,
+	}
+}
+
+func (u *idempotencyUsecase) Begin(ctx context.Context, key, requestHash string) (*entity.IdempotencyRecord, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key == "" || len(key) > entity.IdempotencyKeyMaxLength {
+		return nil, fmt.Errorf("%w: Idempotency-Key must be 1 to %d characters", domainErrors.ErrInvalidInput, entity.IdempotencyKeyMaxLength)
+	}
+
+	record := &entity.IdempotencyRecord{
+		UserID:      actor.ID,
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   u.now(),
+	}
+	err = u.idempotencyRepo.Create(ctx, record)
+	if err == nil {
+		return nil, nil
+	}
+	if !domainErrors.IsDuplicateError(err) {
+		return nil, err
+	}
+
+	existing, err := u.idempotencyRepo.Find(ctx, actor.ID, key)
+	if err != nil {
+		return nil, err
+	}
+	if existing.IsExpired(u.now()) {
+		// 保存期間を過ぎたキーは新しいリクエストとして扱う
+		if err := u.idempotencyRepo.Delete(ctx, actor.ID, key); err != nil {
+			return nil, err
+		}
+		if err := u.idempotencyRepo.Create(ctx, record); err != nil {
+			if domainErrors.IsDuplicateError(err) {
+				return nil, domainErrors.ErrIdempotencyKeyInUse
+			}
+			return nil, err
+		}
+		return nil, nil
+	}
+	if existing.RequestHash != requestHash {
+		return nil, domainErrors.ErrIdempotencyKeyReused
+	}
+	if !existing.Completed {
+		return nil, domainErrors.ErrIdempotencyKeyInUse
+	}
+
+	return existing, nil
+}
+
+func (u *idempotencyUsecase) Complete(ctx context.Context, key string, statusCode int, contentType string, body []byte) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return u.idempotencyRepo.Complete(ctx, &entity.IdempotencyRecord{
+		UserID:      actor.ID,
+		Key:         key,
+		Completed:   true,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Body:        body,
+	})
+}
+
+func (u *idempotencyUsecase) Abort(ctx context.Context, key string) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return u.idempotencyRepo.Delete(ctx, actor.ID, key)
+}
+
+func (u *idempotencyUsecase) DeleteExpired(ctx context.Context) error {
+	return u.idempotencyRepo.DeleteExpired(ctx, u.now().Add(-entity.IdempotencyKeyTTL))
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockIdempotencyRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyRepository) Create(ctx context.Context, record *entity.IdempotencyRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Find(ctx context.Context, userID int64, key string) (*entity.IdempotencyRecord, error) {
+	args := m.Called(ctx, userID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyRepository) Complete(ctx context.Context, record *entity.IdempotencyRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) Delete(ctx context.Context, userID int64, key string) error {
+	args := m.Called(ctx, userID, key)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	args := m.Called(ctx, before)
+	return args.Error(0)
+}
+
+func TestIdempotencyUsecase_Begin(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	ctx := WithActor(context.Background(), &entity.User{ID: 7, Role: entity.RoleEditor})
+	completed := &entity.IdempotencyRecord{
+		UserID:      7,
+		Key:         "key-1",
+		RequestHash: "hash",
+		Completed:   true,
+		StatusCode:  201,
+		ContentType: "application/json",
+		Body:        []byte(`{"id":1}`),
+		CreatedAt:   now.Add(-time.Hour),
+	}
+
+	tests := []struct {
+		name        string
+		key         string
+		hash        string
+		setupMock   func(*MockIdempotencyRepository)
+		expected    *entity.IdempotencyRecord
+		expectedErr error
+	}{
+		{
+			name: "初めてのキーは処理を開始する",
+			key:  "key-1",
+			hash: "hash",
+			setupMock: func(repo *MockIdempotencyRepository) {
+				repo.On("Create", mock.Anything, &entity.IdempotencyRecord{UserID: 7, Key: "key-1", RequestHash: "hash", CreatedAt: now}).Return(nil)
+			},
+		},
+		{
+			name: "処理済みのキーは保存したレスポンスを返す",
+			key:  "key-1",
+			hash: "hash",
+			setupMock: func(repo *MockIdempotencyRepository) {
+				repo.On("Create", mock.Anything, mock.Anything).Return(domainErrors.ErrDuplicateEntry)
+				repo.On("Find", mock.Anything, int64(7), "key-1").Return(completed, nil)
+			},
+			expected: completed,
+		},
+		{
+			name: "処理中のキーは ErrIdempotencyKeyInUse",
+			key:  "key-1",
+			hash: "hash",
+			setupMock: func(repo *MockIdempotencyRepository) {
+				repo.On("Create", mock.Anything, mock.Anything).Return(domainErrors.ErrDuplicateEntry)
+				repo.On("Find", mock.Anything, int64(7), "key-1").Return(&entity.IdempotencyRecord{
+					UserID: 7, Key: "key-1", RequestHash: "hash", CreatedAt: now,
+				}, nil)
+			},
+			expectedErr: domainErrors.ErrIdempotencyKeyInUse,
+		},
+		{
+			name: "異なるリクエストに使われたキーは ErrIdempotencyKeyReused",
+			key:  "key-1",
+			hash: "other",
+			setupMock: func(repo *MockIdempotencyRepository) {
+				repo.On("Create", mock.Anything, mock.Anything).Return(domainErrors.ErrDuplicateEntry)
+				repo.On("Find", mock.Anything, int64(7), "key-1").Return(completed, nil)
+			},
+			expectedErr: domainErrors.ErrIdempotencyKeyReused,
+		},
+		{
+			name: "保存期間を過ぎたキーは新しいリクエストとして扱う",
+			key:  "key-1",
+			hash: "other",
+			setupMock: func(repo *MockIdempotencyRepository) {
+				repo.On("Create", mock.Anything, mock.Anything).Return(domainErrors.ErrDuplicateEntry).Once()
+				repo.On("Find", mock.Anything, int64(7), "key-1").Return(&entity.IdempotencyRecord{
+					UserID: 7, Key: "key-1", RequestHash: "hash", Completed: true, CreatedAt: now.Add(-25 * time.Hour),
+				}, nil)
+				repo.On("Delete", mock.Anything, int64(7), "key-1").Return(nil)
+				repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:        "長すぎるキーはエラー",
+			key:         strings.Repeat("a", entity.IdempotencyKeyMaxLength+1),
+			hash:        "hash",
+			setupMock:   func(repo *MockIdempotencyRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockIdempotencyRepository)
+			tt.setupMock(repo)
+			u := &idempotencyUsecase{idempotencyRepo: repo, now: func() time.Time { return now }}
+
+			record, err := u.Begin(ctx, tt.key, tt.hash)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, record)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, record)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("未認証の場合はエラー", func(t *testing.T) {
+		_, err := NewIdempotencyUsecase(new(MockIdempotencyRepository)).Begin(context.Background(), "key-1", "hash")
+
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
+
+func TestIdempotencyUsecase_CompleteAndAbort(t *testing.T) {
+	ctx := WithActor(context.Background(), &entity.User{ID: 7, Role: entity.RoleEditor})
+	repo := new(MockIdempotencyRepository)
+	repo.On("Complete", mock.Anything, &entity.IdempotencyRecord{
+		UserID: 7, Key: "key-1", Completed: true, StatusCode: 201, ContentType: "application/json", Body: []byte(`{}`),
+	}).Return(nil)
+	repo.On("Delete", mock.Anything, int64(7), "key-2").Return(nil)
+	u := NewIdempotencyUsecase(repo)
+
+	require.NoError(t, u.Complete(ctx, "key-1", 201, "application/json", []byte(`{}`)))
+	require.NoError(t, u.Abort(ctx, "key-2"))
+
+	repo.AssertExpectations(t)
+}
+
+func TestIdempotencyUsecase_DeleteExpired(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	repo := new(MockIdempotencyRepository)
+	repo.On("DeleteExpired", mock.Anything, now.Add(-entity.IdempotencyKeyTTL)).Return(nil)
+	u := &idempotencyUsecase{idempotencyRepo: repo, now: func() time.Time { return now }}
+
+	require.NoError(t, u.DeleteExpired(context.Background()))
+
+	repo.AssertExpectations(t)
+}
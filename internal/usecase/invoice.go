@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// InvoiceRenderer は請求書を帳票（PDF）に変換する
+type InvoiceRenderer interface {
+	Render(invoice *entity.Invoice) ([]byte, error)
+}
+
+// InvoiceInput は委託品を販売済みにするときに発行する請求書の内容。
+// 売れたアイテムの明細（合意した販売価格）は自動で先頭に追加する
+type InvoiceInput struct {
+	BuyerName    string `json:"buyer_name"`
+	BuyerAddress string `json:"buyer_address"`
+	// TaxRate は消費税率（%、省略時 10）
+	TaxRate *float64 `json:"tax_rate,omitempty"`
+	// Lines は送料などの追加の明細
+	Lines []InvoiceLineInput `json:"lines,omitempty"`
+}
+
+type InvoiceLineInput struct {
+	Description string `json:"description"`
+	Quantity    int    `json:"quantity"`
+	UnitPrice   int    `json:"unit_price"`
+}
+
+// InvoiceUsecase は発行した請求書を扱う（発行は ConsignmentUsecase.Put で行う）
+type InvoiceUsecase interface {
+	// List は操作を行うユーザーが発行した請求書を新しい順に返す（管理者はすべて）
+	List(ctx context.Context) ([]*entity.Invoice, error)
+	Get(ctx context.Context, id int64) (*entity.Invoice, error)
+	// PDF は請求書のPDFを生成する
+	PDF(ctx context.Context, id int64) ([]byte, error)
+}
+
+type invoiceUsecase struct {
+	invoiceRepo InvoiceRepository
+	renderer    InvoiceRenderer
+}
+
+func NewInvoiceUsecase(invoiceRepo InvoiceRepository, renderer InvoiceRenderer) InvoiceUsecase {
+	return &invoiceUsecase{
+		invoiceRepo: invoiceRepo,
+		renderer:    renderer,
+	}
+}
+
+func (u *invoiceUsecase) List(ctx context.Context) ([]*entity.Invoice, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	invoices, err := u.invoiceRepo.FindAll(ctx, itemScope(actor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve invoices: %w", err)
+	}
+
+	return invoices, nil
+}
+
+func (u *invoiceUsecase) Get(ctx context.Context, id int64) (*entity.Invoice, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	invoice, err := u.invoiceRepo.FindByID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrInvoiceNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve invoice: %w", err)
+	}
+	// 他のユーザーが発行した請求書は存在しないものとして扱う
+	if !actor.IsAdmin() && invoice.UserID != actor.ID {
+		return nil, domainErrors.ErrInvoiceNotFound
+	}
+
+	return invoice, nil
+}
+
+func (u *invoiceUsecase) PDF(ctx context.Context, id int64) ([]byte, error) {
+	invoice, err := u.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	pdf, err := u.renderer.Render(invoice)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render invoice: %w", err)
+	}
+
+	return pdf, nil
+}
+
+// newSaleInvoice は売れた委託品の請求書を作成する（先頭の明細は合意した販売価格のアイテム）
+func newSaleInvoice(actor *entity.User, item *entity.Item, consignment *entity.Consignment, input InvoiceInput, issuedAt time.Time) (*entity.Invoice, error) {
+	taxRate := entity.DefaultTaxRate
+	if input.TaxRate != nil {
+		taxRate = *input.TaxRate
+	}
+
+	lines := []entity.InvoiceLine{{
+		Description: strings.TrimSpace(item.Brand + " " + item.Name),
+		Quantity:    1,
+		UnitPrice:   consignment.AgreedPrice,
+	}}
+	for _, line := range input.Lines {
+		lines = append(lines, entity.InvoiceLine{
+			Description: line.Description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.UnitPrice,
+		})
+	}
+
+	invoice, err := entity.NewInvoice(actor.ID, item.ID, input.BuyerName, input.BuyerAddress, lines, taxRate, issuedAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invoice: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	return invoice, nil
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockInvoiceRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceRepository) Create(ctx context.Context, invoice *entity.Invoice) (*entity.Invoice, error) {
+	args := m.Called(ctx, invoice)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) FindByID(ctx context.Context, id int64) (*entity.Invoice, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Invoice), args.Error(1)
+}
+
+func (m *MockInvoiceRepository) FindAll(ctx context.Context, userID int64) ([]*entity.Invoice, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Invoice), args.Error(1)
+}
+
+type MockInvoiceRenderer struct {
+	mock.Mock
+}
+
+func (m *MockInvoiceRenderer) Render(invoice *entity.Invoice) ([]byte, error) {
+	args := m.Called(invoice)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestInvoiceUsecase_Get(t *testing.T) {
+	invoice := &entity.Invoice{ID: 1, Number: "INV-2024-000001", UserID: testActor.ID}
+
+	t.Run("正常系: 発行した請求書のPDF", func(t *testing.T) {
+		invoiceRepo := new(MockInvoiceRepository)
+		renderer := new(MockInvoiceRenderer)
+		invoiceRepo.On("FindByID", mock.Anything, int64(1)).Return(invoice, nil)
+		renderer.On("Render", invoice).Return([]byte("%PDF-1.4"), nil)
+
+		pdf, err := NewInvoiceUsecase(invoiceRepo, renderer).PDF(actorContext(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("%PDF-1.4"), pdf)
+	})
+
+	t.Run("異常系: 他のユーザーが発行した請求書", func(t *testing.T) {
+		invoiceRepo := new(MockInvoiceRepository)
+		invoiceRepo.On("FindByID", mock.Anything, int64(1)).Return(invoice, nil)
+		other := WithActor(context.Background(), &entity.User{ID: 2, Role: entity.RoleEditor})
+
+		_, err := NewInvoiceUsecase(invoiceRepo, new(MockInvoiceRenderer)).Get(other, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrInvoiceNotFound)
+	})
+
+	t.Run("正常系: 管理者はすべての請求書を参照できる", func(t *testing.T) {
+		invoiceRepo := new(MockInvoiceRepository)
+		invoiceRepo.On("FindAll", mock.Anything, int64(0)).Return([]*entity.Invoice{invoice}, nil)
+		admin := WithActor(context.Background(), &entity.User{ID: 2, Role: entity.RoleAdmin})
+
+		invoices, err := NewInvoiceUsecase(invoiceRepo, new(MockInvoiceRenderer)).List(admin)
+		require.NoError(t, err)
+		assert.Len(t, invoices, 1)
+	})
+}
+
+func TestConsignmentUsecase_PutWithInvoice(t *testing.T) {
+	newUsecase := func() (*consignmentUsecase, *MockConsignmentRepository, *MockInvoiceRepository) {
+		usecase, consignmentRepo, _ := newConsignmentTestUsecase()
+		invoiceRepo := new(MockInvoiceRepository)
+		usecase.invoiceRepo = invoiceRepo
+		return usecase, consignmentRepo, invoiceRepo
+	}
+
+	t.Run("正常系: 販売済みにするときに請求書を発行する", func(t *testing.T) {
+		usecase, consignmentRepo, invoiceRepo := newUsecase()
+		consignmentRepo.On("Save", mock.Anything, mock.Anything).Return(&entity.Consignment{ID: 1, ItemID: 1, Status: entity.ConsignmentStatusSold}, nil)
+		invoiceRepo.On("Create", mock.Anything, mock.MatchedBy(func(i *entity.Invoice) bool {
+			return i.ItemID == 1 && i.UserID == testActor.ID && len(i.Lines) == 2 &&
+				i.Lines[0].Description == "ROLEX ロレックス デイトナ" && i.Lines[0].UnitPrice == 500000 &&
+				i.Subtotal == 501000 && i.TaxRate == 8 && i.Tax == 40080 && i.Total == 541080 &&
+				i.IssuedAt.Equal(time.Date(2024, 7, 1, 9, 0, 0, 0, time.Local))
+		})).Return(&entity.Invoice{ID: 1, Number: "INV-2024-000001"}, nil)
+
+		taxRate := 8.0
+		consignment, err := usecase.Put(actorContext(), 1, ConsignmentInput{
+			ConsignorName: "佐藤", AgreedPrice: 500000, Status: entity.ConsignmentStatusSold,
+			Invoice: &InvoiceInput{
+				BuyerName: "山田", TaxRate: &taxRate,
+				Lines: []InvoiceLineInput{{Description: "送料", Quantity: 1, UnitPrice: 1000}},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, consignment.Invoice)
+		assert.Equal(t, "INV-2024-000001", consignment.Invoice.Number)
+		invoiceRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 販売済み以外では発行できない", func(t *testing.T) {
+		usecase, consignmentRepo, invoiceRepo := newUsecase()
+
+		_, err := usecase.Put(actorContext(), 1, ConsignmentInput{ConsignorName: "佐藤", Invoice: &InvoiceInput{BuyerName: "山田"}})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		consignmentRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		invoiceRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 購入者の名前がない", func(t *testing.T) {
+		usecase, consignmentRepo, _ := newUsecase()
+
+		_, err := usecase.Put(actorContext(), 1, ConsignmentInput{
+			ConsignorName: "佐藤", Status: entity.ConsignmentStatusSold, Invoice: &InvoiceInput{},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		consignmentRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 発行済みのアイテム", func(t *testing.T) {
+		usecase, consignmentRepo, invoiceRepo := newUsecase()
+		consignmentRepo.On("Save", mock.Anything, mock.Anything).Return(&entity.Consignment{ID: 1, ItemID: 1}, nil)
+		invoiceRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateEntry)
+
+		_, err := usecase.Put(actorContext(), 1, ConsignmentInput{
+			ConsignorName: "佐藤", Status: entity.ConsignmentStatusSold, Invoice: &InvoiceInput{BuyerName: "山田"},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	})
+
+	t.Run("異常系: 請求書の発行が無効", func(t *testing.T) {
+		usecase, _, _ := newConsignmentTestUsecase()
+
+		_, err := usecase.Put(actorContext(), 1, ConsignmentInput{
+			ConsignorName: "佐藤", Status: entity.ConsignmentStatusSold, Invoice: &InvoiceInput{BuyerName: "山田"},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
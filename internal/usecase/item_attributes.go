@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// AttributeType は属性の値の型
+type AttributeType string
+
+const (
+	AttributeTypeString AttributeType = "string"
+	AttributeTypeNumber AttributeType = "number"
+)
+
+// AttributeSchema はカテゴリーの1つの属性の定義
+type AttributeSchema struct {
+	Name string        `json:"name"`
+	Type AttributeType `json:"type"`
+	// Choices は文字列の選択肢（空の場合は MaxLength 文字以内の自由入力）
+	Choices   []string `json:"choices,omitempty"`
+	MaxLength int      `json:"max_length,omitempty"`
+	// Min / Max は数値の範囲（両端を含む）
+	Min float64 `json:"min,omitempty"`
+	Max float64 `json:"max,omitempty"`
+	// Indexed は一覧の絞り込み（attr.<name>=値）に使える属性（items の生成列に索引がある）
+	Indexed bool `json:"indexed"`
+}
+
+// CategoryAttributes はカテゴリーごとの属性のスキーマ（スキーマのないカテゴリーには属性を設定できない）
+var CategoryAttributes = map[string][]AttributeSchema{
+	"時計": {
+		{Name: entity.AttributeReferenceNumber, Type: AttributeTypeString, MaxLength: 32, Indexed: true},
+		{Name: entity.AttributeMovement, Type: AttributeTypeString, Choices: []string{"自動巻き", "手巻き", "クオーツ", "ソーラー"}, Indexed: true},
+		{Name: entity.AttributeCaseSize, Type: AttributeTypeNumber, Min: 10, Max: 70},
+	},
+	"バッグ": {
+		{Name: entity.AttributeMaterial, Type: AttributeTypeString, MaxLength: 50, Indexed: true},
+		{Name: entity.AttributeSize, Type: AttributeTypeString, MaxLength: 50},
+	},
+	"ジュエリー": {
+		{Name: entity.AttributeMetal, Type: AttributeTypeString, Choices: []string{"プラチナ", "K18", "K14", "シルバー", "その他"}, Indexed: true},
+		{Name: entity.AttributeCarat, Type: AttributeTypeNumber, Min: 0, Max: 1000},
+	},
+}
+
+// validateItemAttributes はカテゴリーの属性のスキーマで属性を検証し、正規化した新しい属性を返す。
+// 文字列は前後の空白を除き、null と空文字は未設定として除く
+func validateItemAttributes(category string, attrs entity.ItemAttributes) (entity.ItemAttributes, error) {
+	schemas := CategoryAttributes[category]
+	normalized := entity.ItemAttributes{}
+	var errs domainErrors.ValidationErrors
+
+	// エラーの順序が実行ごとに変わらないよう名前順に検証する
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := "attributes." + name
+		i := slices.IndexFunc(schemas, func(s AttributeSchema) bool { return s.Name == name })
+		if i < 0 {
+			errs.Add(field, domainErrors.CodeNotAllowed, fmt.Sprintf("%s is not an attribute of category %s", name, category))
+			continue
+		}
+		schema := schemas[i]
+
+		switch value := attrs[name]; schema.Type {
+		case AttributeTypeString:
+			if value == nil {
+				continue
+			}
+			s, ok := value.(string)
+			if !ok {
+				errs.Add(field, domainErrors.CodeInvalidType, fmt.Sprintf("%s must be a string", field))
+				continue
+			}
+			s = strings.TrimSpace(s)
+			switch {
+			case s == "":
+				continue
+			case len(schema.Choices) > 0 && !slices.Contains(schema.Choices, s):
+				errs.Add(field, domainErrors.CodeInvalidChoice, fmt.Sprintf("%s must be one of: %s", field, strings.Join(schema.Choices, ", ")))
+				continue
+			case schema.MaxLength > 0 && utf8.RuneCountInString(s) > schema.MaxLength:
+				errs.Add(field, domainErrors.CodeTooLong, fmt.Sprintf("%s must be %d characters or less", field, schema.MaxLength))
+				continue
+			}
+			normalized[name] = s
+		case AttributeTypeNumber:
+			if value == nil {
+				continue
+			}
+			n, ok := attributeNumber(value)
+			if !ok {
+				errs.Add(field, domainErrors.CodeInvalidType, fmt.Sprintf("%s must be a number", field))
+				continue
+			}
+			if n < schema.Min || n > schema.Max {
+				errs.Add(field, domainErrors.CodeOutOfRange, fmt.Sprintf("%s must be between %g and %g", field, schema.Min, schema.Max))
+				continue
+			}
+			normalized[name] = n
+		}
+	}
+
+	if err := errs.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	return normalized, nil
+}
+
+// setItemAttributes はアイテムのカテゴリーのスキーマで属性を検証して設定する
+func setItemAttributes(item *entity.Item, attrs entity.ItemAttributes) error {
+	normalized, err := validateItemAttributes(item.Category, attrs)
+	if err != nil {
+		return err
+	}
+	item.Attributes = normalized
+	return nil
+}
+
+// attributeNumber は JSON から読み込んだ数値（float64 か json.Number）を float64 にする
+func attributeNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// validateAttributeFilter は一覧の属性での絞り込みが索引のある属性かを検証する
+func validateAttributeFilter(filter map[string]string) error {
+	var errs domainErrors.ValidationErrors
+	for name := range filter {
+		if !isIndexedAttribute(name) {
+			errs.Add("attr."+name, domainErrors.CodeNotAllowed, fmt.Sprintf("attr.%s cannot be used for filtering", name))
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	return nil
+}
+
+// isIndexedAttribute はいずれかのカテゴリーで絞り込みに使える属性かを判定する
+func isIndexedAttribute(name string) bool {
+	for _, schemas := range CategoryAttributes {
+		for _, schema := range schemas {
+			if schema.Name == name && schema.Indexed {
+				return true
+			}
+		}
+	}
+	return false
+}
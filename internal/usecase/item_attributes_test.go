@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestValidateItemAttributes(t *testing.T) {
+	t.Run("正常系: 文字列の空白を除き、null と空文字の属性を除く", func(t *testing.T) {
+		attrs, err := validateItemAttributes("時計", entity.ItemAttributes{
+			entity.AttributeReferenceNumber: " 116500LN ",
+			entity.AttributeMovement:        "自動巻き",
+			entity.AttributeCaseSize:        json.Number("40"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemAttributes{"reference_number": "116500LN", "movement": "自動巻き", "case_size_mm": 40.0}, attrs)
+
+		attrs, err = validateItemAttributes("バッグ", entity.ItemAttributes{entity.AttributeMaterial: "  ", entity.AttributeSize: nil})
+		require.NoError(t, err)
+		assert.Empty(t, attrs)
+	})
+
+	t.Run("正常系: 元の属性を書き換えない", func(t *testing.T) {
+		input := entity.ItemAttributes{entity.AttributeMaterial: " レザー "}
+		_, err := validateItemAttributes("バッグ", input)
+		require.NoError(t, err)
+		assert.Equal(t, " レザー ", input[entity.AttributeMaterial])
+	})
+
+	tests := []struct {
+		name     string
+		category string
+		attrs    entity.ItemAttributes
+		field    string
+		code     string
+	}{
+		{"カテゴリーにない属性", "時計", entity.ItemAttributes{entity.AttributeMetal: "K18"}, "attributes.metal", domainErrors.CodeNotAllowed},
+		{"属性のないカテゴリー", "靴", entity.ItemAttributes{entity.AttributeSize: "27cm"}, "attributes.size", domainErrors.CodeNotAllowed},
+		{"選択肢にない値", "時計", entity.ItemAttributes{entity.AttributeMovement: "ゼンマイ"}, "attributes.movement", domainErrors.CodeInvalidChoice},
+		{"文字列の属性に数値", "時計", entity.ItemAttributes{entity.AttributeReferenceNumber: 116500.0}, "attributes.reference_number", domainErrors.CodeInvalidType},
+		{"数値の属性に文字列", "ジュエリー", entity.ItemAttributes{entity.AttributeCarat: "1.5"}, "attributes.carat", domainErrors.CodeInvalidType},
+		{"範囲外の数値", "時計", entity.ItemAttributes{entity.AttributeCaseSize: 200.0}, "attributes.case_size_mm", domainErrors.CodeOutOfRange},
+		{"長すぎる文字列", "バッグ", entity.ItemAttributes{entity.AttributeMaterial: strings.Repeat("あ", 51)}, "attributes.material", domainErrors.CodeTooLong},
+	}
+	for _, tt := range tests {
+		t.Run("異常系: "+tt.name, func(t *testing.T) {
+			_, err := validateItemAttributes(tt.category, tt.attrs)
+			require.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.field, errs[0].Field)
+			assert.Equal(t, tt.code, errs[0].Code)
+		})
+	}
+}
+
+func TestValidateAttributeFilter(t *testing.T) {
+	assert.NoError(t, validateAttributeFilter(map[string]string{"movement": "自動巻き", "metal": "K18"}))
+	assert.NoError(t, validateAttributeFilter(nil))
+
+	// 索引のない属性と存在しない属性では絞り込めない
+	err := validateAttributeFilter(map[string]string{"case_size_mm": "40"})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	err = validateAttributeFilter(map[string]string{"color": "黒"})
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
+
+func TestItemUsecase_Attributes(t *testing.T) {
+	t.Run("正常系: 登録時にカテゴリーの属性を保存する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		var created *entity.Item
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Item) }).
+			Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01",
+			Attributes: entity.ItemAttributes{entity.AttributeMovement: "自動巻き"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemAttributes{"movement": "自動巻き"}, created.Attributes)
+	})
+
+	t.Run("異常系: カテゴリーにない属性では登録しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "バッグ1", Category: "バッグ", Brand: "HERMES", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01",
+			Attributes: entity.ItemAttributes{entity.AttributeMovement: "自動巻き"},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 属性だけを更新でき、空で未設定に戻せる", func(t *testing.T) {
+		existingItem, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), existingItem).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		updated, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Attributes: &entity.ItemAttributes{entity.AttributeCaseSize: 40.0}})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemAttributes{"case_size_mm": 40.0}, updated.Attributes)
+
+		updated, err = usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Attributes: &entity.ItemAttributes{}})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Attributes)
+	})
+
+	t.Run("異常系: 索引のない属性では絞り込めない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(actorContext(), entity.ItemFilter{Attributes: map[string]string{"carat": "1"}})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"slices"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ExposedItem は所有者以外に渡すアイテムの表現（価格と所有者の情報は含めない）
+type ExposedItem struct {
+	Name         string `json:"name"`
+	Category     string `json:"category"`
+	Brand        string `json:"brand"`
+	PurchaseDate string `json:"purchase_date,omitempty"`
+}
+
+// ExposeOptions は受け手に渡す項目の追加設定
+type ExposeOptions struct {
+	PurchaseDate bool
+}
+
+// ExposeItem はアイテムを受け手向けに変換する。公開範囲の外なら false を返す。
+// 共有リンク・公開ポートフォリオ・Webhook・エクスポートなど、所有者以外に渡す経路はすべてここを通す。
+func ExposeItem(item *entity.Item, audience entity.Audience, opts ExposeOptions) (*ExposedItem, bool) {
+	if item == nil || !item.Visibility.VisibleTo(audience) {
+		return nil, false
+	}
+
+	exposed := &ExposedItem{
+		Name:     item.Name,
+		Category: item.Category,
+		Brand:    item.Brand,
+	}
+	if opts.PurchaseDate {
+		exposed.PurchaseDate = item.PurchaseDate
+	}
+	return exposed, true
+}
+
+// exposedItemFields は ExposedItem に含める項目の名前（変更履歴の項目名）
+func exposedItemFields(opts ExposeOptions) []string {
+	fields := []string{"name", "category", "brand"}
+	if opts.PurchaseDate {
+		fields = append(fields, "purchase_date")
+	}
+	return fields
+}
+
+// ExposableItems は受け手に渡せる公開範囲のアイテムだけを返す。
+// エクスポートのように、所有者が自分のアイテムの一覧をファイルにして渡す経路で使う
+func ExposableItems(items []*entity.Item, audience entity.Audience) []*entity.Item {
+	exposable := make([]*entity.Item, 0, len(items))
+	for _, item := range items {
+		if _, ok := ExposeItem(item, audience, ExposeOptions{}); ok {
+			exposable = append(exposable, item)
+		}
+	}
+	return exposable
+}
+
+// ExposeItemEvent はアイテムのイベントを受け手向けに変換する。visibility は操作後（削除では削除前）の公開範囲で、
+// 範囲の外か、ExposedItem に含める項目の変更がない場合は false を返す。変更は ExposedItem に含める項目のみ残す
+func ExposeItemEvent(event *entity.ItemEvent, visibility entity.Visibility, audience entity.Audience, opts ExposeOptions) (*entity.ItemEvent, bool) {
+	if _, ok := ExposeItem(&entity.Item{Visibility: visibility}, audience, opts); !ok {
+		return nil, false
+	}
+
+	fields := exposedItemFields(opts)
+	exposed := *event
+	exposed.Changes = nil
+	for _, change := range event.Changes {
+		if slices.Contains(fields, change.Field) {
+			exposed.Changes = append(exposed.Changes, change)
+		}
+	}
+	if len(exposed.Changes) == 0 {
+		return nil, false
+	}
+	return &exposed, true
+}
@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type ItemImageUsecase interface {
+	// Upload はアイテムに画像を追加する（表示順は末尾）
+	Upload(ctx context.Context, itemID int64, input UploadImageInput) (*entity.ItemImage, error)
+	List(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+	// Open は画像の内容を返す。呼び出し側で Close する
+	Open(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, io.ReadCloser, error)
+	// OpenThumbnail は指定した幅のサムネイル（JPEG）の内容を返す。呼び出し側で Close する
+	OpenThumbnail(ctx context.Context, itemID, imageID int64, width int) (io.ReadCloser, error)
+	Delete(ctx context.Context, itemID, imageID int64) error
+}
+
+// UploadImageInput はアップロードされたファイル。形式は内容から判定する
+type UploadImageInput struct {
+	FileName string
+	Size     int64
+	Body     io.Reader
+}
+
+type itemImageUsecase struct {
+	itemRepo    ItemRepository
+	imageRepo   ItemImageRepository
+	storage     FileStorage
+	thumbnailer ThumbnailGenerator
+	// サムネイル生成の同時実行数を制限する
+	thumbnailSlots chan struct{}
+	recognizer     TextRecognizer
+	documentIndex  DocumentTextIndex
+	// 文字認識の同時実行数を制限する
+	recognitionSlots chan struct{}
+	// async はサムネイル生成をバックグラウンドで実行する（テストでは同期的に実行する）
+	async func(func())
+}
+
+func NewItemImageUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, storage FileStorage, opts ...ItemImageUsecaseOption) ItemImageUsecase {
+	u := &itemImageUsecase{
+		itemRepo:         itemRepo,
+		imageRepo:        imageRepo,
+		storage:          storage,
+		thumbnailSlots:   make(chan struct{}, maxConcurrentThumbnails),
+		recognitionSlots: make(chan struct{}, maxConcurrentRecognitions),
+		async:            func(fn func()) { go fn() },
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *itemImageUsecase) Upload(ctx context.Context, itemID int64, input UploadImageInput) (*entity.ItemImage, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findWritable(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	// Content-Type ヘッダーは信用せず、先頭のバイト列から形式を判定する
+	body := bufio.NewReaderSize(io.LimitReader(input.Body, input.Size), 512)
+	head, _ := body.Peek(512)
+	image, err := entity.NewItemImage(itemID, input.FileName, http.DetectContentType(head), input.Size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	existing, err := u.imageRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve images: %w", err)
+	}
+	for _, e := range existing {
+		if e.Position >= image.Position {
+			image.Position = e.Position + 1
+		}
+	}
+
+	image.StorageKey, err = newImageStorageKey(itemID, image.Extension())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	if err := u.storage.Put(ctx, image.StorageKey, body); err != nil {
+		return nil, fmt.Errorf("failed to store image: %w", err)
+	}
+
+	created, err := u.imageRepo.Create(ctx, image)
+	if err != nil {
+		// 登録に失敗した画像はストレージに残さない
+		_ = u.storage.Delete(ctx, image.StorageKey)
+		return nil, fmt.Errorf("failed to create image: %w", err)
+	}
+
+	if u.thumbnailer != nil {
+		// リクエスト終了後も処理を続けるため、キャンセルを引き継がないコンテキストで実行する
+		target := *created
+		bgCtx := context.WithoutCancel(ctx)
+		u.async(func() {
+			u.thumbnailSlots <- struct{}{}
+			defer func() { <-u.thumbnailSlots }()
+			if err := u.generateThumbnails(bgCtx, &target); err != nil {
+				log.Printf("⚠️  サムネイルを生成できませんでした (image %d): %v", target.ID, err)
+			}
+		})
+	}
+	if u.recognizer != nil && u.documentIndex != nil {
+		// レシートや鑑定書の文字を検索できるよう、認識したテキストを保存する
+		target := *created
+		bgCtx := context.WithoutCancel(ctx)
+		u.async(func() {
+			u.recognitionSlots <- struct{}{}
+			defer func() { <-u.recognitionSlots }()
+			if err := u.indexDocumentText(bgCtx, &target); err != nil {
+				log.Printf("⚠️  画像の文字を検索用に保存できませんでした (image %d): %v", target.ID, err)
+			}
+		})
+	}
+
+	return withImageURL(created), nil
+}
+
+func (u *itemImageUsecase) List(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findItem(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	images, err := u.imageRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve images: %w", err)
+	}
+	for _, image := range images {
+		withImageURL(image)
+	}
+
+	return images, nil
+}
+
+func (u *itemImageUsecase) Open(ctx context.Context, itemID, imageID int64) (*entity.ItemImage, io.ReadCloser, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := u.findItem(ctx, actor, itemID); err != nil {
+		return nil, nil, err
+	}
+
+	image, err := u.imageRepo.FindByID(ctx, itemID, imageID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, nil, domainErrors.ErrItemImageNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to retrieve image: %w", err)
+	}
+
+	body, err := u.storage.Get(ctx, image.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	return withImageURL(image), body, nil
+}
+
+func (u *itemImageUsecase) OpenThumbnail(ctx context.Context, itemID, imageID int64, width int) (io.ReadCloser, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !entity.IsValidThumbnailWidth(width) {
+		return nil, fmt.Errorf("%w: unsupported thumbnail width", domainErrors.ErrInvalidInput)
+	}
+	if _, err := u.findItem(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	image, err := u.imageRepo.FindByID(ctx, itemID, imageID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemImageNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve image: %w", err)
+	}
+	// 生成前のサムネイルは存在しないものとして扱う（クライアントは元の画像を使う）
+	if !image.ThumbnailsReady {
+		return nil, domainErrors.ErrItemImageNotFound
+	}
+
+	body, err := u.storage.Get(ctx, image.ThumbnailKey(width))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read thumbnail: %w", err)
+	}
+
+	return body, nil
+}
+
+func (u *itemImageUsecase) Delete(ctx context.Context, itemID, imageID int64) error {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if _, err := u.findWritable(ctx, actor, itemID); err != nil {
+		return err
+	}
+
+	image, err := u.imageRepo.FindByID(ctx, itemID, imageID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemImageNotFound
+		}
+		return fmt.Errorf("failed to retrieve image: %w", err)
+	}
+
+	if err := u.imageRepo.Delete(ctx, itemID, imageID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemImageNotFound
+		}
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+
+	for _, key := range image.StorageKeys() {
+		if err := u.storage.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to delete stored image: %w", err)
+		}
+	}
+	if u.documentIndex != nil {
+		if err := u.documentIndex.Delete(ctx, itemID, imageID); err != nil {
+			return fmt.Errorf("failed to delete indexed text: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (u *itemImageUsecase) findItem(ctx context.Context, actor *entity.User, itemID int64) (*entity.Item, error) {
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := findItemForActor(ctx, u.itemRepo, actor, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return item, nil
+}
+
+// findWritable は findItem で取得したアイテムを変更できない場合に ErrForbidden を返す
+func (u *itemImageUsecase) findWritable(ctx context.Context, actor *entity.User, itemID int64) (*entity.Item, error) {
+	item, err := u.findItem(ctx, actor, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if !canWriteItem(actor, item) {
+		return nil, domainErrors.ErrForbidden
+	}
+	return item, nil
+}
+
+// withImageURL は画像とサムネイルを取得するためのパスを設定する
+func withImageURL(image *entity.ItemImage) *entity.ItemImage {
+	image.URL = fmt.Sprintf("/items/%d/images/%d", image.ItemID, image.ID)
+	image.Thumbnails = []entity.ImageThumbnail{}
+	if image.ThumbnailsReady {
+		for _, width := range entity.ThumbnailWidths {
+			image.Thumbnails = append(image.Thumbnails, entity.ImageThumbnail{
+				Width: width,
+				URL:   fmt.Sprintf("%s/thumbnails/%d", image.URL, width),
+			})
+		}
+	}
+	return image
+}
+
+// newImageStorageKey は推測されにくいストレージのキーを生成する
+func newImageStorageKey(itemID int64, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("items/%d/%s%s", itemID, hex.EncodeToString(b), ext), nil
+}
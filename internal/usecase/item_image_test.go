@@ -0,0 +1,417 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockItemImageRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemImageRepository) Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error) {
+	args := m.Called(ctx, image)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindByID(ctx context.Context, itemID, id int64) (*entity.ItemImage, error) {
+	args := m.Called(ctx, itemID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) FindCoversByItemIDs(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemImage, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]*entity.ItemImage), args.Error(1)
+}
+
+func (m *MockItemImageRepository) MarkThumbnailsReady(ctx context.Context, itemID, id int64) error {
+	args := m.Called(ctx, itemID, id)
+	return args.Error(0)
+}
+
+func (m *MockItemImageRepository) Delete(ctx context.Context, itemID, id int64) error {
+	args := m.Called(ctx, itemID, id)
+	return args.Error(0)
+}
+
+type MockThumbnailGenerator struct {
+	mock.Mock
+}
+
+func (m *MockThumbnailGenerator) Generate(src io.Reader, width int) ([]byte, error) {
+	body, _ := io.ReadAll(src)
+	args := m.Called(body, width)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+type MockFileStorage struct {
+	mock.Mock
+}
+
+func (m *MockFileStorage) Put(ctx context.Context, key string, r io.Reader) error {
+	body, _ := io.ReadAll(r)
+	args := m.Called(ctx, key, body)
+	return args.Error(0)
+}
+
+func (m *MockFileStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+func (m *MockFileStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+// PNGのシグネチャで始まる画像データ
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func newImageTestItem() *entity.Item {
+	item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	item.ID = 1
+	return item
+}
+
+func TestItemImageUsecase_Upload(t *testing.T) {
+	input := func(body []byte) UploadImageInput {
+		return UploadImageInput{FileName: `C:\photos\front.png`, Size: int64(len(body)), Body: bytes.NewReader(body)}
+	}
+
+	t.Run("正常系: 内容から形式を判定し、末尾の表示順で保存する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 1, Position: 0}, {ID: 2, Position: 3}}, nil)
+		var saved *entity.ItemImage
+		imageRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.ItemImage")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*entity.ItemImage) }).
+			Return(&entity.ItemImage{ID: 5, ItemID: 1, Position: 4}, nil)
+		storage := new(MockFileStorage)
+		storage.On("Put", mock.Anything, mock.AnythingOfType("string"), testPNG).Return(nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, storage)
+
+		image, err := usecase.Upload(actorContext(), 1, input(testPNG))
+		require.NoError(t, err)
+
+		assert.Equal(t, "/items/1/images/5", image.URL)
+		require.NotNil(t, saved)
+		assert.Equal(t, "front.png", saved.FileName)
+		assert.Equal(t, "image/png", saved.ContentType)
+		assert.Equal(t, 4, saved.Position)
+		assert.True(t, strings.HasPrefix(saved.StorageKey, "items/1/"))
+		assert.True(t, strings.HasSuffix(saved.StorageKey, ".png"))
+		storage.AssertCalled(t, "Put", mock.Anything, saved.StorageKey, testPNG)
+	})
+
+	t.Run("異常系: 画像以外のファイル", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		storage := new(MockFileStorage)
+		usecase := NewItemImageUsecase(itemRepo, new(MockItemImageRepository), storage)
+
+		_, err := usecase.Upload(actorContext(), 1, input([]byte("%PDF-1.4")))
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		storage.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 登録に失敗した場合は保存したファイルを削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{}, nil)
+		imageRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+		storage := new(MockFileStorage)
+		storage.On("Put", mock.Anything, mock.Anything, testPNG).Return(nil)
+		storage.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, storage)
+
+		_, err := usecase.Upload(actorContext(), 1, input(testPNG))
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテム", func(t *testing.T) {
+		other := newImageTestItem()
+		other.UserID = 99
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(other, nil)
+		usecase := NewItemImageUsecase(itemRepo, new(MockItemImageRepository), new(MockFileStorage))
+
+		_, err := usecase.Upload(actorContext(), 1, input(testPNG))
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("異常系: 閲覧者はアップロードできない", func(t *testing.T) {
+		viewer := &entity.User{ID: testActor.ID, Role: entity.RoleViewer}
+		usecase := NewItemImageUsecase(new(MockItemRepository), new(MockItemImageRepository), new(MockFileStorage))
+
+		_, err := usecase.Upload(WithActor(context.Background(), viewer), 1, input(testPNG))
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestItemImageUsecase_List(t *testing.T) {
+	t.Run("正常系: 取得用のURLを設定して返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 3, ItemID: 1}, {ID: 2, ItemID: 1, Position: 1}}, nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, new(MockFileStorage))
+
+		images, err := usecase.List(actorContext(), 1)
+		require.NoError(t, err)
+
+		require.Len(t, images, 2)
+		assert.Equal(t, "/items/1/images/3", images[0].URL)
+		assert.Equal(t, "/items/1/images/2", images[1].URL)
+	})
+}
+
+func TestItemImageUsecase_Open(t *testing.T) {
+	t.Run("正常系: ストレージから内容を返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(1), int64(2)).Return(&entity.ItemImage{ID: 2, ItemID: 1, StorageKey: "items/1/a.png"}, nil)
+		storage := new(MockFileStorage)
+		storage.On("Get", mock.Anything, "items/1/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, storage)
+
+		image, body, err := usecase.Open(actorContext(), 1, 2)
+		require.NoError(t, err)
+		defer body.Close()
+
+		assert.Equal(t, int64(2), image.ID)
+		got, _ := io.ReadAll(body)
+		assert.Equal(t, testPNG, got)
+	})
+
+	t.Run("異常系: 存在しない画像", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(1), int64(2)).Return(nil, domainErrors.ErrItemImageNotFound)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, new(MockFileStorage))
+
+		_, _, err := usecase.Open(actorContext(), 1, 2)
+		assert.ErrorIs(t, err, domainErrors.ErrItemImageNotFound)
+	})
+}
+
+func TestItemImageUsecase_Delete(t *testing.T) {
+	t.Run("正常系: レコードとファイルを削除する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(1), int64(2)).Return(&entity.ItemImage{ID: 2, ItemID: 1, StorageKey: "items/1/a.png"}, nil)
+		imageRepo.On("Delete", mock.Anything, int64(1), int64(2)).Return(nil)
+		storage := new(MockFileStorage)
+		storage.On("Delete", mock.Anything, "items/1/a.png").Return(nil)
+		storage.On("Delete", mock.Anything, "items/1/a_200.jpg").Return(nil)
+		storage.On("Delete", mock.Anything, "items/1/a_800.jpg").Return(nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, storage)
+
+		require.NoError(t, usecase.Delete(actorContext(), 1, 2))
+		imageRepo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("異常系: ファイルの削除に失敗", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(1), int64(2)).Return(&entity.ItemImage{ID: 2, ItemID: 1, StorageKey: "items/1/a.png"}, nil)
+		imageRepo.On("Delete", mock.Anything, int64(1), int64(2)).Return(nil)
+		storage := new(MockFileStorage)
+		storage.On("Delete", mock.Anything, "items/1/a.png").Return(errors.New("disk error"))
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, storage)
+
+		assert.Error(t, usecase.Delete(actorContext(), 1, 2))
+	})
+}
+
+func TestItemUsecase_DeleteItemRemovesImages(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+	itemRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+	imageRepo := new(MockItemImageRepository)
+	imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 2, StorageKey: "items/1/a.png"}}, nil)
+	storage := new(MockFileStorage)
+	storage.On("Delete", mock.Anything, "items/1/a.png").Return(nil)
+	storage.On("Delete", mock.Anything, "items/1/a_200.jpg").Return(nil)
+	storage.On("Delete", mock.Anything, "items/1/a_800.jpg").Return(nil)
+	usecase := NewItemUsecase(itemRepo, WithItemImages(imageRepo, storage))
+
+	require.NoError(t, usecase.DeleteItem(actorContext(), 1))
+	storage.AssertExpectations(t)
+}
+
+// newThumbnailTestUsecase はサムネイル生成を同期的に実行する ItemImageUsecase を作成する
+func newThumbnailTestUsecase(itemRepo ItemRepository, imageRepo ItemImageRepository, storage FileStorage, generator ThumbnailGenerator) *itemImageUsecase {
+	u := NewItemImageUsecase(itemRepo, imageRepo, storage, WithThumbnails(generator)).(*itemImageUsecase)
+	u.async = func(fn func()) { fn() }
+	return u
+}
+
+func TestItemImageUsecase_Thumbnails(t *testing.T) {
+	t.Run("正常系: アップロード後に各幅のサムネイルを保存する", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{}, nil)
+		imageRepo.On("Create", mock.Anything, mock.Anything).
+			Return(&entity.ItemImage{ID: 5, ItemID: 1, StorageKey: "items/1/a.png"}, nil)
+		imageRepo.On("MarkThumbnailsReady", mock.Anything, int64(1), int64(5)).Return(nil)
+		storage := new(MockFileStorage)
+		storage.On("Put", mock.Anything, mock.AnythingOfType("string"), testPNG).Return(nil)
+		storage.On("Get", mock.Anything, "items/1/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+		storage.On("Put", mock.Anything, "items/1/a_200.jpg", []byte("small")).Return(nil)
+		storage.On("Put", mock.Anything, "items/1/a_800.jpg", []byte("medium")).Return(nil)
+		generator := new(MockThumbnailGenerator)
+		generator.On("Generate", testPNG, 200).Return([]byte("small"), nil)
+		generator.On("Generate", testPNG, 800).Return([]byte("medium"), nil)
+		usecase := newThumbnailTestUsecase(itemRepo, imageRepo, storage, generator)
+
+		image, err := usecase.Upload(actorContext(), 1, UploadImageInput{FileName: "a.png", Size: int64(len(testPNG)), Body: bytes.NewReader(testPNG)})
+		require.NoError(t, err)
+
+		// レスポンスは生成前の状態
+		assert.Empty(t, image.Thumbnails)
+		storage.AssertExpectations(t)
+		imageRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 生成できない形式の場合はサムネイルなしのまま", func(t *testing.T) {
+		imageRepo := new(MockItemImageRepository)
+		storage := new(MockFileStorage)
+		storage.On("Get", mock.Anything, "items/1/a.webp").Return(io.NopCloser(bytes.NewReader([]byte("webp"))), nil)
+		generator := new(MockThumbnailGenerator)
+		generator.On("Generate", []byte("webp"), 200).Return(nil, errors.New("unsupported"))
+		usecase := newThumbnailTestUsecase(nil, imageRepo, storage, generator)
+
+		err := usecase.generateThumbnails(context.Background(), &entity.ItemImage{ID: 5, ItemID: 1, StorageKey: "items/1/a.webp"})
+		assert.ErrorContains(t, err, "failed to generate 200px thumbnail")
+		imageRepo.AssertNotCalled(t, "MarkThumbnailsReady", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 生成中に画像が削除された場合はサムネイルを削除する", func(t *testing.T) {
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("MarkThumbnailsReady", mock.Anything, int64(1), int64(5)).Return(domainErrors.ErrItemImageNotFound)
+		storage := new(MockFileStorage)
+		storage.On("Get", mock.Anything, "items/1/a.png").Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+		storage.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return(nil)
+		storage.On("Delete", mock.Anything, "items/1/a_200.jpg").Return(nil)
+		storage.On("Delete", mock.Anything, "items/1/a_800.jpg").Return(nil)
+		generator := new(MockThumbnailGenerator)
+		generator.On("Generate", testPNG, mock.Anything).Return([]byte("thumb"), nil)
+		usecase := newThumbnailTestUsecase(nil, imageRepo, storage, generator)
+
+		err := usecase.generateThumbnails(context.Background(), &entity.ItemImage{ID: 5, ItemID: 1, StorageKey: "items/1/a.png"})
+		assert.ErrorIs(t, err, domainErrors.ErrItemImageNotFound)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 生成済みの画像はサムネイルのURLを返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemImage{{ID: 3, ItemID: 1, ThumbnailsReady: true}}, nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, new(MockFileStorage))
+
+		images, err := usecase.List(actorContext(), 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, []entity.ImageThumbnail{
+			{Width: 200, URL: "/items/1/images/3/thumbnails/200"},
+			{Width: 800, URL: "/items/1/images/3/thumbnails/800"},
+		}, images[0].Thumbnails)
+	})
+}
+
+func TestItemImageUsecase_OpenThumbnail(t *testing.T) {
+	t.Run("正常系: サムネイルの内容を返す", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(1), int64(2)).
+			Return(&entity.ItemImage{ID: 2, ItemID: 1, StorageKey: "items/1/a.png", ThumbnailsReady: true}, nil)
+		storage := new(MockFileStorage)
+		storage.On("Get", mock.Anything, "items/1/a_200.jpg").Return(io.NopCloser(strings.NewReader("jpeg")), nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, storage)
+
+		body, err := usecase.OpenThumbnail(actorContext(), 1, 2, 200)
+		require.NoError(t, err)
+		defer body.Close()
+
+		got, _ := io.ReadAll(body)
+		assert.Equal(t, "jpeg", string(got))
+	})
+
+	t.Run("異常系: 生成前のサムネイル", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+		imageRepo := new(MockItemImageRepository)
+		imageRepo.On("FindByID", mock.Anything, int64(1), int64(2)).Return(&entity.ItemImage{ID: 2, ItemID: 1, StorageKey: "items/1/a.png"}, nil)
+		usecase := NewItemImageUsecase(itemRepo, imageRepo, new(MockFileStorage))
+
+		_, err := usecase.OpenThumbnail(actorContext(), 1, 2, 200)
+		assert.ErrorIs(t, err, domainErrors.ErrItemImageNotFound)
+	})
+
+	t.Run("異常系: 生成対象ではない幅", func(t *testing.T) {
+		usecase := NewItemImageUsecase(new(MockItemRepository), new(MockItemImageRepository), new(MockFileStorage))
+
+		_, err := usecase.OpenThumbnail(actorContext(), 1, 2, 300)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_GetItemByIDAttachesThumbnails(t *testing.T) {
+	itemRepo := new(MockItemRepository)
+	itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+	imageRepo := new(MockItemImageRepository)
+	imageRepo.On("FindCoversByItemIDs", mock.Anything, []int64{1}).
+		Return(map[int64]*entity.ItemImage{1: {ID: 3, ItemID: 1, ThumbnailsReady: true}}, nil)
+	usecase := NewItemUsecase(itemRepo, WithItemImages(imageRepo, new(MockFileStorage)))
+
+	item, err := usecase.GetItemByID(actorContext(), 1)
+	require.NoError(t, err)
+
+	require.Len(t, item.Thumbnails, 2)
+	assert.Equal(t, "/items/1/images/3/thumbnails/200", item.Thumbnails[0].URL)
+}
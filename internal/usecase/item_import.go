@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+const (
+	// itemImportChunkSize は1つのトランザクションで登録する行数
+	itemImportChunkSize = 500
+	// maxItemImportRowErrors は結果に含める取り込めなかった行の最大件数
+	maxItemImportRowErrors = 100
+)
+
+// itemImportColumns は取り込むCSVの列（ヘッダーの名前。ほかの列は無視する）
+var itemImportColumns = []string{"name", "category", "brand", "purchase_price", "purchase_currency", "purchase_date", "visibility", "condition", "serial_number", "notes"}
+
+// itemImportRequiredColumns はCSVに必須の列（brand はカテゴリーの既定値を使えるため省略できる）
+var itemImportRequiredColumns = []string{"name", "category", "purchase_price", "purchase_date"}
+
+// utf8BOM は Excel などが書き出すCSVの先頭に付く BOM
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// ItemImportUsecase は他のシステムから書き出したCSVのアイテムを操作者のアイテムとして取り込む。
+// CSVは1行ずつ読み込み、itemImportChunkSize 行ごとに1つのトランザクションで登録するため、
+// ファイル全体をメモリに読み込まずに大きなファイルを取り込める
+type ItemImportUsecase interface {
+	// Import はCSVを取り込む。不正な行は登録せずに結果に含め、残りの行の取り込みを続ける。
+	// 登録に失敗した場合は、それまでのトランザクションで登録した行は取り消さない。
+	// ジョブとして実行する構成では、同じユーザーのジョブが実行中の場合は JobConflictError を返す
+	Import(ctx context.Context, r io.Reader) (*ItemImportResult, error)
+}
+
+// ItemImportResult は取り込みの結果
+type ItemImportResult struct {
+	Imported int `json:"imported"`
+	Failed   int `json:"failed"`
+	// Errors は取り込めなかった行（先頭から最大 maxItemImportRowErrors 件）
+	Errors []ItemImportRowError `json:"errors"`
+}
+
+// ItemImportRowError は取り込めなかった行とその理由
+type ItemImportRowError struct {
+	// Row はCSVの行番号（ヘッダーが1行目）
+	Row    int                           `json:"row"`
+	Errors domainErrors.ValidationErrors `json:"errors"`
+}
+
+type itemImportUsecase struct {
+	itemRepo    ItemRepository
+	historyRepo ItemHistoryRepository
+	jobs        JobUsecase
+	now         func() time.Time
+}
+
+// ItemImportUsecaseOption は ItemImportUsecase の設定を変更する
+type ItemImportUsecaseOption func(*itemImportUsecase)
+
+// WithImportHistory は取り込んだアイテムの登録を、登録と同じトランザクションで変更履歴に記録するよう設定する
+func WithImportHistory(historyRepo ItemHistoryRepository) ItemImportUsecaseOption {
+	return func(u *itemImportUsecase) {
+		u.historyRepo = historyRepo
+	}
+}
+
+// WithImportJobs は取り込みをジョブとして実行するよう設定する。
+// 同じユーザーのエクスポート・取り込み・レポートと同時には実行せず、実行中は GET /jobs/{id} で確認できる
+func WithImportJobs(jobs JobUsecase) ItemImportUsecaseOption {
+	return func(u *itemImportUsecase) {
+		u.jobs = jobs
+	}
+}
+
+func NewItemImportUsecase(itemRepo ItemRepository, opts ...ItemImportUsecaseOption) ItemImportUsecase {
+	u := &itemImportUsecase{
+		itemRepo: itemRepo,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// importRow は登録を待つ行
+type importRow struct {
+	line      int
+	item      *entity.Item
+	histories []*entity.ItemHistory
+}
+
+func (u *itemImportUsecase) Import(ctx context.Context, r io.Reader) (*ItemImportResult, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.jobs == nil {
+		return u.importCSV(ctx, actor, r)
+	}
+
+	// リクエストボディはリクエストの間しか読み込めないため、ジョブの終了を待って結果を返す
+	var result *ItemImportResult
+	var importErr error
+	done := make(chan struct{})
+	job, err := u.jobs.Submit(ctx, strconv.FormatInt(actor.ID, 10), entity.JobKindImport, func(ctx context.Context, job *entity.Job) error {
+		defer close(done)
+		result, importErr = u.importCSV(ctx, actor, r)
+		return importErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	<-done
+	if result == nil && importErr == nil {
+		return nil, fmt.Errorf("import job %d did not finish", job.ID)
+	}
+	return result, importErr
+}
+
+// importCSV はCSVを読み込み、操作者のアイテムとして登録する
+func (u *itemImportUsecase) importCSV(ctx context.Context, actor *entity.User, r io.Reader) (*ItemImportResult, error) {
+	reader := csv.NewReader(skipBOM(r))
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: CSV is empty", domainErrors.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("%w: failed to read CSV header: %w", domainErrors.ErrInvalidInput, err)
+	}
+	columns, err := importColumnIndexes(header)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ItemImportResult{Errors: []ItemImportRowError{}}
+	chunk := make([]importRow, 0, itemImportChunkSize)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				// 圧縮の誤りや切断、上限を超えたサイズでリクエストボディを読めない場合も入力の誤りにする（元のエラーも判別できるようにする）
+				return result, fmt.Errorf("%w: failed to read CSV after %d imported rows: %w", domainErrors.ErrInvalidInput, result.Imported, err)
+			}
+			// 列数の違いなど、その行だけの誤りは行のエラーにして続ける
+			if parseErr.Err != csv.ErrFieldCount {
+				return result, fmt.Errorf("%w: invalid CSV at line %d: %s", domainErrors.ErrInvalidInput, parseErr.Line, parseErr.Err.Error())
+			}
+			result.addError(parseErr.StartLine, domainErrors.ValidationErrors{{Code: domainErrors.CodeInvalidFormat, Message: "number of fields does not match the header"}})
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		input, err := importInput(record, columns)
+		if err != nil {
+			result.addError(line, rowErrors(err))
+			continue
+		}
+		item, err := newItemFromInput(actor, input)
+		if err != nil {
+			result.addError(line, rowErrors(err))
+			continue
+		}
+		// 変更履歴には前後の空白を除く前のCSVの値を送信された値として記録する
+		histories := entity.NewItemHistories(actor.ID, nil, item, u.now())
+		entity.SetRawInputs(histories, importRawValue(record, columns, "name"), importRawValue(record, columns, "brand"))
+		chunk = append(chunk, importRow{line: line, item: item, histories: histories})
+
+		if len(chunk) == itemImportChunkSize {
+			if err := u.flush(ctx, chunk, result); err != nil {
+				return result, err
+			}
+			chunk = chunk[:0]
+		}
+	}
+	if err := u.flush(ctx, chunk, result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// flush は行を1つのトランザクションで登録する。
+// 保存できない行（シリアル番号の重複など）を含む場合は、1行ずつ登録し直してその行だけを結果のエラーにする
+func (u *itemImportUsecase) flush(ctx context.Context, rows []importRow, result *ItemImportResult) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	err := u.createMany(ctx, rows)
+	if err == nil {
+		result.Imported += len(rows)
+		return nil
+	}
+	if !domainErrors.IsValidationError(err) && !domainErrors.IsDuplicateSerialNumberError(err) {
+		return fmt.Errorf("failed to import items after %d imported rows: %w", result.Imported, err)
+	}
+
+	for _, row := range rows {
+		if err := u.create(ctx, row); err != nil {
+			if !domainErrors.IsValidationError(err) && !domainErrors.IsDuplicateSerialNumberError(err) {
+				return fmt.Errorf("failed to import items after %d imported rows: %w", result.Imported, err)
+			}
+			result.addError(row.line, rowErrors(err))
+			continue
+		}
+		result.Imported++
+	}
+	return nil
+}
+
+// createMany は行のアイテムを1つのトランザクションで登録し、同じトランザクションで登録を変更履歴に記録する
+// （変更履歴を扱わない構成では登録のみ行う）
+func (u *itemImportUsecase) createMany(ctx context.Context, rows []importRow) error {
+	items := make([]*entity.Item, len(rows))
+	histories := make([][]*entity.ItemHistory, len(rows))
+	for i, row := range rows {
+		items[i] = row.item
+		histories[i] = row.histories
+	}
+	if u.historyRepo == nil {
+		return u.itemRepo.CreateMany(ctx, items)
+	}
+	return u.historyRepo.RecordCreateMany(ctx, items, histories)
+}
+
+// create は1行のアイテムを登録し、同じトランザクションで登録を変更履歴に記録する
+func (u *itemImportUsecase) create(ctx context.Context, row importRow) error {
+	var err error
+	if u.historyRepo == nil {
+		_, err = u.itemRepo.Create(ctx, row.item)
+	} else {
+		_, err = u.historyRepo.RecordCreate(ctx, row.item, row.histories)
+	}
+	return err
+}
+
+func (r *ItemImportResult) addError(line int, errs domainErrors.ValidationErrors) {
+	r.Failed++
+	if len(r.Errors) < maxItemImportRowErrors {
+		r.Errors = append(r.Errors, ItemImportRowError{Row: line, Errors: errs})
+	}
+}
+
+// rowErrors は行の登録エラーを項目ごとのエラーにする
+func rowErrors(err error) domainErrors.ValidationErrors {
+	if errs, ok := domainErrors.AsValidationErrors(err); ok {
+		return errs
+	}
+	if domainErrors.IsDuplicateSerialNumberError(err) {
+		return domainErrors.ValidationErrors{{Field: "serial_number", Code: domainErrors.CodeInvalid, Message: err.Error()}}
+	}
+	return domainErrors.ValidationErrors{{Code: domainErrors.CodeInvalid, Message: err.Error()}}
+}
+
+// importColumnIndexes はヘッダーから取り込む列の位置を求める（必須の列がない場合は ErrInvalidInput）
+func importColumnIndexes(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for _, column := range itemImportColumns {
+			if name == column {
+				if _, dup := columns[name]; !dup {
+					columns[name] = i
+				}
+			}
+		}
+	}
+
+	var missing []string
+	for _, column := range itemImportRequiredColumns {
+		if _, ok := columns[column]; !ok {
+			missing = append(missing, column)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: CSV header is missing required columns: %s", domainErrors.ErrInvalidInput, strings.Join(missing, ", "))
+	}
+	return columns, nil
+}
+
+// importInput はCSVの1行を登録内容にする
+func importInput(record []string, columns map[string]int) (CreateItemInput, error) {
+	value := func(column string) string {
+		if i, ok := columns[column]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	input := CreateItemInput{
+		Name:             value("name"),
+		Category:         value("category"),
+		Brand:            value("brand"),
+		PurchaseCurrency: value("purchase_currency"),
+		PurchaseDate:     value("purchase_date"),
+		Visibility:       value("visibility"),
+		Condition:        value("condition"),
+		SerialNumber:     value("serial_number"),
+		Notes:            value("notes"),
+	}
+	price, err := entity.ParseDecimal(value("purchase_price"))
+	if err != nil {
+		var errs domainErrors.ValidationErrors
+		errs.Add("purchase_price", domainErrors.CodeInvalidFormat, "purchase_price must be a decimal number")
+		return input, errs
+	}
+	input.PurchasePrice = price
+	return input, nil
+}
+
+// importRawValue はCSVの列の前後の空白を除く前の値を返す（列がないか空の場合は nil）
+func importRawValue(record []string, columns map[string]int, column string) *string {
+	i, ok := columns[column]
+	if !ok || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+		return nil
+	}
+	value := record[i]
+	return &value
+}
+
+// skipBOM は先頭の UTF-8 の BOM を除いた読み込み元を返す
+func skipBOM(r io.Reader) io.Reader {
+	br := bufio.NewReader(r)
+	if prefix, err := br.Peek(len(utf8BOM)); err == nil && bytes.Equal(prefix, utf8BOM) {
+		_, _ = br.Discard(len(utf8BOM))
+	}
+	return br
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemImportUsecase_Import(t *testing.T) {
+	t.Run("正常系: ヘッダーの列名で読み込み、不正な行は結果に含めて続ける", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+			return len(items) == 2 &&
+				items[0].Name == "ロレックス デイトナ" && items[0].PurchasePrice == entity.JPY(1500000) && items[0].UserID == testActor.ID &&
+				items[1].Category == "バッグ" && items[1].PurchasePrice == entity.Money{Amount: 12345, Currency: entity.CurrencyUSD}
+		})).Return(nil)
+
+		csv := "\xef\xbb\xbfpurchase_date,Name,category,brand,purchase_price,purchase_currency,memo\n" +
+			"2023-01-15,ロレックス デイトナ,時計,ROLEX,1500000,,無視する列\n" +
+			"2023-01-15,価格なし,時計,ROLEX,abc,,\n" +
+			"2023-02-01,\"バーキン, 30\",bag,HERMES,123.45,USD,\n" +
+			"2023-02-01,列が足りない\n"
+		result, err := NewItemImportUsecase(mockRepo).Import(actorContext(), strings.NewReader(csv))
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Imported)
+		assert.Equal(t, 2, result.Failed)
+		require.Len(t, result.Errors, 2)
+		assert.Equal(t, 3, result.Errors[0].Row)
+		assert.Equal(t, "purchase_price", result.Errors[0].Errors[0].Field)
+		assert.Equal(t, 5, result.Errors[1].Row)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 500行ごとに1つのトランザクションで登録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool { return len(items) == itemImportChunkSize })).Return(nil).Twice()
+		mockRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool { return len(items) == 1 })).Return(nil).Once()
+
+		var b strings.Builder
+		b.WriteString("name,category,brand,purchase_price,purchase_date\n")
+		for i := 0; i < itemImportChunkSize*2+1; i++ {
+			fmt.Fprintf(&b, "アイテム%d,時計,SEIKO,10000,2023-01-15\n", i)
+		}
+		result, err := NewItemImportUsecase(mockRepo).Import(actorContext(), strings.NewReader(b.String()))
+		require.NoError(t, err)
+		assert.Equal(t, itemImportChunkSize*2+1, result.Imported)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 保存できない行を含むトランザクションは1行ずつ登録し直す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(domainErrors.ErrDuplicateSerialNumber)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Name == "1本目" })).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.Name == "2本目" })).Return(nil, domainErrors.ErrDuplicateSerialNumber)
+
+		csv := "name,category,brand,purchase_price,purchase_date,serial_number\n" +
+			"1本目,時計,ROLEX,10000,2023-01-15,ABC123\n" +
+			"2本目,時計,ROLEX,10000,2023-01-15,ABC123\n"
+		result, err := NewItemImportUsecase(mockRepo).Import(actorContext(), strings.NewReader(csv))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Imported)
+		require.Len(t, result.Errors, 1)
+		assert.Equal(t, 3, result.Errors[0].Row)
+		assert.Equal(t, "serial_number", result.Errors[0].Errors[0].Field)
+	})
+
+	t.Run("正常系: 登録と同じトランザクションで登録を変更履歴に記録し、空白を除く前の名前も記録する", func(t *testing.T) {
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("RecordCreateMany", mock.Anything, mock.Anything, mock.MatchedBy(func(histories [][]*entity.ItemHistory) bool {
+			if len(histories) != 1 {
+				return false
+			}
+			for _, h := range histories[0] {
+				if h.Action != entity.ItemHistoryActionCreate || h.ActorID != testActor.ID {
+					return false
+				}
+				if h.Field == "name" && (h.RawValue == nil || *h.RawValue != " デイトナ ") {
+					return false
+				}
+				// 正規化した値と同じブランドは記録しない
+				if h.Field == "brand" && h.RawValue != nil {
+					return false
+				}
+			}
+			return true
+		})).Return(nil)
+
+		csv := "name,category,brand,purchase_price,purchase_date\n" +
+			"\" デイトナ \",時計,ROLEX,1500000,2023-01-15\n"
+		result, err := NewItemImportUsecase(new(MockItemRepository), WithImportHistory(historyRepo)).Import(actorContext(), strings.NewReader(csv))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Imported)
+		historyRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 必須の列がない", func(t *testing.T) {
+		_, err := NewItemImportUsecase(new(MockItemRepository)).Import(actorContext(), strings.NewReader("name,brand\nデイトナ,ROLEX\n"))
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "category, purchase_price, purchase_date")
+	})
+
+	t.Run("異常系: 登録に失敗した場合はそれまでの件数を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(domainErrors.ErrDatabaseError)
+
+		result, err := NewItemImportUsecase(mockRepo).Import(actorContext(), strings.NewReader("name,category,brand,purchase_price,purchase_date\nデイトナ,時計,ROLEX,1500000,2023-01-15\n"))
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 0, result.Imported)
+	})
+
+	t.Run("異常系: 閲覧者は取り込めない", func(t *testing.T) {
+		viewer := WithActor(context.Background(), &entity.User{ID: testActor.ID, Role: entity.RoleViewer})
+		_, err := NewItemImportUsecase(new(MockItemRepository)).Import(viewer, strings.NewReader(""))
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+
+	t.Run("正常系: ジョブとして実行し、終了を待って結果を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil)
+		jobs := NewJobUsecase()
+
+		result, err := NewItemImportUsecase(mockRepo, WithImportJobs(jobs)).Import(actorContext(), strings.NewReader("name,category,brand,purchase_price,purchase_date\nデイトナ,時計,ROLEX,1500000,2023-01-15\n"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Imported)
+
+		job := waitForJob(t, jobs, strconv.FormatInt(testActor.ID, 10), 1)
+		assert.Equal(t, entity.JobKindImport, job.Kind)
+		assert.Equal(t, entity.JobStatusSucceeded, job.Status)
+	})
+
+	t.Run("異常系: 同じユーザーのエクスポートなどが実行中の場合は読み込まずに競合", func(t *testing.T) {
+		jobs := NewJobUsecase()
+		block := make(chan struct{})
+		defer close(block)
+		running, err := jobs.Submit(actorContext(), strconv.FormatInt(testActor.ID, 10), entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			<-block
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = NewItemImportUsecase(new(MockItemRepository), WithImportJobs(jobs)).Import(actorContext(), strings.NewReader("name,category,brand,purchase_price,purchase_date\n"))
+		var conflict *domainErrors.JobConflictError
+		require.ErrorAs(t, err, &conflict)
+		assert.Equal(t, running.ID, conflict.JobID)
+	})
+}
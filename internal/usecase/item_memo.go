@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// ItemMemoUsecase はアイテムに残す自分用のメモを扱う。
+// アイテムを参照できるユーザーは誰でもメモを残せるが、メモは書いたユーザーにだけ返す
+type ItemMemoUsecase interface {
+	// List は操作者がアイテムに残したメモを新しい順に返す
+	List(ctx context.Context, itemID int64) ([]*entity.ItemMemo, error)
+	Create(ctx context.Context, itemID int64, input ItemMemoInput) (*entity.ItemMemo, error)
+}
+
+type ItemMemoInput struct {
+	Body string `json:"body"`
+}
+
+type itemMemoUsecase struct {
+	memoRepo ItemMemoRepository
+	itemRepo ItemRepository
+	now      func() time.Time
+}
+
+func NewItemMemoUsecase(memoRepo ItemMemoRepository, itemRepo ItemRepository) ItemMemoUsecase {
+	return &itemMemoUsecase{
+		memoRepo: memoRepo,
+		itemRepo: itemRepo,
+		now:      time.Now,
+	}
+}
+
+func (u *itemMemoUsecase) List(ctx context.Context, itemID int64) ([]*entity.ItemMemo, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.findItem(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	memos, err := u.memoRepo.FindByItemID(ctx, itemID, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve memos: %w", err)
+	}
+
+	return memos, nil
+}
+
+func (u *itemMemoUsecase) Create(ctx context.Context, itemID int64, input ItemMemoInput) (*entity.ItemMemo, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.findItem(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	memo, err := entity.NewItemMemo(itemID, actor.ID, input.Body, u.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+
+	created, err := u.memoRepo.Create(ctx, memo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create memo: %w", err)
+	}
+
+	return created, nil
+}
+
+// findItem は操作者がアイテムを参照できることを確かめる（参照できないアイテムは ErrItemNotFound）
+func (u *itemMemoUsecase) findItem(ctx context.Context, actor *entity.User, itemID int64) error {
+	if itemID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if _, err := findItemForActor(ctx, u.itemRepo, actor, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return nil
+}
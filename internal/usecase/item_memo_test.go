@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockItemMemoRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemMemoRepository) Create(ctx context.Context, memo *entity.ItemMemo) (*entity.ItemMemo, error) {
+	args := m.Called(ctx, memo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemMemo), args.Error(1)
+}
+
+func (m *MockItemMemoRepository) FindByItemID(ctx context.Context, itemID, userID int64) ([]*entity.ItemMemo, error) {
+	args := m.Called(ctx, itemID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemMemo), args.Error(1)
+}
+
+func newItemMemoTestUsecase() (*itemMemoUsecase, *MockItemMemoRepository) {
+	memos := new(MockItemMemoRepository)
+	items := new(MockItemRepository)
+	items.On("FindByID", mock.Anything, int64(1)).Return(newImageTestItem(), nil)
+	u := NewItemMemoUsecase(memos, items).(*itemMemoUsecase)
+	u.now = func() time.Time { return time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC) }
+	return u, memos
+}
+
+func TestItemMemoUsecase_Create(t *testing.T) {
+	t.Run("正常系: 操作者のメモとして日時を付けて保存する", func(t *testing.T) {
+		usecase, memos := newItemMemoTestUsecase()
+		var created *entity.ItemMemo
+		memos.On("Create", mock.Anything, mock.AnythingOfType("*entity.ItemMemo")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*entity.ItemMemo) }).
+			Return(&entity.ItemMemo{ID: 10, ItemID: 1, UserID: testActor.ID, Body: "ベルトにひびが入り始めた"}, nil)
+
+		memo, err := usecase.Create(actorContext(), 1, ItemMemoInput{Body: " ベルトにひびが入り始めた "})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(10), memo.ID)
+		assert.Equal(t, &entity.ItemMemo{
+			ItemID: 1, UserID: testActor.ID, Body: "ベルトにひびが入り始めた", CreatedAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+		}, created)
+	})
+
+	t.Run("異常系: 長すぎるメモ", func(t *testing.T) {
+		usecase, memos := newItemMemoTestUsecase()
+
+		_, err := usecase.Create(actorContext(), 1, ItemMemoInput{Body: strings.Repeat("あ", entity.MaxItemMemoLength+1)})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		_, ok := domainErrors.AsValidationErrors(err)
+		assert.True(t, ok)
+		memos.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 参照できないアイテム", func(t *testing.T) {
+		usecase, memos := newItemMemoTestUsecase()
+		stranger := &entity.User{ID: 3, Email: "other@example.com", Role: entity.RoleEditor}
+
+		_, err := usecase.Create(WithActor(context.Background(), stranger), 1, ItemMemoInput{Body: "メモ"})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		memos.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemMemoUsecase_List(t *testing.T) {
+	t.Run("正常系: 操作者が書いたメモだけを返す", func(t *testing.T) {
+		usecase, memos := newItemMemoTestUsecase()
+		memos.On("FindByItemID", mock.Anything, int64(1), testActor.ID).
+			Return([]*entity.ItemMemo{{ID: 11}, {ID: 10}}, nil)
+
+		list, err := usecase.List(actorContext(), 1)
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+		memos.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 不正なアイテムID", func(t *testing.T) {
+		usecase, memos := newItemMemoTestUsecase()
+
+		_, err := usecase.List(actorContext(), 0)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		memos.AssertNotCalled(t, "FindByItemID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"slices"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// redactItems は操作者の組織での役割に応じて、返すアイテムの項目を非表示にする（entity.RedactedItemFields）。
+// 保存と変更履歴の記録を終えた後、操作者に返す直前に呼び出す
+func redactItems(actor *entity.User, items ...*entity.Item) {
+	for _, item := range items {
+		item.RedactFor(actor)
+	}
+}
+
+// hideRedactedMatches は非表示の項目での絞り込みと並べ替えの結果から値を推測できないよう、
+// その項目で絞り込んだ場合は非表示のアイテムを除き、並べ替えた場合は末尾にまとめる（redactItems の後に呼び出す）
+func hideRedactedMatches(filter entity.ItemFilter, items []*entity.Item) []*entity.Item {
+	priceFiltered := filter.MinPurchasePrice != nil || filter.MaxPurchasePrice != nil
+	dateFiltered := filter.PurchaseDateFrom != "" || filter.PurchaseDateTo != ""
+	items = slices.DeleteFunc(items, func(item *entity.Item) bool {
+		return (priceFiltered && item.IsRedacted(entity.ItemFieldPurchasePrice)) ||
+			(dateFiltered && item.IsRedacted(entity.ItemFieldPurchaseDate))
+	})
+
+	switch field := filter.Sort.Key; field {
+	case entity.SortKeyPurchasePrice, entity.SortKeyPurchaseDate:
+		slices.SortStableFunc(items, func(a, b *entity.Item) int {
+			switch ar, br := a.IsRedacted(field), b.IsRedacted(field); {
+			case ar == br:
+				return 0
+			case ar:
+				return 1
+			}
+			return -1
+		})
+	}
+	return items
+}
+
+// redactHistories は非表示にする項目の変更履歴を除く（値の変更前後から非表示の値がわかるため）
+func redactHistories(actor *entity.User, item *entity.Item, histories []*entity.ItemHistory) []*entity.ItemHistory {
+	redacted := entity.ItemRedactionFor(actor, item.OrgID)
+	if len(redacted) == 0 {
+		return histories
+	}
+	return slices.DeleteFunc(histories, func(history *entity.ItemHistory) bool {
+		return slices.Contains(redacted, history.Field)
+	})
+}
+
+// requireUnredacted は fields のいずれかが操作者に非表示の場合に ErrForbidden を返す
+// （非表示の項目の変更や、価格の推移・証明書など項目を除いて返せない機能に使う）
+func requireUnredacted(actor *entity.User, item *entity.Item, fields ...string) error {
+	for _, field := range entity.ItemRedactionFor(actor, item.OrgID) {
+		if slices.Contains(fields, field) {
+			return domainErrors.ErrForbidden
+		}
+	}
+	return nil
+}
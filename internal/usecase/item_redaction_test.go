@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// redactionOrgID は非表示の項目を確認する組織
+const redactionOrgID = 10
+
+// 非表示にされるべき購入価格（JSON に含まれないことを文字列で確認する）
+const redactedPrice = 1234567
+
+var (
+	orgViewer     = &entity.User{ID: 3, Role: entity.RoleEditor, Memberships: []entity.Membership{{OrganizationID: redactionOrgID, UserID: 3, Role: entity.OrgRoleViewer}}}
+	orgItemEditor = &entity.User{ID: 4, Role: entity.RoleEditor, Memberships: []entity.Membership{{OrganizationID: redactionOrgID, UserID: 4, Role: entity.OrgRoleEditor}}}
+)
+
+// newOrgItem は組織のアイテムを作成する（非表示にすると値が消えるため、呼び出しごとに新しいアイテムを返す）
+func newOrgItem() *entity.Item {
+	return &entity.Item{
+		ID: 1, UserID: 2, OrgID: redactionOrgID, Name: "デイトナ", Category: "時計", Brand: "ROLEX",
+		PurchasePrice: entity.JPY(redactedPrice), PurchaseDate: "2023-01-15", SerialNumber: "Z123456",
+		Visibility: entity.VisibilityPrivate, Version: 1,
+	}
+}
+
+// withRedaction はテストの間だけ役割ごとの非表示の項目を変更する
+func withRedaction(t *testing.T, redaction map[entity.OrgRole][]string) {
+	t.Helper()
+	original := entity.RedactedItemFields
+	entity.RedactedItemFields = redaction
+	t.Cleanup(func() { entity.RedactedItemFields = original })
+}
+
+// assertNoPriceLeak はレスポンスの JSON に購入価格が含まれないことを確認する
+func assertNoPriceLeak(t *testing.T, v any) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "1234567")
+	assert.Contains(t, string(data), `"purchase_price":null`)
+	assert.Contains(t, string(data), `"redacted_fields":["purchase_price"]`)
+}
+
+func TestItemUsecase_Redaction(t *testing.T) {
+	ctx := WithActor(context.Background(), orgViewer)
+
+	t.Run("一覧・詳細・検索・最近表示したアイテムで購入価格を返さない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		viewRepo := new(MockItemViewRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{newOrgItem()}, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		mockRepo.On("Search", mock.Anything, "デイトナ", orgViewer.ID).Return([]*entity.SearchResult{{Item: newOrgItem()}}, nil)
+		viewRepo.On("Record", mock.Anything, orgViewer.ID, int64(1), mock.Anything, maxRecentlyViewedItems).Return(nil)
+		viewRepo.On("FindRecentItems", mock.Anything, orgViewer.ID, orgViewer.ID, maxRecentlyViewedItems).
+			Return([]*entity.RecentlyViewedItem{{Item: newOrgItem()}}, nil)
+		usecase := NewItemUsecase(mockRepo, WithRecentlyViewed(viewRepo))
+
+		items, err := usecase.GetAllItems(ctx, entity.ItemFilter{})
+		require.NoError(t, err)
+		assertNoPriceLeak(t, items)
+		assert.Equal(t, "2023-01-15", items[0].PurchaseDate)
+
+		item, err := usecase.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		assertNoPriceLeak(t, item)
+
+		results, err := usecase.SearchItems(ctx, "デイトナ")
+		require.NoError(t, err)
+		assertNoPriceLeak(t, results)
+
+		views, err := usecase.GetRecentlyViewedItems(ctx)
+		require.NoError(t, err)
+		assertNoPriceLeak(t, views)
+	})
+
+	t.Run("購入価格での絞り込みと並べ替えから値を推測させない", func(t *testing.T) {
+		personal := newOrgItem()
+		personal.ID, personal.OrgID, personal.UserID = 2, 0, orgViewer.ID
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(f entity.ItemFilter) bool { return f.MinPurchasePrice != nil })).
+			Return([]*entity.Item{newOrgItem(), personal}, nil)
+		mockRepo.On("FindAll", mock.Anything, mock.MatchedBy(func(f entity.ItemFilter) bool { return f.MinPurchasePrice == nil })).
+			Return([]*entity.Item{newOrgItem(), personal}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		minPrice := 1000000
+		items, err := usecase.GetAllItems(ctx, entity.ItemFilter{MinPurchasePrice: &minPrice})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, int64(2), items[0].ID)
+
+		items, err = usecase.GetAllItems(ctx, entity.ItemFilter{Sort: entity.ItemSort{Key: entity.SortKeyPurchasePrice}})
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, []int64{2, 1}, []int64{items[0].ID, items[1].ID})
+	})
+
+	t.Run("個人のアイテムと管理者には適用しない", func(t *testing.T) {
+		personal := newOrgItem()
+		personal.OrgID = 0
+		personal.UserID = orgViewer.ID
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(personal, nil).Once()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil).Once()
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, redactedPrice, item.PurchasePrice.Amount)
+		assert.Empty(t, item.RedactedFields())
+
+		admin := WithActor(context.Background(), &entity.User{ID: 9, Role: entity.RoleAdmin})
+		item, err = usecase.GetItemByID(admin, 1)
+		require.NoError(t, err)
+		assert.Equal(t, redactedPrice, item.PurchasePrice.Amount)
+	})
+
+	t.Run("更新の結果で非表示にし、保存する値と変更履歴には影響しない", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleEditor: {entity.ItemFieldPurchasePrice}})
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		updated := newOrgItem()
+		updated.Name = "デイトナ 116500LN"
+		historyRepo.On("RecordUpdate", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchasePrice.Amount == redactedPrice && item.Name == "デイトナ 116500LN"
+		}), mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			return len(histories) == 1 && histories[0].Field == "name"
+		})).Return(updated, nil)
+		usecase := NewItemUsecase(mockRepo, WithItemHistory(historyRepo))
+
+		name := "デイトナ 116500LN"
+		item, err := usecase.UpdateItem(WithActor(context.Background(), orgItemEditor), 1, UpdateItemInput{Name: &name})
+		require.NoError(t, err)
+		assertNoPriceLeak(t, item)
+		historyRepo.AssertExpectations(t)
+	})
+
+	t.Run("非表示の項目の変更と置き換えは 403", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleEditor: {entity.ItemFieldPurchasePrice}})
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		usecase := NewItemUsecase(mockRepo)
+		editorCtx := WithActor(context.Background(), orgItemEditor)
+
+		price := entity.Decimal{Units: 1}
+		_, err := usecase.UpdateItem(editorCtx, 1, UpdateItemInput{PurchasePrice: &price})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+
+		_, err = usecase.ReplaceItem(editorCtx, 1, ReplaceItemInput{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: price, PurchaseDate: "2023-01-15"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("変更履歴から非表示の項目の変更を除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		historyRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemHistory{
+			priceChange("1000000", "1234567", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+			{ItemID: 1, Action: entity.ItemHistoryActionUpdate, Field: "name"},
+		}, nil)
+		usecase := NewItemUsecase(mockRepo, WithItemHistory(historyRepo))
+
+		histories, err := usecase.GetItemHistory(ctx, 1)
+		require.NoError(t, err)
+		require.Len(t, histories, 1)
+		assert.Equal(t, "name", histories[0].Field)
+	})
+
+	t.Run("集計では非表示の組織のアイテムを件数のみ数える", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, orgViewer.ID).Return([]entity.CategoryValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, OrgID: redactionOrgID, Count: 1, Value: redactedPrice},
+			{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 300000},
+		}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		summary, err := usecase.GetCategorySummary(ctx, "")
+		require.NoError(t, err)
+		assert.Equal(t, 3, summary.Total)
+		assert.Equal(t, 3, summary.Categories["時計"])
+		assert.Equal(t, int64(300000), summary.TotalValue)
+		assert.Equal(t, int64(300000), summary.Values["時計"])
+	})
+}
+
+func TestExportUsecase_Redaction(t *testing.T) {
+	ctx := WithActor(context.Background(), orgViewer)
+
+	t.Run("エクスポートの換算額と合計に含めない", func(t *testing.T) {
+		usecase, itemRepo, renderer := newExportTestUsecase()
+		item := newOrgItem()
+		item.Visibility = entity.VisibilityShared
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{item}, nil)
+		var rendered *ItemExport
+		renderer.On("Render", mock.Anything).Run(func(args mock.Arguments) { rendered = args.Get(0).(*ItemExport) }).Return([]byte("file"), nil)
+
+		_, err := usecase.Export(ctx, ExportFormatXLSX, entity.ItemFilter{})
+		require.NoError(t, err)
+		require.Len(t, rendered.Items, 1)
+		assert.True(t, rendered.Items[0].IsRedacted(entity.ItemFieldPurchasePrice))
+		assert.Zero(t, rendered.Items[0].PurchasePrice.Amount)
+		assert.Empty(t, rendered.Values)
+		assert.Equal(t, CategoryTotal{Count: 1}, rendered.Total)
+	})
+
+	t.Run("仕訳に含めない", func(t *testing.T) {
+		usecase, itemRepo, invoiceRepo, _, _ := newAccountingExportTestUsecase()
+		itemRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{newOrgItem()}, nil)
+		invoiceRepo.On("FindAll", mock.Anything, orgViewer.ID).Return([]*entity.Invoice{}, nil)
+
+		entries, err := usecase.journalEntries(ctx, orgViewer, "", "", mergeAccountMapping(nil))
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestDigestUsecase_Redaction(t *testing.T) {
+	usecase, deps := newDigestTestUsecase()
+	item := newOrgItem()
+	item.CreatedAt = digestTestNow.Add(-time.Hour)
+	deps.items.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{item}, nil)
+	deps.consignments.On("FindAll", mock.Anything, orgViewer.ID, entity.ConsignmentStatusActive).Return([]*entity.Consignment{}, nil)
+	subscription := &entity.DigestSubscription{UserID: orgViewer.ID, Frequency: entity.DigestFrequencyWeekly}
+
+	digest, err := usecase.collect(context.Background(), orgViewer, subscription, digestTestNow)
+	require.NoError(t, err)
+	assert.Equal(t, 1, digest.TotalCount)
+	assert.Zero(t, digest.TotalValue)
+	assert.Zero(t, digest.AddedValue)
+	require.Len(t, digest.AddedItems, 1)
+	assert.True(t, digest.AddedItems[0].IsRedacted(entity.ItemFieldPurchasePrice))
+}
+
+func TestRedaction_ForbiddenFeatures(t *testing.T) {
+	ctx := WithActor(context.Background(), orgViewer)
+
+	t.Run("価格の推移", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		historyRepo := new(MockItemHistoryRepository)
+		usecase := newTestPriceHistoryUsecase(itemRepo, historyRepo, new(MockConsignmentRepository))
+
+		_, err := usecase.Get(ctx, 1, "")
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		historyRepo.AssertNotCalled(t, "FindByItemID")
+	})
+
+	t.Run("評価証明書", func(t *testing.T) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		renderer := new(MockCertificateRenderer)
+		usecase := newTestCertificateUsecase(itemRepo, renderer)
+
+		_, err := usecase.Generate(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		renderer.AssertNotCalled(t, "Render")
+	})
+}
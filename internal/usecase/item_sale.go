@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// RecordSaleInput はアイテムの売却の内容（金額はアイテムの購入価格の通貨で、補助単位を小数にした金額）
+type RecordSaleInput struct {
+	SoldPrice *entity.Decimal `json:"sold_price"`
+	SoldDate  string          `json:"sold_date"`
+	// Fees は販売手数料・送料など売却にかかった費用（省略時 0）
+	Fees       entity.Decimal `json:"fees"`
+	BuyerNotes string         `json:"buyer_notes,omitempty"`
+}
+
+// RecordSale は売却を記録し、同じトランザクションでアイテムを売却済みにする（売却できない状況のアイテムは検証エラー）
+func (u *itemUsecase) RecordSale(ctx context.Context, id int64, input RecordSaleInput) (*entity.ItemSale, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.saleRepo == nil {
+		return nil, fmt.Errorf("%w: item sales are not enabled", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.findTargetItem(ctx, actor, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.SoldPrice == nil {
+		return nil, domainErrors.ValidationErrors{{Field: "sold_price", Code: domainErrors.CodeRequired, Message: "sold_price is required"}}
+	}
+	sale, err := entity.NewItemSale(item, *input.SoldPrice, input.Fees, input.SoldDate, input.BuyerNotes, u.now())
+	if err != nil {
+		return nil, err
+	}
+
+	before := *item
+	if err := item.ChangeStatus(string(entity.ItemStatusSold)); err != nil {
+		return nil, err
+	}
+
+	var histories []*entity.ItemHistory
+	if u.historyRepo != nil {
+		histories = entity.NewItemHistories(actor.ID, &before, item, u.now())
+	}
+	saved, updatedItem, err := u.saleRepo.Record(ctx, sale, item, histories)
+	if err != nil {
+		if domainErrors.IsDuplicateError(err) {
+			return nil, fmt.Errorf("%w: a sale is already recorded for this item", domainErrors.ErrDuplicateEntry)
+		}
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsVersionConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to record sale: %w", err)
+	}
+
+	redactItems(actor, updatedItem)
+	saved.SetProfit(updatedItem)
+
+	return saved, nil
+}
+
+func (u *itemUsecase) GetSale(ctx context.Context, id int64) (*entity.ItemSale, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.saleRepo == nil {
+		return nil, domainErrors.ErrItemSaleNotFound
+	}
+
+	item, err := u.findTargetItem(ctx, actor, id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	sale, err := u.saleRepo.FindByItemID(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemSaleNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve sale: %w", err)
+	}
+	redactItems(actor, item)
+	sale.SetProfit(item)
+
+	return sale, nil
+}
+
+// findTargetItem は売却や評価額を記録するアイテムを取得する（write が true の場合は変更できることも確認する）
+func (u *itemUsecase) findTargetItem(ctx context.Context, actor *entity.User, id int64, write bool) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	find := findItemForActor
+	if write {
+		find = findWritableItem
+	}
+	item, err := find(ctx, u.itemRepo, actor, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsForbiddenError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	return item, nil
+}
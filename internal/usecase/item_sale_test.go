@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockItemSaleRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemSaleRepository) FindByItemID(ctx context.Context, itemID int64) (*entity.ItemSale, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemSale), args.Error(1)
+}
+
+func (m *MockItemSaleRepository) Record(ctx context.Context, sale *entity.ItemSale, item *entity.Item, histories []*entity.ItemHistory) (*entity.ItemSale, *entity.Item, error) {
+	args := m.Called(ctx, sale, item, histories)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entity.ItemSale), args.Get(1).(*entity.Item), args.Error(2)
+}
+
+func TestItemUsecase_RecordSale(t *testing.T) {
+	newItem := func(status entity.ItemStatus) *entity.Item {
+		item, _ := newOwnedItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		item.Status = status
+		return item
+	}
+	input := RecordSaleInput{SoldPrice: &entity.Decimal{Units: 1800000}, SoldDate: "2024-05-31", Fees: entity.Decimal{Units: 90000}, BuyerNotes: "店頭で販売"}
+
+	t.Run("正常系: 売却を記録してアイテムを売却済みにし、損益を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		saleRepo := new(MockItemSaleRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusListedForSale), nil)
+		saleRepo.On("Record", mock.Anything, mock.MatchedBy(func(sale *entity.ItemSale) bool {
+			return sale.ItemID == 1 && sale.SoldPrice == entity.JPY(1800000) && sale.Fees == entity.JPY(90000) && sale.BuyerNotes == "店頭で販売"
+		}), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Status == entity.ItemStatusSold
+		}), mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			// 所有状況の変更履歴も売却と同じトランザクションで記録する
+			return len(histories) == 1 && histories[0].Field == "status" && *histories[0].NewValue == "sold"
+		})).Return(&entity.ItemSale{ID: 5, ItemID: 1, SoldPrice: entity.JPY(1800000), Fees: entity.JPY(90000), SoldDate: "2024-05-31"}, newItem(entity.ItemStatusSold), nil)
+
+		sale, err := NewItemUsecase(mockRepo, WithItemSales(saleRepo), WithItemHistory(historyRepo)).RecordSale(actorContext(), 1, input)
+		require.NoError(t, err)
+		require.NotNil(t, sale.Profit)
+		assert.Equal(t, entity.JPY(210000), *sale.Profit)
+		saleRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 売却価格の指定がない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		saleRepo := new(MockItemSaleRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusOwned), nil)
+
+		_, err := NewItemUsecase(mockRepo, WithItemSales(saleRepo)).RecordSale(actorContext(), 1, RecordSaleInput{SoldDate: "2024-05-31"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		saleRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 譲渡したアイテムは売却できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		saleRepo := new(MockItemSaleRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusGifted), nil)
+
+		_, err := NewItemUsecase(mockRepo, WithItemSales(saleRepo)).RecordSale(actorContext(), 1, input)
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.CodeNotAllowed, errs[0].Code)
+		saleRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 売却を記録済み", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		saleRepo := new(MockItemSaleRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusSold), nil)
+		saleRepo.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil, domainErrors.ErrDuplicateEntry)
+
+		_, err := NewItemUsecase(mockRepo, WithItemSales(saleRepo)).RecordSale(actorContext(), 1, input)
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateEntry)
+	})
+
+	t.Run("異常系: 閲覧者は記録できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		viewer := &entity.User{ID: 3, Email: "viewer@example.com", Role: entity.RoleViewer}
+
+		_, err := NewItemUsecase(mockRepo, WithItemSales(new(MockItemSaleRepository))).RecordSale(WithActor(actorContext(), viewer), 1, input)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestItemUsecase_GetSale(t *testing.T) {
+	newSale := func() *entity.ItemSale {
+		return &entity.ItemSale{ID: 5, ItemID: 1, SoldPrice: entity.JPY(1800000), Fees: entity.JPY(90000), SoldDate: "2024-05-31"}
+	}
+
+	t.Run("正常系: 損益とともに返す", func(t *testing.T) {
+		item, _ := newOwnedItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		mockRepo := new(MockItemRepository)
+		saleRepo := new(MockItemSaleRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		saleRepo.On("FindByItemID", mock.Anything, int64(1)).Return(newSale(), nil)
+
+		sale, err := NewItemUsecase(mockRepo, WithItemSales(saleRepo)).GetSale(actorContext(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, entity.JPY(210000), *sale.Profit)
+	})
+
+	t.Run("正常系: 購入価格が非表示の役割には損益を返さない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		saleRepo := new(MockItemSaleRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newOrgItem(), nil)
+		saleRepo.On("FindByItemID", mock.Anything, int64(1)).Return(newSale(), nil)
+
+		sale, err := NewItemUsecase(mockRepo, WithItemSales(saleRepo)).GetSale(WithActor(context.Background(), orgViewer), 1)
+		require.NoError(t, err)
+		assert.Nil(t, sale.Profit)
+	})
+
+	t.Run("異常系: 売却を記録していない", func(t *testing.T) {
+		item, _ := newOwnedItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		mockRepo := new(MockItemRepository)
+		saleRepo := new(MockItemSaleRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		saleRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemSaleNotFound)
+
+		_, err := NewItemUsecase(mockRepo, WithItemSales(saleRepo)).GetSale(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemSaleNotFound)
+	})
+}
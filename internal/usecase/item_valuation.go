@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// RecordValuationInput はある日付時点のアイテムの評価額（補助単位を小数にした金額）
+type RecordValuationInput struct {
+	Value *entity.Decimal `json:"value"`
+	// Currency は評価額の通貨（省略時はアイテムの購入価格の通貨）
+	Currency string `json:"currency,omitempty"`
+	ValuedOn string `json:"valued_on"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+// RecordValuation は評価額を記録し、最新の評価日の評価額をアイテムの評価額（current_value）にする
+func (u *itemUsecase) RecordValuation(ctx context.Context, id int64, input RecordValuationInput) (*entity.ItemValuation, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.valuationRepo == nil {
+		return nil, fmt.Errorf("%w: item valuations are not enabled", domainErrors.ErrInvalidInput)
+	}
+
+	item, err := u.findTargetItem(ctx, actor, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Value == nil {
+		return nil, domainErrors.ValidationErrors{{Field: "value", Code: domainErrors.CodeRequired, Message: "value is required"}}
+	}
+	valuation, err := entity.NewItemValuation(item, *input.Value, input.Currency, input.ValuedOn, input.Notes, u.now())
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := u.valuationRepo.Record(ctx, valuation)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to record valuation: %w", err)
+	}
+	return saved, nil
+}
+
+// GetValuations はアイテムの評価額を評価日の古い順に返す
+func (u *itemUsecase) GetValuations(ctx context.Context, id int64) ([]*entity.ItemValuation, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.findTargetItem(ctx, actor, id, false); err != nil {
+		return nil, err
+	}
+	if u.valuationRepo == nil {
+		return []*entity.ItemValuation{}, nil
+	}
+
+	valuations, err := u.valuationRepo.FindByItemID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve valuations: %w", err)
+	}
+	return valuations, nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockItemValuationRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemValuationRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemValuation), args.Error(1)
+}
+
+func (m *MockItemValuationRepository) Record(ctx context.Context, valuation *entity.ItemValuation) (*entity.ItemValuation, error) {
+	args := m.Called(ctx, valuation)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.ItemValuation), args.Error(1)
+}
+
+func TestItemUsecase_RecordValuation(t *testing.T) {
+	newItem := func() *entity.Item {
+		item, _ := newOwnedItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		return item
+	}
+	input := RecordValuationInput{Value: &entity.Decimal{Units: 2100000}, ValuedOn: "2024-05-31", Notes: "買取店の査定"}
+
+	t.Run("正常系: 評価額を記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		valuationRepo := new(MockItemValuationRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		valuationRepo.On("Record", mock.Anything, mock.MatchedBy(func(v *entity.ItemValuation) bool {
+			return v.ItemID == 1 && v.Value == entity.JPY(2100000) && v.ValuedOn == "2024-05-31" && v.Notes == "買取店の査定"
+		})).Return(&entity.ItemValuation{ID: 3, ItemID: 1, Value: entity.JPY(2100000), ValuedOn: "2024-05-31"}, nil)
+
+		valuation, err := NewItemUsecase(mockRepo, WithItemValuations(valuationRepo)).RecordValuation(actorContext(), 1, input)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), valuation.ID)
+		valuationRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 評価額の指定がない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		valuationRepo := new(MockItemValuationRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+
+		_, err := NewItemUsecase(mockRepo, WithItemValuations(valuationRepo)).RecordValuation(actorContext(), 1, RecordValuationInput{ValuedOn: "2024-05-31"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		valuationRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := NewItemUsecase(mockRepo, WithItemValuations(new(MockItemValuationRepository))).RecordValuation(actorContext(), 1, input)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("異常系: 閲覧者は記録できない", func(t *testing.T) {
+		viewer := &entity.User{ID: 3, Email: "viewer@example.com", Role: entity.RoleViewer}
+
+		_, err := NewItemUsecase(new(MockItemRepository), WithItemValuations(new(MockItemValuationRepository))).RecordValuation(WithActor(actorContext(), viewer), 1, input)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestItemUsecase_GetValuations(t *testing.T) {
+	t.Run("正常系: 評価日の古い順に返す", func(t *testing.T) {
+		item, _ := newOwnedItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		mockRepo := new(MockItemRepository)
+		valuationRepo := new(MockItemValuationRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		valuationRepo.On("FindByItemID", mock.Anything, int64(1)).Return([]*entity.ItemValuation{
+			{ID: 1, ItemID: 1, Value: entity.JPY(1800000), ValuedOn: "2023-12-01"},
+			{ID: 2, ItemID: 1, Value: entity.JPY(2100000), ValuedOn: "2024-05-31"},
+		}, nil)
+
+		valuations, err := NewItemUsecase(mockRepo, WithItemValuations(valuationRepo)).GetValuations(actorContext(), 1)
+		require.NoError(t, err)
+		require.Len(t, valuations, 2)
+		assert.Equal(t, "2024-05-31", valuations[1].ValuedOn)
+	})
+
+	t.Run("異常系: 参照できないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		valuationRepo := new(MockItemValuationRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := NewItemUsecase(mockRepo, WithItemValuations(valuationRepo)).GetValuations(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		valuationRepo.AssertNotCalled(t, "FindByItemID", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,357 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// JobFunc はジョブとしてバックグラウンドで実行される処理
+type JobFunc func(ctx context.Context, job *entity.Job) error
+
+type JobUsecase interface {
+	// Submit はジョブを開始する。同じユーザーのジョブが実行中の場合は JobConflictError を返す
+	Submit(ctx context.Context, userID string, kind entity.JobKind, fn JobFunc) (*entity.Job, error)
+	GetJob(ctx context.Context, userID string, id int64) (*entity.Job, error)
+	// GetResult は成功したジョブが出力したファイルを返す
+	GetResult(ctx context.Context, userID string, id int64) (*entity.JobResult, error)
+	// Load は実行中のジョブの数と、同じ種類の処理にかかる時間の見積もりを返す
+	Load(kind entity.JobKind) JobLoad
+	// Observe は同期で実行した処理の時間を見積もりに反映する（ジョブとして実行した時間は自動で反映する）
+	Observe(kind entity.JobKind, d time.Duration)
+	// Subscribe はジョブの進捗の通知を受け取るチャネルと、受け取りをやめる関数を返す。
+	// 最初に現在の進捗（終了している場合は finished）を通知し、finished を通知した後にチャネルを閉じる
+	Subscribe(ctx context.Context, userID string, id int64) (<-chan entity.JobEvent, func(), error)
+}
+
+// 1つのジョブの進捗を同時に受け取れる数
+const maxJobSubscribers = 8
+
+// 進捗の通知のバッファ。受け取りが遅い場合は途中の通知を捨てるが、finished の分は常に空けておく
+const jobEventBuffer = 16
+
+// 終了したジョブを保持する期間と、ユーザーごとに保持する終了したジョブの数の既定値
+const (
+	DefaultJobRetention           = 24 * time.Hour
+	DefaultMaxFinishedJobsPerUser = 50
+)
+
+// JobLoad は重い処理の負荷の目安
+type JobLoad struct {
+	// QueueLength は実行中のジョブの数（すべてのユーザー）
+	QueueLength int
+	// EstimatedDuration は同じ種類の処理の最近の実行時間の平均（実績がない場合は0）
+	EstimatedDuration time.Duration
+}
+
+type jobUsecase struct {
+	mu     sync.Mutex
+	nextID int64
+	jobs   map[int64]*entity.Job
+	// ユーザーごとの実行中ジョブ
+	running map[string]int64
+	// 種類ごとの実行時間の移動平均
+	durations map[entity.JobKind]time.Duration
+	// ジョブごとの進捗の通知先
+	subscribers map[int64][]chan entity.JobEvent
+	// retention は終了したジョブを保持する期間、maxFinishedPerUser はユーザーごとに保持する終了したジョブの数
+	retention          time.Duration
+	maxFinishedPerUser int
+	now                func() time.Time
+}
+
+// JobUsecaseOption は JobUsecase の任意の設定
+type JobUsecaseOption func(*jobUsecase)
+
+// WithJobRetention は終了したジョブ（結果のファイルを含む）を保持する期間と、ユーザーごとに保持する数を設定する。
+// 期間を過ぎたジョブと、数を超えた古いジョブは GET /jobs/{id} で参照できなくなる（0 以下は既定値）
+func WithJobRetention(retention time.Duration, maxFinishedPerUser int) JobUsecaseOption {
+	return func(u *jobUsecase) {
+		if retention > 0 {
+			u.retention = retention
+		}
+		if maxFinishedPerUser > 0 {
+			u.maxFinishedPerUser = maxFinishedPerUser
+		}
+	}
+}
+
+func NewJobUsecase(opts ...JobUsecaseOption) JobUsecase {
+	u := &jobUsecase{
+		jobs:               make(map[int64]*entity.Job),
+		running:            make(map[string]int64),
+		durations:          make(map[entity.JobKind]time.Duration),
+		subscribers:        make(map[int64][]chan entity.JobEvent),
+		retention:          DefaultJobRetention,
+		maxFinishedPerUser: DefaultMaxFinishedJobsPerUser,
+		now:                time.Now,
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *jobUsecase) Submit(ctx context.Context, userID string, kind entity.JobKind, fn JobFunc) (*entity.Job, error) {
+	u.mu.Lock()
+	if id, ok := u.running[userID]; ok {
+		u.mu.Unlock()
+		return nil, &domainErrors.JobConflictError{JobID: id}
+	}
+	u.evictExpired(u.now())
+
+	u.nextID++
+	job := &entity.Job{
+		ID:        u.nextID,
+		UserID:    userID,
+		Kind:      kind,
+		Status:    entity.JobStatusRunning,
+		CreatedAt: u.now(),
+	}
+	u.jobs[job.ID] = job
+	u.running[userID] = job.ID
+	snapshot := *job
+	u.mu.Unlock()
+
+	// リクエスト終了後も処理を続けるため、キャンセルを引き継がないコンテキストで実行する
+	go u.run(context.WithoutCancel(ctx), job, snapshot, fn)
+
+	return &snapshot, nil
+}
+
+func (u *jobUsecase) run(ctx context.Context, job *entity.Job, snapshot entity.Job, fn JobFunc) {
+	var err error
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		err = fn(withJobReporter(ctx, &jobReporter{u: u, job: job}), &snapshot)
+	}()
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := u.now()
+	job.FinishedAt = &now
+	if err != nil {
+		job.Status = entity.JobStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = entity.JobStatusSucceeded
+		job.Progress = 100
+		if snapshot.Result != nil {
+			job.Result = snapshot.Result
+			job.ResultFile = snapshot.Result.FileName
+		}
+		u.observe(job.Kind, now.Sub(job.CreatedAt))
+	}
+	delete(u.running, job.UserID)
+
+	finished := copyJob(job)
+	for _, ch := range u.subscribers[job.ID] {
+		ch <- entity.JobEvent{Type: entity.JobEventFinished, Progress: finished.Progress, Job: finished}
+		close(ch)
+	}
+	delete(u.subscribers, job.ID)
+	u.evictOverflow(job.UserID)
+}
+
+// expired は終了したジョブが保持する期間を過ぎたかを返す。u.mu を確保した状態で呼び出す
+func (u *jobUsecase) expired(job *entity.Job, now time.Time) bool {
+	return job.FinishedAt != nil && now.Sub(*job.FinishedAt) >= u.retention
+}
+
+// evictExpired は保持する期間を過ぎた終了したジョブを取り除く。u.mu を確保した状態で呼び出す
+func (u *jobUsecase) evictExpired(now time.Time) {
+	for id, job := range u.jobs {
+		if u.expired(job, now) {
+			delete(u.jobs, id)
+		}
+	}
+}
+
+// evictOverflow はユーザーの終了したジョブが保持する数を超えた分を古い順に取り除く。u.mu を確保した状態で呼び出す
+func (u *jobUsecase) evictOverflow(userID string) {
+	var finished []*entity.Job
+	for _, job := range u.jobs {
+		if job.UserID == userID && job.IsFinished() {
+			finished = append(finished, job)
+		}
+	}
+	if len(finished) <= u.maxFinishedPerUser {
+		return
+	}
+	slices.SortFunc(finished, func(a, b *entity.Job) int {
+		return a.FinishedAt.Compare(*b.FinishedAt)
+	})
+	for _, job := range finished[:len(finished)-u.maxFinishedPerUser] {
+		delete(u.jobs, job.ID)
+	}
+}
+
+func (u *jobUsecase) Subscribe(ctx context.Context, userID string, id int64) (<-chan entity.JobEvent, func(), error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	job, ok := u.jobs[id]
+	if !ok || job.UserID != userID || u.expired(job, u.now()) {
+		return nil, nil, domainErrors.ErrJobNotFound
+	}
+
+	ch := make(chan entity.JobEvent, jobEventBuffer)
+	if job.IsFinished() {
+		ch <- entity.JobEvent{Type: entity.JobEventFinished, Progress: job.Progress, Job: copyJob(job)}
+		close(ch)
+		return ch, func() {}, nil
+	}
+	if len(u.subscribers[id]) >= maxJobSubscribers {
+		return nil, nil, domainErrors.ErrTooManyJobSubscribers
+	}
+
+	ch <- entity.JobEvent{Type: entity.JobEventProgress, Progress: job.Progress}
+	u.subscribers[id] = append(u.subscribers[id], ch)
+
+	unsubscribe := func() {
+		u.mu.Lock()
+		defer u.mu.Unlock()
+
+		// 終了時に閉じたチャネルは一覧に残っていない
+		for i, subscriber := range u.subscribers[id] {
+			if subscriber == ch {
+				u.subscribers[id] = slices.Delete(u.subscribers[id], i, i+1)
+				close(ch)
+				return
+			}
+		}
+	}
+	return ch, unsubscribe, nil
+}
+
+// publish は実行中のジョブの進捗を通知する。u.mu を確保した状態で呼び出す
+func (u *jobUsecase) publish(id int64, event entity.JobEvent) {
+	for _, ch := range u.subscribers[id] {
+		// 受け取りが遅い通知先には途中の通知を送らない（終了時の finished の分は残す）
+		if len(ch) < cap(ch)-1 {
+			ch <- event
+		}
+	}
+}
+
+// copyJob はジョブの状態を呼び出し元に返すための複製を作る。u.mu を確保した状態で呼び出す
+func copyJob(job *entity.Job) *entity.Job {
+	snapshot := *job
+	snapshot.Errors = slices.Clone(job.Errors)
+	return &snapshot
+}
+
+func (u *jobUsecase) Load(kind entity.JobKind) JobLoad {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return JobLoad{
+		QueueLength:       len(u.running),
+		EstimatedDuration: u.durations[kind],
+	}
+}
+
+func (u *jobUsecase) Observe(kind entity.JobKind, d time.Duration) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.observe(kind, d)
+}
+
+// observe は実行時間を移動平均に反映する（直近の実行ほど重く扱う）。u.mu を確保した状態で呼び出す
+func (u *jobUsecase) observe(kind entity.JobKind, d time.Duration) {
+	average, ok := u.durations[kind]
+	if !ok {
+		u.durations[kind] = d
+		return
+	}
+	u.durations[kind] = average + (d-average)/4
+}
+
+func (u *jobUsecase) GetJob(ctx context.Context, userID string, id int64) (*entity.Job, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	job, ok := u.jobs[id]
+	// 他のユーザーのジョブと保持する期間を過ぎたジョブは存在しないものとして扱う
+	if !ok || job.UserID != userID || u.expired(job, u.now()) {
+		return nil, domainErrors.ErrJobNotFound
+	}
+
+	return copyJob(job), nil
+}
+
+func (u *jobUsecase) GetResult(ctx context.Context, userID string, id int64) (*entity.JobResult, error) {
+	job, err := u.GetJob(ctx, userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 実行中・失敗したジョブ、ファイルを出力しないジョブは結果がない
+	if job.Result == nil {
+		return nil, domainErrors.ErrJobResultNotFound
+	}
+
+	return job.Result, nil
+}
+
+type jobReporterKey struct{}
+
+// jobReporter は実行中のジョブの進捗と一部の失敗を記録する
+type jobReporter struct {
+	u   *jobUsecase
+	job *entity.Job
+}
+
+func withJobReporter(ctx context.Context, r *jobReporter) context.Context {
+	return context.WithValue(ctx, jobReporterKey{}, r)
+}
+
+// ReportJobProgress はジョブとして実行中の処理の進捗（total 件中 done 件）を記録して通知する。
+// 成功時に100にするため、途中の進捗は99までとする。ジョブの外で呼び出した場合は何もしない
+func ReportJobProgress(ctx context.Context, done, total int) {
+	r, ok := ctx.Value(jobReporterKey{}).(*jobReporter)
+	if !ok || total <= 0 {
+		return
+	}
+	progress := min(max(done*100/total, 0), 99)
+
+	r.u.mu.Lock()
+	defer r.u.mu.Unlock()
+
+	// 進捗率が変わらない場合や戻る場合は通知しない
+	if progress <= r.job.Progress {
+		return
+	}
+	r.job.Progress = progress
+	r.u.publish(r.job.ID, entity.JobEvent{Type: entity.JobEventProgress, Progress: progress})
+}
+
+// ReportJobChunkError はジョブとして実行中の処理の、処理を続けた一部の失敗を記録して通知する。
+// ジョブの外で呼び出した場合は何もしない
+func ReportJobChunkError(ctx context.Context, chunk string, err error) {
+	r, ok := ctx.Value(jobReporterKey{}).(*jobReporter)
+	if !ok || err == nil {
+		return
+	}
+	chunkErr := entity.JobChunkError{Chunk: chunk, Message: err.Error()}
+
+	r.u.mu.Lock()
+	defer r.u.mu.Unlock()
+
+	if len(r.job.Errors) >= entity.MaxJobChunkErrors {
+		return
+	}
+	r.job.Errors = append(r.job.Errors, chunkErr)
+	r.u.publish(r.job.ID, entity.JobEvent{Type: entity.JobEventChunkError, Progress: r.job.Progress, ChunkError: &chunkErr})
+}
@@ -0,0 +1,371 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func waitForJob(t *testing.T, u JobUsecase, userID string, id int64) *entity.Job {
+	t.Helper()
+	var job *entity.Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = u.GetJob(context.Background(), userID, id)
+		return err == nil && job.IsFinished()
+	}, time.Second, 5*time.Millisecond)
+	return job
+}
+
+func TestJobUsecase_Submit(t *testing.T) {
+	t.Run("異常系: 同じユーザーのジョブが実行中の場合は競合", func(t *testing.T) {
+		u := NewJobUsecase()
+		ctx := context.Background()
+		block := make(chan struct{})
+
+		first, err := u.Submit(ctx, "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			<-block
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = u.Submit(ctx, "user-1", entity.JobKindImport, func(ctx context.Context, job *entity.Job) error {
+			return nil
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrJobAlreadyRunning)
+		var conflict *domainErrors.JobConflictError
+		require.True(t, errors.As(err, &conflict))
+		assert.Equal(t, first.ID, conflict.JobID)
+
+		// 他のユーザーは実行できる
+		other, err := u.Submit(ctx, "user-2", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			return nil
+		})
+		require.NoError(t, err)
+		waitForJob(t, u, "user-2", other.ID)
+
+		close(block)
+		waitForJob(t, u, "user-1", first.ID)
+
+		// 終了後は再度実行できる
+		_, err = u.Submit(ctx, "user-1", entity.JobKindImport, func(ctx context.Context, job *entity.Job) error {
+			return nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("正常系: 失敗したジョブはエラーを記録する", func(t *testing.T) {
+		u := NewJobUsecase()
+		job, err := u.Submit(context.Background(), "user-1", entity.JobKindReport, func(ctx context.Context, job *entity.Job) error {
+			return errors.New("boom")
+		})
+		require.NoError(t, err)
+
+		finished := waitForJob(t, u, "user-1", job.ID)
+		assert.Equal(t, entity.JobStatusFailed, finished.Status)
+		assert.Equal(t, "boom", finished.Error)
+	})
+}
+
+func TestJobUsecase_GetJob(t *testing.T) {
+	u := NewJobUsecase()
+	job, err := u.Submit(context.Background(), "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	_, err = u.GetJob(context.Background(), "user-2", job.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+
+	_, err = u.GetJob(context.Background(), "user-1", 999)
+	assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+}
+
+func TestJobUsecase_GetResult(t *testing.T) {
+	u := NewJobUsecase()
+	job, err := u.Submit(context.Background(), "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+		job.Result = &entity.JobResult{FileName: "journal.csv", ContentType: "text/csv", Body: []byte("a,b\n")}
+		return nil
+	})
+	require.NoError(t, err)
+	finished := waitForJob(t, u, "user-1", job.ID)
+	assert.Equal(t, "journal.csv", finished.ResultFile)
+
+	result, err := u.GetResult(context.Background(), "user-1", job.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("a,b\n"), result.Body)
+
+	_, err = u.GetResult(context.Background(), "user-2", job.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+
+	// ファイルを出力しないジョブ
+	other, err := u.Submit(context.Background(), "user-1", entity.JobKindReport, func(ctx context.Context, job *entity.Job) error {
+		return nil
+	})
+	require.NoError(t, err)
+	waitForJob(t, u, "user-1", other.ID)
+	_, err = u.GetResult(context.Background(), "user-1", other.ID)
+	assert.ErrorIs(t, err, domainErrors.ErrJobResultNotFound)
+}
+
+func TestJobUsecase_Eviction(t *testing.T) {
+	// ジョブはゴルーチンで終了するため、時刻は atomic で進める
+	newUsecase := func(retention time.Duration, maxFinished int) (*jobUsecase, *atomic.Int64) {
+		var clock atomic.Int64
+		clock.Store(time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC).UnixNano())
+		u := NewJobUsecase(WithJobRetention(retention, maxFinished)).(*jobUsecase)
+		u.now = func() time.Time { return time.Unix(0, clock.Load()).UTC() }
+		return u, &clock
+	}
+	succeed := func(ctx context.Context, job *entity.Job) error { return nil }
+	fail := func(ctx context.Context, job *entity.Job) error { return errors.New("failed") }
+
+	t.Run("正常系: 保持する期間を過ぎた成功・失敗したジョブは取り除く", func(t *testing.T) {
+		u, clock := newUsecase(time.Hour, 10)
+		ctx := context.Background()
+		succeeded, err := u.Submit(ctx, "user-1", entity.JobKindExport, succeed)
+		require.NoError(t, err)
+		waitForJob(t, u, "user-1", succeeded.ID)
+		failed, err := u.Submit(ctx, "user-2", entity.JobKindExport, fail)
+		require.NoError(t, err)
+		waitForJob(t, u, "user-2", failed.ID)
+
+		clock.Add(int64(time.Hour - time.Second))
+		_, err = u.GetJob(ctx, "user-1", succeeded.ID)
+		require.NoError(t, err)
+
+		clock.Add(int64(time.Second))
+		_, err = u.GetJob(ctx, "user-1", succeeded.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+		_, err = u.GetResult(ctx, "user-2", failed.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+		_, _, err = u.Subscribe(ctx, "user-2", failed.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+
+		// 次のジョブの開始でメモリからも取り除く
+		next, err := u.Submit(ctx, "user-3", entity.JobKindExport, succeed)
+		require.NoError(t, err)
+		waitForJob(t, u, "user-3", next.ID)
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		assert.NotContains(t, u.jobs, succeeded.ID)
+		assert.NotContains(t, u.jobs, failed.ID)
+		assert.Contains(t, u.jobs, next.ID)
+	})
+
+	t.Run("正常系: 実行中のジョブは期間を過ぎても取り除かない", func(t *testing.T) {
+		u, clock := newUsecase(time.Hour, 10)
+		ctx := context.Background()
+		block := make(chan struct{})
+		running, err := u.Submit(ctx, "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			<-block
+			return nil
+		})
+		require.NoError(t, err)
+
+		clock.Add(int64(2 * time.Hour))
+		_, err = u.Submit(ctx, "user-2", entity.JobKindExport, succeed)
+		require.NoError(t, err)
+		job, err := u.GetJob(ctx, "user-1", running.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobStatusRunning, job.Status)
+
+		close(block)
+		waitForJob(t, u, "user-1", running.ID)
+	})
+
+	t.Run("正常系: ユーザーごとに保持する数を超えた終了したジョブは古い順に取り除く", func(t *testing.T) {
+		u, clock := newUsecase(time.Hour, 2)
+		ctx := context.Background()
+		var ids []int64
+		for i := 0; i < 3; i++ {
+			fn := succeed
+			if i == 1 {
+				fn = fail
+			}
+			job, err := u.Submit(ctx, "user-1", entity.JobKindExport, fn)
+			require.NoError(t, err)
+			waitForJob(t, u, "user-1", job.ID)
+			ids = append(ids, job.ID)
+			clock.Add(int64(time.Second))
+		}
+		other, err := u.Submit(ctx, "user-2", entity.JobKindExport, succeed)
+		require.NoError(t, err)
+		waitForJob(t, u, "user-2", other.ID)
+
+		_, err = u.GetJob(ctx, "user-1", ids[0])
+		assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+		for _, id := range ids[1:] {
+			_, err = u.GetJob(ctx, "user-1", id)
+			assert.NoError(t, err)
+		}
+		// 他のユーザーのジョブの数には影響しない
+		_, err = u.GetJob(ctx, "user-2", other.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestJobUsecase_Load(t *testing.T) {
+	u := NewJobUsecase()
+	assert.Equal(t, JobLoad{}, u.Load(entity.JobKindExport))
+
+	// 最初の実績はそのまま、以降は直近の実行ほど重く扱う
+	u.Observe(entity.JobKindExport, 4*time.Second)
+	assert.Equal(t, 4*time.Second, u.Load(entity.JobKindExport).EstimatedDuration)
+	u.Observe(entity.JobKindExport, 8*time.Second)
+	assert.Equal(t, 5*time.Second, u.Load(entity.JobKindExport).EstimatedDuration)
+	assert.Zero(t, u.Load(entity.JobKindReport).EstimatedDuration)
+
+	block := make(chan struct{})
+	job, err := u.Submit(context.Background(), "user-1", entity.JobKindReport, func(ctx context.Context, job *entity.Job) error {
+		<-block
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, u.Load(entity.JobKindExport).QueueLength)
+
+	// 成功したジョブの実行時間は見積もりに反映する
+	close(block)
+	waitForJob(t, u, "user-1", job.ID)
+	load := u.Load(entity.JobKindReport)
+	assert.Equal(t, 0, load.QueueLength)
+	assert.Positive(t, load.EstimatedDuration)
+}
+
+func TestJobUsecase_Subscribe(t *testing.T) {
+	t.Run("正常系: 進捗と一部の失敗を通知し、終了したジョブを通知して閉じる", func(t *testing.T) {
+		u := NewJobUsecase()
+		started := make(chan struct{})
+		step := make(chan struct{})
+		job, err := u.Submit(context.Background(), "user-1", entity.JobKindDigest, func(ctx context.Context, job *entity.Job) error {
+			<-started
+			ReportJobProgress(ctx, 1, 4)
+			ReportJobProgress(ctx, 1, 4) // 進捗率が変わらない場合は通知しない
+			ReportJobChunkError(ctx, "user 2", errors.New("smtp error"))
+			<-step
+			ReportJobProgress(ctx, 4, 4) // 終了するまでは99まで
+			return nil
+		})
+		require.NoError(t, err)
+
+		events, unsubscribe, err := u.Subscribe(context.Background(), "user-1", job.ID)
+		require.NoError(t, err)
+		defer unsubscribe()
+		assert.Equal(t, entity.JobEvent{Type: entity.JobEventProgress, Progress: 0}, <-events)
+
+		close(started)
+		assert.Equal(t, entity.JobEvent{Type: entity.JobEventProgress, Progress: 25}, <-events)
+		event := <-events
+		assert.Equal(t, entity.JobEventChunkError, event.Type)
+		assert.Equal(t, &entity.JobChunkError{Chunk: "user 2", Message: "smtp error"}, event.ChunkError)
+
+		// 実行中のジョブの取得にも進捗と一部の失敗を含める
+		running, err := u.GetJob(context.Background(), "user-1", job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 25, running.Progress)
+		assert.Len(t, running.Errors, 1)
+
+		close(step)
+		assert.Equal(t, entity.JobEvent{Type: entity.JobEventProgress, Progress: 99}, <-events)
+		event = <-events
+		assert.Equal(t, entity.JobEventFinished, event.Type)
+		assert.Equal(t, entity.JobStatusSucceeded, event.Job.Status)
+		assert.Equal(t, 100, event.Job.Progress)
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 終了済みのジョブは終了のみ通知する", func(t *testing.T) {
+		u := NewJobUsecase()
+		job, err := u.Submit(context.Background(), "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			return errors.New("boom")
+		})
+		require.NoError(t, err)
+		waitForJob(t, u, "user-1", job.ID)
+
+		events, unsubscribe, err := u.Subscribe(context.Background(), "user-1", job.ID)
+		require.NoError(t, err)
+		defer unsubscribe()
+		event := <-events
+		assert.Equal(t, entity.JobEventFinished, event.Type)
+		assert.Equal(t, entity.JobStatusFailed, event.Job.Status)
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 受け取りが遅くても終了は通知する", func(t *testing.T) {
+		u := NewJobUsecase()
+		subscribed := make(chan struct{})
+		job, err := u.Submit(context.Background(), "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			<-subscribed
+			for i := 1; i < 100; i++ {
+				ReportJobProgress(ctx, i, 100)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		events, unsubscribe, err := u.Subscribe(context.Background(), "user-1", job.ID)
+		require.NoError(t, err)
+		defer unsubscribe()
+		close(subscribed)
+		waitForJob(t, u, "user-1", job.ID)
+
+		var last entity.JobEvent
+		for event := range events {
+			last = event
+		}
+		assert.Equal(t, entity.JobEventFinished, last.Type)
+	})
+
+	t.Run("異常系: 他のユーザーのジョブは見つからない", func(t *testing.T) {
+		u := NewJobUsecase()
+		job, err := u.Submit(context.Background(), "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, _, err = u.Subscribe(context.Background(), "user-2", job.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrJobNotFound)
+	})
+
+	t.Run("異常系: 同時に受け取れる数を超える", func(t *testing.T) {
+		u := NewJobUsecase()
+		block := make(chan struct{})
+		defer close(block)
+		job, err := u.Submit(context.Background(), "user-1", entity.JobKindExport, func(ctx context.Context, job *entity.Job) error {
+			<-block
+			return nil
+		})
+		require.NoError(t, err)
+
+		for range maxJobSubscribers - 1 {
+			_, _, err := u.Subscribe(context.Background(), "user-1", job.ID)
+			require.NoError(t, err)
+		}
+		_, unsubscribe, err := u.Subscribe(context.Background(), "user-1", job.ID)
+		require.NoError(t, err)
+		_, _, err = u.Subscribe(context.Background(), "user-1", job.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrTooManyJobSubscribers)
+
+		// 受け取りをやめると空きができる
+		unsubscribe()
+		_, _, err = u.Subscribe(context.Background(), "user-1", job.ID)
+		assert.NoError(t, err)
+	})
+}
+
+func TestReportJobProgress_OutsideJob(t *testing.T) {
+	// ジョブの外（同期のエクスポートなど）では何もしない
+	assert.NotPanics(t, func() {
+		ReportJobProgress(context.Background(), 1, 2)
+		ReportJobChunkError(context.Background(), "user 1", errors.New("boom"))
+	})
+}
@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+type languageKey struct{}
+
+// WithLanguage はレスポンスの表示言語（Accept-Language から選んだ言語）をコンテキストに設定する
+func WithLanguage(ctx context.Context, lang entity.Language) context.Context {
+	return context.WithValue(ctx, languageKey{}, lang)
+}
+
+// LanguageFromContext はコンテキストの表示言語を返す（未設定の場合は日本語）
+func LanguageFromContext(ctx context.Context) entity.Language {
+	if lang, ok := ctx.Value(languageKey{}).(entity.Language); ok {
+		return lang
+	}
+	return entity.LanguageJapanese
+}
+
+// localizeItems はアイテムのカテゴリーを表示言語での名前にする（レスポンスを返す直前に呼び出す）
+func localizeItems(ctx context.Context, items ...*entity.Item) {
+	lang := LanguageFromContext(ctx)
+	if lang == entity.LanguageJapanese {
+		return
+	}
+	for _, item := range items {
+		item.Category = entity.LocalizeCategory(item.Category, lang)
+	}
+}
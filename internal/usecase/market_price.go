@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// MarketPriceProvider はブランドとモデルから中古相場を取得する（相場 API などで実装する）
+type MarketPriceProvider interface {
+	// Quote は brand の model（アイテムの名前）の相場を返す。相場がない場合は ErrMarketPriceNotFound を返す
+	Quote(ctx context.Context, brand, model string) (*MarketQuote, error)
+}
+
+// MarketQuote はブランドとモデルの相場
+type MarketQuote struct {
+	// Value は補助単位を小数にした金額
+	Value    entity.Decimal
+	Currency entity.Currency
+	// Source は相場の提供元（評価額のメモに記録する）
+	Source string
+}
+
+// MarketPriceRefreshInput は相場で評価額を更新するアイテムの絞り込み
+type MarketPriceRefreshInput struct {
+	// Brand はブランドでの絞り込み（空の場合は操作者が変更できるすべてのアイテム）
+	Brand string `json:"brand"`
+}
+
+// MarketPriceRefreshResult は相場での評価額の更新の結果（アイテムの件数）
+type MarketPriceRefreshResult struct {
+	// Models は相場を問い合わせたブランドとモデルの組の数
+	Models  int `json:"models"`
+	Updated int `json:"updated"`
+	// Unchanged は現在の評価額が相場と同じだったため記録しなかったアイテム
+	Unchanged int `json:"unchanged"`
+	// NotFound は相場がなかったアイテム
+	NotFound int `json:"not_found"`
+	Failed   int `json:"failed"`
+}
+
+type MarketPriceUsecase interface {
+	// StartRefresh は操作者が変更できる手放していないアイテムの評価額を相場で更新するジョブを開始する
+	// （結果は GET /jobs/{id}/result の MarketPriceRefreshResult。一部のアイテムの失敗はジョブの errors に記録する）
+	StartRefresh(ctx context.Context, input MarketPriceRefreshInput) (*entity.Job, error)
+	// RefreshAll はすべてのユーザーの手放していないアイテムの評価額を相場で更新する（定期実行用）。
+	// 一部のアイテムの失敗は続けて更新した後にまとめて返す
+	RefreshAll(ctx context.Context) (*MarketPriceRefreshResult, error)
+}
+
+type marketPriceUsecase struct {
+	itemRepo      ItemRepository
+	valuationRepo ItemValuationRepository
+	provider      MarketPriceProvider
+	jobs          JobUsecase
+	now           func() time.Time
+}
+
+// NewMarketPriceUsecase は provider の相場を評価額として記録する MarketPriceUsecase を返す
+// （provider が nil の場合は相場での更新を受け付けない）
+func NewMarketPriceUsecase(itemRepo ItemRepository, valuationRepo ItemValuationRepository, provider MarketPriceProvider, jobs JobUsecase) MarketPriceUsecase {
+	return &marketPriceUsecase{
+		itemRepo:      itemRepo,
+		valuationRepo: valuationRepo,
+		provider:      provider,
+		jobs:          jobs,
+		now:           time.Now,
+	}
+}
+
+func (u *marketPriceUsecase) StartRefresh(ctx context.Context, input MarketPriceRefreshInput) (*entity.Job, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.provider == nil {
+		return nil, fmt.Errorf("%w: market prices are not enabled", domainErrors.ErrInvalidInput)
+	}
+
+	filter := entity.ItemFilter{UserID: itemScope(actor), Brand: strings.TrimSpace(input.Brand)}
+	fileName := fmt.Sprintf("market-prices-%s.json", u.now().Format("20060102-150405"))
+	return u.jobs.Submit(ctx, strconv.FormatInt(actor.ID, 10), entity.JobKindMarketPrice, func(ctx context.Context, job *entity.Job) error {
+		items, err := u.itemRepo.FindAll(ctx, filter)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve items: %w", err)
+		}
+		writable := items[:0]
+		for _, item := range items {
+			if canWriteItem(actor, item) {
+				writable = append(writable, item)
+			}
+		}
+
+		// 一部のアイテムの失敗はジョブの errors と結果の failed に記録し、ジョブは成功とする
+		result, _ := u.refresh(ctx, writable)
+		body, err := json.Marshal(result)
+		if err != nil {
+			return fmt.Errorf("failed to encode market price result: %w", err)
+		}
+		job.Result = &entity.JobResult{
+			FileName:    fileName,
+			ContentType: "application/json",
+			Body:        body,
+		}
+		return nil
+	})
+}
+
+func (u *marketPriceUsecase) RefreshAll(ctx context.Context) (*MarketPriceRefreshResult, error) {
+	if u.provider == nil {
+		return nil, fmt.Errorf("%w: market prices are not enabled", domainErrors.ErrInvalidInput)
+	}
+
+	items, err := u.itemRepo.FindAll(ctx, entity.ItemFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	return u.refresh(ctx, items)
+}
+
+// refresh は手放していないアイテムをブランドとモデルでまとめて相場を1回ずつ問い合わせ、
+// 現在の評価額と異なるアイテムに今日の評価額として記録する。
+// 1件の失敗では止めずに続け、問い合わせや記録に失敗した場合はすべて終えてから結果とともにエラーを返す
+func (u *marketPriceUsecase) refresh(ctx context.Context, items []*entity.Item) (*MarketPriceRefreshResult, error) {
+	type group struct {
+		brand, model string
+		items        []*entity.Item
+	}
+	groups := make(map[string]*group)
+	var keys []string
+	for _, item := range items {
+		if !item.Status.IsHeld() {
+			continue
+		}
+		model := modelKey(item.Name)
+		if model == "" {
+			continue
+		}
+		brand := canonicalBrand(item.Brand)
+		key := strings.ToLower(brand) + "\x00" + model
+		g, ok := groups[key]
+		if !ok {
+			g = &group{brand: brand, model: strings.TrimSpace(item.Name)}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.items = append(g.items, item)
+	}
+
+	result := &MarketPriceRefreshResult{Models: len(keys)}
+	var errs []error
+	for i, key := range keys {
+		ReportJobProgress(ctx, i, len(keys))
+		g := groups[key]
+		chunk := g.brand + " " + g.model
+
+		quote, err := u.provider.Quote(ctx, g.brand, g.model)
+		if errors.Is(err, domainErrors.ErrMarketPriceNotFound) {
+			result.NotFound += len(g.items)
+			continue
+		}
+		if err != nil {
+			result.Failed += len(g.items)
+			errs = append(errs, fmt.Errorf("%s: %w", chunk, err))
+			ReportJobChunkError(ctx, chunk, err)
+			continue
+		}
+
+		for _, item := range g.items {
+			updated, err := u.record(ctx, item, quote)
+			switch {
+			case err != nil:
+				result.Failed++
+				errs = append(errs, fmt.Errorf("item %d: %w", item.ID, err))
+				ReportJobChunkError(ctx, "item "+strconv.FormatInt(item.ID, 10), err)
+			case updated:
+				result.Updated++
+			default:
+				result.Unchanged++
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// record は相場を item の今日の評価額として記録する。現在の評価額と同じ場合は記録せず false を返す
+func (u *marketPriceUsecase) record(ctx context.Context, item *entity.Item, quote *MarketQuote) (bool, error) {
+	now := u.now()
+	notes := "market price"
+	if quote.Source != "" {
+		notes += ": " + quote.Source
+	}
+	valuation, err := entity.NewItemValuation(item, quote.Value, string(quote.Currency), now.UTC().Format("2006-01-02"), notes, now)
+	if err != nil {
+		return false, err
+	}
+	if item.CurrentValue != nil && *item.CurrentValue == valuation.Value {
+		return false, nil
+	}
+
+	if _, err := u.valuationRepo.Record(ctx, valuation); err != nil {
+		return false, fmt.Errorf("failed to record valuation: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockMarketPriceProvider struct {
+	mock.Mock
+}
+
+func (m *MockMarketPriceProvider) Quote(ctx context.Context, brand, model string) (*MarketQuote, error) {
+	args := m.Called(ctx, brand, model)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MarketQuote), args.Error(1)
+}
+
+func newMarketPriceTestUsecase(provider MarketPriceProvider) (*marketPriceUsecase, *MockItemRepository, *MockItemValuationRepository, JobUsecase) {
+	itemRepo := new(MockItemRepository)
+	valuationRepo := new(MockItemValuationRepository)
+	jobs := NewJobUsecase()
+	u := NewMarketPriceUsecase(itemRepo, valuationRepo, provider, jobs).(*marketPriceUsecase)
+	u.now = func() time.Time { return time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC) }
+	return u, itemRepo, valuationRepo, jobs
+}
+
+func newMarketPriceItem(id int64, name, brand string) *entity.Item {
+	item, _ := newOwnedItem(name, "時計", brand, 1000000, "2023-01-15")
+	item.ID = id
+	return item
+}
+
+func mustDecimal(s string) entity.Decimal {
+	d, err := entity.ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func TestMarketPriceUsecase_RefreshAll(t *testing.T) {
+	t.Run("正常系: ブランドとモデルごとに1回問い合わせ、評価額を記録する", func(t *testing.T) {
+		provider := new(MockMarketPriceProvider)
+		u, itemRepo, valuationRepo, _ := newMarketPriceTestUsecase(provider)
+
+		daytona := newMarketPriceItem(1, "デイトナ 116500LN", "ROLEX")
+		// 表記ゆれのあるブランド・名前は同じモデルとして扱う
+		daytonaVariant := newMarketPriceItem(2, "デイトナ　116500ln", "rolex")
+		unchanged := newMarketPriceItem(3, "デイトナ 116500LN", "ROLEX")
+		unchanged.CurrentValue = &entity.Money{Amount: 3450000, Currency: entity.CurrencyJPY}
+		sold := newMarketPriceItem(4, "デイトナ 116500LN", "ROLEX")
+		sold.Status = entity.ItemStatusSold
+		unknown := newMarketPriceItem(5, "Speedmaster", "OMEGA")
+		itemRepo.On("FindAll", mock.Anything, entity.ItemFilter{}).Return([]*entity.Item{daytona, daytonaVariant, unchanged, sold, unknown}, nil)
+
+		provider.On("Quote", mock.Anything, "ROLEX", "デイトナ 116500LN").Return(&MarketQuote{Value: mustDecimal("3450000"), Currency: entity.CurrencyJPY, Source: "example market"}, nil).Once()
+		provider.On("Quote", mock.Anything, "OMEGA", "Speedmaster").Return(nil, domainErrors.ErrMarketPriceNotFound).Once()
+		valuationRepo.On("Record", mock.Anything, mock.MatchedBy(func(v *entity.ItemValuation) bool {
+			return v.Value == entity.Money{Amount: 3450000, Currency: entity.CurrencyJPY} &&
+				v.ValuedOn == "2024-07-01" && v.Notes == "market price: example market"
+		})).Return(&entity.ItemValuation{}, nil).Twice()
+
+		result, err := u.RefreshAll(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, &MarketPriceRefreshResult{Models: 2, Updated: 2, Unchanged: 1, NotFound: 1}, result)
+		provider.AssertExpectations(t)
+		valuationRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 一部の問い合わせや記録に失敗しても他のアイテムは更新する", func(t *testing.T) {
+		provider := new(MockMarketPriceProvider)
+		u, itemRepo, valuationRepo, _ := newMarketPriceTestUsecase(provider)
+
+		failing := newMarketPriceItem(1, "Speedmaster", "OMEGA")
+		// 購入日より前の評価日になるアイテムは記録できない
+		future := newMarketPriceItem(2, "Submariner", "ROLEX")
+		future.PurchaseDate = "2024-07-02"
+		ok := newMarketPriceItem(3, "Submariner", "ROLEX")
+		itemRepo.On("FindAll", mock.Anything, entity.ItemFilter{}).Return([]*entity.Item{failing, future, ok}, nil)
+
+		provider.On("Quote", mock.Anything, "OMEGA", "Speedmaster").Return(nil, errors.New("rate limited"))
+		provider.On("Quote", mock.Anything, "ROLEX", "Submariner").Return(&MarketQuote{Value: mustDecimal("9800.00"), Currency: entity.CurrencyUSD}, nil)
+		valuationRepo.On("Record", mock.Anything, mock.MatchedBy(func(v *entity.ItemValuation) bool {
+			return v.ItemID == 3 && v.Value == entity.Money{Amount: 980000, Currency: entity.CurrencyUSD} && v.Notes == "market price"
+		})).Return(&entity.ItemValuation{}, nil).Once()
+
+		result, err := u.RefreshAll(context.Background())
+		assert.ErrorContains(t, err, "OMEGA Speedmaster: rate limited")
+		assert.True(t, domainErrors.IsValidationError(err))
+		assert.Equal(t, &MarketPriceRefreshResult{Models: 2, Updated: 1, Failed: 2}, result)
+		valuationRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 相場 API が設定されていない", func(t *testing.T) {
+		u, _, _, _ := newMarketPriceTestUsecase(nil)
+		_, err := u.RefreshAll(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestMarketPriceUsecase_StartRefresh(t *testing.T) {
+	t.Run("正常系: 操作者が変更できるアイテムをジョブで更新し、件数を結果にする", func(t *testing.T) {
+		provider := new(MockMarketPriceProvider)
+		u, itemRepo, valuationRepo, jobs := newMarketPriceTestUsecase(provider)
+
+		own := newMarketPriceItem(1, "Submariner", "ROLEX")
+		// 他のユーザーの共有アイテムは一覧に含まれても変更できない
+		shared := newMarketPriceItem(2, "Submariner", "ROLEX")
+		shared.UserID = 99
+		shared.Visibility = entity.VisibilityShared
+		itemRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID, Brand: "ROLEX"}).Return([]*entity.Item{own, shared}, nil)
+		provider.On("Quote", mock.Anything, "ROLEX", "Submariner").Return(nil, errors.New("timeout"))
+
+		job, err := u.StartRefresh(actorContext(), MarketPriceRefreshInput{Brand: " ROLEX "})
+		require.NoError(t, err)
+		assert.Equal(t, entity.JobKindMarketPrice, job.Kind)
+
+		userID := strconv.FormatInt(testActor.ID, 10)
+		finished := waitForJob(t, jobs, userID, job.ID)
+		// 一部の失敗はジョブの errors に記録し、ジョブは成功とする
+		require.Equal(t, entity.JobStatusSucceeded, finished.Status, finished.Error)
+		assert.Equal(t, []entity.JobChunkError{{Chunk: "ROLEX Submariner", Message: "timeout"}}, finished.Errors)
+		result, err := jobs.GetResult(context.Background(), userID, job.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "market-prices-20240701-090000.json", result.FileName)
+		assert.JSONEq(t, `{"models":1,"updated":0,"unchanged":0,"not_found":0,"failed":1}`, string(result.Body))
+		valuationRepo.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 閲覧のみのユーザーは更新できない", func(t *testing.T) {
+		u, _, _, _ := newMarketPriceTestUsecase(new(MockMarketPriceProvider))
+		viewer := &entity.User{ID: 2, Role: entity.RoleViewer}
+		_, err := u.StartRefresh(WithActor(context.Background(), viewer), MarketPriceRefreshInput{})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+
+	t.Run("異常系: 相場 API が設定されていない", func(t *testing.T) {
+		u, _, _, _ := newMarketPriceTestUsecase(nil)
+		_, err := u.StartRefresh(actorContext(), MarketPriceRefreshInput{})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
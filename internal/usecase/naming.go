@@ -0,0 +1,239 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/width"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// NamingSuggestion は同じモデルと判定したアイテムの名前の表記ゆれと、揃える名前の候補
+type NamingSuggestion struct {
+	Brand string `json:"brand"`
+	// CanonicalName は揃える名前の候補（最も多く使われている表記。同数の場合は短い表記）
+	CanonicalName string `json:"canonical_name"`
+	// Variants は候補と異なる表記と、その表記のアイテム（アイテムの多い順）
+	Variants []NamingVariant `json:"variants"`
+}
+
+type NamingVariant struct {
+	Name    string  `json:"name"`
+	ItemIDs []int64 `json:"item_ids"`
+}
+
+// ApplyNamingInput はアイテムの名前をまとめて変更する内容（同じアイテムを複数の変更に含めることはできない）
+type ApplyNamingInput struct {
+	Changes []NamingChange `json:"changes"`
+}
+
+type NamingChange struct {
+	Name    string  `json:"name"`
+	ItemIDs []int64 `json:"item_ids"`
+}
+
+// モデル名の表記ゆれ（幅と大文字・小文字を揃え、区切りを除いた表記で比較する）と比較用の表記。
+// 日本語と英語の表記を同じモデルとして扱うためのもので、表示には使わない
+var modelAliases = map[string]string{
+	"daytona":      "デイトナ",
+	"デイトナ":         "デイトナ",
+	"submariner":   "サブマリーナー",
+	"サブマリーナ":       "サブマリーナー",
+	"サブマリーナー":      "サブマリーナー",
+	"gmtmaster":    "gmtマスター",
+	"gmtマスター":      "gmtマスター",
+	"datejust":     "デイトジャスト",
+	"デイトジャスト":      "デイトジャスト",
+	"explorer":     "エクスプローラー",
+	"エクスプローラー":     "エクスプローラー",
+	"エクスプローラ":      "エクスプローラー",
+	"speedmaster":  "スピードマスター",
+	"スピードマスター":     "スピードマスター",
+	"seamaster":    "シーマスター",
+	"シーマスター":       "シーマスター",
+	"nautilus":     "ノーチラス",
+	"ノーチラス":        "ノーチラス",
+	"aquanaut":     "アクアノート",
+	"アクアノート":       "アクアノート",
+	"royaloak":     "ロイヤルオーク",
+	"ロイヤルオーク":      "ロイヤルオーク",
+	"santos":       "サントス",
+	"サントス":         "サントス",
+	"birkin":       "バーキン",
+	"バーキン":         "バーキン",
+	"kelly":        "ケリー",
+	"ケリー":          "ケリー",
+	"speedy":       "スピーディ",
+	"スピーディ":        "スピーディ",
+	"スピーディー":       "スピーディ",
+	"neverfull":    "ネヴァーフル",
+	"ネヴァーフル":       "ネヴァーフル",
+	"ネバーフル":        "ネヴァーフル",
+	"classicflap":  "クラシックフラップ",
+	"クラシックフラップ":    "クラシックフラップ",
+	"matelasse":    "マトラッセ",
+	"マトラッセ":        "マトラッセ",
+	"lovebracelet": "ラブブレスレット",
+	"ラブブレスレット":     "ラブブレスレット",
+}
+
+var (
+	// 長い表記から置き換えるため、キーを長さの降順で並べる（サブマリーナー を サブマリーナ で置き換えない）
+	modelAliasReplacer = newAliasReplacer(modelAliases, func(k string) string { return modelAliases[k] })
+	// モデル名に含まれるブランド名は比較から除く（"ロレックス デイトナ" と "デイトナ" を同じモデルにする）
+	brandNameReplacer = newAliasReplacer(brandAliases, func(string) string { return " " })
+	// 型番（4桁以上の数字を含む語。"116520"・"126610LN"・"5711/1A-010"）は比較から除く
+	referenceNumberPattern = regexp.MustCompile(`[0-9a-z./-]*[0-9]{4,}[0-9a-z./-]*`)
+	// 比較で無視する区切り
+	nameSeparatorPattern = regexp.MustCompile(`[\s\p{P}\p{S}]+`)
+)
+
+// newAliasReplacer は m のキーを長い順に照合し、replacement の表記に置き換える Replacer を返す
+func newAliasReplacer(m map[string]string, replacement func(string) string) *strings.Replacer {
+	var pairs []string
+	for _, k := range sortedByLength(m) {
+		pairs = append(pairs, k, replacement(k))
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// modelKey は名前から比較用のモデルの表記を作る（型番とブランド名だけの名前は空文字）
+func modelKey(name string) string {
+	s := strings.ToLower(width.Fold.String(name))
+	s = brandNameReplacer.Replace(s)
+	s = referenceNumberPattern.ReplaceAllString(s, " ")
+	s = nameSeparatorPattern.ReplaceAllString(s, "")
+	return modelAliasReplacer.Replace(s)
+}
+
+// canonicalBrand は比較用のブランドの表記（表記ゆれの辞書にあるブランドは正式な表記）
+func canonicalBrand(brand string) string {
+	brand = strings.TrimSpace(brand)
+	if canonical, ok := brandAliases[strings.ToLower(width.Fold.String(brand))]; ok {
+		return canonical
+	}
+	return brand
+}
+
+// GetNamingSuggestions は操作者が変更できるアイテムのうち、同じブランドで同じモデルと判定したのに
+// 名前の表記が揃っていないアイテムをまとめ、揃える名前の候補を返す（変更するアイテムの多い順）
+func (u *itemUsecase) GetNamingSuggestions(ctx context.Context) ([]NamingSuggestion, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.FindAll(ctx, entity.ItemFilter{UserID: itemScope(actor)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	type group struct {
+		brand string
+		names map[string][]int64
+	}
+	groups := make(map[string]*group)
+	var keys []string
+	for _, item := range items {
+		if !canWriteItem(actor, item) {
+			continue
+		}
+		model := modelKey(item.Name)
+		if model == "" {
+			continue
+		}
+		brand := canonicalBrand(item.Brand)
+		key := strings.ToLower(brand) + "\x00" + model
+		g, ok := groups[key]
+		if !ok {
+			g = &group{brand: brand, names: make(map[string][]int64)}
+			groups[key] = g
+			keys = append(keys, key)
+		}
+		g.names[item.Name] = append(g.names[item.Name], item.ID)
+	}
+
+	suggestions := []NamingSuggestion{}
+	for _, key := range keys {
+		g := groups[key]
+		if len(g.names) < 2 {
+			continue
+		}
+
+		variants := make([]NamingVariant, 0, len(g.names))
+		for name, ids := range g.names {
+			variants = append(variants, NamingVariant{Name: name, ItemIDs: ids})
+		}
+		slices.SortFunc(variants, func(a, b NamingVariant) int {
+			return cmp.Or(
+				cmp.Compare(len(b.ItemIDs), len(a.ItemIDs)),
+				cmp.Compare(utf8.RuneCountInString(a.Name), utf8.RuneCountInString(b.Name)),
+				strings.Compare(a.Name, b.Name),
+			)
+		})
+		suggestions = append(suggestions, NamingSuggestion{
+			Brand:         g.brand,
+			CanonicalName: variants[0].Name,
+			Variants:      variants[1:],
+		})
+	}
+
+	slices.SortStableFunc(suggestions, func(a, b NamingSuggestion) int {
+		return cmp.Or(
+			cmp.Compare(variantItemCount(b.Variants), variantItemCount(a.Variants)),
+			strings.Compare(a.CanonicalName, b.CanonicalName),
+		)
+	})
+
+	return suggestions, nil
+}
+
+func variantItemCount(variants []NamingVariant) int {
+	n := 0
+	for _, v := range variants {
+		n += len(v.ItemIDs)
+	}
+	return n
+}
+
+// ApplyNaming はアイテムの名前を変更内容のとおりに1つのトランザクションで変更する（一括更新と同じく最大100件）
+func (u *itemUsecase) ApplyNaming(ctx context.Context, input ApplyNamingInput) ([]*entity.Item, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(input.Changes) == 0 {
+		return nil, fmt.Errorf("%w: changes must not be empty", domainErrors.ErrInvalidInput)
+	}
+	names := make(map[int64]string)
+	var ids []int64
+	for i, change := range input.Changes {
+		changeIDs, err := validateBulkItemIDs(change.ItemIDs)
+		if err != nil {
+			return nil, fmt.Errorf("%w (changes[%d])", err, i)
+		}
+		for _, id := range changeIDs {
+			if _, ok := names[id]; ok {
+				return nil, fmt.Errorf("%w: item %d is included in more than one change", domainErrors.ErrInvalidInput, id)
+			}
+			names[id] = change.Name
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > maxBulkUpdateItems {
+		return nil, fmt.Errorf("%w: changes must contain at most %d items", domainErrors.ErrInvalidInput, maxBulkUpdateItems)
+	}
+
+	return u.updateItems(ctx, actor, ids, func(id int64) UpdateItemInput {
+		name := names[id]
+		return UpdateItemInput{Name: &name}
+	})
+}
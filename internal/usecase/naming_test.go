@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestModelKey(t *testing.T) {
+	// 同じモデルになる表記
+	for _, name := range []string{"デイトナ", "Daytona", "daytona 116520", "ＤＡＹＴＯＮＡ", "ロレックス デイトナ", "Rolex Daytona Ref.116520"} {
+		assert.Equal(t, "デイトナ", modelKey(name), name)
+	}
+	assert.Equal(t, modelKey("サブマリーナ"), modelKey("Submariner 126610LN"))
+	assert.Equal(t, modelKey("スピーディ 30"), modelKey("Speedy30"))
+
+	// 別のモデル
+	assert.NotEqual(t, modelKey("サブマリーナー"), modelKey("デイトナ"))
+	assert.NotEqual(t, modelKey("スピーディ 25"), modelKey("スピーディ 30"))
+
+	// 型番とブランド名だけの名前は比較しない
+	assert.Equal(t, "", modelKey("ROLEX 116520"))
+}
+
+func TestItemUsecase_GetNamingSuggestions(t *testing.T) {
+	newItem := func(id int64, name, brand string) *entity.Item {
+		item, _ := newOwnedItem(name, "時計", brand, 1000000, "2023-01-01")
+		item.ID = id
+		return item
+	}
+
+	t.Run("正常系: 同じブランドの同じモデルの表記ゆれをまとめる", func(t *testing.T) {
+		others := newItem(7, "デイトナ", "ROLEX")
+		others.UserID = 2 // 操作者が変更できないアイテムは含めない
+		items := []*entity.Item{
+			newItem(1, "デイトナ", "ROLEX"),
+			newItem(2, "Daytona", "Rolex"),
+			newItem(3, "daytona 116520", "ロレックス"),
+			newItem(4, "デイトナ", "ROLEX"),
+			newItem(5, "スピードマスター", "OMEGA"),
+			newItem(6, "Speedmaster", "OMEGA"),
+			newItem(8, "デイトナ", "ノーブランド"), // ブランドが違うため別のモデル
+			newItem(9, "サブマリーナー", "ROLEX"),
+			others,
+		}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID}).Return(items, nil)
+
+		suggestions, err := NewItemUsecase(mockRepo).GetNamingSuggestions(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, []NamingSuggestion{
+			{
+				Brand:         "ROLEX",
+				CanonicalName: "デイトナ",
+				Variants: []NamingVariant{
+					{Name: "Daytona", ItemIDs: []int64{2}},
+					{Name: "daytona 116520", ItemIDs: []int64{3}},
+				},
+			},
+			{
+				// 同数の場合は短い表記
+				Brand:         "OMEGA",
+				CanonicalName: "スピードマスター",
+				Variants:      []NamingVariant{{Name: "Speedmaster", ItemIDs: []int64{6}}},
+			},
+		}, suggestions)
+	})
+
+	t.Run("正常系: 表記ゆれがない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item{newItem(1, "デイトナ", "ROLEX")}, nil)
+
+		suggestions, err := NewItemUsecase(mockRepo).GetNamingSuggestions(actorContext())
+		require.NoError(t, err)
+		assert.Empty(t, suggestions)
+	})
+
+	t.Run("異常系: 閲覧者は利用できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		viewer := &entity.User{ID: 3, Email: "viewer@example.com", Role: entity.RoleViewer}
+
+		_, err := NewItemUsecase(mockRepo).GetNamingSuggestions(WithActor(actorContext(), viewer))
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+}
+
+func TestItemUsecase_ApplyNaming(t *testing.T) {
+	newItem := func(id int64, name string) *entity.Item {
+		item, _ := newOwnedItem(name, "時計", "ROLEX", 1000000, "2023-01-01")
+		item.ID = id
+		return item
+	}
+
+	t.Run("正常系: 変更ごとの名前を1つのトランザクションで適用する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(newItem(2, "Daytona"), nil)
+		mockRepo.On("FindByID", mock.Anything, int64(3)).Return(newItem(3, "daytona 116520"), nil)
+		mockRepo.On("FindByID", mock.Anything, int64(6)).Return(newItem(6, "Speedmaster"), nil)
+		mockRepo.On("UpdateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+			return len(items) == 3 && items[0].Name == "デイトナ" && items[1].Name == "デイトナ" && items[2].Name == "スピードマスター"
+		})).Return([]*entity.Item{newItem(2, "デイトナ"), newItem(3, "デイトナ"), newItem(6, "スピードマスター")}, nil)
+
+		items, err := NewItemUsecase(mockRepo).ApplyNaming(actorContext(), ApplyNamingInput{Changes: []NamingChange{
+			{Name: "デイトナ", ItemIDs: []int64{2, 3}},
+			{Name: "スピードマスター", ItemIDs: []int64{6}},
+		}})
+		require.NoError(t, err)
+		assert.Len(t, items, 3)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name    string
+		changes []NamingChange
+	}{
+		{"変更内容がない", nil},
+		{"アイテムの指定がない", []NamingChange{{Name: "デイトナ"}}},
+		{"同じアイテムを複数の変更に含める", []NamingChange{{Name: "デイトナ", ItemIDs: []int64{2}}, {Name: "Daytona", ItemIDs: []int64{2}}}},
+	}
+	for _, tt := range tests {
+		t.Run("異常系: "+tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+
+			_, err := NewItemUsecase(mockRepo).ApplyNaming(actorContext(), ApplyNamingInput{Changes: tt.changes})
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			mockRepo.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("異常系: 空の名前は何も更新しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(newItem(2, "Daytona"), nil)
+
+		_, err := NewItemUsecase(mockRepo).ApplyNaming(actorContext(), ApplyNamingInput{Changes: []NamingChange{
+			{Name: " ", ItemIDs: []int64{2}},
+		}})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type NotificationUsecase interface {
+	// List は操作者への通知を新しい順に返す
+	List(ctx context.Context, unreadOnly bool) ([]*entity.Notification, error)
+	MarkRead(ctx context.Context, id int64) error
+}
+
+type notificationUsecase struct {
+	notificationRepo NotificationRepository
+}
+
+func NewNotificationUsecase(notificationRepo NotificationRepository) NotificationUsecase {
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+	}
+}
+
+func (u *notificationUsecase) List(ctx context.Context, unreadOnly bool) ([]*entity.Notification, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications, err := u.notificationRepo.FindByUserID(ctx, actor.ID, unreadOnly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+func (u *notificationUsecase) MarkRead(ctx context.Context, id int64) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.notificationRepo.MarkRead(ctx, actor.ID, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrNotificationNotFound
+		}
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	return nil
+}
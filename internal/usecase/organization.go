@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// OrganizationUsecase は組織とメンバーを扱う。
+// メンバーは組織のアイテムを共有し、役割（owner / editor / viewer）に応じて参照・変更できる
+type OrganizationUsecase interface {
+	// List は操作を行うユーザーが所属する組織を返す
+	List(ctx context.Context) ([]*entity.Organization, error)
+	// Create は組織を作成し、操作を行うユーザーを owner にする
+	Create(ctx context.Context, input OrganizationInput) (*entity.Organization, error)
+	// ListMembers は組織のメンバーを返す（メンバーと管理者のみ）
+	ListMembers(ctx context.Context, orgID int64) ([]*entity.Membership, error)
+	// AddMember は登録済みのユーザーをメンバーに追加する（owner と管理者のみ）
+	AddMember(ctx context.Context, orgID int64, input AddMemberInput) (*entity.Membership, error)
+	// RemoveMember はメンバーを外す（owner と管理者、または本人の脱退）。最後の owner は外せない
+	RemoveMember(ctx context.Context, orgID, userID int64) error
+}
+
+type OrganizationInput struct {
+	Name string `json:"name"`
+}
+
+type AddMemberInput struct {
+	Email string `json:"email"`
+	// Role は省略時 viewer
+	Role entity.OrgRole `json:"role,omitempty"`
+}
+
+type organizationUsecase struct {
+	orgRepo  OrganizationRepository
+	userRepo UserRepository
+}
+
+func NewOrganizationUsecase(orgRepo OrganizationRepository, userRepo UserRepository) OrganizationUsecase {
+	return &organizationUsecase{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (u *organizationUsecase) List(ctx context.Context) ([]*entity.Organization, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := u.orgRepo.FindByUserID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve organizations: %w", err)
+	}
+
+	return orgs, nil
+}
+
+func (u *organizationUsecase) Create(ctx context.Context, input OrganizationInput) (*entity.Organization, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := entity.NewOrganization(input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	created, err := u.orgRepo.Create(ctx, org, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *organizationUsecase) ListMembers(ctx context.Context, orgID int64) ([]*entity.Membership, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := u.find(ctx, actor, orgID); err != nil {
+		return nil, err
+	}
+
+	members, err := u.orgRepo.FindMembers(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve members: %w", err)
+	}
+
+	return members, nil
+}
+
+func (u *organizationUsecase) AddMember(ctx context.Context, orgID int64, input AddMemberInput) (*entity.Membership, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := u.find(ctx, actor, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageOrganization(actor, org.ID) {
+		return nil, domainErrors.ErrForbidden
+	}
+
+	role := input.Role
+	if role == "" {
+		role = entity.OrgRoleViewer
+	}
+
+	// 招待の仕組みはないため、登録済みのユーザーのみ追加できる
+	user, err := u.userRepo.FindByEmail(ctx, entity.NormalizeEmail(input.Email))
+	if err != nil {
+		if errors.Is(err, domainErrors.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: no user is registered with that email", domainErrors.ErrInvalidInput)
+		}
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+
+	membership, err := entity.NewMembership(org.ID, user.ID, role)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	added, err := u.orgRepo.AddMember(ctx, membership)
+	if err != nil {
+		if domainErrors.IsDuplicateError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to add member: %w", err)
+	}
+
+	return added, nil
+}
+
+func (u *organizationUsecase) RemoveMember(ctx context.Context, orgID, userID int64) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	org, err := u.find(ctx, actor, orgID)
+	if err != nil {
+		return err
+	}
+	if userID != actor.ID && !canManageOrganization(actor, org.ID) {
+		return domainErrors.ErrForbidden
+	}
+
+	members, err := u.orgRepo.FindMembers(ctx, org.ID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve members: %w", err)
+	}
+
+	var target *entity.Membership
+	owners := 0
+	for _, m := range members {
+		if m.UserID == userID {
+			target = m
+		}
+		if m.Role == entity.OrgRoleOwner {
+			owners++
+		}
+	}
+	if target == nil {
+		return domainErrors.ErrMemberNotFound
+	}
+	// owner がいなくなると誰もメンバーを管理できなくなる
+	if target.Role == entity.OrgRoleOwner && owners == 1 {
+		return fmt.Errorf("%w: the last owner cannot be removed", domainErrors.ErrInvalidInput)
+	}
+
+	if err := u.orgRepo.RemoveMember(ctx, org.ID, userID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrMemberNotFound
+		}
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	return nil
+}
+
+// find は組織を取得する。所属していない組織は存在を知られないよう ErrOrganizationNotFound とする（管理者はすべて取得できる）
+func (u *organizationUsecase) find(ctx context.Context, actor *entity.User, orgID int64) (*entity.Organization, error) {
+	if orgID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	if _, ok := actor.OrgRole(orgID); !ok && !actor.IsAdmin() {
+		return nil, domainErrors.ErrOrganizationNotFound
+	}
+
+	org, err := u.orgRepo.FindByID(ctx, orgID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// canManageOrganization はメンバーを管理できるかを判定する（owner と管理者）
+func canManageOrganization(user *entity.User, orgID int64) bool {
+	if user.IsAdmin() {
+		return true
+	}
+	role, ok := user.OrgRole(orgID)
+	return ok && role == entity.OrgRoleOwner
+}
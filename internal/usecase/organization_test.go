@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *entity.Organization, ownerID int64) (*entity.Organization, error) {
+	args := m.Called(ctx, org, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.Organization, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) FindByID(ctx context.Context, id int64) (*entity.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) FindMembers(ctx context.Context, organizationID int64) ([]*entity.Membership, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Membership), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) AddMember(ctx context.Context, membership *entity.Membership) (*entity.Membership, error) {
+	args := m.Called(ctx, membership)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Membership), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID int64) error {
+	args := m.Called(ctx, organizationID, userID)
+	return args.Error(0)
+}
+
+// 組織 10 の owner（ID 1）と editor（ID 2）
+var (
+	orgOwner  = &entity.User{ID: 1, Role: entity.RoleEditor, Memberships: []entity.Membership{{OrganizationID: 10, UserID: 1, Role: entity.OrgRoleOwner}}}
+	orgEditor = &entity.User{ID: 2, Role: entity.RoleEditor, Memberships: []entity.Membership{{OrganizationID: 10, UserID: 2, Role: entity.OrgRoleEditor}}}
+)
+
+func newOrganizationTestUsecase() (OrganizationUsecase, *MockOrganizationRepository, *MockUserRepository) {
+	orgRepo := new(MockOrganizationRepository)
+	userRepo := new(MockUserRepository)
+	orgRepo.On("FindByID", mock.Anything, int64(10)).Return(&entity.Organization{ID: 10, Name: "山田家"}, nil).Maybe()
+	orgRepo.On("FindMembers", mock.Anything, int64(10)).Return([]*entity.Membership{
+		{OrganizationID: 10, UserID: 1, Role: entity.OrgRoleOwner},
+		{OrganizationID: 10, UserID: 2, Role: entity.OrgRoleEditor},
+	}, nil).Maybe()
+	return NewOrganizationUsecase(orgRepo, userRepo), orgRepo, userRepo
+}
+
+func TestOrganizationUsecase_Create(t *testing.T) {
+	t.Run("正常系: 作成したユーザーが owner になる", func(t *testing.T) {
+		usecase, orgRepo, _ := newOrganizationTestUsecase()
+		orgRepo.On("Create", mock.Anything, mock.MatchedBy(func(org *entity.Organization) bool {
+			return org.Name == "山田家"
+		}), testActor.ID).Return(&entity.Organization{ID: 10, Name: "山田家", Role: entity.OrgRoleOwner}, nil)
+
+		org, err := usecase.Create(actorContext(), OrganizationInput{Name: " 山田家 "})
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrgRoleOwner, org.Role)
+	})
+
+	t.Run("異常系: 名前が空", func(t *testing.T) {
+		usecase, orgRepo, _ := newOrganizationTestUsecase()
+
+		_, err := usecase.Create(actorContext(), OrganizationInput{Name: " "})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		orgRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrganizationUsecase_ListMembers(t *testing.T) {
+	t.Run("正常系: メンバーは一覧を取得できる", func(t *testing.T) {
+		usecase, _, _ := newOrganizationTestUsecase()
+
+		members, err := usecase.ListMembers(WithActor(context.Background(), orgEditor), 10)
+		require.NoError(t, err)
+		assert.Len(t, members, 2)
+	})
+
+	t.Run("異常系: 所属していない組織は存在しないものとして扱う", func(t *testing.T) {
+		usecase, orgRepo, _ := newOrganizationTestUsecase()
+
+		_, err := usecase.ListMembers(WithActor(context.Background(), &entity.User{ID: 3, Role: entity.RoleEditor}), 10)
+		assert.ErrorIs(t, err, domainErrors.ErrOrganizationNotFound)
+		orgRepo.AssertNotCalled(t, "FindMembers", mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrganizationUsecase_AddMember(t *testing.T) {
+	partner := &entity.User{ID: 3, Email: "partner@example.com", Role: entity.RoleEditor}
+
+	t.Run("正常系: owner は登録済みのユーザーを追加できる（役割の省略時は viewer）", func(t *testing.T) {
+		usecase, orgRepo, userRepo := newOrganizationTestUsecase()
+		userRepo.On("FindByEmail", mock.Anything, "partner@example.com").Return(partner, nil)
+		orgRepo.On("AddMember", mock.Anything, mock.MatchedBy(func(m *entity.Membership) bool {
+			return m.OrganizationID == 10 && m.UserID == partner.ID && m.Role == entity.OrgRoleViewer
+		})).Return(&entity.Membership{OrganizationID: 10, UserID: partner.ID, Role: entity.OrgRoleViewer}, nil)
+
+		_, err := usecase.AddMember(WithActor(context.Background(), orgOwner), 10, AddMemberInput{Email: "Partner@example.com"})
+		require.NoError(t, err)
+		orgRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: owner 以外は追加できない", func(t *testing.T) {
+		usecase, orgRepo, _ := newOrganizationTestUsecase()
+
+		_, err := usecase.AddMember(WithActor(context.Background(), orgEditor), 10, AddMemberInput{Email: "partner@example.com"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		orgRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 登録されていないユーザー", func(t *testing.T) {
+		usecase, _, userRepo := newOrganizationTestUsecase()
+		userRepo.On("FindByEmail", mock.Anything, "nobody@example.com").Return(nil, domainErrors.ErrUserNotFound)
+
+		_, err := usecase.AddMember(WithActor(context.Background(), orgOwner), 10, AddMemberInput{Email: "nobody@example.com"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 定義されていない役割", func(t *testing.T) {
+		usecase, _, userRepo := newOrganizationTestUsecase()
+		userRepo.On("FindByEmail", mock.Anything, "partner@example.com").Return(partner, nil)
+
+		_, err := usecase.AddMember(WithActor(context.Background(), orgOwner), 10, AddMemberInput{Email: "partner@example.com", Role: "admin"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestOrganizationUsecase_RemoveMember(t *testing.T) {
+	t.Run("正常系: メンバーは自分で脱退できる", func(t *testing.T) {
+		usecase, orgRepo, _ := newOrganizationTestUsecase()
+		orgRepo.On("RemoveMember", mock.Anything, int64(10), orgEditor.ID).Return(nil)
+
+		require.NoError(t, usecase.RemoveMember(WithActor(context.Background(), orgEditor), 10, orgEditor.ID))
+		orgRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: owner 以外は他のメンバーを外せない", func(t *testing.T) {
+		usecase, orgRepo, _ := newOrganizationTestUsecase()
+
+		err := usecase.RemoveMember(WithActor(context.Background(), orgEditor), 10, orgOwner.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		orgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 最後の owner は外せない", func(t *testing.T) {
+		usecase, orgRepo, _ := newOrganizationTestUsecase()
+
+		err := usecase.RemoveMember(WithActor(context.Background(), orgOwner), 10, orgOwner.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		orgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: メンバーではないユーザー", func(t *testing.T) {
+		usecase, _, _ := newOrganizationTestUsecase()
+
+		err := usecase.RemoveMember(WithActor(context.Background(), orgOwner), 10, 99)
+		assert.ErrorIs(t, err, domainErrors.ErrMemberNotFound)
+	})
+}
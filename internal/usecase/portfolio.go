@@ -0,0 +1,269 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 公開トークンのランダム部分のバイト数
+const portfolioTokenBytes = 24
+
+type PortfolioUsecase interface {
+	Create(ctx context.Context, input PortfolioViewInput) (*entity.PortfolioView, error)
+	List(ctx context.Context) ([]*entity.PortfolioView, error)
+	Get(ctx context.Context, id int64) (*entity.PortfolioView, error)
+	Update(ctx context.Context, id int64, input UpdatePortfolioViewInput) (*entity.PortfolioView, error)
+	Delete(ctx context.Context, id int64) error
+	// GetPublic は公開トークンに対応するポートフォリオを価格を除いて返す（認証不要）
+	GetPublic(ctx context.Context, token string) (*PublicPortfolio, error)
+}
+
+type PortfolioViewInput struct {
+	Title            string  `json:"title"`
+	Description      string  `json:"description"`
+	ItemIDs          []int64 `json:"item_ids"`
+	ShowPurchaseDate bool    `json:"show_purchase_date"`
+	HTMLEnabled      bool    `json:"html_enabled"`
+}
+
+type UpdatePortfolioViewInput struct {
+	Title            *string  `json:"title,omitempty"`
+	Description      *string  `json:"description,omitempty"`
+	ItemIDs          *[]int64 `json:"item_ids,omitempty"`
+	ShowPurchaseDate *bool    `json:"show_purchase_date,omitempty"`
+	HTMLEnabled      *bool    `json:"html_enabled,omitempty"`
+}
+
+// PublicPortfolio は公開用のポートフォリオ（公開範囲が public のアイテムだけを含む）
+type PublicPortfolio struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description"`
+	Items       []*ExposedItem `json:"items"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	// HTMLEnabled はHTMLページの公開が許可されているか
+	HTMLEnabled bool `json:"-"`
+}
+
+type portfolioUsecase struct {
+	portfolioRepo PortfolioViewRepository
+	itemRepo      ItemRepository
+}
+
+func NewPortfolioUsecase(portfolioRepo PortfolioViewRepository, itemRepo ItemRepository) PortfolioUsecase {
+	return &portfolioUsecase{
+		portfolioRepo: portfolioRepo,
+		itemRepo:      itemRepo,
+	}
+}
+
+func (u *portfolioUsecase) Create(ctx context.Context, input PortfolioViewInput) (*entity.PortfolioView, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := entity.NewPortfolioView(actor.ID, input.Title, input.Description, input.ItemIDs, input.ShowPurchaseDate, input.HTMLEnabled)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if err := u.checkItems(ctx, actor, view.ItemIDs); err != nil {
+		return nil, err
+	}
+
+	view.Token, err = generatePortfolioToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	created, err := u.portfolioRepo.Create(ctx, view)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create portfolio: %w", err)
+	}
+
+	return created, nil
+}
+
+func (u *portfolioUsecase) List(ctx context.Context) ([]*entity.PortfolioView, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := u.portfolioRepo.FindByUserID(ctx, actor.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve portfolios: %w", err)
+	}
+
+	return views, nil
+}
+
+func (u *portfolioUsecase) Get(ctx context.Context, id int64) (*entity.PortfolioView, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.find(ctx, actor, id)
+}
+
+func (u *portfolioUsecase) Update(ctx context.Context, id int64, input UpdatePortfolioViewInput) (*entity.PortfolioView, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := u.find(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Title != nil {
+		view.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.Description != nil {
+		view.Description = strings.TrimSpace(*input.Description)
+	}
+	if input.ItemIDs != nil {
+		view.SetItemIDs(*input.ItemIDs)
+	}
+	if input.ShowPurchaseDate != nil {
+		view.ShowPurchaseDate = *input.ShowPurchaseDate
+	}
+	if input.HTMLEnabled != nil {
+		view.HTMLEnabled = *input.HTMLEnabled
+	}
+	if err := view.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if input.ItemIDs != nil {
+		if err := u.checkItems(ctx, actor, view.ItemIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := u.portfolioRepo.Update(ctx, view)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrPortfolioNotFound
+		}
+		return nil, fmt.Errorf("failed to update portfolio: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (u *portfolioUsecase) Delete(ctx context.Context, id int64) error {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return err
+	}
+
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.portfolioRepo.Delete(ctx, actor.ID, id); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrPortfolioNotFound
+		}
+		return fmt.Errorf("failed to delete portfolio: %w", err)
+	}
+
+	return nil
+}
+
+func (u *portfolioUsecase) GetPublic(ctx context.Context, token string) (*PublicPortfolio, error) {
+	if token == "" {
+		return nil, domainErrors.ErrPortfolioNotFound
+	}
+
+	view, err := u.portfolioRepo.FindByToken(ctx, token)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrPortfolioNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve portfolio: %w", err)
+	}
+
+	portfolio := &PublicPortfolio{
+		Title:       view.Title,
+		Description: view.Description,
+		Items:       make([]*ExposedItem, 0, len(view.ItemIDs)),
+		UpdatedAt:   view.UpdatedAt,
+		HTMLEnabled: view.HTMLEnabled,
+	}
+	for _, id := range view.ItemIDs {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			// 設定後に削除されたアイテムは表示しない
+			if domainErrors.IsNotFoundError(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		// 所有者が変わったアイテムや組織に移したアイテムは表示しない
+		if item.UserID != view.UserID || item.OrgID != 0 {
+			continue
+		}
+
+		// 公開範囲が public でないアイテムは表示しない
+		publicItem, ok := ExposeItem(item, entity.AudiencePublic, ExposeOptions{PurchaseDate: view.ShowPurchaseDate})
+		if !ok {
+			continue
+		}
+		portfolio.Items = append(portfolio.Items, publicItem)
+	}
+
+	return portfolio, nil
+}
+
+func (u *portfolioUsecase) find(ctx context.Context, actor *entity.User, id int64) (*entity.PortfolioView, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	view, err := u.portfolioRepo.FindByID(ctx, actor.ID, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrPortfolioNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve portfolio: %w", err)
+	}
+
+	return view, nil
+}
+
+// checkItems は掲載するアイテムがすべて操作者の個人のアイテムであることを確認する
+func (u *portfolioUsecase) checkItems(ctx context.Context, actor *entity.User, itemIDs []int64) error {
+	for _, id := range itemIDs {
+		item, err := u.itemRepo.FindByID(ctx, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return fmt.Errorf("%w: item %d not found", domainErrors.ErrInvalidInput, id)
+			}
+			return fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		// 管理者であっても他のユーザーのアイテムは掲載できない。
+		// 組織のアイテムは一人のメンバーの判断で公開しないよう掲載できない
+		if item.UserID != actor.ID || item.OrgID != 0 {
+			return fmt.Errorf("%w: item %d not found", domainErrors.ErrInvalidInput, id)
+		}
+	}
+	return nil
+}
+
+// generatePortfolioToken は推測されにくい公開用トークンを生成する
+func generatePortfolioToken() (string, error) {
+	b := make([]byte, portfolioTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
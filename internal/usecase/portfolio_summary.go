@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// PortfolioSummary は手放していないアイテム（所有・出品中・委託中）の件数と購入価格・評価額の合計
+type PortfolioSummary struct {
+	// Currency は金額の通貨（操作者の表示通貨）
+	Currency entity.Currency `json:"currency"`
+	PortfolioTotals
+	// Categories はカテゴリーごとの合計（カテゴリーの一覧の順）
+	Categories []PortfolioCategory `json:"categories"`
+}
+
+// PortfolioCategory はカテゴリーの件数と金額の合計
+type PortfolioCategory struct {
+	Category string `json:"category"`
+	PortfolioTotals
+}
+
+// PortfolioTotals は件数と金額の合計（Currency の最小単位）。
+// 金額は換算できない外貨建てのアイテムと購入価格が非表示のアイテムを除いて集計する
+type PortfolioTotals struct {
+	ItemCount     int   `json:"item_count"`
+	PurchaseValue int64 `json:"purchase_value"`
+	// EstimatedValue は評価額の合計（評価額を記録していないアイテムは購入価格で見積もる）
+	EstimatedValue int64 `json:"estimated_value"`
+	// ValuedCount は評価額を記録したアイテムの数
+	ValuedCount int `json:"valued_count"`
+	// ExcludedCount は金額の合計に含めていないアイテムの数
+	ExcludedCount int `json:"excluded_count"`
+}
+
+func (t *PortfolioTotals) add(v entity.HoldingValue, purchase, estimated int64, ok bool) {
+	t.ItemCount += v.Count
+	if !ok {
+		t.ExcludedCount += v.Count
+		return
+	}
+	t.PurchaseValue = entity.AddAmount(t.PurchaseValue, purchase)
+	t.EstimatedValue = entity.AddAmount(t.EstimatedValue, estimated)
+	t.ValuedCount += v.ValuedCount
+}
+
+// GetPortfolioSummary は手放していないアイテムの件数・購入価格の合計・評価額の合計とカテゴリーごとの内訳を返す。
+// 集計はリポジトリでカテゴリー・通貨ごとに行い、通貨ごとの合計を表示通貨に換算する
+func (u *itemUsecase) GetPortfolioSummary(ctx context.Context) (*PortfolioSummary, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := u.itemRepo.GetHoldingValues(ctx, itemScope(actor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio summary: %w", err)
+	}
+
+	valuation := newValuation(actor, u.converter)
+	summary := &PortfolioSummary{Currency: valuation.currency, Categories: []PortfolioCategory{}}
+	categories := make(map[string]*PortfolioTotals)
+	for _, category := range entity.GetValidCategories() {
+		categories[category] = &PortfolioTotals{}
+	}
+	for _, v := range values {
+		// 評価額も購入価格から推測できるため、購入価格を非表示にする組織のアイテムは件数のみ数える
+		ok := !slices.Contains(entity.ItemRedactionFor(actor, v.OrgID), entity.ItemFieldPurchasePrice)
+		var purchase, estimated int64
+		if ok {
+			if purchase, estimated, ok, err = convertHoldingValue(ctx, valuation, v); err != nil {
+				return nil, err
+			}
+		}
+		summary.add(v, purchase, estimated, ok)
+		if totals, known := categories[v.Category]; known {
+			totals.add(v, purchase, estimated, ok)
+		}
+	}
+	for _, category := range entity.GetValidCategories() {
+		summary.Categories = append(summary.Categories, PortfolioCategory{Category: category, PortfolioTotals: *categories[category]})
+	}
+
+	return summary, nil
+}
+
+// convertHoldingValue はグループの購入価格の合計と評価額の見積もりの合計を表示通貨に換算する。
+// どちらかを換算できない場合は ok が false になる（購入価格と評価額の合計の対象を揃えるため）
+func convertHoldingValue(ctx context.Context, valuation valuation, v entity.HoldingValue) (purchase, estimated int64, ok bool, err error) {
+	purchase, ok, err = valuation.convert(ctx, v.PurchaseValue, v.Currency)
+	if err != nil || !ok {
+		return 0, 0, false, err
+	}
+	// グループは評価額の通貨ごとのため、評価額のないグループは購入価格をそのまま見積もりにする
+	if v.ValuedCount == 0 {
+		return purchase, purchase, true, nil
+	}
+	estimated, ok, err = valuation.convert(ctx, v.CurrentValue, v.ValueCurrency)
+	if err != nil || !ok {
+		return 0, 0, false, err
+	}
+	if v.UnvaluedPurchaseValue > 0 {
+		unvalued, _, err := valuation.convert(ctx, v.UnvaluedPurchaseValue, v.Currency)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		estimated = entity.AddAmount(estimated, unvalued)
+	}
+	return purchase, estimated, true, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestItemUsecase_GetPortfolioSummary(t *testing.T) {
+	categoryTotals := func(summary *PortfolioSummary, category string) PortfolioTotals {
+		for _, c := range summary.Categories {
+			if c.Category == category {
+				return c.PortfolioTotals
+			}
+		}
+		t.Fatalf("category %s not found", category)
+		return PortfolioTotals{}
+	}
+
+	t.Run("正常系: 購入価格と評価額（記録のないアイテムは購入価格）の合計をカテゴリーごとに返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetHoldingValues", mock.Anything, testActor.ID).Return([]entity.HoldingValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, ValueCurrency: entity.CurrencyJPY, Count: 2, PurchaseValue: 3000000, ValuedCount: 2, CurrentValue: 4000000},
+			{Category: "時計", Currency: entity.CurrencyJPY, Count: 1, PurchaseValue: 1000000, UnvaluedPurchaseValue: 1000000},
+			{Category: "バッグ", Currency: entity.CurrencyJPY, ValueCurrency: entity.CurrencyJPY, Count: 1, PurchaseValue: 2000000, ValuedCount: 1, CurrentValue: 1500000},
+			// 換算できない外貨建てのアイテムは件数のみ数える
+			{Category: "バッグ", Currency: entity.CurrencyUSD, Count: 1, PurchaseValue: 500000, UnvaluedPurchaseValue: 500000},
+		}, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetPortfolioSummary(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyJPY, summary.Currency)
+		assert.Equal(t, PortfolioTotals{ItemCount: 5, PurchaseValue: 6000000, EstimatedValue: 6500000, ValuedCount: 3, ExcludedCount: 1}, summary.PortfolioTotals)
+		assert.Equal(t, PortfolioTotals{ItemCount: 3, PurchaseValue: 4000000, EstimatedValue: 5000000, ValuedCount: 2}, categoryTotals(summary, "時計"))
+		assert.Equal(t, PortfolioTotals{ItemCount: 2, PurchaseValue: 2000000, EstimatedValue: 1500000, ValuedCount: 1, ExcludedCount: 1}, categoryTotals(summary, "バッグ"))
+		// アイテムのないカテゴリーも含める
+		assert.Len(t, summary.Categories, len(entity.GetValidCategories()))
+		assert.Equal(t, PortfolioTotals{}, categoryTotals(summary, "靴"))
+	})
+
+	t.Run("正常系: 表示通貨に換算して合計する", func(t *testing.T) {
+		usdActor := &entity.User{ID: testActor.ID, Email: testActor.Email, Role: entity.RoleEditor, PreferredCurrency: entity.CurrencyUSD}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetHoldingValues", mock.Anything, testActor.ID).Return([]entity.HoldingValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, ValueCurrency: entity.CurrencyUSD, Count: 1, PurchaseValue: 3000000, ValuedCount: 1, CurrentValue: 2500000},
+		}, nil)
+		converter := new(MockCurrencyConverter)
+		converter.On("Convert", mock.Anything, int64(3000000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(2000000), nil)
+
+		summary, err := NewItemUsecase(mockRepo, WithCurrencyConverter(converter)).GetPortfolioSummary(WithActor(context.Background(), usdActor))
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, summary.Currency)
+		assert.Equal(t, int64(2000000), summary.PurchaseValue)
+		assert.Equal(t, int64(2500000), summary.EstimatedValue)
+	})
+
+	t.Run("正常系: 購入価格が非表示の組織のアイテムは件数のみ数える", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleViewer: {entity.ItemFieldPurchasePrice}})
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetHoldingValues", mock.Anything, orgViewer.ID).Return([]entity.HoldingValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, ValueCurrency: entity.CurrencyJPY, OrgID: redactionOrgID, Count: 2, PurchaseValue: 3000000, ValuedCount: 2, CurrentValue: 4000000},
+		}, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetPortfolioSummary(WithActor(context.Background(), orgViewer))
+		require.NoError(t, err)
+		assert.Equal(t, PortfolioTotals{ItemCount: 2, ExcludedCount: 2}, summary.PortfolioTotals)
+	})
+}
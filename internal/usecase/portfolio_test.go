@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockPortfolioViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockPortfolioViewRepository) Create(ctx context.Context, view *entity.PortfolioView) (*entity.PortfolioView, error) {
+	args := m.Called(ctx, view)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PortfolioView), args.Error(1)
+}
+
+func (m *MockPortfolioViewRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.PortfolioView, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.PortfolioView), args.Error(1)
+}
+
+func (m *MockPortfolioViewRepository) FindByID(ctx context.Context, userID, id int64) (*entity.PortfolioView, error) {
+	args := m.Called(ctx, userID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PortfolioView), args.Error(1)
+}
+
+func (m *MockPortfolioViewRepository) FindByToken(ctx context.Context, token string) (*entity.PortfolioView, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PortfolioView), args.Error(1)
+}
+
+func (m *MockPortfolioViewRepository) Update(ctx context.Context, view *entity.PortfolioView) (*entity.PortfolioView, error) {
+	args := m.Called(ctx, view)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.PortfolioView), args.Error(1)
+}
+
+func (m *MockPortfolioViewRepository) Delete(ctx context.Context, userID, id int64) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func TestPortfolioUsecase_Create(t *testing.T) {
+	item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	item.ID = 1
+
+	t.Run("正常系: 公開トークン付きで作成する", func(t *testing.T) {
+		portfolioRepo := new(MockPortfolioViewRepository)
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		var view *entity.PortfolioView
+		portfolioRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.PortfolioView")).
+			Run(func(args mock.Arguments) { view = args.Get(1).(*entity.PortfolioView) }).
+			Return(&entity.PortfolioView{ID: 1}, nil)
+		usecase := NewPortfolioUsecase(portfolioRepo, itemRepo)
+
+		_, err := usecase.Create(actorContext(), PortfolioViewInput{Title: "コレクション", ItemIDs: []int64{1, 1}})
+		require.NoError(t, err)
+
+		require.NotNil(t, view)
+
+		assert.Equal(t, testActor.ID, view.UserID)
+		assert.Equal(t, []int64{1}, view.ItemIDs)
+		assert.Len(t, view.Token, 32)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテムは掲載できない", func(t *testing.T) {
+		other := *item
+		other.UserID = 99
+		portfolioRepo := new(MockPortfolioViewRepository)
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&other, nil)
+		usecase := NewPortfolioUsecase(portfolioRepo, itemRepo)
+
+		_, err := usecase.Create(actorContext(), PortfolioViewInput{Title: "コレクション", ItemIDs: []int64{1}})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		portfolioRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: タイトルが空", func(t *testing.T) {
+		usecase := NewPortfolioUsecase(new(MockPortfolioViewRepository), new(MockItemRepository))
+
+		_, err := usecase.Create(actorContext(), PortfolioViewInput{Title: " "})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 操作者が未設定", func(t *testing.T) {
+		usecase := NewPortfolioUsecase(new(MockPortfolioViewRepository), new(MockItemRepository))
+
+		_, err := usecase.Create(context.Background(), PortfolioViewInput{Title: "コレクション"})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
+
+func TestPortfolioUsecase_Update(t *testing.T) {
+	t.Run("正常系: 指定した項目だけ変更する", func(t *testing.T) {
+		view := &entity.PortfolioView{ID: 1, UserID: testActor.ID, Title: "コレクション", ItemIDs: []int64{1}, Token: "token"}
+		portfolioRepo := new(MockPortfolioViewRepository)
+		portfolioRepo.On("FindByID", mock.Anything, testActor.ID, int64(1)).Return(view, nil)
+		portfolioRepo.On("Update", mock.Anything, view).Return(view, nil)
+		itemRepo := new(MockItemRepository)
+		usecase := NewPortfolioUsecase(portfolioRepo, itemRepo)
+
+		enabled := true
+		updated, err := usecase.Update(actorContext(), 1, UpdatePortfolioViewInput{HTMLEnabled: &enabled})
+		require.NoError(t, err)
+
+		assert.True(t, updated.HTMLEnabled)
+		assert.Equal(t, "コレクション", updated.Title)
+		itemRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 存在しないポートフォリオ", func(t *testing.T) {
+		portfolioRepo := new(MockPortfolioViewRepository)
+		portfolioRepo.On("FindByID", mock.Anything, testActor.ID, int64(1)).Return(nil, domainErrors.ErrPortfolioNotFound)
+		usecase := NewPortfolioUsecase(portfolioRepo, new(MockItemRepository))
+
+		_, err := usecase.Update(actorContext(), 1, UpdatePortfolioViewInput{})
+		assert.ErrorIs(t, err, domainErrors.ErrPortfolioNotFound)
+	})
+}
+
+func TestPortfolioUsecase_GetPublic(t *testing.T) {
+	item1, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+	item1.ID = 1
+	item1.Visibility = entity.VisibilityPublic
+	item2, _ := newOwnedItem("バッグ1", "バッグ", "HERMES", 500000, "2023-02-01")
+	item2.ID = 2
+	item2.UserID = 99
+	item2.Visibility = entity.VisibilityPublic
+	item4, _ := newOwnedItem("靴1", "靴", "LOUBOUTIN", 100000, "2023-03-01")
+	item4.ID = 4
+	item4.Visibility = entity.VisibilityShared
+
+	t.Run("正常系: 価格を含めず、削除・譲渡・非公開のアイテムは除外する", func(t *testing.T) {
+		view := &entity.PortfolioView{ID: 1, UserID: testActor.ID, Title: "コレクション", ItemIDs: []int64{1, 2, 3, 4}, Token: "token"}
+		portfolioRepo := new(MockPortfolioViewRepository)
+		portfolioRepo.On("FindByToken", mock.Anything, "token").Return(view, nil)
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item1, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(2)).Return(item2, nil)
+		itemRepo.On("FindByID", mock.Anything, int64(3)).Return(nil, domainErrors.ErrItemNotFound)
+		itemRepo.On("FindByID", mock.Anything, int64(4)).Return(item4, nil)
+		usecase := NewPortfolioUsecase(portfolioRepo, itemRepo)
+
+		portfolio, err := usecase.GetPublic(context.Background(), "token")
+		require.NoError(t, err)
+
+		assert.Equal(t, "コレクション", portfolio.Title)
+		assert.Equal(t, []*ExposedItem{{Name: "時計1", Category: "時計", Brand: "ROLEX"}}, portfolio.Items)
+	})
+
+	t.Run("正常系: 購入日の表示が有効なら含める", func(t *testing.T) {
+		view := &entity.PortfolioView{ID: 1, UserID: testActor.ID, Title: "コレクション", ItemIDs: []int64{1}, ShowPurchaseDate: true, Token: "token"}
+		portfolioRepo := new(MockPortfolioViewRepository)
+		portfolioRepo.On("FindByToken", mock.Anything, "token").Return(view, nil)
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item1, nil)
+		usecase := NewPortfolioUsecase(portfolioRepo, itemRepo)
+
+		portfolio, err := usecase.GetPublic(context.Background(), "token")
+		require.NoError(t, err)
+
+		require.Len(t, portfolio.Items, 1)
+		assert.Equal(t, "2023-01-01", portfolio.Items[0].PurchaseDate)
+	})
+
+	t.Run("異常系: 存在しないトークン", func(t *testing.T) {
+		portfolioRepo := new(MockPortfolioViewRepository)
+		portfolioRepo.On("FindByToken", mock.Anything, "unknown").Return(nil, domainErrors.ErrPortfolioNotFound)
+		usecase := NewPortfolioUsecase(portfolioRepo, new(MockItemRepository))
+
+		_, err := usecase.GetPublic(context.Background(), "unknown")
+		assert.ErrorIs(t, err, domainErrors.ErrPortfolioNotFound)
+	})
+}
+
+func TestExposeItem(t *testing.T) {
+	item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+
+	tests := []struct {
+		visibility entity.Visibility
+		audience   entity.Audience
+		want       bool
+	}{
+		{entity.VisibilityPrivate, entity.AudienceShared, false},
+		{entity.VisibilityPrivate, entity.AudiencePublic, false},
+		{entity.VisibilityShared, entity.AudienceShared, true},
+		{entity.VisibilityShared, entity.AudiencePublic, false},
+		{entity.VisibilityPublic, entity.AudienceShared, true},
+		{entity.VisibilityPublic, entity.AudiencePublic, true},
+		{"", entity.AudienceShared, false},
+	}
+	for _, tt := range tests {
+		item.Visibility = tt.visibility
+		exposed, ok := ExposeItem(item, tt.audience, ExposeOptions{})
+		assert.Equal(t, tt.want, ok, "%s -> %s", tt.visibility, tt.audience)
+		if ok {
+			assert.Equal(t, &ExposedItem{Name: "時計1", Category: "時計", Brand: "ROLEX"}, exposed)
+		}
+	}
+}
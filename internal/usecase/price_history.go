@@ -0,0 +1,266 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// PriceHistoryInterpolation は価格の記録がない月の値の補い方
+type PriceHistoryInterpolation string
+
+const (
+	// PriceHistoryInterpolationPrevious は直前の月の価格を引き継ぐ（既定）
+	PriceHistoryInterpolationPrevious PriceHistoryInterpolation = "previous"
+	// PriceHistoryInterpolationLinear は前後の記録の間を直線で補う（最後の記録の後は引き継ぐ）
+	PriceHistoryInterpolationLinear PriceHistoryInterpolation = "linear"
+	// PriceHistoryInterpolationNone は補わずに null とする
+	PriceHistoryInterpolationNone PriceHistoryInterpolation = "none"
+)
+
+// IsValid は定義済みの補い方かを判定する
+func (i PriceHistoryInterpolation) IsValid() bool {
+	switch i {
+	case PriceHistoryInterpolationPrevious, PriceHistoryInterpolationLinear, PriceHistoryInterpolationNone:
+		return true
+	}
+	return false
+}
+
+// PriceEventKind は価格の記録の種類
+type PriceEventKind string
+
+const (
+	// PriceEventPurchase は購入時の価格
+	PriceEventPurchase PriceEventKind = "purchase"
+	// PriceEventValuation は評価額（購入価格）の変更
+	PriceEventValuation PriceEventKind = "valuation"
+	// PriceEventSale は委託販売で売れたときの販売価格
+	PriceEventSale PriceEventKind = "sale"
+)
+
+// PriceEvent はアイテムの価格の1つの記録
+type PriceEvent struct {
+	Kind  PriceEventKind `json:"kind"`
+	Date  string         `json:"date"` // YYYY-MM-DD 形式
+	Price int            `json:"price"`
+}
+
+// PricePoint はグラフの1か月分の値（Price はその月の最後の記録の価格。記録がなく補わない場合は nil）
+type PricePoint struct {
+	Month        string           `json:"month"` // YYYY-MM 形式
+	Price        *int             `json:"price"`
+	Interpolated bool             `json:"interpolated"`
+	Events       []PriceEventKind `json:"events"`
+}
+
+// PriceHistory はアイテムの価格の推移（購入月から販売月、売れていない場合は今月まで）
+type PriceHistory struct {
+	ItemID        int64                     `json:"item_id"`
+	Interpolation PriceHistoryInterpolation `json:"interpolation"`
+	// Currency は価格の通貨（アイテムの購入価格の通貨）
+	Currency entity.Currency `json:"currency"`
+	Events   []PriceEvent    `json:"events"`
+	Points   []PricePoint    `json:"points"`
+}
+
+type PriceHistoryUsecase interface {
+	// Get は操作を行うユーザーが参照できるアイテムの価格の推移を月ごとに返す（interpolation の省略時は previous）
+	Get(ctx context.Context, itemID int64, interpolation PriceHistoryInterpolation) (*PriceHistory, error)
+}
+
+type priceHistoryUsecase struct {
+	itemRepo        ItemRepository
+	historyRepo     ItemHistoryRepository
+	consignmentRepo ConsignmentRepository
+	now             func() time.Time
+}
+
+// NewPriceHistoryUsecase は変更履歴の購入価格の変更を評価額、販売済みの委託の合意価格を販売価格とする PriceHistoryUsecase を返す
+func NewPriceHistoryUsecase(itemRepo ItemRepository, historyRepo ItemHistoryRepository, consignmentRepo ConsignmentRepository) PriceHistoryUsecase {
+	return &priceHistoryUsecase{
+		itemRepo:        itemRepo,
+		historyRepo:     historyRepo,
+		consignmentRepo: consignmentRepo,
+		now:             time.Now,
+	}
+}
+
+func (u *priceHistoryUsecase) Get(ctx context.Context, itemID int64, interpolation PriceHistoryInterpolation) (*PriceHistory, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if itemID <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if interpolation == "" {
+		interpolation = PriceHistoryInterpolationPrevious
+	}
+	if !interpolation.IsValid() {
+		var errs domainErrors.ValidationErrors
+		errs.Add("interpolation", domainErrors.CodeInvalidChoice, "interpolation must be one of: previous, linear, none")
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, errs)
+	}
+
+	item, err := findItemForActor(ctx, u.itemRepo, actor, itemID)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	// 価格の推移は購入価格と購入日から作るため、どちらかが非表示の場合は返さない
+	if err := requireUnredacted(actor, item, entity.ItemFieldPurchasePrice, entity.ItemFieldPurchaseDate); err != nil {
+		return nil, err
+	}
+
+	histories, err := u.historyRepo.FindByItemID(ctx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item history: %w", err)
+	}
+
+	var sale *PriceEvent
+	consignment, err := u.consignmentRepo.FindByItemID(ctx, itemID)
+	switch {
+	case err == nil:
+		// 委託の合意価格は円のため、外貨建てのアイテムには販売価格を含めない
+		if consignment.Status == entity.ConsignmentStatusSold && item.PurchasePrice.IsJPY() {
+			sale = &PriceEvent{Kind: PriceEventSale, Date: consignment.UpdatedAt.Format("2006-01-02"), Price: consignment.AgreedPrice}
+		}
+	case !domainErrors.IsNotFoundError(err):
+		return nil, fmt.Errorf("failed to retrieve consignment: %w", err)
+	}
+
+	events := priceEvents(item, histories, sale)
+	end := u.now().Format("2006-01")
+	if sale != nil {
+		end = sale.Date[:7]
+	}
+
+	return &PriceHistory{
+		ItemID:        item.ID,
+		Interpolation: interpolation,
+		Currency:      item.PurchasePrice.Currency,
+		Events:        events,
+		Points:        pricePoints(events, end, interpolation),
+	}, nil
+}
+
+// priceEvents は購入、評価額の変更、販売の記録を日付順に返す。
+// 購入時の価格は最初の購入価格の変更前の値（変更がない場合は現在の購入価格）とする
+func priceEvents(item *entity.Item, histories []*entity.ItemHistory, sale *PriceEvent) []PriceEvent {
+	purchase := PriceEvent{Kind: PriceEventPurchase, Date: item.PurchaseDate, Price: item.PurchasePrice.Amount}
+	var valuations []PriceEvent
+	for _, h := range histories {
+		if h.Field != "purchase_price" || h.Action != entity.ItemHistoryActionUpdate || h.NewValue == nil {
+			continue
+		}
+		price, err := strconv.Atoi(*h.NewValue)
+		if err != nil {
+			continue
+		}
+		if len(valuations) == 0 && h.OldValue != nil {
+			if old, err := strconv.Atoi(*h.OldValue); err == nil {
+				purchase.Price = old
+			}
+		}
+		valuations = append(valuations, PriceEvent{Kind: PriceEventValuation, Date: h.CreatedAt.Format("2006-01-02"), Price: price})
+	}
+
+	events := append([]PriceEvent{purchase}, valuations...)
+	if sale != nil {
+		events = append(events, *sale)
+	}
+	// 同じ日付の記録は購入、評価、販売の順を保つ
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Date < events[j].Date })
+	return events
+}
+
+// pricePoints は最初の記録の月から end（YYYY-MM）までの月ごとの値を返す（end が最後の記録より前の場合は最後の記録の月まで）
+func pricePoints(events []PriceEvent, end string, interpolation PriceHistoryInterpolation) []PricePoint {
+	if len(events) == 0 {
+		return []PricePoint{}
+	}
+
+	first, ok := monthIndex(events[0].Date)
+	if !ok {
+		return []PricePoint{}
+	}
+	last := first
+	if i, ok := monthIndex(end); ok && i > last {
+		last = i
+	}
+
+	// 月ごとに最後の記録の価格を求める
+	recorded := map[int]int{}
+	kinds := map[int][]PriceEventKind{}
+	for _, e := range events {
+		i, ok := monthIndex(e.Date)
+		if !ok {
+			continue
+		}
+		recorded[i] = e.Price
+		kinds[i] = append(kinds[i], e.Kind)
+		if i > last {
+			last = i
+		}
+	}
+
+	points := make([]PricePoint, 0, last-first+1)
+	prev := first
+	for i := first; i <= last; i++ {
+		point := PricePoint{Month: monthString(i), Events: kinds[i]}
+		if point.Events == nil {
+			point.Events = []PriceEventKind{}
+		}
+		if price, ok := recorded[i]; ok {
+			point.Price = &price
+			prev = i
+		} else if interpolation != PriceHistoryInterpolationNone {
+			price := recorded[prev]
+			if interpolation == PriceHistoryInterpolationLinear {
+				if next, ok := nextRecordedMonth(recorded, i, last); ok {
+					ratio := float64(i-prev) / float64(next-prev)
+					price = int(math.Round(float64(recorded[prev]) + float64(recorded[next]-recorded[prev])*ratio))
+				}
+			}
+			point.Price = &price
+			point.Interpolated = true
+		}
+		points = append(points, point)
+	}
+	return points
+}
+
+func nextRecordedMonth(recorded map[int]int, from, last int) (int, bool) {
+	for i := from + 1; i <= last; i++ {
+		if _, ok := recorded[i]; ok {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// monthIndex は YYYY-MM または YYYY-MM-DD の月を通算の月に変換する
+func monthIndex(date string) (int, bool) {
+	if len(date) < 7 {
+		return 0, false
+	}
+	t, err := time.Parse("2006-01", date[:7])
+	if err != nil {
+		return 0, false
+	}
+	return t.Year()*12 + int(t.Month()) - 1, true
+}
+
+func monthString(index int) string {
+	return fmt.Sprintf("%04d-%02d", index/12, index%12+1)
+}
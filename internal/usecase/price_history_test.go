@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func newTestPriceHistoryUsecase(itemRepo ItemRepository, historyRepo ItemHistoryRepository, consignmentRepo ConsignmentRepository) *priceHistoryUsecase {
+	u := NewPriceHistoryUsecase(itemRepo, historyRepo, consignmentRepo).(*priceHistoryUsecase)
+	u.now = func() time.Time { return time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC) }
+	return u
+}
+
+func priceChange(oldValue, newValue string, at time.Time) *entity.ItemHistory {
+	return &entity.ItemHistory{
+		ItemID:    1,
+		Action:    entity.ItemHistoryActionUpdate,
+		Field:     "purchase_price",
+		OldValue:  &oldValue,
+		NewValue:  &newValue,
+		CreatedAt: at,
+	}
+}
+
+// prices はグラフの各月の値を並べる（値がない月は -1）
+func prices(points []PricePoint) []int {
+	values := make([]int, 0, len(points))
+	for _, p := range points {
+		if p.Price == nil {
+			values = append(values, -1)
+			continue
+		}
+		values = append(values, *p.Price)
+	}
+	return values
+}
+
+func TestPriceHistoryUsecase_Get(t *testing.T) {
+	item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1300000, "2024-01-15")
+	item.ID = 1
+	histories := []*entity.ItemHistory{
+		{ItemID: 1, Action: entity.ItemHistoryActionUpdate, Field: "name", CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		priceChange("1000000", "1300000", time.Date(2024, 4, 20, 0, 0, 0, 0, time.UTC)),
+	}
+
+	newRepos := func(consignment *entity.Consignment) (*MockItemRepository, *MockItemHistoryRepository, *MockConsignmentRepository) {
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("FindByItemID", mock.Anything, int64(1)).Return(histories, nil)
+		consignmentRepo := new(MockConsignmentRepository)
+		if consignment != nil {
+			consignmentRepo.On("FindByItemID", mock.Anything, int64(1)).Return(consignment, nil)
+		} else {
+			consignmentRepo.On("FindByItemID", mock.Anything, int64(1)).Return(nil, domainErrors.ErrConsignmentNotFound)
+		}
+		return itemRepo, historyRepo, consignmentRepo
+	}
+
+	t.Run("正常系: 購入価格の変更を評価額として今月まで直前の値を引き継ぐ", func(t *testing.T) {
+		usecase := newTestPriceHistoryUsecase(newRepos(nil))
+
+		history, err := usecase.Get(actorContext(), 1, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, PriceHistoryInterpolationPrevious, history.Interpolation)
+		assert.Equal(t, []PriceEvent{
+			{Kind: PriceEventPurchase, Date: "2024-01-15", Price: 1000000},
+			{Kind: PriceEventValuation, Date: "2024-04-20", Price: 1300000},
+		}, history.Events)
+		require.Len(t, history.Points, 6)
+		assert.Equal(t, "2024-01", history.Points[0].Month)
+		assert.Equal(t, "2024-06", history.Points[5].Month)
+		assert.Equal(t, []int{1000000, 1000000, 1000000, 1300000, 1300000, 1300000}, prices(history.Points))
+		assert.False(t, history.Points[0].Interpolated)
+		assert.True(t, history.Points[1].Interpolated)
+		assert.Equal(t, []PriceEventKind{PriceEventValuation}, history.Points[3].Events)
+		assert.Equal(t, []PriceEventKind{}, history.Points[1].Events)
+	})
+
+	t.Run("正常系: linear は前後の記録の間を直線で補う", func(t *testing.T) {
+		usecase := newTestPriceHistoryUsecase(newRepos(nil))
+
+		history, err := usecase.Get(actorContext(), 1, PriceHistoryInterpolationLinear)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{1000000, 1100000, 1200000, 1300000, 1300000, 1300000}, prices(history.Points))
+	})
+
+	t.Run("正常系: none は記録のない月を null にする", func(t *testing.T) {
+		usecase := newTestPriceHistoryUsecase(newRepos(nil))
+
+		history, err := usecase.Get(actorContext(), 1, PriceHistoryInterpolationNone)
+		require.NoError(t, err)
+
+		assert.Equal(t, []int{1000000, -1, -1, 1300000, -1, -1}, prices(history.Points))
+		assert.False(t, history.Points[1].Interpolated)
+	})
+
+	t.Run("正常系: 売れたアイテムは販売月までで販売価格を含める", func(t *testing.T) {
+		sold := &entity.Consignment{ItemID: 1, AgreedPrice: 1500000, Status: entity.ConsignmentStatusSold, UpdatedAt: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)}
+		usecase := newTestPriceHistoryUsecase(newRepos(sold))
+
+		history, err := usecase.Get(actorContext(), 1, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, PriceEvent{Kind: PriceEventSale, Date: "2024-05-03", Price: 1500000}, history.Events[2])
+		assert.Equal(t, []int{1000000, 1000000, 1000000, 1300000, 1500000}, prices(history.Points))
+	})
+
+	t.Run("正常系: 外貨建てのアイテムは円の販売価格を含めない", func(t *testing.T) {
+		sold := &entity.Consignment{ItemID: 1, AgreedPrice: 1500000, Status: entity.ConsignmentStatusSold, UpdatedAt: time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC)}
+		itemRepo, historyRepo, consignmentRepo := newRepos(sold)
+		usd := *item
+		usd.PurchasePrice = entity.NewMoney(1300000, "USD")
+		itemRepo.ExpectedCalls = nil
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&usd, nil)
+		usecase := newTestPriceHistoryUsecase(itemRepo, historyRepo, consignmentRepo)
+
+		history, err := usecase.Get(actorContext(), 1, "")
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.CurrencyUSD, history.Currency)
+		assert.Len(t, history.Events, 2)
+		assert.Len(t, history.Points, 6)
+	})
+
+	t.Run("異常系: 補い方が不正", func(t *testing.T) {
+		usecase := newTestPriceHistoryUsecase(new(MockItemRepository), new(MockItemHistoryRepository), new(MockConsignmentRepository))
+
+		_, err := usecase.Get(actorContext(), 1, "cubic")
+		require.Error(t, err)
+
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, "interpolation", errs[0].Field)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテム", func(t *testing.T) {
+		other := *item
+		other.UserID = 99
+		itemRepo := new(MockItemRepository)
+		itemRepo.On("FindByID", mock.Anything, int64(1)).Return(&other, nil)
+		usecase := newTestPriceHistoryUsecase(itemRepo, new(MockItemHistoryRepository), new(MockConsignmentRepository))
+
+		_, err := usecase.Get(actorContext(), 1, "")
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("異常系: 未認証", func(t *testing.T) {
+		usecase := newTestPriceHistoryUsecase(new(MockItemRepository), new(MockItemHistoryRepository), new(MockConsignmentRepository))
+
+		_, err := usecase.Get(context.Background(), 1, "")
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
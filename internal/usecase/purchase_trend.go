@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// PurchaseTrendReport は購入日の期間ごとのアイテムの件数と購入価格の合計
+type PurchaseTrendReport struct {
+	// GroupBy は期間の単位（month / year）
+	GroupBy entity.PurchasePeriodUnit `json:"group_by"`
+	// Currency は金額の通貨（操作者の表示通貨）
+	Currency entity.Currency `json:"currency"`
+	// Periods は最初の購入から最後の購入までの期間ごとの集計（古い順。購入のない期間も0件で含む）
+	Periods []PurchasePeriod `json:"periods"`
+	// Total はすべての期間の合計
+	Total PurchasePeriod `json:"total"`
+	// Undated は購入日が操作者に非表示のため期間に含めないアイテムの数
+	Undated int `json:"undated"`
+}
+
+// PurchasePeriod は期間の件数と購入価格の合計（Currency の最小単位）
+type PurchasePeriod struct {
+	// Period は YYYY-MM（月ごと）または YYYY（年ごと）。Total では空
+	Period string `json:"period,omitempty"`
+	Count  int    `json:"count"`
+	// ValuedCount は金額の集計に含めたアイテムの数（換算できない外貨建てと購入価格が非表示のアイテムを除く）
+	ValuedCount int   `json:"valued_count"`
+	Spend       int64 `json:"spend"`
+}
+
+// 期間の単位ごとの期間の書式と次の期間
+var purchasePeriodSteps = map[entity.PurchasePeriodUnit]struct {
+	layout string
+	next   func(time.Time) time.Time
+}{
+	entity.PurchasePeriodMonth: {"2006-01", func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+	entity.PurchasePeriodYear:  {"2006", func(t time.Time) time.Time { return t.AddDate(1, 0, 0) }},
+}
+
+// GetPurchaseTrend は購入日の月・年ごとのアイテムの件数と購入価格の合計を古い順に返す（groupBy の既定は month）。
+// 集計はリポジトリで期間・通貨ごとに行い、通貨ごとの合計を表示通貨に換算する
+func (u *itemUsecase) GetPurchaseTrend(ctx context.Context, groupBy string) (*PurchaseTrendReport, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	unit := entity.PurchasePeriodUnit(groupBy)
+	if unit == "" {
+		unit = entity.PurchasePeriodMonth
+	}
+	if !unit.IsValid() {
+		return nil, fmt.Errorf("%w: group_by must be one of: month, year", domainErrors.ErrInvalidInput)
+	}
+
+	values, err := u.itemRepo.GetPurchasesByPeriod(ctx, itemScope(actor), unit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get purchase trend: %w", err)
+	}
+
+	valuation := newValuation(actor, u.converter)
+	report := &PurchaseTrendReport{GroupBy: unit, Currency: valuation.currency, Periods: []PurchasePeriod{}}
+	periods := make(map[string]*PurchasePeriod)
+	for _, v := range values {
+		redacted := entity.ItemRedactionFor(actor, v.OrgID)
+		if slices.Contains(redacted, entity.ItemFieldPurchaseDate) {
+			report.Undated += v.Count
+			continue
+		}
+		period, ok := periods[v.Period]
+		if !ok {
+			period = &PurchasePeriod{Period: v.Period}
+			periods[v.Period] = period
+		}
+		period.Count += v.Count
+		report.Total.Count += v.Count
+
+		// 購入価格を非表示にする組織のアイテムは件数のみ数える
+		if slices.Contains(redacted, entity.ItemFieldPurchasePrice) {
+			continue
+		}
+		spend, ok, err := valuation.convert(ctx, v.Value, v.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		period.ValuedCount += v.Count
+		period.Spend = entity.AddAmount(period.Spend, spend)
+		report.Total.ValuedCount += v.Count
+		report.Total.Spend = entity.AddAmount(report.Total.Spend, spend)
+	}
+
+	if report.Periods, err = fillPurchasePeriods(unit, periods); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// fillPurchasePeriods は最初の期間から最後の期間までを古い順に並べ、購入のない期間を0件で補う
+func fillPurchasePeriods(unit entity.PurchasePeriodUnit, periods map[string]*PurchasePeriod) ([]PurchasePeriod, error) {
+	keys := make([]string, 0, len(periods))
+	for key := range periods {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	if len(keys) == 0 {
+		return []PurchasePeriod{}, nil
+	}
+
+	step := purchasePeriodSteps[unit]
+	first, err := time.Parse(step.layout, keys[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid purchase period %q: %w", keys[0], err)
+	}
+	last, err := time.Parse(step.layout, keys[len(keys)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid purchase period %q: %w", keys[len(keys)-1], err)
+	}
+	filled := []PurchasePeriod{}
+	for t := first; !t.After(last); t = step.next(t) {
+		key := t.Format(step.layout)
+		if period, ok := periods[key]; ok {
+			filled = append(filled, *period)
+			continue
+		}
+		filled = append(filled, PurchasePeriod{Period: key})
+	}
+	return filled, nil
+}
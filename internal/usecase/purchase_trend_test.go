@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetPurchaseTrend(t *testing.T) {
+	t.Run("正常系: 月ごとの件数と購入価格の合計を古い順に返し、購入のない月を0件で補う", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetPurchasesByPeriod", mock.Anything, testActor.ID, entity.PurchasePeriodMonth).Return([]entity.PurchasePeriodValue{
+			{Period: "2023-11", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000},
+			{Period: "2023-11", Currency: entity.CurrencyJPY, OrgID: 10, Count: 1, Value: 500000},
+			// 換算できない外貨建てのアイテムは件数のみ数える
+			{Period: "2024-02", Currency: entity.CurrencyUSD, Count: 1, Value: 120000},
+		}, nil)
+
+		report, err := NewItemUsecase(mockRepo).GetPurchaseTrend(actorContext(), "")
+		require.NoError(t, err)
+		assert.Equal(t, entity.PurchasePeriodMonth, report.GroupBy)
+		assert.Equal(t, entity.CurrencyJPY, report.Currency)
+		assert.Equal(t, []PurchasePeriod{
+			{Period: "2023-11", Count: 3, ValuedCount: 3, Spend: 3500000},
+			{Period: "2023-12"},
+			{Period: "2024-01"},
+			{Period: "2024-02", Count: 1},
+		}, report.Periods)
+		assert.Equal(t, PurchasePeriod{Count: 4, ValuedCount: 3, Spend: 3500000}, report.Total)
+	})
+
+	t.Run("正常系: 年ごとに表示通貨に換算して合計する", func(t *testing.T) {
+		usdActor := &entity.User{ID: testActor.ID, Email: testActor.Email, Role: entity.RoleEditor, PreferredCurrency: entity.CurrencyUSD}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetPurchasesByPeriod", mock.Anything, testActor.ID, entity.PurchasePeriodYear).Return([]entity.PurchasePeriodValue{
+			{Period: "2022", Currency: entity.CurrencyJPY, Count: 1, Value: 1500000},
+			{Period: "2024", Currency: entity.CurrencyUSD, Count: 1, Value: 250000},
+		}, nil)
+		converter := new(MockCurrencyConverter)
+		converter.On("Convert", mock.Anything, int64(1500000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(1000000), nil)
+
+		report, err := NewItemUsecase(mockRepo, WithCurrencyConverter(converter)).GetPurchaseTrend(WithActor(context.Background(), usdActor), "year")
+		require.NoError(t, err)
+		assert.Equal(t, []PurchasePeriod{
+			{Period: "2022", Count: 1, ValuedCount: 1, Spend: 1000000},
+			{Period: "2023"},
+			{Period: "2024", Count: 1, ValuedCount: 1, Spend: 250000},
+		}, report.Periods)
+		assert.Equal(t, int64(1250000), report.Total.Spend)
+	})
+
+	t.Run("正常系: 購入日が非表示の組織のアイテムは期間に含めない", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleViewer: {entity.ItemFieldPurchaseDate}})
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetPurchasesByPeriod", mock.Anything, orgViewer.ID, entity.PurchasePeriodMonth).Return([]entity.PurchasePeriodValue{
+			{Period: "2024-01", Currency: entity.CurrencyJPY, OrgID: redactionOrgID, Count: 2, Value: 3000000},
+		}, nil)
+
+		report, err := NewItemUsecase(mockRepo).GetPurchaseTrend(WithActor(context.Background(), orgViewer), "month")
+		require.NoError(t, err)
+		assert.Empty(t, report.Periods)
+		assert.Equal(t, 2, report.Undated)
+		assert.Equal(t, PurchasePeriod{}, report.Total)
+	})
+
+	t.Run("異常系: 不正な期間の単位", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).GetPurchaseTrend(actorContext(), "week")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "GetPurchasesByPeriod", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
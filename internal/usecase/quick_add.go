@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 一度に解析できる最大行数
+const maxQuickAddLines = 100
+
+type QuickAddInput struct {
+	Text string `json:"text"`
+}
+
+// QuickAddPreview は1行分の解析結果（登録はせず、確認用に返す）
+type QuickAddPreview struct {
+	Line   int             `json:"line"`
+	Raw    string          `json:"raw"`
+	Input  CreateItemInput `json:"input"`
+	Valid  bool            `json:"valid"`
+	Errors []string        `json:"errors"`
+}
+
+// カテゴリーの別名
+var categoryAliases = map[string]string{
+	"watch":   "時計",
+	"watches": "時計",
+	"ウォッチ":    "時計",
+	"bag":     "バッグ",
+	"bags":    "バッグ",
+	"かばん":     "バッグ",
+	"鞄":       "バッグ",
+	"jewelry": "ジュエリー",
+	"アクセサリー":  "ジュエリー",
+	"shoes":   "靴",
+	"シューズ":    "靴",
+	"other":   "その他",
+}
+
+// ParseQuickAdd は「ブランド 名前 カテゴリー 価格 購入日」形式の複数行テキストを解析する。
+// カテゴリー・購入日・価格は順不同で判定し（数値が複数ある場合は最後のものを価格とする）、
+// 残りの先頭をブランド、それ以降を名前とする。
+func ParseQuickAdd(text string) ([]QuickAddPreview, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	var previews []QuickAddPreview
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if len(previews) >= maxQuickAddLines {
+			return nil, fmt.Errorf("text must contain %d lines or less", maxQuickAddLines)
+		}
+		previews = append(previews, parseQuickAddLine(i+1, line))
+	}
+
+	if len(previews) == 0 {
+		return nil, fmt.Errorf("text is required")
+	}
+	return previews, nil
+}
+
+func parseQuickAddLine(lineNo int, line string) QuickAddPreview {
+	preview := QuickAddPreview{Line: lineNo, Raw: strings.TrimSpace(line)}
+
+	var rest []string
+	priceIndex := -1
+	for _, token := range strings.FieldsFunc(line, isQuickAddSeparator) {
+		normalized := toHalfWidthDigits(token)
+
+		if preview.Input.Category == "" {
+			if category, ok := parseCategory(normalized); ok {
+				preview.Input.Category = category
+				continue
+			}
+		}
+		if preview.Input.PurchaseDate == "" {
+			if date, ok := parseDate(normalized); ok {
+				preview.Input.PurchaseDate = date
+				continue
+			}
+		}
+		if price, ok := parsePrice(normalized); ok {
+			preview.Input.PurchasePrice = entity.Decimal{Units: int64(price)}
+			priceIndex = len(rest)
+		}
+		rest = append(rest, token)
+	}
+	if priceIndex >= 0 {
+		rest = append(rest[:priceIndex], rest[priceIndex+1:]...)
+	}
+
+	if len(rest) > 0 {
+		preview.Input.Brand = rest[0]
+	}
+	if len(rest) > 1 {
+		preview.Input.Name = strings.Join(rest[1:], " ")
+	}
+
+	preview.Errors = validateCreateItemInput(preview.Input)
+	preview.Valid = len(preview.Errors) == 0
+
+	return preview
+}
+
+// validateCreateItemInput は登録内容を検証し、エラーを項目ごとに返す（エラーがない場合は空）
+func validateCreateItemInput(input CreateItemInput) []string {
+	price, err := purchasePrice(input.PurchasePrice, input.PurchaseCurrency)
+	if err == nil {
+		_, err = entity.NewItem(input.Name, input.Category, input.Brand, price, input.PurchaseDate)
+	}
+	if err != nil {
+		fieldErrs, ok := domainErrors.AsValidationErrors(err)
+		if !ok {
+			return []string{err.Error()}
+		}
+		messages := make([]string, 0, len(fieldErrs))
+		for _, fe := range fieldErrs {
+			messages = append(messages, fe.Message)
+		}
+		return messages
+	}
+	return []string{}
+}
+
+// 空白（全角含む）と読点を区切りとして扱う。カンマは価格の桁区切りに使われるため区切りにしない
+func isQuickAddSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '、'
+}
+
+func toHalfWidthDigits(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= '０' && r <= '９':
+			return r - '０' + '0'
+		case r == '／':
+			return '/'
+		case r == '－':
+			return '-'
+		case r == '，':
+			return ','
+		}
+		return r
+	}, s)
+}
+
+func parseCategory(token string) (string, bool) {
+	for _, category := range entity.GetValidCategories() {
+		if token == category {
+			return category, true
+		}
+	}
+	category, ok := categoryAliases[strings.ToLower(token)]
+	return category, ok
+}
+
+// 2023-01-15 / 2023/1/15 / 2023.01.15 を YYYY-MM-DD に正規化する
+func parseDate(token string) (string, bool) {
+	parts := strings.FieldsFunc(token, func(r rune) bool { return r == '-' || r == '/' || r == '.' })
+	if len(parts) != 3 || len(parts[0]) != 4 {
+		return "", false
+	}
+
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return "", false
+		}
+		nums[i] = n
+	}
+
+	date := fmt.Sprintf("%04d-%02d-%02d", nums[0], nums[1], nums[2])
+	if _, err := time.Parse("2006-01-02", date); err != nil {
+		return "", false
+	}
+	return date, true
+}
+
+// 1500000 / 1,500,000 / ¥1500000 / 1500000円 / 150万 / 150万円 を整数に変換する
+func parsePrice(token string) (int, bool) {
+	s := strings.TrimPrefix(strings.TrimPrefix(token, "¥"), "￥")
+	s = strings.TrimSuffix(s, "円")
+
+	multiplier := 1
+	if strings.HasSuffix(s, "万") {
+		s = strings.TrimSuffix(s, "万")
+		multiplier = 10000
+	}
+	s = strings.ReplaceAll(s, ",", "")
+	if s == "" {
+		return 0, false
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n * multiplier, true
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected CreateItemInput
+		valid    bool
+	}{
+		{
+			name: "正常系: 基本形式",
+			line: "ROLEX デイトナ 時計 1500000 2023-01-15",
+			expected: CreateItemInput{
+				Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1500000}, PurchaseDate: "2023-01-15",
+			},
+			valid: true,
+		},
+		{
+			name: "正常系: 順不同・全角・単位付き",
+			line: "２０２３/２/２０　エルメス バーキン 30 ¥2,000,000 bag",
+			expected: CreateItemInput{
+				Name: "バーキン 30", Category: "バッグ", Brand: "エルメス", PurchasePrice: entity.Decimal{Units: 2000000}, PurchaseDate: "2023-02-20",
+			},
+			valid: true,
+		},
+		{
+			name: "正常系: 万円表記",
+			line: "Tiffany ネックレス ジュエリー 30万円 2023.3.10",
+			expected: CreateItemInput{
+				Name: "ネックレス", Category: "ジュエリー", Brand: "Tiffany", PurchasePrice: entity.Decimal{Units: 300000}, PurchaseDate: "2023-03-10",
+			},
+			valid: true,
+		},
+		{
+			name: "異常系: 名前とカテゴリーが不足",
+			line: "Apple 50000 2023-05-12",
+			expected: CreateItemInput{
+				Brand: "Apple", PurchasePrice: entity.Decimal{Units: 50000}, PurchaseDate: "2023-05-12",
+			},
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previews, err := ParseQuickAdd(tt.line)
+			require.NoError(t, err)
+			require.Len(t, previews, 1)
+
+			assert.Equal(t, tt.expected, previews[0].Input)
+			assert.Equal(t, tt.valid, previews[0].Valid)
+			if !tt.valid {
+				assert.NotEmpty(t, previews[0].Errors)
+			}
+		})
+	}
+}
+
+func TestParseQuickAdd_MultipleLines(t *testing.T) {
+	previews, err := ParseQuickAdd("ROLEX デイトナ 時計 1500000 2023-01-15\n\r\n\nルブタン パンプス 靴 150000 2023-04-05\r\n")
+	require.NoError(t, err)
+	require.Len(t, previews, 2)
+	assert.Equal(t, 1, previews[0].Line)
+	assert.Equal(t, 4, previews[1].Line)
+
+	_, err = ParseQuickAdd("  \n ")
+	assert.Error(t, err)
+
+	_, err = ParseQuickAdd(strings.Repeat("ROLEX デイトナ 時計 1 2023-01-15\n", maxQuickAddLines+1))
+	assert.Error(t, err)
+}
+
+func TestValidateCreateItemInput(t *testing.T) {
+	errs := validateCreateItemInput(CreateItemInput{
+		Name: "デイトナ", Category: "家具", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: -1}, PurchaseDate: "2023-01-15",
+	})
+
+	// カンマを含むメッセージも1件のエラーとして返す
+	assert.Equal(t, []string{
+		"category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
+		"purchase_price must be 0 or greater",
+	}, errs)
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// QuickStatsMaxAge はクイック集計に使うカテゴリー集計を再利用する期間
+const QuickStatsMaxAge = 30 * time.Second
+
+// QuickStats は画面のヘッダーに表示するアイテムの件数と購入価格の合計
+type QuickStats struct {
+	ItemCount int `json:"item_count"`
+	// Currency は TotalValue と TotalValueChange の通貨（操作者の表示通貨）
+	Currency   entity.Currency `json:"currency"`
+	TotalValue int64           `json:"total_value"`
+	// ItemCountChange と TotalValueChange は先月末からの増加分（今月登録したアイテムの件数と購入価格の合計）
+	ItemCountChange  int   `json:"item_count_change"`
+	TotalValueChange int64 `json:"total_value_change"`
+	// AsOf は集計した時刻（最大 QuickStatsMaxAge 前）
+	AsOf time.Time `json:"as_of"`
+}
+
+// GetQuickStats は直近のカテゴリー集計からアイテムの件数と購入価格の合計を返す。
+// 集計が QuickStatsMaxAge より古い場合と、同じリクエスト（トークン）で集計の後に書き込んだ場合はリポジトリから集計し直す。
+// アイテムの登録・更新・削除では書き込んだアイテムを含む集計を破棄するため、トークンを扱わない構成でも書き込みを反映する
+func (u *itemUsecase) GetQuickStats(ctx context.Context) (*QuickStats, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := itemScope(actor)
+	var writtenAt time.Time
+	if u.lastWrite != nil {
+		writtenAt = u.lastWrite(ctx)
+	}
+	values, asOf, ok := u.summaryCache.get(scope, u.now(), writtenAt)
+	if !ok {
+		if values, err = u.loadCategoryValues(ctx, scope); err != nil {
+			return nil, fmt.Errorf("failed to get quick stats: %w", err)
+		}
+		asOf = u.now()
+	}
+
+	valuation := newValuation(actor, u.converter)
+	stats := &QuickStats{Currency: valuation.currency, AsOf: asOf}
+	for _, v := range values {
+		stats.ItemCount += v.Count
+		stats.ItemCountChange += v.AddedCount
+		// 購入価格を非表示にする組織のアイテムは件数のみ数える
+		if slices.Contains(entity.ItemRedactionFor(actor, v.OrgID), entity.ItemFieldPurchasePrice) {
+			continue
+		}
+		value, ok, err := valuation.convert(ctx, v.Value, v.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		added, _, err := valuation.convert(ctx, v.AddedValue, v.Currency)
+		if err != nil {
+			return nil, err
+		}
+		stats.TotalValue = entity.AddAmount(stats.TotalValue, value)
+		stats.TotalValueChange = entity.AddAmount(stats.TotalValueChange, added)
+	}
+
+	return stats, nil
+}
+
+// loadCategoryValues はリポジトリからカテゴリー集計を取得し、クイック集計用に保持する。
+// 同時に届く同じ範囲の集計はリポジトリの呼び出しを1回にまとめる
+// （結果のスライスは呼び出し元の間で共有するため読み取りにのみ使う）
+func (u *itemUsecase) loadCategoryValues(ctx context.Context, scope int64) ([]entity.CategoryValue, error) {
+	return coalesce(ctx, &u.summaryFlight, u.summaryStats, "summary:"+strconv.FormatInt(scope, 10),
+		func(ctx context.Context) ([]entity.CategoryValue, error) {
+			generation := u.summaryCache.generation()
+			values, err := u.itemRepo.GetSummaryByCategory(ctx, scope)
+			if err != nil {
+				return nil, err
+			}
+			u.summaryCache.put(scope, values, u.now(), generation)
+			return values, nil
+		})
+}
+
+// invalidateSummary は書き込んだアイテムを含むクイック集計用のカテゴリー集計を破棄する。
+// 組織から個人のアイテムに戻した場合は元の組織のメンバーの集計にも含まれていたため、すべての集計を破棄する
+func (u *itemUsecase) invalidateSummary(histories []*entity.ItemHistory, items ...*entity.Item) {
+	moved := slices.ContainsFunc(histories, func(h *entity.ItemHistory) bool {
+		return h.Field == "org_id" && h.OldValue != nil && *h.OldValue != "0"
+	})
+	u.summaryCache.invalidate(moved, items...)
+}
+
+// summaryCache は範囲ごとの直近のカテゴリー集計を QuickStatsMaxAge の間保持する
+type summaryCache struct {
+	mu      sync.Mutex
+	entries map[int64]summaryCacheEntry
+	// invalidations は破棄した回数（破棄の前に読み込み始めた集計を保持しないために使う）
+	invalidations uint64
+}
+
+type summaryCacheEntry struct {
+	values    []entity.CategoryValue
+	fetchedAt time.Time
+}
+
+func newSummaryCache() *summaryCache {
+	return &summaryCache{entries: make(map[int64]summaryCacheEntry)}
+}
+
+// get は now の時点で有効な集計と集計した時刻を返す。
+// writtenAt（ゼロ値は書き込みなし）以前に集計した結果は書き込みを含まない可能性があるため返さない
+func (c *summaryCache) get(scope int64, now, writtenAt time.Time) ([]entity.CategoryValue, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[scope]
+	if !ok || now.Sub(entry.fetchedAt) >= QuickStatsMaxAge {
+		return nil, time.Time{}, false
+	}
+	if !writtenAt.IsZero() && !entry.fetchedAt.After(writtenAt) {
+		return nil, time.Time{}, false
+	}
+	return entry.values, entry.fetchedAt, true
+}
+
+// generation は集計を読み込み始める時点の破棄の回数を返す（put に渡す）
+func (c *summaryCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.invalidations
+}
+
+// put は集計を保持し、期限切れの集計を取り除く。
+// generation（読み込み始めた時点の破棄の回数）の後に破棄した場合は、書き込みを含まない可能性があるため保持しない
+func (c *summaryCache) put(scope int64, values []entity.CategoryValue, now time.Time, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.invalidations {
+		return
+	}
+	for key, entry := range c.entries {
+		if now.Sub(entry.fetchedAt) >= QuickStatsMaxAge {
+			delete(c.entries, key)
+		}
+	}
+	c.entries[scope] = summaryCacheEntry{values: values, fetchedAt: now}
+}
+
+// invalidate は items を含む範囲の集計（all ではすべての集計）を破棄する。個人のアイテムは所有者と管理者（範囲 0）の集計に含まれる。
+// 組織のアイテムはメンバー全員の集計に含まれ、ここではメンバーが分からないためすべての集計を破棄する
+func (c *summaryCache) invalidate(all bool, items ...*entity.Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.invalidations++
+	if all || slices.ContainsFunc(items, func(item *entity.Item) bool { return item.OrgID != 0 }) {
+		clear(c.entries)
+		return
+	}
+	delete(c.entries, 0)
+	for _, item := range items {
+		delete(c.entries, item.UserID)
+	}
+}
@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetQuickStats(t *testing.T) {
+	values := []entity.CategoryValue{
+		{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000, AddedCount: 1, AddedValue: 1000000},
+		{Category: "バッグ", Currency: entity.CurrencyJPY, Count: 1, Value: 2000000},
+		{Category: "バッグ", Currency: entity.CurrencyUSD, Count: 1, Value: 150000, AddedCount: 1, AddedValue: 150000},
+	}
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	newUsecase := func(mockRepo *MockItemRepository, clock *time.Time) ItemUsecase {
+		u := NewItemUsecase(mockRepo).(*itemUsecase)
+		u.now = func() time.Time { return *clock }
+		return u
+	}
+
+	t.Run("正常系: 件数と合計、今月の増加分を返す（換算できない外貨建ての金額は件数のみ）", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		clock := now
+
+		stats, err := newUsecase(mockRepo, &clock).GetQuickStats(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, &QuickStats{
+			ItemCount: 4, Currency: entity.CurrencyJPY, TotalValue: 5000000,
+			ItemCountChange: 2, TotalValueChange: 1000000, AsOf: now,
+		}, stats)
+	})
+
+	t.Run("正常系: QuickStatsMaxAge の間はカテゴリー集計を再利用する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		clock := now
+		u := newUsecase(mockRepo, &clock)
+
+		// ダッシュボードの集計の結果もクイック集計に使う
+		_, err := u.GetCategorySummary(actorContext(), "")
+		require.NoError(t, err)
+		clock = now.Add(QuickStatsMaxAge - time.Second)
+		stats, err := u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, now, stats.AsOf)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+
+		clock = now.Add(QuickStatsMaxAge)
+		stats, err = u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, clock, stats.AsOf)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+	})
+
+	t.Run("正常系: 集計の後に同じリクエストで書き込んだ場合は集計し直す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		clock := now
+		var writtenAt time.Time
+		u := NewItemUsecase(mockRepo, WithLastWrite(func(context.Context) time.Time { return writtenAt })).(*itemUsecase)
+		u.now = func() time.Time { return clock }
+
+		_, err := u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+
+		// 集計より前の書き込みでは再利用する
+		writtenAt = now.Add(-time.Second)
+		clock = now.Add(time.Second)
+		_, err = u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+
+		writtenAt = now.Add(time.Second)
+		clock = now.Add(2 * time.Second)
+		stats, err := u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, clock, stats.AsOf)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+
+		// 集計し直した結果は書き込みより後の集計なので再利用する
+		clock = now.Add(3 * time.Second)
+		_, err = u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+	})
+
+	t.Run("正常系: アイテムの登録・更新・削除の後は書き込みの時刻がなくても集計し直す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, orgViewer.ID).Return([]entity.CategoryValue{}, nil)
+		item := &entity.Item{ID: 1, UserID: testActor.ID, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX",
+			PurchasePrice: entity.JPY(1500000), PurchaseDate: "2023-01-15"}
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(item, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.Anything).Return(item, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		clock := now
+		u := newUsecase(mockRepo, &clock)
+		viewerCtx := WithActor(context.Background(), orgViewer)
+
+		_, err := u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		_, err = u.GetQuickStats(viewerCtx)
+		require.NoError(t, err)
+
+		_, err = u.CreateItem(actorContext(), CreateItemInput{Name: item.Name, Category: item.Category, Brand: item.Brand,
+			PurchasePrice: entity.Decimal{Units: 1500000}, PurchaseDate: item.PurchaseDate})
+		require.NoError(t, err)
+		_, err = u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 3)
+
+		// 個人のアイテムの書き込みでは、他のユーザーの集計は再利用する
+		_, err = u.GetQuickStats(viewerCtx)
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 3)
+
+		name := "ロレックス デイトナ 116500LN"
+		_, err = u.UpdateItem(actorContext(), 1, UpdateItemInput{Name: &name})
+		require.NoError(t, err)
+		_, err = u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 4)
+
+		require.NoError(t, u.DeleteItem(actorContext(), 1))
+		_, err = u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 5)
+	})
+
+	t.Run("正常系: 組織のアイテムの書き込みではすべての集計を破棄する", func(t *testing.T) {
+		cache := newSummaryCache()
+		cache.put(testActor.ID, values, now, cache.generation())
+		cache.put(orgViewer.ID, values, now, cache.generation())
+
+		cache.invalidate(false, &entity.Item{UserID: testActor.ID, OrgID: redactionOrgID})
+		_, _, ok := cache.get(orgViewer.ID, now, time.Time{})
+		assert.False(t, ok)
+
+		// 組織から個人のアイテムに戻した場合も同じ
+		cache.put(orgViewer.ID, values, now, cache.generation())
+		cache.invalidate(true, &entity.Item{UserID: testActor.ID})
+		_, _, ok = cache.get(orgViewer.ID, now, time.Time{})
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 読み込み中に破棄した集計は保持しない", func(t *testing.T) {
+		cache := newSummaryCache()
+		generation := cache.generation()
+		cache.invalidate(false, &entity.Item{UserID: testActor.ID})
+		cache.put(testActor.ID, values, now, generation)
+
+		_, _, ok := cache.get(testActor.ID, now, time.Time{})
+		assert.False(t, ok)
+	})
+
+	t.Run("正常系: 集計は範囲ごとに保持する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, orgViewer.ID).Return([]entity.CategoryValue{}, nil)
+		clock := now
+		u := newUsecase(mockRepo, &clock)
+
+		_, err := u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		stats, err := u.GetQuickStats(WithActor(context.Background(), orgViewer))
+		require.NoError(t, err)
+		assert.Equal(t, 0, stats.ItemCount)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+	})
+
+	t.Run("正常系: 購入価格が非表示の組織のアイテムは件数のみ数える", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, orgViewer.ID).Return([]entity.CategoryValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, OrgID: redactionOrgID, Count: 1, Value: redactedPrice, AddedCount: 1, AddedValue: redactedPrice},
+			{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 300000},
+		}, nil)
+		clock := now
+
+		stats, err := newUsecase(mockRepo, &clock).GetQuickStats(WithActor(context.Background(), orgViewer))
+		require.NoError(t, err)
+		assert.Equal(t, 3, stats.ItemCount)
+		assert.Equal(t, int64(300000), stats.TotalValue)
+		assert.Equal(t, 1, stats.ItemCountChange)
+		assert.Equal(t, int64(0), stats.TotalValueChange)
+	})
+
+	t.Run("異常系: 集計に失敗した結果は保持しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(nil, domainErrors.ErrDatabaseError).Once()
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil).Once()
+		clock := now
+		u := newUsecase(mockRepo, &clock)
+
+		_, err := u.GetQuickStats(actorContext())
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		stats, err := u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, 4, stats.ItemCount)
+	})
+
+	t.Run("異常系: 操作者がいない", func(t *testing.T) {
+		_, err := NewItemUsecase(new(MockItemRepository)).GetQuickStats(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
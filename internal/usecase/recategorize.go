@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// カテゴリーの一括変更で変更できるアイテムの最大数（絞り込みで指定する場合も含む）
+const maxRecategorizeItems = 1000
+
+// BulkRecategorizeInput はカテゴリーを変更するアイテム（ids か filter のどちらか）と変更先のカテゴリー
+type BulkRecategorizeInput struct {
+	IDs    []int64                 `json:"ids"`
+	Filter *BulkRecategorizeFilter `json:"filter"`
+	// Category は変更先のカテゴリー（英語の表示名も受け付ける）
+	Category string `json:"category"`
+	// DryRun は変更せずに変更するアイテムの件数のみ返す
+	DryRun bool `json:"dry_run"`
+}
+
+// BulkRecategorizeFilter はカテゴリーを変更するアイテムの絞り込み条件。
+// GET /items のクエリパラメータに置き換えて一覧と同じ関数で解釈するため、一覧の同じ名前の条件と同じ意味になる
+type BulkRecategorizeFilter struct {
+	Category  string   `json:"category"`
+	Brand     string   `json:"brand"`
+	Condition string   `json:"condition"`
+	Status    string   `json:"status"`
+	Tags      []string `json:"tags"`
+	OrgID     int64    `json:"org_id"`
+	// MinPrice・MaxPrice は PurchaseCurrency の補助単位を小数にした10進数（省略した通貨は JPY）
+	PurchaseCurrency string          `json:"purchase_currency"`
+	MinPrice         *entity.Decimal `json:"min_price"`
+	MaxPrice         *entity.Decimal `json:"max_price"`
+	PurchaseDateFrom string          `json:"purchase_date_from"`
+	PurchaseDateTo   string          `json:"purchase_date_to"`
+	// Attributes は属性の値での絞り込み（一覧の attr.<属性名>=値 と同じ）
+	Attributes map[string]string `json:"attributes"`
+}
+
+// query は絞り込み条件を GET /items のクエリパラメータにする
+func (f BulkRecategorizeFilter) query() url.Values {
+	query := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	set("category", f.Category)
+	set("brand", f.Brand)
+	set("condition", f.Condition)
+	set("status", f.Status)
+	set("purchase_currency", f.PurchaseCurrency)
+	set("purchase_date_from", f.PurchaseDateFrom)
+	set("purchase_date_to", f.PurchaseDateTo)
+	if f.OrgID != 0 {
+		query.Set("org_id", strconv.FormatInt(f.OrgID, 10))
+	}
+	if f.MinPrice != nil {
+		query.Set("min_price", f.MinPrice.String())
+	}
+	if f.MaxPrice != nil {
+		query.Set("max_price", f.MaxPrice.String())
+	}
+	for _, tag := range f.Tags {
+		query.Add("tag", tag)
+	}
+	for name, value := range f.Attributes {
+		set(entity.ItemFilterAttributePrefix+name, value)
+	}
+	return query
+}
+
+// BulkRecategorizeResult はカテゴリーの一括変更の結果（dry_run の場合は変更する予定の件数）
+type BulkRecategorizeResult struct {
+	Category string `json:"category"`
+	DryRun   bool   `json:"dry_run"`
+	// Matched は指定したアイテムの数（すでに変更先のカテゴリーのアイテムを含む）
+	Matched int `json:"matched"`
+	// Changed はカテゴリーを変更するアイテムの数
+	Changed int `json:"changed"`
+	// Skipped は絞り込みに一致したが操作者が変更できないため変更しないアイテムの数
+	Skipped int `json:"skipped"`
+	// FromCategories は変更するアイテムの変更前のカテゴリーごとの件数
+	FromCategories map[string]int `json:"from_categories"`
+	// AttributesDropped は変更先のカテゴリーにない属性を削除するアイテムの数
+	AttributesDropped int `json:"attributes_dropped"`
+	// ItemIDs はカテゴリーを変更するアイテム
+	ItemIDs []int64 `json:"item_ids"`
+}
+
+// BulkRecategorize はアイテムのカテゴリーを1つのトランザクションで変更する（1件でも変更できない場合は何も変更しない）。
+// 変更先のカテゴリーにない属性は削除する
+func (u *itemUsecase) BulkRecategorize(ctx context.Context, input BulkRecategorizeInput) (*BulkRecategorizeResult, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	category := entity.ResolveCategory(input.Category)
+	if category == "" {
+		return nil, domainErrors.ValidationErrors{{Field: "category", Code: domainErrors.CodeRequired, Message: "category is required"}}
+	}
+	if categories := entity.GetValidCategories(); !slices.Contains(categories, category) {
+		return nil, domainErrors.ValidationErrors{{Field: "category", Code: domainErrors.CodeInvalidChoice, Message: "category must be one of: " + strings.Join(categories, ", ")}}
+	}
+
+	var items []*entity.Item
+	skipped := 0
+	switch {
+	case len(input.IDs) > 0 && input.Filter != nil:
+		return nil, fmt.Errorf("%w: specify either ids or filter, not both", domainErrors.ErrInvalidInput)
+	case len(input.IDs) == 0 && input.Filter == nil:
+		return nil, fmt.Errorf("%w: ids or filter is required", domainErrors.ErrInvalidInput)
+	case input.Filter != nil:
+		items, skipped, err = u.findRecategorizeTargets(ctx, actor, *input.Filter)
+	default:
+		items, err = u.findBulkItems(ctx, actor, input.IDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkRecategorizeResult{
+		Category:       category,
+		DryRun:         input.DryRun,
+		Matched:        len(items) + skipped,
+		Skipped:        skipped,
+		FromCategories: map[string]int{},
+		ItemIDs:        []int64{},
+	}
+	var changed []*entity.Item
+	before := make(map[int64]entity.Item)
+	for _, item := range items {
+		if item.Category == category {
+			continue
+		}
+		before[item.ID] = *item
+		result.FromCategories[item.Category]++
+		item.Category = category
+		var dropped bool
+		item.Attributes, dropped = attributesForCategory(category, item.Attributes)
+		if dropped {
+			result.AttributesDropped++
+		}
+		changed = append(changed, item)
+		result.ItemIDs = append(result.ItemIDs, item.ID)
+	}
+	result.Changed = len(changed)
+	if result.Changed > maxRecategorizeItems {
+		return nil, fmt.Errorf("%w: at most %d items can be recategorized at once, narrow the filter", domainErrors.ErrInvalidInput, maxRecategorizeItems)
+	}
+	if input.DryRun || len(changed) == 0 {
+		return result, nil
+	}
+
+	now := u.now()
+	var histories []*entity.ItemHistory
+	for _, item := range changed {
+		old := before[item.ID]
+		histories = append(histories, entity.NewItemHistories(actor.ID, &old, item, now)...)
+	}
+	if _, err := u.updateManyWithHistory(ctx, changed, histories); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsVersionConflictError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to update items: %w", err)
+	}
+	return result, nil
+}
+
+// findBulkItems は ID で指定された変更するアイテムを返す（一括更新と同じく、見つからないか変更できないアイテムがあればエラー）
+func (u *itemUsecase) findBulkItems(ctx context.Context, actor *entity.User, ids []int64) ([]*entity.Item, error) {
+	ids, err := validateBulkItemIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*entity.Item, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		item, err := findWritableItem(ctx, u.itemRepo, actor, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				missing = append(missing, strconv.FormatInt(id, 10))
+				continue
+			}
+			if domainErrors.IsForbiddenError(err) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrItemNotFound, strings.Join(missing, ", "))
+	}
+	return items, nil
+}
+
+// findRecategorizeTargets は絞り込みに一致するアイテムのうち操作者が変更できるアイテムと、変更できないアイテムの数を返す
+func (u *itemUsecase) findRecategorizeTargets(ctx context.Context, actor *entity.User, input BulkRecategorizeFilter) ([]*entity.Item, int, error) {
+	filter, errs := entity.ParseItemFilterQuery(input.query())
+	if len(errs) > 0 {
+		return nil, 0, errs
+	}
+	filter, err := scopeItemFilter(actor, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	found, err := u.itemRepo.FindAll(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	items := make([]*entity.Item, 0, len(found))
+	skipped := 0
+	for _, item := range found {
+		if !canWriteItem(actor, item) {
+			skipped++
+			continue
+		}
+		items = append(items, item)
+	}
+	return items, skipped, nil
+}
+
+// attributesForCategory はカテゴリーの属性のスキーマで検証できる属性のみを返す（削除した属性があれば true）
+func attributesForCategory(category string, attrs entity.ItemAttributes) (entity.ItemAttributes, bool) {
+	if len(attrs) == 0 {
+		return attrs, false
+	}
+	kept := entity.ItemAttributes{}
+	dropped := false
+	for _, name := range slices.Sorted(maps.Keys(attrs)) {
+		normalized, err := validateItemAttributes(category, entity.ItemAttributes{name: attrs[name]})
+		if err != nil {
+			dropped = true
+			continue
+		}
+		maps.Copy(kept, normalized)
+	}
+	return kept, dropped
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_BulkRecategorize(t *testing.T) {
+	newItem := func(id int64, category string, attrs entity.ItemAttributes) *entity.Item {
+		item, _ := newOwnedItem("アイテム", category, "HERMÈS", 1000000, "2023-01-01")
+		item.ID = id
+		item.Attributes = attrs
+		return item
+	}
+
+	t.Run("正常系: 絞り込みに一致したアイテムのカテゴリーを変更し、変更先にない属性を削除する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID, Brand: "HERMÈS"}).Return([]*entity.Item{
+			newItem(1, "その他", nil),
+			newItem(2, "時計", entity.ItemAttributes{entity.AttributeMovement: "手巻き"}),
+			newItem(3, "バッグ", entity.ItemAttributes{entity.AttributeMaterial: "トゴ"}),
+		}, nil)
+		mockRepo.On("UpdateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+			return len(items) == 2 && items[0].ID == 1 && items[1].ID == 2 &&
+				items[0].Category == "バッグ" && items[1].Category == "バッグ" && len(items[1].Attributes) == 0
+		})).Return([]*entity.Item{newItem(1, "バッグ", nil), newItem(2, "バッグ", entity.ItemAttributes{})}, nil)
+
+		result, err := NewItemUsecase(mockRepo).BulkRecategorize(actorContext(), BulkRecategorizeInput{
+			Filter:   &BulkRecategorizeFilter{Brand: "HERMÈS"},
+			Category: "Bag",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &BulkRecategorizeResult{
+			Category:          "バッグ",
+			Matched:           3,
+			Changed:           2,
+			FromCategories:    map[string]int{"その他": 1, "時計": 1},
+			AttributesDropped: 1,
+			ItemIDs:           []int64{1, 2},
+		}, result)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: dry_run は件数のみ返して変更しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(1, "その他", nil), nil)
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(newItem(2, "時計", nil), nil)
+
+		result, err := NewItemUsecase(mockRepo).BulkRecategorize(actorContext(), BulkRecategorizeInput{
+			IDs:      []int64{1, 2, 1},
+			Category: "時計",
+			DryRun:   true,
+		})
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 2, result.Matched)
+		assert.Equal(t, 1, result.Changed)
+		assert.Equal(t, map[string]int{"その他": 1}, result.FromCategories)
+		mockRepo.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 絞り込みに一致した変更できないアイテムは変更しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: orgViewer.ID, Category: "時計"}).Return([]*entity.Item{newOrgItem()}, nil)
+
+		result, err := NewItemUsecase(mockRepo).BulkRecategorize(WithActor(context.Background(), orgViewer), BulkRecategorizeInput{
+			Filter:   &BulkRecategorizeFilter{Category: "時計"},
+			Category: "その他",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Matched)
+		assert.Equal(t, 1, result.Skipped)
+		assert.Equal(t, 0, result.Changed)
+		mockRepo.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 絞り込み条件は一覧の同じ名前のクエリパラメータと同じ条件になる", func(t *testing.T) {
+		listFilter, errs := entity.ParseItemFilterQuery(url.Values{
+			"category":           {"Watch"},
+			"tag":                {"Vintage"},
+			"min_price":          {"1000"},
+			"attr.movement":      {"自動巻き"},
+			"purchase_date_from": {"2023-01-01"},
+		})
+		require.Empty(t, errs)
+		expected, err := scopeItemFilter(testActor, listFilter)
+		require.NoError(t, err)
+
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, expected).Return([]*entity.Item{}, nil)
+
+		minPrice := entity.Decimal{Units: 1000}
+		_, err = NewItemUsecase(mockRepo).BulkRecategorize(actorContext(), BulkRecategorizeInput{
+			Filter: &BulkRecategorizeFilter{
+				Category:         "Watch",
+				Tags:             []string{"Vintage"},
+				MinPrice:         &minPrice,
+				Attributes:       map[string]string{entity.AttributeMovement: "自動巻き"},
+				PurchaseDateFrom: "2023-01-01",
+			},
+			Category: "バッグ",
+		})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: ID で指定したアイテムが見つからない場合は何も変更しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(1, "その他", nil), nil)
+		mockRepo.On("FindByID", mock.Anything, int64(9)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := NewItemUsecase(mockRepo).BulkRecategorize(actorContext(), BulkRecategorizeInput{IDs: []int64{1, 9}, Category: "バッグ"})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.ErrorContains(t, err, "9")
+		mockRepo.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything)
+	})
+
+	tests := []struct {
+		name  string
+		input BulkRecategorizeInput
+	}{
+		{"変更先のカテゴリーがない", BulkRecategorizeInput{IDs: []int64{1}}},
+		{"変更先のカテゴリーが存在しない", BulkRecategorizeInput{IDs: []int64{1}, Category: "家具"}},
+		{"アイテムの指定がない", BulkRecategorizeInput{Category: "バッグ"}},
+		{"ids と filter を両方指定", BulkRecategorizeInput{IDs: []int64{1}, Filter: &BulkRecategorizeFilter{}, Category: "バッグ"}},
+		{"不正な絞り込み条件", BulkRecategorizeInput{Filter: &BulkRecategorizeFilter{Status: "broken"}, Category: "バッグ"}},
+		{"絞り込みに使えない属性", BulkRecategorizeInput{Filter: &BulkRecategorizeFilter{Attributes: map[string]string{"color": "黒"}}, Category: "バッグ"}},
+	}
+	for _, tt := range tests {
+		t.Run("異常系: "+tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+
+			_, err := NewItemUsecase(mockRepo).BulkRecategorize(actorContext(), tt.input)
+			assert.True(t, domainErrors.IsValidationError(err), err)
+			mockRepo.AssertNotCalled(t, "UpdateMany", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -2,27 +2,496 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 )
 
 // ItemRepository defines the interface for item data access
 type ItemRepository interface {
-	// FindAll retrieves all items
-	FindAll(ctx context.Context) ([]*entity.Item, error)
+	// FindAll retrieves all items matching the filter
+	FindAll(ctx context.Context, filter entity.ItemFilter) ([]*entity.Item, error)
 
 	// FindByID retrieves an item by ID
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
 
-	// Create creates a new item and returns it with the generated ID
+	// Create creates a new item and returns it with the generated ID.
+	// Returns ErrInvalidInput if the name or brand does not fit in the storage column.
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// Update updates an existing item by ID and returns the updated item
+	// CreateMany creates items in one transaction.
+	// Returns ErrInvalidInput or ErrDuplicateSerialNumber and creates nothing if any of the items cannot be stored.
+	CreateMany(ctx context.Context, items []*entity.Item) error
+
+	// Update updates an existing item by ID and returns the updated item.
+	// The update only applies if item.Version is still the stored version, and increments it;
+	// returns ErrItemVersionConflict if another request updated the item in between.
+	// Returns ErrInvalidInput if the name or brand does not fit in the storage column.
 	Update(ctx context.Context, id int64, item *entity.Item) (*entity.Item, error)
 
+	// UpdateMany updates existing items in one transaction and returns the updated items in the same order.
+	// Returns ErrItemNotFound or ErrItemVersionConflict and updates nothing if any of the items
+	// has been deleted or updated by another request since it was read.
+	UpdateMany(ctx context.Context, items []*entity.Item) ([]*entity.Item, error)
+
 	// Delete deletes an item by ID
 	Delete(ctx context.Context, id int64) error
 
-	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
-	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+	// Search retrieves the items accessible to the user whose name or brand matches the query, most relevant first.
+	// Accessible items are the user's personal items and the items of organizations the user belongs to.
+	// A userID of 0 searches the items of all users.
+	// Each result carries its relevance score and the matched spans of the name and brand.
+	Search(ctx context.Context, query string, userID int64) ([]*entity.SearchResult, error)
+
+	// GetSummaryByCategory returns the counts and total purchase prices of items accessible to the user
+	// grouped by category and purchase currency (bonus feature). A userID of 0 counts the items of all users.
+	GetSummaryByCategory(ctx context.Context, userID int64) ([]entity.CategoryValue, error)
+
+	// GetSummaryByCategoryAsOf is GetSummaryByCategory limited to the items held on asOf (YYYY-MM-DD):
+	// items purchased on or before asOf, excluding items with a sale recorded before asOf.
+	GetSummaryByCategoryAsOf(ctx context.Context, userID int64, asOf string) ([]entity.CategoryValue, error)
+
+	// GetSummaryByBrand returns the counts and total purchase prices of items accessible to the user
+	// grouped by brand and purchase currency. A userID of 0 counts the items of all users.
+	GetSummaryByBrand(ctx context.Context, userID int64) ([]entity.BrandValue, error)
+
+	// GetHoldingValues returns the counts, total purchase prices and total current values of the held items
+	// (owned, listed for sale or consigned) accessible to the user, grouped by category, purchase currency,
+	// current value currency and organization. A userID of 0 counts the items of all users.
+	GetHoldingValues(ctx context.Context, userID int64) ([]entity.HoldingValue, error)
+
+	// GetPurchasesByPeriod returns the counts and total purchase prices of items accessible to the user
+	// grouped by purchase period (YYYY-MM or YYYY), purchase currency and organization, oldest period first.
+	// A userID of 0 counts the items of all users.
+	GetPurchasesByPeriod(ctx context.Context, userID int64, unit entity.PurchasePeriodUnit) ([]entity.PurchasePeriodValue, error)
+
+	// FindTopByValue retrieves up to n items accessible to the user with the highest amount of the given kind
+	// in each currency, highest first. Amounts in different currencies are not comparable, so the caller
+	// converts them and picks the overall top items. Items without the amount are not included.
+	// A userID of 0 retrieves the items of all users.
+	FindTopByValue(ctx context.Context, userID int64, by entity.ItemValueKey, n int) ([]*entity.Item, error)
+
+	// FindHeld retrieves the held (owned, listed for sale or consigned) items accessible to the user in the
+	// default order (newest first) with only the columns used for value reports: ID, owner, name, category,
+	// brand, purchase price and date, status and current value. A userID of 0 retrieves the items of all users.
+	FindHeld(ctx context.Context, userID int64) ([]*entity.Item, error)
+}
+
+// UserRepository defines the interface for user data access
+type UserRepository interface {
+	// FindByID retrieves a user by ID, with the organizations the user belongs to in Memberships
+	FindByID(ctx context.Context, id int64) (*entity.User, error)
+
+	// FindByEmail retrieves a user by normalized email address, with the organizations the user belongs to in Memberships
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+
+	// Create creates a new user and returns it with the generated ID.
+	// Returns ErrDuplicateEntry if the email is already registered.
+	Create(ctx context.Context, user *entity.User) (*entity.User, error)
+
+	// UpdatePreferredCurrency changes the currency the user's summaries and exports are reported in.
+	// Returns ErrUserNotFound if the user does not exist.
+	UpdatePreferredCurrency(ctx context.Context, id int64, currency entity.Currency) (*entity.User, error)
+}
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	// Create creates a new API key and returns it with the generated ID
+	Create(ctx context.Context, key *entity.APIKey) (*entity.APIKey, error)
+
+	// FindByHash retrieves an API key by the hash of the key
+	FindByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+
+	// FindByUserID retrieves all API keys of a user, newest first
+	FindByUserID(ctx context.Context, userID int64) ([]*entity.APIKey, error)
+
+	// Delete deletes a user's API key by ID.
+	// Returns ErrAPIKeyNotFound if the key does not exist or belongs to another user.
+	Delete(ctx context.Context, userID, id int64) error
+
+	// TouchLastUsed records when an API key was last used
+	TouchLastUsed(ctx context.Context, id int64, usedAt time.Time) error
+}
+
+// ItemImageRepository defines the interface for item image data access
+type ItemImageRepository interface {
+	// Create creates a new image record and returns it with the generated ID
+	Create(ctx context.Context, image *entity.ItemImage) (*entity.ItemImage, error)
+
+	// FindByItemID retrieves all images of an item ordered by position
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemImage, error)
+
+	// FindByID retrieves an image of an item by ID.
+	// Returns ErrItemImageNotFound if the image does not exist or belongs to another item.
+	FindByID(ctx context.Context, itemID, id int64) (*entity.ItemImage, error)
+
+	// FindCoversByItemIDs retrieves the first image (by position) of each item.
+	// Items without images are not included in the result.
+	FindCoversByItemIDs(ctx context.Context, itemIDs []int64) (map[int64]*entity.ItemImage, error)
+
+	// MarkThumbnailsReady records that the thumbnails of an image have been generated.
+	// Returns ErrItemImageNotFound if the image has been deleted.
+	MarkThumbnailsReady(ctx context.Context, itemID, id int64) error
+
+	// Delete deletes an image of an item by ID.
+	// Returns ErrItemImageNotFound if the image does not exist or belongs to another item.
+	Delete(ctx context.Context, itemID, id int64) error
+}
+
+// PortfolioViewRepository defines the interface for portfolio view data access
+type PortfolioViewRepository interface {
+	// Create creates a new portfolio view and returns it with the generated ID
+	Create(ctx context.Context, view *entity.PortfolioView) (*entity.PortfolioView, error)
+
+	// FindByUserID retrieves all portfolio views of a user, newest first
+	FindByUserID(ctx context.Context, userID int64) ([]*entity.PortfolioView, error)
+
+	// FindByID retrieves a user's portfolio view by ID.
+	// Returns ErrPortfolioNotFound if the view does not exist or belongs to another user.
+	FindByID(ctx context.Context, userID, id int64) (*entity.PortfolioView, error)
+
+	// FindByToken retrieves a portfolio view by its public token
+	FindByToken(ctx context.Context, token string) (*entity.PortfolioView, error)
+
+	// Update updates a portfolio view and returns the updated view
+	Update(ctx context.Context, view *entity.PortfolioView) (*entity.PortfolioView, error)
+
+	// Delete deletes a user's portfolio view by ID.
+	// Returns ErrPortfolioNotFound if the view does not exist or belongs to another user.
+	Delete(ctx context.Context, userID, id int64) error
+}
+
+// CommentRepository defines the interface for item comment data access
+type CommentRepository interface {
+	// Create creates a new comment and returns it with the generated ID and author email
+	Create(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
+
+	// FindByItemID retrieves all comments of an item, oldest first
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.Comment, error)
+
+	// FindByID retrieves a comment of an item by ID.
+	// Returns ErrCommentNotFound if the comment does not exist or belongs to another item.
+	FindByID(ctx context.Context, itemID, id int64) (*entity.Comment, error)
+
+	// Update updates the body of a comment and returns the updated comment
+	Update(ctx context.Context, comment *entity.Comment) (*entity.Comment, error)
+
+	// Delete deletes a comment of an item by ID.
+	// Returns ErrCommentNotFound if the comment does not exist or belongs to another item.
+	Delete(ctx context.Context, itemID, id int64) error
+}
+
+// TagRepository defines the interface for item tag data access
+type TagRepository interface {
+	// AddToItem attaches a tag to an item, creating the tag if it does not exist (no-op if already attached)
+	AddToItem(ctx context.Context, itemID int64, name string) error
+
+	// RemoveFromItem detaches a tag from an item.
+	// Returns ErrTagNotFound if the item does not have the tag.
+	RemoveFromItem(ctx context.Context, itemID int64, name string) error
+
+	// FindByItemIDs retrieves the tag names of each item, sorted by name (items without tags are omitted)
+	FindByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]string, error)
+
+	// CountByUser counts the items the user can access for each tag, most used first (0 counts all items)
+	CountByUser(ctx context.Context, userID int64) ([]entity.TagCount, error)
+}
+
+// CategoryRepository defines the interface for item category data access
+type CategoryRepository interface {
+	// FindAll retrieves all categories in the order they were created
+	FindAll(ctx context.Context) ([]*entity.Category, error)
+
+	// FindByID retrieves a category by ID.
+	// Returns ErrCategoryNotFound if the category does not exist.
+	FindByID(ctx context.Context, id int64) (*entity.Category, error)
+
+	// Create creates a new category and returns it with the generated ID.
+	// Returns ErrDuplicateEntry if a category with the same name exists.
+	Create(ctx context.Context, category *entity.Category) (*entity.Category, error)
+
+	// Update sets the name, English display name, item defaults and required fields of a category,
+	// and moves its items to the new name when the name changes, recording the category change in the
+	// history of each moved item as made by actorID at at, in the same transaction.
+	// Returns ErrCategoryNotFound if the category does not exist, ErrDuplicateEntry if the name is taken.
+	Update(ctx context.Context, id int64, category *entity.Category, actorID int64, at time.Time) (*entity.Category, error)
+
+	// Delete deletes a category.
+	// Returns ErrCategoryNotFound if the category does not exist, ErrCategoryInUse if it has items.
+	Delete(ctx context.Context, id int64) error
+}
+
+// ItemMemoRepository defines the interface for personal item memo data access
+type ItemMemoRepository interface {
+	// Create creates a new memo and returns it with the generated ID
+	Create(ctx context.Context, memo *entity.ItemMemo) (*entity.ItemMemo, error)
+
+	// FindByItemID retrieves the memos a user wrote on an item, newest first
+	FindByItemID(ctx context.Context, itemID, userID int64) ([]*entity.ItemMemo, error)
+}
+
+// ItemHistoryRepository defines the interface for item change history data access
+type ItemHistoryRepository interface {
+	// Create records the changes of an item in a single statement (does nothing if there are none)
+	Create(ctx context.Context, histories []*entity.ItemHistory) error
+
+	// FindByItemID retrieves all changes of an item with the actor email, oldest first.
+	// The history is kept after the item is deleted.
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemHistory, error)
+
+	// FindSince retrieves up to limit changes of all items recorded at or after since (zero for no lower bound)
+	// with an ID greater than afterID, in ID order. Used to read the whole history in pages.
+	FindSince(ctx context.Context, since time.Time, afterID int64, limit int) ([]*entity.ItemHistory, error)
+
+	// RecordCreate creates the item like ItemRepository.Create and records its creation in a single transaction.
+	// The ItemID of the histories is set to the ID assigned on insert. Returns the same errors as ItemRepository.Create.
+	RecordCreate(ctx context.Context, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error)
+
+	// RecordCreateMany creates the items like ItemRepository.CreateMany and records their creation in a single transaction.
+	// histories[i] are the histories of items[i]; their ItemID is set to the ID assigned on insert.
+	// Returns the same errors as ItemRepository.CreateMany.
+	RecordCreateMany(ctx context.Context, items []*entity.Item, histories [][]*entity.ItemHistory) error
+
+	// RecordUpdate updates the item like ItemRepository.Update and records its changes in a single transaction,
+	// so neither is saved without the other. Returns the same errors as ItemRepository.Update.
+	RecordUpdate(ctx context.Context, id int64, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error)
+
+	// RecordUpdates updates the items like ItemRepository.UpdateMany and records their changes in a single transaction.
+	// Returns the same errors as ItemRepository.UpdateMany.
+	RecordUpdates(ctx context.Context, items []*entity.Item, histories []*entity.ItemHistory) ([]*entity.Item, error)
+
+	// RecordDelete deletes the item like ItemRepository.Delete and records its deletion in a single transaction.
+	// Returns ErrItemNotFound if the item does not exist.
+	RecordDelete(ctx context.Context, id int64, histories []*entity.ItemHistory) error
+}
+
+// ItemViewRepository defines the interface for recently viewed item data access
+type ItemViewRepository interface {
+	// Record records that the user viewed the item at viewedAt, keeping only the user's latest limit views
+	Record(ctx context.Context, userID, itemID int64, viewedAt time.Time, limit int) error
+
+	// FindRecentItems retrieves the items the user viewed, most recent first.
+	// Only items within scope are returned (the user's own and organization items; 0 for all items).
+	FindRecentItems(ctx context.Context, userID, scope int64, limit int) ([]*entity.RecentlyViewedItem, error)
+}
+
+// NotificationRepository defines the interface for notification data access
+type NotificationRepository interface {
+	// Create creates a new notification and returns it with the generated ID
+	Create(ctx context.Context, notification *entity.Notification) (*entity.Notification, error)
+
+	// FindByUserID retrieves notifications of a user, newest first.
+	// If unreadOnly is true, read notifications are excluded.
+	FindByUserID(ctx context.Context, userID int64, unreadOnly bool) ([]*entity.Notification, error)
+
+	// MarkRead marks a user's notification as read.
+	// Returns ErrNotificationNotFound if the notification does not exist or belongs to another user.
+	MarkRead(ctx context.Context, userID, id int64) error
+}
+
+// OrganizationRepository defines the interface for organization data access
+type OrganizationRepository interface {
+	// Create creates a new organization with the user as its owner and returns it with the generated ID
+	Create(ctx context.Context, org *entity.Organization, ownerID int64) (*entity.Organization, error)
+
+	// FindByUserID retrieves the organizations a user belongs to with the user's role, oldest first
+	FindByUserID(ctx context.Context, userID int64) ([]*entity.Organization, error)
+
+	// FindByID retrieves an organization by ID
+	FindByID(ctx context.Context, id int64) (*entity.Organization, error)
+
+	// FindMembers retrieves the members of an organization, oldest first
+	FindMembers(ctx context.Context, organizationID int64) ([]*entity.Membership, error)
+
+	// AddMember adds a user to an organization.
+	// Returns ErrDuplicateEntry if the user is already a member.
+	AddMember(ctx context.Context, membership *entity.Membership) (*entity.Membership, error)
+
+	// RemoveMember removes a user from an organization.
+	// Returns ErrMemberNotFound if the user is not a member.
+	RemoveMember(ctx context.Context, organizationID, userID int64) error
+}
+
+// ItemSaleRepository defines the interface for item sale data access
+type ItemSaleRepository interface {
+	// FindByItemID retrieves the sale of an item.
+	// Returns ErrItemSaleNotFound if the item has no recorded sale.
+	FindByItemID(ctx context.Context, itemID int64) (*entity.ItemSale, error)
+
+	// Record saves the sale, updates the item (its status) and records the item's changes in a single transaction,
+	// and returns the saved sale and the updated item.
+	// Returns ErrDuplicateEntry if a sale is already recorded for the item,
+	// and ErrItemVersionConflict if the item was modified by another request.
+	Record(ctx context.Context, sale *entity.ItemSale, item *entity.Item, histories []*entity.ItemHistory) (*entity.ItemSale, *entity.Item, error)
+}
+
+// ItemValuationRepository defines the interface for item valuation data access
+type ItemValuationRepository interface {
+	// FindByItemID retrieves the valuations of an item ordered by valued_on (oldest first).
+	// Returns an empty slice if the item has no valuation.
+	FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemValuation, error)
+
+	// Record saves the valuation and sets the current value of the item to the valuation
+	// with the latest valued_on in a single transaction, and returns the saved valuation.
+	// Returns ErrItemNotFound if the item does not exist.
+	Record(ctx context.Context, valuation *entity.ItemValuation) (*entity.ItemValuation, error)
+}
+
+// ConsignmentRepository defines the interface for consignment data access
+type ConsignmentRepository interface {
+	// FindByItemID retrieves the consignment of an item.
+	// Returns ErrConsignmentNotFound if the item is not held on consignment.
+	FindByItemID(ctx context.Context, itemID int64) (*entity.Consignment, error)
+
+	// Save creates or replaces the consignment of an item and returns it
+	Save(ctx context.Context, consignment *entity.Consignment) (*entity.Consignment, error)
+
+	// Delete deletes the consignment of an item.
+	// Returns ErrConsignmentNotFound if the item is not held on consignment.
+	Delete(ctx context.Context, itemID int64) error
+
+	// FindAll retrieves the consignments of items accessible to the user, earliest deadline first.
+	// An empty status matches every status, and a userID of 0 covers the items of all users.
+	FindAll(ctx context.Context, userID int64, status entity.ConsignmentStatus) ([]*entity.Consignment, error)
+
+	// SummarizeOwned returns the count and total JPY purchase price of items accessible to the user
+	// that have never been on consignment. A userID of 0 covers the items of all users.
+	SummarizeOwned(ctx context.Context, userID int64) (count int, purchaseValue int64, err error)
+}
+
+// InvoiceRepository defines the interface for invoice data access
+type InvoiceRepository interface {
+	// Create assigns the next number in the sequence for the year of issue and saves the invoice.
+	// Returns ErrDuplicateEntry if an invoice has already been issued for the item.
+	Create(ctx context.Context, invoice *entity.Invoice) (*entity.Invoice, error)
+
+	// FindByID retrieves an invoice by ID.
+	// Returns ErrInvoiceNotFound if the invoice does not exist.
+	FindByID(ctx context.Context, id int64) (*entity.Invoice, error)
+
+	// FindAll retrieves the invoices issued by the user, newest first.
+	// A userID of 0 covers the invoices of all users.
+	FindAll(ctx context.Context, userID int64) ([]*entity.Invoice, error)
+}
+
+// BackupRepository defines the interface for dumping and reloading the item dataset
+type BackupRepository interface {
+	// Restore writes the rows of the backup in a single transaction, replacing rows with the same ID
+	// and deleting rows that are not in the backup. Returns ErrInvalidInput if the backup has a column
+	// that does not exist in the current schema.
+	Restore(ctx context.Context, backup *entity.Backup) (*entity.RestoreResult, error)
+
+	// Snapshot returns every row of the backup tables, including IDs and timestamps, and the row
+	// count and checksum of each production table, both read from the same consistent snapshot.
+	Snapshot(ctx context.Context) (*entity.Backup, []entity.TableChecksum, error)
+
+	// RestoreInto recreates the backup tables in the scratch schema, writes the rows of the backup
+	// and returns the row count and checksum of each restored table. Returns ErrInvalidInput if the
+	// schema is empty or is the production database.
+	RestoreInto(ctx context.Context, schema string, backup *entity.Backup) ([]entity.TableChecksum, error)
+}
+
+// DigestSubscriptionRepository defines the interface for digest email subscription data access
+type DigestSubscriptionRepository interface {
+	// FindByUserID retrieves the digest subscription of a user with the user's email.
+	// A user who has never set a preference has a subscription with DigestFrequencyOff.
+	// Returns ErrUserNotFound if the user does not exist.
+	FindByUserID(ctx context.Context, userID int64) (*entity.DigestSubscription, error)
+
+	// Save creates or replaces the frequency of a user's digest subscription and returns it
+	Save(ctx context.Context, subscription *entity.DigestSubscription) (*entity.DigestSubscription, error)
+
+	// FindSubscribed retrieves the subscriptions of all users whose frequency is not off
+	FindSubscribed(ctx context.Context) ([]*entity.DigestSubscription, error)
+
+	// MarkSent records when the digest was last sent to a user
+	MarkSent(ctx context.Context, userID int64, sentAt time.Time) error
+}
+
+// APIKeyUsageRepository defines the interface for the daily request counts of API keys
+type APIKeyUsageRepository interface {
+	// Add adds the counts to the stored daily counts in a single statement (does nothing if there are none)
+	Add(ctx context.Context, usage []*entity.APIKeyUsage) error
+
+	// FindByUserID retrieves the daily counts of a user's API keys on or after the date (YYYY-MM-DD), oldest first
+	FindByUserID(ctx context.Context, userID int64, from string) ([]*entity.APIKeyUsage, error)
+}
+
+// AuditLogRepository defines the interface for audit log data access
+type AuditLogRepository interface {
+	// Create stores the audit logs in a single statement (does nothing if there are none)
+	Create(ctx context.Context, logs []*entity.AuditLog) error
+
+	// Find retrieves the audit logs matching the filter with the user email, newest first
+	Find(ctx context.Context, filter entity.AuditLogFilter) ([]*entity.AuditLog, error)
+}
+
+// AdminReportRepository defines the read-only queries behind the predefined admin reports
+type AdminReportRepository interface {
+	// TopBrands retrieves the brands with the most items, largest first
+	TopBrands(ctx context.Context, limit int) ([]*entity.BrandRanking, error)
+
+	// InactiveUsers retrieves the users with no audit-logged activity since the given time, least recently active first
+	InactiveUsers(ctx context.Context, since time.Time, limit int) ([]*entity.InactiveUser, error)
+
+	// LargestAttachments retrieves the largest item images, largest first
+	LargestAttachments(ctx context.Context, limit int) ([]*entity.LargeAttachment, error)
+}
+
+// IdempotencyRepository defines the interface for idempotency key data access
+type IdempotencyRepository interface {
+	// Create stores a record for a request that has not been processed yet.
+	// Returns ErrDuplicateEntry if the user has already used the key.
+	Create(ctx context.Context, record *entity.IdempotencyRecord) error
+
+	// Find retrieves the record of the user's key.
+	// Returns ErrIdempotencyKeyNotFound if the key has not been used.
+	Find(ctx context.Context, userID int64, key string) (*entity.IdempotencyRecord, error)
+
+	// Complete stores the response of the request in the record
+	Complete(ctx context.Context, record *entity.IdempotencyRecord) error
+
+	// Delete deletes the record of the user's key so the key can be used again
+	Delete(ctx context.Context, userID int64, key string) error
+
+	// DeleteExpired deletes the records created before the time
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
+
+// DraftSessionRepository defines the interface for item draft session data access
+type DraftSessionRepository interface {
+	// Create stores a new draft session
+	Create(ctx context.Context, session *entity.DraftSession) error
+
+	// FindByID retrieves the draft session, including expired ones.
+	// Returns ErrDraftSessionNotFound if the session does not exist.
+	FindByID(ctx context.Context, id string) (*entity.DraftSession, error)
+
+	// Update stores the session if its stored version is still the given version.
+	// Returns ErrDraftSessionNotFound if the session was deleted or updated by another request.
+	Update(ctx context.Context, session *entity.DraftSession, version int64) error
+
+	// CountActive counts the user's sessions that have not expired at the time
+	CountActive(ctx context.Context, userID int64, now time.Time) (int, error)
+
+	// Delete deletes the session.
+	// Returns ErrDraftSessionNotFound if the session has already been deleted.
+	Delete(ctx context.Context, id string) error
+
+	// FindExpired retrieves up to limit sessions that expired at or before the time, oldest first
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.DraftSession, error)
+}
+
+// ColumnMigrationRepository defines the interface for backfilling and verifying column migrations in ID ranges
+type ColumnMigrationRepository interface {
+	// NextBatch returns the number of rows after afterID (up to limit) and the largest ID among them (0 when there are none)
+	NextBatch(ctx context.Context, m *entity.ColumnMigration, afterID int64, limit int) (int64, int64, error)
+
+	// Backfill sets the new column from the old column for the rows in (afterID, throughID] where they differ
+	// and returns the number of updated rows
+	Backfill(ctx context.Context, m *entity.ColumnMigration, afterID, throughID int64) (int64, error)
+
+	// Mismatches returns up to limit IDs of the rows in (afterID, throughID] whose new column differs
+	// from the value converted from the old column, and the total number of such rows
+	Mismatches(ctx context.Context, m *entity.ColumnMigration, afterID, throughID int64, limit int) ([]int64, int64, error)
 }
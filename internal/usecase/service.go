@@ -3,177 +3,1315 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/sync/singleflight"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 type ItemUsecase interface {
-	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	GetAllItems(ctx context.Context, filter entity.ItemFilter) ([]*entity.Item, error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
 	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
 	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
+	// ReplaceItem はアイテムの内容を input で置き換える（PUT。省略した項目は既定値に戻す）
+	ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error)
+	// BulkUpdateItems は同じ部分更新を複数のアイテムに1つのトランザクションで適用する
+	BulkUpdateItems(ctx context.Context, input BulkUpdateItemsInput) ([]*entity.Item, error)
+	// ChangeItemStatus はアイテムの所有状況を変更する（変更できる状況は entity.ItemStatus.CanTransitionTo）
+	ChangeItemStatus(ctx context.Context, id int64, input ChangeItemStatusInput) (*entity.Item, error)
+	// RecordSale はアイテムの売却を記録し、アイテムを売却済み（sold）にする
+	RecordSale(ctx context.Context, id int64, input RecordSaleInput) (*entity.ItemSale, error)
+	// GetSale はアイテムの売却の記録を損益とともに返す
+	GetSale(ctx context.Context, id int64) (*entity.ItemSale, error)
+	// RecordValuation はアイテムの評価額を記録し、最新の評価日の評価額をアイテムの current_value にする
+	RecordValuation(ctx context.Context, id int64, input RecordValuationInput) (*entity.ItemValuation, error)
+	// GetValuations はアイテムの評価額を評価日の古い順に返す
+	GetValuations(ctx context.Context, id int64) ([]*entity.ItemValuation, error)
 	DeleteItem(ctx context.Context, id int64) error
-	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	// GetRecentlyViewedItems は操作者が最近詳細を表示したアイテムを新しい順に返す
+	GetRecentlyViewedItems(ctx context.Context) ([]*entity.RecentlyViewedItem, error)
+	// GetItemHistory はアイテムの変更履歴を古い順に返す
+	GetItemHistory(ctx context.Context, id int64) ([]*entity.ItemHistory, error)
+	// GetCategorySummary はカテゴリー別集計を返す（asOf を指定した場合はその日に所有していたアイテムのみ）
+	GetCategorySummary(ctx context.Context, asOf string) (*CategorySummary, error)
+	// GetBrandSummary はブランドごとのアイテムの件数と購入価格の合計・平均を件数の多い順に返す（limit が0の場合はすべて）
+	GetBrandSummary(ctx context.Context, limit int) (*BrandSummary, error)
+	// GetTopItems は購入価格または評価額の高いアイテムを高い順に n 件返す
+	GetTopItems(ctx context.Context, n int, by string) (*TopItems, error)
+	// GetDashboardStats はカテゴリー別集計・ブランド別集計・月ごとの購入・高額なアイテムを並行して集計してまとめて返す
+	GetDashboardStats(ctx context.Context) (*DashboardStats, error)
+	// GetPortfolioSummary は手放していないアイテムの件数・購入価格と評価額の合計とカテゴリーごとの内訳を返す
+	GetPortfolioSummary(ctx context.Context) (*PortfolioSummary, error)
+	// GetQuickStats は直近のカテゴリー集計からアイテムの件数と購入価格の合計を返す（最大 QuickStatsMaxAge 前の値）
+	GetQuickStats(ctx context.Context) (*QuickStats, error)
+	SearchItems(ctx context.Context, query string) ([]*entity.SearchResult, error)
+	PreviewQuickAdd(ctx context.Context, input QuickAddInput) ([]QuickAddPreview, error)
+	ParseItemText(ctx context.Context, input ParseItemInput) (*ItemDraft, error)
+	// GetNamingSuggestions は同じモデルなのに名前の表記が揃っていないアイテムと、揃える名前の候補を返す
+	GetNamingSuggestions(ctx context.Context) ([]NamingSuggestion, error)
+	// ApplyNaming は複数のアイテムの名前を1つのトランザクションで変更する
+	ApplyNaming(ctx context.Context, input ApplyNamingInput) ([]*entity.Item, error)
+	// BulkRecategorize は ID か絞り込み条件で指定したアイテムのカテゴリーを1つのトランザクションで変更する（dry_run は件数のみ返す）
+	BulkRecategorize(ctx context.Context, input BulkRecategorizeInput) (*BulkRecategorizeResult, error)
+	// GetValueChangeReport は手放していないアイテムの購入価格と最新の評価額を比較し、カテゴリー・ブランドごとに集計する
+	GetValueChangeReport(ctx context.Context) (*ValueChangeReport, error)
+	// GetPurchaseTrend は購入日の月・年ごとのアイテムの件数と購入価格の合計を古い順に返す
+	GetPurchaseTrend(ctx context.Context, groupBy string) (*PurchaseTrendReport, error)
 }
 
 type CreateItemInput struct {
-	Name          string `json:"name"`
-	Category      string `json:"category"`
-	Brand         string `json:"brand"`
-	PurchasePrice int    `json:"purchase_price"`
-	PurchaseDate  string `json:"purchase_date"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Brand    string `json:"brand"`
+	// PurchasePrice は補助単位を小数にした金額（USD の 123.45）
+	PurchasePrice entity.Decimal `json:"purchase_price"`
+	// PurchaseCurrency は購入価格の通貨（省略時 JPY）
+	PurchaseCurrency string `json:"purchase_currency,omitempty"`
+	PurchaseDate     string `json:"purchase_date"`
+	// Visibility は省略時 private
+	Visibility string `json:"visibility,omitempty"`
+	// Condition は状態（省略時は未設定）
+	Condition string `json:"condition,omitempty"`
+	// SerialNumber はシリアル番号（省略時は未設定）
+	SerialNumber string `json:"serial_number,omitempty"`
+	// Notes は自由記述のメモ（省略時は未設定）
+	Notes string `json:"notes,omitempty"`
+	// Attributes はカテゴリーごとの属性（CategoryAttributes で検証する。省略時は未設定）
+	Attributes entity.ItemAttributes `json:"attributes,omitempty"`
+	// OrgID は登録先の組織（省略時は個人のアイテム）
+	OrgID int64 `json:"org_id,omitempty"`
 }
 
 type UpdateItemInput struct {
-	Name          *string `json:"name,omitempty"`
-	Brand         *string `json:"brand,omitempty"`
-	PurchasePrice *int    `json:"purchase_price,omitempty"`
+	Name  *string `json:"name,omitempty"`
+	Brand *string `json:"brand,omitempty"`
+	// PurchasePrice は補助単位を小数にした金額（省略時は現在の金額のまま）
+	PurchasePrice *entity.Decimal `json:"purchase_price,omitempty"`
+	// PurchaseCurrency は購入価格の通貨（省略時は現在の通貨のまま。金額は同じ数値のまま通貨を変える）
+	PurchaseCurrency *string `json:"purchase_currency,omitempty"`
+	Visibility       *string `json:"visibility,omitempty"`
+	// Condition は状態（空文字は未設定に戻す）
+	Condition *string `json:"condition,omitempty"`
+	// SerialNumber はシリアル番号（空文字は未設定に戻す）
+	SerialNumber *string `json:"serial_number,omitempty"`
+	// Notes は自由記述のメモ（空文字は未設定に戻す）
+	Notes *string `json:"notes,omitempty"`
+	// Attributes はカテゴリーごとの属性（すべての属性を置き換える。空は未設定に戻す）
+	Attributes *entity.ItemAttributes `json:"attributes,omitempty"`
+	// OrgID はアイテムを移す組織（0 は操作を行うユーザーの個人のアイテムに戻す）
+	OrgID *int64 `json:"org_id,omitempty"`
+	// Version は If-Match で指定された更新前のバージョン（0 は照合しない。一括更新では使わない）
+	Version int64 `json:"-"`
+}
+
+// ReplaceItemInput は置き換えるアイテムの内容。
+// Visibility の省略は private に、Condition・SerialNumber・Notes・Attributes の省略は未設定に戻す。OrgID はアイテムの所有者で内容ではないため、省略した場合は変更しない
+type ReplaceItemInput struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Brand    string `json:"brand"`
+	// PurchasePrice は補助単位を小数にした金額（USD の 123.45）
+	PurchasePrice entity.Decimal `json:"purchase_price"`
+	// PurchaseCurrency は購入価格の通貨（省略時 JPY）
+	PurchaseCurrency string `json:"purchase_currency,omitempty"`
+	PurchaseDate     string `json:"purchase_date"`
+	Visibility       string `json:"visibility,omitempty"`
+	Condition        string `json:"condition,omitempty"`
+	SerialNumber     string `json:"serial_number,omitempty"`
+	Notes            string `json:"notes,omitempty"`
+	// Attributes は置き換え後のカテゴリーの属性
+	Attributes entity.ItemAttributes `json:"attributes,omitempty"`
+	// OrgID はアイテムを移す組織（0 は操作を行うユーザーの個人のアイテムに戻す）
+	OrgID *int64 `json:"org_id,omitempty"`
+	// Version は If-Match で指定された更新前のバージョン（0 は照合しない）
+	Version int64 `json:"-"`
+}
+
+// BulkUpdateItemsInput は一括更新の対象のアイテムと、すべてに適用する部分更新の内容
+type BulkUpdateItemsInput struct {
+	IDs []int64 `json:"ids"`
+	UpdateItemInput
 }
 
 type CategorySummary struct {
+	// AsOf は集計の基準日（YYYY-MM-DD。現在の集計では空）
+	AsOf       string         `json:"as_of,omitempty"`
 	Categories map[string]int `json:"categories"`
 	Total      int            `json:"total"`
+	// Currency は Values と TotalValue の通貨（操作者の表示通貨）
+	Currency entity.Currency `json:"currency"`
+	// Values はカテゴリーごとの購入価格の合計（Currency の最小単位）
+	Values     map[string]int64 `json:"values"`
+	TotalValue int64            `json:"total_value"`
+	// Stats はカテゴリーごとの購入価格の統計と最も新しい購入日
+	Stats map[string]*CategoryStats `json:"stats"`
+}
+
+// CategoryStats はカテゴリーの購入価格の統計（Currency の最小単位）。
+// 金額は換算できない外貨建てのアイテムと購入価格が非表示のアイテムを除いて集計し、対象がない場合は null
+type CategoryStats struct {
+	Count int `json:"count"`
+	// ValuedCount は金額の集計に含めたアイテムの数
+	ValuedCount  int    `json:"valued_count"`
+	TotalValue   int64  `json:"total_value"`
+	AverageValue *int64 `json:"average_value"`
+	MinValue     *int64 `json:"min_value"`
+	MaxValue     *int64 `json:"max_value"`
+	// NewestPurchaseDate は最も新しい購入日（購入日が非表示のアイテムを除く）
+	NewestPurchaseDate *string `json:"newest_purchase_date"`
+}
+
+// 検索キーワードの最大文字数
+const maxSearchQueryLength = 100
+
+// 一覧で指定可能なソートキー
+var itemSortKeys = []string{
+	entity.SortKeyName,
+	entity.SortKeyPurchasePrice,
+	entity.SortKeyPurchaseDate,
+	entity.SortKeyCreatedAt,
 }
 
 type itemUsecase struct {
-	itemRepo ItemRepository
+	itemRepo      ItemRepository
+	extractor     EntityExtractor
+	imageRepo     ItemImageRepository
+	storage       FileStorage
+	historyRepo   ItemHistoryRepository
+	saleRepo      ItemSaleRepository
+	valuationRepo ItemValuationRepository
+	viewRepo      ItemViewRepository
+	tagRepo       TagRepository
+	documentIndex DocumentTextIndex
+	converter     CurrencyConverter
+	now           func() time.Time
+
+	// summaryFlight は同時に実行された同じ範囲のカテゴリー集計をまとめる
+	summaryFlight singleflight.Group
+	summaryStats  *CoalescingStats
+	// summaryCache はクイック集計に使う直近のカテゴリー集計を保持する
+	summaryCache *summaryCache
+	// lastWrite はリクエストで最後に書き込んだ時刻を返す（nil は書き込みの時刻を考慮しない）
+	lastWrite func(ctx context.Context) time.Time
+	// canaries は新しい実装を試しているメソッドの振り分けの設定（nil は従来の実装のみ）
+	canaries *Canaries
+}
+
+// ItemUsecaseOption は ItemUsecase の設定を変更する
+type ItemUsecaseOption func(*itemUsecase)
+
+// WithEntityExtractor はテキスト解析に使う EntityExtractor を設定する（デフォルトはルールベース）
+func WithEntityExtractor(extractor EntityExtractor) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.extractor = extractor
+	}
+}
+
+// WithItemImages はアイテムの削除時に添付画像のファイルも削除するよう設定する
+func WithItemImages(imageRepo ItemImageRepository, storage FileStorage) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.imageRepo = imageRepo
+		u.storage = storage
+	}
+}
+
+// WithItemHistory はアイテムの登録・更新・削除を変更履歴に記録するよう設定する
+func WithItemHistory(historyRepo ItemHistoryRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.historyRepo = historyRepo
+	}
+}
+
+// WithItemSales はアイテムの売却の記録を有効にする
+func WithItemSales(saleRepo ItemSaleRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.saleRepo = saleRepo
+	}
+}
+
+// WithItemValuations はアイテムの評価額の記録を有効にする
+func WithItemValuations(valuationRepo ItemValuationRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.valuationRepo = valuationRepo
+	}
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
-	return &itemUsecase{
-		itemRepo: itemRepo,
+// WithCurrencyConverter は集計の金額を操作者の表示通貨に換算するよう設定する（未設定の場合は円建てのみ合計する）
+func WithCurrencyConverter(converter CurrencyConverter) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.converter = converter
 	}
 }
 
-func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	items, err := u.itemRepo.FindAll(ctx)
+// WithRecentlyViewed はアイテムの詳細の表示を最近表示したアイテムとして記録するよう設定する
+func WithRecentlyViewed(viewRepo ItemViewRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.viewRepo = viewRepo
+	}
+}
+
+// WithTags はアイテムのレスポンスに付いているタグを含めるよう設定する
+func WithTags(tagRepo TagRepository) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.tagRepo = tagRepo
+	}
+}
+
+// WithCoalescingStats はカテゴリー集計をまとめた回数を stats に記録するよう設定する
+func WithCoalescingStats(stats *CoalescingStats) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.summaryStats = stats
+	}
+}
+
+// WithCanaries は一部のリクエストを新しい実装に振り分けて従来の実装と比べるよう設定する
+func WithCanaries(canaries *Canaries) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.canaries = canaries
+	}
+}
+
+// WithLastWrite はリクエストで最後に書き込んだ時刻を返す関数を設定する。
+// クイック集計は書き込みより前に集計した結果を再利用せず、集計し直す（read-your-writes）
+func WithLastWrite(lastWrite func(ctx context.Context) time.Time) ItemUsecaseOption {
+	return func(u *itemUsecase) {
+		u.lastWrite = lastWrite
+	}
+}
+
+func NewItemUsecase(itemRepo ItemRepository, opts ...ItemUsecaseOption) ItemUsecase {
+	u := &itemUsecase{
+		itemRepo:     itemRepo,
+		extractor:    NewRuleBasedExtractor(),
+		now:          time.Now,
+		summaryStats: NewCoalescingStats(),
+		summaryCache: newSummaryCache(),
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+func (u *itemUsecase) GetAllItems(ctx context.Context, filter entity.ItemFilter) ([]*entity.Item, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err = scopeItemFilter(actor, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.FindAll(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve items: %w", err)
 	}
+	if err := u.attachThumbnails(ctx, items); err != nil {
+		return nil, err
+	}
+	if err := u.attachTags(ctx, items); err != nil {
+		return nil, err
+	}
+	redactItems(actor, items...)
+	localizeItems(ctx, items...)
 
-	return items, nil
+	return hideRedactedMatches(filter, items), nil
 }
 
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
 
-	item, err := u.itemRepo.FindByID(ctx, id)
+	item, err := u.findOwnedItem(ctx, actor, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return nil, domainErrors.ErrItemNotFound
 		}
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
+	if err := u.attachThumbnails(ctx, []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+	if err := u.attachTags(ctx, []*entity.Item{item}); err != nil {
+		return nil, err
+	}
+
+	// 表示の記録は補助的な機能のため、記録に失敗してもアイテムの取得は成功とする
+	if u.viewRepo != nil {
+		_ = u.viewRepo.Record(ctx, actor.ID, item.ID, u.now(), maxRecentlyViewedItems)
+	}
+	redactItems(actor, item)
+	localizeItems(ctx, item)
 
 	return item, nil
 }
 
+// ユーザーごとに記録する最近表示したアイテムの最大数
+const maxRecentlyViewedItems = 20
+
+func (u *itemUsecase) GetRecentlyViewedItems(ctx context.Context) ([]*entity.RecentlyViewedItem, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.viewRepo == nil {
+		return []*entity.RecentlyViewedItem{}, nil
+	}
+	// 表示した後に参照できなくなったアイテム（組織から外れた場合など）は含めない
+	views, err := u.viewRepo.FindRecentItems(ctx, actor.ID, itemScope(actor), maxRecentlyViewedItems)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recently viewed items: %w", err)
+	}
+	items := make([]*entity.Item, len(views))
+	for i, view := range views {
+		items[i] = view.Item
+	}
+	if err := u.attachThumbnails(ctx, items); err != nil {
+		return nil, err
+	}
+	if err := u.attachTags(ctx, items); err != nil {
+		return nil, err
+	}
+	redactItems(actor, items...)
+	localizeItems(ctx, items...)
+
+	return views, nil
+}
+
+// attachThumbnails は各アイテムに先頭の画像のサムネイルを設定する（画像を扱わない構成では何もしない）
+func (u *itemUsecase) attachThumbnails(ctx context.Context, items []*entity.Item) error {
+	if u.imageRepo == nil || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	covers, err := u.imageRepo.FindCoversByItemIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve images: %w", err)
+	}
+
+	for _, item := range items {
+		if cover, ok := covers[item.ID]; ok {
+			item.Thumbnails = withImageURL(cover).Thumbnails
+		}
+	}
+	return nil
+}
+
+// attachTags は各アイテムに付いているタグを設定する（タグを扱わない構成では何もしない）
+func (u *itemUsecase) attachTags(ctx context.Context, items []*entity.Item) error {
+	if u.tagRepo == nil || len(items) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	tags, err := u.tagRepo.FindByItemIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	for _, item := range items {
+		item.Tags = tags[item.ID]
+	}
+	return nil
+}
+
+// findOwnedItem はユーザーが参照できるアイテムを取得する（管理者はすべてのアイテムを取得できる）。
+// 参照できないアイテムは存在を知られないよう ErrItemNotFound とする
+func (u *itemUsecase) findOwnedItem(ctx context.Context, actor *entity.User, id int64) (*entity.Item, error) {
+	return findItemForActor(ctx, u.itemRepo, actor, id)
+}
+
+// findItemForActor は findOwnedItem と同じ規則でアイテムを取得する（アイテム以外のユースケースからも利用する）
+func findItemForActor(ctx context.Context, itemRepo ItemRepository, actor *entity.User, id int64) (*entity.Item, error) {
+	item, err := itemRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canReadItem(actor, item) {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return item, nil
+}
+
+// findWritableItem は findItemForActor で取得したアイテムを変更できない場合に ErrForbidden を返す
+func findWritableItem(ctx context.Context, itemRepo ItemRepository, actor *entity.User, id int64) (*entity.Item, error) {
+	item, err := findItemForActor(ctx, itemRepo, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if !canWriteItem(actor, item) {
+		return nil, domainErrors.ErrForbidden
+	}
+	return item, nil
+}
+
+// requireOrgWriter は組織にアイテムを登録・移動できない場合に ErrForbidden を返す（0 は個人のアイテム）
+func requireOrgWriter(actor *entity.User, orgID int64) error {
+	if orgID < 0 {
+		return fmt.Errorf("%w: org_id must be a positive integer", domainErrors.ErrInvalidInput)
+	}
+	if orgID == 0 {
+		return nil
+	}
+	if role, ok := actor.OrgRole(orgID); !ok || !role.CanWrite() {
+		return domainErrors.ErrForbidden
+	}
+	return nil
+}
+
 func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := newItemFromInput(actor, input)
+	if err != nil {
+		return nil, err
+	}
+
+	histories := entity.NewItemHistories(actor.ID, nil, item, u.now())
+	// 省略したブランド（カテゴリーの既定値を使う）は送信された値として記録しない
+	var rawBrand *string
+	if input.Brand != "" {
+		rawBrand = &input.Brand
+	}
+	entity.SetRawInputs(histories, &input.Name, rawBrand)
+
+	createdItem, err := u.createWithHistory(ctx, item, histories)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create item: %w", err)
+	}
+	redactItems(actor, createdItem)
+	localizeItems(ctx, createdItem)
+
+	return createdItem, nil
+}
+
+// newItemFromInput は登録内容を検証して、操作者が登録する新しいアイテムを作る（取り込みの各行にも使う）
+func newItemFromInput(actor *entity.User, input CreateItemInput) (*entity.Item, error) {
+	price, err := purchasePrice(input.PurchasePrice, input.PurchaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	applyCategoryDefaults(input.Category, &input.Brand, &input.Condition)
+
 	// バリデーションして、新しいエンティティを作成
 	item, err := entity.NewItem(
 		input.Name,
 		input.Category,
 		input.Brand,
-		input.PurchasePrice,
+		price,
 		input.PurchaseDate,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
 	}
-
-	createdItem, err := u.itemRepo.Create(ctx, item)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create item: %w", err)
+	if input.Visibility != "" {
+		if err := item.SetVisibility(input.Visibility); err != nil {
+			return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+	}
+	if err := item.SetCondition(input.Condition); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := item.SetSerialNumber(input.SerialNumber); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
 	}
+	if err := item.SetNotes(input.Notes); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := setItemAttributes(item, input.Attributes); err != nil {
+		return nil, err
+	}
+	if err := requireCategoryFields(item); err != nil {
+		return nil, err
+	}
+	if err := requireOrgWriter(actor, input.OrgID); err != nil {
+		return nil, err
+	}
+	item.UserID = actor.ID
+	item.OrgID = input.OrgID
 
-	return createdItem, nil
+	return item, nil
 }
 
 func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Validate ID
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
 
 	// Check if at least one field is provided
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
-		return nil, fmt.Errorf("%w: at least one field (name, brand, purchase_price) must be provided", domainErrors.ErrInvalidInput)
+	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil && input.PurchaseCurrency == nil && input.Visibility == nil && input.Condition == nil && input.SerialNumber == nil && input.Notes == nil && input.Attributes == nil && input.OrgID == nil {
+		return nil, fmt.Errorf("%w: at least one field (name, brand, purchase_price, purchase_currency, visibility, condition, serial_number, notes, attributes, org_id) must be provided", domainErrors.ErrInvalidInput)
 	}
 
-	// Fetch existing item to check existence and get current values
-	existingItem, err := u.itemRepo.FindByID(ctx, id)
+	// Fetch existing item to check existence, ownership and get current values
+	existingItem, err := findWritableItem(ctx, u.itemRepo, actor, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return nil, domainErrors.ErrItemNotFound
 		}
+		if domainErrors.IsForbiddenError(err) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
 
-	// Apply partial update using entity method
-	// This validates only the fields being updated
-	if err := existingItem.UpdatePartial(input.Name, input.Brand, input.PurchasePrice); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	// 他のクライアントの更新を上書きしないよう、クライアントが読み込んだバージョンと照合する
+	if input.Version != 0 && input.Version != existingItem.Version {
+		return nil, domainErrors.ErrItemVersionMismatch
 	}
 
+	before := *existingItem
+	if err := applyItemUpdate(actor, existingItem, input); err != nil {
+		return nil, err
+	}
+
+	histories := entity.NewItemHistories(actor.ID, &before, existingItem, u.now())
+	entity.SetRawInputs(histories, input.Name, input.Brand)
+
 	// Update in repository
-	updatedItem, err := u.itemRepo.Update(ctx, id, existingItem)
+	updatedItem, err := u.updateWithHistory(ctx, id, existingItem, histories)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return nil, domainErrors.ErrItemNotFound
 		}
 		return nil, fmt.Errorf("failed to update item: %w", err)
 	}
+	if err := u.attachTags(ctx, []*entity.Item{updatedItem}); err != nil {
+		return nil, err
+	}
+	redactItems(actor, updatedItem)
+	localizeItems(ctx, updatedItem)
+
+	return updatedItem, nil
+}
+
+// applyItemUpdate は部分更新の内容をアイテムに反映する（指定された項目のみ検証する）
+func applyItemUpdate(actor *entity.User, item *entity.Item, input UpdateItemInput) error {
+	// 操作者に非表示の項目は現在の値を確認できないため変更させない
+	var fields []string
+	if input.PurchasePrice != nil || input.PurchaseCurrency != nil {
+		fields = append(fields, entity.ItemFieldPurchasePrice)
+	}
+	if input.SerialNumber != nil {
+		fields = append(fields, entity.ItemFieldSerialNumber)
+	}
+	if err := requireUnredacted(actor, item, fields...); err != nil {
+		return err
+	}
+
+	// Apply partial update using entity method
+	// This validates only the fields being updated
+	price, err := updatedPurchasePrice(item.PurchasePrice, input)
+	if err != nil {
+		return err
+	}
+	if err := item.UpdatePartial(input.Name, input.Brand, price); err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if input.Visibility != nil {
+		if err := item.SetVisibility(*input.Visibility); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+	}
+	if input.Condition != nil {
+		if err := item.SetCondition(*input.Condition); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+	}
+	if input.SerialNumber != nil {
+		if err := item.SetSerialNumber(*input.SerialNumber); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+	}
+	if input.Notes != nil {
+		if err := item.SetNotes(*input.Notes); err != nil {
+			return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+		}
+	}
+	if input.Attributes != nil {
+		if err := setItemAttributes(item, *input.Attributes); err != nil {
+			return err
+		}
+	}
+	// 変更しない項目は検証しない（必須項目を設定する前に登録したアイテムも他の項目は変更できる）
+	if updated := updatedFields(input); len(updated) > 0 {
+		if err := requireCategoryFields(item, updated...); err != nil {
+			return err
+		}
+	}
+
+	if input.OrgID != nil {
+		return moveItemToOrg(actor, item, *input.OrgID)
+	}
+
+	return nil
+}
+
+// updatedFields は部分更新で指定された項目のうち、カテゴリーで必須にできる項目
+func updatedFields(input UpdateItemInput) []string {
+	var fields []string
+	if input.Condition != nil {
+		fields = append(fields, "condition")
+	}
+	if input.SerialNumber != nil {
+		fields = append(fields, entity.ItemFieldSerialNumber)
+	}
+	if input.Notes != nil {
+		fields = append(fields, "notes")
+	}
+	return fields
+}
+
+// updatedPurchasePrice は金額と通貨の指定を現在の購入価格に反映する（どちらも指定がない場合は nil）。
+// 通貨だけを変更した場合は金額の数値をそのまま新しい通貨の金額にする
+func updatedPurchasePrice(current entity.Money, input UpdateItemInput) (*entity.Money, error) {
+	if input.PurchasePrice == nil && input.PurchaseCurrency == nil {
+		return nil, nil
+	}
+	amount := current.Decimal()
+	if input.PurchasePrice != nil {
+		amount = *input.PurchasePrice
+	}
+	currency := string(current.Currency)
+	if input.PurchaseCurrency != nil {
+		currency = *input.PurchaseCurrency
+	}
+	price, err := purchasePrice(amount, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &price, nil
+}
+
+// purchasePrice は入力の金額（補助単位を小数にした10進数）と通貨を購入価格にする。
+// 通貨の補助単位より細かい端数（JPY の 0.5 や USD の 0.001）は検証エラーにする
+func purchasePrice(amount entity.Decimal, currency string) (entity.Money, error) {
+	price, err := entity.MoneyFromDecimal(amount, currency)
+	if err != nil {
+		var errs domainErrors.ValidationErrors
+		errs.Add("purchase_price", domainErrors.CodeInvalidFormat, "purchase_price "+err.Error())
+		return price, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, errs)
+	}
+	return price, nil
+}
+
+// moveItemToOrg はアイテムを組織に移す（0 は操作を行うユーザーの個人のアイテムに戻す）
+func moveItemToOrg(actor *entity.User, item *entity.Item, orgID int64) error {
+	if orgID == item.OrgID {
+		return nil
+	}
+	if err := requireOrgWriter(actor, orgID); err != nil {
+		return err
+	}
+	// 個人のアイテムに戻す場合は操作を行うユーザーのアイテムになる
+	if orgID == 0 {
+		item.UserID = actor.ID
+	}
+	item.OrgID = orgID
+	return nil
+}
+
+func (u *itemUsecase) ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	existingItem, err := findWritableItem(ctx, u.itemRepo, actor, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsForbiddenError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	// 他のクライアントの更新を上書きしないよう、クライアントが読み込んだバージョンと照合する
+	if input.Version != 0 && input.Version != existingItem.Version {
+		return nil, domainErrors.ErrItemVersionMismatch
+	}
+
+	// すべての項目を置き換えるため、操作者に非表示の項目があるアイテムは置き換えさせない
+	if err := requireUnredacted(actor, existingItem, entity.RedactableItemFields...); err != nil {
+		return nil, err
+	}
+
+	before := *existingItem
+	price, err := purchasePrice(input.PurchasePrice, input.PurchaseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	// 変更履歴には既定値を適用する前の送信された値を記録する
+	rawName, rawBrand := input.Name, input.Brand
+	applyCategoryDefaults(input.Category, &input.Brand, &input.Condition)
+	// 部分更新と異なり、カテゴリーと購入日を含むすべての項目を検証して置き換える
+	if err := existingItem.Update(input.Name, input.Category, input.Brand, price, input.PurchaseDate); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	visibility := input.Visibility
+	if visibility == "" {
+		visibility = string(entity.VisibilityPrivate)
+	}
+	if err := existingItem.SetVisibility(visibility); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := existingItem.SetCondition(input.Condition); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := existingItem.SetSerialNumber(input.SerialNumber); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := existingItem.SetNotes(input.Notes); err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := setItemAttributes(existingItem, input.Attributes); err != nil {
+		return nil, err
+	}
+	if err := requireCategoryFields(existingItem); err != nil {
+		return nil, err
+	}
+	if input.OrgID != nil {
+		if err := moveItemToOrg(actor, existingItem, *input.OrgID); err != nil {
+			return nil, err
+		}
+	}
+
+	histories := entity.NewItemHistories(actor.ID, &before, existingItem, u.now())
+	entity.SetRawInputs(histories, &rawName, &rawBrand)
+	updatedItem, err := u.updateWithHistory(ctx, id, existingItem, histories)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to replace item: %w", err)
+	}
+	if err := u.attachTags(ctx, []*entity.Item{updatedItem}); err != nil {
+		return nil, err
+	}
+	redactItems(actor, updatedItem)
+	localizeItems(ctx, updatedItem)
+
+	return updatedItem, nil
+}
+
+// 一括更新で指定できるアイテムの最大数
+const maxBulkUpdateItems = 100
+
+func (u *itemUsecase) BulkUpdateItems(ctx context.Context, input BulkUpdateItemsInput) ([]*entity.Item, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := validateBulkItemIDs(input.IDs)
+	if err != nil {
+		return nil, err
+	}
+	update := input.UpdateItemInput
+	if update.Name == nil && update.Brand == nil && update.PurchasePrice == nil && update.PurchaseCurrency == nil && update.Visibility == nil && update.Condition == nil && update.SerialNumber == nil && update.Notes == nil && update.Attributes == nil && update.OrgID == nil {
+		return nil, fmt.Errorf("%w: at least one field (name, brand, purchase_price, purchase_currency, visibility, condition, serial_number, notes, attributes, org_id) must be provided", domainErrors.ErrInvalidInput)
+	}
+
+	return u.updateItems(ctx, actor, ids, func(int64) UpdateItemInput { return update })
+}
+
+// updateItems はアイテムごとの変更（updateFor）をすべてのアイテムで検証してから、1つのトランザクションで更新する
+// （1件でも失敗した場合は何も更新しない）
+func (u *itemUsecase) updateItems(ctx context.Context, actor *entity.User, ids []int64, updateFor func(id int64) UpdateItemInput) ([]*entity.Item, error) {
+	items := make([]*entity.Item, 0, len(ids))
+	before := make(map[int64]entity.Item, len(ids))
+	inputs := make(map[int64]UpdateItemInput, len(ids))
+	var missing []string
+	for _, id := range ids {
+		item, err := findWritableItem(ctx, u.itemRepo, actor, id)
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				missing = append(missing, strconv.FormatInt(id, 10))
+				continue
+			}
+			if domainErrors.IsForbiddenError(err) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to retrieve item: %w", err)
+		}
+		before[id] = *item
+		inputs[id] = updateFor(id)
+		if err := applyItemUpdate(actor, item, inputs[id]); err != nil {
+			if domainErrors.IsValidationError(err) {
+				return nil, fmt.Errorf("%w (item %d)", err, id)
+			}
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrItemNotFound, strings.Join(missing, ", "))
+	}
+
+	now := u.now()
+	var histories []*entity.ItemHistory
+	for _, item := range items {
+		old := before[item.ID]
+		itemHistories := entity.NewItemHistories(actor.ID, &old, item, now)
+		entity.SetRawInputs(itemHistories, inputs[item.ID].Name, inputs[item.ID].Brand)
+		histories = append(histories, itemHistories...)
+	}
+
+	updated, err := u.updateManyWithHistory(ctx, items, histories)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to update items: %w", err)
+	}
+	if err := u.attachTags(ctx, updated); err != nil {
+		return nil, err
+	}
+	redactItems(actor, updated...)
+	localizeItems(ctx, updated...)
+
+	return updated, nil
+}
+
+// validateBulkItemIDs は一括操作の対象のIDを検証し、重複を除いて指定された順に返す
+func validateBulkItemIDs(ids []int64) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: ids must not be empty", domainErrors.ErrInvalidInput)
+	}
+
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: ids must be positive integers", domainErrors.ErrInvalidInput)
+		}
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > maxBulkUpdateItems {
+		return nil, fmt.Errorf("%w: ids must contain at most %d items", domainErrors.ErrInvalidInput, maxBulkUpdateItems)
+	}
+
+	return unique, nil
+}
+
+// ChangeItemStatusInput はアイテムの所有状況の変更内容
+type ChangeItemStatusInput struct {
+	Status string `json:"status"`
+}
+
+func (u *itemUsecase) ChangeItemStatus(ctx context.Context, id int64, input ChangeItemStatusInput) (*entity.Item, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := findWritableItem(ctx, u.itemRepo, actor, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsForbiddenError(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	before := *item
+	if err := item.ChangeStatus(input.Status); err != nil {
+		return nil, err
+	}
+
+	updatedItem := item
+	if item.Status != before.Status {
+		updatedItem, err = u.updateWithHistory(ctx, id, item, entity.NewItemHistories(actor.ID, &before, item, u.now()))
+		if err != nil {
+			if domainErrors.IsNotFoundError(err) {
+				return nil, domainErrors.ErrItemNotFound
+			}
+			return nil, fmt.Errorf("failed to update item: %w", err)
+		}
+	}
+	if err := u.attachTags(ctx, []*entity.Item{updatedItem}); err != nil {
+		return nil, err
+	}
+	redactItems(actor, updatedItem)
+	localizeItems(ctx, updatedItem)
 
 	return updatedItem, nil
 }
 
 func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return err
+	}
+
 	if id <= 0 {
 		return domainErrors.ErrInvalidInput
 	}
 
-	_, err := u.itemRepo.FindByID(ctx, id)
+	item, err := findWritableItem(ctx, u.itemRepo, actor, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return domainErrors.ErrItemNotFound
 		}
+		if domainErrors.IsForbiddenError(err) {
+			return err
+		}
 		return fmt.Errorf("failed to check item existence: %w", err)
 	}
 
-	err = u.itemRepo.Delete(ctx, id)
+	// 画像のレコードはアイテムと一緒に削除されるため、先にファイルの保存先を取得しておく
+	var images []*entity.ItemImage
+	if u.imageRepo != nil {
+		images, err = u.imageRepo.FindByItemID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve images: %w", err)
+		}
+	}
+
+	// 削除前の値を残すため、アイテムの削除後も変更履歴は削除しない
+	err = u.deleteWithHistory(ctx, item, entity.NewItemHistories(actor.ID, item, nil, u.now()))
 	if err != nil {
 		return fmt.Errorf("failed to delete item: %w", err)
 	}
 
+	// アイテムは削除済みのため、ファイルの削除に失敗しても処理は成功とする
+	for _, image := range images {
+		for _, key := range image.StorageKeys() {
+			_ = u.storage.Delete(ctx, key)
+		}
+	}
+
 	return nil
 }
 
-func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
-	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
+func (u *itemUsecase) GetItemHistory(ctx context.Context, id int64) ([]*entity.ItemHistory, error) {
+	actor, err := requireActor(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get category summary: %w", err)
+		return nil, err
+	}
+
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
 	}
 
-	// 合計計算
-	total := 0
-	for _, count := range categoryCounts {
-		total += count
+	item, err := findItemForActor(ctx, u.itemRepo, actor, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
 
-	summary := make(map[string]int)
+	if u.historyRepo == nil {
+		return []*entity.ItemHistory{}, nil
+	}
+	histories, err := u.historyRepo.FindByItemID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item history: %w", err)
+	}
+
+	return redactHistories(actor, item, histories), nil
+}
+
+// createWithHistory はアイテムを登録し、同じトランザクションで登録を変更履歴に記録する（変更履歴を扱わない構成では登録のみ行う）
+func (u *itemUsecase) createWithHistory(ctx context.Context, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error) {
+	defer u.invalidateSummary(histories, item)
+	if u.historyRepo == nil {
+		return u.itemRepo.Create(ctx, item)
+	}
+	return u.historyRepo.RecordCreate(ctx, item, histories)
+}
+
+// updateWithHistory はアイテムを更新し、同じトランザクションで変更履歴を記録する（変更履歴を扱わない構成では更新のみ行う）
+func (u *itemUsecase) updateWithHistory(ctx context.Context, id int64, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error) {
+	defer u.invalidateSummary(histories, item)
+	if u.historyRepo == nil {
+		return u.itemRepo.Update(ctx, id, item)
+	}
+	return u.historyRepo.RecordUpdate(ctx, id, item, histories)
+}
+
+// updateManyWithHistory はアイテムを1つのトランザクションで更新し、同じトランザクションで変更履歴を記録する
+func (u *itemUsecase) updateManyWithHistory(ctx context.Context, items []*entity.Item, histories []*entity.ItemHistory) ([]*entity.Item, error) {
+	defer u.invalidateSummary(histories, items...)
+	if u.historyRepo == nil {
+		return u.itemRepo.UpdateMany(ctx, items)
+	}
+	return u.historyRepo.RecordUpdates(ctx, items, histories)
+}
+
+// deleteWithHistory はアイテムを削除し、同じトランザクションで削除を変更履歴に記録する
+func (u *itemUsecase) deleteWithHistory(ctx context.Context, item *entity.Item, histories []*entity.ItemHistory) error {
+	defer u.invalidateSummary(histories, item)
+	if u.historyRepo == nil {
+		return u.itemRepo.Delete(ctx, item.ID)
+	}
+	return u.historyRepo.RecordDelete(ctx, item.ID, histories)
+}
+
+func (u *itemUsecase) GetCategorySummary(ctx context.Context, asOf string) (*CategorySummary, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []entity.CategoryValue
+	if asOf == "" {
+		values, err = u.loadCategoryValues(ctx, itemScope(actor))
+	} else {
+		// 過去の時点の集計は同時実行をまとめず、クイック集計にも使わない
+		date, parseErr := time.Parse("2006-01-02", asOf)
+		if parseErr != nil {
+			return nil, fmt.Errorf("%w: as_of must be in YYYY-MM-DD format", domainErrors.ErrInvalidInput)
+		}
+		if date.After(u.now()) {
+			return nil, fmt.Errorf("%w: as_of must not be in the future", domainErrors.ErrInvalidInput)
+		}
+		values, err = u.itemRepo.GetSummaryByCategoryAsOf(ctx, itemScope(actor), asOf)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+
+	valuation := newValuation(actor, u.converter)
+	summary := &CategorySummary{
+		AsOf:       asOf,
+		Categories: make(map[string]int),
+		Currency:   valuation.currency,
+		Values:     make(map[string]int64),
+		Stats:      make(map[string]*CategoryStats),
+	}
 	for _, category := range entity.GetValidCategories() {
-		if count, exists := categoryCounts[category]; exists {
-			summary[category] = count
-		} else {
-			summary[category] = 0
+		summary.Categories[category] = 0
+		summary.Values[category] = 0
+		summary.Stats[category] = &CategoryStats{}
+	}
+	for _, v := range values {
+		// アイテムごとではなくカテゴリーと通貨ごとの合計を換算する。
+		// 購入価格を非表示にする組織のアイテムは件数のみ数える
+		redacted := entity.ItemRedactionFor(actor, v.OrgID)
+		var value int64
+		ok := false
+		if !slices.Contains(redacted, entity.ItemFieldPurchasePrice) {
+			value, ok, err = valuation.convert(ctx, v.Value, v.Currency)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		summary.Total += v.Count
+		if ok {
+			summary.TotalValue = entity.AddAmount(summary.TotalValue, value)
+		}
+		if _, known := summary.Categories[v.Category]; !known {
+			continue
+		}
+		summary.Categories[v.Category] += v.Count
+		stats := summary.Stats[v.Category]
+		stats.Count += v.Count
+		if !slices.Contains(redacted, entity.ItemFieldPurchaseDate) && v.NewestPurchaseDate != "" &&
+			(stats.NewestPurchaseDate == nil || v.NewestPurchaseDate > *stats.NewestPurchaseDate) {
+			stats.NewestPurchaseDate = &v.NewestPurchaseDate
+		}
+		if !ok {
+			continue
+		}
+		summary.Values[v.Category] = entity.AddAmount(summary.Values[v.Category], value)
+		minValue, maxValue, err := convertValueRange(ctx, valuation, v, value)
+		if err != nil {
+			return nil, err
+		}
+		stats.addValues(v.Count, value, minValue, maxValue)
+	}
+	for _, stats := range summary.Stats {
+		if stats.ValuedCount > 0 {
+			average := stats.TotalValue / int64(stats.ValuedCount)
+			stats.AverageValue = &average
 		}
 	}
 
-	return &CategorySummary{
-		Categories: summary,
-		Total:      total,
+	return summary, nil
+}
+
+// convertValueRange はグループの購入価格の最小と最大を表示通貨に換算する（1件のグループは換算済みの合計と同じ）
+func convertValueRange(ctx context.Context, valuation valuation, v entity.CategoryValue, value int64) (int64, int64, error) {
+	if v.Count == 1 {
+		return value, value, nil
+	}
+	minValue, _, err := valuation.convert(ctx, v.MinValue, v.Currency)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxValue, _, err := valuation.convert(ctx, v.MaxValue, v.Currency)
+	if err != nil {
+		return 0, 0, err
+	}
+	return minValue, maxValue, nil
+}
+
+// addValues は表示通貨に換算したグループの件数・合計・最小・最大を統計に加える
+func (s *CategoryStats) addValues(count int, total, minValue, maxValue int64) {
+	s.ValuedCount += count
+	s.TotalValue = entity.AddAmount(s.TotalValue, total)
+	if s.MinValue == nil || minValue < *s.MinValue {
+		s.MinValue = &minValue
+	}
+	if s.MaxValue == nil || maxValue > *s.MaxValue {
+		s.MaxValue = &maxValue
+	}
+}
+
+func (u *itemUsecase) SearchItems(ctx context.Context, query string) ([]*entity.SearchResult, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, fmt.Errorf("%w: q is required", domainErrors.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, fmt.Errorf("%w: q must be %d characters or less", domainErrors.ErrInvalidInput, maxSearchQueryLength)
+	}
+
+	results, err := u.itemRepo.Search(ctx, query, itemScope(actor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search items: %w", err)
+	}
+	if u.documentIndex != nil {
+		if results, err = u.searchDocuments(ctx, actor, query, results); err != nil {
+			return nil, err
+		}
+	}
+	items := make([]*entity.Item, len(results))
+	for i, result := range results {
+		items[i] = result.Item
+	}
+	if err := u.attachThumbnails(ctx, items); err != nil {
+		return nil, err
+	}
+	if err := u.attachTags(ctx, items); err != nil {
+		return nil, err
+	}
+	redactItems(actor, items...)
+	localizeItems(ctx, items...)
+
+	return results, nil
+}
+
+// PreviewQuickAdd はテキストを解析して登録内容のプレビューを返す（登録は行わない）
+func (u *itemUsecase) PreviewQuickAdd(ctx context.Context, input QuickAddInput) ([]QuickAddPreview, error) {
+	previews, err := ParseQuickAdd(input.Text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+
+	return previews, nil
+}
+
+// ParseItemText は自由入力のテキストから登録内容の下書きを推定する（登録は行わない）
+func (u *itemUsecase) ParseItemText(ctx context.Context, input ParseItemInput) (*ItemDraft, error) {
+	text := strings.TrimSpace(input.Text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: text is required", domainErrors.ErrInvalidInput)
+	}
+	if utf8.RuneCountInString(text) > maxParseTextLength {
+		return nil, fmt.Errorf("%w: text must be %d characters or less", domainErrors.ErrInvalidInput, maxParseTextLength)
+	}
+
+	extracted, err := u.extractor.Extract(ctx, text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract item fields: %w", err)
+	}
+
+	errs := validateCreateItemInput(extracted)
+	return &ItemDraft{
+		Text:   text,
+		Input:  extracted,
+		Valid:  len(errs) == 0,
+		Errors: errs,
 	}, nil
 }
+
+// scopeItemFilter は絞り込み条件を検証し、操作者が参照できるアイテムに絞り込む条件を返す。
+// 一覧・エクスポート・カテゴリーの一括変更で同じ検証をするため、同じ条件がどこでも同じアイテムに一致する
+func scopeItemFilter(actor *entity.User, filter entity.ItemFilter) (entity.ItemFilter, error) {
+	// 英語の表示名で指定されたカテゴリーも受け付ける
+	filter.Category = entity.ResolveCategory(filter.Category)
+	if err := filter.Validate(); err != nil {
+		return filter, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+	}
+	if err := validateItemSort(filter.Sort); err != nil {
+		return filter, err
+	}
+	if err := validateAttributeFilter(filter.Attributes); err != nil {
+		return filter, err
+	}
+	filter.UserID = itemScope(actor)
+	return filter, nil
+}
+
+// ソート条件をホワイトリストで検証する
+func validateItemSort(sort entity.ItemSort) error {
+	if sort.Key != "" && !slices.Contains(itemSortKeys, sort.Key) {
+		return fmt.Errorf("%w: sort must be one of: %s", domainErrors.ErrInvalidInput, strings.Join(itemSortKeys, ", "))
+	}
+	if sort.Order != "" && sort.Order != entity.SortOrderAsc && sort.Order != entity.SortOrderDesc {
+		return fmt.Errorf("%w: order must be one of: asc, desc", domainErrors.ErrInvalidInput)
+	}
+	if sort.Collation != "" {
+		if sort.Collation != entity.CollationJa && sort.Collation != entity.CollationNatural {
+			return fmt.Errorf("%w: collation must be one of: ja, natural", domainErrors.ErrInvalidInput)
+		}
+		if sort.Key != entity.SortKeyName {
+			return fmt.Errorf("%w: collation requires sort=name", domainErrors.ErrInvalidInput)
+		}
+	}
+	return nil
+}
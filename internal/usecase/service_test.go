@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,8 +19,8 @@ type MockItemRepository struct {
 	mock.Mock
 }
 
-func (m *MockItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
+func (m *MockItemRepository) FindAll(ctx context.Context, filter entity.ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
 	return args.Get(0).([]*entity.Item), args.Error(1)
 }
 
@@ -46,17 +48,179 @@ func (m *MockItemRepository) Update(ctx context.Context, id int64, item *entity.
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) CreateMany(ctx context.Context, items []*entity.Item) error {
+	args := m.Called(ctx, items)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) UpdateMany(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	args := m.Called(ctx, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) Delete(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
-	args := m.Called(ctx)
+func (m *MockItemRepository) Search(ctx context.Context, query string, ownerID int64) ([]*entity.SearchResult, error) {
+	args := m.Called(ctx, query, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.SearchResult), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context, ownerID int64) ([]entity.CategoryValue, error) {
+	args := m.Called(ctx, ownerID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string]int), args.Error(1)
+	return args.Get(0).([]entity.CategoryValue), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByBrand(ctx context.Context, ownerID int64) ([]entity.BrandValue, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.BrandValue), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByCategoryAsOf(ctx context.Context, ownerID int64, asOf string) ([]entity.CategoryValue, error) {
+	args := m.Called(ctx, ownerID, asOf)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.CategoryValue), args.Error(1)
+}
+
+func (m *MockItemRepository) GetPurchasesByPeriod(ctx context.Context, ownerID int64, unit entity.PurchasePeriodUnit) ([]entity.PurchasePeriodValue, error) {
+	args := m.Called(ctx, ownerID, unit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.PurchasePeriodValue), args.Error(1)
+}
+
+func (m *MockItemRepository) GetHoldingValues(ctx context.Context, ownerID int64) ([]entity.HoldingValue, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.HoldingValue), args.Error(1)
+}
+
+func (m *MockItemRepository) FindTopByValue(ctx context.Context, ownerID int64, by entity.ItemValueKey, n int) ([]*entity.Item, error) {
+	args := m.Called(ctx, ownerID, by, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) FindHeld(ctx context.Context, ownerID int64) ([]*entity.Item, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+// testActor はテストで操作を行うユーザー
+var testActor = &entity.User{ID: 1, Email: "user@example.com", Role: entity.RoleEditor}
+
+type MockItemHistoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemHistoryRepository) Create(ctx context.Context, histories []*entity.ItemHistory) error {
+	args := m.Called(ctx, histories)
+	return args.Error(0)
+}
+
+func (m *MockItemHistoryRepository) FindByItemID(ctx context.Context, itemID int64) ([]*entity.ItemHistory, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemHistory), args.Error(1)
+}
+
+func (m *MockItemHistoryRepository) FindSince(ctx context.Context, since time.Time, afterID int64, limit int) ([]*entity.ItemHistory, error) {
+	args := m.Called(ctx, since, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.ItemHistory), args.Error(1)
+}
+
+func (m *MockItemHistoryRepository) RecordCreate(ctx context.Context, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error) {
+	args := m.Called(ctx, item, histories)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemHistoryRepository) RecordCreateMany(ctx context.Context, items []*entity.Item, histories [][]*entity.ItemHistory) error {
+	args := m.Called(ctx, items, histories)
+	return args.Error(0)
+}
+
+func (m *MockItemHistoryRepository) RecordUpdate(ctx context.Context, id int64, item *entity.Item, histories []*entity.ItemHistory) (*entity.Item, error) {
+	args := m.Called(ctx, id, item, histories)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Item), args.Error(1)
+}
+
+func (m *MockItemHistoryRepository) RecordUpdates(ctx context.Context, items []*entity.Item, histories []*entity.ItemHistory) ([]*entity.Item, error) {
+	args := m.Called(ctx, items, histories)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemHistoryRepository) RecordDelete(ctx context.Context, id int64, histories []*entity.ItemHistory) error {
+	args := m.Called(ctx, id, histories)
+	return args.Error(0)
+}
+
+type MockItemViewRepository struct {
+	mock.Mock
+}
+
+func (m *MockItemViewRepository) Record(ctx context.Context, userID, itemID int64, viewedAt time.Time, limit int) error {
+	args := m.Called(ctx, userID, itemID, viewedAt, limit)
+	return args.Error(0)
+}
+
+func (m *MockItemViewRepository) FindRecentItems(ctx context.Context, userID, scope int64, limit int) ([]*entity.RecentlyViewedItem, error) {
+	args := m.Called(ctx, userID, scope, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.RecentlyViewedItem), args.Error(1)
+}
+
+// actorContext は testActor を設定したコンテキストを返す
+func actorContext() context.Context {
+	return WithActor(context.Background(), testActor)
+}
+
+// newOwnedItem は testActor が所有するアイテムを作成する
+func newOwnedItem(name, category, brand string, purchasePrice int, purchaseDate string) (*entity.Item, error) {
+	item, err := entity.NewItem(name, category, brand, entity.JPY(purchasePrice), purchaseDate)
+	if item != nil {
+		item.UserID = testActor.ID
+	}
+	return item, err
 }
 
 func TestNewItemUsecase(t *testing.T) {
@@ -76,10 +240,10 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 		{
 			name: "正常系: 複数のアイテムを取得",
 			setupMock: func(mockRepo *MockItemRepository) {
-				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
-				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
+				item1, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item2, _ := newOwnedItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
 				items := []*entity.Item{item1, item2}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(items, nil)
 			},
 			expectedCount: 2,
 			expectedErr:   nil,
@@ -88,7 +252,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 			name: "正常系: アイテムが0件",
 			setupMock: func(mockRepo *MockItemRepository) {
 				items := []*entity.Item{}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(items, nil)
 			},
 			expectedCount: 0,
 			expectedErr:   nil,
@@ -96,7 +260,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindAll", mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("FindAll", mock.Anything, mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectedCount: 0,
 			expectedErr:   domainErrors.ErrDatabaseError,
@@ -109,8 +273,8 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 			tt.setupMock(mockRepo)
 			usecase := NewItemUsecase(mockRepo)
 
-			ctx := context.Background()
-			items, err := usecase.GetAllItems(ctx)
+			ctx := actorContext()
+			items, err := usecase.GetAllItems(ctx, entity.ItemFilter{})
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -126,6 +290,111 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_GetAllItems_Filter(t *testing.T) {
+	t.Run("正常系: 絞り込み条件をリポジトリに渡す", func(t *testing.T) {
+		minPrice, maxPrice := 100000, 2000000
+		filter := entity.ItemFilter{
+			Category:         "時計",
+			MinPurchasePrice: &minPrice,
+			MaxPurchasePrice: &maxPrice,
+			PurchaseDateFrom: "2023-01-01",
+			PurchaseDateTo:   "2023-12-31",
+		}
+
+		// 所有者はユースケースが操作者から設定する
+		expected := filter
+		expected.UserID = testActor.ID
+
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, expected).Return([]*entity.Item{}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(actorContext(), filter)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 英語の表示名で絞り込み、表示言語のカテゴリー名で返す", func(t *testing.T) {
+		item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		expected := entity.ItemFilter{Category: "時計", UserID: testActor.ID}
+
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, expected).Return([]*entity.Item{item}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		ctx := WithLanguage(actorContext(), entity.LanguageEnglish)
+		items, err := usecase.GetAllItems(ctx, entity.ItemFilter{Category: "watch"})
+		require.NoError(t, err)
+		assert.Equal(t, "Watch", items[0].Category)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 不正な絞り込み条件", func(t *testing.T) {
+		minPrice, maxPrice := 2000000, 100000
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(actorContext(), entity.ItemFilter{
+			MinPurchasePrice: &minPrice,
+			MaxPurchasePrice: &maxPrice,
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: ホワイトリストにないソートキー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(actorContext(), entity.ItemFilter{
+			Sort: entity.ItemSort{Key: "id; DROP TABLE items"},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 不正なソート順", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(actorContext(), entity.ItemFilter{
+			Sort: entity.ItemSort{Key: entity.SortKeyName, Order: "sideways"},
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("異常系: 名前以外のソートキーへの照合順序の指定", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		for _, sort := range []entity.ItemSort{
+			{Key: entity.SortKeyPurchasePrice, Collation: entity.CollationJa},
+			{Key: entity.SortKeyName, Collation: "fr"},
+		} {
+			_, err := usecase.GetAllItems(actorContext(), entity.ItemFilter{Sort: sort})
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		}
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: ソート条件をリポジトリに渡す", func(t *testing.T) {
+		filter := entity.ItemFilter{
+			Sort: entity.ItemSort{Key: entity.SortKeyPurchasePrice, Order: entity.SortOrderDesc},
+		}
+		// 所有者はユースケースが操作者から設定する
+		expected := filter
+		expected.UserID = testActor.ID
+
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, expected).Return([]*entity.Item{}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(actorContext(), filter)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestItemUsecase_GetItemByID(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -138,7 +407,7 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 			name: "正常系: 存在するアイテムを取得",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
 			},
@@ -178,7 +447,7 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 			tt.setupMock(mockRepo)
 			usecase := NewItemUsecase(mockRepo)
 
-			ctx := context.Background()
+			ctx := actorContext()
 			item, err := usecase.GetItemByID(ctx, tt.id)
 
 			if tt.expectError {
@@ -212,11 +481,11 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "ロレックス デイトナ",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: entity.Decimal{Units: 1500000},
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				createdItem, _ := entity.NewItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				createdItem, _ := newOwnedItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
 				createdItem.ID = 1
 				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(createdItem, nil)
 			},
@@ -228,7 +497,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "",
 				Category:      "時計",
 				Brand:         "ROLEX",
-				PurchasePrice: 1500000,
+				PurchasePrice: entity.Decimal{Units: 1500000},
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -243,7 +512,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "アイテム",
 				Category:      "無効なカテゴリー",
 				Brand:         "ブランド",
-				PurchasePrice: 100000,
+				PurchasePrice: entity.Decimal{Units: 100000},
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -258,7 +527,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				Name:          "アイテム",
 				Category:      "時計",
 				Brand:         "ブランド",
-				PurchasePrice: 100000,
+				PurchasePrice: entity.Decimal{Units: 100000},
 				PurchaseDate:  "2023-01-15",
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
@@ -274,7 +543,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 			tt.setupMock(mockRepo)
 			usecase := NewItemUsecase(mockRepo)
 
-			ctx := context.Background()
+			ctx := actorContext()
 			item, err := usecase.CreateItem(ctx, tt.input)
 
 			if tt.expectError {
@@ -289,7 +558,7 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 				assert.Equal(t, tt.input.Name, item.Name)
 				assert.Equal(t, tt.input.Category, item.Category)
 				assert.Equal(t, tt.input.Brand, item.Brand)
-				assert.Equal(t, tt.input.PurchasePrice, item.PurchasePrice)
+				assert.Equal(t, tt.input.PurchasePrice, item.PurchasePrice.Decimal())
 				assert.Equal(t, tt.input.PurchaseDate, item.PurchaseDate)
 			}
 
@@ -319,9 +588,9 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				PurchasePrice: nil,
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
-				updatedItem, _ := entity.NewItem("更新された名前", "時計", "初期ブランド", 100000, "2023-01-01")
+				updatedItem, _ := newOwnedItem("更新された名前", "時計", "初期ブランド", 100000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
@@ -340,9 +609,9 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				PurchasePrice: nil,
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
-				updatedItem, _ := entity.NewItem("初期アイテム", "時計", "更新されたブランド", 100000, "2023-01-01")
+				updatedItem, _ := newOwnedItem("初期アイテム", "時計", "更新されたブランド", 100000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
@@ -358,12 +627,12 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			input: UpdateItemInput{
 				Name:          nil,
 				Brand:         nil,
-				PurchasePrice: intPtr(200000),
+				PurchasePrice: decimalPtr(200000),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
-				updatedItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 200000, "2023-01-01")
+				updatedItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 200000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
@@ -379,12 +648,12 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			input: UpdateItemInput{
 				Name:          stringPtr("新しい名前"),
 				Brand:         stringPtr("新しいブランド"),
-				PurchasePrice: intPtr(300000),
+				PurchasePrice: decimalPtr(300000),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
-				updatedItem, _ := entity.NewItem("新しい名前", "時計", "新しいブランド", 300000, "2023-01-01")
+				updatedItem, _ := newOwnedItem("新しい名前", "時計", "新しいブランド", 300000, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
@@ -400,12 +669,12 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			input: UpdateItemInput{
 				Name:          nil,
 				Brand:         nil,
-				PurchasePrice: intPtr(0),
+				PurchasePrice: decimalPtr(0),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
-				updatedItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 0, "2023-01-01")
+				updatedItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 0, "2023-01-01")
 				updatedItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return(updatedItem, nil)
@@ -466,7 +735,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				PurchasePrice: nil,
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				// Updateは呼ばれない
@@ -478,12 +747,12 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			name: "異常系: nameが100文字超過",
 			id:   1,
 			input: UpdateItemInput{
-				Name:          stringPtr("ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム"),
+				Name:          stringPtr(strings.Repeat("あ", 101)),
 				Brand:         nil,
 				PurchasePrice: nil,
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				// Updateは呼ばれない
@@ -497,10 +766,10 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			input: UpdateItemInput{
 				Name:          nil,
 				Brand:         nil,
-				PurchasePrice: intPtr(-1),
+				PurchasePrice: decimalPtr(-1),
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				// Updateは呼ばれない
@@ -530,7 +799,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				PurchasePrice: nil,
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
@@ -546,7 +815,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 				PurchasePrice: nil,
 			},
 			setupMock: func(mockRepo *MockItemRepository) {
-				existingItem, _ := entity.NewItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
+				existingItem, _ := newOwnedItem("初期アイテム", "時計", "初期ブランド", 100000, "2023-01-01")
 				existingItem.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
 				mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
@@ -562,7 +831,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 			tt.setupMock(mockRepo)
 			usecase := NewItemUsecase(mockRepo)
 
-			ctx := context.Background()
+			ctx := actorContext()
 			item, err := usecase.UpdateItem(ctx, tt.id, tt.input)
 
 			if tt.expectError {
@@ -581,7 +850,7 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 					assert.Equal(t, tt.checkBrand, item.Brand)
 				}
 				if tt.checkPrice != 0 || tt.input.PurchasePrice != nil {
-					assert.Equal(t, tt.checkPrice, item.PurchasePrice)
+					assert.Equal(t, tt.checkPrice, item.PurchasePrice.Amount)
 				}
 			}
 
@@ -590,6 +859,260 @@ func TestItemUsecase_UpdateItem(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_BulkUpdateItems(t *testing.T) {
+	newItems := func() (*entity.Item, *entity.Item) {
+		first, _ := newOwnedItem("バーキン", "バッグ", "HERMES", 2000000, "2023-01-01")
+		first.ID = 1
+		second, _ := newOwnedItem("ケリー", "バッグ", "HERMES", 1800000, "2023-02-01")
+		second.ID = 2
+		return first, second
+	}
+
+	t.Run("正常系: 同じ部分更新を複数のアイテムに適用する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		first, second := newItems()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(first, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(second, nil)
+		mockRepo.On("UpdateMany", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+			return len(items) == 2 && items[0].Brand == "HERMÈS" && items[1].Brand == "HERMÈS"
+		})).Return([]*entity.Item{first, second}, nil)
+
+		items, err := NewItemUsecase(mockRepo).BulkUpdateItems(actorContext(), BulkUpdateItemsInput{
+			IDs:             []int64{1, 2, 1},
+			UpdateItemInput: UpdateItemInput{Brand: stringPtr("HERMÈS")},
+		})
+
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 存在しないアイテムが含まれる場合は何も更新しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		first, _ := newItems()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(first, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(3)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+		mockRepo.On("FindByID", mock.Anything, int64(5)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+
+		_, err := NewItemUsecase(mockRepo).BulkUpdateItems(actorContext(), BulkUpdateItemsInput{
+			IDs:             []int64{1, 3, 5},
+			UpdateItemInput: UpdateItemInput{Brand: stringPtr("HERMÈS")},
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Contains(t, err.Error(), "3, 5")
+		mockRepo.AssertNotCalled(t, "UpdateMany")
+	})
+
+	t.Run("異常系: 1件でも検証に失敗した場合は何も更新しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		first, second := newItems()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(first, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(2)).Return(second, nil)
+
+		_, err := NewItemUsecase(mockRepo).BulkUpdateItems(actorContext(), BulkUpdateItemsInput{
+			IDs:             []int64{1, 2},
+			UpdateItemInput: UpdateItemInput{Brand: stringPtr("")},
+		})
+
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "UpdateMany")
+	})
+
+	t.Run("異常系: idsが空", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).BulkUpdateItems(actorContext(), BulkUpdateItemsInput{
+			UpdateItemInput: UpdateItemInput{Brand: stringPtr("HERMÈS")},
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByID")
+	})
+}
+
+func TestItemUsecase_ItemHistory(t *testing.T) {
+	newItem := func() *entity.Item {
+		item, _ := newOwnedItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		return item
+	}
+
+	t.Run("正常系: 更新した項目の変更前後の値を記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		updated := newItem()
+		updated.PurchasePrice = entity.JPY(1600000)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		// 更新と変更履歴の記録は同じトランザクションで行う
+		historyRepo.On("RecordUpdate", mock.Anything, int64(1), mock.Anything, mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			return len(histories) == 1 && histories[0].Field == "purchase_price" &&
+				*histories[0].OldValue == "1500000" && *histories[0].NewValue == "1600000" &&
+				histories[0].ActorID == testActor.ID && histories[0].Action == entity.ItemHistoryActionUpdate
+		})).Return(updated, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).UpdateItem(actorContext(), 1, UpdateItemInput{PurchasePrice: decimalPtr(1600000)})
+
+		require.NoError(t, err)
+		historyRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 名前とブランドは正規化する前の送信された値も記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		updated := newItem()
+		updated.Name = "デイトナ 116500LN"
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		// 正規化しても変わらないブランドは変更履歴に記録しない
+		historyRepo.On("RecordUpdate", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "デイトナ 116500LN" && item.Brand == "ROLEX"
+		}), mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			return len(histories) == 1 && histories[0].Field == "name" &&
+				*histories[0].NewValue == "デイトナ 116500LN" && *histories[0].RawValue == "  デイトナ 116500LN\t"
+		})).Return(updated, nil)
+
+		item, err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).UpdateItem(actorContext(), 1, UpdateItemInput{
+			Name:  stringPtr("  デイトナ 116500LN\t"),
+			Brand: stringPtr(" ROLEX "),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "デイトナ 116500LN", item.Name)
+		historyRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 登録では同じトランザクションで登録を記録し、送信された名前も記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		historyRepo.On("RecordCreate", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "デイトナ 116500LN"
+		}), mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			return len(histories) == 13 && histories[0].Field == "name" && histories[0].Action == entity.ItemHistoryActionCreate &&
+				histories[0].ActorID == testActor.ID && histories[0].OldValue == nil &&
+				*histories[0].NewValue == "デイトナ 116500LN" && *histories[0].RawValue == " デイトナ 116500LN\t" &&
+				histories[2].Field == "brand" && histories[2].RawValue == nil
+		})).Return(newItem(), nil)
+
+		_, err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).CreateItem(actorContext(), CreateItemInput{
+			Name: " デイトナ 116500LN\t", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1500000}, PurchaseDate: "2023-01-15",
+		})
+
+		require.NoError(t, err)
+		historyRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 置き換えでも送信された名前を記録し、正規化後と同じ場合は記録しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		updated := newItem()
+		updated.Name = "デイトナ 116500LN"
+		updated.Brand = "Rolex"
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		historyRepo.On("RecordUpdate", mock.Anything, int64(1), mock.Anything, mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			return len(histories) == 2 &&
+				histories[0].Field == "name" && *histories[0].RawValue == "デイトナ 116500LN " &&
+				histories[1].Field == "brand" && histories[1].RawValue == nil
+		})).Return(updated, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).ReplaceItem(actorContext(), 1, ReplaceItemInput{
+			Name: "デイトナ 116500LN ", Category: "時計", Brand: "Rolex", PurchasePrice: entity.Decimal{Units: 1500000}, PurchaseDate: "2023-01-15",
+		})
+
+		require.NoError(t, err)
+		historyRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 削除したアイテムの削除前の値を記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		historyRepo.On("RecordDelete", mock.Anything, int64(1), mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			return len(histories) > 0 && histories[0].Action == entity.ItemHistoryActionDelete && histories[0].NewValue == nil
+		})).Return(nil)
+
+		err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).DeleteItem(actorContext(), 1)
+
+		require.NoError(t, err)
+		historyRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 変更履歴を取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		histories := []*entity.ItemHistory{{ID: 1, ItemID: 1, Field: "name"}}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		historyRepo.On("FindByItemID", mock.Anything, int64(1)).Return(histories, nil)
+
+		result, err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).GetItemHistory(actorContext(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, histories, result)
+	})
+
+	t.Run("異常系: 参照できないアイテムの変更履歴", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		other := newItem()
+		other.UserID = testActor.ID + 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(other, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).GetItemHistory(actorContext(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		historyRepo.AssertNotCalled(t, "FindByItemID")
+	})
+}
+
+func TestItemUsecase_RecentlyViewed(t *testing.T) {
+	now := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	newItem := func() *entity.Item {
+		item, _ := newOwnedItem("デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+		item.ID = 1
+		return item
+	}
+
+	t.Run("正常系: 詳細の表示を記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		viewRepo := new(MockItemViewRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		viewRepo.On("Record", mock.Anything, testActor.ID, int64(1), now, maxRecentlyViewedItems).Return(nil)
+		usecase := NewItemUsecase(mockRepo, WithRecentlyViewed(viewRepo)).(*itemUsecase)
+		usecase.now = func() time.Time { return now }
+
+		_, err := usecase.GetItemByID(actorContext(), 1)
+
+		require.NoError(t, err)
+		viewRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 記録に失敗してもアイテムを返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		viewRepo := new(MockItemViewRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(), nil)
+		viewRepo.On("Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(domainErrors.ErrDatabaseError)
+
+		item, err := NewItemUsecase(mockRepo, WithRecentlyViewed(viewRepo)).GetItemByID(actorContext(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), item.ID)
+	})
+
+	t.Run("正常系: 最近表示したアイテムを参照できる範囲で取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		viewRepo := new(MockItemViewRepository)
+		views := []*entity.RecentlyViewedItem{{Item: newItem(), ViewedAt: now}}
+		viewRepo.On("FindRecentItems", mock.Anything, testActor.ID, testActor.ID, maxRecentlyViewedItems).Return(views, nil)
+
+		result, err := NewItemUsecase(mockRepo, WithRecentlyViewed(viewRepo)).GetRecentlyViewedItems(actorContext())
+
+		require.NoError(t, err)
+		assert.Equal(t, views, result)
+	})
+}
+
 func TestItemUsecase_DeleteItem(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -602,7 +1125,7 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			name: "正常系: 存在するアイテムを削除",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
 				mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
@@ -639,7 +1162,7 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			name: "異常系: Deleteでデータベースエラー",
 			id:   1,
 			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item.ID = 1
 				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
 				mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
@@ -654,7 +1177,7 @@ func TestItemUsecase_DeleteItem(t *testing.T) {
 			tt.setupMock(mockRepo)
 			usecase := NewItemUsecase(mockRepo)
 
-			ctx := context.Background()
+			ctx := actorContext()
 			err := usecase.DeleteItem(ctx, tt.id)
 
 			if tt.expectError {
@@ -683,11 +1206,11 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "正常系: 複数カテゴリーのアイテムがある場合",
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{
-					"時計":  2,
-					"バッグ": 1,
+				summary := []entity.CategoryValue{
+					{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000},
+					{Category: "バッグ", Currency: entity.CurrencyJPY, Count: 1, Value: 2000000},
 				}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(summary, nil)
 			},
 			expectedTotal:      3,
 			expectedWatchCount: 2,
@@ -697,8 +1220,8 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "正常系: アイテムが0件の場合",
 			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
+				summary := []entity.CategoryValue{}
+				mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(summary, nil)
 			},
 			expectedTotal:      0,
 			expectedWatchCount: 0,
@@ -708,7 +1231,7 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return((map[string]int)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(nil, domainErrors.ErrDatabaseError)
 			},
 			expectError: true,
 		},
@@ -720,8 +1243,8 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 			tt.setupMock(mockRepo)
 			usecase := NewItemUsecase(mockRepo)
 
-			ctx := context.Background()
-			summary, err := usecase.GetCategorySummary(ctx)
+			ctx := actorContext()
+			summary, err := usecase.GetCategorySummary(ctx, "")
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -748,11 +1271,955 @@ func TestItemUsecase_GetCategorySummary(t *testing.T) {
 	}
 }
 
+// 管理者が追加したアイテムのないカテゴリーも、グラフの軸がそろうよう0件で含める
+func TestItemUsecase_GetCategorySummary_AddedCategory(t *testing.T) {
+	restoreValidCategories(t)
+	entity.SetCategories(append(entity.Categories(), entity.Category{ID: 6, Name: "カメラ"}))
+	mockRepo := new(MockItemRepository)
+	mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return([]entity.CategoryValue{
+		{Category: "時計", Currency: entity.CurrencyJPY, Count: 1, Value: 1000000},
+	}, nil)
+
+	summary, err := NewItemUsecase(mockRepo).GetCategorySummary(actorContext(), "")
+	require.NoError(t, err)
+	assert.Len(t, summary.Categories, 6)
+	assert.Equal(t, 0, summary.Categories["カメラ"])
+	assert.Equal(t, int64(0), summary.Values["カメラ"])
+}
+
+func TestItemUsecase_GetCategorySummary_Values(t *testing.T) {
+	values := []entity.CategoryValue{
+		{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000, MinValue: 1000000, MaxValue: 2000000},
+		{Category: "時計", Currency: entity.CurrencyUSD, Count: 1, Value: 1234550, MinValue: 1234550, MaxValue: 1234550},
+		{Category: "バッグ", Currency: entity.CurrencyEUR, Count: 1, Value: 500000, MinValue: 500000, MaxValue: 500000},
+	}
+	usdActor := &entity.User{ID: testActor.ID, Email: testActor.Email, Role: entity.RoleEditor, PreferredCurrency: entity.CurrencyUSD}
+
+	t.Run("正常系: 為替レートを設定していない場合は円建てのみ合計する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetCategorySummary(WithActor(context.Background(), usdActor), "")
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyJPY, summary.Currency)
+		assert.Equal(t, 3, summary.Categories["時計"])
+		assert.Equal(t, int64(3000000), summary.Values["時計"])
+		assert.Equal(t, int64(0), summary.Values["バッグ"])
+		assert.Equal(t, 4, summary.Total)
+		assert.Equal(t, int64(3000000), summary.TotalValue)
+	})
+
+	t.Run("正常系: 表示通貨に換算して合計する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		converter := new(MockCurrencyConverter)
+		converter.On("Convert", mock.Anything, int64(3000000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(2000000), nil)
+		converter.On("Convert", mock.Anything, int64(1000000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(666667), nil)
+		converter.On("Convert", mock.Anything, int64(2000000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(1333333), nil)
+		converter.On("Convert", mock.Anything, int64(500000), entity.CurrencyEUR, entity.CurrencyUSD).Return(int64(540000), nil)
+
+		summary, err := NewItemUsecase(mockRepo, WithCurrencyConverter(converter)).GetCategorySummary(WithActor(context.Background(), usdActor), "")
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, summary.Currency)
+		assert.Equal(t, int64(3234550), summary.Values["時計"])
+		assert.Equal(t, int64(540000), summary.Values["バッグ"])
+		assert.Equal(t, int64(3774550), summary.TotalValue)
+		// 最小と最大は通貨ごとの最小と最大を換算して比較する
+		assert.Equal(t, int64(666667), *summary.Stats["時計"].MinValue)
+		assert.Equal(t, int64(1333333), *summary.Stats["時計"].MaxValue)
+		assert.Equal(t, int64(1078183), *summary.Stats["時計"].AverageValue)
+		converter.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 為替レートを取得できない場合", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+		converter := new(MockCurrencyConverter)
+		converter.On("Convert", mock.Anything, mock.Anything, mock.Anything, entity.CurrencyUSD).Return(int64(0), domainErrors.ErrExchangeRateUnavailable)
+
+		summary, err := NewItemUsecase(mockRepo, WithCurrencyConverter(converter)).GetCategorySummary(WithActor(context.Background(), usdActor), "")
+		assert.True(t, domainErrors.IsExchangeRateUnavailableError(err))
+		assert.Nil(t, summary)
+	})
+}
+
+func TestItemUsecase_GetCategorySummary_Stats(t *testing.T) {
+	t.Run("正常系: カテゴリーごとの平均・最小・最大と最も新しい購入日を返す", func(t *testing.T) {
+		values := []entity.CategoryValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000, MinValue: 1000000, MaxValue: 2000000, NewestPurchaseDate: "2023-06-01"},
+			{Category: "時計", Currency: entity.CurrencyJPY, OrgID: 10, Count: 1, Value: 500000, MinValue: 500000, MaxValue: 500000, NewestPurchaseDate: "2024-01-15"},
+			// 換算できない外貨建てのアイテムは件数と購入日のみ数える
+			{Category: "時計", Currency: entity.CurrencyUSD, Count: 1, Value: 1234550, MinValue: 1234550, MaxValue: 1234550, NewestPurchaseDate: "2024-03-01"},
+		}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return(values, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetCategorySummary(actorContext(), "")
+		require.NoError(t, err)
+
+		stats := summary.Stats["時計"]
+		assert.Equal(t, 4, stats.Count)
+		assert.Equal(t, 3, stats.ValuedCount)
+		assert.Equal(t, int64(3500000), stats.TotalValue)
+		assert.Equal(t, int64(1166666), *stats.AverageValue)
+		assert.Equal(t, int64(500000), *stats.MinValue)
+		assert.Equal(t, int64(2000000), *stats.MaxValue)
+		assert.Equal(t, "2024-03-01", *stats.NewestPurchaseDate)
+
+		// アイテムのないカテゴリーは金額と購入日が null
+		assert.Equal(t, &CategoryStats{}, summary.Stats["バッグ"])
+	})
+
+	t.Run("正常系: 購入価格と購入日が非表示の組織のアイテムは件数のみ数える", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleViewer: {entity.ItemFieldPurchasePrice, entity.ItemFieldPurchaseDate}})
+		values := []entity.CategoryValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, OrgID: redactionOrgID, Count: 2, Value: 3000000, MinValue: 1000000, MaxValue: 2000000, NewestPurchaseDate: "2024-01-15"},
+		}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, orgViewer.ID).Return(values, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetCategorySummary(WithActor(context.Background(), orgViewer), "")
+		require.NoError(t, err)
+		assert.Equal(t, &CategoryStats{Count: 2}, summary.Stats["時計"])
+	})
+}
+
+func TestItemUsecase_GetCategorySummary_AsOf(t *testing.T) {
+	newUsecase := func(repo *MockItemRepository) *itemUsecase {
+		u := NewItemUsecase(repo).(*itemUsecase)
+		u.now = func() time.Time { return time.Date(2024, 8, 1, 9, 0, 0, 0, time.UTC) }
+		return u
+	}
+
+	t.Run("正常系: 基準日に所有していたアイテムのみ集計する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategoryAsOf", mock.Anything, testActor.ID, "2023-12-31").Return([]entity.CategoryValue{
+			{Category: "時計", Currency: entity.CurrencyJPY, Count: 2, Value: 3000000, MinValue: 1000000, MaxValue: 2000000},
+		}, nil)
+
+		summary, err := newUsecase(mockRepo).GetCategorySummary(actorContext(), "2023-12-31")
+		require.NoError(t, err)
+		assert.Equal(t, "2023-12-31", summary.AsOf)
+		assert.Equal(t, 2, summary.Total)
+		assert.Equal(t, int64(3000000), summary.TotalValue)
+		// 過去の時点の集計は現在の集計を使わない
+		mockRepo.AssertNotCalled(t, "GetSummaryByCategory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 基準日にアイテムのないカテゴリーも0件で含める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategoryAsOf", mock.Anything, testActor.ID, "2023-12-31").Return([]entity.CategoryValue{}, nil)
+
+		summary, err := newUsecase(mockRepo).GetCategorySummary(actorContext(), "2023-12-31")
+		require.NoError(t, err)
+		for _, category := range entity.GetValidCategories() {
+			assert.Equal(t, 0, summary.Categories[category])
+			assert.Equal(t, int64(0), summary.Values[category])
+			assert.Contains(t, summary.Stats, category)
+		}
+	})
+
+	t.Run("正常系: 基準日の集計はクイック集計に使わない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategoryAsOf", mock.Anything, testActor.ID, "2023-12-31").Return([]entity.CategoryValue{}, nil)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, testActor.ID).Return([]entity.CategoryValue{}, nil)
+		u := newUsecase(mockRepo)
+
+		_, err := u.GetCategorySummary(actorContext(), "2023-12-31")
+		require.NoError(t, err)
+		_, err = u.GetQuickStats(actorContext())
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+	})
+
+	tests := []struct {
+		name string
+		asOf string
+	}{
+		{"日付の形式が不正", "2023/12/31"},
+		{"存在しない日付", "2023-02-30"},
+		{"未来の日付", "2024-08-02"},
+	}
+	for _, tt := range tests {
+		t.Run("異常系: "+tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+
+			_, err := newUsecase(mockRepo).GetCategorySummary(actorContext(), tt.asOf)
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			mockRepo.AssertNotCalled(t, "GetSummaryByCategoryAsOf", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 // Helper functions for test
 func stringPtr(s string) *string {
 	return &s
 }
 
-func intPtr(i int) *int {
+func decimalPtr(units int64) *entity.Decimal {
+	return &entity.Decimal{Units: units}
+}
+
+func int64Ptr(i int64) *int64 {
 	return &i
 }
+
+func TestItemUsecase_SearchItems(t *testing.T) {
+	tests := []struct {
+		name        string
+		query       string
+		setupMock   func(*MockItemRepository)
+		expectedErr error
+	}{
+		{
+			name:  "正常系: 前後の空白を除去して検索",
+			query: "  ロレックス ",
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := newOwnedItem("ロレックス デイトナ", "時計", "ROLEX", 1500000, "2023-01-15")
+				mockRepo.On("Search", mock.Anything, "ロレックス", testActor.ID).Return([]*entity.SearchResult{{
+					Item:  item,
+					Score: 1.5,
+					Highlights: []entity.SearchHighlight{
+						{Field: entity.SearchFieldName, Spans: []entity.TextSpan{{Start: 0, End: 5}}},
+					},
+				}}, nil)
+			},
+		},
+		{
+			name:        "異常系: キーワードが空",
+			query:       "   ",
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:        "異常系: キーワードが長すぎる",
+			query:       strings.Repeat("あ", 101),
+			setupMock:   func(mockRepo *MockItemRepository) {},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:  "異常系: データベースエラー",
+			query: "ROLEX",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("Search", mock.Anything, "ROLEX", testActor.ID).Return(nil, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			items, err := usecase.SearchItems(actorContext(), tt.query)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, items, 1)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_Ownership(t *testing.T) {
+	otherItem := func() *entity.Item {
+		item, _ := entity.NewItem("他人の時計", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+		item.ID = 1
+		item.UserID = testActor.ID + 1
+		return item
+	}
+
+	t.Run("異常系: 操作者が未設定", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+		ctx := context.Background()
+
+		_, err := usecase.GetAllItems(ctx, entity.ItemFilter{})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+		_, err = usecase.GetItemByID(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+		_, err = usecase.CreateItem(ctx, CreateItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01"})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+		_, err = usecase.UpdateItem(ctx, 1, UpdateItemInput{Name: stringPtr("更新")})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+		err = usecase.DeleteItem(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+		_, err = usecase.GetCategorySummary(ctx, "")
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+		_, err = usecase.SearchItems(ctx, "ROLEX")
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 作成したアイテムの所有者は操作者", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.UserID == testActor.ID
+		})).Return(&entity.Item{ID: 1, UserID: testActor.ID}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1500000}, PurchaseDate: "2023-01-15",
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテムは取得できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(otherItem(), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.GetItemByID(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Nil(t, item)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテムは更新できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(otherItem(), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Name: stringPtr("更新")})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテムは削除できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(otherItem(), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		err := usecase.DeleteItem(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_Organizations(t *testing.T) {
+	// 組織 10 の editor、組織 20 の viewer
+	member := &entity.User{ID: 5, Role: entity.RoleEditor, Memberships: []entity.Membership{
+		{OrganizationID: 10, UserID: 5, Role: entity.OrgRoleEditor},
+		{OrganizationID: 20, UserID: 5, Role: entity.OrgRoleViewer},
+	}}
+	memberContext := func() context.Context {
+		return WithActor(context.Background(), member)
+	}
+	orgItem := func(orgID int64) *entity.Item {
+		item, _ := entity.NewItem("家族の時計", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+		item.ID = 1
+		item.UserID = testActor.ID
+		item.OrgID = orgID
+		return item
+	}
+
+	t.Run("正常系: 所属する組織のアイテムは登録者以外も取得できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(orgItem(20), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		item, err := usecase.GetItemByID(memberContext(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), item.OrgID)
+	})
+
+	t.Run("異常系: 組織を抜けた登録者は取得できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(orgItem(30), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetItemByID(actorContext(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("正常系: editor は組織のアイテムを更新できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(orgItem(10), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.AnythingOfType("*entity.Item")).Return(orgItem(10), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(memberContext(), 1, UpdateItemInput{Name: stringPtr("更新")})
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: viewer は組織のアイテムを更新・削除できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(orgItem(20), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(memberContext(), 1, UpdateItemInput{Name: stringPtr("更新")})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		assert.ErrorIs(t, usecase.DeleteItem(memberContext(), 1), domainErrors.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 組織に登録したアイテムは組織の所有になる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.UserID == member.ID && item.OrgID == 10
+		})).Return(orgItem(10), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(memberContext(), CreateItemInput{
+			Name: "家族の時計", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", OrgID: 10,
+		})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: viewer の組織や所属していない組織には登録できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		for _, orgID := range []int64{20, 30} {
+			_, err := usecase.CreateItem(memberContext(), CreateItemInput{
+				Name: "家族の時計", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01", OrgID: orgID,
+			})
+			assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		}
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 個人のアイテムを組織に移し、個人のアイテムに戻す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		personal := orgItem(0)
+		personal.UserID = member.ID
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(personal, nil).Once()
+		mockRepo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.OrgID == 10
+		})).Return(orgItem(10), nil).Once()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(orgItem(10), nil).Once()
+		mockRepo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.OrgID == 0 && item.UserID == member.ID
+		})).Return(personal, nil).Once()
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(memberContext(), 1, UpdateItemInput{OrgID: int64Ptr(10)})
+		require.NoError(t, err)
+		_, err = usecase.UpdateItem(memberContext(), 1, UpdateItemInput{OrgID: int64Ptr(0)})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: viewer の組織には移せない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(orgItem(10), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(memberContext(), 1, UpdateItemInput{OrgID: int64Ptr(20)})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_Roles(t *testing.T) {
+	viewerContext := func() context.Context {
+		return WithActor(context.Background(), &entity.User{ID: 2, Role: entity.RoleViewer})
+	}
+	adminContext := func() context.Context {
+		return WithActor(context.Background(), &entity.User{ID: 3, Role: entity.RoleAdmin})
+	}
+
+	t.Run("異常系: 閲覧者はアイテムを変更できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+		ctx := viewerContext()
+
+		_, err := usecase.CreateItem(ctx, CreateItemInput{Name: "時計", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		_, err = usecase.UpdateItem(ctx, 1, UpdateItemInput{Name: stringPtr("更新")})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		err = usecase.DeleteItem(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 閲覧者は自分のアイテムを参照できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: 2}).Return([]*entity.Item{}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(viewerContext(), entity.ItemFilter{})
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 管理者はすべてのアイテムを参照できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{}).Return([]*entity.Item{}, nil)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, int64(0)).Return([]entity.CategoryValue{}, nil)
+		mockRepo.On("Search", mock.Anything, "ROLEX", int64(0)).Return([]*entity.SearchResult{}, nil)
+		usecase := NewItemUsecase(mockRepo)
+		ctx := adminContext()
+
+		_, err := usecase.GetAllItems(ctx, entity.ItemFilter{})
+		assert.NoError(t, err)
+		_, err = usecase.GetCategorySummary(ctx, "")
+		assert.NoError(t, err)
+		_, err = usecase.SearchItems(ctx, "ROLEX")
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 管理者は他のユーザーのアイテムを削除できる", func(t *testing.T) {
+		item, _ := entity.NewItem("他人の時計", "時計", "ROLEX", entity.JPY(1000000), "2023-01-01")
+		item.ID = 1
+		item.UserID = testActor.ID
+
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		err := usecase.DeleteItem(adminContext(), 1)
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_UpdateItemVersion(t *testing.T) {
+	newVersionedItem := func() *entity.Item {
+		item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item.ID = 1
+		item.Version = 3
+		return item
+	}
+
+	t.Run("正常系: 読み込んだバージョンで更新する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newVersionedItem(), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Version == 3 && item.Name == "時計2"
+		})).Return(&entity.Item{ID: 1, Name: "時計2", Version: 4}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		updated, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Name: stringPtr("時計2"), Version: 3})
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), updated.Version)
+	})
+
+	t.Run("異常系: If-Match のバージョンが古い", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newVersionedItem(), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Name: stringPtr("時計2"), Version: 2})
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionMismatch)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 読み込んでから更新するまでに他のリクエストが更新した", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newVersionedItem(), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.Anything).Return((*entity.Item)(nil), domainErrors.ErrItemVersionConflict)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Name: stringPtr("時計2"), Version: 3})
+		assert.True(t, domainErrors.IsVersionConflictError(err))
+	})
+}
+
+func TestItemUsecase_PurchaseCurrency(t *testing.T) {
+	t.Run("正常系: 通貨を省略した登録は円建て", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchasePrice == entity.JPY(1000000)
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01",
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("正常系: 小数の金額は通貨の最小単位で保存する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchasePrice == entity.NewMoney(12345, "USD")
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 12345, Scale: 2}, PurchaseCurrency: "USD", PurchaseDate: "2023-01-01",
+		})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 部分更新で通貨のみ変更すると金額の数値は保つ", func(t *testing.T) {
+		item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.PurchasePrice == entity.NewMoney(100000000, "USD")
+		})).Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{PurchaseCurrency: stringPtr("usd")})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 通貨の補助単位より細かい端数", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 15, Scale: 1}, PurchaseDate: "2023-01-01",
+		})
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.FieldError{Field: "purchase_price", Code: domainErrors.CodeInvalidFormat, Message: "purchase_price must have at most 0 decimal places for JPY"}, errs[0])
+
+		// 端数のある USD の金額は、金額を指定せずに JPY に変更できない
+		item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item.ID = 1
+		item.PurchasePrice = entity.NewMoney(12345, "USD")
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+
+		_, err = NewItemUsecase(mockRepo).UpdateItem(actorContext(), 1, UpdateItemInput{PurchaseCurrency: stringPtr("JPY")})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 対応していない通貨", func(t *testing.T) {
+		usecase := NewItemUsecase(new(MockItemRepository))
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseCurrency: "GBP", PurchaseDate: "2023-01-01",
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		errs, ok := domainErrors.AsValidationErrors(err)
+		require.True(t, ok)
+		assert.Equal(t, "purchase_currency", errs[0].Field)
+	})
+}
+
+func TestItemUsecase_ReplaceItem(t *testing.T) {
+	newSharedItem := func() *entity.Item {
+		item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item.ID = 1
+		item.Version = 3
+		item.Visibility = entity.VisibilityShared
+		item.Condition = entity.ConditionGood
+		return item
+	}
+	input := ReplaceItemInput{
+		Name:          "バッグ1",
+		Category:      "バッグ",
+		Brand:         "HERMES",
+		PurchasePrice: entity.Decimal{Units: 800000},
+		PurchaseDate:  "2023-03-01",
+		Version:       3,
+	}
+
+	t.Run("正常系: カテゴリーと購入日を含めて置き換え、省略した公開範囲は private に、状態は未設定に戻す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newSharedItem(), nil)
+		mockRepo.On("Update", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "バッグ1" && item.Category == "バッグ" && item.Brand == "HERMES" &&
+				item.PurchasePrice == entity.JPY(800000) && item.PurchaseDate == "2023-03-01" &&
+				item.Visibility == entity.VisibilityPrivate && item.Condition == entity.ConditionUnknown && item.Version == 3
+		})).Return(&entity.Item{ID: 1, Version: 4}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		replaced, err := usecase.ReplaceItem(actorContext(), 1, input)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), replaced.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 置き換える内容が不正", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newSharedItem(), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		invalid := input
+		invalid.Category = "家具"
+		_, err := usecase.ReplaceItem(actorContext(), 1, invalid)
+		assert.True(t, domainErrors.IsValidationError(err))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: If-Match のバージョンが古い", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newSharedItem(), nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		stale := input
+		stale.Version = 2
+		_, err := usecase.ReplaceItem(actorContext(), 1, stale)
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionMismatch)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_Visibility(t *testing.T) {
+	t.Run("正常系: 登録時に公開範囲を指定できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		var created *entity.Item
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Item) }).
+			Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01", Visibility: "shared",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.VisibilityShared, created.Visibility)
+	})
+
+	t.Run("正常系: 公開範囲だけを更新できる", func(t *testing.T) {
+		existingItem, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), existingItem).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		updated, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Visibility: stringPtr("public")})
+		require.NoError(t, err)
+		assert.Equal(t, entity.VisibilityPublic, updated.Visibility)
+	})
+
+	t.Run("異常系: 未定義の公開範囲", func(t *testing.T) {
+		existingItem, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Visibility: stringPtr("friends")})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_Condition(t *testing.T) {
+	t.Run("正常系: 登録時に状態を指定できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		var created *entity.Item
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Item) }).
+			Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01", Condition: "やや傷あり",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ConditionFair, created.Condition)
+	})
+
+	t.Run("異常系: 未定義の状態では登録しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01", Condition: "美品",
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: 状態だけを更新でき、空文字で未設定に戻せる", func(t *testing.T) {
+		existingItem, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), existingItem).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		updated, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Condition: stringPtr("新品")})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ConditionNew, updated.Condition)
+
+		updated, err = usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Condition: stringPtr("")})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ConditionUnknown, updated.Condition)
+	})
+
+	t.Run("異常系: 未定義の状態での絞り込み", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.GetAllItems(actorContext(), entity.ItemFilter{Condition: "美品"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindAll", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_ChangeItemStatus(t *testing.T) {
+	newItem := func(status entity.ItemStatus) *entity.Item {
+		item, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item.ID = 1
+		item.Status = status
+		return item
+	}
+
+	t.Run("正常系: 所有状況を変更し、変更履歴を記録する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		historyRepo := new(MockItemHistoryRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusOwned), nil)
+		historyRepo.On("RecordUpdate", mock.Anything, int64(1), mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Status == entity.ItemStatusListedForSale
+		}), mock.MatchedBy(func(histories []*entity.ItemHistory) bool {
+			return len(histories) == 1 && histories[0].Field == "status" &&
+				*histories[0].OldValue == "owned" && *histories[0].NewValue == "listed_for_sale"
+		})).Return(newItem(entity.ItemStatusListedForSale), nil)
+
+		item, err := NewItemUsecase(mockRepo, WithItemHistory(historyRepo)).ChangeItemStatus(actorContext(), 1, ChangeItemStatusInput{Status: "listed_for_sale"})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemStatusListedForSale, item.Status)
+		historyRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 現在と同じ所有状況は更新しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusSold), nil)
+
+		item, err := NewItemUsecase(mockRepo).ChangeItemStatus(actorContext(), 1, ChangeItemStatusInput{Status: "sold"})
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemStatusSold, item.Status)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 売却したアイテムは所有に戻せない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newItem(entity.ItemStatusSold), nil)
+
+		_, err := NewItemUsecase(mockRepo).ChangeItemStatus(actorContext(), 1, ChangeItemStatusInput{Status: "owned"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 閲覧者は変更できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		viewer := &entity.User{ID: 3, Email: "viewer@example.com", Role: entity.RoleViewer}
+
+		_, err := NewItemUsecase(mockRepo).ChangeItemStatus(WithActor(actorContext(), viewer), 1, ChangeItemStatusInput{Status: "sold"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+	})
+
+	t.Run("正常系: 所有状況で絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID, Status: entity.ItemStatusLost}).Return([]*entity.Item{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).GetAllItems(actorContext(), entity.ItemFilter{Status: entity.ItemStatusLost})
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_Notes(t *testing.T) {
+	t.Run("正常系: 登録時に前後の空白を除いたメモを保存する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		var created *entity.Item
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Item) }).
+			Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01", Notes: " 箱・保証書あり ",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "箱・保証書あり", created.Notes)
+	})
+
+	t.Run("異常系: 長すぎるメモでは登録しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01", Notes: strings.Repeat("あ", entity.NotesMaxLength+1),
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: メモだけを更新でき、空文字で未設定に戻せる", func(t *testing.T) {
+		existingItem, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), existingItem).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		updated, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Notes: stringPtr("ベルト交換済み")})
+		require.NoError(t, err)
+		assert.Equal(t, "ベルト交換済み", updated.Notes)
+
+		updated, err = usecase.UpdateItem(actorContext(), 1, UpdateItemInput{Notes: stringPtr("")})
+		require.NoError(t, err)
+		assert.Empty(t, updated.Notes)
+	})
+}
+
+func TestItemUsecase_SerialNumber(t *testing.T) {
+	t.Run("正常系: 登録時に正規化したシリアル番号を保存する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		var created *entity.Item
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*entity.Item) }).
+			Return(&entity.Item{ID: 1}, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01", SerialNumber: " z123456 ",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Z123456", created.SerialNumber)
+	})
+
+	t.Run("異常系: 同じシリアル番号のアイテムが登録済み", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(nil, domainErrors.ErrDuplicateSerialNumber)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.CreateItem(actorContext(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: entity.Decimal{Units: 1000000}, PurchaseDate: "2023-01-01", SerialNumber: "Z123456",
+		})
+		assert.True(t, domainErrors.IsDuplicateSerialNumberError(err))
+	})
+
+	t.Run("正常系: シリアル番号だけを更新でき、空文字で未設定に戻せる", func(t *testing.T) {
+		existingItem, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		mockRepo.On("Update", mock.Anything, int64(1), existingItem).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		updated, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{SerialNumber: stringPtr("a-1")})
+		require.NoError(t, err)
+		assert.Equal(t, "A-1", updated.SerialNumber)
+
+		updated, err = usecase.UpdateItem(actorContext(), 1, UpdateItemInput{SerialNumber: stringPtr("")})
+		require.NoError(t, err)
+		assert.Empty(t, updated.SerialNumber)
+	})
+
+	t.Run("異常系: 使えない文字を含むシリアル番号では更新しない", func(t *testing.T) {
+		existingItem, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existingItem.ID = 1
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existingItem, nil)
+		usecase := NewItemUsecase(mockRepo)
+
+		_, err := usecase.UpdateItem(actorContext(), 1, UpdateItemInput{SerialNumber: stringPtr("AB#1")})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"io"
+)
+
+// FileStorage はアップロードされたファイルの保存先。
+// ユースケースはキーだけを扱い、ファイルシステムなどの保存方法には依存しない
+type FileStorage interface {
+	// Put は r の内容を key に保存する（既存の内容は上書きする）
+	Put(ctx context.Context, key string, r io.Reader) error
+	// Get は key の内容を返す。呼び出し側で Close する
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete は key を削除する。存在しない場合もエラーにしない
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// TagUsecase はアイテムのタグを扱う。
+// タグはアイテムを変更できるユーザーが付け外しでき、一覧と件数は操作者が参照できるアイテムだけで数える
+type TagUsecase interface {
+	// List は操作者が参照できるアイテムに付いたタグを、付いているアイテムの多い順に返す
+	List(ctx context.Context) ([]entity.TagCount, error)
+	// AddToItem はアイテムにタグを付け、アイテムのタグを名前順に返す（既に付いている場合は何もしない）
+	AddToItem(ctx context.Context, itemID int64, input TagInput) ([]string, error)
+	// RemoveFromItem はアイテムからタグを外す
+	RemoveFromItem(ctx context.Context, itemID int64, name string) error
+}
+
+type TagInput struct {
+	Name string `json:"name"`
+}
+
+type tagUsecase struct {
+	tagRepo  TagRepository
+	itemRepo ItemRepository
+}
+
+func NewTagUsecase(tagRepo TagRepository, itemRepo ItemRepository) TagUsecase {
+	return &tagUsecase{
+		tagRepo:  tagRepo,
+		itemRepo: itemRepo,
+	}
+}
+
+func (u *tagUsecase) List(ctx context.Context) ([]entity.TagCount, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := u.tagRepo.CountByUser(ctx, itemScope(actor))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (u *tagUsecase) AddToItem(ctx context.Context, itemID int64, input TagInput) ([]string, error) {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := entity.NormalizeTag(input.Name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := u.findItem(ctx, actor, itemID); err != nil {
+		return nil, err
+	}
+
+	tags, err := u.itemTags(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if slices.Contains(tags, name) {
+		return tags, nil
+	}
+	if len(tags) >= entity.MaxTagsPerItem {
+		var errs domainErrors.ValidationErrors
+		errs.Add("name", domainErrors.CodeOutOfRange, fmt.Sprintf("an item can have at most %d tags", entity.MaxTagsPerItem))
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, errs)
+	}
+
+	if err := u.tagRepo.AddToItem(ctx, itemID, name); err != nil {
+		return nil, fmt.Errorf("failed to add tag: %w", err)
+	}
+
+	return u.itemTags(ctx, itemID)
+}
+
+func (u *tagUsecase) RemoveFromItem(ctx context.Context, itemID int64, name string) error {
+	actor, err := requireWriter(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 付けたときと同じ正規化をして、大文字・小文字や空白の違いを無視する
+	name, err = entity.NormalizeTag(name)
+	if err != nil {
+		return fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, err)
+	}
+	if err := u.findItem(ctx, actor, itemID); err != nil {
+		return err
+	}
+
+	if err := u.tagRepo.RemoveFromItem(ctx, itemID, name); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrTagNotFound
+		}
+		return fmt.Errorf("failed to remove tag: %w", err)
+	}
+
+	return nil
+}
+
+// findItem は操作者がアイテムを変更できることを確かめる（参照できないアイテムは ErrItemNotFound）
+func (u *tagUsecase) findItem(ctx context.Context, actor *entity.User, itemID int64) error {
+	if itemID <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if _, err := findWritableItem(ctx, u.itemRepo, actor, itemID); err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return domainErrors.ErrItemNotFound
+		}
+		if domainErrors.IsForbiddenError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	return nil
+}
+
+// itemTags はアイテムのタグを名前順に返す（タグがない場合は空）
+func (u *tagUsecase) itemTags(ctx context.Context, itemID int64) ([]string, error) {
+	tags, err := u.tagRepo.FindByItemIDs(ctx, []int64{itemID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tags: %w", err)
+	}
+	if tags[itemID] == nil {
+		return []string{}, nil
+	}
+	return tags[itemID], nil
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type MockTagRepository struct {
+	mock.Mock
+}
+
+func (m *MockTagRepository) AddToItem(ctx context.Context, itemID int64, name string) error {
+	args := m.Called(ctx, itemID, name)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) RemoveFromItem(ctx context.Context, itemID int64, name string) error {
+	args := m.Called(ctx, itemID, name)
+	return args.Error(0)
+}
+
+func (m *MockTagRepository) FindByItemIDs(ctx context.Context, itemIDs []int64) (map[int64][]string, error) {
+	args := m.Called(ctx, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64][]string), args.Error(1)
+}
+
+func (m *MockTagRepository) CountByUser(ctx context.Context, userID int64) ([]entity.TagCount, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.TagCount), args.Error(1)
+}
+
+func newTagTestUsecase(item *entity.Item) (TagUsecase, *MockTagRepository) {
+	tags := new(MockTagRepository)
+	items := new(MockItemRepository)
+	items.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+	return NewTagUsecase(tags, items), tags
+}
+
+func TestTagUsecase_AddToItem(t *testing.T) {
+	t.Run("正常系: 正規化したタグを付けて、アイテムのタグを返す", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+		tags.On("FindByItemIDs", mock.Anything, []int64{1}).Return(map[int64][]string{}, nil).Once()
+		tags.On("AddToItem", mock.Anything, int64(1), "vintage").Return(nil)
+		tags.On("FindByItemIDs", mock.Anything, []int64{1}).Return(map[int64][]string{1: {"vintage"}}, nil).Once()
+
+		result, err := usecase.AddToItem(actorContext(), 1, TagInput{Name: " Vintage "})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vintage"}, result)
+		tags.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 付いているタグは付け直さない", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+		tags.On("FindByItemIDs", mock.Anything, []int64{1}).Return(map[int64][]string{1: {"vintage"}}, nil)
+
+		result, err := usecase.AddToItem(actorContext(), 1, TagInput{Name: "VINTAGE"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vintage"}, result)
+		tags.AssertNotCalled(t, "AddToItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: タグの数の上限", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+		existing := make([]string, entity.MaxTagsPerItem)
+		for i := range existing {
+			existing[i] = fmt.Sprintf("tag%d", i)
+		}
+		tags.On("FindByItemIDs", mock.Anything, []int64{1}).Return(map[int64][]string{1: existing}, nil)
+
+		_, err := usecase.AddToItem(actorContext(), 1, TagInput{Name: "new"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		tags.AssertNotCalled(t, "AddToItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 空のタグ名", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+
+		_, err := usecase.AddToItem(actorContext(), 1, TagInput{Name: "  "})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		tags.AssertNotCalled(t, "AddToItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 組織の閲覧者はタグを付けられない", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newOrgItem())
+
+		_, err := usecase.AddToItem(WithActor(context.Background(), orgViewer), 1, TagInput{Name: "vintage"})
+		assert.ErrorIs(t, err, domainErrors.ErrForbidden)
+		tags.AssertNotCalled(t, "AddToItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 参照できないアイテム", func(t *testing.T) {
+		usecase, _ := newTagTestUsecase(newImageTestItem())
+		stranger := &entity.User{ID: 3, Email: "other@example.com", Role: entity.RoleEditor}
+
+		_, err := usecase.AddToItem(WithActor(context.Background(), stranger), 1, TagInput{Name: "vintage"})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestTagUsecase_RemoveFromItem(t *testing.T) {
+	t.Run("正常系: 付けたときと同じ正規化をして外す", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+		tags.On("RemoveFromItem", mock.Anything, int64(1), "vintage").Return(nil)
+
+		require.NoError(t, usecase.RemoveFromItem(actorContext(), 1, "Vintage"))
+		tags.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 付いていないタグ", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+		tags.On("RemoveFromItem", mock.Anything, int64(1), "vintage").Return(domainErrors.ErrTagNotFound)
+
+		assert.ErrorIs(t, usecase.RemoveFromItem(actorContext(), 1, "vintage"), domainErrors.ErrTagNotFound)
+	})
+}
+
+func TestTagUsecase_List(t *testing.T) {
+	t.Run("正常系: 操作者が参照できるアイテムで数える", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+		tags.On("CountByUser", mock.Anything, testActor.ID).Return([]entity.TagCount{{Name: "vintage", Count: 2}}, nil)
+
+		counts, err := usecase.List(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, []entity.TagCount{{Name: "vintage", Count: 2}}, counts)
+	})
+
+	t.Run("正常系: 管理者はすべてのアイテムで数える", func(t *testing.T) {
+		usecase, tags := newTagTestUsecase(newImageTestItem())
+		admin := &entity.User{ID: 2, Role: entity.RoleAdmin}
+		tags.On("CountByUser", mock.Anything, int64(0)).Return([]entity.TagCount{}, nil)
+
+		_, err := usecase.List(WithActor(context.Background(), admin))
+		require.NoError(t, err)
+		tags.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_Tags(t *testing.T) {
+	t.Run("正常系: 一覧のアイテムにタグを含める", func(t *testing.T) {
+		first, _ := newOwnedItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		first.ID = 1
+		second, _ := newOwnedItem("時計2", "時計", "OMEGA", 500000, "2023-01-01")
+		second.ID = 2
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID, Tags: []string{"vintage"}}).Return([]*entity.Item{first, second}, nil)
+		tags := new(MockTagRepository)
+		tags.On("FindByItemIDs", mock.Anything, []int64{1, 2}).Return(map[int64][]string{1: {"vintage", "箱あり"}}, nil)
+		usecase := NewItemUsecase(mockRepo, WithTags(tags))
+
+		items, err := usecase.GetAllItems(actorContext(), entity.ItemFilter{Tags: []string{"vintage"}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"vintage", "箱あり"}, items[0].Tags)
+		assert.Empty(t, items[1].Tags)
+	})
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 同時に生成するサムネイルの画像数（デコードのメモリ使用量を抑える）
+const maxConcurrentThumbnails = 2
+
+// ThumbnailGenerator は画像を指定した幅に縮小したJPEGを作成する
+type ThumbnailGenerator interface {
+	Generate(src io.Reader, width int) ([]byte, error)
+}
+
+// ItemImageUsecaseOption は ItemImageUsecase の設定を変更する
+type ItemImageUsecaseOption func(*itemImageUsecase)
+
+// WithThumbnails はアップロード後にサムネイルを非同期で生成する
+func WithThumbnails(generator ThumbnailGenerator) ItemImageUsecaseOption {
+	return func(u *itemImageUsecase) {
+		u.thumbnailer = generator
+	}
+}
+
+// generateThumbnails は元の画像から ThumbnailWidths の各幅のサムネイルを作成して保存する
+func (u *itemImageUsecase) generateThumbnails(ctx context.Context, image *entity.ItemImage) error {
+	src, err := u.storage.Get(ctx, image.StorageKey)
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+	data, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read image: %w", err)
+	}
+
+	for _, width := range entity.ThumbnailWidths {
+		thumbnail, err := u.thumbnailer.Generate(bytes.NewReader(data), width)
+		if err != nil {
+			return fmt.Errorf("failed to generate %dpx thumbnail: %w", width, err)
+		}
+		if err := u.storage.Put(ctx, image.ThumbnailKey(width), bytes.NewReader(thumbnail)); err != nil {
+			return fmt.Errorf("failed to store %dpx thumbnail: %w", width, err)
+		}
+	}
+
+	if err := u.imageRepo.MarkThumbnailsReady(ctx, image.ItemID, image.ID); err != nil {
+		// 生成中に画像が削除された場合はサムネイルも残さない
+		for _, width := range entity.ThumbnailWidths {
+			_ = u.storage.Delete(ctx, image.ThumbnailKey(width))
+		}
+		return fmt.Errorf("failed to mark thumbnails ready: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// 高額なアイテムの件数の既定値と最大値
+const (
+	defaultTopItems = 10
+	maxTopItems     = 100
+)
+
+// TopItems は金額の高いアイテムの一覧
+type TopItems struct {
+	// By は比べた金額の種類（purchase_price / current_value）
+	By entity.ItemValueKey `json:"by"`
+	// Currency は Value の通貨（操作者の表示通貨）
+	Currency entity.Currency `json:"currency"`
+	// Items は金額の高い順のアイテム
+	Items []TopItem `json:"items"`
+}
+
+// TopItem はアイテムと表示通貨に換算した金額（Currency の最小単位）
+type TopItem struct {
+	Value int64        `json:"value"`
+	Item  *entity.Item `json:"item"`
+}
+
+// GetTopItems は購入価格または評価額の高いアイテムを n 件返す（n の既定は10、by の既定は purchase_price）。
+// リポジトリで通貨ごとに高い順の n 件を選び、表示通貨に換算して上位を決める。
+// 換算できない外貨建てのアイテムと購入価格が非表示のアイテムは含めない
+func (u *itemUsecase) GetTopItems(ctx context.Context, n int, by string) (*TopItems, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if n == 0 {
+		n = defaultTopItems
+	}
+	if n < 0 || n > maxTopItems {
+		return nil, fmt.Errorf("%w: n must be between 1 and %d", domainErrors.ErrInvalidInput, maxTopItems)
+	}
+	key := entity.ItemValueKey(by)
+	if key == "" {
+		key = entity.ItemValuePurchasePrice
+	}
+	if !key.IsValid() {
+		return nil, fmt.Errorf("%w: by must be one of: purchase_price, current_value", domainErrors.ErrInvalidInput)
+	}
+
+	items, err := u.itemRepo.FindTopByValue(ctx, itemScope(actor), key, n)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve top items: %w", err)
+	}
+
+	valuation := newValuation(actor, u.converter)
+	top := &TopItems{By: key, Currency: valuation.currency, Items: []TopItem{}}
+	for _, item := range items {
+		if !canReadItem(actor, item) {
+			continue
+		}
+		// 評価額も購入価格から推測できるため、購入価格を非表示にする組織のアイテムはどちらでも含めない
+		if slices.Contains(entity.ItemRedactionFor(actor, item.OrgID), entity.ItemFieldPurchasePrice) {
+			continue
+		}
+		amount := item.PurchasePrice
+		if key == entity.ItemValueCurrent {
+			if item.CurrentValue == nil {
+				continue
+			}
+			amount = *item.CurrentValue
+		}
+		value, ok, err := valuation.convert(ctx, int64(amount.Amount), amount.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		top.Items = append(top.Items, TopItem{Value: value, Item: item})
+	}
+	slices.SortStableFunc(top.Items, func(a, b TopItem) int {
+		return cmp.Or(cmp.Compare(b.Value, a.Value), cmp.Compare(b.Item.ID, a.Item.ID))
+	})
+	if len(top.Items) > n {
+		top.Items = top.Items[:n]
+	}
+
+	selected := make([]*entity.Item, len(top.Items))
+	for i, t := range top.Items {
+		selected[i] = t.Item
+	}
+	if err := u.attachThumbnails(ctx, selected); err != nil {
+		return nil, err
+	}
+	if err := u.attachTags(ctx, selected); err != nil {
+		return nil, err
+	}
+	redactItems(actor, selected...)
+	localizeItems(ctx, selected...)
+
+	return top, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetTopItems(t *testing.T) {
+	t.Run("正常系: 既定では購入価格の高い順に10件を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindTopByValue", mock.Anything, testActor.ID, entity.ItemValuePurchasePrice, defaultTopItems).Return([]*entity.Item{
+			{ID: 1, UserID: testActor.ID, Name: "デイトナ", PurchasePrice: entity.JPY(3000000)},
+			{ID: 2, UserID: testActor.ID, Name: "バーキン", PurchasePrice: entity.JPY(2000000)},
+		}, nil)
+
+		top, err := NewItemUsecase(mockRepo).GetTopItems(actorContext(), 0, "")
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemValuePurchasePrice, top.By)
+		assert.Equal(t, entity.CurrencyJPY, top.Currency)
+		require.Len(t, top.Items, 2)
+		assert.Equal(t, int64(3000000), top.Items[0].Value)
+		assert.Equal(t, int64(1), top.Items[0].Item.ID)
+		assert.Equal(t, int64(2), top.Items[1].Item.ID)
+	})
+
+	t.Run("正常系: 通貨ごとの上位を表示通貨に換算して上位 n 件を選ぶ", func(t *testing.T) {
+		usdActor := &entity.User{ID: testActor.ID, Email: testActor.Email, Role: entity.RoleEditor, PreferredCurrency: entity.CurrencyUSD}
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindTopByValue", mock.Anything, testActor.ID, entity.ItemValuePurchasePrice, 2).Return([]*entity.Item{
+			{ID: 1, UserID: testActor.ID, PurchasePrice: entity.JPY(3000000)},
+			{ID: 2, UserID: testActor.ID, PurchasePrice: entity.Money{Amount: 1500000, Currency: entity.CurrencyUSD}},
+			{ID: 3, UserID: testActor.ID, PurchasePrice: entity.JPY(1000000)},
+		}, nil)
+		converter := new(MockCurrencyConverter)
+		converter.On("Convert", mock.Anything, int64(3000000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(2000000), nil)
+		converter.On("Convert", mock.Anything, int64(1000000), entity.CurrencyJPY, entity.CurrencyUSD).Return(int64(700000), nil)
+
+		top, err := NewItemUsecase(mockRepo, WithCurrencyConverter(converter)).GetTopItems(WithActor(context.Background(), usdActor), 2, "purchase_price")
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, top.Currency)
+		require.Len(t, top.Items, 2)
+		assert.Equal(t, int64(1), top.Items[0].Item.ID)
+		assert.Equal(t, int64(2000000), top.Items[0].Value)
+		assert.Equal(t, int64(2), top.Items[1].Item.ID)
+		assert.Equal(t, int64(1500000), top.Items[1].Value)
+	})
+
+	t.Run("正常系: 評価額の高い順に返す", func(t *testing.T) {
+		value := entity.JPY(4000000)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindTopByValue", mock.Anything, testActor.ID, entity.ItemValueCurrent, 5).Return([]*entity.Item{
+			{ID: 1, UserID: testActor.ID, PurchasePrice: entity.JPY(3000000), CurrentValue: &value},
+		}, nil)
+
+		top, err := NewItemUsecase(mockRepo).GetTopItems(actorContext(), 5, "current_value")
+		require.NoError(t, err)
+		assert.Equal(t, entity.ItemValueCurrent, top.By)
+		require.Len(t, top.Items, 1)
+		assert.Equal(t, int64(4000000), top.Items[0].Value)
+	})
+
+	t.Run("正常系: 購入価格が非表示の組織のアイテムは含めない", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleViewer: {entity.ItemFieldPurchasePrice}})
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindTopByValue", mock.Anything, orgViewer.ID, entity.ItemValuePurchasePrice, defaultTopItems).Return([]*entity.Item{
+			{ID: 1, OrgID: redactionOrgID, PurchasePrice: entity.JPY(3000000)},
+		}, nil)
+
+		top, err := NewItemUsecase(mockRepo).GetTopItems(WithActor(context.Background(), orgViewer), 0, "")
+		require.NoError(t, err)
+		assert.Empty(t, top.Items)
+	})
+
+	t.Run("異常系: 不正な n と by", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).GetTopItems(actorContext(), maxTopItems+1, "")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		_, err = NewItemUsecase(mockRepo).GetTopItems(actorContext(), 0, "name")
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindTopByValue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
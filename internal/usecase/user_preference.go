@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+type UserPreferenceUsecase interface {
+	// GetPreferences は操作者の表示設定を返す
+	GetPreferences(ctx context.Context) (*UserPreferences, error)
+	// UpdatePreferences は操作者の集計やエクスポートの金額を表示する通貨を変更する
+	UpdatePreferences(ctx context.Context, input UserPreferences) (*UserPreferences, error)
+}
+
+// UserPreferences はユーザーの表示設定
+type UserPreferences struct {
+	PreferredCurrency entity.Currency `json:"preferred_currency"`
+}
+
+type userPreferenceUsecase struct {
+	userRepo UserRepository
+}
+
+func NewUserPreferenceUsecase(userRepo UserRepository) UserPreferenceUsecase {
+	return &userPreferenceUsecase{
+		userRepo: userRepo,
+	}
+}
+
+func (u *userPreferenceUsecase) GetPreferences(ctx context.Context) (*UserPreferences, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserPreferences{PreferredCurrency: actor.Currency()}, nil
+}
+
+func (u *userPreferenceUsecase) UpdatePreferences(ctx context.Context, input UserPreferences) (*UserPreferences, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs domainErrors.ValidationErrors
+	currency := entity.NewMoney(0, string(input.PreferredCurrency)).Currency
+	switch {
+	case strings.TrimSpace(string(input.PreferredCurrency)) == "":
+		errs.Add("preferred_currency", domainErrors.CodeRequired, "preferred_currency is required")
+	case !currency.IsValid():
+		errs.Add("preferred_currency", domainErrors.CodeInvalidChoice, "preferred_currency must be one of: JPY, USD, EUR")
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %w", domainErrors.ErrInvalidInput, errs)
+	}
+
+	user, err := u.userRepo.UpdatePreferredCurrency(ctx, actor.ID, currency)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update preferences: %w", err)
+	}
+
+	return &UserPreferences{PreferredCurrency: user.Currency()}, nil
+}
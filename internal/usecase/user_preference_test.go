@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestUserPreferenceUsecase(t *testing.T) {
+	t.Run("正常系: 表示通貨が未設定の場合は JPY を返す", func(t *testing.T) {
+		u := NewUserPreferenceUsecase(new(MockUserRepository))
+
+		preferences, err := u.GetPreferences(actorContext())
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyJPY, preferences.PreferredCurrency)
+	})
+
+	t.Run("正常系: 表示通貨を変更する", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		userRepo.On("UpdatePreferredCurrency", mock.Anything, testActor.ID, entity.CurrencyUSD).
+			Return(&entity.User{ID: testActor.ID, PreferredCurrency: entity.CurrencyUSD}, nil)
+		u := NewUserPreferenceUsecase(userRepo)
+
+		preferences, err := u.UpdatePreferences(actorContext(), UserPreferences{PreferredCurrency: " usd "})
+		require.NoError(t, err)
+		assert.Equal(t, entity.CurrencyUSD, preferences.PreferredCurrency)
+		userRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 未指定や対応していない通貨", func(t *testing.T) {
+		userRepo := new(MockUserRepository)
+		u := NewUserPreferenceUsecase(userRepo)
+
+		for _, currency := range []entity.Currency{"GBP", ""} {
+			_, err := u.UpdatePreferences(actorContext(), UserPreferences{PreferredCurrency: currency})
+			assert.True(t, domainErrors.IsValidationError(err))
+			errs, ok := domainErrors.AsValidationErrors(err)
+			require.True(t, ok)
+			assert.Equal(t, "preferred_currency", errs[0].Field)
+		}
+		userRepo.AssertNotCalled(t, "UpdatePreferredCurrency")
+	})
+
+	t.Run("異常系: 未認証", func(t *testing.T) {
+		_, err := NewUserPreferenceUsecase(new(MockUserRepository)).GetPreferences(context.Background())
+		assert.True(t, domainErrors.IsUnauthorizedError(err))
+	})
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// ValueChangeReport は手放していないアイテムの購入価格と最新の評価額の比較
+type ValueChangeReport struct {
+	// Currency は金額の通貨（操作者の表示通貨）
+	Currency entity.Currency `json:"currency"`
+	// Total はすべての比較したアイテムの合計
+	Total ValueChangeGroup `json:"total"`
+	// ByCategory と ByBrand はカテゴリー・ブランドごとの合計（変化率の高い順）
+	ByCategory []ValueChangeGroup `json:"by_category"`
+	ByBrand    []ValueChangeGroup `json:"by_brand"`
+	// Items はアイテムごとの比較（変化率の高い順）
+	Items []ValueChangeItem `json:"items"`
+	// Unvalued は評価額を記録していないため比較していないアイテムの数
+	Unvalued int `json:"unvalued"`
+	// Unconverted は表示通貨に換算できない金額のため比較していないアイテムの数
+	Unconverted int `json:"unconverted"`
+}
+
+// ValueChangeItem はアイテムの購入価格と最新の評価額の比較
+type ValueChangeItem struct {
+	ItemID        int64  `json:"item_id"`
+	Name          string `json:"name"`
+	Category      string `json:"category"`
+	Brand         string `json:"brand"`
+	PurchaseDate  string `json:"purchase_date"`
+	PurchasePrice int64  `json:"purchase_price"`
+	CurrentValue  int64  `json:"current_value"`
+	// Change は評価額と購入価格の差（値下がりは負の値）
+	Change int64 `json:"change"`
+	// ChangePercent は購入価格に対する Change の割合（%、小数第1位まで。購入価格が0の場合は null）
+	ChangePercent *float64 `json:"change_percent"`
+	// HoldingDays は購入日から今日までの日数
+	HoldingDays int `json:"holding_days"`
+}
+
+// ValueChangeGroup はカテゴリー・ブランドごとの購入価格と評価額の合計
+type ValueChangeGroup struct {
+	// Key はカテゴリー名またはブランド名（Total では空）
+	Key           string   `json:"key,omitempty"`
+	Count         int      `json:"count"`
+	PurchasePrice int64    `json:"purchase_price"`
+	CurrentValue  int64    `json:"current_value"`
+	Change        int64    `json:"change"`
+	ChangePercent *float64 `json:"change_percent"`
+	// AverageHoldingDays はアイテムの保有日数の平均（日未満は切り捨て）
+	AverageHoldingDays int `json:"average_holding_days"`
+
+	holdingDays int
+}
+
+func (g *ValueChangeGroup) add(item ValueChangeItem) {
+	g.Count++
+	g.PurchasePrice = entity.AddAmount(g.PurchasePrice, item.PurchasePrice)
+	g.CurrentValue = entity.AddAmount(g.CurrentValue, item.CurrentValue)
+	g.holdingDays += item.HoldingDays
+}
+
+func (g *ValueChangeGroup) finish() {
+	g.Change = g.CurrentValue - g.PurchasePrice
+	g.ChangePercent = changePercent(g.Change, g.PurchasePrice)
+	if g.Count > 0 {
+		g.AverageHoldingDays = g.holdingDays / g.Count
+	}
+}
+
+// GetValueChangeReport は手放していないアイテムの購入価格と最新の評価額（current_value）を比較し、
+// アイテムごととカテゴリー・ブランドごとの値上がり・値下がりを返す。
+// 購入価格が非表示のアイテムは比較せず、件数にも含めない
+func (u *itemUsecase) GetValueChangeReport(ctx context.Context) (*ValueChangeReport, error) {
+	actor, err := requireActor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return runCanary(ctx, u.canaries.get(CanaryValueChangeReport),
+		// 従来の実装: すべてのアイテムを読み込み、手放したアイテムを除いて集計する
+		func(ctx context.Context) (*ValueChangeReport, error) {
+			items, err := u.itemRepo.FindAll(ctx, entity.ItemFilter{UserID: itemScope(actor)})
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve items: %w", err)
+			}
+			return u.buildValueChangeReport(ctx, actor, items)
+		},
+		// 新しい実装: 手放していないアイテムを集計に使う列だけ SQL で絞り込んで読み込む
+		func(ctx context.Context) (*ValueChangeReport, error) {
+			items, err := u.itemRepo.FindHeld(ctx, itemScope(actor))
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve held items: %w", err)
+			}
+			return u.buildValueChangeReport(ctx, actor, items)
+		})
+}
+
+// buildValueChangeReport はアイテムのうち手放していないものを集計する
+func (u *itemUsecase) buildValueChangeReport(ctx context.Context, actor *entity.User, items []*entity.Item) (*ValueChangeReport, error) {
+	valuation := newValuation(actor, u.converter)
+	report := &ValueChangeReport{
+		Currency:   valuation.currency,
+		ByCategory: []ValueChangeGroup{},
+		ByBrand:    []ValueChangeGroup{},
+		Items:      []ValueChangeItem{},
+	}
+	today := u.now().UTC().Truncate(24 * time.Hour)
+	categories := make(map[string]*ValueChangeGroup)
+	brands := make(map[string]*ValueChangeGroup)
+	for _, item := range items {
+		if !item.Status.IsHeld() || !canReadItem(actor, item) {
+			continue
+		}
+		if slices.Contains(entity.ItemRedactionFor(actor, item.OrgID), entity.ItemFieldPurchasePrice) {
+			continue
+		}
+		if item.CurrentValue == nil {
+			report.Unvalued++
+			continue
+		}
+
+		purchasePrice, ok, err := valuation.convert(ctx, int64(item.PurchasePrice.Amount), item.PurchasePrice.Currency)
+		if err != nil {
+			return nil, err
+		}
+		currentValue, valueOK, err := valuation.convert(ctx, int64(item.CurrentValue.Amount), item.CurrentValue.Currency)
+		if err != nil {
+			return nil, err
+		}
+		if !ok || !valueOK {
+			report.Unconverted++
+			continue
+		}
+
+		row := ValueChangeItem{
+			ItemID:        item.ID,
+			Name:          item.Name,
+			Category:      item.Category,
+			Brand:         item.Brand,
+			PurchaseDate:  item.PurchaseDate,
+			PurchasePrice: purchasePrice,
+			CurrentValue:  currentValue,
+			Change:        currentValue - purchasePrice,
+			ChangePercent: changePercent(currentValue-purchasePrice, purchasePrice),
+			HoldingDays:   holdingDays(item.PurchaseDate, today),
+		}
+		report.Items = append(report.Items, row)
+		report.Total.add(row)
+		addToValueChangeGroup(categories, item.Category, row)
+		// 表記の異なる同じブランドはまとめる
+		addToValueChangeGroup(brands, canonicalBrand(item.Brand), row)
+	}
+
+	report.Total.finish()
+	report.ByCategory = sortedValueChangeGroups(categories)
+	report.ByBrand = sortedValueChangeGroups(brands)
+	slices.SortStableFunc(report.Items, func(a, b ValueChangeItem) int {
+		return compareChangePercent(a.ChangePercent, b.ChangePercent)
+	})
+	return report, nil
+}
+
+func addToValueChangeGroup(groups map[string]*ValueChangeGroup, key string, item ValueChangeItem) {
+	g, ok := groups[strings.ToLower(key)]
+	if !ok {
+		g = &ValueChangeGroup{Key: key}
+		groups[strings.ToLower(key)] = g
+	}
+	g.add(item)
+}
+
+// sortedValueChangeGroups は合計を確定したグループを変化率の高い順（同じ場合は名前順）に返す
+func sortedValueChangeGroups(groups map[string]*ValueChangeGroup) []ValueChangeGroup {
+	sorted := make([]ValueChangeGroup, 0, len(groups))
+	for _, g := range groups {
+		g.finish()
+		sorted = append(sorted, *g)
+	}
+	slices.SortFunc(sorted, func(a, b ValueChangeGroup) int {
+		return cmp.Or(compareChangePercent(a.ChangePercent, b.ChangePercent), cmp.Compare(a.Key, b.Key))
+	})
+	return sorted
+}
+
+// compareChangePercent は変化率の高い順に並べる比較（変化率のないものは最後）
+func compareChangePercent(a, b *float64) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+	return cmp.Compare(*b, *a)
+}
+
+// changePercent は base に対する change の割合（%）を小数第1位に丸めて返す（base が0の場合は nil）
+func changePercent(change, base int64) *float64 {
+	if base == 0 {
+		return nil
+	}
+	percent := math.Round(float64(change)/float64(base)*1000) / 10
+	return &percent
+}
+
+// holdingDays は購入日（YYYY-MM-DD 形式）から today までの日数を返す
+func holdingDays(purchaseDate string, today time.Time) int {
+	purchased, err := time.Parse("2006-01-02", purchaseDate)
+	if err != nil || purchased.After(today) {
+		return 0
+	}
+	return int(today.Sub(purchased).Hours() / 24)
+}
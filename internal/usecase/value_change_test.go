@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemUsecase_GetValueChangeReport(t *testing.T) {
+	now := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	newItem := func(id int64, category, brand string, price int, purchaseDate string, value *entity.Money) *entity.Item {
+		item, _ := newOwnedItem("アイテム", category, brand, price, purchaseDate)
+		item.ID = id
+		item.CurrentValue = value
+		return item
+	}
+	jpy := func(amount int) *entity.Money {
+		value := entity.JPY(amount)
+		return &value
+	}
+	percent := func(p float64) *float64 { return &p }
+	newUsecase := func(mockRepo *MockItemRepository) ItemUsecase {
+		u := NewItemUsecase(mockRepo).(*itemUsecase)
+		u.now = func() time.Time { return now }
+		return u
+	}
+
+	t.Run("正常系: アイテムごとと、カテゴリー・ブランドごとの変化を変化率の高い順に返す", func(t *testing.T) {
+		sold := newItem(5, "時計", "ROLEX", 1000000, "2023-01-01", jpy(3000000))
+		sold.Status = entity.ItemStatusSold
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID}).Return([]*entity.Item{
+			newItem(1, "時計", "ROLEX", 1000000, "2023-06-01", jpy(1500000)),
+			newItem(2, "時計", "ロレックス", 2000000, "2024-05-01", jpy(1800000)),
+			newItem(3, "バッグ", "HERMÈS", 1000000, "2024-01-01", jpy(1200000)),
+			newItem(4, "バッグ", "CHANEL", 500000, "2024-01-01", nil),
+			sold,
+		}, nil)
+
+		report, err := newUsecase(mockRepo).GetValueChangeReport(actorContext())
+		require.NoError(t, err)
+
+		assert.Equal(t, entity.CurrencyJPY, report.Currency)
+		assert.Equal(t, []int64{1, 3, 2}, []int64{report.Items[0].ItemID, report.Items[1].ItemID, report.Items[2].ItemID})
+		assert.Equal(t, ValueChangeItem{
+			ItemID: 1, Name: "アイテム", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-06-01",
+			PurchasePrice: 1000000, CurrentValue: 1500000, Change: 500000, ChangePercent: percent(50), HoldingDays: 366,
+		}, report.Items[0])
+		assert.Equal(t, int64(-200000), report.Items[2].Change)
+		assert.Equal(t, percent(-10), report.Items[2].ChangePercent)
+
+		assert.Equal(t, 3, report.Total.Count)
+		assert.Equal(t, int64(4000000), report.Total.PurchasePrice)
+		assert.Equal(t, int64(4500000), report.Total.CurrentValue)
+		assert.Equal(t, percent(12.5), report.Total.ChangePercent)
+
+		require.Len(t, report.ByCategory, 2)
+		assert.Equal(t, "バッグ", report.ByCategory[0].Key)
+		assert.Equal(t, percent(20), report.ByCategory[0].ChangePercent)
+		assert.Equal(t, 152, report.ByCategory[0].AverageHoldingDays)
+		assert.Equal(t, "時計", report.ByCategory[1].Key)
+		assert.Equal(t, 2, report.ByCategory[1].Count)
+		assert.Equal(t, percent(10), report.ByCategory[1].ChangePercent)
+
+		// 表記の異なる同じブランドはまとめる
+		require.Len(t, report.ByBrand, 2)
+		assert.Equal(t, "HERMÈS", report.ByBrand[0].Key)
+		assert.Equal(t, "ROLEX", report.ByBrand[1].Key)
+		assert.Equal(t, 2, report.ByBrand[1].Count)
+
+		assert.Equal(t, 1, report.Unvalued)
+		assert.Equal(t, 0, report.Unconverted)
+	})
+
+	t.Run("正常系: 換算できない外貨建てのアイテムと購入価格が非表示のアイテムは比較しない", func(t *testing.T) {
+		withRedaction(t, map[entity.OrgRole][]string{entity.OrgRoleViewer: {entity.ItemFieldPurchasePrice}})
+		usd := newItem(1, "時計", "ROLEX", 10000, "2024-01-01", jpy(1500000))
+		usd.UserID = orgViewer.ID
+		usd.PurchasePrice = entity.NewMoney(10000, "USD")
+		org := newOrgItem()
+		org.CurrentValue = jpy(2000000)
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: orgViewer.ID}).Return([]*entity.Item{usd, org}, nil)
+
+		report, err := newUsecase(mockRepo).GetValueChangeReport(WithActor(context.Background(), orgViewer))
+		require.NoError(t, err)
+		assert.Empty(t, report.Items)
+		assert.Empty(t, report.ByCategory)
+		assert.Equal(t, 0, report.Total.Count)
+		assert.Nil(t, report.Total.ChangePercent)
+		assert.Equal(t, 1, report.Unconverted)
+		assert.Equal(t, 0, report.Unvalued)
+	})
+
+	t.Run("正常系: 振り分けたリクエストは手放していないアイテムだけを読み込み、従来の実装と同じ結果を返す", func(t *testing.T) {
+		held := []*entity.Item{
+			newItem(1, "時計", "ROLEX", 1000000, "2023-06-01", jpy(1500000)),
+			newItem(2, "バッグ", "CHANEL", 500000, "2024-01-01", nil),
+		}
+		sold := newItem(3, "時計", "ROLEX", 1000000, "2023-01-01", jpy(3000000))
+		sold.Status = entity.ItemStatusSold
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindHeld", mock.Anything, testActor.ID).Return(held, nil).Once()
+		mockRepo.On("FindAll", mock.Anything, entity.ItemFilter{UserID: testActor.ID}).Return(append(held, sold), nil).Once()
+		canaries := NewCanaries(map[string]float64{CanaryValueChangeReport: 100})
+		u := newUsecase(mockRepo).(*itemUsecase)
+		WithCanaries(canaries)(u)
+
+		report, err := u.GetValueChangeReport(actorContext())
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, report.Total.Count)
+		assert.Equal(t, 1, report.Unvalued)
+		require.Eventually(t, func() bool { return canaries.Snapshot()[0].Compared == 1 }, time.Second, 5*time.Millisecond)
+		assert.Zero(t, canaries.Snapshot()[0].Mismatches)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: アイテムの取得に失敗", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindAll", mock.Anything, mock.Anything).Return([]*entity.Item(nil), domainErrors.ErrDatabaseError)
+
+		_, err := newUsecase(mockRepo).GetValueChangeReport(actorContext())
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+
+	t.Run("異常系: 操作者がいない", func(t *testing.T) {
+		_, err := NewItemUsecase(new(MockItemRepository)).GetValueChangeReport(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthorized)
+	})
+}
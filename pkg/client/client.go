@@ -0,0 +1,191 @@
+// Package client は所持品管理APIのGoクライアント。
+// アイテムの操作は Client.Items（ItemsClient）から行う
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client は所持品管理APIのクライアント
+type Client struct {
+	// Items は /items のエンドポイント
+	Items *ItemsClient
+
+	baseURL    *url.URL
+	httpClient *http.Client
+	retry      RetryPolicy
+	token      string
+	apiKey     string
+}
+
+// Option は Client の設定を変更する
+type Option func(*Client)
+
+// WithHTTPClient は利用する http.Client を指定する
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken は Authorization: Bearer ヘッダーに付与するアクセストークンを指定する
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithAPIKey は X-API-Key ヘッダーに付与するAPIキーを指定する（ログインせずに利用する場合）
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithRetryPolicy はリトライ設定を指定する
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// New は baseURL（例: http://localhost:8080）に接続するクライアントを作成する
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.Items = &ItemsClient{c: c}
+	return c, nil
+}
+
+// APIError はAPIがエラーステータスを返した場合のエラー
+type APIError struct {
+	StatusCode int
+	Message    string
+	Details    []string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("api error: status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("api error: status %d: %s", e.StatusCode, e.Message)
+}
+
+// requestBody はリクエストの本文（リトライで再送できるようメモリ上に保持する）
+type requestBody struct {
+	contentType     string
+	contentEncoding string
+	// ifMatch は If-Match ヘッダーの値（更新するバージョンの照合）
+	ifMatch string
+	data    []byte
+}
+
+// jsonBody は v を JSON の本文にする
+func jsonBody(v any) (*requestBody, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	return &requestBody{contentType: "application/json", data: data}, nil
+}
+
+// do はリクエストを送信し、成功時はレスポンスボディを out にデコードする（out が io.Writer の場合はそのまま書き込む）
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body *requestBody, out any) (*http.Response, error) {
+	u := c.baseURL.JoinPath(path)
+	if len(query) > 0 {
+		u.RawQuery = query.Encode()
+	}
+	resp, err := c.sendWithRetry(ctx, func() (*http.Request, error) {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body.data)
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u.String(), reader)
+		if err != nil {
+			return nil, err
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", body.contentType)
+			if body.contentEncoding != "" {
+				req.Header.Set("Content-Encoding", body.contentEncoding)
+			}
+			if body.ifMatch != "" {
+				req.Header.Set("If-Match", body.ifMatch)
+			}
+		}
+		if c.token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.token)
+		}
+		if c.apiKey != "" {
+			req.Header.Set("X-API-Key", c.apiKey)
+		}
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, decodeAPIError(resp)
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if w, ok := out.(io.Writer); ok {
+			if _, err := io.Copy(w, resp.Body); err != nil {
+				return resp, fmt.Errorf("failed to read response: %w", err)
+			}
+			return resp, nil
+		}
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp, fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return resp, nil
+}
+
+func decodeAPIError(resp *http.Response) error {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return apiErr
+	}
+
+	// problem+json（detail と項目ごとの errors）と、以前の形式（error と details）の両方を読む
+	var payload struct {
+		Error   string       `json:"error"`
+		Details []string     `json:"details"`
+		Detail  string       `json:"detail"`
+		Errors  []FieldError `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) == nil {
+		apiErr.Message = payload.Error
+		if apiErr.Message == "" {
+			apiErr.Message = payload.Detail
+		}
+		apiErr.Details = payload.Details
+		for _, e := range payload.Errors {
+			apiErr.Details = append(apiErr.Details, e.Field+": "+e.Message)
+		}
+	}
+	return apiErr
+}
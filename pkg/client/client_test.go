@@ -0,0 +1,337 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+var fastRetry = RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+func TestItemsClient_List(t *testing.T) {
+	t.Run("正常系: 絞り込み条件を送信して一覧を取得", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+			assert.Equal(t, "時計", r.URL.Query().Get("category"))
+			assert.Equal(t, []string{"vintage", "箱あり"}, r.URL.Query()["tag"])
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode([]Item{{ID: 1}, {ID: 2}, {ID: 3}})
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL, WithRetryPolicy(fastRetry), WithToken("secret-token"))
+		require.NoError(t, err)
+
+		items, err := c.Items.List(context.Background(), ListFilter{Category: "時計", Tags: []string{"vintage", "箱あり"}})
+		require.NoError(t, err)
+		require.Len(t, items, 3)
+		assert.Equal(t, int64(3), items[2].ID)
+	})
+
+	t.Run("異常系: APIエラー", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"error":"failed to retrieve items"}`))
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL)
+		require.NoError(t, err)
+
+		_, err = c.Items.List(context.Background(), ListFilter{})
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+		assert.Equal(t, "failed to retrieve items", apiErr.Message)
+	})
+}
+
+func TestListFilter_Values(t *testing.T) {
+	// サーバーが一覧・エクスポート・一括変更で使う解釈で、SDK の絞り込み条件が同じ条件になることを確認する
+	filter, errs := entity.ParseItemFilterQuery(ListFilter{
+		Category:   "時計",
+		Brand:      "ROLEX",
+		Condition:  string(entity.ConditionGood),
+		Tags:       []string{"vintage", "箱あり"},
+		Attributes: map[string]string{entity.AttributeMovement: "自動巻き"},
+	}.values())
+	assert.Empty(t, errs)
+	assert.Equal(t, entity.ItemFilter{
+		Category:   "時計",
+		Brand:      "ROLEX",
+		Condition:  entity.ConditionGood,
+		Tags:       []string{"vintage", "箱あり"},
+		Attributes: map[string]string{entity.AttributeMovement: "自動巻き"},
+	}, filter)
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Run("正常系: 429の後に成功", func(t *testing.T) {
+		var calls int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			json.NewEncoder(w).Encode([]Item{{ID: 1}})
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL, WithRetryPolicy(fastRetry))
+		require.NoError(t, err)
+
+		items, err := c.Items.List(context.Background(), ListFilter{})
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("異常系: リトライ上限を超えた場合は最後のエラーを返す", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL, WithRetryPolicy(fastRetry))
+		require.NoError(t, err)
+
+		_, err = c.Items.List(context.Background(), ListFilter{})
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+	})
+
+	t.Run("異常系: 待機中にコンテキストがキャンセルされる", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL, WithRetryPolicy(RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err = c.Items.List(ctx, ListFilter{})
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 100*time.Millisecond, p.delay(0, "", now))
+	assert.Equal(t, 400*time.Millisecond, p.delay(2, "", now))
+	assert.Equal(t, time.Second, p.delay(10, "", now))
+	assert.Equal(t, time.Second, p.delay(0, "5", now))
+	assert.Equal(t, 500*time.Millisecond, RetryPolicy{}.clamp(500*time.Millisecond))
+	assert.Equal(t, time.Duration(0), p.delay(0, now.Add(-time.Minute).Format(http.TimeFormat), now))
+}
+
+func TestItem_PurchasePriceJSON(t *testing.T) {
+	var item Item
+	require.NoError(t, json.Unmarshal([]byte(`{"id":1,"purchase_price":19.99,"purchase_currency":"USD"}`), &item))
+	assert.Equal(t, 1999, item.PurchasePrice)
+
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"purchase_price":19.99`)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"purchase_price":1500000,"purchase_currency":"JPY"}`), &item))
+	assert.Equal(t, 1500000, item.PurchasePrice)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"purchase_price":0.5,"purchase_currency":"JPY"}`), &item))
+	assert.Equal(t, "-0.05", formatMinorUnits(-5, 2))
+
+	// 非表示にされた購入価格は null で返る
+	item = Item{}
+	require.NoError(t, json.Unmarshal([]byte(`{"purchase_price":null,"purchase_currency":"JPY","redacted_fields":["purchase_price"]}`), &item))
+	assert.Equal(t, 0, item.PurchasePrice)
+	assert.Equal(t, []string{"purchase_price"}, item.RedactedFields)
+
+	// 評価額は評価額の通貨の最小単位にし、記録していない場合は nil にする
+	item = Item{}
+	require.NoError(t, json.Unmarshal([]byte(`{"current_value":60.5,"current_value_currency":"EUR"}`), &item))
+	require.NotNil(t, item.CurrentValue)
+	assert.Equal(t, 6050, *item.CurrentValue)
+	require.NoError(t, json.Unmarshal([]byte(`{"current_value":null,"current_value_currency":null}`), &item))
+	assert.Nil(t, item.CurrentValue)
+}
+
+func TestItemsClient_Create(t *testing.T) {
+	t.Run("正常系: 購入価格を10進数で送信する", func(t *testing.T) {
+		var body map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "/items", r.URL.Path)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "aic_key", r.Header.Get("X-API-Key"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":10,"name":"Speedmaster","purchase_price":5250.50,"purchase_currency":"USD"}`))
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL, WithAPIKey("aic_key"))
+		require.NoError(t, err)
+
+		item, err := c.Items.Create(context.Background(), CreateItemInput{
+			Name: "Speedmaster", Category: "時計", Brand: "OMEGA", PurchasePrice: 525050, PurchaseCurrency: "USD", PurchaseDate: "2024-01-15",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(10), item.ID)
+		assert.Equal(t, 525050, item.PurchasePrice)
+		assert.Equal(t, 5250.5, body["purchase_price"])
+		assert.Equal(t, "USD", body["purchase_currency"])
+		// 空の項目は送らない
+		assert.NotContains(t, body, "serial_number")
+	})
+
+	t.Run("異常系: problem+json の検証エラー", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":400,"detail":"validation failed","code":"validation_failed","errors":[{"field":"name","code":"required","message":"name is required"}]}`))
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL)
+		require.NoError(t, err)
+
+		_, err = c.Items.Create(context.Background(), CreateItemInput{})
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "validation failed", apiErr.Message)
+		assert.Equal(t, []string{"name: name is required"}, apiErr.Details)
+	})
+}
+
+func TestItemsClient_UpdatePartial(t *testing.T) {
+	t.Run("正常系: バージョンを If-Match で送信し、未設定に戻す項目は null にする", func(t *testing.T) {
+		var body map[string]any
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPatch, r.Method)
+			assert.Equal(t, "/items/5", r.URL.Path)
+			assert.Equal(t, `"3"`, r.Header.Get("If-Match"))
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.Write([]byte(`{"id":5,"name":"Submariner","purchase_price":1500000,"purchase_currency":"JPY","version":4}`))
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL)
+		require.NoError(t, err)
+
+		name, price, currency, empty := "Submariner", 1999, "USD", ""
+		item, err := c.Items.UpdatePartial(context.Background(), 5, 3, UpdateItemInput{
+			Name: &name, PurchasePrice: &price, PurchaseCurrency: &currency, Condition: &empty,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(4), item.Version)
+		assert.Equal(t, map[string]any{"name": "Submariner", "purchase_price": 19.99, "purchase_currency": "USD", "condition": nil}, body)
+	})
+
+	t.Run("正常系: バージョンが0の場合は照合しない", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "*", r.Header.Get("If-Match"))
+			w.Write([]byte(`{"id":5}`))
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL)
+		require.NoError(t, err)
+
+		notes := "箱あり"
+		_, err = c.Items.UpdatePartial(context.Background(), 5, 0, UpdateItemInput{Notes: &notes})
+		require.NoError(t, err)
+	})
+
+	t.Run("異常系: ほかのクライアントが先に更新していた場合は412", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/problem+json")
+			w.WriteHeader(http.StatusPreconditionFailed)
+			w.Write([]byte(`{"status":412,"detail":"item has been modified","code":"precondition_failed"}`))
+		}))
+		defer srv.Close()
+
+		c, err := New(srv.URL, WithRetryPolicy(fastRetry))
+		require.NoError(t, err)
+
+		name := "a"
+		_, err = c.Items.UpdatePartial(context.Background(), 5, 3, UpdateItemInput{Name: &name})
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, http.StatusPreconditionFailed, apiErr.StatusCode)
+	})
+
+	t.Run("異常系: 通貨を指定せずに購入価格を送らない", func(t *testing.T) {
+		c, err := New("http://localhost:0")
+		require.NoError(t, err)
+
+		price := 100
+		_, err = c.Items.UpdatePartial(context.Background(), 5, 3, UpdateItemInput{PurchasePrice: &price})
+		assert.ErrorContains(t, err, "purchase_currency is required")
+	})
+}
+
+func TestItemsClient_Import(t *testing.T) {
+	csv := "name,category,brand,purchase_price,purchase_date\nロレックス,時計,ROLEX,1500000,2023-01-15\n"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/items/import", r.URL.Path)
+		assert.Equal(t, "text/csv", r.Header.Get("Content-Type"))
+		assert.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		gz, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		received, err := io.ReadAll(gz)
+		require.NoError(t, err)
+		assert.Equal(t, csv, string(received))
+		w.Write([]byte(`{"imported":1,"failed":1,"errors":[{"row":3,"errors":[{"field":"purchase_date","code":"invalid_format","message":"purchase_date must be in YYYY-MM-DD format"}]}]}`))
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	result, err := c.Items.Import(context.Background(), strings.NewReader(csv))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Imported)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, 3, result.Errors[0].Row)
+	assert.Equal(t, "purchase_date", result.Errors[0].Errors[0].Field)
+}
+
+func TestItemsClient_Export(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/items/export", r.URL.Path)
+		assert.Equal(t, "xlsx", r.URL.Query().Get("format"))
+		assert.Equal(t, "時計", r.URL.Query().Get("category"))
+		w.Write([]byte("xlsx-bytes"))
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, c.Items.Export(context.Background(), ListFilter{Category: "時計"}, &out))
+	assert.Equal(t, "xlsx-bytes", out.String())
+}
@@ -0,0 +1,176 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/api"
+)
+
+func init() {
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.FileBodyDecoder)
+}
+
+const contractItem = `{"id":1,"user_id":1,"name":"Speedmaster","category":"時計","brand":"OMEGA","purchase_price":5250.5,"purchase_currency":"USD",` +
+	`"purchase_date":"2024-01-15","visibility":"private","condition":null,"status":"owned","current_value":6000,"current_value_currency":"USD",` +
+	`"serial_number":null,"notes":null,"attributes":{},"version":3,"created_at":"2024-01-15T00:00:00Z","updated_at":"2024-01-15T00:00:00Z",` +
+	`"thumbnails":[],"tags":[],"redacted_fields":[]}`
+
+const contractSummary = `{"categories":{"時計":1},"total":1,"currency":"JPY","values":{"時計":1500000},"total_value":1500000,` +
+	`"stats":{"時計":{"count":1,"valued_count":1,"total_value":1500000,"average_value":1500000,"min_value":1500000,"max_value":1500000,"newest_purchase_date":"2023-01-15"}}}`
+
+// ItemsClient が送信するリクエストと受け取るレスポンスが api/openapi.yaml と一致していること（ハンドラーの変更への追従漏れを検出する）
+func TestItemsClient_Contract(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(api.Spec)
+	require.NoError(t, err)
+	doc.Servers = nil
+	router, err := gorillamux.NewRouter(doc)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	called := map[string]bool{}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route, pathParams, err := router.FindRoute(r)
+		if !assert.NoError(t, err, "%s %s", r.Method, r.URL) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+			},
+		}
+		if !assert.NoError(t, openapi3filter.ValidateRequest(context.Background(), input), route.Operation.OperationID) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		called[route.Operation.OperationID] = true
+		mu.Unlock()
+
+		status, contentType, body := http.StatusOK, "application/json", contractItem
+		switch route.Operation.OperationID {
+		case "listItems":
+			body = "[" + contractItem + "]"
+		case "createItem":
+			status = http.StatusCreated
+		case "deleteItem":
+			status, contentType, body = http.StatusNoContent, "", ""
+		case "getCategorySummary":
+			body = contractSummary
+		case "importItems":
+			body = `{"imported":1,"failed":0,"errors":[]}`
+		case "exportItems":
+			contentType, body = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "PK\x03\x04"
+		}
+
+		// 返す JSON も仕様に適合させ、クライアントが仕様どおりのレスポンスを読めることを確かめる
+		if contentType == "application/json" {
+			header := http.Header{"Content-Type": {contentType}}
+			assert.NoError(t, openapi3filter.ValidateResponse(context.Background(), &openapi3filter.ResponseValidationInput{
+				RequestValidationInput: input,
+				Status:                 status,
+				Header:                 header,
+				Body:                   io.NopCloser(strings.NewReader(body)),
+			}), route.Operation.OperationID)
+		}
+
+		if contentType != "" {
+			w.Header().Set("Content-Type", contentType)
+		}
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	defer srv.Close()
+
+	c, err := New(srv.URL, WithToken("token"))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	items, err := c.Items.List(ctx, ListFilter{Category: "時計", Tags: []string{"vintage"}})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	item, err := c.Items.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 525050, item.PurchasePrice)
+	require.NotNil(t, item.CurrentValue)
+	assert.Equal(t, 600000, *item.CurrentValue)
+
+	_, err = c.Items.Create(ctx, CreateItemInput{Name: "a", Category: "時計", Brand: "b", PurchasePrice: 100, PurchaseDate: "2024-01-01"})
+	require.NoError(t, err)
+
+	price, currency, empty := 1999, "USD", ""
+	attributes := map[string]any{}
+	_, err = c.Items.UpdatePartial(ctx, 1, item.Version, UpdateItemInput{
+		PurchasePrice: &price, PurchaseCurrency: &currency, Condition: &empty, Notes: &empty, Attributes: &attributes,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Items.Delete(ctx, 1))
+
+	summary, err := c.Items.Summary(ctx, "2023-12-31")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500000), *summary.Stats["時計"].AverageValue)
+
+	_, err = c.Items.Import(ctx, strings.NewReader("name,category,brand,purchase_price,purchase_date\n"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	require.NoError(t, c.Items.Export(ctx, ListFilter{Category: "時計"}, &out))
+
+	var exercised []string
+	for id := range called {
+		exercised = append(exercised, id)
+	}
+	sort.Strings(exercised)
+	assert.Equal(t, []string{
+		"createItem", "deleteItem", "exportItems", "getCategorySummary", "getItem", "importItems", "listItems", "updateItem",
+	}, exercised)
+}
+
+// Item が仕様の Item のすべての項目を読み書きすること（サーバーに追加した項目の反映漏れを検出する）
+func TestItem_MatchesSpec(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(api.Spec)
+	require.NoError(t, err)
+	schema := doc.Components.Schemas["Item"].Value
+
+	value, currency := 600000, "JPY"
+	item := Item{
+		ID: 1, UserID: 1, OrgID: 2, Name: "a", Category: "時計", Brand: "b", PurchasePrice: 100, PurchaseCurrency: "JPY",
+		PurchaseDate: "2024-01-01", Visibility: "private", Condition: "新品", Status: "owned",
+		CurrentValue: &value, CurrentValueCurrency: currency, SerialNumber: "s", Notes: "n",
+		Attributes: map[string]any{"movement": "自動巻き"}, Thumbnails: []Thumbnail{{Width: 200, URL: "/items/1/images/1/thumbnails/200"}},
+		Tags: []string{"vintage"}, Version: 1, RedactedFields: []string{"notes"},
+	}
+	data, err := json.Marshal(item)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(data, &fields))
+	for name := range schema.Properties {
+		assert.Contains(t, fields, name, "client.Item has no field for %q", name)
+	}
+	for name := range fields {
+		assert.Contains(t, schema.Properties, name, "%q is not in the Item schema", name)
+	}
+}
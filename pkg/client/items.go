@@ -0,0 +1,407 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ItemsClient は /items のエンドポイントを呼び出す（Client.Items から利用する）
+type ItemsClient struct {
+	c *Client
+}
+
+// Item はAPIが返すアイテム
+type Item struct {
+	ID     int64 `json:"id"`
+	UserID int64 `json:"user_id"`
+	// OrgID は所有する組織（個人のアイテムでは 0）
+	OrgID         int64  `json:"org_id,omitempty"`
+	Name          string `json:"name"`
+	Category      string `json:"category"`
+	Brand         string `json:"brand"`
+	PurchasePrice int    `json:"purchase_price"`
+	// PurchaseCurrency は購入価格の通貨（JPY, USD, EUR）。PurchasePrice は通貨の最小単位（円、セント）の整数
+	// （API では補助単位を小数にした10進数のため、JSON の読み書きで変換する）
+	PurchaseCurrency string `json:"purchase_currency"`
+	PurchaseDate     string `json:"purchase_date"`
+	Visibility       string `json:"visibility"`
+	// Condition は状態（新品, 未使用に近い, 目立った傷なし, やや傷あり, 傷あり, ジャンク。未設定は空）
+	Condition string `json:"condition"`
+	// Status は所有状況（owned, listed_for_sale, sold, consigned, lost, gifted）
+	Status string `json:"status"`
+	// CurrentValue は最新の評価額（CurrentValueCurrency の最小単位の整数。評価額を記録していない場合は nil）
+	CurrentValue         *int   `json:"-"`
+	CurrentValueCurrency string `json:"current_value_currency"`
+	// SerialNumber はシリアル番号（未設定は空）
+	SerialNumber string `json:"serial_number"`
+	// Notes は自由記述のメモ（未設定は空）
+	Notes string `json:"notes"`
+	// Attributes はカテゴリーごとの属性（movement, case_size_mm など。未設定は空）
+	Attributes map[string]any `json:"attributes"`
+	// Thumbnails は先頭の画像のサムネイル（画像がない場合や生成前は空）
+	Thumbnails []Thumbnail `json:"thumbnails"`
+	// Tags はアイテムに付けたタグ（名前順）
+	Tags []string `json:"tags"`
+	// Version は更新時に If-Match で指定するバージョン
+	Version   int64     `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// RedactedFields は組織での役割によって非表示にされた項目（purchase_price など。値は空または 0 になる）
+	RedactedFields []string `json:"redacted_fields,omitempty"`
+}
+
+// Thumbnail は画像のサムネイル
+type Thumbnail struct {
+	Width int    `json:"width"`
+	URL   string `json:"url"`
+}
+
+// 通貨ごとの小数点以下の桁数（未知の通貨は円と同じく0桁）
+var currencyScales = map[string]int{"USD": 2, "EUR": 2}
+
+// itemFields は JSON の変換で Item のメソッドを引き継がないための型
+type itemFields Item
+
+func (i Item) MarshalJSON() ([]byte, error) {
+	var currentValue *json.Number
+	if i.CurrentValue != nil {
+		n := json.Number(formatMinorUnits(*i.CurrentValue, currencyScales[i.CurrentValueCurrency]))
+		currentValue = &n
+	}
+	return json.Marshal(struct {
+		itemFields
+		PurchasePrice json.Number  `json:"purchase_price"`
+		CurrentValue  *json.Number `json:"current_value"`
+	}{itemFields(i), json.Number(formatMinorUnits(i.PurchasePrice, currencyScales[i.PurchaseCurrency])), currentValue})
+}
+
+// UnmarshalJSON は 123.45 USD のような購入価格と評価額を最小単位の整数（12345）にする
+func (i *Item) UnmarshalJSON(data []byte) error {
+	v := struct {
+		*itemFields
+		PurchasePrice json.Number  `json:"purchase_price"`
+		CurrentValue  *json.Number `json:"current_value"`
+	}{itemFields: (*itemFields)(i)}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	price, err := parseMinorUnits(v.PurchasePrice.String(), currencyScales[i.PurchaseCurrency])
+	if err != nil {
+		return fmt.Errorf("purchase_price: %w", err)
+	}
+	i.PurchasePrice = price
+	i.CurrentValue = nil
+	if v.CurrentValue != nil {
+		value, err := parseMinorUnits(v.CurrentValue.String(), currencyScales[i.CurrentValueCurrency])
+		if err != nil {
+			return fmt.Errorf("current_value: %w", err)
+		}
+		i.CurrentValue = &value
+	}
+	return nil
+}
+
+// parseMinorUnits は小数点以下 scale 桁までの10進数を最小単位の整数にする
+func parseMinorUnits(s string, scale int) (int, error) {
+	if s == "" {
+		return 0, nil
+	}
+	digits, fraction, _ := strings.Cut(s, ".")
+	if len(fraction) > scale {
+		return 0, fmt.Errorf("%q has more than %d decimal places", s, scale)
+	}
+	return strconv.Atoi(digits + fraction + strings.Repeat("0", scale-len(fraction)))
+}
+
+// formatMinorUnits は最小単位の整数を小数点以下 scale 桁の10進数にする
+func formatMinorUnits(amount, scale int) string {
+	s := strconv.Itoa(amount)
+	if scale == 0 {
+		return s
+	}
+	sign := ""
+	if amount < 0 {
+		sign, s = "-", s[1:]
+	}
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	return sign + s[:len(s)-scale] + "." + s[len(s)-scale:]
+}
+
+// FormatPrice は通貨の最小単位の整数の金額を補助単位を小数にした10進数にする（USD の 12345 は "123.45"）
+func FormatPrice(amount int, currency string) string {
+	return formatMinorUnits(amount, currencyScales[currency])
+}
+
+// ParsePrice は補助単位を小数にした10進数の金額を通貨の最小単位の整数にする（USD の "123.45" は 12345）
+func ParsePrice(s, currency string) (int, error) {
+	return parseMinorUnits(s, currencyScales[currency])
+}
+
+// ListFilter はアイテム一覧の絞り込み条件
+type ListFilter struct {
+	Category  string
+	Brand     string
+	Condition string
+	// Tags はすべてのタグが付いたアイテムに絞り込む
+	Tags []string
+	// Attributes は属性の値での絞り込み（属性名 → 値。reference_number, movement, material, metal のみ）
+	Attributes map[string]string
+}
+
+func (f ListFilter) values() url.Values {
+	v := url.Values{}
+	if f.Category != "" {
+		v.Set("category", f.Category)
+	}
+	if f.Brand != "" {
+		v.Set("brand", f.Brand)
+	}
+	if f.Condition != "" {
+		v.Set("condition", f.Condition)
+	}
+	for _, tag := range f.Tags {
+		v.Add("tag", tag)
+	}
+	for name, value := range f.Attributes {
+		v.Set("attr."+name, value)
+	}
+	return v
+}
+
+// List は条件に一致するアイテムの一覧を取得する（サーバーはページに分けずにすべて返す）
+func (s *ItemsClient) List(ctx context.Context, filter ListFilter) ([]Item, error) {
+	var items []Item
+	if _, err := s.c.do(ctx, http.MethodGet, "/items", filter.values(), nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// CreateItemInput はアイテムの登録内容（空の項目は送らず、サーバーの既定値になる）
+type CreateItemInput struct {
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	Brand    string `json:"brand,omitempty"`
+	// PurchasePrice は通貨の最小単位（円、セント）の整数（送信時に補助単位を小数にした10進数にする）
+	PurchasePrice    int            `json:"-"`
+	PurchaseCurrency string         `json:"purchase_currency,omitempty"`
+	PurchaseDate     string         `json:"purchase_date"`
+	Visibility       string         `json:"visibility,omitempty"`
+	Condition        string         `json:"condition,omitempty"`
+	SerialNumber     string         `json:"serial_number,omitempty"`
+	Notes            string         `json:"notes,omitempty"`
+	Attributes       map[string]any `json:"attributes,omitempty"`
+	OrgID            int64          `json:"org_id,omitempty"`
+}
+
+// createItemFields は JSON の変換で CreateItemInput のメソッドを引き継がないための型
+type createItemFields CreateItemInput
+
+func (in CreateItemInput) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		createItemFields
+		PurchasePrice json.Number `json:"purchase_price"`
+	}{createItemFields(in), json.Number(formatMinorUnits(in.PurchasePrice, currencyScales[in.PurchaseCurrency]))})
+}
+
+// Get は ID のアイテムを取得する（更新に使うバージョンは Item.Version）
+func (s *ItemsClient) Get(ctx context.Context, id int64) (*Item, error) {
+	var item Item
+	if _, err := s.c.do(ctx, http.MethodGet, itemPath(id), nil, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Create はアイテムを登録し、登録したアイテムを返す
+func (s *ItemsClient) Create(ctx context.Context, input CreateItemInput) (*Item, error) {
+	body, err := jsonBody(input)
+	if err != nil {
+		return nil, err
+	}
+	var item Item
+	if _, err := s.c.do(ctx, http.MethodPost, "/items", nil, body, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// UpdateItemInput は部分更新の内容（nil の項目は変更しない）
+type UpdateItemInput struct {
+	Name  *string `json:"name,omitempty"`
+	Brand *string `json:"brand,omitempty"`
+	// PurchasePrice は通貨の最小単位（円、セント）の整数。桁を決めるため PurchaseCurrency も指定する
+	PurchasePrice    *int    `json:"-"`
+	PurchaseCurrency *string `json:"purchase_currency,omitempty"`
+	Visibility       *string `json:"visibility,omitempty"`
+	// Condition・SerialNumber・Notes は空文字、Attributes は空の map で未設定に戻す
+	Condition    *string         `json:"-"`
+	SerialNumber *string         `json:"-"`
+	Notes        *string         `json:"-"`
+	Attributes   *map[string]any `json:"-"`
+	// OrgID はアイテムを移す組織（0 は個人のアイテムに戻す）
+	OrgID *int64 `json:"org_id,omitempty"`
+}
+
+// updateItemFields は JSON の変換で UpdateItemInput のメソッドを引き継がないための型
+type updateItemFields UpdateItemInput
+
+// MarshalJSON は未設定に戻す項目を null にする（API は状態の空文字を受け付けない）
+func (in UpdateItemInput) MarshalJSON() ([]byte, error) {
+	if in.PurchasePrice != nil && in.PurchaseCurrency == nil {
+		return nil, fmt.Errorf("purchase_currency is required to send purchase_price")
+	}
+	fields := map[string]any{}
+	base, err := json.Marshal(updateItemFields(in))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(base, &fields); err != nil {
+		return nil, err
+	}
+	if in.PurchasePrice != nil {
+		fields["purchase_price"] = json.Number(formatMinorUnits(*in.PurchasePrice, currencyScales[*in.PurchaseCurrency]))
+	}
+	for name, value := range map[string]*string{"condition": in.Condition, "serial_number": in.SerialNumber, "notes": in.Notes} {
+		if value == nil {
+			continue
+		}
+		if *value == "" {
+			fields[name] = nil
+		} else {
+			fields[name] = *value
+		}
+	}
+	if in.Attributes != nil {
+		if len(*in.Attributes) == 0 {
+			fields["attributes"] = nil
+		} else {
+			fields["attributes"] = *in.Attributes
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// UpdatePartial はアイテムの項目を部分的に更新し、更新後のアイテムを返す。
+// version は取得したときの Item.Version で、ほかのクライアントが先に更新していた場合は 412 の APIError を返す（0 は照合しない）
+func (s *ItemsClient) UpdatePartial(ctx context.Context, id, version int64, input UpdateItemInput) (*Item, error) {
+	body, err := jsonBody(input)
+	if err != nil {
+		return nil, err
+	}
+	body.ifMatch = "*"
+	if version > 0 {
+		body.ifMatch = `"` + strconv.FormatInt(version, 10) + `"`
+	}
+	var item Item
+	if _, err := s.c.do(ctx, http.MethodPatch, itemPath(id), nil, body, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Delete は ID のアイテムを削除する
+func (s *ItemsClient) Delete(ctx context.Context, id int64) error {
+	_, err := s.c.do(ctx, http.MethodDelete, itemPath(id), nil, nil, nil)
+	return err
+}
+
+// Summary はカテゴリーごとの集計（金額は Currency の最小単位の整数）
+type Summary struct {
+	// AsOf は集計の基準日（YYYY-MM-DD。現在の集計では空）
+	AsOf       string           `json:"as_of"`
+	Categories map[string]int   `json:"categories"`
+	Total      int              `json:"total"`
+	Currency   string           `json:"currency"`
+	Values     map[string]int64 `json:"values"`
+	TotalValue int64            `json:"total_value"`
+	// Stats はカテゴリーごとの購入価格の統計
+	Stats map[string]CategoryStats `json:"stats"`
+}
+
+// CategoryStats はカテゴリーの購入価格の統計（金額を集計したアイテムがない場合、平均・最小・最大は nil）
+type CategoryStats struct {
+	Count              int     `json:"count"`
+	ValuedCount        int     `json:"valued_count"`
+	TotalValue         int64   `json:"total_value"`
+	AverageValue       *int64  `json:"average_value"`
+	MinValue           *int64  `json:"min_value"`
+	MaxValue           *int64  `json:"max_value"`
+	NewestPurchaseDate *string `json:"newest_purchase_date"`
+}
+
+// Summary はカテゴリーごとの件数と購入価格の集計を取得する（asOf は YYYY-MM-DD の基準日。空の場合は現在）
+func (s *ItemsClient) Summary(ctx context.Context, asOf string) (*Summary, error) {
+	query := url.Values{}
+	if asOf != "" {
+		query.Set("as_of", asOf)
+	}
+	var summary Summary
+	if _, err := s.c.do(ctx, http.MethodGet, "/items/summary", query, nil, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+func itemPath(id int64) string {
+	return "/items/" + strconv.FormatInt(id, 10)
+}
+
+// ImportResult はCSVの取り込みの結果
+type ImportResult struct {
+	Imported int `json:"imported"`
+	Failed   int `json:"failed"`
+	// Errors は取り込めなかった行（先頭から最大100件）
+	Errors []ImportRowError `json:"errors"`
+}
+
+// ImportRowError は取り込めなかった行（Row はヘッダーを1行目とした行番号）とその理由
+type ImportRowError struct {
+	Row    int          `json:"row"`
+	Errors []FieldError `json:"errors"`
+}
+
+// FieldError は項目ごとの検証エラー
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Import は POST /items/import と同じ形式のCSVを gzip で圧縮して送信し、取り込みの結果を返す
+func (s *ItemsClient) Import(ctx context.Context, csv io.Reader) (*ImportResult, error) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := io.Copy(gz, csv); err != nil {
+		return nil, fmt.Errorf("failed to read csv: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress csv: %w", err)
+	}
+
+	var result ImportResult
+	body := &requestBody{contentType: "text/csv", contentEncoding: "gzip", data: compressed.Bytes()}
+	if _, err := s.c.do(ctx, http.MethodPost, "/items/import", nil, body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Export は条件に一致するアイテムの Excel ファイル（GET /items/export）を w に書き込む
+func (s *ItemsClient) Export(ctx context.Context, filter ListFilter, w io.Writer) error {
+	query := filter.values()
+	query.Set("format", "xlsx")
+	_, err := s.c.do(ctx, http.MethodGet, "/items/export", query, nil, w)
+	return err
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy は 429 / 503 応答時のリトライ設定
+type RetryPolicy struct {
+	// MaxRetries は最大リトライ回数（0の場合はリトライしない）
+	MaxRetries int
+	// BaseDelay は指数バックオフの初期待機時間
+	BaseDelay time.Duration
+	// MaxDelay は1回あたりの待機時間の上限
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy はデフォルトのリトライ設定
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable
+}
+
+// sendWithRetry はリクエストを送信し、429 / 503 の場合は Retry-After に従って再送する
+func (c *Client) sendWithRetry(ctx context.Context, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isRetryableStatus(resp.StatusCode) || attempt >= c.retry.MaxRetries {
+			return resp, nil
+		}
+
+		delay := c.retry.delay(attempt, resp.Header.Get("Retry-After"), time.Now())
+		// コネクションを再利用するためボディを読み捨てる
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+		resp.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// delay は次のリトライまでの待機時間を返す。Retry-After があればそれを優先する
+func (p RetryPolicy) delay(attempt int, retryAfter string, now time.Time) time.Duration {
+	if d, ok := parseRetryAfter(retryAfter, now); ok {
+		return p.clamp(d)
+	}
+	return p.clamp(p.BaseDelay << attempt)
+}
+
+func (p RetryPolicy) clamp(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return d
+}
+
+// parseRetryAfter は秒数またはHTTP日付形式の Retry-After を解釈する
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return t.Sub(now), true
+	}
+	return 0, false
+}
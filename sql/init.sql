@@ -5,20 +5,407 @@ SET CHARACTER SET utf8mb4;
 -- Create items table for managing valuable items and collections
 CREATE TABLE IF NOT EXISTS items (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NULL COMMENT 'Owner user ID, or the user who added it for organization items (NULL for unowned sample data)',
+    org_id BIGINT NULL COMMENT 'Owning organization ID (NULL for personal items)',
     name VARCHAR(100) NOT NULL COMMENT 'Item name',
-    category VARCHAR(50) NOT NULL COMMENT 'Item category: 時計, バッグ, ジュエリー, 靴, その他',
+    category VARCHAR(50) NOT NULL COMMENT 'Item category, one of categories.name',
     brand VARCHAR(100) NOT NULL COMMENT 'Brand name',
-    purchase_price INT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen',
+    purchase_price INT NOT NULL DEFAULT 0 COMMENT 'Purchase price in the minor unit of purchase_currency (yen, cents)',
+    purchase_currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code of purchase_price: JPY, USD, EUR',
     purchase_date DATE NOT NULL COMMENT 'Purchase date in YYYY-MM-DD format',
+    visibility VARCHAR(20) NOT NULL DEFAULT 'private' COMMENT 'Visibility outside the owner: private, shared, public',
+    item_condition VARCHAR(20) NOT NULL DEFAULT '' COMMENT 'Item condition: 新品, 未使用に近い, 目立った傷なし, やや傷あり, 傷あり, ジャンク (empty when not recorded)',
+    status VARCHAR(20) NOT NULL DEFAULT 'owned' COMMENT 'Ownership status: owned, listed_for_sale, sold, consigned, lost, gifted',
+    current_value INT NULL COMMENT 'Value of the latest valuation in the minor unit of current_value_currency, kept in sync with item_valuations (NULL when not recorded)',
+    current_value_currency CHAR(3) NULL COMMENT 'ISO 4217 currency code of current_value',
+    serial_number VARCHAR(64) NULL COMMENT 'Serial number in upper case, unique per user_id (NULL when not recorded)',
+    notes TEXT NULL COMMENT 'Free-form notes, up to 2000 characters (NULL when not recorded)',
+    attributes JSON NULL COMMENT 'Category-specific attributes validated by the usecase schema (NULL when not recorded)',
+    -- 絞り込みに使う属性は索引を作成できるよう生成列にする
+    attr_reference_number VARCHAR(32) GENERATED ALWAYS AS (attributes->>'$.reference_number') STORED COMMENT 'Watch reference number from attributes',
+    attr_movement VARCHAR(32) GENERATED ALWAYS AS (attributes->>'$.movement') STORED COMMENT 'Watch movement from attributes',
+    attr_material VARCHAR(50) GENERATED ALWAYS AS (attributes->>'$.material') STORED COMMENT 'Bag material from attributes',
+    attr_metal VARCHAR(32) GENERATED ALWAYS AS (attributes->>'$.metal') STORED COMMENT 'Jewelry metal from attributes',
+    version BIGINT NOT NULL DEFAULT 1 COMMENT 'Incremented on every update for optimistic locking (ETag / If-Match)',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
     
+    INDEX idx_user_id (user_id),
+    INDEX idx_org_id (org_id),
     INDEX idx_category (category),
     INDEX idx_brand (brand),
+    INDEX idx_item_condition (item_condition),
+    INDEX idx_status (status),
+    INDEX idx_attr_reference_number (attr_reference_number),
+    INDEX idx_attr_movement (attr_movement),
+    INDEX idx_attr_material (attr_material),
+    INDEX idx_attr_metal (attr_metal),
+    -- 同じユーザー（組織のアイテムは登録したユーザー）はシリアル番号を重複して登録できない
+    UNIQUE KEY uq_items_user_serial_number (user_id, serial_number),
     INDEX idx_purchase_date (purchase_date),
-    INDEX idx_created_at (created_at)
+    INDEX idx_created_at (created_at),
+    -- 日本語の部分一致検索のため ngram パーサーで全文インデックスを作成
+    FULLTEXT INDEX ft_name_brand (name, brand) WITH PARSER ngram
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing valuable items and collections';
 
+-- Create categories table for the item categories that can be selected
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    -- Compared with the same collation as items.category, so names matching each other's items cannot coexist
+    name VARCHAR(50) NOT NULL COMMENT 'Category name stored in items.category',
+    -- The case-insensitive collation also rejects English names differing only in case, which would be ambiguous on input
+    name_en VARCHAR(50) NULL COMMENT 'English display name, returned for Accept-Language: en and accepted on input',
+    defaults JSON NULL COMMENT 'Values used for fields omitted when creating an item, e.g. {"brand": "不明"} (NULL when none)',
+    required_fields JSON NULL COMMENT 'Item fields required in addition to the usual ones, e.g. ["serial_number"] (NULL when none)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uq_categories_name (name),
+    UNIQUE KEY uq_categories_name_en (name_en)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item categories';
+
+-- Create users table for authentication
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    email VARCHAR(255) NOT NULL COMMENT 'Normalized (lower-case) email address',
+    password_hash VARCHAR(255) NOT NULL COMMENT 'bcrypt password hash',
+    role VARCHAR(20) NOT NULL DEFAULT 'editor' COMMENT 'User role: admin, editor, viewer',
+    preferred_currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code summaries and exports are reported in',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE INDEX idx_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for API users';
+
+-- Create organizations table for households and small dealers sharing an inventory
+CREATE TABLE IF NOT EXISTS organizations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(100) NOT NULL COMMENT 'Organization name',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for organizations';
+
+-- Create organization_members table for users belonging to organizations
+CREATE TABLE IF NOT EXISTS organization_members (
+    organization_id BIGINT NOT NULL COMMENT 'Organization ID',
+    user_id BIGINT NOT NULL COMMENT 'Member user ID',
+    role VARCHAR(20) NOT NULL DEFAULT 'viewer' COMMENT 'Role in the organization: owner, editor, viewer',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    PRIMARY KEY (organization_id, user_id),
+    INDEX idx_user_id (user_id),
+    FOREIGN KEY (organization_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for organization memberships';
+
+-- Create api_keys table for machine client authentication
+CREATE TABLE IF NOT EXISTS api_keys (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL COMMENT 'Owner user ID',
+    name VARCHAR(100) NOT NULL COMMENT 'Label for the key',
+    prefix VARCHAR(16) NOT NULL COMMENT 'Leading characters of the key for identification',
+    key_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the key',
+    last_used_at TIMESTAMP NULL COMMENT 'Last successful authentication',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE INDEX idx_key_hash (key_hash),
+    INDEX idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for API keys';
+
+-- Create api_key_usage table for the daily request counts of each API key (GET /me/api-usage)
+CREATE TABLE IF NOT EXISTS api_key_usage (
+    api_key_id BIGINT NOT NULL COMMENT 'API key sent in X-API-Key',
+    date DATE NOT NULL COMMENT 'Day of the requests (UTC)',
+    requests INT NOT NULL DEFAULT 0 COMMENT 'Number of requests',
+    client_errors INT NOT NULL DEFAULT 0 COMMENT 'Number of 4xx responses',
+    server_errors INT NOT NULL DEFAULT 0 COMMENT 'Number of 5xx responses',
+    rate_limited INT NOT NULL DEFAULT 0 COMMENT 'Number of requests rejected by rate limits (429, or 503 with Retry-After)',
+
+    PRIMARY KEY (api_key_id, date),
+    FOREIGN KEY (api_key_id) REFERENCES api_keys(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for daily API key usage';
+
+-- Create item_images table for photos attached to items
+CREATE TABLE IF NOT EXISTS item_images (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item the image belongs to',
+    position INT NOT NULL DEFAULT 0 COMMENT 'Display order (ascending)',
+    file_name VARCHAR(255) NOT NULL COMMENT 'Original file name',
+    content_type VARCHAR(50) NOT NULL COMMENT 'MIME type detected from the content',
+    size BIGINT NOT NULL COMMENT 'File size in bytes',
+    storage_key VARCHAR(255) NOT NULL COMMENT 'Key in the file storage',
+    thumbnails_ready BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether resized thumbnails have been generated',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_position (item_id, position),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item images';
+
+-- Create item_draft_sessions table for items entered across several screens before they are created
+CREATE TABLE IF NOT EXISTS item_draft_sessions (
+    id CHAR(32) PRIMARY KEY COMMENT 'Random identifier of the draft',
+    user_id BIGINT NOT NULL COMMENT 'User who owns the draft',
+    fields JSON NOT NULL COMMENT 'Entered fields of the item (JSON of the create request)',
+    images JSON NOT NULL COMMENT 'Uploaded images (file name, content type, size and storage key)',
+    version BIGINT NOT NULL DEFAULT 1 COMMENT 'Incremented on every update to detect concurrent updates',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL COMMENT 'The draft is deleted after this time (extended on every update)',
+
+    INDEX idx_user_id_expires_at (user_id, expires_at),
+    INDEX idx_expires_at (expires_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item draft sessions';
+
+-- Create item_document_texts table for text recognized in images of purchase documents (receipts, appraisals)
+CREATE TABLE IF NOT EXISTS item_document_texts (
+    image_id BIGINT PRIMARY KEY COMMENT 'Image the text was recognized in',
+    item_id BIGINT NOT NULL COMMENT 'Item the image belongs to',
+    text MEDIUMTEXT NOT NULL COMMENT 'Recognized text',
+    indexed_at TIMESTAMP NOT NULL COMMENT 'When the text was recognized',
+
+    INDEX idx_item_id (item_id),
+    -- 日本語の部分一致検索のため ngram パーサーで全文インデックスを作成
+    FULLTEXT INDEX ft_text (text) WITH PARSER ngram,
+    FOREIGN KEY (image_id) REFERENCES item_images(id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for text recognized in purchase documents';
+
+-- Create portfolio_views table for public, token-protected portfolio pages
+CREATE TABLE IF NOT EXISTS portfolio_views (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL COMMENT 'Owner user ID',
+    title VARCHAR(100) NOT NULL COMMENT 'Page title',
+    description TEXT NOT NULL COMMENT 'Page description',
+    item_ids JSON NOT NULL COMMENT 'Item IDs to show, in display order',
+    show_purchase_date BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether purchase dates are public',
+    html_enabled BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether the server-rendered HTML page is public',
+    token VARCHAR(64) NOT NULL COMMENT 'Secret token in the public URL',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE INDEX idx_token (token),
+    INDEX idx_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for public portfolio page settings';
+
+-- Create item_comments table for discussions on items
+CREATE TABLE IF NOT EXISTS item_comments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item the comment belongs to',
+    author_id BIGINT NOT NULL COMMENT 'User who wrote the comment',
+    body TEXT NOT NULL COMMENT 'Comment body (may contain @email mentions)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    INDEX idx_item_created (item_id, created_at),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item comments';
+
+-- Create item_memos table for short personal notes on items (visible only to the writer)
+CREATE TABLE IF NOT EXISTS item_memos (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Item the memo belongs to',
+    user_id BIGINT NOT NULL COMMENT 'User who wrote the memo (the only user who can read it)',
+    body VARCHAR(500) NOT NULL COMMENT 'Memo body, up to 500 characters',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_user_created (item_id, user_id, created_at),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for personal item memos';
+
+-- Create tags table for tag names shared by all items
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    -- Names are normalized by the application, so compare them exactly (unicode_ci would treat ひらがな and カタカナ as equal)
+    name VARCHAR(30) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'Normalized tag name (trimmed, lower-case)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uq_tags_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for tag names';
+
+-- Create item_tags table for the many-to-many relation between items and tags
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL COMMENT 'Tagged item',
+    tag_id BIGINT NOT NULL COMMENT 'Tag attached to the item',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_tag (tag_id),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for tags attached to items';
+
+-- Create item_histories table for the change history of items (kept after the item is deleted)
+CREATE TABLE IF NOT EXISTS item_histories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Changed item (no foreign key so the history outlives the item)',
+    actor_id BIGINT NOT NULL COMMENT 'User who made the change',
+    action VARCHAR(20) NOT NULL COMMENT 'Change kind: create, update, delete',
+    field VARCHAR(50) NOT NULL COMMENT 'Changed field of the item',
+    old_value TEXT NULL COMMENT 'Value before the change',
+    new_value TEXT NULL COMMENT 'Value after the change (NULL for delete)',
+    raw_value TEXT NULL COMMENT 'Submitted name or brand before normalization (NULL when equal to new_value)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'When the change was made',
+
+    INDEX idx_item_created (item_id, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for item change history';
+
+-- Create audit_logs table for who did what (written asynchronously by the API server)
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL DEFAULT 0 COMMENT 'User who made the request (0 if unauthenticated, no foreign key so the log outlives the user)',
+    action VARCHAR(20) NOT NULL COMMENT 'Operation: create, update, delete, import, export',
+    method VARCHAR(10) NOT NULL COMMENT 'HTTP method',
+    path VARCHAR(255) NOT NULL COMMENT 'Route of the request (e.g. /items/:id)',
+    resource_id VARCHAR(64) NOT NULL DEFAULT '' COMMENT 'ID in the path',
+    status SMALLINT NOT NULL COMMENT 'HTTP status of the response',
+    request_id VARCHAR(64) NOT NULL COMMENT 'X-Request-ID of the request',
+    ip VARCHAR(45) NOT NULL COMMENT 'Client IP address',
+    created_at TIMESTAMP(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) COMMENT 'When the request was made',
+
+    INDEX idx_created (created_at),
+    INDEX idx_user_created (user_id, created_at),
+    INDEX idx_action_created (action, created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for the audit log';
+
+-- Create idempotency_keys table for replaying the response of retried create requests
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    user_id BIGINT NOT NULL COMMENT 'User who sent the request',
+    idempotency_key VARCHAR(255) NOT NULL COMMENT 'Idempotency-Key header of the request',
+    request_hash CHAR(64) NOT NULL COMMENT 'SHA-256 hash of the method, path and body of the request',
+    completed BOOLEAN NOT NULL DEFAULT FALSE COMMENT 'Whether the response has been stored (false while the first request is in progress)',
+    status_code SMALLINT NOT NULL DEFAULT 0 COMMENT 'HTTP status of the stored response',
+    content_type VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Content-Type of the stored response',
+    body MEDIUMBLOB NULL COMMENT 'Body of the stored response',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT 'When the first request was received',
+
+    PRIMARY KEY (user_id, idempotency_key),
+    INDEX idx_created_at (created_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin COMMENT='Table for idempotency keys of create requests';
+
+-- Create item_views table for the recently viewed items of each user (latest views only)
+CREATE TABLE IF NOT EXISTS item_views (
+    user_id BIGINT NOT NULL COMMENT 'User who viewed the item',
+    item_id BIGINT NOT NULL COMMENT 'Viewed item',
+    viewed_at TIMESTAMP(3) NOT NULL COMMENT 'When the user last viewed the item',
+
+    PRIMARY KEY (user_id, item_id),
+    INDEX idx_user_viewed (user_id, viewed_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for recently viewed items';
+
+-- Create notifications table for per-user notifications such as mentions
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL COMMENT 'User to notify',
+    kind VARCHAR(50) NOT NULL COMMENT 'Notification kind: comment.mention',
+    item_id BIGINT NOT NULL COMMENT 'Related item',
+    comment_id BIGINT NOT NULL COMMENT 'Related comment',
+    actor_id BIGINT NOT NULL COMMENT 'User who triggered the notification',
+    read_at TIMESTAMP NULL COMMENT 'When the user read the notification',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_user_created (user_id, created_at),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id) REFERENCES item_comments(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for user notifications';
+
+-- Create consignments table for items a dealer holds on behalf of consignors
+CREATE TABLE IF NOT EXISTS consignments (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Consigned item (one consignment per item)',
+    consignor_name VARCHAR(100) NOT NULL COMMENT 'Name of the consignor',
+    consignor_contact VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Phone number, email address, etc. of the consignor',
+    agreed_price INT NOT NULL DEFAULT 0 COMMENT 'Agreed selling price in yen',
+    commission_rate DECIMAL(5,2) NOT NULL DEFAULT 0 COMMENT 'Commission as a percentage of the agreed price',
+    deadline DATE NULL COMMENT 'Date by which the item should be sold or returned',
+    status VARCHAR(20) NOT NULL DEFAULT 'active' COMMENT 'Consignment status: active, sold, returned',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    UNIQUE INDEX idx_item_id (item_id),
+    INDEX idx_status_deadline (status, deadline),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for consignments';
+
+-- Create item_sales table for recorded sales of items
+CREATE TABLE IF NOT EXISTS item_sales (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Sold item (one sale per item)',
+    sold_price INT NOT NULL COMMENT 'Sold price in the minor unit of currency',
+    fees INT NOT NULL DEFAULT 0 COMMENT 'Selling fees, shipping, etc. in the minor unit of currency',
+    currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code, the purchase_currency of the item at the time of sale',
+    sold_date DATE NOT NULL COMMENT 'Date of sale',
+    buyer_notes TEXT NULL COMMENT 'Free-form notes about the buyer (NULL when not recorded)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE INDEX idx_item_id (item_id),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for recorded sales of items';
+
+-- Create item_valuations table for point-in-time value estimates of items
+CREATE TABLE IF NOT EXISTS item_valuations (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Valued item',
+    value INT NOT NULL COMMENT 'Estimated value in the minor unit of currency',
+    currency CHAR(3) NOT NULL DEFAULT 'JPY' COMMENT 'ISO 4217 currency code',
+    valued_on DATE NOT NULL COMMENT 'Date of the estimate',
+    notes TEXT NULL COMMENT 'Free-form notes such as the source of the estimate (NULL when not recorded)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id_valued_on (item_id, valued_on),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for value estimates of items';
+
+-- Create invoice_sequences table for the per-year invoice number sequence
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    year INT PRIMARY KEY COMMENT 'Year of issue',
+    last_number INT NOT NULL COMMENT 'Last invoice number issued in the year'
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for invoice number sequences';
+
+-- Create invoices table for invoices issued when consigned items are sold
+CREATE TABLE IF NOT EXISTS invoices (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    number VARCHAR(32) NOT NULL COMMENT 'Invoice number (INV-<year>-<sequence>)',
+    item_id BIGINT NULL COMMENT 'Sold item (NULL after the item is deleted)',
+    user_id BIGINT NOT NULL COMMENT 'User who issued the invoice',
+    buyer_name VARCHAR(100) NOT NULL COMMENT 'Name of the buyer',
+    buyer_address VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'Address of the buyer',
+    line_items JSON NOT NULL COMMENT 'Line items excluding tax',
+    subtotal INT NOT NULL COMMENT 'Total of the line items in yen',
+    tax_rate DECIMAL(5,2) NOT NULL COMMENT 'Consumption tax rate as a percentage',
+    tax INT NOT NULL COMMENT 'Consumption tax in yen (rounded down)',
+    total INT NOT NULL COMMENT 'Subtotal plus tax in yen',
+    issued_at TIMESTAMP NOT NULL COMMENT 'Issue timestamp',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE INDEX idx_number (number),
+    UNIQUE INDEX idx_item_id (item_id),
+    INDEX idx_user_issued_at (user_id, issued_at),
+    FOREIGN KEY (item_id) REFERENCES items(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for invoices';
+
+-- Create digest_subscriptions table for per-user digest email preferences
+CREATE TABLE IF NOT EXISTS digest_subscriptions (
+    user_id BIGINT PRIMARY KEY COMMENT 'Subscribed user (one subscription per user)',
+    frequency VARCHAR(20) NOT NULL DEFAULT 'off' COMMENT 'Digest frequency: off, weekly, monthly',
+    last_sent_at TIMESTAMP NULL COMMENT 'When the digest was last sent (start of the next period)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+
+    INDEX idx_frequency (frequency),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for digest email subscriptions';
+
+-- Insert the initial categories
+INSERT INTO categories (name, name_en) VALUES
+('時計', 'Watch'),
+('バッグ', 'Bag'),
+('ジュエリー', 'Jewelry'),
+('靴', 'Shoes'),
+('その他', 'Other');
+
 -- Insert sample data for testing
 INSERT INTO items (name, category, brand, purchase_price, purchase_date) VALUES
 ('ロレックス デイトナ', '時計', 'ROLEX', 1500000, '2023-01-15'),
@@ -0,0 +1,19 @@
+package web
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+//go:embed templates/portfolio.html
+var portfolioHTML string
+
+var portfolioTemplate = template.Must(template.New("portfolio.html").Parse(portfolioHTML))
+
+// RenderPortfolio は公開ポートフォリオのHTMLページを描画する
+func RenderPortfolio(w io.Writer, portfolio *usecase.PublicPortfolio) error {
+	return portfolioTemplate.Execute(w, portfolio)
+}
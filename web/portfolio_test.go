@@ -0,0 +1,31 @@
+package web
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestRenderPortfolio(t *testing.T) {
+	var buf bytes.Buffer
+	err := RenderPortfolio(&buf, &usecase.PublicPortfolio{
+		Title: "時計コレクション <2024>",
+		Items: []*usecase.ExposedItem{
+			{Name: "デイトナ", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"},
+		},
+		UpdatedAt: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	body := buf.String()
+	assert.Contains(t, body, "時計コレクション &lt;2024&gt;")
+	assert.Contains(t, body, "デイトナ")
+	assert.Contains(t, body, "時計 ・ 2023-01-15")
+	assert.Contains(t, body, "最終更新: 2024-05-01")
+	assert.NotContains(t, body, "表示するアイテムはありません")
+}
@@ -0,0 +1,306 @@
+const form = document.getElementById("item-form");
+const formTitle = document.getElementById("form-title");
+const formErrors = document.getElementById("form-errors");
+const cancelEdit = document.getElementById("cancel-edit");
+const itemsBody = document.getElementById("items");
+const summaryChart = document.getElementById("summary");
+const summaryTotal = document.getElementById("summary-total");
+
+const authSection = document.getElementById("auth-section");
+const authForm = document.getElementById("auth-form");
+const authErrors = document.getElementById("auth-errors");
+const registerButton = document.getElementById("register");
+const appSection = document.getElementById("app");
+const currentUser = document.getElementById("current-user");
+const logoutButton = document.getElementById("logout");
+
+// サーバーが index.html に埋め込んだ設定（APIのベースURLと有効な機能）
+const config = JSON.parse(document.getElementById("app-config").textContent);
+const apiBaseUrl = (config.apiBaseUrl || "").replace(/\/+$/, "");
+
+// 購入価格は補助単位を小数にした金額（123.45）。集計の金額は通貨の最小単位の整数（円は1円、USD・EUR は1セント）
+const MINOR_UNITS = { JPY: 0, USD: 2, EUR: 2 };
+
+function minorUnits(currency) {
+  return MINOR_UNITS[currency] ?? 0;
+}
+
+function formatPrice(amount, currency = "JPY") {
+  const format = new Intl.NumberFormat("ja-JP", { style: "currency", currency });
+  return format.format(amount);
+}
+
+const TOKEN_KEY = "items.token";
+const EMAIL_KEY = "items.email";
+
+async function api(method, path, body, headers = {}) {
+  const init = { method, headers: { Accept: "application/json", ...headers } };
+  const token = localStorage.getItem(TOKEN_KEY);
+  if (token) init.headers.Authorization = `Bearer ${token}`;
+  if (body !== undefined) {
+    init.headers["Content-Type"] = "application/json";
+    init.body = JSON.stringify(body);
+  }
+  const res = await fetch(apiBaseUrl + path, init);
+  const text = await res.text();
+  const data = text ? JSON.parse(text) : undefined;
+  if (res.status === 401 && token) {
+    showLogin();
+  }
+  if (!res.ok) {
+    const err = new Error((data && (data.detail || data.title)) || `request failed (${res.status})`);
+    err.status = res.status;
+    err.details = (data && data.errors) || [];
+    throw err;
+  }
+  return data;
+}
+
+function cell(text, className) {
+  const td = document.createElement("td");
+  td.textContent = text;
+  if (className) td.className = className;
+  return td;
+}
+
+async function loadItems() {
+  const items = (await api("GET", "/items")) || [];
+  itemsBody.replaceChildren(
+    ...items.map((item) => {
+      const tr = document.createElement("tr");
+      tr.append(
+        cell(item.name),
+        cell(item.category),
+        cell(item.brand),
+        // 組織での役割によって非表示にされた項目は null で返る
+        cell(item.purchase_price === null ? "非表示" : formatPrice(item.purchase_price, item.purchase_currency || "JPY"), "number"),
+        cell(item.purchase_date ?? "非表示"),
+        cell(item.condition || "未設定"),
+      );
+
+      const actions = document.createElement("td");
+      const edit = document.createElement("button");
+      edit.textContent = "編集";
+      edit.addEventListener("click", () => startEdit(item));
+      const remove = document.createElement("button");
+      remove.textContent = "削除";
+      remove.addEventListener("click", () => deleteItem(item));
+      actions.append(edit, " ", remove);
+      tr.append(actions);
+      return tr;
+    }),
+  );
+}
+
+async function loadSummary() {
+  const summary = await api("GET", "/items/summary");
+  const max = Math.max(1, ...Object.values(summary.categories));
+  summaryChart.replaceChildren(
+    ...Object.entries(summary.categories).map(([category, count]) => {
+      const row = document.createElement("div");
+      row.className = "row";
+      const label = document.createElement("span");
+      label.className = "label";
+      label.textContent = `${category} (${count})`;
+      const bar = document.createElement("span");
+      bar.className = "bar";
+      bar.style.width = `${(count / max) * 70}%`;
+      row.append(label, bar);
+      return row;
+    }),
+  );
+  const totalValue = formatPrice(summary.total_value / 10 ** minorUnits(summary.currency), summary.currency);
+  summaryTotal.textContent = `合計: ${summary.total} 件 / ${totalValue}`;
+}
+
+// カテゴリーは管理者が追加・変更できるため、選択肢は API から取得する。
+// アイテムと同じ表示言語の名前を選択肢にする（どちらの言語の名前でも登録できる）
+async function loadCategories() {
+  const categories = (await api("GET", "/categories")) || [];
+  const selected = form.category.value;
+  form.category.replaceChildren(
+    ...categories.map((category) => {
+      const option = document.createElement("option");
+      option.textContent = category.display_name;
+      return option;
+    }),
+  );
+  if (selected) form.category.value = selected;
+}
+
+function refresh() {
+  return Promise.all([loadCategories(), loadItems(), loadSummary()]).catch(showErrors);
+}
+
+// 項目ごとの検証エラーはフォームの該当する入力欄も強調する
+function showErrors(err, target = formErrors) {
+  const details = err.details && err.details.length ? err.details : [{ message: err.message }];
+  const messages = details.map((d) => d.message);
+  markInvalidFields(target.closest("form"), details);
+  target.replaceChildren(
+    ...messages.map((m) => {
+      const li = document.createElement("li");
+      li.textContent = m;
+      return li;
+    }),
+  );
+}
+
+function markInvalidFields(targetForm, details = []) {
+  if (!targetForm) return;
+  for (const el of targetForm.querySelectorAll("[aria-invalid]")) {
+    el.removeAttribute("aria-invalid");
+  }
+  for (const d of details) {
+    const el = d.field && targetForm.elements.namedItem(d.field);
+    if (el) el.setAttribute("aria-invalid", "true");
+  }
+}
+
+// 編集時はカテゴリーと購入日は変更できない（PATCH /items/{id} の仕様）。
+// 表示した時点のバージョンを If-Match で送り、他の画面での変更を上書きしないようにする
+function startEdit(item) {
+  form.id.value = item.id;
+  form.version.value = item.version;
+  form.name.value = item.name;
+  form.category.value = item.category;
+  form.brand.value = item.brand;
+  form.purchase_currency.value = item.purchase_currency || "JPY";
+  form.purchase_price.value = item.purchase_price ?? "";
+  form.purchase_date.value = item.purchase_date ?? "";
+  form.condition.value = item.condition || "";
+  form.serial_number.value = item.serial_number || "";
+  form.notes.value = item.notes || "";
+  form.category.disabled = true;
+  form.purchase_date.disabled = true;
+  // 非表示にされた項目は値がわからないため変更できない（サーバーも 403 を返す）
+  const redacted = item.redacted_fields || [];
+  form.purchase_price.disabled = redacted.includes("purchase_price");
+  form.purchase_currency.disabled = redacted.includes("purchase_price");
+  form.serial_number.disabled = redacted.includes("serial_number");
+  formTitle.textContent = "アイテム編集";
+  cancelEdit.hidden = false;
+  formErrors.replaceChildren();
+  markInvalidFields(form);
+}
+
+function resetForm() {
+  form.reset();
+  form.id.value = "";
+  form.version.value = "";
+  form.category.disabled = false;
+  form.purchase_date.disabled = false;
+  form.purchase_price.disabled = false;
+  form.purchase_currency.disabled = false;
+  form.serial_number.disabled = false;
+  formTitle.textContent = "アイテム登録";
+  cancelEdit.hidden = true;
+  formErrors.replaceChildren();
+  markInvalidFields(form);
+}
+
+async function deleteItem(item) {
+  if (!confirm(`「${item.name}」を削除しますか？`)) return;
+  try {
+    await api("DELETE", `/items/${item.id}`);
+    await refresh();
+  } catch (err) {
+    showErrors(err);
+  }
+}
+
+form.addEventListener("submit", async (event) => {
+  event.preventDefault();
+  const currency = form.purchase_currency.value;
+  const price = Number(form.purchase_price.value);
+  // 未設定は null で送る（PATCH では未設定に戻す）
+  const condition = form.condition.value || null;
+  const serialNumber = form.serial_number.value.trim() || null;
+  const notes = form.notes.value.trim() || null;
+  try {
+    if (form.id.value) {
+      const body = { name: form.name.value, brand: form.brand.value, condition, notes };
+      if (!form.purchase_price.disabled) {
+        body.purchase_price = price;
+        body.purchase_currency = currency;
+      }
+      if (!form.serial_number.disabled) body.serial_number = serialNumber;
+      await api("PATCH", `/items/${form.id.value}`, body, { "If-Match": `"${form.version.value}"` });
+    } else {
+      await api("POST", "/items", {
+        name: form.name.value,
+        category: form.category.value,
+        brand: form.brand.value,
+        purchase_price: price,
+        purchase_currency: currency,
+        purchase_date: form.purchase_date.value,
+        condition,
+        serial_number: serialNumber,
+        notes,
+      });
+    }
+    resetForm();
+    await refresh();
+  } catch (err) {
+    showErrors(err);
+    // 他の画面で変更されていた場合は最新の内容を一覧に表示する
+    if (err.status === 409 || err.status === 412) refresh();
+  }
+});
+
+cancelEdit.addEventListener("click", resetForm);
+
+function showLogin() {
+  localStorage.removeItem(TOKEN_KEY);
+  localStorage.removeItem(EMAIL_KEY);
+  authSection.hidden = false;
+  appSection.hidden = true;
+  logoutButton.hidden = true;
+  currentUser.textContent = "";
+}
+
+function showApp() {
+  authSection.hidden = true;
+  appSection.hidden = false;
+  logoutButton.hidden = false;
+  currentUser.textContent = localStorage.getItem(EMAIL_KEY) || "";
+  authErrors.replaceChildren();
+  return refresh();
+}
+
+async function login(email, password) {
+  const token = await api("POST", "/auth/login", { email, password });
+  localStorage.setItem(TOKEN_KEY, token.access_token);
+  localStorage.setItem(EMAIL_KEY, token.user.email);
+  authForm.reset();
+  await showApp();
+}
+
+authForm.addEventListener("submit", async (event) => {
+  event.preventDefault();
+  try {
+    await login(authForm.email.value, authForm.password.value);
+  } catch (err) {
+    showErrors(err, authErrors);
+  }
+});
+
+registerButton.addEventListener("click", async () => {
+  if (!authForm.reportValidity()) return;
+  const email = authForm.email.value;
+  const password = authForm.password.value;
+  try {
+    await api("POST", "/auth/register", { email, password });
+    await login(email, password);
+  } catch (err) {
+    showErrors(err, authErrors);
+  }
+});
+
+logoutButton.addEventListener("click", showLogin);
+
+if (localStorage.getItem(TOKEN_KEY)) {
+  showApp();
+} else {
+  showLogin();
+}
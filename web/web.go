@@ -0,0 +1,154 @@
+// Package web は管理用の簡易UIを埋め込んで提供する
+package web
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+//go:embed static
+var static embed.FS
+
+// AssetPrefix はフィンガープリント付きアセットを配信するパス
+const AssetPrefix = "/assets/"
+
+// Config はUIに埋め込む設定
+type Config struct {
+	// APIBaseURL はAPIのベースURL（空の場合は同一オリジン）
+	APIBaseURL string `json:"apiBaseUrl"`
+	// Features は有効な機能（/capabilities と同じ内容）
+	Features []string `json:"features"`
+}
+
+type asset struct {
+	content     []byte
+	contentType string
+}
+
+// Handler はUIを配信する http.Handler
+type Handler struct {
+	index   []byte
+	assets  map[string]asset  // フィンガープリント付きのファイル名 -> 内容
+	mapping map[string]string // 元のファイル名 -> フィンガープリント付きのパス
+	modTime time.Time
+}
+
+// NewHandler は静的ファイルにフィンガープリントを付け、index.html に設定を埋め込んだハンドラーを作成する
+func NewHandler(cfg Config) (*Handler, error) {
+	root, err := fs.Sub(static, "static")
+	if err != nil {
+		return nil, err
+	}
+
+	h := &Handler{
+		assets:  make(map[string]asset),
+		mapping: make(map[string]string),
+		modTime: time.Now(),
+	}
+
+	err = fs.WalkDir(root, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || name == "index.html" {
+			return err
+		}
+		content, err := fs.ReadFile(root, name)
+		if err != nil {
+			return err
+		}
+		hashed := fingerprint(name, content)
+		h.assets[hashed] = asset{content: content, contentType: contentType(name)}
+		h.mapping[name] = AssetPrefix + hashed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.renderIndex(root, cfg); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *Handler) renderIndex(root fs.FS, cfg Config) error {
+	if cfg.Features == nil {
+		cfg.Features = []string{}
+	}
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New("index.html").Funcs(template.FuncMap{
+		"asset": func(name string) (string, error) {
+			if hashed, ok := h.mapping[name]; ok {
+				return hashed, nil
+			}
+			return "", fs.ErrNotExist
+		},
+	}).ParseFS(root, "index.html")
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, map[string]any{
+		"Config": template.JS(configJSON),
+	}); err != nil {
+		return err
+	}
+	h.index = buf.Bytes()
+	return nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/" || r.URL.Path == "/index.html":
+		// index.html は常に再検証させ、アセットの更新をすぐ反映する
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		http.ServeContent(w, r, "index.html", h.modTime, bytes.NewReader(h.index))
+	case strings.HasPrefix(r.URL.Path, AssetPrefix):
+		a, ok := h.assets[strings.TrimPrefix(r.URL.Path, AssetPrefix)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		// ファイル名に内容のハッシュを含むため、永続的にキャッシュできる
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("Content-Type", a.contentType)
+		http.ServeContent(w, r, "", h.modTime, bytes.NewReader(a.content))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// app.js -> app.<hash>.js
+func fingerprint(name string, content []byte) string {
+	sum := sha256.Sum256(content)
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hex.EncodeToString(sum[:])[:12] + ext
+}
+
+func contentType(name string) string {
+	switch path.Ext(name) {
+	case ".js":
+		return "text/javascript; charset=utf-8"
+	case ".css":
+		return "text/css; charset=utf-8"
+	case ".svg":
+		return "image/svg+xml"
+	case ".png":
+		return "image/png"
+	default:
+		return "application/octet-stream"
+	}
+}
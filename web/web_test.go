@@ -0,0 +1,44 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	h, err := NewHandler(Config{APIBaseURL: "https://api.example.com", Features: []string{"auth"}})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-cache", rec.Header().Get("Cache-Control"))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `"apiBaseUrl":"https://api.example.com"`)
+	assert.Contains(t, body, `"features":["auth"]`)
+
+	// index.html はフィンガープリント付きのアセットを参照する
+	script := regexp.MustCompile(`/assets/app\.[0-9a-f]{12}\.js`).FindString(body)
+	require.NotEmpty(t, script)
+	assert.Regexp(t, `/assets/style\.[0-9a-f]{12}\.css`, body)
+
+	t.Run("正常系: アセットは永続キャッシュ可能", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, script, nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "public, max-age=31536000, immutable", rec.Header().Get("Cache-Control"))
+		assert.Equal(t, "text/javascript; charset=utf-8", rec.Header().Get("Content-Type"))
+	})
+
+	t.Run("異常系: 古いハッシュのアセットは404", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/assets/app.000000000000.js", nil))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
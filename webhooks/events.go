@@ -0,0 +1,86 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"Aicon-assignment/pkg/client"
+)
+
+// イベント種別
+const (
+	TypeItemCreated = "item.created"
+	TypeItemUpdated = "item.updated"
+	TypeItemDeleted = "item.deleted"
+)
+
+// CurrentVersion はこのパッケージが解釈できるペイロードのバージョン
+const CurrentVersion = "v1"
+
+var (
+	ErrUnsupportedVersion = errors.New("webhooks: unsupported event version")
+	ErrUnknownEventType   = errors.New("webhooks: unknown event type")
+)
+
+// Envelope は全イベント共通の外側の形式
+type Envelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Version   string          `json:"version"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Event は型付きのイベント。具体的な型は ItemCreatedEvent などで判別する
+type Event interface {
+	Meta() Envelope
+}
+
+type ItemCreatedEvent struct {
+	Envelope `json:"-"`
+	Item     client.Item `json:"item"`
+}
+
+type ItemUpdatedEvent struct {
+	Envelope `json:"-"`
+	Item     client.Item `json:"item"`
+}
+
+type ItemDeletedEvent struct {
+	Envelope `json:"-"`
+	ItemID   int64 `json:"item_id"`
+}
+
+func (e *ItemCreatedEvent) Meta() Envelope { return e.Envelope }
+func (e *ItemUpdatedEvent) Meta() Envelope { return e.Envelope }
+func (e *ItemDeletedEvent) Meta() Envelope { return e.Envelope }
+
+// Parse はペイロードを型付きのイベントに変換する
+func Parse(body []byte) (Event, error) {
+	var env Envelope
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("webhooks: invalid payload: %w", err)
+	}
+	if env.Version != CurrentVersion {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedVersion, env.Version)
+	}
+
+	var event Event
+	switch env.Type {
+	case TypeItemCreated:
+		event = &ItemCreatedEvent{Envelope: env}
+	case TypeItemUpdated:
+		event = &ItemUpdatedEvent{Envelope: env}
+	case TypeItemDeleted:
+		event = &ItemDeletedEvent{Envelope: env}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEventType, env.Type)
+	}
+
+	if err := json.Unmarshal(env.Data, event); err != nil {
+		return nil, fmt.Errorf("webhooks: invalid %s data: %w", env.Type, err)
+	}
+	return event, nil
+}
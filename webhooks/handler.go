@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+)
+
+// maxBodyBytes は受け付けるペイロードの最大サイズ
+const maxBodyBytes = 1 << 20
+
+// HandlerFunc は検証済みのイベントを処理する
+type HandlerFunc func(ctx context.Context, event Event) error
+
+// Handler は署名検証とパースを行ってから HandlerFunc を呼び出す http.Handler
+type Handler struct {
+	Secret    []byte
+	Tolerance time.Duration
+	Handle    HandlerFunc
+	// Now はテスト用に現在時刻を差し替える（nilの場合は time.Now）
+	Now func() time.Time
+}
+
+// NewHandler は Handler を作成する
+func NewHandler(secret []byte, handle HandlerFunc) *Handler {
+	return &Handler{Secret: secret, Handle: handle}
+}
+
+// ServeHTTP は署名が不正なら401、ペイロードが不正なら400、
+// 処理に失敗した場合は送信側に再送させるため500を返す
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now
+	if h.Now != nil {
+		now = h.Now
+	}
+	if err := Verify(h.Secret, body, r.Header.Get(SignatureHeader), h.Tolerance, now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := Parse(body)
+	if err != nil {
+		// 未対応のイベントは再送されても処理できないので受理扱いにする
+		if errors.Is(err, ErrUnknownEventType) {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.Handle(r.Context(), event); err != nil {
+		http.Error(w, "failed to handle event", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
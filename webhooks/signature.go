@@ -0,0 +1,85 @@
+// Package webhooks は所持品管理APIのWebhookを受信する側のためのヘルパー
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignatureHeader は署名が格納されるHTTPヘッダー名
+const SignatureHeader = "X-Webhook-Signature"
+
+// DefaultTolerance は署名のタイムスタンプと現在時刻のずれの許容範囲
+const DefaultTolerance = 5 * time.Minute
+
+var (
+	ErrMissingSignature = errors.New("webhooks: missing signature")
+	ErrInvalidSignature = errors.New("webhooks: invalid signature")
+	ErrSignatureExpired = errors.New("webhooks: signature timestamp outside tolerance")
+)
+
+// Sign は "t=<unix秒>,v1=<hex>" 形式の署名ヘッダー値を返す。
+// 署名対象は "<unix秒>.<body>" の HMAC-SHA256。
+func Sign(secret, body []byte, at time.Time) string {
+	ts := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", ts, computeMAC(secret, ts, body))
+}
+
+// Verify は署名ヘッダー値を検証する。tolerance が0以下の場合は DefaultTolerance を使う
+func Verify(secret, body []byte, header string, tolerance time.Duration, now time.Time) error {
+	if header == "" {
+		return ErrMissingSignature
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	var ts string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "t":
+			ts = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	if ts == "" || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if diff := now.Sub(time.Unix(unix, 0)); diff > tolerance || diff < -tolerance {
+		return ErrSignatureExpired
+	}
+
+	expected := computeMAC(secret, ts, body)
+	for _, sig := range signatures {
+		// 比較は定数時間で行う
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+func computeMAC(secret []byte, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,110 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	secret = []byte("whsec_test")
+	now    = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+)
+
+const createdPayload = `{"id":"evt_1","type":"item.created","version":"v1","created_at":"2024-01-01T12:00:00Z","data":{"item":{"id":1,"name":"ロレックス デイトナ","category":"時計"}}}`
+
+func TestVerify(t *testing.T) {
+	body := []byte(createdPayload)
+
+	assert.NoError(t, Verify(secret, body, Sign(secret, body, now), 0, now))
+	assert.ErrorIs(t, Verify(secret, body, "", 0, now), ErrMissingSignature)
+	assert.ErrorIs(t, Verify([]byte("other"), body, Sign(secret, body, now), 0, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, []byte("{}"), Sign(secret, body, now), 0, now), ErrInvalidSignature)
+	assert.ErrorIs(t, Verify(secret, body, Sign(secret, body, now.Add(-time.Hour)), 0, now), ErrSignatureExpired)
+	assert.ErrorIs(t, Verify(secret, body, "v1=abc", 0, now), ErrInvalidSignature)
+}
+
+func TestParse(t *testing.T) {
+	event, err := Parse([]byte(createdPayload))
+	require.NoError(t, err)
+	created, ok := event.(*ItemCreatedEvent)
+	require.True(t, ok)
+	assert.Equal(t, int64(1), created.Item.ID)
+	assert.Equal(t, "evt_1", created.Meta().ID)
+
+	event, err = Parse([]byte(`{"type":"item.deleted","version":"v1","data":{"item_id":5}}`))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), event.(*ItemDeletedEvent).ItemID)
+
+	_, err = Parse([]byte(`{"type":"item.created","version":"v2","data":{}}`))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+
+	_, err = Parse([]byte(`{"type":"item.exploded","version":"v1","data":{}}`))
+	assert.ErrorIs(t, err, ErrUnknownEventType)
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		signature      func(body []byte) string
+		handleErr      error
+		expectedStatus int
+		expectHandled  bool
+	}{
+		{
+			name:           "正常系: 署名が正しいイベント",
+			body:           createdPayload,
+			signature:      func(body []byte) string { return Sign(secret, body, now) },
+			expectedStatus: http.StatusNoContent,
+			expectHandled:  true,
+		},
+		{
+			name:           "異常系: 署名が不正",
+			body:           createdPayload,
+			signature:      func(body []byte) string { return Sign([]byte("wrong"), body, now) },
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "異常系: ペイロードが不正",
+			body:           `not json`,
+			signature:      func(body []byte) string { return Sign(secret, body, now) },
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "異常系: 処理の失敗は500",
+			body:           createdPayload,
+			signature:      func(body []byte) string { return Sign(secret, body, now) },
+			handleErr:      errors.New("boom"),
+			expectedStatus: http.StatusInternalServerError,
+			expectHandled:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handled := false
+			h := NewHandler(secret, func(ctx context.Context, event Event) error {
+				handled = true
+				return tt.handleErr
+			})
+			h.Now = func() time.Time { return now }
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(tt.body))
+			req.Header.Set(SignatureHeader, tt.signature([]byte(tt.body)))
+			rec := httptest.NewRecorder()
+
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectHandled, handled)
+		})
+	}
+}